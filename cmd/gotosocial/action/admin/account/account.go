@@ -21,13 +21,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"text/tabwriter"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
 	"golang.org/x/crypto/bcrypt"
@@ -83,6 +87,9 @@ var Create action.GTSAction = func(ctx context.Context) error {
 	if password == "" {
 		return errors.New("no password set")
 	}
+	if err := validate.LoadBreachedPasswords(); err != nil {
+		return err
+	}
 	if err := validate.NewPassword(password); err != nil {
 		return err
 	}
@@ -95,11 +102,65 @@ var Create action.GTSAction = func(ctx context.Context) error {
 	return dbConn.Stop(ctx)
 }
 
-// List returns all existing local accounts.
+// List prints existing accounts, filtered using the provided flags.
 var List action.GTSAction = func(ctx context.Context) error {
+	var (
+		yes       = true
+		no        = false
+		local     *bool
+		suspended *bool
+		domain    = config.GetAdminAccountListDomain()
+		admin     = config.GetAdminAccountListAdmin()
+	)
+
+	switch origin := config.GetAdminAccountListOrigin(); origin {
+	case "local":
+		local = &yes
+	case "remote":
+		local = &no
+	case "all":
+	default:
+		return fmt.Errorf("invalid origin %q, must be one of local, remote, all", origin)
+	}
+
+	if domain != "" {
+		// Only remote accounts have a domain set.
+		local = &no
+	}
+
+	if admin && local != nil && !*local {
+		return errors.New("only local accounts can be admins")
+	}
+
+	if config.GetAdminAccountListSuspended() {
+		suspended = &yes
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintln(w, "user\taccount\tapproved\tadmin\tmoderator\tsuspended\tconfirmed")
+
+	list := listDB
+	if config.GetAdminAccountUseAPI() {
+		list = listAPI
+	}
+
+	if err := list(ctx, w, local, domain, suspended, admin); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+// listDB writes the accounts matching the given
+// filters to w, fetching them from the database.
+func listDB(ctx context.Context, w io.Writer, local *bool, domain string, suspended *bool, admin bool) error {
 	var state state.State
 	state.Caches.Init()
+	state.Caches.Start()
+	defer state.Caches.Stop()
+
 	state.Workers.Start()
+	defer state.Workers.Stop()
 
 	dbConn, err := bundb.NewBunDBService(ctx, &state)
 	if err != nil {
@@ -109,35 +170,107 @@ var List action.GTSAction = func(ctx context.Context) error {
 	// Set the state DB connection
 	state.DB = dbConn
 
-	users, err := dbConn.GetAllUsers(ctx)
-	if err != nil {
-		return err
-	}
+	defer func() {
+		if err := dbConn.Stop(ctx); err != nil {
+			log.Errorf(ctx, "error stopping database: %v", err)
+		}
+	}()
 
 	fmtBool := func(b *bool) string {
 		if b == nil {
 			return "unknown"
 		}
-		if *b {
-			return "yes"
-		}
-		return "no"
+		return fmtYesNo(*b)
 	}
 
 	fmtDate := func(t time.Time) string {
-		if t.Equal(time.Time{}) {
-			return "no"
+		return fmtYesNo(!t.IsZero())
+	}
+
+	const pageSize = 100
+	var maxID string
+	for {
+		accounts, err := dbConn.GetAccounts(ctx, local, domain, suspended, maxID, "", "", pageSize)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return err
 		}
-		return "yes"
+
+		if len(accounts) == 0 {
+			// Reached the end.
+			return nil
+		}
+
+		for _, a := range accounts {
+			if !a.IsLocal() {
+				if admin {
+					continue
+				}
+
+				// Remote accounts have no user, so most columns don't apply.
+				fmt.Fprintf(w, "%s@%s\t%s\t-\t-\t-\t%s\t-\n", a.Username, a.Domain, a.ID, fmtDate(a.SuspendedAt))
+				continue
+			}
+
+			u, err := dbConn.GetUserByAccountID(ctx, a.ID)
+			if errors.Is(err, db.ErrNoEntries) {
+				// Instance account, no user.
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			if admin && !*u.Admin {
+				continue
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", a.Username, a.ID, fmtBool(u.Approved), fmtBool(u.Admin), fmtBool(u.Moderator), fmtDate(a.SuspendedAt), fmtDate(u.ConfirmedAt))
+		}
+
+		maxID = accounts[len(accounts)-1].ID
 	}
+}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
-	fmt.Fprintln(w, "user\taccount\tapproved\tadmin\tmoderator\tsuspended\tconfirmed")
-	for _, u := range users {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", u.Account.Username, u.AccountID, fmtBool(u.Approved), fmtBool(u.Admin), fmtBool(u.Moderator), fmtDate(u.Account.SuspendedAt), fmtDate(u.ConfirmedAt))
+// listAPI writes the accounts matching the given filters
+// to w, fetching them from the running instance's API.
+func listAPI(ctx context.Context, w io.Writer, local *bool, domain string, suspended *bool, admin bool) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
 	}
-	w.Flush()
-	return nil
+
+	return client.listAccounts(ctx, local, domain, suspended, func(accounts []*apimodel.AdminAccountInfo) error {
+		for _, a := range accounts {
+			if a.Domain != nil {
+				if admin {
+					continue
+				}
+
+				// Remote accounts have no user, so most columns don't apply.
+				fmt.Fprintf(w, "%s@%s\t%s\t-\t-\t-\t%s\t-\n", a.Username, *a.Domain, a.ID, fmtYesNo(a.Suspended))
+				continue
+			}
+
+			if a.Username == config.GetHost() {
+				// Instance account, no user.
+				continue
+			}
+
+			isAdmin := a.Role.Name == apimodel.AccountRoleAdmin
+			if admin && !isAdmin {
+				continue
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", a.Username, a.ID, fmtYesNo(a.Approved), fmtYesNo(isAdmin), fmtYesNo(a.Role.Name == apimodel.AccountRoleModerator), fmtYesNo(a.Suspended), fmtYesNo(a.Confirmed))
+		}
+		return nil
+	})
+}
+
+func fmtYesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
 }
 
 // Confirm sets a user to Approved, sets Email to the current UnconfirmedEmail value, and sets ConfirmedAt to now.
@@ -333,6 +466,9 @@ var Password action.GTSAction = func(ctx context.Context) error {
 	if password == "" {
 		return errors.New("no password set")
 	}
+	if err := validate.LoadBreachedPasswords(); err != nil {
+		return err
+	}
 	if err := validate.NewPassword(password); err != nil {
 		return err
 	}
@@ -355,3 +491,50 @@ var Password action.GTSAction = func(ctx context.Context) error {
 	u.EncryptedPassword = string(pw)
 	return dbConn.UpdateUser(ctx, u, "encrypted_password")
 }
+
+// ClearPasskeys removes all passkeys (WebAuthn credentials) of a user,
+// and re-enables signing in with their password, in case they've lost
+// access to their passkeys.
+var ClearPasskeys action.GTSAction = func(ctx context.Context) error {
+	var state state.State
+	state.Caches.Init()
+	state.Workers.Start()
+
+	dbConn, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	// Set the state DB connection
+	state.DB = dbConn
+
+	username := config.GetAdminAccountUsername()
+	if username == "" {
+		return errors.New("no username set")
+	}
+	if err := validate.Username(username); err != nil {
+		return err
+	}
+
+	a, err := dbConn.GetAccountByUsernameDomain(ctx, username, "")
+	if err != nil {
+		return err
+	}
+
+	u, err := dbConn.GetUserByAccountID(ctx, a.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := dbConn.DeleteWebAuthnCredentialsByUserID(ctx, u.ID); err != nil {
+		return err
+	}
+
+	passwordLoginDisabled := false
+	u.PasswordLoginDisabled = &passwordLoginDisabled
+	if err := dbConn.UpdateUser(ctx, u, "password_login_disabled"); err != nil {
+		return err
+	}
+
+	return dbConn.Stop(ctx)
+}
@@ -0,0 +1,156 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// apiClient makes requests to the client API of the running
+// instance, for commands run with --use-api. This means they
+// can be used without stopping the server, unlike going to
+// the database directly, which sqlite in particular doesn't
+// allow while the server has it open.
+type apiClient struct {
+	client  http.Client
+	baseURL string
+	token   string
+}
+
+// newAPIClient returns a new apiClient, using the configured
+// protocol and host, and --token as the admin access token.
+func newAPIClient() (*apiClient, error) {
+	token := config.GetAdminAccountAPIToken()
+	if token == "" {
+		return nil, errors.New("no token set, an access token of an admin account is required with --use-api")
+	}
+
+	return &apiClient{
+		client:  http.Client{Timeout: 30 * time.Second},
+		baseURL: config.GetProtocol() + "://" + config.GetHost(),
+		token:   token,
+	}, nil
+}
+
+// get makes a GET request to the given API path
+// with query, decoding the JSON response into v.
+func (c *apiClient) get(ctx context.Context, path string, query url.Values, v interface{}) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error making request to %s: %w", u, err)
+	}
+	defer rsp.Body.Close()
+
+	b, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response from %s: %w", u, err)
+	}
+
+	if rsp.StatusCode != http.StatusOK {
+		// Try to give the API's
+		// own error description.
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(b, &apiErr) == nil && apiErr.Error != "" {
+			return fmt.Errorf("request to %s failed: %s", u, apiErr.Error)
+		}
+		return fmt.Errorf("request to %s failed: %s", u, rsp.Status)
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+// listAccounts pages through admin accounts matching the given filters,
+// calling fn with each page of accounts, newest first.
+func (c *apiClient) listAccounts(ctx context.Context, local *bool, domain string, suspended *bool, fn func([]*apimodel.AdminAccountInfo) error) error {
+	query := url.Values{"limit": {"100"}}
+	if local != nil {
+		if *local {
+			query.Set("local", "true")
+		} else {
+			query.Set("remote", "true")
+		}
+	}
+	if domain != "" {
+		query.Set("by_domain", domain)
+	}
+	if suspended != nil {
+		query.Set("suspended", fmt.Sprint(*suspended))
+	}
+
+	for {
+		var accounts []*apimodel.AdminAccountInfo
+		if err := c.get(ctx, "/api/v1/admin/accounts", query, &accounts); err != nil {
+			return err
+		}
+
+		if len(accounts) == 0 {
+			// Reached the end.
+			return nil
+		}
+
+		if err := fn(accounts); err != nil {
+			return err
+		}
+
+		query.Set("max_id", accounts[len(accounts)-1].ID)
+	}
+}
+
+// getAccount fetches the admin view of the account with the given
+// namestring, which can be either a local username, or username@domain.
+func (c *apiClient) getAccount(ctx context.Context, namestring string) (*apimodel.AdminAccountInfo, error) {
+	if namestring == "" {
+		return nil, errors.New("no username set")
+	}
+
+	var account apimodel.Account
+	if err := c.get(ctx, "/api/v1/accounts/lookup", url.Values{"acct": {namestring}}, &account); err != nil {
+		return nil, fmt.Errorf("error looking up account %s: %w", namestring, err)
+	}
+
+	var adminAccount apimodel.AdminAccountInfo
+	if err := c.get(ctx, "/api/v1/admin/accounts/"+url.PathEscape(account.ID), nil, &adminAccount); err != nil {
+		return nil, fmt.Errorf("error getting account %s: %w", namestring, err)
+	}
+
+	return &adminAccount, nil
+}
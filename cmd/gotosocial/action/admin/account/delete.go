@@ -0,0 +1,183 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/federatingdb"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	tlprocessor "github.com/superseriousbusiness/gotosocial/internal/processing/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+)
+
+// Delete deletes the local account with the given username, as
+// if the account had deleted itself. The delete is federated out,
+// and the account's statuses, media, follows etc are all removed.
+//
+// If dry run is set, which it is by default, this only logs
+// what would be removed.
+var Delete action.GTSAction = func(ctx context.Context) error {
+	var state state.State
+	state.Caches.Init()
+	state.Caches.Start()
+	defer state.Caches.Stop()
+
+	dbConn, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	// Set the state DB connection
+	state.DB = dbConn
+
+	defer func() {
+		if err := dbConn.Stop(ctx); err != nil {
+			log.Errorf(ctx, "error stopping database: %v", err)
+		}
+	}()
+
+	a, err := getAccount(ctx, dbConn, config.GetAdminAccountUsername())
+	if err != nil {
+		return err
+	}
+
+	if !a.IsLocal() {
+		return errors.New("only local accounts can be deleted, block the domain or suspend the account instead")
+	}
+
+	if a.Username == config.GetHost() {
+		return errors.New("the instance account cannot be deleted")
+	}
+
+	if !a.SuspendedAt.IsZero() {
+		return fmt.Errorf("account %s is already suspended or deleted", a.Username)
+	}
+
+	counts, err := countAccount(ctx, dbConn, a)
+	if err != nil {
+		return err
+	}
+
+	if config.GetAdminDryRun() {
+		log.Info(ctx, "delete DRY RUN")
+	}
+
+	log.Infof(ctx,
+		"deleting account %s would remove %d statuses, %d media attachments (%d bytes), %d follows, %d followers, and %d follow requests",
+		a.Username,
+		counts.Statuses,
+		counts.MediaAttachments,
+		counts.MediaBytes,
+		counts.Following,
+		counts.Followers,
+		counts.FollowRequests+counts.FollowRequesting,
+	)
+
+	if config.GetAdminDryRun() {
+		return nil
+	}
+
+	//nolint:contextcheck
+	storage, err := gtsstorage.AutoConfig()
+	if err != nil {
+		return fmt.Errorf("error creating storage backend: %w", err)
+	}
+	state.Storage = storage
+
+	defer func() {
+		if err := storage.Close(); err != nil {
+			log.Errorf(ctx, "error closing storage backend: %v", err)
+		}
+	}()
+
+	state.Workers.Start()
+	defer state.Workers.Stop()
+
+	// Build the parts of the processor needed to
+	// run (and federate) the delete side effects.
+	client := httpclient.New(httpclient.Config{
+		AllowPrivateIPs: config.GetFederationAllowPrivateIPs(),
+	})
+	mediaManager := media.NewManager(&state)
+	oauthServer := oauth.New(ctx, dbConn)
+	typeConverter := typeutils.NewConverter(dbConn)
+	filter := visibility.NewFilter(&state)
+	federatingDB := federatingdb.New(&state, typeConverter)
+	transportController := transport.NewController(&state, federatingDB, &federation.Clock{}, client)
+	federator := federation.NewFederator(&state, federatingDB, transportController, typeConverter, mediaManager)
+
+	emailSender, err := email.NewNoopSender(nil)
+	if err != nil {
+		return fmt.Errorf("error creating noop email sender: %w", err)
+	}
+
+	// Deleted statuses are wiped from timelines.
+	state.Timelines.Home = timeline.NewManager(
+		tlprocessor.HomeTimelineGrab(&state),
+		tlprocessor.HomeTimelineFilter(&state, filter),
+		tlprocessor.HomeTimelineStatusPrepare(&state, typeConverter),
+		tlprocessor.SkipInsert(),
+	)
+	if err := state.Timelines.Home.Start(); err != nil {
+		return fmt.Errorf("error starting home timeline: %w", err)
+	}
+	defer state.Timelines.Home.Stop() //nolint:errcheck
+
+	state.Timelines.List = timeline.NewManager(
+		tlprocessor.ListTimelineGrab(&state),
+		tlprocessor.ListTimelineFilter(&state, filter),
+		tlprocessor.ListTimelineStatusPrepare(&state, typeConverter),
+		tlprocessor.SkipInsert(),
+	)
+	if err := state.Timelines.List.Start(); err != nil {
+		return fmt.Errorf("error starting list timeline: %w", err)
+	}
+	defer state.Timelines.List.Stop() //nolint:errcheck
+
+	processor := processing.NewProcessor(typeConverter, federator, oauthServer, mediaManager, &state, emailSender)
+	state.Workers.EnqueueClientAPI = processor.EnqueueClientAPI
+	state.Workers.EnqueueFederator = processor.EnqueueFederator
+
+	if errWithCode := processor.Account().DeleteSelf(ctx, a); errWithCode != nil {
+		return errWithCode
+	}
+
+	// Wait for the delete, and everything
+	// it kicks off in turn, to be processed.
+	state.Workers.Drain(ctx)
+
+	log.Infof(ctx, "deleted account %s", a.Username)
+	return nil
+}
@@ -0,0 +1,247 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// accountSummary is the JSON summary of an account printed by Inspect.
+type accountSummary struct {
+	ID           string        `json:"id"`
+	Username     string        `json:"username"`
+	Domain       string        `json:"domain,omitempty"`
+	URI          string        `json:"uri"`
+	CreatedAt    time.Time     `json:"created_at"`
+	SuspendedAt  *time.Time    `json:"suspended_at,omitempty"`
+	LastStatusAt *time.Time    `json:"last_status_at,omitempty"`
+	User         *userSummary  `json:"user,omitempty"`
+	Counts       accountCounts `json:"counts"`
+}
+
+// userSummary is the part of accountSummary only applicable to local accounts.
+type userSummary struct {
+	ID               string     `json:"id"`
+	Email            string     `json:"email,omitempty"`
+	UnconfirmedEmail string     `json:"unconfirmed_email,omitempty"`
+	Role             string     `json:"role"`
+	Approved         bool       `json:"approved"`
+	Confirmed        bool       `json:"confirmed"`
+	Disabled         bool       `json:"disabled"`
+	LastSignInAt     *time.Time `json:"last_sign_in_at,omitempty"`
+	SignInCount      int        `json:"sign_in_count"`
+}
+
+// accountCounts contains counts of items owned by an account,
+// ie., those that would be removed were the account deleted.
+type accountCounts struct {
+	Statuses         int   `json:"statuses"`
+	Following        int   `json:"following"`
+	Followers        int   `json:"followers"`
+	FollowRequests   int   `json:"follow_requests"`
+	FollowRequesting int   `json:"follow_requesting"`
+	MediaAttachments int   `json:"media_attachments"`
+	MediaBytes       int64 `json:"media_bytes"`
+}
+
+// Inspect prints a JSON summary of the account with the given username.
+//
+// With --use-api, the summary is the admin API's view of the account
+// instead, which lacks the follow request and media counts, but can
+// be had while the server is running.
+var Inspect action.GTSAction = func(ctx context.Context) error {
+	if config.GetAdminAccountUseAPI() {
+		client, err := newAPIClient()
+		if err != nil {
+			return err
+		}
+
+		account, err := client.getAccount(ctx, config.GetAdminAccountUsername())
+		if err != nil {
+			return err
+		}
+
+		return printJSON(account)
+	}
+
+	var state state.State
+	state.Caches.Init()
+	state.Caches.Start()
+	defer state.Caches.Stop()
+
+	state.Workers.Start()
+	defer state.Workers.Stop()
+
+	dbConn, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	// Set the state DB connection
+	state.DB = dbConn
+
+	defer func() {
+		if err := dbConn.Stop(ctx); err != nil {
+			log.Errorf(ctx, "error stopping database: %v", err)
+		}
+	}()
+
+	a, err := getAccount(ctx, dbConn, config.GetAdminAccountUsername())
+	if err != nil {
+		return err
+	}
+
+	summary := accountSummary{
+		ID:          a.ID,
+		Username:    a.Username,
+		Domain:      a.Domain,
+		URI:         a.URI,
+		CreatedAt:   a.CreatedAt,
+		SuspendedAt: timeOrNil(a.SuspendedAt),
+	}
+
+	lastStatusAt, err := dbConn.GetAccountLastPosted(ctx, a.ID, false)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+	summary.LastStatusAt = timeOrNil(lastStatusAt)
+
+	if a.IsLocal() {
+		u, err := dbConn.GetUserByAccountID(ctx, a.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return err
+		}
+
+		if u != nil {
+			role := "user"
+			switch {
+			case *u.Admin:
+				role = "admin"
+			case *u.Moderator:
+				role = "moderator"
+			}
+
+			summary.User = &userSummary{
+				ID:               u.ID,
+				Email:            u.Email,
+				UnconfirmedEmail: u.UnconfirmedEmail,
+				Role:             role,
+				Approved:         *u.Approved,
+				Confirmed:        !u.ConfirmedAt.IsZero(),
+				Disabled:         *u.Disabled,
+				LastSignInAt:     timeOrNil(u.CurrentSignInAt),
+				SignInCount:      u.SignInCount,
+			}
+		}
+	}
+
+	summary.Counts, err = countAccount(ctx, dbConn, a)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(summary)
+}
+
+// printJSON prints v to stdout as indented JSON.
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// getAccount fetches the account with the given namestring, which
+// can be either a local username, or username@domain for remote accounts.
+func getAccount(ctx context.Context, dbConn db.DB, namestring string) (*gtsmodel.Account, error) {
+	if namestring == "" {
+		return nil, errors.New("no username set")
+	}
+
+	username, domain, err := util.ExtractWebfingerParts(namestring)
+	if err != nil {
+		return nil, fmt.Errorf("invalid username %s: %w", namestring, err)
+	}
+
+	if domain == config.GetHost() || domain == config.GetAccountDomain() {
+		// Local account.
+		domain = ""
+	}
+
+	a, err := dbConn.GetAccountByUsernameDomain(ctx, username, domain)
+	if err != nil {
+		return nil, fmt.Errorf("error getting account %s: %w", namestring, err)
+	}
+
+	return a, nil
+}
+
+// countAccount counts the items owned by the given account.
+func countAccount(ctx context.Context, dbConn db.DB, a *gtsmodel.Account) (accountCounts, error) {
+	var (
+		counts accountCounts
+		err    error
+	)
+
+	if counts.Statuses, err = dbConn.CountAccountStatuses(ctx, a.ID); err != nil {
+		return counts, fmt.Errorf("error counting statuses: %w", err)
+	}
+
+	if counts.Following, err = dbConn.CountAccountFollows(ctx, a.ID); err != nil {
+		return counts, fmt.Errorf("error counting follows: %w", err)
+	}
+
+	if counts.Followers, err = dbConn.CountAccountFollowers(ctx, a.ID); err != nil {
+		return counts, fmt.Errorf("error counting followers: %w", err)
+	}
+
+	if counts.FollowRequests, err = dbConn.CountAccountFollowRequests(ctx, a.ID); err != nil {
+		return counts, fmt.Errorf("error counting follow requests: %w", err)
+	}
+
+	if counts.FollowRequesting, err = dbConn.CountAccountFollowRequesting(ctx, a.ID); err != nil {
+		return counts, fmt.Errorf("error counting follow requests: %w", err)
+	}
+
+	if counts.MediaAttachments, counts.MediaBytes, err = dbConn.CountAccountAttachments(ctx, a.ID); err != nil {
+		return counts, fmt.Errorf("error counting media: %w", err)
+	}
+
+	return counts, nil
+}
+
+// timeOrNil returns nil for the zero time, for omitempty.
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,292 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/federatingdb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	checkPass = "PASS"
+	checkWarn = "WARN"
+	checkFail = "FAIL"
+	checkSkip = "SKIP"
+)
+
+// checkStep is the outcome of one step of Check.
+type checkStep struct {
+	name   string
+	result string
+	took   time.Duration
+	detail string
+}
+
+// Check runs through the steps needed to federate with the target
+// account or domain, in order, and prints a report of which passed
+// or failed. Requests are made using the same http client, proxy
+// settings and request signing as the running server would use.
+var Check action.GTSAction = func(ctx context.Context) error {
+	target := config.GetAdminFederationCheckTarget()
+	if target == "" {
+		return errors.New("no target set")
+	}
+
+	var (
+		username string
+		domain   = target
+		err      error
+	)
+
+	if strings.Contains(target, "@") {
+		username, domain, err = util.ExtractWebfingerParts(target)
+		if err != nil {
+			return fmt.Errorf("invalid target %s: %w", target, err)
+		}
+	}
+
+	domain, err = util.Punify(domain)
+	if err != nil {
+		return fmt.Errorf("invalid domain %s: %w", domain, err)
+	}
+
+	var state state.State
+	state.Caches.Init()
+	state.Caches.Start()
+	defer state.Caches.Stop()
+
+	dbConn, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	// Set the state DB connection
+	state.DB = dbConn
+
+	defer func() {
+		if err := dbConn.Stop(ctx); err != nil {
+			log.Errorf(ctx, "error stopping database: %v", err)
+		}
+	}()
+
+	// Build a transport signed as the instance actor,
+	// in the same way the running server would.
+	client := httpclient.New(httpclient.Config{
+		AllowPrivateIPs: config.GetFederationAllowPrivateIPs(),
+	})
+	typeConverter := typeutils.NewConverter(dbConn)
+	federatingDB := federatingdb.New(&state, typeConverter)
+	transportController := transport.NewController(&state, federatingDB, &federation.Clock{}, client)
+
+	instanceAccount, err := dbConn.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return fmt.Errorf("error getting instance account: %w", err)
+	}
+
+	tsport, err := transportController.NewTransport(instanceAccount.PublicKeyURI, instanceAccount.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("error creating transport: %w", err)
+	}
+
+	// Don't retry + backoff, we
+	// want to see failures as-is.
+	ctx = gtscontext.SetFastFail(ctx)
+
+	var (
+		steps    []checkStep
+		actorIRI *url.URL
+		inboxIRI *url.URL
+	)
+
+	do := func(name string, fn func() (result string, detail string)) {
+		start := time.Now()
+		result, detail := fn()
+		steps = append(steps, checkStep{
+			name:   name,
+			result: result,
+			took:   time.Since(start),
+			detail: detail,
+		})
+	}
+
+	do("domain permissions", func() (string, string) {
+		blocked, err := dbConn.IsDomainBlocked(ctx, domain)
+		if err != nil {
+			return checkFail, fmt.Sprintf("db error checking domain block: %v", err)
+		}
+
+		if blocked {
+			return checkFail, fmt.Sprintf("%s, or a parent domain, is blocked", domain)
+		}
+
+		return checkPass, fmt.Sprintf("%s is not blocked", domain)
+	})
+
+	do("instance info", func() (string, string) {
+		instance, err := tsport.DereferenceInstance(ctx, &url.URL{Scheme: "https", Host: domain})
+		if err != nil {
+			return checkFail, err.Error()
+		}
+
+		return checkPass, strings.TrimSpace(fmt.Sprintf("%s %s", instance.Title, instance.Version))
+	})
+
+	do("webfinger", func() (string, string) {
+		if username == "" {
+			return checkSkip, "no username given, use username@domain as target to check accounts"
+		}
+
+		b, err := tsport.Finger(ctx, username, domain)
+		if err != nil {
+			return checkFail, err.Error()
+		}
+
+		resp := &apimodel.WellKnownResponse{}
+		if err := json.Unmarshal(b, resp); err != nil {
+			return checkFail, fmt.Sprintf("could not parse webfinger response: %v", err)
+		}
+
+		for _, l := range resp.Links {
+			if l.Rel != "self" || !strings.Contains(l.Type, "activity+json") && !strings.Contains(l.Type, "ld+json") {
+				continue
+			}
+
+			actorIRI, err = url.Parse(l.Href)
+			if err != nil {
+				return checkFail, fmt.Sprintf("could not parse actor uri %s: %v", l.Href, err)
+			}
+
+			return checkPass, fmt.Sprintf("actor is %s", actorIRI)
+		}
+
+		return checkFail, "no activitypub self link in webfinger response"
+	})
+
+	do("actor fetch (signed)", func() (string, string) {
+		if actorIRI == nil {
+			return checkSkip, "no actor uri"
+		}
+
+		b, err := tsport.Dereference(ctx, actorIRI)
+		if err != nil {
+			return checkFail, err.Error()
+		}
+
+		actor := &struct {
+			Inbox     string `json:"inbox"`
+			Endpoints struct {
+				SharedInbox string `json:"sharedInbox"`
+			} `json:"endpoints"`
+		}{}
+		if err := json.Unmarshal(b, actor); err != nil {
+			return checkFail, fmt.Sprintf("could not parse actor: %v", err)
+		}
+
+		inbox := actor.Endpoints.SharedInbox
+		if inbox == "" {
+			inbox = actor.Inbox
+		}
+
+		if inbox == "" {
+			return checkFail, "actor has no inbox"
+		}
+
+		inboxIRI, err = url.Parse(inbox)
+		if err != nil {
+			return checkFail, fmt.Sprintf("could not parse inbox uri %s: %v", inbox, err)
+		}
+
+		return checkPass, fmt.Sprintf("inbox is %s", inboxIRI)
+	})
+
+	do("actor fetch (unsigned)", func() (string, string) {
+		if actorIRI == nil {
+			return checkSkip, "no actor uri"
+		}
+
+		code, err := tsport.Probe(ctx, http.MethodGet, actorIRI, false)
+		switch {
+		case err != nil:
+			return checkFail, err.Error()
+		case code == http.StatusOK:
+			return checkPass, "actor is publicly fetchable"
+		case code == http.StatusUnauthorized || code == http.StatusForbidden:
+			// Not a problem in itself.
+			return checkWarn, fmt.Sprintf("%d %s: remote requires signed requests", code, http.StatusText(code))
+		default:
+			return checkWarn, fmt.Sprintf("%d %s", code, http.StatusText(code))
+		}
+	})
+
+	do("inbox (signed HEAD)", func() (string, string) {
+		if inboxIRI == nil {
+			return checkSkip, "no inbox uri"
+		}
+
+		code, err := tsport.Probe(ctx, http.MethodHead, inboxIRI, true)
+		switch {
+		case err != nil:
+			return checkFail, err.Error()
+		case code == http.StatusUnauthorized || code == http.StatusForbidden:
+			return checkFail, fmt.Sprintf("%d %s: remote rejected our signature", code, http.StatusText(code))
+		default:
+			// Inboxes generally only accept POST, so any
+			// other response just shows the inbox is reachable.
+			return checkPass, fmt.Sprintf("%d %s", code, http.StatusText(code))
+		}
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintln(w, "step\tresult\ttime\tdetail")
+	for _, s := range steps {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.name, s.result, s.took.Round(time.Millisecond), s.detail)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, s := range steps {
+		if s.result == checkFail {
+			return fmt.Errorf("federation check for %s failed at step %q: %s", target, s.name, s.detail)
+		}
+	}
+
+	return nil
+}
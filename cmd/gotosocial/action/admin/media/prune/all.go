@@ -41,7 +41,7 @@ var All action.GTSAction = func(ctx context.Context) error {
 		}
 	}()
 
-	if config.GetAdminMediaPruneDryRun() {
+	if config.GetAdminDryRun() {
 		log.Info(ctx, "prune DRY RUN")
 		ctx = gtscontext.SetDryRun(ctx)
 	}
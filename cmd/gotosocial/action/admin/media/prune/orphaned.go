@@ -19,6 +19,7 @@ package prune
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -41,13 +42,23 @@ var Orphaned action.GTSAction = func(ctx context.Context) error {
 		}
 	}()
 
-	if config.GetAdminMediaPruneDryRun() {
+	if config.GetAdminDryRun() {
 		log.Info(ctx, "prune DRY RUN")
 		ctx = gtscontext.SetDryRun(ctx)
 	}
 
+	// Only prune files older than configured
+	// duration, to leave in-progress uploads.
+	var olderThan time.Time
+	if d := config.GetAdminMediaPruneOlderThan(); d > 0 {
+		olderThan = time.Now().Add(-d)
+	}
+
 	// Perform the actual pruning with logging.
-	prune.cleaner.Media().LogPruneOrphaned(ctx)
+	prune.cleaner.Media().LogPruneOrphaned(ctx, olderThan)
+
+	// Report database entries missing their files.
+	prune.cleaner.Media().LogDangling(ctx)
 
 	// Perform a cleanup of storage (for removed local dirs).
 	if err := prune.storage.Storage.Clean(ctx); err != nil {
@@ -42,7 +42,7 @@ var Remote action.GTSAction = func(ctx context.Context) error {
 		}
 	}()
 
-	if config.GetAdminMediaPruneDryRun() {
+	if config.GetAdminDryRun() {
 		log.Info(ctx, "prune DRY RUN")
 		ctx = gtscontext.SetDryRun(ctx)
 	}
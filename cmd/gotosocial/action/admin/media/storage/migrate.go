@@ -0,0 +1,74 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+// migrateBatchSize is the number of keys
+// to copy between each progress report.
+const migrateBatchSize = 100
+
+// Migrate copies all stored media from the storage backend set by
+// storage-migrate-from, to the storage backend set by storage-backend.
+var Migrate action.GTSAction = func(ctx context.Context) error {
+	from, to, err := gtsstorage.OpenMigration()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := from.Close(); err != nil {
+			log.Error(ctx, err)
+		}
+		if err := to.Close(); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	log.Info(ctx, "migrating storage, this may take a while...")
+
+	res, err := gtsstorage.Migrate(ctx, to.Storage, from.Storage, migrateBatchSize,
+		func(p gtsstorage.MigrateProgress) {
+			log.Infof(ctx, "processed %d keys (%d copied, %d already copied, %d failed)",
+				p.Total(), p.Copied, p.Skipped, p.Failed)
+		},
+		func(key string, err error) {
+			log.Errorf(ctx, "error migrating %s: %v", key, err)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error migrating storage: %w", err)
+	}
+
+	log.Infof(ctx, "finished migrating storage: %d copied, %d already copied, %d failed",
+		res.Copied, res.Skipped, res.Failed)
+
+	if res.Failed > 0 {
+		return errors.New("some keys failed to migrate, run the migration again to retry them")
+	}
+
+	return nil
+}
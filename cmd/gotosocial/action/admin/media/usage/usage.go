@@ -0,0 +1,125 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"codeberg.org/gruf/go-bytesize"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/admin"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+// Usage prints the storage used by cached media, grouped by domain or
+// account, totalled up from database entries. Orphaned files in storage
+// (i.e. with no database entry) are walked and counted separately.
+var Usage action.GTSAction = func(ctx context.Context) error {
+	var (
+		groupBy = config.GetAdminMediaUsageGroupBy()
+		top     = config.GetAdminMediaUsageTop()
+		format  = config.GetAdminMediaUsageFormat()
+	)
+
+	if groupBy != "domain" && groupBy != "account" {
+		return fmt.Errorf("invalid group-by %q, must be one of domain, account", groupBy)
+	}
+
+	if format != "table" && format != "json" {
+		return fmt.Errorf("invalid format %q, must be one of table, json", format)
+	}
+
+	var state state.State
+	state.Caches.Init()
+	state.Caches.Start()
+	defer state.Caches.Stop()
+
+	dbConn, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %w", err)
+	}
+
+	// Set the state DB connection
+	state.DB = dbConn
+
+	defer func() {
+		if err := dbConn.Stop(ctx); err != nil {
+			log.Errorf(ctx, "error stopping database: %v", err)
+		}
+	}()
+
+	//nolint:contextcheck
+	storage, err := gtsstorage.AutoConfig()
+	if err != nil {
+		return fmt.Errorf("error creating storage backend: %w", err)
+	}
+	state.Storage = storage
+
+	defer func() {
+		if err := storage.Close(); err != nil {
+			log.Errorf(ctx, "error closing storage backend: %v", err)
+		}
+	}()
+
+	// The media usage report only relies on state,
+	// so the admin processor's other dependencies
+	// (converter, media manager etc) are left unset.
+	processor := admin.New(&state, nil, nil, nil, nil)
+
+	log.Info(ctx, "counting orphaned files in storage, this may take a while...")
+
+	//nolint:contextcheck
+	report, errWithCode := processor.MediaUsage(ctx, groupBy, top, true)
+	if errWithCode != nil {
+		return fmt.Errorf("error getting media usage: %w", errWithCode)
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	fmtSize := func(sz int64) string {
+		return bytesize.Size(sz).StringIEC()
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%s\tcount\tsize\n", groupBy)
+	for _, e := range report.Usage {
+		name := e.Key
+		switch {
+		case groupBy == "account" && e.Account != "":
+			name = e.Account
+		case groupBy == "domain" && e.Key == "":
+			name = "(local)"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\n", name, e.Count, fmtSize(e.Size))
+	}
+	fmt.Fprintf(w, "(orphaned)\t%d\t%s\n", report.Orphaned.Count, fmtSize(report.Orphaned.Size))
+
+	return w.Flush()
+}
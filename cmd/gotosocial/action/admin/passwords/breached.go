@@ -0,0 +1,137 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package passwords
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/bloom"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// falsePositiveRate is the rate at which the built filter wrongly
+// reports a password as breached. At 1%, a user picking a fresh
+// password is unlikely to be bothered, while the filter takes
+// about 1.2 bytes per breached password.
+const falsePositiveRate = 0.01
+
+// Build builds a filter of breached passwords from a list of SHA-1
+// password hashes in the format of Have I Been Pwned, one hash per
+// line, optionally followed by ':' and a count, and writes it to the
+// configured breached passwords filter path.
+var Build action.GTSAction = func(ctx context.Context) error {
+	input := config.GetAdminBreachedPasswordsInput()
+	if input == "" {
+		return errors.New("no input set")
+	}
+
+	output := config.GetAccountsPasswordBreachedFilter()
+	if output == "" {
+		return fmt.Errorf("%s must be set to the path to write the filter to", config.AccountsPasswordBreachedFilterFlag())
+	}
+
+	// First pass: count hashes so
+	// the filter can be sized right.
+	var count uint64
+	if err := eachHash(input, func([]byte) { count++ }); err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return fmt.Errorf("no password hashes found in %s", input)
+	}
+
+	// Second pass: add the hashes.
+	filter := bloom.New(count, falsePositiveRate)
+	if err := eachHash(input, filter.Add); err != nil {
+		return err
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", output, err)
+	}
+
+	w := bufio.NewWriter(file)
+	if _, err := filter.WriteTo(w); err != nil {
+		file.Close()
+		return fmt.Errorf("error writing filter: %w", err)
+	}
+
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("error writing filter: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error closing %s: %w", output, err)
+	}
+
+	log.Infof(ctx, "wrote filter of %d breached passwords (%d bytes) to %s", count, filter.Size(), output)
+	return nil
+}
+
+// eachHash calls fn with each SHA-1 hash in the list at the given path.
+func eachHash(path string, fn func([]byte)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var (
+		r    = bufio.NewReader(file)
+		sum  = make([]byte, 20)
+		line int
+	)
+
+	for {
+		text, err := r.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("error reading %s: %w", path, err)
+		}
+
+		line++
+
+		text = strings.TrimSpace(text)
+		if h, _, _ := strings.Cut(text, ":"); h != "" {
+			if len(h) != 40 {
+				return fmt.Errorf("%s line %d: not a SHA-1 hash", path, line)
+			}
+
+			if _, err := hex.Decode(sum, []byte(h)); err != nil {
+				return fmt.Errorf("%s line %d: not a SHA-1 hash: %w", path, line, err)
+			}
+
+			fn(sum)
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+	}
+}
@@ -29,6 +29,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/health"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
@@ -55,6 +57,8 @@ import (
 	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 	"github.com/superseriousbusiness/gotosocial/internal/web"
 
 	// Inherit memory limit if set from cgroup
@@ -79,6 +83,11 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error initializing tracing: %w", err)
 	}
 
+	// Load breached passwords filter, if configured
+	if err := validate.LoadBreachedPasswords(); err != nil {
+		return err
+	}
+
 	// Open connection to the database
 	dbService, err := bundb.NewBunDBService(ctx, &state)
 	if err != nil {
@@ -87,6 +96,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 
 	// Set the state DB connection
 	state.DB = dbService
+	state.Workers.FederatorBacklog.Init(dbService)
 
 	if err := dbService.CreateInstanceAccount(ctx); err != nil {
 		return fmt.Errorf("error creating instance account: %s", err)
@@ -106,7 +116,9 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	state.Storage = storage
 
 	// Build HTTP client (TODO: add configurables here)
-	client := httpclient.New(httpclient.Config{})
+	client := httpclient.New(httpclient.Config{
+		AllowPrivateIPs: config.GetFederationAllowPrivateIPs(),
+	})
 
 	// Initialize workers.
 	state.Workers.Start()
@@ -126,7 +138,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	var emailSender email.Sender
 	if smtpHost := config.GetSMTPHost(); smtpHost != "" {
 		// Host is defined; create a proper sender.
-		emailSender, err = email.NewSender()
+		emailSender, err = email.NewSender(&state)
 		if err != nil {
 			return fmt.Errorf("error creating email sender: %s", err)
 		}
@@ -166,6 +178,14 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	state.Workers.EnqueueClientAPI = processor.EnqueueClientAPI
 	state.Workers.EnqueueFederator = processor.EnqueueFederator
 
+	// Process federated activities deferred by
+	// maintenance mode before the last shutdown.
+	processor.Admin().DrainBacklog(ctx)
+
+	// Schedule deletion of accounts whose
+	// scheduled self-deletion falls due.
+	processor.Account().ScheduleDeleteDue()
+
 	/*
 		HTTP router initialization
 	*/
@@ -185,9 +205,24 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		// note: hooks adding ctx fields must be ABOVE
 		// the logger, otherwise won't be accessible.
 		middleware.Logger(config.GetLogClientIP()),
+		middleware.Forwarded(),
 		middleware.UserAgent(),
 		middleware.CORS(),
 		middleware.ExtraHeaders(),
+		// while in maintenance mode, serve a 503 for
+		// everything except federation, media, health
+		// checks, and what admins need to turn it off
+		middleware.Maintenance(processor.InstanceGetV1,
+			"/users/",
+			"/emoji/",
+			"/fileserver/",
+			"/.well-known/",
+			"/nodeinfo/",
+			"/assets/",
+			health.LivenessPath,
+			"/api"+admin.MaintenancePath,
+			"/api"+admin.ConfigReloadPath,
+		),
 	}...)
 
 	// attach global middlewares which are used for every request
@@ -212,24 +247,33 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error retrieving router session for session middleware: %w", err)
 	}
 
+	// Sign image proxy URIs with a key
+	// derived from the instance secret.
+	uris.SetProxiedImageSecret(routerSession.Auth)
+
 	sessionName, err := middleware.SessionName()
 	if err != nil {
 		return fmt.Errorf("error generating session name for session middleware: %w", err)
 	}
 
+	authModule, err := api.NewAuth(dbService, processor, idp, routerSession, sessionName) // auth/oauth paths
+	if err != nil {
+		return fmt.Errorf("error creating auth module: %w", err)
+	}
+
 	var (
-		authModule        = api.NewAuth(dbService, processor, idp, routerSession, sessionName) // auth/oauth paths
-		clientModule      = api.NewClient(dbService, processor)                                // api client endpoints
-		fileserverModule  = api.NewFileserver(processor)                                       // fileserver endpoints
-		wellKnownModule   = api.NewWellKnown(processor)                                        // .well-known endpoints
-		nodeInfoModule    = api.NewNodeInfo(processor)                                         // nodeinfo endpoint
-		activityPubModule = api.NewActivityPub(dbService, processor)                           // ActivityPub endpoints
-		webModule         = web.New(dbService, processor)                                      // web pages + user profiles + settings panels etc
+		clientModule      = api.NewClient(dbService, processor)      // api client endpoints
+		fileserverModule  = api.NewFileserver(processor)             // fileserver endpoints
+		wellKnownModule   = api.NewWellKnown(processor)              // .well-known endpoints
+		nodeInfoModule    = api.NewNodeInfo(processor)               // nodeinfo endpoint
+		activityPubModule = api.NewActivityPub(dbService, processor) // ActivityPub endpoints
+		webModule         = web.New(dbService, processor)            // web pages + user profiles + settings panels etc
+		healthModule      = api.NewHealth()                          // health check endpoints
 	)
 
 	// create required middleware
 	// rate limiting
-	limit := config.GetAdvancedRateLimitRequests()
+	limit := config.GetAdvancedRateLimitRequests
 	clLimit := middleware.RateLimit(limit)  // client api
 	s2sLimit := middleware.RateLimit(limit) // server-to-server (AP)
 	fsLimit := middleware.RateLimit(limit)  // fileserver / web templates
@@ -254,8 +298,9 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	activityPubModule.Route(router, s2sLimit, s2sThrottle, gzip)
 	activityPubModule.RoutePublicKey(router, s2sLimit, pkThrottle, gzip)
 	webModule.Route(router, fsLimit, fsThrottle, gzip)
+	healthModule.Route(router)
 
-	gts, err := gotosocial.NewServer(dbService, router, federator, mediaManager)
+	gts, err := gotosocial.NewServer(&state, router, federator, mediaManager)
 	if err != nil {
 		return fmt.Errorf("error creating gotosocial service: %s", err)
 	}
@@ -264,11 +309,19 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error starting gotosocial service: %s", err)
 	}
 
-	// catch shutdown signals from the operating system
+	// catch shutdown / reload signals from the operating system
 	sigs := make(chan os.Signal, 1)
-	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
-	sig := <-sigs // block until signal received
-	log.Infof(ctx, "received signal %s, shutting down", sig)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := range sigs { // block until signal received
+		if sig == syscall.SIGHUP {
+			log.Infof(ctx, "received signal %s, reloading config", sig)
+			_, _ = processor.Admin().ConfigReload(ctx)
+			continue
+		}
+
+		log.Infof(ctx, "received signal %s, shutting down", sig)
+		break
+	}
 
 	// close down all running services in order
 	if err := gts.Stop(ctx); err != nil {
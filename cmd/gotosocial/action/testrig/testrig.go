@@ -117,6 +117,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	}
 
 	processor := testrig.NewTestProcessor(&state, federator, emailSender, mediaManager)
+	processor.Account().ScheduleDeleteDue()
 
 	/*
 		HTTP router initialization
@@ -164,14 +165,18 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error generating session name for session middleware: %w", err)
 	}
 
+	authModule, err := api.NewAuth(state.DB, processor, idp, routerSession, sessionName) // auth/oauth paths
+	if err != nil {
+		return fmt.Errorf("error creating auth module: %w", err)
+	}
+
 	var (
-		authModule        = api.NewAuth(state.DB, processor, idp, routerSession, sessionName) // auth/oauth paths
-		clientModule      = api.NewClient(state.DB, processor)                                // api client endpoints
-		fileserverModule  = api.NewFileserver(processor)                                      // fileserver endpoints
-		wellKnownModule   = api.NewWellKnown(processor)                                       // .well-known endpoints
-		nodeInfoModule    = api.NewNodeInfo(processor)                                        // nodeinfo endpoint
-		activityPubModule = api.NewActivityPub(state.DB, processor)                           // ActivityPub endpoints
-		webModule         = web.New(state.DB, processor)                                      // web pages + user profiles + settings panels etc
+		clientModule      = api.NewClient(state.DB, processor)      // api client endpoints
+		fileserverModule  = api.NewFileserver(processor)            // fileserver endpoints
+		wellKnownModule   = api.NewWellKnown(processor)             // .well-known endpoints
+		nodeInfoModule    = api.NewNodeInfo(processor)              // nodeinfo endpoint
+		activityPubModule = api.NewActivityPub(state.DB, processor) // ActivityPub endpoints
+		webModule         = web.New(state.DB, processor)            // web pages + user profiles + settings panels etc
 	)
 
 	// these should be routed in order
@@ -184,7 +189,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	activityPubModule.RoutePublicKey(router)
 	webModule.Route(router)
 
-	gts, err := gotosocial.NewServer(state.DB, router, federator, mediaManager)
+	gts, err := gotosocial.NewServer(&state, router, federator, mediaManager)
 	if err != nil {
 		return fmt.Errorf("error creating gotosocial service: %s", err)
 	}
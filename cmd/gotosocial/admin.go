@@ -20,7 +20,11 @@ package main
 import (
 	"github.com/spf13/cobra"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/account"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/federation"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media/prune"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media/storage"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media/usage"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/passwords"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/trans"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 )
@@ -37,7 +41,7 @@ func adminCommands() *cobra.Command {
 
 	adminAccountCmd := &cobra.Command{
 		Use:   "account",
-		Short: "admin commands related to local (this instance) accounts",
+		Short: "admin commands related to accounts",
 	}
 	config.AddAdminAccount(adminAccountCmd)
 
@@ -56,7 +60,7 @@ func adminCommands() *cobra.Command {
 
 	adminAccountListCmd := &cobra.Command{
 		Use:   "list",
-		Short: "list all existing local accounts",
+		Short: "list existing accounts, by default only local ones",
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return preRun(preRunArgs{cmd: cmd})
 		},
@@ -64,8 +68,22 @@ func adminCommands() *cobra.Command {
 			return run(cmd.Context(), account.List)
 		},
 	}
+	config.AddAdminAccountList(adminAccountListCmd)
 	adminAccountCmd.AddCommand(adminAccountListCmd)
 
+	adminAccountInspectCmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "print a JSON summary of an account; use username@domain for remote accounts",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.Inspect)
+		},
+	}
+	config.AddAdminAccountInspect(adminAccountInspectCmd)
+	adminAccountCmd.AddCommand(adminAccountInspectCmd)
+
 	adminAccountConfirmCmd := &cobra.Command{
 		Use:   "confirm",
 		Short: "confirm an existing local account manually, thereby skipping email confirmation",
@@ -132,6 +150,32 @@ func adminCommands() *cobra.Command {
 	config.AddAdminAccountPassword(adminAccountPasswordCmd)
 	adminAccountCmd.AddCommand(adminAccountPasswordCmd)
 
+	adminAccountClearPasskeysCmd := &cobra.Command{
+		Use:   "clear-passkeys",
+		Short: "remove all passkeys of the given local account, and re-enable signing in with its password",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.ClearPasskeys)
+		},
+	}
+	config.AddAdminAccount(adminAccountClearPasskeysCmd)
+	adminAccountCmd.AddCommand(adminAccountClearPasskeysCmd)
+
+	adminAccountDeleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "delete a local account and everything it owns, federating the delete out to other instances",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.Delete)
+		},
+	}
+	config.AddAdminAccountDelete(adminAccountDeleteCmd)
+	adminAccountCmd.AddCommand(adminAccountDeleteCmd)
+
 	adminCmd.AddCommand(adminAccountCmd)
 
 	/*
@@ -164,6 +208,29 @@ func adminCommands() *cobra.Command {
 	config.AddAdminTrans(adminImportCmd)
 	adminCmd.AddCommand(adminImportCmd)
 
+	/*
+	   ADMIN BREACHED PASSWORDS COMMANDS
+	*/
+
+	adminBreachedPasswordsCmd := &cobra.Command{
+		Use:   "breached-passwords",
+		Short: "admin commands related to checking new passwords against breaches",
+	}
+
+	adminBreachedPasswordsBuildCmd := &cobra.Command{
+		Use:   "build",
+		Short: "build a breached passwords filter from a list of SHA-1 hashes, and write it to accounts-password-breached-filter",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), passwords.Build)
+		},
+	}
+	config.AddAdminBreachedPasswords(adminBreachedPasswordsBuildCmd)
+	adminBreachedPasswordsCmd.AddCommand(adminBreachedPasswordsBuildCmd)
+	adminCmd.AddCommand(adminBreachedPasswordsCmd)
+
 	/*
 		ADMIN MEDIA COMMANDS
 	*/
@@ -191,7 +258,7 @@ func adminCommands() *cobra.Command {
 			return run(cmd.Context(), prune.Orphaned)
 		},
 	}
-	config.AddAdminMediaPrune(adminMediaPruneOrphanedCmd)
+	config.AddAdminMediaPruneOrphaned(adminMediaPruneOrphanedCmd)
 	adminMediaPruneCmd.AddCommand(adminMediaPruneOrphanedCmd)
 
 	adminMediaPruneRemoteCmd := &cobra.Command{
@@ -222,7 +289,56 @@ func adminCommands() *cobra.Command {
 
 	adminMediaCmd.AddCommand(adminMediaPruneCmd)
 
+	adminMediaMigrateStorageCmd := &cobra.Command{
+		Use:   "migrate-storage",
+		Short: "copy all stored media from the storage-migrate-from backend to the storage-backend backend",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), storage.Migrate)
+		},
+	}
+	adminMediaCmd.AddCommand(adminMediaMigrateStorageCmd)
+
+	adminMediaUsageCmd := &cobra.Command{
+		Use:   "usage",
+		Short: "report how much storage is used by media, per domain or per account",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), usage.Usage)
+		},
+	}
+	config.AddAdminMediaUsage(adminMediaUsageCmd)
+	adminMediaCmd.AddCommand(adminMediaUsageCmd)
+
 	adminCmd.AddCommand(adminMediaCmd)
 
+	/*
+		ADMIN FEDERATION COMMANDS
+	*/
+
+	adminFederationCmd := &cobra.Command{
+		Use:   "federation",
+		Short: "admin commands related to federating with other instances",
+	}
+
+	adminFederationCheckCmd := &cobra.Command{
+		Use:   "check",
+		Short: "check each step of federating with a remote account or domain, and report which fail",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), federation.Check)
+		},
+	}
+	config.AddAdminFederationCheck(adminFederationCheckCmd)
+	adminFederationCmd.AddCommand(adminFederationCheckCmd)
+
+	adminCmd.AddCommand(adminFederationCmd)
+
 	return adminCmd
 }
@@ -20,6 +20,7 @@ package ap
 // https://www.w3.org/TR/activitystreams-vocabulary
 const (
 	ActivityAccept          = "Accept"          // ActivityStreamsAccept https://www.w3.org/TR/activitystreams-vocabulary/#dfn-accept
+	ActivityActivity        = "Activity"        // ActivityStreamsActivity https://www.w3.org/TR/activitystreams-vocabulary/#dfn-activity
 	ActivityAdd             = "Add"             // ActivityStreamsAdd https://www.w3.org/TR/activitystreams-vocabulary/#dfn-add
 	ActivityAnnounce        = "Announce"        // ActivityStreamsAnnounce https://www.w3.org/TR/activitystreams-vocabulary/#dfn-announce
 	ActivityArrive          = "Arrive"          // ActivityStreamsArrive https://www.w3.org/TR/activitystreams-vocabulary/#dfn-arrive
@@ -76,4 +77,15 @@ const (
 	// See https://www.w3.org/TR/activitystreams-vocabulary/#microsyntaxes
 	// and https://www.w3.org/TR/activitystreams-vocabulary/#dfn-tag
 	TagHashtag = "Hashtag"
+
+	// Play is not in the AS spec, but is used by some music
+	// scrobbling implementations in the same way as 'Listen'.
+	ActivityPlay = "Play"
+
+	// Bite is not in the AS spec, but is used by some Misskey
+	// forks to let one actor playfully bite another actor (or
+	// one of their objects). Go-fed has no type for it, so it's
+	// represented as a plain 'Activity' with 'Bite' as a second
+	// type value; see IsBite.
+	ActivityBite = "Bite"
 )
@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
@@ -391,6 +392,33 @@ func ExtractURL(i WithURL) (*url.URL, error) {
 	return nil, gtserror.New("no valid URL property found")
 }
 
+// ExtractFeaturedTagsURI extracts the URI of the collection of hashtags
+// featured by an account, if set. This isn't part of the vocabulary known
+// to go-fed, so it's taken from the unknown properties, where Mastodon
+// sets it as "featuredTags". Either an IRI or an embedded object with an
+// id is accepted. Returns nil if not set, or not a valid http(s) URI.
+func ExtractFeaturedTagsURI(i WithUnknownProperties) *url.URL {
+	var uriStr string
+
+	switch v := i.GetUnknownProperties()["featuredTags"].(type) {
+	case string:
+		uriStr = v
+	case map[string]interface{}:
+		uriStr, _ = v["id"].(string)
+	}
+
+	if uriStr == "" {
+		return nil
+	}
+
+	uri, err := url.Parse(uriStr)
+	if err != nil || (uri.Scheme != "http" && uri.Scheme != "https") {
+		return nil
+	}
+
+	return uri
+}
+
 // ExtractPublicKey extracts the public key, public key ID, and public
 // key owner ID from an interface, or an error if something goes wrong.
 func ExtractPublicKey(i WithPublicKey) (
@@ -496,6 +524,24 @@ func ExtractContent(i WithContent) string {
 	return ""
 }
 
+// ExtractContentMap returns the language map of the given
+// item's 'content' property, keyed by language tag, eg.,
+// {"en": "hello", "fr": "bonjour"}, or nil if it has none.
+func ExtractContentMap(i WithContent) map[string]string {
+	contentProperty := i.GetActivityStreamsContent()
+	if contentProperty == nil {
+		return nil
+	}
+
+	for iter := contentProperty.Begin(); iter != contentProperty.End(); iter = iter.Next() {
+		if iter.IsRDFLangString() {
+			return iter.GetRDFLangString()
+		}
+	}
+
+	return nil
+}
+
 // ExtractAttachment extracts a minimal gtsmodel.Attachment
 // (just remote URL, description, and blurhash) from the given
 // Attachmentable interface, or an error if no remote URL is set.
@@ -600,8 +646,9 @@ func ExtractHashtag(i Hashtaggable) (*gtsmodel.Tag, error) {
 	tagName := strings.TrimPrefix(name, "#")
 
 	return &gtsmodel.Tag{
-		URL:  tagURL,
-		Name: tagName,
+		URL:         tagURL,
+		Name:        strings.ToLower(tagName),
+		DisplayName: tagName,
 	}, nil
 }
 
@@ -815,6 +862,44 @@ func ExtractObjectURIs(withObject WithObject) ([]*url.URL, error) {
 	return urls, nil
 }
 
+// ExtractTargetURIs extracts the URLs of each Target
+// it can find from a WithTarget interface.
+func ExtractTargetURIs(withTarget WithTarget) ([]*url.URL, error) {
+	targetProp := withTarget.GetActivityStreamsTarget()
+	if targetProp == nil {
+		return nil, gtserror.New("target property was nil")
+	}
+
+	urls := make([]*url.URL, 0, targetProp.Len())
+	for iter := targetProp.Begin(); iter != targetProp.End(); iter = iter.Next() {
+		id, err := pub.ToId(iter)
+		if err == nil {
+			// Found one we can use.
+			urls = append(urls, id)
+		}
+	}
+
+	return urls, nil
+}
+
+// IsBite returns true if the given Activity also has
+// a type value of 'Bite', ie., it's a Bite which has
+// been resolved to a plain ActivityStreams Activity.
+func IsBite(activity vocab.ActivityStreamsActivity) bool {
+	typeProp := activity.GetJSONLDType()
+	if typeProp == nil {
+		return false
+	}
+
+	for iter := typeProp.Begin(); iter != typeProp.End(); iter = iter.Next() {
+		if iter.IsXMLSchemaString() && iter.GetXMLSchemaString() == ActivityBite {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ExtractVisibility extracts the gtsmodel.Visibility
 // of a given addressable with a To and CC property.
 //
@@ -877,6 +962,229 @@ func ExtractSensitive(withSensitive WithSensitive) bool {
 	return false
 }
 
+// ExtractLikesCount extracts the totalItems of the likes
+// collection of the given item, if it is embedded in the item.
+//
+// If no likes property is set, or it is only an IRI, or
+// it has no totalItems set, then 0 will be returned.
+func ExtractLikesCount(withLikes WithLikes) int {
+	likesProp := withLikes.GetActivityStreamsLikes()
+	if likesProp == nil {
+		return 0
+	}
+	return extractTotalItems(likesProp.GetType())
+}
+
+// ExtractSharesCount extracts the totalItems of the shares
+// collection of the given item, if it is embedded in the item.
+//
+// If no shares property is set, or it is only an IRI, or
+// it has no totalItems set, then 0 will be returned.
+func ExtractSharesCount(withShares WithShares) int {
+	sharesProp := withShares.GetActivityStreamsShares()
+	if sharesProp == nil {
+		return 0
+	}
+	return extractTotalItems(sharesProp.GetType())
+}
+
+// extractTotalItems extracts the totalItems
+// of the given collection type, or returns 0.
+func extractTotalItems(t vocab.Type) int {
+	withTotalItems, ok := t.(WithTotalItems)
+	if !ok {
+		return 0
+	}
+
+	totalItemsProp := withTotalItems.GetActivityStreamsTotalItems()
+	if totalItemsProp == nil || !totalItemsProp.IsXMLSchemaNonNegativeInteger() {
+		return 0
+	}
+
+	return totalItemsProp.Get()
+}
+
+// ExtractStartTime extracts the startTime of the given
+// item, returning zero time if this property is not set.
+func ExtractStartTime(i WithStartTime) time.Time {
+	startTimeProp := i.GetActivityStreamsStartTime()
+	if startTimeProp == nil || !startTimeProp.IsXMLSchemaDateTime() {
+		return time.Time{}
+	}
+	return startTimeProp.Get()
+}
+
+// ExtractEndTime extracts the endTime of the given
+// item, returning zero time if this property is not set.
+func ExtractEndTime(i WithEndTime) time.Time {
+	endTimeProp := i.GetActivityStreamsEndTime()
+	if endTimeProp == nil || !endTimeProp.IsXMLSchemaDateTime() {
+		return time.Time{}
+	}
+	return endTimeProp.Get()
+}
+
+// ExtractPoll extracts a placeholder Poll from the given Pollable,
+// with options, vote counts, the multiple-choice flag and expiry
+// populated. Options given as anyOf make a multiple-choice poll,
+// options given as oneOf a single-choice one.
+//
+// An error is returned if the Pollable has both anyOf and oneOf
+// set (it's then ambiguous whether it's multiple-choice or not),
+// or if it has no options at all.
+func ExtractPoll(poll Pollable) (*gtsmodel.Poll, error) {
+	var (
+		oneOfProp = poll.GetActivityStreamsOneOf()
+		anyOfProp = poll.GetActivityStreamsAnyOf()
+		hasOneOf  = oneOfProp != nil && oneOfProp.Len() != 0
+		hasAnyOf  = anyOfProp != nil && anyOfProp.Len() != 0
+		items     []vocab.Type
+	)
+
+	switch {
+	case hasOneOf && hasAnyOf:
+		return nil, gtserror.New("both anyOf and oneOf set on poll")
+
+	case hasOneOf:
+		for iter := oneOfProp.Begin(); iter != oneOfProp.End(); iter = iter.Next() {
+			items = append(items, iter.GetType())
+		}
+
+	case hasAnyOf:
+		for iter := anyOfProp.Begin(); iter != anyOfProp.End(); iter = iter.Next() {
+			items = append(items, iter.GetType())
+		}
+
+	default:
+		return nil, gtserror.New("no anyOf or oneOf options set on poll")
+	}
+
+	var (
+		options = make([]string, 0, len(items))
+		votes   = make([]int, 0, len(items))
+		total   int
+	)
+
+	for _, item := range items {
+		withName, ok := item.(WithName)
+		if !ok {
+			return nil, gtserror.Newf("poll option %T has no name", item)
+		}
+
+		name := ExtractName(withName)
+		if name == "" {
+			return nil, gtserror.New("empty poll option name")
+		}
+
+		var count int
+		if withReplies, ok := item.(WithReplies); ok {
+			if repliesProp := withReplies.GetActivityStreamsReplies(); repliesProp != nil {
+				count = extractTotalItems(repliesProp.GetType())
+			}
+		}
+
+		options = append(options, name)
+		votes = append(votes, count)
+		total += count
+	}
+
+	voters := total
+	if votersProp := poll.GetTootVotersCount(); votersProp != nil && votersProp.IsXMLSchemaNonNegativeInteger() {
+		voters = votersProp.Get()
+	} else if hasAnyOf {
+		// One voter may have voted for many
+		// options, so we can't know from the
+		// option vote counts alone.
+		voters = 0
+	}
+
+	// Prefer the time the poll ends, but fall
+	// back to when it closed for ended polls.
+	expiresAt := ExtractEndTime(poll)
+	if closedProp := poll.GetActivityStreamsClosed(); expiresAt.IsZero() && closedProp != nil {
+		for iter := closedProp.Begin(); iter != closedProp.End(); iter = iter.Next() {
+			if iter.IsXMLSchemaDateTime() {
+				expiresAt = iter.GetXMLSchemaDateTime()
+				break
+			}
+
+			if iter.IsXMLSchemaBoolean() && iter.GetXMLSchemaBoolean() {
+				expiresAt = time.Now()
+				break
+			}
+		}
+	}
+
+	return &gtsmodel.Poll{
+		Options:   options,
+		Votes:     votes,
+		Voters:    voters,
+		Multiple:  &hasAnyOf,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// ExtractLocation extracts the name of the first Place
+// set as location of the given item, or an empty string.
+func ExtractLocation(i WithLocation) string {
+	locationProp := i.GetActivityStreamsLocation()
+	if locationProp == nil {
+		return ""
+	}
+
+	for iter := locationProp.Begin(); iter != locationProp.End(); iter = iter.Next() {
+		if !iter.IsActivityStreamsPlace() {
+			continue
+		}
+
+		if name := ExtractName(iter.GetActivityStreamsPlace()); name != "" {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// ExtractPlace extracts the name of the first named Place set as
+// location of the given item, and its latitude and longitude, if
+// both are set and in range. Returns an empty name and nil
+// coordinates if no named Place is set.
+func ExtractPlace(i WithLocation) (name string, latitude *float64, longitude *float64) {
+	locationProp := i.GetActivityStreamsLocation()
+	if locationProp == nil {
+		return "", nil, nil
+	}
+
+	for iter := locationProp.Begin(); iter != locationProp.End(); iter = iter.Next() {
+		if !iter.IsActivityStreamsPlace() {
+			continue
+		}
+
+		place := iter.GetActivityStreamsPlace()
+		if name = ExtractName(place); name == "" {
+			continue
+		}
+
+		latProp := place.GetActivityStreamsLatitude()
+		lonProp := place.GetActivityStreamsLongitude()
+		if latProp == nil || !latProp.IsXMLSchemaFloat() ||
+			lonProp == nil || !lonProp.IsXMLSchemaFloat() {
+			// Name only.
+			return name, nil, nil
+		}
+
+		lat, lon := latProp.Get(), lonProp.Get()
+		if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+			// Nonsense coordinates.
+			return name, nil, nil
+		}
+
+		return name, &lat, &lon
+	}
+
+	return "", nil, nil
+}
+
 // ExtractSharedInbox extracts the sharedInbox URI property
 // from an Actor. Returns nil if this property is not set.
 func ExtractSharedInbox(withEndpoints WithEndpoints) *url.URL {
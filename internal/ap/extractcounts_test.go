@@ -0,0 +1,78 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+type ExtractCountsTestSuite struct {
+	APTestSuite
+}
+
+func (suite *ExtractCountsTestSuite) TestExtractCounts() {
+	t, _ := suite.jsonToType(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/users/someone/statuses/01H4BTTG9AQX5SWN1RZGQ1A1VP",
+  "type": "Note",
+  "content": "popular post",
+  "likes": {
+    "id": "https://example.org/users/someone/statuses/01H4BTTG9AQX5SWN1RZGQ1A1VP/likes",
+    "type": "Collection",
+    "totalItems": 1312
+  },
+  "shares": {
+    "id": "https://example.org/users/someone/statuses/01H4BTTG9AQX5SWN1RZGQ1A1VP/shares",
+    "type": "OrderedCollection",
+    "totalItems": 69
+  }
+}`)
+
+	statusable, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type was not statusable")
+	}
+
+	suite.Equal(1312, ap.ExtractLikesCount(statusable))
+	suite.Equal(69, ap.ExtractSharesCount(statusable))
+}
+
+func (suite *ExtractCountsTestSuite) TestExtractCountsIRIOnly() {
+	t, _ := suite.jsonToType(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/users/someone/statuses/01H4BTTG9AQX5SWN1RZGQ1A1VP",
+  "type": "Note",
+  "content": "popular post",
+  "likes": "https://example.org/users/someone/statuses/01H4BTTG9AQX5SWN1RZGQ1A1VP/likes"
+}`)
+
+	statusable, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type was not statusable")
+	}
+
+	suite.Zero(ap.ExtractLikesCount(statusable))
+	suite.Zero(ap.ExtractSharesCount(statusable))
+}
+
+func TestExtractCountsTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractCountsTestSuite{})
+}
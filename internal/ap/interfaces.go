@@ -43,11 +43,13 @@ type Accountable interface {
 	WithManuallyApprovesFollowers
 	WithEndpoints
 	WithTag
+	WithUnknownProperties
 }
 
 // Statusable represents the minimum activitypub interface for representing a 'status'.
 // This interface is fulfilled by: Article, Document, Image, Video, Note, Page, Event, Place, Mention, Profile
 type Statusable interface {
+	vocab.Type
 	WithJSONLDId
 	WithTypeName
 
@@ -68,6 +70,30 @@ type Statusable interface {
 	WithAttachment
 	WithTag
 	WithReplies
+	WithLikes
+	WithShares
+}
+
+// Eventable represents the minimum activitypub interface for representing an 'event' status.
+// This interface is fulfilled by: Event
+type Eventable interface {
+	Statusable
+
+	WithStartTime
+	WithEndTime
+	WithLocation
+}
+
+// Pollable represents the minimum activitypub interface for representing a 'poll' status.
+// This interface is fulfilled by: Question
+type Pollable interface {
+	Statusable
+
+	WithOneOf
+	WithAnyOf
+	WithEndTime
+	WithClosed
+	WithVotersCount
 }
 
 // Attachmentable represents the minimum activitypub interface for representing a 'mediaAttachment'.
@@ -121,6 +147,15 @@ type Likeable interface {
 	WithObject
 }
 
+// Dislikeable represents the minimum interface for an activitystreams 'dislike' activity.
+type Dislikeable interface {
+	WithJSONLDId
+	WithTypeName
+
+	WithActor
+	WithObject
+}
+
 // Blockable represents the minimum interface for an activitystreams 'block' activity.
 type Blockable interface {
 	WithJSONLDId
@@ -258,6 +293,12 @@ type WithFeatured interface {
 	GetTootFeatured() vocab.TootFeaturedProperty
 }
 
+// WithUnknownProperties represents an activity with properties
+// outside of the vocabulary known to go-fed, keyed by JSON name.
+type WithUnknownProperties interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
 // WithAttributedTo represents an activity with ActivityStreamsAttributedToProperty
 type WithAttributedTo interface {
 	GetActivityStreamsAttributedTo() vocab.ActivityStreamsAttributedToProperty
@@ -317,6 +358,56 @@ type WithReplies interface {
 	GetActivityStreamsReplies() vocab.ActivityStreamsRepliesProperty
 }
 
+// WithLikes represents an activity with ActivityStreamsLikesProperty
+type WithLikes interface {
+	GetActivityStreamsLikes() vocab.ActivityStreamsLikesProperty
+}
+
+// WithShares represents an activity with ActivityStreamsSharesProperty
+type WithShares interface {
+	GetActivityStreamsShares() vocab.ActivityStreamsSharesProperty
+}
+
+// WithTotalItems represents an activity with ActivityStreamsTotalItemsProperty
+type WithTotalItems interface {
+	GetActivityStreamsTotalItems() vocab.ActivityStreamsTotalItemsProperty
+}
+
+// WithStartTime represents an activity with ActivityStreamsStartTimeProperty
+type WithStartTime interface {
+	GetActivityStreamsStartTime() vocab.ActivityStreamsStartTimeProperty
+}
+
+// WithEndTime represents an activity with ActivityStreamsEndTimeProperty
+type WithEndTime interface {
+	GetActivityStreamsEndTime() vocab.ActivityStreamsEndTimeProperty
+}
+
+// WithOneOf represents an activity with ActivityStreamsOneOfProperty
+type WithOneOf interface {
+	GetActivityStreamsOneOf() vocab.ActivityStreamsOneOfProperty
+}
+
+// WithAnyOf represents an activity with ActivityStreamsAnyOfProperty
+type WithAnyOf interface {
+	GetActivityStreamsAnyOf() vocab.ActivityStreamsAnyOfProperty
+}
+
+// WithClosed represents an activity with ActivityStreamsClosedProperty
+type WithClosed interface {
+	GetActivityStreamsClosed() vocab.ActivityStreamsClosedProperty
+}
+
+// WithVotersCount represents an activity with TootVotersCountProperty
+type WithVotersCount interface {
+	GetTootVotersCount() vocab.TootVotersCountProperty
+}
+
+// WithLocation represents an activity with ActivityStreamsLocationProperty
+type WithLocation interface {
+	GetActivityStreamsLocation() vocab.ActivityStreamsLocationProperty
+}
+
 // WithMediaType represents an activity with ActivityStreamsMediaTypeProperty
 type WithMediaType interface {
 	GetActivityStreamsMediaType() vocab.ActivityStreamsMediaTypeProperty
@@ -351,6 +442,11 @@ type WithObject interface {
 	GetActivityStreamsObject() vocab.ActivityStreamsObjectProperty
 }
 
+// WithTarget represents an activity with ActivityStreamsTargetProperty
+type WithTarget interface {
+	GetActivityStreamsTarget() vocab.ActivityStreamsTargetProperty
+}
+
 // WithNext represents an activity with ActivityStreamsNextProperty
 type WithNext interface {
 	GetActivityStreamsNext() vocab.ActivityStreamsNextProperty
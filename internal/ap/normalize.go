@@ -75,7 +75,7 @@ func NormalizeIncomingActivityObject(activity pub.Activity, rawJSON map[string]i
 	}
 
 	switch t.GetTypeName() {
-	case ObjectArticle, ObjectDocument, ObjectImage, ObjectVideo, ObjectNote, ObjectPage, ObjectEvent, ObjectPlace, ObjectProfile:
+	case ObjectArticle, ObjectDocument, ObjectImage, ObjectVideo, ObjectNote, ObjectPage, ObjectEvent, ObjectPlace, ObjectProfile, ActivityQuestion:
 		statusable, ok := t.(Statusable)
 		if !ok {
 			// Object is not Statusable;
@@ -126,32 +126,56 @@ func NormalizeIncomingActivityObject(activity pub.Activity, rawJSON map[string]i
 }
 
 // NormalizeIncomingContent replaces the Content of the given item
-// with the raw 'content' value from the raw json object map.
+// with the raw 'content' value from the raw json object map, followed
+// by the raw 'contentMap' language map if there is one, since go-fed
+// ignores 'contentMap' when 'content' is also set.
 //
-// noop if there was no content in the json object map or the
-// content was not a plain string.
+// noop if there was neither plain string content nor a contentMap
+// of plain strings in the json object map.
 func NormalizeIncomingContent(item WithSetContent, rawJSON map[string]interface{}) {
-	rawContent, ok := rawJSON["content"]
-	if !ok {
-		// No content in rawJSON.
-		// TODO: In future we might also
-		// look for "contentMap" property.
-		return
-	}
-
-	content, ok := rawContent.(string)
-	if !ok {
-		// Not interested in content arrays.
+	content, hasContent := rawJSON["content"].(string)
+	contentMap := normalizeContentMap(rawJSON["contentMap"])
+	if !hasContent && contentMap == nil {
+		// No content we're
+		// interested in.
 		return
 	}
 
-	// Set normalized content property from the raw string;
+	// Set normalized content property from the raw values;
 	// this replaces any existing content property on the item.
 	contentProp := streams.NewActivityStreamsContentProperty()
-	contentProp.AppendXMLSchemaString(content)
+	if hasContent {
+		contentProp.AppendXMLSchemaString(content)
+	}
+	if contentMap != nil {
+		contentProp.AppendRDFLangString(contentMap)
+	}
 	item.SetActivityStreamsContent(contentProp)
 }
 
+// normalizeContentMap returns the given raw 'contentMap'
+// value as a map of language tag to content, skipping any
+// non-string values, or nil if there are no string values.
+func normalizeContentMap(rawContentMap interface{}) map[string]string {
+	raw, ok := rawContentMap.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	contentMap := make(map[string]string, len(raw))
+	for lang, rawContent := range raw {
+		if content, ok := rawContent.(string); ok {
+			contentMap[lang] = content
+		}
+	}
+
+	if len(contentMap) == 0 {
+		return nil
+	}
+
+	return contentMap
+}
+
 // NormalizeIncomingAttachments normalizes all attachments (if any) of the given
 // item, replacing the 'name' (aka content warning) field of each attachment
 // with the raw 'name' value from the raw json object map.
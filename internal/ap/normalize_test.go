@@ -169,6 +169,23 @@ func (suite *NormalizeTestSuite) getStatusableWithWeirdSummaryAndName() (vocab.A
 	return t.(vocab.ActivityStreamsNote), raw
 }
 
+func (suite *NormalizeTestSuite) getStatusableWithContentMap() (vocab.ActivityStreamsNote, map[string]interface{}) {
+	t, raw := suite.jsonToType(`{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "https://example.org/users/someone/statuses/01H6Z1J8M7Y0XQ2S2YV3C5PKMV",
+		"type": "Note",
+		"attributedTo": "https://example.org/users/someone",
+		"to": "https://www.w3.org/ns/activitystreams#Public",
+		"content": "<p>hello world</p>",
+		"contentMap": {
+		  "en": "<p>hello world</p>",
+		  "de": "<p>hallo welt</p>"
+		}
+	  }`)
+
+	return t.(vocab.ActivityStreamsNote), raw
+}
+
 func (suite *NormalizeTestSuite) getAccountable() (vocab.ActivityStreamsPerson, map[string]interface{}) {
 	t, raw := suite.jsonToType(`{
 		"@context": "https://www.w3.org/ns/activitystreams",
@@ -391,6 +408,17 @@ func (suite *NormalizeTestSuite) TestNormalizeStatusableName() {
 	suite.Equal(`WARNING: #WEIRD #nameEE ;;;;a;;a;asv    khop8273987(*^&^)`, ap.ExtractName(statusable))
 }
 
+func (suite *NormalizeTestSuite) TestNormalizeStatusableContentMap() {
+	statusable, rawStatusable := suite.getStatusableWithContentMap()
+
+	ap.NormalizeIncomingContent(statusable, rawStatusable)
+	suite.Equal("<p>hello world</p>", ap.ExtractContent(statusable))
+	suite.Equal(map[string]string{
+		"en": "<p>hello world</p>",
+		"de": "<p>hallo welt</p>",
+	}, ap.ExtractContentMap(statusable))
+}
+
 func TestNormalizeTestSuite(t *testing.T) {
 	suite.Run(t, new(NormalizeTestSuite))
 }
@@ -37,14 +37,17 @@ import (
 //
 //   - OrderedCollection: 'orderedItems' property will always be made into an array.
 //   - Any Accountable type: 'attachment' property will always be made into an array.
-//   - Update: any Accountable 'object's set on an update will be custom serialized as above.
+//   - Any Statusable type: 'content' language map will be moved to 'contentMap'.
+//   - Create, Update: any Accountable or Statusable 'object's will be custom serialized as above.
 func Serialize(t vocab.Type) (m map[string]interface{}, e error) {
 	switch t.GetTypeName() {
 	case ObjectOrderedCollection:
 		return serializeOrderedCollection(t)
 	case ActorApplication, ActorGroup, ActorOrganization, ActorPerson, ActorService:
 		return serializeAccountable(t, true)
-	case ActivityUpdate:
+	case ObjectArticle, ObjectDocument, ObjectImage, ObjectVideo, ObjectNote, ObjectPage, ObjectEvent, ObjectPlace, ObjectProfile, ActivityQuestion:
+		return serializeStatusable(t, true)
+	case ActivityCreate, ActivityUpdate:
 		return serializeWithObject(t)
 	default:
 		// No custom serializer necessary.
@@ -132,6 +135,67 @@ func serializeAccountable(accountable vocab.Type, includeContext bool) (map[stri
 	return data, nil
 }
 
+// serializeStatusable is a custom serializer for any Statusable type.
+// go-fed serializes a language map value of the 'content' property
+// under 'content', rather than as 'contentMap', alongside any plain
+// string value; this serializer moves the language map to 'contentMap'
+// where other implementations expect it, eg.:
+//
+//	"content": "<p>hello</p>",
+//	"contentMap": {"en": "<p>hello</p>"}
+//
+// See serializeAccountable for an explanation of includeContext.
+func serializeStatusable(statusable vocab.Type, includeContext bool) (map[string]interface{}, error) {
+	var (
+		data map[string]interface{}
+		err  error
+	)
+
+	if includeContext {
+		data, err = streams.Serialize(statusable)
+	} else {
+		data, err = statusable.Serialize()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	content, ok := data["content"]
+	if !ok {
+		// No 'content', nothing to change.
+		return data, nil
+	}
+
+	values, ok := content.([]interface{})
+	if !ok {
+		// Coerce single value to slice.
+		values = []interface{}{content}
+	}
+
+	// Separate plain string
+	// content from the map.
+	strs := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		if contentMap, ok := value.(map[string]string); ok {
+			data["contentMap"] = contentMap
+			continue
+		}
+		strs = append(strs, value)
+	}
+
+	switch len(strs) {
+	case 0:
+		delete(data, "content")
+	case 1:
+		data["content"] = strs[0]
+	default:
+		data["content"] = strs
+	}
+
+	return data, nil
+}
+
 func serializeWithObject(t vocab.Type) (map[string]interface{}, error) {
 	withObject, ok := t.(WithObject)
 	if !ok {
@@ -182,6 +246,8 @@ func serializeWithObject(t vocab.Type) (map[string]interface{}, error) {
 			// @context will be included in wrapping type already,
 			// we don't need to include it in the object itself.
 			objectSer, err = serializeAccountable(objectType, false)
+		case ObjectArticle, ObjectDocument, ObjectImage, ObjectVideo, ObjectNote, ObjectPage, ObjectEvent, ObjectPlace, ObjectProfile, ActivityQuestion:
+			objectSer, err = serializeStatusable(objectType, false)
 		default:
 			// No custom serializer for this type; serialize as normal.
 			objectSer, err = objectType.Serialize()
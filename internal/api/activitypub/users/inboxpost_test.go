@@ -272,6 +272,38 @@ func (suite *InboxPostTestSuite) TestPostBlock() {
 	}
 }
 
+// TestPostBlockToDeletedAccount verifies that activities
+// delivered to a deleted account are accepted, but dropped.
+func (suite *InboxPostTestSuite) TestPostBlockToDeletedAccount() {
+	var (
+		ctx               = context.Background()
+		requestingAccount = suite.testAccounts["remote_account_1"]
+		targetAccount     = suite.testAccounts["local_account_1"]
+		activityID        = requestingAccount.URI + "/some-new-activity/01FG9C441MCTW3R2W117V2PQK3"
+	)
+
+	if errWithCode := suite.processor.Account().Delete(ctx, targetAccount, targetAccount.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	block := suite.newBlock(activityID, requestingAccount, targetAccount)
+
+	// Block.
+	suite.inboxPost(
+		block,
+		requestingAccount,
+		targetAccount,
+		http.StatusAccepted,
+		"",
+		suite.signatureCheck,
+	)
+
+	// Ensure no block was created in the database.
+	blocked, err := suite.db.IsBlocked(ctx, requestingAccount.ID, targetAccount.ID)
+	suite.NoError(err)
+	suite.False(blocked)
+}
+
 // TestPostUnblock verifies that a remote account who blocks
 // one of our instance users should be able to undo that block.
 func (suite *InboxPostTestSuite) TestPostUnblock() {
@@ -419,6 +451,69 @@ func (suite *InboxPostTestSuite) TestPostUpdate() {
 	suite.EqualValues(requestingAccount.SuspensionOrigin, dbUpdatedAccount.SuspensionOrigin)
 }
 
+func (suite *InboxPostTestSuite) TestPostUpdateRedelivered() {
+	var (
+		requestingAccount = new(gtsmodel.Account)
+		targetAccount     = suite.testAccounts["local_account_1"]
+	)
+
+	// Copy the requesting account, since we'll be changing it.
+	*requestingAccount = *suite.testAccounts["remote_account_1"]
+
+	postUpdate := func(activityID string, displayName string) {
+		requestingAccount.DisplayName = displayName
+		asAccount, err := suite.tc.AccountToAS(context.Background(), requestingAccount)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		suite.inboxPost(
+			suite.newUpdatePerson(asAccount, targetAccount.URI, activityID),
+			requestingAccount,
+			targetAccount,
+			http.StatusAccepted,
+			`{"status":"Accepted"}`,
+			suite.signatureCheck,
+		)
+	}
+
+	waitForDisplayName := func(displayName string) {
+		if !testrig.WaitFor(func() bool {
+			dbAccount, _ := suite.db.GetAccountByID(context.Background(), requestingAccount.ID)
+			return dbAccount.DisplayName == displayName
+		}) {
+			suite.FailNow("timed out waiting for display name " + displayName)
+		}
+	}
+
+	// Deliver the same update 5 times, as
+	// a remote retrying delivery would do.
+	for i := 0; i < 5; i++ {
+		postUpdate("http://fossbros-anonymous.io/updates/a", "display name a")
+	}
+	waitForDisplayName("display name a")
+
+	// The redeliveries shouldn't have counted
+	// towards the limit, so 4 more distinct
+	// updates should all make it to the database.
+	for _, id := range []string{"b", "c", "d", "e"} {
+		postUpdate("http://fossbros-anonymous.io/updates/"+id, "display name "+id)
+		waitForDisplayName("display name " + id)
+	}
+
+	// The next distinct update is over the
+	// limit, so the database row should
+	// not be updated by it.
+	postUpdate("http://fossbros-anonymous.io/updates/f", "display name f")
+	time.Sleep(2 * time.Second)
+
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), requestingAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("display name e", dbAccount.DisplayName)
+}
+
 func (suite *InboxPostTestSuite) TestPostDelete() {
 	var (
 		ctx               = context.Background()
@@ -450,7 +545,7 @@ func (suite *InboxPostTestSuite) TestPostDelete() {
 
 	if !testrig.WaitFor(func() bool {
 		// no statuses from foss satan should be left in the database
-		dbStatuses, err := suite.db.GetAccountStatuses(ctx, requestingAccount.ID, 0, false, false, "", "", false, false)
+		dbStatuses, err := suite.db.GetAccountStatuses(ctx, requestingAccount.ID, 0, false, false, "", "", false, false, false, false)
 		return len(dbStatuses) == 0 && errors.Is(err, db.ErrNoEntries)
 	}) {
 		suite.FailNow("timed out waiting for statuses to be removed")
@@ -487,7 +582,7 @@ func (suite *InboxPostTestSuite) TestPostEmptyCreate() {
 		requestingAccount,
 		targetAccount,
 		http.StatusBadRequest,
-		`{"error":"Bad Request: incoming Activity Create did not have required id property set"}`,
+		`{"error":"Bad Request: incoming Activity Create did not have required id property set","error_code":"ERR_BAD_REQUEST"}`,
 		suite.signatureCheck,
 	)
 }
@@ -512,7 +607,7 @@ func (suite *InboxPostTestSuite) TestPostFromBlockedAccount() {
 		requestingAccount,
 		targetAccount,
 		http.StatusForbidden,
-		`{"error":"Forbidden"}`,
+		`{"error":"Forbidden","error_code":"ERR_FORBIDDEN"}`,
 		suite.signatureCheck,
 	)
 }
@@ -556,7 +651,7 @@ func (suite *InboxPostTestSuite) TestPostUnauthorized() {
 		requestingAccount,
 		targetAccount,
 		http.StatusUnauthorized,
-		`{"error":"Unauthorized"}`,
+		`{"error":"Unauthorized","error_code":"ERR_AUTH_REQUIRED"}`,
 		// Omit signature check middleware.
 	)
 }
@@ -60,7 +60,7 @@ func (m *Module) StatusGETHandler(c *gin.Context) {
 
 	resp, errWithCode := m.processor.Fedi().StatusGet(c.Request.Context(), requestedUsername, requestedStatusID)
 	if errWithCode != nil {
-		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		m.errorHandler(c, format, resp, errWithCode)
 		return
 	}
 
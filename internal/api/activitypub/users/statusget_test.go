@@ -156,6 +156,62 @@ func (suite *StatusGetTestSuite) TestGetStatusLowercase() {
 	suite.EqualValues(targetStatus.Content, a.Content)
 }
 
+func (suite *StatusGetTestSuite) TestGetStatusDeleted() {
+	// the dereference we're gonna use
+	derefRequests := testrig.NewTestDereferenceRequests(suite.testAccounts)
+	signedRequest := derefRequests["foss_satan_dereference_local_account_1_status_1"]
+	targetAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	if _, errWithCode := suite.processor.Status().Delete(context.Background(), targetAccount, targetStatus.ID, false); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// wait for the status delete to be processed
+	if !testrig.WaitFor(func() bool {
+		deleted, _ := suite.db.TombstoneExistsWithURI(context.Background(), targetStatus.URI)
+		return deleted
+	}) {
+		suite.FailNow("delete of status timed out")
+	}
+
+	// setup request
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Request = httptest.NewRequest(http.MethodGet, targetStatus.URI, nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("accept", "application/activity+json")
+	ctx.Request.Header.Set("Signature", signedRequest.SignatureHeader)
+	ctx.Request.Header.Set("Date", signedRequest.DateHeader)
+
+	// we need to pass the context through signature check first to set appropriate values on it
+	suite.signatureCheck(ctx)
+
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   users.UsernameKey,
+			Value: targetAccount.Username,
+		},
+		gin.Param{
+			Key:   users.StatusIDKey,
+			Value: targetStatus.ID,
+		},
+	}
+
+	// trigger the function being tested
+	suite.userModule.StatusGETHandler(ctx)
+
+	// check response
+	suite.EqualValues(http.StatusGone, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	// should be a Tombstone
+	suite.Equal(`{"@context":"https://www.w3.org/ns/activitystreams","formerType":"Note","id":"`+targetStatus.URI+`","type":"Tombstone"}`, string(b))
+}
+
 func TestStatusGetTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusGetTestSuite))
 }
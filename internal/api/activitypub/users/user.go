@@ -18,9 +18,12 @@
 package users
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
@@ -30,6 +33,8 @@ const (
 	UsernameKey = "username"
 	// StatusIDKey is for status IDs
 	StatusIDKey = "status"
+	// AcceptIDKey is for accept IDs
+	AcceptIDKey = "accept"
 	// OnlyOtherAccountsKey is for filtering status responses.
 	OnlyOtherAccountsKey = "only_other_accounts"
 	// MinIDKey is for filtering status responses.
@@ -55,6 +60,8 @@ const (
 	StatusPath = BasePath + "/" + uris.StatusesPath + "/:" + StatusIDKey
 	// StatusRepliesPath is for serving the replies collection of a status.
 	StatusRepliesPath = StatusPath + "/replies"
+	// AcceptPath is for serving GET requests to a particular accept of an interaction, with the given username key and accept ID.
+	AcceptPath = BasePath + "/" + uris.AcceptsPath + "/:" + AcceptIDKey
 )
 
 type Module struct {
@@ -76,4 +83,24 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, StatusPath, m.StatusGETHandler)
 	attachHandler(http.MethodGet, StatusRepliesPath, m.StatusRepliesGETHandler)
 	attachHandler(http.MethodGet, OutboxPath, m.OutboxGETHandler)
+	attachHandler(http.MethodGet, AcceptPath, m.AcceptGETHandler)
+}
+
+// errorHandler handles an error returned by the processor. If the
+// error is 410 Gone, and the processor returned a tombstone as resp,
+// the tombstone is written as the response body, so that remote
+// instances know the requested Actor or Object is gone for good.
+func (m *Module) errorHandler(c *gin.Context, format string, resp interface{}, errWithCode gtserror.WithCode) {
+	if resp == nil || errWithCode.Code() != http.StatusGone {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
+		return
+	}
+
+	c.Data(http.StatusGone, format, b)
 }
@@ -60,7 +60,7 @@ func (m *Module) UsersGETHandler(c *gin.Context) {
 
 	resp, errWithCode := m.processor.Fedi().UserGet(c.Request.Context(), requestedUsername, c.Request.URL)
 	if errWithCode != nil {
-		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		m.errorHandler(c, format, resp, errWithCode)
 		return
 	}
 
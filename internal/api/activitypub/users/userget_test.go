@@ -30,6 +30,10 @@ import (
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/api/activitypub/users"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -159,6 +163,117 @@ func (suite *UserGetTestSuite) TestGetUserPublicKeyDeleted() {
 	suite.EqualValues(targetAccount.Username, a.Username)
 }
 
+// TestGetUserSuspendedUnsuspended checks that a suspended account is gone
+// over ActivityPub, and can be dereferenced again after being unsuspended.
+func (suite *UserGetTestSuite) TestGetUserSuspendedUnsuspended() {
+	userModule := users.New(suite.processor)
+	targetAccount := suite.testAccounts["local_account_1"]
+
+	getUser := func() int {
+		// the dereference we're gonna use
+		derefRequests := testrig.NewTestDereferenceRequests(suite.testAccounts)
+		signedRequest := derefRequests["foss_satan_dereference_zork"]
+
+		// setup request
+		recorder := httptest.NewRecorder()
+		ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+		ctx.Request = httptest.NewRequest(http.MethodGet, targetAccount.URI, nil) // the endpoint we're hitting
+		ctx.Request.Header.Set("accept", "application/activity+json")
+		ctx.Request.Header.Set("Signature", signedRequest.SignatureHeader)
+		ctx.Request.Header.Set("Date", signedRequest.DateHeader)
+
+		// we need to pass the context through signature check first to set appropriate values on it
+		suite.signatureCheck(ctx)
+
+		ctx.Params = gin.Params{
+			gin.Param{
+				Key:   users.UsernameKey,
+				Value: targetAccount.Username,
+			},
+		}
+
+		// trigger the function being tested
+		userModule.UsersGETHandler(ctx)
+		return recorder.Code
+	}
+
+	suite.processor.Account().DeleteSelf(context.Background(), targetAccount)
+
+	// wait for the account delete to be processed
+	if !testrig.WaitFor(func() bool {
+		a, _ := suite.db.GetAccountByID(context.Background(), targetAccount.ID)
+		return !a.SuspendedAt.IsZero()
+	}) {
+		suite.FailNow("delete of account timed out")
+	}
+
+	suite.EqualValues(http.StatusGone, getUser())
+
+	// unsuspend the account again
+	if errWithCode := suite.processor.Admin().AccountAction(context.Background(), suite.testAccounts["admin_account"], &apimodel.AdminAccountActionRequest{
+		Type:            "unsuspend",
+		TargetAccountID: targetAccount.ID,
+	}); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.EqualValues(http.StatusOK, getUser())
+}
+
+// TestGetUserBehindProxy checks that an actor served to a plain http
+// request from a trusted TLS-terminating proxy still has an https id.
+func (suite *UserGetTestSuite) TestGetUserBehindProxy() {
+	config.SetProtocol("https")
+
+	// give the target account the URIs it
+	// would have been created with over https
+	targetAccount := suite.testAccounts["local_account_1"]
+	accountURIs := uris.GenerateURIsForAccount(targetAccount.Username)
+	targetAccount.URI = accountURIs.UserURI
+	targetAccount.URL = accountURIs.UserURL
+	targetAccount.InboxURI = accountURIs.InboxURI
+	targetAccount.OutboxURI = accountURIs.OutboxURI
+	targetAccount.FollowersURI = accountURIs.FollowersURI
+	targetAccount.FollowingURI = accountURIs.FollowingURI
+	targetAccount.FeaturedCollectionURI = accountURIs.FeaturedCollectionURI
+	targetAccount.PublicKeyURI = accountURIs.PublicKeyURI
+	if err := suite.db.UpdateAccount(context.Background(), targetAccount); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	derefRequests := testrig.NewTestDereferenceRequests(suite.testAccounts)
+	signedRequest := derefRequests["foss_satan_dereference_zork"]
+
+	engine := gin.New()
+	engine.Use(middleware.Forwarded())
+	engine.GET("/users/:"+users.UsernameKey, suite.signatureCheck, suite.userModule.UsersGETHandler)
+
+	// the proxy terminates TLS and talks plain http to us
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/users/"+targetAccount.Username, nil)
+	request.RemoteAddr = "127.0.0.1:54321"
+	request.Header.Set("accept", "application/activity+json")
+	request.Header.Set("Signature", signedRequest.SignatureHeader)
+	request.Header.Set("Date", signedRequest.DateHeader)
+	request.Header.Set("X-Forwarded-Proto", "https")
+	engine.ServeHTTP(recorder, request)
+
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(b, &m); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("https://localhost:8080/users/the_mighty_zork", m["id"])
+	suite.Equal("https://localhost:8080/users/the_mighty_zork/inbox", m["inbox"])
+}
+
 func TestUserGetTestSuite(t *testing.T) {
 	suite.Run(t, new(UserGetTestSuite))
 }
@@ -29,8 +29,7 @@ import (
 )
 
 type Auth struct {
-	routerSession *gtsmodel.RouterSession
-	sessionName   string
+	session gin.HandlerFunc
 
 	auth *auth.Module
 }
@@ -42,23 +41,24 @@ func (a *Auth) Route(r router.Router, m ...gin.HandlerFunc) {
 	oauthGroup := r.AttachGroup("oauth")
 
 	// instantiate + attach shared, non-global middlewares to both of these groups
-	var (
-		cacheControlMiddleware = middleware.CacheControl("private", "max-age=120")
-		sessionMiddleware      = middleware.Session(a.sessionName, a.routerSession.Auth, a.routerSession.Crypt)
-	)
+	cacheControlMiddleware := middleware.CacheControl("private", "max-age=120")
 	authGroup.Use(m...)
 	oauthGroup.Use(m...)
-	authGroup.Use(cacheControlMiddleware, sessionMiddleware)
-	oauthGroup.Use(cacheControlMiddleware, sessionMiddleware)
+	authGroup.Use(cacheControlMiddleware, a.session)
+	oauthGroup.Use(cacheControlMiddleware, a.session)
 
 	a.auth.RouteAuth(authGroup.Handle)
 	a.auth.RouteOauth(oauthGroup.Handle)
 }
 
-func NewAuth(db db.DB, p *processing.Processor, idp oidc.IDP, routerSession *gtsmodel.RouterSession, sessionName string) *Auth {
-	return &Auth{
-		routerSession: routerSession,
-		sessionName:   sessionName,
-		auth:          auth.New(db, p, idp),
+func NewAuth(db db.DB, p *processing.Processor, idp oidc.IDP, routerSession *gtsmodel.RouterSession, sessionName string) (*Auth, error) {
+	session, err := middleware.Session(sessionName, routerSession.Auth, routerSession.Crypt)
+	if err != nil {
+		return nil, err
 	}
+
+	return &Auth{
+		session: session,
+		auth:    auth.New(db, p, idp),
+	}, nil
 }
@@ -43,6 +43,10 @@ const (
 	AuthAccountDisabledPath = "/account_disabled"
 	// AuthCallbackPath is the API path for receiving callback tokens from external OIDC providers
 	AuthCallbackPath = "/callback"
+	// AuthPasskeyBeginPath is the API path for starting to sign in with a passkey
+	AuthPasskeyBeginPath = "/passkey/begin"
+	// AuthPasskeyFinishPath is the API path for finishing signing in with a passkey
+	AuthPasskeyFinishPath = "/passkey/finish"
 
 	/*
 		paths prefixed with 'oauth'
@@ -61,18 +65,21 @@ const (
 		params / session keys
 	*/
 
-	callbackStateParam   = "state"
-	callbackCodeParam    = "code"
-	sessionUserID        = "userid"
-	sessionClientID      = "client_id"
-	sessionRedirectURI   = "redirect_uri"
-	sessionForceLogin    = "force_login"
-	sessionResponseType  = "response_type"
-	sessionScope         = "scope"
-	sessionInternalState = "internal_state"
-	sessionClientState   = "client_state"
-	sessionClaims        = "claims"
-	sessionAppID         = "app_id"
+	callbackStateParam         = "state"
+	callbackCodeParam          = "code"
+	sessionUserID              = "userid"
+	sessionClientID            = "client_id"
+	sessionRedirectURI         = "redirect_uri"
+	sessionForceLogin          = "force_login"
+	sessionResponseType        = "response_type"
+	sessionScope               = "scope"
+	sessionInternalState       = "internal_state"
+	sessionClientState         = "client_state"
+	sessionClaims              = "claims"
+	sessionAppID               = "app_id"
+	sessionWebAuthnChallenge   = "webauthn_challenge"
+	sessionWebAuthnChallengeAt = "webauthn_challenge_at"
+	sessionWebSessionID        = "web_session_id"
 )
 
 type Module struct {
@@ -97,6 +104,8 @@ func (m *Module) RouteAuth(attachHandler func(method string, path string, f ...g
 	attachHandler(http.MethodGet, AuthSignInPath, m.SignInGETHandler)
 	attachHandler(http.MethodPost, AuthSignInPath, m.SignInPOSTHandler)
 	attachHandler(http.MethodGet, AuthCallbackPath, m.CallbackGETHandler)
+	attachHandler(http.MethodPost, AuthPasskeyBeginPath, m.PasskeyBeginPOSTHandler)
+	attachHandler(http.MethodPost, AuthPasskeyFinishPath, m.PasskeyFinishPOSTHandler)
 }
 
 // RouteOauth routes all paths that should have an 'oauth' prefix
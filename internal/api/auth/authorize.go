@@ -29,6 +29,7 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
@@ -202,6 +203,10 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 		errs = append(errs, fmt.Sprintf("key %s was not found in session", sessionUserID))
 	}
 
+	// web session ID may not be set
+	// on sessions from before upgrade
+	webSessionID, _ := s.Get(sessionWebSessionID).(string)
+
 	if len(errs) != 0 {
 		errs = append(errs, oauth.HelpfulAdvice)
 		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(errors.New("one or more missing keys on session during AuthorizePOSTHandler"), errs...), m.processor.InstanceGetV1)
@@ -260,6 +265,12 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 		c.Request.Form.Set("state", clientState)
 	}
 
+	if webSessionID != "" {
+		// link created tokens to this web session
+		ctx := gtscontext.SetWebSessionID(c.Request.Context(), webSessionID)
+		c.Request = c.Request.WithContext(ctx)
+	}
+
 	if errWithCode := m.processor.OAuthHandleAuthorizeRequest(c.Writer, c.Request); errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 	}
@@ -317,6 +328,13 @@ func ensureUserIsAuthorizedOrRedirect(ctx *gin.Context, user *gtsmodel.User, acc
 		return
 	}
 
+	if !user.RejectedAt.IsZero() {
+		// Sign-up was rejected by a moderator.
+		ctx.Redirect(http.StatusSeeOther, "/auth"+AuthAccountDisabledPath)
+		redirected = true
+		return
+	}
+
 	if !*user.Approved {
 		ctx.Redirect(http.StatusSeeOther, "/auth"+AuthWaitForApprovalPath)
 		redirected = true
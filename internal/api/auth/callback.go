@@ -19,10 +19,12 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-contrib/sessions"
@@ -38,6 +40,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
+const (
+	maxUsernameLength = 64  // max length of local usernames, see validate.Username
+	maxUsernameSuffix = 100 // max number to suffix to usernames in "suffix" mode
+)
+
 // extraInfo wraps a form-submitted username and transmitted name
 type extraInfo struct {
 	Username string `form:"username"`
@@ -128,15 +135,53 @@ func (m *Module) CallbackGETHandler(c *gin.Context) {
 		return
 	}
 	if user == nil {
-		// no user exists yet - let's ask them for their preferred username
+		// no user exists yet - check they're allowed to sign up
+		if signupGroups := config.GetOIDCSignupGroups(); len(signupGroups) > 0 && !claims.InGroups(signupGroups) {
+			m.clearSession(s)
+			err := fmt.Errorf("user with sub %s is not a member of any of %s", claims.Sub, signupGroups)
+			help := "Sorry, signing up to this instance is limited to members of certain groups, and you're not currently a member of any of them. " +
+				"If you think this is a mistake, please get in touch with the instance admin."
+			apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, help), m.processor.InstanceGetV1)
+			return
+		}
+
+		if config.GetOIDCUsernameMode() != "ask" {
+			// username is taken from the claims, so
+			// we can go ahead and create the user now
+			username, errWithCode := m.usernameFromClaims(c.Request.Context(), claims)
+			if errWithCode != nil {
+				m.clearSession(s)
+				apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+				return
+			}
+
+			user, errWithCode = m.createUserFromOIDC(c.Request.Context(), claims, &extraInfo{Username: username}, net.IP(c.ClientIP()), app.ID)
+			if errWithCode != nil {
+				m.clearSession(s)
+				apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+				return
+			}
+
+			m.loginOIDCUser(c, s, user)
+			return
+		}
+
+		// let's ask them for their preferred username
 		instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
 		if errWithCode != nil {
 			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 			return
 		}
 
-		// store the claims in the session - that way we know the user is authenticated when processing the form later
-		s.Set(sessionClaims, claims)
+		// store the claims in the session - that way we know the user is authenticated when processing the form later;
+		// they're stored JSON-encoded, since session stores other than memory only keep plain values
+		claimsJSON, err := json.Marshal(claims)
+		if err != nil {
+			m.clearSession(s)
+			apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
+			return
+		}
+		s.Set(sessionClaims, string(claimsJSON))
 		s.Set(sessionAppID, app.ID)
 		if err := s.Save(); err != nil {
 			m.clearSession(s)
@@ -150,7 +195,27 @@ func (m *Module) CallbackGETHandler(c *gin.Context) {
 		})
 		return
 	}
-	s.Set(sessionUserID, user.ID)
+
+	// existing user; bring their roles up to date
+	// with any changes to their groups in the IdP
+	if err := m.syncOIDCRoles(c.Request.Context(), user, claims); err != nil {
+		m.clearSession(s)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
+		return
+	}
+
+	m.loginOIDCUser(c, s, user)
+}
+
+// loginOIDCUser sets the given user as logged in on the
+// session, and redirects to continue the oauth flow.
+func (m *Module) loginOIDCUser(c *gin.Context, s sessions.Session, user *gtsmodel.User) {
+	if err := m.startWebSession(c, s, user.ID); err != nil {
+		m.clearSession(s)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
+		return
+	}
+
 	if err := s.Save(); err != nil {
 		m.clearSession(s)
 		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
@@ -211,13 +276,20 @@ func (m *Module) FinalizePOSTHandler(c *gin.Context) {
 	}
 
 	// retrieve the claims returned by the IDP. Having this present means that we previously already verified these claims
-	claims, ok := s.Get(sessionClaims).(*oidc.Claims)
+	claimsJSON, ok := s.Get(sessionClaims).(string)
 	if !ok {
 		err := fmt.Errorf("key %s was not found in session", sessionClaims)
 		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
 		return
 	}
 
+	claims := &oidc.Claims{}
+	if err := json.Unmarshal([]byte(claimsJSON), claims); err != nil {
+		m.clearSession(s)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
+		return
+	}
+
 	// we're now ready to actually create the user
 	user, errWithCode := m.createUserFromOIDC(c.Request.Context(), claims, form, net.IP(c.ClientIP()), appID)
 	if errWithCode != nil {
@@ -227,13 +299,7 @@ func (m *Module) FinalizePOSTHandler(c *gin.Context) {
 	}
 	s.Delete(sessionClaims)
 	s.Delete(sessionAppID)
-	s.Set(sessionUserID, user.ID)
-	if err := s.Save(); err != nil {
-		m.clearSession(s)
-		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
-		return
-	}
-	c.Redirect(http.StatusFound, "/oauth"+OauthAuthorizePath)
+	m.loginOIDCUser(c, s, user)
 }
 
 func (m *Module) fetchUserForClaims(ctx context.Context, claims *oidc.Claims, ip net.IP, appID string) (*gtsmodel.User, gtserror.WithCode) {
@@ -283,17 +349,7 @@ func (m *Module) createUserFromOIDC(ctx context.Context, claims *oidc.Claims, ex
 	}
 
 	// check if the user is in any recognised admin groups
-	adminGroups := config.GetOIDCAdminGroups()
-	var admin bool
-LOOP:
-	for _, g := range claims.Groups {
-		for _, ag := range adminGroups {
-			if strings.EqualFold(g, ag) {
-				admin = true
-				break LOOP
-			}
-		}
-	}
+	admin := claims.InGroups(config.GetOIDCAdminGroups())
 
 	// We still need to set *a* password even if it's not a password the user will end up using, so set something random.
 	// We'll just set two uuids on top of each other, which should be long + random enough to baffle any attempts to crack.
@@ -317,5 +373,107 @@ LOOP:
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// set any other roles (eg., moderator)
+	if err := m.syncOIDCRoles(ctx, user, claims); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	return user, nil
 }
+
+// syncOIDCRoles sets the admin and moderator roles of the given user
+// from their group membership in the given claims. Roles are only
+// managed this way if admin and/or moderator groups are configured,
+// in which case the IdP is the source of truth: users who are no
+// longer members of the relevant groups will be demoted.
+func (m *Module) syncOIDCRoles(ctx context.Context, user *gtsmodel.User, claims *oidc.Claims) error {
+	adminGroups := config.GetOIDCAdminGroups()
+	moderatorGroups := config.GetOIDCModeratorGroups()
+	if len(adminGroups) == 0 && len(moderatorGroups) == 0 {
+		// roles not managed by IdP
+		return nil
+	}
+
+	// admins are always moderators too
+	admin := claims.InGroups(adminGroups)
+	moderator := admin || claims.InGroups(moderatorGroups)
+
+	if user.Admin != nil && *user.Admin == admin &&
+		user.Moderator != nil && *user.Moderator == moderator {
+		// nothing changed
+		return nil
+	}
+
+	user.Admin = &admin
+	user.Moderator = &moderator
+	if err := m.db.UpdateUser(ctx, user, "admin", "moderator"); err != nil {
+		return fmt.Errorf("error updating roles of user %s: %w", user.ID, err)
+	}
+
+	return nil
+}
+
+// usernameFromClaims derives a username for a new account from the preferred
+// username in the given claims. If the username is already taken, then in
+// "suffix" username mode a number is appended to find a free one, while in
+// "deny" mode the signup is refused.
+func (m *Module) usernameFromClaims(ctx context.Context, claims *oidc.Claims) (string, gtserror.WithCode) {
+	username := sanitizeUsername(claims.PreferredUsername)
+	if err := validate.Username(username); err != nil {
+		help := fmt.Sprintf("The username given to us by your authentication provider (%s) can't be used on this instance. "+
+			"Please get in touch with the instance admin.", claims.PreferredUsername)
+		return "", gtserror.NewErrorForbidden(err, help)
+	}
+
+	suffix := config.GetOIDCUsernameMode() == "suffix"
+	candidate := username
+
+	for i := 2; i <= maxUsernameSuffix; i++ {
+		available, err := m.db.IsUsernameAvailable(ctx, candidate)
+		if err != nil {
+			return "", gtserror.NewErrorInternalError(err)
+		}
+
+		if available {
+			return candidate, nil
+		}
+
+		if !suffix {
+			break
+		}
+
+		// try again with a suffix, trimming
+		// the username so the result still fits
+		s := "_" + strconv.Itoa(i)
+		candidate = username
+		if len(candidate)+len(s) > maxUsernameLength {
+			candidate = candidate[:maxUsernameLength-len(s)]
+		}
+		candidate += s
+	}
+
+	err := fmt.Errorf("username %s is not available", username)
+	help := fmt.Sprintf("The username given to us by your authentication provider (%s) is already taken on this instance. "+
+		"Please get in touch with the instance admin.", claims.PreferredUsername)
+	return "", gtserror.NewErrorConflict(err, help)
+}
+
+// sanitizeUsername lowercases the given username, and replaces any
+// characters not permitted in local usernames with underscores,
+// trimming the result to the maximum permitted username length.
+func sanitizeUsername(username string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(username) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+
+	if b.Len() > maxUsernameLength {
+		return b.String()[:maxUsernameLength]
+	}
+
+	return b.String()
+}
@@ -0,0 +1,208 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
+)
+
+// PasskeyBeginPOSTHandler should be served at https://example.org/auth/passkey/begin.
+// It stores a new WebAuthn challenge on the session, and returns the options that the
+// sign in page should pass to navigator.credentials.get() to sign in with a passkey.
+func (m *Module) PasskeyBeginPOSTHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if config.GetOIDCEnabled() {
+		err := errors.New("sign in is handled by an external OIDC provider on this instance, so passkeys cannot be used")
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
+		return
+	}
+
+	s := sessions.Default(c)
+	s.Set(sessionWebAuthnChallenge, challenge)
+	s.Set(sessionWebAuthnChallengeAt, time.Now().Unix())
+	if err := s.Save(); err != nil {
+		err := fmt.Errorf("error saving webauthn challenge onto session: %s", err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	// allowCredentials is left empty, so that the
+	// browser offers all of the user's passkeys
+	// for this instance without them needing to
+	// enter their email address first.
+	c.JSON(http.StatusOK, &apimodel.WebAuthnRequestOptions{
+		Challenge:        challenge,
+		RPID:             webauthn.RPID(),
+		Timeout:          webauthn.ChallengeTimeout.Milliseconds(),
+		UserVerification: "preferred",
+		AllowCredentials: []apimodel.WebAuthnCredentialDescriptor{},
+	})
+}
+
+// PasskeyFinishPOSTHandler should be served at https://example.org/auth/passkey/finish.
+// It verifies the signed WebAuthn challenge sent by the sign in page, and on success
+// signs the user in, returning the URI to continue the oauth flow at.
+func (m *Module) PasskeyFinishPOSTHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	s := sessions.Default(c)
+
+	form := &apimodel.WebAuthnAssertionRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	// Challenges may only be used once, so
+	// take it off the session straight away.
+	challenge, _ := s.Get(sessionWebAuthnChallenge).(string)
+	challengeAt, _ := s.Get(sessionWebAuthnChallengeAt).(int64)
+	s.Delete(sessionWebAuthnChallenge)
+	s.Delete(sessionWebAuthnChallengeAt)
+
+	if challenge == "" || time.Since(time.Unix(challengeAt, 0)) > webauthn.ChallengeTimeout {
+		err := errors.New("no passkey sign in in progress, or it took too long; please try again")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	userID, errWithCode := m.ValidatePasskey(c.Request.Context(), form, challenge)
+	if errWithCode != nil {
+		// don't clear session here, so the user can just try again
+		_ = s.Save()
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if err := m.startWebSession(c, s, userID); err != nil {
+		err := fmt.Errorf("error starting web session: %s", err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	if err := s.Save(); err != nil {
+		err := fmt.Errorf("error saving user id onto session: %s", err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"redirect_uri": "/oauth" + OauthAuthorizePath,
+	})
+}
+
+// ValidatePasskey takes a WebAuthn assertion made in response to the given challenge.
+// The goal is to verify the assertion against the public key of the passkey that
+// was used. If OK, we return the userid (a ulid) of the user owning that passkey,
+// so that it can be used in further Oauth flows.
+func (m *Module) ValidatePasskey(ctx context.Context, form *apimodel.WebAuthnAssertionRequest, challenge string) (string, gtserror.WithCode) {
+	rawID, err := webauthn.DecodeField("id", form.ID)
+	if err != nil {
+		return incorrectPasskey(err)
+	}
+
+	assertion := &webauthn.Assertion{}
+	for _, field := range []struct {
+		name  string
+		value string
+		dst   *[]byte
+	}{
+		{"client_data_json", form.ClientDataJSON, &assertion.ClientDataJSON},
+		{"authenticator_data", form.AuthenticatorData, &assertion.AuthenticatorData},
+		{"signature", form.Signature, &assertion.Signature},
+	} {
+		b, err := webauthn.DecodeField(field.name, field.value)
+		if err != nil {
+			return incorrectPasskey(err)
+		}
+		*field.dst = b
+	}
+
+	credential, err := m.db.GetWebAuthnCredentialByCredentialID(ctx, base64.RawURLEncoding.EncodeToString(rawID))
+	if err != nil {
+		err := fmt.Errorf("webauthn credential %s was not retrievable from db during passkey sign in attempt: %s", form.ID, err)
+		return incorrectPasskey(err)
+	}
+
+	if form.UserHandle != "" {
+		userHandle, err := webauthn.DecodeField("user_handle", form.UserHandle)
+		if err != nil {
+			return incorrectPasskey(err)
+		}
+
+		if !bytes.Equal(userHandle, []byte(credential.UserID)) {
+			err := fmt.Errorf("user handle didn't match owner of webauthn credential %s", credential.ID)
+			return incorrectPasskey(err)
+		}
+	}
+
+	authData, err := webauthn.VerifyAssertion(assertion, challenge, credential.PublicKey, credential.Algorithm)
+	if err != nil {
+		err := fmt.Errorf("webauthn credential %s didn't verify during passkey sign in attempt: %s", credential.ID, err)
+		return incorrectPasskey(err)
+	}
+
+	if !webauthn.SignCountValid(credential.SignCount, authData.SignCount) {
+		err := fmt.Errorf("webauthn credential %s reported sign count %d after %d, it may have been cloned", credential.ID, authData.SignCount, credential.SignCount)
+		return incorrectPasskey(err)
+	}
+
+	credential.SignCount = authData.SignCount
+	credential.LastUsedAt = time.Now()
+	if err := m.db.UpdateWebAuthnCredential(ctx, credential, "sign_count", "last_used_at"); err != nil {
+		err := fmt.Errorf("error updating webauthn credential %s: %s", credential.ID, err)
+		return "", gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice)
+	}
+
+	return credential.UserID, nil
+}
+
+// incorrectPasskey wraps the given error in a gtserror.WithCode, and returns
+// only a generic 'safe' error message to the user, to not give any info away.
+func incorrectPasskey(err error) (string, gtserror.WithCode) {
+	safeErr := fmt.Errorf("passkey could not be verified")
+	return "", gtserror.NewErrorUnauthorized(err, safeErr.Error(), oauth.HelpfulAdvice)
+}
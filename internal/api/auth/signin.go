@@ -57,7 +57,8 @@ func (m *Module) SignInGETHandler(c *gin.Context) {
 
 		// no idp provider, use our own funky little sign in page
 		c.HTML(http.StatusOK, "sign-in.tmpl", gin.H{
-			"instance": instance,
+			"instance":   instance,
+			"javascript": []string{"/assets/dist/passkey.js"},
 		})
 		return
 	}
@@ -98,7 +99,12 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 		return
 	}
 
-	s.Set(sessionUserID, userid)
+	if err := m.startWebSession(c, s, userid); err != nil {
+		err := fmt.Errorf("error starting web session: %s", err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
 	if err := s.Save(); err != nil {
 		err := fmt.Errorf("error saving user id onto session: %s", err)
 		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
@@ -133,6 +139,11 @@ func (m *Module) ValidatePassword(ctx context.Context, email string, password st
 		return incorrectPassword(err)
 	}
 
+	if user.PasswordLoginDisabled != nil && *user.PasswordLoginDisabled {
+		err := fmt.Errorf("user %s has disabled password login", user.Email)
+		return "", gtserror.NewErrorForbidden(err, "password login is disabled for this account; please sign in with a passkey instead")
+	}
+
 	return user.ID, nil
 }
 
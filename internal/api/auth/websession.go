@@ -0,0 +1,49 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth
+
+import (
+	"net"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// startWebSession records a new web session for the user with the given
+// ID, who has just signed in with the given request, and sets the user ID
+// and web session ID on the given session for use later in the oauth flow.
+func (m *Module) startWebSession(c *gin.Context, s sessions.Session, userID string) error {
+	ipChanged := false
+	webSession := &gtsmodel.WebSession{
+		ID:        id.NewULID(),
+		UserID:    userID,
+		IP:        net.ParseIP(c.ClientIP()),
+		UserAgent: c.Request.UserAgent(),
+		IPChanged: &ipChanged,
+	}
+
+	if err := m.db.PutWebSession(c.Request.Context(), webSession); err != nil {
+		return err
+	}
+
+	s.Set(sessionUserID, userID)
+	s.Set(sessionWebSessionID, webSession.ID)
+	return nil
+}
@@ -18,8 +18,6 @@
 package api
 
 import (
-	"time"
-
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
@@ -27,14 +25,17 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/blocks"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/bookmarks"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/customemojis"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/drafts"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/favourites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/featuredtags"
 	filter "github.com/superseriousbusiness/gotosocial/internal/api/client/filters"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequests"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/interactionrequests"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/lists"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/media"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/notifications"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/polls"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/preferences"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/reports"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/search"
@@ -42,6 +43,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timelines"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
@@ -52,27 +54,30 @@ type Client struct {
 	processor *processing.Processor
 	db        db.DB
 
-	accounts       *accounts.Module       // api/v1/accounts
-	admin          *admin.Module          // api/v1/admin
-	apps           *apps.Module           // api/v1/apps
-	blocks         *blocks.Module         // api/v1/blocks
-	bookmarks      *bookmarks.Module      // api/v1/bookmarks
-	customEmojis   *customemojis.Module   // api/v1/custom_emojis
-	favourites     *favourites.Module     // api/v1/favourites
-	featuredTags   *featuredtags.Module   // api/v1/featured_tags
-	filters        *filter.Module         // api/v1/filters
-	followRequests *followrequests.Module // api/v1/follow_requests
-	instance       *instance.Module       // api/v1/instance
-	lists          *lists.Module          // api/v1/lists
-	media          *media.Module          // api/v1/media, api/v2/media
-	notifications  *notifications.Module  // api/v1/notifications
-	preferences    *preferences.Module    // api/v1/preferences
-	reports        *reports.Module        // api/v1/reports
-	search         *search.Module         // api/v1/search, api/v2/search
-	statuses       *statuses.Module       // api/v1/statuses
-	streaming      *streaming.Module      // api/v1/streaming
-	timelines      *timelines.Module      // api/v1/timelines
-	user           *user.Module           // api/v1/user
+	accounts            *accounts.Module            // api/v1/accounts
+	admin               *admin.Module               // api/v1/admin
+	apps                *apps.Module                // api/v1/apps
+	blocks              *blocks.Module              // api/v1/blocks
+	bookmarks           *bookmarks.Module           // api/v1/bookmarks
+	customEmojis        *customemojis.Module        // api/v1/custom_emojis
+	drafts              *drafts.Module              // api/v1/drafts
+	favourites          *favourites.Module          // api/v1/favourites
+	featuredTags        *featuredtags.Module        // api/v1/featured_tags
+	filters             *filter.Module              // api/v1/filters
+	followRequests      *followrequests.Module      // api/v1/follow_requests
+	instance            *instance.Module            // api/v1/instance
+	interactionRequests *interactionrequests.Module // api/v1/interaction_requests
+	lists               *lists.Module               // api/v1/lists
+	media               *media.Module               // api/v1/media, api/v2/media
+	notifications       *notifications.Module       // api/v1/notifications
+	polls               *polls.Module               // api/v1/polls
+	preferences         *preferences.Module         // api/v1/preferences
+	reports             *reports.Module             // api/v1/reports
+	search              *search.Module              // api/v1/search, api/v2/search
+	statuses            *statuses.Module            // api/v1/statuses
+	streaming           *streaming.Module           // api/v1/streaming
+	timelines           *timelines.Module           // api/v1/timelines
+	user                *user.Module                // api/v1/user
 }
 
 func (c *Client) Route(r router.Router, m ...gin.HandlerFunc) {
@@ -95,14 +100,17 @@ func (c *Client) Route(r router.Router, m ...gin.HandlerFunc) {
 	c.blocks.Route(h)
 	c.bookmarks.Route(h)
 	c.customEmojis.Route(h)
+	c.drafts.Route(h)
 	c.favourites.Route(h)
 	c.featuredTags.Route(h)
 	c.filters.Route(h)
 	c.followRequests.Route(h)
 	c.instance.Route(h)
+	c.interactionRequests.Route(h)
 	c.lists.Route(h)
 	c.media.Route(h)
 	c.notifications.Route(h)
+	c.polls.Route(h)
 	c.preferences.Route(h)
 	c.reports.Route(h)
 	c.search.Route(h)
@@ -117,26 +125,29 @@ func NewClient(db db.DB, p *processing.Processor) *Client {
 		processor: p,
 		db:        db,
 
-		accounts:       accounts.New(p),
-		admin:          admin.New(p),
-		apps:           apps.New(p),
-		blocks:         blocks.New(p),
-		bookmarks:      bookmarks.New(p),
-		customEmojis:   customemojis.New(p),
-		favourites:     favourites.New(p),
-		featuredTags:   featuredtags.New(p),
-		filters:        filter.New(p),
-		followRequests: followrequests.New(p),
-		instance:       instance.New(p),
-		lists:          lists.New(p),
-		media:          media.New(p),
-		notifications:  notifications.New(p),
-		preferences:    preferences.New(p),
-		reports:        reports.New(p),
-		search:         search.New(p),
-		statuses:       statuses.New(p),
-		streaming:      streaming.New(p, time.Second*30, 4096),
-		timelines:      timelines.New(p),
-		user:           user.New(p),
+		accounts:            accounts.New(p),
+		admin:               admin.New(p),
+		apps:                apps.New(p),
+		blocks:              blocks.New(p),
+		bookmarks:           bookmarks.New(p),
+		customEmojis:        customemojis.New(p),
+		drafts:              drafts.New(p),
+		favourites:          favourites.New(p),
+		featuredTags:        featuredtags.New(p),
+		filters:             filter.New(p),
+		followRequests:      followrequests.New(p),
+		instance:            instance.New(p),
+		interactionRequests: interactionrequests.New(p),
+		lists:               lists.New(p),
+		media:               media.New(p),
+		notifications:       notifications.New(p),
+		polls:               polls.New(p),
+		preferences:         preferences.New(p),
+		reports:             reports.New(p),
+		search:              search.New(p),
+		statuses:            statuses.New(p),
+		streaming:           streaming.New(p, config.GetStreamingPingInterval(), config.GetStreamingPingTimeout(), 4096),
+		timelines:           timelines.New(p),
+		user:                user.New(p),
 	}
 }
@@ -33,6 +33,11 @@ import (
 //
 // Delete your account.
 //
+// Unless the instance is configured to delete accounts immediately, the account is
+// hidden straight away, but only deleted after a grace period (7 days by default).
+// Until then, the deletion can be cancelled with `POST /api/v1/accounts/delete/cancel`.
+// If the account has a confirmed email address, it is sent an email explaining how.
+//
 //	---
 //	tags:
 //	- accounts
@@ -72,29 +77,37 @@ func (m *Module) AccountDeletePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	// Self account delete requires password to ensure it's for real.
+	if errWithCode := checkDeletePassword(c, authed); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if errWithCode := m.processor.Account().ScheduleDeleteSelf(c.Request.Context(), authed.User, authed.Account); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "accepted"})
+}
+
+// checkDeletePassword checks that the password submitted in
+// the request form matches the password of the authed user.
+func checkDeletePassword(c *gin.Context, authed *oauth.Auth) gtserror.WithCode {
 	form := &apimodel.AccountDeleteRequest{}
 	if err := c.ShouldBind(&form); err != nil {
-		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
-		return
+		return gtserror.NewErrorBadRequest(err, err.Error())
 	}
 
-	// Self account delete requires password to ensure it's for real.
 	if form.Password == "" {
-		err = errors.New("no password provided in account delete request")
-		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
-		return
+		err := errors.New("no password provided in account delete request")
+		return gtserror.NewErrorBadRequest(err, err.Error())
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(authed.User.EncryptedPassword), []byte(form.Password)); err != nil {
 		err = errors.New("invalid password provided in account delete request")
-		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
-		return
+		return gtserror.NewErrorForbidden(err, err.Error())
 	}
 
-	if errWithCode := m.processor.Account().DeleteSelf(c.Request.Context(), authed.Account); errWithCode != nil {
-		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
-		return
-	}
-
-	c.JSON(http.StatusAccepted, gin.H{"message": "accepted"})
+	return nil
 }
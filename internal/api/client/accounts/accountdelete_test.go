@@ -18,10 +18,13 @@
 package accounts_test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
 	"github.com/superseriousbusiness/gotosocial/testrig"
@@ -51,6 +54,42 @@ func (suite *AccountDeleteTestSuite) TestAccountDeletePOSTHandler() {
 
 	// 1. we should have Accepted because our request was valid
 	suite.Equal(http.StatusAccepted, recorder.Code)
+
+	// 2. the account should be scheduled for deletion in a week, but not deleted yet
+	dbUser, err := suite.db.GetUserByAccountID(context.Background(), suite.testAccounts["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.WithinDuration(time.Now().Add(7*24*time.Hour), dbUser.DeleteAt, time.Minute)
+}
+
+func (suite *AccountDeleteTestSuite) TestAccountDeleteCancelPOSTHandler() {
+	post := func(path string, handler func(*gin.Context)) int {
+		requestBody, w, err := testrig.CreateMultipartFormData(
+			"", "",
+			map[string]string{
+				"password": "password",
+			})
+		if err != nil {
+			panic(err)
+		}
+		recorder := httptest.NewRecorder()
+		ctx := suite.newContext(recorder, http.MethodPost, requestBody.Bytes(), path, w.FormDataContentType())
+		handler(ctx)
+		return recorder.Code
+	}
+
+	// Nothing to cancel yet.
+	suite.Equal(http.StatusNotFound, post(accounts.DeleteCancelPath, suite.accountsModule.AccountDeleteCancelPOSTHandler))
+
+	suite.Equal(http.StatusAccepted, post(accounts.DeletePath, suite.accountsModule.AccountDeletePOSTHandler))
+	suite.Equal(http.StatusOK, post(accounts.DeleteCancelPath, suite.accountsModule.AccountDeleteCancelPOSTHandler))
+
+	dbUser, err := suite.db.GetUserByAccountID(context.Background(), suite.testAccounts["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(dbUser.DeleteAt)
 }
 
 func (suite *AccountDeleteTestSuite) TestAccountDeletePOSTHandlerWrongPassword() {
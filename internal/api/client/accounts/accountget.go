@@ -31,6 +31,11 @@ import (
 //
 // Get information about an account with the given ID.
 //
+// If the account is a remote account which has not been refreshed from its origin server in the last 24 hours,
+// the cached version of the account is returned, and a refresh is started in the background. In this case, the
+// response will contain the header `Fetching-For-Cache-Refresh: true`, so clients know to retry later for
+// up-to-date account details. Use `force_refresh=true` to wait for the account to be refreshed instead.
+//
 //	---
 //	tags:
 //	- accounts
@@ -45,6 +50,12 @@ import (
 //		description: The id of the requested account.
 //		in: path
 //		required: true
+//	-
+//		name: force_refresh
+//		type: boolean
+//		description: Refresh a remote account from its origin server before returning it.
+//		default: false
+//		in: query
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -84,11 +95,23 @@ func (m *Module) AccountGETHandler(c *gin.Context) {
 		return
 	}
 
-	acctInfo, errWithCode := m.processor.Account().Get(c.Request.Context(), authed.Account, targetAcctID)
+	forceRefresh, errWithCode := apiutil.ParseAccountForceRefresh(c.Query(apiutil.AccountForceRefreshKey), false)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
+	acctInfo, refreshing, errWithCode := m.processor.Account().GetWithRefresh(c.Request.Context(), authed.Account, targetAcctID, forceRefresh)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if refreshing {
+		// Let the client know
+		// to try again later.
+		c.Header(FetchingForCacheRefreshHeader, "true")
+	}
+
 	c.JSON(http.StatusOK, acctInfo)
 }
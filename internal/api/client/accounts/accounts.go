@@ -25,33 +25,52 @@ import (
 )
 
 const (
-	ExcludeReblogsKey = "exclude_reblogs"
-	ExcludeRepliesKey = "exclude_replies"
-	LimitKey          = "limit"
-	MaxIDKey          = "max_id"
-	MinIDKey          = "min_id"
-	OnlyMediaKey      = "only_media"
-	OnlyPublicKey     = "only_public"
-	PinnedKey         = "pinned"
+	ExcludeOriginalStatusesKey = "exclude_original_statuses"
+	ExcludeReblogsKey          = "exclude_reblogs"
+	ExcludeRepliesKey          = "exclude_replies"
+	LimitKey                   = "limit"
+	MaxIDKey                   = "max_id"
+	MinIDKey                   = "min_id"
+	OnlyBoostsKey              = "only_boosts"
+	OnlyMediaKey               = "only_media"
+	OnlyPinnedKey              = "only_pinned"
+	OnlyPollsKey               = "only_polls"
+	OnlyPublicKey              = "only_public"
+	PinnedKey                  = "pinned"
+
+	// FetchingForCacheRefreshHeader is set on account responses
+	// when a stale remote account is being refreshed in the background.
+	FetchingForCacheRefreshHeader = "Fetching-For-Cache-Refresh"
 
 	BasePath       = "/v1/accounts"
 	IDKey          = "id"
 	BasePathWithID = BasePath + "/:" + IDKey
 
-	BlockPath         = BasePathWithID + "/block"
-	DeletePath        = BasePath + "/delete"
-	FollowersPath     = BasePathWithID + "/followers"
-	FollowingPath     = BasePathWithID + "/following"
-	FollowPath        = BasePathWithID + "/follow"
-	ListsPath         = BasePathWithID + "/lists"
-	LookupPath        = BasePath + "/lookup"
-	RelationshipsPath = BasePath + "/relationships"
-	SearchPath        = BasePath + "/search"
-	StatusesPath      = BasePathWithID + "/statuses"
-	UnblockPath       = BasePathWithID + "/unblock"
-	UnfollowPath      = BasePathWithID + "/unfollow"
-	UpdatePath        = BasePath + "/update_credentials"
-	VerifyPath        = BasePath + "/verify_credentials"
+	BitePath              = BasePathWithID + "/bite"
+	BlockPath             = BasePathWithID + "/block"
+	DeletePath            = BasePath + "/delete"
+	DeleteCancelPath      = DeletePath + "/cancel"
+	FamiliarTagsPath      = BasePathWithID + "/familiar_tags"
+	FeaturedTagsPath      = BasePathWithID + "/featured_tags"
+	FollowersPath         = BasePathWithID + "/followers"
+	FollowersCountPath    = BasePathWithID + "/followers_count"
+	FollowingPath         = BasePathWithID + "/following"
+	FollowingCountPath    = BasePathWithID + "/following_count"
+	FollowPath            = BasePathWithID + "/follow"
+	ListsPath             = BasePathWithID + "/lists"
+	LookupPath            = BasePath + "/lookup"
+	NowPlayingPath        = BasePathWithID + "/now_playing"
+	RelationshipsPath     = BasePath + "/relationships"
+	RemoveFollowerPath    = BasePathWithID + "/remove_from_followers"
+	ScrobblePath          = BasePath + "/scrobble"
+	SearchPath            = BasePath + "/search"
+	StatisticsPath        = BasePath + "/statistics"
+	StatisticsMonthlyPath = StatisticsPath + "/monthly"
+	StatusesPath          = BasePathWithID + "/statuses"
+	UnblockPath           = BasePathWithID + "/unblock"
+	UnfollowPath          = BasePathWithID + "/unfollow"
+	UpdatePath            = BasePath + "/update_credentials"
+	VerifyPath            = BasePath + "/verify_credentials"
 )
 
 type Module struct {
@@ -73,6 +92,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 
 	// delete account
 	attachHandler(http.MethodPost, DeletePath, m.AccountDeletePOSTHandler)
+	attachHandler(http.MethodPost, DeleteCancelPath, m.AccountDeleteCancelPOSTHandler)
 
 	// verify account
 	attachHandler(http.MethodGet, VerifyPath, m.AccountVerifyGETHandler)
@@ -80,12 +100,18 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	// modify account
 	attachHandler(http.MethodPatch, UpdatePath, m.AccountUpdateCredentialsPATCHHandler)
 
+	// get own account statistics
+	attachHandler(http.MethodGet, StatisticsPath, m.AccountStatisticsGETHandler)
+	attachHandler(http.MethodGet, StatisticsMonthlyPath, m.AccountStatisticsMonthlyGETHandler)
+
 	// get account's statuses
 	attachHandler(http.MethodGet, StatusesPath, m.AccountStatusesGETHandler)
 
 	// get following or followers
 	attachHandler(http.MethodGet, FollowersPath, m.AccountFollowersGETHandler)
 	attachHandler(http.MethodGet, FollowingPath, m.AccountFollowingGETHandler)
+	attachHandler(http.MethodGet, FollowersCountPath, m.AccountFollowersCountGETHandler)
+	attachHandler(http.MethodGet, FollowingCountPath, m.AccountFollowingCountGETHandler)
 
 	// get relationship with account
 	attachHandler(http.MethodGet, RelationshipsPath, m.AccountRelationshipsGETHandler)
@@ -94,13 +120,29 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, FollowPath, m.AccountFollowPOSTHandler)
 	attachHandler(http.MethodPost, UnfollowPath, m.AccountUnfollowPOSTHandler)
 
+	// remove account from followers
+	attachHandler(http.MethodPost, RemoveFollowerPath, m.AccountRemoveFollowerPOSTHandler)
+
 	// block or unblock account
 	attachHandler(http.MethodPost, BlockPath, m.AccountBlockPOSTHandler)
 	attachHandler(http.MethodPost, UnblockPath, m.AccountUnblockPOSTHandler)
 
+	// bite account
+	attachHandler(http.MethodPost, BitePath, m.AccountBitePOSTHandler)
+
 	// account lists
 	attachHandler(http.MethodGet, ListsPath, m.AccountListsGETHandler)
 
+	// account featured tags
+	attachHandler(http.MethodGet, FeaturedTagsPath, m.AccountFeaturedTagsGETHandler)
+
+	// account familiar tags
+	attachHandler(http.MethodGet, FamiliarTagsPath, m.AccountFamiliarTagsGETHandler)
+
+	// now playing / scrobbling
+	attachHandler(http.MethodGet, NowPlayingPath, m.AccountNowPlayingGETHandler)
+	attachHandler(http.MethodPost, ScrobblePath, m.AccountScrobblePOSTHandler)
+
 	// search for accounts
 	attachHandler(http.MethodGet, SearchPath, m.AccountSearchGETHandler)
 	attachHandler(http.MethodGet, LookupPath, m.AccountLookupGETHandler)
@@ -108,6 +108,28 @@ import (
 //		description: Default content type to use for authored statuses (text/plain or text/markdown).
 //		type: string
 //	-
+//		name: source[hide_link_previews]
+//		in: formData
+//		description: >-
+//			Don't generate link previews (OpenGraph metadata) of this account's profile
+//			and statuses, and ask search engines not to index them.
+//		type: boolean
+//	-
+//		name: source[mention_policy]
+//		in: formData
+//		description: >-
+//			Which accounts are allowed to mention this account: `anyone`, `followed` (accounts
+//			this account follows), `followers` (followers of this account), or `nobody`.
+//			Mentions from other accounts don't generate notifications. With `followed`
+//			or `nobody`, their statuses are also kept off this account's timelines,
+//			and their replies left out of web views of this account's threads.
+//		type: string
+//		enum:
+//			- anyone
+//			- followed
+//			- followers
+//			- nobody
+//	-
 //		name: custom_css
 //		in: formData
 //		description: >-
@@ -120,6 +142,16 @@ import (
 //		description: Enable RSS feed for this account's Public posts at `/[username]/feed.rss`
 //		type: boolean
 //	-
+//		name: attribution_domains[]
+//		in: formData
+//		description: >-
+//			Domains of websites allowed to credit this account as the author of their pages,
+//			using the fediverse:creator meta tag. Pages on the account's own domain are always allowed.
+//			Submit an empty value to clear the list. Maximum 10 domains.
+//		type: array
+//		items:
+//			type: string
+//	-
 //		name: fields_attributes
 //		in: formData
 //		description: Profile fields to be added to this account's profile
@@ -253,9 +285,12 @@ func parseUpdateAccountForm(c *gin.Context) (*apimodel.UpdateCredentialsRequest,
 			form.Source.Sensitive == nil &&
 			form.Source.Language == nil &&
 			form.Source.StatusContentType == nil &&
+			form.Source.HideLinkPreviews == nil &&
+			form.Source.MentionPolicy == nil &&
 			form.FieldsAttributes == nil &&
 			form.CustomCSS == nil &&
-			form.EnableRSS == nil) {
+			form.EnableRSS == nil &&
+			form.AttributionDomains == nil) {
 		return nil, errors.New("empty form submitted")
 	}
 
@@ -384,7 +384,7 @@ func (suite *AccountUpdateTestSuite) TestUpdateAccountWithImageFormData() {
 func (suite *AccountUpdateTestSuite) TestUpdateAccountEmptyForm() {
 	data := make(map[string]string)
 
-	_, err := suite.updateAccountFromForm(data, http.StatusBadRequest, `{"error":"Bad Request: empty form submitted"}`)
+	_, err := suite.updateAccountFromForm(data, http.StatusBadRequest, `{"error":"Bad Request: empty form submitted","error_code":"ERR_BAD_REQUEST"}`)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
@@ -393,7 +393,7 @@ func (suite *AccountUpdateTestSuite) TestUpdateAccountEmptyForm() {
 func (suite *AccountUpdateTestSuite) TestUpdateAccountEmptyFormData() {
 	data := make(map[string]string)
 
-	_, err := suite.updateAccountFromFormData(data, http.StatusBadRequest, `{"error":"Bad Request: empty form submitted"}`)
+	_, err := suite.updateAccountFromFormData(data, http.StatusBadRequest, `{"error":"Bad Request: empty form submitted","error_code":"ERR_BAD_REQUEST"}`)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
@@ -485,7 +485,7 @@ func (suite *AccountUpdateTestSuite) TestAccountUpdateCredentialsPATCHHandlerUpd
 		"source[status_content_type]": "peepeepoopoo",
 	}
 
-	_, err := suite.updateAccountFromFormData(data, http.StatusBadRequest, `{"error":"Bad Request: status content type 'peepeepoopoo' was not recognized, valid options are 'text/plain', 'text/markdown'"}`)
+	_, err := suite.updateAccountFromFormData(data, http.StatusBadRequest, `{"error":"Bad Request: status content type 'peepeepoopoo' was not recognized, valid options are 'text/plain', 'text/markdown'","error_code":"ERR_BAD_REQUEST"}`)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
@@ -0,0 +1,168 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// followCountMaxAge is how long clients
+// may cache follower/following counts for.
+const followCountMaxAge = "60"
+
+// AccountFollowersCountGETHandler swagger:operation GET /api/v1/accounts/{id}/followers_count accountFollowersCount
+//
+// Get the number of accounts following the account with the given ID.
+//
+// This is a lightweight alternative to fetching the whole account, for clients which only need the count.
+// Authentication is not required, unless the account is locked.
+//
+//	---
+//	tags:
+//	- accounts
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: Account ID.
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			description: The number of followers.
+//			schema:
+//				"$ref": "#/definitions/accountCount"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden; the account hides its followers
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) AccountFollowersCountGETHandler(c *gin.Context) {
+	m.followCountGETHandler(c, m.processor.Account().FollowersCountGet)
+}
+
+// AccountFollowingCountGETHandler swagger:operation GET /api/v1/accounts/{id}/following_count accountFollowingCount
+//
+// Get the number of accounts that the account with the given ID is following.
+//
+// This is a lightweight alternative to fetching the whole account, for clients which only need the count.
+// Authentication is not required, unless the account is locked.
+//
+//	---
+//	tags:
+//	- accounts
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: Account ID.
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			description: The number of accounts followed.
+//			schema:
+//				"$ref": "#/definitions/accountCount"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden; the account hides who it follows
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) AccountFollowingCountGETHandler(c *gin.Context) {
+	m.followCountGETHandler(c, m.processor.Account().FollowingCountGet)
+}
+
+func (m *Module) followCountGETHandler(
+	c *gin.Context,
+	countGet func(context.Context, *gtsmodel.Account, string) (*apimodel.AccountCount, gtserror.WithCode),
+) {
+	authed, err := oauth.Authed(c, false, false, false, false)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	targetAcctID := c.Param(IDKey)
+	if targetAcctID == "" {
+		err := errors.New("no account id specified")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	count, errWithCode := countGet(c.Request.Context(), authed.Account, targetAcctID)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Responses to authenticated requests may
+	// depend on blocks, so mustn't be shared.
+	if authed.Account != nil {
+		c.Header("Cache-Control", "private,max-age="+followCountMaxAge)
+	} else {
+		c.Header("Cache-Control", "public,max-age="+followCountMaxAge)
+	}
+
+	c.JSON(http.StatusOK, count)
+}
@@ -0,0 +1,159 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package accounts_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type FollowCountTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// getCount performs a GET to the given account path for the
+// target account, authenticated as requestingAccount if not nil.
+func (suite *FollowCountTestSuite) getCount(
+	requestingAccount string,
+	path string,
+	targetAccountID string,
+	handler func(*gin.Context),
+) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	if requestingAccount != "" {
+		ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[requestingAccount])
+		ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[requestingAccount]))
+		ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+		ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[requestingAccount])
+	}
+
+	requestPath := strings.Replace(path, ":"+accounts.IDKey, targetAccountID, 1)
+	ctx.Request = httptest.NewRequest(http.MethodGet, config.GetProtocol()+"://"+config.GetHost()+"/api"+requestPath, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.AddParam(accounts.IDKey, targetAccountID)
+
+	handler(ctx)
+	return recorder
+}
+
+func (suite *FollowCountTestSuite) decodeCount(recorder *httptest.ResponseRecorder) int {
+	count := &apimodel.AccountCount{}
+	if err := json.NewDecoder(recorder.Body).Decode(count); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return count.Count
+}
+
+func (suite *FollowCountTestSuite) TestFollowersCount() {
+	targetAccount := suite.testAccounts["admin_account"]
+
+	expected, err := suite.db.CountAccountFollowers(context.Background(), targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	recorder := suite.getCount("local_account_1", accounts.FollowersCountPath, targetAccount.ID, suite.accountsModule.AccountFollowersCountGETHandler)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("private,max-age=60", recorder.Header().Get("Cache-Control"))
+	suite.NotZero(expected)
+	suite.Equal(expected, suite.decodeCount(recorder))
+}
+
+func (suite *FollowCountTestSuite) TestFollowingCountUnauthenticated() {
+	targetAccount := suite.testAccounts["local_account_1"]
+
+	expected, err := suite.db.CountAccountFollows(context.Background(), targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	recorder := suite.getCount("", accounts.FollowingCountPath, targetAccount.ID, suite.accountsModule.AccountFollowingCountGETHandler)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("public,max-age=60", recorder.Header().Get("Cache-Control"))
+	suite.NotZero(expected)
+	suite.Equal(expected, suite.decodeCount(recorder))
+}
+
+func (suite *FollowCountTestSuite) TestFollowersCountLockedUnauthenticated() {
+	targetAccount := suite.testAccounts["local_account_2"]
+
+	recorder := suite.getCount("", accounts.FollowersCountPath, targetAccount.ID, suite.accountsModule.AccountFollowersCountGETHandler)
+	suite.Equal(http.StatusUnauthorized, recorder.Code)
+}
+
+func (suite *FollowCountTestSuite) TestFollowCountsHideCollections() {
+	targetAccount := &gtsmodel.Account{}
+	*targetAccount = *suite.testAccounts["local_account_1"]
+	targetAccount.HideCollections = testrig.TrueBool()
+	if err := suite.db.UpdateAccount(context.Background(), targetAccount, "hide_collections"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	recorder := suite.getCount("admin_account", accounts.FollowersCountPath, targetAccount.ID, suite.accountsModule.AccountFollowersCountGETHandler)
+	suite.Equal(http.StatusForbidden, recorder.Code)
+
+	recorder = suite.getCount("admin_account", accounts.FollowingCountPath, targetAccount.ID, suite.accountsModule.AccountFollowingCountGETHandler)
+	suite.Equal(http.StatusForbidden, recorder.Code)
+
+	// The account can still see its own counts.
+	recorder = suite.getCount("local_account_1", accounts.FollowingCountPath, targetAccount.ID, suite.accountsModule.AccountFollowingCountGETHandler)
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+// TestFollowersCountBenchmark compares the followers
+// count endpoint against fetching the full account.
+func (suite *FollowCountTestSuite) TestFollowersCountBenchmark() {
+	if testing.Short() {
+		suite.T().Skip("skipping benchmark in short mode")
+	}
+
+	targetAccount := suite.testAccounts["admin_account"]
+
+	countResult := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			suite.getCount("local_account_1", accounts.FollowersCountPath, targetAccount.ID, suite.accountsModule.AccountFollowersCountGETHandler)
+		}
+	})
+
+	accountResult := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			suite.getCount("local_account_1", accounts.BasePathWithID, targetAccount.ID, suite.accountsModule.AccountGETHandler)
+		}
+	})
+
+	suite.T().Logf("followers_count: %s", countResult)
+	suite.T().Logf("account:         %s", accountResult)
+}
+
+func TestFollowCountTestSuite(t *testing.T) {
+	suite.Run(t, new(FollowCountTestSuite))
+}
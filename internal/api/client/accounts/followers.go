@@ -31,6 +31,11 @@ import (
 //
 // See followers of account with given id.
 //
+// Accounts that are blocking, or blocked by, the requesting account are not included.
+//
+// If the account has chosen to hide its collections, this will return 403 Forbidden,
+// unless the requesting account is the account itself.
+//
 //	---
 //	tags:
 //	- accounts
@@ -62,6 +67,8 @@ import (
 //			description: bad request
 //		'401':
 //			description: unauthorized
+//		'403':
+//			description: forbidden, the account has chosen to hide its collections
 //		'404':
 //			description: not found
 //		'406':
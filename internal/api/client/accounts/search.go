@@ -81,6 +81,7 @@ import (
 //		description: >-
 //			Show only accounts that the requesting account follows. If this is set to `true`, then the GoToSocial instance
 //			will enhance the search by also searching within account notes, not just in usernames and display names.
+//			Accounts are never resolved from remote instances when this is `true`, regardless of `resolve`.
 //		default: false
 //		in: query
 //
@@ -18,6 +18,7 @@
 package accounts_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -32,6 +33,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
@@ -425,6 +427,120 @@ func (suite *AccountSearchTestSuite) TestSearchANotFollowing() {
 	suite.EqualValues([]string{"1happyturtle", "admin"}, usernames)
 }
 
+func (suite *AccountSearchTestSuite) TestSearchFossSatanExactFollowing() {
+	var (
+		requestingAccount        = suite.testAccounts["local_account_1"]
+		token                    = suite.testTokens["local_account_1"]
+		user                     = suite.testUsers["local_account_1"]
+		limit              *int  = nil
+		offset             *int  = nil
+		resolve            *bool = nil
+		query                    = "@foss_satan@fossbros-anonymous.io"
+		following          *bool = func() *bool { i := true; return &i }()
+		expectedHTTPStatus       = http.StatusOK
+		expectedBody             = ""
+	)
+
+	accounts, err := suite.getSearch(
+		requestingAccount,
+		token,
+		user,
+		limit,
+		offset,
+		query,
+		resolve,
+		following,
+		expectedHTTPStatus,
+		expectedBody,
+	)
+
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if l := len(accounts); l != 0 {
+		suite.FailNow("", "expected length %d got %d", 0, l)
+	}
+}
+
+func (suite *AccountSearchTestSuite) TestSearchTurtleExactFollowing() {
+	var (
+		requestingAccount        = suite.testAccounts["local_account_1"]
+		token                    = suite.testTokens["local_account_1"]
+		user                     = suite.testUsers["local_account_1"]
+		limit              *int  = nil
+		offset             *int  = nil
+		resolve            *bool = nil
+		query                    = "@1happyturtle@localhost:8080"
+		following          *bool = func() *bool { i := true; return &i }()
+		expectedHTTPStatus       = http.StatusOK
+		expectedBody             = ""
+	)
+
+	accounts, err := suite.getSearch(
+		requestingAccount,
+		token,
+		user,
+		limit,
+		offset,
+		query,
+		resolve,
+		following,
+		expectedHTTPStatus,
+		expectedBody,
+	)
+
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if l := len(accounts); l != 1 {
+		suite.FailNow("", "expected length %d got %d", 1, l)
+	}
+
+	suite.Equal("1happyturtle", accounts[0].Username)
+}
+
+func (suite *AccountSearchTestSuite) TestSearchResolveFollowing() {
+	var (
+		requestingAccount        = suite.testAccounts["local_account_1"]
+		token                    = suite.testTokens["local_account_1"]
+		user                     = suite.testUsers["local_account_1"]
+		limit              *int  = nil
+		offset             *int  = nil
+		resolve            *bool = func() *bool { i := true; return &i }()
+		query                    = "@brand_new_person@unknown-instance.com"
+		following          *bool = func() *bool { i := true; return &i }()
+		expectedHTTPStatus       = http.StatusOK
+		expectedBody             = ""
+	)
+
+	accounts, err := suite.getSearch(
+		requestingAccount,
+		token,
+		user,
+		limit,
+		offset,
+		query,
+		resolve,
+		following,
+		expectedHTTPStatus,
+		expectedBody,
+	)
+
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if l := len(accounts); l != 0 {
+		suite.FailNow("", "expected length %d got %d", 0, l)
+	}
+
+	// Account should not have been resolved.
+	_, err = suite.db.GetAccountByUsernameDomain(context.Background(), "brand_new_person", "unknown-instance.com")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func TestAccountSearchTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountSearchTestSuite))
 }
@@ -0,0 +1,100 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package accounts_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type AccountStatisticsTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountStatisticsTestSuite) TestAccountStatisticsGet() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, accounts.StatisticsPath, "")
+	suite.accountsModule.AccountStatisticsGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	statistics := &apimodel.AccountStatistics{}
+	if err := json.NewDecoder(recorder.Body).Decode(statistics); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(5, statistics.StatusesCount)
+	suite.Equal(1, statistics.FavouritesReceived)
+	suite.Equal(1, statistics.ReblogsReceived)
+	suite.Equal(0, statistics.FollowersGainedWeek)
+	suite.NotNil(statistics.TopHashtags)
+	suite.Empty(statistics.TopHashtags)
+}
+
+func (suite *AccountStatisticsTestSuite) TestAccountStatisticsGetTopHashtags() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, accounts.StatisticsPath, "")
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["admin_account"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["admin_account"])
+	suite.accountsModule.AccountStatisticsGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	statistics := &apimodel.AccountStatistics{}
+	if err := json.NewDecoder(recorder.Body).Decode(statistics); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal([]apimodel.AccountStatisticsHashtag{{Name: "welcome", Count: 1}}, statistics.TopHashtags)
+}
+
+func (suite *AccountStatisticsTestSuite) TestAccountStatisticsMonthlyGet() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, accounts.StatisticsMonthlyPath, "")
+	suite.accountsModule.AccountStatisticsMonthlyGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	months := []*apimodel.AccountStatisticsMonth{}
+	if err := json.NewDecoder(recorder.Body).Decode(&months); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	now := time.Now().UTC()
+	suite.Len(months, 12)
+	suite.Equal(now.Format("2006-01"), months[11].Month)
+	suite.Equal(time.Date(now.Year(), now.Month()-11, 1, 0, 0, 0, 0, time.UTC).Format("2006-01"), months[0].Month)
+
+	// None of the test statuses, faves
+	// or follows are from the past year.
+	for _, month := range months {
+		suite.Zero(month.StatusesCount)
+		suite.Zero(month.FavouritesReceived)
+		suite.Zero(month.ReblogsReceived)
+		suite.Zero(month.FollowersGained)
+	}
+}
+
+func TestAccountStatisticsTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountStatisticsTestSuite))
+}
@@ -71,11 +71,22 @@ import (
 //		in: query
 //		required: false
 //	-
+//		name: exclude_original_statuses
+//		type: boolean
+//		description: >-
+//			Exclude statuses that are not a reblog/boost of another status,
+//			ie., show only boosts. Equivalent to only_boosts.
+//		default: false
+//		in: query
+//		required: false
+//	-
 //		name: max_id
 //		type: string
 //		description: >-
 //			Return only statuses *OLDER* than the given max status ID.
 //			The status with the specified ID will not be included in the response.
+//			Can be set to `latest` (or `now`) to page from the newest status,
+//			which is the same as not setting max_id.
 //		in: query
 //	-
 //		name: min_id
@@ -83,6 +94,7 @@ import (
 //		description: >-
 //			Return only statuses *NEWER* than the given min status ID.
 //			The status with the specified ID will not be included in the response.
+//			Can be set to `earliest` to page from the oldest status.
 //		in: query
 //		required: false
 //	-
@@ -93,6 +105,13 @@ import (
 //		in: query
 //		required: false
 //	-
+//		name: only_pinned
+//		type: boolean
+//		description: Show only pinned statuses. Equivalent to pinned_only.
+//		default: false
+//		in: query
+//		required: false
+//	-
 //		name: only_media
 //		type: boolean
 //		description: Show only statuses with media attachments.
@@ -100,12 +119,29 @@ import (
 //		in: query
 //		required: false
 //	-
+//		name: only_polls
+//		type: boolean
+//		description: >-
+//			Show only statuses with a poll.
+//		default: false
+//		in: query
+//		required: false
+//	-
 //		name: only_public
 //		type: boolean
 //		description: Show only statuses with a privacy setting of 'public'.
 //		default: false
 //		in: query
 //		required: false
+//	-
+//		name: only_boosts
+//		type: boolean
+//		description: >-
+//			Show only statuses that are a reblog/boost of another status.
+//			The boosted status is included as the reblog of each returned status.
+//		default: false
+//		in: query
+//		required: false
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -184,18 +220,32 @@ func (m *Module) AccountStatusesGETHandler(c *gin.Context) {
 		excludeReblogs = i
 	}
 
-	maxID := ""
-	maxIDString := c.Query(MaxIDKey)
-	if maxIDString != "" {
-		maxID = maxIDString
+	boostsOnly := false
+	excludeOriginalString := c.Query(ExcludeOriginalStatusesKey)
+	if excludeOriginalString != "" {
+		i, err := strconv.ParseBool(excludeOriginalString)
+		if err != nil {
+			err := fmt.Errorf("error parsing %s: %s", ExcludeOriginalStatusesKey, err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+		boostsOnly = i
 	}
 
-	minID := ""
-	minIDString := c.Query(MinIDKey)
-	if minIDString != "" {
-		minID = minIDString
+	onlyBoostsString := c.Query(OnlyBoostsKey)
+	if onlyBoostsString != "" {
+		i, err := strconv.ParseBool(onlyBoostsString)
+		if err != nil {
+			err := fmt.Errorf("error parsing %s: %s", OnlyBoostsKey, err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+		boostsOnly = boostsOnly || i
 	}
 
+	maxID := apiutil.ParseMaxID(c.Query(MaxIDKey), "")
+	minID := apiutil.ParseMinID(c.Query(MinIDKey), "")
+
 	pinnedOnly := false
 	pinnedString := c.Query(PinnedKey)
 	if pinnedString != "" {
@@ -208,6 +258,17 @@ func (m *Module) AccountStatusesGETHandler(c *gin.Context) {
 		pinnedOnly = i
 	}
 
+	onlyPinnedString := c.Query(OnlyPinnedKey)
+	if onlyPinnedString != "" {
+		i, err := strconv.ParseBool(onlyPinnedString)
+		if err != nil {
+			err := fmt.Errorf("error parsing %s: %s", OnlyPinnedKey, err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+		pinnedOnly = pinnedOnly || i
+	}
+
 	mediaOnly := false
 	mediaOnlyString := c.Query(OnlyMediaKey)
 	if mediaOnlyString != "" {
@@ -220,6 +281,18 @@ func (m *Module) AccountStatusesGETHandler(c *gin.Context) {
 		mediaOnly = i
 	}
 
+	pollsOnly := false
+	pollsOnlyString := c.Query(OnlyPollsKey)
+	if pollsOnlyString != "" {
+		i, err := strconv.ParseBool(pollsOnlyString)
+		if err != nil {
+			err := fmt.Errorf("error parsing %s: %s", OnlyPollsKey, err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+		pollsOnly = i
+	}
+
 	publicOnly := false
 	publicOnlyString := c.Query(OnlyPublicKey)
 	if publicOnlyString != "" {
@@ -232,7 +305,7 @@ func (m *Module) AccountStatusesGETHandler(c *gin.Context) {
 		publicOnly = i
 	}
 
-	resp, errWithCode := m.processor.Account().StatusesGet(c.Request.Context(), authed.Account, targetAcctID, limit, excludeReplies, excludeReblogs, maxID, minID, pinnedOnly, mediaOnly, publicOnly)
+	resp, errWithCode := m.processor.Account().StatusesGet(c.Request.Context(), authed.Account, targetAcctID, limit, excludeReplies, excludeReblogs, maxID, minID, pinnedOnly, mediaOnly, pollsOnly, publicOnly, boostsOnly)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
@@ -18,6 +18,7 @@
 package accounts_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -29,6 +30,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
@@ -117,6 +119,52 @@ func (suite *AccountStatusesTestSuite) TestGetStatusesPublicOnlyMediaOnly() {
 	suite.Equal(`<http://localhost:8080/api/v1/accounts/01F8MH17FWEB39HZJ76B6VXSKF/statuses?limit=20&max_id=01F8MH75CBF9JFX4ZAD54N0W0R&exclude_replies=false&exclude_reblogs=false&pinned=false&only_media=true&only_public=true>; rel="next", <http://localhost:8080/api/v1/accounts/01F8MH17FWEB39HZJ76B6VXSKF/statuses?limit=20&min_id=01F8MH75CBF9JFX4ZAD54N0W0R&exclude_replies=false&exclude_reblogs=false&pinned=false&only_media=true&only_public=true>; rel="prev"`, result.Header.Get("link"))
 }
 
+func (suite *AccountStatusesTestSuite) TestGetStatusesBoostsOnly() {
+	// admin has a mix of original statuses and boosts;
+	// both params should return only the boosts
+	targetAccount := suite.testAccounts["admin_account"]
+	for _, param := range []string{"exclude_original_statuses", "only_boosts"} {
+		recorder := httptest.NewRecorder()
+		ctx := suite.newContext(recorder, http.MethodGet, nil, fmt.Sprintf("/api/v1/accounts/%s/statuses?limit=20&%s=true", targetAccount.ID, param), "")
+		ctx.Params = gin.Params{
+			gin.Param{
+				Key:   accounts.IDKey,
+				Value: targetAccount.ID,
+			},
+		}
+
+		// call the handler
+		suite.accountsModule.AccountStatusesGETHandler(ctx)
+
+		// 1. we should have OK because our request was valid
+		suite.Equal(http.StatusOK, recorder.Code)
+
+		// 2. we should have no error message in the result body
+		result := recorder.Result()
+		defer result.Body.Close()
+
+		// check the response
+		b, err := ioutil.ReadAll(result.Body)
+		suite.NoError(err)
+
+		// unmarshal the returned statuses
+		apimodelStatuses := []*apimodel.Status{}
+		err = json.Unmarshal(b, &apimodelStatuses)
+		suite.NoError(err)
+		suite.Len(apimodelStatuses, 1)
+
+		// the boosted status should be included in full
+		boost := apimodelStatuses[0]
+		suite.Equal(suite.testStatuses["admin_account_status_4"].ID, boost.ID)
+		suite.NotNil(boost.Reblog)
+		suite.Equal(suite.testStatuses["admin_account_status_4"].BoostOfID, boost.Reblog.ID)
+		suite.NotEmpty(boost.Reblog.Content)
+		suite.NotNil(boost.Reblog.Account)
+
+		suite.Contains(result.Header.Get("link"), "only_boosts=true")
+	}
+}
+
 func (suite *AccountStatusesTestSuite) TestGetStatusesPinnedOnlyPublicPins() {
 	// admin has a couple statuses pinned
 	// we're getting pinned statuses of admin, as local account 1
@@ -286,6 +334,142 @@ func (suite *AccountStatusesTestSuite) TestGetStatusesPinnedOnlyGetOwn() {
 	}
 }
 
+// getStatuses gets the statuses of targetAccount with the
+// given query, as local account 1, checking for a 200 OK.
+func (suite *AccountStatusesTestSuite) getStatuses(targetAccount *gtsmodel.Account, query string) ([]*apimodel.Status, string) {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, fmt.Sprintf("/api/v1/accounts/%s/statuses?%s", targetAccount.ID, query), "")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   accounts.IDKey,
+			Value: targetAccount.ID,
+		},
+	}
+
+	// call the handler
+	suite.accountsModule.AccountStatusesGETHandler(ctx)
+
+	// we should have OK because our request was valid
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	// check the response
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	// unmarshal the returned statuses
+	apimodelStatuses := []*apimodel.Status{}
+	err = json.Unmarshal(b, &apimodelStatuses)
+	suite.NoError(err)
+
+	return apimodelStatuses, result.Header.Get("link")
+}
+
+func (suite *AccountStatusesTestSuite) TestGetStatusesOnlyPinned() {
+	// admin has a couple statuses pinned
+	apimodelStatuses, link := suite.getStatuses(suite.testAccounts["admin_account"], "only_pinned=true")
+	suite.Len(apimodelStatuses, 2)
+	suite.Empty(link)
+}
+
+func (suite *AccountStatusesTestSuite) TestGetStatusesOnlyPinnedExcludeRepliesReblogs() {
+	// admin's pinned statuses are neither replies nor reblogs
+	apimodelStatuses, link := suite.getStatuses(suite.testAccounts["admin_account"], "only_pinned=true&exclude_replies=true&exclude_reblogs=true")
+	suite.Len(apimodelStatuses, 2)
+	suite.Empty(link)
+
+	for _, s := range apimodelStatuses {
+		suite.Nil(s.InReplyToID)
+		suite.Nil(s.Reblog)
+	}
+}
+
+// attachPoll attaches a new poll to the given status.
+func (suite *AccountStatusesTestSuite) attachPoll(status *gtsmodel.Status) {
+	ctx := context.Background()
+
+	poll := &gtsmodel.Poll{
+		ID:       "01H7Y6R0D4W2M8XQ5T3K9B7NZE",
+		StatusID: status.ID,
+		Options:  []string{"yes", "no"},
+		Votes:    []int{0, 0},
+	}
+	if err := suite.db.PutPoll(ctx, poll); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	pollStatus := new(gtsmodel.Status)
+	*pollStatus = *status
+	pollStatus.PollID = poll.ID
+	if err := suite.db.UpdateStatus(ctx, pollStatus, "poll_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *AccountStatusesTestSuite) TestGetStatusesOnlyPolls() {
+	// admin has no polls yet
+	apimodelStatuses, link := suite.getStatuses(suite.testAccounts["admin_account"], "only_polls=true")
+	suite.Empty(apimodelStatuses)
+	suite.Empty(link)
+
+	suite.attachPoll(suite.testStatuses["admin_account_status_1"])
+
+	apimodelStatuses, link = suite.getStatuses(suite.testAccounts["admin_account"], "only_polls=true")
+	if suite.Len(apimodelStatuses, 1) {
+		suite.Equal(suite.testStatuses["admin_account_status_1"].ID, apimodelStatuses[0].ID)
+	}
+	suite.NotEmpty(link)
+}
+
+func (suite *AccountStatusesTestSuite) TestGetStatusesOnlyPollsExcludeRepliesReblogs() {
+	suite.attachPoll(suite.testStatuses["admin_account_status_1"])
+
+	apimodelStatuses, _ := suite.getStatuses(suite.testAccounts["admin_account"], "only_polls=true&exclude_replies=true&exclude_reblogs=true")
+	if suite.Len(apimodelStatuses, 1) {
+		suite.Equal(suite.testStatuses["admin_account_status_1"].ID, apimodelStatuses[0].ID)
+	}
+}
+
+func (suite *AccountStatusesTestSuite) TestGetStatusesOnlyPollsOnlyPinned() {
+	// admin_account_status_1 is pinned
+	suite.attachPoll(suite.testStatuses["admin_account_status_1"])
+
+	apimodelStatuses, link := suite.getStatuses(suite.testAccounts["admin_account"], "only_polls=true&only_pinned=true")
+	if suite.Len(apimodelStatuses, 1) {
+		suite.Equal(suite.testStatuses["admin_account_status_1"].ID, apimodelStatuses[0].ID)
+	}
+	suite.Empty(link)
+}
+
+func (suite *AccountStatusesTestSuite) TestGetStatusesMaxIDLatest() {
+	targetAccount := suite.testAccounts["admin_account"]
+	expectedStatuses, expectedLink := suite.getStatuses(targetAccount, "limit=20")
+	suite.NotEmpty(expectedStatuses)
+
+	// latest and now should both be
+	// the same as not setting max_id.
+	for _, maxID := range []string{"latest", "now"} {
+		apimodelStatuses, link := suite.getStatuses(targetAccount, "limit=20&max_id="+maxID)
+		suite.Equal(expectedStatuses, apimodelStatuses)
+		suite.Equal(expectedLink, link)
+	}
+}
+
+func (suite *AccountStatusesTestSuite) TestGetStatusesMinIDEarliest() {
+	// admin has fewer than 20 statuses, so
+	// paging up from the earliest status
+	// gets the same page as not setting min_id.
+	targetAccount := suite.testAccounts["admin_account"]
+	expectedStatuses, expectedLink := suite.getStatuses(targetAccount, "limit=20")
+	suite.NotEmpty(expectedStatuses)
+
+	apimodelStatuses, link := suite.getStatuses(targetAccount, "limit=20&min_id=earliest")
+	suite.Equal(expectedStatuses, apimodelStatuses)
+	suite.Equal(expectedLink, link)
+}
+
 func TestAccountStatusesTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountStatusesTestSuite))
 }
@@ -53,7 +53,7 @@ import (
 //	-
 //		name: type
 //		in: formData
-//		description: Type of action to be taken, currently only supports `suspend`.
+//		description: Type of action to be taken, currently only supports `suspend`, `unsuspend` and `unsilence`.
 //		type: string
 //		required: true
 //	-
@@ -61,6 +61,12 @@ import (
 //		in: formData
 //		description: Optional text describing why this action was taken.
 //		type: string
+//	-
+//		name: send_email
+//		in: formData
+//		description: Email the user of the (local) target account to explain the suspension, and how to appeal it.
+//		type: boolean
+//		default: true
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AccountGetTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AccountGetTestSuite) getAccount(requester string, targetAccountID string) (int, []byte) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[requester])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[requester]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[requester])
+
+	requestURI := config.GetProtocol() + "://" + config.GetHost() + "/api/" + admin.AccountsPath + "/" + targetAccountID
+	ctx.Request = httptest.NewRequest(http.MethodGet, requestURI, nil)
+	ctx.AddParam(admin.IDKey, targetAccountID)
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.adminModule.AccountGETHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := ioutil.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder.Code, b
+}
+
+func (suite *AccountGetTestSuite) TestGetLocalAccount() {
+	target := suite.testAccounts["local_account_1"]
+
+	code, b := suite.getAccount("admin_account", target.ID)
+	suite.Equal(http.StatusOK, code)
+
+	account := &apimodel.AdminAccountInfo{}
+	if err := json.Unmarshal(b, account); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(target.ID, account.ID)
+	suite.Equal(target.Username, account.Username)
+	suite.Nil(account.Domain)
+	suite.Equal(suite.testUsers["local_account_1"].Email, account.Email)
+	suite.Equal(apimodel.AccountRoleUser, account.Role.Name)
+	suite.NotNil(account.Account)
+}
+
+func (suite *AccountGetTestSuite) TestGetRemoteAccount() {
+	target := suite.testAccounts["remote_account_1"]
+
+	code, b := suite.getAccount("admin_account", target.ID)
+	suite.Equal(http.StatusOK, code)
+
+	account := &apimodel.AdminAccountInfo{}
+	if err := json.Unmarshal(b, account); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(target.ID, account.ID)
+	suite.Equal(target.Domain, *account.Domain)
+	suite.Empty(account.Email)
+}
+
+func (suite *AccountGetTestSuite) TestGetUnknownAccount() {
+	code, b := suite.getAccount("admin_account", "01H9ZFMRTGZ0WZQ8VKGD1KYXTP")
+	suite.Equal(http.StatusNotFound, code)
+	suite.Contains(string(b), "account 01H9ZFMRTGZ0WZQ8VKGD1KYXTP not found")
+}
+
+func (suite *AccountGetTestSuite) TestGetNotAdmin() {
+	code, _ := suite.getAccount("local_account_1", suite.testAccounts["local_account_2"].ID)
+	suite.Equal(http.StatusForbidden, code)
+}
+
+func TestAccountGetTestSuite(t *testing.T) {
+	suite.Run(t, &AccountGetTestSuite{})
+}
@@ -0,0 +1,221 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AccountsGETHandler swagger:operation GET /api/v1/admin/accounts adminAccounts
+//
+// View accounts known to this instance, both local and remote.
+//
+// The accounts will be returned in descending chronological order (newest first), with sequential IDs (bigger = newer).
+//
+// The next and previous queries can be parsed from the returned Link header.
+//
+// Example:
+//
+// ```
+// <https://example.org/api/v1/admin/accounts?limit=20&max_id=01FC0SKA48HNSVR6YKZCQGS2V8&local=true>; rel="next", <https://example.org/api/v1/admin/accounts?limit=20&min_id=01FC0SKW5JK2Q4EVAV2B462YY0&local=true>; rel="prev"
+// ````
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: local
+//		type: boolean
+//		description: If set to true, only local accounts will be returned.
+//		in: query
+//	-
+//		name: remote
+//		type: boolean
+//		description: If set to true, only remote accounts will be returned.
+//		in: query
+//	-
+//		name: by_domain
+//		type: string
+//		description: Return only accounts from the given domain.
+//		in: query
+//	-
+//		name: suspended
+//		type: boolean
+//		description: >-
+//			If set to true, only suspended accounts will be returned.
+//			If false, only accounts which are not suspended will be returned.
+//			If unset, accounts will not be filtered on their suspended status.
+//		in: query
+//	-
+//		name: max_id
+//		type: string
+//		description: >-
+//			Return only accounts *OLDER* than the given max ID.
+//			The account with the specified ID will not be included in the response.
+//		in: query
+//	-
+//		name: since_id
+//		type: string
+//		description: >-
+//			Return only accounts *NEWER* than the given since ID.
+//			The account with the specified ID will not be included in the response.
+//		in: query
+//	-
+//		name: min_id
+//		type: string
+//		description: >-
+//			Return only accounts immediately *NEWER* than the given min ID.
+//			The account with the specified ID will not be included in the response.
+//		in: query
+//	-
+//		name: limit
+//		type: integer
+//		description: >-
+//			Number of accounts to return.
+//			If more than 100 or less than 1, will be clamped to 100.
+//		default: 20
+//		in: query
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			name: accounts
+//			description: Array of accounts.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/adminAccountInfo"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) AccountsGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	localOnly, errWithCode := parseBoolQuery(c, LocalKey)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	remoteOnly, errWithCode := parseBoolQuery(c, RemoteKey)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	var local *bool
+	switch {
+	case localOnly != nil && *localOnly && remoteOnly != nil && *remoteOnly:
+		err := errors.New("local and remote cannot both be set to true")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	case localOnly != nil && *localOnly:
+		local = localOnly
+	case remoteOnly != nil && *remoteOnly:
+		l := false
+		local = &l
+	}
+
+	suspended, errWithCode := parseBoolQuery(c, SuspendedKey)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	limit := 20
+	if limitString := c.Query(LimitKey); limitString != "" {
+		i, err := strconv.Atoi(limitString)
+		if err != nil {
+			err := fmt.Errorf("error parsing %s: %s", LimitKey, err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+
+		// normalize
+		if i < 1 || i > 100 {
+			i = 100
+		}
+		limit = i
+	}
+
+	resp, errWithCode := m.processor.Admin().AccountsGet(c.Request.Context(), local, c.Query(ByDomainKey), suspended, c.Query(MaxIDKey), c.Query(SinceIDKey), c.Query(MinIDKey), limit)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if resp.LinkHeader != "" {
+		c.Header("Link", resp.LinkHeader)
+	}
+	c.JSON(http.StatusOK, resp.Items)
+}
+
+// parseBoolQuery parses the boolean query parameter
+// with the given key, returning nil if it was not set.
+func parseBoolQuery(c *gin.Context, key string) (*bool, gtserror.WithCode) {
+	str := c.Query(key)
+	if str == "" {
+		return nil, nil
+	}
+
+	b, err := strconv.ParseBool(str)
+	if err != nil {
+		err := fmt.Errorf("error parsing %s: %s", key, err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return &b, nil
+}
@@ -0,0 +1,190 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+var linkRegex = regexp.MustCompile(`<([^>]+)>; rel="(next|prev)"`)
+
+type AccountsGetTestSuite struct {
+	AdminStandardTestSuite
+}
+
+// getAccounts gets one page of accounts from the given
+// url, returning the accounts, and the next and prev links.
+func (suite *AccountsGetTestSuite) getAccounts(url string, expectedHTTPStatus int) ([]*apimodel.AdminAccountInfo, string, string) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["admin_account"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["admin_account"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["admin_account"])
+	ctx.Request = httptest.NewRequest(http.MethodGet, url, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.adminModule.AccountsGETHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := ioutil.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(expectedHTTPStatus, recorder.Code, string(b))
+	if recorder.Code != http.StatusOK {
+		return nil, "", ""
+	}
+
+	accounts := []*apimodel.AdminAccountInfo{}
+	if err := json.Unmarshal(b, &accounts); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var next, prev string
+	for _, match := range linkRegex.FindAllStringSubmatch(result.Header.Get("Link"), -1) {
+		switch match[2] {
+		case "next":
+			next = match[1]
+		case "prev":
+			prev = match[1]
+		}
+	}
+
+	return accounts, next, prev
+}
+
+func (suite *AccountsGetTestSuite) TestAccountsGetPaged() {
+	const (
+		domain = "paging.example.org"
+		total  = 100
+		limit  = 7
+	)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Put a bunch of accounts from
+	// the same domain in the database.
+	expect := make(map[string]bool, total)
+	for i := 0; i < total; i++ {
+		username := "paging_" + string(rune('a'+i/26)) + string(rune('a'+i%26))
+		account := &gtsmodel.Account{
+			ID:           id.NewULID(),
+			Username:     username,
+			Domain:       domain,
+			URI:          "https://" + domain + "/users/" + username,
+			URL:          "https://" + domain + "/@" + username,
+			ActorType:    ap.ActorPerson,
+			PublicKey:    &key.PublicKey,
+			PublicKeyURI: "https://" + domain + "/users/" + username + "#main-key",
+		}
+
+		if err := suite.db.PutAccount(context.Background(), account); err != nil {
+			suite.FailNow(err.Error())
+		}
+		expect[account.ID] = true
+	}
+
+	// Page down through all of them.
+	var (
+		url   = config.GetProtocol() + "://" + config.GetHost() + "/api" + admin.AccountsPath + "?limit=7&by_domain=" + domain
+		seen  = make(map[string]bool, total)
+		pages [][]*apimodel.AdminAccountInfo
+		prevs []string
+		last  string
+	)
+	for url != "" {
+		accounts, next, prev := suite.getAccounts(url, http.StatusOK)
+		if len(accounts) == 0 {
+			break
+		}
+		suite.LessOrEqual(len(accounts), limit)
+
+		for _, a := range accounts {
+			if suite.NotNil(a.Domain) {
+				suite.Equal(domain, *a.Domain)
+			}
+			suite.False(seen[a.ID], "duplicate account %s", a.ID)
+			seen[a.ID] = true
+
+			// Accounts should be in ID-descending order across pages too.
+			suite.True(last == "" || a.ID < last, "account %s out of order", a.ID)
+			last = a.ID
+		}
+
+		pages = append(pages, accounts)
+		prevs = append(prevs, prev)
+		url = next
+	}
+
+	suite.Equal(expect, seen)
+	suite.Len(pages, (total+limit-1)/limit)
+
+	// Paging up from any page should
+	// yield exactly the page before it.
+	for i := 1; i < len(pages); i++ {
+		accounts, _, _ := suite.getAccounts(prevs[i], http.StatusOK)
+		if suite.Len(accounts, len(pages[i-1])) {
+			for j, a := range accounts {
+				suite.Equal(pages[i-1][j].ID, a.ID)
+			}
+		}
+	}
+}
+
+func (suite *AccountsGetTestSuite) TestAccountsGetLocal() {
+	url := config.GetProtocol() + "://" + config.GetHost() + "/api" + admin.AccountsPath + "?local=true"
+	accounts, _, _ := suite.getAccounts(url, http.StatusOK)
+	suite.NotEmpty(accounts)
+	for _, a := range accounts {
+		suite.Nil(a.Domain)
+	}
+}
+
+func (suite *AccountsGetTestSuite) TestAccountsGetLocalAndRemote() {
+	url := config.GetProtocol() + "://" + config.GetHost() + "/api" + admin.AccountsPath + "?local=true&remote=true"
+	suite.getAccounts(url, http.StatusBadRequest)
+}
+
+func TestAccountsGetTestSuite(t *testing.T) {
+	suite.Run(t, &AccountsGetTestSuite{})
+}
@@ -0,0 +1,151 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AccountUnsuspendTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AccountUnsuspendTestSuite) postAction(action string, targetAccountID string, handler func(*gin.Context)) (int, string) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["admin_account"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["admin_account"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["admin_account"])
+
+	requestPath := admin.AccountsPath + "/" + targetAccountID + "/" + action
+	requestURI := config.GetProtocol() + "://" + config.GetHost() + "/api/" + requestPath
+	ctx.Request = httptest.NewRequest(http.MethodPost, requestURI, nil)
+	ctx.AddParam(admin.IDKey, targetAccountID)
+	ctx.Request.Header.Set("accept", "application/json")
+
+	handler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := ioutil.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder.Code, string(b)
+}
+
+func (suite *AccountUnsuspendTestSuite) TestUnsuspend() {
+	var (
+		ctx           = context.Background()
+		filter        = visibility.NewFilter(&suite.state)
+		follower      = suite.testAccounts["local_account_1"]
+		targetAccount = suite.testAccounts["local_account_2"]
+		status        = suite.testStatuses["local_account_2_status_1"]
+	)
+
+	// Suspend the account.
+	targetAccount.SuspendedAt = time.Now()
+	targetAccount.SuspensionOrigin = suite.testAccounts["admin_account"].ID
+	if err := suite.db.UpdateAccount(ctx, targetAccount, "suspended_at", "suspension_origin"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Statuses of the account are no
+	// longer shown to its followers.
+	timelineable, err := filter.StatusHomeTimelineable(ctx, follower, status)
+	suite.NoError(err)
+	suite.False(timelineable)
+
+	code, body := suite.postAction("unsuspend", targetAccount.ID, suite.adminModule.AccountUnsuspendPOSTHandler)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{"message":"OK"}`, body)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(dbAccount.SuspendedAt)
+	suite.Empty(dbAccount.SuspensionOrigin)
+
+	// Statuses of the account are
+	// shown to followers again.
+	timelineable, err = filter.StatusHomeTimelineable(ctx, follower, status)
+	suite.NoError(err)
+	suite.True(timelineable)
+
+	// The unsuspension was recorded.
+	actions := []*gtsmodel.AdminAccountAction{}
+	if err := suite.db.GetAll(ctx, &actions); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(actions, 1)
+	suite.Equal(gtsmodel.AdminActionUnsuspend, actions[0].Type)
+}
+
+func (suite *AccountUnsuspendTestSuite) TestUnsuspendNotSuspended() {
+	targetAccount := suite.testAccounts["local_account_2"]
+
+	code, body := suite.postAction("unsuspend", targetAccount.ID, suite.adminModule.AccountUnsuspendPOSTHandler)
+	suite.Equal(http.StatusUnprocessableEntity, code)
+	suite.Equal(`{"error":"Unprocessable Entity: account `+targetAccount.ID+` is not suspended","error_code":"ERR_UNPROCESSABLE_ENTITY"}`, body)
+}
+
+func (suite *AccountUnsuspendTestSuite) TestUnsilence() {
+	ctx := context.Background()
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	targetAccount.SilencedAt = time.Now()
+	if err := suite.db.UpdateAccount(ctx, targetAccount, "silenced_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	code, body := suite.postAction("unsilence", targetAccount.ID, suite.adminModule.AccountUnsilencePOSTHandler)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{"message":"OK"}`, body)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(dbAccount.SilencedAt)
+
+	// Unsilencing again fails.
+	code, _ = suite.postAction("unsilence", targetAccount.ID, suite.adminModule.AccountUnsilencePOSTHandler)
+	suite.Equal(http.StatusUnprocessableEntity, code)
+}
+
+func TestAccountUnsuspendTestSuite(t *testing.T) {
+	suite.Run(t, &AccountUnsuspendTestSuite{})
+}
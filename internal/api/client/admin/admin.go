@@ -25,25 +25,50 @@ import (
 )
 
 const (
-	BasePath               = "/v1/admin"
-	EmojiPath              = BasePath + "/custom_emojis"
-	EmojiPathWithID        = EmojiPath + "/:" + IDKey
-	EmojiCategoriesPath    = EmojiPath + "/categories"
-	DomainBlocksPath       = BasePath + "/domain_blocks"
-	DomainBlocksPathWithID = DomainBlocksPath + "/:" + IDKey
-	AccountsPath           = BasePath + "/accounts"
-	AccountsPathWithID     = AccountsPath + "/:" + IDKey
-	AccountsActionPath     = AccountsPathWithID + "/action"
-	MediaCleanupPath       = BasePath + "/media_cleanup"
-	MediaRefetchPath       = BasePath + "/media_refetch"
-	ReportsPath            = BasePath + "/reports"
-	ReportsPathWithID      = ReportsPath + "/:" + IDKey
-	ReportsResolvePath     = ReportsPathWithID + "/resolve"
-	EmailPath              = BasePath + "/email"
-	EmailTestPath          = EmailPath + "/test"
+	BasePath                = "/v1/admin"
+	EmojiPath               = BasePath + "/custom_emojis"
+	EmojiPathWithID         = EmojiPath + "/:" + IDKey
+	EmojiCategoriesPath     = EmojiPath + "/categories"
+	EmojiCategoryPathWithID = EmojiCategoriesPath + "/:" + IDKey
+	DomainBlocksPath        = BasePath + "/domain_blocks"
+	DomainBlocksPathWithID  = DomainBlocksPath + "/:" + IDKey
+	DomainBlocksExportPath  = DomainBlocksPath + "/export"
+	DomainBlocksImportPath  = DomainBlocksPath + "/import"
+	AccountsPath            = BasePath + "/accounts"
+	AccountsPathWithID      = AccountsPath + "/:" + IDKey
+	AccountsActionPath      = AccountsPathWithID + "/action"
+	AccountsUnsilencePath   = AccountsPathWithID + "/unsilence"
+	AccountsUnsuspendPath   = AccountsPathWithID + "/unsuspend"
+	AccountsMaxCharsPath    = AccountsPathWithID + "/max_chars"
+	AccountsMediaRolePath   = AccountsPathWithID + "/media_role"
+	MediaCleanupPath        = BasePath + "/media_cleanup"
+	MediaRefetchPath        = BasePath + "/media_refetch"
+	MediaUsagePath          = BasePath + "/media_usage"
+	CohortRetentionPath     = BasePath + "/cohort_retention"
+	ReportsPath             = BasePath + "/reports"
+	ReportsPathWithID       = ReportsPath + "/:" + IDKey
+	ReportsResolvePath      = ReportsPathWithID + "/resolve"
+	EmailPath               = BasePath + "/email"
+	EmailTestPath           = EmailPath + "/test"
+	EmailFailedPath         = EmailPath + "/failed"
+	EmailFailedRetryPath    = EmailFailedPath + "/:" + IDKey + "/retry"
+	ConfigPath              = BasePath + "/config"
+	ConfigReloadPath        = ConfigPath + "/reload"
+	MaintenancePath         = BasePath + "/maintenance"
+	InstanceRulesPath       = BasePath + "/instance/rules"
+	InstanceRulesPathWithID = InstanceRulesPath + "/:" + IDKey
+	HashtagsPath            = BasePath + "/hashtags"
+	HashtagRequireCWPath    = HashtagsPath + "/:" + TagKey + "/require_cw"
+	RegistrationsPath       = BasePath + "/registrations"
+	RegistrationsPathWithID = RegistrationsPath + "/:" + IDKey
+	RegistrationApprovePath = RegistrationsPathWithID + "/approve"
+	RegistrationRejectPath  = RegistrationsPathWithID + "/reject"
 
 	ExportQueryKey        = "export"
 	ImportQueryKey        = "import"
+	FormatQueryKey        = "format"
+	PrivateCommentsKey    = "private_comments"
+	DryRunKey             = "dry_run"
 	IDKey                 = "id"
 	FilterQueryKey        = "filter"
 	MaxShortcodeDomainKey = "max_shortcode_domain"
@@ -56,6 +81,12 @@ const (
 	MaxIDKey              = "max_id"
 	SinceIDKey            = "since_id"
 	MinIDKey              = "min_id"
+	TagKey                = "tag"
+	StatusKey             = "status"
+	LocalKey              = "local"
+	RemoteKey             = "remote"
+	ByDomainKey           = "by_domain"
+	SuspendedKey          = "suspended"
 )
 
 type Module struct {
@@ -76,19 +107,33 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, EmojiPathWithID, m.EmojiGETHandler)
 	attachHandler(http.MethodPatch, EmojiPathWithID, m.EmojiPATCHHandler)
 	attachHandler(http.MethodGet, EmojiCategoriesPath, m.EmojiCategoriesGETHandler)
+	attachHandler(http.MethodPatch, EmojiCategoryPathWithID, m.EmojiCategoryPATCHHandler)
 
 	// domain block stuff
 	attachHandler(http.MethodPost, DomainBlocksPath, m.DomainBlocksPOSTHandler)
 	attachHandler(http.MethodGet, DomainBlocksPath, m.DomainBlocksGETHandler)
+	attachHandler(http.MethodGet, DomainBlocksExportPath, m.DomainBlocksExportGETHandler)
+	attachHandler(http.MethodPost, DomainBlocksImportPath, m.DomainBlocksImportPOSTHandler)
 	attachHandler(http.MethodGet, DomainBlocksPathWithID, m.DomainBlockGETHandler)
+	attachHandler(http.MethodPatch, DomainBlocksPathWithID, m.DomainBlockPATCHHandler)
 	attachHandler(http.MethodDelete, DomainBlocksPathWithID, m.DomainBlockDELETEHandler)
 
 	// accounts stuff
+	attachHandler(http.MethodGet, AccountsPath, m.AccountsGETHandler)
+	attachHandler(http.MethodGet, AccountsPathWithID, m.AccountGETHandler)
 	attachHandler(http.MethodPost, AccountsActionPath, m.AccountActionPOSTHandler)
+	attachHandler(http.MethodPost, AccountsUnsilencePath, m.AccountUnsilencePOSTHandler)
+	attachHandler(http.MethodPost, AccountsUnsuspendPath, m.AccountUnsuspendPOSTHandler)
+	attachHandler(http.MethodPost, AccountsMaxCharsPath, m.AccountMaxCharsPOSTHandler)
+	attachHandler(http.MethodPost, AccountsMediaRolePath, m.AccountMediaRolePOSTHandler)
 
 	// media stuff
 	attachHandler(http.MethodPost, MediaCleanupPath, m.MediaCleanupPOSTHandler)
 	attachHandler(http.MethodPost, MediaRefetchPath, m.MediaRefetchPOSTHandler)
+	attachHandler(http.MethodGet, MediaUsagePath, m.MediaUsageGETHandler)
+
+	// user stats stuff
+	attachHandler(http.MethodGet, CohortRetentionPath, m.CohortRetentionGETHandler)
 
 	// reports stuff
 	attachHandler(http.MethodGet, ReportsPath, m.ReportsGETHandler)
@@ -97,4 +142,27 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 
 	// email stuff
 	attachHandler(http.MethodPost, EmailTestPath, m.EmailTestPOSTHandler)
+	attachHandler(http.MethodGet, EmailFailedPath, m.EmailFailedGETHandler)
+	attachHandler(http.MethodPost, EmailFailedRetryPath, m.EmailFailedRetryPOSTHandler)
+
+	// config stuff
+	attachHandler(http.MethodPost, ConfigReloadPath, m.ConfigReloadPOSTHandler)
+
+	// maintenance stuff
+	attachHandler(http.MethodGet, MaintenancePath, m.MaintenanceGETHandler)
+	attachHandler(http.MethodPost, MaintenancePath, m.MaintenancePOSTHandler)
+
+	// instance rules stuff
+	attachHandler(http.MethodPost, InstanceRulesPath, m.RulePOSTHandler)
+	attachHandler(http.MethodPut, InstanceRulesPathWithID, m.RulePUTHandler)
+	attachHandler(http.MethodDelete, InstanceRulesPathWithID, m.RuleDELETEHandler)
+
+	// hashtags stuff
+	attachHandler(http.MethodGet, HashtagsPath, m.HashtagsGETHandler)
+	attachHandler(http.MethodPost, HashtagRequireCWPath, m.HashtagRequireCWPOSTHandler)
+
+	// registrations stuff
+	attachHandler(http.MethodGet, RegistrationsPath, m.RegistrationsGETHandler)
+	attachHandler(http.MethodPost, RegistrationApprovePath, m.RegistrationApprovePOSTHandler)
+	attachHandler(http.MethodPost, RegistrationRejectPath, m.RegistrationRejectPOSTHandler)
 }
@@ -63,6 +63,7 @@ type AdminStandardTestSuite struct {
 	testEmojis          map[string]*gtsmodel.Emoji
 	testEmojiCategories map[string]*gtsmodel.EmojiCategory
 	testReports         map[string]*gtsmodel.Report
+	testDomainBlocks    map[string]*gtsmodel.DomainBlock
 
 	// module being tested
 	adminModule *admin.Module
@@ -79,6 +80,7 @@ func (suite *AdminStandardTestSuite) SetupSuite() {
 	suite.testEmojis = testrig.NewTestEmojis()
 	suite.testEmojiCategories = testrig.NewTestEmojiCategories()
 	suite.testReports = testrig.NewTestReports()
+	suite.testDomainBlocks = testrig.NewTestDomainBlocks()
 }
 
 func (suite *AdminStandardTestSuite) SetupTest() {
@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// CohortRetentionGETHandler swagger:operation GET /api/v1/admin/cohort_retention cohortRetentionGet
+//
+// View user retention by month of sign-up.
+//
+// Approved users are grouped into cohorts by the month (UTC) in which they signed up,
+// for each of the last 12 months including the current month. For each cohort, the
+// number of users who were active in each following month is given, where a user is
+// active in a month if they posted a status, or signed in, during that month.
+//
+// Only the two most recent sign-ins of each user are stored, so older months may
+// undercount users who signed in without posting.
+//
+// The report is cached for 24 hours.
+//
+// The report can be downloaded as CSV by sending `Accept: text/csv`. The CSV has one
+// row per cohort, with columns `period`, `users`, and one column per month of the report.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//	- text/csv
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: Cohort retention report.
+//			schema:
+//				"$ref": "#/definitions/adminCohortRetention"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) CohortRetentionGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	format, err := apiutil.NegotiateAccept(c, apiutil.AppJSON, apiutil.TextCSV)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if format == string(apiutil.TextCSV) {
+		b, errWithCode := m.processor.Admin().CohortRetentionCSV(c.Request.Context())
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		c.Header("Content-Disposition", `attachment; filename="cohort_retention.csv"`)
+		c.Data(http.StatusOK, format, b)
+		return
+	}
+
+	resp, errWithCode := m.processor.Admin().CohortRetention(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
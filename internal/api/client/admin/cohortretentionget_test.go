@@ -0,0 +1,192 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type CohortRetentionGetTestSuite struct {
+	AdminStandardTestSuite
+}
+
+// seedActivity moves some test users and statuses into
+// the last few months, and returns the start of the
+// current month. It sets up:
+//
+//   - local_account_1: signed up 2 months ago, signed in last month and this month.
+//   - local_account_2: signed up 2 months ago, posted 2 months ago and last month.
+//   - admin_account: signed up last month, signed in last month.
+//   - unconfirmed_account: signed up last month, but not approved, so not counted.
+func (suite *CohortRetentionGetTestSuite) seedActivity() time.Time {
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastMonth := thisMonth.AddDate(0, -1, 0)
+	twoMonthsAgo := thisMonth.AddDate(0, -2, 0)
+
+	user1 := suite.testUsers["local_account_1"]
+	user1.CreatedAt = twoMonthsAgo.Add(24 * time.Hour)
+	user1.LastSignInAt = lastMonth.Add(48 * time.Hour)
+	user1.CurrentSignInAt = thisMonth
+	if err := suite.db.UpdateUser(ctx, user1, "created_at", "last_sign_in_at", "current_sign_in_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user2 := suite.testUsers["local_account_2"]
+	user2.CreatedAt = twoMonthsAgo.Add(24 * time.Hour)
+	user2.LastSignInAt = time.Time{}
+	user2.CurrentSignInAt = time.Time{}
+	if err := suite.db.UpdateUser(ctx, user2, "created_at", "last_sign_in_at", "current_sign_in_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for statusKey, createdAt := range map[string]time.Time{
+		"local_account_2_status_1": twoMonthsAgo.Add(48 * time.Hour),
+		"local_account_2_status_2": twoMonthsAgo.Add(72 * time.Hour),
+		"local_account_2_status_3": lastMonth.Add(48 * time.Hour),
+	} {
+		status := suite.testStatuses[statusKey]
+		status.CreatedAt = createdAt
+		if err := suite.db.UpdateStatus(ctx, status, "created_at"); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	adminUser := suite.testUsers["admin_account"]
+	adminUser.CreatedAt = lastMonth.Add(24 * time.Hour)
+	adminUser.LastSignInAt = lastMonth.Add(24 * time.Hour)
+	adminUser.CurrentSignInAt = lastMonth.Add(48 * time.Hour)
+	if err := suite.db.UpdateUser(ctx, adminUser, "created_at", "last_sign_in_at", "current_sign_in_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	unconfirmedUser := suite.testUsers["unconfirmed_account"]
+	unconfirmedUser.CreatedAt = lastMonth.Add(24 * time.Hour)
+	if err := suite.db.UpdateUser(ctx, unconfirmedUser, "created_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return thisMonth
+}
+
+func (suite *CohortRetentionGetTestSuite) getRetention(accept string) ([]byte, *httptest.ResponseRecorder) {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, admin.CohortRetentionPath, "")
+	ctx.Request.Header.Set("accept", accept)
+
+	suite.adminModule.CohortRetentionGETHandler(ctx)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return b, recorder
+}
+
+func (suite *CohortRetentionGetTestSuite) TestCohortRetention() {
+	thisMonth := suite.seedActivity()
+
+	b, recorder := suite.getRetention("application/json")
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	resp := &apimodel.AdminCohortRetention{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// There should be one cohort per month, oldest first,
+	// each with one activity count per month since then.
+	suite.Len(resp.Months, 12)
+	suite.Len(resp.Cohorts, 12)
+	suite.Equal(thisMonth.AddDate(0, -11, 0).Format("2006-01"), resp.Months[0])
+	suite.Equal(thisMonth.Format("2006-01"), resp.Months[11])
+	for i, cohort := range resp.Cohorts {
+		suite.Equal(resp.Months[i], cohort.Period)
+		suite.Len(cohort.Active, 12-i)
+	}
+
+	// Test users all signed up in 2022, so
+	// older cohorts should be empty.
+	for _, cohort := range resp.Cohorts[:9] {
+		suite.Zero(cohort.Users)
+		for _, active := range cohort.Active {
+			suite.Zero(active)
+		}
+	}
+
+	// 2 months ago: local_account_2 posted in the month they signed
+	// up, both were active last month, local_account_1 this month.
+	suite.Equal(2, resp.Cohorts[9].Users)
+	suite.Equal([]int{1, 2, 1}, resp.Cohorts[9].Active)
+
+	// Last month: admin_account signed in, but not this month;
+	// unconfirmed_account isn't approved so isn't counted at all.
+	suite.Equal(1, resp.Cohorts[10].Users)
+	suite.Equal([]int{1, 0}, resp.Cohorts[10].Active)
+
+	// Nobody signed up this month.
+	suite.Equal(0, resp.Cohorts[11].Users)
+	suite.Equal([]int{0}, resp.Cohorts[11].Active)
+}
+
+func (suite *CohortRetentionGetTestSuite) TestCohortRetentionCSV() {
+	thisMonth := suite.seedActivity()
+
+	b, recorder := suite.getRetention("text/csv")
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("text/csv", recorder.Header().Get("Content-Type"))
+
+	records, err := csv.NewReader(bytes.NewReader(b)).ReadAll()
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Header, then one row per cohort.
+	suite.Len(records, 13)
+	suite.Equal("period", records[0][0])
+	suite.Equal("users", records[0][1])
+	suite.Equal(thisMonth.Format("2006-01"), records[0][13])
+
+	// Columns before each cohort signed up are empty.
+	twoMonthsAgo := thisMonth.AddDate(0, -2, 0).Format("2006-01")
+	suite.Equal([]string{twoMonthsAgo, "2", "", "", "", "", "", "", "", "", "", "1", "2", "1"}, records[10])
+}
+
+func (suite *CohortRetentionGetTestSuite) TestCohortRetentionNotAcceptable() {
+	_, recorder := suite.getRetention("text/html")
+	suite.Equal(http.StatusNotAcceptable, recorder.Code)
+}
+
+func TestCohortRetentionGetTestSuite(t *testing.T) {
+	suite.Run(t, &CohortRetentionGetTestSuite{})
+}
@@ -0,0 +1,124 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// DomainBlocksExportGETHandler swagger:operation GET /api/v1/admin/domain_blocks/export domainBlocksExport
+//
+// Export all domain blocks currently in place, in a format suitable for sharing with other instances.
+//
+// The `csv` format is a CSV file with the header row `domain,severity,reject_media,reject_reports,public_comment,obfuscate`.
+// The `mastodon` format is the same, but with column names prefixed by `#`, as in Mastodon's own domain block exports.
+// If private comments are included, a `private_comment` column is added to either CSV format.
+// The `json` format is an array of domain blocks, without IDs or creation details.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//	- text/csv
+//
+//	parameters:
+//	-
+//		name: format
+//		type: string
+//		enum:
+//			- csv
+//			- json
+//			- mastodon
+//		default: json
+//		description: Format of the export.
+//		in: query
+//	-
+//		name: private_comments
+//		type: boolean
+//		default: false
+//		description: Include the private comments of domain blocks in the export.
+//		in: query
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: All domain blocks currently in place, in the requested format.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'500':
+//			description: internal server error
+func (m *Module) DomainBlocksExportGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	format := c.DefaultQuery(FormatQueryKey, apimodel.DomainBlocksFormatJSON)
+
+	privateComments := false
+	privateCommentsString := c.Query(PrivateCommentsKey)
+	if privateCommentsString != "" {
+		i, err := strconv.ParseBool(privateCommentsString)
+		if err != nil {
+			err := fmt.Errorf("error parsing %s: %s", PrivateCommentsKey, err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+		privateComments = i
+	}
+
+	b, errWithCode := m.processor.Admin().DomainBlocksExport(c.Request.Context(), authed.Account, format, privateComments)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	contentType, ext := string(apiutil.TextCSV), "csv"
+	if format == apimodel.DomainBlocksFormatJSON {
+		contentType, ext = string(apiutil.AppJSON), "json"
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="domain_blocks.`+ext+`"`)
+	c.Data(http.StatusOK, contentType, b)
+}
@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type DomainBlocksExportTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainBlocksExportTestSuite) exportDomainBlocks(query string, expectedHTTPStatus int) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, "api"+admin.DomainBlocksExportPath+"?"+query, "")
+
+	suite.adminModule.DomainBlocksExportGETHandler(ctx)
+
+	suite.Equal(expectedHTTPStatus, recorder.Code)
+	return recorder
+}
+
+func (suite *DomainBlocksExportTestSuite) TestExportMastodon() {
+	recorder := suite.exportDomainBlocks("format=mastodon", http.StatusOK)
+	suite.Equal("text/csv", recorder.Header().Get("Content-Type"))
+	suite.Equal(`#domain,#severity,#reject_media,#reject_reports,#public_comment,#obfuscate
+replyguys.com,suspend,false,false,reply-guying to tech posts,false
+`, recorder.Body.String())
+}
+
+func (suite *DomainBlocksExportTestSuite) TestExportCSVPrivateComments() {
+	recorder := suite.exportDomainBlocks("format=csv&private_comments=true", http.StatusOK)
+	suite.Equal(`domain,severity,reject_media,reject_reports,public_comment,obfuscate,private_comment
+replyguys.com,suspend,false,false,reply-guying to tech posts,false,i blocked this domain because they keep replying with pushy + unwarranted linux advice
+`, recorder.Body.String())
+}
+
+func (suite *DomainBlocksExportTestSuite) TestExportJSON() {
+	recorder := suite.exportDomainBlocks("", http.StatusOK)
+	suite.Equal("application/json", recorder.Header().Get("Content-Type"))
+
+	blocks := []*apimodel.DomainBlock{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &blocks); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if suite.Len(blocks, 1) {
+		suite.Equal("replyguys.com", blocks[0].Domain.Domain)
+		suite.Equal("suspend", blocks[0].Severity)
+		suite.Empty(blocks[0].ID)
+		suite.Empty(blocks[0].PrivateComment)
+	}
+}
+
+func (suite *DomainBlocksExportTestSuite) TestExportBadFormat() {
+	suite.exportDomainBlocks("format=xml", http.StatusBadRequest)
+}
+
+func TestDomainBlocksExportTestSuite(t *testing.T) {
+	suite.Run(t, &DomainBlocksExportTestSuite{})
+}
@@ -0,0 +1,155 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// DomainBlocksImportPOSTHandler swagger:operation POST /api/v1/admin/domain_blocks/import domainBlocksImport
+//
+// Import a list of domain blocks from a file, in any of the formats produced by /api/v1/admin/domain_blocks/export.
+//
+// Each entry is reported as either new, existing, conflicting, or erroring:
+//
+// - `new` entries are created as domain blocks.
+// - `existing` entries are skipped, because the domain is already blocked with the same severity,
+// or is covered by a block of a parent domain (either existing, or in the imported list).
+// - `conflicting` entries are skipped, because they conflict with the current state of this instance,
+// eg. the domain is already blocked with different settings, or blocking it would block this instance.
+// - `errors` are entries which couldn't be parsed or created.
+//
+// Entries are created one at a time, so an error creating one doesn't prevent the others from being imported.
+// Set `dry_run` to preview the result of the import, without creating anything.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- multipart/form-data
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: format
+//		type: string
+//		enum:
+//			- csv
+//			- json
+//			- mastodon
+//		default: json
+//		description: Format of the imported file.
+//		in: query
+//	-
+//		name: dry_run
+//		type: boolean
+//		default: false
+//		description: Only report what the import would do, without creating any domain blocks.
+//		in: query
+//	-
+//		name: domains
+//		type: file
+//		description: List of domain blocks to import.
+//		in: formData
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: The result of the import.
+//			schema:
+//				"$ref": "#/definitions/domainBlocksImportResult"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) DomainBlocksImportPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	format := c.DefaultQuery(FormatQueryKey, apimodel.DomainBlocksFormatJSON)
+
+	dryRun := false
+	dryRunString := c.Query(DryRunKey)
+	if dryRunString != "" {
+		i, err := strconv.ParseBool(dryRunString)
+		if err != nil {
+			err := fmt.Errorf("error parsing %s: %s", DryRunKey, err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+		dryRun = i
+	}
+
+	form := &apimodel.DomainBlockCreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if form.Domains == nil || form.Domains.Size == 0 {
+		err := errors.New("no domains file provided")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	result, errWithCode := m.processor.Admin().DomainBlocksImportFile(c.Request.Context(), authed.Account, form.Domains, format, dryRun)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,155 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type DomainBlocksImportTestSuite struct {
+	AdminStandardTestSuite
+}
+
+const testDomainBlocksMastodonCSV = `#domain,#severity,#reject_media,#reject_reports,#public_comment,#obfuscate
+replyguys.com,suspend,false,false,reply-guying to tech posts,false
+replyguys.com,silence,true,false,,false
+sub.replyguys.com,suspend,false,false,,false
+example.org,suspend,false,false,bad vibes,true
+www.example.org,silence,false,false,,false
+localhost,suspend,false,false,,false
+noop.example.net,noop,false,false,,false
+`
+
+func (suite *DomainBlocksImportTestSuite) importDomainBlocks(
+	list string,
+	query string,
+	expectedHTTPStatus int,
+) *apimodel.DomainBlocksImportResult {
+	requestBody := new(bytes.Buffer)
+	w := multipart.NewWriter(requestBody)
+	fw, err := w.CreateFormFile("domains", "domain_blocks.csv")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := io.WriteString(fw, list); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if err := w.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, requestBody.Bytes(), "api"+admin.DomainBlocksImportPath+"?"+query, w.FormDataContentType())
+
+	suite.adminModule.DomainBlocksImportPOSTHandler(ctx)
+
+	suite.Equal(expectedHTTPStatus, recorder.Code)
+	if expectedHTTPStatus != http.StatusOK {
+		return nil
+	}
+
+	result := &apimodel.DomainBlocksImportResult{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), result); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return result
+}
+
+func importEntryDomains(entries []*apimodel.DomainBlocksImportEntry) []string {
+	domains := make([]string, 0, len(entries))
+	for _, e := range entries {
+		domains = append(domains, e.Domain)
+	}
+	return domains
+}
+
+func (suite *DomainBlocksImportTestSuite) TestImportMastodonDryRun() {
+	result := suite.importDomainBlocks(testDomainBlocksMastodonCSV, "format=mastodon&dry_run=true", http.StatusOK)
+	suite.True(result.DryRun)
+
+	if suite.Len(result.New, 1) {
+		suite.Equal("example.org", result.New[0].Domain.Domain)
+		suite.Equal("bad vibes", result.New[0].PublicComment)
+		suite.True(result.New[0].Obfuscate)
+		suite.Empty(result.New[0].ID)
+	}
+	suite.Equal([]string{"replyguys.com", "sub.replyguys.com", "www.example.org"}, importEntryDomains(result.Existing))
+	suite.Equal("covered by existing block of replyguys.com", result.Existing[1].Reason)
+	suite.Equal("covered by imported block of example.org", result.Existing[2].Reason)
+	suite.Equal([]string{"replyguys.com", "localhost"}, importEntryDomains(result.Conflicting))
+	suite.Equal([]string{"noop.example.net"}, importEntryDomains(result.Errors))
+
+	// Nothing should have been blocked.
+	blocked, err := suite.db.IsDomainBlocked(context.Background(), "example.org")
+	suite.NoError(err)
+	suite.False(blocked)
+}
+
+func (suite *DomainBlocksImportTestSuite) TestImportMastodon() {
+	result := suite.importDomainBlocks(testDomainBlocksMastodonCSV, "format=mastodon", http.StatusOK)
+	suite.False(result.DryRun)
+
+	if suite.Len(result.New, 1) {
+		suite.Equal("example.org", result.New[0].Domain.Domain)
+		suite.NotEmpty(result.New[0].ID)
+	}
+
+	blocked, err := suite.db.IsDomainBlocked(context.Background(), "www.example.org")
+	suite.NoError(err)
+	suite.True(blocked)
+
+	// Importing again should find
+	// everything is already in place.
+	result = suite.importDomainBlocks(testDomainBlocksMastodonCSV, "format=mastodon&dry_run=true", http.StatusOK)
+	suite.Empty(result.New)
+	suite.Equal([]string{"replyguys.com", "sub.replyguys.com", "example.org", "www.example.org"}, importEntryDomains(result.Existing))
+	suite.Equal("covered by existing block of example.org", result.Existing[3].Reason)
+}
+
+func (suite *DomainBlocksImportTestSuite) TestImportJSON() {
+	result := suite.importDomainBlocks(`[
+  {"domain":"example.org","severity":"silence","reject_media":true},
+  {"domain":"not a domain"}
+]`, "format=json", http.StatusOK)
+
+	if suite.Len(result.New, 1) {
+		suite.Equal("silence", result.New[0].Severity)
+		suite.True(result.New[0].RejectMedia)
+	}
+	suite.Equal([]string{"not a domain"}, importEntryDomains(result.Errors))
+}
+
+func (suite *DomainBlocksImportTestSuite) TestImportBadFormat() {
+	suite.importDomainBlocks(testDomainBlocksMastodonCSV, "format=xml", http.StatusBadRequest)
+}
+
+func TestDomainBlocksImportTestSuite(t *testing.T) {
+	suite.Run(t, &DomainBlocksImportTestSuite{})
+}
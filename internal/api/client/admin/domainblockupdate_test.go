@@ -0,0 +1,349 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DomainBlockUpdateTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainBlockUpdateTestSuite) patchDomainBlock(
+	domainBlockID string,
+	form url.Values,
+	expectedHTTPStatus int,
+) *apimodel.DomainBlock {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["admin_account"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["admin_account"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["admin_account"])
+
+	requestURI := config.GetProtocol() + "://" + config.GetHost() + "/api" + admin.DomainBlocksPath + "/" + domainBlockID
+	ctx.Request = httptest.NewRequest(http.MethodPatch, requestURI, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = form
+	ctx.AddParam(admin.IDKey, domainBlockID)
+
+	suite.adminModule.DomainBlockPATCHHandler(ctx)
+
+	suite.Equal(expectedHTTPStatus, recorder.Code)
+	if expectedHTTPStatus != http.StatusOK {
+		return nil
+	}
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	domainBlock := &apimodel.DomainBlock{}
+	if err := json.Unmarshal(b, domainBlock); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return domainBlock
+}
+
+// putDomainBlock puts a domain block with the given
+// severity straight into the database, without any of
+// the side effects of creating it through the API.
+func (suite *DomainBlockUpdateTestSuite) putDomainBlock(domain string, severity gtsmodel.DomainBlockSeverity) *gtsmodel.DomainBlock {
+	block := &gtsmodel.DomainBlock{
+		ID:                 "01H8HZ3AQ4BKE3R1CJ8W1QXXAV",
+		Domain:             domain,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		Obfuscate:          testrig.FalseBool(),
+		Severity:           severity,
+		RejectMedia:        testrig.FalseBool(),
+		RejectReports:      testrig.FalseBool(),
+	}
+
+	if err := suite.db.CreateDomainBlock(context.Background(), block); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return block
+}
+
+func (suite *DomainBlockUpdateTestSuite) TestUpdateSeverity() {
+	ctx := context.Background()
+	block := suite.testDomainBlocks["replyguys.com"]
+
+	blocked, err := suite.db.IsDomainBlocked(ctx, block.Domain)
+	suite.NoError(err)
+	suite.True(blocked)
+
+	updated := suite.patchDomainBlock(block.ID, url.Values{
+		"severity":     {"silence"},
+		"reject_media": {"true"},
+	}, http.StatusOK)
+	suite.Equal(block.ID, updated.ID)
+	suite.Equal("silence", updated.Severity)
+	suite.True(updated.RejectMedia)
+	suite.False(updated.RejectReports)
+	suite.Equal(block.PublicComment, updated.PublicComment)
+
+	// A silenced domain is no longer blocked
+	// from federating with this instance.
+	blocked, err = suite.db.IsDomainBlocked(ctx, block.Domain)
+	suite.NoError(err)
+	suite.False(blocked)
+
+	updated = suite.patchDomainBlock(block.ID, url.Values{
+		"severity": {"suspend"},
+	}, http.StatusOK)
+	suite.Equal("suspend", updated.Severity)
+	suite.True(updated.RejectMedia)
+
+	blocked, err = suite.db.IsDomainBlocked(ctx, block.Domain)
+	suite.NoError(err)
+	suite.True(blocked)
+}
+
+func (suite *DomainBlockUpdateTestSuite) TestUpdateSilenceToSuspend() {
+	ctx := context.Background()
+	account := suite.testAccounts["remote_account_1"]
+	block := suite.putDomainBlock(account.Domain, gtsmodel.DomainBlockSeveritySilence)
+
+	updated := suite.patchDomainBlock(block.ID, url.Values{
+		"severity": {"suspend"},
+	}, http.StatusOK)
+	suite.Equal("suspend", updated.Severity)
+
+	// The side effects of a new block should be run
+	// asynchronously: accounts on the domain get
+	// suspended, with this block as the origin.
+	if !testrig.WaitFor(func() bool {
+		dbAccount, err := suite.db.GetAccountByID(ctx, account.ID)
+		return err == nil && !dbAccount.SuspendedAt.IsZero()
+	}) {
+		suite.FailNow("timed out waiting for account to be suspended")
+	}
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(block.ID, dbAccount.SuspensionOrigin)
+	suite.Empty(dbAccount.DisplayName)
+
+	instance := &gtsmodel.Instance{}
+	if err := suite.db.GetWhere(ctx, []db.Where{{Key: "domain", Value: account.Domain}}, instance); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(instance.SuspendedAt.IsZero())
+	suite.Equal(block.ID, instance.DomainBlockID)
+}
+
+func (suite *DomainBlockUpdateTestSuite) TestUpdateSuspendToSilence() {
+	ctx := context.Background()
+	block := suite.putDomainBlock("fossbros-anonymous.io", gtsmodel.DomainBlockSeveritySuspend)
+
+	// One account was suspended by the block, and
+	// the other separately, by a moderator.
+	suspendedByBlock := &gtsmodel.Account{}
+	*suspendedByBlock = *suite.testAccounts["remote_account_1"]
+	suspendedByBlock.SuspendedAt = time.Now()
+	suspendedByBlock.SuspensionOrigin = block.ID
+	if err := suite.db.UpdateAccount(ctx, suspendedByBlock, "suspended_at", "suspension_origin"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suspendedByMod := &gtsmodel.Account{}
+	*suspendedByMod = *suite.testAccounts["remote_account_1"]
+	suspendedByMod.ID = "01H8J0B0V7X0K7T3M5QXYH0F2C"
+	suspendedByMod.Username = "foss_pope"
+	suspendedByMod.URI = "http://fossbros-anonymous.io/users/foss_pope"
+	suspendedByMod.URL = "http://fossbros-anonymous.io/@foss_pope"
+	suspendedByMod.InboxURI = "http://fossbros-anonymous.io/users/foss_pope/inbox"
+	suspendedByMod.OutboxURI = "http://fossbros-anonymous.io/users/foss_pope/outbox"
+	suspendedByMod.FollowersURI = "http://fossbros-anonymous.io/users/foss_pope/followers"
+	suspendedByMod.FollowingURI = "http://fossbros-anonymous.io/users/foss_pope/following"
+	suspendedByMod.FeaturedCollectionURI = "http://fossbros-anonymous.io/users/foss_pope/collections/featured"
+	suspendedByMod.PublicKeyURI = "http://fossbros-anonymous.io/users/foss_pope#main-key"
+	suspendedByMod.SuspendedAt = time.Now()
+	suspendedByMod.SuspensionOrigin = suite.testAccounts["admin_account"].ID
+	if err := suite.db.PutAccount(ctx, suspendedByMod); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	instance := &gtsmodel.Instance{}
+	if err := suite.db.GetWhere(ctx, []db.Where{{Key: "domain", Value: block.Domain}}, instance); err != nil {
+		suite.FailNow(err.Error())
+	}
+	instance.SuspendedAt = time.Now()
+	if err := suite.db.UpdateByID(ctx, instance, instance.ID, "suspended_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	updated := suite.patchDomainBlock(block.ID, url.Values{
+		"severity": {"silence"},
+	}, http.StatusOK)
+	suite.Equal("silence", updated.Severity)
+
+	// Accounts suspended by the block should be
+	// unsuspended, and every account on the domain
+	// silenced, asynchronously.
+	if !testrig.WaitFor(func() bool {
+		dbAccount, err := suite.db.GetAccountByID(ctx, suspendedByBlock.ID)
+		return err == nil && !dbAccount.SilencedAt.IsZero()
+	}) {
+		suite.FailNow("timed out waiting for account to be silenced")
+	}
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, suspendedByBlock.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbAccount.SuspendedAt.IsZero())
+	suite.Empty(dbAccount.SuspensionOrigin)
+
+	// The moderator's suspension stands.
+	dbAccount, err = suite.db.GetAccountByID(ctx, suspendedByMod.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(dbAccount.SuspendedAt.IsZero())
+	suite.Equal(suite.testAccounts["admin_account"].ID, dbAccount.SuspensionOrigin)
+	suite.False(dbAccount.SilencedAt.IsZero())
+
+	instance = &gtsmodel.Instance{}
+	if err := suite.db.GetWhere(ctx, []db.Where{{Key: "domain", Value: block.Domain}}, instance); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(instance.SuspendedAt.IsZero())
+}
+
+func (suite *DomainBlockUpdateTestSuite) TestUpdateRejectMedia() {
+	ctx := context.Background()
+	block := suite.putDomainBlock("turnip.farm", gtsmodel.DomainBlockSeveritySilence)
+
+	updated := suite.patchDomainBlock(block.ID, url.Values{
+		"reject_media": {"true"},
+	}, http.StatusOK)
+	suite.Equal("silence", updated.Severity)
+	suite.True(updated.RejectMedia)
+
+	// The domain is only silenced, so its statuses
+	// are still fetched, but without their media.
+	statusURI := testrig.URLMustParse("https://turnip.farm/users/turniplover6969/statuses/70c53e54-3146-42d5-a630-83c8b6c7c042")
+	status, _, err := suite.federator.GetStatusByURI(ctx, suite.testAccounts["local_account_1"].Username, statusURI)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(status.AttachmentIDs)
+
+	err = suite.db.GetWhere(ctx, []db.Where{{Key: "status_id", Value: status.ID}}, &gtsmodel.MediaAttachment{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *DomainBlockUpdateTestSuite) TestUpdateRejectReports() {
+	var (
+		reportingAccount = suite.testAccounts["remote_account_1"]
+		reportedAccount  = suite.testAccounts["local_account_1"]
+		block            = suite.putDomainBlock(reportingAccount.Domain, gtsmodel.DomainBlockSeveritySilence)
+	)
+
+	// flag federates a report from the
+	// reporting account, returning whether
+	// it was stored.
+	flag := func(flagURI string) bool {
+		t, err := streams.ToType(context.Background(), map[string]interface{}{
+			"@context": "https://www.w3.org/ns/activitystreams",
+			"actor":    reportingAccount.URI,
+			"content":  "ban this sick filth ⛔",
+			"id":       flagURI,
+			"object":   reportedAccount.URI,
+			"type":     "Flag",
+		})
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		ctx := gtscontext.SetReceivingAccount(context.Background(), reportedAccount)
+		ctx = gtscontext.SetRequestingAccount(ctx, reportingAccount)
+		if err := suite.federator.FederatingDB().Create(ctx, t); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		err = suite.db.GetWhere(ctx, []db.Where{{Key: "uri", Value: flagURI}}, &gtsmodel.Report{})
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			suite.FailNow(err.Error())
+		}
+		return err == nil
+	}
+
+	// A silence alone doesn't stop reports.
+	suite.True(flag("http://fossbros-anonymous.io/db22128d-884e-4358-9935-6a7c3940535d"))
+
+	updated := suite.patchDomainBlock(block.ID, url.Values{
+		"reject_reports": {"true"},
+	}, http.StatusOK)
+	suite.True(updated.RejectReports)
+	suite.False(updated.RejectMedia)
+
+	suite.False(flag("http://fossbros-anonymous.io/5d8e2c1a-2f4b-4f63-9b0e-7c2a1d3e4f56"))
+
+	updated = suite.patchDomainBlock(block.ID, url.Values{
+		"reject_reports": {"false"},
+	}, http.StatusOK)
+	suite.False(updated.RejectReports)
+
+	suite.True(flag("http://fossbros-anonymous.io/9a7b6c5d-4e3f-4a2b-8c1d-0e9f8a7b6c5d"))
+}
+
+func (suite *DomainBlockUpdateTestSuite) TestUpdateInvalidSeverity() {
+	block := suite.testDomainBlocks["replyguys.com"]
+
+	suite.patchDomainBlock(block.ID, url.Values{
+		"severity": {"obliterate"},
+	}, http.StatusBadRequest)
+}
+
+func (suite *DomainBlockUpdateTestSuite) TestUpdateNotFound() {
+	suite.patchDomainBlock("01H7D8W5HRNKNWF7YXFW7Q4B9X", url.Values{
+		"severity": {"silence"},
+	}, http.StatusNotFound)
+}
+
+func TestDomainBlockUpdateTestSuite(t *testing.T) {
+	suite.Run(t, &DomainBlockUpdateTestSuite{})
+}
@@ -0,0 +1,214 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type EmailFailedTestSuite struct {
+	AdminStandardTestSuite
+}
+
+// putEmails puts two failed emails and
+// one email still being retried in the queue.
+func (suite *EmailFailedTestSuite) putEmails() {
+	for _, email := range []*gtsmodel.QueuedEmail{
+		{
+			ID:            "01H5ZK6B8M1Q3RX1V8Q0P9E8TW",
+			CreatedAt:     testrig.TimeMustParse("2023-07-20T10:00:00Z"),
+			UpdatedAt:     testrig.TimeMustParse("2023-07-20T10:00:00Z"),
+			ToAddresses:   []string{"zork@example.org"},
+			Subject:       "GoToSocial Email Confirmation",
+			Message:       "To: zork@example.org\r\n\r\nhello\r\n",
+			Attempts:      8,
+			NextAttemptAt: testrig.TimeMustParse("2023-07-20T12:07:00Z"),
+			LastError:     "421 service not available",
+			FailedAt:      testrig.TimeMustParse("2023-07-20T12:07:00Z"),
+		},
+		{
+			ID:            "01H5ZK7Q0SD2MRYW4B3KJ6JX4Z",
+			CreatedAt:     testrig.TimeMustParse("2023-07-21T10:00:00Z"),
+			UpdatedAt:     testrig.TimeMustParse("2023-07-21T10:00:00Z"),
+			ToAddresses:   []string{"nobody@example.org"},
+			Subject:       "GoToSocial Password Reset",
+			Message:       "To: nobody@example.org\r\n\r\nhello\r\n",
+			Attempts:      1,
+			NextAttemptAt: testrig.TimeMustParse("2023-07-21T10:00:00Z"),
+			LastError:     "550 no such user",
+			FailedAt:      testrig.TimeMustParse("2023-07-21T10:00:01Z"),
+		},
+		{
+			ID:            "01H5ZK8XWQ2G9C7T1VJ0YB3N5E",
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+			ToAddresses:   []string{"admin@example.org"},
+			Subject:       "New Signup",
+			Message:       "To: admin@example.org\r\n\r\nhello\r\n",
+			Attempts:      2,
+			NextAttemptAt: time.Now().Add(time.Hour),
+			LastError:     "451 try again later",
+		},
+	} {
+		if err := suite.db.PutQueuedEmail(context.Background(), email); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+}
+
+func (suite *EmailFailedTestSuite) getFailed(query string, expectedHTTPStatus int) []*apimodel.AdminFailedEmail {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, "api"+admin.EmailFailedPath+"?"+query, "")
+
+	suite.adminModule.EmailFailedGETHandler(ctx)
+	suite.Equal(expectedHTTPStatus, recorder.Code)
+	if recorder.Code != http.StatusOK {
+		return nil
+	}
+
+	emails := []*apimodel.AdminFailedEmail{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &emails); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return emails
+}
+
+func (suite *EmailFailedTestSuite) retry(id string, expectedHTTPStatus int) {
+	recorder := httptest.NewRecorder()
+	path := strings.Replace(admin.EmailFailedRetryPath, ":"+admin.IDKey, id, 1)
+	ctx := suite.newContext(recorder, http.MethodPost, nil, "api"+path, "")
+	ctx.AddParam(admin.IDKey, id)
+
+	suite.adminModule.EmailFailedRetryPOSTHandler(ctx)
+	suite.Equal(expectedHTTPStatus, recorder.Code)
+}
+
+func (suite *EmailFailedTestSuite) TestEmailFailedGet() {
+	suite.putEmails()
+
+	emails := suite.getFailed("", http.StatusOK)
+	if !suite.Len(emails, 2) {
+		suite.FailNow("")
+	}
+
+	// Most recently failed first, and
+	// the pending email isn't included.
+	suite.Equal(&apimodel.AdminFailedEmail{
+		ID:        "01H5ZK7Q0SD2MRYW4B3KJ6JX4Z",
+		CreatedAt: "2023-07-21T10:00:00.000Z",
+		To:        []string{"nobody@example.org"},
+		Subject:   "GoToSocial Password Reset",
+		Attempts:  1,
+		LastError: "550 no such user",
+		FailedAt:  "2023-07-21T10:00:01.000Z",
+	}, emails[0])
+	suite.Equal("01H5ZK6B8M1Q3RX1V8Q0P9E8TW", emails[1].ID)
+	suite.Equal(8, emails[1].Attempts)
+}
+
+func (suite *EmailFailedTestSuite) TestEmailFailedGetLimit() {
+	suite.putEmails()
+
+	emails := suite.getFailed("limit=1", http.StatusOK)
+	if suite.Len(emails, 1) {
+		suite.Equal("01H5ZK7Q0SD2MRYW4B3KJ6JX4Z", emails[0].ID)
+	}
+
+	suite.getFailed("limit=one", http.StatusBadRequest)
+}
+
+func (suite *EmailFailedTestSuite) TestEmailFailedGetNone() {
+	suite.Empty(suite.getFailed("", http.StatusOK))
+}
+
+func (suite *EmailFailedTestSuite) TestEmailFailedGetNotAdmin() {
+	suite.putEmails()
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, "api"+admin.EmailFailedPath, "")
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	suite.adminModule.EmailFailedGETHandler(ctx)
+	suite.Equal(http.StatusForbidden, recorder.Code)
+}
+
+func (suite *EmailFailedTestSuite) TestEmailFailedRetry() {
+	suite.putEmails()
+
+	suite.retry("01H5ZK6B8M1Q3RX1V8Q0P9E8TW", http.StatusOK)
+
+	// The email should be back in the queue
+	// with a fresh set of attempts, and due now.
+	email, err := suite.db.GetQueuedEmailByID(context.Background(), "01H5ZK6B8M1Q3RX1V8Q0P9E8TW")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(email.Attempts)
+	suite.True(email.FailedAt.IsZero())
+	suite.WithinDuration(time.Now(), email.NextAttemptAt, 10*time.Second)
+	suite.Equal("To: zork@example.org\r\n\r\nhello\r\n", email.Message)
+
+	due, err := suite.db.GetDueQueuedEmails(context.Background(), time.Now(), 10)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(due, 1) {
+		suite.Equal("01H5ZK6B8M1Q3RX1V8Q0P9E8TW", due[0].ID)
+	}
+
+	// And no longer be listed as failed.
+	emails := suite.getFailed("", http.StatusOK)
+	if suite.Len(emails, 1) {
+		suite.Equal("01H5ZK7Q0SD2MRYW4B3KJ6JX4Z", emails[0].ID)
+	}
+}
+
+func (suite *EmailFailedTestSuite) TestEmailFailedRetryNotFailed() {
+	suite.putEmails()
+
+	// Still being retried, so not a failed email.
+	suite.retry("01H5ZK8XWQ2G9C7T1VJ0YB3N5E", http.StatusNotFound)
+
+	email, err := suite.db.GetQueuedEmailByID(context.Background(), "01H5ZK8XWQ2G9C7T1VJ0YB3N5E")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(2, email.Attempts)
+}
+
+func (suite *EmailFailedTestSuite) TestEmailFailedRetryUnknown() {
+	suite.retry("01H5ZKA3R0V6XN8H4S2D9QF7MB", http.StatusNotFound)
+}
+
+func TestEmailFailedTestSuite(t *testing.T) {
+	suite.Run(t, &EmailFailedTestSuite{})
+}
@@ -35,8 +35,12 @@ import (
 //
 // This can be used to validate an instance's SMTP configuration, and to debug any potential issues.
 //
+// The test email skips the email queue, and is sent straight away. A transcript of each step
+// of the conversation with the SMTP server is returned, with any credentials left out.
+//
 // If an error is returned by the SMTP connection, this handler will return code 422 to indicate that
-// the request could not be processed, and the SMTP error will be returned to the caller.
+// the request could not be processed, and the SMTP error will be returned to the caller, along with
+// the transcript up to the point of the error.
 //
 //	---
 //	tags:
@@ -62,6 +66,8 @@ import (
 //	responses:
 //		'202':
 //			description: Test email was sent.
+//			schema:
+//				"$ref": "#/definitions/adminEmailTestResult"
 //		'400':
 //			description: bad request
 //		'401':
@@ -77,7 +83,7 @@ import (
 //				An smtp occurred while the email attempt was in progress.
 //				Check the returned json for more information. The smtp error
 //				will be included, to help you debug communication with the
-//				smtp server.
+//				smtp server, followed by the transcript.
 //		'500':
 //			description: internal server error
 func (m *Module) EmailTestPOSTHandler(c *gin.Context) {
@@ -110,11 +116,11 @@ func (m *Module) EmailTestPOSTHandler(c *gin.Context) {
 		return
 	}
 
-	errWithCode := m.processor.Admin().EmailTest(c.Request.Context(), authed.Account, email.Address)
+	result, errWithCode := m.processor.Admin().EmailTest(c.Request.Context(), authed.Account, email.Address)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
-	c.JSON(http.StatusAccepted, gin.H{"status": "test email sent"})
+	c.JSON(http.StatusAccepted, result)
 }
@@ -51,11 +51,13 @@ func (suite *EmojiCategoriesGetTestSuite) TestEmojiCategoriesGet() {
 	suite.Equal(`[
   {
     "id": "01GGQ989PTT9PMRN4FZ1WWK2B9",
-    "name": "cute stuff"
+    "name": "cute stuff",
+    "position": 0
   },
   {
     "id": "01GGQ8V4993XK67B2JB396YFB7",
-    "name": "reactions"
+    "name": "reactions",
+    "position": 0
   }
 ]`, dst.String())
 }
@@ -23,6 +23,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/errorcodes"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -141,7 +142,8 @@ func validateCreateEmoji(form *apimodel.EmojiCreateRequest) error {
 
 	maxSize := config.GetMediaEmojiLocalMaxSize()
 	if form.Image.Size > int64(maxSize) {
-		return fmt.Errorf("emoji image too large: image is %dKB but size limit for custom emojis is %dKB", form.Image.Size/1024, maxSize/1024)
+		err := fmt.Errorf("emoji image too large: image is %dKB but size limit for custom emojis is %dKB", form.Image.Size/1024, maxSize/1024)
+		return errorcodes.Set(err, errorcodes.MediaTooLarge)
 	}
 
 	if err := validate.EmojiShortcode(form.Shortcode); err != nil {
@@ -110,7 +110,7 @@ func (suite *EmojiGetTestSuite) TestEmojiGetNotFound() {
 	b, err := io.ReadAll(recorder.Body)
 	suite.NoError(err)
 	suite.NotNil(b)
-	suite.Equal(`{"error":"Not Found"}`, string(b))
+	suite.Equal(`{"error":"Not Found","error_code":"ERR_NOT_FOUND"}`, string(b))
 }
 
 func TestEmojiGetTestSuite(t *testing.T) {
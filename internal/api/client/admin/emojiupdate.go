@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/errorcodes"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -201,7 +202,8 @@ func validateUpdateEmoji(form *apimodel.EmojiUpdateRequest) error {
 		if hasImage {
 			maxSize := config.GetMediaEmojiLocalMaxSize()
 			if form.Image.Size > int64(maxSize) {
-				return fmt.Errorf("emoji image too large: image is %dKB but size limit for custom emojis is %dKB", form.Image.Size/1024, maxSize/1024)
+				err := fmt.Errorf("emoji image too large: image is %dKB but size limit for custom emojis is %dKB", form.Image.Size/1024, maxSize/1024)
+				return errorcodes.Set(err, errorcodes.MediaTooLarge)
 			}
 		}
 
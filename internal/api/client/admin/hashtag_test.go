@@ -0,0 +1,120 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type HashtagTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *HashtagTestSuite) hashtagRequest(
+	method string,
+	tag string,
+	form url.Values,
+	handler func(*gin.Context),
+	expectedHTTPStatus int,
+) []byte {
+	// instantiate recorder + test context
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["admin_account"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["admin_account"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["admin_account"])
+
+	// create the request
+	requestURI := config.GetProtocol() + "://" + config.GetHost() + "/api" + admin.HashtagsPath
+	if tag != "" {
+		requestURI += "/" + url.PathEscape(tag) + "/require_cw"
+		ctx.AddParam(admin.TagKey, tag)
+	}
+
+	ctx.Request = httptest.NewRequest(method, requestURI, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	if form != nil {
+		ctx.Request.Form = form
+	}
+
+	// trigger the handler
+	handler(ctx)
+
+	// check the response
+	suite.Equal(expectedHTTPStatus, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return b
+}
+
+func (suite *HashtagTestSuite) TestHashtagRequireCW() {
+	b := suite.hashtagRequest(http.MethodPost, "#Spiders", nil, suite.adminModule.HashtagRequireCWPOSTHandler, http.StatusOK)
+
+	hashtag := &apimodel.AdminHashtag{}
+	if err := json.Unmarshal(b, hashtag); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("spiders", hashtag.Name)
+	suite.True(hashtag.ContentWarningRequired)
+
+	// Hashtag should now be listed.
+	b = suite.hashtagRequest(http.MethodGet, "", nil, suite.adminModule.HashtagsGETHandler, http.StatusOK)
+
+	hashtags := []*apimodel.AdminHashtag{}
+	if err := json.Unmarshal(b, &hashtags); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]*apimodel.AdminHashtag{hashtag}, hashtags)
+
+	// Lift the requirement again.
+	b = suite.hashtagRequest(http.MethodPost, "spiders", url.Values{"required": {"false"}}, suite.adminModule.HashtagRequireCWPOSTHandler, http.StatusOK)
+
+	hashtag = &apimodel.AdminHashtag{}
+	if err := json.Unmarshal(b, hashtag); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("spiders", hashtag.Name)
+	suite.False(hashtag.ContentWarningRequired)
+}
+
+func (suite *HashtagTestSuite) TestHashtagRequireCWInvalidTag() {
+	b := suite.hashtagRequest(http.MethodPost, "not-a-hashtag", nil, suite.adminModule.HashtagRequireCWPOSTHandler, http.StatusBadRequest)
+	suite.Equal(`{"error":"Bad Request: hashtag not-a-hashtag must contain at least one letter, and only letters, numbers and underscores"}`, string(b))
+}
+
+func TestHashtagTestSuite(t *testing.T) {
+	suite.Run(t, &HashtagTestSuite{})
+}
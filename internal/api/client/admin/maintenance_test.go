@@ -0,0 +1,105 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+type MaintenanceTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *MaintenanceTestSuite) setMaintenance(body string) *apimodel.AdminMaintenance {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(body), admin.MaintenancePath, "application/x-www-form-urlencoded")
+
+	suite.adminModule.MaintenancePOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	return suite.readMaintenance(recorder)
+}
+
+func (suite *MaintenanceTestSuite) getMaintenance() *apimodel.AdminMaintenance {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, admin.MaintenancePath, "")
+
+	suite.adminModule.MaintenanceGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	return suite.readMaintenance(recorder)
+}
+
+func (suite *MaintenanceTestSuite) readMaintenance(recorder *httptest.ResponseRecorder) *apimodel.AdminMaintenance {
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	resp := &apimodel.AdminMaintenance{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return resp
+}
+
+func (suite *MaintenanceTestSuite) TestMaintenanceOnOff() {
+	resp := suite.getMaintenance()
+	suite.False(resp.Enabled)
+	suite.Zero(resp.Backlog)
+
+	resp = suite.setMaintenance("enabled=true&message=back+soon")
+	suite.True(resp.Enabled)
+	suite.Equal("back soon", resp.Message)
+	suite.True(config.GetMaintenanceMode())
+
+	// Federated activities should be deferred while in maintenance mode.
+	// (Use a combination that's a no-op to process, it doesn't matter here.)
+	suite.state.Workers.EnqueueFederator(context.Background(), messages.FromFederator{
+		APObjectType:     ap.ObjectNote,
+		APActivityType:   ap.ActivityUpdate,
+		ReceivingAccount: suite.testAccounts["admin_account"],
+	})
+
+	resp = suite.getMaintenance()
+	suite.Equal(1, resp.Backlog)
+
+	// Turning maintenance mode off should take the backlog to be processed.
+	resp = suite.setMaintenance("enabled=false")
+	suite.False(resp.Enabled)
+	suite.Equal("back soon", resp.Message)
+	suite.Zero(resp.Backlog)
+	suite.False(config.GetMaintenanceMode())
+}
+
+func TestMaintenanceTestSuite(t *testing.T) {
+	suite.Run(t, &MaintenanceTestSuite{})
+}
@@ -0,0 +1,92 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type MediaUsageGetTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *MediaUsageGetTestSuite) getUsage(query string, expectedHTTPStatus int) *apimodel.AdminMediaUsage {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, admin.MediaUsagePath+query, "")
+
+	suite.adminModule.MediaUsageGETHandler(ctx)
+	suite.Equal(expectedHTTPStatus, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if expectedHTTPStatus != http.StatusOK {
+		return nil
+	}
+
+	resp := &apimodel.AdminMediaUsage{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return resp
+}
+
+func (suite *MediaUsageGetTestSuite) TestMediaUsageByDomain() {
+	resp := suite.getUsage("", http.StatusOK)
+	suite.Equal("domain", resp.GroupBy)
+	suite.NotEmpty(resp.Usage)
+	suite.Nil(resp.Orphaned)
+
+	for i, u := range resp.Usage {
+		suite.Empty(u.Account)
+		if i > 0 {
+			suite.GreaterOrEqual(resp.Usage[i-1].Size, u.Size)
+		}
+	}
+}
+
+func (suite *MediaUsageGetTestSuite) TestMediaUsageByAccount() {
+	resp := suite.getUsage("?group_by=account&limit=1", http.StatusOK)
+	suite.Equal("account", resp.GroupBy)
+	suite.Len(resp.Usage, 1)
+	suite.NotEmpty(resp.Usage[0].Account)
+}
+
+func (suite *MediaUsageGetTestSuite) TestMediaUsageOrphaned() {
+	resp := suite.getUsage("?orphaned=true", http.StatusOK)
+	suite.NotNil(resp.Orphaned)
+}
+
+func (suite *MediaUsageGetTestSuite) TestMediaUsageBadGroupBy() {
+	suite.getUsage("?group_by=instance", http.StatusBadRequest)
+}
+
+func TestMediaUsageGetTestSuite(t *testing.T) {
+	suite.Run(t, &MediaUsageGetTestSuite{})
+}
@@ -0,0 +1,201 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RegistrationTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *RegistrationTestSuite) request(method string, requestPath string, body string, targetAccountID string, handler func(*gin.Context)) (int, string) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["admin_account"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["admin_account"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["admin_account"])
+
+	requestURI := config.GetProtocol() + "://" + config.GetHost() + "/api" + requestPath
+	ctx.Request = httptest.NewRequest(method, requestURI, strings.NewReader(body))
+	if body != "" {
+		ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	ctx.Request.Header.Set("accept", "application/json")
+	if targetAccountID != "" {
+		ctx.AddParam(admin.IDKey, targetAccountID)
+	}
+
+	handler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := ioutil.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder.Code, string(b)
+}
+
+func (suite *RegistrationTestSuite) getRegistrations(status string) (int, string) {
+	return suite.request(http.MethodGet, admin.RegistrationsPath+"?status="+status, "", "", suite.adminModule.RegistrationsGETHandler)
+}
+
+func (suite *RegistrationTestSuite) approve(targetAccountID string) (int, string) {
+	requestPath := admin.RegistrationsPath + "/" + targetAccountID + "/approve"
+	return suite.request(http.MethodPost, requestPath, "", targetAccountID, suite.adminModule.RegistrationApprovePOSTHandler)
+}
+
+func (suite *RegistrationTestSuite) reject(targetAccountID string, reason string) (int, string) {
+	requestPath := admin.RegistrationsPath + "/" + targetAccountID + "/reject"
+	body := ""
+	if reason != "" {
+		body = "reason=" + reason
+	}
+	return suite.request(http.MethodPost, requestPath, body, targetAccountID, suite.adminModule.RegistrationRejectPOSTHandler)
+}
+
+func (suite *RegistrationTestSuite) TestRegistrationsGetPending() {
+	pending := suite.testAccounts["unconfirmed_account"]
+
+	code, body := suite.getRegistrations("pending")
+	suite.Equal(http.StatusOK, code)
+	suite.Contains(body, `"id":"`+pending.ID+`"`)
+	suite.Contains(body, `"invite_request":"hi, please let me in! I'm looking for somewhere neato bombeato to hang out."`)
+	suite.NotContains(body, suite.testAccounts["local_account_1"].ID)
+}
+
+func (suite *RegistrationTestSuite) TestRegistrationsGetBadStatus() {
+	code, body := suite.getRegistrations("maybe")
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Contains(body, `"error":"Bad Request: status must be one of pending, approved or rejected, provided value was maybe"`)
+}
+
+func (suite *RegistrationTestSuite) TestRegistrationApprove() {
+	config.SetSMTPHost("smtp.example.org")
+
+	var (
+		ctx           = context.Background()
+		targetAccount = suite.testAccounts["unconfirmed_account"]
+	)
+
+	code, body := suite.approve(targetAccount.ID)
+	suite.Equal(http.StatusOK, code)
+	suite.Contains(body, `"approved":true`)
+
+	dbUser, err := suite.db.GetUserByAccountID(ctx, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*dbUser.Approved)
+	suite.False(*dbUser.Disabled)
+	suite.Zero(dbUser.RejectedAt)
+
+	// The applicant hasn't confirmed their email
+	// address yet, so should be reminded to do so.
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: weed_lord420@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Sign-Up Approved\r\n\r\nHello weed_lord420!\r\n\r\nYou are receiving this mail because your sign-up for an account on GoToSocial Testrig Instance (http://localhost:8080) has been approved by a moderator. Welcome!\r\n\r\nBefore you can log in, please confirm your email address using the link in the separate confirmation email we've sent you.\r\n\r\n", suite.sentEmails["weed_lord420@example.org"])
+
+	// The sign-up is no longer pending.
+	code, body = suite.getRegistrations("pending")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("[]", body)
+
+	// Approving it again is a conflict.
+	code, _ = suite.approve(targetAccount.ID)
+	suite.Equal(http.StatusConflict, code)
+}
+
+func (suite *RegistrationTestSuite) TestRegistrationReject() {
+	config.SetSMTPHost("smtp.example.org")
+
+	var (
+		ctx           = context.Background()
+		targetAccount = suite.testAccounts["unconfirmed_account"]
+	)
+
+	code, body := suite.reject(targetAccount.ID, "we're full, sorry!")
+	suite.Equal(http.StatusOK, code)
+	suite.Contains(body, `"approved":false`)
+	suite.Contains(body, `"disabled":true`)
+
+	dbUser, err := suite.db.GetUserByAccountID(ctx, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*dbUser.Approved)
+	suite.True(*dbUser.Disabled)
+	suite.NotZero(dbUser.RejectedAt)
+
+	// The applicant should be told why.
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: weed_lord420@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Sign-Up Rejected\r\n\r\nHello weed_lord420!\r\n\r\nYou are receiving this mail because you signed up for an account on GoToSocial Testrig Instance (http://localhost:8080).\r\n\r\nUnfortunately, your sign-up has been rejected by a moderator, and the account cannot be used.\r\n\r\nThe moderator who rejected your sign-up gave the following reason: we're full, sorry!\r\n\r\n", suite.sentEmails["weed_lord420@example.org"])
+
+	// The sign-up is now listed as rejected.
+	code, body = suite.getRegistrations("rejected")
+	suite.Equal(http.StatusOK, code)
+	suite.Contains(body, `"id":"`+targetAccount.ID+`"`)
+
+	// Rejecting it again is a conflict.
+	code, _ = suite.reject(targetAccount.ID, "")
+	suite.Equal(http.StatusConflict, code)
+
+	// But a rejected sign-up can still be approved.
+	code, _ = suite.approve(targetAccount.ID)
+	suite.Equal(http.StatusOK, code)
+
+	dbUser, err = suite.db.GetUserByAccountID(ctx, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*dbUser.Approved)
+	suite.False(*dbUser.Disabled)
+	suite.Zero(dbUser.RejectedAt)
+}
+
+func (suite *RegistrationTestSuite) TestRegistrationRejectApproved() {
+	code, body := suite.reject(suite.testAccounts["local_account_1"].ID, "")
+	suite.Equal(http.StatusConflict, code)
+	suite.Contains(body, "has already been approved")
+	suite.Empty(suite.sentEmails)
+}
+
+func (suite *RegistrationTestSuite) TestRegistrationApproveNotFound() {
+	code, _ := suite.approve("01GZ8YQZ4Y1ZBEHE2DJRMC1X2A")
+	suite.Equal(http.StatusNotFound, code)
+}
+
+func TestRegistrationTestSuite(t *testing.T) {
+	suite.Run(t, &RegistrationTestSuite{})
+}
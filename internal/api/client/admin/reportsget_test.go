@@ -518,7 +518,8 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
               }
             },
             "description": "tweet from thoughts of dog: i drank. all the water. in my bowl. earlier. but just now. i returned. to the same bowl. and it was. full again.. the bowl. is haunted",
-            "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6"
+            "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6",
+            "processing_status": "succeeded"
           }
         ],
         "mentions": [],
@@ -730,7 +731,8 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
               }
             },
             "description": "tweet from thoughts of dog: i drank. all the water. in my bowl. earlier. but just now. i returned. to the same bowl. and it was. full again.. the bowl. is haunted",
-            "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6"
+            "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6",
+            "processing_status": "succeeded"
           }
         ],
         "mentions": [],
@@ -942,7 +944,8 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
               }
             },
             "description": "tweet from thoughts of dog: i drank. all the water. in my bowl. earlier. but just now. i returned. to the same bowl. and it was. full again.. the bowl. is haunted",
-            "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6"
+            "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6",
+            "processing_status": "succeeded"
           }
         ],
         "mentions": [],
@@ -983,7 +986,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetNotAdmin() {
 	testToken := suite.testTokens["local_account_1"]
 	testUser := suite.testUsers["local_account_1"]
 
-	reports, _, err := suite.getReports(testAccount, testToken, testUser, http.StatusForbidden, `{"error":"Forbidden: user 01F8MGVGPHQ2D3P3X0454H54Z5 not an admin"}`, nil, "", "", "", "", "", 20)
+	reports, _, err := suite.getReports(testAccount, testToken, testUser, http.StatusForbidden, `{"error":"Forbidden: user 01F8MGVGPHQ2D3P3X0454H54Z5 not an admin","error_code":"ERR_FORBIDDEN"}`, nil, "", "", "", "", "", 20)
 	suite.NoError(err)
 	suite.Empty(reports)
 }
@@ -0,0 +1,136 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RuleTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *RuleTestSuite) ruleRequest(
+	method string,
+	ruleID string,
+	text string,
+	handler func(*gin.Context),
+	expectedHTTPStatus int,
+) *apimodel.InstanceRule {
+	// instantiate recorder + test context
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["admin_account"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["admin_account"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["admin_account"])
+
+	// create the request
+	requestURI := config.GetProtocol() + "://" + config.GetHost() + "/api" + admin.InstanceRulesPath
+	if ruleID != "" {
+		requestURI += "/" + ruleID
+	}
+
+	ctx.Request = httptest.NewRequest(method, requestURI, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	if ruleID != "" {
+		ctx.AddParam(admin.IDKey, ruleID)
+	}
+	if text != "" {
+		ctx.Request.Form = url.Values{"text": {text}}
+	}
+
+	// trigger the handler
+	handler(ctx)
+
+	// check the response
+	suite.Equal(expectedHTTPStatus, recorder.Code)
+	if expectedHTTPStatus != http.StatusOK {
+		return nil
+	}
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	rule := &apimodel.InstanceRule{}
+	if err := json.Unmarshal(b, rule); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return rule
+}
+
+func (suite *RuleTestSuite) TestRuleCreateUpdateDelete() {
+	ctx := context.Background()
+
+	rule := suite.ruleRequest(http.MethodPost, "", "Don't be a jerk.", suite.adminModule.RulePOSTHandler, http.StatusOK)
+	suite.NotEmpty(rule.ID)
+	suite.Equal("Don't be a jerk.", rule.Text)
+
+	// Rule should be shown in the v2 instance response.
+	instance, errWithCode := suite.processor.InstanceGetV2(ctx, nil)
+	suite.NoError(errWithCode)
+	suite.Equal([]apimodel.InstanceRule{*rule}, instance.Rules)
+
+	updated := suite.ruleRequest(http.MethodPut, rule.ID, "Be excellent to each other.", suite.adminModule.RulePUTHandler, http.StatusOK)
+	suite.Equal(rule.ID, updated.ID)
+	suite.Equal("Be excellent to each other.", updated.Text)
+
+	deleted := suite.ruleRequest(http.MethodDelete, rule.ID, "", suite.adminModule.RuleDELETEHandler, http.StatusOK)
+	suite.Equal(updated, deleted)
+
+	_, err := suite.db.GetRuleByID(ctx, rule.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	rules, errWithCode := suite.processor.InstanceRulesGet(ctx)
+	suite.NoError(errWithCode)
+	suite.Empty(rules)
+}
+
+func (suite *RuleTestSuite) TestRuleCreateNoText() {
+	suite.ruleRequest(http.MethodPost, "", "", suite.adminModule.RulePOSTHandler, http.StatusBadRequest)
+}
+
+func (suite *RuleTestSuite) TestRuleUpdateNotFound() {
+	suite.ruleRequest(http.MethodPut, "01H3ZKAT4VPDZ8M1FKB6TBHBHA", "No spam.", suite.adminModule.RulePUTHandler, http.StatusNotFound)
+}
+
+func (suite *RuleTestSuite) TestRuleDeleteNotFound() {
+	suite.ruleRequest(http.MethodDelete, "01H3ZKAT4VPDZ8M1FKB6TBHBHA", "", suite.adminModule.RuleDELETEHandler, http.StatusNotFound)
+}
+
+func TestRuleTestSuite(t *testing.T) {
+	suite.Run(t, &RuleTestSuite{})
+}
@@ -27,6 +27,9 @@ import (
 const (
 	// BasePath is the base path for serving custom emojis, minus the 'api' prefix
 	BasePath = "/v1/custom_emojis"
+
+	ifModifiedSinceHeader = "If-Modified-Since" // https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/If-Modified-Since
+	lastModifiedHeader    = "Last-Modified"     // https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Last-Modified
 )
 
 type Module struct {
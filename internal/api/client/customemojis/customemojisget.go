@@ -19,6 +19,7 @@ package customemojis
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
@@ -30,6 +31,15 @@ import (
 //
 // Get an array of custom emojis available on the instance.
 //
+// Emojis are returned in the order of the admin-defined position of their category, then by shortcode.
+//
+// If `updated_since` is set, then instead of an array of all emojis, an object is returned containing
+// only the emojis that were created or updated since then, and the shortcodes of emojis that were deleted
+// since then. This allows clients to keep their emoji list in sync without downloading it in full each time.
+//
+// The response includes a `Last-Modified` header, and if the `If-Modified-Since` header of the request
+// is not before the time the custom emojis of the instance last changed, 304 Not Modified is returned.
+//
 //	---
 //	tags:
 //	- custom_emojis
@@ -37,17 +47,31 @@ import (
 //	produces:
 //	- application/json
 //
+//	parameters:
+//	-
+//		name: updated_since
+//		type: string
+//		description: >-
+//			Only return changes since this time, either as an ISO 8601 datetime, or a unix timestamp in seconds.
+//			If set, an emojisDelta object is returned rather than an array of emojis.
+//		in: query
+//
 //	security:
 //	- OAuth2 Bearer:
 //		- read:custom_emojis
 //
 //	responses:
 //		'200':
-//			description: Array of custom emojis.
+//			description: >-
+//				Array of custom emojis, or an emojisDelta object if `updated_since` was set.
 //			schema:
 //				type: array
 //				items:
 //					"$ref": "#/definitions/emoji"
+//		'304':
+//			description: not modified
+//		'400':
+//			description: bad request
 //		'401':
 //			description: unauthorized
 //		'406':
@@ -65,11 +89,45 @@ func (m *Module) CustomEmojisGETHandler(c *gin.Context) {
 		return
 	}
 
-	emojis, errWithCode := m.processor.Media().GetCustomEmojis(c)
+	updatedSince, errWithCode := apiutil.ParseCustomEmojisUpdatedSince(c.Query(apiutil.CustomEmojisUpdatedSinceKey), time.Time{})
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	lastModified, errWithCode := m.processor.Media().GetCustomEmojisLastModified(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if !lastModified.IsZero() {
+		c.Header(lastModifiedHeader, lastModified.UTC().Format(http.TimeFormat))
+
+		// HTTP dates only have second precision.
+		ifModifiedSince, err := http.ParseTime(c.GetHeader(ifModifiedSinceHeader))
+		if err == nil && lastModified.Unix() <= ifModifiedSince.Unix() {
+			c.AbortWithStatus(http.StatusNotModified)
+			return
+		}
+	}
+
+	if updatedSince.IsZero() {
+		emojis, errWithCode := m.processor.Media().GetCustomEmojis(c.Request.Context())
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		c.JSON(http.StatusOK, emojis)
+		return
+	}
+
+	delta, errWithCode := m.processor.Media().GetCustomEmojisSince(c.Request.Context(), updatedSince)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
-	c.JSON(http.StatusOK, emojis)
+	c.JSON(http.StatusOK, delta)
 }
@@ -0,0 +1,144 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package drafts
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// DraftCreatePOSTHandler swagger:operation POST /api/v1/drafts draftCreate
+//
+// Create a new status draft.
+//
+// Drafts are stored privately for the authorized account only, and are never federated.
+// Unlike statuses, drafts may be empty or incomplete; they are fully validated only when published.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+//	---
+//	tags:
+//	- drafts
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: status
+//		type: string
+//		description: Text content of the status.
+//		in: formData
+//	-
+//		name: media_ids[]
+//		type: array
+//		items:
+//			type: string
+//		description: |-
+//		  Array of Attachment ids to be attached as media.
+//		  Referenced media will not be cleaned up while it's referenced by a draft.
+//		in: formData
+//	-
+//		name: in_reply_to_id
+//		type: string
+//		description: ID of the status being replied to, if status is a reply.
+//		in: formData
+//	-
+//		name: sensitive
+//		type: boolean
+//		description: Status and attached media should be marked as sensitive.
+//		in: formData
+//	-
+//		name: spoiler_text
+//		type: string
+//		description: Text to be shown as a warning or subject before the actual content.
+//		in: formData
+//	-
+//		name: visibility
+//		type: string
+//		description: Visibility of the status. If not set, the account's default visibility is used on publish.
+//		in: formData
+//	-
+//		name: language
+//		type: string
+//		description: ISO 639 language code for the status.
+//		in: formData
+//	-
+//		name: content_type
+//		type: string
+//		description: Content type to use when parsing the status.
+//		in: formData
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:statuses
+//
+//	responses:
+//		'200':
+//			description: "The newly created draft."
+//			schema:
+//				"$ref": "#/definitions/draft"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable entity
+//		'500':
+//			description: internal server error
+func (m *Module) DraftCreatePOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.DraftRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	apiDraft, errWithCode := m.processor.Status().DraftCreate(c.Request.Context(), authed.Account, form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, apiDraft)
+}
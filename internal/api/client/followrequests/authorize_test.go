@@ -123,7 +123,7 @@ func (suite *AuthorizeTestSuite) TestAuthorizeNoFR() {
 	b, err := ioutil.ReadAll(result.Body)
 	suite.NoError(err)
 
-	suite.Equal(`{"error":"Not Found"}`, string(b))
+	suite.Equal(`{"error":"Not Found","error_code":"ERR_NOT_FOUND"}`, string(b))
 }
 
 func TestAuthorizeTestSuite(t *testing.T) {
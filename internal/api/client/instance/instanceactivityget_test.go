@@ -0,0 +1,134 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package instance_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type InstanceActivityGetTestSuite struct {
+	InstanceStandardTestSuite
+}
+
+func (suite *InstanceActivityGetTestSuite) getActivity(auth bool, expectedHTTPStatus int) []byte {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, instance.InstanceActivityPath, nil, "", auth)
+
+	suite.instanceModule.InstanceActivityGETHandler(ctx)
+
+	suite.Equal(expectedHTTPStatus, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	suite.NoError(err)
+	return b
+}
+
+func (suite *InstanceActivityGetTestSuite) TestInstanceActivityGet() {
+	var (
+		ctx    = context.Background()
+		now    = time.Now()
+		status = suite.testStatuses["local_account_1_status_1"]
+		user   = suite.testUsers["local_account_1"]
+	)
+
+	// Post, sign in and sign up this week.
+	status.CreatedAt = now
+	if err := suite.db.UpdateStatus(ctx, status, "created_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user.CreatedAt = now
+	user.CurrentSignInAt = now
+	if err := suite.db.UpdateUser(ctx, user, "created_at", "current_sign_in_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	b := suite.getActivity(false, http.StatusOK)
+
+	activity := []*apimodel.InstanceActivity{}
+	if err := json.Unmarshal(b, &activity); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(activity, 12)
+
+	// First week should be this week,
+	// starting at midnight on Monday.
+	week, err := strconv.ParseInt(activity[0].Week, 10, 64)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	weekStart := time.Unix(week, 0).UTC()
+	suite.Equal(time.Monday, weekStart.Weekday())
+	suite.True(weekStart.Before(now))
+	suite.True(weekStart.Add(7 * 24 * time.Hour).After(now))
+
+	suite.Equal("1", activity[0].Statuses)
+	suite.Equal("1", activity[0].Logins)
+	suite.Equal("1", activity[0].Registrations)
+
+	// Each following week should be a week earlier.
+	for i := 1; i < len(activity); i++ {
+		suite.Equal(strconv.FormatInt(week-int64(i*7*24*60*60), 10), activity[i].Week)
+		suite.Equal("0", activity[i].Statuses)
+	}
+}
+
+func (suite *InstanceActivityGetTestSuite) TestInstanceActivityGetUnauthorized() {
+	config.SetInstanceExposeActivity(false)
+
+	b := suite.getActivity(false, http.StatusUnauthorized)
+	suite.Equal(`{"error":"Unauthorized: activity query requires an authenticated account/user","error_code":"ERR_AUTH_REQUIRED"}`, string(b))
+}
+
+func (suite *InstanceActivityGetTestSuite) TestInstanceActivityGetAuthorized() {
+	config.SetInstanceExposeActivity(false)
+
+	b := suite.getActivity(true, http.StatusOK)
+
+	activity := []*apimodel.InstanceActivity{}
+	if err := json.Unmarshal(b, &activity); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(activity, 12)
+}
+
+func (suite *InstanceActivityGetTestSuite) TestInstanceActivityGetDisabled() {
+	config.SetInstanceStatsAPI(false)
+
+	b := suite.getActivity(true, http.StatusNotFound)
+	suite.Equal(`{"error":"Not Found","error_code":"ERR_NOT_FOUND"}`, string(b))
+}
+
+func TestInstanceActivityGetTestSuite(t *testing.T) {
+	suite.Run(t, &InstanceActivityGetTestSuite{})
+}
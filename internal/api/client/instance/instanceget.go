@@ -22,6 +22,7 @@ import (
 
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 
 	"github.com/gin-gonic/gin"
 )
@@ -65,6 +66,9 @@ func (m *Module) InstanceInformationGETHandlerV1(c *gin.Context) {
 //
 // View instance information.
 //
+// If the request is authenticated, `configuration.statuses.max_characters` (and
+// `max_characters_by_visibility`) are the limits which apply to the requesting user.
+//
 //	---
 //	tags:
 //	- instance
@@ -82,12 +86,20 @@ func (m *Module) InstanceInformationGETHandlerV1(c *gin.Context) {
 //		'500':
 //			description: internal error
 func (m *Module) InstanceInformationGETHandlerV2(c *gin.Context) {
+	authed, err := oauth.Authed(c, false, false, false, false)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
 	}
 
-	instance, errWithCode := m.processor.InstanceGetV2(c.Request.Context())
+	// Status length limits advertised
+	// depend on who's asking, if anyone.
+	instance, errWithCode := m.processor.InstanceGetV2(c.Request.Context(), authed.User)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
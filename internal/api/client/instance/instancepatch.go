@@ -23,6 +23,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/errorcodes"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -180,7 +181,8 @@ func validateInstanceUpdate(form *apimodel.InstanceSettingsUpdateRequest) error
 	if form.Avatar != nil {
 		maxImageSize := config.GetMediaImageMaxSize()
 		if size := form.Avatar.Size; size > int64(maxImageSize) {
-			return fmt.Errorf("file size limit exceeded: limit is %d bytes but desired instance avatar was %d bytes", maxImageSize, size)
+			err := fmt.Errorf("file size limit exceeded: limit is %d bytes but desired instance avatar was %d bytes", maxImageSize, size)
+			return errorcodes.Set(err, errorcodes.MediaTooLarge)
 		}
 	}
 
@@ -94,6 +94,13 @@ func (suite *InstancePatchTestSuite) TestInstancePatch1() {
       "supported_mime_types": [
         "text/plain",
         "text/markdown"
+      ],
+      "supported_visibilities": [
+        "public",
+        "unlisted",
+        "private",
+        "mutuals_only",
+        "direct"
       ]
     },
     "media_attachments": {
@@ -198,6 +205,13 @@ func (suite *InstancePatchTestSuite) TestInstancePatch2() {
       "supported_mime_types": [
         "text/plain",
         "text/markdown"
+      ],
+      "supported_visibilities": [
+        "public",
+        "unlisted",
+        "private",
+        "mutuals_only",
+        "direct"
       ]
     },
     "media_attachments": {
@@ -302,6 +316,13 @@ func (suite *InstancePatchTestSuite) TestInstancePatch3() {
       "supported_mime_types": [
         "text/plain",
         "text/markdown"
+      ],
+      "supported_visibilities": [
+        "public",
+        "unlisted",
+        "private",
+        "mutuals_only",
+        "direct"
       ]
     },
     "media_attachments": {
@@ -386,7 +407,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch4() {
 		suite.FailNow(err.Error())
 	}
 
-	suite.Equal(`{"error":"Bad Request: empty form submitted"}`, string(b))
+	suite.Equal(`{"error":"Bad Request: empty form submitted","error_code":"ERR_BAD_REQUEST"}`, string(b))
 }
 
 func (suite *InstancePatchTestSuite) TestInstancePatch5() {
@@ -420,7 +441,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch5() {
 	b, err := io.ReadAll(result.Body)
 	suite.NoError(err)
 
-	suite.Equal(`{"error":"Forbidden: user is not an admin so cannot update instance settings"}`, string(b))
+	suite.Equal(`{"error":"Forbidden: user is not an admin so cannot update instance settings","error_code":"ERR_FORBIDDEN"}`, string(b))
 }
 
 func (suite *InstancePatchTestSuite) TestInstancePatch6() {
@@ -457,6 +478,13 @@ func (suite *InstancePatchTestSuite) TestInstancePatch6() {
       "supported_mime_types": [
         "text/plain",
         "text/markdown"
+      ],
+      "supported_visibilities": [
+        "public",
+        "unlisted",
+        "private",
+        "mutuals_only",
+        "direct"
       ]
     },
     "media_attachments": {
@@ -541,7 +569,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch7() {
 		suite.FailNow(err.Error())
 	}
 
-	suite.Equal(`{"error":"Bad Request: mail: missing '@' or angle-addr"}`, string(b))
+	suite.Equal(`{"error":"Bad Request: mail: missing '@' or angle-addr","error_code":"ERR_BAD_REQUEST"}`, string(b))
 }
 
 func (suite *InstancePatchTestSuite) TestInstancePatch8() {
@@ -583,6 +611,13 @@ func (suite *InstancePatchTestSuite) TestInstancePatch8() {
       "supported_mime_types": [
         "text/plain",
         "text/markdown"
+      ],
+      "supported_visibilities": [
+        "public",
+        "unlisted",
+        "private",
+        "mutuals_only",
+        "direct"
       ]
     },
     "media_attachments": {
@@ -655,7 +690,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch8() {
 }`, dst.String())
 
 	// extra bonus: check the v2 model thumbnail after the patch
-	instanceV2, err := suite.processor.InstanceGetV2(context.Background())
+	instanceV2, err := suite.processor.InstanceGetV2(context.Background(), nil)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
@@ -724,6 +759,13 @@ func (suite *InstancePatchTestSuite) TestInstancePatch9() {
       "supported_mime_types": [
         "text/plain",
         "text/markdown"
+      ],
+      "supported_visibilities": [
+        "public",
+        "unlisted",
+        "private",
+        "mutuals_only",
+        "direct"
       ]
     },
     "media_attachments": {
@@ -82,6 +82,9 @@ import (
 //
 //				Whether a flat response or a more detailed response is returned, domains
 //				will be sorted alphabetically by hostname.
+//
+//
+//				If `instance-stats-api` is disabled, this endpoint always returns 404 Not Found.
 //			schema:
 //				type: array
 //				items:
@@ -99,6 +102,12 @@ import (
 //		'500':
 //			description: internal server error
 func (m *Module) InstancePeersGETHandler(c *gin.Context) {
+	if !config.GetInstanceStatsAPI() {
+		err := fmt.Errorf("instance stats api is disabled")
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotFound(err), m.processor.InstanceGetV1)
+		return
+	}
+
 	authed, err := oauth.Authed(c, false, false, false, false)
 	if err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
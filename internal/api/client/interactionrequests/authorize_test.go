@@ -0,0 +1,65 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interactionrequests_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type AuthorizeTestSuite struct {
+	InteractionRequestsStandardTestSuite
+}
+
+func (suite *AuthorizeTestSuite) TestAuthorizeInteractionRequest() {
+	fave, req := suite.putPendingFave("local_account_1_status_1", "remote_account_1")
+
+	code, body := suite.request(suite.interactionRequestsModule.InteractionRequestAuthorizePOSTHandler, http.MethodPost, "local_account_1", req.ID, "")
+	suite.Equal(http.StatusOK, code)
+
+	apiReq := &apimodel.InteractionRequest{}
+	if err := json.Unmarshal([]byte(body), apiReq); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(req.ID, apiReq.ID)
+	suite.NotEmpty(apiReq.AcceptedAt)
+	suite.Empty(apiReq.RejectedAt)
+	suite.Equal("http://localhost:8080/users/the_mighty_zork/accepts/"+req.ID, apiReq.URI)
+
+	// The fave should now be approved.
+	dbFave, err := suite.db.GetStatusFaveByID(context.Background(), fave.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(dbFave.IsPendingApproval())
+	suite.Equal(apiReq.URI, dbFave.ApprovedByURI)
+
+	// Authorizing again should fail.
+	code, _ = suite.request(suite.interactionRequestsModule.InteractionRequestAuthorizePOSTHandler, http.MethodPost, "local_account_1", req.ID, "")
+	suite.Equal(http.StatusUnprocessableEntity, code)
+}
+
+func TestAuthorizeTestSuite(t *testing.T) {
+	suite.Run(t, &AuthorizeTestSuite{})
+}
@@ -0,0 +1,267 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interactionrequests
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// InteractionRequestsGETHandler swagger:operation GET /api/v1/interaction_requests getInteractionRequests
+//
+// Get an array of pending interaction requests targeting your statuses.
+//
+// Interaction requests are created when a remote account likes, replies to, or boosts one of
+// your statuses which has likes, replies, or boosts (respectively) turned off. The interaction
+// won't be shown or counted until you authorize it.
+//
+// The requests will be returned in descending chronological order (newest first), with sequential IDs (bigger = newer).
+//
+// The returned Link header can be used to generate the previous and next queries when paging up or down.
+//
+// Example:
+//
+// ```
+// <https://example.org/api/v1/interaction_requests?limit=20&max_id=01FC3GSQ8A3MMJ43BPZSGEG29M>; rel="next", <https://example.org/api/v1/interaction_requests?limit=20&min_id=01FC3KJW2GYXSDDRA6RWNDM46M>; rel="prev"
+// ````
+//
+//	---
+//	tags:
+//	- interaction_requests
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: status_id
+//		type: string
+//		description: If set, only return interaction requests targeting the status with this ID.
+//		in: query
+//		required: false
+//	-
+//		name: favourites
+//		type: boolean
+//		description: Include pending likes/favourites.
+//		default: true
+//		in: query
+//		required: false
+//	-
+//		name: replies
+//		type: boolean
+//		description: Include pending replies.
+//		default: true
+//		in: query
+//		required: false
+//	-
+//		name: reblogs
+//		type: boolean
+//		description: Include pending boosts/reblogs.
+//		default: true
+//		in: query
+//		required: false
+//	-
+//		name: max_id
+//		type: string
+//		description: >-
+//			Return only interaction requests *OLDER* than the given max ID.
+//			The request with the specified ID will not be included in the response.
+//		in: query
+//		required: false
+//	-
+//		name: since_id
+//		type: string
+//		description: >-
+//			Return only interaction requests *NEWER* than the given since ID.
+//			The request with the specified ID will not be included in the response.
+//		in: query
+//		required: false
+//	-
+//		name: min_id
+//		type: string
+//		description: >-
+//			Return only interaction requests *IMMEDIATELY NEWER* than the given min ID.
+//			The request with the specified ID will not be included in the response.
+//		in: query
+//		required: false
+//	-
+//		name: limit
+//		type: integer
+//		description: Number of interaction requests to return.
+//		default: 20
+//		minimum: 1
+//		maximum: 100
+//		in: query
+//		required: false
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:notifications
+//
+//	responses:
+//		'200':
+//			headers:
+//				Link:
+//					type: string
+//					description: Links to the next and previous queries.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/interactionRequest"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) InteractionRequestsGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	favourites, errWithCode := apiutil.ParseInteractionFavourites(c.Query(apiutil.InteractionFavouritesKey), true)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	replies, errWithCode := apiutil.ParseInteractionReplies(c.Query(apiutil.InteractionRepliesKey), true)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	reblogs, errWithCode := apiutil.ParseInteractionReblogs(c.Query(apiutil.InteractionReblogsKey), true)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	limit, errWithCode := apiutil.ParseLimit(c.Query(apiutil.LimitKey), 20, 100, 1)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	resp, errWithCode := m.processor.InteractionRequests().GetMultiple(
+		c.Request.Context(),
+		authed.Account,
+		c.Query(StatusIDKey),
+		favourites,
+		replies,
+		reblogs,
+		c.Query(MaxIDKey),
+		c.Query(SinceIDKey),
+		c.Query(MinIDKey),
+		limit,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if resp.LinkHeader != "" {
+		c.Header("Link", resp.LinkHeader)
+	}
+	c.JSON(http.StatusOK, resp.Items)
+}
+
+// InteractionRequestGETHandler swagger:operation GET /api/v1/interaction_requests/{id} getInteractionRequest
+//
+// Get one pending interaction request with the given ID.
+//
+//	---
+//	tags:
+//	- interaction_requests
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: ID of the interaction request.
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:notifications
+//
+//	responses:
+//		'200':
+//			name: interaction request
+//			description: The requested interaction request.
+//			schema:
+//				"$ref": "#/definitions/interactionRequest"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: interaction request has already been authorized or rejected
+//		'500':
+//			description: internal server error
+func (m *Module) InteractionRequestGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	reqID := c.Param(IDKey)
+	if reqID == "" {
+		err := errors.New("no interaction request id specified")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	req, errWithCode := m.processor.InteractionRequests().Get(c.Request.Context(), authed.Account, reqID)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, req)
+}
@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interactionrequests_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type GetTestSuite struct {
+	InteractionRequestsStandardTestSuite
+}
+
+func (suite *GetTestSuite) TestGetInteractionRequests() {
+	_, req := suite.putPendingFave("local_account_1_status_1", "remote_account_1")
+
+	code, body := suite.request(suite.interactionRequestsModule.InteractionRequestsGETHandler, http.MethodGet, "local_account_1", "", "")
+	suite.Equal(http.StatusOK, code)
+
+	apiReqs := []*apimodel.InteractionRequest{}
+	if err := json.Unmarshal([]byte(body), &apiReqs); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(apiReqs, 1)
+	suite.Equal(req.ID, apiReqs[0].ID)
+	suite.Equal("favourite", apiReqs[0].Type)
+	suite.Equal(suite.testAccounts["remote_account_1"].ID, apiReqs[0].Account.ID)
+	suite.Equal(suite.testStatuses["local_account_1_status_1"].ID, apiReqs[0].Status.ID)
+	suite.Empty(apiReqs[0].AcceptedAt)
+	suite.Empty(apiReqs[0].RejectedAt)
+}
+
+func (suite *GetTestSuite) TestGetInteractionRequestsExcludeFavourites() {
+	suite.putPendingFave("local_account_1_status_1", "remote_account_1")
+
+	code, body := suite.request(suite.interactionRequestsModule.InteractionRequestsGETHandler, http.MethodGet, "local_account_1", "", "favourites=false")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("[]", body)
+}
+
+func (suite *GetTestSuite) TestGetInteractionRequestNotOwned() {
+	_, req := suite.putPendingFave("local_account_1_status_1", "remote_account_1")
+
+	code, _ := suite.request(suite.interactionRequestsModule.InteractionRequestGETHandler, http.MethodGet, "admin_account", req.ID, "")
+	suite.Equal(http.StatusNotFound, code)
+}
+
+func TestGetTestSuite(t *testing.T) {
+	suite.Run(t, &GetTestSuite{})
+}
@@ -0,0 +1,64 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interactionrequests
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+)
+
+const (
+	// IDKey is for interaction request IDs
+	IDKey = "id"
+	// BasePath is the base path for serving the interaction requests API, minus the 'api' prefix
+	BasePath = "/v1/interaction_requests"
+	// BasePathWithID is just the base path with the ID key in it.
+	BasePathWithID = BasePath + "/:" + IDKey
+	// AuthorizePath is used for authorizing interaction requests
+	AuthorizePath = BasePathWithID + "/authorize"
+	// RejectPath is used for rejecting interaction requests
+	RejectPath = BasePathWithID + "/reject"
+
+	// StatusIDKey is for filtering interaction requests by status ID
+	StatusIDKey = "status_id"
+	// MaxIDKey is the url query for setting a max ID to return
+	MaxIDKey = "max_id"
+	// SinceIDKey is the url query for returning results newer than the given ID
+	SinceIDKey = "since_id"
+	// MinIDKey is the url query for returning results immediately newer than the given ID
+	MinIDKey = "min_id"
+)
+
+type Module struct {
+	processor *processing.Processor
+}
+
+func New(processor *processing.Processor) *Module {
+	return &Module{
+		processor: processor,
+	}
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	attachHandler(http.MethodGet, BasePath, m.InteractionRequestsGETHandler)
+	attachHandler(http.MethodGet, BasePathWithID, m.InteractionRequestGETHandler)
+	attachHandler(http.MethodPost, AuthorizePath, m.InteractionRequestAuthorizePOSTHandler)
+	attachHandler(http.MethodPost, RejectPath, m.InteractionRequestRejectPOSTHandler)
+}
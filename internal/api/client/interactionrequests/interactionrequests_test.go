@@ -0,0 +1,187 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interactionrequests_test
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/interactionrequests"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type InteractionRequestsStandardTestSuite struct {
+	// standard suite interfaces
+	suite.Suite
+	db           db.DB
+	mediaManager *media.Manager
+	federator    federation.Federator
+	emailSender  email.Sender
+	processor    *processing.Processor
+	storage      *storage.Driver
+	state        state.State
+
+	// standard suite models
+	testTokens       map[string]*gtsmodel.Token
+	testApplications map[string]*gtsmodel.Application
+	testUsers        map[string]*gtsmodel.User
+	testAccounts     map[string]*gtsmodel.Account
+	testStatuses     map[string]*gtsmodel.Status
+
+	// module being tested
+	interactionRequestsModule *interactionrequests.Module
+}
+
+func (suite *InteractionRequestsStandardTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testStatuses = testrig.NewTestStatuses()
+}
+
+func (suite *InteractionRequestsStandardTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+	testrig.StartWorkers(&suite.state)
+
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.state.DB = suite.db
+	suite.storage = testrig.NewInMemoryStorage()
+	suite.state.Storage = suite.storage
+
+	testrig.StartTimelines(
+		&suite.state,
+		visibility.NewFilter(&suite.state),
+		testrig.NewTestTypeConverter(suite.db),
+	)
+
+	suite.mediaManager = testrig.NewTestMediaManager(&suite.state)
+	suite.federator = testrig.NewTestFederator(&suite.state, testrig.NewTestTransportController(&suite.state, testrig.NewMockHTTPClient(nil, "../../../../testrig/media")), suite.mediaManager)
+	suite.emailSender = testrig.NewEmailSender("../../../../web/template/", nil)
+	suite.processor = testrig.NewTestProcessor(&suite.state, suite.federator, suite.emailSender, suite.mediaManager)
+	suite.interactionRequestsModule = interactionrequests.New(suite.processor)
+
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *InteractionRequestsStandardTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+	testrig.StopWorkers(&suite.state)
+}
+
+// putPendingFave stores a fave of the given test status by
+// the given test account, which is pending approval, along
+// with the interaction request for it.
+func (suite *InteractionRequestsStandardTestSuite) putPendingFave(statusKey string, accountKey string) (*gtsmodel.StatusFave, *gtsmodel.InteractionRequest) {
+	ctx := context.Background()
+	status := suite.testStatuses[statusKey]
+	account := suite.testAccounts[accountKey]
+
+	pending := true
+	fave := &gtsmodel.StatusFave{
+		ID:              id.NewULID(),
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       account.ID,
+		TargetAccountID: status.AccountID,
+		StatusID:        status.ID,
+		URI:             account.URI + "/likes/" + id.NewULID(),
+		PendingApproval: &pending,
+	}
+	if err := suite.db.PutStatusFave(ctx, fave); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	req := &gtsmodel.InteractionRequest{
+		ID:                   id.NewULID(),
+		StatusID:             status.ID,
+		TargetAccountID:      status.AccountID,
+		InteractingAccountID: account.ID,
+		InteractionType:      gtsmodel.InteractionLike,
+		InteractionID:        fave.ID,
+		InteractionURI:       fave.URI,
+	}
+	if err := suite.db.PutInteractionRequest(ctx, req); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return fave, req
+}
+
+// request calls the given handler as the given account, with
+// the given request id and query, returning code and body.
+func (suite *InteractionRequestsStandardTestSuite) request(
+	handler gin.HandlerFunc,
+	method string,
+	accountKey string,
+	reqID string,
+	query string,
+) (int, string) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountKey])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountKey]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountKey])
+
+	path := "http://localhost:8080/api" + interactionrequests.BasePath
+	if reqID != "" {
+		path += "/" + reqID
+	}
+	if query != "" {
+		path += "?" + query
+	}
+
+	ctx.Request = httptest.NewRequest(method, path, nil)
+	ctx.Request.Header.Set("Accept", "application/json")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   interactionrequests.IDKey,
+			Value: reqID,
+		},
+	}
+
+	handler(ctx)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder.Code, string(b)
+}
@@ -47,4 +47,5 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, BasePath, m.MediaCreatePOSTHandler)
 	attachHandler(http.MethodGet, AttachmentWithID, m.MediaGETHandler)
 	attachHandler(http.MethodPut, AttachmentWithID, m.MediaPUTHandler)
+	attachHandler(http.MethodDelete, AttachmentWithID, m.MediaDELETEHandler)
 }
@@ -23,17 +23,25 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/errorcodes"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
 // MediaCreatePOSTHandler swagger:operation POST /api/{api_version}/media mediaCreate
 //
 // Upload a new media attachment.
 //
+// Uploads via the v1 API block until the attachment has been fully processed.
+// Uploads via the v2 API return as soon as the original file has been stored,
+// with response code 202 if the thumbnail and blurhash are still being generated:
+// in that case, poll `/api/v1/media/{id}` until `processing_status` is `succeeded`.
+//
 //	---
 //	tags:
 //	- media
@@ -84,6 +92,10 @@ import (
 //			description: The newly-created media attachment.
 //			schema:
 //				"$ref": "#/definitions/attachment"
+//		'202':
+//			description: The newly-created media attachment, which is still being processed (v2 only).
+//			schema:
+//				"$ref": "#/definitions/attachment"
 //		'400':
 //			description: bad request
 //		'401':
@@ -117,37 +129,55 @@ func (m *Module) MediaCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
-	if err := validateCreateMedia(form); err != nil {
+	if err := validateCreateMedia(form, authed.User); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
 		return
 	}
 
-	apiAttachment, errWithCode := m.processor.Media().Create(c.Request.Context(), authed.Account, form)
+	if apiVersion == APIv1 {
+		// the v1 media API is synchronous, so
+		// wait for processing to be finished
+		apiAttachment, errWithCode := m.processor.Media().Create(c.Request.Context(), authed.Account, form)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		c.JSON(http.StatusOK, apiAttachment)
+		return
+	}
+
+	apiAttachment, errWithCode := m.processor.Media().CreateAsync(c.Request.Context(), authed.Account, form)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
-	if apiVersion == APIv2 {
-		// the mastodon v2 media API specifies that the URL should be null
-		// and that the client should call /api/v1/media/:id to get the URL
-		//
-		// so even though we have the URL already, remove it now to comply
-		// with the api
-		apiAttachment.URL = nil
+	// the mastodon v2 media API specifies that the URL should be null
+	// and that the client should call /api/v1/media/:id to get the URL
+	//
+	// so even though we have the URL already, remove it now to comply
+	// with the api
+	apiAttachment.URL = nil
+
+	if apiAttachment.ProcessingStatus == "processing" {
+		// thumbnail and blurhash are still
+		// being generated in the background
+		c.JSON(http.StatusAccepted, apiAttachment)
+		return
 	}
 
 	c.JSON(http.StatusOK, apiAttachment)
 }
 
-func validateCreateMedia(form *apimodel.AttachmentRequest) error {
+func validateCreateMedia(form *apimodel.AttachmentRequest, user *gtsmodel.User) error {
 	// check there actually is a file attached and it's not size 0
 	if form.File == nil {
 		return errors.New("no attachment given")
 	}
 
-	maxVideoSize := config.GetMediaVideoMaxSize()
-	maxImageSize := config.GetMediaImageMaxSize()
+	maxVideoSize := validate.UserMediaVideoMaxSize(user)
+	maxImageSize := validate.UserMediaImageMaxSize(user)
 	minDescriptionChars := config.GetMediaDescriptionMinChars()
 	maxDescriptionChars := config.GetMediaDescriptionMaxChars()
 
@@ -159,7 +189,8 @@ func validateCreateMedia(form *apimodel.AttachmentRequest) error {
 	}
 
 	if form.File.Size > int64(maxSize) {
-		return fmt.Errorf("file size limit exceeded: limit is %d bytes but attachment was %d bytes", maxSize, form.File.Size)
+		err := fmt.Errorf("file size limit exceeded: limit is %d bytes but attachment was %d bytes", maxSize, form.File.Size)
+		return errorcodes.Set(err, errorcodes.MediaTooLarge)
 	}
 
 	if length := len([]rune(form.Description)); length > maxDescriptionChars {
@@ -27,8 +27,10 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/suite"
 	mediamodule "github.com/superseriousbusiness/gotosocial/internal/api/client/media"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -224,65 +226,33 @@ func (suite *MediaCreateTestSuite) TestMediaCreateSuccessful() {
 }
 
 func (suite *MediaCreateTestSuite) TestMediaCreateSuccessfulV2() {
-	// set up the context for the request
-	t := suite.testTokens["local_account_1"]
-	oauthToken := oauth.DBTokenToToken(t)
-	recorder := httptest.NewRecorder()
-	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
-	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
-	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
-	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
-	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
-
 	// see what's in storage *before* the request
 	var storageKeysBeforeRequest []string
-	if err := suite.storage.WalkKeys(ctx, func(ctx context.Context, key string) error {
+	if err := suite.storage.WalkKeys(context.Background(), func(ctx context.Context, key string) error {
 		storageKeysBeforeRequest = append(storageKeysBeforeRequest, key)
 		return nil
 	}); err != nil {
 		panic(err)
 	}
 
-	// create the request
-	buf, w, err := testrig.CreateMultipartFormData("file", "../../../../testrig/media/test-jpeg.jpg", map[string]string{
+	// do the actual request
+	code, attachmentReply := suite.createMediaV2("../../../../testrig/media/test-jpeg.jpg", map[string]string{
 		"description": "this is a test image -- a cool background from somewhere",
 		"focus":       "-0.5,0.5",
 	})
-	if err != nil {
-		panic(err)
-	}
-	ctx.Request = httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v2/media", bytes.NewReader(buf.Bytes())) // the endpoint we're hitting
-	ctx.Request.Header.Set("Content-Type", w.FormDataContentType())
-	ctx.Request.Header.Set("accept", "application/json")
-	ctx.AddParam(mediamodule.APIVersionKey, mediamodule.APIv2)
-
-	// do the actual request
-	suite.mediaModule.MediaCreatePOSTHandler(ctx)
-
-	// check what's in storage *after* the request
-	var storageKeysAfterRequest []string
-	if err := suite.storage.WalkKeys(ctx, func(ctx context.Context, key string) error {
-		storageKeysAfterRequest = append(storageKeysAfterRequest, key)
-		return nil
-	}); err != nil {
-		panic(err)
-	}
-
-	// check response
-	suite.EqualValues(http.StatusOK, recorder.Code)
-
-	result := recorder.Result()
-	defer result.Body.Close()
-	b, err := ioutil.ReadAll(result.Body)
-	suite.NoError(err)
-	fmt.Println(string(b))
-
-	attachmentReply := &apimodel.Attachment{}
-	err = json.Unmarshal(b, attachmentReply)
-	suite.NoError(err)
 
+	// the upload returns before the thumbnail has been generated
+	suite.EqualValues(http.StatusAccepted, code)
+	suite.Equal("processing", attachmentReply.ProcessingStatus)
 	suite.Equal("this is a test image -- a cool background from somewhere", *attachmentReply.Description)
 	suite.Equal("image", attachmentReply.Type)
+	suite.NotEmpty(attachmentReply.ID)
+	suite.Nil(attachmentReply.URL)
+
+	// poll until processing has finished
+	attachmentReply = suite.waitForMedia(attachmentReply.ID)
+	suite.Equal("succeeded", attachmentReply.ProcessingStatus)
+	suite.Equal("this is a test image -- a cool background from somewhere", *attachmentReply.Description)
 	suite.EqualValues(apimodel.MediaMeta{
 		Original: apimodel.MediaDimensions{
 			Width:  1920,
@@ -302,12 +272,118 @@ func (suite *MediaCreateTestSuite) TestMediaCreateSuccessfulV2() {
 		},
 	}, attachmentReply.Meta)
 	suite.Equal("LiBzRk#6V[WF_NvzV@WY_3rqV@a$", attachmentReply.Blurhash)
-	suite.NotEmpty(attachmentReply.ID)
-	suite.Nil(attachmentReply.URL)
+	suite.NotEmpty(attachmentReply.URL)
 	suite.NotEmpty(attachmentReply.PreviewURL)
+
+	// check what's in storage *after* processing
+	var storageKeysAfterRequest []string
+	if err := suite.storage.WalkKeys(context.Background(), func(ctx context.Context, key string) error {
+		storageKeysAfterRequest = append(storageKeysAfterRequest, key)
+		return nil
+	}); err != nil {
+		panic(err)
+	}
 	suite.Equal(len(storageKeysBeforeRequest)+2, len(storageKeysAfterRequest)) // 2 images should be added to storage: the original and the thumbnail
 }
 
+func (suite *MediaCreateTestSuite) TestMediaCreateConcurrentV2() {
+	files := []string{
+		"../../../../testrig/media/test-jpeg.jpg",
+		"../../../../testrig/media/ohyou-original.jpg",
+	}
+
+	var (
+		wg  sync.WaitGroup
+		ids = make([]string, len(files))
+	)
+
+	for i, file := range files {
+		wg.Add(1)
+		go func(i int, file string) {
+			defer wg.Done()
+			code, attachmentReply := suite.createMediaV2(file, map[string]string{
+				"description": "concurrent upload",
+			})
+			suite.EqualValues(http.StatusAccepted, code)
+			ids[i] = attachmentReply.ID
+		}(i, file)
+	}
+	wg.Wait()
+
+	// both uploads should eventually be processed
+	for _, id := range ids {
+		suite.NotEmpty(id)
+		attachmentReply := suite.waitForMedia(id)
+		suite.Equal("succeeded", attachmentReply.ProcessingStatus)
+		suite.NotEmpty(attachmentReply.Blurhash)
+		suite.NotEmpty(attachmentReply.PreviewURL)
+	}
+}
+
+// createMediaV2 uploads the given file as local_account_1 through the v2 media API.
+func (suite *MediaCreateTestSuite) createMediaV2(file string, fields map[string]string) (int, *apimodel.Attachment) {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder)
+
+	buf, w, err := testrig.CreateMultipartFormData("file", file, fields)
+	if err != nil {
+		panic(err)
+	}
+	ctx.Request = httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v2/media", bytes.NewReader(buf.Bytes())) // the endpoint we're hitting
+	ctx.Request.Header.Set("Content-Type", w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.AddParam(mediamodule.APIVersionKey, mediamodule.APIv2)
+
+	suite.mediaModule.MediaCreatePOSTHandler(ctx)
+
+	return recorder.Code, suite.attachmentReply(recorder)
+}
+
+// waitForMedia polls the v1 media API until the given attachment is no longer processing.
+func (suite *MediaCreateTestSuite) waitForMedia(id string) *apimodel.Attachment {
+	var attachmentReply *apimodel.Attachment
+
+	if !testrig.WaitFor(func() bool {
+		recorder := httptest.NewRecorder()
+		ctx := suite.newContext(recorder)
+		ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/media/"+id, nil) // the endpoint we're hitting
+		ctx.Request.Header.Set("accept", "application/json")
+		ctx.AddParam(mediamodule.APIVersionKey, mediamodule.APIv1)
+		ctx.AddParam(mediamodule.IDKey, id)
+
+		suite.mediaModule.MediaGETHandler(ctx)
+
+		attachmentReply = suite.attachmentReply(recorder)
+		return recorder.Code == http.StatusOK
+	}) {
+		suite.FailNow("timed out waiting for media " + id + " to be processed")
+	}
+
+	return attachmentReply
+}
+
+func (suite *MediaCreateTestSuite) newContext(recorder *httptest.ResponseRecorder) *gin.Context {
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	return ctx
+}
+
+func (suite *MediaCreateTestSuite) attachmentReply(recorder *httptest.ResponseRecorder) *apimodel.Attachment {
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	attachmentReply := &apimodel.Attachment{}
+	if err := json.Unmarshal(b, attachmentReply); err != nil {
+		suite.FailNow(err.Error(), string(b))
+	}
+	return attachmentReply
+}
+
 func (suite *MediaCreateTestSuite) TestMediaCreateLongDescription() {
 	// set up the context for the request
 	t := suite.testTokens["local_account_1"]
@@ -350,7 +426,7 @@ func (suite *MediaCreateTestSuite) TestMediaCreateLongDescription() {
 	b, err := ioutil.ReadAll(result.Body)
 	suite.NoError(err)
 
-	suite.Equal(`{"error":"Bad Request: image description length must be between 0 and 500 characters (inclusive), but provided image description was 6667 chars"}`, string(b))
+	suite.Equal(`{"error":"Bad Request: image description length must be between 0 and 500 characters (inclusive), but provided image description was 6667 chars","error_code":"ERR_BAD_REQUEST"}`, string(b))
 }
 
 func (suite *MediaCreateTestSuite) TestMediaCreateTooShortDescription() {
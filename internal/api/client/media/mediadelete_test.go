@@ -0,0 +1,190 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	mediamodule "github.com/superseriousbusiness/gotosocial/internal/api/client/media"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type MediaDeleteTestSuite struct {
+	// standard suite interfaces
+	suite.Suite
+	db           db.DB
+	storage      *storage.Driver
+	federator    federation.Federator
+	tc           typeutils.TypeConverter
+	mediaManager *media.Manager
+	emailSender  email.Sender
+	processor    *processing.Processor
+	state        state.State
+
+	// standard suite models
+	testTokens       map[string]*gtsmodel.Token
+	testApplications map[string]*gtsmodel.Application
+	testUsers        map[string]*gtsmodel.User
+	testAccounts     map[string]*gtsmodel.Account
+	testAttachments  map[string]*gtsmodel.MediaAttachment
+
+	// item being tested
+	mediaModule *mediamodule.Module
+}
+
+func (suite *MediaDeleteTestSuite) SetupSuite() {
+	testrig.StartWorkers(&suite.state)
+
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.state.DB = suite.db
+	suite.storage = testrig.NewInMemoryStorage()
+	suite.state.Storage = suite.storage
+
+	suite.tc = testrig.NewTestTypeConverter(suite.db)
+
+	testrig.StartTimelines(
+		&suite.state,
+		visibility.NewFilter(&suite.state),
+		suite.tc,
+	)
+
+	suite.mediaManager = testrig.NewTestMediaManager(&suite.state)
+	suite.federator = testrig.NewTestFederator(&suite.state, testrig.NewTestTransportController(&suite.state, testrig.NewMockHTTPClient(nil, "../../../../testrig/media")), suite.mediaManager)
+	suite.emailSender = testrig.NewEmailSender("../../../../web/template/", nil)
+	suite.processor = testrig.NewTestProcessor(&suite.state, suite.federator, suite.emailSender, suite.mediaManager)
+
+	suite.mediaModule = mediamodule.New(suite.processor)
+}
+
+func (suite *MediaDeleteTestSuite) TearDownSuite() {
+	if err := suite.db.Stop(context.Background()); err != nil {
+		log.Panicf(nil, "error closing db connection: %s", err)
+	}
+	testrig.StopWorkers(&suite.state)
+}
+
+func (suite *MediaDeleteTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAttachments = testrig.NewTestAttachments()
+}
+
+func (suite *MediaDeleteTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *MediaDeleteTestSuite) deleteMedia(attachmentID string, expectedHTTPStatus int) string {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("http://localhost:8080/api/v1/media/%s", attachmentID), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.AddParam(mediamodule.APIVersionKey, mediamodule.APIv1)
+	ctx.AddParam(mediamodule.IDKey, attachmentID)
+
+	suite.mediaModule.MediaDELETEHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(expectedHTTPStatus, recorder.Code, string(b))
+	return string(b)
+}
+
+func (suite *MediaDeleteTestSuite) TestDeleteUnattached() {
+	ctx := context.Background()
+	toDelete := suite.testAttachments["local_account_1_unattached_1"]
+
+	body := suite.deleteMedia(toDelete.ID, http.StatusOK)
+	suite.Equal(`{}`, body)
+
+	// Attachment should be gone from the db.
+	_, err := suite.db.GetAttachmentByID(ctx, toDelete.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// And its files from storage.
+	_, err = suite.storage.Get(ctx, toDelete.File.Path)
+	suite.Error(err)
+	_, err = suite.storage.Get(ctx, toDelete.Thumbnail.Path)
+	suite.Error(err)
+}
+
+func (suite *MediaDeleteTestSuite) TestDeleteAttached() {
+	ctx := context.Background()
+	toDelete := suite.testAttachments["local_account_1_status_4_attachment_1"]
+
+	body := suite.deleteMedia(toDelete.ID, http.StatusUnprocessableEntity)
+	suite.Contains(body, "attached to a status")
+
+	// Attachment and its files should be untouched.
+	_, err := suite.db.GetAttachmentByID(ctx, toDelete.ID)
+	suite.NoError(err)
+	_, err = suite.storage.Get(ctx, toDelete.File.Path)
+	suite.NoError(err)
+}
+
+func (suite *MediaDeleteTestSuite) TestDeleteAvatar() {
+	toDelete := suite.testAttachments["local_account_1_avatar"]
+	suite.deleteMedia(toDelete.ID, http.StatusUnprocessableEntity)
+}
+
+func (suite *MediaDeleteTestSuite) TestDeleteNotOwned() {
+	toDelete := suite.testAttachments["admin_account_status_1_attachment_1"]
+	suite.deleteMedia(toDelete.ID, http.StatusNotFound)
+}
+
+func TestMediaDeleteTestSuite(t *testing.T) {
+	suite.Run(t, &MediaDeleteTestSuite{})
+}
@@ -31,6 +31,10 @@ import (
 //
 // Get a media attachment that you own.
 //
+// While the attachment is still being processed, for example after uploading it via
+// the v2 API, the response code is 206 and its `processing_status` is `processing`.
+// Clients should poll this endpoint until `processing_status` is `succeeded`.
+//
 //	---
 //	tags:
 //	- media
@@ -55,6 +59,10 @@ import (
 //			description: The requested media attachment.
 //			schema:
 //				"$ref": "#/definitions/attachment"
+//		'206':
+//			description: The requested media attachment, which is still being processed.
+//			schema:
+//				"$ref": "#/definitions/attachment"
 //		'400':
 //			description: bad request
 //		'401':
@@ -96,5 +104,12 @@ func (m *Module) MediaGETHandler(c *gin.Context) {
 		return
 	}
 
+	if attachment.ProcessingStatus == "processing" {
+		// Mastodon API specifies Partial
+		// Content for unprocessed media.
+		c.JSON(http.StatusPartialContent, attachment)
+		return
+	}
+
 	c.JSON(http.StatusOK, attachment)
 }
@@ -0,0 +1,98 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package polls_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type PollGetTestSuite struct {
+	PollsStandardTestSuite
+}
+
+func (suite *PollGetTestSuite) getPoll(accountKey string, pollID string) (int, *apimodel.Poll) {
+	code, body := suite.request(suite.pollsModule.PollGETHandler, http.MethodGet, accountKey, pollID, nil)
+	if code != http.StatusOK {
+		return code, nil
+	}
+
+	apiPoll := &apimodel.Poll{}
+	if err := json.Unmarshal([]byte(body), apiPoll); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return code, apiPoll
+}
+
+func (suite *PollGetTestSuite) TestGetPollNotVoted() {
+	expiresAt := time.Now().Add(time.Hour)
+	poll := suite.putPoll("admin_account_status_1", []string{"yes", "no"}, false, false, expiresAt)
+
+	code, apiPoll := suite.getPoll("local_account_1", poll.ID)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(poll.ID, apiPoll.ID)
+	suite.NotEmpty(apiPoll.ExpiresAt)
+	suite.False(apiPoll.Expired)
+	suite.False(apiPoll.Voted)
+	suite.Empty(apiPoll.OwnVotes)
+	suite.Len(apiPoll.Options, 2)
+}
+
+func (suite *PollGetTestSuite) TestGetPollOwn() {
+	poll := suite.putPoll("admin_account_status_1", []string{"yes", "no"}, false, false, time.Now().Add(time.Hour))
+
+	// Authors count as having
+	// voted in their own polls.
+	code, apiPoll := suite.getPoll("admin_account", poll.ID)
+	suite.Equal(http.StatusOK, code)
+	suite.True(apiPoll.Voted)
+}
+
+func (suite *PollGetTestSuite) TestGetPollHiddenCounts() {
+	poll := suite.putPoll("admin_account_status_1", []string{"yes", "no"}, false, true, time.Now().Add(time.Hour))
+
+	code, _ := suite.request(suite.pollsModule.PollVotePOSTHandler, http.MethodPost, "local_account_2", poll.ID, map[string][]string{"choices[]": {"0"}})
+	suite.Equal(http.StatusOK, code)
+
+	// Per-option counts are hidden from
+	// others until the poll has ended...
+	code, apiPoll := suite.getPoll("local_account_1", poll.ID)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(1, apiPoll.VotesCount)
+	suite.Equal(0, apiPoll.Options[0].VotesCount)
+
+	// ...but not from the author.
+	code, apiPoll = suite.getPoll("admin_account", poll.ID)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(1, apiPoll.Options[0].VotesCount)
+}
+
+func (suite *PollGetTestSuite) TestGetPollNotFound() {
+	code, _ := suite.getPoll("local_account_1", "01H53FQ0V5Y3RJRZ4J0ZW7FQ7Y")
+	suite.Equal(http.StatusNotFound, code)
+}
+
+func TestPollGetTestSuite(t *testing.T) {
+	suite.Run(t, &PollGetTestSuite{})
+}
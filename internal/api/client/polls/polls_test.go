@@ -0,0 +1,172 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package polls_test
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/polls"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type PollsStandardTestSuite struct {
+	// standard suite interfaces
+	suite.Suite
+	db           db.DB
+	mediaManager *media.Manager
+	federator    federation.Federator
+	emailSender  email.Sender
+	processor    *processing.Processor
+	storage      *storage.Driver
+	state        state.State
+
+	// standard suite models
+	testTokens       map[string]*gtsmodel.Token
+	testApplications map[string]*gtsmodel.Application
+	testUsers        map[string]*gtsmodel.User
+	testAccounts     map[string]*gtsmodel.Account
+	testStatuses     map[string]*gtsmodel.Status
+
+	// module being tested
+	pollsModule *polls.Module
+}
+
+func (suite *PollsStandardTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testStatuses = testrig.NewTestStatuses()
+}
+
+func (suite *PollsStandardTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+	testrig.StartWorkers(&suite.state)
+
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.state.DB = suite.db
+	suite.storage = testrig.NewInMemoryStorage()
+	suite.state.Storage = suite.storage
+
+	testrig.StartTimelines(
+		&suite.state,
+		visibility.NewFilter(&suite.state),
+		testrig.NewTestTypeConverter(suite.db),
+	)
+
+	suite.mediaManager = testrig.NewTestMediaManager(&suite.state)
+	suite.federator = testrig.NewTestFederator(&suite.state, testrig.NewTestTransportController(&suite.state, testrig.NewMockHTTPClient(nil, "../../../../testrig/media")), suite.mediaManager)
+	suite.emailSender = testrig.NewEmailSender("../../../../web/template/", nil)
+	suite.processor = testrig.NewTestProcessor(&suite.state, suite.federator, suite.emailSender, suite.mediaManager)
+	suite.pollsModule = polls.New(suite.processor)
+
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *PollsStandardTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+	testrig.StopWorkers(&suite.state)
+}
+
+// putPoll attaches a new poll with the given
+// options to the test status with the given key.
+func (suite *PollsStandardTestSuite) putPoll(statusKey string, options []string, multiple bool, hideCounts bool, expiresAt time.Time) *gtsmodel.Poll {
+	ctx := context.Background()
+	status := suite.testStatuses[statusKey]
+
+	poll := &gtsmodel.Poll{
+		ID:         id.NewULID(),
+		StatusID:   status.ID,
+		Options:    options,
+		Votes:      make([]int, len(options)),
+		Multiple:   &multiple,
+		HideCounts: &hideCounts,
+		ExpiresAt:  expiresAt,
+	}
+	if err := suite.db.PutPoll(ctx, poll); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	status.PollID = poll.ID
+	if err := suite.db.UpdateStatus(ctx, status, "poll_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return poll
+}
+
+// request calls the given handler for the given poll as
+// the given account, returning the response code and body.
+func (suite *PollsStandardTestSuite) request(
+	handler gin.HandlerFunc,
+	method string,
+	accountKey string,
+	pollID string,
+	form url.Values,
+) (int, string) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountKey])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountKey]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountKey])
+
+	ctx.Request = httptest.NewRequest(method, "http://localhost:8080/api"+polls.BasePath+"/"+pollID, strings.NewReader(form.Encode()))
+	ctx.Request.Header.Set("Accept", "application/json")
+	if form != nil {
+		ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   polls.IDKey,
+			Value: pollID,
+		},
+	}
+
+	handler(ctx)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder.Code, string(b)
+}
@@ -0,0 +1,152 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package polls_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type PollVoteTestSuite struct {
+	PollsStandardTestSuite
+}
+
+func (suite *PollVoteTestSuite) vote(accountKey string, pollID string, choices ...string) (int, string) {
+	return suite.request(suite.pollsModule.PollVotePOSTHandler, http.MethodPost, accountKey, pollID, url.Values{"choices[]": choices})
+}
+
+func (suite *PollVoteTestSuite) TestVoteSingleChoice() {
+	poll := suite.putPoll("admin_account_status_1", []string{"yes", "no", "maybe"}, false, false, time.Now().Add(time.Hour))
+
+	code, body := suite.vote("local_account_1", poll.ID, "1")
+	suite.Equal(http.StatusOK, code)
+
+	apiPoll := &apimodel.Poll{}
+	if err := json.Unmarshal([]byte(body), apiPoll); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(poll.ID, apiPoll.ID)
+	suite.False(apiPoll.Expired)
+	suite.False(apiPoll.Multiple)
+	suite.True(apiPoll.Voted)
+	suite.Equal([]int{1}, apiPoll.OwnVotes)
+	suite.Equal(1, apiPoll.VotesCount)
+	suite.Equal(0, apiPoll.VotersCount)
+	suite.Equal([]apimodel.PollOptions{
+		{Title: "yes", VotesCount: 0},
+		{Title: "no", VotesCount: 1},
+		{Title: "maybe", VotesCount: 0},
+	}, apiPoll.Options)
+
+	// Counts should be stored on the poll.
+	dbPoll, err := suite.db.GetPollByID(context.Background(), poll.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]int{0, 1, 0}, dbPoll.Votes)
+	suite.Equal(1, dbPoll.Voters)
+}
+
+func (suite *PollVoteTestSuite) TestVoteMultipleChoice() {
+	poll := suite.putPoll("admin_account_status_1", []string{"tea", "coffee", "water"}, true, false, time.Now().Add(time.Hour))
+
+	code, body := suite.vote("local_account_1", poll.ID, "0", "2")
+	suite.Equal(http.StatusOK, code)
+
+	code, body = suite.vote("local_account_2", poll.ID, "2")
+	suite.Equal(http.StatusOK, code)
+
+	apiPoll := &apimodel.Poll{}
+	if err := json.Unmarshal([]byte(body), apiPoll); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.True(apiPoll.Multiple)
+	suite.True(apiPoll.Voted)
+	suite.Equal([]int{2}, apiPoll.OwnVotes)
+	suite.Equal(3, apiPoll.VotesCount)
+	suite.Equal(2, apiPoll.VotersCount)
+	suite.Equal([]apimodel.PollOptions{
+		{Title: "tea", VotesCount: 1},
+		{Title: "coffee", VotesCount: 0},
+		{Title: "water", VotesCount: 2},
+	}, apiPoll.Options)
+}
+
+func (suite *PollVoteTestSuite) TestVoteMultipleChoicesSingleChoicePoll() {
+	poll := suite.putPoll("admin_account_status_1", []string{"yes", "no"}, false, false, time.Now().Add(time.Hour))
+
+	code, body := suite.vote("local_account_1", poll.ID, "0", "1")
+	suite.Equal(http.StatusUnprocessableEntity, code)
+	suite.Equal(`{"error":"Unprocessable Entity: poll `+poll.ID+` only allows one choice, 2 provided","error_code":"ERR_UNPROCESSABLE_ENTITY"}`, body)
+}
+
+func (suite *PollVoteTestSuite) TestVoteChoiceOutOfRange() {
+	poll := suite.putPoll("admin_account_status_1", []string{"yes", "no"}, true, false, time.Now().Add(time.Hour))
+
+	code, body := suite.vote("local_account_1", poll.ID, "0", "2")
+	suite.Equal(http.StatusUnprocessableEntity, code)
+	suite.Equal(`{"error":"Unprocessable Entity: choice 2 is not an option in poll `+poll.ID+`","error_code":"ERR_UNPROCESSABLE_ENTITY"}`, body)
+}
+
+func (suite *PollVoteTestSuite) TestVoteNoChoices() {
+	poll := suite.putPoll("admin_account_status_1", []string{"yes", "no"}, false, false, time.Now().Add(time.Hour))
+
+	code, body := suite.vote("local_account_1", poll.ID)
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"Bad Request: no choices provided","error_code":"ERR_BAD_REQUEST"}`, body)
+}
+
+func (suite *PollVoteTestSuite) TestVoteTwice() {
+	poll := suite.putPoll("admin_account_status_1", []string{"yes", "no"}, false, false, time.Now().Add(time.Hour))
+
+	code, _ := suite.vote("local_account_1", poll.ID, "0")
+	suite.Equal(http.StatusOK, code)
+
+	code, body := suite.vote("local_account_1", poll.ID, "1")
+	suite.Equal(http.StatusUnprocessableEntity, code)
+	suite.Equal(`{"error":"Unprocessable Entity: you have already voted in poll `+poll.ID+`","error_code":"ERR_UNPROCESSABLE_ENTITY"}`, body)
+}
+
+func (suite *PollVoteTestSuite) TestVoteExpired() {
+	poll := suite.putPoll("admin_account_status_1", []string{"yes", "no"}, false, false, time.Now().Add(-time.Hour))
+
+	code, body := suite.vote("local_account_1", poll.ID, "0")
+	suite.Equal(http.StatusUnprocessableEntity, code)
+	suite.Equal(`{"error":"Unprocessable Entity: poll `+poll.ID+` has ended","error_code":"ERR_UNPROCESSABLE_ENTITY"}`, body)
+}
+
+func (suite *PollVoteTestSuite) TestVoteOwnPoll() {
+	poll := suite.putPoll("admin_account_status_1", []string{"yes", "no"}, false, false, time.Now().Add(time.Hour))
+
+	code, body := suite.vote("admin_account", poll.ID, "0")
+	suite.Equal(http.StatusUnprocessableEntity, code)
+	suite.Equal(`{"error":"Unprocessable Entity: you can't vote in your own poll","error_code":"ERR_UNPROCESSABLE_ENTITY"}`, body)
+}
+
+func TestPollVoteTestSuite(t *testing.T) {
+	suite.Run(t, &PollVoteTestSuite{})
+}
@@ -137,19 +137,19 @@ func (suite *ReportGetTestSuite) TestGetReport1() {
 
 func (suite *ReportGetTestSuite) TestGetReport2() {
 	targetReport := suite.testReports["remote_account_1_report_local_account_2"]
-	report, err := suite.getReport(http.StatusNotFound, `{"error":"Not Found"}`, targetReport.ID)
+	report, err := suite.getReport(http.StatusNotFound, `{"error":"Not Found","error_code":"ERR_NOT_FOUND"}`, targetReport.ID)
 	suite.NoError(err)
 	suite.Nil(report)
 }
 
 func (suite *ReportGetTestSuite) TestGetReport3() {
-	report, err := suite.getReport(http.StatusBadRequest, `{"error":"Bad Request: no report id specified"}`, "")
+	report, err := suite.getReport(http.StatusBadRequest, `{"error":"Bad Request: no report id specified","error_code":"ERR_BAD_REQUEST"}`, "")
 	suite.NoError(err)
 	suite.Nil(report)
 }
 
 func (suite *ReportGetTestSuite) TestGetReport4() {
-	report, err := suite.getReport(http.StatusNotFound, `{"error":"Not Found"}`, "01GPJWHQS1BG0SF0WZ1SABC4RZ")
+	report, err := suite.getReport(http.StatusNotFound, `{"error":"Not Found","error_code":"ERR_NOT_FOUND"}`, "01GPJWHQS1BG0SF0WZ1SABC4RZ")
 	suite.NoError(err)
 	suite.Nil(report)
 }
@@ -1164,7 +1164,7 @@ func (suite *SearchGetTestSuite) TestSearchBadQueryType() {
 		queryType          *string = func() *string { i := "aaaaaaaaaaa"; return &i }()
 		following          *bool   = nil
 		expectedHTTPStatus         = http.StatusBadRequest
-		expectedBody               = `{"error":"Bad Request: search query type aaaaaaaaaaa was not recognized, valid options are ['', 'accounts', 'statuses', 'hashtags']"}`
+		expectedBody               = `{"error":"Bad Request: search query type aaaaaaaaaaa was not recognized, valid options are ['', 'accounts', 'statuses', 'hashtags']","error_code":"ERR_BAD_REQUEST"}`
 	)
 
 	_, err := suite.getSearch(
@@ -1200,7 +1200,7 @@ func (suite *SearchGetTestSuite) TestSearchEmptyQuery() {
 		queryType          *string = func() *string { i := "aaaaaaaaaaa"; return &i }()
 		following          *bool   = nil
 		expectedHTTPStatus         = http.StatusBadRequest
-		expectedBody               = `{"error":"Bad Request: required key q was not set or had empty value"}`
+		expectedBody               = `{"error":"Bad Request: required key q was not set or had empty value","error_code":"ERR_BAD_REQUEST"}`
 	)
 
 	_, err := suite.getSearch(
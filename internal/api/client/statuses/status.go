@@ -52,7 +52,7 @@ const (
 	// UnbookmarkPath is for removing a bookmark from a given status
 	UnbookmarkPath = BasePathWithID + "/unbookmark"
 
-	// MutePath is for muting a given status so that notifications will no longer be received about it.
+	// MutePath is for muting the thread of a given status so that notifications will no longer be received about it.
 	MutePath = BasePathWithID + "/mute"
 	// UnmutePath is for undoing an existing mute
 	UnmutePath = BasePathWithID + "/unmute"
@@ -64,6 +64,8 @@ const (
 
 	// ContextPath is used for fetching context of posts
 	ContextPath = BasePathWithID + "/context"
+	// ThreadRootPath is used for jumping to the root of a thread
+	ThreadRootPath = BasePathWithID + "/thread_root"
 )
 
 type Module struct {
@@ -85,6 +87,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	// fave stuff
 	attachHandler(http.MethodPost, FavouritePath, m.StatusFavePOSTHandler)
 	attachHandler(http.MethodPost, UnfavouritePath, m.StatusUnfavePOSTHandler)
+	attachHandler(http.MethodDelete, FavouritePath, m.StatusFaveDELETEHandler)
 	attachHandler(http.MethodGet, FavouritedPath, m.StatusFavedByGETHandler)
 
 	// pin stuff
@@ -97,7 +100,10 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, RebloggedPath, m.StatusBoostedByGETHandler)
 	attachHandler(http.MethodPost, BookmarkPath, m.StatusBookmarkPOSTHandler)
 	attachHandler(http.MethodPost, UnbookmarkPath, m.StatusUnbookmarkPOSTHandler)
+	attachHandler(http.MethodPost, MutePath, m.StatusMutePOSTHandler)
+	attachHandler(http.MethodPost, UnmutePath, m.StatusUnmutePOSTHandler)
 
 	// context / status thread
 	attachHandler(http.MethodGet, ContextPath, m.StatusContextGETHandler)
+	attachHandler(http.MethodGet, ThreadRootPath, m.StatusThreadRootGETHandler)
 }
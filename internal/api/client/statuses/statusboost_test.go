@@ -203,7 +203,7 @@ func (suite *StatusBoostTestSuite) TestPostUnboostable() {
 	defer result.Body.Close()
 	b, err := ioutil.ReadAll(result.Body)
 	suite.NoError(err)
-	suite.Equal(`{"error":"Not Found"}`, string(b))
+	suite.Equal(`{"error":"Not Found","error_code":"ERR_NOT_FOUND"}`, string(b))
 }
 
 // try to boost a status that's not visible to the user
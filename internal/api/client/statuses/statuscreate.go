@@ -18,14 +18,11 @@
 package statuses
 
 import (
-	"errors"
-	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
-	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
@@ -38,6 +35,9 @@ import (
 // The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
 // The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
 //
+// To safely retry a request, clients can set an Idempotency-Key header. Only one status is created per key
+// (within an hour), with repeats of a completed request returning the status that was created by it.
+//
 //	---
 //	tags:
 //	- statuses
@@ -69,6 +69,11 @@ import (
 //			description: not found
 //		'406':
 //			description: not acceptable
+//		'409':
+//			description: >-
+//				A status with the same Idempotency-Key is still being created (error_code ERR_DUPLICATE_STATUS).
+//		'422':
+//			description: The status uses a hashtag which requires a content warning, but has none.
 //		'500':
 //			description: internal server error
 func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
@@ -98,12 +103,12 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 	// }
 	// form.Status += "\n\nsent from " + user + "'s iphone\n"
 
-	if err := validateCreateStatus(form); err != nil {
+	if err := validate.StatusCreateRequest(&form.StatusCreateRequest); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
 		return
 	}
 
-	apiStatus, errWithCode := m.processor.Status().Create(c.Request.Context(), authed.Account, authed.Application, form)
+	apiStatus, errWithCode := m.processor.Status().CreateIdempotent(c.Request.Context(), authed.Account, authed.Application, c.GetHeader("Idempotency-Key"), form)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
@@ -111,61 +116,3 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 
 	c.JSON(http.StatusOK, apiStatus)
 }
-
-func validateCreateStatus(form *apimodel.AdvancedStatusCreateForm) error {
-	hasStatus := form.Status != ""
-	hasMedia := len(form.MediaIDs) != 0
-	hasPoll := form.Poll != nil
-
-	if !hasStatus && !hasMedia && !hasPoll {
-		return errors.New("no status, media, or poll provided")
-	}
-
-	if hasMedia && hasPoll {
-		return errors.New("can't post media + poll in same status")
-	}
-
-	maxChars := config.GetStatusesMaxChars()
-	maxMediaFiles := config.GetStatusesMediaMaxFiles()
-	maxPollOptions := config.GetStatusesPollMaxOptions()
-	maxPollChars := config.GetStatusesPollOptionMaxChars()
-	maxCwChars := config.GetStatusesCWMaxChars()
-
-	if form.Status != "" {
-		if length := len([]rune(form.Status)); length > maxChars {
-			return fmt.Errorf("status too long, %d characters provided but limit is %d", length, maxChars)
-		}
-	}
-
-	if len(form.MediaIDs) > maxMediaFiles {
-		return fmt.Errorf("too many media files attached to status, %d attached but limit is %d", len(form.MediaIDs), maxMediaFiles)
-	}
-
-	if form.Poll != nil {
-		if form.Poll.Options == nil {
-			return errors.New("poll with no options")
-		}
-		if len(form.Poll.Options) > maxPollOptions {
-			return fmt.Errorf("too many poll options provided, %d provided but limit is %d", len(form.Poll.Options), maxPollOptions)
-		}
-		for _, p := range form.Poll.Options {
-			if length := len([]rune(p)); length > maxPollChars {
-				return fmt.Errorf("poll option too long, %d characters provided but limit is %d", length, maxPollChars)
-			}
-		}
-	}
-
-	if form.SpoilerText != "" {
-		if length := len([]rune(form.SpoilerText)); length > maxCwChars {
-			return fmt.Errorf("content-warning/spoilertext too long, %d characters provided but limit is %d", length, maxCwChars)
-		}
-	}
-
-	if form.Language != "" {
-		if err := validate.Language(form.Language); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
@@ -101,6 +101,47 @@ func (suite *StatusCreateTestSuite) TestPostNewStatus() {
 	suite.Equal(statusReply.Account.ID, gtsTag.FirstSeenFromAccountID)
 }
 
+func (suite *StatusCreateTestSuite) TestPostNewStatusIdempotencyKey() {
+	post := func(idempotencyKey string) *apimodel.Status {
+		recorder := httptest.NewRecorder()
+		ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+		ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+		ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+		ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+		ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+		ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", statuses.BasePath), nil)
+		ctx.Request.Header.Set("accept", "application/json")
+		ctx.Request.Header.Set("Idempotency-Key", idempotencyKey)
+		ctx.Request.Form = url.Values{
+			"status":     {"posting from a train with a flaky connection"},
+			"visibility": {string(apimodel.VisibilityPublic)},
+		}
+		suite.statusModule.StatusCreatePOSTHandler(ctx)
+		suite.EqualValues(http.StatusOK, recorder.Code)
+
+		result := recorder.Result()
+		defer result.Body.Close()
+		b, err := ioutil.ReadAll(result.Body)
+		suite.NoError(err)
+
+		apiStatus := &apimodel.Status{}
+		if err := json.Unmarshal(b, apiStatus); err != nil {
+			suite.FailNow(err.Error())
+		}
+		return apiStatus
+	}
+
+	first := post("a3b1d0c6-52c4-4f3b-9b1f-7f35b2f0e001")
+
+	// Retrying with the same key gets the same status back.
+	retried := post("a3b1d0c6-52c4-4f3b-9b1f-7f35b2f0e001")
+	suite.Equal(first.ID, retried.ID)
+
+	// But a new key means a new status.
+	another := post("a3b1d0c6-52c4-4f3b-9b1f-7f35b2f0e002")
+	suite.NotEqual(first.ID, another.ID)
+}
+
 func (suite *StatusCreateTestSuite) TestPostNewStatusMarkdown() {
 	// set default post language of account 1 to markdown
 	testAccount := suite.testAccounts["local_account_1"]
@@ -291,7 +332,7 @@ func (suite *StatusCreateTestSuite) TestReplyToNonexistentStatus() {
 	defer result.Body.Close()
 	b, err := ioutil.ReadAll(result.Body)
 	suite.NoError(err)
-	suite.Equal(`{"error":"Bad Request: status with id 3759e7ef-8ee1-4c0c-86f6-8b70b9ad3d50 not replyable because it doesn't exist"}`, string(b))
+	suite.Equal(`{"error":"Bad Request: status with id 3759e7ef-8ee1-4c0c-86f6-8b70b9ad3d50 not replyable because it doesn't exist","error_code":"ERR_BAD_REQUEST"}`, string(b))
 }
 
 // Post a reply to the status of a local user that allows replies.
@@ -31,8 +31,11 @@ import (
 //
 // Delete status with the given ID. The status must belong to you.
 //
-// The deleted status will be returned in the response. The `text` field will contain the original text of the status as it was submitted.
-// This is useful when doing a 'delete and redraft' type operation.
+// The deleted status will be returned in the response. The `text` field will contain the original text of the status as it was submitted,
+// and the `content_type` field will contain the content type it was submitted with. This is useful when doing a 'delete and redraft' type operation.
+//
+// Unless `delete_media` is true, media attachments of the status are kept so that they can be reused when redrafting.
+// Attachments which are not reused are cleaned up by the media cleaner after a grace period.
 //
 //	---
 //	tags:
@@ -48,6 +51,12 @@ import (
 //		description: Target status ID.
 //		in: path
 //		required: true
+//	-
+//		name: delete_media
+//		type: boolean
+//		description: Delete media attachments of the status immediately, rather than keeping them for a redraft.
+//		default: false
+//		in: query
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -89,7 +98,13 @@ func (m *Module) StatusDELETEHandler(c *gin.Context) {
 		return
 	}
 
-	apiStatus, errWithCode := m.processor.Status().Delete(c.Request.Context(), authed.Account, targetStatusID)
+	deleteMedia, errWithCode := apiutil.ParseStatusDeleteMedia(c.Query(apiutil.StatusDeleteMediaKey), false)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiStatus, errWithCode := m.processor.Status().Delete(c.Request.Context(), authed.Account, targetStatusID, deleteMedia)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
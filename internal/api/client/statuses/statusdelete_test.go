@@ -86,6 +86,61 @@ func (suite *StatusDeleteTestSuite) TestPostDelete() {
 
 }
 
+func (suite *StatusDeleteTestSuite) TestPostDeleteKeepMedia() {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+	targetStatus := suite.testStatuses["local_account_1_status_4"]
+
+	// setup
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("http://localhost:8080%s", strings.Replace(statuses.BasePathWithID, ":id", targetStatus.ID, 1)), nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("accept", "application/json")
+
+	// normally the router would populate these params from the path values,
+	// but because we're calling the function directly, we need to set them manually.
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   statuses.IDKey,
+			Value: targetStatus.ID,
+		},
+	}
+
+	suite.statusModule.StatusDELETEHandler(ctx)
+
+	// check response
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	statusReply := &apimodel.Status{}
+	err = json.Unmarshal(b, statusReply)
+	suite.NoError(err)
+	suite.Equal(targetStatus.Text, statusReply.Text)
+	suite.Len(statusReply.MediaAttachments, 2)
+
+	if !testrig.WaitFor(func() bool {
+		_, err := suite.db.GetStatusByID(ctx, targetStatus.ID)
+		return errors.Is(err, db.ErrNoEntries)
+	}) {
+		suite.FailNow("time out waiting for status to be deleted")
+	}
+
+	// Attachments should be kept for a redraft, but no longer attached.
+	for _, attachmentID := range targetStatus.AttachmentIDs {
+		attachment, err := suite.db.GetAttachmentByID(ctx, attachmentID)
+		suite.NoError(err)
+		suite.Empty(attachment.StatusID)
+	}
+}
+
 func TestStatusDeleteTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusDeleteTestSuite))
 }
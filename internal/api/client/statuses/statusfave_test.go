@@ -123,7 +123,7 @@ func (suite *StatusFaveTestSuite) TestPostUnfaveable() {
 	defer result.Body.Close()
 	b, err := ioutil.ReadAll(result.Body)
 	assert.NoError(suite.T(), err)
-	assert.Equal(suite.T(), `{"error":"Forbidden: status is not faveable"}`, string(b))
+	assert.Equal(suite.T(), `{"error":"Forbidden: status is not faveable","error_code":"ERR_FORBIDDEN"}`, string(b))
 }
 
 func TestStatusFaveTestSuite(t *testing.T) {
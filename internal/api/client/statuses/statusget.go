@@ -42,7 +42,7 @@ import (
 //	-
 //		name: id
 //		type: string
-//		description: Target status ID.
+//		description: Target status ID, or the short alias of a local status.
 //		in: path
 //		required: true
 //
@@ -134,7 +134,7 @@ func (suite *StatusPinTestSuite) TestPinStatusTwiceError() {
 
 	if _, err := suite.createPin(
 		http.StatusUnprocessableEntity,
-		`{"error":"Unprocessable Entity: status already pinned"}`,
+		`{"error":"Unprocessable Entity: status already pinned","error_code":"ERR_STATUS_ALREADY_PINNED"}`,
 		targetStatus.ID,
 	); err != nil {
 		suite.FailNow(err.Error())
@@ -147,7 +147,7 @@ func (suite *StatusPinTestSuite) TestPinStatusOtherAccountError() {
 
 	if _, err := suite.createPin(
 		http.StatusUnprocessableEntity,
-		`{"error":"Unprocessable Entity: status 01F8MH75CBF9JFX4ZAD54N0W0R does not belong to account 01F8MH1H7YV1Z7D2C8K2730QBF"}`,
+		`{"error":"Unprocessable Entity: status 01F8MH75CBF9JFX4ZAD54N0W0R does not belong to account 01F8MH1H7YV1Z7D2C8K2730QBF","error_code":"ERR_UNPROCESSABLE_ENTITY"}`,
 		targetStatus.ID,
 	); err != nil {
 		suite.FailNow(err.Error())
@@ -185,7 +185,7 @@ func (suite *StatusPinTestSuite) TestPinStatusTooManyPins() {
 	targetStatus := suite.testStatuses["local_account_1_status_1"]
 	if _, err := suite.createPin(
 		http.StatusUnprocessableEntity,
-		`{"error":"Unprocessable Entity: status pin limit exceeded, you've already pinned 10 status(es) out of 10"}`,
+		`{"error":"Unprocessable Entity: status pin limit exceeded, you've already pinned 10 status(es) out of 10","error_code":"ERR_PIN_LIMIT_REACHED"}`,
 		targetStatus.ID,
 	); err != nil {
 		suite.FailNow(err.Error())
@@ -0,0 +1,93 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package statuses_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusThreadRootTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusThreadRootTestSuite) getThreadRoot(requester string, targetStatusID string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[requester]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[requester])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[requester])
+	ctx.Request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080%s", strings.Replace(statuses.ThreadRootPath, ":id", targetStatusID, 1)), nil)
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   statuses.IDKey,
+			Value: targetStatusID,
+		},
+	}
+
+	suite.statusModule.StatusThreadRootGETHandler(ctx)
+	return recorder
+}
+
+func (suite *StatusThreadRootTestSuite) TestThreadRootOfReply() {
+	recorder := suite.getThreadRoot("local_account_2", suite.testStatuses["local_account_2_status_5"].ID)
+
+	suite.Equal(http.StatusFound, recorder.Code)
+	suite.Equal("/api/v1/statuses/"+suite.testStatuses["local_account_1_status_1"].ID, recorder.Header().Get("Location"))
+}
+
+func (suite *StatusThreadRootTestSuite) TestThreadRootOfRoot() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+	recorder := suite.getThreadRoot("local_account_2", targetStatus.ID)
+
+	suite.Equal(http.StatusFound, recorder.Code)
+	suite.Equal("/api/v1/statuses/"+targetStatus.ID, recorder.Header().Get("Location"))
+}
+
+func (suite *StatusThreadRootTestSuite) TestThreadRootParentNotVisible() {
+	// Make the parent a DM that local_account_2 isn't part of.
+	parent := &gtsmodel.Status{}
+	*parent = *suite.testStatuses["local_account_1_status_1"]
+	parent.Visibility = gtsmodel.VisibilityDirect
+	if err := suite.db.UpdateStatus(context.Background(), parent, "visibility"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The deepest visible status is the reply itself.
+	targetStatus := suite.testStatuses["local_account_2_status_5"]
+	recorder := suite.getThreadRoot("local_account_2", targetStatus.ID)
+
+	suite.Equal(http.StatusFound, recorder.Code)
+	suite.Equal("/api/v1/statuses/"+targetStatus.ID, recorder.Header().Get("Location"))
+}
+
+func TestStatusThreadRootTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusThreadRootTestSuite))
+}
@@ -108,7 +108,7 @@ func (suite *StatusUnpinTestSuite) TestUnpinStatusNotFound() {
 	// Unpin a pinned followers-only status owned by another account.
 	targetStatus := suite.testStatuses["local_account_2_status_7"]
 
-	if _, err := suite.createUnpin(http.StatusNotFound, `{"error":"Not Found"}`, targetStatus.ID); err != nil {
+	if _, err := suite.createUnpin(http.StatusNotFound, `{"error":"Not Found","error_code":"ERR_NOT_FOUND"}`, targetStatus.ID); err != nil {
 		suite.FailNow(err.Error())
 	}
 }
@@ -119,7 +119,7 @@ func (suite *StatusUnpinTestSuite) TestUnpinStatusUnprocessable() {
 
 	if _, err := suite.createUnpin(
 		http.StatusUnprocessableEntity,
-		`{"error":"Unprocessable Entity: status 01F8MHAMCHF6Y650WCRSCP4WMY does not belong to account 01F8MH17FWEB39HZJ76B6VXSKF"}`,
+		`{"error":"Unprocessable Entity: status 01F8MHAMCHF6Y650WCRSCP4WMY does not belong to account 01F8MH17FWEB39HZJ76B6VXSKF","error_code":"ERR_UNPROCESSABLE_ENTITY"}`,
 		targetStatus.ID,
 	); err != nil {
 		suite.FailNow(err.Error())
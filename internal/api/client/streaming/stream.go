@@ -19,10 +19,15 @@ package streaming
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
 	"time"
 
 	"codeberg.org/gruf/go-kv"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -44,9 +49,12 @@ import (
 //
 // As long as the connection is open, various message types will be streamed into it.
 //
-// GoToSocial will ping the connection every 30 seconds to check whether the client is still receiving.
+// GoToSocial will ping the connection every 30 seconds (configurable with `streaming-ping-interval`) to check whether the client is still receiving.
 //
-// If the ping fails, or something else goes wrong during transmission, then the connection will be dropped, and the client will be expected to start it again.
+// If the ping fails, the client doesn't respond with a pong within 10 seconds (configurable with `streaming-ping-timeout`), or something else goes wrong during transmission, then the connection will be dropped, and the client will be expected to start it again.
+//
+// Since the browser WebSocket API can't set an `Authorization` header on the upgrade request, the access token may be given in the `access_token` query parameter instead.
+// This is a deliberate usability tradeoff: tokens in URLs are easier to leak than tokens in headers, so GoToSocial never logs query parameters, and only logs a hash of the token for the lifetime of the connection.
 //
 //	---
 //	tags:
@@ -62,9 +70,11 @@ import (
 //	-
 //		name: access_token
 //		type: string
-//		description: Access token for the requesting account.
+//		description: |-
+//			Access token for the requesting account.
+//
+//			May also be given in the `Sec-Websocket-Protocol` header, or as a regular `Authorization: Bearer` header.
 //		in: query
-//		required: true
 //	-
 //		name: stream
 //		type: string
@@ -208,12 +218,19 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 		return
 	}
 
+	// Never log the token itself: if it came
+	// from the query it was part of the URL.
+	fields := kv.Fields{
+		{"username", account.Username},
+		{"streamID", stream.ID},
+	}
+	if token != "" {
+		fields = append(fields, kv.Field{"tokenHash", tokenHash(token)})
+	}
+
 	l := log.
 		WithContext(c.Request.Context()).
-		WithFields(kv.Fields{
-			{"username", account.Username},
-			{"streamID", stream.ID},
-		}...)
+		WithFields(fields...)
 
 	// Upgrade the incoming HTTP request. This hijacks the
 	// underlying connection and reuses it for the websocket
@@ -257,6 +274,12 @@ func (m *Module) handleWSConn(username string, wsConn *websocket.Conn, stream *s
 	// Create ticker to send keepalive pings
 	pinger := time.NewTicker(m.dTicker)
 
+	// A read deadline is set each time we send a ping;
+	// clear it again when the client answers with a pong.
+	wsConn.SetPongHandler(func(string) error {
+		return wsConn.SetReadDeadline(time.Time{})
+	})
+
 	// Read messages coming from the Websocket client connection into the server.
 	go func() {
 		defer cancel()
@@ -318,9 +341,13 @@ readLoop:
 			// Read JSON objects from the client and act on them.
 			var msg map[string]string
 			if err := wsConn.ReadJSON(&msg); err != nil {
-				// Only log an error if something weird happened.
-				// See: https://www.rfc-editor.org/rfc/rfc6455.html#section-11.7
-				if websocket.IsUnexpectedCloseError(err, []int{
+				var netErr net.Error
+				if errors.As(err, &netErr) && netErr.Timeout() {
+					// Read deadline set on last ping expired.
+					l.Debug("no pong received from websocket client in time")
+				} else if websocket.IsUnexpectedCloseError(err, []int{
+					// Only log an error if something weird happened.
+					// See: https://www.rfc-editor.org/rfc/rfc6455.html#section-11.7
 					websocket.CloseNormalClosure,
 					websocket.CloseGoingAway,
 					websocket.CloseNoStatusReceived,
@@ -384,10 +411,13 @@ readLoop:
 // writeToWSConn receives messages coming from the processor via the
 // given stream, and writes them into the given websockets connection.
 // This function also handles sending ping messages into the websockets
-// connection to keep it alive when no other activity occurs.
+// connection to keep it alive when no other activity occurs, and setting
+// the read deadline by which the client must answer each ping with a pong. If the instance
+// is in maintenance mode when a ping is due, the connection is closed instead,
+// with close code 1013 (try again later).
 //
-// This is a blocking function; will return only on write error or
-// if the given context is canceled.
+// This is a blocking function; will return only on write error,
+// maintenance mode, or if the given context is canceled.
 func (m *Module) writeToWSConn(
 	ctx context.Context,
 	username string,
@@ -422,6 +452,26 @@ writeLoop:
 			pinger.Reset(m.dTicker)
 
 		case <-pinger.C:
+			if config.GetMaintenanceMode() {
+				// Instance is in maintenance mode; close the
+				// connection, telling the client to come back later.
+				l.Debug("closing websocket for maintenance mode")
+				msg := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "maintenance mode")
+				if err := wsConn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(m.dTicker)); err != nil {
+					l.Debugf("error writing close to websocket: %v", err)
+				}
+				break writeLoop
+			}
+
+			// Give the client until the timeout to answer this
+			// ping. This must be set *before* writing the ping,
+			// so a very quick pong can't be followed by a stale
+			// deadline which then drops a healthy connection.
+			if err := wsConn.SetReadDeadline(time.Now().Add(m.dTimeout)); err != nil {
+				l.Debugf("error setting websocket read deadline: %v", err)
+				break writeLoop
+			}
+
 			// Time to send a keep-alive "ping".
 			l.Trace("writing ping control message to websocket")
 			if err := wsConn.WriteControl(websocket.PingMessage, nil, time.Time{}); err != nil {
@@ -433,3 +483,11 @@ writeLoop:
 
 	l.Debug("finished writing to websocket connection")
 }
+
+// tokenHash returns a short hex-encoded hash of the given access
+// token, suitable for telling connections apart in logs without
+// leaking the token itself.
+func tokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8])
+}
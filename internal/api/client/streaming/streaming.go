@@ -38,10 +38,11 @@ const (
 type Module struct {
 	processor *processing.Processor
 	dTicker   time.Duration
+	dTimeout  time.Duration
 	wsUpgrade websocket.Upgrader
 }
 
-func New(processor *processing.Processor, dTicker time.Duration, wsBuf int) *Module {
+func New(processor *processing.Processor, dTicker time.Duration, dTimeout time.Duration, wsBuf int) *Module {
 	// We expect CORS requests for websockets,
 	// (via eg., semaphore.social) so be lenient.
 	// TODO: make this customizable?
@@ -50,6 +51,7 @@ func New(processor *processing.Processor, dTicker time.Duration, wsBuf int) *Mod
 	return &Module{
 		processor: processor,
 		dTicker:   dTicker,
+		dTimeout:  dTimeout,
 		wsUpgrade: websocket.Upgrader{
 			ReadBufferSize:  wsBuf,
 			WriteBufferSize: wsBuf,
@@ -25,10 +25,12 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -109,7 +111,7 @@ func (suite *StreamingTestSuite) SetupTest() {
 	suite.federator = testrig.NewTestFederator(&suite.state, testrig.NewTestTransportController(&suite.state, testrig.NewMockHTTPClient(nil, "../../../../testrig/media")), suite.mediaManager)
 	suite.emailSender = testrig.NewEmailSender("../../../../web/template/", nil)
 	suite.processor = testrig.NewTestProcessor(&suite.state, suite.federator, suite.emailSender, suite.mediaManager)
-	suite.streamingModule = streaming.New(suite.processor, 1, 4096)
+	suite.streamingModule = streaming.New(suite.processor, 1, 1, 4096)
 }
 
 func (suite *StreamingTestSuite) TearDownTest() {
@@ -240,6 +242,127 @@ func (suite *StreamingTestSuite) TestSecurityHeader() {
 	suite.NoError(err)
 }
 
+func (suite *StreamingTestSuite) newTestServer(pingInterval time.Duration, pingTimeout time.Duration) *httptest.Server {
+	module := streaming.New(suite.processor, pingInterval, pingTimeout, 4096)
+
+	engine := gin.New()
+	engine.GET("/api"+streaming.BasePath, module.StreamGETHandler)
+	return httptest.NewServer(engine)
+}
+
+func streamURL(server *httptest.Server, token string) string {
+	return fmt.Sprintf(
+		"ws://%s/api%s?stream=user&access_token=%s",
+		strings.TrimPrefix(server.URL, "http://"),
+		streaming.BasePath,
+		token,
+	)
+}
+
+func (suite *StreamingTestSuite) dialTestServer(pingInterval time.Duration, pingTimeout time.Duration) (*websocket.Conn, func()) {
+	server := suite.newTestServer(pingInterval, pingTimeout)
+	url := streamURL(server, suite.testTokens["local_account_1"].Access)
+
+	wsConn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		server.Close()
+		suite.FailNow(err.Error())
+	}
+	resp.Body.Close()
+
+	return wsConn, func() {
+		wsConn.Close()
+		server.Close()
+	}
+}
+
+func (suite *StreamingTestSuite) TestQueryParamAuth() {
+	server := suite.newTestServer(30*time.Second, 10*time.Second)
+	defer server.Close()
+
+	// A valid token in the query, with no
+	// Authorization header, should be upgraded.
+	wsConn, resp, err := websocket.DefaultDialer.Dial(
+		streamURL(server, suite.testTokens["local_account_1"].Access),
+		nil,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	resp.Body.Close()
+	wsConn.Close()
+	suite.Equal(http.StatusSwitchingProtocols, resp.StatusCode)
+
+	// An invalid token should be rejected before the upgrade.
+	_, resp, err = websocket.DefaultDialer.Dial(
+		streamURL(server, "not-a-real-token"),
+		nil,
+	)
+	suite.ErrorIs(err, websocket.ErrBadHandshake)
+	if resp == nil {
+		suite.FailNow("expected http response to failed handshake")
+	}
+	resp.Body.Close()
+	suite.Equal(http.StatusUnauthorized, resp.StatusCode)
+}
+
+func (suite *StreamingTestSuite) TestPingNoPongDisconnects() {
+	var (
+		pingInterval = 100 * time.Millisecond
+		pingTimeout  = 200 * time.Millisecond
+		epsilon      = 500 * time.Millisecond
+	)
+
+	wsConn, closeFn := suite.dialTestServer(pingInterval, pingTimeout)
+	defer closeFn()
+
+	// Swallow pings without answering them.
+	wsConn.SetPingHandler(func(string) error { return nil })
+
+	// Don't wait forever if the server never hangs up.
+	start := time.Now()
+	if err := wsConn.SetReadDeadline(start.Add(pingInterval + pingTimeout + epsilon)); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Read until the server closes the connection.
+	for {
+		if _, _, err := wsConn.ReadMessage(); err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				suite.FailNow("server did not close connection after missed pong")
+			}
+			break
+		}
+	}
+
+	elapsed := time.Since(start)
+	suite.GreaterOrEqual(elapsed, pingInterval+pingTimeout)
+	suite.Less(elapsed, pingInterval+pingTimeout+epsilon)
+}
+
+func (suite *StreamingTestSuite) TestPingPongStaysConnected() {
+	var (
+		pingInterval = 100 * time.Millisecond
+		pingTimeout  = 200 * time.Millisecond
+		wait         = 1 * time.Second
+	)
+
+	wsConn, closeFn := suite.dialTestServer(pingInterval, pingTimeout)
+	defer closeFn()
+
+	// The default ping handler answers with a pong,
+	// so the connection should stay up until we stop
+	// waiting for it on our end.
+	if err := wsConn.SetReadDeadline(time.Now().Add(wait)); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, _, err := wsConn.ReadMessage()
+	var netErr net.Error
+	suite.True(errors.As(err, &netErr) && netErr.Timeout(), "expected read timeout, got %v", err)
+}
+
 func TestStreamingTestSuite(t *testing.T) {
 	suite.Run(t, new(StreamingTestSuite))
 }
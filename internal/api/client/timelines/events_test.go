@@ -0,0 +1,209 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timelines_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/timelines"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type EventsTestSuite struct {
+	TimelinesStandardTestSuite
+
+	// events put in the db for each test
+	testEvents map[string]*gtsmodel.Status
+}
+
+func (suite *EventsTestSuite) SetupTest() {
+	suite.TimelinesStandardTestSuite.SetupTest()
+
+	suite.testEvents = map[string]*gtsmodel.Status{
+		"local_account_1_event":  suite.newEvent(suite.testAccounts["local_account_1"], "Sloth appreciation meetup", "2023-07-01T10:00:00Z", gtsmodel.VisibilityPublic),
+		"remote_account_1_event": suite.newEvent(suite.testAccounts["remote_account_1"], "Foss bros anonymous", "2023-07-02T10:00:00Z", gtsmodel.VisibilityPublic),
+		"local_account_2_event":  suite.newEvent(suite.testAccounts["local_account_2"], "Turtle race", "2023-07-03T10:00:00Z", gtsmodel.VisibilityFollowersOnly),
+	}
+
+	for _, event := range suite.testEvents {
+		if err := suite.db.PutStatus(context.Background(), event); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+}
+
+// newEvent returns a new event status by the
+// given account, created at the given time.
+func (suite *EventsTestSuite) newEvent(account *gtsmodel.Account, name string, created string, visibility gtsmodel.Visibility) *gtsmodel.Status {
+	createdAt := testrig.TimeMustParse(created)
+
+	statusID, err := id.NewULIDFromTime(createdAt)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	local := account.IsLocal()
+
+	return &gtsmodel.Status{
+		ID:                  statusID,
+		CreatedAt:           createdAt,
+		UpdatedAt:           createdAt,
+		URI:                 account.URI + "/events/" + statusID,
+		URL:                 account.URL + "/events/" + statusID,
+		Content:             "<p>See you there!</p>",
+		Local:               &local,
+		AccountID:           account.ID,
+		AccountURI:          account.URI,
+		Visibility:          visibility,
+		ActivityStreamsType: ap.ObjectEvent,
+		EventName:           name,
+		EventStartAt:        testrig.TimeMustParse("2023-08-01T18:00:00Z"),
+		EventEndAt:          testrig.TimeMustParse("2023-08-01T21:00:00Z"),
+		EventLocation:       "The Old Tree, Slothville",
+		Federated:           testrig.TrueBool(),
+		Boostable:           testrig.TrueBool(),
+		Replyable:           testrig.TrueBool(),
+		Likeable:            testrig.TrueBool(),
+	}
+}
+
+// getEvents calls the events timeline handler as the given
+// account, or without auth if empty, returning the response
+// code, Link header, and any statuses in the response.
+func (suite *EventsTestSuite) getEvents(requester string, query string) (int, string, []*apimodel.Status) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	if requester != "" {
+		ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+		ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[requester]))
+		ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[requester])
+		ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[requester])
+	}
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080"+timelines.EventsTimeline+query, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.timelinesModule.EventsTimelineGETHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	if result.StatusCode != http.StatusOK {
+		return result.StatusCode, "", nil
+	}
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statuses := []*apimodel.Status{}
+	if err := json.Unmarshal(b, &statuses); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return result.StatusCode, result.Header.Get("Link"), statuses
+}
+
+func (suite *EventsTestSuite) TestGetEvents() {
+	code, link, statuses := suite.getEvents("local_account_1", "")
+	suite.Equal(http.StatusOK, code)
+
+	// Public events only, newest first.
+	if !suite.Len(statuses, 2) {
+		suite.FailNow("")
+	}
+	suite.Equal(suite.testEvents["remote_account_1_event"].ID, statuses[0].ID)
+	suite.Equal(suite.testEvents["local_account_1_event"].ID, statuses[1].ID)
+
+	suite.Equal(`<http://localhost:8080/api/v1/timelines/events?limit=20&max_id=`+statuses[1].ID+`>; rel="next", `+
+		`<http://localhost:8080/api/v1/timelines/events?limit=20&min_id=`+statuses[0].ID+`>; rel="prev"`, link)
+
+	event := statuses[0].Event
+	if !suite.NotNil(event) {
+		suite.FailNow("")
+	}
+	suite.Equal("Foss bros anonymous", event.Name)
+	suite.Equal("2023-08-01T18:00:00.000Z", event.StartTime)
+	suite.Equal("2023-08-01T21:00:00.000Z", event.EndTime)
+	suite.Equal("The Old Tree, Slothville", event.Location)
+	suite.Equal("<p><strong>Foss bros anonymous</strong></p><p>See you there!</p>", statuses[0].Content)
+}
+
+func (suite *EventsTestSuite) TestGetEventsPaging() {
+	code, link, statuses := suite.getEvents("local_account_1", "?limit=1")
+	suite.Equal(http.StatusOK, code)
+	if !suite.Len(statuses, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal(suite.testEvents["remote_account_1_event"].ID, statuses[0].ID)
+	suite.Contains(link, "max_id="+statuses[0].ID)
+
+	code, _, statuses = suite.getEvents("local_account_1", "?limit=1&max_id="+statuses[0].ID)
+	suite.Equal(http.StatusOK, code)
+	if !suite.Len(statuses, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal(suite.testEvents["local_account_1_event"].ID, statuses[0].ID)
+
+	code, link, statuses = suite.getEvents("local_account_1", "?limit=1&max_id="+statuses[0].ID)
+	suite.Equal(http.StatusOK, code)
+	suite.Empty(statuses)
+	suite.Empty(link)
+}
+
+func (suite *EventsTestSuite) TestGetEventsLocal() {
+	code, link, statuses := suite.getEvents("local_account_1", "?local=true")
+	suite.Equal(http.StatusOK, code)
+	if !suite.Len(statuses, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal(suite.testEvents["local_account_1_event"].ID, statuses[0].ID)
+	suite.Contains(link, "&local=true")
+}
+
+func (suite *EventsTestSuite) TestGetEventsBlocked() {
+	// local_account_2 blocks remote_account_1, so only sees the
+	// event of local_account_1; not even their own event, since
+	// it's not public.
+	code, _, statuses := suite.getEvents("local_account_2", "")
+	suite.Equal(http.StatusOK, code)
+	if !suite.Len(statuses, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal(suite.testEvents["local_account_1_event"].ID, statuses[0].ID)
+}
+
+func (suite *EventsTestSuite) TestGetEventsUnauthorized() {
+	code, _, _ := suite.getEvents("", "")
+	suite.Equal(http.StatusUnauthorized, code)
+}
+
+func TestEventsTestSuite(t *testing.T) {
+	suite.Run(t, new(EventsTestSuite))
+}
@@ -33,6 +33,8 @@ const (
 	// PublicTimeline is the path for the public (and public local) timeline
 	PublicTimeline = BasePath + "/public"
 	ListTimeline   = BasePath + "/list/:" + IDKey
+	// EventsTimeline is the path for the timeline of public events
+	EventsTimeline = BasePath + "/events"
 	// MaxIDKey is the url query for setting a max status ID to return
 	MaxIDKey = "max_id"
 	// SinceIDKey is the url query for returning results newer than the given ID
@@ -59,4 +61,5 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, HomeTimeline, m.HomeTimelineGETHandler)
 	attachHandler(http.MethodGet, PublicTimeline, m.PublicTimelineGETHandler)
 	attachHandler(http.MethodGet, ListTimeline, m.ListTimelineGETHandler)
+	attachHandler(http.MethodGet, EventsTimeline, m.EventsTimelineGETHandler)
 }
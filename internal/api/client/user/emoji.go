@@ -0,0 +1,265 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/errorcodes"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// EmojisGETHandler swagger:operation GET /api/v1/user/emojis userEmojisGet
+//
+// List the personal custom emojis owned by the authenticated user.
+//
+//	---
+//	tags:
+//	- user
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			description: Personal emojis owned by the authenticated user, sorted by shortcode.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/personalEmoji"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) EmojisGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	emojis, errWithCode := m.processor.Account().PersonalEmojisGet(c.Request.Context(), authed.Account)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, emojis)
+}
+
+// EmojiCreatePOSTHandler swagger:operation POST /api/v1/user/emojis userEmojiCreate
+//
+// Upload and create a new personal custom emoji, owned by the authenticated user.
+//
+// Personal emojis can only be used in their owner's own statuses and display name.
+// The given shortcode will be prefixed with the user's username and an underscore,
+// eg., `blobcat` uploaded by `alice` becomes `alice_blobcat`.
+//
+// The number of personal emojis each user can have, and their maximum size,
+// are set by the instance admin.
+//
+//	---
+//	tags:
+//	- user
+//
+//	consumes:
+//	- multipart/form-data
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: shortcode
+//		in: formData
+//		description: >-
+//			The code to use for the emoji, without the username prefix.
+//			Including the prefix, the shortcode must be 30 characters or less.
+//		type: string
+//		pattern: \w{2,30}
+//		required: true
+//	-
+//		name: image
+//		in: formData
+//		description: A png or gif image of the emoji. Animated pngs work too!
+//		type: file
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:accounts
+//
+//	responses:
+//		'200':
+//			description: The newly-created emoji.
+//			schema:
+//				"$ref": "#/definitions/personalEmoji"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden -- personal emojis are not enabled on this instance
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: conflict -- shortcode for this emoji is already in use
+//		'422':
+//			description: unprocessable -- maximum number of personal emojis reached, or image could not be processed
+//		'500':
+//			description: internal server error
+func (m *Module) EmojiCreatePOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.PersonalEmojiCreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if err := validateCreatePersonalEmoji(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	apiEmoji, errWithCode := m.processor.Account().PersonalEmojiCreate(c.Request.Context(), authed.Account, form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, apiEmoji)
+}
+
+// EmojiDELETEHandler swagger:operation DELETE /api/v1/user/emojis/{id} userEmojiDelete
+//
+// Delete one personal custom emoji owned by the authenticated user.
+//
+// Statuses that used the emoji will no longer show it.
+//
+//	---
+//	tags:
+//	- user
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: The id of the emoji.
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:accounts
+//
+//	responses:
+//		'200':
+//			description: The deleted emoji.
+//			schema:
+//				"$ref": "#/definitions/personalEmoji"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) EmojiDELETEHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	emojiID := c.Param(IDKey)
+	if emojiID == "" {
+		err := errors.New("no emoji id specified")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	apiEmoji, errWithCode := m.processor.Account().PersonalEmojiDelete(c.Request.Context(), authed.Account, emojiID)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, apiEmoji)
+}
+
+func validateCreatePersonalEmoji(form *apimodel.PersonalEmojiCreateRequest) error {
+	if form.Image == nil || form.Image.Size == 0 {
+		return errors.New("no emoji given")
+	}
+
+	maxSize := config.GetAccountsEmojiMaxSize()
+	if localMax := config.GetMediaEmojiLocalMaxSize(); localMax < maxSize {
+		// Personal emojis still go through
+		// the normal local emoji processing.
+		maxSize = localMax
+	}
+
+	if form.Image.Size > int64(maxSize) {
+		err := fmt.Errorf("emoji image too large: image is %dKB but size limit for personal emojis is %dKB", form.Image.Size/1024, maxSize/1024)
+		return errorcodes.Set(err, errorcodes.MediaTooLarge)
+	}
+
+	return validate.EmojiShortcode(form.Shortcode)
+}
@@ -0,0 +1,173 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type EmojiTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *EmojiTestSuite) newContext(recorder *httptest.ResponseRecorder, method string, path string, body []byte, contentType string) *gin.Context {
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(method, fmt.Sprintf("http://localhost:8080%s", path), bytes.NewReader(body))
+	ctx.Request.Header.Set("accept", "application/json")
+	if contentType != "" {
+		ctx.Request.Header.Set("Content-Type", contentType)
+	}
+	return ctx
+}
+
+func (suite *EmojiTestSuite) createEmoji(shortcode string) *httptest.ResponseRecorder {
+	requestBody, w, err := testrig.CreateMultipartFormData(
+		"image", "../../../../testrig/media/rainbow-original.png",
+		map[string]string{
+			"shortcode": shortcode,
+		})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, user.EmojisPath, requestBody.Bytes(), w.FormDataContentType())
+	suite.userModule.EmojiCreatePOSTHandler(ctx)
+	return recorder
+}
+
+func (suite *EmojiTestSuite) TestCreateListDelete() {
+	recorder := suite.createEmoji("blobcat")
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	created := &apimodel.PersonalEmoji{}
+	if err := json.NewDecoder(recorder.Body).Decode(created); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("the_mighty_zork_blobcat", created.Shortcode)
+	suite.NotEmpty(created.ID)
+
+	// Emoji should be owned by the
+	// account, and not in the picker.
+	dbEmoji, err := suite.db.GetEmojiByID(context.Background(), created.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(suite.testAccounts["local_account_1"].ID, dbEmoji.AccountID)
+
+	useable, err := suite.db.GetUseableEmojis(context.Background())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	for _, emoji := range useable {
+		suite.NotEqual(created.ID, emoji.ID)
+	}
+
+	// List should contain the new emoji.
+	recorder = httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, user.EmojisPath, nil, "")
+	suite.userModule.EmojisGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	listed := []*apimodel.PersonalEmoji{}
+	if err := json.NewDecoder(recorder.Body).Decode(&listed); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(listed, 1) {
+		suite.Equal(created.ID, listed[0].ID)
+	}
+
+	// Delete the emoji again.
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodDelete, user.EmojisPath+"/"+created.ID, nil, "")
+	ctx.AddParam(user.IDKey, created.ID)
+	suite.userModule.EmojiDELETEHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	_, err = suite.db.GetEmojiByID(context.Background(), created.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *EmojiTestSuite) TestCreateDisabled() {
+	config.SetAccountsMaxEmojis(0)
+
+	recorder := suite.createEmoji("blobcat")
+	suite.Equal(http.StatusForbidden, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	suite.NoError(err)
+	suite.Contains(string(b), "personal emojis are not enabled on this instance")
+}
+
+func (suite *EmojiTestSuite) TestCreateQuotaReached() {
+	config.SetAccountsMaxEmojis(1)
+
+	recorder := suite.createEmoji("blobcat")
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	recorder = suite.createEmoji("blobfox")
+	suite.Equal(http.StatusUnprocessableEntity, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	suite.NoError(err)
+	suite.Contains(string(b), "you already have the maximum of 1 personal emojis")
+}
+
+func (suite *EmojiTestSuite) TestCreateShortcodeTooLong() {
+	// the_mighty_zork_ + 15 chars = 31 chars.
+	recorder := suite.createEmoji("blobcat_waving_")
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (suite *EmojiTestSuite) TestDeleteNotOwned() {
+	emojiID := testrig.NewTestEmojis()["rainbow"].ID
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodDelete, user.EmojisPath+"/"+emojiID, nil, "")
+	ctx.AddParam(user.IDKey, emojiID)
+	suite.userModule.EmojiDELETEHandler(ctx)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+
+	// Instance emoji should still be there.
+	_, err := suite.db.GetEmojiByID(context.Background(), emojiID)
+	suite.NoError(err)
+}
+
+func TestEmojiTestSuite(t *testing.T) {
+	suite.Run(t, &EmojiTestSuite{})
+}
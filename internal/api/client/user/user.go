@@ -29,6 +29,27 @@ const (
 	BasePath = "/v1/user"
 	// PasswordChangePath is the path for POSTing a password change request.
 	PasswordChangePath = BasePath + "/password_change"
+	// EmojisPath is the path for listing and creating personal emojis.
+	EmojisPath = BasePath + "/emojis"
+	// EmojiPathWithID is the path for deleting one personal emoji.
+	EmojiPathWithID = EmojisPath + "/:" + IDKey
+	// WebAuthnRegisterBeginPath is the path for starting registration of a new passkey.
+	WebAuthnRegisterBeginPath = BasePath + "/webauthn/register/begin"
+	// WebAuthnRegisterFinishPath is the path for finishing registration of a new passkey.
+	WebAuthnRegisterFinishPath = BasePath + "/webauthn/register/finish"
+	// WebAuthnCredentialsPath is the path for listing passkeys.
+	WebAuthnCredentialsPath = BasePath + "/webauthn/credentials"
+	// WebAuthnCredentialPathWithID is the path for removing one passkey.
+	WebAuthnCredentialPathWithID = WebAuthnCredentialsPath + "/:" + IDKey
+	// WebAuthnPasswordLoginPath is the path for checking and setting whether password login is enabled.
+	WebAuthnPasswordLoginPath = BasePath + "/webauthn/password_login"
+	// WebSessionsPath is the path for listing and revoking web sessions.
+	WebSessionsPath = BasePath + "/sessions"
+	// WebSessionPathWithID is the path for revoking one web session.
+	WebSessionPathWithID = WebSessionsPath + "/:" + IDKey
+
+	// IDKey is the key for the ID of a personal emoji, passkey or web session in request paths.
+	IDKey = "id"
 )
 
 type Module struct {
@@ -43,4 +64,16 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodPost, PasswordChangePath, m.PasswordChangePOSTHandler)
+	attachHandler(http.MethodGet, EmojisPath, m.EmojisGETHandler)
+	attachHandler(http.MethodPost, EmojisPath, m.EmojiCreatePOSTHandler)
+	attachHandler(http.MethodDelete, EmojiPathWithID, m.EmojiDELETEHandler)
+	attachHandler(http.MethodPost, WebAuthnRegisterBeginPath, m.WebAuthnRegisterBeginPOSTHandler)
+	attachHandler(http.MethodPost, WebAuthnRegisterFinishPath, m.WebAuthnRegisterFinishPOSTHandler)
+	attachHandler(http.MethodGet, WebAuthnCredentialsPath, m.WebAuthnCredentialsGETHandler)
+	attachHandler(http.MethodDelete, WebAuthnCredentialPathWithID, m.WebAuthnCredentialDELETEHandler)
+	attachHandler(http.MethodGet, WebAuthnPasswordLoginPath, m.WebAuthnPasswordLoginGETHandler)
+	attachHandler(http.MethodPost, WebAuthnPasswordLoginPath, m.WebAuthnPasswordLoginPOSTHandler)
+	attachHandler(http.MethodGet, WebSessionsPath, m.WebSessionsGETHandler)
+	attachHandler(http.MethodDelete, WebSessionsPath, m.WebSessionsDELETEHandler)
+	attachHandler(http.MethodDelete, WebSessionPathWithID, m.WebSessionDELETEHandler)
 }
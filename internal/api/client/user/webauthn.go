@@ -0,0 +1,425 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// WebAuthnRegisterBeginPOSTHandler swagger:operation POST /api/v1/user/webauthn/register/begin userWebAuthnRegisterBegin
+//
+// Start registering a new passkey (WebAuthn credential) for the authenticated user.
+//
+// The returned options should be passed to `navigator.credentials.create()` as `publicKey`,
+// after decoding the base64url encoded `challenge`, `user.id`, and `excludeCredentials[].id`.
+// The registration must then be finished within 5 minutes.
+//
+//	---
+//	tags:
+//	- user
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:accounts
+//
+//	responses:
+//		'200':
+//			description: Options for creating the new credential.
+//			schema:
+//				"$ref": "#/definitions/webAuthnCreationOptions"
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden -- sign in is handled by an external OIDC provider on this instance
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) WebAuthnRegisterBeginPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	options, errWithCode := m.processor.User().WebAuthnRegisterBegin(c.Request.Context(), authed.User, authed.Account)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, options)
+}
+
+// WebAuthnRegisterFinishPOSTHandler swagger:operation POST /api/v1/user/webauthn/register/finish userWebAuthnRegisterFinish
+//
+// Finish registering a new passkey (WebAuthn credential) for the authenticated user.
+//
+// All binary values must be base64url encoded. The public key and authenticator data
+// are those returned by `getPublicKey()` and `getAuthenticatorData()` on the response
+// of the newly created credential.
+//
+//	---
+//	tags:
+//	- user
+//
+//	consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: name
+//		in: formData
+//		description: Name to give the new passkey, eg., 'phone'. 64 characters or less.
+//		type: string
+//		required: true
+//	-
+//		name: id
+//		in: formData
+//		description: Raw ID of the new credential.
+//		type: string
+//		required: true
+//	-
+//		name: client_data_json
+//		in: formData
+//		description: Client data JSON of the new credential.
+//		type: string
+//		required: true
+//	-
+//		name: authenticator_data
+//		in: formData
+//		description: Authenticator data of the new credential.
+//		type: string
+//		required: true
+//	-
+//		name: public_key
+//		in: formData
+//		description: DER encoded SubjectPublicKeyInfo of the new credential.
+//		type: string
+//		required: true
+//	-
+//		name: public_key_algorithm
+//		in: formData
+//		description: COSE algorithm identifier of the public key.
+//		type: integer
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:accounts
+//
+//	responses:
+//		'200':
+//			description: The newly registered passkey.
+//			schema:
+//				"$ref": "#/definitions/webAuthnCredential"
+//		'400':
+//			description: bad request -- no registration in progress, or the credential could not be verified
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden -- sign in is handled by an external OIDC provider on this instance
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: conflict -- this passkey is already registered
+//		'500':
+//			description: internal server error
+func (m *Module) WebAuthnRegisterFinishPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.WebAuthnRegisterRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	credential, errWithCode := m.processor.User().WebAuthnRegisterFinish(c.Request.Context(), authed.User, form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, credential)
+}
+
+// WebAuthnCredentialsGETHandler swagger:operation GET /api/v1/user/webauthn/credentials userWebAuthnCredentialsGet
+//
+// List the passkeys (WebAuthn credentials) registered by the authenticated user.
+//
+//	---
+//	tags:
+//	- user
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			description: Passkeys of the authenticated user, oldest first.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/webAuthnCredential"
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) WebAuthnCredentialsGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	credentials, errWithCode := m.processor.User().WebAuthnCredentialsGet(c.Request.Context(), authed.User)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, credentials)
+}
+
+// WebAuthnCredentialDELETEHandler swagger:operation DELETE /api/v1/user/webauthn/credentials/{id} userWebAuthnCredentialDelete
+//
+// Remove one passkey (WebAuthn credential) registered by the authenticated user.
+//
+// The last passkey cannot be removed while password login is disabled.
+//
+//	---
+//	tags:
+//	- user
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: The id of the passkey.
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:accounts
+//
+//	responses:
+//		'200':
+//			description: The removed passkey.
+//			schema:
+//				"$ref": "#/definitions/webAuthnCredential"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable -- this is the last passkey and password login is disabled
+//		'500':
+//			description: internal server error
+func (m *Module) WebAuthnCredentialDELETEHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	credentialID := c.Param(IDKey)
+	if credentialID == "" {
+		err := errors.New("no passkey id specified")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	credential, errWithCode := m.processor.User().WebAuthnCredentialDelete(c.Request.Context(), authed.User, credentialID)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, credential)
+}
+
+// WebAuthnPasswordLoginGETHandler swagger:operation GET /api/v1/user/webauthn/password_login userWebAuthnPasswordLoginGet
+//
+// Check whether the authenticated user can still sign in with their password.
+//
+//	---
+//	tags:
+//	- user
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			description: Whether password login is enabled.
+//			schema:
+//				"$ref": "#/definitions/webAuthnPasswordLogin"
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+func (m *Module) WebAuthnPasswordLoginGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, m.processor.User().WebAuthnPasswordLoginGet(c.Request.Context(), authed.User))
+}
+
+// WebAuthnPasswordLoginPOSTHandler swagger:operation POST /api/v1/user/webauthn/password_login userWebAuthnPasswordLoginSet
+//
+// Enable or disable signing in with a password for the authenticated user.
+//
+// Password login can only be disabled once at least one passkey has been registered.
+// If all passkeys are lost, an admin can clear them using the CLI, which re-enables password login.
+//
+//	---
+//	tags:
+//	- user
+//
+//	consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: enabled
+//		in: formData
+//		description: Whether password login should be enabled.
+//		type: boolean
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:accounts
+//
+//	responses:
+//		'200':
+//			description: Whether password login is now enabled.
+//			schema:
+//				"$ref": "#/definitions/webAuthnPasswordLogin"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden -- sign in is handled by an external OIDC provider on this instance
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable -- no passkey registered yet
+//		'500':
+//			description: internal server error
+func (m *Module) WebAuthnPasswordLoginPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.WebAuthnPasswordLoginRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if form.Enabled == nil {
+		err := errors.New("enabled must be set")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	passwordLogin, errWithCode := m.processor.User().WebAuthnPasswordLoginSet(c.Request.Context(), authed.User, *form.Enabled)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, passwordLogin)
+}
@@ -0,0 +1,241 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type WebAuthnTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *WebAuthnTestSuite) newContext(recorder *httptest.ResponseRecorder, method string, path string, body any) *gin.Context {
+	var b []byte
+	if body != nil {
+		var err error
+		if b, err = json.Marshal(body); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(method, fmt.Sprintf("http://localhost:8080%s", path), bytes.NewReader(b))
+	ctx.Request.Header.Set("accept", "application/json")
+	if body != nil {
+		ctx.Request.Header.Set("Content-Type", "application/json")
+	}
+	return ctx
+}
+
+// register registers a new passkey with a fresh
+// P-256 key, pretending to be the browser.
+func (suite *WebAuthnTestSuite) register(name string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, user.WebAuthnRegisterBeginPath, nil)
+	suite.userModule.WebAuthnRegisterBeginPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	options := &apimodel.WebAuthnCreationOptions{}
+	if err := json.NewDecoder(recorder.Body).Decode(options); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("localhost", options.RP.ID)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	credentialID := make([]byte, 16)
+	if _, err := rand.Read(credentialID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	clientDataJSON, err := json.Marshal(map[string]string{
+		"type":      "webauthn.create",
+		"challenge": options.Challenge,
+		"origin":    webauthn.Origin(),
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	rpIDHash := sha256.Sum256([]byte(options.RP.ID))
+	authData := append([]byte{}, rpIDHash[:]...)
+	authData = append(authData, 0x41) // user present, attested credential data
+	authData = binary.BigEndian.AppendUint32(authData, 0)
+	authData = append(authData, make([]byte, 16)...)
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(credentialID)))
+	authData = append(authData, credentialID...)
+
+	encode := base64.RawURLEncoding.EncodeToString
+
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodPost, user.WebAuthnRegisterFinishPath, &apimodel.WebAuthnRegisterRequest{
+		Name:               name,
+		ID:                 encode(credentialID),
+		ClientDataJSON:     encode(clientDataJSON),
+		AuthenticatorData:  encode(authData),
+		PublicKey:          encode(publicKey),
+		PublicKeyAlgorithm: webauthn.AlgES256,
+	})
+	suite.userModule.WebAuthnRegisterFinishPOSTHandler(ctx)
+	return recorder
+}
+
+func (suite *WebAuthnTestSuite) setPasswordLogin(enabled bool) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, user.WebAuthnPasswordLoginPath, map[string]bool{"enabled": enabled})
+	suite.userModule.WebAuthnPasswordLoginPOSTHandler(ctx)
+	return recorder
+}
+
+func (suite *WebAuthnTestSuite) deleteCredential(id string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodDelete, user.WebAuthnCredentialsPath+"/"+id, nil)
+	ctx.AddParam(user.IDKey, id)
+	suite.userModule.WebAuthnCredentialDELETEHandler(ctx)
+	return recorder
+}
+
+func (suite *WebAuthnTestSuite) TestRegisterListDelete() {
+	recorder := suite.register("phone")
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	created := &apimodel.WebAuthnCredential{}
+	if err := json.NewDecoder(recorder.Body).Decode(created); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("phone", created.Name)
+	suite.Nil(created.LastUsedAt)
+
+	// List should contain the new passkey.
+	recorder = httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, user.WebAuthnCredentialsPath, nil)
+	suite.userModule.WebAuthnCredentialsGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	listed := []*apimodel.WebAuthnCredential{}
+	if err := json.NewDecoder(recorder.Body).Decode(&listed); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(listed, 1) {
+		suite.Equal(created.ID, listed[0].ID)
+	}
+
+	// Remove the passkey again.
+	recorder = suite.deleteCredential(created.ID)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	credentials, err := suite.db.GetWebAuthnCredentialsByUserID(context.Background(), suite.testUsers["local_account_1"].ID)
+	suite.NoError(err)
+	suite.Empty(credentials)
+}
+
+func (suite *WebAuthnTestSuite) TestRegisterFinishWithoutBegin() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, user.WebAuthnRegisterFinishPath, &apimodel.WebAuthnRegisterRequest{
+		Name: "phone",
+	})
+	suite.userModule.WebAuthnRegisterFinishPOSTHandler(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	suite.NoError(err)
+	suite.Contains(string(b), "no passkey registration in progress")
+}
+
+func (suite *WebAuthnTestSuite) TestDisablePasswordLoginWithoutPasskey() {
+	recorder := suite.setPasswordLogin(false)
+	suite.Equal(http.StatusUnprocessableEntity, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	suite.NoError(err)
+	suite.Contains(string(b), "register a passkey before disabling password login")
+}
+
+func (suite *WebAuthnTestSuite) TestDisablePasswordLoginKeepsLastPasskey() {
+	recorder := suite.register("phone")
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	created := &apimodel.WebAuthnCredential{}
+	if err := json.NewDecoder(recorder.Body).Decode(created); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	recorder = suite.setPasswordLogin(false)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	dbUser, err := suite.db.GetUserByID(context.Background(), suite.testUsers["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*dbUser.PasswordLoginDisabled)
+
+	// The only passkey can't be removed
+	// while password login is disabled.
+	recorder = suite.deleteCredential(created.ID)
+	suite.Equal(http.StatusUnprocessableEntity, recorder.Code)
+
+	recorder = suite.setPasswordLogin(true)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	recorder = suite.deleteCredential(created.ID)
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func (suite *WebAuthnTestSuite) TestDeleteNotFound() {
+	recorder := suite.deleteCredential("01H4Q5Y8M0XKJ7ZJ2B6PQW0S3D")
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func TestWebAuthnTestSuite(t *testing.T) {
+	suite.Run(t, &WebAuthnTestSuite{})
+}
@@ -0,0 +1,177 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type WebSessionTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *WebSessionTestSuite) newContext(recorder *httptest.ResponseRecorder, method string, path string) *gin.Context {
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(method, fmt.Sprintf("http://localhost:8080%s", path), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	return ctx
+}
+
+// putSession stores a new web session for the given user,
+// started the given time ago, along with a token
+// created through it, returning both.
+func (suite *WebSessionTestSuite) putSession(userID string, age time.Duration) (*gtsmodel.WebSession, *gtsmodel.Token) {
+	sessionID, err := id.NewULIDFromTime(time.Now().Add(-age))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	session := &gtsmodel.WebSession{
+		ID:        sessionID,
+		UserID:    userID,
+		IP:        net.ParseIP("192.0.2.1"),
+		UserAgent: "Mozilla/5.0 (X11; Linux x86_64; rv:109.0) Gecko/20100101 Firefox/115.0",
+	}
+	if err := suite.db.PutWebSession(context.Background(), session); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	token := &gtsmodel.Token{
+		ID:              id.NewULID(),
+		ClientID:        suite.testApplications["application_1"].ClientID,
+		UserID:          userID,
+		RedirectURI:     "http://localhost:8080",
+		Scope:           "read write",
+		Access:          id.NewULID(),
+		AccessCreateAt:  time.Now(),
+		AccessExpiresAt: time.Now().Add(time.Hour),
+		WebSessionID:    session.ID,
+	}
+	if err := suite.db.Put(context.Background(), token); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return session, token
+}
+
+func (suite *WebSessionTestSuite) getSessions() []*apimodel.WebSession {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, user.WebSessionsPath)
+	suite.userModule.WebSessionsGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	sessions := []*apimodel.WebSession{}
+	if err := json.NewDecoder(recorder.Body).Decode(&sessions); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return sessions
+}
+
+func (suite *WebSessionTestSuite) deleteSession(id string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodDelete, user.WebSessionsPath+"/"+id)
+	ctx.AddParam(user.IDKey, id)
+	suite.userModule.WebSessionDELETEHandler(ctx)
+	return recorder
+}
+
+func (suite *WebSessionTestSuite) tokenExists(tokenID string) bool {
+	err := suite.db.GetByID(context.Background(), tokenID, &gtsmodel.Token{})
+	if err == db.ErrNoEntries {
+		return false
+	}
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return true
+}
+
+func (suite *WebSessionTestSuite) TestListAndDelete() {
+	first, firstToken := suite.putSession(suite.testUsers["local_account_1"].ID, time.Hour)
+	second, secondToken := suite.putSession(suite.testUsers["local_account_1"].ID, time.Minute)
+
+	sessions := suite.getSessions()
+	suite.Len(sessions, 2)
+	suite.Equal(second.ID, sessions[0].ID)
+	suite.Equal(first.ID, sessions[1].ID)
+	suite.Equal("192.0.2.1", sessions[0].IP)
+	suite.Nil(sessions[0].LastSeenAt)
+	suite.False(sessions[0].IPChanged)
+	suite.False(sessions[0].Current)
+
+	recorder := suite.deleteSession(first.ID)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.False(suite.tokenExists(firstToken.ID))
+	suite.True(suite.tokenExists(secondToken.ID))
+
+	sessions = suite.getSessions()
+	suite.Len(sessions, 1)
+	suite.Equal(second.ID, sessions[0].ID)
+}
+
+func (suite *WebSessionTestSuite) TestDeleteOtherUsersSession() {
+	other, otherToken := suite.putSession(suite.testUsers["local_account_2"].ID, time.Minute)
+
+	recorder := suite.deleteSession(other.ID)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+	suite.True(suite.tokenExists(otherToken.ID))
+}
+
+func (suite *WebSessionTestSuite) TestLogOutEverywhere() {
+	_, firstToken := suite.putSession(suite.testUsers["local_account_1"].ID, time.Hour)
+	_, secondToken := suite.putSession(suite.testUsers["local_account_1"].ID, time.Minute)
+	_, otherToken := suite.putSession(suite.testUsers["local_account_2"].ID, time.Minute)
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodDelete, user.WebSessionsPath)
+	suite.userModule.WebSessionsDELETEHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	suite.Empty(suite.getSessions())
+	suite.False(suite.tokenExists(firstToken.ID))
+	suite.False(suite.tokenExists(secondToken.ID))
+	suite.True(suite.tokenExists(otherToken.ID))
+
+	// Tokens not created through a session are left alone.
+	suite.True(suite.tokenExists(suite.testTokens["local_account_1"].ID))
+}
+
+func TestWebSessionTestSuite(t *testing.T) {
+	suite.Run(t, &WebSessionTestSuite{})
+}
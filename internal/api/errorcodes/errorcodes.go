@@ -0,0 +1,146 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package errorcodes provides machine-readable codes for errors returned
+// by the API, served in the 'error_code' field of error responses alongside
+// the human-readable 'error' field. Clients can rely on these codes staying
+// stable, unlike the wording of the 'error' field, which may change.
+package errorcodes
+
+import (
+	"net/http"
+
+	"codeberg.org/gruf/go-errors/v2"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// Code is a machine-readable API error code.
+type Code string
+
+// Generic codes, used when no more specific code
+// has been set on an error, based on status code.
+const (
+	BadRequest          Code = "ERR_BAD_REQUEST"          // 400
+	AuthRequired        Code = "ERR_AUTH_REQUIRED"        // 401
+	Forbidden           Code = "ERR_FORBIDDEN"            // 403
+	NotFound            Code = "ERR_NOT_FOUND"            // 404
+	NotAcceptable       Code = "ERR_NOT_ACCEPTABLE"       // 406
+	Conflict            Code = "ERR_CONFLICT"             // 409
+	Gone                Code = "ERR_GONE"                 // 410
+	PayloadTooLarge     Code = "ERR_PAYLOAD_TOO_LARGE"    // 413
+	UserAgentRequired   Code = "ERR_USER_AGENT_REQUIRED"  // 418
+	UnprocessableEntity Code = "ERR_UNPROCESSABLE_ENTITY" // 422
+	RateLimited         Code = "ERR_RATE_LIMITED"         // 429
+	Internal            Code = "ERR_INTERNAL"             // 500
+	ServiceUnavailable  Code = "ERR_SERVICE_UNAVAILABLE"  // 503
+	InsufficientStorage Code = "ERR_INSUFFICIENT_STORAGE" // 507
+	Unknown             Code = "ERR_UNKNOWN"              // any other status code
+)
+
+// Specific codes, set on errors using Set().
+const (
+	// ServerBusy indicates that the server is at capacity,
+	// and the request should be retried after waiting for
+	// the number of seconds given in Retry-After (429).
+	ServerBusy Code = "ERR_SERVER_BUSY"
+
+	// MediaTooLarge indicates that an uploaded file was
+	// larger than the configured size limit for its type (400).
+	MediaTooLarge Code = "ERR_MEDIA_TOO_LARGE"
+
+	// StatusAlreadyPinned indicates that a status
+	// couldn't be pinned as it already is (422).
+	StatusAlreadyPinned Code = "ERR_STATUS_ALREADY_PINNED"
+
+	// DuplicateStatus indicates that a status create request
+	// was given the same Idempotency-Key as an earlier request,
+	// which is still being processed (409).
+	DuplicateStatus Code = "ERR_DUPLICATE_STATUS"
+
+	// PinLimitReached indicates that a status couldn't be pinned
+	// as the account has already pinned the max number of statuses (422).
+	PinLimitReached Code = "ERR_PIN_LIMIT_REACHED"
+
+	// Maintenance indicates that the instance is in maintenance
+	// mode, and the request should be retried after waiting for
+	// the number of seconds given in Retry-After (503).
+	Maintenance Code = "ERR_MAINTENANCE"
+)
+
+// package private error key type.
+type errkey struct{}
+
+// Set will wrap the given error to store an API error code,
+// returning wrapped error. This code will be served instead
+// of the generic code for the status when the error (or an
+// error wrapping it) is passed to an API error handler.
+func Set(err error, code Code) error {
+	return errors.WithValue(err, errkey{}, code)
+}
+
+// Get checks error for a stored API error code,
+// returning an empty code if none is stored.
+func Get(err error) Code {
+	c, _ := errors.Value(err, errkey{}).(Code)
+	return c
+}
+
+// For returns the API error code to serve for errWithCode: either
+// the code stored on the wrapped error using Set(), or the generic
+// code for the error's status code if none is stored.
+func For(errWithCode gtserror.WithCode) Code {
+	if c := Get(errWithCode.Unwrap()); c != "" {
+		return c
+	}
+	return FromStatus(errWithCode.Code())
+}
+
+// FromStatus returns the generic API error code for the given HTTP status code.
+func FromStatus(status int) Code {
+	switch status {
+	case http.StatusBadRequest:
+		return BadRequest
+	case http.StatusUnauthorized:
+		return AuthRequired
+	case http.StatusForbidden:
+		return Forbidden
+	case http.StatusNotFound:
+		return NotFound
+	case http.StatusNotAcceptable:
+		return NotAcceptable
+	case http.StatusConflict:
+		return Conflict
+	case http.StatusGone:
+		return Gone
+	case http.StatusRequestEntityTooLarge:
+		return PayloadTooLarge
+	case http.StatusTeapot:
+		return UserAgentRequired
+	case http.StatusUnprocessableEntity:
+		return UnprocessableEntity
+	case http.StatusTooManyRequests:
+		return RateLimited
+	case http.StatusInternalServerError:
+		return Internal
+	case http.StatusServiceUnavailable:
+		return ServiceUnavailable
+	case http.StatusInsufficientStorage:
+		return InsufficientStorage
+	default:
+		return Unknown
+	}
+}
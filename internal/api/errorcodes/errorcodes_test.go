@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package errorcodes_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/api/errorcodes"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+func TestFor(t *testing.T) {
+	for _, test := range []struct {
+		err    gtserror.WithCode
+		expect errorcodes.Code
+	}{
+		{
+			err:    gtserror.NewErrorBadRequest(errors.New("oh no")),
+			expect: errorcodes.BadRequest,
+		},
+		{
+			err:    gtserror.NewErrorUnauthorized(errors.New("oh no")),
+			expect: errorcodes.AuthRequired,
+		},
+		{
+			err:    gtserror.NewErrorClientClosedRequest(errors.New("oh no")),
+			expect: errorcodes.Unknown,
+		},
+		{
+			err:    gtserror.NewErrorBadRequest(errorcodes.Set(errors.New("oh no"), errorcodes.MediaTooLarge)),
+			expect: errorcodes.MediaTooLarge,
+		},
+		{
+			// Code should still be found when wrapped further.
+			err: gtserror.NewErrorBadRequest(fmt.Errorf("wrapped: %w",
+				errorcodes.Set(errors.New("oh no"), errorcodes.MediaTooLarge),
+			)),
+			expect: errorcodes.MediaTooLarge,
+		},
+	} {
+		if code := errorcodes.For(test.err); code != test.expect {
+			t.Errorf("expected %s for %q (status %d), got %s", test.expect, test.err.Error(), test.err.Code(), code)
+		}
+	}
+}
+
+func TestSetPreservesError(t *testing.T) {
+	err := errors.New("oh no")
+	wrapped := errorcodes.Set(err, errorcodes.RateLimited)
+
+	if wrapped.Error() != err.Error() {
+		t.Errorf("expected error string %q, got %q", err.Error(), wrapped.Error())
+	}
+
+	if !errors.Is(wrapped, err) {
+		t.Error("expected wrapped error to match original")
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
 type Fileserver struct {
@@ -51,6 +52,17 @@ func (f *Fileserver) Route(r router.Router, m ...gin.HandlerFunc) {
 	}
 
 	f.fileserver.Route(fileserverGroup.Handle)
+
+	if !config.GetMediaProxyEnabled() {
+		return
+	}
+
+	proxyGroup := r.AttachGroup(uris.ProxyPath)
+	proxyGroup.Use(m...)
+	// Proxied images are only kept while they're
+	// being viewed, so they may be re-fetched at the
+	// same URL; cache control is set in the handler.
+	f.fileserver.RouteProxy(proxyGroup.Handle)
 }
 
 func NewFileserver(p *processing.Processor) *Fileserver {
@@ -35,6 +35,14 @@ const (
 	FileNameKey = "file_name"
 	// FileServePath is the fileserve path minus the 'fileserver' prefix.
 	FileServePath = "/:" + AccountIDKey + "/:" + MediaTypeKey + "/:" + MediaSizeKey + "/:" + FileNameKey
+	// ProxyOwnerIDKey is the url key for the id of the status or account a proxied image was found in
+	ProxyOwnerIDKey = "owner_id"
+	// ProxyURLKey is the url key for the base64 encoded url of a proxied image
+	ProxyURLKey = "url"
+	// ProxySignatureKey is the url key for the base64 encoded signature of a proxied image owner + url
+	ProxySignatureKey = "signature"
+	// ProxyServePath is the proxied image serve path minus the 'proxy' prefix.
+	ProxyServePath = "/:" + ProxyOwnerIDKey + "/:" + ProxyURLKey + "/:" + ProxySignatureKey
 )
 
 type Module struct {
@@ -51,3 +59,9 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, FileServePath, m.ServeFile)
 	attachHandler(http.MethodHead, FileServePath, m.ServeFile)
 }
+
+// RouteProxy attaches handlers for serving proxied external images.
+func (m *Module) RouteProxy(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	attachHandler(http.MethodGet, ProxyServePath, m.ServeProxiedImage)
+	attachHandler(http.MethodHead, ProxyServePath, m.ServeProxiedImage)
+}
@@ -0,0 +1,96 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package fileserver
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// proxiedImageMaxAge is how long clients may cache proxied images
+// for; this matches how long they're kept in storage without access.
+const proxiedImageMaxAge = 7 * 24 * time.Hour
+
+// ServeProxiedImage is for serving external images, embedded in remote
+// status content, to the requester via this instance's image proxy.
+//
+// As with ServeFile, no information is given out on a bad request except "404 page not found".
+func (m *Module) ServeProxiedImage(c *gin.Context) {
+	ownerID := c.Param(ProxyOwnerIDKey)
+	if ownerID == "" {
+		err := errors.New("missing " + ProxyOwnerIDKey + " from request")
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotFound(err), m.processor.InstanceGetV1)
+		return
+	}
+
+	encoded := c.Param(ProxyURLKey)
+	if encoded == "" {
+		err := errors.New("missing " + ProxyURLKey + " from request")
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotFound(err), m.processor.InstanceGetV1)
+		return
+	}
+
+	signature := c.Param(ProxySignatureKey)
+	if signature == "" {
+		err := errors.New("missing " + ProxySignatureKey + " from request")
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotFound(err), m.processor.InstanceGetV1)
+		return
+	}
+
+	// Acquire context from gin request.
+	ctx := c.Request.Context()
+
+	content, errWithCode := m.processor.Media().GetProxiedImage(ctx, ownerID, encoded, signature)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	defer func() {
+		// Close content when we're done, catch errors.
+		if err := content.Content.Close(); err != nil {
+			log.Errorf(ctx, "ServeProxiedImage: error closing readcloser: %s", err)
+		}
+	}()
+
+	format, err := apiutil.NegotiateAccept(c, apiutil.MIME(content.ContentType))
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	c.Header("Cache-Control", "public,max-age="+strconv.Itoa(int(proxiedImageMaxAge.Seconds())))
+	c.Header("Expires", time.Now().Add(proxiedImageMaxAge).UTC().Format(http.TimeFormat))
+
+	// if this is a head request, just return info + throw the reader away
+	if c.Request.Method == http.MethodHead {
+		c.Header("Content-Type", format)
+		c.Header("Content-Length", strconv.FormatInt(content.ContentLength, 10))
+		c.Status(http.StatusOK)
+		return
+	}
+
+	c.DataFromReader(http.StatusOK, content.ContentLength, format, content.Content, nil)
+}
@@ -93,6 +93,9 @@ type Account struct {
 	CustomCSS string `json:"custom_css,omitempty"`
 	// Account has enabled RSS feed.
 	EnableRSS bool `json:"enable_rss,omitempty"`
+	// Account has asked for its profile and statuses
+	// to be shown without link previews, and not indexed.
+	HideLinkPreviews bool `json:"hide_link_previews,omitempty"`
 	// Role of the account on this instance.
 	// Omitted for remote accounts.
 	Role *AccountRole `json:"role,omitempty"`
@@ -163,6 +166,8 @@ type UpdateCredentialsRequest struct {
 	CustomCSS *string `form:"custom_css" json:"custom_css"`
 	// Enable RSS feed of public toots for this account at /@[username]/feed.rss
 	EnableRSS *bool `form:"enable_rss" json:"enable_rss"`
+	// Domains of websites allowed to credit this account as the author of their pages.
+	AttributionDomains *[]string `form:"attribution_domains[]" json:"attribution_domains"`
 }
 
 // UpdateSource is to be used specifically in an UpdateCredentialsRequest.
@@ -177,6 +182,12 @@ type UpdateSource struct {
 	Language *string `form:"language" json:"language"`
 	// Default format for authored statuses (text/plain or text/markdown).
 	StatusContentType *string `form:"status_content_type" json:"status_content_type"`
+	// Don't generate link previews of this account's profile and statuses,
+	// and ask search engines not to index them.
+	HideLinkPreviews *bool `form:"hide_link_previews" json:"hide_link_previews"`
+	// Which accounts are allowed to mention this account
+	// (anyone, followed, followers, or nobody).
+	MentionPolicy *string `form:"mention_policy" json:"mention_policy"`
 }
 
 // UpdateField is to be used specifically in an UpdateCredentialsRequest.
@@ -213,6 +224,15 @@ type AccountDeleteRequest struct {
 	Password string `form:"password" json:"password" xml:"password"`
 }
 
+// AccountCount models the number of
+// followers or followed accounts of an account.
+//
+// swagger:model accountCount
+type AccountCount struct {
+	// The number of accounts.
+	Count int `json:"count"`
+}
+
 // AccountRole models the role of an account.
 //
 // swagger:model accountRole
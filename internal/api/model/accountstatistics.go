@@ -0,0 +1,74 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AccountStatistics models statistics about the
+// requesting account's own activity and reach.
+//
+// swagger:model accountStatistics
+type AccountStatistics struct {
+	// Number of statuses posted by the account, including boosts.
+	// example: 1337
+	StatusesCount int `json:"statuses_count"`
+	// Number of times statuses of the account were favourited by others.
+	// example: 420
+	FavouritesReceived int `json:"favourites_received"`
+	// Number of times statuses of the account were boosted by others.
+	// example: 69
+	ReblogsReceived int `json:"reblogs_received"`
+	// Number of current followers who started following the account in the past 7 days.
+	// example: 5
+	FollowersGainedWeek int `json:"followers_gained_week"`
+	// Hashtags most used by the account, most used first.
+	TopHashtags []AccountStatisticsHashtag `json:"top_hashtags"`
+}
+
+// AccountStatisticsHashtag models how many
+// statuses of an account used one hashtag.
+//
+// swagger:model accountStatisticsHashtag
+type AccountStatisticsHashtag struct {
+	// Name of the hashtag, without the leading #.
+	// example: caturday
+	Name string `json:"name"`
+	// Number of statuses of the account using the hashtag.
+	// example: 12
+	Count int `json:"count"`
+}
+
+// AccountStatisticsMonth models statistics about
+// the requesting account's activity in one month.
+//
+// swagger:model accountStatisticsMonth
+type AccountStatisticsMonth struct {
+	// The month, in YYYY-MM format (UTC).
+	// example: 2023-07
+	Month string `json:"month"`
+	// Number of statuses posted by the account in this month, including boosts.
+	// example: 42
+	StatusesCount int `json:"statuses_count"`
+	// Number of times statuses of the account were favourited by others in this month.
+	// example: 17
+	FavouritesReceived int `json:"favourites_received"`
+	// Number of times statuses of the account were boosted by others in this month.
+	// example: 3
+	ReblogsReceived int `json:"reblogs_received"`
+	// Number of current followers who started following the account in this month.
+	// example: 2
+	FollowersGained int `json:"followers_gained"`
+}
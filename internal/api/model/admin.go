@@ -161,16 +161,25 @@ type AdminEmoji struct {
 	// The ActivityPub URI of the emoji.
 	// example: https://example.org/emojis/016T5Q3SQKBT337DAKVSKNXXW1
 	URI string `json:"uri"`
+	// ID of the local account that owns this emoji, if it's a personal emoji. Otherwise key will not be set.
+	// example: 01GEM7SFDZ7GZNRXFVZ3X4E4N1
+	OwnerAccountID string `json:"owner_account_id,omitempty"`
+	// Username of the local account that owns this emoji, if it's a personal emoji. Otherwise key will not be set.
+	// example: some_user
+	OwnerUsername string `json:"owner_username,omitempty"`
 }
 
 // AdminAccountActionRequest models the admin view of an account's details.
 //
 // swagger:ignore
 type AdminAccountActionRequest struct {
-	// Type of the account action. One of disable, silence, suspend.
+	// Type of the account action. One of disable, silence, suspend, unsilence, unsuspend.
 	Type string `form:"type" json:"type" xml:"type"`
 	// Text describing why an action was taken.
 	Text string `form:"text" json:"text" xml:"text"`
+	// Send an email to the target account's user explaining the action.
+	// Only applies to suspensions of local accounts. Defaults to true.
+	SendEmail *bool `form:"send_email" json:"send_email" xml:"send_email"`
 	// ID of the account to be acted on.
 	TargetAccountID string `form:"-" json:"-" xml:"-"`
 }
@@ -189,3 +198,185 @@ type AdminSendTestEmailRequest struct {
 	// Email address to send the test email to.
 	Email string `form:"email" json:"email" xml:"email"`
 }
+
+// AdminAccountMaxCharsRequest can be submitted along with a POST to /api/v1/admin/accounts/{id}/max_chars
+//
+// swagger:ignore
+type AdminAccountMaxCharsRequest struct {
+	// Max permitted characters for statuses posted by the account. 0 removes the override.
+	MaxChars *int `form:"max_chars" json:"max_chars" xml:"max_chars"`
+}
+
+// AdminAccountMediaRoleRequest can be submitted along with a POST to /api/v1/admin/accounts/{id}/media_role
+//
+// swagger:ignore
+type AdminAccountMediaRoleRequest struct {
+	// Role to look up the account's media limits with. Empty removes the role.
+	MediaRole *string `form:"media_role" json:"media_role" xml:"media_role"`
+}
+
+// AdminRegistrationRejectRequest can be submitted along with a POST to /api/v1/admin/registrations/{id}/reject
+//
+// swagger:ignore
+type AdminRegistrationRejectRequest struct {
+	// Reason for the rejection, to be emailed to the applicant.
+	Reason string `form:"reason" json:"reason" xml:"reason"`
+}
+
+// AdminEmailTestResult models the result of successfully sending a test email.
+//
+// swagger:model adminEmailTestResult
+type AdminEmailTestResult struct {
+	// Status of the test.
+	// example: test email sent
+	Status string `json:"status"`
+	// Transcript of each step of the conversation with the SMTP server.
+	Transcript string `json:"transcript"`
+}
+
+// AdminFailedEmail models an email which could not be sent after retrying.
+//
+// swagger:model adminFailedEmail
+type AdminFailedEmail struct {
+	// The ID of the email.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// When the email was first queued for sending (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Addresses the email was to be sent to.
+	To []string `json:"to"`
+	// Subject of the email.
+	Subject string `json:"subject"`
+	// Number of times sending the email was attempted.
+	Attempts int `json:"attempts"`
+	// Error returned by the last attempt at sending the email.
+	LastError string `json:"last_error"`
+	// When sending the email was given up on (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	FailedAt string `json:"failed_at"`
+}
+
+// AdminConfigReload models the result of reloading the instance configuration.
+//
+// swagger:model adminConfigReload
+type AdminConfigReload struct {
+	// Keys of configuration values that were changed and applied by the reload.
+	Changed []string `json:"changed"`
+	// Keys of configuration values that were changed, but which cannot be applied without a restart.
+	// These keep their previous value until the instance is restarted.
+	Rejected []string `json:"rejected"`
+}
+
+// AdminMediaUsage models a report of stored media, grouped by domain or account.
+//
+// swagger:model adminMediaUsage
+type AdminMediaUsage struct {
+	// How the usage entries are grouped: domain or account.
+	// example: domain
+	GroupBy string `json:"group_by"`
+	// Usage of each domain or account, largest first.
+	Usage []AdminMediaUsageEntry `json:"usage"`
+	// Files in storage which have no corresponding database entry.
+	// Only set if orphaned files were requested.
+	Orphaned *AdminMediaUsageEntry `json:"orphaned,omitempty"`
+}
+
+// AdminMediaUsageEntry models the stored media of one domain or account.
+//
+// swagger:model adminMediaUsageEntry
+type AdminMediaUsageEntry struct {
+	// Domain, or account ID, that this entry is for.
+	// Empty string for local media when grouped by domain.
+	// example: example.org
+	Key string `json:"key"`
+	// When grouped by account, the username@domain of the account.
+	// example: someone@example.org
+	Account string `json:"account,omitempty"`
+	// Number of media items: attachments, and emojis when grouped by domain.
+	// example: 420
+	Count int `json:"count"`
+	// Total size in bytes of the media items.
+	// example: 69420
+	Size int64 `json:"size"`
+}
+
+// AdminCohortRetention models how many users who signed up in each month
+// (a cohort) were active in each of the following months. Cohorts and their
+// active counts can be laid out as rows of a retention heat map.
+//
+// swagger:model adminCohortRetention
+type AdminCohortRetention struct {
+	// Months covered by the report, oldest first, in the form YYYY-MM (UTC).
+	// example: ["2023-07","2023-08"]
+	Months []string `json:"months"`
+	// One cohort for each month covered by the report, oldest first.
+	Cohorts []AdminRetentionCohort `json:"cohorts"`
+}
+
+// AdminRetentionCohort models the users who signed up in one month.
+//
+// swagger:model adminRetentionCohort
+type AdminRetentionCohort struct {
+	// Month in which users of this cohort signed up, in the form YYYY-MM (UTC).
+	// example: 2023-07
+	Period string `json:"period"`
+	// Number of approved users who signed up in this month.
+	// example: 10
+	Users int `json:"users"`
+	// Number of users from this cohort who were active in each month,
+	// starting with the month they signed up in and ending with the
+	// current month. A user is active in a month if they posted a
+	// status, or signed in, during that month.
+	// example: [10,6]
+	Active []int `json:"active"`
+}
+
+// AdminMaintenance models the maintenance mode state of the instance.
+//
+// swagger:model adminMaintenance
+type AdminMaintenance struct {
+	// Whether maintenance mode is turned on.
+	// example: true
+	Enabled bool `json:"enabled"`
+	// Message shown on the maintenance page, and in client API errors, while in maintenance mode.
+	// example: Migrating to a new server, back in an hour!
+	Message string `json:"message"`
+	// Number of federated activities received during maintenance mode,
+	// which are waiting to be processed once maintenance mode is turned off.
+	// example: 42
+	Backlog int `json:"backlog"`
+}
+
+// AdminMaintenanceRequest models a request to turn maintenance mode on or off.
+//
+// swagger:ignore
+type AdminMaintenanceRequest struct {
+	// Whether maintenance mode should be turned on.
+	Enabled *bool `form:"enabled" json:"enabled" xml:"enabled"`
+	// Message to show while in maintenance mode.
+	// If not set, the current message is kept.
+	Message *string `form:"message" json:"message" xml:"message"`
+}
+
+// AdminHashtag models the admin settings for one hashtag.
+//
+// swagger:model adminHashtag
+type AdminHashtag struct {
+	// Name of the hashtag, lowercase and without the hash part.
+	// example: spiders
+	Name string `json:"name"`
+	// Statuses created by local accounts using this hashtag must have a content warning (spoiler text).
+	ContentWarningRequired bool `json:"content_warning_required"`
+	// When the settings for this hashtag were last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	UpdatedAt string `json:"updated_at"`
+}
+
+// AdminHashtagRequireCWRequest can be submitted along with a POST to /api/v1/admin/hashtags/{tag}/require_cw
+//
+// swagger:ignore
+type AdminHashtagRequireCWRequest struct {
+	// Require a content warning on statuses using this hashtag. Defaults to true; set false to lift the requirement.
+	Required *bool `form:"required" json:"required" xml:"required"`
+}
@@ -87,9 +87,23 @@ type Attachment struct {
 	// Alt text that describes what is in the media attachment.
 	// example: This is a picture of a kitten.
 	Description *string `json:"description"`
+	// Suggested alt text for the media attachment, generated by the instance.
+	// Only set in the response to uploading an image without a description,
+	// when alt text suggestions are enabled on the instance. Clients should offer
+	// this to the user as a hint, since it is not used as the description unless confirmed.
+	// example: A kitten sitting on a windowsill.
+	DescriptionSuggestion *string `json:"description_suggestion,omitempty"`
 	// A hash computed by the BlurHash algorithm, for generating colorful preview thumbnails when media has not been downloaded yet.
 	// See https://github.com/woltapp/blurhash
 	Blurhash string `json:"blurhash,omitempty"`
+	// Processing status of the attachment. The preview, blurhash and
+	// small dimensions are only set once processing has succeeded.
+	// enum:
+	//   - processing
+	//   - succeeded
+	//   - failed
+	// example: succeeded
+	ProcessingStatus string `json:"processing_status"`
 }
 
 // MediaMeta models media metadata.
@@ -35,6 +35,7 @@ type Card struct {
 	// - link
 	// - photo
 	// - video
+	// - audio
 	// - rich
 	// example: link
 	Type string `json:"type"`
@@ -51,6 +52,7 @@ type Card struct {
 	// example: https://buzzfeed.com
 	ProviderURL string `json:"provider_url"`
 	// HTML to be used for generating the preview card.
+	// For video and audio cards, a sandboxed iframe embedding the media.
 	HTML string `json:"html"`
 	// Width of preview, in pixels.
 	Width int `json:"width"`
@@ -63,4 +65,20 @@ type Card struct {
 	EmbedURL string `json:"embed_url"`
 	// A hash computed by the BlurHash algorithm, for generating colorful preview thumbnails when media has not been downloaded yet.
 	Blurhash string `json:"blurhash"`
+	// Authors of the linked resource.
+	Authors []CardAuthor `json:"authors"`
+}
+
+// CardAuthor represents the author of a resource linked in a preview card.
+//
+// swagger:model cardAuthor
+type CardAuthor struct {
+	// Name of the author.
+	// example: Some Writer
+	Name string `json:"name"`
+	// A link to the author.
+	// example: https://buzzfeed.com/authors/weewee
+	URL string `json:"url"`
+	// Fediverse account of the author, if the linked resource credits one, and is allowed to.
+	Account *Account `json:"account"`
 }
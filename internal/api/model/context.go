@@ -25,4 +25,8 @@ type Context struct {
 	Ancestors []Status `json:"ancestors"`
 	// Children in the thread.
 	Descendants []Status `json:"descendants"`
+	// The thread has been truncated: the topmost status in
+	// the thread was detached from the status it replied to,
+	// because it was deeper than the instance's max thread depth.
+	Truncated bool `json:"truncated"`
 }
@@ -62,6 +62,15 @@ type DomainBlock struct {
 	// Time at which this block was created (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	CreatedAt string `json:"created_at,omitempty"`
+	// Severity of this block: `silence` or `suspend`.
+	// example: suspend
+	Severity string `json:"severity,omitempty"`
+	// Media from this domain is not fetched.
+	// example: false
+	RejectMedia bool `json:"reject_media,omitempty"`
+	// Reports federated from this domain are ignored.
+	// example: false
+	RejectReports bool `json:"reject_reports,omitempty"`
 }
 
 // DomainBlockCreateRequest is the form submitted as a POST to /api/v1/admin/domain_blocks to create a new block.
@@ -79,3 +88,61 @@ type DomainBlockCreateRequest struct {
 	// public comment on the reason for the domain block
 	PublicComment string `form:"public_comment" json:"public_comment" xml:"public_comment"`
 }
+
+// DomainBlockUpdateRequest is the form submitted as a PATCH to /api/v1/admin/domain_blocks/{id} to update a block.
+// Fields that aren't set are left unchanged.
+//
+// swagger:ignore
+type DomainBlockUpdateRequest struct {
+	// severity of the block: silence or suspend
+	Severity *string `form:"severity" json:"severity" xml:"severity"`
+	// whether media from the domain should not be fetched
+	RejectMedia *bool `form:"reject_media" json:"reject_media" xml:"reject_media"`
+	// whether reports federated from the domain should be ignored
+	RejectReports *bool `form:"reject_reports" json:"reject_reports" xml:"reject_reports"`
+	// whether the domain should be obfuscated when being displayed publicly
+	Obfuscate *bool `form:"obfuscate" json:"obfuscate" xml:"obfuscate"`
+	// private comment for other admins on why the domain was blocked
+	PrivateComment *string `form:"private_comment" json:"private_comment" xml:"private_comment"`
+	// public comment on the reason for the domain block
+	PublicComment *string `form:"public_comment" json:"public_comment" xml:"public_comment"`
+}
+
+// Formats for exporting and importing lists of domain blocks.
+const (
+	DomainBlocksFormatCSV      = "csv"      // CSV with a header row of field names, eg. 'domain,severity,...'
+	DomainBlocksFormatJSON     = "json"     // JSON array of domain blocks
+	DomainBlocksFormatMastodon = "mastodon" // CSV as exported by Mastodon, with a header row of '#'-prefixed field names
+)
+
+// DomainBlocksImportResult is the result of importing a list of domain blocks,
+// or of previewing an import with dry_run.
+//
+// swagger:model domainBlocksImportResult
+type DomainBlocksImportResult struct {
+	// This was a dry run, so nothing was changed.
+	DryRun bool `json:"dry_run"`
+	// Domain blocks that were created by the import, or would be if this was a dry run.
+	New []*DomainBlock `json:"new"`
+	// Entries that were skipped because they're already in place: either the domain is
+	// already blocked with the same severity, or it's covered by a block of a parent domain.
+	Existing []*DomainBlocksImportEntry `json:"existing"`
+	// Entries that were skipped because they conflict with the current state
+	// of this instance, eg. an existing block of the domain with different settings.
+	Conflicting []*DomainBlocksImportEntry `json:"conflicting"`
+	// Entries that couldn't be parsed or created.
+	Errors []*DomainBlocksImportEntry `json:"errors"`
+}
+
+// DomainBlocksImportEntry is one entry of an imported list
+// of domain blocks which wasn't created, and why not.
+//
+// swagger:model domainBlocksImportEntry
+type DomainBlocksImportEntry struct {
+	// The domain of the entry, as given in the imported list.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Why the entry wasn't created.
+	// example: covered by existing block of example.org
+	Reason string `json:"reason"`
+}
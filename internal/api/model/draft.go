@@ -0,0 +1,78 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Draft represents an unpublished status draft. GoToSocial extension.
+//
+// swagger:model draft
+type Draft struct {
+	// The ID of the draft.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// The date when this draft was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The date when this draft was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	UpdatedAt string `json:"updated_at"`
+	// Text content of the status, as submitted.
+	Status string `json:"status"`
+	// Text to be shown as a warning or subject before the actual content.
+	SpoilerText string `json:"spoiler_text"`
+	// Status and attached media should be marked as sensitive.
+	Sensitive bool `json:"sensitive"`
+	// Visibility of the status. If empty, the account's default visibility will be used.
+	Visibility Visibility `json:"visibility,omitempty"`
+	// ID of the status being replied to, if the status is a reply.
+	InReplyToID string `json:"in_reply_to_id,omitempty"`
+	// IDs of media attachments to be attached to the status.
+	MediaIDs []string `json:"media_ids"`
+	// Media attachments to be attached to the status.
+	MediaAttachments []Attachment `json:"media_attachments"`
+	// Poll to be attached to the status.
+	// swagger:ignore
+	Poll *PollRequest `json:"poll,omitempty"`
+	// ISO 639 language code of the status.
+	Language string `json:"language,omitempty"`
+	// Content type to use when parsing the status.
+	ContentType StatusContentType `json:"content_type,omitempty"`
+}
+
+// DraftRequest models draft creation and update parameters.
+//
+// swagger:ignore
+type DraftRequest struct {
+	// Text content of the status.
+	Status string `form:"status" json:"status" xml:"status"`
+	// Array of Attachment ids to be attached as media.
+	MediaIDs []string `form:"media_ids[]" json:"media_ids" xml:"media_ids"`
+	// Poll to include with the status.
+	Poll *PollRequest `form:"poll" json:"poll" xml:"poll"`
+	// ID of the status being replied to, if status is a reply.
+	InReplyToID string `form:"in_reply_to_id" json:"in_reply_to_id" xml:"in_reply_to_id"`
+	// Status and attached media should be marked as sensitive.
+	Sensitive bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
+	// Text to be shown as a warning or subject before the actual content.
+	SpoilerText string `form:"spoiler_text" json:"spoiler_text" xml:"spoiler_text"`
+	// Visibility of the status.
+	Visibility Visibility `form:"visibility" json:"visibility" xml:"visibility"`
+	// ISO 639 language code for the status.
+	Language string `form:"language" json:"language" xml:"language"`
+	// Content type to use when parsing the status.
+	ContentType StatusContentType `form:"content_type" json:"content_type" xml:"content_type"`
+}
@@ -40,6 +40,24 @@ type Emoji struct {
 	Category string `json:"category,omitempty"`
 }
 
+// EmojisDelta represents the changes to the custom emojis of the instance since a given time.
+//
+// swagger:model emojisDelta
+type EmojisDelta struct {
+	// Custom emojis that were created or updated since the given time.
+	Emojis []*Emoji `json:"emojis"`
+	// Shortcodes of custom emojis that were deleted, or made unusable, since the given time.
+	// example: ["blobcat_uwu"]
+	Deleted []string `json:"deleted"`
+	// Names of all custom emoji categories, in the order they should be shown in the picker.
+	// example: ["blobcats","reactions"]
+	Categories []string `json:"categories"`
+	// If true, the given time was too long ago to calculate a delta, and
+	// `emojis` contains all custom emojis of the instance instead. Any
+	// emojis not present in `emojis` should be considered deleted.
+	Full bool `json:"full"`
+}
+
 // EmojiCreateRequest represents a request to create a custom emoji made through the admin API.
 //
 // swagger:model emojiCreateRequest
@@ -54,6 +72,28 @@ type EmojiCreateRequest struct {
 	CategoryName string `form:"category"`
 }
 
+// PersonalEmoji models a personal custom emoji owned by the requesting account.
+//
+// swagger:model personalEmoji
+type PersonalEmoji struct {
+	Emoji
+	// The ID of the emoji.
+	// example: 01GEM7SFDZ7GZNRXFVZ3X4E4N1
+	ID string `json:"id"`
+}
+
+// PersonalEmojiCreateRequest represents a request to create
+// a personal custom emoji, made through the user API.
+//
+// swagger:ignore
+type PersonalEmojiCreateRequest struct {
+	// Desired shortcode for the emoji, without surrounding colons.
+	// It will be prefixed with the requesting account's username.
+	Shortcode string `form:"shortcode" validation:"required"`
+	// Image file to use for the emoji. Must be png or gif.
+	Image *multipart.FileHeader `form:"image" validation:"required"`
+}
+
 // EmojiUpdateRequest represents a request to update a custom emoji, made through the admin API.
 //
 // swagger:model emojiUpdateRequest
@@ -25,4 +25,14 @@ type EmojiCategory struct {
 	ID string `json:"id"`
 	// The name of the custom emoji category.
 	Name string `json:"name"`
+	// The position of the custom emoji category in the emoji picker; lower comes first.
+	Position int `json:"position"`
+}
+
+// EmojiCategoryUpdateRequest represents a request to update a custom emoji category, made through the admin API.
+//
+// swagger:ignore
+type EmojiCategoryUpdateRequest struct {
+	// Position of the category in the emoji picker; lower comes first.
+	Position *int `form:"position" json:"position" xml:"position"`
 }
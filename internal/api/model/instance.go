@@ -67,6 +67,12 @@ type InstanceConfigurationStatuses struct {
 	//
 	// example: 5000
 	MaxCharacters int `json:"max_characters"`
+	// Maximum allowed length of a post on this instance, in characters, keyed by post visibility.
+	// Only set on the v2 instance model.
+	// GoToSocial extension.
+	//
+	// example: {"public":500,"unlisted":500,"private":5000,"mutuals_only":5000,"direct":10000}
+	MaxCharactersByVisibility map[string]int `json:"max_characters_by_visibility,omitempty"`
 	// Max number of attachments allowed on a status.
 	//
 	// example: 4
@@ -79,6 +85,12 @@ type InstanceConfigurationStatuses struct {
 	//
 	// example: ["text/plain","text/markdown"]
 	SupportedMimeTypes []string `json:"supported_mime_types,omitempty"`
+	// List of visibilities that it's possible to use for statuses on this instance.
+	// Includes non-standard visibilities such as mutuals_only, so clients can offer them.
+	// GoToSocial extension.
+	//
+	// example: ["public","unlisted","private","mutuals_only","direct"]
+	SupportedVisibilities []string `json:"supported_visibilities,omitempty"`
 }
 
 // InstanceConfigurationMediaAttachments models instance media attachment config parameters.
@@ -139,6 +151,19 @@ type InstanceConfigurationPolls struct {
 	MaxExpiration int `json:"max_expiration"`
 }
 
+// InstanceConfigurationStorage models instance local storage quota and usage.
+// This is a GoToSocial extension, and is only set when a storage quota is configured.
+type InstanceConfigurationStorage struct {
+	// Maximum total size in bytes of media in local storage.
+	//
+	// example: 10737418240
+	QuotaBytes int64 `json:"quota_bytes"`
+	// Current total size in bytes of media in local storage.
+	//
+	// example: 5368709120
+	UsedBytes int64 `json:"used_bytes"`
+}
+
 // InstanceConfigurationEmojis models instance emoji config parameters.
 type InstanceConfigurationEmojis struct {
 	// Max allowed emoji image size in bytes.
@@ -146,3 +171,22 @@ type InstanceConfigurationEmojis struct {
 	// example: 51200
 	EmojiSizeLimit int `json:"emoji_size_limit"`
 }
+
+// InstanceActivity models activity on this instance during one week.
+// Values are strings, for compatibility with the Mastodon API.
+//
+// swagger:model instanceActivity
+type InstanceActivity struct {
+	// Unix timestamp of midnight (UTC) on the Monday that the week started.
+	// example: 1690761600
+	Week string `json:"week"`
+	// Number of statuses posted by local accounts during the week.
+	// example: 120
+	Statuses string `json:"statuses"`
+	// Number of local users who signed in during the week.
+	// example: 10
+	Logins string `json:"logins"`
+	// Number of new users who signed up during the week.
+	// example: 2
+	Registrations string `json:"registrations"`
+}
@@ -62,9 +62,8 @@ type InstanceV2 struct {
 	Registrations InstanceV2Registrations `json:"registrations"`
 	//  Hints related to contacting a representative of the instance.
 	Contact InstanceV2Contact `json:"contact"`
-	// An itemized list of rules for this website.
-	// Currently not implemented (will always be empty array).
-	Rules []interface{} `json:"rules"`
+	// An itemized list of rules for this instance.
+	Rules []InstanceRule `json:"rules"`
 }
 
 // Usage data for this instance.
@@ -156,6 +155,9 @@ type InstanceV2Configuration struct {
 	Translation InstanceV2ConfigurationTranslation `json:"translation"`
 	// Instance configuration pertaining to emojis.
 	Emojis InstanceConfigurationEmojis `json:"emojis"`
+	// Local storage quota and usage. GoToSocial extension,
+	// omitted if no storage quota is configured.
+	Storage *InstanceConfigurationStorage `json:"storage,omitempty"`
 }
 
 // Information about registering for this instance.
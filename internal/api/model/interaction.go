@@ -0,0 +1,53 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// InteractionRequest models a favourite, reply, or reblog of one of
+// the requesting account's statuses, which needs their approval.
+//
+// swagger:model interactionRequest
+type InteractionRequest struct {
+	// ID of the interaction request.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Type of interaction awaiting approval.
+	// enum:
+	//	- favourite
+	//	- reply
+	//	- reblog
+	// example: reply
+	Type string `json:"type"`
+	// The date when this interaction request was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Account that performed the interaction.
+	Account *Account `json:"account"`
+	// Status that was interacted with.
+	Status *Status `json:"status"`
+	// The reply awaiting approval, if type is reply.
+	Reply *Status `json:"reply,omitempty"`
+	// The date when this interaction was accepted (ISO 8601 Datetime), if it was.
+	// example: 2021-07-30T09:20:25+00:00
+	AcceptedAt string `json:"accepted_at,omitempty"`
+	// The date when this interaction was rejected (ISO 8601 Datetime), if it was.
+	// example: 2021-07-30T09:20:25+00:00
+	RejectedAt string `json:"rejected_at,omitempty"`
+	// URI of the Accept or Reject of this interaction, once it's been handled.
+	// example: https://example.org/users/some_user/accepts/01FBVD42CQ3ZEEVMW180SBX03B
+	URI string `json:"uri,omitempty"`
+}
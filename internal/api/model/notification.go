@@ -33,6 +33,10 @@ type Notification struct {
 	// 	favourite = Someone favourited one of your statuses
 	// 	poll = A poll you have voted in or created has ended
 	// 	status = Someone you enabled notifications for has posted a status
+	// 	pending.favourite = Someone favourited one of your statuses, and it needs your approval
+	// 	pending.reply = Someone replied to one of your statuses, and it needs your approval
+	// 	pending.reblog = Someone boosted one of your statuses, and it needs your approval
+	// 	bite = Someone bit you, or one of your statuses
 	Type string `json:"type"`
 	// The timestamp of the notification (ISO 8601 Datetime)
 	CreatedAt string `json:"created_at"`
@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// NowPlaying represents the music track that an account most recently listened to. GoToSocial extension.
+//
+// swagger:model nowPlaying
+type NowPlaying struct {
+	// Name of the track.
+	// example: Pyramid Song
+	TrackName string `json:"track_name"`
+	// Name of the artist of the track, if known.
+	// example: Radiohead
+	ArtistName string `json:"artist_name,omitempty"`
+	// Name of the album of the track, if known.
+	// example: Amnesiac
+	AlbumName string `json:"album_name,omitempty"`
+	// When the track was listened to (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	ListenedAt string `json:"listened_at"`
+	// The track was listened to recently enough that it's probably still playing.
+	Active bool `json:"active"`
+}
+
+// ScrobbleRequest models a request to share a listen to a music track.
+//
+// swagger:ignore
+type ScrobbleRequest struct {
+	// Name of the track.
+	TrackName string `form:"track_name" json:"track_name" xml:"track_name"`
+	// Name of the artist of the track.
+	ArtistName string `form:"artist_name" json:"artist_name" xml:"artist_name"`
+	// Name of the album of the track.
+	AlbumName string `form:"album_name" json:"album_name" xml:"album_name"`
+}
@@ -0,0 +1,46 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// InstanceRule models one rule of this instance.
+//
+// swagger:model instanceRule
+type InstanceRule struct {
+	// The ID of the rule.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	ID string `json:"id"`
+	// The text content of the rule.
+	// example: Don't be a jerk.
+	Text string `json:"text"`
+}
+
+// InstanceRuleCreateRequest is the form submitted as a POST to /api/v1/admin/instance/rules to create a new rule.
+//
+// swagger:ignore
+type InstanceRuleCreateRequest struct {
+	// Text content of the rule.
+	Text string `form:"text" json:"text" xml:"text"`
+}
+
+// InstanceRuleUpdateRequest is the form submitted as a PUT to /api/v1/admin/instance/rules/{id} to update a rule.
+//
+// swagger:ignore
+type InstanceRuleUpdateRequest struct {
+	// Text content of the rule.
+	Text string `form:"text" json:"text" xml:"text"`
+}
@@ -38,4 +38,19 @@ type Source struct {
 	Fields []Field `json:"fields"`
 	// The number of pending follow requests.
 	FollowRequestsCount int `json:"follow_requests_count"`
+	// Whether link previews of the profile and statuses are hidden,
+	// and search engines asked not to index them.
+	HideLinkPreviews bool `json:"hide_link_previews"`
+	// Domains of websites allowed to credit this account as the
+	// author of their pages, in addition to the account's own domain.
+	AttributionDomains []string `json:"attribution_domains"`
+	// Which accounts are allowed to mention this account.
+	//    anyone = Anyone
+	//    followed = Only accounts followed by this account
+	//    followers = Only followers of this account
+	//    nobody = Nobody
+	MentionPolicy string `json:"mention_policy"`
+	// When the account will be deleted, following a self-delete request (ISO 8601 Datetime).
+	// Omitted if no deletion is pending.
+	DeleteAt string `json:"delete_at,omitempty"`
 }
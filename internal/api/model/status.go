@@ -60,6 +60,9 @@ type Status struct {
 	ReblogsCount int `json:"reblogs_count"`
 	// Number of favourites/likes this status has received, according to our instance.
 	FavouritesCount int `json:"favourites_count"`
+	// Number of dislikes this status has received, according to our instance.
+	// Only set if the instance is configured to show dislikes.
+	DislikesCount *int `json:"dislikes_count,omitempty"`
 	// This status has been favourited by the account viewing it.
 	Favourited bool `json:"favourited"`
 	// This status has been boosted/reblogged by the account viewing it.
@@ -73,6 +76,10 @@ type Status struct {
 	// The content of this status. Should be HTML, but might also be plaintext in some cases.
 	// example: <p>Hey this is a status!</p>
 	Content string `json:"content"`
+	// Language variants of the content of this status, keyed by
+	// ISO 639 language code, if the remote status provided more than one.
+	// This is a GoToSocial extension and is omitted when there's only one variant.
+	ContentMap map[string]string `json:"content_map,omitempty"`
 	// The status that this status reblogs/boosts.
 	// nullable: true
 	Reblog *StatusReblogged `json:"reblog"`
@@ -98,6 +105,55 @@ type Status struct {
 	// so the user may redraft from the source text without the client having to reverse-engineer
 	// the original text from the HTML content.
 	Text string `json:"text,omitempty"`
+	// Content type with which the plain-text source of the status was parsed.
+	// Returned alongside text when a status is deleted. GoToSocial extension.
+	ContentType StatusContentType `json:"content_type,omitempty"`
+	// Details of the event described by this status.
+	// Only set if the status is an event. GoToSocial extension.
+	Event *StatusEvent `json:"event,omitempty"`
+	// Place this status is about, if any.
+	// GoToSocial extension.
+	Location *StatusLocation `json:"location,omitempty"`
+}
+
+// StatusEvent models details of an event, as
+// federated by event platforms such as Mobilizon.
+//
+// swagger:model statusEvent
+type StatusEvent struct {
+	// Name of the event.
+	// example: Sloth appreciation meetup
+	Name string `json:"name"`
+	// When the event starts (ISO 8601 Datetime).
+	// Key/value not set if unknown.
+	// example: 2021-07-30T09:20:25+00:00
+	StartTime string `json:"start_time,omitempty"`
+	// When the event ends (ISO 8601 Datetime).
+	// Key/value not set if unknown.
+	// example: 2021-07-30T11:20:25+00:00
+	EndTime string `json:"end_time,omitempty"`
+	// Where the event takes place.
+	// Key/value not set if unknown.
+	// example: The Old Tree, Slothville
+	Location string `json:"location,omitempty"`
+}
+
+// StatusLocation models the place a status is about,
+// such as where a photo was taken, or where an event is.
+//
+// swagger:model statusLocation
+type StatusLocation struct {
+	// Name of the place.
+	// example: The Old Tree, Slothville
+	Name string `json:"name"`
+	// Latitude of the place, rounded to 3 decimal places.
+	// Key/value not set if unknown.
+	// example: 51.501
+	Latitude *float64 `json:"latitude,omitempty"`
+	// Longitude of the place, rounded to 3 decimal places.
+	// Key/value not set if unknown.
+	// example: -0.142
+	Longitude *float64 `json:"longitude,omitempty"`
 }
 
 /*
@@ -181,6 +237,17 @@ type StatusCreateRequest struct {
 	// Content type to use when parsing this status.
 	// in: formData
 	ContentType StatusContentType `form:"content_type" json:"content_type" xml:"content_type"`
+	// Name of a place to attach to this status, such as where a photo was taken.
+	// in: formData
+	PlaceName string `form:"place_name" json:"place_name" xml:"place_name"`
+	// Latitude of the place, between -90 and 90. Requires place_name and place_longitude.
+	// Rounded to 3 decimal places. Never taken from attached media.
+	// in: formData
+	PlaceLatitude *float64 `form:"place_latitude" json:"place_latitude" xml:"place_latitude"`
+	// Longitude of the place, between -180 and 180. Requires place_name and place_latitude.
+	// Rounded to 3 decimal places. Never taken from attached media.
+	// in: formData
+	PlaceLongitude *float64 `form:"place_longitude" json:"place_longitude" xml:"place_longitude"`
 }
 
 // Visibility models the visibility of a status.
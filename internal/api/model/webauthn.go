@@ -0,0 +1,171 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// WebAuthnCredential models a WebAuthn credential (aka a passkey)
+// which a user has registered to sign in with.
+//
+// swagger:model webAuthnCredential
+type WebAuthnCredential struct {
+	// The ID of the credential.
+	// example: 01H4Q5Y8M0XKJ7ZJ2B6PQW0S3D
+	ID string `json:"id"`
+	// The name given to the credential when it was registered.
+	// example: phone
+	Name string `json:"name"`
+	// When the credential was registered (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// When the credential was last used to sign in (ISO 8601 Datetime), if ever.
+	// example: 2021-07-30T09:20:25+00:00
+	LastUsedAt *string `json:"last_used_at"`
+}
+
+// WebAuthnCreationOptions models the options to pass to
+// navigator.credentials.create() in order to register a new
+// WebAuthn credential. Field names follow the WebAuthn spec,
+// and binary values are base64url encoded.
+//
+// See: https://www.w3.org/TR/webauthn-2/#dictionary-makecredentialoptions
+//
+// swagger:model webAuthnCreationOptions
+type WebAuthnCreationOptions struct {
+	Challenge              string                         `json:"challenge"`
+	RP                     WebAuthnRelyingParty           `json:"rp"`
+	User                   WebAuthnUser                   `json:"user"`
+	PubKeyCredParams       []WebAuthnCredentialParameters `json:"pubKeyCredParams"`
+	Timeout                int64                          `json:"timeout"`
+	ExcludeCredentials     []WebAuthnCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection WebAuthnAuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                         `json:"attestation"`
+}
+
+// WebAuthnRequestOptions models the options to pass
+// to navigator.credentials.get() in order to sign in
+// with a WebAuthn credential. Field names follow the
+// WebAuthn spec, and binary values are base64url encoded.
+//
+// See: https://www.w3.org/TR/webauthn-2/#dictionary-assertion-options
+//
+// swagger:model webAuthnRequestOptions
+type WebAuthnRequestOptions struct {
+	Challenge        string                         `json:"challenge"`
+	RPID             string                         `json:"rpId"`
+	Timeout          int64                          `json:"timeout"`
+	UserVerification string                         `json:"userVerification"`
+	AllowCredentials []WebAuthnCredentialDescriptor `json:"allowCredentials"`
+}
+
+// WebAuthnRelyingParty models the instance as a WebAuthn relying party.
+//
+// swagger:model webAuthnRelyingParty
+type WebAuthnRelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// WebAuthnUser models the user registering a new WebAuthn credential.
+//
+// swagger:model webAuthnUser
+type WebAuthnUser struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// WebAuthnCredentialParameters models a type of public key
+// which may be used for a new WebAuthn credential.
+//
+// swagger:model webAuthnCredentialParameters
+type WebAuthnCredentialParameters struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+// WebAuthnCredentialDescriptor models an existing WebAuthn credential.
+//
+// swagger:model webAuthnCredentialDescriptor
+type WebAuthnCredentialDescriptor struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// WebAuthnAuthenticatorSelection models the requirements
+// on authenticators used to create a new WebAuthn credential.
+//
+// swagger:model webAuthnAuthenticatorSelection
+type WebAuthnAuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// WebAuthnRegisterRequest models the request to finish
+// registering a new WebAuthn credential. Binary values
+// are base64url encoded.
+//
+// swagger:ignore
+type WebAuthnRegisterRequest struct {
+	// Name to give to the new credential, eg., 'phone'.
+	Name string `form:"name" json:"name" xml:"name"`
+	// Raw ID of the new credential.
+	ID string `form:"id" json:"id" xml:"id"`
+	// response.clientDataJSON of the new credential.
+	ClientDataJSON string `form:"client_data_json" json:"client_data_json" xml:"client_data_json"`
+	// response.getAuthenticatorData() of the new credential.
+	AuthenticatorData string `form:"authenticator_data" json:"authenticator_data" xml:"authenticator_data"`
+	// response.getPublicKey() of the new credential.
+	PublicKey string `form:"public_key" json:"public_key" xml:"public_key"`
+	// response.getPublicKeyAlgorithm() of the new credential.
+	PublicKeyAlgorithm int `form:"public_key_algorithm" json:"public_key_algorithm" xml:"public_key_algorithm"`
+}
+
+// WebAuthnAssertionRequest models the request to finish signing
+// in with a WebAuthn credential. Binary values are base64url encoded.
+//
+// swagger:ignore
+type WebAuthnAssertionRequest struct {
+	// Raw ID of the credential used.
+	ID string `form:"id" json:"id" xml:"id"`
+	// response.clientDataJSON of the assertion.
+	ClientDataJSON string `form:"client_data_json" json:"client_data_json" xml:"client_data_json"`
+	// response.authenticatorData of the assertion.
+	AuthenticatorData string `form:"authenticator_data" json:"authenticator_data" xml:"authenticator_data"`
+	// response.signature of the assertion.
+	Signature string `form:"signature" json:"signature" xml:"signature"`
+	// response.userHandle of the assertion, if set.
+	UserHandle string `form:"user_handle" json:"user_handle" xml:"user_handle"`
+}
+
+// WebAuthnPasswordLogin models whether a user
+// may still sign in with their password.
+//
+// swagger:model webAuthnPasswordLogin
+type WebAuthnPasswordLogin struct {
+	// Whether signing in with a password is enabled.
+	// example: true
+	Enabled bool `json:"enabled"`
+}
+
+// WebAuthnPasswordLoginRequest models the request to
+// enable or disable signing in with a password.
+//
+// swagger:ignore
+type WebAuthnPasswordLoginRequest struct {
+	// Whether signing in with a password should be enabled.
+	Enabled *bool `form:"enabled" json:"enabled" xml:"enabled"`
+}
@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// WebSession models a sign in through the web sign in flow,
+// through which tokens may have been created for apps.
+//
+// swagger:model webSession
+type WebSession struct {
+	// The ID of the session.
+	// example: 01H4Q5Y8M0XKJ7ZJ2B6PQW0S3D
+	ID string `json:"id"`
+	// When the session was started (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// IP address from which the session was started.
+	// example: 192.0.2.1
+	IP string `json:"ip"`
+	// User agent with which the session was started.
+	// example: Mozilla/5.0 (X11; Linux x86_64; rv:109.0) Gecko/20100101 Firefox/115.0
+	UserAgent string `json:"user_agent"`
+	// When a token of the session was last used (ISO 8601 Datetime), if ever.
+	// This is only updated periodically, so it may be a few minutes behind.
+	// example: 2021-07-30T09:20:25+00:00
+	LastSeenAt *string `json:"last_seen_at"`
+	// IP address from which a token of the session was last used, if ever.
+	// example: 192.0.2.1
+	LastSeenIP *string `json:"last_seen_ip"`
+	// The session has been used from a different network than the one it was
+	// started on. This can be perfectly normal, eg., when switching from wifi to
+	// mobile data, but it may also be a sign that the session is being misused.
+	IPChanged bool `json:"ip_changed"`
+	// This is the session of the token used to make the request.
+	Current bool `json:"current"`
+}
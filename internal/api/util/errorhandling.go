@@ -23,6 +23,7 @@ import (
 
 	"codeberg.org/gruf/go-kv"
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/errorcodes"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -33,7 +34,8 @@ import (
 
 // NotFoundHandler serves a 404 html page through the provided gin context,
 // if accept is 'text/html', or just returns a json error if 'accept' is empty
-// or application/json.
+// or application/json. The json error will contain the generic not found
+// error code from the errorcodes package.
 //
 // When serving html, NotFoundHandler calls the provided InstanceGet function
 // to fetch the apimodel representation of the instance, for serving in the
@@ -55,7 +57,8 @@ func NotFoundHandler(c *gin.Context, instanceGet func(ctx context.Context) (*api
 		})
 	default:
 		c.JSON(http.StatusNotFound, gin.H{
-			"error": http.StatusText(http.StatusNotFound),
+			"error":      http.StatusText(http.StatusNotFound),
+			"error_code": errorcodes.NotFound,
 		})
 	}
 }
@@ -76,10 +79,14 @@ func genericErrorHandler(c *gin.Context, instanceGet func(ctx context.Context) (
 			"instance":  instance,
 			"code":      errWithCode.Code(),
 			"error":     errWithCode.Safe(),
+			"errorCode": errorcodes.For(errWithCode),
 			"requestID": gtscontext.RequestID(ctx),
 		})
 	default:
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		c.JSON(errWithCode.Code(), gin.H{
+			"error":      errWithCode.Safe(),
+			"error_code": errorcodes.For(errWithCode),
+		})
 	}
 }
 
@@ -92,6 +99,14 @@ func genericErrorHandler(c *gin.Context, instanceGet func(ctx context.Context) (
 // try to serve an appropriate application/json content-type error.
 // To override the default response type, specify `offers`.
 //
+// JSON errors are served in the form:
+//
+//	{"error":"Bad Request: some help text","error_code":"ERR_BAD_REQUEST"}
+//
+// where "error_code" is a machine-readable code from the errorcodes package,
+// either set on the wrapped error using errorcodes.Set(), or derived from the
+// status code of errWithCode.
+//
 // If the requester already hung up on the request, ErrorHandler
 // will overwrite the given errWithCode with a 499 error to indicate
 // that the failure wasn't due to something we did, and will avoid
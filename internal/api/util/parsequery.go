@@ -20,8 +20,10 @@ package util
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 )
 
 const (
@@ -32,6 +34,12 @@ const (
 	MaxIDKey = "max_id"
 	MinIDKey = "min_id"
 
+	/* Paging sentinel values */
+
+	MaxIDLatest   = "latest"   // MaxIDLatest can be given as max_id to page from the newest item.
+	MaxIDNow      = "now"      // MaxIDNow is an alias of MaxIDLatest.
+	MinIDEarliest = "earliest" // MinIDEarliest can be given as min_id to page from the oldest item.
+
 	/* Search keys */
 
 	SearchExcludeUnreviewedKey = "exclude_unreviewed"
@@ -41,6 +49,30 @@ const (
 	SearchQueryKey             = "q"
 	SearchResolveKey           = "resolve"
 	SearchTypeKey              = "type"
+
+	/* Account keys */
+
+	AccountForceRefreshKey = "force_refresh"
+
+	/* Status keys */
+
+	StatusDeleteMediaKey = "delete_media"
+	StatusDislikeModeKey = "dislike_mode"
+
+	/* Interaction request keys */
+
+	InteractionFavouritesKey = "favourites"
+	InteractionRepliesKey    = "replies"
+	InteractionReblogsKey    = "reblogs"
+
+	/* Custom emoji keys */
+
+	CustomEmojisUpdatedSinceKey = "updated_since"
+
+	/* Admin media usage keys */
+
+	MediaUsageGroupByKey  = "group_by"
+	MediaUsageOrphanedKey = "orphaned"
 )
 
 // parseError returns gtserror.WithCode set to 400 Bad Request, to indicate
@@ -80,6 +112,34 @@ func ParseLimit(value string, defaultValue int, max, min int) (int, gtserror.Wit
 	return i, nil
 }
 
+// ParseMaxID returns the given max ID, or defaultValue if not set. The
+// sentinel values "latest" and "now" are converted to the highest ID
+// that can be generated at the current time, for clients that want the
+// newest items without knowing their IDs.
+func ParseMaxID(value string, defaultValue string) string {
+	switch value {
+	case "":
+		return defaultValue
+	case MaxIDLatest, MaxIDNow:
+		return id.NewMaxULIDFromTime(time.Now())
+	default:
+		return value
+	}
+}
+
+// ParseMinID returns the given min ID, or defaultValue if not set.
+// The sentinel value "earliest" is converted to the lowest possible ID.
+func ParseMinID(value string, defaultValue string) string {
+	switch value {
+	case "":
+		return defaultValue
+	case MinIDEarliest:
+		return id.Lowest
+	default:
+		return value
+	}
+}
+
 func ParseLocal(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	key := LimitKey
 
@@ -161,6 +221,132 @@ func ParseSearchResolve(value string, defaultValue bool) (bool, gtserror.WithCod
 	return i, nil
 }
 
+func ParseAccountForceRefresh(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	key := AccountForceRefreshKey
+
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	i, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue, parseError(key, value, defaultValue, err)
+	}
+
+	return i, nil
+}
+
+func ParseStatusDeleteMedia(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	key := StatusDeleteMediaKey
+
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	i, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue, parseError(key, value, defaultValue, err)
+	}
+
+	return i, nil
+}
+
+func ParseStatusDislikeMode(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	key := StatusDislikeModeKey
+
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	i, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue, parseError(key, value, defaultValue, err)
+	}
+
+	return i, nil
+}
+
+func ParseMediaUsageOrphaned(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	key := MediaUsageOrphanedKey
+
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	i, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue, parseError(key, value, defaultValue, err)
+	}
+
+	return i, nil
+}
+
+func ParseInteractionFavourites(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	key := InteractionFavouritesKey
+
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	i, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue, parseError(key, value, defaultValue, err)
+	}
+
+	return i, nil
+}
+
+func ParseInteractionReplies(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	key := InteractionRepliesKey
+
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	i, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue, parseError(key, value, defaultValue, err)
+	}
+
+	return i, nil
+}
+
+func ParseInteractionReblogs(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	key := InteractionReblogsKey
+
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	i, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue, parseError(key, value, defaultValue, err)
+	}
+
+	return i, nil
+}
+
+// ParseCustomEmojisUpdatedSince parses the given value as either
+// an RFC3339 (ISO 8601) datetime, or a unix timestamp in seconds.
+func ParseCustomEmojisUpdatedSince(value string, defaultValue time.Time) (time.Time, gtserror.WithCode) {
+	key := CustomEmojisUpdatedSinceKey
+
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(i, 0), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return defaultValue, parseError(key, value, defaultValue, err)
+	}
+
+	return t, nil
+}
+
 /*
 	Parse functions for *REQUIRED* parameters.
 */
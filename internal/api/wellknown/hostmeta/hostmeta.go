@@ -27,6 +27,7 @@ import (
 const (
 	HostMetaContentType = "application/xrd+xml"
 	HostMetaPath        = "/host-meta"
+	HostMetaJSONPath    = "/host-meta.json"
 )
 
 type Module struct {
@@ -41,4 +42,5 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, HostMetaPath, m.HostMetaGETHandler)
+	attachHandler(http.MethodGet, HostMetaJSONPath, m.HostMetaJSONGETHandler)
 }
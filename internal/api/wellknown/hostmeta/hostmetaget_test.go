@@ -0,0 +1,145 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package hostmeta_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/wellknown/hostmeta"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type HostMetaGetTestSuite struct {
+	suite.Suite
+	db        db.DB
+	state     state.State
+	storage   *storage.Driver
+	federator federation.Federator
+
+	hostMetaModule *hostmeta.Module
+}
+
+func (suite *HostMetaGetTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+	testrig.StartWorkers(&suite.state)
+
+	testrig.InitTestLog()
+	testrig.InitTestConfig()
+
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.state.DB = suite.db
+
+	testrig.StartTimelines(
+		&suite.state,
+		visibility.NewFilter(&suite.state),
+		testrig.NewTestTypeConverter(suite.db),
+	)
+
+	suite.storage = testrig.NewInMemoryStorage()
+	suite.state.Storage = suite.storage
+	mediaManager := testrig.NewTestMediaManager(&suite.state)
+	suite.federator = testrig.NewTestFederator(&suite.state, testrig.NewTestTransportController(&suite.state, testrig.NewMockHTTPClient(nil, "../../../../testrig/media")), mediaManager)
+	processor := testrig.NewTestProcessor(&suite.state, suite.federator, testrig.NewEmailSender("../../../../web/template/", nil), mediaManager)
+	suite.hostMetaModule = hostmeta.New(processor)
+	testrig.StandardDBSetup(suite.db, nil)
+}
+
+func (suite *HostMetaGetTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StopWorkers(&suite.state)
+}
+
+func (suite *HostMetaGetTestSuite) get(path string, accept string, handler func(*gin.Context)) (*http.Response, []byte) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/.well-known"+path, nil)
+	ctx.Request.Header.Set("accept", accept)
+
+	handler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return result, b
+}
+
+func (suite *HostMetaGetTestSuite) TestHostMetaGet() {
+	result, b := suite.get(hostmeta.HostMetaPath, "application/xrd+xml", suite.hostMetaModule.HostMetaGETHandler)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	suite.Equal(hostmeta.HostMetaContentType, result.Header.Get("Content-Type"))
+
+	// Must be well-formed XML, in the XRD namespace.
+	var xrd struct {
+		XMLName xml.Name
+		Links   []struct {
+			Rel      string `xml:"rel,attr"`
+			Template string `xml:"template,attr"`
+		} `xml:"Link"`
+	}
+	if err := xml.Unmarshal(b, &xrd); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("http://docs.oasis-open.org/ns/xri/xrd-1.0", xrd.XMLName.Space)
+	suite.Equal("XRD", xrd.XMLName.Local)
+	suite.Len(xrd.Links, 1)
+	suite.Equal("lrdd", xrd.Links[0].Rel)
+	suite.Equal("http://localhost:8080/.well-known/webfinger?resource={uri}", xrd.Links[0].Template)
+}
+
+func (suite *HostMetaGetTestSuite) TestHostMetaJSONGet() {
+	result, b := suite.get(hostmeta.HostMetaJSONPath, "application/json", suite.hostMetaModule.HostMetaJSONGETHandler)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	suite.Equal("application/json; charset=utf-8", result.Header.Get("Content-Type"))
+
+	var jrd map[string]interface{}
+	if err := json.Unmarshal(b, &jrd); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(map[string]interface{}{
+		"links": []interface{}{
+			map[string]interface{}{
+				"rel":      "lrdd",
+				"type":     "application/xrd+xml",
+				"template": "http://localhost:8080/.well-known/webfinger?resource={uri}",
+			},
+		},
+	}, jrd)
+}
+
+func TestHostMetaGetTestSuite(t *testing.T) {
+	suite.Run(t, &HostMetaGetTestSuite{})
+}
@@ -28,6 +28,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -223,6 +224,27 @@ func (suite *WebfingerGetTestSuite) TestFingerUserWithoutAcct() {
 }`, resp)
 }
 
+func (suite *WebfingerGetTestSuite) TestFingerUserSuspended() {
+	targetAccount := &gtsmodel.Account{}
+	*targetAccount = *suite.testAccounts["local_account_1"]
+	targetAccount.SuspendedAt = time.Now()
+	if err := suite.db.UpdateAccount(context.Background(), targetAccount, "suspended_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	requestPath := fmt.Sprintf("/%s?resource=acct:%s@%s", webfinger.WebfingerBasePath, targetAccount.Username, config.GetHost())
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Request = httptest.NewRequest(http.MethodGet, requestPath, nil)
+	ctx.Request.Header.Set("accept", "application/jrd+json")
+
+	suite.webfingerModule.WebfingerGETRequest(ctx)
+
+	// A suspended or deleted account is gone.
+	suite.Equal(http.StatusGone, recorder.Code)
+}
+
 func TestWebfingerGetTestSuite(t *testing.T) {
 	suite.Run(t, new(WebfingerGetTestSuite))
 }
@@ -0,0 +1,163 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package bloom provides a simple bloom filter that
+// can be written to and read from disk, for offline
+// membership checks against very large sets.
+package bloom
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"os"
+)
+
+// magic identifies a bloom filter file.
+var magic = [8]byte{'G', 'T', 'S', 'B', 'L', 'M', '0', '1'}
+
+// Filter is a bloom filter: a set which may report
+// false positives, but never false negatives.
+type Filter struct {
+	k    uint32 // number of hash positions per key
+	m    uint64 // number of bits
+	bits []byte
+}
+
+// New returns a new empty Filter sized for n keys,
+// with a false positive rate of approximately p.
+func New(n uint64, p float64) *Filter {
+	if n == 0 {
+		n = 1
+	}
+
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	if m == 0 {
+		m = 1
+	}
+
+	k := uint32(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k == 0 {
+		k = 1
+	}
+
+	return &Filter{
+		k:    k,
+		m:    m,
+		bits: make([]byte, (m+7)/8),
+	}
+}
+
+// Add adds the given key to the filter.
+func (f *Filter) Add(key []byte) {
+	h1, h2 := hash(key)
+	for i := uint64(0); i < uint64(f.k); i++ {
+		pos := (h1 + i*h2) % f.m
+		f.bits[pos>>3] |= 1 << (pos & 7)
+	}
+}
+
+// Has returns whether the given key may have been
+// added to the filter. False means it definitely wasn't.
+func (f *Filter) Has(key []byte) bool {
+	h1, h2 := hash(key)
+	for i := uint64(0); i < uint64(f.k); i++ {
+		pos := (h1 + i*h2) % f.m
+		if f.bits[pos>>3]&(1<<(pos&7)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Size returns the size of the filter in bytes.
+func (f *Filter) Size() int {
+	return len(f.bits)
+}
+
+// WriteTo writes the filter to the given writer,
+// in the format expected by ReadFrom.
+func (f *Filter) WriteTo(w io.Writer) (int64, error) {
+	hdr := make([]byte, 0, len(magic)+4+8)
+	hdr = append(hdr, magic[:]...)
+	hdr = binary.BigEndian.AppendUint32(hdr, f.k)
+	hdr = binary.BigEndian.AppendUint64(hdr, f.m)
+
+	n, err := w.Write(hdr)
+	if err != nil {
+		return int64(n), err
+	}
+
+	nb, err := w.Write(f.bits)
+	return int64(n + nb), err
+}
+
+// ReadFrom reads a filter written by WriteTo from the given reader.
+func ReadFrom(r io.Reader) (*Filter, error) {
+	hdr := make([]byte, len(magic)+4+8)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return nil, fmt.Errorf("error reading header: %w", err)
+	}
+
+	if [8]byte(hdr[:8]) != magic {
+		return nil, errors.New("not a bloom filter")
+	}
+
+	f := &Filter{
+		k: binary.BigEndian.Uint32(hdr[8:12]),
+		m: binary.BigEndian.Uint64(hdr[12:20]),
+	}
+
+	if f.k == 0 || f.m == 0 {
+		return nil, errors.New("invalid bloom filter parameters")
+	}
+
+	f.bits = make([]byte, (f.m+7)/8)
+	if _, err := io.ReadFull(r, f.bits); err != nil {
+		return nil, fmt.Errorf("error reading bits: %w", err)
+	}
+
+	return f, nil
+}
+
+// Load reads a filter from the file at the given path.
+func Load(path string) (*Filter, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReadFrom(bufio.NewReader(file))
+}
+
+// hash returns two 64 bit hashes of the
+// given key, from which the positions of
+// the key in the filter are derived.
+func hash(key []byte) (uint64, uint64) {
+	h := fnv.New128a()
+	_, _ = h.Write(key)
+	sum := h.Sum(nil)
+
+	h1 := binary.BigEndian.Uint64(sum[:8])
+	h2 := binary.BigEndian.Uint64(sum[8:]) | 1
+	return h1, h2
+}
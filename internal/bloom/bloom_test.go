@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bloom_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/bloom"
+)
+
+type BloomTestSuite struct {
+	suite.Suite
+}
+
+func (suite *BloomTestSuite) TestAddHas() {
+	f := bloom.New(1000, 0.01)
+
+	for i := 0; i < 1000; i++ {
+		f.Add([]byte(fmt.Sprintf("key-%d", i)))
+	}
+
+	// No false negatives.
+	for i := 0; i < 1000; i++ {
+		suite.True(f.Has([]byte(fmt.Sprintf("key-%d", i))))
+	}
+
+	// Roughly the expected rate of false positives.
+	var falsePositives int
+	for i := 0; i < 10000; i++ {
+		if f.Has([]byte(fmt.Sprintf("other-%d", i))) {
+			falsePositives++
+		}
+	}
+	suite.Less(falsePositives, 300)
+}
+
+func (suite *BloomTestSuite) TestWriteRead() {
+	f := bloom.New(100, 0.01)
+	f.Add([]byte("hello"))
+	f.Add([]byte("world"))
+
+	buf := &bytes.Buffer{}
+	n, err := f.WriteTo(buf)
+	suite.NoError(err)
+	suite.EqualValues(buf.Len(), n)
+
+	read, err := bloom.ReadFrom(buf)
+	suite.NoError(err)
+	suite.Equal(f.Size(), read.Size())
+	suite.True(read.Has([]byte("hello")))
+	suite.True(read.Has([]byte("world")))
+	suite.False(read.Has([]byte("goodbye")))
+}
+
+func (suite *BloomTestSuite) TestReadNotAFilter() {
+	_, err := bloom.ReadFrom(bytes.NewReader([]byte("this is definitely not a bloom filter")))
+	suite.EqualError(err, "not a bloom filter")
+
+	_, err = bloom.ReadFrom(bytes.NewReader([]byte("short")))
+	suite.ErrorContains(err, "error reading header")
+}
+
+func TestBloomTestSuite(t *testing.T) {
+	suite.Run(t, new(BloomTestSuite))
+}
@@ -20,6 +20,7 @@ package cleaner
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"time"
 
 	"codeberg.org/gruf/go-runners"
@@ -40,6 +41,10 @@ type Cleaner struct {
 	state *state.State
 	emoji Emoji
 	media Media
+
+	// set while evicting remote
+	// media on storage high-water.
+	evicting atomic.Bool
 }
 
 func New(state *state.State) *Cleaner {
@@ -47,6 +52,9 @@ func New(state *state.State) *Cleaner {
 	c.state = state
 	c.emoji.Cleaner = c
 	c.media.Cleaner = c
+	if state.Storage != nil {
+		state.Storage.OnHighWater = c.onHighWater
+	}
 	scheduleJobs(c)
 	return c
 }
@@ -105,6 +113,31 @@ func (c *Cleaner) removeFiles(ctx context.Context, files ...string) (int, error)
 	return diff, nil
 }
 
+// onHighWater is called by the storage driver when storage usage is above the
+// high-water mark, to evict remote media in the background (if not already).
+func (c *Cleaner) onHighWater() {
+	if !c.state.Workers.Scheduler.Running() {
+		return
+	}
+
+	if !c.evicting.CompareAndSwap(false, true) {
+		// Already evicting.
+		return
+	}
+
+	// Get ctx associated with scheduler run state.
+	done := c.state.Workers.Scheduler.Done()
+	doneCtx := runners.CancelCtx(done)
+
+	// Schedule eviction to run once, now.
+	c.state.Workers.Scheduler.Schedule(sched.NewJob(func(start time.Time) {
+		defer c.evicting.Store(false)
+		log.Warnf(nil, "storage usage above high-water mark (%d of %d bytes), evicting remote media", c.state.Storage.Used(), c.state.Storage.MaxSize)
+		c.Media().LogEvictRemote(doneCtx)
+		log.Infof(nil, "finished evicting remote media after %s", time.Since(start))
+	}))
+}
+
 func scheduleJobs(c *Cleaner) {
 	const day = time.Hour * 24
 
@@ -132,4 +165,27 @@ func scheduleJobs(c *Cleaner) {
 		c.Emoji().All(doneCtx)
 		log.Infof(nil, "finished media clean after %s", time.Since(start))
 	}).EveryAt(midnight, day))
+
+	if c.state.Storage == nil || c.state.Storage.MaxSize <= 0 {
+		// Storage usage only
+		// needed for quota.
+		return
+	}
+
+	// Schedule reconciling tracked storage usage now (as the
+	// counter starts at zero), and then every week after, to
+	// correct any drift of the counter from real usage.
+	c.state.Workers.Scheduler.Schedule(sched.NewJob(func(start time.Time) {
+		log.Info(nil, "starting storage usage reconcile")
+		used, err := c.state.Storage.Reconcile(doneCtx)
+		if err != nil {
+			log.Errorf(nil, "error reconciling storage usage: %v", err)
+			return
+		}
+		log.Infof(nil, "finished storage usage reconcile after %s: %d of %d bytes used", time.Since(start), used, c.state.Storage.MaxSize)
+
+		if c.state.Storage.AboveHighWater() {
+			c.onHighWater()
+		}
+	}).EveryAt(now, 7*day))
 }
@@ -20,6 +20,7 @@ package cleaner
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
@@ -39,6 +40,7 @@ type Emoji struct {
 func (e *Emoji) All(ctx context.Context) {
 	e.LogPruneMissing(ctx)
 	e.LogFixBroken(ctx)
+	e.LogPruneTombstones(ctx)
 }
 
 // LogPruneMissing performs emoji.PruneMissing(...), logging the start and outcome.
@@ -61,6 +63,16 @@ func (e *Emoji) LogFixBroken(ctx context.Context) {
 	}
 }
 
+// LogPruneTombstones performs emoji.PruneTombstones(...), logging the start and outcome.
+func (e *Emoji) LogPruneTombstones(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := e.PruneTombstones(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "pruned: %d", n)
+	}
+}
+
 // PruneMissing will delete emoji with missing files from the database and storage driver.
 // Context will be checked for `gtscontext.DryRun()` to perform the action. NOTE: this function
 // should be updated to match media.FixCacheStat() if we ever support emoji uncaching.
@@ -145,6 +157,24 @@ func (e *Emoji) FixBroken(ctx context.Context) (int, error) {
 	return total, nil
 }
 
+// PruneTombstones will delete tombstones of deleted local emojis that are older than
+// gtsmodel.EmojiTombstoneRetention. Context will be checked for `gtscontext.DryRun()`
+// in order to actually perform the action.
+func (e *Emoji) PruneTombstones(ctx context.Context) (int, error) {
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return 0, nil
+	}
+
+	olderThan := time.Now().Add(-gtsmodel.EmojiTombstoneRetention)
+	n, err := e.state.DB.DeleteEmojiTombstonesOlderThan(ctx, olderThan)
+	if err != nil {
+		return 0, gtserror.Newf("error deleting emoji tombstones: %w", err)
+	}
+
+	return n, nil
+}
+
 func (e *Emoji) pruneMissing(ctx context.Context, emoji *gtsmodel.Emoji) (bool, error) {
 	return e.checkFiles(ctx, func() error {
 		// Emoji missing files, delete it.
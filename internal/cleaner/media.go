@@ -22,16 +22,24 @@ import (
 	"errors"
 	"time"
 
+	"codeberg.org/gruf/go-bytesize"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
+// proxiedImageTTL is how long images cached by
+// the image proxy are kept without being accessed.
+const proxiedImageTTL = 7 * 24 * time.Hour
+
 // Media encompasses a set of
 // media cleanup / admin utils.
 type Media struct {
@@ -43,9 +51,10 @@ type Media struct {
 func (m *Media) All(ctx context.Context, maxRemoteDays int) {
 	t := time.Now().Add(-24 * time.Hour * time.Duration(maxRemoteDays))
 	m.LogUncacheRemote(ctx, t)
-	m.LogPruneOrphaned(ctx)
+	m.LogPruneOrphaned(ctx, time.Time{})
 	m.LogPruneUnused(ctx)
 	m.LogFixCacheStates(ctx)
+	m.LogEvictProxied(ctx, time.Now().Add(-proxiedImageTTL))
 	_ = m.state.Storage.Storage.Clean(ctx)
 }
 
@@ -59,13 +68,37 @@ func (m *Media) LogUncacheRemote(ctx context.Context, olderThan time.Time) {
 	}
 }
 
-// LogPruneOrphaned performs Media.PruneOrphaned(...), logging the start and outcome.
-func (m *Media) LogPruneOrphaned(ctx context.Context) {
+// LogEvictRemote performs Media.EvictRemote(...), logging the start and outcome.
+func (m *Media) LogEvictRemote(ctx context.Context) {
 	log.Info(ctx, "start")
-	if n, err := m.PruneOrphaned(ctx); err != nil {
+	if n, err := m.EvictRemote(ctx); err != nil {
 		log.Error(ctx, err)
 	} else {
-		log.Infof(ctx, "pruned: %d", n)
+		log.Infof(ctx, "evicted: %d", n)
+	}
+}
+
+// LogEvictProxied performs Media.EvictProxied(...), logging the start and outcome.
+func (m *Media) LogEvictProxied(ctx context.Context, accessedBefore time.Time) {
+	log.Infof(ctx, "start accessed before: %s", accessedBefore.Format(time.Stamp))
+	if n, err := m.EvictProxied(ctx, accessedBefore); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "evicted: %d", n)
+	}
+}
+
+// LogPruneOrphaned performs Media.PruneOrphaned(...), logging the start and outcome.
+func (m *Media) LogPruneOrphaned(ctx context.Context, olderThan time.Time) {
+	if olderThan.IsZero() {
+		log.Info(ctx, "start")
+	} else {
+		log.Infof(ctx, "start older than: %s", olderThan.Format(time.Stamp))
+	}
+	if n, sz, err := m.PruneOrphaned(ctx, olderThan); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "pruned: %d (%s)", n, bytesize.Size(sz).StringIEC())
 	}
 }
 
@@ -79,6 +112,16 @@ func (m *Media) LogPruneUnused(ctx context.Context) {
 	}
 }
 
+// LogDangling performs Media.Dangling(...), logging the start and outcome.
+func (m *Media) LogDangling(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := m.Dangling(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "dangling: %d", n)
+	}
+}
+
 // LogFixCacheStates performs Media.FixCacheStates(...), logging the start and outcome.
 func (m *Media) LogFixCacheStates(ctx context.Context) {
 	log.Info(ctx, "start")
@@ -89,19 +132,134 @@ func (m *Media) LogFixCacheStates(ctx context.Context) {
 	}
 }
 
-// PruneOrphaned will delete orphaned files from storage (i.e. media missing a database entry).
+// EvictProxied will delete images cached by the image proxy which
+// were last accessed before the given time, from storage and the database.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (m *Media) EvictProxied(ctx context.Context, accessedBefore time.Time) (int, error) {
+	// Proxied images are small db entries, so
+	// just fetch all of them to be evicted at once.
+	images, err := m.state.DB.GetProxiedImagesAccessedBefore(ctx, accessedBefore, 0)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return 0, gtserror.Newf("error getting proxied images: %w", err)
+	}
+
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return len(images), nil
+	}
+
+	var total int
+
+	for _, image := range images {
+		// Remove stored file first, so it's never orphaned.
+		if _, err := m.removeFiles(ctx, image.StoragePath()); err != nil {
+			return total, err
+		}
+
+		if err := m.state.DB.DeleteProxiedImageByID(ctx, image.ID); err != nil {
+			return total, gtserror.Newf("error deleting proxied image: %w", err)
+		}
+
+		total++
+	}
+
+	return total, nil
+}
+
+// PruneOrphaned will delete orphaned files from storage (i.e. media missing a database entry),
+// returning the number of files and bytes removed. If olderThan is non-zero, only files for media
+// created before this time are pruned, so that media currently being processed is left alone.
 // Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
-func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
-	var files []string
+func (m *Media) PruneOrphaned(ctx context.Context, olderThan time.Time) (int, int64, error) {
+	var (
+		files []string
+		sizes []int64
+	)
+
+	if err := m.walkOrphaned(ctx, olderThan, func(path string, size int64) {
+		// Add this orphaned entry.
+		files = append(files, path)
+		sizes = append(sizes, size)
+	}); err != nil {
+		return 0, 0, err
+	}
+
+	var (
+		total int
+		freed int64
+		errs  gtserror.MultiError
+	)
+
+	for i, path := range files {
+		// Delete each orphaned file from storage,
+		// tallying the size of those removed.
+		n, err := m.removeFiles(ctx, path)
+		if err != nil {
+			errs.Append(err)
+		}
+
+		if n > 0 {
+			total++
+			if sizes[i] > 0 {
+				freed += sizes[i]
+			}
+		}
+	}
+
+	return total, freed, errs.Combine()
+}
+
+// CountOrphaned returns the number and total size in bytes of orphaned
+// files in storage (i.e. media missing a database entry), without removing them.
+// Files with a size unknown to the storage backend are counted but not sized.
+func (m *Media) CountOrphaned(ctx context.Context) (int, int64, error) {
+	var (
+		count int
+		size  int64
+	)
+
+	if err := m.walkOrphaned(ctx, time.Time{}, func(_ string, sz int64) {
+		count++
+		if sz > 0 {
+			size += sz
+		}
+	}); err != nil {
+		return 0, 0, err
+	}
+
+	return count, size, nil
+}
+
+// walkOrphaned walks media files in storage, calling onOrphan with the path
+// and size of each orphaned one. If olderThan is non-zero, files for media
+// created at or after this time (going by their ULID) are skipped.
+func (m *Media) walkOrphaned(ctx context.Context, olderThan time.Time, onOrphan func(path string, size int64)) error {
+	var maxID string
+
+	if !olderThan.IsZero() {
+		// Media IDs are ULIDs, so anything sorting
+		// at or after this ID was created too recently.
+		var err error
+		maxID, err = id.NewULIDFromTime(olderThan)
+		if err != nil {
+			return gtserror.Newf("error generating max id: %w", err)
+		}
+	}
 
 	// All media files in storage will have path fitting: {$account}/{$type}/{$size}/{$id}.{$ext}
-	if err := m.state.Storage.WalkKeys(ctx, func(ctx context.Context, path string) error {
-		if !regexes.FilePath.MatchString(path) {
+	if err := m.state.Storage.WalkEntries(ctx, func(ctx context.Context, path string, size int64) error {
+		pathParts := regexes.FilePath.FindStringSubmatch(path)
+		if len(pathParts) != 6 {
 			// This is not our expected media
 			// path format, skip this one.
 			return nil
 		}
 
+		if maxID != "" && pathParts[4] >= maxID {
+			// Too recent, skip.
+			return nil
+		}
+
 		// Check whether this entry is orphaned.
 		orphaned, err := m.isOrphaned(ctx, path)
 		if err != nil {
@@ -109,17 +267,15 @@ func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
 		}
 
 		if orphaned {
-			// Add this orphaned entry.
-			files = append(files, path)
+			onOrphan(path, size)
 		}
 
 		return nil
 	}); err != nil {
-		return 0, gtserror.Newf("error walking storage: %w", err)
+		return gtserror.Newf("error walking storage: %w", err)
 	}
 
-	// Delete all orphaned files from storage.
-	return m.removeFiles(ctx, files...)
+	return nil
 }
 
 // PruneUnused will delete all unused media attachments from the database and storage driver.
@@ -209,6 +365,91 @@ func (m *Media) UncacheRemote(ctx context.Context, olderThan time.Time) (int, er
 	return total, nil
 }
 
+// EvictRemote will uncache remote media attachments oldest-first, until storage
+// usage is back below the storage quota low-water mark. It does this by uncaching
+// remote media older than media-remote-cache-days, then older than half of that,
+// and so on, down to remote media older than an hour (which is never evicted).
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (m *Media) EvictRemote(ctx context.Context) (int, error) {
+	const minAge = time.Hour
+
+	var total int
+
+	age := 24 * time.Hour * time.Duration(config.GetMediaRemoteCacheDays())
+	if age < minAge {
+		age = minAge
+	}
+
+	for ; age >= minAge && m.state.Storage.AboveLowWater(); age /= 2 {
+		n, err := m.UncacheRemote(ctx, time.Now().Add(-age))
+		total += n
+		if err != nil {
+			return total, err
+		}
+
+		if gtscontext.DryRun(ctx) {
+			// Usage won't go down
+			// on a dry run, so one
+			// pass is all we'll do.
+			break
+		}
+	}
+
+	return total, nil
+}
+
+// Dangling will check all cached media for files missing from storage (i.e. a database entry
+// with nothing behind it), logging each one found and returning the count. Unlike FixCacheStates
+// this makes no changes, it is purely for reporting.
+func (m *Media) Dangling(ctx context.Context) (int, error) {
+	var (
+		total int
+		maxID string
+	)
+
+	for {
+		// Fetch the next batch of media attachments up to next max ID.
+		attachments, err := m.state.DB.GetAttachments(ctx, maxID, selectLimit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting attachments: %w", err)
+		}
+
+		if len(attachments) == 0 {
+			// reached end.
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = attachments[len(attachments)-1].ID
+
+		for _, media := range attachments {
+			if !*media.Cached {
+				// Not expected in storage.
+				continue
+			}
+
+			missing, err := m.checkFiles(ctx, func() error {
+				log.Warnf(ctx, "dangling media %s: missing file(s) in storage", media.ID)
+				return nil
+			},
+				media.Thumbnail.Path,
+				media.File.Path,
+			)
+			if err != nil {
+				return total, err
+			}
+
+			if missing {
+				// Update
+				// count.
+				total++
+			}
+		}
+	}
+
+	return total, nil
+}
+
 // FixCacheStatus will check all media for up-to-date cache status (i.e. in storage driver).
 // Media marked as cached, with any required files missing, will be automatically uncached.
 // Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
@@ -361,6 +602,29 @@ func (m *Media) pruneUnused(ctx context.Context, media *gtsmodel.MediaAttachment
 		}
 	}
 
+	if media.RemoteURL == "" {
+		// Check whether local media is referenced
+		// by a draft, which may be published later.
+		inDraft, err := m.state.DB.IsAttachmentInDraft(ctx, media.AccountID, media.ID)
+		if err != nil {
+			return false, gtserror.Newf("error checking drafts: %w", err)
+		}
+
+		if inDraft {
+			l.Debug("skipping as referenced by draft")
+			return false, nil
+		}
+	}
+
+	if media.RemoteURL == "" &&
+		time.Since(media.UpdatedAt) < config.GetMediaUnusedGracePeriod() {
+		// Local media recently uploaded or unattached
+		// from a deleted status, which may still be
+		// used in a new status (eg., delete + redraft).
+		l.Debug("skipping as local media within grace period")
+		return false, nil
+	}
+
 	// Media totally unused, delete it.
 	l.Debug("deleting unused media")
 	return true, m.delete(ctx, media)
@@ -425,3 +425,80 @@ func (suite *MediaTestSuite) TestUncacheOneNonExistent() {
 	suite.NoError(err)
 	suite.Equal(2, totalUncached)
 }
+
+func (suite *MediaTestSuite) TestDangling() {
+	ctx := context.Background()
+
+	// Count any already dangling before we start.
+	before, err := suite.cleaner.Media().Dangling(ctx)
+	suite.NoError(err)
+
+	// Remove the file for a cached attachment from storage.
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+	suite.True(*testAttachment.Cached)
+	err = suite.storage.Delete(ctx, testAttachment.File.Path)
+	suite.NoError(err)
+
+	after, err := suite.cleaner.Media().Dangling(ctx)
+	suite.NoError(err)
+	suite.Equal(before+1, after)
+
+	// Dangling should only report, attachment should remain cached.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.NoError(err)
+	suite.True(*dbAttachment.Cached)
+}
+
+func (suite *MediaTestSuite) TestEvictProxied() {
+	ctx := context.Background()
+
+	putImage := func(id string, accessedAt time.Time) *gtsmodel.ProxiedImage {
+		image := &gtsmodel.ProxiedImage{
+			ID:          id,
+			URL:         "https://images.example.org/" + id + ".png",
+			ContentType: "image/png",
+			FileSize:    4,
+			AccessedAt:  accessedAt,
+		}
+		if _, err := suite.storage.Put(ctx, image.StoragePath(), []byte("data")); err != nil {
+			suite.FailNow(err.Error())
+		}
+		if err := suite.db.PutProxiedImage(ctx, image); err != nil {
+			suite.FailNow(err.Error())
+		}
+		return image
+	}
+
+	stale := putImage("01H46YB4JDV6B0E3D7QD1ZG8Q3", time.Now().Add(-8*24*time.Hour))
+	fresh := putImage("01H46YBHG2EV2SHYJ8AWSR6CVR", time.Now().Add(-time.Hour))
+
+	before := time.Now().Add(-7 * 24 * time.Hour)
+
+	// Dry run should count the stale image, but not remove it.
+	totalEvicted, err := suite.cleaner.Media().EvictProxied(gtscontext.SetDryRun(ctx), before)
+	suite.NoError(err)
+	suite.Equal(1, totalEvicted)
+
+	_, err = suite.db.GetProxiedImageByURL(ctx, stale.URL)
+	suite.NoError(err)
+
+	totalEvicted, err = suite.cleaner.Media().EvictProxied(ctx, before)
+	suite.NoError(err)
+	suite.Equal(1, totalEvicted)
+
+	// Stale image should be gone from db and storage.
+	_, err = suite.db.GetProxiedImageByURL(ctx, stale.URL)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	has, err := suite.storage.Has(ctx, stale.StoragePath())
+	suite.NoError(err)
+	suite.False(has)
+
+	// Fresh image should still be there.
+	_, err = suite.db.GetProxiedImageByURL(ctx, fresh.URL)
+	suite.NoError(err)
+
+	has, err = suite.storage.Has(ctx, fresh.StoragePath())
+	suite.NoError(err)
+	suite.True(has)
+}
@@ -55,7 +55,7 @@ type Configuration struct {
 	Protocol        string   `name:"protocol" usage:"Protocol to use for the REST api of the server (only use http if you are debugging or behind a reverse proxy!)"`
 	BindAddress     string   `name:"bind-address" usage:"Bind address to use for the GoToSocial server (eg., 0.0.0.0, 172.138.0.9, [::], localhost). For ipv6, enclose the address in square brackets, eg [2001:db8::fed1]. Default binds to all interfaces."`
 	Port            int      `name:"port" usage:"Port to use for GoToSocial. Change this to 443 if you're running the binary directly on the host machine."`
-	TrustedProxies  []string `name:"trusted-proxies" usage:"Proxies to trust when parsing x-forwarded headers into real IPs."`
+	TrustedProxies  []string `name:"trusted-proxies" usage:"Proxies to trust when parsing x-forwarded headers into real IPs, schemes and hosts."`
 	SoftwareVersion string   `name:"software-version" usage:""`
 
 	DbType                   string        `name:"db-type" usage:"Database type: eg., postgres"`
@@ -78,37 +78,80 @@ type Configuration struct {
 	InstanceExposePeers            bool `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
 	InstanceExposeSuspended        bool `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
 	InstanceExposeSuspendedWeb     bool `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
+	InstanceExposeActivity         bool `name:"instance-expose-activity" usage:"Allow unauthenticated users to query /api/v1/instance/activity"`
+	InstanceStatsAPI               bool `name:"instance-stats-api" usage:"Enable the /api/v1/instance/peers and /api/v1/instance/activity endpoints. If false, both will return 404 Not Found."`
 	InstanceExposePublicTimeline   bool `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
 	InstanceDeliverToSharedInboxes bool `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
 
-	AccountsRegistrationOpen bool `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
-	AccountsApprovalRequired bool `name:"accounts-approval-required" usage:"Do account signups require approval by an admin or moderator before user can log in? If false, new registrations will be automatically approved."`
-	AccountsReasonRequired   bool `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
-	AccountsAllowCustomCSS   bool `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
-	AccountsCustomCSSLength  int  `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
+	FederationAllowPrivateIPs bool   `name:"federation-allow-private-ips" usage:"Allow outgoing requests to private, loopback and other reserved IP addresses. Cloud metadata addresses are always blocked. Only use this for development and testing."`
+	FederationUserAgent       string `name:"federation-user-agent" usage:"User-Agent to send with outgoing federation requests. If empty, gotosocial/<version> (+<instance url>) is used."`
+
+	AccountsRegistrationOpen         bool          `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
+	AccountsApprovalRequired         bool          `name:"accounts-approval-required" usage:"Do account signups require approval by an admin or moderator before user can log in? If false, new registrations will be automatically approved."`
+	AccountsNotifyNewSignups         bool          `name:"accounts-notify-new-signups" usage:"Email admins and moderators when a new account signup is awaiting approval. Requires SMTP to be configured."`
+	AccountsNotifyNewSignupsInterval time.Duration `name:"accounts-notify-new-signups-interval" usage:"Minimum time between new signup emails to admins and moderators. Signups arriving within this interval are batched into a single email. If 0, every signup is emailed immediately."`
+	AccountsReasonRequired           bool          `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
+	AccountsAllowCustomCSS           bool          `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
+	AccountsCustomCSSLength          int           `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
+	AccountsMaxEmojis                int           `name:"accounts-max-emojis" usage:"Maximum number of personal custom emojis that each account can upload. If 0, personal emojis are disabled."`
+	AccountsEmojiMaxSize             bytesize.Size `name:"accounts-emoji-max-size" usage:"Max size in bytes of personal custom emojis uploaded by accounts."`
+	AccountsPasswordMinLength        int           `name:"accounts-password-min-length" usage:"Minimum length (characters) of new passwords."`
+	AccountsPasswordMinEntropy       float64       `name:"accounts-password-min-entropy" usage:"Minimum strength (bits of entropy) of new passwords. If 0, strength is not checked."`
+	AccountsPasswordBreachedFilter   string        `name:"accounts-password-breached-filter" usage:"Path to a breached passwords filter built with 'gotosocial admin breached-passwords build'. New passwords found in it are rejected. If empty, passwords are not checked against breaches."`
+	AccountsSelfDeleteDelay          time.Duration `name:"accounts-self-delete-delay" usage:"Grace period between a user requesting deletion of their own account and the account actually being deleted, during which the deletion can be cancelled. If 0, accounts are deleted immediately."`
 
 	MediaImageMaxSize        bytesize.Size `name:"media-image-max-size" usage:"Max size of accepted images in bytes"`
 	MediaVideoMaxSize        bytesize.Size `name:"media-video-max-size" usage:"Max size of accepted videos in bytes"`
 	MediaDescriptionMinChars int           `name:"media-description-min-chars" usage:"Min required chars for an image description"`
 	MediaDescriptionMaxChars int           `name:"media-description-max-chars" usage:"Max permitted chars for an image description"`
 	MediaRemoteCacheDays     int           `name:"media-remote-cache-days" usage:"Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely."`
+	MediaUnusedGracePeriod   time.Duration `name:"media-unused-grace-period" usage:"How long local media attachments may remain unattached to any status before they are cleaned up, eg., to allow reuse after delete and redraft."`
 	MediaEmojiLocalMaxSize   bytesize.Size `name:"media-emoji-local-max-size" usage:"Max size in bytes of emojis uploaded to this instance via the admin API."`
 	MediaEmojiRemoteMaxSize  bytesize.Size `name:"media-emoji-remote-max-size" usage:"Max size in bytes of emojis to download from other instances."`
-
-	StorageBackend       string `name:"storage-backend" usage:"Storage backend to use for media attachments"`
-	StorageLocalBasePath string `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
-	StorageS3Endpoint    string `name:"storage-s3-endpoint" usage:"S3 Endpoint URL (e.g 'minio.example.org:9000')"`
-	StorageS3AccessKey   string `name:"storage-s3-access-key" usage:"S3 Access Key"`
-	StorageS3SecretKey   string `name:"storage-s3-secret-key" usage:"S3 Secret Key"`
-	StorageS3UseSSL      bool   `name:"storage-s3-use-ssl" usage:"Use SSL for S3 connections. Only set this to 'false' when testing locally"`
-	StorageS3BucketName  string `name:"storage-s3-bucket" usage:"Place blobs in this bucket"`
-	StorageS3Proxy       bool   `name:"storage-s3-proxy" usage:"Proxy S3 contents through GoToSocial instead of redirecting to a presigned URL"`
-
-	StatusesMaxChars           int `name:"statuses-max-chars" usage:"Max permitted characters for posted statuses"`
-	StatusesCWMaxChars         int `name:"statuses-cw-max-chars" usage:"Max permitted characters for content/spoiler warnings on statuses"`
-	StatusesPollMaxOptions     int `name:"statuses-poll-max-options" usage:"Max amount of options permitted on a poll"`
-	StatusesPollOptionMaxChars int `name:"statuses-poll-option-max-chars" usage:"Max amount of characters for a poll option"`
-	StatusesMediaMaxFiles      int `name:"statuses-media-max-files" usage:"Maximum number of media files/attachments per status"`
+	MediaProxyEnabled        bool          `name:"media-proxy-enabled" usage:"Serve images embedded in remote status content via this instance, instead of having viewers' browsers load them from remote servers."`
+	MediaAutoAltTextEnabled  bool          `name:"media-auto-alt-text-enabled" usage:"Request alt text suggestions for uploaded images without a description from a locally-hosted image captioning service."`
+	MediaAutoAltTextURL      string        `name:"media-auto-alt-text-url" usage:"URL of the image captioning service to POST uploaded images to, when media-auto-alt-text-enabled is true."`
+	MediaAllowedTypes        []string      `name:"media-allowed-types" usage:"MIME types of media that may be uploaded, eg., image/png or image/*. If empty, all supported types are allowed. Cannot be set together with media-blocked-types."`
+	MediaBlockedTypes        []string      `name:"media-blocked-types" usage:"MIME types of media that may not be uploaded, eg., video/mp4 or video/*. Cannot be set together with media-allowed-types."`
+	MediaThumbnailWorkers    int           `name:"media-thumbnail-workers" usage:"Number of workers generating thumbnails and blurhashes for uploaded media in the background. If 0, the number of CPUs is used."`
+
+	// Media limits overridden per role, keyed by role name.
+	// Only settable from the config file, not flags or env.
+	MediaRoles map[string]MediaRoleConfiguration `name:"media-roles"`
+
+	// Trusted oEmbed providers of audio and video embeds, keyed
+	// by provider name. Only settable from the config file.
+	MediaOEmbedProviders map[string]OEmbedProviderConfiguration `name:"media-oembed-providers"`
+
+	StorageBackend              string        `name:"storage-backend" usage:"Storage backend to use for media attachments"`
+	StorageLocalBasePath        string        `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
+	StorageLocalMaxSize         bytesize.Size `name:"storage-local-max-size" usage:"Maximum total size of media in local storage. Uploads by local accounts are rejected when this would be exceeded, and cached remote media is evicted when usage gets close. 0 means no limit."`
+	StorageS3Endpoint           string        `name:"storage-s3-endpoint" usage:"S3 Endpoint URL (e.g 'minio.example.org:9000')"`
+	StorageS3AccessKey          string        `name:"storage-s3-access-key" usage:"S3 Access Key"`
+	StorageS3SecretKey          string        `name:"storage-s3-secret-key" usage:"S3 Secret Key"`
+	StorageS3UseSSL             bool          `name:"storage-s3-use-ssl" usage:"Use SSL for S3 connections. Only set this to 'false' when testing locally"`
+	StorageS3BucketName         string        `name:"storage-s3-bucket" usage:"Place blobs in this bucket"`
+	StorageS3Proxy              bool          `name:"storage-s3-proxy" usage:"Proxy S3 contents through GoToSocial instead of redirecting to a presigned URL"`
+	StorageS3RedirectURLExpiry  time.Duration `name:"storage-s3-redirect-url-expiry" usage:"How long presigned URLs that requests are redirected to remain valid for, when not proxying S3 contents. Between 1 minute and 7 days."`
+	StorageS3MultipartThreshold bytesize.Size `name:"storage-s3-multipart-threshold" usage:"Files larger than this are uploaded to S3 in parts of this size, rather than all at once. Minimum 5MiB."`
+	StorageMigrateFrom          string        `name:"storage-migrate-from" usage:"Storage backend to migrate media away from. If set, media not found in the current storage backend will be read from this one instead. Leave empty once migration is complete."`
+
+	StatusesMaxChars           int    `name:"statuses-max-chars" usage:"Max permitted characters for posted statuses"`
+	StatusesMaxCharsPublic     int    `name:"statuses-max-chars-public" usage:"Max permitted characters for posted public statuses. If 0, statuses-max-chars is used"`
+	StatusesMaxCharsUnlisted   int    `name:"statuses-max-chars-unlisted" usage:"Max permitted characters for posted unlisted statuses. If 0, statuses-max-chars is used"`
+	StatusesMaxCharsPrivate    int    `name:"statuses-max-chars-private" usage:"Max permitted characters for posted private (followers-only and mutuals-only) statuses. If 0, statuses-max-chars is used"`
+	StatusesMaxCharsDirect     int    `name:"statuses-max-chars-direct" usage:"Max permitted characters for posted direct statuses. If 0, statuses-max-chars is used"`
+	StatusesCWMaxChars         int    `name:"statuses-cw-max-chars" usage:"Max permitted characters for content/spoiler warnings on statuses"`
+	StatusesPollMaxOptions     int    `name:"statuses-poll-max-options" usage:"Max amount of options permitted on a poll"`
+	StatusesPollOptionMaxChars int    `name:"statuses-poll-option-max-chars" usage:"Max amount of characters for a poll option"`
+	StatusesMediaMaxFiles      int    `name:"statuses-media-max-files" usage:"Maximum number of media files/attachments per status"`
+	StatusesMaxDrafts          int    `name:"statuses-max-drafts" usage:"Maximum number of status drafts that each account can keep. If 0, drafts are disabled"`
+	StatusesMaxThreadDepth     int    `name:"statuses-max-thread-depth" usage:"Maximum depth of incoming remote replies relative to the root of their thread. If 0, thread depth is not limited"`
+	StatusesThreadDepthPolicy  string `name:"statuses-thread-depth-policy" usage:"What to do with incoming remote replies beyond statuses-max-thread-depth. Options: [detach, reject]"`
+	StatusShowDislikes         bool   `name:"status-show-dislikes" usage:"Show counts of federated dislikes (downvotes) of statuses in the client API and ActivityPub representations of statuses"`
+
+	StreamingPingInterval time.Duration `name:"streaming-ping-interval" usage:"Interval at which to send keep-alive pings to connected streaming websocket clients."`
+	StreamingPingTimeout  time.Duration `name:"streaming-ping-timeout" usage:"Time to wait for a streaming websocket client to respond to a ping with a pong before closing its connection."`
 
 	LetsEncryptEnabled      bool   `name:"letsencrypt-enabled" usage:"Enable letsencrypt TLS certs for this server. If set to true, then cert dir also needs to be set (or take the default)."`
 	LetsEncryptPort         int    `name:"letsencrypt-port" usage:"Port to listen on for letsencrypt certificate challenges. Must not be the same as the GtS webserver/API port."`
@@ -127,42 +170,89 @@ type Configuration struct {
 	OIDCScopes           []string `name:"oidc-scopes" usage:"OIDC scopes."`
 	OIDCLinkExisting     bool     `name:"oidc-link-existing" usage:"link existing user accounts to OIDC logins based on the stored email value"`
 	OIDCAdminGroups      []string `name:"oidc-admin-groups" usage:"Membership of one of the listed groups makes someone a GtS admin"`
+	OIDCModeratorGroups  []string `name:"oidc-moderator-groups" usage:"Membership of one of the listed groups makes someone a GtS moderator"`
+	OIDCSignupGroups     []string `name:"oidc-signup-groups" usage:"If set, only members of one of the listed groups may sign up via OIDC"`
+	OIDCGroupsClaim      string   `name:"oidc-groups-claim" usage:"Name of the ID token claim from which to read group membership"`
+	OIDCUsernameClaim    string   `name:"oidc-username-claim" usage:"Name of the ID token claim from which to read the username for new accounts"`
+	OIDCUsernameMode     string   `name:"oidc-username-mode" usage:"How to choose a username for new accounts: 'ask' the user, or take it from the username claim and 'suffix' a number or 'deny' signup if it's already taken"`
 
 	TracingEnabled           bool   `name:"tracing-enabled" usage:"Enable OTLP Tracing"`
 	TracingTransport         string `name:"tracing-transport" usage:"grpc or jaeger"`
 	TracingEndpoint          string `name:"tracing-endpoint" usage:"Endpoint of your trace collector. Eg., 'localhost:4317' for gRPC, 'http://localhost:14268/api/traces' for jaeger"`
 	TracingInsecureTransport bool   `name:"tracing-insecure" usage:"Disable HTTPS for the gRPC transport protocol"`
 
-	SMTPHost               string `name:"smtp-host" usage:"Host of the smtp server. Eg., 'smtp.eu.mailgun.org'"`
-	SMTPPort               int    `name:"smtp-port" usage:"Port of the smtp server. Eg., 587"`
-	SMTPUsername           string `name:"smtp-username" usage:"Username to authenticate with the smtp server as. Eg., 'postmaster@mail.example.org'"`
-	SMTPPassword           string `name:"smtp-password" usage:"Password to pass to the smtp server."`
-	SMTPFrom               string `name:"smtp-from" usage:"Address to use as the 'from' field of the email. Eg., 'gotosocial@example.org'"`
-	SMTPDiscloseRecipients bool   `name:"smtp-disclose-recipients" usage:"If true, email notifications sent to multiple recipients will be To'd to every recipient at once. If false, recipients will not be disclosed"`
+	SMTPHost                string `name:"smtp-host" usage:"Host of the smtp server. Eg., 'smtp.eu.mailgun.org'"`
+	SMTPPort                int    `name:"smtp-port" usage:"Port of the smtp server. Eg., 587"`
+	SMTPUsername            string `name:"smtp-username" usage:"Username to authenticate with the smtp server as. Eg., 'postmaster@mail.example.org'"`
+	SMTPPassword            string `name:"smtp-password" usage:"Password to pass to the smtp server."`
+	SMTPFrom                string `name:"smtp-from" usage:"Address to use as the 'from' field of the email. Eg., 'gotosocial@example.org'"`
+	SMTPDiscloseRecipients  bool   `name:"smtp-disclose-recipients" usage:"If true, email notifications sent to multiple recipients will be To'd to every recipient at once. If false, recipients will not be disclosed"`
+	SMTPTemplateOverrideDir string `name:"smtp-template-override-dir" usage:"Directory containing email templates (named like email_*) to use instead of the default templates of the same name. Leave empty to use only the defaults."`
 
 	SyslogEnabled  bool   `name:"syslog-enabled" usage:"Enable the syslog logging hook. Logs will be mirrored to the configured destination."`
 	SyslogProtocol string `name:"syslog-protocol" usage:"Protocol to use when directing logs to syslog. Leave empty to connect to local syslog."`
 	SyslogAddress  string `name:"syslog-address" usage:"Address:port to send syslog logs to. Leave empty to connect to local syslog."`
 
-	AdvancedCookiesSamesite      string        `name:"advanced-cookies-samesite" usage:"'strict' or 'lax', see https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie/SameSite"`
-	AdvancedRateLimitRequests    int           `name:"advanced-rate-limit-requests" usage:"Amount of HTTP requests to permit within a 5 minute window. 0 or less turns rate limiting off."`
-	AdvancedThrottlingMultiplier int           `name:"advanced-throttling-multiplier" usage:"Multiplier to use per cpu for http request throttling. 0 or less turns throttling off."`
-	AdvancedThrottlingRetryAfter time.Duration `name:"advanced-throttling-retry-after" usage:"Retry-After duration response to send for throttled requests."`
-	AdvancedSenderMultiplier     int           `name:"advanced-sender-multiplier" usage:"Multiplier to use per cpu for batching outgoing fedi messages. 0 or less turns batching off (not recommended)."`
+	SessionBackend       string `name:"session-backend" usage:"Where to store sessions: 'memory' or 'redis'. Use 'redis' when running more than one GoToSocial process behind a load balancer."`
+	SessionRedisAddress  string `name:"session-redis-address" usage:"Address:port of the Redis server to store sessions in, if session-backend is 'redis'."`
+	SessionRedisPassword string `name:"session-redis-password" usage:"Password to authenticate with the Redis server. Leave empty if the server doesn't require authentication."`
+	SessionRedisDB       int    `name:"session-redis-db" usage:"Number of the Redis database to store sessions in."`
+	SessionEncryptionKey string `name:"session-encryption-key" usage:"Hex-encoded 256-bit key to encrypt session cookies with. If not set, a key generated on first startup and stored in the database is used."`
+
+	AdvancedCookiesSamesite        string        `name:"advanced-cookies-samesite" usage:"'strict' or 'lax', see https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie/SameSite"`
+	AdvancedRateLimitRequests      int           `name:"advanced-rate-limit-requests" usage:"Amount of HTTP requests to permit within a 5 minute window. 0 or less turns rate limiting off."`
+	AdvancedInboxRateLimitRequests int           `name:"advanced-inbox-rate-limit-requests" usage:"Amount of ActivityPub inbox deliveries to permit from a single remote domain within a 5 minute window. 0 or less turns inbox rate limiting off."`
+	AdvancedInboxMaxBodySize       bytesize.Size `name:"advanced-inbox-max-body-size" usage:"Max size in bytes of ActivityPub inbox request bodies. Larger bodies are rejected with 413."`
+	AdvancedThrottlingMultiplier   int           `name:"advanced-throttling-multiplier" usage:"Multiplier to use per cpu for http request throttling. 0 or less turns throttling off."`
+	AdvancedThrottlingRetryAfter   time.Duration `name:"advanced-throttling-retry-after" usage:"Retry-After duration response to send for throttled requests."`
+	AdvancedSenderMultiplier       int           `name:"advanced-sender-multiplier" usage:"Multiplier to use per cpu for batching outgoing fedi messages. 0 or less turns batching off (not recommended)."`
+
+	MaintenanceMode    bool   `name:"maintenance-mode" usage:"Serve a 503 for web and client API requests, while still accepting federated deliveries, which are queued until maintenance mode is turned off."`
+	MaintenanceMessage string `name:"maintenance-message" usage:"Message to show on the maintenance page, and in client API errors, while in maintenance mode."`
+
+	ShutdownGracePeriod time.Duration `name:"shutdown-grace-period" usage:"Maximum time to wait on shutdown for queued background work (eg., side effects of federated activities) to finish. 0 or less drops queued work immediately."`
 
 	// Cache configuration vars.
 	Cache CacheConfiguration `name:"cache"`
 
 	// TODO: move these elsewhere, these are more ephemeral vs long-running flags like above
-	AdminAccountUsername  string `name:"username" usage:"the username to create/delete/etc"`
-	AdminAccountEmail     string `name:"email" usage:"the email address of this account"`
-	AdminAccountPassword  string `name:"password" usage:"the password to set for this account"`
-	AdminTransPath        string `name:"path" usage:"the path of the file to import from/export to"`
-	AdminMediaPruneDryRun bool   `name:"dry-run" usage:"perform a dry run and only log number of items eligible for pruning"`
+	AdminAccountUsername        string        `name:"username" usage:"the username to create/delete/etc"`
+	AdminAccountEmail           string        `name:"email" usage:"the email address of this account"`
+	AdminAccountPassword        string        `name:"password" usage:"the password to set for this account"`
+	AdminAccountListOrigin      string        `name:"origin" usage:"which accounts to list: local, remote, or all"`
+	AdminAccountListDomain      string        `name:"domain" usage:"only list accounts from this domain"`
+	AdminAccountListSuspended   bool          `name:"suspended" usage:"only list suspended accounts"`
+	AdminAccountListAdmin       bool          `name:"admin" usage:"only list local admin accounts"`
+	AdminAccountUseAPI          bool          `name:"use-api" usage:"make requests to the API of the running instance, rather than to the database"`
+	AdminAccountAPIToken        string        `name:"token" usage:"access token of an admin account, for use with --use-api"`
+	AdminTransPath              string        `name:"path" usage:"the path of the file to import from/export to"`
+	AdminBreachedPasswordsInput string        `name:"input" usage:"the path of a breached passwords list to build a filter from, in the SHA-1 format of Have I Been Pwned"`
+	AdminDryRun                 bool          `name:"dry-run" usage:"perform a dry run and only log what would be done"`
+	AdminFederationCheckTarget  string        `name:"target" usage:"the remote account (username@domain) or domain to check federation with"`
+	AdminMediaUsageGroupBy      string        `name:"group-by" usage:"how to group media usage: domain or account"`
+	AdminMediaUsageTop          int           `name:"top" usage:"only show this many of the largest groups"`
+	AdminMediaUsageFormat       string        `name:"format" usage:"output format: table or json"`
+	AdminMediaPruneOlderThan    time.Duration `name:"older-than" usage:"only prune orphaned files created longer ago than this (eg., 30d or 12h), to avoid pruning in-progress uploads"`
 
 	RequestIDHeader string `name:"request-id-header" usage:"Header to extract the Request ID from. Eg.,'X-Request-Id'."`
 }
 
+// MediaRoleConfiguration contains media limits which override the
+// instance-wide limits for local users with the corresponding role.
+// Zero values mean the instance-wide limit applies.
+type MediaRoleConfiguration struct {
+	ImageMaxSize bytesize.Size `name:"image-max-size"`
+	VideoMaxSize bytesize.Size `name:"video-max-size"`
+	MaxFiles     int           `name:"max-files"`
+}
+
+// OEmbedProviderConfiguration contains the oEmbed endpoint of
+// a trusted provider, and the domains whose links it embeds.
+type OEmbedProviderConfiguration struct {
+	Domains  []string `name:"domains"`
+	Endpoint string   `name:"endpoint"`
+}
+
 type CacheConfiguration struct {
 	GTS GTSCacheConfiguration `name:"gts"`
 
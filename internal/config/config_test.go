@@ -22,6 +22,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -204,3 +205,84 @@ func TestCLIParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestAdminMediaPruneOlderThan(t *testing.T) {
+	type testcase struct {
+		cli      []string
+		env      []string
+		expected time.Duration
+		err      bool
+	}
+
+	testcases := map[string]testcase{
+		"Default": {
+			expected: 24 * time.Hour,
+		},
+
+		"Days using cli flag": {
+			cli:      []string{"--older-than", "30d"},
+			expected: 30 * 24 * time.Hour,
+		},
+
+		"Days and hours using cli flag": {
+			cli:      []string{"--older-than", "1d12h"},
+			expected: 36 * time.Hour,
+		},
+
+		"Hours using cli flag": {
+			cli:      []string{"--older-than", "720h"},
+			expected: 720 * time.Hour,
+		},
+
+		"Zero using cli flag": {
+			cli:      []string{"--older-than", "0"},
+			expected: 0,
+		},
+
+		"Days using env var": {
+			env:      []string{"GTS_OLDER_THAN=7d"},
+			expected: 7 * 24 * time.Hour,
+		},
+
+		"Invalid unit using cli flag": {
+			cli: []string{"--older-than", "30x"},
+			err: true,
+		},
+
+		"Negative days using cli flag": {
+			cli: []string{"--older-than", "-1d"},
+			err: true,
+		},
+
+		"Days with trailing garbage using cli flag": {
+			cli: []string{"--older-than", "1dfoo"},
+			err: true,
+		},
+	}
+
+	for desc, data := range testcases {
+		t.Run(desc, func(t *testing.T) {
+			os.Clearenv()
+
+			for _, s := range data.env {
+				kv := strings.SplitN(s, "=", 2)
+				os.Setenv(kv[0], kv[1])
+			}
+
+			state := config.NewState()
+			cmd := cobra.Command{}
+			config.AddAdminMediaPruneOrphaned(&cmd)
+
+			err := cmd.ParseFlags(data.cli)
+			if data.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			assert.NoError(t, state.BindFlags(&cmd))
+			assert.NoError(t, state.Reload())
+			assert.Equal(t, data.expected, state.GetAdminMediaPruneOlderThan())
+		})
+	}
+}
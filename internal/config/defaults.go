@@ -59,32 +59,83 @@ var Defaults = Configuration{
 	InstanceExposePeers:            false,
 	InstanceExposeSuspended:        false,
 	InstanceExposeSuspendedWeb:     false,
+	InstanceExposeActivity:         false,
+	InstanceStatsAPI:               true,
 	InstanceDeliverToSharedInboxes: true,
 
-	AccountsRegistrationOpen: true,
-	AccountsApprovalRequired: true,
-	AccountsReasonRequired:   true,
-	AccountsAllowCustomCSS:   false,
-	AccountsCustomCSSLength:  10000,
+	FederationAllowPrivateIPs: false,
+	FederationUserAgent:       "",
+
+	AccountsRegistrationOpen:         true,
+	AccountsApprovalRequired:         true,
+	AccountsNotifyNewSignups:         true,
+	AccountsNotifyNewSignupsInterval: 10 * time.Minute,
+	AccountsReasonRequired:           true,
+	AccountsAllowCustomCSS:           false,
+	AccountsCustomCSSLength:          10000,
+	AccountsMaxEmojis:                0,
+	AccountsEmojiMaxSize:             50 * bytesize.KiB,
+	AccountsPasswordMinLength:        8,
+	AccountsPasswordMinEntropy:       60,
+	AccountsPasswordBreachedFilter:   "",
+	AccountsSelfDeleteDelay:          7 * 24 * time.Hour,
 
 	MediaImageMaxSize:        10 * bytesize.MiB,
 	MediaVideoMaxSize:        40 * bytesize.MiB,
 	MediaDescriptionMinChars: 0,
 	MediaDescriptionMaxChars: 500,
 	MediaRemoteCacheDays:     30,
+	MediaUnusedGracePeriod:   time.Hour,
 	MediaEmojiLocalMaxSize:   50 * bytesize.KiB,
 	MediaEmojiRemoteMaxSize:  100 * bytesize.KiB,
+	MediaProxyEnabled:        false,
+	MediaAutoAltTextEnabled:  false,
+	MediaAutoAltTextURL:      "http://localhost:8085/caption",
+	MediaAllowedTypes:        []string{},
+	MediaBlockedTypes:        []string{},
+	MediaThumbnailWorkers:    0,
+	MediaOEmbedProviders: map[string]OEmbedProviderConfiguration{
+		"youtube": {
+			Domains:  []string{"youtube.com", "youtu.be"},
+			Endpoint: "https://www.youtube.com/oembed",
+		},
+		"vimeo": {
+			Domains:  []string{"vimeo.com"},
+			Endpoint: "https://vimeo.com/api/oembed.json",
+		},
+		"soundcloud": {
+			Domains:  []string{"soundcloud.com"},
+			Endpoint: "https://soundcloud.com/oembed",
+		},
+		"spotify": {
+			Domains:  []string{"open.spotify.com"},
+			Endpoint: "https://open.spotify.com/oembed",
+		},
+	},
 
-	StorageBackend:       "local",
-	StorageLocalBasePath: "/gotosocial/storage",
-	StorageS3UseSSL:      true,
-	StorageS3Proxy:       false,
+	StorageBackend:              "local",
+	StorageLocalBasePath:        "/gotosocial/storage",
+	StorageS3UseSSL:             true,
+	StorageS3Proxy:              false,
+	StorageS3RedirectURLExpiry:  24 * time.Hour,
+	StorageS3MultipartThreshold: 5 * bytesize.MiB,
 
 	StatusesMaxChars:           5000,
+	StatusesMaxCharsPublic:     0,
+	StatusesMaxCharsUnlisted:   0,
+	StatusesMaxCharsPrivate:    0,
+	StatusesMaxCharsDirect:     0,
 	StatusesCWMaxChars:         100,
 	StatusesPollMaxOptions:     6,
 	StatusesPollOptionMaxChars: 50,
 	StatusesMediaMaxFiles:      6,
+	StatusesMaxDrafts:          20,
+	StatusesMaxThreadDepth:     0,
+	StatusesThreadDepthPolicy:  "detach",
+	StatusShowDislikes:         false,
+
+	StreamingPingInterval: 30 * time.Second,
+	StreamingPingTimeout:  10 * time.Second,
 
 	LetsEncryptEnabled:      false,
 	LetsEncryptPort:         80,
@@ -102,13 +153,17 @@ var Defaults = Configuration{
 	OIDCClientSecret:     "",
 	OIDCScopes:           []string{oidc.ScopeOpenID, "profile", "email", "groups"},
 	OIDCLinkExisting:     false,
-
-	SMTPHost:               "",
-	SMTPPort:               0,
-	SMTPUsername:           "",
-	SMTPPassword:           "",
-	SMTPFrom:               "GoToSocial",
-	SMTPDiscloseRecipients: false,
+	OIDCGroupsClaim:      "groups",
+	OIDCUsernameClaim:    "preferred_username",
+	OIDCUsernameMode:     "ask",
+
+	SMTPHost:                "",
+	SMTPPort:                0,
+	SMTPUsername:            "",
+	SMTPPassword:            "",
+	SMTPFrom:                "GoToSocial",
+	SMTPDiscloseRecipients:  false,
+	SMTPTemplateOverrideDir: "",
 
 	TracingEnabled:           false,
 	TracingTransport:         "grpc",
@@ -119,10 +174,17 @@ var Defaults = Configuration{
 	SyslogProtocol: "udp",
 	SyslogAddress:  "localhost:514",
 
-	AdvancedCookiesSamesite:      "lax",
-	AdvancedRateLimitRequests:    300, // 1 per second per 5 minutes
-	AdvancedThrottlingMultiplier: 8,   // 8 open requests per CPU
-	AdvancedSenderMultiplier:     2,   // 2 senders per CPU
+	SessionBackend:      "memory",
+	SessionRedisAddress: "localhost:6379",
+
+	AdvancedCookiesSamesite:        "lax",
+	AdvancedRateLimitRequests:      300,  // 1 per second per 5 minutes
+	AdvancedInboxRateLimitRequests: 3000, // 10 per second per 5 minutes
+	AdvancedInboxMaxBodySize:       1 * bytesize.MiB,
+	AdvancedThrottlingMultiplier:   8, // 8 open requests per CPU
+	AdvancedSenderMultiplier:       2, // 2 senders per CPU
+
+	ShutdownGracePeriod: time.Second * 30,
 
 	Cache: CacheConfiguration{
 		GTS: GTSCacheConfiguration{
@@ -204,7 +266,12 @@ var Defaults = Configuration{
 		VisibilitySweepFreq: time.Minute,
 	},
 
-	AdminMediaPruneDryRun: true,
+	AdminAccountListOrigin:   "local",
+	AdminDryRun:              true,
+	AdminMediaUsageGroupBy:   "domain",
+	AdminMediaUsageTop:       50,
+	AdminMediaUsageFormat:    "table",
+	AdminMediaPruneOlderThan: 24 * time.Hour,
 
 	RequestIDHeader: "X-Request-Id",
 
@@ -79,13 +79,27 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Bool(InstanceExposePeersFlag(), cfg.InstanceExposePeers, fieldtag("InstanceExposePeers", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedFlag(), cfg.InstanceExposeSuspended, fieldtag("InstanceExposeSuspended", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedWebFlag(), cfg.InstanceExposeSuspendedWeb, fieldtag("InstanceExposeSuspendedWeb", "usage"))
+		cmd.Flags().Bool(InstanceExposeActivityFlag(), cfg.InstanceExposeActivity, fieldtag("InstanceExposeActivity", "usage"))
+		cmd.Flags().Bool(InstanceStatsAPIFlag(), cfg.InstanceStatsAPI, fieldtag("InstanceStatsAPI", "usage"))
 		cmd.Flags().Bool(InstanceDeliverToSharedInboxesFlag(), cfg.InstanceDeliverToSharedInboxes, fieldtag("InstanceDeliverToSharedInboxes", "usage"))
 
+		// Federation
+		cmd.Flags().Bool(FederationAllowPrivateIPsFlag(), cfg.FederationAllowPrivateIPs, fieldtag("FederationAllowPrivateIPs", "usage"))
+		cmd.Flags().String(FederationUserAgentFlag(), cfg.FederationUserAgent, fieldtag("FederationUserAgent", "usage"))
+
 		// Accounts
 		cmd.Flags().Bool(AccountsRegistrationOpenFlag(), cfg.AccountsRegistrationOpen, fieldtag("AccountsRegistrationOpen", "usage"))
 		cmd.Flags().Bool(AccountsApprovalRequiredFlag(), cfg.AccountsApprovalRequired, fieldtag("AccountsApprovalRequired", "usage"))
+		cmd.Flags().Bool(AccountsNotifyNewSignupsFlag(), cfg.AccountsNotifyNewSignups, fieldtag("AccountsNotifyNewSignups", "usage"))
+		cmd.Flags().Duration(AccountsNotifyNewSignupsIntervalFlag(), cfg.AccountsNotifyNewSignupsInterval, fieldtag("AccountsNotifyNewSignupsInterval", "usage"))
 		cmd.Flags().Bool(AccountsReasonRequiredFlag(), cfg.AccountsReasonRequired, fieldtag("AccountsReasonRequired", "usage"))
 		cmd.Flags().Bool(AccountsAllowCustomCSSFlag(), cfg.AccountsAllowCustomCSS, fieldtag("AccountsAllowCustomCSS", "usage"))
+		cmd.Flags().Int(AccountsMaxEmojisFlag(), cfg.AccountsMaxEmojis, fieldtag("AccountsMaxEmojis", "usage"))
+		cmd.Flags().Uint64(AccountsEmojiMaxSizeFlag(), uint64(cfg.AccountsEmojiMaxSize), fieldtag("AccountsEmojiMaxSize", "usage"))
+		cmd.Flags().Int(AccountsPasswordMinLengthFlag(), cfg.AccountsPasswordMinLength, fieldtag("AccountsPasswordMinLength", "usage"))
+		cmd.Flags().Float64(AccountsPasswordMinEntropyFlag(), cfg.AccountsPasswordMinEntropy, fieldtag("AccountsPasswordMinEntropy", "usage"))
+		cmd.Flags().String(AccountsPasswordBreachedFilterFlag(), cfg.AccountsPasswordBreachedFilter, fieldtag("AccountsPasswordBreachedFilter", "usage"))
+		cmd.Flags().Duration(AccountsSelfDeleteDelayFlag(), cfg.AccountsSelfDeleteDelay, fieldtag("AccountsSelfDeleteDelay", "usage"))
 
 		// Media
 		cmd.Flags().Uint64(MediaImageMaxSizeFlag(), uint64(cfg.MediaImageMaxSize), fieldtag("MediaImageMaxSize", "usage"))
@@ -93,19 +107,40 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Int(MediaDescriptionMinCharsFlag(), cfg.MediaDescriptionMinChars, fieldtag("MediaDescriptionMinChars", "usage"))
 		cmd.Flags().Int(MediaDescriptionMaxCharsFlag(), cfg.MediaDescriptionMaxChars, fieldtag("MediaDescriptionMaxChars", "usage"))
 		cmd.Flags().Int(MediaRemoteCacheDaysFlag(), cfg.MediaRemoteCacheDays, fieldtag("MediaRemoteCacheDays", "usage"))
+		cmd.Flags().Duration(MediaUnusedGracePeriodFlag(), cfg.MediaUnusedGracePeriod, fieldtag("MediaUnusedGracePeriod", "usage"))
 		cmd.Flags().Uint64(MediaEmojiLocalMaxSizeFlag(), uint64(cfg.MediaEmojiLocalMaxSize), fieldtag("MediaEmojiLocalMaxSize", "usage"))
 		cmd.Flags().Uint64(MediaEmojiRemoteMaxSizeFlag(), uint64(cfg.MediaEmojiRemoteMaxSize), fieldtag("MediaEmojiRemoteMaxSize", "usage"))
+		cmd.Flags().Bool(MediaProxyEnabledFlag(), cfg.MediaProxyEnabled, fieldtag("MediaProxyEnabled", "usage"))
+		cmd.Flags().Bool(MediaAutoAltTextEnabledFlag(), cfg.MediaAutoAltTextEnabled, fieldtag("MediaAutoAltTextEnabled", "usage"))
+		cmd.Flags().String(MediaAutoAltTextURLFlag(), cfg.MediaAutoAltTextURL, fieldtag("MediaAutoAltTextURL", "usage"))
+		cmd.Flags().StringSlice(MediaAllowedTypesFlag(), cfg.MediaAllowedTypes, fieldtag("MediaAllowedTypes", "usage"))
+		cmd.Flags().StringSlice(MediaBlockedTypesFlag(), cfg.MediaBlockedTypes, fieldtag("MediaBlockedTypes", "usage"))
+		cmd.Flags().Int(MediaThumbnailWorkersFlag(), cfg.MediaThumbnailWorkers, fieldtag("MediaThumbnailWorkers", "usage"))
 
 		// Storage
 		cmd.Flags().String(StorageBackendFlag(), cfg.StorageBackend, fieldtag("StorageBackend", "usage"))
 		cmd.Flags().String(StorageLocalBasePathFlag(), cfg.StorageLocalBasePath, fieldtag("StorageLocalBasePath", "usage"))
+		cmd.Flags().Uint64(StorageLocalMaxSizeFlag(), uint64(cfg.StorageLocalMaxSize), fieldtag("StorageLocalMaxSize", "usage"))
+		cmd.Flags().String(StorageMigrateFromFlag(), cfg.StorageMigrateFrom, fieldtag("StorageMigrateFrom", "usage"))
 
 		// Statuses
 		cmd.Flags().Int(StatusesMaxCharsFlag(), cfg.StatusesMaxChars, fieldtag("StatusesMaxChars", "usage"))
+		cmd.Flags().Int(StatusesMaxCharsPublicFlag(), cfg.StatusesMaxCharsPublic, fieldtag("StatusesMaxCharsPublic", "usage"))
+		cmd.Flags().Int(StatusesMaxCharsUnlistedFlag(), cfg.StatusesMaxCharsUnlisted, fieldtag("StatusesMaxCharsUnlisted", "usage"))
+		cmd.Flags().Int(StatusesMaxCharsPrivateFlag(), cfg.StatusesMaxCharsPrivate, fieldtag("StatusesMaxCharsPrivate", "usage"))
+		cmd.Flags().Int(StatusesMaxCharsDirectFlag(), cfg.StatusesMaxCharsDirect, fieldtag("StatusesMaxCharsDirect", "usage"))
 		cmd.Flags().Int(StatusesCWMaxCharsFlag(), cfg.StatusesCWMaxChars, fieldtag("StatusesCWMaxChars", "usage"))
 		cmd.Flags().Int(StatusesPollMaxOptionsFlag(), cfg.StatusesPollMaxOptions, fieldtag("StatusesPollMaxOptions", "usage"))
 		cmd.Flags().Int(StatusesPollOptionMaxCharsFlag(), cfg.StatusesPollOptionMaxChars, fieldtag("StatusesPollOptionMaxChars", "usage"))
 		cmd.Flags().Int(StatusesMediaMaxFilesFlag(), cfg.StatusesMediaMaxFiles, fieldtag("StatusesMediaMaxFiles", "usage"))
+		cmd.Flags().Int(StatusesMaxDraftsFlag(), cfg.StatusesMaxDrafts, fieldtag("StatusesMaxDrafts", "usage"))
+		cmd.Flags().Int(StatusesMaxThreadDepthFlag(), cfg.StatusesMaxThreadDepth, fieldtag("StatusesMaxThreadDepth", "usage"))
+		cmd.Flags().String(StatusesThreadDepthPolicyFlag(), cfg.StatusesThreadDepthPolicy, fieldtag("StatusesThreadDepthPolicy", "usage"))
+		cmd.Flags().Bool(StatusShowDislikesFlag(), cfg.StatusShowDislikes, fieldtag("StatusShowDislikes", "usage"))
+
+		// Streaming
+		cmd.Flags().Duration(StreamingPingIntervalFlag(), cfg.StreamingPingInterval, fieldtag("StreamingPingInterval", "usage"))
+		cmd.Flags().Duration(StreamingPingTimeoutFlag(), cfg.StreamingPingTimeout, fieldtag("StreamingPingTimeout", "usage"))
 
 		// LetsEncrypt
 		cmd.Flags().Bool(LetsEncryptEnabledFlag(), cfg.LetsEncryptEnabled, fieldtag("LetsEncryptEnabled", "usage"))
@@ -125,6 +160,11 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().String(OIDCClientIDFlag(), cfg.OIDCClientID, fieldtag("OIDCClientID", "usage"))
 		cmd.Flags().String(OIDCClientSecretFlag(), cfg.OIDCClientSecret, fieldtag("OIDCClientSecret", "usage"))
 		cmd.Flags().StringSlice(OIDCScopesFlag(), cfg.OIDCScopes, fieldtag("OIDCScopes", "usage"))
+		cmd.Flags().StringSlice(OIDCModeratorGroupsFlag(), cfg.OIDCModeratorGroups, fieldtag("OIDCModeratorGroups", "usage"))
+		cmd.Flags().StringSlice(OIDCSignupGroupsFlag(), cfg.OIDCSignupGroups, fieldtag("OIDCSignupGroups", "usage"))
+		cmd.Flags().String(OIDCGroupsClaimFlag(), cfg.OIDCGroupsClaim, fieldtag("OIDCGroupsClaim", "usage"))
+		cmd.Flags().String(OIDCUsernameClaimFlag(), cfg.OIDCUsernameClaim, fieldtag("OIDCUsernameClaim", "usage"))
+		cmd.Flags().String(OIDCUsernameModeFlag(), cfg.OIDCUsernameMode, fieldtag("OIDCUsernameMode", "usage"))
 
 		// SMTP
 		cmd.Flags().String(SMTPHostFlag(), cfg.SMTPHost, fieldtag("SMTPHost", "usage"))
@@ -133,19 +173,36 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().String(SMTPPasswordFlag(), cfg.SMTPPassword, fieldtag("SMTPPassword", "usage"))
 		cmd.Flags().String(SMTPFromFlag(), cfg.SMTPFrom, fieldtag("SMTPFrom", "usage"))
 		cmd.Flags().Bool(SMTPDiscloseRecipientsFlag(), cfg.SMTPDiscloseRecipients, fieldtag("SMTPDiscloseRecipients", "usage"))
+		cmd.Flags().String(SMTPTemplateOverrideDirFlag(), cfg.SMTPTemplateOverrideDir, fieldtag("SMTPTemplateOverrideDir", "usage"))
 
 		// Syslog
 		cmd.Flags().Bool(SyslogEnabledFlag(), cfg.SyslogEnabled, fieldtag("SyslogEnabled", "usage"))
 		cmd.Flags().String(SyslogProtocolFlag(), cfg.SyslogProtocol, fieldtag("SyslogProtocol", "usage"))
 		cmd.Flags().String(SyslogAddressFlag(), cfg.SyslogAddress, fieldtag("SyslogAddress", "usage"))
 
+		// Sessions
+		cmd.Flags().String(SessionBackendFlag(), cfg.SessionBackend, fieldtag("SessionBackend", "usage"))
+		cmd.Flags().String(SessionRedisAddressFlag(), cfg.SessionRedisAddress, fieldtag("SessionRedisAddress", "usage"))
+		cmd.Flags().String(SessionRedisPasswordFlag(), cfg.SessionRedisPassword, fieldtag("SessionRedisPassword", "usage"))
+		cmd.Flags().Int(SessionRedisDBFlag(), cfg.SessionRedisDB, fieldtag("SessionRedisDB", "usage"))
+		cmd.Flags().String(SessionEncryptionKeyFlag(), cfg.SessionEncryptionKey, fieldtag("SessionEncryptionKey", "usage"))
+
 		// Advanced flags
 		cmd.Flags().String(AdvancedCookiesSamesiteFlag(), cfg.AdvancedCookiesSamesite, fieldtag("AdvancedCookiesSamesite", "usage"))
 		cmd.Flags().Int(AdvancedRateLimitRequestsFlag(), cfg.AdvancedRateLimitRequests, fieldtag("AdvancedRateLimitRequests", "usage"))
+		cmd.Flags().Int(AdvancedInboxRateLimitRequestsFlag(), cfg.AdvancedInboxRateLimitRequests, fieldtag("AdvancedInboxRateLimitRequests", "usage"))
+		cmd.Flags().Uint64(AdvancedInboxMaxBodySizeFlag(), uint64(cfg.AdvancedInboxMaxBodySize), fieldtag("AdvancedInboxMaxBodySize", "usage"))
 		cmd.Flags().Int(AdvancedThrottlingMultiplierFlag(), cfg.AdvancedThrottlingMultiplier, fieldtag("AdvancedThrottlingMultiplier", "usage"))
 		cmd.Flags().Duration(AdvancedThrottlingRetryAfterFlag(), cfg.AdvancedThrottlingRetryAfter, fieldtag("AdvancedThrottlingRetryAfter", "usage"))
 		cmd.Flags().Int(AdvancedSenderMultiplierFlag(), cfg.AdvancedSenderMultiplier, fieldtag("AdvancedSenderMultiplier", "usage"))
 
+		// Maintenance
+		cmd.Flags().Bool(MaintenanceModeFlag(), cfg.MaintenanceMode, fieldtag("MaintenanceMode", "usage"))
+		cmd.Flags().String(MaintenanceMessageFlag(), cfg.MaintenanceMessage, fieldtag("MaintenanceMessage", "usage"))
+
+		// Shutdown
+		cmd.Flags().Duration(ShutdownGracePeriodFlag(), cfg.ShutdownGracePeriod, fieldtag("ShutdownGracePeriod", "usage"))
+
 		cmd.Flags().String(RequestIDHeaderFlag(), cfg.RequestIDHeader, fieldtag("RequestIDHeader", "usage"))
 	})
 }
@@ -194,9 +251,74 @@ func AddAdminTrans(cmd *cobra.Command) {
 	}
 }
 
+// AddAdminBreachedPasswords attaches flags pertaining to building a breached passwords filter.
+func AddAdminBreachedPasswords(cmd *cobra.Command) {
+	name := AdminBreachedPasswordsInputFlag()
+	usage := fieldtag("AdminBreachedPasswordsInput", "usage")
+	cmd.Flags().String(name, "", usage) // REQUIRED
+	if err := cmd.MarkFlagRequired(name); err != nil {
+		panic(err)
+	}
+}
+
+// AddAdminAccountList attaches flags pertaining to listing accounts.
+func AddAdminAccountList(cmd *cobra.Command) {
+	cmd.Flags().String(AdminAccountListOriginFlag(), Defaults.AdminAccountListOrigin, fieldtag("AdminAccountListOrigin", "usage"))
+	cmd.Flags().String(AdminAccountListDomainFlag(), "", fieldtag("AdminAccountListDomain", "usage"))
+	cmd.Flags().Bool(AdminAccountListSuspendedFlag(), false, fieldtag("AdminAccountListSuspended", "usage"))
+	cmd.Flags().Bool(AdminAccountListAdminFlag(), false, fieldtag("AdminAccountListAdmin", "usage"))
+	AddAdminAccountAPI(cmd)
+}
+
+// AddAdminAccountInspect attaches flags pertaining to inspecting an account.
+func AddAdminAccountInspect(cmd *cobra.Command) {
+	AddAdminAccount(cmd)
+	AddAdminAccountAPI(cmd)
+}
+
+// AddAdminAccountAPI attaches flags for admin account commands
+// which can go through the API of the running instance instead
+// of the database.
+func AddAdminAccountAPI(cmd *cobra.Command) {
+	cmd.Flags().Bool(AdminAccountUseAPIFlag(), false, fieldtag("AdminAccountUseAPI", "usage"))
+	cmd.Flags().String(AdminAccountAPITokenFlag(), "", fieldtag("AdminAccountAPIToken", "usage"))
+}
+
+// AddAdminAccountDelete attaches flags pertaining to admin account deletion.
+func AddAdminAccountDelete(cmd *cobra.Command) {
+	AddAdminAccount(cmd)
+	AddAdminDryRun(cmd)
+}
+
 // AddAdminMediaPrune attaches flags pertaining to media storage prune commands.
 func AddAdminMediaPrune(cmd *cobra.Command) {
-	name := AdminMediaPruneDryRunFlag()
-	usage := fieldtag("AdminMediaPruneDryRun", "usage")
+	AddAdminDryRun(cmd)
+}
+
+// AddAdminMediaPruneOrphaned attaches flags pertaining to the orphaned media prune command.
+func AddAdminMediaPruneOrphaned(cmd *cobra.Command) {
+	AddAdminMediaPrune(cmd)
+	cmd.Flags().Var(newDurationValue(Defaults.AdminMediaPruneOlderThan), AdminMediaPruneOlderThanFlag(), fieldtag("AdminMediaPruneOlderThan", "usage"))
+}
+
+// AddAdminMediaUsage attaches flags pertaining to the media usage report.
+func AddAdminMediaUsage(cmd *cobra.Command) {
+	cmd.Flags().String(AdminMediaUsageGroupByFlag(), Defaults.AdminMediaUsageGroupBy, fieldtag("AdminMediaUsageGroupBy", "usage"))
+	cmd.Flags().Int(AdminMediaUsageTopFlag(), Defaults.AdminMediaUsageTop, fieldtag("AdminMediaUsageTop", "usage"))
+	cmd.Flags().String(AdminMediaUsageFormatFlag(), Defaults.AdminMediaUsageFormat, fieldtag("AdminMediaUsageFormat", "usage"))
+}
+
+// AddAdminDryRun attaches the dry run flag used by
+// potentially destructive admin commands.
+func AddAdminDryRun(cmd *cobra.Command) {
+	name := AdminDryRunFlag()
+	usage := fieldtag("AdminDryRun", "usage")
 	cmd.Flags().Bool(name, true, usage)
 }
+
+// AddAdminFederationCheck attaches flags pertaining to the federation check command.
+func AddAdminFederationCheck(cmd *cobra.Command) {
+	name := AdminFederationCheckTargetFlag()
+	usage := fieldtag("AdminFederationCheckTarget", "usage")
+	cmd.Flags().String(name, "", usage)
+}
@@ -41,6 +41,12 @@ func Reload() error {
 	return global.Reload()
 }
 
+// HotReload will reload the current configuration values from file and env,
+// applying only those that are safe to change while running. See ConfigState.HotReload().
+func HotReload() (changed []string, rejected []string, err error) {
+	return global.HotReload()
+}
+
 // LoadEarlyFlags will bind specific flags from given Cobra command to global viper
 // instance, and load the current configuration values. This is useful for flags like
 // .ConfigPath which have to parsed first in order to perform early configuration load.
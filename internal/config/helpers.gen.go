@@ -799,6 +799,56 @@ func GetInstanceExposeSuspendedWeb() bool { return global.GetInstanceExposeSuspe
 // SetInstanceExposeSuspendedWeb safely sets the value for global configuration 'InstanceExposeSuspendedWeb' field
 func SetInstanceExposeSuspendedWeb(v bool) { global.SetInstanceExposeSuspendedWeb(v) }
 
+// GetInstanceExposeActivity safely fetches the Configuration value for state's 'InstanceExposeActivity' field
+func (st *ConfigState) GetInstanceExposeActivity() (v bool) {
+	st.mutex.Lock()
+	v = st.config.InstanceExposeActivity
+	st.mutex.Unlock()
+	return
+}
+
+// SetInstanceExposeActivity safely sets the Configuration value for state's 'InstanceExposeActivity' field
+func (st *ConfigState) SetInstanceExposeActivity(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceExposeActivity = v
+	st.reloadToViper()
+}
+
+// InstanceExposeActivityFlag returns the flag name for the 'InstanceExposeActivity' field
+func InstanceExposeActivityFlag() string { return "instance-expose-activity" }
+
+// GetInstanceExposeActivity safely fetches the value for global configuration 'InstanceExposeActivity' field
+func GetInstanceExposeActivity() bool { return global.GetInstanceExposeActivity() }
+
+// SetInstanceExposeActivity safely sets the value for global configuration 'InstanceExposeActivity' field
+func SetInstanceExposeActivity(v bool) { global.SetInstanceExposeActivity(v) }
+
+// GetInstanceStatsAPI safely fetches the Configuration value for state's 'InstanceStatsAPI' field
+func (st *ConfigState) GetInstanceStatsAPI() (v bool) {
+	st.mutex.Lock()
+	v = st.config.InstanceStatsAPI
+	st.mutex.Unlock()
+	return
+}
+
+// SetInstanceStatsAPI safely sets the Configuration value for state's 'InstanceStatsAPI' field
+func (st *ConfigState) SetInstanceStatsAPI(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceStatsAPI = v
+	st.reloadToViper()
+}
+
+// InstanceStatsAPIFlag returns the flag name for the 'InstanceStatsAPI' field
+func InstanceStatsAPIFlag() string { return "instance-stats-api" }
+
+// GetInstanceStatsAPI safely fetches the value for global configuration 'InstanceStatsAPI' field
+func GetInstanceStatsAPI() bool { return global.GetInstanceStatsAPI() }
+
+// SetInstanceStatsAPI safely sets the value for global configuration 'InstanceStatsAPI' field
+func SetInstanceStatsAPI(v bool) { global.SetInstanceStatsAPI(v) }
+
 // GetInstanceExposePublicTimeline safely fetches the Configuration value for state's 'InstanceExposePublicTimeline' field
 func (st *ConfigState) GetInstanceExposePublicTimeline() (v bool) {
 	st.mutex.Lock()
@@ -849,6 +899,56 @@ func GetInstanceDeliverToSharedInboxes() bool { return global.GetInstanceDeliver
 // SetInstanceDeliverToSharedInboxes safely sets the value for global configuration 'InstanceDeliverToSharedInboxes' field
 func SetInstanceDeliverToSharedInboxes(v bool) { global.SetInstanceDeliverToSharedInboxes(v) }
 
+// GetFederationAllowPrivateIPs safely fetches the Configuration value for state's 'FederationAllowPrivateIPs' field
+func (st *ConfigState) GetFederationAllowPrivateIPs() (v bool) {
+	st.mutex.Lock()
+	v = st.config.FederationAllowPrivateIPs
+	st.mutex.Unlock()
+	return
+}
+
+// SetFederationAllowPrivateIPs safely sets the Configuration value for state's 'FederationAllowPrivateIPs' field
+func (st *ConfigState) SetFederationAllowPrivateIPs(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.FederationAllowPrivateIPs = v
+	st.reloadToViper()
+}
+
+// FederationAllowPrivateIPsFlag returns the flag name for the 'FederationAllowPrivateIPs' field
+func FederationAllowPrivateIPsFlag() string { return "federation-allow-private-ips" }
+
+// GetFederationAllowPrivateIPs safely fetches the value for global configuration 'FederationAllowPrivateIPs' field
+func GetFederationAllowPrivateIPs() bool { return global.GetFederationAllowPrivateIPs() }
+
+// SetFederationAllowPrivateIPs safely sets the value for global configuration 'FederationAllowPrivateIPs' field
+func SetFederationAllowPrivateIPs(v bool) { global.SetFederationAllowPrivateIPs(v) }
+
+// GetFederationUserAgent safely fetches the Configuration value for state's 'FederationUserAgent' field
+func (st *ConfigState) GetFederationUserAgent() (v string) {
+	st.mutex.Lock()
+	v = st.config.FederationUserAgent
+	st.mutex.Unlock()
+	return
+}
+
+// SetFederationUserAgent safely sets the Configuration value for state's 'FederationUserAgent' field
+func (st *ConfigState) SetFederationUserAgent(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.FederationUserAgent = v
+	st.reloadToViper()
+}
+
+// FederationUserAgentFlag returns the flag name for the 'FederationUserAgent' field
+func FederationUserAgentFlag() string { return "federation-user-agent" }
+
+// GetFederationUserAgent safely fetches the value for global configuration 'FederationUserAgent' field
+func GetFederationUserAgent() string { return global.GetFederationUserAgent() }
+
+// SetFederationUserAgent safely sets the value for global configuration 'FederationUserAgent' field
+func SetFederationUserAgent(v string) { global.SetFederationUserAgent(v) }
+
 // GetAccountsRegistrationOpen safely fetches the Configuration value for state's 'AccountsRegistrationOpen' field
 func (st *ConfigState) GetAccountsRegistrationOpen() (v bool) {
 	st.mutex.Lock()
@@ -899,6 +999,60 @@ func GetAccountsApprovalRequired() bool { return global.GetAccountsApprovalRequi
 // SetAccountsApprovalRequired safely sets the value for global configuration 'AccountsApprovalRequired' field
 func SetAccountsApprovalRequired(v bool) { global.SetAccountsApprovalRequired(v) }
 
+// GetAccountsNotifyNewSignups safely fetches the Configuration value for state's 'AccountsNotifyNewSignups' field
+func (st *ConfigState) GetAccountsNotifyNewSignups() (v bool) {
+	st.mutex.Lock()
+	v = st.config.AccountsNotifyNewSignups
+	st.mutex.Unlock()
+	return
+}
+
+// SetAccountsNotifyNewSignups safely sets the Configuration value for state's 'AccountsNotifyNewSignups' field
+func (st *ConfigState) SetAccountsNotifyNewSignups(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsNotifyNewSignups = v
+	st.reloadToViper()
+}
+
+// AccountsNotifyNewSignupsFlag returns the flag name for the 'AccountsNotifyNewSignups' field
+func AccountsNotifyNewSignupsFlag() string { return "accounts-notify-new-signups" }
+
+// GetAccountsNotifyNewSignups safely fetches the value for global configuration 'AccountsNotifyNewSignups' field
+func GetAccountsNotifyNewSignups() bool { return global.GetAccountsNotifyNewSignups() }
+
+// SetAccountsNotifyNewSignups safely sets the value for global configuration 'AccountsNotifyNewSignups' field
+func SetAccountsNotifyNewSignups(v bool) { global.SetAccountsNotifyNewSignups(v) }
+
+// GetAccountsNotifyNewSignupsInterval safely fetches the Configuration value for state's 'AccountsNotifyNewSignupsInterval' field
+func (st *ConfigState) GetAccountsNotifyNewSignupsInterval() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.AccountsNotifyNewSignupsInterval
+	st.mutex.Unlock()
+	return
+}
+
+// SetAccountsNotifyNewSignupsInterval safely sets the Configuration value for state's 'AccountsNotifyNewSignupsInterval' field
+func (st *ConfigState) SetAccountsNotifyNewSignupsInterval(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsNotifyNewSignupsInterval = v
+	st.reloadToViper()
+}
+
+// AccountsNotifyNewSignupsIntervalFlag returns the flag name for the 'AccountsNotifyNewSignupsInterval' field
+func AccountsNotifyNewSignupsIntervalFlag() string { return "accounts-notify-new-signups-interval" }
+
+// GetAccountsNotifyNewSignupsInterval safely fetches the value for global configuration 'AccountsNotifyNewSignupsInterval' field
+func GetAccountsNotifyNewSignupsInterval() time.Duration {
+	return global.GetAccountsNotifyNewSignupsInterval()
+}
+
+// SetAccountsNotifyNewSignupsInterval safely sets the value for global configuration 'AccountsNotifyNewSignupsInterval' field
+func SetAccountsNotifyNewSignupsInterval(v time.Duration) {
+	global.SetAccountsNotifyNewSignupsInterval(v)
+}
+
 // GetAccountsReasonRequired safely fetches the Configuration value for state's 'AccountsReasonRequired' field
 func (st *ConfigState) GetAccountsReasonRequired() (v bool) {
 	st.mutex.Lock()
@@ -974,6 +1128,156 @@ func GetAccountsCustomCSSLength() int { return global.GetAccountsCustomCSSLength
 // SetAccountsCustomCSSLength safely sets the value for global configuration 'AccountsCustomCSSLength' field
 func SetAccountsCustomCSSLength(v int) { global.SetAccountsCustomCSSLength(v) }
 
+// GetAccountsMaxEmojis safely fetches the Configuration value for state's 'AccountsMaxEmojis' field
+func (st *ConfigState) GetAccountsMaxEmojis() (v int) {
+	st.mutex.Lock()
+	v = st.config.AccountsMaxEmojis
+	st.mutex.Unlock()
+	return
+}
+
+// SetAccountsMaxEmojis safely sets the Configuration value for state's 'AccountsMaxEmojis' field
+func (st *ConfigState) SetAccountsMaxEmojis(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsMaxEmojis = v
+	st.reloadToViper()
+}
+
+// AccountsMaxEmojisFlag returns the flag name for the 'AccountsMaxEmojis' field
+func AccountsMaxEmojisFlag() string { return "accounts-max-emojis" }
+
+// GetAccountsMaxEmojis safely fetches the value for global configuration 'AccountsMaxEmojis' field
+func GetAccountsMaxEmojis() int { return global.GetAccountsMaxEmojis() }
+
+// SetAccountsMaxEmojis safely sets the value for global configuration 'AccountsMaxEmojis' field
+func SetAccountsMaxEmojis(v int) { global.SetAccountsMaxEmojis(v) }
+
+// GetAccountsEmojiMaxSize safely fetches the Configuration value for state's 'AccountsEmojiMaxSize' field
+func (st *ConfigState) GetAccountsEmojiMaxSize() (v bytesize.Size) {
+	st.mutex.Lock()
+	v = st.config.AccountsEmojiMaxSize
+	st.mutex.Unlock()
+	return
+}
+
+// SetAccountsEmojiMaxSize safely sets the Configuration value for state's 'AccountsEmojiMaxSize' field
+func (st *ConfigState) SetAccountsEmojiMaxSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsEmojiMaxSize = v
+	st.reloadToViper()
+}
+
+// AccountsEmojiMaxSizeFlag returns the flag name for the 'AccountsEmojiMaxSize' field
+func AccountsEmojiMaxSizeFlag() string { return "accounts-emoji-max-size" }
+
+// GetAccountsEmojiMaxSize safely fetches the value for global configuration 'AccountsEmojiMaxSize' field
+func GetAccountsEmojiMaxSize() bytesize.Size { return global.GetAccountsEmojiMaxSize() }
+
+// SetAccountsEmojiMaxSize safely sets the value for global configuration 'AccountsEmojiMaxSize' field
+func SetAccountsEmojiMaxSize(v bytesize.Size) { global.SetAccountsEmojiMaxSize(v) }
+
+// GetAccountsPasswordMinLength safely fetches the Configuration value for state's 'AccountsPasswordMinLength' field
+func (st *ConfigState) GetAccountsPasswordMinLength() (v int) {
+	st.mutex.Lock()
+	v = st.config.AccountsPasswordMinLength
+	st.mutex.Unlock()
+	return
+}
+
+// SetAccountsPasswordMinLength safely sets the Configuration value for state's 'AccountsPasswordMinLength' field
+func (st *ConfigState) SetAccountsPasswordMinLength(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsPasswordMinLength = v
+	st.reloadToViper()
+}
+
+// AccountsPasswordMinLengthFlag returns the flag name for the 'AccountsPasswordMinLength' field
+func AccountsPasswordMinLengthFlag() string { return "accounts-password-min-length" }
+
+// GetAccountsPasswordMinLength safely fetches the value for global configuration 'AccountsPasswordMinLength' field
+func GetAccountsPasswordMinLength() int { return global.GetAccountsPasswordMinLength() }
+
+// SetAccountsPasswordMinLength safely sets the value for global configuration 'AccountsPasswordMinLength' field
+func SetAccountsPasswordMinLength(v int) { global.SetAccountsPasswordMinLength(v) }
+
+// GetAccountsPasswordMinEntropy safely fetches the Configuration value for state's 'AccountsPasswordMinEntropy' field
+func (st *ConfigState) GetAccountsPasswordMinEntropy() (v float64) {
+	st.mutex.Lock()
+	v = st.config.AccountsPasswordMinEntropy
+	st.mutex.Unlock()
+	return
+}
+
+// SetAccountsPasswordMinEntropy safely sets the Configuration value for state's 'AccountsPasswordMinEntropy' field
+func (st *ConfigState) SetAccountsPasswordMinEntropy(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsPasswordMinEntropy = v
+	st.reloadToViper()
+}
+
+// AccountsPasswordMinEntropyFlag returns the flag name for the 'AccountsPasswordMinEntropy' field
+func AccountsPasswordMinEntropyFlag() string { return "accounts-password-min-entropy" }
+
+// GetAccountsPasswordMinEntropy safely fetches the value for global configuration 'AccountsPasswordMinEntropy' field
+func GetAccountsPasswordMinEntropy() float64 { return global.GetAccountsPasswordMinEntropy() }
+
+// SetAccountsPasswordMinEntropy safely sets the value for global configuration 'AccountsPasswordMinEntropy' field
+func SetAccountsPasswordMinEntropy(v float64) { global.SetAccountsPasswordMinEntropy(v) }
+
+// GetAccountsPasswordBreachedFilter safely fetches the Configuration value for state's 'AccountsPasswordBreachedFilter' field
+func (st *ConfigState) GetAccountsPasswordBreachedFilter() (v string) {
+	st.mutex.Lock()
+	v = st.config.AccountsPasswordBreachedFilter
+	st.mutex.Unlock()
+	return
+}
+
+// SetAccountsPasswordBreachedFilter safely sets the Configuration value for state's 'AccountsPasswordBreachedFilter' field
+func (st *ConfigState) SetAccountsPasswordBreachedFilter(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsPasswordBreachedFilter = v
+	st.reloadToViper()
+}
+
+// AccountsPasswordBreachedFilterFlag returns the flag name for the 'AccountsPasswordBreachedFilter' field
+func AccountsPasswordBreachedFilterFlag() string { return "accounts-password-breached-filter" }
+
+// GetAccountsPasswordBreachedFilter safely fetches the value for global configuration 'AccountsPasswordBreachedFilter' field
+func GetAccountsPasswordBreachedFilter() string { return global.GetAccountsPasswordBreachedFilter() }
+
+// SetAccountsPasswordBreachedFilter safely sets the value for global configuration 'AccountsPasswordBreachedFilter' field
+func SetAccountsPasswordBreachedFilter(v string) { global.SetAccountsPasswordBreachedFilter(v) }
+
+// GetAccountsSelfDeleteDelay safely fetches the Configuration value for state's 'AccountsSelfDeleteDelay' field
+func (st *ConfigState) GetAccountsSelfDeleteDelay() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.AccountsSelfDeleteDelay
+	st.mutex.Unlock()
+	return
+}
+
+// SetAccountsSelfDeleteDelay safely sets the Configuration value for state's 'AccountsSelfDeleteDelay' field
+func (st *ConfigState) SetAccountsSelfDeleteDelay(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsSelfDeleteDelay = v
+	st.reloadToViper()
+}
+
+// AccountsSelfDeleteDelayFlag returns the flag name for the 'AccountsSelfDeleteDelay' field
+func AccountsSelfDeleteDelayFlag() string { return "accounts-self-delete-delay" }
+
+// GetAccountsSelfDeleteDelay safely fetches the value for global configuration 'AccountsSelfDeleteDelay' field
+func GetAccountsSelfDeleteDelay() time.Duration { return global.GetAccountsSelfDeleteDelay() }
+
+// SetAccountsSelfDeleteDelay safely sets the value for global configuration 'AccountsSelfDeleteDelay' field
+func SetAccountsSelfDeleteDelay(v time.Duration) { global.SetAccountsSelfDeleteDelay(v) }
+
 // GetMediaImageMaxSize safely fetches the Configuration value for state's 'MediaImageMaxSize' field
 func (st *ConfigState) GetMediaImageMaxSize() (v bytesize.Size) {
 	st.mutex.Lock()
@@ -1149,6 +1453,235 @@ func GetMediaEmojiRemoteMaxSize() bytesize.Size { return global.GetMediaEmojiRem
 // SetMediaEmojiRemoteMaxSize safely sets the value for global configuration 'MediaEmojiRemoteMaxSize' field
 func SetMediaEmojiRemoteMaxSize(v bytesize.Size) { global.SetMediaEmojiRemoteMaxSize(v) }
 
+// GetMediaProxyEnabled safely fetches the Configuration value for state's 'MediaProxyEnabled' field
+func (st *ConfigState) GetMediaProxyEnabled() (v bool) {
+	st.mutex.Lock()
+	v = st.config.MediaProxyEnabled
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaProxyEnabled safely sets the Configuration value for state's 'MediaProxyEnabled' field
+func (st *ConfigState) SetMediaProxyEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaProxyEnabled = v
+	st.reloadToViper()
+}
+
+// MediaProxyEnabledFlag returns the flag name for the 'MediaProxyEnabled' field
+func MediaProxyEnabledFlag() string { return "media-proxy-enabled" }
+
+// GetMediaProxyEnabled safely fetches the value for global configuration 'MediaProxyEnabled' field
+func GetMediaProxyEnabled() bool { return global.GetMediaProxyEnabled() }
+
+// SetMediaProxyEnabled safely sets the value for global configuration 'MediaProxyEnabled' field
+func SetMediaProxyEnabled(v bool) { global.SetMediaProxyEnabled(v) }
+
+// GetMediaAutoAltTextEnabled safely fetches the Configuration value for state's 'MediaAutoAltTextEnabled' field
+func (st *ConfigState) GetMediaAutoAltTextEnabled() (v bool) {
+	st.mutex.Lock()
+	v = st.config.MediaAutoAltTextEnabled
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaAutoAltTextEnabled safely sets the Configuration value for state's 'MediaAutoAltTextEnabled' field
+func (st *ConfigState) SetMediaAutoAltTextEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaAutoAltTextEnabled = v
+	st.reloadToViper()
+}
+
+// MediaAutoAltTextEnabledFlag returns the flag name for the 'MediaAutoAltTextEnabled' field
+func MediaAutoAltTextEnabledFlag() string { return "media-auto-alt-text-enabled" }
+
+// GetMediaAutoAltTextEnabled safely fetches the value for global configuration 'MediaAutoAltTextEnabled' field
+func GetMediaAutoAltTextEnabled() bool { return global.GetMediaAutoAltTextEnabled() }
+
+// SetMediaAutoAltTextEnabled safely sets the value for global configuration 'MediaAutoAltTextEnabled' field
+func SetMediaAutoAltTextEnabled(v bool) { global.SetMediaAutoAltTextEnabled(v) }
+
+// GetMediaAutoAltTextURL safely fetches the Configuration value for state's 'MediaAutoAltTextURL' field
+func (st *ConfigState) GetMediaAutoAltTextURL() (v string) {
+	st.mutex.Lock()
+	v = st.config.MediaAutoAltTextURL
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaAutoAltTextURL safely sets the Configuration value for state's 'MediaAutoAltTextURL' field
+func (st *ConfigState) SetMediaAutoAltTextURL(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaAutoAltTextURL = v
+	st.reloadToViper()
+}
+
+// MediaAutoAltTextURLFlag returns the flag name for the 'MediaAutoAltTextURL' field
+func MediaAutoAltTextURLFlag() string { return "media-auto-alt-text-url" }
+
+// GetMediaAutoAltTextURL safely fetches the value for global configuration 'MediaAutoAltTextURL' field
+func GetMediaAutoAltTextURL() string { return global.GetMediaAutoAltTextURL() }
+
+// SetMediaAutoAltTextURL safely sets the value for global configuration 'MediaAutoAltTextURL' field
+func SetMediaAutoAltTextURL(v string) { global.SetMediaAutoAltTextURL(v) }
+
+// GetMediaUnusedGracePeriod safely fetches the Configuration value for state's 'MediaUnusedGracePeriod' field
+func (st *ConfigState) GetMediaUnusedGracePeriod() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.MediaUnusedGracePeriod
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaUnusedGracePeriod safely sets the Configuration value for state's 'MediaUnusedGracePeriod' field
+func (st *ConfigState) SetMediaUnusedGracePeriod(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaUnusedGracePeriod = v
+	st.reloadToViper()
+}
+
+// MediaUnusedGracePeriodFlag returns the flag name for the 'MediaUnusedGracePeriod' field
+func MediaUnusedGracePeriodFlag() string { return "media-unused-grace-period" }
+
+// GetMediaUnusedGracePeriod safely fetches the value for global configuration 'MediaUnusedGracePeriod' field
+func GetMediaUnusedGracePeriod() time.Duration { return global.GetMediaUnusedGracePeriod() }
+
+// SetMediaUnusedGracePeriod safely sets the value for global configuration 'MediaUnusedGracePeriod' field
+func SetMediaUnusedGracePeriod(v time.Duration) { global.SetMediaUnusedGracePeriod(v) }
+
+// GetMediaAllowedTypes safely fetches the Configuration value for state's 'MediaAllowedTypes' field
+func (st *ConfigState) GetMediaAllowedTypes() (v []string) {
+	st.mutex.Lock()
+	v = st.config.MediaAllowedTypes
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaAllowedTypes safely sets the Configuration value for state's 'MediaAllowedTypes' field
+func (st *ConfigState) SetMediaAllowedTypes(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaAllowedTypes = v
+	st.reloadToViper()
+}
+
+// MediaAllowedTypesFlag returns the flag name for the 'MediaAllowedTypes' field
+func MediaAllowedTypesFlag() string { return "media-allowed-types" }
+
+// GetMediaAllowedTypes safely fetches the value for global configuration 'MediaAllowedTypes' field
+func GetMediaAllowedTypes() []string { return global.GetMediaAllowedTypes() }
+
+// SetMediaAllowedTypes safely sets the value for global configuration 'MediaAllowedTypes' field
+func SetMediaAllowedTypes(v []string) { global.SetMediaAllowedTypes(v) }
+
+// GetMediaBlockedTypes safely fetches the Configuration value for state's 'MediaBlockedTypes' field
+func (st *ConfigState) GetMediaBlockedTypes() (v []string) {
+	st.mutex.Lock()
+	v = st.config.MediaBlockedTypes
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaBlockedTypes safely sets the Configuration value for state's 'MediaBlockedTypes' field
+func (st *ConfigState) SetMediaBlockedTypes(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaBlockedTypes = v
+	st.reloadToViper()
+}
+
+// MediaBlockedTypesFlag returns the flag name for the 'MediaBlockedTypes' field
+func MediaBlockedTypesFlag() string { return "media-blocked-types" }
+
+// GetMediaBlockedTypes safely fetches the value for global configuration 'MediaBlockedTypes' field
+func GetMediaBlockedTypes() []string { return global.GetMediaBlockedTypes() }
+
+// SetMediaBlockedTypes safely sets the value for global configuration 'MediaBlockedTypes' field
+func SetMediaBlockedTypes(v []string) { global.SetMediaBlockedTypes(v) }
+
+// GetMediaThumbnailWorkers safely fetches the Configuration value for state's 'MediaThumbnailWorkers' field
+func (st *ConfigState) GetMediaThumbnailWorkers() (v int) {
+	st.mutex.Lock()
+	v = st.config.MediaThumbnailWorkers
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaThumbnailWorkers safely sets the Configuration value for state's 'MediaThumbnailWorkers' field
+func (st *ConfigState) SetMediaThumbnailWorkers(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaThumbnailWorkers = v
+	st.reloadToViper()
+}
+
+// MediaThumbnailWorkersFlag returns the flag name for the 'MediaThumbnailWorkers' field
+func MediaThumbnailWorkersFlag() string { return "media-thumbnail-workers" }
+
+// GetMediaThumbnailWorkers safely fetches the value for global configuration 'MediaThumbnailWorkers' field
+func GetMediaThumbnailWorkers() int { return global.GetMediaThumbnailWorkers() }
+
+// SetMediaThumbnailWorkers safely sets the value for global configuration 'MediaThumbnailWorkers' field
+func SetMediaThumbnailWorkers(v int) { global.SetMediaThumbnailWorkers(v) }
+
+// GetMediaRoles safely fetches the Configuration value for state's 'MediaRoles' field
+func (st *ConfigState) GetMediaRoles() (v map[string]MediaRoleConfiguration) {
+	st.mutex.Lock()
+	v = st.config.MediaRoles
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaRoles safely sets the Configuration value for state's 'MediaRoles' field
+func (st *ConfigState) SetMediaRoles(v map[string]MediaRoleConfiguration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaRoles = v
+	st.reloadToViper()
+}
+
+// MediaRolesFlag returns the flag name for the 'MediaRoles' field
+func MediaRolesFlag() string { return "media-roles" }
+
+// GetMediaRoles safely fetches the value for global configuration 'MediaRoles' field
+func GetMediaRoles() map[string]MediaRoleConfiguration { return global.GetMediaRoles() }
+
+// SetMediaRoles safely sets the value for global configuration 'MediaRoles' field
+func SetMediaRoles(v map[string]MediaRoleConfiguration) { global.SetMediaRoles(v) }
+
+// GetMediaOEmbedProviders safely fetches the Configuration value for state's 'MediaOEmbedProviders' field
+func (st *ConfigState) GetMediaOEmbedProviders() (v map[string]OEmbedProviderConfiguration) {
+	st.mutex.Lock()
+	v = st.config.MediaOEmbedProviders
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaOEmbedProviders safely sets the Configuration value for state's 'MediaOEmbedProviders' field
+func (st *ConfigState) SetMediaOEmbedProviders(v map[string]OEmbedProviderConfiguration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaOEmbedProviders = v
+	st.reloadToViper()
+}
+
+// MediaOEmbedProvidersFlag returns the flag name for the 'MediaOEmbedProviders' field
+func MediaOEmbedProvidersFlag() string { return "media-oembed-providers" }
+
+// GetMediaOEmbedProviders safely fetches the value for global configuration 'MediaOEmbedProviders' field
+func GetMediaOEmbedProviders() map[string]OEmbedProviderConfiguration {
+	return global.GetMediaOEmbedProviders()
+}
+
+// SetMediaOEmbedProviders safely sets the value for global configuration 'MediaOEmbedProviders' field
+func SetMediaOEmbedProviders(v map[string]OEmbedProviderConfiguration) {
+	global.SetMediaOEmbedProviders(v)
+}
+
 // GetStorageBackend safely fetches the Configuration value for state's 'StorageBackend' field
 func (st *ConfigState) GetStorageBackend() (v string) {
 	st.mutex.Lock()
@@ -1199,6 +1732,31 @@ func GetStorageLocalBasePath() string { return global.GetStorageLocalBasePath()
 // SetStorageLocalBasePath safely sets the value for global configuration 'StorageLocalBasePath' field
 func SetStorageLocalBasePath(v string) { global.SetStorageLocalBasePath(v) }
 
+// GetStorageLocalMaxSize safely fetches the Configuration value for state's 'StorageLocalMaxSize' field
+func (st *ConfigState) GetStorageLocalMaxSize() (v bytesize.Size) {
+	st.mutex.Lock()
+	v = st.config.StorageLocalMaxSize
+	st.mutex.Unlock()
+	return
+}
+
+// SetStorageLocalMaxSize safely sets the Configuration value for state's 'StorageLocalMaxSize' field
+func (st *ConfigState) SetStorageLocalMaxSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageLocalMaxSize = v
+	st.reloadToViper()
+}
+
+// StorageLocalMaxSizeFlag returns the flag name for the 'StorageLocalMaxSize' field
+func StorageLocalMaxSizeFlag() string { return "storage-local-max-size" }
+
+// GetStorageLocalMaxSize safely fetches the value for global configuration 'StorageLocalMaxSize' field
+func GetStorageLocalMaxSize() bytesize.Size { return global.GetStorageLocalMaxSize() }
+
+// SetStorageLocalMaxSize safely sets the value for global configuration 'StorageLocalMaxSize' field
+func SetStorageLocalMaxSize(v bytesize.Size) { global.SetStorageLocalMaxSize(v) }
+
 // GetStorageS3Endpoint safely fetches the Configuration value for state's 'StorageS3Endpoint' field
 func (st *ConfigState) GetStorageS3Endpoint() (v string) {
 	st.mutex.Lock()
@@ -1349,6 +1907,81 @@ func GetStorageS3Proxy() bool { return global.GetStorageS3Proxy() }
 // SetStorageS3Proxy safely sets the value for global configuration 'StorageS3Proxy' field
 func SetStorageS3Proxy(v bool) { global.SetStorageS3Proxy(v) }
 
+// GetStorageS3RedirectURLExpiry safely fetches the Configuration value for state's 'StorageS3RedirectURLExpiry' field
+func (st *ConfigState) GetStorageS3RedirectURLExpiry() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.StorageS3RedirectURLExpiry
+	st.mutex.Unlock()
+	return
+}
+
+// SetStorageS3RedirectURLExpiry safely sets the Configuration value for state's 'StorageS3RedirectURLExpiry' field
+func (st *ConfigState) SetStorageS3RedirectURLExpiry(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3RedirectURLExpiry = v
+	st.reloadToViper()
+}
+
+// StorageS3RedirectURLExpiryFlag returns the flag name for the 'StorageS3RedirectURLExpiry' field
+func StorageS3RedirectURLExpiryFlag() string { return "storage-s3-redirect-url-expiry" }
+
+// GetStorageS3RedirectURLExpiry safely fetches the value for global configuration 'StorageS3RedirectURLExpiry' field
+func GetStorageS3RedirectURLExpiry() time.Duration { return global.GetStorageS3RedirectURLExpiry() }
+
+// SetStorageS3RedirectURLExpiry safely sets the value for global configuration 'StorageS3RedirectURLExpiry' field
+func SetStorageS3RedirectURLExpiry(v time.Duration) { global.SetStorageS3RedirectURLExpiry(v) }
+
+// GetStorageS3MultipartThreshold safely fetches the Configuration value for state's 'StorageS3MultipartThreshold' field
+func (st *ConfigState) GetStorageS3MultipartThreshold() (v bytesize.Size) {
+	st.mutex.Lock()
+	v = st.config.StorageS3MultipartThreshold
+	st.mutex.Unlock()
+	return
+}
+
+// SetStorageS3MultipartThreshold safely sets the Configuration value for state's 'StorageS3MultipartThreshold' field
+func (st *ConfigState) SetStorageS3MultipartThreshold(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3MultipartThreshold = v
+	st.reloadToViper()
+}
+
+// StorageS3MultipartThresholdFlag returns the flag name for the 'StorageS3MultipartThreshold' field
+func StorageS3MultipartThresholdFlag() string { return "storage-s3-multipart-threshold" }
+
+// GetStorageS3MultipartThreshold safely fetches the value for global configuration 'StorageS3MultipartThreshold' field
+func GetStorageS3MultipartThreshold() bytesize.Size { return global.GetStorageS3MultipartThreshold() }
+
+// SetStorageS3MultipartThreshold safely sets the value for global configuration 'StorageS3MultipartThreshold' field
+func SetStorageS3MultipartThreshold(v bytesize.Size) { global.SetStorageS3MultipartThreshold(v) }
+
+// GetStorageMigrateFrom safely fetches the Configuration value for state's 'StorageMigrateFrom' field
+func (st *ConfigState) GetStorageMigrateFrom() (v string) {
+	st.mutex.Lock()
+	v = st.config.StorageMigrateFrom
+	st.mutex.Unlock()
+	return
+}
+
+// SetStorageMigrateFrom safely sets the Configuration value for state's 'StorageMigrateFrom' field
+func (st *ConfigState) SetStorageMigrateFrom(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageMigrateFrom = v
+	st.reloadToViper()
+}
+
+// StorageMigrateFromFlag returns the flag name for the 'StorageMigrateFrom' field
+func StorageMigrateFromFlag() string { return "storage-migrate-from" }
+
+// GetStorageMigrateFrom safely fetches the value for global configuration 'StorageMigrateFrom' field
+func GetStorageMigrateFrom() string { return global.GetStorageMigrateFrom() }
+
+// SetStorageMigrateFrom safely sets the value for global configuration 'StorageMigrateFrom' field
+func SetStorageMigrateFrom(v string) { global.SetStorageMigrateFrom(v) }
+
 // GetStatusesMaxChars safely fetches the Configuration value for state's 'StatusesMaxChars' field
 func (st *ConfigState) GetStatusesMaxChars() (v int) {
 	st.mutex.Lock()
@@ -1371,8 +2004,108 @@ func StatusesMaxCharsFlag() string { return "statuses-max-chars" }
 // GetStatusesMaxChars safely fetches the value for global configuration 'StatusesMaxChars' field
 func GetStatusesMaxChars() int { return global.GetStatusesMaxChars() }
 
-// SetStatusesMaxChars safely sets the value for global configuration 'StatusesMaxChars' field
-func SetStatusesMaxChars(v int) { global.SetStatusesMaxChars(v) }
+// SetStatusesMaxChars safely sets the value for global configuration 'StatusesMaxChars' field
+func SetStatusesMaxChars(v int) { global.SetStatusesMaxChars(v) }
+
+// GetStatusesMaxCharsPublic safely fetches the Configuration value for state's 'StatusesMaxCharsPublic' field
+func (st *ConfigState) GetStatusesMaxCharsPublic() (v int) {
+	st.mutex.Lock()
+	v = st.config.StatusesMaxCharsPublic
+	st.mutex.Unlock()
+	return
+}
+
+// SetStatusesMaxCharsPublic safely sets the Configuration value for state's 'StatusesMaxCharsPublic' field
+func (st *ConfigState) SetStatusesMaxCharsPublic(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesMaxCharsPublic = v
+	st.reloadToViper()
+}
+
+// StatusesMaxCharsPublicFlag returns the flag name for the 'StatusesMaxCharsPublic' field
+func StatusesMaxCharsPublicFlag() string { return "statuses-max-chars-public" }
+
+// GetStatusesMaxCharsPublic safely fetches the value for global configuration 'StatusesMaxCharsPublic' field
+func GetStatusesMaxCharsPublic() int { return global.GetStatusesMaxCharsPublic() }
+
+// SetStatusesMaxCharsPublic safely sets the value for global configuration 'StatusesMaxCharsPublic' field
+func SetStatusesMaxCharsPublic(v int) { global.SetStatusesMaxCharsPublic(v) }
+
+// GetStatusesMaxCharsUnlisted safely fetches the Configuration value for state's 'StatusesMaxCharsUnlisted' field
+func (st *ConfigState) GetStatusesMaxCharsUnlisted() (v int) {
+	st.mutex.Lock()
+	v = st.config.StatusesMaxCharsUnlisted
+	st.mutex.Unlock()
+	return
+}
+
+// SetStatusesMaxCharsUnlisted safely sets the Configuration value for state's 'StatusesMaxCharsUnlisted' field
+func (st *ConfigState) SetStatusesMaxCharsUnlisted(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesMaxCharsUnlisted = v
+	st.reloadToViper()
+}
+
+// StatusesMaxCharsUnlistedFlag returns the flag name for the 'StatusesMaxCharsUnlisted' field
+func StatusesMaxCharsUnlistedFlag() string { return "statuses-max-chars-unlisted" }
+
+// GetStatusesMaxCharsUnlisted safely fetches the value for global configuration 'StatusesMaxCharsUnlisted' field
+func GetStatusesMaxCharsUnlisted() int { return global.GetStatusesMaxCharsUnlisted() }
+
+// SetStatusesMaxCharsUnlisted safely sets the value for global configuration 'StatusesMaxCharsUnlisted' field
+func SetStatusesMaxCharsUnlisted(v int) { global.SetStatusesMaxCharsUnlisted(v) }
+
+// GetStatusesMaxCharsPrivate safely fetches the Configuration value for state's 'StatusesMaxCharsPrivate' field
+func (st *ConfigState) GetStatusesMaxCharsPrivate() (v int) {
+	st.mutex.Lock()
+	v = st.config.StatusesMaxCharsPrivate
+	st.mutex.Unlock()
+	return
+}
+
+// SetStatusesMaxCharsPrivate safely sets the Configuration value for state's 'StatusesMaxCharsPrivate' field
+func (st *ConfigState) SetStatusesMaxCharsPrivate(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesMaxCharsPrivate = v
+	st.reloadToViper()
+}
+
+// StatusesMaxCharsPrivateFlag returns the flag name for the 'StatusesMaxCharsPrivate' field
+func StatusesMaxCharsPrivateFlag() string { return "statuses-max-chars-private" }
+
+// GetStatusesMaxCharsPrivate safely fetches the value for global configuration 'StatusesMaxCharsPrivate' field
+func GetStatusesMaxCharsPrivate() int { return global.GetStatusesMaxCharsPrivate() }
+
+// SetStatusesMaxCharsPrivate safely sets the value for global configuration 'StatusesMaxCharsPrivate' field
+func SetStatusesMaxCharsPrivate(v int) { global.SetStatusesMaxCharsPrivate(v) }
+
+// GetStatusesMaxCharsDirect safely fetches the Configuration value for state's 'StatusesMaxCharsDirect' field
+func (st *ConfigState) GetStatusesMaxCharsDirect() (v int) {
+	st.mutex.Lock()
+	v = st.config.StatusesMaxCharsDirect
+	st.mutex.Unlock()
+	return
+}
+
+// SetStatusesMaxCharsDirect safely sets the Configuration value for state's 'StatusesMaxCharsDirect' field
+func (st *ConfigState) SetStatusesMaxCharsDirect(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesMaxCharsDirect = v
+	st.reloadToViper()
+}
+
+// StatusesMaxCharsDirectFlag returns the flag name for the 'StatusesMaxCharsDirect' field
+func StatusesMaxCharsDirectFlag() string { return "statuses-max-chars-direct" }
+
+// GetStatusesMaxCharsDirect safely fetches the value for global configuration 'StatusesMaxCharsDirect' field
+func GetStatusesMaxCharsDirect() int { return global.GetStatusesMaxCharsDirect() }
+
+// SetStatusesMaxCharsDirect safely sets the value for global configuration 'StatusesMaxCharsDirect' field
+func SetStatusesMaxCharsDirect(v int) { global.SetStatusesMaxCharsDirect(v) }
 
 // GetStatusesCWMaxChars safely fetches the Configuration value for state's 'StatusesCWMaxChars' field
 func (st *ConfigState) GetStatusesCWMaxChars() (v int) {
@@ -1474,6 +2207,156 @@ func GetStatusesMediaMaxFiles() int { return global.GetStatusesMediaMaxFiles() }
 // SetStatusesMediaMaxFiles safely sets the value for global configuration 'StatusesMediaMaxFiles' field
 func SetStatusesMediaMaxFiles(v int) { global.SetStatusesMediaMaxFiles(v) }
 
+// GetStatusesMaxDrafts safely fetches the Configuration value for state's 'StatusesMaxDrafts' field
+func (st *ConfigState) GetStatusesMaxDrafts() (v int) {
+	st.mutex.Lock()
+	v = st.config.StatusesMaxDrafts
+	st.mutex.Unlock()
+	return
+}
+
+// SetStatusesMaxDrafts safely sets the Configuration value for state's 'StatusesMaxDrafts' field
+func (st *ConfigState) SetStatusesMaxDrafts(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesMaxDrafts = v
+	st.reloadToViper()
+}
+
+// StatusesMaxDraftsFlag returns the flag name for the 'StatusesMaxDrafts' field
+func StatusesMaxDraftsFlag() string { return "statuses-max-drafts" }
+
+// GetStatusesMaxDrafts safely fetches the value for global configuration 'StatusesMaxDrafts' field
+func GetStatusesMaxDrafts() int { return global.GetStatusesMaxDrafts() }
+
+// SetStatusesMaxDrafts safely sets the value for global configuration 'StatusesMaxDrafts' field
+func SetStatusesMaxDrafts(v int) { global.SetStatusesMaxDrafts(v) }
+
+// GetStatusesMaxThreadDepth safely fetches the Configuration value for state's 'StatusesMaxThreadDepth' field
+func (st *ConfigState) GetStatusesMaxThreadDepth() (v int) {
+	st.mutex.Lock()
+	v = st.config.StatusesMaxThreadDepth
+	st.mutex.Unlock()
+	return
+}
+
+// SetStatusesMaxThreadDepth safely sets the Configuration value for state's 'StatusesMaxThreadDepth' field
+func (st *ConfigState) SetStatusesMaxThreadDepth(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesMaxThreadDepth = v
+	st.reloadToViper()
+}
+
+// StatusesMaxThreadDepthFlag returns the flag name for the 'StatusesMaxThreadDepth' field
+func StatusesMaxThreadDepthFlag() string { return "statuses-max-thread-depth" }
+
+// GetStatusesMaxThreadDepth safely fetches the value for global configuration 'StatusesMaxThreadDepth' field
+func GetStatusesMaxThreadDepth() int { return global.GetStatusesMaxThreadDepth() }
+
+// SetStatusesMaxThreadDepth safely sets the value for global configuration 'StatusesMaxThreadDepth' field
+func SetStatusesMaxThreadDepth(v int) { global.SetStatusesMaxThreadDepth(v) }
+
+// GetStatusesThreadDepthPolicy safely fetches the Configuration value for state's 'StatusesThreadDepthPolicy' field
+func (st *ConfigState) GetStatusesThreadDepthPolicy() (v string) {
+	st.mutex.Lock()
+	v = st.config.StatusesThreadDepthPolicy
+	st.mutex.Unlock()
+	return
+}
+
+// SetStatusesThreadDepthPolicy safely sets the Configuration value for state's 'StatusesThreadDepthPolicy' field
+func (st *ConfigState) SetStatusesThreadDepthPolicy(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesThreadDepthPolicy = v
+	st.reloadToViper()
+}
+
+// StatusesThreadDepthPolicyFlag returns the flag name for the 'StatusesThreadDepthPolicy' field
+func StatusesThreadDepthPolicyFlag() string { return "statuses-thread-depth-policy" }
+
+// GetStatusesThreadDepthPolicy safely fetches the value for global configuration 'StatusesThreadDepthPolicy' field
+func GetStatusesThreadDepthPolicy() string { return global.GetStatusesThreadDepthPolicy() }
+
+// SetStatusesThreadDepthPolicy safely sets the value for global configuration 'StatusesThreadDepthPolicy' field
+func SetStatusesThreadDepthPolicy(v string) { global.SetStatusesThreadDepthPolicy(v) }
+
+// GetStatusShowDislikes safely fetches the Configuration value for state's 'StatusShowDislikes' field
+func (st *ConfigState) GetStatusShowDislikes() (v bool) {
+	st.mutex.Lock()
+	v = st.config.StatusShowDislikes
+	st.mutex.Unlock()
+	return
+}
+
+// SetStatusShowDislikes safely sets the Configuration value for state's 'StatusShowDislikes' field
+func (st *ConfigState) SetStatusShowDislikes(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusShowDislikes = v
+	st.reloadToViper()
+}
+
+// StatusShowDislikesFlag returns the flag name for the 'StatusShowDislikes' field
+func StatusShowDislikesFlag() string { return "status-show-dislikes" }
+
+// GetStatusShowDislikes safely fetches the value for global configuration 'StatusShowDislikes' field
+func GetStatusShowDislikes() bool { return global.GetStatusShowDislikes() }
+
+// SetStatusShowDislikes safely sets the value for global configuration 'StatusShowDislikes' field
+func SetStatusShowDislikes(v bool) { global.SetStatusShowDislikes(v) }
+
+// GetStreamingPingInterval safely fetches the Configuration value for state's 'StreamingPingInterval' field
+func (st *ConfigState) GetStreamingPingInterval() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.StreamingPingInterval
+	st.mutex.Unlock()
+	return
+}
+
+// SetStreamingPingInterval safely sets the Configuration value for state's 'StreamingPingInterval' field
+func (st *ConfigState) SetStreamingPingInterval(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StreamingPingInterval = v
+	st.reloadToViper()
+}
+
+// StreamingPingIntervalFlag returns the flag name for the 'StreamingPingInterval' field
+func StreamingPingIntervalFlag() string { return "streaming-ping-interval" }
+
+// GetStreamingPingInterval safely fetches the value for global configuration 'StreamingPingInterval' field
+func GetStreamingPingInterval() time.Duration { return global.GetStreamingPingInterval() }
+
+// SetStreamingPingInterval safely sets the value for global configuration 'StreamingPingInterval' field
+func SetStreamingPingInterval(v time.Duration) { global.SetStreamingPingInterval(v) }
+
+// GetStreamingPingTimeout safely fetches the Configuration value for state's 'StreamingPingTimeout' field
+func (st *ConfigState) GetStreamingPingTimeout() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.StreamingPingTimeout
+	st.mutex.Unlock()
+	return
+}
+
+// SetStreamingPingTimeout safely sets the Configuration value for state's 'StreamingPingTimeout' field
+func (st *ConfigState) SetStreamingPingTimeout(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StreamingPingTimeout = v
+	st.reloadToViper()
+}
+
+// StreamingPingTimeoutFlag returns the flag name for the 'StreamingPingTimeout' field
+func StreamingPingTimeoutFlag() string { return "streaming-ping-timeout" }
+
+// GetStreamingPingTimeout safely fetches the value for global configuration 'StreamingPingTimeout' field
+func GetStreamingPingTimeout() time.Duration { return global.GetStreamingPingTimeout() }
+
+// SetStreamingPingTimeout safely sets the value for global configuration 'StreamingPingTimeout' field
+func SetStreamingPingTimeout(v time.Duration) { global.SetStreamingPingTimeout(v) }
+
 // GetLetsEncryptEnabled safely fetches the Configuration value for state's 'LetsEncryptEnabled' field
 func (st *ConfigState) GetLetsEncryptEnabled() (v bool) {
 	st.mutex.Lock()
@@ -1849,6 +2732,131 @@ func GetOIDCAdminGroups() []string { return global.GetOIDCAdminGroups() }
 // SetOIDCAdminGroups safely sets the value for global configuration 'OIDCAdminGroups' field
 func SetOIDCAdminGroups(v []string) { global.SetOIDCAdminGroups(v) }
 
+// GetOIDCModeratorGroups safely fetches the Configuration value for state's 'OIDCModeratorGroups' field
+func (st *ConfigState) GetOIDCModeratorGroups() (v []string) {
+	st.mutex.Lock()
+	v = st.config.OIDCModeratorGroups
+	st.mutex.Unlock()
+	return
+}
+
+// SetOIDCModeratorGroups safely sets the Configuration value for state's 'OIDCModeratorGroups' field
+func (st *ConfigState) SetOIDCModeratorGroups(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.OIDCModeratorGroups = v
+	st.reloadToViper()
+}
+
+// OIDCModeratorGroupsFlag returns the flag name for the 'OIDCModeratorGroups' field
+func OIDCModeratorGroupsFlag() string { return "oidc-moderator-groups" }
+
+// GetOIDCModeratorGroups safely fetches the value for global configuration 'OIDCModeratorGroups' field
+func GetOIDCModeratorGroups() []string { return global.GetOIDCModeratorGroups() }
+
+// SetOIDCModeratorGroups safely sets the value for global configuration 'OIDCModeratorGroups' field
+func SetOIDCModeratorGroups(v []string) { global.SetOIDCModeratorGroups(v) }
+
+// GetOIDCSignupGroups safely fetches the Configuration value for state's 'OIDCSignupGroups' field
+func (st *ConfigState) GetOIDCSignupGroups() (v []string) {
+	st.mutex.Lock()
+	v = st.config.OIDCSignupGroups
+	st.mutex.Unlock()
+	return
+}
+
+// SetOIDCSignupGroups safely sets the Configuration value for state's 'OIDCSignupGroups' field
+func (st *ConfigState) SetOIDCSignupGroups(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.OIDCSignupGroups = v
+	st.reloadToViper()
+}
+
+// OIDCSignupGroupsFlag returns the flag name for the 'OIDCSignupGroups' field
+func OIDCSignupGroupsFlag() string { return "oidc-signup-groups" }
+
+// GetOIDCSignupGroups safely fetches the value for global configuration 'OIDCSignupGroups' field
+func GetOIDCSignupGroups() []string { return global.GetOIDCSignupGroups() }
+
+// SetOIDCSignupGroups safely sets the value for global configuration 'OIDCSignupGroups' field
+func SetOIDCSignupGroups(v []string) { global.SetOIDCSignupGroups(v) }
+
+// GetOIDCGroupsClaim safely fetches the Configuration value for state's 'OIDCGroupsClaim' field
+func (st *ConfigState) GetOIDCGroupsClaim() (v string) {
+	st.mutex.Lock()
+	v = st.config.OIDCGroupsClaim
+	st.mutex.Unlock()
+	return
+}
+
+// SetOIDCGroupsClaim safely sets the Configuration value for state's 'OIDCGroupsClaim' field
+func (st *ConfigState) SetOIDCGroupsClaim(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.OIDCGroupsClaim = v
+	st.reloadToViper()
+}
+
+// OIDCGroupsClaimFlag returns the flag name for the 'OIDCGroupsClaim' field
+func OIDCGroupsClaimFlag() string { return "oidc-groups-claim" }
+
+// GetOIDCGroupsClaim safely fetches the value for global configuration 'OIDCGroupsClaim' field
+func GetOIDCGroupsClaim() string { return global.GetOIDCGroupsClaim() }
+
+// SetOIDCGroupsClaim safely sets the value for global configuration 'OIDCGroupsClaim' field
+func SetOIDCGroupsClaim(v string) { global.SetOIDCGroupsClaim(v) }
+
+// GetOIDCUsernameClaim safely fetches the Configuration value for state's 'OIDCUsernameClaim' field
+func (st *ConfigState) GetOIDCUsernameClaim() (v string) {
+	st.mutex.Lock()
+	v = st.config.OIDCUsernameClaim
+	st.mutex.Unlock()
+	return
+}
+
+// SetOIDCUsernameClaim safely sets the Configuration value for state's 'OIDCUsernameClaim' field
+func (st *ConfigState) SetOIDCUsernameClaim(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.OIDCUsernameClaim = v
+	st.reloadToViper()
+}
+
+// OIDCUsernameClaimFlag returns the flag name for the 'OIDCUsernameClaim' field
+func OIDCUsernameClaimFlag() string { return "oidc-username-claim" }
+
+// GetOIDCUsernameClaim safely fetches the value for global configuration 'OIDCUsernameClaim' field
+func GetOIDCUsernameClaim() string { return global.GetOIDCUsernameClaim() }
+
+// SetOIDCUsernameClaim safely sets the value for global configuration 'OIDCUsernameClaim' field
+func SetOIDCUsernameClaim(v string) { global.SetOIDCUsernameClaim(v) }
+
+// GetOIDCUsernameMode safely fetches the Configuration value for state's 'OIDCUsernameMode' field
+func (st *ConfigState) GetOIDCUsernameMode() (v string) {
+	st.mutex.Lock()
+	v = st.config.OIDCUsernameMode
+	st.mutex.Unlock()
+	return
+}
+
+// SetOIDCUsernameMode safely sets the Configuration value for state's 'OIDCUsernameMode' field
+func (st *ConfigState) SetOIDCUsernameMode(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.OIDCUsernameMode = v
+	st.reloadToViper()
+}
+
+// OIDCUsernameModeFlag returns the flag name for the 'OIDCUsernameMode' field
+func OIDCUsernameModeFlag() string { return "oidc-username-mode" }
+
+// GetOIDCUsernameMode safely fetches the value for global configuration 'OIDCUsernameMode' field
+func GetOIDCUsernameMode() string { return global.GetOIDCUsernameMode() }
+
+// SetOIDCUsernameMode safely sets the value for global configuration 'OIDCUsernameMode' field
+func SetOIDCUsernameMode(v string) { global.SetOIDCUsernameMode(v) }
+
 // GetTracingEnabled safely fetches the Configuration value for state's 'TracingEnabled' field
 func (st *ConfigState) GetTracingEnabled() (v bool) {
 	st.mutex.Lock()
@@ -2099,80 +3107,230 @@ func GetSMTPDiscloseRecipients() bool { return global.GetSMTPDiscloseRecipients(
 // SetSMTPDiscloseRecipients safely sets the value for global configuration 'SMTPDiscloseRecipients' field
 func SetSMTPDiscloseRecipients(v bool) { global.SetSMTPDiscloseRecipients(v) }
 
+// GetSMTPTemplateOverrideDir safely fetches the Configuration value for state's 'SMTPTemplateOverrideDir' field
+func (st *ConfigState) GetSMTPTemplateOverrideDir() (v string) {
+	st.mutex.Lock()
+	v = st.config.SMTPTemplateOverrideDir
+	st.mutex.Unlock()
+	return
+}
+
+// SetSMTPTemplateOverrideDir safely sets the Configuration value for state's 'SMTPTemplateOverrideDir' field
+func (st *ConfigState) SetSMTPTemplateOverrideDir(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.SMTPTemplateOverrideDir = v
+	st.reloadToViper()
+}
+
+// SMTPTemplateOverrideDirFlag returns the flag name for the 'SMTPTemplateOverrideDir' field
+func SMTPTemplateOverrideDirFlag() string { return "smtp-template-override-dir" }
+
+// GetSMTPTemplateOverrideDir safely fetches the value for global configuration 'SMTPTemplateOverrideDir' field
+func GetSMTPTemplateOverrideDir() string { return global.GetSMTPTemplateOverrideDir() }
+
+// SetSMTPTemplateOverrideDir safely sets the value for global configuration 'SMTPTemplateOverrideDir' field
+func SetSMTPTemplateOverrideDir(v string) { global.SetSMTPTemplateOverrideDir(v) }
+
 // GetSyslogEnabled safely fetches the Configuration value for state's 'SyslogEnabled' field
 func (st *ConfigState) GetSyslogEnabled() (v bool) {
 	st.mutex.Lock()
-	v = st.config.SyslogEnabled
+	v = st.config.SyslogEnabled
+	st.mutex.Unlock()
+	return
+}
+
+// SetSyslogEnabled safely sets the Configuration value for state's 'SyslogEnabled' field
+func (st *ConfigState) SetSyslogEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.SyslogEnabled = v
+	st.reloadToViper()
+}
+
+// SyslogEnabledFlag returns the flag name for the 'SyslogEnabled' field
+func SyslogEnabledFlag() string { return "syslog-enabled" }
+
+// GetSyslogEnabled safely fetches the value for global configuration 'SyslogEnabled' field
+func GetSyslogEnabled() bool { return global.GetSyslogEnabled() }
+
+// SetSyslogEnabled safely sets the value for global configuration 'SyslogEnabled' field
+func SetSyslogEnabled(v bool) { global.SetSyslogEnabled(v) }
+
+// GetSyslogProtocol safely fetches the Configuration value for state's 'SyslogProtocol' field
+func (st *ConfigState) GetSyslogProtocol() (v string) {
+	st.mutex.Lock()
+	v = st.config.SyslogProtocol
+	st.mutex.Unlock()
+	return
+}
+
+// SetSyslogProtocol safely sets the Configuration value for state's 'SyslogProtocol' field
+func (st *ConfigState) SetSyslogProtocol(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.SyslogProtocol = v
+	st.reloadToViper()
+}
+
+// SyslogProtocolFlag returns the flag name for the 'SyslogProtocol' field
+func SyslogProtocolFlag() string { return "syslog-protocol" }
+
+// GetSyslogProtocol safely fetches the value for global configuration 'SyslogProtocol' field
+func GetSyslogProtocol() string { return global.GetSyslogProtocol() }
+
+// SetSyslogProtocol safely sets the value for global configuration 'SyslogProtocol' field
+func SetSyslogProtocol(v string) { global.SetSyslogProtocol(v) }
+
+// GetSyslogAddress safely fetches the Configuration value for state's 'SyslogAddress' field
+func (st *ConfigState) GetSyslogAddress() (v string) {
+	st.mutex.Lock()
+	v = st.config.SyslogAddress
+	st.mutex.Unlock()
+	return
+}
+
+// SetSyslogAddress safely sets the Configuration value for state's 'SyslogAddress' field
+func (st *ConfigState) SetSyslogAddress(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.SyslogAddress = v
+	st.reloadToViper()
+}
+
+// SyslogAddressFlag returns the flag name for the 'SyslogAddress' field
+func SyslogAddressFlag() string { return "syslog-address" }
+
+// GetSyslogAddress safely fetches the value for global configuration 'SyslogAddress' field
+func GetSyslogAddress() string { return global.GetSyslogAddress() }
+
+// SetSyslogAddress safely sets the value for global configuration 'SyslogAddress' field
+func SetSyslogAddress(v string) { global.SetSyslogAddress(v) }
+
+// GetSessionBackend safely fetches the Configuration value for state's 'SessionBackend' field
+func (st *ConfigState) GetSessionBackend() (v string) {
+	st.mutex.Lock()
+	v = st.config.SessionBackend
+	st.mutex.Unlock()
+	return
+}
+
+// SetSessionBackend safely sets the Configuration value for state's 'SessionBackend' field
+func (st *ConfigState) SetSessionBackend(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.SessionBackend = v
+	st.reloadToViper()
+}
+
+// SessionBackendFlag returns the flag name for the 'SessionBackend' field
+func SessionBackendFlag() string { return "session-backend" }
+
+// GetSessionBackend safely fetches the value for global configuration 'SessionBackend' field
+func GetSessionBackend() string { return global.GetSessionBackend() }
+
+// SetSessionBackend safely sets the value for global configuration 'SessionBackend' field
+func SetSessionBackend(v string) { global.SetSessionBackend(v) }
+
+// GetSessionRedisAddress safely fetches the Configuration value for state's 'SessionRedisAddress' field
+func (st *ConfigState) GetSessionRedisAddress() (v string) {
+	st.mutex.Lock()
+	v = st.config.SessionRedisAddress
+	st.mutex.Unlock()
+	return
+}
+
+// SetSessionRedisAddress safely sets the Configuration value for state's 'SessionRedisAddress' field
+func (st *ConfigState) SetSessionRedisAddress(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.SessionRedisAddress = v
+	st.reloadToViper()
+}
+
+// SessionRedisAddressFlag returns the flag name for the 'SessionRedisAddress' field
+func SessionRedisAddressFlag() string { return "session-redis-address" }
+
+// GetSessionRedisAddress safely fetches the value for global configuration 'SessionRedisAddress' field
+func GetSessionRedisAddress() string { return global.GetSessionRedisAddress() }
+
+// SetSessionRedisAddress safely sets the value for global configuration 'SessionRedisAddress' field
+func SetSessionRedisAddress(v string) { global.SetSessionRedisAddress(v) }
+
+// GetSessionRedisPassword safely fetches the Configuration value for state's 'SessionRedisPassword' field
+func (st *ConfigState) GetSessionRedisPassword() (v string) {
+	st.mutex.Lock()
+	v = st.config.SessionRedisPassword
 	st.mutex.Unlock()
 	return
 }
 
-// SetSyslogEnabled safely sets the Configuration value for state's 'SyslogEnabled' field
-func (st *ConfigState) SetSyslogEnabled(v bool) {
+// SetSessionRedisPassword safely sets the Configuration value for state's 'SessionRedisPassword' field
+func (st *ConfigState) SetSessionRedisPassword(v string) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.SyslogEnabled = v
+	st.config.SessionRedisPassword = v
 	st.reloadToViper()
 }
 
-// SyslogEnabledFlag returns the flag name for the 'SyslogEnabled' field
-func SyslogEnabledFlag() string { return "syslog-enabled" }
+// SessionRedisPasswordFlag returns the flag name for the 'SessionRedisPassword' field
+func SessionRedisPasswordFlag() string { return "session-redis-password" }
 
-// GetSyslogEnabled safely fetches the value for global configuration 'SyslogEnabled' field
-func GetSyslogEnabled() bool { return global.GetSyslogEnabled() }
+// GetSessionRedisPassword safely fetches the value for global configuration 'SessionRedisPassword' field
+func GetSessionRedisPassword() string { return global.GetSessionRedisPassword() }
 
-// SetSyslogEnabled safely sets the value for global configuration 'SyslogEnabled' field
-func SetSyslogEnabled(v bool) { global.SetSyslogEnabled(v) }
+// SetSessionRedisPassword safely sets the value for global configuration 'SessionRedisPassword' field
+func SetSessionRedisPassword(v string) { global.SetSessionRedisPassword(v) }
 
-// GetSyslogProtocol safely fetches the Configuration value for state's 'SyslogProtocol' field
-func (st *ConfigState) GetSyslogProtocol() (v string) {
+// GetSessionRedisDB safely fetches the Configuration value for state's 'SessionRedisDB' field
+func (st *ConfigState) GetSessionRedisDB() (v int) {
 	st.mutex.Lock()
-	v = st.config.SyslogProtocol
+	v = st.config.SessionRedisDB
 	st.mutex.Unlock()
 	return
 }
 
-// SetSyslogProtocol safely sets the Configuration value for state's 'SyslogProtocol' field
-func (st *ConfigState) SetSyslogProtocol(v string) {
+// SetSessionRedisDB safely sets the Configuration value for state's 'SessionRedisDB' field
+func (st *ConfigState) SetSessionRedisDB(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.SyslogProtocol = v
+	st.config.SessionRedisDB = v
 	st.reloadToViper()
 }
 
-// SyslogProtocolFlag returns the flag name for the 'SyslogProtocol' field
-func SyslogProtocolFlag() string { return "syslog-protocol" }
+// SessionRedisDBFlag returns the flag name for the 'SessionRedisDB' field
+func SessionRedisDBFlag() string { return "session-redis-db" }
 
-// GetSyslogProtocol safely fetches the value for global configuration 'SyslogProtocol' field
-func GetSyslogProtocol() string { return global.GetSyslogProtocol() }
+// GetSessionRedisDB safely fetches the value for global configuration 'SessionRedisDB' field
+func GetSessionRedisDB() int { return global.GetSessionRedisDB() }
 
-// SetSyslogProtocol safely sets the value for global configuration 'SyslogProtocol' field
-func SetSyslogProtocol(v string) { global.SetSyslogProtocol(v) }
+// SetSessionRedisDB safely sets the value for global configuration 'SessionRedisDB' field
+func SetSessionRedisDB(v int) { global.SetSessionRedisDB(v) }
 
-// GetSyslogAddress safely fetches the Configuration value for state's 'SyslogAddress' field
-func (st *ConfigState) GetSyslogAddress() (v string) {
+// GetSessionEncryptionKey safely fetches the Configuration value for state's 'SessionEncryptionKey' field
+func (st *ConfigState) GetSessionEncryptionKey() (v string) {
 	st.mutex.Lock()
-	v = st.config.SyslogAddress
+	v = st.config.SessionEncryptionKey
 	st.mutex.Unlock()
 	return
 }
 
-// SetSyslogAddress safely sets the Configuration value for state's 'SyslogAddress' field
-func (st *ConfigState) SetSyslogAddress(v string) {
+// SetSessionEncryptionKey safely sets the Configuration value for state's 'SessionEncryptionKey' field
+func (st *ConfigState) SetSessionEncryptionKey(v string) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.SyslogAddress = v
+	st.config.SessionEncryptionKey = v
 	st.reloadToViper()
 }
 
-// SyslogAddressFlag returns the flag name for the 'SyslogAddress' field
-func SyslogAddressFlag() string { return "syslog-address" }
+// SessionEncryptionKeyFlag returns the flag name for the 'SessionEncryptionKey' field
+func SessionEncryptionKeyFlag() string { return "session-encryption-key" }
 
-// GetSyslogAddress safely fetches the value for global configuration 'SyslogAddress' field
-func GetSyslogAddress() string { return global.GetSyslogAddress() }
+// GetSessionEncryptionKey safely fetches the value for global configuration 'SessionEncryptionKey' field
+func GetSessionEncryptionKey() string { return global.GetSessionEncryptionKey() }
 
-// SetSyslogAddress safely sets the value for global configuration 'SyslogAddress' field
-func SetSyslogAddress(v string) { global.SetSyslogAddress(v) }
+// SetSessionEncryptionKey safely sets the value for global configuration 'SessionEncryptionKey' field
+func SetSessionEncryptionKey(v string) { global.SetSessionEncryptionKey(v) }
 
 // GetAdvancedCookiesSamesite safely fetches the Configuration value for state's 'AdvancedCookiesSamesite' field
 func (st *ConfigState) GetAdvancedCookiesSamesite() (v string) {
@@ -2224,6 +3382,56 @@ func GetAdvancedRateLimitRequests() int { return global.GetAdvancedRateLimitRequ
 // SetAdvancedRateLimitRequests safely sets the value for global configuration 'AdvancedRateLimitRequests' field
 func SetAdvancedRateLimitRequests(v int) { global.SetAdvancedRateLimitRequests(v) }
 
+// GetAdvancedInboxRateLimitRequests safely fetches the Configuration value for state's 'AdvancedInboxRateLimitRequests' field
+func (st *ConfigState) GetAdvancedInboxRateLimitRequests() (v int) {
+	st.mutex.Lock()
+	v = st.config.AdvancedInboxRateLimitRequests
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdvancedInboxRateLimitRequests safely sets the Configuration value for state's 'AdvancedInboxRateLimitRequests' field
+func (st *ConfigState) SetAdvancedInboxRateLimitRequests(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedInboxRateLimitRequests = v
+	st.reloadToViper()
+}
+
+// AdvancedInboxRateLimitRequestsFlag returns the flag name for the 'AdvancedInboxRateLimitRequests' field
+func AdvancedInboxRateLimitRequestsFlag() string { return "advanced-inbox-rate-limit-requests" }
+
+// GetAdvancedInboxRateLimitRequests safely fetches the value for global configuration 'AdvancedInboxRateLimitRequests' field
+func GetAdvancedInboxRateLimitRequests() int { return global.GetAdvancedInboxRateLimitRequests() }
+
+// SetAdvancedInboxRateLimitRequests safely sets the value for global configuration 'AdvancedInboxRateLimitRequests' field
+func SetAdvancedInboxRateLimitRequests(v int) { global.SetAdvancedInboxRateLimitRequests(v) }
+
+// GetAdvancedInboxMaxBodySize safely fetches the Configuration value for state's 'AdvancedInboxMaxBodySize' field
+func (st *ConfigState) GetAdvancedInboxMaxBodySize() (v bytesize.Size) {
+	st.mutex.Lock()
+	v = st.config.AdvancedInboxMaxBodySize
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdvancedInboxMaxBodySize safely sets the Configuration value for state's 'AdvancedInboxMaxBodySize' field
+func (st *ConfigState) SetAdvancedInboxMaxBodySize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedInboxMaxBodySize = v
+	st.reloadToViper()
+}
+
+// AdvancedInboxMaxBodySizeFlag returns the flag name for the 'AdvancedInboxMaxBodySize' field
+func AdvancedInboxMaxBodySizeFlag() string { return "advanced-inbox-max-body-size" }
+
+// GetAdvancedInboxMaxBodySize safely fetches the value for global configuration 'AdvancedInboxMaxBodySize' field
+func GetAdvancedInboxMaxBodySize() bytesize.Size { return global.GetAdvancedInboxMaxBodySize() }
+
+// SetAdvancedInboxMaxBodySize safely sets the value for global configuration 'AdvancedInboxMaxBodySize' field
+func SetAdvancedInboxMaxBodySize(v bytesize.Size) { global.SetAdvancedInboxMaxBodySize(v) }
+
 // GetAdvancedThrottlingMultiplier safely fetches the Configuration value for state's 'AdvancedThrottlingMultiplier' field
 func (st *ConfigState) GetAdvancedThrottlingMultiplier() (v int) {
 	st.mutex.Lock()
@@ -2299,6 +3507,81 @@ func GetAdvancedSenderMultiplier() int { return global.GetAdvancedSenderMultipli
 // SetAdvancedSenderMultiplier safely sets the value for global configuration 'AdvancedSenderMultiplier' field
 func SetAdvancedSenderMultiplier(v int) { global.SetAdvancedSenderMultiplier(v) }
 
+// GetMaintenanceMode safely fetches the Configuration value for state's 'MaintenanceMode' field
+func (st *ConfigState) GetMaintenanceMode() (v bool) {
+	st.mutex.Lock()
+	v = st.config.MaintenanceMode
+	st.mutex.Unlock()
+	return
+}
+
+// SetMaintenanceMode safely sets the Configuration value for state's 'MaintenanceMode' field
+func (st *ConfigState) SetMaintenanceMode(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MaintenanceMode = v
+	st.reloadToViper()
+}
+
+// MaintenanceModeFlag returns the flag name for the 'MaintenanceMode' field
+func MaintenanceModeFlag() string { return "maintenance-mode" }
+
+// GetMaintenanceMode safely fetches the value for global configuration 'MaintenanceMode' field
+func GetMaintenanceMode() bool { return global.GetMaintenanceMode() }
+
+// SetMaintenanceMode safely sets the value for global configuration 'MaintenanceMode' field
+func SetMaintenanceMode(v bool) { global.SetMaintenanceMode(v) }
+
+// GetMaintenanceMessage safely fetches the Configuration value for state's 'MaintenanceMessage' field
+func (st *ConfigState) GetMaintenanceMessage() (v string) {
+	st.mutex.Lock()
+	v = st.config.MaintenanceMessage
+	st.mutex.Unlock()
+	return
+}
+
+// SetMaintenanceMessage safely sets the Configuration value for state's 'MaintenanceMessage' field
+func (st *ConfigState) SetMaintenanceMessage(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MaintenanceMessage = v
+	st.reloadToViper()
+}
+
+// MaintenanceMessageFlag returns the flag name for the 'MaintenanceMessage' field
+func MaintenanceMessageFlag() string { return "maintenance-message" }
+
+// GetMaintenanceMessage safely fetches the value for global configuration 'MaintenanceMessage' field
+func GetMaintenanceMessage() string { return global.GetMaintenanceMessage() }
+
+// SetMaintenanceMessage safely sets the value for global configuration 'MaintenanceMessage' field
+func SetMaintenanceMessage(v string) { global.SetMaintenanceMessage(v) }
+
+// GetShutdownGracePeriod safely fetches the Configuration value for state's 'ShutdownGracePeriod' field
+func (st *ConfigState) GetShutdownGracePeriod() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.ShutdownGracePeriod
+	st.mutex.Unlock()
+	return
+}
+
+// SetShutdownGracePeriod safely sets the Configuration value for state's 'ShutdownGracePeriod' field
+func (st *ConfigState) SetShutdownGracePeriod(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.ShutdownGracePeriod = v
+	st.reloadToViper()
+}
+
+// ShutdownGracePeriodFlag returns the flag name for the 'ShutdownGracePeriod' field
+func ShutdownGracePeriodFlag() string { return "shutdown-grace-period" }
+
+// GetShutdownGracePeriod safely fetches the value for global configuration 'ShutdownGracePeriod' field
+func GetShutdownGracePeriod() time.Duration { return global.GetShutdownGracePeriod() }
+
+// SetShutdownGracePeriod safely sets the value for global configuration 'ShutdownGracePeriod' field
+func SetShutdownGracePeriod(v time.Duration) { global.SetShutdownGracePeriod(v) }
+
 // GetCacheGTSAccountMaxSize safely fetches the Configuration value for state's 'Cache.GTS.AccountMaxSize' field
 func (st *ConfigState) GetCacheGTSAccountMaxSize() (v int) {
 	st.mutex.Lock()
@@ -3805,6 +5088,156 @@ func GetAdminAccountPassword() string { return global.GetAdminAccountPassword()
 // SetAdminAccountPassword safely sets the value for global configuration 'AdminAccountPassword' field
 func SetAdminAccountPassword(v string) { global.SetAdminAccountPassword(v) }
 
+// GetAdminAccountListOrigin safely fetches the Configuration value for state's 'AdminAccountListOrigin' field
+func (st *ConfigState) GetAdminAccountListOrigin() (v string) {
+	st.mutex.Lock()
+	v = st.config.AdminAccountListOrigin
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdminAccountListOrigin safely sets the Configuration value for state's 'AdminAccountListOrigin' field
+func (st *ConfigState) SetAdminAccountListOrigin(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminAccountListOrigin = v
+	st.reloadToViper()
+}
+
+// AdminAccountListOriginFlag returns the flag name for the 'AdminAccountListOrigin' field
+func AdminAccountListOriginFlag() string { return "origin" }
+
+// GetAdminAccountListOrigin safely fetches the value for global configuration 'AdminAccountListOrigin' field
+func GetAdminAccountListOrigin() string { return global.GetAdminAccountListOrigin() }
+
+// SetAdminAccountListOrigin safely sets the value for global configuration 'AdminAccountListOrigin' field
+func SetAdminAccountListOrigin(v string) { global.SetAdminAccountListOrigin(v) }
+
+// GetAdminAccountListDomain safely fetches the Configuration value for state's 'AdminAccountListDomain' field
+func (st *ConfigState) GetAdminAccountListDomain() (v string) {
+	st.mutex.Lock()
+	v = st.config.AdminAccountListDomain
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdminAccountListDomain safely sets the Configuration value for state's 'AdminAccountListDomain' field
+func (st *ConfigState) SetAdminAccountListDomain(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminAccountListDomain = v
+	st.reloadToViper()
+}
+
+// AdminAccountListDomainFlag returns the flag name for the 'AdminAccountListDomain' field
+func AdminAccountListDomainFlag() string { return "domain" }
+
+// GetAdminAccountListDomain safely fetches the value for global configuration 'AdminAccountListDomain' field
+func GetAdminAccountListDomain() string { return global.GetAdminAccountListDomain() }
+
+// SetAdminAccountListDomain safely sets the value for global configuration 'AdminAccountListDomain' field
+func SetAdminAccountListDomain(v string) { global.SetAdminAccountListDomain(v) }
+
+// GetAdminAccountListSuspended safely fetches the Configuration value for state's 'AdminAccountListSuspended' field
+func (st *ConfigState) GetAdminAccountListSuspended() (v bool) {
+	st.mutex.Lock()
+	v = st.config.AdminAccountListSuspended
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdminAccountListSuspended safely sets the Configuration value for state's 'AdminAccountListSuspended' field
+func (st *ConfigState) SetAdminAccountListSuspended(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminAccountListSuspended = v
+	st.reloadToViper()
+}
+
+// AdminAccountListSuspendedFlag returns the flag name for the 'AdminAccountListSuspended' field
+func AdminAccountListSuspendedFlag() string { return "suspended" }
+
+// GetAdminAccountListSuspended safely fetches the value for global configuration 'AdminAccountListSuspended' field
+func GetAdminAccountListSuspended() bool { return global.GetAdminAccountListSuspended() }
+
+// SetAdminAccountListSuspended safely sets the value for global configuration 'AdminAccountListSuspended' field
+func SetAdminAccountListSuspended(v bool) { global.SetAdminAccountListSuspended(v) }
+
+// GetAdminAccountListAdmin safely fetches the Configuration value for state's 'AdminAccountListAdmin' field
+func (st *ConfigState) GetAdminAccountListAdmin() (v bool) {
+	st.mutex.Lock()
+	v = st.config.AdminAccountListAdmin
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdminAccountListAdmin safely sets the Configuration value for state's 'AdminAccountListAdmin' field
+func (st *ConfigState) SetAdminAccountListAdmin(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminAccountListAdmin = v
+	st.reloadToViper()
+}
+
+// AdminAccountListAdminFlag returns the flag name for the 'AdminAccountListAdmin' field
+func AdminAccountListAdminFlag() string { return "admin" }
+
+// GetAdminAccountListAdmin safely fetches the value for global configuration 'AdminAccountListAdmin' field
+func GetAdminAccountListAdmin() bool { return global.GetAdminAccountListAdmin() }
+
+// SetAdminAccountListAdmin safely sets the value for global configuration 'AdminAccountListAdmin' field
+func SetAdminAccountListAdmin(v bool) { global.SetAdminAccountListAdmin(v) }
+
+// GetAdminAccountUseAPI safely fetches the Configuration value for state's 'AdminAccountUseAPI' field
+func (st *ConfigState) GetAdminAccountUseAPI() (v bool) {
+	st.mutex.Lock()
+	v = st.config.AdminAccountUseAPI
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdminAccountUseAPI safely sets the Configuration value for state's 'AdminAccountUseAPI' field
+func (st *ConfigState) SetAdminAccountUseAPI(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminAccountUseAPI = v
+	st.reloadToViper()
+}
+
+// AdminAccountUseAPIFlag returns the flag name for the 'AdminAccountUseAPI' field
+func AdminAccountUseAPIFlag() string { return "use-api" }
+
+// GetAdminAccountUseAPI safely fetches the value for global configuration 'AdminAccountUseAPI' field
+func GetAdminAccountUseAPI() bool { return global.GetAdminAccountUseAPI() }
+
+// SetAdminAccountUseAPI safely sets the value for global configuration 'AdminAccountUseAPI' field
+func SetAdminAccountUseAPI(v bool) { global.SetAdminAccountUseAPI(v) }
+
+// GetAdminAccountAPIToken safely fetches the Configuration value for state's 'AdminAccountAPIToken' field
+func (st *ConfigState) GetAdminAccountAPIToken() (v string) {
+	st.mutex.Lock()
+	v = st.config.AdminAccountAPIToken
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdminAccountAPIToken safely sets the Configuration value for state's 'AdminAccountAPIToken' field
+func (st *ConfigState) SetAdminAccountAPIToken(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminAccountAPIToken = v
+	st.reloadToViper()
+}
+
+// AdminAccountAPITokenFlag returns the flag name for the 'AdminAccountAPIToken' field
+func AdminAccountAPITokenFlag() string { return "token" }
+
+// GetAdminAccountAPIToken safely fetches the value for global configuration 'AdminAccountAPIToken' field
+func GetAdminAccountAPIToken() string { return global.GetAdminAccountAPIToken() }
+
+// SetAdminAccountAPIToken safely sets the value for global configuration 'AdminAccountAPIToken' field
+func SetAdminAccountAPIToken(v string) { global.SetAdminAccountAPIToken(v) }
+
 // GetAdminTransPath safely fetches the Configuration value for state's 'AdminTransPath' field
 func (st *ConfigState) GetAdminTransPath() (v string) {
 	st.mutex.Lock()
@@ -3830,30 +5263,180 @@ func GetAdminTransPath() string { return global.GetAdminTransPath() }
 // SetAdminTransPath safely sets the value for global configuration 'AdminTransPath' field
 func SetAdminTransPath(v string) { global.SetAdminTransPath(v) }
 
-// GetAdminMediaPruneDryRun safely fetches the Configuration value for state's 'AdminMediaPruneDryRun' field
-func (st *ConfigState) GetAdminMediaPruneDryRun() (v bool) {
+// GetAdminBreachedPasswordsInput safely fetches the Configuration value for state's 'AdminBreachedPasswordsInput' field
+func (st *ConfigState) GetAdminBreachedPasswordsInput() (v string) {
+	st.mutex.Lock()
+	v = st.config.AdminBreachedPasswordsInput
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdminBreachedPasswordsInput safely sets the Configuration value for state's 'AdminBreachedPasswordsInput' field
+func (st *ConfigState) SetAdminBreachedPasswordsInput(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminBreachedPasswordsInput = v
+	st.reloadToViper()
+}
+
+// AdminBreachedPasswordsInputFlag returns the flag name for the 'AdminBreachedPasswordsInput' field
+func AdminBreachedPasswordsInputFlag() string { return "input" }
+
+// GetAdminBreachedPasswordsInput safely fetches the value for global configuration 'AdminBreachedPasswordsInput' field
+func GetAdminBreachedPasswordsInput() string { return global.GetAdminBreachedPasswordsInput() }
+
+// SetAdminBreachedPasswordsInput safely sets the value for global configuration 'AdminBreachedPasswordsInput' field
+func SetAdminBreachedPasswordsInput(v string) { global.SetAdminBreachedPasswordsInput(v) }
+
+// GetAdminDryRun safely fetches the Configuration value for state's 'AdminDryRun' field
+func (st *ConfigState) GetAdminDryRun() (v bool) {
+	st.mutex.Lock()
+	v = st.config.AdminDryRun
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdminDryRun safely sets the Configuration value for state's 'AdminDryRun' field
+func (st *ConfigState) SetAdminDryRun(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminDryRun = v
+	st.reloadToViper()
+}
+
+// AdminDryRunFlag returns the flag name for the 'AdminDryRun' field
+func AdminDryRunFlag() string { return "dry-run" }
+
+// GetAdminDryRun safely fetches the value for global configuration 'AdminDryRun' field
+func GetAdminDryRun() bool { return global.GetAdminDryRun() }
+
+// SetAdminDryRun safely sets the value for global configuration 'AdminDryRun' field
+func SetAdminDryRun(v bool) { global.SetAdminDryRun(v) }
+
+// GetAdminFederationCheckTarget safely fetches the Configuration value for state's 'AdminFederationCheckTarget' field
+func (st *ConfigState) GetAdminFederationCheckTarget() (v string) {
+	st.mutex.Lock()
+	v = st.config.AdminFederationCheckTarget
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdminFederationCheckTarget safely sets the Configuration value for state's 'AdminFederationCheckTarget' field
+func (st *ConfigState) SetAdminFederationCheckTarget(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminFederationCheckTarget = v
+	st.reloadToViper()
+}
+
+// AdminFederationCheckTargetFlag returns the flag name for the 'AdminFederationCheckTarget' field
+func AdminFederationCheckTargetFlag() string { return "target" }
+
+// GetAdminFederationCheckTarget safely fetches the value for global configuration 'AdminFederationCheckTarget' field
+func GetAdminFederationCheckTarget() string { return global.GetAdminFederationCheckTarget() }
+
+// SetAdminFederationCheckTarget safely sets the value for global configuration 'AdminFederationCheckTarget' field
+func SetAdminFederationCheckTarget(v string) { global.SetAdminFederationCheckTarget(v) }
+
+// GetAdminMediaUsageGroupBy safely fetches the Configuration value for state's 'AdminMediaUsageGroupBy' field
+func (st *ConfigState) GetAdminMediaUsageGroupBy() (v string) {
+	st.mutex.Lock()
+	v = st.config.AdminMediaUsageGroupBy
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdminMediaUsageGroupBy safely sets the Configuration value for state's 'AdminMediaUsageGroupBy' field
+func (st *ConfigState) SetAdminMediaUsageGroupBy(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminMediaUsageGroupBy = v
+	st.reloadToViper()
+}
+
+// AdminMediaUsageGroupByFlag returns the flag name for the 'AdminMediaUsageGroupBy' field
+func AdminMediaUsageGroupByFlag() string { return "group-by" }
+
+// GetAdminMediaUsageGroupBy safely fetches the value for global configuration 'AdminMediaUsageGroupBy' field
+func GetAdminMediaUsageGroupBy() string { return global.GetAdminMediaUsageGroupBy() }
+
+// SetAdminMediaUsageGroupBy safely sets the value for global configuration 'AdminMediaUsageGroupBy' field
+func SetAdminMediaUsageGroupBy(v string) { global.SetAdminMediaUsageGroupBy(v) }
+
+// GetAdminMediaUsageTop safely fetches the Configuration value for state's 'AdminMediaUsageTop' field
+func (st *ConfigState) GetAdminMediaUsageTop() (v int) {
+	st.mutex.Lock()
+	v = st.config.AdminMediaUsageTop
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdminMediaUsageTop safely sets the Configuration value for state's 'AdminMediaUsageTop' field
+func (st *ConfigState) SetAdminMediaUsageTop(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminMediaUsageTop = v
+	st.reloadToViper()
+}
+
+// AdminMediaUsageTopFlag returns the flag name for the 'AdminMediaUsageTop' field
+func AdminMediaUsageTopFlag() string { return "top" }
+
+// GetAdminMediaUsageTop safely fetches the value for global configuration 'AdminMediaUsageTop' field
+func GetAdminMediaUsageTop() int { return global.GetAdminMediaUsageTop() }
+
+// SetAdminMediaUsageTop safely sets the value for global configuration 'AdminMediaUsageTop' field
+func SetAdminMediaUsageTop(v int) { global.SetAdminMediaUsageTop(v) }
+
+// GetAdminMediaUsageFormat safely fetches the Configuration value for state's 'AdminMediaUsageFormat' field
+func (st *ConfigState) GetAdminMediaUsageFormat() (v string) {
+	st.mutex.Lock()
+	v = st.config.AdminMediaUsageFormat
+	st.mutex.Unlock()
+	return
+}
+
+// SetAdminMediaUsageFormat safely sets the Configuration value for state's 'AdminMediaUsageFormat' field
+func (st *ConfigState) SetAdminMediaUsageFormat(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminMediaUsageFormat = v
+	st.reloadToViper()
+}
+
+// AdminMediaUsageFormatFlag returns the flag name for the 'AdminMediaUsageFormat' field
+func AdminMediaUsageFormatFlag() string { return "format" }
+
+// GetAdminMediaUsageFormat safely fetches the value for global configuration 'AdminMediaUsageFormat' field
+func GetAdminMediaUsageFormat() string { return global.GetAdminMediaUsageFormat() }
+
+// SetAdminMediaUsageFormat safely sets the value for global configuration 'AdminMediaUsageFormat' field
+func SetAdminMediaUsageFormat(v string) { global.SetAdminMediaUsageFormat(v) }
+
+// GetAdminMediaPruneOlderThan safely fetches the Configuration value for state's 'AdminMediaPruneOlderThan' field
+func (st *ConfigState) GetAdminMediaPruneOlderThan() (v time.Duration) {
 	st.mutex.Lock()
-	v = st.config.AdminMediaPruneDryRun
+	v = st.config.AdminMediaPruneOlderThan
 	st.mutex.Unlock()
 	return
 }
 
-// SetAdminMediaPruneDryRun safely sets the Configuration value for state's 'AdminMediaPruneDryRun' field
-func (st *ConfigState) SetAdminMediaPruneDryRun(v bool) {
+// SetAdminMediaPruneOlderThan safely sets the Configuration value for state's 'AdminMediaPruneOlderThan' field
+func (st *ConfigState) SetAdminMediaPruneOlderThan(v time.Duration) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.AdminMediaPruneDryRun = v
+	st.config.AdminMediaPruneOlderThan = v
 	st.reloadToViper()
 }
 
-// AdminMediaPruneDryRunFlag returns the flag name for the 'AdminMediaPruneDryRun' field
-func AdminMediaPruneDryRunFlag() string { return "dry-run" }
+// AdminMediaPruneOlderThanFlag returns the flag name for the 'AdminMediaPruneOlderThan' field
+func AdminMediaPruneOlderThanFlag() string { return "older-than" }
 
-// GetAdminMediaPruneDryRun safely fetches the value for global configuration 'AdminMediaPruneDryRun' field
-func GetAdminMediaPruneDryRun() bool { return global.GetAdminMediaPruneDryRun() }
+// GetAdminMediaPruneOlderThan safely fetches the value for global configuration 'AdminMediaPruneOlderThan' field
+func GetAdminMediaPruneOlderThan() time.Duration { return global.GetAdminMediaPruneOlderThan() }
 
-// SetAdminMediaPruneDryRun safely sets the value for global configuration 'AdminMediaPruneDryRun' field
-func SetAdminMediaPruneDryRun(v bool) { global.SetAdminMediaPruneDryRun(v) }
+// SetAdminMediaPruneOlderThan safely sets the value for global configuration 'AdminMediaPruneOlderThan' field
+func SetAdminMediaPruneOlderThan(v time.Duration) { global.SetAdminMediaPruneOlderThan(v) }
 
 // GetRequestIDHeader safely fetches the Configuration value for state's 'RequestIDHeader' field
 func (st *ConfigState) GetRequestIDHeader() (v string) {
@@ -0,0 +1,163 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// hotReloadable contains the configuration values that are safe to change
+// while running, ie., they're always accessed through the config getters
+// rather than being read once on startup, mapped by key to their handling.
+var hotReloadable = map[string]struct {
+	// validate checks the value in cfg, before
+	// any of the reloaded values are applied.
+	validate func(cfg *Configuration) error
+
+	// apply copies the value from src to dst,
+	// and applies it elsewhere if need be.
+	apply func(dst, src *Configuration)
+}{
+	LogLevelFlag(): {
+		validate: func(cfg *Configuration) error {
+			if !log.ValidLevel(cfg.LogLevel) {
+				return fmt.Errorf("unknown log level: %q", cfg.LogLevel)
+			}
+			return nil
+		},
+		apply: func(dst, src *Configuration) {
+			dst.LogLevel = src.LogLevel
+			_ = log.ParseLevel(dst.LogLevel)
+		},
+	},
+	AdvancedRateLimitRequestsFlag(): {
+		apply: func(dst, src *Configuration) {
+			dst.AdvancedRateLimitRequests = src.AdvancedRateLimitRequests
+		},
+	},
+	MediaRemoteCacheDaysFlag(): {
+		apply: func(dst, src *Configuration) {
+			dst.MediaRemoteCacheDays = src.MediaRemoteCacheDays
+		},
+	},
+	MaintenanceModeFlag(): {
+		apply: func(dst, src *Configuration) {
+			dst.MaintenanceMode = src.MaintenanceMode
+		},
+	},
+	MaintenanceMessageFlag(): {
+		apply: func(dst, src *Configuration) {
+			dst.MaintenanceMessage = src.MaintenanceMessage
+		},
+	},
+}
+
+// HotReload will re-read configuration values from file and env, in the same order
+// of precedence as on startup, and apply any changes to values that are safe to change
+// while running. The keys of changed values are returned as 'changed'. Any other values
+// that have changed (eg., bind address, database settings) are NOT applied, instead
+// their keys are returned as 'rejected', as these require a restart to take effect.
+//
+// If any of the reloaded values are invalid, nothing is applied and an error is returned.
+func (st *ConfigState) HotReload() (changed []string, rejected []string, err error) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	current, err := st.config.MarshalMap()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start from the current values, so that any
+	// set programmatically (or removed from file)
+	// are kept, then layer the usual sources over.
+	v := viper.New()
+	loadEnv(v)
+
+	if err := v.MergeConfigMap(current); err != nil {
+		return nil, nil, err
+	}
+
+	if st.config.ConfigPath != "" {
+		v.SetConfigFile(st.config.ConfigPath)
+		if err := v.MergeInConfig(); err != nil {
+			return nil, nil, fmt.Errorf("error reading config file: %w", err)
+		}
+	}
+
+	if st.cmd != nil {
+		// Flags passed on the command line still
+		// take precedence over file and env vars.
+		if err := v.BindPFlags(st.cmd.Flags()); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var next Configuration
+	if err := unmarshalViper(v, &next); err != nil {
+		return nil, nil, fmt.Errorf("error parsing config: %w", err)
+	}
+
+	reloaded, err := next.MarshalMap()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for key, value := range reloaded {
+		if reflect.DeepEqual(value, current[key]) {
+			continue
+		}
+
+		if _, ok := hotReloadable[key]; ok {
+			changed = append(changed, key)
+		} else {
+			rejected = append(rejected, key)
+		}
+	}
+
+	sort.Strings(changed)
+	sort.Strings(rejected)
+
+	var errs []error
+	for _, key := range changed {
+		if validate := hotReloadable[key].validate; validate != nil {
+			if err := validate(&next); err != nil {
+				errs = append(errs, fmt.Errorf("invalid %s: %w", key, err))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+
+	for _, key := range changed {
+		hotReloadable[key].apply(&st.config, &next)
+	}
+
+	// Ensure viper is up-to-date.
+	st.reloadToViper()
+
+	return changed, rejected, nil
+}
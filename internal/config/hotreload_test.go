@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+func hotReloadState(t *testing.T, content string, cli ...string) (*config.ConfigState, string) {
+	os.Clearenv()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	state := config.NewState()
+	cmd := cobra.Command{}
+	state.AddGlobalFlags(&cmd)
+	state.AddServerFlags(&cmd)
+	assert.NoError(t, cmd.ParseFlags(append([]string{"--config-path", path}, cli...)))
+	assert.NoError(t, state.BindFlags(&cmd))
+	assert.NoError(t, state.Reload())
+
+	return state, path
+}
+
+func TestHotReload(t *testing.T) {
+	state, path := hotReloadState(t, "log-level: info\nbind-address: 0.0.0.0\nadvanced-rate-limit-requests: 300\n")
+
+	assert.NoError(t, os.WriteFile(path, []byte("log-level: info\nbind-address: 127.0.0.1\nadvanced-rate-limit-requests: 100\nmedia-remote-cache-days: 7\n"), 0o600))
+
+	changed, rejected, err := state.HotReload()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"advanced-rate-limit-requests", "media-remote-cache-days"}, changed)
+	assert.Equal(t, []string{"bind-address"}, rejected)
+
+	assert.Equal(t, 100, state.GetAdvancedRateLimitRequests())
+	assert.Equal(t, 7, state.GetMediaRemoteCacheDays())
+	assert.Equal(t, "0.0.0.0", state.GetBindAddress())
+
+	// Nothing changed since.
+	changed, rejected, err = state.HotReload()
+	assert.NoError(t, err)
+	assert.Empty(t, changed)
+	assert.Equal(t, []string{"bind-address"}, rejected)
+}
+
+func TestHotReloadFlagPrecedence(t *testing.T) {
+	state, path := hotReloadState(t, "advanced-rate-limit-requests: 300\n", "--advanced-rate-limit-requests", "50")
+
+	assert.NoError(t, os.WriteFile(path, []byte("advanced-rate-limit-requests: 100\n"), 0o600))
+
+	changed, rejected, err := state.HotReload()
+	assert.NoError(t, err)
+	assert.Empty(t, changed)
+	assert.Empty(t, rejected)
+	assert.Equal(t, 50, state.GetAdvancedRateLimitRequests())
+}
+
+func TestHotReloadInvalid(t *testing.T) {
+	state, path := hotReloadState(t, "log-level: info\nadvanced-rate-limit-requests: 300\n")
+
+	assert.NoError(t, os.WriteFile(path, []byte("log-level: loud\nadvanced-rate-limit-requests: 100\n"), 0o600))
+
+	_, _, err := state.HotReload()
+	assert.EqualError(t, err, `invalid log-level: unknown log level: "loud"`)
+
+	// Nothing should have been applied.
+	assert.Equal(t, "info", state.GetLogLevel())
+	assert.Equal(t, 300, state.GetAdvancedRateLimitRequests())
+}
@@ -31,6 +31,7 @@ import (
 // environment, CLI and configuration file variables.
 type ConfigState struct { //nolint
 	viper  *viper.Viper
+	cmd    *cobra.Command
 	config Configuration
 	mutex  sync.Mutex
 }
@@ -39,12 +40,8 @@ type ConfigState struct { //nolint
 func NewState() *ConfigState {
 	viper := viper.New()
 
-	// Flag 'some-flag-name' becomes env var 'GTS_SOME_FLAG_NAME'
-	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
-	viper.SetEnvPrefix("gts")
-
 	// Load appropriate named vals from env
-	viper.AutomaticEnv()
+	loadEnv(viper)
 
 	// Create new ConfigState with defaults
 	state := &ConfigState{
@@ -95,6 +92,8 @@ func (st *ConfigState) LoadEarlyFlags(cmd *cobra.Command) (err error) {
 // BindFlags will bind given Cobra command's pflags to this ConfigState's viper instance.
 func (st *ConfigState) BindFlags(cmd *cobra.Command) (err error) {
 	st.Viper(func(v *viper.Viper) {
+		// Keep hold of cmd for HotReload().
+		st.cmd = cmd
 		err = v.BindPFlags(cmd.Flags())
 	})
 	return
@@ -129,7 +128,22 @@ func (st *ConfigState) reloadToViper() {
 
 // reloadFromViper will reload Configuration{} values from viper.
 func (st *ConfigState) reloadFromViper() {
-	if err := st.viper.Unmarshal(&st.config, func(c *mapstructure.DecoderConfig) {
+	if err := unmarshalViper(st.viper, &st.config); err != nil {
+		panic(err)
+	}
+}
+
+// loadEnv will set given viper instance to load config values from env.
+func loadEnv(v *viper.Viper) {
+	// Flag 'some-flag-name' becomes env var 'GTS_SOME_FLAG_NAME'
+	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	v.SetEnvPrefix("gts")
+	v.AutomaticEnv()
+}
+
+// unmarshalViper will unmarshal given viper instance's values into cfg.
+func unmarshalViper(v *viper.Viper, cfg *Configuration) error {
+	return v.Unmarshal(cfg, func(c *mapstructure.DecoderConfig) {
 		c.TagName = "name"
 
 		// empty config before marshaling
@@ -137,12 +151,12 @@ func (st *ConfigState) reloadFromViper() {
 
 		oldhook := c.DecodeHook
 
-		// Use the TextUnmarshaler interface when decoding.
+		// Use the TextUnmarshaler interface when decoding,
+		// and accept days in durations before viper's hooks.
 		c.DecodeHook = mapstructure.ComposeDecodeHookFunc(
 			mapstructure.TextUnmarshallerHookFunc(),
+			durationDecodeHook(),
 			oldhook,
 		)
-	}); err != nil {
-		panic(err)
-	}
+	})
 }
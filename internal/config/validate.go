@@ -18,8 +18,10 @@
 package config
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/miekg/dns"
@@ -66,6 +68,82 @@ func Validate() error {
 		errs = append(errs, fmt.Errorf("%s must be set", WebAssetBaseDirFlag()))
 	}
 
+	switch policy := GetStatusesThreadDepthPolicy(); policy {
+	case "detach", "reject":
+		// no problem
+		break
+	default:
+		errs = append(errs, fmt.Errorf("%s must be set to either detach or reject, provided value was %s", StatusesThreadDepthPolicyFlag(), policy))
+	}
+
+	switch mode := GetOIDCUsernameMode(); mode {
+	case "ask", "suffix", "deny":
+		// no problem
+		break
+	default:
+		errs = append(errs, fmt.Errorf("%s must be set to one of ask, suffix or deny, provided value was %s", OIDCUsernameModeFlag(), mode))
+	}
+
+	if GetStreamingPingInterval() <= 0 {
+		errs = append(errs, fmt.Errorf("%s must be greater than 0", StreamingPingIntervalFlag()))
+	}
+
+	if GetStreamingPingTimeout() <= 0 {
+		errs = append(errs, fmt.Errorf("%s must be greater than 0", StreamingPingTimeoutFlag()))
+	}
+
+	mediaAllowedTypes := GetMediaAllowedTypes()
+	mediaBlockedTypes := GetMediaBlockedTypes()
+
+	if len(mediaAllowedTypes) > 0 && len(mediaBlockedTypes) > 0 {
+		errs = append(errs, fmt.Errorf("%s and %s cannot both be set", MediaAllowedTypesFlag(), MediaBlockedTypesFlag()))
+	}
+
+	for _, mimeType := range append(mediaAllowedTypes, mediaBlockedTypes...) {
+		if t, sub, ok := strings.Cut(mimeType, "/"); !ok || t == "" || sub == "" {
+			errs = append(errs, fmt.Errorf("%s is not a valid MIME type; entries in %s and %s should look like image/png or image/*", mimeType, MediaAllowedTypesFlag(), MediaBlockedTypesFlag()))
+		}
+	}
+
+	for role, limits := range GetMediaRoles() {
+		if limits.MaxFiles < 0 {
+			errs = append(errs, fmt.Errorf("%s.%s.max-files must be 0 or greater, provided value was %d", MediaRolesFlag(), role, limits.MaxFiles))
+		}
+	}
+
+	for name, provider := range GetMediaOEmbedProviders() {
+		if provider.Endpoint == "" {
+			// Provider disabled.
+			continue
+		}
+
+		if u, err := url.Parse(provider.Endpoint); err != nil || u.Scheme != "https" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("%s.%s.endpoint must be an absolute https url, provided value was %q", MediaOEmbedProvidersFlag(), name, provider.Endpoint))
+		}
+
+		if len(provider.Domains) == 0 {
+			errs = append(errs, fmt.Errorf("%s.%s.domains must not be empty", MediaOEmbedProvidersFlag(), name))
+		}
+	}
+
+	switch backend := GetSessionBackend(); backend {
+	case "memory":
+		// no problem
+		break
+	case "redis":
+		if GetSessionRedisAddress() == "" {
+			errs = append(errs, fmt.Errorf("%s must be set when %s is redis", SessionRedisAddressFlag(), SessionBackendFlag()))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("%s must be set to either memory or redis, provided value was %s", SessionBackendFlag(), backend))
+	}
+
+	if key := GetSessionEncryptionKey(); key != "" {
+		if b, err := hex.DecodeString(key); err != nil || len(b) != 32 {
+			errs = append(errs, fmt.Errorf("%s must be 64 hex characters (256 bits), if set", SessionEncryptionKeyFlag()))
+		}
+	}
+
 	tlsChain := GetTLSCertificateChain()
 	tlsKey := GetTLSCertificateKey()
 	tlsChainFlag := TLSCertificateChainFlag()
@@ -140,6 +140,101 @@ func (suite *ConfigValidateTestSuite) TestValidateConfigBadProtocolNoHost() {
 	suite.EqualError(err, "host must be set; protocol must be set to either http or https, provided value was foo")
 }
 
+func (suite *ConfigValidateTestSuite) TestValidateConfigMediaAllowedAndBlockedTypes() {
+	testrig.InitTestConfig()
+
+	config.SetMediaAllowedTypes([]string{"image/*"})
+	config.SetMediaBlockedTypes([]string{"video/mp4"})
+
+	err := config.Validate()
+	suite.EqualError(err, "media-allowed-types and media-blocked-types cannot both be set")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateConfigMediaAllowedTypesBadType() {
+	testrig.InitTestConfig()
+
+	config.SetMediaAllowedTypes([]string{"image/png", "png"})
+
+	err := config.Validate()
+	suite.EqualError(err, "png is not a valid MIME type; entries in media-allowed-types and media-blocked-types should look like image/png or image/*")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateConfigMediaRolesBadMaxFiles() {
+	testrig.InitTestConfig()
+
+	config.SetMediaRoles(map[string]config.MediaRoleConfiguration{
+		"photographer": {MaxFiles: -1},
+	})
+
+	err := config.Validate()
+	suite.EqualError(err, "media-roles.photographer.max-files must be 0 or greater, provided value was -1")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateConfigMediaOEmbedProvidersBadEndpoint() {
+	testrig.InitTestConfig()
+
+	config.SetMediaOEmbedProviders(map[string]config.OEmbedProviderConfiguration{
+		"videos": {
+			Domains:  []string{"videos.example.org"},
+			Endpoint: "http://videos.example.org/oembed",
+		},
+	})
+
+	err := config.Validate()
+	suite.EqualError(err, `media-oembed-providers.videos.endpoint must be an absolute https url, provided value was "http://videos.example.org/oembed"`)
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateConfigBadOIDCUsernameMode() {
+	testrig.InitTestConfig()
+
+	config.SetOIDCUsernameMode("random")
+
+	err := config.Validate()
+	suite.EqualError(err, "oidc-username-mode must be set to one of ask, suffix or deny, provided value was random")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateConfigBadSessionBackend() {
+	testrig.InitTestConfig()
+
+	config.SetSessionBackend("memcached")
+
+	err := config.Validate()
+	suite.EqualError(err, "session-backend must be set to either memory or redis, provided value was memcached")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateConfigRedisSessionBackendNoAddress() {
+	testrig.InitTestConfig()
+
+	config.SetSessionBackend("redis")
+	config.SetSessionRedisAddress("")
+
+	err := config.Validate()
+	suite.EqualError(err, "session-redis-address must be set when session-backend is redis")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateConfigSessionEncryptionKey() {
+	testrig.InitTestConfig()
+
+	config.SetSessionEncryptionKey("3f4d1c0b7a9e8d2c5b6a7f8e9d0c1b2a3f4d1c0b7a9e8d2c5b6a7f8e9d0c1b2a")
+
+	err := config.Validate()
+	suite.NoError(err)
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateConfigBadSessionEncryptionKey() {
+	testrig.InitTestConfig()
+
+	for _, key := range []string{
+		"not hex at all",
+		"3f4d1c0b7a9e8d2c",
+	} {
+		config.SetSessionEncryptionKey(key)
+
+		err := config.Validate()
+		suite.EqualError(err, "session-encryption-key must be 64 hex characters (256 bits), if set")
+	}
+}
+
 func TestConfigValidateTestSuite(t *testing.T) {
 	suite.Run(t, &ConfigValidateTestSuite{})
 }
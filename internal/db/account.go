@@ -53,6 +53,15 @@ type Account interface {
 	// GetAccountByFollowersURI returns one account with the given followers_uri, or an error if something goes wrong.
 	GetAccountByFollowersURI(ctx context.Context, uri string) (*gtsmodel.Account, Error)
 
+	// GetAccounts returns up to limit accounts with maxID > ID > sinceID, in order of ID descending.
+	// If minID is set, the limit accounts immediately newer than minID will be returned, still in
+	// order of ID descending, so that pages can be walked in both directions by account ID.
+	//
+	// If local is set, only local (true) or remote (false) accounts will be returned. If domain
+	// is set, only accounts from that domain will be returned. If suspended is set, only accounts
+	// that are (true) or aren't (false) suspended will be returned.
+	GetAccounts(ctx context.Context, local *bool, domain string, suspended *bool, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Account, Error)
+
 	// PopulateAccount ensures that all sub-models of an account are populated (e.g. avatar, header etc).
 	PopulateAccount(ctx context.Context, account *gtsmodel.Account) error
 
@@ -79,12 +88,41 @@ type Account interface {
 	// CountAccountPinned returns the total number of pinned statuses owned by account with the given id.
 	CountAccountPinned(ctx context.Context, accountID string) (int, Error)
 
+	// CountAccountStatusesBetween counts statuses created by the given account within the
+	// given time range. A zero since or until time leaves that end of the range open.
+	CountAccountStatusesBetween(ctx context.Context, accountID string, since time.Time, until time.Time) (int, Error)
+
+	// CountAccountFavesReceivedBetween counts faves by other accounts of statuses
+	// owned by the given account, created within the given time range. A zero
+	// since or until time leaves that end of the range open.
+	CountAccountFavesReceivedBetween(ctx context.Context, accountID string, since time.Time, until time.Time) (int, Error)
+
+	// CountAccountBoostsReceivedBetween counts boosts by other accounts of statuses
+	// owned by the given account, created within the given time range. A zero
+	// since or until time leaves that end of the range open.
+	CountAccountBoostsReceivedBetween(ctx context.Context, accountID string, since time.Time, until time.Time) (int, Error)
+
+	// CountAccountFollowersBetween counts current followers of the given account who
+	// started following it within the given time range. A zero since or until time
+	// leaves that end of the range open.
+	CountAccountFollowersBetween(ctx context.Context, accountID string, since time.Time, until time.Time) (int, Error)
+
+	// GetAccountTopTags returns the limit n tags most used in statuses created by
+	// the given account, keyed by tag name, in order of use count descending.
+	GetAccountTopTags(ctx context.Context, accountID string, limit int) ([]*TagUsage, Error)
+
+	// GetAccountFamiliarTags returns up to limit n tags used since the given time both in
+	// public or unlisted statuses of targetAccountID, and in statuses of accountID, ordered
+	// by how often they were used by the two accounts combined. If accountID is empty, tags
+	// are instead compared with public statuses of any other account.
+	GetAccountFamiliarTags(ctx context.Context, accountID string, targetAccountID string, since time.Time, limit int) ([]*gtsmodel.Tag, Error)
+
 	// GetAccountStatuses is a shortcut for getting the most recent statuses. accountID is optional, if not provided
 	// then all statuses will be returned. If limit is set to 0, the size of the returned slice will not be limited. This can
 	// be very memory intensive so you probably shouldn't do this!
 	//
 	// In the case of no statuses, this function will return db.ErrNoEntries.
-	GetAccountStatuses(ctx context.Context, accountID string, limit int, excludeReplies bool, excludeReblogs bool, maxID string, minID string, mediaOnly bool, publicOnly bool) ([]*gtsmodel.Status, Error)
+	GetAccountStatuses(ctx context.Context, accountID string, limit int, excludeReplies bool, excludeReblogs bool, maxID string, minID string, mediaOnly bool, pollsOnly bool, publicOnly bool, boostsOnly bool) ([]*gtsmodel.Status, Error)
 
 	// GetAccountPinnedStatuses returns ONLY statuses owned by the give accountID for which a corresponding StatusPin
 	// exists in the database. Statuses which are not pinned will not be returned by this function.
@@ -117,3 +155,11 @@ type Account interface {
 	// If domain is empty, this instance account will be returned.
 	GetInstanceAccount(ctx context.Context, domain string) (*gtsmodel.Account, Error)
 }
+
+// TagUsage is the number of
+// statuses using the tag with
+// the given name, Key.
+type TagUsage struct {
+	Key   string
+	Count int
+}
@@ -0,0 +1,37 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Backlog handles getting/creation/deletion of deferred federator messages.
+type Backlog interface {
+	// GetBacklogMessages gets all backlog messages, in the order they were put.
+	GetBacklogMessages(ctx context.Context) ([]*gtsmodel.BacklogMessage, Error)
+	// CountBacklogMessages returns the number of messages in the backlog.
+	CountBacklogMessages(ctx context.Context) (int, Error)
+	// PutBacklogMessages puts the given messages in the database.
+	PutBacklogMessages(ctx context.Context, msgs []*gtsmodel.BacklogMessage) Error
+	// DeleteBacklogMessagesUpTo deletes all backlog messages with
+	// an id of less than or equal to the given id.
+	DeleteBacklogMessagesUpTo(ctx context.Context, id string) Error
+}
@@ -202,6 +202,102 @@ func (a *accountDB) GetInstanceAccount(ctx context.Context, domain string) (*gts
 	return a.GetAccountByUsernameDomain(ctx, username, domain)
 }
 
+func (a *accountDB) GetAccounts(ctx context.Context, local *bool, domain string, suspended *bool, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Account, db.Error) {
+	var (
+		accountIDs  = []string{}
+		frontToBack = true
+	)
+
+	q := a.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.id")
+
+	if local != nil {
+		i := bun.Ident("account.domain")
+		if *local {
+			q = q.Where("? IS NULL", i)
+		} else {
+			q = q.Where("? IS NOT NULL", i)
+		}
+	}
+
+	if domain != "" {
+		q = q.Where("? = ?", bun.Ident("account.domain"), domain)
+	}
+
+	if suspended != nil {
+		i := bun.Ident("account.suspended_at")
+		if *suspended {
+			q = q.Where("? IS NOT NULL", i)
+		} else {
+			q = q.Where("? IS NULL", i)
+		}
+	}
+
+	if maxID != "" {
+		// return only accounts LOWER (ie., older) than maxID
+		q = q.Where("? < ?", bun.Ident("account.id"), maxID)
+	}
+
+	if sinceID != "" {
+		// return only accounts HIGHER (ie., newer) than sinceID
+		q = q.Where("? > ?", bun.Ident("account.id"), sinceID)
+	}
+
+	if minID != "" {
+		// return only accounts HIGHER (ie., newer) than minID
+		q = q.Where("? > ?", bun.Ident("account.id"), minID)
+
+		// page up
+		frontToBack = false
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if frontToBack {
+		// Page down.
+		q = q.Order("account.id DESC")
+	} else {
+		// Page up.
+		q = q.Order("account.id ASC")
+	}
+
+	if err := q.Scan(ctx, &accountIDs); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	// Catch case of no accounts early
+	if len(accountIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want accounts
+	// to be sorted by ID desc, so reverse ids slice.
+	if !frontToBack {
+		for l, r := 0, len(accountIDs)-1; l < r; l, r = l+1, r-1 {
+			accountIDs[l], accountIDs[r] = accountIDs[r], accountIDs[l]
+		}
+	}
+
+	// Allocate return slice (will be at most len accountIDs)
+	accounts := make([]*gtsmodel.Account, 0, len(accountIDs))
+	for _, id := range accountIDs {
+		account, err := a.GetAccountByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting account %q: %v", id, err)
+			continue
+		}
+
+		// Append to return slice
+		accounts = append(accounts, account)
+	}
+
+	return accounts, nil
+}
+
 func (a *accountDB) getAccount(ctx context.Context, lookup string, dbQuery func(*gtsmodel.Account) error, keyParts ...any) (*gtsmodel.Account, db.Error) {
 	// Fetch account from database cache with loader callback
 	account, err := a.state.Caches.GTS.Account().Load(lookup, func() (*gtsmodel.Account, error) {
@@ -475,7 +571,164 @@ func (a *accountDB) CountAccountPinned(ctx context.Context, accountID string) (i
 		Count(ctx)
 }
 
-func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, limit int, excludeReplies bool, excludeReblogs bool, maxID string, minID string, mediaOnly bool, publicOnly bool) ([]*gtsmodel.Status, db.Error) {
+func (a *accountDB) CountAccountStatusesBetween(ctx context.Context, accountID string, since time.Time, until time.Time) (int, db.Error) {
+	q := a.conn.
+		Read().
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Where("? = ?", bun.Ident("status.account_id"), accountID)
+	q = whereCreatedBetween(q, "status.created_at", since, until)
+
+	count, err := q.Count(ctx)
+	return count, a.conn.ProcessError(err)
+}
+
+func (a *accountDB) CountAccountFavesReceivedBetween(ctx context.Context, accountID string, since time.Time, until time.Time) (int, db.Error) {
+	q := a.conn.
+		Read().
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+		Where("? = ?", bun.Ident("status_fave.target_account_id"), accountID).
+		Where("? != ?", bun.Ident("status_fave.account_id"), accountID)
+	q = whereCreatedBetween(q, "status_fave.created_at", since, until)
+
+	count, err := q.Count(ctx)
+	return count, a.conn.ProcessError(err)
+}
+
+func (a *accountDB) CountAccountBoostsReceivedBetween(ctx context.Context, accountID string, since time.Time, until time.Time) (int, db.Error) {
+	q := a.conn.
+		Read().
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Where("? = ?", bun.Ident("status.boost_of_account_id"), accountID).
+		Where("? != ?", bun.Ident("status.account_id"), accountID)
+	q = whereCreatedBetween(q, "status.created_at", since, until)
+
+	count, err := q.Count(ctx)
+	return count, a.conn.ProcessError(err)
+}
+
+func (a *accountDB) CountAccountFollowersBetween(ctx context.Context, accountID string, since time.Time, until time.Time) (int, db.Error) {
+	q := a.conn.
+		Read().
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
+		Where("? = ?", bun.Ident("follow.target_account_id"), accountID)
+	q = whereCreatedBetween(q, "follow.created_at", since, until)
+
+	count, err := q.Count(ctx)
+	return count, a.conn.ProcessError(err)
+}
+
+func (a *accountDB) GetAccountTopTags(ctx context.Context, accountID string, limit int) ([]*db.TagUsage, db.Error) {
+	usage := []*db.TagUsage{}
+
+	q := a.conn.
+		Read().
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("status_to_tag.status_id"),
+		).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("tags"), bun.Ident("tag"),
+			bun.Ident("tag.id"), bun.Ident("status_to_tag.tag_id"),
+		).
+		ColumnExpr("? AS ?", bun.Ident("tag.name"), bun.Ident("key")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		GroupExpr("?", bun.Ident("tag.name")).
+		OrderExpr("? DESC, ? ASC", bun.Ident("count"), bun.Ident("key"))
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &usage); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	return usage, nil
+}
+
+func (a *accountDB) GetAccountFamiliarTags(ctx context.Context, accountID string, targetAccountID string, since time.Time, limit int) ([]*gtsmodel.Tag, db.Error) {
+	tags := []*gtsmodel.Tag{}
+
+	q := a.conn.
+		Read().
+		NewSelect().
+		Model(&tags).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("status_to_tags"), bun.Ident("status_to_tag"),
+			bun.Ident("status_to_tag.tag_id"), bun.Ident("tag.id"),
+		).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("status_to_tag.status_id"),
+		).
+		Where("? >= ?", bun.Ident("status.created_at"), since).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			// Only take tags from statuses of the
+			// target account that anyone could see.
+			q = q.WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("? = ?", bun.Ident("status.account_id"), targetAccountID).
+					Where("? IN (?)", bun.Ident("status.visibility"), bun.In([]gtsmodel.Visibility{
+						gtsmodel.VisibilityPublic,
+						gtsmodel.VisibilityUnlocked,
+					}))
+			})
+
+			if accountID != "" {
+				// Compare with all statuses of the account.
+				return q.WhereOr("? = ?", bun.Ident("status.account_id"), accountID)
+			}
+
+			// Compare with public statuses of anyone else.
+			return q.WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("? != ?", bun.Ident("status.account_id"), targetAccountID).
+					Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic)
+			})
+		}).
+		GroupExpr("?", bun.Ident("tag.id")).
+		// Tag must be used on both sides.
+		Having("SUM(CASE WHEN ? = ? THEN 1 ELSE 0 END) > 0", bun.Ident("status.account_id"), targetAccountID).
+		Having("SUM(CASE WHEN ? != ? THEN 1 ELSE 0 END) > 0", bun.Ident("status.account_id"), targetAccountID).
+		OrderExpr("COUNT(*) DESC, ? ASC", bun.Ident("tag.name"))
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	return tags, nil
+}
+
+// whereCreatedBetween limits the given query to rows with the given
+// created at column within the given time range, if set.
+func whereCreatedBetween(q *bun.SelectQuery, column string, since time.Time, until time.Time) *bun.SelectQuery {
+	if !since.IsZero() {
+		q = q.Where("? >= ?", bun.Ident(column), since)
+	}
+
+	if !until.IsZero() {
+		q = q.Where("? < ?", bun.Ident(column), until)
+	}
+
+	return q
+}
+
+func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, limit int, excludeReplies bool, excludeReblogs bool, maxID string, minID string, mediaOnly bool, pollsOnly bool, publicOnly bool, boostsOnly bool) ([]*gtsmodel.Status, db.Error) {
 	// Ensure reasonable
 	if limit < 0 {
 		limit = 0
@@ -488,6 +741,7 @@ func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, li
 	)
 
 	q := a.conn.
+		Read().
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
 		// Select only IDs from table
@@ -508,6 +762,10 @@ func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, li
 		q = q.Where("? IS NULL", bun.Ident("status.boost_of_id"))
 	}
 
+	if boostsOnly {
+		q = q.Where("? IS NOT NULL", bun.Ident("status.boost_of_id"))
+	}
+
 	if mediaOnly {
 		// Attachments are stored as a json object; this
 		// implementation differs between SQLite and Postgres,
@@ -532,6 +790,10 @@ func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, li
 		})
 	}
 
+	if pollsOnly {
+		q = q.Where("? IS NOT NULL", bun.Ident("status.poll_id"))
+	}
+
 	if publicOnly {
 		q = q.Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic)
 	}
@@ -583,6 +845,7 @@ func (a *accountDB) GetAccountPinnedStatuses(ctx context.Context, accountID stri
 	statusIDs := []string{}
 
 	q := a.conn.
+		Read().
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
 		Column("status.id").
@@ -607,6 +870,7 @@ func (a *accountDB) GetAccountWebStatuses(ctx context.Context, accountID string,
 	statusIDs := make([]string, 0, limit)
 
 	q := a.conn.
+		Read().
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
 		// Select only IDs from table
@@ -32,6 +32,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 	"github.com/uptrace/bun"
 )
 
@@ -40,57 +42,106 @@ type AccountTestSuite struct {
 }
 
 func (suite *AccountTestSuite) TestGetAccountStatuses() {
-	statuses, err := suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 20, false, false, "", "", false, false)
+	statuses, err := suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 20, false, false, "", "", false, false, false, false)
 	suite.NoError(err)
 	suite.Len(statuses, 5)
 }
 
 func (suite *AccountTestSuite) TestGetAccountStatusesPageDown() {
 	// get the first page
-	statuses, err := suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 2, false, false, "", "", false, false)
+	statuses, err := suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 2, false, false, "", "", false, false, false, false)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
 	suite.Len(statuses, 2)
 
 	// get the second page
-	statuses, err = suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 2, false, false, statuses[len(statuses)-1].ID, "", false, false)
+	statuses, err = suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 2, false, false, statuses[len(statuses)-1].ID, "", false, false, false, false)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
 	suite.Len(statuses, 2)
 
 	// get the third page
-	statuses, err = suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 2, false, false, statuses[len(statuses)-1].ID, "", false, false)
+	statuses, err = suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 2, false, false, statuses[len(statuses)-1].ID, "", false, false, false, false)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
 	suite.Len(statuses, 1)
 
 	// try to get the last page (should be empty)
-	statuses, err = suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 2, false, false, statuses[len(statuses)-1].ID, "", false, false)
+	statuses, err = suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 2, false, false, statuses[len(statuses)-1].ID, "", false, false, false, false)
 	suite.ErrorIs(err, db.ErrNoEntries)
 	suite.Empty(statuses)
 }
 
 func (suite *AccountTestSuite) TestGetAccountStatusesExcludeRepliesAndReblogs() {
-	statuses, err := suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 20, true, true, "", "", false, false)
+	statuses, err := suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 20, true, true, "", "", false, false, false, false)
 	suite.NoError(err)
 	suite.Len(statuses, 5)
 }
 
 func (suite *AccountTestSuite) TestGetAccountStatusesExcludeRepliesAndReblogsPublicOnly() {
-	statuses, err := suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 20, true, true, "", "", false, true)
+	statuses, err := suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 20, true, true, "", "", false, false, true, false)
 	suite.NoError(err)
 	suite.Len(statuses, 1)
 }
 
 func (suite *AccountTestSuite) TestGetAccountStatusesMediaOnly() {
-	statuses, err := suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 20, false, false, "", "", true, false)
+	statuses, err := suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 20, false, false, "", "", true, false, false, false)
 	suite.NoError(err)
 	suite.Len(statuses, 1)
 }
 
+func (suite *AccountTestSuite) TestGetAccountStatusesPollsOnly() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	// Attach a poll to a public status, and to
+	// an unlisted status which replies to admin.
+	status1 := new(gtsmodel.Status)
+	*status1 = *suite.testStatuses["local_account_1_status_1"]
+	status1.PollID = "01H7Y5BQ3K9T6N2W8E4R1XJ0VC"
+	if err := suite.db.UpdateStatus(ctx, status1, "poll_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	status2 := new(gtsmodel.Status)
+	*status2 = *suite.testStatuses["local_account_1_status_2"]
+	status2.PollID = "01H7Y5BQ3K9T6N2W8E4R1XJ0VD"
+	status2.InReplyToID = suite.testStatuses["admin_account_status_1"].ID
+	status2.InReplyToURI = suite.testStatuses["admin_account_status_1"].URI
+	status2.InReplyToAccountID = suite.testAccounts["admin_account"].ID
+	if err := suite.db.UpdateStatus(ctx, status2, "poll_id", "in_reply_to_id", "in_reply_to_uri", "in_reply_to_account_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statuses, err := suite.db.GetAccountStatuses(ctx, account.ID, 20, false, false, "", "", false, true, false, false)
+	suite.NoError(err)
+	suite.Len(statuses, 2)
+
+	// Polls only should combine with the other filters.
+	statuses, err = suite.db.GetAccountStatuses(ctx, account.ID, 20, true, true, "", "", false, true, false, false)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(status1.ID, statuses[0].ID)
+	}
+
+	// Admin has no polls at all.
+	statuses, err = suite.db.GetAccountStatuses(ctx, suite.testAccounts["admin_account"].ID, 20, false, false, "", "", false, true, false, false)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Empty(statuses)
+}
+
+func (suite *AccountTestSuite) TestGetAccountStatusesBoostsOnly() {
+	// Admin has a mix of original statuses and one boost.
+	statuses, err := suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["admin_account"].ID, 20, false, false, "", "", false, false, false, true)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal(suite.testStatuses["admin_account_status_4"].ID, statuses[0].ID)
+	suite.NotEmpty(statuses[0].BoostOfID)
+}
+
 func (suite *AccountTestSuite) TestGetAccountBy() {
 	t := suite.T()
 
@@ -315,6 +366,246 @@ func (suite *AccountTestSuite) TestGetAccountLastPostedWebOnly() {
 	suite.EqualValues(1634726437, lastPosted.Unix())
 }
 
+func (suite *AccountTestSuite) TestAccountStatisticsCounts() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+		since   = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	// 5 statuses, of which only
+	// local_account_1_status_5
+	// is from 2022 onwards.
+	count, err := suite.db.CountAccountStatusesBetween(ctx, account.ID, time.Time{}, time.Time{})
+	suite.NoError(err)
+	suite.Equal(5, count)
+
+	count, err = suite.db.CountAccountStatusesBetween(ctx, account.ID, since, time.Time{})
+	suite.NoError(err)
+	suite.Equal(1, count)
+
+	count, err = suite.db.CountAccountStatusesBetween(ctx, account.ID, time.Time{}, since)
+	suite.NoError(err)
+	suite.Equal(4, count)
+
+	// Faved once by admin_account.
+	count, err = suite.db.CountAccountFavesReceivedBetween(ctx, account.ID, time.Time{}, time.Time{})
+	suite.NoError(err)
+	suite.Equal(1, count)
+
+	// Boosted once by admin_account.
+	count, err = suite.db.CountAccountBoostsReceivedBetween(ctx, account.ID, time.Time{}, time.Time{})
+	suite.NoError(err)
+	suite.Equal(1, count)
+
+	// Followed by admin_account and local_account_2.
+	count, err = suite.db.CountAccountFollowersBetween(ctx, account.ID, time.Time{}, time.Time{})
+	suite.NoError(err)
+	suite.Equal(2, count)
+
+	count, err = suite.db.CountAccountFollowersBetween(ctx, account.ID, time.Now(), time.Time{})
+	suite.NoError(err)
+	suite.Zero(count)
+}
+
+func (suite *AccountTestSuite) TestGetAccountTopTags() {
+	usage, err := suite.db.GetAccountTopTags(context.Background(), suite.testAccounts["admin_account"].ID, 10)
+	suite.NoError(err)
+	suite.Len(usage, 1)
+	suite.Equal("welcome", usage[0].Key)
+	suite.Equal(1, usage[0].Count)
+
+	usage, err = suite.db.GetAccountTopTags(context.Background(), suite.testAccounts["local_account_2"].ID, 10)
+	suite.NoError(err)
+	suite.Empty(usage)
+}
+
+func (suite *AccountTestSuite) TestGetAccountFamiliarTags() {
+	var (
+		ctx    = context.Background()
+		zork   = suite.testAccounts["local_account_1"]
+		turtle = suite.testAccounts["local_account_2"]
+		admin  = suite.testAccounts["admin_account"]
+		now    = time.Now()
+		tags   = make(map[string]*gtsmodel.Tag)
+	)
+
+	putTaggedStatus := func(account *gtsmodel.Account, visibility gtsmodel.Visibility, createdAt time.Time, names ...string) {
+		tagIDs := make([]string, 0, len(names))
+		for _, name := range names {
+			tag, ok := tags[name]
+			if !ok {
+				tag = &gtsmodel.Tag{
+					ID:       id.NewULID(),
+					URL:      "http://localhost:8080/tags/" + name,
+					Name:     name,
+					Useable:  testrig.TrueBool(),
+					Listable: testrig.TrueBool(),
+				}
+				if err := suite.db.Put(ctx, tag); err != nil {
+					suite.FailNow(err.Error())
+				}
+				tags[name] = tag
+			}
+			tagIDs = append(tagIDs, tag.ID)
+		}
+
+		statusID, err := id.NewULIDFromTime(createdAt)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		if err := suite.db.PutStatus(ctx, &gtsmodel.Status{
+			ID:         statusID,
+			URI:        account.URI + "/statuses/" + statusID,
+			CreatedAt:  createdAt,
+			UpdatedAt:  createdAt,
+			AccountID:  account.ID,
+			AccountURI: account.URI,
+			Visibility: visibility,
+			TagIDs:     tagIDs,
+			Local:      testrig.TrueBool(),
+			Federated:  testrig.TrueBool(),
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Target account.
+	putTaggedStatus(zork, gtsmodel.VisibilityPublic, now, "cats", "dogs")
+	putTaggedStatus(zork, gtsmodel.VisibilityUnlocked, now, "cats", "lizards")
+	putTaggedStatus(zork, gtsmodel.VisibilityFollowersOnly, now, "secrets")
+	putTaggedStatus(zork, gtsmodel.VisibilityPublic, now.Add(-100*24*time.Hour), "oldies")
+
+	// Requesting account.
+	putTaggedStatus(turtle, gtsmodel.VisibilityPublic, now, "cats", "secrets", "oldies")
+	putTaggedStatus(turtle, gtsmodel.VisibilityFollowersOnly, now, "dogs", "turtles")
+
+	// Someone else, only in public timeline.
+	putTaggedStatus(admin, gtsmodel.VisibilityPublic, now, "lizards", "lizards2")
+	putTaggedStatus(admin, gtsmodel.VisibilityUnlocked, now, "dogs")
+
+	names := func(tags []*gtsmodel.Tag) []string {
+		names := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			names = append(names, tag.Name)
+		}
+		return names
+	}
+
+	since := now.Add(-90 * 24 * time.Hour)
+
+	// Tags in common between turtle and zork.
+	familiar, err := suite.db.GetAccountFamiliarTags(ctx, turtle.ID, zork.ID, since, 10)
+	suite.NoError(err)
+	suite.Equal([]string{"cats", "dogs"}, names(familiar))
+
+	// Tags in common between zork and the public timeline:
+	// turtle's public cats, and admin's public lizards.
+	familiar, err = suite.db.GetAccountFamiliarTags(ctx, "", zork.ID, since, 10)
+	suite.NoError(err)
+	suite.Equal([]string{"cats", "lizards"}, names(familiar))
+
+	// Limit is respected.
+	familiar, err = suite.db.GetAccountFamiliarTags(ctx, turtle.ID, zork.ID, since, 1)
+	suite.NoError(err)
+	suite.Equal([]string{"cats"}, names(familiar))
+
+	// Nothing in common with an account with no tags.
+	familiar, err = suite.db.GetAccountFamiliarTags(ctx, turtle.ID, suite.testAccounts["unconfirmed_account"].ID, since, 10)
+	suite.NoError(err)
+	suite.Empty(familiar)
+}
+
+func (suite *AccountTestSuite) TestGetAccounts() {
+	ctx := context.Background()
+
+	var (
+		local     = true
+		remote    = false
+		suspended = true
+	)
+
+	for _, test := range []struct {
+		name      string
+		local     *bool
+		domain    string
+		suspended *bool
+		expect    func(*gtsmodel.Account) bool
+	}{
+		{
+			name:   "all",
+			expect: func(*gtsmodel.Account) bool { return true },
+		},
+		{
+			name:   "local",
+			local:  &local,
+			expect: func(a *gtsmodel.Account) bool { return a.Domain == "" },
+		},
+		{
+			name:   "remote",
+			local:  &remote,
+			expect: func(a *gtsmodel.Account) bool { return a.Domain != "" },
+		},
+		{
+			name:   "domain",
+			domain: "example.org",
+			expect: func(a *gtsmodel.Account) bool { return a.Domain == "example.org" },
+		},
+		{
+			name:      "suspended",
+			suspended: &suspended,
+			expect:    func(a *gtsmodel.Account) bool { return !a.SuspendedAt.IsZero() },
+		},
+	} {
+		expect := make(map[string]bool)
+		for _, a := range suite.testAccounts {
+			if test.expect(a) {
+				expect[a.ID] = true
+			}
+		}
+
+		accounts, err := suite.db.GetAccounts(ctx, test.local, test.domain, test.suspended, "", "", "", 0)
+		if len(expect) == 0 {
+			suite.ErrorIs(err, db.ErrNoEntries, test.name)
+			continue
+		}
+		suite.NoError(err, test.name)
+
+		// Accounts created outside of the test fixtures
+		// (e.g. the instance account) may also be returned.
+		got := make(map[string]bool, len(accounts))
+		for i, a := range accounts {
+			suite.True(i == 0 || a.ID < accounts[i-1].ID, test.name)
+			got[a.ID] = true
+		}
+		for id := range expect {
+			suite.True(got[id], "%s: missing account %s", test.name, id)
+		}
+	}
+}
+
+func (suite *AccountTestSuite) TestGetAccountsPaged() {
+	ctx := context.Background()
+
+	first, err := suite.db.GetAccounts(ctx, nil, "", nil, "", "", "", 2)
+	suite.NoError(err)
+	suite.Len(first, 2)
+
+	next, err := suite.db.GetAccounts(ctx, nil, "", nil, first[1].ID, "", "", 2)
+	suite.NoError(err)
+	suite.Len(next, 2)
+	suite.Less(next[0].ID, first[1].ID)
+
+	// Paging back up from the second
+	// page should give the first again.
+	prev, err := suite.db.GetAccounts(ctx, nil, "", nil, "", "", next[0].ID, 2)
+	suite.NoError(err)
+	suite.Len(prev, 2)
+	suite.Equal(first[0].ID, prev[0].ID)
+	suite.Equal(first[1].ID, prev[1].ID)
+}
+
 func (suite *AccountTestSuite) TestInsertAccountWithDefaults() {
 	key, err := rsa.GenerateKey(rand.Reader, 2048)
 	suite.NoError(err)
@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type backlogDB struct {
+	conn *DBConn
+}
+
+func (b *backlogDB) GetBacklogMessages(ctx context.Context) ([]*gtsmodel.BacklogMessage, db.Error) {
+	msgs := []*gtsmodel.BacklogMessage{}
+
+	if err := b.conn.
+		NewSelect().
+		Model(&msgs).
+		Order("backlog_message.id ASC").
+		Scan(ctx); err != nil {
+		return nil, b.conn.ProcessError(err)
+	}
+
+	return msgs, nil
+}
+
+func (b *backlogDB) CountBacklogMessages(ctx context.Context) (int, db.Error) {
+	n, err := b.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("backlog_messages"), bun.Ident("backlog_message")).
+		Count(ctx)
+	return n, b.conn.ProcessError(err)
+}
+
+func (b *backlogDB) PutBacklogMessages(ctx context.Context, msgs []*gtsmodel.BacklogMessage) db.Error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	_, err := b.conn.NewInsert().Model(&msgs).Exec(ctx)
+	return b.conn.ProcessError(err)
+}
+
+func (b *backlogDB) DeleteBacklogMessagesUpTo(ctx context.Context, id string) db.Error {
+	_, err := b.conn.NewDelete().
+		TableExpr("? AS ?", bun.Ident("backlog_messages"), bun.Ident("backlog_message")).
+		Where("? <= ?", bun.Ident("backlog_message.id"), id).
+		Exec(ctx)
+	return b.conn.ProcessError(err)
+}
@@ -61,24 +61,39 @@ var registerTables = []interface{}{
 type DBService struct {
 	db.Account
 	db.Admin
+	db.Backlog
 	db.Basic
 	db.Domain
+	db.Draft
+	db.EmailQueue
 	db.Emoji
+	db.FeaturedTag
+	db.HashtagSetting
 	db.Instance
+	db.Interaction
 	db.List
+	db.Listen
 	db.Media
 	db.Mention
 	db.Notification
+	db.Poll
+	db.PreviewCard
+	db.ProxiedImage
 	db.Relationship
 	db.Report
+	db.Rule
 	db.Search
 	db.Session
 	db.Status
 	db.StatusBookmark
+	db.StatusDislike
 	db.StatusFave
+	db.StatusMute
 	db.Timeline
 	db.User
 	db.Tombstone
+	db.WebAuthn
+	db.WebSession
 	conn *DBConn
 }
 
@@ -132,9 +147,9 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 	}
 
 	// Add database query hooks.
-	conn.DB.AddQueryHook(queryHook{})
+	conn.AddQueryHook(queryHook{})
 	if config.GetTracingEnabled() {
-		conn.DB.AddQueryHook(tracing.InstrumentBun())
+		conn.AddQueryHook(tracing.InstrumentBun())
 	}
 
 	// execute sqlite pragmas *after* adding database hook;
@@ -143,6 +158,20 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 		if err := sqlitePragmas(ctx, conn); err != nil {
 			return nil, err
 		}
+
+		// open the read-only pool *after* setting pragmas,
+		// as it relies on the journal mode already being WAL
+		conn.readDB, err = sqliteReadConn(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if conn.readDB != nil {
+			conn.readDB.AddQueryHook(queryHook{})
+			if config.GetTracingEnabled() {
+				conn.readDB.AddQueryHook(tracing.InstrumentBun())
+			}
+		}
 	}
 
 	// table registration is needed for many-to-many, see:
@@ -167,6 +196,9 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			conn:  conn,
 			state: state,
 		},
+		Backlog: &backlogDB{
+			conn: conn,
+		},
 		Basic: &basicDB{
 			conn: conn,
 		},
@@ -174,17 +206,36 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			conn:  conn,
 			state: state,
 		},
+		Draft: &draftDB{
+			conn: conn,
+		},
+		EmailQueue: &emailQueueDB{
+			conn: conn,
+		},
 		Emoji: &emojiDB{
 			conn:  conn,
 			state: state,
 		},
+		FeaturedTag: &featuredTagDB{
+			conn: conn,
+		},
+		HashtagSetting: &hashtagSettingDB{
+			conn: conn,
+		},
 		Instance: &instanceDB{
 			conn: conn,
 		},
+		Interaction: &interactionDB{
+			conn:  conn,
+			state: state,
+		},
 		List: &listDB{
 			conn:  conn,
 			state: state,
 		},
+		Listen: &listenDB{
+			conn: conn,
+		},
 		Media: &mediaDB{
 			conn:  conn,
 			state: state,
@@ -197,6 +248,15 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			conn:  conn,
 			state: state,
 		},
+		Poll: &pollDB{
+			conn: conn,
+		},
+		PreviewCard: &previewCardDB{
+			conn: conn,
+		},
+		ProxiedImage: &proxiedImageDB{
+			conn: conn,
+		},
 		Relationship: &relationshipDB{
 			conn:  conn,
 			state: state,
@@ -205,6 +265,9 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			conn:  conn,
 			state: state,
 		},
+		Rule: &ruleDB{
+			conn: conn,
+		},
 		Search: &searchDB{
 			conn:  conn,
 			state: state,
@@ -220,10 +283,16 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			conn:  conn,
 			state: state,
 		},
+		StatusDislike: &statusDislikeDB{
+			conn: conn,
+		},
 		StatusFave: &statusFaveDB{
 			conn:  conn,
 			state: state,
 		},
+		StatusMute: &statusMuteDB{
+			conn: conn,
+		},
 		Timeline: &timelineDB{
 			conn:  conn,
 			state: state,
@@ -236,6 +305,12 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			conn:  conn,
 			state: state,
 		},
+		WebAuthn: &webAuthnDB{
+			conn: conn,
+		},
+		WebSession: &webSessionDB{
+			conn: conn,
+		},
 		conn: conn,
 	}
 
@@ -344,6 +419,68 @@ func sqliteConn(ctx context.Context) (*DBConn, error) {
 	return conn, nil
 }
 
+// sqliteReadConn opens a secondary pool of read-only connections to the
+// sqlite database, to allow read-heavy queries to run concurrently with
+// each other and with the single writer connection. It returns nil if no
+// read pool should be used, ie., when running in-memory, or when not in WAL
+// journal mode, since in any other mode readers and the writer block each other.
+func sqliteReadConn(ctx context.Context) (*bun.DB, error) {
+	address := config.GetDbAddress()
+	address = strings.Split(address, "?")[0]
+	address = strings.TrimPrefix(address, "file:")
+
+	if address == ":memory:" || !strings.EqualFold(config.GetDbSqliteJournalMode(), "WAL") {
+		return nil, nil
+	}
+
+	// Per-connection pragmas must be passed in the
+	// address, as they need applying to every new
+	// connection in the pool, not just the first.
+	prefs := []string{
+		"mode=ro",               // open read-only
+		"_pragma=query_only(1)", // and refuse writes even if it wasn't
+		"_pragma=temp_store(MEMORY)",
+	}
+
+	if size := config.GetDbSqliteCacheSize(); size > 0 {
+		s := strconv.FormatUint(uint64(size/bytesize.KiB), 10)
+		prefs = append(prefs, "_pragma=cache_size(-"+s+")")
+	}
+
+	if timeout := config.GetDbSqliteBusyTimeout(); timeout > 0 {
+		t := strconv.FormatInt(timeout.Milliseconds(), 10)
+		prefs = append(prefs, "_pragma=busy_timeout("+t+")")
+	}
+
+	address = "file:" + address + "?" + strings.Join(prefs, "&")
+
+	sqldb, err := sql.Open("sqlite", address)
+	if err != nil {
+		if errWithCode, ok := err.(*sqlite.Error); ok {
+			err = errors.New(sqlite.ErrorCodeString[errWithCode.Code()])
+		}
+		return nil, fmt.Errorf("could not open read-only sqlite db with address %s: %w", address, err)
+	}
+
+	// WAL mode allows any number of readers alongside
+	// the writer, so tune the same as for postgres.
+	sqldb.SetMaxOpenConns(maxOpenConns())
+	sqldb.SetMaxIdleConns(2)
+	sqldb.SetConnMaxLifetime(0)
+
+	readDB := bun.NewDB(sqldb, sqlitedialect.New())
+
+	if err := readDB.PingContext(ctx); err != nil {
+		if errWithCode, ok := err.(*sqlite.Error); ok {
+			err = errors.New(sqlite.ErrorCodeString[errWithCode.Code()])
+		}
+		return nil, fmt.Errorf("sqlite read-only ping: %s", err)
+	}
+	log.Infof(ctx, "opened read-only SQLITE connection pool with address %s", address)
+
+	return readDB, nil
+}
+
 /*
 	HANDY STUFF
 */
@@ -470,6 +607,14 @@ func sqlitePragmas(ctx context.Context, conn *DBConn) error {
 		pragmas = append(pragmas, []string{"busy_timeout", t})
 	}
 
+	// Always enforce foreign key constraints,
+	// and keep temporary tables + indices in
+	// memory rather than writing them to disk.
+	pragmas = append(pragmas,
+		[]string{"foreign_keys", "ON"},
+		[]string{"temp_store", "MEMORY"},
+	)
+
 	for _, p := range pragmas {
 		pk := p[0]
 		pv := p[1]
@@ -511,9 +656,11 @@ func (dbService *DBService) TagStringToTag(ctx context.Context, t string, origin
 		if err != nil {
 			return nil, err
 		}
+		name := strings.ToLower(t)
 		tag.ID = newID
-		tag.URL = protocol + "://" + host + "/tags/" + t
-		tag.Name = t
+		tag.URL = protocol + "://" + host + "/tags/" + name
+		tag.Name = name
+		tag.DisplayName = t
 		tag.FirstSeenFromAccountID = originAccountID
 		tag.CreatedAt = now
 		tag.UpdatedAt = now
@@ -19,11 +19,13 @@ package bundb_test
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/uptrace/bun"
 )
 
 type BundbNewTestSuite struct {
@@ -46,6 +48,60 @@ func (suite *BundbNewTestSuite) TestCreateNewSqliteDBNoAddress() {
 	suite.Nil(db)
 }
 
+func (suite *BundbNewTestSuite) TestSqlitePragmas() {
+	if config.GetDbType() != "sqlite" {
+		suite.T().Skip("sqlite only")
+	}
+
+	conn := suite.db.(*bundb.DBService).GetConn()
+
+	for pragma, expected := range map[string]string{
+		"foreign_keys": "1",
+		"temp_store":   "2", // MEMORY
+		"synchronous":  "1", // NORMAL
+		"cache_size":   "-8192",
+	} {
+		var res string
+		err := conn.NewRaw("PRAGMA ?", bun.Ident(pragma)).Scan(context.Background(), &res)
+		suite.NoError(err)
+		suite.Equal(expected, res, pragma)
+	}
+}
+
+func (suite *BundbNewTestSuite) TestSqliteReadPool() {
+	if config.GetDbType() != "sqlite" {
+		suite.T().Skip("sqlite only")
+	}
+
+	ctx := context.Background()
+
+	// read pool is only used for on-disk databases
+	config.SetDbAddress(filepath.Join(suite.T().TempDir(), "sqlite.db"))
+	db, err := bundb.NewBunDBService(ctx, nil)
+	suite.NoError(err)
+	defer db.Stop(ctx)
+
+	conn := db.(*bundb.DBService).GetConn()
+
+	var mode string
+	err = conn.NewRaw("PRAGMA journal_mode").Scan(ctx, &mode)
+	suite.NoError(err)
+	suite.Equal("wal", mode)
+
+	// read conn should be separate from the writer
+	read := conn.Read()
+	suite.NotSame(conn.DB, read)
+
+	var queryOnly string
+	err = read.NewRaw("PRAGMA query_only").Scan(ctx, &queryOnly)
+	suite.NoError(err)
+	suite.Equal("1", queryOnly)
+
+	// and should refuse any writes
+	_, err = read.ExecContext(ctx, "DELETE FROM ?", bun.Ident("accounts"))
+	suite.Error(err)
+}
+
 func TestBundbNewTestSuite(t *testing.T) {
 	suite.Run(t, new(BundbNewTestSuite))
 }
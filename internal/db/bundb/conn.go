@@ -30,6 +30,7 @@ import (
 type DBConn struct {
 	errProc func(error) db.Error // errProc is the SQL-type specific error processor
 	*bun.DB                      // DB is the underlying bun.DB connection
+	readDB  *bun.DB              // readDB is an optional read-only connection pool, may be nil
 }
 
 // WrapDBConn wraps a bun DB connection to provide our own error processing dependent on DB dialect.
@@ -49,6 +50,35 @@ func WrapDBConn(dbConn *bun.DB) *DBConn {
 	}
 }
 
+// Read returns the connection to use for read-only queries. This is
+// the secondary read-only connection pool if one has been opened, else
+// the primary connection. Queries made using Read() must *never* write.
+func (conn *DBConn) Read() bun.IDB {
+	if conn.readDB != nil {
+		return conn.readDB
+	}
+	return conn.DB
+}
+
+// AddQueryHook adds the given query hook to the
+// primary connection and the read-only pool (if set).
+func (conn *DBConn) AddQueryHook(hook bun.QueryHook) {
+	conn.DB.AddQueryHook(hook)
+	if conn.readDB != nil {
+		conn.readDB.AddQueryHook(hook)
+	}
+}
+
+// Close closes the primary connection and the read-only pool (if set).
+func (conn *DBConn) Close() error {
+	if conn.readDB != nil {
+		if err := conn.readDB.Close(); err != nil {
+			return err
+		}
+	}
+	return conn.DB.Close()
+}
+
 // RunInTx wraps execution of the supplied transaction function.
 func (conn *DBConn) RunInTx(ctx context.Context, fn func(bun.Tx) error) db.Error {
 	return conn.ProcessError(func() error {
@@ -20,6 +20,7 @@ package bundb
 import (
 	"context"
 	"net/url"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -82,6 +83,29 @@ func (d *domainDB) GetDomainBlock(ctx context.Context, domain string) (*gtsmodel
 	return &block, nil
 }
 
+func (d *domainDB) UpdateDomainBlock(ctx context.Context, block *gtsmodel.DomainBlock, columns ...string) db.Error {
+	block.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	if _, err := d.conn.NewUpdate().
+		Model(block).
+		Column(columns...).
+		Where("? = ?", bun.Ident("domain_block.id"), block.ID).
+		Exec(ctx); err != nil {
+		return d.conn.ProcessError(err)
+	}
+
+	// Severity may have changed, so clear the
+	// domain block cache (for later reload).
+	d.state.Caches.GTS.DomainBlock().Clear()
+
+	return nil
+}
+
 func (d *domainDB) DeleteDomainBlock(ctx context.Context, domain string) db.Error {
 	// Normalize the domain as punycode
 	domain, err := util.Punify(domain)
@@ -120,10 +144,12 @@ func (d *domainDB) IsDomainBlocked(ctx context.Context, domain string) (bool, db
 	return d.state.Caches.GTS.DomainBlock().IsBlocked(domain, func() ([]string, error) {
 		var domains []string
 
-		// Scan list of all blocked domains from DB
+		// Scan list of all blocked domains from DB,
+		// leaving out those that are only silenced.
 		q := d.conn.NewSelect().
 			Table("domain_blocks").
-			Column("domain")
+			Column("domain").
+			Where("? != ?", bun.Ident("severity"), gtsmodel.DomainBlockSeveritySilence)
 		if err := q.Scan(ctx, &domains); err != nil {
 			return nil, d.conn.ProcessError(err)
 		}
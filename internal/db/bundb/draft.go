@@ -0,0 +1,152 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type draftDB struct {
+	conn *DBConn
+}
+
+func (d *draftDB) GetDraftByID(ctx context.Context, id string) (*gtsmodel.Draft, db.Error) {
+	draft := &gtsmodel.Draft{}
+
+	if err := d.conn.
+		NewSelect().
+		Model(draft).
+		Where("? = ?", bun.Ident("draft.id"), id).
+		Scan(ctx); err != nil {
+		return nil, d.conn.ProcessError(err)
+	}
+
+	return draft, nil
+}
+
+func (d *draftDB) GetAccountDrafts(ctx context.Context, accountID string) ([]*gtsmodel.Draft, db.Error) {
+	drafts := []*gtsmodel.Draft{}
+
+	if err := d.conn.
+		NewSelect().
+		Model(&drafts).
+		Where("? = ?", bun.Ident("draft.account_id"), accountID).
+		Order("draft.id DESC").
+		Scan(ctx); err != nil {
+		return nil, d.conn.ProcessError(err)
+	}
+
+	return drafts, nil
+}
+
+func (d *draftDB) CountAccountDrafts(ctx context.Context, accountID string) (int, db.Error) {
+	count, err := d.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("drafts"), bun.Ident("draft")).
+		Where("? = ?", bun.Ident("draft.account_id"), accountID).
+		Count(ctx)
+	if err != nil {
+		return 0, d.conn.ProcessError(err)
+	}
+
+	return count, nil
+}
+
+func (d *draftDB) IsAttachmentInDraft(ctx context.Context, accountID string, attachmentID string) (bool, db.Error) {
+	// Attachment IDs are stored as an array, which
+	// is represented differently by SQLite and Postgres,
+	// so just select them all and check here. Accounts
+	// only have a limited number of drafts anyway.
+	drafts := []*gtsmodel.Draft{}
+
+	if err := d.conn.
+		NewSelect().
+		Model(&drafts).
+		Column("draft.attachments").
+		Where("? = ?", bun.Ident("draft.account_id"), accountID).
+		Scan(ctx); err != nil {
+		return false, d.conn.ProcessError(err)
+	}
+
+	for _, draft := range drafts {
+		for _, id := range draft.AttachmentIDs {
+			if id == attachmentID {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func (d *draftDB) PutDraft(ctx context.Context, draft *gtsmodel.Draft) db.Error {
+	_, err := d.conn.NewInsert().Model(draft).Exec(ctx)
+	return d.conn.ProcessError(err)
+}
+
+func (d *draftDB) UpdateDraft(ctx context.Context, draft *gtsmodel.Draft, columns ...string) db.Error {
+	// Update the draft's last-updated
+	draft.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := d.conn.
+		NewUpdate().
+		Model(draft).
+		Where("? = ?", bun.Ident("draft.id"), draft.ID).
+		Column(columns...).
+		Exec(ctx)
+	return d.conn.ProcessError(err)
+}
+
+func (d *draftDB) DeleteDraftByID(ctx context.Context, id string) db.Error {
+	res, err := d.conn.NewDelete().
+		TableExpr("? AS ?", bun.Ident("drafts"), bun.Ident("draft")).
+		Where("? = ?", bun.Ident("draft.id"), id).
+		Exec(ctx)
+	if err != nil {
+		return d.conn.ProcessError(err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		// Draft was already deleted,
+		// possibly by another request.
+		return db.ErrNoEntries
+	}
+
+	return nil
+}
+
+func (d *draftDB) DeleteAccountDrafts(ctx context.Context, accountID string) db.Error {
+	_, err := d.conn.NewDelete().
+		TableExpr("? AS ?", bun.Ident("drafts"), bun.Ident("draft")).
+		Where("? = ?", bun.Ident("draft.account_id"), accountID).
+		Exec(ctx)
+	return d.conn.ProcessError(err)
+}
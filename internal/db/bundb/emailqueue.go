@@ -0,0 +1,111 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type emailQueueDB struct {
+	conn *DBConn
+}
+
+func (e *emailQueueDB) GetQueuedEmailByID(ctx context.Context, id string) (*gtsmodel.QueuedEmail, db.Error) {
+	email := &gtsmodel.QueuedEmail{}
+
+	if err := e.conn.
+		NewSelect().
+		Model(email).
+		Where("? = ?", bun.Ident("queued_email.id"), id).
+		Scan(ctx); err != nil {
+		return nil, e.conn.ProcessError(err)
+	}
+
+	return email, nil
+}
+
+func (e *emailQueueDB) GetDueQueuedEmails(ctx context.Context, now time.Time, limit int) ([]*gtsmodel.QueuedEmail, db.Error) {
+	emails := []*gtsmodel.QueuedEmail{}
+
+	if err := e.conn.
+		NewSelect().
+		Model(&emails).
+		Where("? IS NULL", bun.Ident("queued_email.failed_at")).
+		Where("? <= ?", bun.Ident("queued_email.next_attempt_at"), now).
+		Order("queued_email.next_attempt_at ASC").
+		Limit(limit).
+		Scan(ctx); err != nil {
+		return nil, e.conn.ProcessError(err)
+	}
+
+	return emails, nil
+}
+
+func (e *emailQueueDB) GetFailedQueuedEmails(ctx context.Context, limit int) ([]*gtsmodel.QueuedEmail, db.Error) {
+	emails := []*gtsmodel.QueuedEmail{}
+
+	if err := e.conn.
+		NewSelect().
+		Model(&emails).
+		Where("? IS NOT NULL", bun.Ident("queued_email.failed_at")).
+		Order("queued_email.failed_at DESC").
+		Limit(limit).
+		Scan(ctx); err != nil {
+		return nil, e.conn.ProcessError(err)
+	}
+
+	return emails, nil
+}
+
+func (e *emailQueueDB) PutQueuedEmail(ctx context.Context, email *gtsmodel.QueuedEmail) db.Error {
+	_, err := e.conn.
+		NewInsert().
+		Model(email).
+		Exec(ctx)
+	return e.conn.ProcessError(err)
+}
+
+func (e *emailQueueDB) UpdateQueuedEmail(ctx context.Context, email *gtsmodel.QueuedEmail, columns ...string) db.Error {
+	email.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := e.conn.
+		NewUpdate().
+		Model(email).
+		Where("? = ?", bun.Ident("queued_email.id"), email.ID).
+		Column(columns...).
+		Exec(ctx)
+	return e.conn.ProcessError(err)
+}
+
+func (e *emailQueueDB) DeleteQueuedEmailByID(ctx context.Context, id string) db.Error {
+	_, err := e.conn.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("queued_emails"), bun.Ident("queued_email")).
+		Where("? = ?", bun.Ident("queued_email.id"), id).
+		Exec(ctx)
+	return e.conn.ProcessError(err)
+}
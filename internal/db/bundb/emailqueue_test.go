@@ -0,0 +1,108 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type EmailQueueTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *EmailQueueTestSuite) TestDueAndFailedQueuedEmails() {
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, email := range []*gtsmodel.QueuedEmail{
+		{
+			// Due now.
+			ID:            "01H5531YNW5E3PZ0A8RD3R0MQA",
+			ToAddresses:   []string{"zork@example.org"},
+			Subject:       "GoToSocial Email Confirmation",
+			Message:       "To: zork@example.org\r\n\r\nhello\r\n",
+			NextAttemptAt: now.Add(-time.Minute),
+		},
+		{
+			// Due later.
+			ID:            "01H5531YNW5E3PZ0A8RD3R0MQB",
+			ToAddresses:   []string{"zork@example.org"},
+			Subject:       "GoToSocial Password Reset",
+			Message:       "To: zork@example.org\r\n\r\nhello\r\n",
+			Attempts:      1,
+			NextAttemptAt: now.Add(time.Hour),
+		},
+		{
+			// Given up on.
+			ID:            "01H5531YNW5E3PZ0A8RD3R0MQC",
+			ToAddresses:   []string{"admin@example.org", "mod@example.org"},
+			Subject:       "GoToSocial New Report",
+			Message:       "To: Undisclosed Recipients:;\r\n\r\nhello\r\n",
+			Attempts:      8,
+			LastError:     "550 5.1.1 mailbox unavailable",
+			NextAttemptAt: now.Add(-time.Hour),
+			FailedAt:      now,
+		},
+	} {
+		if err := suite.db.PutQueuedEmail(ctx, email); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	due, err := suite.db.GetDueQueuedEmails(ctx, now, 10)
+	suite.NoError(err)
+	suite.Len(due, 1)
+	suite.Equal("01H5531YNW5E3PZ0A8RD3R0MQA", due[0].ID)
+
+	failed, err := suite.db.GetFailedQueuedEmails(ctx, 10)
+	suite.NoError(err)
+	suite.Len(failed, 1)
+	suite.Equal("01H5531YNW5E3PZ0A8RD3R0MQC", failed[0].ID)
+	suite.Equal([]string{"admin@example.org", "mod@example.org"}, failed[0].ToAddresses)
+
+	// Put the failed email back in the queue.
+	email := failed[0]
+	email.Attempts = 0
+	email.FailedAt = time.Time{}
+	if err := suite.db.UpdateQueuedEmail(ctx, email, "attempts", "failed_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	due, err = suite.db.GetDueQueuedEmails(ctx, now, 10)
+	suite.NoError(err)
+	suite.Len(due, 2)
+	suite.Equal("01H5531YNW5E3PZ0A8RD3R0MQC", due[0].ID)
+
+	if err := suite.db.DeleteQueuedEmailByID(ctx, email.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetQueuedEmailByID(ctx, email.ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+}
+
+func TestEmailQueueTestSuite(t *testing.T) {
+	suite.Run(t, new(EmailQueueTestSuite))
+}
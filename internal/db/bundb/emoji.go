@@ -27,6 +27,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/uptrace/bun"
@@ -94,7 +95,7 @@ func (e *emojiDB) DeleteEmojiByID(ctx context.Context, id string) db.Error {
 	// Load emoji into cache before attempting a delete,
 	// as we need it cached in order to trigger the invalidate
 	// callback. This in turn invalidates others.
-	_, err := e.GetEmojiByID(
+	emoji, err := e.GetEmojiByID(
 		gtscontext.SetBarebones(ctx),
 		id,
 	)
@@ -206,6 +207,18 @@ func (e *emojiDB) DeleteEmojiByID(ctx context.Context, id string) db.Error {
 			return err
 		}
 
+		if emoji != nil && emoji.Domain == "" && emoji.AccountID == "" {
+			// Leave a tombstone so that clients
+			// delta-syncing the custom emoji list
+			// know to remove this emoji. Personal
+			// emojis are never in that list.
+			if _, err := tx.NewInsert().
+				Model(newEmojiTombstone(emoji.Shortcode)).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
 }
@@ -360,15 +373,120 @@ func (e *emojiDB) GetUseableEmojis(ctx context.Context) ([]*gtsmodel.Emoji, db.E
 		Where("? = ?", bun.Ident("emoji.visible_in_picker"), true).
 		Where("? = ?", bun.Ident("emoji.disabled"), false).
 		Where("? IS NULL", bun.Ident("emoji.domain")).
+		Where("? IS NULL", bun.Ident("emoji.account_id")).
+		Join(
+			"LEFT JOIN ? AS ? ON ? = ?",
+			bun.Ident("emoji_categories"), bun.Ident("emoji_category"),
+			bun.Ident("emoji_category.id"), bun.Ident("emoji.category_id"),
+		).
+		// Uncategorized emojis go last.
+		OrderExpr("? IS NULL ASC", bun.Ident("emoji.category_id")).
+		OrderExpr("? ASC", bun.Ident("emoji_category.position")).
+		OrderExpr("? ASC", bun.Ident("emoji_category.name")).
+		Order("emoji.shortcode ASC")
+
+	if err := q.Scan(ctx, &emojiIDs); err != nil {
+		return nil, e.conn.ProcessError(err)
+	}
+
+	return e.GetEmojisByIDs(ctx, emojiIDs)
+}
+
+func (e *emojiDB) GetEmojisByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.Emoji, error) {
+	emojiIDs := []string{}
+
+	q := e.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("emojis"), bun.Ident("emoji")).
+		Column("emoji.id").
+		Where("? = ?", bun.Ident("emoji.account_id"), accountID).
+		Order("emoji.shortcode ASC")
+
+	if err := q.Scan(ctx, &emojiIDs); err != nil {
+		return nil, e.conn.ProcessError(err)
+	}
+
+	if len(emojiIDs) == 0 {
+		return nil, nil
+	}
+
+	return e.GetEmojisByIDs(ctx, emojiIDs)
+}
+
+func (e *emojiDB) CountEmojisByAccountID(ctx context.Context, accountID string) (int, error) {
+	count, err := e.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("emojis"), bun.Ident("emoji")).
+		Where("? = ?", bun.Ident("emoji.account_id"), accountID).
+		Count(ctx)
+	if err != nil {
+		return 0, e.conn.ProcessError(err)
+	}
+
+	return count, nil
+}
+
+func (e *emojiDB) GetLocalEmojisUpdatedSince(ctx context.Context, since time.Time) ([]*gtsmodel.Emoji, error) {
+	emojiIDs := []string{}
+
+	q := e.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("emojis"), bun.Ident("emoji")).
+		Column("emoji.id").
+		Where("? IS NULL", bun.Ident("emoji.domain")).
+		Where("? IS NULL", bun.Ident("emoji.account_id")).
+		Where("? > ?", bun.Ident("emoji.updated_at"), since).
 		Order("emoji.shortcode ASC")
 
 	if err := q.Scan(ctx, &emojiIDs); err != nil {
 		return nil, e.conn.ProcessError(err)
 	}
 
+	if len(emojiIDs) == 0 {
+		return nil, nil
+	}
+
 	return e.GetEmojisByIDs(ctx, emojiIDs)
 }
 
+func (e *emojiDB) GetLocalEmojisLastModified(ctx context.Context) (time.Time, error) {
+	var lastModified time.Time
+
+	for _, q := range []*bun.SelectQuery{
+		e.conn.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("emojis"), bun.Ident("emoji")).
+			Column("emoji.updated_at").
+			Where("? IS NULL", bun.Ident("emoji.domain")).
+			Where("? IS NULL", bun.Ident("emoji.account_id")).
+			Order("emoji.updated_at DESC"),
+		e.conn.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("emoji_categories"), bun.Ident("emoji_category")).
+			Column("emoji_category.updated_at").
+			Order("emoji_category.updated_at DESC"),
+		e.conn.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("emoji_tombstones"), bun.Ident("emoji_tombstone")).
+			Column("emoji_tombstone.created_at").
+			Order("emoji_tombstone.created_at DESC"),
+	} {
+		var t time.Time
+		if err := q.Limit(1).Scan(ctx, &t); err != nil {
+			if err := e.conn.ProcessError(err); !errors.Is(err, db.ErrNoEntries) {
+				return time.Time{}, err
+			}
+			continue
+		}
+
+		if t.After(lastModified) {
+			lastModified = t
+		}
+	}
+
+	return lastModified, nil
+}
+
 func (e *emojiDB) GetEmojiByID(ctx context.Context, id string) (*gtsmodel.Emoji, db.Error) {
 	return e.getEmoji(
 		ctx,
@@ -443,6 +561,25 @@ func (e *emojiDB) PutEmojiCategory(ctx context.Context, emojiCategory *gtsmodel.
 	})
 }
 
+func (e *emojiDB) UpdateEmojiCategory(ctx context.Context, emojiCategory *gtsmodel.EmojiCategory, columns ...string) error {
+	emojiCategory.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	// Update the emoji category model in the database.
+	return e.state.Caches.GTS.EmojiCategory().Store(emojiCategory, func() error {
+		_, err := e.conn.
+			NewUpdate().
+			Model(emojiCategory).
+			Where("? = ?", bun.Ident("emoji_category.id"), emojiCategory.ID).
+			Column(columns...).
+			Exec(ctx)
+		return e.conn.ProcessError(err)
+	})
+}
+
 func (e *emojiDB) GetEmojiCategories(ctx context.Context) ([]*gtsmodel.EmojiCategory, db.Error) {
 	emojiCategoryIDs := []string{}
 
@@ -450,6 +587,7 @@ func (e *emojiDB) GetEmojiCategories(ctx context.Context) ([]*gtsmodel.EmojiCate
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("emoji_categories"), bun.Ident("emoji_category")).
 		Column("emoji_category.id").
+		Order("emoji_category.position ASC").
 		Order("emoji_category.name ASC")
 
 	if err := q.Scan(ctx, &emojiCategoryIDs); err != nil {
@@ -487,6 +625,49 @@ func (e *emojiDB) GetEmojiCategoryByName(ctx context.Context, name string) (*gts
 	)
 }
 
+// newEmojiTombstone returns a new
+// tombstone for the given shortcode.
+func newEmojiTombstone(shortcode string) *gtsmodel.EmojiTombstone {
+	return &gtsmodel.EmojiTombstone{
+		ID:        id.NewULID(),
+		CreatedAt: time.Now(),
+		Shortcode: shortcode,
+	}
+}
+
+func (e *emojiDB) GetEmojiTombstonesSince(ctx context.Context, since time.Time) ([]*gtsmodel.EmojiTombstone, error) {
+	tombstones := []*gtsmodel.EmojiTombstone{}
+
+	if err := e.conn.
+		NewSelect().
+		Model(&tombstones).
+		Where("? > ?", bun.Ident("emoji_tombstone.created_at"), since).
+		Order("emoji_tombstone.created_at ASC").
+		Scan(ctx); err != nil {
+		return nil, e.conn.ProcessError(err)
+	}
+
+	return tombstones, nil
+}
+
+func (e *emojiDB) DeleteEmojiTombstonesOlderThan(ctx context.Context, olderThan time.Time) (int, error) {
+	res, err := e.conn.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("emoji_tombstones"), bun.Ident("emoji_tombstone")).
+		Where("? < ?", bun.Ident("emoji_tombstone.created_at"), olderThan).
+		Exec(ctx)
+	if err != nil {
+		return 0, e.conn.ProcessError(err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(n), nil
+}
+
 func (e *emojiDB) getEmoji(ctx context.Context, lookup string, dbQuery func(*gtsmodel.Emoji) error, keyParts ...any) (*gtsmodel.Emoji, db.Error) {
 	// Fetch emoji from database cache with loader callback
 	emoji, err := e.state.Caches.GTS.Emoji().Load(lookup, func() (*gtsmodel.Emoji, error) {
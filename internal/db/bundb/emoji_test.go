@@ -20,6 +20,7 @@ package bundb_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -47,6 +48,16 @@ func (suite *EmojiTestSuite) TestDeleteEmojiByID() {
 	dbEmoji, err := suite.db.GetEmojiByID(context.Background(), testEmoji.ID)
 	suite.Nil(dbEmoji)
 	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// A tombstone should have been left for the local emoji.
+	tombstones, err := suite.db.GetEmojiTombstonesSince(context.Background(), time.Now().Add(-time.Minute))
+	suite.NoError(err)
+	suite.Len(tombstones, 1)
+	suite.Equal(testEmoji.Shortcode, tombstones[0].Shortcode)
+
+	n, err := suite.db.DeleteEmojiTombstonesOlderThan(context.Background(), time.Now().Add(time.Minute))
+	suite.NoError(err)
+	suite.Equal(1, n)
 }
 
 func (suite *EmojiTestSuite) TestGetEmojiByStaticURL() {
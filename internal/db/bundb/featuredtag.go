@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type featuredTagDB struct {
+	conn *DBConn
+}
+
+func (f *featuredTagDB) GetAccountFeaturedTags(ctx context.Context, accountID string) ([]*gtsmodel.FeaturedTag, db.Error) {
+	featuredTags := []*gtsmodel.FeaturedTag{}
+
+	if err := f.conn.
+		NewSelect().
+		Model(&featuredTags).
+		Where("? = ?", bun.Ident("featured_tag.account_id"), accountID).
+		Order("featured_tag.id ASC").
+		Scan(ctx); err != nil {
+		return nil, f.conn.ProcessError(err)
+	}
+
+	return featuredTags, nil
+}
+
+func (f *featuredTagDB) PutFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) db.Error {
+	_, err := f.conn.
+		NewInsert().
+		Model(featuredTag).
+		Exec(ctx)
+	return f.conn.ProcessError(err)
+}
+
+func (f *featuredTagDB) DeleteFeaturedTagByID(ctx context.Context, id string) db.Error {
+	_, err := f.conn.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("featured_tags"), bun.Ident("featured_tag")).
+		Where("? = ?", bun.Ident("featured_tag.id"), id).
+		Exec(ctx)
+	return f.conn.ProcessError(err)
+}
+
+func (f *featuredTagDB) DeleteAccountFeaturedTags(ctx context.Context, accountID string) db.Error {
+	_, err := f.conn.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("featured_tags"), bun.Ident("featured_tag")).
+		Where("? = ?", bun.Ident("featured_tag.account_id"), accountID).
+		Exec(ctx)
+	return f.conn.ProcessError(err)
+}
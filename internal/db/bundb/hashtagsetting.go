@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type hashtagSettingDB struct {
+	conn *DBConn
+}
+
+func (h *hashtagSettingDB) GetHashtagSettingByName(ctx context.Context, name string) (*gtsmodel.HashtagSetting, db.Error) {
+	setting := &gtsmodel.HashtagSetting{}
+
+	if err := h.conn.
+		NewSelect().
+		Model(setting).
+		Where("? = ?", bun.Ident("hashtag_setting.name"), strings.ToLower(name)).
+		Scan(ctx); err != nil {
+		return nil, h.conn.ProcessError(err)
+	}
+
+	return setting, nil
+}
+
+func (h *hashtagSettingDB) GetHashtagSettings(ctx context.Context) ([]*gtsmodel.HashtagSetting, db.Error) {
+	settings := []*gtsmodel.HashtagSetting{}
+
+	if err := h.conn.
+		NewSelect().
+		Model(&settings).
+		Order("hashtag_setting.name ASC").
+		Scan(ctx); err != nil {
+		return nil, h.conn.ProcessError(err)
+	}
+
+	return settings, nil
+}
+
+func (h *hashtagSettingDB) GetContentWarningRequiredHashtags(ctx context.Context, names []string) ([]string, db.Error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	lower := make([]string, 0, len(names))
+	for _, name := range names {
+		lower = append(lower, strings.ToLower(name))
+	}
+
+	required := []string{}
+	if err := h.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("hashtag_settings"), bun.Ident("hashtag_setting")).
+		Column("hashtag_setting.name").
+		Where("? IN (?)", bun.Ident("hashtag_setting.name"), bun.In(lower)).
+		Where("? = ?", bun.Ident("hashtag_setting.content_warning_required"), true).
+		Order("hashtag_setting.name ASC").
+		Scan(ctx, &required); err != nil {
+		return nil, h.conn.ProcessError(err)
+	}
+
+	return required, nil
+}
+
+func (h *hashtagSettingDB) PutHashtagSetting(ctx context.Context, setting *gtsmodel.HashtagSetting) db.Error {
+	setting.Name = strings.ToLower(setting.Name)
+	_, err := h.conn.NewInsert().Model(setting).Exec(ctx)
+	return h.conn.ProcessError(err)
+}
+
+func (h *hashtagSettingDB) UpdateHashtagSetting(ctx context.Context, setting *gtsmodel.HashtagSetting, columns ...string) db.Error {
+	// Update the setting's last-updated
+	setting.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := h.conn.
+		NewUpdate().
+		Model(setting).
+		Where("? = ?", bun.Ident("hashtag_setting.id"), setting.ID).
+		Column(columns...).
+		Exec(ctx)
+	return h.conn.ProcessError(err)
+}
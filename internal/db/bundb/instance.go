@@ -19,6 +19,7 @@ package bundb
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -98,6 +99,52 @@ func (i *instanceDB) CountInstanceDomains(ctx context.Context, domain string) (i
 	return count, nil
 }
 
+func (i *instanceDB) CountInstanceActivity(ctx context.Context, since time.Time, until time.Time) (int, int, int, db.Error) {
+	statuses, err := i.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Where("? = ?", bun.Ident("status.local"), true).
+		Where("? >= ?", bun.Ident("status.created_at"), since).
+		Where("? < ?", bun.Ident("status.created_at"), until).
+		Count(ctx)
+	if err != nil {
+		return 0, 0, 0, i.conn.ProcessError(err)
+	}
+
+	// We only keep the two most recent sign-ins of
+	// each user, so count users who signed in at
+	// least once in the period by either of those.
+	logins, err := i.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? >= ?", bun.Ident("user.current_sign_in_at"), since).
+				Where("? < ?", bun.Ident("user.current_sign_in_at"), until)
+		}).
+		WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? >= ?", bun.Ident("user.last_sign_in_at"), since).
+				Where("? < ?", bun.Ident("user.last_sign_in_at"), until)
+		}).
+		Count(ctx)
+	if err != nil {
+		return 0, 0, 0, i.conn.ProcessError(err)
+	}
+
+	registrations, err := i.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		Where("? >= ?", bun.Ident("user.created_at"), since).
+		Where("? < ?", bun.Ident("user.created_at"), until).
+		Count(ctx)
+	if err != nil {
+		return 0, 0, 0, i.conn.ProcessError(err)
+	}
+
+	return statuses, logins, registrations, nil
+}
+
 func (i *instanceDB) GetInstance(ctx context.Context, domain string) (*gtsmodel.Instance, db.Error) {
 	instance := &gtsmodel.Instance{}
 
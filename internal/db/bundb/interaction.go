@@ -0,0 +1,192 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type interactionDB struct {
+	conn  *DBConn
+	state *state.State
+}
+
+func (i *interactionDB) GetInteractionRequestByID(ctx context.Context, id string) (*gtsmodel.InteractionRequest, db.Error) {
+	return i.getInteractionRequest(ctx, "id", id)
+}
+
+func (i *interactionDB) GetInteractionRequestByInteractionURI(ctx context.Context, uri string) (*gtsmodel.InteractionRequest, db.Error) {
+	return i.getInteractionRequest(ctx, "interaction_uri", uri)
+}
+
+func (i *interactionDB) GetInteractionRequestByURI(ctx context.Context, uri string) (*gtsmodel.InteractionRequest, db.Error) {
+	return i.getInteractionRequest(ctx, "uri", uri)
+}
+
+func (i *interactionDB) getInteractionRequest(ctx context.Context, column string, value string) (*gtsmodel.InteractionRequest, db.Error) {
+	req := &gtsmodel.InteractionRequest{}
+
+	if err := i.conn.
+		NewSelect().
+		Model(req).
+		Where("? = ?", bun.Ident("interaction_request."+column), value).
+		Scan(ctx); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	if err := i.populateInteractionRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (i *interactionDB) populateInteractionRequest(ctx context.Context, req *gtsmodel.InteractionRequest) error {
+	var err error
+
+	// Set the status being interacted with.
+	req.Status, err = i.state.DB.GetStatusByID(ctx, req.StatusID)
+	if err != nil {
+		return gtserror.Newf("error getting interaction request status %s: %w", req.StatusID, err)
+	}
+
+	// Set the account whose approval is required.
+	req.TargetAccount, err = i.state.DB.GetAccountByID(ctx, req.TargetAccountID)
+	if err != nil {
+		return gtserror.Newf("error getting interaction request target account %s: %w", req.TargetAccountID, err)
+	}
+
+	// Set the interacting account.
+	req.InteractingAccount, err = i.state.DB.GetAccountByID(ctx, req.InteractingAccountID)
+	if err != nil {
+		return gtserror.Newf("error getting interaction request interacting account %s: %w", req.InteractingAccountID, err)
+	}
+
+	if req.IsRejected() {
+		// Rejected interactions
+		// no longer exist.
+		return nil
+	}
+
+	// Set the interaction itself.
+	switch req.InteractionType {
+	case gtsmodel.InteractionLike:
+		req.Like, err = i.state.DB.GetStatusFaveByID(ctx, req.InteractionID)
+	case gtsmodel.InteractionReply:
+		req.Reply, err = i.state.DB.GetStatusByID(ctx, req.InteractionID)
+	case gtsmodel.InteractionAnnounce:
+		req.Announce, err = i.state.DB.GetStatusByID(ctx, req.InteractionID)
+	}
+	if err != nil {
+		return gtserror.Newf("error getting interaction request %s %s: %w", req.InteractionType, req.InteractionID, err)
+	}
+
+	return nil
+}
+
+func (i *interactionDB) GetPendingInteractionRequests(ctx context.Context, targetAccountID string, statusID string, types []gtsmodel.InteractionType, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.InteractionRequest, db.Error) {
+	if len(types) == 0 {
+		// Nothing can match.
+		return nil, db.ErrNoEntries
+	}
+
+	reqIDs := []string{}
+
+	q := i.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("interaction_requests"), bun.Ident("interaction_request")).
+		Column("interaction_request.id").
+		Where("? = ?", bun.Ident("interaction_request.target_account_id"), targetAccountID).
+		Where("? IS NULL", bun.Ident("interaction_request.accepted_at")).
+		Where("? IS NULL", bun.Ident("interaction_request.rejected_at")).
+		Where("? IN (?)", bun.Ident("interaction_request.interaction_type"), bun.In(types)).
+		Order("interaction_request.id DESC")
+
+	if statusID != "" {
+		q = q.Where("? = ?", bun.Ident("interaction_request.status_id"), statusID)
+	}
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("interaction_request.id"), maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("? > ?", bun.Ident("interaction_request.id"), sinceID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("interaction_request.id"), minID)
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &reqIDs); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	// Catch case of no requests early
+	if len(reqIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// Allocate return slice (will be at most len reqIDs)
+	reqs := make([]*gtsmodel.InteractionRequest, 0, len(reqIDs))
+	for _, id := range reqIDs {
+		req, err := i.GetInteractionRequestByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting interaction request %q: %v", id, err)
+			continue
+		}
+
+		// Append to return slice
+		reqs = append(reqs, req)
+	}
+
+	return reqs, nil
+}
+
+func (i *interactionDB) PutInteractionRequest(ctx context.Context, req *gtsmodel.InteractionRequest) db.Error {
+	_, err := i.conn.NewInsert().Model(req).Exec(ctx)
+	return i.conn.ProcessError(err)
+}
+
+func (i *interactionDB) UpdateInteractionRequest(ctx context.Context, req *gtsmodel.InteractionRequest, columns ...string) db.Error {
+	// Update the request's last-updated
+	req.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := i.conn.
+		NewUpdate().
+		Model(req).
+		Where("? = ?", bun.Ident("interaction_request.id"), req.ID).
+		Column(columns...).
+		Exec(ctx)
+	return i.conn.ProcessError(err)
+}
@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type listenDB struct {
+	conn *DBConn
+}
+
+func (l *listenDB) GetAccountListen(ctx context.Context, accountID string) (*gtsmodel.Listen, db.Error) {
+	listen := &gtsmodel.Listen{}
+
+	if err := l.conn.
+		NewSelect().
+		Model(listen).
+		Where("? = ?", bun.Ident("listen.account_id"), accountID).
+		Order("listen.created_at DESC").
+		Limit(1).
+		Scan(ctx); err != nil {
+		return nil, l.conn.ProcessError(err)
+	}
+
+	return listen, nil
+}
+
+func (l *listenDB) PutListen(ctx context.Context, listen *gtsmodel.Listen) db.Error {
+	return l.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		if _, err := tx.
+			NewInsert().
+			Model(listen).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Only the most recent listen
+		// of each account is kept.
+		_, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("listens"), bun.Ident("listen")).
+			Where("? = ?", bun.Ident("listen.account_id"), listen.AccountID).
+			Where("? != ?", bun.Ident("listen.id"), listen.ID).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (l *listenDB) DeleteAccountListens(ctx context.Context, accountID string) db.Error {
+	_, err := l.conn.NewDelete().
+		TableExpr("? AS ?", bun.Ident("listens"), bun.Ident("listen")).
+		Where("? = ?", bun.Ident("listen.account_id"), accountID).
+		Exec(ctx)
+	return l.conn.ProcessError(err)
+}
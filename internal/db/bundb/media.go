@@ -20,6 +20,7 @@ package bundb
 import (
 	"context"
 	"errors"
+	"sort"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -232,6 +233,127 @@ func (m *mediaDB) DeleteAttachment(ctx context.Context, id string) error {
 	return m.conn.ProcessError(err)
 }
 
+func (m *mediaDB) CountAccountAttachments(ctx context.Context, accountID string) (int, int64, error) {
+	var (
+		count int
+		size  int64
+	)
+
+	if err := m.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		ColumnExpr("COUNT(*)").
+		ColumnExpr("COALESCE(SUM(? + ?), 0)",
+			bun.Ident("media_attachment.file_file_size"),
+			bun.Ident("media_attachment.thumbnail_file_size"),
+		).
+		Where("? = ?", bun.Ident("media_attachment.account_id"), accountID).
+		Where("? = ?", bun.Ident("media_attachment.cached"), true).
+		Scan(ctx, &count, &size); err != nil {
+		return 0, 0, m.conn.ProcessError(err)
+	}
+
+	return count, size, nil
+}
+
+func (m *mediaDB) GetMediaUsageByAccount(ctx context.Context, limit int) ([]*db.MediaUsage, error) {
+	usage := []*db.MediaUsage{}
+
+	q := m.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		ColumnExpr("? AS ?", bun.Ident("media_attachment.account_id"), bun.Ident("key")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		ColumnExpr("SUM(? + ?) AS ?",
+			bun.Ident("media_attachment.file_file_size"),
+			bun.Ident("media_attachment.thumbnail_file_size"),
+			bun.Ident("size"),
+		).
+		Where("? = ?", bun.Ident("media_attachment.cached"), true).
+		GroupExpr("?", bun.Ident("media_attachment.account_id")).
+		OrderExpr("? DESC", bun.Ident("size"))
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &usage); err != nil {
+		return nil, m.conn.ProcessError(err)
+	}
+
+	return usage, nil
+}
+
+func (m *mediaDB) GetMediaUsageByDomain(ctx context.Context, limit int) ([]*db.MediaUsage, error) {
+	attachments := []*db.MediaUsage{}
+
+	if err := m.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("account"),
+			bun.Ident("account.id"), bun.Ident("media_attachment.account_id"),
+		).
+		ColumnExpr("COALESCE(?, '') AS ?", bun.Ident("account.domain"), bun.Ident("key")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		ColumnExpr("SUM(? + ?) AS ?",
+			bun.Ident("media_attachment.file_file_size"),
+			bun.Ident("media_attachment.thumbnail_file_size"),
+			bun.Ident("size"),
+		).
+		Where("? = ?", bun.Ident("media_attachment.cached"), true).
+		GroupExpr("?", bun.Ident("account.domain")).
+		Scan(ctx, &attachments); err != nil {
+		return nil, m.conn.ProcessError(err)
+	}
+
+	emojis := []*db.MediaUsage{}
+
+	if err := m.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("emojis"), bun.Ident("emoji")).
+		ColumnExpr("COALESCE(?, '') AS ?", bun.Ident("emoji.domain"), bun.Ident("key")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		ColumnExpr("SUM(? + ?) AS ?",
+			bun.Ident("emoji.image_file_size"),
+			bun.Ident("emoji.image_static_file_size"),
+			bun.Ident("size"),
+		).
+		Where("? = ?", bun.Ident("emoji.cached"), true).
+		GroupExpr("?", bun.Ident("emoji.domain")).
+		Scan(ctx, &emojis); err != nil {
+		return nil, m.conn.ProcessError(err)
+	}
+
+	// Merge emoji usage into
+	// attachment usage by domain.
+	byDomain := make(map[string]*db.MediaUsage, len(attachments))
+	for _, u := range attachments {
+		byDomain[u.Key] = u
+	}
+
+	for _, u := range emojis {
+		if existing, ok := byDomain[u.Key]; ok {
+			existing.Count += u.Count
+			existing.Size += u.Size
+			continue
+		}
+		byDomain[u.Key] = u
+		attachments = append(attachments, u)
+	}
+
+	sort.Slice(attachments, func(i, j int) bool {
+		return attachments[i].Size > attachments[j].Size
+	})
+
+	if limit > 0 && len(attachments) > limit {
+		attachments = attachments[:limit]
+	}
+
+	return attachments, nil
+}
+
 func (m *mediaDB) GetRemoteOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.MediaAttachment, db.Error) {
 	attachmentIDs := []string{}
 
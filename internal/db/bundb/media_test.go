@@ -23,6 +23,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -59,6 +61,121 @@ func (suite *MediaTestSuite) TestGetLocalUnattachedOlderThan() {
 	suite.Len(attachments, 1)
 }
 
+func (suite *MediaTestSuite) TestCountAccountAttachments() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	var (
+		expectCount int
+		expectSize  int64
+	)
+	for _, a := range suite.testAttachments {
+		if a.AccountID == testAccount.ID && *a.Cached {
+			expectCount++
+			expectSize += int64(a.File.FileSize + a.Thumbnail.FileSize)
+		}
+	}
+
+	count, size, err := suite.db.CountAccountAttachments(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.NotZero(count)
+	suite.Equal(expectCount, count)
+	suite.Equal(expectSize, size)
+}
+
+func (suite *MediaTestSuite) TestGetMediaUsageByAccount() {
+	ctx := context.Background()
+
+	expect := make(map[string]int64)
+	for _, a := range suite.testAttachments {
+		if *a.Cached {
+			expect[a.AccountID] += int64(a.File.FileSize + a.Thumbnail.FileSize)
+		}
+	}
+
+	usage, err := suite.db.GetMediaUsageByAccount(ctx, 0)
+	suite.NoError(err)
+	suite.Len(usage, len(expect))
+
+	for i, u := range usage {
+		suite.Equal(expect[u.Key], u.Size)
+		if i > 0 {
+			suite.GreaterOrEqual(usage[i-1].Size, u.Size)
+		}
+	}
+
+	// Limit should only
+	// return the largest.
+	usage, err = suite.db.GetMediaUsageByAccount(ctx, 1)
+	suite.NoError(err)
+	suite.Len(usage, 1)
+}
+
+func (suite *MediaTestSuite) TestGetMediaUsageByDomain() {
+	ctx := context.Background()
+
+	domains := make(map[string]string)
+	for _, a := range suite.testAccounts {
+		domains[a.ID] = a.Domain
+	}
+
+	expect := make(map[string]int64)
+	for _, a := range suite.testAttachments {
+		if *a.Cached {
+			expect[domains[a.AccountID]] += int64(a.File.FileSize + a.Thumbnail.FileSize)
+		}
+	}
+	for _, e := range suite.testEmojis {
+		if *e.Cached {
+			expect[e.Domain] += int64(e.ImageFileSize + e.ImageStaticFileSize)
+		}
+	}
+
+	usage, err := suite.db.GetMediaUsageByDomain(ctx, 0)
+	suite.NoError(err)
+	suite.Len(usage, len(expect))
+
+	for i, u := range usage {
+		suite.Equal(expect[u.Key], u.Size)
+		if i > 0 {
+			suite.GreaterOrEqual(usage[i-1].Size, u.Size)
+		}
+	}
+}
+
+func (suite *MediaTestSuite) TestGetMediaUsageByDomainUncachedEmoji() {
+	ctx := context.Background()
+
+	usageFor := func(domain string) *db.MediaUsage {
+		usage, err := suite.db.GetMediaUsageByDomain(ctx, 0)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		for _, u := range usage {
+			if u.Key == domain {
+				return u
+			}
+		}
+		return &db.MediaUsage{Key: domain}
+	}
+
+	emoji := suite.testEmojis["yell"]
+	before := usageFor(emoji.Domain)
+
+	// Mark the remote emoji as uncached,
+	// as though its files were pruned.
+	uncached := new(gtsmodel.Emoji)
+	*uncached = *emoji
+	uncached.Cached = testrig.FalseBool()
+	if err := suite.db.UpdateEmoji(ctx, uncached, "cached"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	after := usageFor(emoji.Domain)
+	suite.Equal(before.Count-1, after.Count)
+	suite.Equal(before.Size-int64(emoji.ImageFileSize+emoji.ImageStaticFileSize), after.Size)
+}
+
 func TestMediaTestSuite(t *testing.T) {
 	suite.Run(t, new(MediaTestSuite))
 }
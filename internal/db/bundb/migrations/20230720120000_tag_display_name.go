@@ -0,0 +1,72 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			_, err := tx.ExecContext(ctx, "ALTER TABLE ? ADD COLUMN ? VARCHAR", bun.Ident("tags"), bun.Ident("display_name"))
+			if err != nil && !(strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "duplicate column name") || strings.Contains(err.Error(), "SQLSTATE 42701")) {
+				return err
+			}
+
+			// Existing tags were stored as first
+			// seen, so that's their display name.
+			if _, err := tx.NewUpdate().
+				Table("tags").
+				Set("? = ?", bun.Ident("display_name"), bun.Ident("name")).
+				Where("? IS NULL", bun.Ident("display_name")).
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Normalize names to lowercase, leaving
+			// alone any which would clash with an
+			// existing lowercase tag; lookups are
+			// case-insensitive so these still work.
+			if _, err := tx.NewUpdate().
+				Table("tags").
+				Set("? = LOWER(?)", bun.Ident("name"), bun.Ident("name")).
+				Where("? != LOWER(?)", bun.Ident("name"), bun.Ident("name")).
+				Where("NOT EXISTS (SELECT 1 FROM ? AS ? WHERE ? = LOWER(?))",
+					bun.Ident("tags"), bun.Ident("other"), bun.Ident("other.name"), bun.Ident("tags.name")).
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
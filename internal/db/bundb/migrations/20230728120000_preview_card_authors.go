@@ -0,0 +1,72 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+func init() {
+	alreadyExists := func(err error) bool {
+		return strings.Contains(err.Error(), "already exists") ||
+			strings.Contains(err.Error(), "duplicate column name") ||
+			strings.Contains(err.Error(), "SQLSTATE 42701")
+	}
+
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			q := tx.NewAddColumn().Model(&gtsmodel.Account{})
+
+			switch tx.Dialect().Name() {
+			case dialect.PG:
+				q = q.ColumnExpr("? VARCHAR[]", bun.Ident("attribution_domains"))
+			case dialect.SQLite:
+				q = q.ColumnExpr("? VARCHAR", bun.Ident("attribution_domains"))
+			default:
+				log.Panic(ctx, "db dialect was neither pg nor sqlite")
+			}
+
+			if _, err := q.Exec(ctx); err != nil && !alreadyExists(err) {
+				return err
+			}
+
+			_, err := tx.ExecContext(ctx, "ALTER TABLE ? ADD COLUMN ? CHAR(26)", bun.Ident("preview_cards"), bun.Ident("author_account_id"))
+			if err != nil && !alreadyExists(err) {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
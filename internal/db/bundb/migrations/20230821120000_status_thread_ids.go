@@ -0,0 +1,124 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			_, err := tx.ExecContext(ctx, "ALTER TABLE ? ADD COLUMN ? CHAR(26)", bun.Ident("statuses"), bun.Ident("thread_id"))
+			if err != nil && !(strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "duplicate column name") || strings.Contains(err.Error(), "SQLSTATE 42701")) {
+				return err
+			}
+
+			// Statuses that aren't replies, or whose
+			// parent we don't have, start a thread.
+			if _, err := tx.ExecContext(ctx,
+				"UPDATE ? SET ? = ? WHERE ? IS NULL AND ? IS NULL AND (? IS NULL OR NOT EXISTS (SELECT 1 FROM ? AS ? WHERE ? = ?))",
+				bun.Ident("statuses"), bun.Ident("thread_id"), bun.Ident("id"),
+				bun.Ident("thread_id"), bun.Ident("boost_of_id"), bun.Ident("in_reply_to_id"),
+				bun.Ident("statuses"), bun.Ident("parent"), bun.Ident("parent.id"), bun.Ident("statuses.in_reply_to_id"),
+			); err != nil {
+				return err
+			}
+
+			// Replies inherit the thread of their parent,
+			// one level of the thread tree at a time.
+			for {
+				res, err := tx.ExecContext(ctx,
+					"UPDATE ? SET ? = (SELECT ? FROM ? AS ? WHERE ? = ?) WHERE ? IS NULL AND EXISTS (SELECT 1 FROM ? AS ? WHERE ? = ? AND ? IS NOT NULL)",
+					bun.Ident("statuses"), bun.Ident("thread_id"),
+					bun.Ident("parent.thread_id"), bun.Ident("statuses"), bun.Ident("parent"), bun.Ident("parent.id"), bun.Ident("statuses.in_reply_to_id"),
+					bun.Ident("thread_id"),
+					bun.Ident("statuses"), bun.Ident("parent"), bun.Ident("parent.id"), bun.Ident("statuses.in_reply_to_id"), bun.Ident("parent.thread_id"),
+				)
+				if err != nil {
+					return err
+				}
+
+				if n, err := res.RowsAffected(); err != nil {
+					return err
+				} else if n == 0 {
+					break
+				}
+			}
+
+			// Boosts are in the thread of the boosted status.
+			if _, err := tx.ExecContext(ctx,
+				"UPDATE ? SET ? = (SELECT ? FROM ? AS ? WHERE ? = ?) WHERE ? IS NULL AND ? IS NOT NULL",
+				bun.Ident("statuses"), bun.Ident("thread_id"),
+				bun.Ident("boosted.thread_id"), bun.Ident("statuses"), bun.Ident("boosted"), bun.Ident("boosted.id"), bun.Ident("statuses.boost_of_id"),
+				bun.Ident("thread_id"), bun.Ident("boost_of_id"),
+			); err != nil {
+				return err
+			}
+
+			// Anything left over (eg., boosts of
+			// missing statuses) is its own thread.
+			if _, err := tx.
+				NewUpdate().
+				Table("statuses").
+				Set("? = ?", bun.Ident("thread_id"), bun.Ident("id")).
+				Where("? IS NULL", bun.Ident("thread_id")).
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Mutes were keyed on the ID of the highest known
+			// ancestor of the muted status (or, from before that,
+			// the muted status itself), so point them at the
+			// thread that status is now in.
+			if _, err := tx.ExecContext(ctx,
+				"UPDATE ? SET ? = (SELECT ? FROM ? AS ? WHERE ? = ?) WHERE EXISTS (SELECT 1 FROM ? AS ? WHERE ? = ?)",
+				bun.Ident("status_mutes"), bun.Ident("thread_id"),
+				bun.Ident("status.thread_id"), bun.Ident("statuses"), bun.Ident("status"), bun.Ident("status.id"), bun.Ident("status_mutes.thread_id"),
+				bun.Ident("statuses"), bun.Ident("status"), bun.Ident("status.id"), bun.Ident("status_mutes.thread_id"),
+			); err != nil {
+				return err
+			}
+
+			if _, err := tx.
+				NewCreateIndex().
+				Table("statuses").
+				Index("statuses_thread_id_idx").
+				Column("thread_id").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
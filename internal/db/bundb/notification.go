@@ -64,8 +64,16 @@ func (n *notificationDB) GetNotification(
 			Model(&notif).
 			Where("? = ?", bun.Ident("notification_type"), notificationType).
 			Where("? = ?", bun.Ident("target_account_id"), targetAccountID).
-			Where("? = ?", bun.Ident("origin_account_id"), originAccountID).
-			Where("? = ?", bun.Ident("status_id"), statusID)
+			Where("? = ?", bun.Ident("origin_account_id"), originAccountID)
+
+		if statusID == "" {
+			// Notifications which don't pertain
+			// to a status (follows, bites) store
+			// a null status ID.
+			q = q.Where("? IS NULL", bun.Ident("status_id"))
+		} else {
+			q = q.Where("? = ?", bun.Ident("status_id"), statusID)
+		}
 
 		if err := q.Scan(ctx); err != nil {
 			return nil, n.conn.ProcessError(err)
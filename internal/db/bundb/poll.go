@@ -0,0 +1,146 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+type pollDB struct {
+	conn *DBConn
+}
+
+func (p *pollDB) GetPollByID(ctx context.Context, id string) (*gtsmodel.Poll, db.Error) {
+	poll := &gtsmodel.Poll{}
+
+	if err := p.conn.
+		NewSelect().
+		Model(poll).
+		Where("? = ?", bun.Ident("poll.id"), id).
+		Scan(ctx); err != nil {
+		return nil, p.conn.ProcessError(err)
+	}
+
+	return poll, nil
+}
+
+func (p *pollDB) PutPoll(ctx context.Context, poll *gtsmodel.Poll) db.Error {
+	_, err := p.conn.NewInsert().Model(poll).Exec(ctx)
+	return p.conn.ProcessError(err)
+}
+
+func (p *pollDB) UpdatePoll(ctx context.Context, poll *gtsmodel.Poll, columns ...string) db.Error {
+	// Update the poll's last-updated
+	poll.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := p.conn.
+		NewUpdate().
+		Model(poll).
+		Where("? = ?", bun.Ident("poll.id"), poll.ID).
+		Column(columns...).
+		Exec(ctx)
+	return p.conn.ProcessError(err)
+}
+
+func (p *pollDB) GetPollVoteBy(ctx context.Context, pollID string, accountID string) (*gtsmodel.PollVote, db.Error) {
+	vote := &gtsmodel.PollVote{}
+
+	if err := p.conn.
+		NewSelect().
+		Model(vote).
+		Where("? = ?", bun.Ident("poll_vote.poll_id"), pollID).
+		Where("? = ?", bun.Ident("poll_vote.account_id"), accountID).
+		Scan(ctx); err != nil {
+		return nil, p.conn.ProcessError(err)
+	}
+
+	return vote, nil
+}
+
+func (p *pollDB) PutPollVote(ctx context.Context, vote *gtsmodel.PollVote) db.Error {
+	poll := &gtsmodel.Poll{}
+
+	err := p.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		// Insert the vote first; if the account has
+		// already voted, this fails on the unique index
+		// before the counts are touched.
+		if _, err := tx.
+			NewInsert().
+			Model(vote).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		q := tx.
+			NewSelect().
+			Model(poll).
+			Where("? = ?", bun.Ident("poll.id"), vote.PollID)
+
+		// Lock the poll row until the counts are updated,
+		// so that concurrent votes can't overwrite each
+		// other's counts. SQLite doesn't support this, but
+		// it doesn't need to either: the vote insert above
+		// already holds the database write lock for this tx.
+		if p.conn.Dialect().Name() == dialect.PG {
+			q = q.For("UPDATE")
+		}
+
+		if err := q.Scan(ctx); err != nil {
+			return err
+		}
+
+		// Counts may be missing for polls
+		// nobody has voted in yet.
+		if len(poll.Votes) != len(poll.Options) {
+			poll.Votes = make([]int, len(poll.Options))
+		}
+
+		for _, choice := range vote.Choices {
+			if choice < 0 || choice >= len(poll.Votes) {
+				return gtserror.Newf("choice %d out of range for poll %s", choice, poll.ID)
+			}
+			poll.Votes[choice]++
+		}
+		poll.Voters++
+		poll.UpdatedAt = time.Now()
+
+		_, err := tx.
+			NewUpdate().
+			Model(poll).
+			Where("? = ?", bun.Ident("poll.id"), poll.ID).
+			Column("votes", "voters", "updated_at").
+			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return p.conn.ProcessError(err)
+	}
+
+	vote.Poll = poll
+	return nil
+}
@@ -0,0 +1,86 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type PollTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *PollTestSuite) TestPutPollVoteConcurrent() {
+	ctx := context.Background()
+
+	poll := &gtsmodel.Poll{
+		ID:       "01H7Y7KX6C3N1V8Q2R5T9W0MAB",
+		StatusID: suite.testStatuses["local_account_1_status_1"].ID,
+		Options:  []string{"yes", "no"},
+	}
+	if err := suite.db.PutPoll(ctx, poll); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	voters := []*gtsmodel.Account{
+		suite.testAccounts["admin_account"],
+		suite.testAccounts["local_account_1"],
+		suite.testAccounts["local_account_2"],
+		suite.testAccounts["remote_account_1"],
+		suite.testAccounts["remote_account_2"],
+	}
+
+	// Vote from all accounts at once; no
+	// vote should get lost along the way.
+	var wg sync.WaitGroup
+	errs := make([]error, len(voters))
+	for i, voter := range voters {
+		wg.Add(1)
+		go func(i int, voter *gtsmodel.Account) {
+			defer wg.Done()
+			errs[i] = suite.db.PutPollVote(ctx, &gtsmodel.PollVote{
+				ID:        id.NewULID(),
+				PollID:    poll.ID,
+				AccountID: voter.ID,
+				Choices:   []int{i % 2},
+			})
+		}(i, voter)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		suite.NoError(err)
+	}
+
+	dbPoll, err := suite.db.GetPollByID(ctx, poll.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(len(voters), dbPoll.Voters)
+	suite.Equal([]int{3, 2}, dbPoll.Votes)
+}
+
+func TestPollTestSuite(t *testing.T) {
+	suite.Run(t, new(PollTestSuite))
+}
@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type proxiedImageDB struct {
+	conn *DBConn
+}
+
+func (p *proxiedImageDB) GetProxiedImageByURL(ctx context.Context, url string) (*gtsmodel.ProxiedImage, db.Error) {
+	image := &gtsmodel.ProxiedImage{}
+
+	if err := p.conn.
+		NewSelect().
+		Model(image).
+		Where("? = ?", bun.Ident("proxied_image.url"), url).
+		Scan(ctx); err != nil {
+		return nil, p.conn.ProcessError(err)
+	}
+
+	return image, nil
+}
+
+func (p *proxiedImageDB) GetProxiedImagesAccessedBefore(ctx context.Context, before time.Time, limit int) ([]*gtsmodel.ProxiedImage, db.Error) {
+	images := []*gtsmodel.ProxiedImage{}
+
+	q := p.conn.
+		NewSelect().
+		Model(&images).
+		Where("? < ?", bun.Ident("proxied_image.accessed_at"), before).
+		Order("proxied_image.accessed_at ASC")
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, p.conn.ProcessError(err)
+	}
+
+	return images, nil
+}
+
+func (p *proxiedImageDB) PutProxiedImage(ctx context.Context, image *gtsmodel.ProxiedImage) db.Error {
+	_, err := p.conn.NewInsert().Model(image).Exec(ctx)
+	return p.conn.ProcessError(err)
+}
+
+func (p *proxiedImageDB) UpdateProxiedImage(ctx context.Context, image *gtsmodel.ProxiedImage, columns ...string) db.Error {
+	// Update the image's last-updated
+	image.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := p.conn.
+		NewUpdate().
+		Model(image).
+		Where("? = ?", bun.Ident("proxied_image.id"), image.ID).
+		Column(columns...).
+		Exec(ctx)
+	return p.conn.ProcessError(err)
+}
+
+func (p *proxiedImageDB) DeleteProxiedImageByID(ctx context.Context, id string) db.Error {
+	_, err := p.conn.NewDelete().
+		TableExpr("? AS ?", bun.Ident("proxied_images"), bun.Ident("proxied_image")).
+		Where("? = ?", bun.Ident("proxied_image.id"), id).
+		Exec(ctx)
+	return p.conn.ProcessError(err)
+}
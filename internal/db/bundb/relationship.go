@@ -97,6 +97,15 @@ func (r *relationshipDB) GetAccountFollows(ctx context.Context, accountID string
 	return r.GetFollowsByIDs(ctx, followIDs)
 }
 
+func (r *relationshipDB) GetAccountFollowsNotBlocked(ctx context.Context, accountID string, requestingAccountID string) ([]*gtsmodel.Follow, error) {
+	var followIDs []string
+	if err := newSelectFollowsNotBlocked(r.conn, accountID, requestingAccountID).
+		Scan(ctx, &followIDs); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+	return r.GetFollowsByIDs(ctx, followIDs)
+}
+
 func (r *relationshipDB) GetAccountLocalFollows(ctx context.Context, accountID string) ([]*gtsmodel.Follow, error) {
 	var followIDs []string
 	if err := newSelectLocalFollows(r.conn, accountID).
@@ -115,6 +124,24 @@ func (r *relationshipDB) GetAccountFollowers(ctx context.Context, accountID stri
 	return r.GetFollowsByIDs(ctx, followIDs)
 }
 
+func (r *relationshipDB) GetAccountMutualFollowers(ctx context.Context, accountID string) ([]*gtsmodel.Follow, error) {
+	var followIDs []string
+	if err := newSelectMutualFollowers(r.conn, accountID).
+		Scan(ctx, &followIDs); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+	return r.GetFollowsByIDs(ctx, followIDs)
+}
+
+func (r *relationshipDB) GetAccountFollowersNotBlocked(ctx context.Context, accountID string, requestingAccountID string) ([]*gtsmodel.Follow, error) {
+	var followIDs []string
+	if err := newSelectFollowersNotBlocked(r.conn, accountID, requestingAccountID).
+		Scan(ctx, &followIDs); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+	return r.GetFollowsByIDs(ctx, followIDs)
+}
+
 func (r *relationshipDB) GetAccountLocalFollowers(ctx context.Context, accountID string) ([]*gtsmodel.Follow, error) {
 	var followIDs []string
 	if err := newSelectLocalFollowers(r.conn, accountID).
@@ -199,6 +226,17 @@ func newSelectFollows(conn *DBConn, accountID string) *bun.SelectQuery {
 		OrderExpr("? DESC", bun.Ident("updated_at"))
 }
 
+// newSelectFollowsNotBlocked returns a new select query for all rows in the follows table with account_id = accountID,
+// where the corresponding target account ID is not blocking, or blocked by, requestingAccountID.
+func newSelectFollowsNotBlocked(conn *DBConn, accountID string, requestingAccountID string) *bun.SelectQuery {
+	q := conn.NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
+		ColumnExpr("?", bun.Ident("follow.id")).
+		Where("? = ?", bun.Ident("follow.account_id"), accountID).
+		OrderExpr("? DESC", bun.Ident("follow.updated_at"))
+	return joinNotBlocked(q, "follow.target_account_id", requestingAccountID)
+}
+
 // newSelectLocalFollows returns a new select query for all rows in the follows table with
 // account_id = accountID where the corresponding account ID has a NULL domain (i.e. is local).
 func newSelectLocalFollows(conn *DBConn, accountID string) *bun.SelectQuery {
@@ -226,6 +264,32 @@ func newSelectFollowers(conn *DBConn, accountID string) *bun.SelectQuery {
 		OrderExpr("? DESC", bun.Ident("updated_at"))
 }
 
+// newSelectMutualFollowers returns a new select query for all rows in the follows table with target_account_id = accountID,
+// where accountID also has a corresponding follow row targeting the follow's account_id (i.e. the follow is mutual).
+func newSelectMutualFollowers(conn *DBConn, accountID string) *bun.SelectQuery {
+	return conn.NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
+		ColumnExpr("?", bun.Ident("follow.id")).
+		Join("JOIN ? AS ? ON ? = ? AND ? = ?",
+			bun.Ident("follows"), bun.Ident("follow_back"),
+			bun.Ident("follow_back.account_id"), bun.Ident("follow.target_account_id"),
+			bun.Ident("follow_back.target_account_id"), bun.Ident("follow.account_id"),
+		).
+		Where("? = ?", bun.Ident("follow.target_account_id"), accountID).
+		OrderExpr("? DESC", bun.Ident("follow.updated_at"))
+}
+
+// newSelectFollowersNotBlocked returns a new select query for all rows in the follows table with target_account_id = accountID,
+// where the corresponding account ID is not blocking, or blocked by, requestingAccountID.
+func newSelectFollowersNotBlocked(conn *DBConn, accountID string, requestingAccountID string) *bun.SelectQuery {
+	q := conn.NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
+		ColumnExpr("?", bun.Ident("follow.id")).
+		Where("? = ?", bun.Ident("follow.target_account_id"), accountID).
+		OrderExpr("? DESC", bun.Ident("follow.updated_at"))
+	return joinNotBlocked(q, "follow.account_id", requestingAccountID)
+}
+
 // newSelectLocalFollowers returns a new select query for all rows in the follows table with
 // target_account_id = accountID where the corresponding account ID has a NULL domain (i.e. is local).
 func newSelectLocalFollowers(conn *DBConn, accountID string) *bun.SelectQuery {
@@ -243,3 +307,17 @@ func newSelectLocalFollowers(conn *DBConn, accountID string) *bun.SelectQuery {
 		).
 		OrderExpr("? DESC", bun.Ident("updated_at"))
 }
+
+// joinNotBlocked joins the given select query against the blocks table, excluding rows
+// where the account ID in the given column is blocking, or blocked by, requestingAccountID.
+func joinNotBlocked(q *bun.SelectQuery, column string, requestingAccountID string) *bun.SelectQuery {
+	return q.
+		Join("LEFT JOIN ? AS ? ON (? = ? AND ? = ?) OR (? = ? AND ? = ?)",
+			bun.Ident("blocks"), bun.Ident("block"),
+			bun.Ident("block.account_id"), requestingAccountID,
+			bun.Ident("block.target_account_id"), bun.Ident(column),
+			bun.Ident("block.account_id"), bun.Ident(column),
+			bun.Ident("block.target_account_id"), requestingAccountID,
+		).
+		Where("? IS NULL", bun.Ident("block.id"))
+}
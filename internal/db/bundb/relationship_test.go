@@ -765,6 +765,38 @@ func (suite *RelationshipTestSuite) TestGetAccountFollows() {
 	suite.Len(follows, 2)
 }
 
+func (suite *RelationshipTestSuite) TestGetAccountFollowsNotBlocked() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+
+	// local_account_2 blocks remote_account_1
+	// in the fixtures, so only admin is returned.
+	follows, err := suite.db.GetAccountFollowsNotBlocked(ctx, account.ID, requestingAccount.ID)
+	suite.NoError(err)
+	suite.Len(follows, 1)
+	suite.Equal(suite.testAccounts["admin_account"].ID, follows[0].TargetAccountID)
+
+	// Now block in the other direction.
+	if err := suite.db.PutBlock(ctx, &gtsmodel.Block{
+		ID:              id.NewULID(),
+		URI:             "http://fossbros-anonymous.io/users/foss_satan/blocks/01H4DAEN0SP9NP5X54FKPYCTC0",
+		AccountID:       requestingAccount.ID,
+		TargetAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	follows, err = suite.db.GetAccountFollowsNotBlocked(ctx, account.ID, requestingAccount.ID)
+	suite.NoError(err)
+	suite.Empty(follows)
+
+	// Nobody is blocked by local_account_1.
+	follows, err = suite.db.GetAccountFollowsNotBlocked(ctx, account.ID, account.ID)
+	suite.NoError(err)
+	suite.Len(follows, 2)
+}
+
 func (suite *RelationshipTestSuite) TestCountAccountFollowsLocalOnly() {
 	account := suite.testAccounts["local_account_1"]
 	followsCount, err := suite.db.CountAccountLocalFollows(context.Background(), account.ID)
@@ -786,6 +818,41 @@ func (suite *RelationshipTestSuite) TestGetAccountFollowers() {
 	suite.Len(follows, 2)
 }
 
+func (suite *RelationshipTestSuite) TestGetAccountMutualFollowers() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	// Both followers of local_account_1
+	// are followed back in the fixtures.
+	follows, err := suite.db.GetAccountMutualFollowers(ctx, account.ID)
+	suite.NoError(err)
+	suite.Len(follows, 2)
+
+	// Stop following local_account_2 back,
+	// it should no longer be a mutual.
+	err = suite.db.DeleteFollowByID(ctx, suite.testFollows["local_account_1_local_account_2"].ID)
+	suite.NoError(err)
+
+	follows, err = suite.db.GetAccountMutualFollowers(ctx, account.ID)
+	suite.NoError(err)
+	if suite.Len(follows, 1) {
+		suite.Equal(suite.testAccounts["admin_account"].ID, follows[0].AccountID)
+		suite.NotNil(follows[0].Account)
+	}
+}
+
+func (suite *RelationshipTestSuite) TestGetAccountFollowersNotBlocked() {
+	account := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+
+	// local_account_2 blocks remote_account_1
+	// in the fixtures, so only admin is returned.
+	follows, err := suite.db.GetAccountFollowersNotBlocked(context.Background(), account.ID, requestingAccount.ID)
+	suite.NoError(err)
+	suite.Len(follows, 1)
+	suite.Equal(suite.testAccounts["admin_account"].ID, follows[0].AccountID)
+}
+
 func (suite *RelationshipTestSuite) TestCountAccountFollowers() {
 	account := suite.testAccounts["local_account_1"]
 	followsCount, err := suite.db.CountAccountFollowers(context.Background(), account.ID)
@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type RuleTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *RuleTestSuite) TestGetRulesEmpty() {
+	rules, err := suite.db.GetRules(context.Background())
+	suite.NoError(err)
+	suite.NotNil(rules)
+	suite.Empty(rules)
+}
+
+func (suite *RuleTestSuite) TestPutUpdateDeleteRule() {
+	ctx := context.Background()
+
+	for _, rule := range []*gtsmodel.Rule{
+		{ID: "01H3ZKAT4VPDZ8M1FKB6TBHBHA", Text: "Don't be a jerk."},
+		{ID: "01H3ZKAT4VPDZ8M1FKB6TBHBHB", Text: "No spam."},
+	} {
+		if err := suite.db.PutRule(ctx, rule); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	rules, err := suite.db.GetRules(ctx)
+	suite.NoError(err)
+	suite.Len(rules, 2)
+	suite.Equal("Don't be a jerk.", rules[0].Text)
+	suite.Equal("No spam.", rules[1].Text)
+
+	rule := rules[1]
+	rule.Text = "No spam, please."
+	_, err = suite.db.UpdateRule(ctx, rule, "text")
+	suite.NoError(err)
+
+	dbRule, err := suite.db.GetRuleByID(ctx, rule.ID)
+	suite.NoError(err)
+	suite.Equal("No spam, please.", dbRule.Text)
+
+	err = suite.db.DeleteRuleByID(ctx, rule.ID)
+	suite.NoError(err)
+
+	_, err = suite.db.GetRuleByID(ctx, rule.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	rules, err = suite.db.GetRules(ctx)
+	suite.NoError(err)
+	suite.Len(rules, 1)
+}
+
+func TestRuleTestSuite(t *testing.T) {
+	suite.Run(t, new(RuleTestSuite))
+}
@@ -98,6 +98,17 @@ func (s *statusDB) GetStatusByURL(ctx context.Context, url string) (*gtsmodel.St
 	)
 }
 
+func (s *statusDB) GetStatusByAlias(ctx context.Context, alias string) (*gtsmodel.Status, db.Error) {
+	return s.getStatus(
+		ctx,
+		"Alias",
+		func(status *gtsmodel.Status) error {
+			return s.newStatusQ(status).Where("? = ?", bun.Ident("status.alias"), alias).Scan(ctx)
+		},
+		alias,
+	)
+}
+
 func (s *statusDB) getStatus(ctx context.Context, lookup string, dbQuery func(*gtsmodel.Status) error, keyParts ...any) (*gtsmodel.Status, db.Error) {
 	// Fetch status from database cache with loader callback
 	status, err := s.state.Caches.GTS.Status().Load(lookup, func() (*gtsmodel.Status, error) {
@@ -244,11 +255,24 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 }
 
 func (s *statusDB) PutStatus(ctx context.Context, status *gtsmodel.Status) db.Error {
-	return s.state.Caches.GTS.Status().Store(status, func() error {
+	// IDs of statuses moved to the
+	// thread of this status on insert.
+	var rethreadedIDs []string
+
+	if err := s.state.Caches.GTS.Status().Store(status, func() error {
 		// It is safe to run this database transaction within cache.Store
 		// as the cache does not attempt a mutex lock until AFTER hook.
 		//
 		return s.conn.RunInTx(ctx, func(tx bun.Tx) error {
+			var err error
+
+			// Work out which thread this status is in, pulling
+			// in any replies to it that were stored before it.
+			rethreadedIDs, err = s.threadStatus(ctx, tx, status)
+			if err != nil {
+				return err
+			}
+
 			// create links between this status and any emojis it uses
 			for _, i := range status.EmojiIDs {
 				if _, err := tx.
@@ -300,10 +324,115 @@ func (s *statusDB) PutStatus(ctx context.Context, status *gtsmodel.Status) db.Er
 			}
 
 			// Finally, insert the status
-			_, err := tx.NewInsert().Model(status).Exec(ctx)
+			_, err = tx.NewInsert().Model(status).Exec(ctx)
 			return err
 		})
-	})
+	}); err != nil {
+		return err
+	}
+
+	for _, id := range rethreadedIDs {
+		// Drop cached copies with the old thread ID.
+		s.state.Caches.GTS.Status().Invalidate("ID", id)
+	}
+
+	return nil
+}
+
+// threadStatus sets the thread ID of the given status to that of the status
+// it boosts or replies to, if it isn't already set. Replies to the status that
+// were stored before it was will have started threads of their own; these are
+// merged into the thread of the status, and the IDs of the statuses moved are
+// returned. If the status has no known parent, it adopts the thread of one of
+// those replies (so existing mutes of it still apply), or else starts a thread.
+func (s *statusDB) threadStatus(ctx context.Context, tx bun.Tx, status *gtsmodel.Status) ([]string, error) {
+	if status.ThreadID == "" {
+		parentID := status.InReplyToID
+		if status.BoostOfID != "" {
+			parentID = status.BoostOfID
+		}
+
+		if parentID != "" {
+			if err := tx.
+				NewSelect().
+				TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+				Column("status.thread_id").
+				Where("? = ?", bun.Ident("status.id"), parentID).
+				Scan(ctx, &status.ThreadID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return nil, err
+			}
+		}
+	}
+
+	if status.BoostOfID != "" {
+		// Boosts can't be replied to.
+		if status.ThreadID == "" {
+			status.ThreadID = status.ID
+		}
+		return nil, nil
+	}
+
+	var replyThreadIDs []string
+	if err := tx.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr("DISTINCT ?", bun.Ident("status.thread_id")).
+		Where("? = ?", bun.Ident("status.in_reply_to_uri"), status.URI).
+		Where("? IS NOT NULL", bun.Ident("status.thread_id")).
+		Scan(ctx, &replyThreadIDs); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if status.ThreadID == "" {
+		if len(replyThreadIDs) == 0 {
+			status.ThreadID = status.ID
+			return nil, nil
+		}
+
+		status.ThreadID = replyThreadIDs[0]
+		replyThreadIDs = replyThreadIDs[1:]
+	}
+
+	var rethreadedIDs []string
+	for _, threadID := range replyThreadIDs {
+		if threadID == status.ThreadID {
+			continue
+		}
+
+		var ids []string
+		if err := tx.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+			Column("status.id").
+			Where("? = ?", bun.Ident("status.thread_id"), threadID).
+			Scan(ctx, &ids); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+
+		if _, err := tx.
+			NewUpdate().
+			Table("statuses").
+			Set("? = ?", bun.Ident("thread_id"), status.ThreadID).
+			Where("? = ?", bun.Ident("thread_id"), threadID).
+			Exec(ctx); err != nil {
+			return nil, err
+		}
+
+		// Mutes of the merged thread
+		// now cover the whole thread.
+		if _, err := tx.
+			NewUpdate().
+			Table("status_mutes").
+			Set("? = ?", bun.Ident("thread_id"), status.ThreadID).
+			Where("? = ?", bun.Ident("thread_id"), threadID).
+			Exec(ctx); err != nil {
+			return nil, err
+		}
+
+		rethreadedIDs = append(rethreadedIDs, ids...)
+	}
+
+	return rethreadedIDs, nil
 }
 
 func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status, columns ...string) db.Error {
@@ -416,6 +545,39 @@ func (s *statusDB) DeleteStatusByID(ctx context.Context, id string) db.Error {
 			return err
 		}
 
+		// delete votes in any poll attached to this status
+		if _, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("poll_votes"), bun.Ident("poll_vote")).
+			Where("? IN (?)", bun.Ident("poll_vote.poll_id"), tx.
+				NewSelect().
+				TableExpr("? AS ?", bun.Ident("polls"), bun.Ident("poll")).
+				Column("poll.id").
+				Where("? = ?", bun.Ident("poll.status_id"), id)).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// delete any poll attached to this status
+		if _, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("polls"), bun.Ident("poll")).
+			Where("? = ?", bun.Ident("poll.status_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// delete interaction requests for this status, or
+		// for this status as a reply to / boost of another
+		if _, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("interaction_requests"), bun.Ident("interaction_request")).
+			WhereOr("? = ?", bun.Ident("interaction_request.status_id"), id).
+			WhereOr("? = ?", bun.Ident("interaction_request.interaction_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
 		// delete the status itself
 		if _, err := tx.
 			NewDelete().
@@ -531,6 +693,7 @@ func (s *statusDB) CountStatusReplies(ctx context.Context, status *gtsmodel.Stat
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
 		Where("? = ?", bun.Ident("status.in_reply_to_id"), status.ID).
+		Where("? = ?", bun.Ident("status.pending_approval"), false).
 		Count(ctx)
 }
 
@@ -539,6 +702,7 @@ func (s *statusDB) CountStatusReblogs(ctx context.Context, status *gtsmodel.Stat
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
 		Where("? = ?", bun.Ident("status.boost_of_id"), status.ID).
+		Where("? = ?", bun.Ident("status.pending_approval"), false).
 		Count(ctx)
 }
 
@@ -547,6 +711,7 @@ func (s *statusDB) CountStatusFaves(ctx context.Context, status *gtsmodel.Status
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
 		Where("? = ?", bun.Ident("status_fave.status_id"), status.ID).
+		Where("? = ?", bun.Ident("status_fave.pending_approval"), false).
 		Count(ctx)
 }
 
@@ -570,24 +735,29 @@ func (s *statusDB) IsStatusRebloggedBy(ctx context.Context, status *gtsmodel.Sta
 	return s.conn.Exists(ctx, q)
 }
 
-func (s *statusDB) IsStatusMutedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, db.Error) {
+func (s *statusDB) IsStatusBookmarkedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, db.Error) {
 	q := s.conn.
 		NewSelect().
-		TableExpr("? AS ?", bun.Ident("status_mutes"), bun.Ident("status_mute")).
-		Where("? = ?", bun.Ident("status_mute.status_id"), status.ID).
-		Where("? = ?", bun.Ident("status_mute.account_id"), accountID)
+		TableExpr("? AS ?", bun.Ident("status_bookmarks"), bun.Ident("status_bookmark")).
+		Where("? = ?", bun.Ident("status_bookmark.status_id"), status.ID).
+		Where("? = ?", bun.Ident("status_bookmark.account_id"), accountID)
 
 	return s.conn.Exists(ctx, q)
 }
 
-func (s *statusDB) IsStatusBookmarkedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, db.Error) {
+func (s *statusDB) GetStatusIDsInThread(ctx context.Context, threadID string) ([]string, db.Error) {
+	var statusIDs []string
+
 	q := s.conn.
 		NewSelect().
-		TableExpr("? AS ?", bun.Ident("status_bookmarks"), bun.Ident("status_bookmark")).
-		Where("? = ?", bun.Ident("status_bookmark.status_id"), status.ID).
-		Where("? = ?", bun.Ident("status_bookmark.account_id"), accountID)
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Column("status.id").
+		Where("? = ?", bun.Ident("status.thread_id"), threadID)
 
-	return s.conn.Exists(ctx, q)
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+	return statusIDs, nil
 }
 
 func (s *statusDB) GetStatusReblogs(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.Status, db.Error) {
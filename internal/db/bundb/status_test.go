@@ -24,8 +24,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type StatusTestSuite struct {
@@ -212,6 +214,112 @@ func (suite *StatusTestSuite) TestUpdateStatus() {
 	suite.True(updated.PinnedAt.IsZero())
 }
 
+func (suite *StatusTestSuite) newThreadStatus(id string, inReplyToURI string) *gtsmodel.Status {
+	account := suite.testAccounts["remote_account_1"]
+	return &gtsmodel.Status{
+		ID:                  id,
+		URI:                 "http://fossbros-anonymous.io/users/foss_satan/statuses/" + id,
+		AccountID:           account.ID,
+		AccountURI:          account.URI,
+		InReplyToURI:        inReplyToURI,
+		Visibility:          gtsmodel.VisibilityPublic,
+		ActivityStreamsType: ap.ObjectNote,
+		Federated:           testrig.TrueBool(),
+		Boostable:           testrig.TrueBool(),
+		Replyable:           testrig.TrueBool(),
+		Likeable:            testrig.TrueBool(),
+	}
+}
+
+func (suite *StatusTestSuite) TestPutStatusInheritsThread() {
+	ctx := context.Background()
+	parent := suite.testStatuses["admin_account_status_3"]
+
+	reply := suite.newThreadStatus("01H8E4ZJ3W7R6HV3WSY8A8QG2T", parent.URI)
+	reply.InReplyToID = parent.ID
+	reply.InReplyToAccountID = parent.AccountID
+	suite.NoError(suite.db.PutStatus(ctx, reply))
+
+	// The reply is in the thread of
+	// the root, not of its parent.
+	suite.Equal(suite.testStatuses["local_account_1_status_1"].ID, reply.ThreadID)
+
+	statusIDs, err := suite.db.GetStatusIDsInThread(ctx, reply.ThreadID)
+	suite.NoError(err)
+	suite.Contains(statusIDs, reply.ID)
+	suite.Contains(statusIDs, parent.ID)
+}
+
+func (suite *StatusTestSuite) TestPutStatusAdoptsReplyThread() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	parent := suite.newThreadStatus("01H8E4ZJ3W7R6HV3WSY8A8QG2T", "")
+	reply := suite.newThreadStatus("01H8E50C1TQ4C4PS6ZK6T9SBVM", parent.URI)
+
+	// The reply arrives first, and
+	// so is in a thread of its own.
+	suite.NoError(suite.db.PutStatus(ctx, reply))
+	suite.Equal(reply.ID, reply.ThreadID)
+
+	suite.NoError(suite.db.PutStatusMute(ctx, &gtsmodel.StatusMute{
+		ID:              "01H8E51J4EBWX2ZS5Y6G2WRK5T",
+		AccountID:       account.ID,
+		TargetAccountID: reply.AccountID,
+		StatusID:        reply.ID,
+		ThreadID:        reply.ThreadID,
+	}))
+
+	// The parent takes over the thread of the
+	// reply, so the mute now covers it too.
+	suite.NoError(suite.db.PutStatus(ctx, parent))
+	suite.Equal(reply.ID, parent.ThreadID)
+
+	muted, err := suite.db.IsStatusMutedBy(ctx, parent, account.ID)
+	suite.NoError(err)
+	suite.True(muted)
+}
+
+func (suite *StatusTestSuite) TestPutStatusMergesReplyThreads() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	root := suite.testStatuses["local_account_1_status_1"]
+
+	// A reply to the root that hasn't been
+	// stored yet, and a reply to that reply
+	// which arrives before it does.
+	parent := suite.newThreadStatus("01H8E4ZJ3W7R6HV3WSY8A8QG2T", root.URI)
+	parent.InReplyToID = root.ID
+	parent.InReplyToAccountID = root.AccountID
+	reply := suite.newThreadStatus("01H8E50C1TQ4C4PS6ZK6T9SBVM", parent.URI)
+
+	suite.NoError(suite.db.PutStatus(ctx, reply))
+	suite.Equal(reply.ID, reply.ThreadID)
+
+	suite.NoError(suite.db.PutStatusMute(ctx, &gtsmodel.StatusMute{
+		ID:              "01H8E51J4EBWX2ZS5Y6G2WRK5T",
+		AccountID:       account.ID,
+		TargetAccountID: reply.AccountID,
+		StatusID:        reply.ID,
+		ThreadID:        reply.ThreadID,
+	}))
+
+	// Storing the missing parent merges the
+	// thread of the reply into that of the root.
+	suite.NoError(suite.db.PutStatus(ctx, parent))
+	suite.Equal(root.ID, parent.ThreadID)
+
+	dbReply, err := suite.db.GetStatusByID(ctx, reply.ID)
+	suite.NoError(err)
+	suite.Equal(root.ID, dbReply.ThreadID)
+
+	// The mute moved with the
+	// thread it was keyed on.
+	muted, err := suite.db.IsStatusMutedBy(ctx, root, account.ID)
+	suite.NoError(err)
+	suite.True(muted)
+}
+
 func TestStatusTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusTestSuite))
 }
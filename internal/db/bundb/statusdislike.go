@@ -0,0 +1,108 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type statusDislikeDB struct {
+	conn *DBConn
+}
+
+func (s *statusDislikeDB) GetStatusDislike(ctx context.Context, accountID string, statusID string) (*gtsmodel.StatusDislike, db.Error) {
+	dislike := &gtsmodel.StatusDislike{}
+
+	if err := s.conn.
+		NewSelect().
+		Model(dislike).
+		Where("? = ?", bun.Ident("status_dislike.account_id"), accountID).
+		Where("? = ?", bun.Ident("status_dislike.status_id"), statusID).
+		Scan(ctx); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+
+	return dislike, nil
+}
+
+func (s *statusDislikeDB) GetStatusDislikeByURI(ctx context.Context, uri string) (*gtsmodel.StatusDislike, db.Error) {
+	dislike := &gtsmodel.StatusDislike{}
+
+	if err := s.conn.
+		NewSelect().
+		Model(dislike).
+		Where("? = ?", bun.Ident("status_dislike.uri"), uri).
+		Scan(ctx); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+
+	return dislike, nil
+}
+
+func (s *statusDislikeDB) CountStatusDislikes(ctx context.Context, statusID string) (int, db.Error) {
+	count, err := s.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_dislikes"), bun.Ident("status_dislike")).
+		Where("? = ?", bun.Ident("status_dislike.status_id"), statusID).
+		Count(ctx)
+	if err != nil {
+		return 0, s.conn.ProcessError(err)
+	}
+
+	return count, nil
+}
+
+func (s *statusDislikeDB) PutStatusDislike(ctx context.Context, dislike *gtsmodel.StatusDislike) db.Error {
+	_, err := s.conn.
+		NewInsert().
+		Model(dislike).
+		Exec(ctx)
+	return s.conn.ProcessError(err)
+}
+
+func (s *statusDislikeDB) DeleteStatusDislikeByID(ctx context.Context, id string) db.Error {
+	_, err := s.conn.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("status_dislikes"), bun.Ident("status_dislike")).
+		Where("? = ?", bun.Ident("status_dislike.id"), id).
+		Exec(ctx)
+	return s.conn.ProcessError(err)
+}
+
+func (s *statusDislikeDB) DeleteStatusDislikes(ctx context.Context, accountID string) db.Error {
+	_, err := s.conn.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("status_dislikes"), bun.Ident("status_dislike")).
+		WhereOr("? = ?", bun.Ident("status_dislike.account_id"), accountID).
+		WhereOr("? = ?", bun.Ident("status_dislike.target_account_id"), accountID).
+		Exec(ctx)
+	return s.conn.ProcessError(err)
+}
+
+func (s *statusDislikeDB) DeleteStatusDislikesForStatus(ctx context.Context, statusID string) db.Error {
+	_, err := s.conn.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("status_dislikes"), bun.Ident("status_dislike")).
+		Where("? = ?", bun.Ident("status_dislike.status_id"), statusID).
+		Exec(ctx)
+	return s.conn.ProcessError(err)
+}
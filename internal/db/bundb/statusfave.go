@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
@@ -198,6 +199,24 @@ func (s *statusFaveDB) PutStatusFave(ctx context.Context, fave *gtsmodel.StatusF
 	})
 }
 
+func (s *statusFaveDB) UpdateStatusFave(ctx context.Context, fave *gtsmodel.StatusFave, columns ...string) db.Error {
+	// Update the fave's last-updated
+	fave.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	return s.state.Caches.GTS.StatusFave().Store(fave, func() error {
+		_, err := s.conn.
+			NewUpdate().
+			Model(fave).
+			Where("? = ?", bun.Ident("status_fave.id"), fave.ID).
+			Column(columns...).
+			Exec(ctx)
+		return s.conn.ProcessError(err)
+	})
+}
+
 func (s *statusFaveDB) DeleteStatusFaveByID(ctx context.Context, id string) db.Error {
 	defer s.state.Caches.GTS.StatusFave().Invalidate("ID", id)
 
@@ -213,6 +232,14 @@ func (s *statusFaveDB) DeleteStatusFaveByID(ctx context.Context, id string) db.E
 		return err
 	}
 
+	// Delete any interaction request for this fave.
+	if _, err := s.conn.NewDelete().
+		Table("interaction_requests").
+		Where("? = ?", bun.Ident("interaction_id"), id).
+		Exec(ctx); err != nil {
+		return s.conn.ProcessError(err)
+	}
+
 	// Finally delete fave from DB.
 	_, err = s.conn.NewDelete().
 		Table("status_faves").
@@ -0,0 +1,67 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type statusMuteDB struct {
+	conn *DBConn
+}
+
+func (s *statusMuteDB) IsStatusMutedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, db.Error) {
+	threadID := status.ThreadID
+	if threadID == "" {
+		// Not stored yet, so
+		// in its own thread.
+		threadID = status.ID
+	}
+
+	q := s.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_mutes"), bun.Ident("status_mute")).
+		Where("? = ?", bun.Ident("status_mute.thread_id"), threadID).
+		Where("? = ?", bun.Ident("status_mute.account_id"), accountID)
+
+	return s.conn.Exists(ctx, q)
+}
+
+func (s *statusMuteDB) PutStatusMute(ctx context.Context, statusMute *gtsmodel.StatusMute) db.Error {
+	_, err := s.conn.
+		NewInsert().
+		Model(statusMute).
+		Exec(ctx)
+
+	return s.conn.ProcessError(err)
+}
+
+func (s *statusMuteDB) DeleteThreadMutes(ctx context.Context, threadID string, accountID string) db.Error {
+	_, err := s.conn.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("status_mutes"), bun.Ident("status_mute")).
+		Where("? = ?", bun.Ident("status_mute.thread_id"), threadID).
+		Where("? = ?", bun.Ident("status_mute.account_id"), accountID).
+		Exec(ctx)
+
+	return s.conn.ProcessError(err)
+}
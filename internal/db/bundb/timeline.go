@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -50,6 +51,7 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 	)
 
 	q := t.conn.
+		Read().
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
 		// Select only IDs from table
@@ -104,6 +106,7 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 	// Subquery to select target (followed) account
 	// IDs from follows owned by given accountID.
 	subQ := t.conn.
+		Read().
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
 		Column("follow.target_account_id").
@@ -161,6 +164,7 @@ func (t *timelineDB) GetPublicTimeline(ctx context.Context, maxID string, sinceI
 	statusIDs := make([]string, 0, limit)
 
 	q := t.conn.
+		Read().
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
 		Column("status.id").
@@ -222,6 +226,78 @@ func (t *timelineDB) GetPublicTimeline(ctx context.Context, maxID string, sinceI
 	return statuses, nil
 }
 
+func (t *timelineDB) GetEventsTimeline(ctx context.Context, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, db.Error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	statusIDs := make([]string, 0, limit)
+
+	q := t.conn.
+		Read().
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Column("status.id").
+		// Events only.
+		Where("? = ?", bun.Ident("status.activity_streams_type"), ap.ObjectEvent).
+		// Public only.
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Order("status.id DESC")
+
+	if maxID == "" {
+		const future = 24 * time.Hour
+
+		var err error
+
+		// don't return statuses more than 24hr in the future
+		maxID, err = id.NewULIDFromTime(time.Now().Add(future))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// return only statuses LOWER (ie., older) than maxID
+	q = q.Where("? < ?", bun.Ident("status.id"), maxID)
+
+	if sinceID != "" {
+		q = q.Where("? > ?", bun.Ident("status.id"), sinceID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("status.id"), minID)
+	}
+
+	if local {
+		q = q.Where("? = ?", bun.Ident("status.local"), local)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+
+	statuses := make([]*gtsmodel.Status, 0, len(statusIDs))
+
+	for _, id := range statusIDs {
+		// Fetch status from db for ID
+		status, err := t.state.DB.GetStatusByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error fetching status %q: %v", id, err)
+			continue
+		}
+
+		// Append status to slice
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
 // TODO optimize this query and the logic here, because it's slow as balls -- it takes like a literal second to return with a limit of 20!
 // It might be worth serving it through a timeline instead of raw DB queries, like we do for Home feeds.
 func (t *timelineDB) GetFavedTimeline(ctx context.Context, accountID string, maxID string, minID string, limit int) ([]*gtsmodel.Status, string, string, db.Error) {
@@ -234,6 +310,7 @@ func (t *timelineDB) GetFavedTimeline(ctx context.Context, accountID string, max
 	faves := make([]*gtsmodel.StatusFave, 0, limit)
 
 	fq := t.conn.
+		Read().
 		NewSelect().
 		Model(&faves).
 		Where("? = ?", bun.Ident("status_fave.account_id"), accountID).
@@ -323,6 +400,7 @@ func (t *timelineDB) GetListTimeline(
 
 	// Select target account IDs from follows.
 	subQ := t.conn.
+		Read().
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
 		Column("follow.target_account_id").
@@ -331,6 +409,7 @@ func (t *timelineDB) GetListTimeline(
 	// Select only status IDs created
 	// by one of the followed accounts.
 	q := t.conn.
+		Read().
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
 		// Select only IDs from table
@@ -25,8 +25,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/schema"
 )
 
 type userDB struct {
@@ -138,6 +141,97 @@ func (u *userDB) GetAllUsers(ctx context.Context) ([]*gtsmodel.User, db.Error) {
 	return users, nil
 }
 
+func (u *userDB) GetRegistrations(ctx context.Context, status gtsmodel.RegistrationStatus, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.User, db.Error) {
+	accountIDs := []string{}
+
+	q := u.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		Column("user.account_id").
+		Order("user.account_id DESC")
+
+	switch status {
+	case gtsmodel.RegistrationStatusPending:
+		q = q.
+			Where("? = ?", bun.Ident("user.approved"), false).
+			Where("? IS NULL", bun.Ident("user.rejected_at"))
+	case gtsmodel.RegistrationStatusApproved:
+		q = q.Where("? = ?", bun.Ident("user.approved"), true)
+	case gtsmodel.RegistrationStatusRejected:
+		q = q.Where("? IS NOT NULL", bun.Ident("user.rejected_at"))
+	}
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("user.account_id"), maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("? > ?", bun.Ident("user.account_id"), sinceID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("user.account_id"), minID)
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &accountIDs); err != nil {
+		return nil, u.conn.ProcessError(err)
+	}
+
+	// Catch case of no users early
+	if len(accountIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	users := make([]*gtsmodel.User, 0, len(accountIDs))
+	for _, accountID := range accountIDs {
+		user, err := u.GetUserByAccountID(ctx, accountID)
+		if err != nil {
+			log.Errorf(ctx, "error getting user for account %q: %v", accountID, err)
+			continue
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func (u *userDB) GetUsersDeleteDue(ctx context.Context, until time.Time) ([]*gtsmodel.User, db.Error) {
+	userIDs := []string{}
+
+	if err := u.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		Column("user.id").
+		Where("? <= ?", bun.Ident("user.delete_at"), until).
+		Order("user.delete_at ASC").
+		Scan(ctx, &userIDs); err != nil {
+		return nil, u.conn.ProcessError(err)
+	}
+
+	// Catch case of no users early
+	if len(userIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	users := make([]*gtsmodel.User, 0, len(userIDs))
+	for _, id := range userIDs {
+		user, err := u.GetUserByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting user %q: %v", id, err)
+			continue
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
 func (u *userDB) PutUser(ctx context.Context, user *gtsmodel.User) db.Error {
 	return u.state.Caches.GTS.User().Store(user, func() error {
 		_, err := u.conn.
@@ -190,3 +284,134 @@ func (u *userDB) DeleteUserByID(ctx context.Context, userID string) db.Error {
 		Exec(ctx)
 	return u.conn.ProcessError(err)
 }
+
+func (u *userDB) CountUserCohorts(ctx context.Context, since time.Time) ([]*db.UserCohortCount, error) {
+	counts := []*db.UserCohortCount{}
+
+	if err := u.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		ColumnExpr("? AS ?", u.monthExpr("user.created_at"), bun.Ident("cohort")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("users")).
+		Where("? >= ?", bun.Ident("user.created_at"), since).
+		Where("? = ?", bun.Ident("user.approved"), true).
+		GroupExpr("?", u.monthExpr("user.created_at")).
+		Scan(ctx, &counts); err != nil {
+		return nil, u.conn.ProcessError(err)
+	}
+
+	return counts, nil
+}
+
+func (u *userDB) CountUserCohortActivity(ctx context.Context, since time.Time) ([]*db.UserCohortCount, error) {
+	type userMonths struct {
+		UserID        string
+		Cohort        string
+		StatusMonth   string
+		CurrentSignIn string
+		LastSignIn    string
+	}
+
+	// Get the distinct months in which each user posted statuses.
+	posted := []*userMonths{}
+	if err := u.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.account_id"), bun.Ident("user.account_id"),
+		).
+		ColumnExpr("? AS ?", bun.Ident("user.id"), bun.Ident("user_id")).
+		ColumnExpr("? AS ?", u.monthExpr("user.created_at"), bun.Ident("cohort")).
+		ColumnExpr("? AS ?", u.monthExpr("status.created_at"), bun.Ident("status_month")).
+		Where("? >= ?", bun.Ident("user.created_at"), since).
+		Where("? = ?", bun.Ident("user.approved"), true).
+		Where("? >= ?", bun.Ident("status.created_at"), since).
+		GroupExpr("?, ?, ?",
+			bun.Ident("user.id"),
+			u.monthExpr("user.created_at"),
+			u.monthExpr("status.created_at"),
+		).
+		Scan(ctx, &posted); err != nil {
+		return nil, u.conn.ProcessError(err)
+	}
+
+	// Get the months in which each user signed in. We only keep
+	// the two most recent sign-ins of each user, so older
+	// sign-ins can't be taken into account.
+	signedIn := []*userMonths{}
+	if err := u.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		ColumnExpr("? AS ?", bun.Ident("user.id"), bun.Ident("user_id")).
+		ColumnExpr("? AS ?", u.monthExpr("user.created_at"), bun.Ident("cohort")).
+		ColumnExpr("COALESCE(?, '') AS ?", u.monthExpr("user.current_sign_in_at"), bun.Ident("current_sign_in")).
+		ColumnExpr("COALESCE(?, '') AS ?", u.monthExpr("user.last_sign_in_at"), bun.Ident("last_sign_in")).
+		Where("? >= ?", bun.Ident("user.created_at"), since).
+		Where("? = ?", bun.Ident("user.approved"), true).
+		Scan(ctx, &signedIn); err != nil {
+		return nil, u.conn.ProcessError(err)
+	}
+
+	// Count each user at most once per month,
+	// however many ways they were active in it.
+	type cohortMonth struct {
+		cohort string
+		month  string
+	}
+
+	var (
+		seen   = make(map[string]struct{})
+		users  = make(map[cohortMonth]int)
+		counts = []*db.UserCohortCount{}
+	)
+
+	addMonth := func(userID string, cohort string, month string) {
+		if month == "" || month < cohort {
+			// Inactive, or active before
+			// signing up (clock skew?).
+			return
+		}
+
+		key := userID + "/" + month
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+
+		cm := cohortMonth{cohort: cohort, month: month}
+		if _, ok := users[cm]; !ok {
+			counts = append(counts, &db.UserCohortCount{Cohort: cohort, Month: month})
+		}
+		users[cm]++
+	}
+
+	for _, m := range posted {
+		addMonth(m.UserID, m.Cohort, m.StatusMonth)
+	}
+
+	for _, m := range signedIn {
+		addMonth(m.UserID, m.Cohort, m.CurrentSignIn)
+		addMonth(m.UserID, m.Cohort, m.LastSignIn)
+	}
+
+	for _, count := range counts {
+		count.Users = users[cohortMonth{cohort: count.Cohort, month: count.Month}]
+	}
+
+	return counts, nil
+}
+
+// monthExpr returns an expression formatting the
+// given timestamp column as YYYY-MM in UTC.
+func (u *userDB) monthExpr(column string) schema.QueryWithArgs {
+	switch u.conn.Dialect().Name() {
+	case dialect.SQLite:
+		return schema.SafeQuery("strftime('%Y-%m', ?)", []interface{}{bun.Ident(column)})
+	case dialect.PG:
+		return schema.SafeQuery("to_char(? AT TIME ZONE 'UTC', 'YYYY-MM')", []interface{}{bun.Ident(column)})
+	default:
+		panic("db conn was neither pg not sqlite")
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
@@ -35,6 +36,27 @@ func (suite *UserTestSuite) TestGetAllUsers() {
 	suite.Len(users, len(suite.testUsers))
 }
 
+func (suite *UserTestSuite) TestGetRegistrations() {
+	ctx := context.Background()
+
+	users, err := suite.db.GetRegistrations(ctx, "", "", "", "", 0)
+	suite.NoError(err)
+	suite.Len(users, len(suite.testUsers))
+
+	users, err = suite.db.GetRegistrations(ctx, gtsmodel.RegistrationStatusPending, "", "", "", 0)
+	suite.NoError(err)
+	suite.Len(users, 1)
+	suite.Equal(suite.testUsers["unconfirmed_account"].ID, users[0].ID)
+
+	users, err = suite.db.GetRegistrations(ctx, gtsmodel.RegistrationStatusApproved, "", "", "", 2)
+	suite.NoError(err)
+	suite.Len(users, 2)
+	suite.Greater(users[0].AccountID, users[1].AccountID)
+
+	_, err = suite.db.GetRegistrations(ctx, gtsmodel.RegistrationStatusRejected, "", "", "", 0)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func (suite *UserTestSuite) TestGetUser() {
 	user, err := suite.db.GetUserByID(context.Background(), suite.testUsers["local_account_1"].ID)
 	suite.NoError(err)
@@ -0,0 +1,110 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type webAuthnDB struct {
+	conn *DBConn
+}
+
+func (w *webAuthnDB) getWebAuthnCredential(ctx context.Context, column string, value any) (*gtsmodel.WebAuthnCredential, db.Error) {
+	credential := &gtsmodel.WebAuthnCredential{}
+
+	if err := w.conn.
+		NewSelect().
+		Model(credential).
+		Where("? = ?", bun.Ident("web_authn_credential."+column), value).
+		Scan(ctx); err != nil {
+		return nil, w.conn.ProcessError(err)
+	}
+
+	return credential, nil
+}
+
+func (w *webAuthnDB) GetWebAuthnCredentialByID(ctx context.Context, id string) (*gtsmodel.WebAuthnCredential, db.Error) {
+	return w.getWebAuthnCredential(ctx, "id", id)
+}
+
+func (w *webAuthnDB) GetWebAuthnCredentialByCredentialID(ctx context.Context, credentialID string) (*gtsmodel.WebAuthnCredential, db.Error) {
+	return w.getWebAuthnCredential(ctx, "credential_id", credentialID)
+}
+
+func (w *webAuthnDB) GetWebAuthnCredentialsByUserID(ctx context.Context, userID string) ([]*gtsmodel.WebAuthnCredential, db.Error) {
+	credentials := []*gtsmodel.WebAuthnCredential{}
+
+	if err := w.conn.
+		NewSelect().
+		Model(&credentials).
+		Where("? = ?", bun.Ident("web_authn_credential.user_id"), userID).
+		Order("web_authn_credential.id ASC").
+		Scan(ctx); err != nil {
+		return nil, w.conn.ProcessError(err)
+	}
+
+	return credentials, nil
+}
+
+func (w *webAuthnDB) PutWebAuthnCredential(ctx context.Context, credential *gtsmodel.WebAuthnCredential) db.Error {
+	_, err := w.conn.
+		NewInsert().
+		Model(credential).
+		Exec(ctx)
+	return w.conn.ProcessError(err)
+}
+
+func (w *webAuthnDB) UpdateWebAuthnCredential(ctx context.Context, credential *gtsmodel.WebAuthnCredential, columns ...string) db.Error {
+	credential.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := w.conn.
+		NewUpdate().
+		Model(credential).
+		Where("? = ?", bun.Ident("web_authn_credential.id"), credential.ID).
+		Column(columns...).
+		Exec(ctx)
+	return w.conn.ProcessError(err)
+}
+
+func (w *webAuthnDB) DeleteWebAuthnCredentialByID(ctx context.Context, id string) db.Error {
+	_, err := w.conn.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("web_authn_credentials"), bun.Ident("web_authn_credential")).
+		Where("? = ?", bun.Ident("web_authn_credential.id"), id).
+		Exec(ctx)
+	return w.conn.ProcessError(err)
+}
+
+func (w *webAuthnDB) DeleteWebAuthnCredentialsByUserID(ctx context.Context, userID string) db.Error {
+	_, err := w.conn.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("web_authn_credentials"), bun.Ident("web_authn_credential")).
+		Where("? = ?", bun.Ident("web_authn_credential.user_id"), userID).
+		Exec(ctx)
+	return w.conn.ProcessError(err)
+}
@@ -0,0 +1,137 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type webSessionDB struct {
+	conn *DBConn
+}
+
+func (w *webSessionDB) GetWebSessionByID(ctx context.Context, id string) (*gtsmodel.WebSession, db.Error) {
+	session := &gtsmodel.WebSession{}
+
+	if err := w.conn.
+		NewSelect().
+		Model(session).
+		Where("? = ?", bun.Ident("web_session.id"), id).
+		Scan(ctx); err != nil {
+		return nil, w.conn.ProcessError(err)
+	}
+
+	return session, nil
+}
+
+func (w *webSessionDB) GetWebSessionsByUserID(ctx context.Context, userID string) ([]*gtsmodel.WebSession, db.Error) {
+	sessions := []*gtsmodel.WebSession{}
+
+	if err := w.conn.
+		NewSelect().
+		Model(&sessions).
+		Where("? = ?", bun.Ident("web_session.user_id"), userID).
+		Order("web_session.id DESC").
+		Scan(ctx); err != nil {
+		return nil, w.conn.ProcessError(err)
+	}
+
+	return sessions, nil
+}
+
+func (w *webSessionDB) GetWebSessionIDByAccessToken(ctx context.Context, access string) (string, db.Error) {
+	var sessionID string
+
+	if err := w.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("tokens"), bun.Ident("token")).
+		ColumnExpr("COALESCE(?, '')", bun.Ident("token.web_session_id")).
+		Where("? = ?", bun.Ident("token.access"), access).
+		Scan(ctx, &sessionID); err != nil {
+		return "", w.conn.ProcessError(err)
+	}
+
+	return sessionID, nil
+}
+
+func (w *webSessionDB) PutWebSession(ctx context.Context, session *gtsmodel.WebSession) db.Error {
+	_, err := w.conn.
+		NewInsert().
+		Model(session).
+		Exec(ctx)
+	return w.conn.ProcessError(err)
+}
+
+func (w *webSessionDB) UpdateWebSession(ctx context.Context, session *gtsmodel.WebSession, columns ...string) db.Error {
+	session.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := w.conn.
+		NewUpdate().
+		Model(session).
+		Where("? = ?", bun.Ident("web_session.id"), session.ID).
+		Column(columns...).
+		Exec(ctx)
+	return w.conn.ProcessError(err)
+}
+
+func (w *webSessionDB) DeleteWebSessionByID(ctx context.Context, id string) db.Error {
+	return w.deleteWebSessions(ctx, "id", id)
+}
+
+func (w *webSessionDB) DeleteWebSessionsByUserID(ctx context.Context, userID string) db.Error {
+	return w.deleteWebSessions(ctx, "user_id", userID)
+}
+
+// deleteWebSessions deletes web sessions where the given
+// column has the given value, along with their tokens.
+func (w *webSessionDB) deleteWebSessions(ctx context.Context, column string, value any) db.Error {
+	return w.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		// Select IDs of the sessions to delete.
+		sessionIDs := tx.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("web_sessions"), bun.Ident("web_session")).
+			Column("web_session.id").
+			Where("? = ?", bun.Ident("web_session."+column), value)
+
+		// Delete tokens created through these sessions.
+		if _, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("tokens"), bun.Ident("token")).
+			Where("? IN (?)", bun.Ident("token.web_session_id"), sessionIDs).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Delete the sessions themselves.
+		_, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("web_sessions"), bun.Ident("web_session")).
+			Where("? = ?", bun.Ident("web_session."+column), value).
+			Exec(ctx)
+		return err
+	})
+}
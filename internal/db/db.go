@@ -32,31 +32,47 @@ const (
 type DB interface {
 	Account
 	Admin
+	Backlog
 	Basic
 	Domain
+	Draft
+	EmailQueue
 	Emoji
+	FeaturedTag
+	HashtagSetting
 	Instance
+	Interaction
 	List
+	Listen
 	Media
 	Mention
 	Notification
+	Poll
+	PreviewCard
+	ProxiedImage
 	Relationship
 	Report
+	Rule
 	Search
 	Session
 	Status
 	StatusBookmark
+	StatusDislike
 	StatusFave
+	StatusMute
 	Timeline
 	User
 	Tombstone
+	WebAuthn
+	WebSession
 
 	/*
 		USEFUL CONVERSION FUNCTIONS
 	*/
 
-	// TagStringToTag takes a lowercase tag in the form "somehashtag", which has been
-	// used in a status. It takes the id of the account that wrote the status, and the id of the status itself, and then
+	// TagStringToTag takes a tag in the form "SomeHashtag", which has been
+	// used in a status, and matches it case-insensitively. New tags are given a
+	// lowercase name for matching, and keep the given form as their display name. It takes the id of the account that wrote the status, and the id of the status itself, and then
 	// returns an *apimodel.Tag corresponding to the given tags. If the tag already exists in database, that tag
 	// will be returned. Otherwise a pointer to a new tag struct will be created and returned.
 	//
@@ -32,10 +32,14 @@ type Domain interface {
 	// GetDomainBlock ...
 	GetDomainBlock(ctx context.Context, domain string) (*gtsmodel.DomainBlock, Error)
 
+	// UpdateDomainBlock updates the given domain block, setting the provided columns (empty for all).
+	UpdateDomainBlock(ctx context.Context, block *gtsmodel.DomainBlock, columns ...string) Error
+
 	// DeleteDomainBlock ...
 	DeleteDomainBlock(ctx context.Context, domain string) Error
 
 	// IsDomainBlocked checks if an instance-level domain block exists for the given domain string (eg., `example.org`).
+	// Only blocks with suspend severity are considered: silenced domains can still be federated with.
 	IsDomainBlocked(ctx context.Context, domain string) (bool, Error)
 
 	// AreDomainsBlocked checks if an instance-level domain block exists for any of the given domains strings, and returns true if even one is found.
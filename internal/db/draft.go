@@ -0,0 +1,51 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Draft handles getting/creation/deletion/updating of status drafts.
+type Draft interface {
+	// GetDraftByID gets one draft by its db id.
+	GetDraftByID(ctx context.Context, id string) (*gtsmodel.Draft, Error)
+	// GetAccountDrafts gets all drafts owned by the given account, newest first.
+	// An empty slice is returned if the account has no drafts.
+	GetAccountDrafts(ctx context.Context, accountID string) ([]*gtsmodel.Draft, Error)
+	// CountAccountDrafts counts the drafts owned by the given account.
+	CountAccountDrafts(ctx context.Context, accountID string) (int, Error)
+	// IsAttachmentInDraft returns true if the given attachment is
+	// referenced by any draft belonging to the given account.
+	IsAttachmentInDraft(ctx context.Context, accountID string, attachmentID string) (bool, Error)
+	// PutDraft puts the given draft in the database.
+	PutDraft(ctx context.Context, draft *gtsmodel.Draft) Error
+	// UpdateDraft updates one draft by its db id.
+	// The given columns will be updated; if no columns are
+	// provided, then all columns will be updated.
+	// updated_at will also be updated, no need to pass this
+	// as a specific column.
+	UpdateDraft(ctx context.Context, draft *gtsmodel.Draft, columns ...string) Error
+	// DeleteDraftByID deletes draft with the given id, returning
+	// ErrNoEntries if there was no such draft left to delete.
+	DeleteDraftByID(ctx context.Context, id string) Error
+	// DeleteAccountDrafts deletes all drafts owned by the given account.
+	DeleteAccountDrafts(ctx context.Context, accountID string) Error
+}
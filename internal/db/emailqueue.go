@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// EmailQueue handles getting/creation/deletion of queued emails.
+type EmailQueue interface {
+	// GetQueuedEmailByID gets one queued email with the given database ID.
+	GetQueuedEmailByID(ctx context.Context, id string) (*gtsmodel.QueuedEmail, Error)
+	// GetDueQueuedEmails gets up to limit queued emails which haven't failed, and
+	// which are due to be sent at the given time, in order of next attempt ascending.
+	GetDueQueuedEmails(ctx context.Context, now time.Time, limit int) ([]*gtsmodel.QueuedEmail, Error)
+	// GetFailedQueuedEmails gets up to limit queued emails which
+	// could not be sent after retrying, most recently failed first.
+	GetFailedQueuedEmails(ctx context.Context, limit int) ([]*gtsmodel.QueuedEmail, Error)
+	// PutQueuedEmail puts the given queued email in the database.
+	PutQueuedEmail(ctx context.Context, email *gtsmodel.QueuedEmail) Error
+	// UpdateQueuedEmail updates the given queued email,
+	// updating either only the specified columns, or all of them.
+	UpdateQueuedEmail(ctx context.Context, email *gtsmodel.QueuedEmail, columns ...string) Error
+	// DeleteQueuedEmailByID deletes one queued email with the given database ID.
+	DeleteQueuedEmailByID(ctx context.Context, id string) Error
+}
@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -38,8 +39,19 @@ type Emoji interface {
 	DeleteEmojiByID(ctx context.Context, id string) Error
 	// GetEmojisByIDs gets emojis for the given IDs.
 	GetEmojisByIDs(ctx context.Context, ids []string) ([]*gtsmodel.Emoji, Error)
-	// GetUseableEmojis gets all emojis which are useable by accounts on this instance.
+	// GetUseableEmojis gets all emojis which are useable by accounts on this instance,
+	// ordered by the position of their category, and then by shortcode. Personal emojis
+	// owned by individual accounts are not included.
 	GetUseableEmojis(ctx context.Context) ([]*gtsmodel.Emoji, Error)
+	// GetEmojisByAccountID gets all personal emojis owned by the given account, ordered by shortcode.
+	GetEmojisByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.Emoji, error)
+	// CountEmojisByAccountID counts the personal emojis owned by the given account.
+	CountEmojisByAccountID(ctx context.Context, accountID string) (int, error)
+	// GetLocalEmojisUpdatedSince gets all local instance emojis, useable or not, that were created or updated after the given time.
+	GetLocalEmojisUpdatedSince(ctx context.Context, since time.Time) ([]*gtsmodel.Emoji, error)
+	// GetLocalEmojisLastModified returns the last time that a local emoji or
+	// emoji category was created, updated, or deleted, or the zero time if never.
+	GetLocalEmojisLastModified(ctx context.Context) (time.Time, error)
 	// GetEmojis ...
 	GetEmojis(ctx context.Context, maxID string, limit int) ([]*gtsmodel.Emoji, error)
 	// GetEmojisBy gets emojis based on given parameters. Useful for admin actions.
@@ -63,4 +75,12 @@ type Emoji interface {
 	GetEmojiCategory(ctx context.Context, id string) (*gtsmodel.EmojiCategory, Error)
 	// GetEmojiCategoryByName gets one emoji category by its name.
 	GetEmojiCategoryByName(ctx context.Context, name string) (*gtsmodel.EmojiCategory, Error)
+	// UpdateEmojiCategory updates the given columns of one emoji category.
+	// If no columns are specified, every column is updated.
+	UpdateEmojiCategory(ctx context.Context, emojiCategory *gtsmodel.EmojiCategory, columns ...string) error
+	// GetEmojiTombstonesSince gets tombstones of local emojis deleted after the given time.
+	GetEmojiTombstonesSince(ctx context.Context, since time.Time) ([]*gtsmodel.EmojiTombstone, error)
+	// DeleteEmojiTombstonesOlderThan deletes tombstones of local emojis deleted before
+	// the given time, returning the number of tombstones deleted.
+	DeleteEmojiTombstonesOlderThan(ctx context.Context, olderThan time.Time) (int, error)
 }
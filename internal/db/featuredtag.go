@@ -0,0 +1,41 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// FeaturedTag handles getting/creation/deletion of hashtags featured on account profiles.
+type FeaturedTag interface {
+	// GetAccountFeaturedTags gets all tags featured by the
+	// given account, in the order they were featured.
+	GetAccountFeaturedTags(ctx context.Context, accountID string) ([]*gtsmodel.FeaturedTag, Error)
+
+	// PutFeaturedTag inserts the given featured tag into the database.
+	PutFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) Error
+
+	// DeleteFeaturedTagByID deletes one featured tag with the given id.
+	DeleteFeaturedTagByID(ctx context.Context, id string) Error
+
+	// DeleteAccountFeaturedTags deletes all tags featured by the given account.
+	// This is useful when an account has been deleted, and you need to clean up after it.
+	DeleteAccountFeaturedTags(ctx context.Context, accountID string) Error
+}
@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// HashtagSetting handles getting/creation/updating of admin hashtag settings.
+type HashtagSetting interface {
+	// GetHashtagSettingByName gets the settings for the hashtag with the given name, if any.
+	GetHashtagSettingByName(ctx context.Context, name string) (*gtsmodel.HashtagSetting, Error)
+	// GetHashtagSettings gets all hashtag settings, in order of hashtag name ascending.
+	// An empty slice is returned if there are no settings.
+	GetHashtagSettings(ctx context.Context) ([]*gtsmodel.HashtagSetting, Error)
+	// GetContentWarningRequiredHashtags returns those of the given hashtag names
+	// which require a content warning. Names are compared case-insensitively.
+	GetContentWarningRequiredHashtags(ctx context.Context, names []string) ([]string, Error)
+	// PutHashtagSetting puts the given hashtag setting in the database.
+	PutHashtagSetting(ctx context.Context, setting *gtsmodel.HashtagSetting) Error
+	// UpdateHashtagSetting updates one hashtag setting by its db id.
+	// The given columns will be updated; if no columns are
+	// provided, then all columns will be updated.
+	// updated_at will also be updated, no need to pass this
+	// as a specific column.
+	UpdateHashtagSetting(ctx context.Context, setting *gtsmodel.HashtagSetting, columns ...string) Error
+}
@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -34,6 +35,11 @@ type Instance interface {
 	// CountInstanceDomains returns the number of known instances known that the given domain federates with.
 	CountInstanceDomains(ctx context.Context, domain string) (int, Error)
 
+	// CountInstanceActivity returns the number of statuses posted by local accounts, the
+	// number of local users who signed in, and the number of local users who signed up,
+	// between since (inclusive) and until (exclusive).
+	CountInstanceActivity(ctx context.Context, since time.Time, until time.Time) (statuses int, logins int, registrations int, err Error)
+
 	// GetInstance returns the instance entry for the given domain, if it exists.
 	GetInstance(ctx context.Context, domain string) (*gtsmodel.Instance, Error)
 
@@ -0,0 +1,48 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Interaction handles getting/creation/updating of interaction requests.
+type Interaction interface {
+	// GetInteractionRequestByID gets one interaction request by its db id.
+	GetInteractionRequestByID(ctx context.Context, id string) (*gtsmodel.InteractionRequest, Error)
+	// GetInteractionRequestByInteractionURI gets one interaction
+	// request by the activitypub uri of the fave, reply or boost.
+	GetInteractionRequestByInteractionURI(ctx context.Context, uri string) (*gtsmodel.InteractionRequest, Error)
+	// GetInteractionRequestByURI gets one interaction request
+	// by the activitypub uri of its Accept or Reject.
+	GetInteractionRequestByURI(ctx context.Context, uri string) (*gtsmodel.InteractionRequest, Error)
+	// GetPendingInteractionRequests gets limit n pending interaction
+	// requests targeting the given account, optionally only those for
+	// the given statusID, and only of the given interaction types.
+	GetPendingInteractionRequests(ctx context.Context, targetAccountID string, statusID string, types []gtsmodel.InteractionType, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.InteractionRequest, Error)
+	// PutInteractionRequest puts the given interaction request in the database.
+	PutInteractionRequest(ctx context.Context, req *gtsmodel.InteractionRequest) Error
+	// UpdateInteractionRequest updates one interaction request by its db id.
+	// The given columns will be updated; if no columns are
+	// provided, then all columns will be updated.
+	// updated_at will also be updated, no need to pass this
+	// as a specific column.
+	UpdateInteractionRequest(ctx context.Context, req *gtsmodel.InteractionRequest, columns ...string) Error
+}
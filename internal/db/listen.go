@@ -0,0 +1,36 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Listen handles getting/creation/deletion of music listens.
+type Listen interface {
+	// GetAccountListen gets the most recent listen of the given account.
+	GetAccountListen(ctx context.Context, accountID string) (*gtsmodel.Listen, Error)
+	// PutListen puts the given listen in the database, replacing
+	// any older listens of the same account, since only the most
+	// recent listen of each account is kept.
+	PutListen(ctx context.Context, listen *gtsmodel.Listen) Error
+	// DeleteAccountListens deletes all listens of the given account.
+	DeleteAccountListens(ctx context.Context, accountID string) Error
+}
@@ -44,6 +44,20 @@ type Media interface {
 	// GetAttachments ...
 	GetAttachments(ctx context.Context, maxID string, limit int) ([]*gtsmodel.MediaAttachment, error)
 
+	// CountAccountAttachments returns the number of cached media attachments owned by the given
+	// account, and the total size in bytes of their files and thumbnails currently in storage.
+	CountAccountAttachments(ctx context.Context, accountID string) (int, int64, error)
+
+	// GetMediaUsageByAccount returns the number and total size in bytes of cached media attachments
+	// owned by each account, keyed by account ID, for the limit n accounts using the most storage.
+	// These will be returned in order of size descending.
+	GetMediaUsageByAccount(ctx context.Context, limit int) ([]*MediaUsage, error)
+
+	// GetMediaUsageByDomain is like GetMediaUsageByAccount, except media attachments are totalled
+	// up across all accounts on each domain, along with emojis from that domain. Results are keyed
+	// by domain, with an empty domain for local media.
+	GetMediaUsageByDomain(ctx context.Context, limit int) ([]*MediaUsage, error)
+
 	// GetRemoteOlderThan gets limit n remote media attachments (including avatars and headers) older than the given
 	// olderThan time. These will be returned in order of attachment.created_at descending (newest to oldest in other words).
 	//
@@ -71,3 +85,12 @@ type Media interface {
 	// it just counts how many local attachments in the database meet the olderThan criteria.
 	CountLocalUnattachedOlderThan(ctx context.Context, olderThan time.Time) (int, Error)
 }
+
+// MediaUsage is the number and total size
+// in bytes of stored media in one grouping
+// (eg., account or domain), given by Key.
+type MediaUsage struct {
+	Key   string
+	Count int
+	Size  int64
+}
@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Poll handles getting/creation/deletion/updating of polls and votes.
+type Poll interface {
+	// GetPollByID gets one poll by its db id.
+	GetPollByID(ctx context.Context, id string) (*gtsmodel.Poll, Error)
+	// PutPoll puts the given poll in the database.
+	PutPoll(ctx context.Context, poll *gtsmodel.Poll) Error
+	// UpdatePoll updates one poll by its db id.
+	// The given columns will be updated; if no columns are
+	// provided, then all columns will be updated.
+	// updated_at will also be updated, no need to pass this
+	// as a specific column.
+	UpdatePoll(ctx context.Context, poll *gtsmodel.Poll, columns ...string) Error
+	// GetPollVoteBy gets the vote of the given account in the given poll.
+	GetPollVoteBy(ctx context.Context, pollID string, accountID string) (*gtsmodel.PollVote, Error)
+	// PutPollVote puts the given vote in the database, and adds
+	// its choices to the vote counts of the poll it belongs to.
+	// The updated poll is set on the vote.
+	PutPollVote(ctx context.Context, vote *gtsmodel.PollVote) Error
+}
@@ -0,0 +1,40 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// PreviewCard handles getting/creation/updating of link preview cards.
+type PreviewCard interface {
+	// GetPreviewCardByID gets one preview card by its db id.
+	GetPreviewCardByID(ctx context.Context, id string) (*gtsmodel.PreviewCard, Error)
+	// GetPreviewCardByURL gets one preview card by the URL of the page it previews.
+	GetPreviewCardByURL(ctx context.Context, url string) (*gtsmodel.PreviewCard, Error)
+	// PutPreviewCard puts the given preview card in the database.
+	PutPreviewCard(ctx context.Context, card *gtsmodel.PreviewCard) Error
+	// UpdatePreviewCard updates one preview card by its db id.
+	// The given columns will be updated; if no columns are
+	// provided, then all columns will be updated.
+	// updated_at will also be updated, no need to pass this
+	// as a specific column.
+	UpdatePreviewCard(ctx context.Context, card *gtsmodel.PreviewCard, columns ...string) Error
+}
@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// ProxiedImage handles getting/creation/deletion/updating of images cached by the image proxy.
+type ProxiedImage interface {
+	// GetProxiedImageByURL gets one proxied image by the remote URL it was fetched from.
+	GetProxiedImageByURL(ctx context.Context, url string) (*gtsmodel.ProxiedImage, Error)
+	// GetProxiedImagesAccessedBefore gets limit n proxied images which were
+	// last accessed before the given time, in order of accessed_at ascending.
+	GetProxiedImagesAccessedBefore(ctx context.Context, before time.Time, limit int) ([]*gtsmodel.ProxiedImage, Error)
+	// PutProxiedImage puts the given proxied image in the database.
+	PutProxiedImage(ctx context.Context, image *gtsmodel.ProxiedImage) Error
+	// UpdateProxiedImage updates one proxied image by its db id.
+	// The given columns will be updated; if no columns are
+	// provided, then all columns will be updated.
+	// updated_at will also be updated, no need to pass this
+	// as a specific column.
+	UpdateProxiedImage(ctx context.Context, image *gtsmodel.ProxiedImage, columns ...string) Error
+	// DeleteProxiedImageByID deletes proxied image with the given id.
+	DeleteProxiedImageByID(ctx context.Context, id string) Error
+}
@@ -127,6 +127,10 @@ type Relationship interface {
 	// GetAccountFollows returns a slice of follows owned by the given accountID.
 	GetAccountFollows(ctx context.Context, accountID string) ([]*gtsmodel.Follow, error)
 
+	// GetAccountFollowsNotBlocked returns a slice of follows owned by the given accountID, excluding follows
+	// of accounts that are blocking, or blocked by, the given requestingAccountID.
+	GetAccountFollowsNotBlocked(ctx context.Context, accountID string, requestingAccountID string) ([]*gtsmodel.Follow, error)
+
 	// GetAccountLocalFollows returns a slice of follows owned by the given accountID, only including follows from this instance.
 	GetAccountLocalFollows(ctx context.Context, accountID string) ([]*gtsmodel.Follow, error)
 
@@ -139,6 +143,14 @@ type Relationship interface {
 	// GetAccountFollowers fetches follows that target given accountID.
 	GetAccountFollowers(ctx context.Context, accountID string) ([]*gtsmodel.Follow, error)
 
+	// GetAccountFollowersNotBlocked fetches follows that target given accountID, excluding follows
+	// from accounts that are blocking, or blocked by, the given requestingAccountID.
+	GetAccountFollowersNotBlocked(ctx context.Context, accountID string, requestingAccountID string) ([]*gtsmodel.Follow, error)
+
+	// GetAccountMutualFollowers fetches follows that target given accountID, only including
+	// follows from accounts that the given accountID also follows back (i.e. mutuals).
+	GetAccountMutualFollowers(ctx context.Context, accountID string) ([]*gtsmodel.Follow, error)
+
 	// GetAccountLocalFollowers fetches follows that target given accountID, only including follows from this instance.
 	GetAccountLocalFollowers(ctx context.Context, accountID string) ([]*gtsmodel.Follow, error)
 
@@ -37,6 +37,9 @@ type Status interface {
 	// GetStatusByURL returns one status from the database, with no rel fields populated, only their linking ID / URIs
 	GetStatusByURL(ctx context.Context, uri string) (*gtsmodel.Status, Error)
 
+	// GetStatusByAlias returns one local status from the database with the given short alias, with no rel fields populated, only their linking ID / URIs
+	GetStatusByAlias(ctx context.Context, alias string) (*gtsmodel.Status, Error)
+
 	// PopulateStatus ensures that all sub-models of a status are populated (e.g. mentions, attachments, etc).
 	PopulateStatus(ctx context.Context, status *gtsmodel.Status) error
 
@@ -74,12 +77,12 @@ type Status interface {
 	// IsStatusRebloggedBy checks if a given status has been reblogged/boosted by a given account ID
 	IsStatusRebloggedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, Error)
 
-	// IsStatusMutedBy checks if a given status has been muted by a given account ID
-	IsStatusMutedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, Error)
-
 	// IsStatusBookmarkedBy checks if a given status has been bookmarked by a given account ID
 	IsStatusBookmarkedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, Error)
 
+	// GetStatusIDsInThread returns the IDs of all statuses with the given thread ID.
+	GetStatusIDsInThread(ctx context.Context, threadID string) ([]string, Error)
+
 	// GetStatusReblogs returns a slice of statuses that are a boost/reblog of the given status.
 	// This slice will be unfiltered, not taking account of blocks and whatnot, so filter it before serving it back to a user.
 	GetStatusReblogs(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.Status, Error)
@@ -0,0 +1,53 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// StatusDislike handles getting/creation/deletion of status dislikes.
+type StatusDislike interface {
+	// GetStatusDislike gets one status dislike created by
+	// the given accountID, targeting the given statusID.
+	GetStatusDislike(ctx context.Context, accountID string, statusID string) (*gtsmodel.StatusDislike, Error)
+
+	// GetStatusDislikeByURI gets one status dislike with the given ActivityPub URI.
+	GetStatusDislikeByURI(ctx context.Context, uri string) (*gtsmodel.StatusDislike, Error)
+
+	// CountStatusDislikes returns the number of dislikes of the given status.
+	CountStatusDislikes(ctx context.Context, statusID string) (int, Error)
+
+	// PutStatusDislike inserts the given status dislike into the database.
+	PutStatusDislike(ctx context.Context, dislike *gtsmodel.StatusDislike) Error
+
+	// DeleteStatusDislikeByID deletes one status dislike with the given id.
+	DeleteStatusDislikeByID(ctx context.Context, id string) Error
+
+	// DeleteStatusDislikes deletes all status dislikes created by,
+	// or targeting, the given account. This is useful when an
+	// account has been deleted, and you need to clean up after it.
+	DeleteStatusDislikes(ctx context.Context, accountID string) Error
+
+	// DeleteStatusDislikesForStatus deletes all status dislikes that target the
+	// given status ID. This is useful when a status has been deleted, and you need
+	// to clean up after it.
+	DeleteStatusDislikesForStatus(ctx context.Context, statusID string) Error
+}
@@ -41,6 +41,13 @@ type StatusFave interface {
 	// PutStatusFave inserts the given statusFave into the database.
 	PutStatusFave(ctx context.Context, statusFave *gtsmodel.StatusFave) Error
 
+	// UpdateStatusFave updates one status fave by its db id.
+	// The given columns will be updated; if no columns are
+	// provided, then all columns will be updated.
+	// updated_at will also be updated, no need to pass this
+	// as a specific column.
+	UpdateStatusFave(ctx context.Context, statusFave *gtsmodel.StatusFave, columns ...string) Error
+
 	// DeleteStatusFave deletes one status fave with the given id.
 	DeleteStatusFaveByID(ctx context.Context, id string) Error
 
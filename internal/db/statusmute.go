@@ -0,0 +1,37 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type StatusMute interface {
+	// IsStatusMutedBy checks if the thread containing the given status
+	// has been muted by the given account ID.
+	IsStatusMutedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, Error)
+
+	// PutStatusMute inserts the given statusMute into the database.
+	PutStatusMute(ctx context.Context, statusMute *gtsmodel.StatusMute) Error
+
+	// DeleteThreadMutes deletes all status mutes created by
+	// the given accountID, targeting the given threadID.
+	DeleteThreadMutes(ctx context.Context, threadID string, accountID string) Error
+}
@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -27,6 +28,12 @@ import (
 type User interface {
 	// GetAllUsers returns all local user accounts, or an error if something goes wrong.
 	GetAllUsers(ctx context.Context) ([]*gtsmodel.User, Error)
+	// GetUsersDeleteDue returns local users whose accounts were
+	// scheduled for deletion at or before the given time.
+	GetUsersDeleteDue(ctx context.Context, until time.Time) ([]*gtsmodel.User, Error)
+	// GetRegistrations returns local users whose sign-ups have the given registration status,
+	// or all local users if status is empty, paged by the IDs of their accounts, newest first.
+	GetRegistrations(ctx context.Context, status gtsmodel.RegistrationStatus, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.User, Error)
 	// GetUserByID returns one user with the given ID, or an error if something goes wrong.
 	GetUserByID(ctx context.Context, id string) (*gtsmodel.User, Error)
 	// GetUserByAccountID returns one user by its account ID, or an error if something goes wrong.
@@ -43,4 +50,23 @@ type User interface {
 	UpdateUser(ctx context.Context, user *gtsmodel.User, columns ...string) Error
 	// DeleteUserByID deletes one user by its ID.
 	DeleteUserByID(ctx context.Context, userID string) Error
+	// CountUserCohorts returns the number of approved users who signed up since the
+	// given time, grouped into cohorts by the month (UTC) in which they signed up.
+	// Month is not set on the returned counts.
+	CountUserCohorts(ctx context.Context, since time.Time) ([]*UserCohortCount, error)
+	// CountUserCohortActivity returns the number of approved users who signed up since
+	// the given time and were active in each month (UTC) since they signed up, grouped
+	// into cohorts by the month in which they signed up. A user is active in a month if
+	// they posted a status, or signed in, during that month.
+	CountUserCohortActivity(ctx context.Context, since time.Time) ([]*UserCohortCount, error)
+}
+
+// UserCohortCount is the number of users who
+// signed up in the month given by Cohort, and
+// optionally were active in the month given by
+// Month. Months are in the form YYYY-MM.
+type UserCohortCount struct {
+	Cohort string
+	Month  string
+	Users  int
 }
@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// WebAuthn handles getting/creation/deletion of WebAuthn credentials.
+type WebAuthn interface {
+	// GetWebAuthnCredentialByID gets one WebAuthn credential with the given database ID.
+	GetWebAuthnCredentialByID(ctx context.Context, id string) (*gtsmodel.WebAuthnCredential, Error)
+	// GetWebAuthnCredentialByCredentialID gets one WebAuthn credential
+	// with the given base64url encoded authenticator credential ID.
+	GetWebAuthnCredentialByCredentialID(ctx context.Context, credentialID string) (*gtsmodel.WebAuthnCredential, Error)
+	// GetWebAuthnCredentialsByUserID gets all WebAuthn credentials of the given user, oldest first.
+	GetWebAuthnCredentialsByUserID(ctx context.Context, userID string) ([]*gtsmodel.WebAuthnCredential, Error)
+	// PutWebAuthnCredential puts the given WebAuthn credential in the database.
+	PutWebAuthnCredential(ctx context.Context, credential *gtsmodel.WebAuthnCredential) Error
+	// UpdateWebAuthnCredential updates the given WebAuthn credential,
+	// updating either only the specified columns, or all of them.
+	UpdateWebAuthnCredential(ctx context.Context, credential *gtsmodel.WebAuthnCredential, columns ...string) Error
+	// DeleteWebAuthnCredentialByID deletes one WebAuthn credential with the given database ID.
+	DeleteWebAuthnCredentialByID(ctx context.Context, id string) Error
+	// DeleteWebAuthnCredentialsByUserID deletes all WebAuthn credentials of the given user.
+	DeleteWebAuthnCredentialsByUserID(ctx context.Context, userID string) Error
+}
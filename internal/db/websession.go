@@ -0,0 +1,46 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// WebSession handles getting/creation/deletion of web sessions.
+type WebSession interface {
+	// GetWebSessionByID gets one web session with the given database ID.
+	GetWebSessionByID(ctx context.Context, id string) (*gtsmodel.WebSession, Error)
+	// GetWebSessionsByUserID gets all web sessions of the given user, newest first.
+	GetWebSessionsByUserID(ctx context.Context, userID string) ([]*gtsmodel.WebSession, Error)
+	// GetWebSessionIDByAccessToken gets the ID of the web session through
+	// which the given access token was created, or "" if there is none.
+	GetWebSessionIDByAccessToken(ctx context.Context, access string) (string, Error)
+	// PutWebSession puts the given web session in the database.
+	PutWebSession(ctx context.Context, session *gtsmodel.WebSession) Error
+	// UpdateWebSession updates the given web session,
+	// updating either only the specified columns, or all of them.
+	UpdateWebSession(ctx context.Context, session *gtsmodel.WebSession, columns ...string) Error
+	// DeleteWebSessionByID deletes one web session with the
+	// given database ID, and any tokens created through it.
+	DeleteWebSessionByID(ctx context.Context, id string) Error
+	// DeleteWebSessionsByUserID deletes all web sessions of the
+	// given user, and any tokens created through them.
+	DeleteWebSessionsByUserID(ctx context.Context, userID string) Error
+}
@@ -21,32 +21,53 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"net/smtp"
 	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
-	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 )
 
 func (s *sender) sendTemplate(template string, subject string, data any, toAddresses ...string) error {
+	msg, err := s.renderTemplate(template, subject, data, toAddresses...)
+	if err != nil {
+		return err
+	}
+
+	return s.enqueue(subject, msg, toAddresses...)
+}
+
+func (s *sender) renderTemplate(template string, subject string, data any, toAddresses ...string) ([]byte, error) {
 	buf := &bytes.Buffer{}
 	if err := s.template.ExecuteTemplate(buf, template, data); err != nil {
-		return err
+		return nil, err
 	}
 
-	msg, err := assembleMessage(subject, buf.String(), s.from, toAddresses...)
-	if err != nil {
-		return err
+	return assembleMessage(subject, buf.String(), s.from, toAddresses...)
+}
+
+// executeSubject executes the subject template with the given
+// name, allowing admins to customize the subject of an email by
+// defining it in the email template. If no such template has been
+// loaded, or it renders to an empty string, fallback is returned.
+func executeSubject(t *template.Template, name string, fallback string, data any) (string, error) {
+	subjectTemplate := t.Lookup(name)
+	if subjectTemplate == nil {
+		return fallback, nil
+	}
+
+	buf := &bytes.Buffer{}
+	if err := subjectTemplate.Execute(buf, data); err != nil {
+		return "", err
 	}
 
-	if err := smtp.SendMail(s.hostAddress, s.auth, s.from, toAddresses, msg); err != nil {
-		return gtserror.SetType(err, gtserror.TypeSMTP)
+	subject := strings.TrimSpace(buf.String())
+	if subject == "" {
+		return fallback, nil
 	}
 
-	return nil
+	return subject, nil
 }
 
 func loadTemplates(templateBaseDir string) (*template.Template, error) {
@@ -59,7 +80,28 @@ func loadTemplates(templateBaseDir string) (*template.Template, error) {
 	}
 
 	// look for all templates that start with 'email_'
-	return template.ParseGlob(filepath.Join(templateBaseDir, "email_*"))
+	t, err := template.ParseGlob(filepath.Join(templateBaseDir, "email_*"))
+	if err != nil {
+		return nil, err
+	}
+
+	overrideDir := config.GetSMTPTemplateOverrideDir()
+	if overrideDir == "" {
+		return t, nil
+	}
+
+	// Templates in the override dir replace
+	// the default template with the same name.
+	overrides, err := filepath.Glob(filepath.Join(overrideDir, "email_*"))
+	if err != nil {
+		return nil, fmt.Errorf("error looking for email templates in %s: %w", overrideDir, err)
+	}
+
+	if len(overrides) == 0 {
+		return t, nil
+	}
+
+	return t.ParseFiles(overrides...)
 }
 
 // assembleMessage assembles a valid email message following:
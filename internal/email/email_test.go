@@ -18,6 +18,8 @@
 package email_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -185,6 +187,182 @@ func (suite *EmailTestSuite) TestTemplateReportClosedLocalAccountNoComment() {
 	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Report Closed\r\n\r\nHello !\r\n\r\nYou recently reported the account @1happyturtle to the moderator(s) of Test Instance (https://example.org).\r\n\r\nThe report you submitted has now been closed.\r\n\r\nThe moderator who closed the report did not leave a comment.\r\n\r\n", suite.sentEmails["user@example.org"])
 }
 
+func (suite *EmailTestSuite) TestTemplateAccountSuspended() {
+	accountSuspendedData := email.AccountSuspendedData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		Reason:       "Repeated spam.",
+		ContactEmail: "admin@example.org",
+	}
+
+	if err := suite.sender.SendAccountSuspendedEmail("user@example.org", accountSuspendedData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Account Suspended\r\n\r\nHello test!\r\n\r\nYou are receiving this mail because your account on Test Instance (https://example.org) has been suspended by a moderator.\r\n\r\nYour account can no longer be used to log in, post, or interact with others, and its content has been removed.\r\n\r\nThe moderator who suspended your account gave the following reason: Repeated spam.\r\n\r\nIf you believe this was a mistake, you can appeal the suspension by writing to admin@example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateAccountSuspendedNoReasonNoContact() {
+	accountSuspendedData := email.AccountSuspendedData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+	}
+
+	if err := suite.sender.SendAccountSuspendedEmail("user@example.org", accountSuspendedData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Account Suspended\r\n\r\nHello test!\r\n\r\nYou are receiving this mail because your account on Test Instance (https://example.org) has been suspended by a moderator.\r\n\r\nYour account can no longer be used to log in, post, or interact with others, and its content has been removed.\r\n\r\nThe moderator who suspended your account did not give a reason.\r\n\r\nIf you believe this was a mistake, you can appeal the suspension by contacting the administrator of https://example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateAccountDeleteScheduled() {
+	accountDeleteScheduledData := email.AccountDeleteScheduledData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		DeleteAt:     "Mon, 24 Jul 2023 12:00:00 UTC",
+		CancelURL:    "https://example.org/settings/user/settings",
+	}
+
+	if err := suite.sender.SendAccountDeleteScheduledEmail("user@example.org", accountDeleteScheduledData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Account Deletion Scheduled\r\n\r\nHello test!\r\n\r\nYou are receiving this mail because deletion of your account on Test Instance (https://example.org) was requested.\r\n\r\nYour account has been hidden, and will be permanently deleted on Mon, 24 Jul 2023 12:00:00 UTC.\r\n\r\nIf you did not request this, or have changed your mind, you can cancel the deletion before then by logging in at the following link, and confirming your password:\r\n\r\nhttps://example.org/settings/user/settings\r\n\r\nIf you did not request this, you should also change your password once you have cancelled the deletion.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateNewSignup() {
+	newSignupData := email.NewSignupData{
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		AccountsURL:  "https://example.org/settings/admin/accounts",
+		Signups: []email.NewSignupEntry{
+			{
+				Username:   "newbie",
+				Email:      "newbie@example.org",
+				Reason:     "I'd like to join.",
+				AccountURL: "https://example.org/settings/admin/accounts/01H4X0KJ2J4CZ7AZS7PBYA0W2E",
+			},
+		},
+	}
+
+	if err := suite.sender.SendNewSignupEmail([]string{"admin@example.org"}, newSignupData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: admin@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial New Sign-Up\r\n\r\nHello moderator of Test Instance (https://example.org)!\r\n\r\nSomeone has signed up to your instance, and their account is awaiting approval.\r\n\r\nUsername: newbie\r\nEmail: newbie@example.org\r\nReason: I'd like to join.\r\nTo review this account, paste the following link into your browser: https://example.org/settings/admin/accounts/01H4X0KJ2J4CZ7AZS7PBYA0W2E\r\n\r\nTo view accounts on your instance, paste the following link into your browser: https://example.org/settings/admin/accounts\r\n\r\n", suite.sentEmails["admin@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateNewSignupBatched() {
+	newSignupData := email.NewSignupData{
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		AccountsURL:  "https://example.org/settings/admin/accounts",
+		Signups: []email.NewSignupEntry{
+			{
+				Username:   "newbie",
+				Email:      "newbie@example.org",
+				Reason:     "I'd like to join.",
+				AccountURL: "https://example.org/settings/admin/accounts/01H4X0KJ2J4CZ7AZS7PBYA0W2E",
+			},
+			{
+				Username:   "spammer",
+				Email:      "spam@example.com",
+				AccountURL: "https://example.org/settings/admin/accounts/01H4X0M7Q1T0D3J8ZB6ESNW8QH",
+			},
+		},
+		MoreSignups: 3,
+	}
+
+	if err := suite.sender.SendNewSignupEmail([]string{"admin@example.org"}, newSignupData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: admin@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial New Sign-Up\r\n\r\nHello moderator of Test Instance (https://example.org)!\r\n\r\n2 people have signed up to your instance, and their accounts are awaiting approval.\r\n\r\nUsername: newbie\r\nEmail: newbie@example.org\r\nReason: I'd like to join.\r\nTo review this account, paste the following link into your browser: https://example.org/settings/admin/accounts/01H4X0KJ2J4CZ7AZS7PBYA0W2E\r\n\r\nUsername: spammer\r\nEmail: spam@example.com\r\nReason: (none given)\r\nTo review this account, paste the following link into your browser: https://example.org/settings/admin/accounts/01H4X0M7Q1T0D3J8ZB6ESNW8QH\r\n\r\n...and 3 more.\r\n\r\nTo view accounts on your instance, paste the following link into your browser: https://example.org/settings/admin/accounts\r\n\r\n", suite.sentEmails["admin@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateSignupReceived() {
+	signupReceivedData := email.SignupReceivedData{
+		Username:     "newbie",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+	}
+
+	if err := suite.sender.SendSignupReceivedEmail("newbie@example.org", signupReceivedData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: newbie@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Sign-Up Received\r\n\r\nHello newbie!\r\n\r\nYou are receiving this mail because you've signed up for an account on Test Instance (https://example.org).\r\n\r\nSign-ups on this instance are reviewed by a moderator before they can be used. Your sign-up has been received, and is awaiting review.\r\n\r\nIn the meantime, please confirm your email address using the link in the separate confirmation email we've sent you.\r\n\r\nIf you believe you've been sent this email in error, feel free to ignore it, or contact the administrator of https://example.org\r\n\r\n", suite.sentEmails["newbie@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateSignupApproved() {
+	signupApprovedData := email.SignupApprovedData{
+		Username:     "newbie",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		Confirmed:    true,
+	}
+
+	if err := suite.sender.SendSignupApprovedEmail("newbie@example.org", signupApprovedData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: newbie@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Sign-Up Approved\r\n\r\nHello newbie!\r\n\r\nYou are receiving this mail because your sign-up for an account on Test Instance (https://example.org) has been approved by a moderator. Welcome!\r\n\r\nYou can now log in to your account at https://example.org.\r\n\r\n", suite.sentEmails["newbie@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateSignupRejected() {
+	signupRejectedData := email.SignupRejectedData{
+		Username:     "newbie",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		Reason:       "we're not accepting new members right now",
+	}
+
+	if err := suite.sender.SendSignupRejectedEmail("newbie@example.org", signupRejectedData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: newbie@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Sign-Up Rejected\r\n\r\nHello newbie!\r\n\r\nYou are receiving this mail because you signed up for an account on Test Instance (https://example.org).\r\n\r\nUnfortunately, your sign-up has been rejected by a moderator, and the account cannot be used.\r\n\r\nThe moderator who rejected your sign-up gave the following reason: we're not accepting new members right now\r\n\r\n", suite.sentEmails["newbie@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateOverride() {
+	overrideDir := suite.T().TempDir()
+	if err := os.WriteFile(filepath.Join(overrideDir, "email_confirm.tmpl"), []byte("Hi {{.Username}}, confirm here: {{.ConfirmLink}}\n"), 0o600); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	config.SetSMTPTemplateOverrideDir(overrideDir)
+	defer config.SetSMTPTemplateOverrideDir("")
+	sender := testrig.NewEmailSender("../../web/template/", suite.sentEmails)
+
+	confirmData := email.ConfirmData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		ConfirmLink:  "https://example.org/confirm_email?token=ee24f71d-e615-43f9-afae-385c0799b7fa",
+	}
+
+	if err := sender.SendConfirmEmail("user@example.org", confirmData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Email Confirmation\r\n\r\nHi test, confirm here: https://example.org/confirm_email?token=ee24f71d-e615-43f9-afae-385c0799b7fa\r\n\r\n", suite.sentEmails["user@example.org"])
+
+	// Templates which weren't overridden are unchanged.
+	resetData := email.ResetData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		ResetLink:    "https://example.org/reset_email?token=ee24f71d-e615-43f9-afae-385c0799b7fa",
+	}
+
+	if err := sender.SendResetEmail("other@example.org", resetData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Contains(suite.sentEmails["other@example.org"], "To reset your password, paste the following in your browser's address bar:")
+}
+
 func TestEmailTestSuite(t *testing.T) {
 	suite.Run(t, new(EmailTestSuite))
 }
@@ -56,8 +56,8 @@ func (s *noopSender) SendResetEmail(toAddress string, data ResetData) error {
 	return s.sendTemplate(resetTemplate, resetSubject, data, toAddress)
 }
 
-func (s *noopSender) SendTestEmail(toAddress string, data TestData) error {
-	return s.sendTemplate(testTemplate, testSubject, data, toAddress)
+func (s *noopSender) SendTestEmail(toAddress string, data TestData) (string, error) {
+	return "", s.sendTemplate(testTemplate, testSubject, data, toAddress)
 }
 
 func (s *noopSender) SendNewReportEmail(toAddresses []string, data NewReportData) error {
@@ -68,6 +68,34 @@ func (s *noopSender) SendReportClosedEmail(toAddress string, data ReportClosedDa
 	return s.sendTemplate(reportClosedTemplate, reportClosedSubject, data, toAddress)
 }
 
+func (s *noopSender) SendAccountSuspendedEmail(toAddress string, data AccountSuspendedData) error {
+	subject, err := executeSubject(s.template, accountSuspendedSubjectTemplate, accountSuspendedSubject, data)
+	if err != nil {
+		return err
+	}
+	return s.sendTemplate(accountSuspendedTemplate, subject, data, toAddress)
+}
+
+func (s *noopSender) SendAccountDeleteScheduledEmail(toAddress string, data AccountDeleteScheduledData) error {
+	return s.sendTemplate(accountDeleteScheduledTemplate, accountDeleteScheduledSubject, data, toAddress)
+}
+
+func (s *noopSender) SendNewSignupEmail(toAddresses []string, data NewSignupData) error {
+	return s.sendTemplate(newSignupTemplate, newSignupSubject, data, toAddresses...)
+}
+
+func (s *noopSender) SendSignupReceivedEmail(toAddress string, data SignupReceivedData) error {
+	return s.sendTemplate(signupReceivedTemplate, signupReceivedSubject, data, toAddress)
+}
+
+func (s *noopSender) SendSignupApprovedEmail(toAddress string, data SignupApprovedData) error {
+	return s.sendTemplate(signupApprovedTemplate, signupApprovedSubject, data, toAddress)
+}
+
+func (s *noopSender) SendSignupRejectedEmail(toAddress string, data SignupRejectedData) error {
+	return s.sendTemplate(signupRejectedTemplate, signupRejectedSubject, data, toAddress)
+}
+
 func (s *noopSender) sendTemplate(template string, subject string, data any, toAddresses ...string) error {
 	buf := &bytes.Buffer{}
 	if err := s.template.ExecuteTemplate(buf, template, data); err != nil {
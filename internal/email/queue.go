@@ -0,0 +1,164 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+import (
+	"context"
+	"errors"
+	"net/textproto"
+	"time"
+
+	"codeberg.org/gruf/go-runners"
+	"codeberg.org/gruf/go-sched"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// queuePollInterval is how often the
+	// queue is checked for emails which are
+	// due to be (re)sent.
+	queuePollInterval = 30 * time.Second
+
+	// queueBatchSize is the max number of emails
+	// that will be attempted per check of the queue.
+	queueBatchSize = 50
+
+	// queueMaxAttempts is the number of attempts
+	// after which sending an email is given up on.
+	queueMaxAttempts = 8
+
+	// queueBaseBackoff is the wait before the first retry;
+	// this doubles for each attempt after that, so the last
+	// retry happens a little over 2 hours after the first.
+	queueBaseBackoff = time.Minute
+)
+
+// enqueue stores the given message in the email queue,
+// and triggers an attempt to deliver it straight away.
+func (s *sender) enqueue(subject string, msg []byte, toAddresses ...string) error {
+	now := time.Now()
+
+	emailID, err := id.NewULIDFromTime(now)
+	if err != nil {
+		return err
+	}
+
+	email := &gtsmodel.QueuedEmail{
+		ID:            emailID,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		ToAddresses:   toAddresses,
+		Subject:       subject,
+		Message:       string(msg),
+		NextAttemptAt: now,
+	}
+
+	if err := s.state.DB.PutQueuedEmail(s.doneCtx(), email); err != nil {
+		return err
+	}
+
+	s.state.Workers.Scheduler.Schedule(sched.NewJob(func(time.Time) {
+		s.deliverQueue(s.doneCtx())
+	}))
+
+	return nil
+}
+
+// scheduleQueue schedules regular attempts at delivering
+// emails in the queue, including any left over from before
+// the last restart.
+func (s *sender) scheduleQueue() {
+	s.state.Workers.Scheduler.Schedule(sched.NewJob(func(time.Time) {
+		s.deliverQueue(s.doneCtx())
+	}).EveryAt(time.Now(), queuePollInterval))
+}
+
+// doneCtx returns a context which is
+// cancelled when the scheduler stops.
+func (s *sender) doneCtx() context.Context {
+	return runners.CancelCtx(s.state.Workers.Scheduler.Done())
+}
+
+// deliverQueue attempts to deliver all emails
+// in the queue which are currently due.
+func (s *sender) deliverQueue(ctx context.Context) {
+	// Only one delivery run at a time, so
+	// that no email is ever sent twice over.
+	s.deliverMu.Lock()
+	defer s.deliverMu.Unlock()
+
+	emails, err := s.state.DB.GetDueQueuedEmails(ctx, time.Now(), queueBatchSize)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "error getting queued emails: %v", err)
+		return
+	}
+
+	for _, email := range emails {
+		if ctx.Err() != nil {
+			// Shutting down.
+			return
+		}
+		s.deliver(ctx, email)
+	}
+}
+
+// deliver attempts to send one queued email, removing it
+// from the queue on success, and scheduling a retry (or
+// giving up on the email entirely) on failure.
+func (s *sender) deliver(ctx context.Context, email *gtsmodel.QueuedEmail) {
+	l := log.WithContext(ctx).WithField("id", email.ID)
+
+	err := s.sendMail(s.hostAddress, s.auth, s.from, email.ToAddresses, []byte(email.Message))
+	if err == nil {
+		if err := s.state.DB.DeleteQueuedEmailByID(ctx, email.ID); err != nil {
+			l.Errorf("error removing sent email from queue: %v", err)
+		}
+		return
+	}
+
+	now := time.Now()
+	email.Attempts++
+	email.LastError = err.Error()
+
+	if permanentSMTPError(err) || email.Attempts >= queueMaxAttempts {
+		l.Errorf("giving up on sending email after %d attempt(s): %v", email.Attempts, err)
+		email.FailedAt = now
+	} else {
+		l.Warnf("error sending email, will retry: %v", err)
+		email.NextAttemptAt = now.Add(queueBaseBackoff << (email.Attempts - 1))
+	}
+
+	if err := s.state.DB.UpdateQueuedEmail(ctx, email,
+		"attempts",
+		"last_error",
+		"next_attempt_at",
+		"failed_at",
+	); err != nil {
+		l.Errorf("error updating queued email: %v", err)
+	}
+}
+
+// permanentSMTPError returns whether the given error was a 5xx
+// reply from the SMTP server, meaning retrying is pointless.
+func permanentSMTPError(err error) bool {
+	var tpErr *textproto.Error
+	return errors.As(err, &tpErr) && tpErr.Code >= 500
+}
@@ -0,0 +1,304 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/smtp"
+	"net/textproto"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+// queueDB is an in-memory stand-in for the
+// email queue parts of the database.
+type queueDB struct {
+	db.DB
+
+	mu     sync.Mutex
+	emails map[string]*gtsmodel.QueuedEmail
+}
+
+func (q *queueDB) GetQueuedEmailByID(ctx context.Context, id string) (*gtsmodel.QueuedEmail, db.Error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	email, ok := q.emails[id]
+	if !ok {
+		return nil, db.ErrNoEntries
+	}
+
+	email2 := *email
+	return &email2, nil
+}
+
+func (q *queueDB) GetDueQueuedEmails(ctx context.Context, now time.Time, limit int) ([]*gtsmodel.QueuedEmail, db.Error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	emails := []*gtsmodel.QueuedEmail{}
+	for _, email := range q.emails {
+		if email.FailedAt.IsZero() && !email.NextAttemptAt.After(now) {
+			email2 := *email
+			emails = append(emails, &email2)
+		}
+	}
+
+	sort.Slice(emails, func(i, j int) bool {
+		return emails[i].NextAttemptAt.Before(emails[j].NextAttemptAt)
+	})
+
+	if len(emails) > limit {
+		emails = emails[:limit]
+	}
+
+	if len(emails) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return emails, nil
+}
+
+func (q *queueDB) PutQueuedEmail(ctx context.Context, email *gtsmodel.QueuedEmail) db.Error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	email2 := *email
+	q.emails[email.ID] = &email2
+	return nil
+}
+
+func (q *queueDB) UpdateQueuedEmail(ctx context.Context, email *gtsmodel.QueuedEmail, columns ...string) db.Error {
+	return q.PutQueuedEmail(ctx, email)
+}
+
+func (q *queueDB) DeleteQueuedEmailByID(ctx context.Context, id string) db.Error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.emails, id)
+	return nil
+}
+
+type QueueTestSuite struct {
+	suite.Suite
+
+	db     *queueDB
+	sender *sender
+
+	// errors to return from successive
+	// sends; nil once these run out
+	sendErrs []error
+	sent     [][]byte
+}
+
+func (suite *QueueTestSuite) SetupTest() {
+	suite.db = &queueDB{emails: make(map[string]*gtsmodel.QueuedEmail)}
+	suite.sendErrs = nil
+	suite.sent = nil
+
+	suite.sender = &sender{
+		state:       &state.State{DB: suite.db},
+		hostAddress: "smtp.example.org:587",
+		from:        "test@example.org",
+		sendMail: func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+			if len(suite.sendErrs) > 0 {
+				err := suite.sendErrs[0]
+				suite.sendErrs = suite.sendErrs[1:]
+				return err
+			}
+			suite.sent = append(suite.sent, msg)
+			return nil
+		},
+	}
+}
+
+// queue puts an email which is due straight away in the queue.
+func (suite *QueueTestSuite) queue(id string) {
+	now := time.Now()
+	if err := suite.db.PutQueuedEmail(context.Background(), &gtsmodel.QueuedEmail{
+		ID:            id,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		ToAddresses:   []string{"user@example.org"},
+		Subject:       "Test",
+		Message:       "message " + id,
+		NextAttemptAt: now,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+// get returns the queued email with the given ID, or nil if it's gone.
+func (suite *QueueTestSuite) get(id string) *gtsmodel.QueuedEmail {
+	email, err := suite.db.GetQueuedEmailByID(context.Background(), id)
+	if errors.Is(err, db.ErrNoEntries) {
+		return nil
+	}
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return email
+}
+
+// makeDue pretends that the next attempt at the
+// email with the given ID is due now.
+func (suite *QueueTestSuite) makeDue(id string) {
+	email := suite.get(id)
+	email.NextAttemptAt = time.Now()
+	if err := suite.db.UpdateQueuedEmail(context.Background(), email); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *QueueTestSuite) TestDeliverSent() {
+	suite.queue("01H5ZK6B8M1Q3RX1V8Q0P9E8TW")
+
+	suite.sender.deliverQueue(context.Background())
+
+	suite.Equal([][]byte{[]byte("message 01H5ZK6B8M1Q3RX1V8Q0P9E8TW")}, suite.sent)
+	suite.Nil(suite.get("01H5ZK6B8M1Q3RX1V8Q0P9E8TW"))
+}
+
+func (suite *QueueTestSuite) TestDeliverRetryBackoff() {
+	const id = "01H5ZK6B8M1Q3RX1V8Q0P9E8TW"
+	suite.queue(id)
+
+	tempErr := &textproto.Error{Code: 451, Msg: "try again later"}
+	for i := 0; i < queueMaxAttempts-1; i++ {
+		suite.sendErrs = append(suite.sendErrs, tempErr)
+	}
+
+	// Each failed attempt should push the next
+	// attempt back twice as far as the last one.
+	for attempt, backoff := 1, time.Minute; attempt < queueMaxAttempts; attempt, backoff = attempt+1, backoff*2 {
+		suite.sender.deliverQueue(context.Background())
+
+		email := suite.get(id)
+		suite.Equal(attempt, email.Attempts)
+		suite.Equal("451 try again later", email.LastError)
+		suite.WithinDuration(time.Now().Add(backoff), email.NextAttemptAt, 5*time.Second)
+		suite.Zero(email.FailedAt)
+
+		// Not due yet, so another
+		// run shouldn't send it.
+		suite.sender.deliverQueue(context.Background())
+		suite.Equal(attempt, suite.get(id).Attempts)
+
+		suite.makeDue(id)
+	}
+
+	// The last attempt succeeds.
+	suite.sender.deliverQueue(context.Background())
+	suite.Len(suite.sent, 1)
+	suite.Nil(suite.get(id))
+}
+
+func (suite *QueueTestSuite) TestDeliverGiveUpAfterMaxAttempts() {
+	const id = "01H5ZK6B8M1Q3RX1V8Q0P9E8TW"
+	suite.queue(id)
+
+	for i := 0; i < queueMaxAttempts; i++ {
+		suite.sendErrs = append(suite.sendErrs, &textproto.Error{Code: 421, Msg: "service not available"})
+	}
+
+	for i := 0; i < queueMaxAttempts; i++ {
+		suite.sender.deliverQueue(context.Background())
+		if i < queueMaxAttempts-1 {
+			suite.makeDue(id)
+		}
+	}
+
+	// The email should be kept, but marked as failed,
+	// so it won't be picked up by the queue again.
+	email := suite.get(id)
+	suite.Equal(queueMaxAttempts, email.Attempts)
+	suite.Equal("421 service not available", email.LastError)
+	suite.WithinDuration(time.Now(), email.FailedAt, 5*time.Second)
+
+	suite.sendErrs = nil
+	suite.sender.deliverQueue(context.Background())
+	suite.Empty(suite.sent)
+	suite.NotNil(suite.get(id))
+}
+
+func (suite *QueueTestSuite) TestDeliverPermanentError() {
+	const id = "01H5ZK6B8M1Q3RX1V8Q0P9E8TW"
+	suite.queue(id)
+
+	suite.sendErrs = []error{&textproto.Error{Code: 550, Msg: "no such user"}}
+	suite.sender.deliverQueue(context.Background())
+
+	// A 5xx reply should be given up on straight away.
+	email := suite.get(id)
+	suite.Equal(1, email.Attempts)
+	suite.Equal("550 no such user", email.LastError)
+	suite.WithinDuration(time.Now(), email.FailedAt, 5*time.Second)
+}
+
+func (suite *QueueTestSuite) TestDeliverOneFailureDoesNotBlockOthers() {
+	suite.queue("01H5ZK6B8M1Q3RX1V8Q0P9E8TW")
+	suite.queue("01H5ZK7Q0SD2MRYW4B3KJ6JX4Z")
+
+	suite.sendErrs = []error{errors.New("connection refused")}
+	suite.sender.deliverQueue(context.Background())
+
+	// One of the two was sent, the other is queued for a retry.
+	suite.Len(suite.sent, 1)
+	remaining := 0
+	for _, id := range []string{"01H5ZK6B8M1Q3RX1V8Q0P9E8TW", "01H5ZK7Q0SD2MRYW4B3KJ6JX4Z"} {
+		if email := suite.get(id); email != nil {
+			remaining++
+			suite.Equal(1, email.Attempts)
+			suite.Zero(email.FailedAt)
+		}
+	}
+	suite.Equal(1, remaining)
+}
+
+func (suite *QueueTestSuite) TestPermanentSMTPError() {
+	for _, test := range []struct {
+		err       error
+		permanent bool
+	}{
+		{err: &textproto.Error{Code: 550, Msg: "no such user"}, permanent: true},
+		{err: &textproto.Error{Code: 554, Msg: "transaction failed"}, permanent: true},
+		{err: &textproto.Error{Code: 500, Msg: "syntax error"}, permanent: true},
+		{err: &textproto.Error{Code: 451, Msg: "try again later"}, permanent: false},
+		{err: &textproto.Error{Code: 421, Msg: "service not available"}, permanent: false},
+		{err: errors.New("dial tcp: connection refused"), permanent: false},
+		{err: context.DeadlineExceeded, permanent: false},
+		{err: fmt.Errorf("error sending: %w", &textproto.Error{Code: 553, Msg: "mailbox name not allowed"}), permanent: true},
+		{err: fmt.Errorf("error sending: %w", &textproto.Error{Code: 452, Msg: "insufficient storage"}), permanent: false},
+	} {
+		suite.Equal(test.permanent, permanentSMTPError(test.err), test.err.Error())
+	}
+}
+
+func TestQueueTestSuite(t *testing.T) {
+	suite.Run(t, new(QueueTestSuite))
+}
@@ -20,9 +20,11 @@ package email
 import (
 	"fmt"
 	"net/smtp"
+	"sync"
 	"text/template"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
 )
 
 // Sender contains functions for sending emails to instance users/new signups.
@@ -34,7 +36,10 @@ type Sender interface {
 	SendResetEmail(toAddress string, data ResetData) error
 
 	// SendTestEmail sends a 'testing email sending' style email to the given toAddress, with the given data.
-	SendTestEmail(toAddress string, data TestData) error
+	//
+	// Unlike other emails, the test email skips the queue and is sent immediately, and a
+	// transcript of the SMTP conversation is returned, whether or not sending succeeded.
+	SendTestEmail(toAddress string, data TestData) (string, error)
 
 	// SendNewReportEmail sends an email notification to the given addresses, letting them
 	// know that a new report has been created targeting a user on this instance.
@@ -46,10 +51,40 @@ type Sender interface {
 	// SendReportClosedEmail sends an email notification to the given address, letting them
 	// know that a report that they created has been closed / resolved by an admin.
 	SendReportClosedEmail(toAddress string, data ReportClosedData) error
+
+	// SendAccountSuspendedEmail sends an email notification to the given address, letting
+	// them know that their account has been suspended by an admin, and how to appeal.
+	SendAccountSuspendedEmail(toAddress string, data AccountSuspendedData) error
+
+	// SendAccountDeleteScheduledEmail sends an email notification to the given address,
+	// letting them know that their account will be deleted, and how to cancel this.
+	SendAccountDeleteScheduledEmail(toAddress string, data AccountDeleteScheduledData) error
+
+	// SendNewSignupEmail sends an email notification to the given addresses, letting them
+	// know that one or more new sign-ups are awaiting approval on this instance.
+	//
+	// It is expected that the toAddresses have already been filtered to ensure that they
+	// all belong to admins + moderators.
+	SendNewSignupEmail(toAddresses []string, data NewSignupData) error
+
+	// SendSignupReceivedEmail sends an email to the given address, letting the new
+	// sign-up know that their sign-up has been received and is pending review.
+	SendSignupReceivedEmail(toAddress string, data SignupReceivedData) error
+
+	// SendSignupApprovedEmail sends an email to the given address, letting the new
+	// sign-up know that their sign-up has been approved by a moderator.
+	SendSignupApprovedEmail(toAddress string, data SignupApprovedData) error
+
+	// SendSignupRejectedEmail sends an email to the given address, letting the new
+	// sign-up know that their sign-up has been rejected by a moderator, and why.
+	SendSignupRejectedEmail(toAddress string, data SignupRejectedData) error
 }
 
 // NewSender returns a new email Sender interface with the given configuration, or an error if something goes wrong.
-func NewSender() (Sender, error) {
+//
+// Emails are placed in a queue in the database, and sent from there by the scheduler,
+// so the state workers must have been started before calling this function.
+func NewSender(state *state.State) (Sender, error) {
 	templateBaseDir := config.GetWebTemplateBaseDir()
 	t, err := loadTemplates(templateBaseDir)
 	if err != nil {
@@ -62,17 +97,28 @@ func NewSender() (Sender, error) {
 	port := config.GetSMTPPort()
 	from := config.GetSMTPFrom()
 
-	return &sender{
+	s := &sender{
+		state:       state,
 		hostAddress: fmt.Sprintf("%s:%d", host, port),
 		from:        from,
 		auth:        smtp.PlainAuth("", username, password, host),
 		template:    t,
-	}, nil
+		sendMail:    smtp.SendMail,
+	}
+	s.scheduleQueue()
+
+	return s, nil
 }
 
 type sender struct {
+	state       *state.State
+	deliverMu   sync.Mutex
 	hostAddress string
 	from        string
 	auth        smtp.Auth
 	template    *template.Template
+
+	// sendMail sends one message over SMTP;
+	// this is smtp.SendMail, except in tests.
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
 }
@@ -0,0 +1,106 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+const (
+	newSignupTemplate      = "email_new_signup.tmpl"
+	newSignupSubject       = "GoToSocial New Sign-Up"
+	signupReceivedTemplate = "email_signup_received.tmpl"
+	signupReceivedSubject  = "GoToSocial Sign-Up Received"
+	signupApprovedTemplate = "email_signup_approved.tmpl"
+	signupApprovedSubject  = "GoToSocial Sign-Up Approved"
+	signupRejectedTemplate = "email_signup_rejected.tmpl"
+	signupRejectedSubject  = "GoToSocial Sign-Up Rejected"
+)
+
+type NewSignupData struct {
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// URL to open the accounts overview in the settings panel.
+	AccountsURL string
+	// Sign-ups awaiting approval. When sign-ups arrive
+	// in quick succession, several may be batched into
+	// one email, so this will always contain at least one.
+	Signups []NewSignupEntry
+	// Number of further sign-ups awaiting approval
+	// which were left out of Signups for brevity.
+	MoreSignups int
+}
+
+type NewSignupEntry struct {
+	// Username of the new sign-up.
+	Username string
+	// Email address given by the new sign-up.
+	Email string
+	// Reason given for wanting to join the instance.
+	// Can be empty string if no reason was given.
+	Reason string
+	// URL to open the new account in the settings panel.
+	AccountURL string
+}
+
+func (s *sender) SendNewSignupEmail(toAddresses []string, data NewSignupData) error {
+	return s.sendTemplate(newSignupTemplate, newSignupSubject, data, toAddresses...)
+}
+
+type SignupReceivedData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+}
+
+func (s *sender) SendSignupReceivedEmail(toAddress string, data SignupReceivedData) error {
+	return s.sendTemplate(signupReceivedTemplate, signupReceivedSubject, data, toAddress)
+}
+
+type SignupApprovedData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// Whether the new user has already
+	// confirmed their email address.
+	Confirmed bool
+}
+
+func (s *sender) SendSignupApprovedEmail(toAddress string, data SignupApprovedData) error {
+	return s.sendTemplate(signupApprovedTemplate, signupApprovedSubject, data, toAddress)
+}
+
+type SignupRejectedData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// Reason given by the admin who rejected the sign-up.
+	// Can be empty string if no reason was given.
+	Reason string
+}
+
+func (s *sender) SendSignupRejectedEmail(toAddress string, data SignupRejectedData) error {
+	return s.sendTemplate(signupRejectedTemplate, signupRejectedSubject, data, toAddress)
+}
@@ -0,0 +1,47 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+const (
+	accountSuspendedTemplate        = "email_account_suspended.tmpl"
+	accountSuspendedSubjectTemplate = "email_account_suspended_subject"
+	accountSuspendedSubject         = "GoToSocial Account Suspended"
+)
+
+type AccountSuspendedData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// Reason given by the admin who suspended the account.
+	// Can be empty string if no reason was given.
+	Reason string
+	// Email address at which the suspension can be appealed.
+	// Can be empty string if the instance has no contact email.
+	ContactEmail string
+}
+
+func (s *sender) SendAccountSuspendedEmail(toAddress string, data AccountSuspendedData) error {
+	subject, err := executeSubject(s.template, accountSuspendedSubjectTemplate, accountSuspendedSubject, data)
+	if err != nil {
+		return err
+	}
+	return s.sendTemplate(accountSuspendedTemplate, subject, data, toAddress)
+}
@@ -31,6 +31,11 @@ type TestData struct {
 	InstanceName string
 }
 
-func (s *sender) SendTestEmail(toAddress string, data TestData) error {
-	return s.sendTemplate(testTemplate, testSubject, data, toAddress)
+func (s *sender) SendTestEmail(toAddress string, data TestData) (string, error) {
+	msg, err := s.renderTemplate(testTemplate, testSubject, data, toAddress)
+	if err != nil {
+		return "", err
+	}
+
+	return s.sendWithTranscript(msg, toAddress)
 }
@@ -0,0 +1,106 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// sendWithTranscript sends the given message directly, without
+// going through the queue, and returns a transcript of each step
+// of the SMTP conversation along with the result of that step.
+//
+// Credentials are never included in the transcript.
+func (s *sender) sendWithTranscript(msg []byte, toAddresses ...string) (string, error) {
+	t := &strings.Builder{}
+	step := func(name string, err error) error {
+		if err != nil {
+			fmt.Fprintf(t, "%s: %v\n", name, err)
+			return gtserror.SetType(err, gtserror.TypeSMTP)
+		}
+		fmt.Fprintf(t, "%s: ok\n", name)
+		return nil
+	}
+
+	host, _, _ := net.SplitHostPort(s.hostAddress)
+
+	conn, err := net.DialTimeout("tcp", s.hostAddress, 30*time.Second)
+	if err := step("connect "+s.hostAddress, err); err != nil {
+		return t.String(), err
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err := step("greeting", err); err != nil {
+		conn.Close()
+		return t.String(), err
+	}
+	defer c.Close()
+
+	if err := step("EHLO localhost", c.Hello("localhost")); err != nil {
+		return t.String(), err
+	}
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		err := c.StartTLS(&tls.Config{ServerName: host})
+		if err := step("STARTTLS", err); err != nil {
+			return t.String(), err
+		}
+	}
+
+	if ok, mechs := c.Extension("AUTH"); ok && s.auth != nil {
+		if err := step("AUTH "+mechs, c.Auth(s.auth)); err != nil {
+			return t.String(), err
+		}
+	}
+
+	if err := step("MAIL FROM:<"+s.from+">", c.Mail(s.from)); err != nil {
+		return t.String(), err
+	}
+
+	for _, to := range toAddresses {
+		if err := step("RCPT TO:<"+to+">", c.Rcpt(to)); err != nil {
+			return t.String(), err
+		}
+	}
+
+	w, err := c.Data()
+	if err := step("DATA", err); err != nil {
+		return t.String(), err
+	}
+
+	if _, err := w.Write(msg); err != nil {
+		return t.String(), step("write message", err)
+	}
+
+	if err := step("end of message", w.Close()); err != nil {
+		return t.String(), err
+	}
+
+	if err := step("QUIT", c.Quit()); err != nil {
+		return t.String(), err
+	}
+
+	return t.String(), nil
+}
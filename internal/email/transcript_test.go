@@ -0,0 +1,211 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+import (
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// fakeSMTPServer is a minimal SMTP server which accepts
+// one connection, and records the commands it receives.
+type fakeSMTPServer struct {
+	listener net.Listener
+
+	// replies to RCPT TO for the given
+	// address, instead of accepting it
+	rcptReplies map[string]string
+
+	commands []string
+	message  []byte
+	done     chan struct{}
+}
+
+func newFakeSMTPServer(rcptReplies map[string]string) (*fakeSMTPServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &fakeSMTPServer{
+		listener:    listener,
+		rcptReplies: rcptReplies,
+		done:        make(chan struct{}),
+	}
+	go s.serve()
+
+	return s, nil
+}
+
+func (s *fakeSMTPServer) serve() {
+	defer close(s.done)
+
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	c := textproto.NewConn(conn)
+	_ = c.PrintfLine("220 fake ESMTP")
+
+	for {
+		line, err := c.ReadLine()
+		if err != nil {
+			return
+		}
+		s.commands = append(s.commands, line)
+
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch verb {
+		case "EHLO":
+			_ = c.PrintfLine("250-fake")
+			_ = c.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			_ = c.PrintfLine("235 authenticated")
+		case "MAIL":
+			_ = c.PrintfLine("250 ok")
+		case "RCPT":
+			to := strings.TrimSuffix(strings.TrimPrefix(line, "RCPT TO:<"), ">")
+			if reply, ok := s.rcptReplies[to]; ok {
+				_ = c.PrintfLine(reply)
+				continue
+			}
+			_ = c.PrintfLine("250 ok")
+		case "DATA":
+			_ = c.PrintfLine("354 go ahead")
+			s.message, err = c.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			_ = c.PrintfLine("250 queued")
+		case "QUIT":
+			_ = c.PrintfLine("221 bye")
+			return
+		default:
+			_ = c.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+type TranscriptTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TranscriptTestSuite) newSender(server *fakeSMTPServer) *sender {
+	return &sender{
+		hostAddress: server.listener.Addr().String(),
+		from:        "test@example.org",
+		auth:        smtp.PlainAuth("", "smtpuser", "sup3rs3cr3t", "127.0.0.1"),
+	}
+}
+
+func (suite *TranscriptTestSuite) TestSendWithTranscript() {
+	server, err := newFakeSMTPServer(nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer server.listener.Close()
+
+	s := suite.newSender(server)
+	transcript, err := s.sendWithTranscript([]byte("Subject: Test\r\n\r\nHello!\r\n"), "user@example.org")
+	suite.NoError(err)
+	<-server.done
+
+	suite.Equal(
+		"connect "+s.hostAddress+": ok\n"+
+			"greeting: ok\n"+
+			"EHLO localhost: ok\n"+
+			"AUTH PLAIN: ok\n"+
+			"MAIL FROM:<test@example.org>: ok\n"+
+			"RCPT TO:<user@example.org>: ok\n"+
+			"DATA: ok\n"+
+			"end of message: ok\n"+
+			"QUIT: ok\n",
+		transcript,
+	)
+	suite.Equal("Subject: Test\n\nHello!\n", string(server.message))
+
+	// The server did get credentials, but
+	// they must not end up in the transcript.
+	suite.Contains(strings.Join(server.commands, "\n"), "AUTH PLAIN ")
+	suite.NotContains(transcript, "smtpuser")
+	suite.NotContains(transcript, "sup3rs3cr3t")
+	for _, command := range server.commands {
+		if strings.HasPrefix(command, "AUTH PLAIN ") {
+			suite.NotContains(transcript, strings.TrimPrefix(command, "AUTH PLAIN "))
+		}
+	}
+}
+
+func (suite *TranscriptTestSuite) TestSendWithTranscriptRcptRejected() {
+	server, err := newFakeSMTPServer(map[string]string{
+		"nobody@example.org": "550 no such user",
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer server.listener.Close()
+
+	s := suite.newSender(server)
+	transcript, err := s.sendWithTranscript([]byte("Subject: Test\r\n\r\nHello!\r\n"), "user@example.org", "nobody@example.org")
+	suite.EqualError(err, "550 no such user")
+	suite.Equal(gtserror.TypeSMTP, gtserror.Type(err))
+	<-server.done
+
+	// The transcript should stop at the step that failed.
+	suite.Equal(
+		"connect "+s.hostAddress+": ok\n"+
+			"greeting: ok\n"+
+			"EHLO localhost: ok\n"+
+			"AUTH PLAIN: ok\n"+
+			"MAIL FROM:<test@example.org>: ok\n"+
+			"RCPT TO:<user@example.org>: ok\n"+
+			"RCPT TO:<nobody@example.org>: 550 no such user\n",
+		transcript,
+	)
+	suite.Nil(server.message)
+}
+
+func (suite *TranscriptTestSuite) TestSendWithTranscriptConnectFailed() {
+	// Grab a free port, then close the
+	// listener, so connecting to it fails.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	hostAddress := listener.Addr().String()
+	listener.Close()
+
+	s := &sender{hostAddress: hostAddress, from: "test@example.org"}
+	transcript, err := s.sendWithTranscript([]byte("Subject: Test\r\n\r\nHello!\r\n"), "user@example.org")
+	suite.Error(err)
+	suite.Equal(gtserror.TypeSMTP, gtserror.Type(err))
+	suite.True(strings.HasPrefix(transcript, "connect "+hostAddress+": "))
+	suite.NotContains(transcript, "greeting")
+}
+
+func TestTranscriptTestSuite(t *testing.T) {
+	suite.Run(t, new(TranscriptTestSuite))
+}
@@ -72,11 +72,15 @@ func (d *deref) GetAccountByURI(ctx context.Context, requestUser string, uri *ur
 	}
 
 	if apubAcc != nil {
-		// This account was updated, enqueue re-dereference featured posts.
+		// This account was updated, enqueue re-dereference featured posts and tags.
 		d.state.Workers.Federator.MustEnqueueCtx(ctx, func(ctx context.Context) {
 			if err := d.dereferenceAccountFeatured(ctx, requestUser, account); err != nil {
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
+
+			if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, account); err != nil {
+				log.Errorf(ctx, "error fetching account featured tags collection: %v", err)
+			}
 		})
 	}
 
@@ -192,11 +196,15 @@ func (d *deref) GetAccountByUsernameDomain(ctx context.Context, requestUser stri
 			return nil, nil, err
 		}
 
-		// This account was updated, enqueue dereference featured posts.
+		// This account was updated, enqueue dereference featured posts and tags.
 		d.state.Workers.Federator.MustEnqueueCtx(ctx, func(ctx context.Context) {
 			if err := d.dereferenceAccountFeatured(ctx, requestUser, account); err != nil {
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
+
+			if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, account); err != nil {
+				log.Errorf(ctx, "error fetching account featured tags collection: %v", err)
+			}
 		})
 
 		return account, apubAcc, nil
@@ -254,11 +262,15 @@ func (d *deref) RefreshAccount(ctx context.Context, requestUser string, account
 		return nil, nil, err
 	}
 
-	// This account was updated, enqueue re-dereference featured posts.
+	// This account was updated, enqueue re-dereference featured posts and tags.
 	d.state.Workers.Federator.MustEnqueueCtx(ctx, func(ctx context.Context) {
 		if err := d.dereferenceAccountFeatured(ctx, requestUser, account); err != nil {
 			log.Errorf(ctx, "error fetching account featured collection: %v", err)
 		}
+
+		if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, account); err != nil {
+			log.Errorf(ctx, "error fetching account featured tags collection: %v", err)
+		}
 	})
 
 	return latest, apubAcc, nil
@@ -286,10 +298,14 @@ func (d *deref) RefreshAccountAsync(ctx context.Context, requestUser string, acc
 			return
 		}
 
-		// This account was updated, re-dereference account featured posts.
+		// This account was updated, re-dereference account featured posts and tags.
 		if err := d.dereferenceAccountFeatured(ctx, requestUser, latest); err != nil {
 			log.Errorf(ctx, "error fetching account featured collection: %v", err)
 		}
+
+		if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, latest); err != nil {
+			log.Errorf(ctx, "error fetching account featured tags collection: %v", err)
+		}
 	})
 }
 
@@ -420,14 +436,23 @@ func (d *deref) enrichAccount(ctx context.Context, requestUser string, uri *url.
 	latestAcc.ID = account.ID
 	latestAcc.FetchedAt = time.Now()
 
-	// Ensure the account's avatar media is populated, passing in existing to check for chages.
-	if err := d.fetchRemoteAccountAvatar(ctx, tsport, account, latestAcc); err != nil {
-		log.Errorf(ctx, "error fetching remote avatar for account %s: %v", uri, err)
+	// Check for a (silencing) block on the account's
+	// domain, which may also say not to fetch its media.
+	domainBlock, err := d.state.DB.GetDomainBlock(ctx, latestAcc.Domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, nil, gtserror.Newf("db error getting domain block for %s: %w", latestAcc.Domain, err)
 	}
 
-	// Ensure the account's avatar media is populated, passing in existing to check for chages.
-	if err := d.fetchRemoteAccountHeader(ctx, tsport, account, latestAcc); err != nil {
-		log.Errorf(ctx, "error fetching remote header for account %s: %v", uri, err)
+	if domainBlock == nil || !*domainBlock.RejectMedia {
+		// Ensure the account's avatar media is populated, passing in existing to check for chages.
+		if err := d.fetchRemoteAccountAvatar(ctx, tsport, account, latestAcc); err != nil {
+			log.Errorf(ctx, "error fetching remote avatar for account %s: %v", uri, err)
+		}
+
+		// Ensure the account's avatar media is populated, passing in existing to check for chages.
+		if err := d.fetchRemoteAccountHeader(ctx, tsport, account, latestAcc); err != nil {
+			log.Errorf(ctx, "error fetching remote header for account %s: %v", uri, err)
+		}
 	}
 
 	// Fetch the latest remote account emoji IDs used in account display name/bio.
@@ -443,6 +468,12 @@ func (d *deref) enrichAccount(ctx context.Context, requestUser string, uri *url.
 		latestAcc.CreatedAt = latestAcc.FetchedAt
 		latestAcc.UpdatedAt = latestAcc.FetchedAt
 
+		if domainBlock != nil && domainBlock.IsSilence() {
+			// Account is on a silenced domain,
+			// so it starts out silenced too.
+			latestAcc.SilencedAt = latestAcc.FetchedAt
+		}
+
 		// This is new, put it in the database.
 		err := d.state.DB.PutAccount(ctx, latestAcc)
 
@@ -455,6 +486,9 @@ func (d *deref) enrichAccount(ctx context.Context, requestUser string, uri *url.
 		if err != nil {
 			return nil, nil, gtserror.Newf("error putting in database: %w", err)
 		}
+
+		// This account is newly discovered, enqueue backfilling its recent statuses.
+		d.BackfillAccountAsync(ctx, requestUser, latestAcc)
 	} else {
 		// Set time of update from the last-fetched date.
 		latestAcc.UpdatedAt = latestAcc.FetchedAt
@@ -462,6 +496,7 @@ func (d *deref) enrichAccount(ctx context.Context, requestUser string, uri *url.
 		// Use existing account values.
 		latestAcc.CreatedAt = account.CreatedAt
 		latestAcc.Language = account.Language
+		latestAcc.SilencedAt = account.SilencedAt
 
 		// This is an existing account, update the model in the database.
 		if err := d.state.DB.UpdateAccount(ctx, latestAcc); err != nil {
@@ -19,12 +19,15 @@ package dereferencing_test
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -193,6 +196,36 @@ func (suite *AccountTestSuite) TestDereferenceLocalAccountWithUnknownUsernameDom
 	suite.Nil(fetchedAccount)
 }
 
+func (suite *AccountTestSuite) TestDereferenceAccountOnBlockedDomainSendsNoRequests() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	// Record any requests that go out.
+	requests := []string{}
+	httpClient := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.URL.String())
+		return nil, errors.New("no requests should be sent")
+	}, "../../../testrig/media")
+
+	dereferencer := dereferencing.NewDereferencer(
+		&suite.state,
+		testrig.NewTestTypeConverter(suite.db),
+		testrig.NewTestTransportController(&suite.state, httpClient),
+		testrig.NewTestMediaManager(&suite.state),
+	)
+
+	// replyguys.com is domain blocked, so we
+	// shouldn't even try to webfinger the account.
+	fetchedAccount, _, err := dereferencer.GetAccountByUsernameDomain(
+		context.Background(),
+		fetchingAccount.Username,
+		"some_guy",
+		"replyguys.com",
+	)
+	suite.Error(err)
+	suite.Nil(fetchedAccount)
+	suite.Empty(requests)
+}
+
 func (suite *AccountTestSuite) TestDereferenceLocalAccountWithUnknownUserURI() {
 	fetchingAccount := suite.testAccounts["local_account_1"]
 
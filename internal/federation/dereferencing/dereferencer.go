@@ -52,6 +52,11 @@ type Dereferencer interface {
 	// This is a more optimized form of manually enqueueing .UpdateAccount() to the federation worker, since it only enqueues update if necessary.
 	RefreshAccountAsync(ctx context.Context, requestUser string, account *gtsmodel.Account, apubAcc ap.Accountable, force bool)
 
+	// BackfillAccountAsync enqueues fetching the recent statuses of the given remote account from the first page of its outbox.
+	// Statuses that are new to us are marked as backfilled, and enqueued for federated create processing, which doesn't notify
+	// or timeline backfilled statuses. This is done when an account is newly discovered, or first followed by a local account.
+	BackfillAccountAsync(ctx context.Context, requestUser string, account *gtsmodel.Account)
+
 	// GetStatusByURI will attempt to fetch a status by its URI, first checking the database. In the case of a newly-met remote model, or a remote model
 	// whose last_fetched date is beyond a certain interval, the status will be dereferenced. In the case of dereferencing, some low-priority status information
 	// may be enqueued for asynchronous fetching, e.g. dereferencing the remainder of the status thread. An ActivityPub object indicates the status was dereferenced.
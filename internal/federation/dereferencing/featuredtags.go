@@ -0,0 +1,161 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// featuredTagsLimit is the maximum number of featured
+// tags stored for one account, matching the number of
+// tags that Mastodon allows an account to feature.
+const featuredTagsLimit = 10
+
+// dereferenceAccountFeaturedTags dereferences the featured tags collection of an account (if set),
+// and updates the account's stored featured tags to match it. If the account no longer advertises
+// a featured tags collection, stored featured tags are removed. Collections hidden from us are
+// skipped silently, leaving stored featured tags as they were.
+func (d *deref) dereferenceAccountFeaturedTags(ctx context.Context, requestUser string, account *gtsmodel.Account) error {
+	if account.FeaturedTagsURI == "" {
+		// Nothing (or nothing anymore) to feature.
+		return d.state.DB.DeleteAccountFeaturedTags(ctx, account.ID)
+	}
+
+	uri, err := url.Parse(account.FeaturedTagsURI)
+	if err != nil {
+		return err
+	}
+
+	// Pre-fetch a transport for requesting username, used by later deref procedures.
+	tsport, err := d.transportController.NewTransportForUsername(ctx, requestUser)
+	if err != nil {
+		return gtserror.Newf("couldn't create transport: %w", err)
+	}
+
+	t, err := dereferenceType(ctx, tsport, uri)
+	if err != nil {
+		if collectionHidden(err) {
+			log.Debugf(ctx, "featured tags %s not available: %v", uri, err)
+			return nil
+		}
+		return err
+	}
+
+	// Mastodon serves an unordered Collection,
+	// but be lenient and accept ordered as well.
+	var types []vocab.Type
+	switch collection := t.(type) {
+	case vocab.ActivityStreamsCollection:
+		if items := collection.GetActivityStreamsItems(); items != nil {
+			for iter := items.Begin(); iter != items.End(); iter = iter.Next() {
+				types = append(types, iter.GetType())
+			}
+		}
+	case vocab.ActivityStreamsOrderedCollection:
+		if items := collection.GetActivityStreamsOrderedItems(); items != nil {
+			for iter := items.Begin(); iter != items.End(); iter = iter.Next() {
+				types = append(types, iter.GetType())
+			}
+		}
+	default:
+		return gtserror.Newf("%s was not a Collection or OrderedCollection", uri)
+	}
+
+	// Extract the featured hashtags, deduplicated by name.
+	tags := make([]*gtsmodel.Tag, 0, len(types))
+	names := make(map[string]struct{}, len(types))
+	for _, t := range types {
+		if len(tags) >= featuredTagsLimit {
+			break
+		}
+
+		if t == nil || t.GetTypeName() != ap.TagHashtag {
+			continue
+		}
+
+		hashtaggable, ok := t.(ap.Hashtaggable)
+		if !ok {
+			continue
+		}
+
+		tag, err := ap.ExtractHashtag(hashtaggable)
+		if err != nil {
+			log.Debugf(ctx, "invalid hashtag in featured tags %s: %v", uri, err)
+			continue
+		}
+
+		if _, ok := names[tag.Name]; ok {
+			continue
+		}
+
+		names[tag.Name] = struct{}{}
+		tags = append(tags, tag)
+	}
+
+	// Get previously featured tags, to
+	// work out which have been added or
+	// removed since we last looked.
+	wasFeatured, err := d.state.DB.GetAccountFeaturedTags(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting account featured tags: %w", err)
+	}
+
+	featured := make(map[string]struct{}, len(wasFeatured))
+	for _, featuredTag := range wasFeatured {
+		if _, ok := names[featuredTag.Name]; ok {
+			featured[featuredTag.Name] = struct{}{}
+			continue
+		}
+
+		// No longer featured.
+		if err := d.state.DB.DeleteFeaturedTagByID(ctx, featuredTag.ID); err != nil {
+			log.Errorf(ctx, "error deleting featured tag %s: %v", featuredTag.ID, err)
+		}
+	}
+
+	for _, tag := range tags {
+		if _, ok := featured[tag.Name]; ok {
+			// Already featured.
+			continue
+		}
+
+		featuredTag := &gtsmodel.FeaturedTag{
+			ID:          id.NewULID(),
+			AccountID:   account.ID,
+			Name:        tag.Name,
+			DisplayName: tag.DisplayName,
+			URL:         tag.URL,
+		}
+
+		if err := d.state.DB.PutFeaturedTag(ctx, featuredTag); err != nil {
+			log.Errorf(ctx, "error putting featured tag %s: %v", tag.Name, err)
+		}
+	}
+
+	return nil
+}
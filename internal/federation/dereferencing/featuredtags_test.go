@@ -0,0 +1,161 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type FeaturedTagsTestSuite struct {
+	DereferencerStandardTestSuite
+}
+
+const (
+	featuredTagsPersonURI = "https://unknown-instance.com/users/brand_new_person"
+	featuredTagsURI       = featuredTagsPersonURI + "/collections/tags"
+)
+
+// newFeaturedTagsDereferencer returns a dereferencer whose http client serves
+// brand_new_person with a featuredTags collection, which is answered with the
+// given status code and body. Any other request goes to the usual mock client.
+func (suite *FeaturedTagsTestSuite) newFeaturedTagsDereferencer(collectionCode int, collection string) dereferencing.Dereferencer {
+	mockClient := testrig.NewMockHTTPClient(nil, "../../../testrig/media")
+
+	httpClient := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		var (
+			code = http.StatusOK
+			body []byte
+		)
+
+		switch req.URL.String() {
+		case featuredTagsPersonURI:
+			personI, err := ap.Serialize(mockClient.TestRemotePeople[featuredTagsPersonURI])
+			if err != nil {
+				suite.FailNow(err.Error())
+			}
+			personI["featuredTags"] = featuredTagsURI
+
+			body, err = json.Marshal(personI)
+			if err != nil {
+				suite.FailNow(err.Error())
+			}
+		case featuredTagsURI:
+			code = collectionCode
+			body = []byte(collection)
+		default:
+			return mockClient.Do(req)
+		}
+
+		return &http.Response{
+			Request:       req,
+			StatusCode:    code,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Header: http.Header{
+				"content-type": {"application/activity+json"},
+			},
+		}, nil
+	}, "../../../testrig/media")
+
+	return dereferencing.NewDereferencer(
+		&suite.state,
+		testrig.NewTestTypeConverter(suite.db),
+		testrig.NewTestTransportController(&suite.state, httpClient),
+		testrig.NewTestMediaManager(&suite.state),
+	)
+}
+
+func (suite *FeaturedTagsTestSuite) TestDereferenceFeaturedTags() {
+	ctx := context.Background()
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	dereferencer := suite.newFeaturedTagsDereferencer(http.StatusOK, `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "`+featuredTagsURI+`",
+  "type": "Collection",
+  "totalItems": 3,
+  "items": [
+    {
+      "type": "Hashtag",
+      "href": "https://unknown-instance.com/tags/gardening",
+      "name": "#Gardening"
+    },
+    {
+      "type": "Hashtag",
+      "href": "https://unknown-instance.com/tags/frogs",
+      "name": "#frogs"
+    },
+    {
+      "type": "Mention",
+      "href": "https://unknown-instance.com/users/someone_else",
+      "name": "@someone_else"
+    }
+  ]
+}`)
+
+	account, _, err := dereferencer.GetAccountByURI(ctx, fetchingAccount.Username, testrig.URLMustParse(featuredTagsPersonURI))
+	suite.NoError(err)
+	suite.Equal(featuredTagsURI, account.FeaturedTagsURI)
+
+	// Featured tags are fetched asynchronously.
+	var featuredTags []*gtsmodel.FeaturedTag
+	if !testrig.WaitFor(func() bool {
+		featuredTags, _ = suite.state.DB.GetAccountFeaturedTags(ctx, account.ID)
+		return len(featuredTags) == 2
+	}) {
+		suite.FailNow("timed out waiting for featured tags")
+	}
+
+	suite.Equal("gardening", featuredTags[0].Name)
+	suite.Equal("Gardening", featuredTags[0].DisplayName)
+	suite.Equal("https://unknown-instance.com/tags/gardening", featuredTags[0].URL)
+	suite.Equal("frogs", featuredTags[1].Name)
+}
+
+func (suite *FeaturedTagsTestSuite) TestDereferenceFeaturedTagsNotFound() {
+	ctx := context.Background()
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	dereferencer := suite.newFeaturedTagsDereferencer(http.StatusNotFound, `{"error":"404 not found"}`)
+
+	// A missing featured tags collection
+	// shouldn't stop the account from
+	// being dereferenced.
+	account, _, err := dereferencer.GetAccountByURI(ctx, fetchingAccount.Username, testrig.URLMustParse(featuredTagsPersonURI))
+	suite.NoError(err)
+	suite.Equal(featuredTagsURI, account.FeaturedTagsURI)
+
+	featuredTags, err := suite.state.DB.GetAccountFeaturedTags(ctx, account.ID)
+	suite.NoError(err)
+	suite.Empty(featuredTags)
+}
+
+func TestFeaturedTagsTestSuite(t *testing.T) {
+	suite.Run(t, new(FeaturedTagsTestSuite))
+}
@@ -31,6 +31,16 @@ import (
 )
 
 func (d *deref) fingerRemoteAccount(ctx context.Context, transport transport.Transport, targetUsername string, targetHost string) (accountDomain string, accountURI *url.URL, err error) {
+	// Don't send webfinger requests to blocked domains.
+	blocked, err := d.state.DB.IsDomainBlocked(ctx, targetHost)
+	if err != nil {
+		err = fmt.Errorf("fingerRemoteAccount: error checking blocked domain %s: %w", targetHost, err)
+		return
+	} else if blocked {
+		err = fmt.Errorf("fingerRemoteAccount: %s is blocked", targetHost)
+		return
+	}
+
 	b, err := transport.Finger(ctx, targetUsername, targetHost)
 	if err != nil {
 		err = fmt.Errorf("fingerRemoteAccount: error fingering @%s@%s: %s", targetUsername, targetHost, err)
@@ -58,6 +68,19 @@ func (d *deref) fingerRemoteAccount(ctx context.Context, transport transport.Tra
 		err = fmt.Errorf("fingerRemoteAccount: error extracting webfinger subject parts: %s", err)
 	}
 
+	// The account domain may differ from the host we fingered,
+	// so make sure we're not being pointed at a blocked domain.
+	if accountDomain != "" && accountDomain != targetHost {
+		blocked, err = d.state.DB.IsDomainBlocked(ctx, accountDomain)
+		if err != nil {
+			err = fmt.Errorf("fingerRemoteAccount: error checking blocked domain %s: %w", accountDomain, err)
+			return "", nil, err
+		} else if blocked {
+			err = fmt.Errorf("fingerRemoteAccount: %s is blocked", accountDomain)
+			return "", nil, err
+		}
+	}
+
 	// look through the links for the first one that matches what we need
 	for _, l := range resp.Links {
 		if l.Rel == "self" && (strings.EqualFold(l.Type, "application/activity+json") || strings.EqualFold(l.Type, "application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\"")) {
@@ -0,0 +1,247 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+)
+
+// outboxBackfillLimit is the maximum number of statuses
+// backfilled from the outbox of a newly discovered account.
+const outboxBackfillLimit = 20
+
+func (d *deref) BackfillAccountAsync(ctx context.Context, requestUser string, account *gtsmodel.Account) {
+	if account.IsLocal() {
+		// Nothing to do.
+		return
+	}
+
+	d.state.Workers.Federator.MustEnqueueCtx(ctx, func(ctx context.Context) {
+		if err := d.dereferenceAccountOutbox(ctx, requestUser, account); err != nil {
+			log.Errorf(ctx, "error backfilling account outbox: %v", err)
+		}
+	})
+}
+
+// dereferenceAccountOutbox dereferences the first page of an account's outbox (if not empty), and fetches
+// up to outboxBackfillLimit of the statuses created by the account. Newly fetched statuses are marked as
+// backfilled, and go through federated create processing like any other status, except that they do not
+// generate notifications, and are not inserted into timelines. Outboxes hidden from us are skipped silently.
+func (d *deref) dereferenceAccountOutbox(ctx context.Context, requestUser string, account *gtsmodel.Account) error {
+	if account.OutboxURI == "" {
+		// Nothing to do.
+		return nil
+	}
+
+	// Get the local account that backfilled
+	// statuses are processed on behalf of;
+	// the instance account if none is given.
+	username := requestUser
+	if username == "" {
+		username = config.GetHost()
+	}
+
+	receivingAccount, err := d.state.DB.GetAccountByUsernameDomain(ctx, username, "")
+	if err != nil {
+		return gtserror.Newf("error getting account %s: %w", username, err)
+	}
+
+	uri, err := url.Parse(account.OutboxURI)
+	if err != nil {
+		return err
+	}
+
+	// Pre-fetch a transport for requesting username, used by later deref procedures.
+	tsport, err := d.transportController.NewTransportForUsername(ctx, requestUser)
+	if err != nil {
+		return gtserror.Newf("couldn't create transport: %w", err)
+	}
+
+	t, err := dereferenceType(ctx, tsport, uri)
+	if err != nil {
+		if collectionHidden(err) {
+			log.Debugf(ctx, "outbox %s not available: %v", uri, err)
+			return nil
+		}
+		return err
+	}
+
+	collection, ok := t.(vocab.ActivityStreamsOrderedCollection)
+	if !ok {
+		return gtserror.Newf("%s was not an OrderedCollection", uri)
+	}
+
+	first := collection.GetActivityStreamsFirst()
+	if first == nil {
+		// No pages exposed to us.
+		return nil
+	}
+
+	// The first page may be embedded in
+	// the collection, else dereference it.
+	page := first.GetActivityStreamsOrderedCollectionPage()
+	if page == nil && first.IsIRI() {
+		pageURI := first.GetIRI()
+		if pageURI.Host != uri.Host {
+			return gtserror.Newf("outbox page %s not on outbox host %s", pageURI, uri.Host)
+		}
+
+		t, err := dereferenceType(ctx, tsport, pageURI)
+		if err != nil {
+			if collectionHidden(err) {
+				log.Debugf(ctx, "outbox page %s not available: %v", pageURI, err)
+				return nil
+			}
+			return err
+		}
+
+		page, ok = t.(vocab.ActivityStreamsOrderedCollectionPage)
+		if !ok {
+			return gtserror.Newf("%s was not an OrderedCollectionPage", pageURI)
+		}
+	}
+
+	if page == nil {
+		return gtserror.Newf("couldn't get first page of %s", uri)
+	}
+
+	items := page.GetActivityStreamsOrderedItems()
+	if items == nil {
+		// Empty outbox.
+		return nil
+	}
+
+	// Mark statuses that are new to
+	// us as backfilled when fetched.
+	ctx = gtscontext.SetBackfill(ctx)
+
+	var backfilled int
+	for iter := items.Begin(); iter != items.End(); iter = iter.Next() {
+		if backfilled >= outboxBackfillLimit {
+			break
+		}
+
+		if !iter.IsActivityStreamsCreate() {
+			// Only interested in statuses
+			// authored by this account, so
+			// skip Announces and the like.
+			continue
+		}
+
+		statusURI, err := ap.ExtractObjectURI(iter.GetActivityStreamsCreate())
+		if err != nil {
+			continue
+		}
+
+		if statusURI.Host != uri.Host {
+			// If this status doesn't share a host with
+			// the outbox URI, we shouldn't trust it.
+			continue
+		}
+
+		backfilled++
+
+		if _, err := d.state.DB.GetStatusByURI(
+			gtscontext.SetBarebones(ctx),
+			statusURI.String(),
+		); err == nil {
+			// We already have this status,
+			// it's been processed before.
+			continue
+		} else if !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "error checking for status %s: %v", statusURI, err)
+			continue
+		}
+
+		status, _, err := d.getStatusByURI(ctx, requestUser, statusURI)
+		if err != nil {
+			// We couldn't get the status, just log + move on.
+			log.Errorf(ctx, "error getting status from outbox %s: %v", statusURI, err)
+			continue
+		}
+
+		if !status.IsBackfilled() {
+			// This status was fetched in the
+			// meantime by other means, and is
+			// being processed as normal.
+			continue
+		}
+
+		// Process side effects of the new status
+		// (thread, preview card, etc) as normal.
+		d.state.Workers.EnqueueFederator(ctx, messages.FromFederator{
+			APObjectType:     ap.ObjectNote,
+			APActivityType:   ap.ActivityCreate,
+			GTSModel:         status,
+			ReceivingAccount: receivingAccount,
+		})
+	}
+
+	return nil
+}
+
+// dereferenceType dereferences the given IRI using
+// transport, resolving the response into an AP type.
+func dereferenceType(ctx context.Context, tsport transport.Transport, iri *url.URL) (vocab.Type, error) {
+	b, err := tsport.Dereference(ctx, iri)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, gtserror.Newf("error unmarshalling bytes into json: %w", err)
+	}
+
+	t, err := streams.ToType(ctx, m)
+	if err != nil {
+		return nil, gtserror.Newf("error resolving json into ap vocab type: %w", err)
+	}
+
+	return t, nil
+}
+
+// collectionHidden returns whether the given dereference error
+// indicates that a remote collection is missing, or hidden from us.
+func collectionHidden(err error) bool {
+	switch gtserror.StatusCode(err) {
+	case http.StatusUnauthorized,
+		http.StatusForbidden,
+		http.StatusNotFound,
+		http.StatusGone:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,270 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type OutboxTestSuite struct {
+	DereferencerStandardTestSuite
+}
+
+const (
+	outboxPersonURI = "https://unknown-instance.com/users/brand_new_person"
+	outboxURI       = outboxPersonURI + "/outbox"
+	outboxStatus1   = outboxPersonURI + "/statuses/01FE4NTHKWW7THT67EF10EB839"
+	outboxStatus2   = outboxPersonURI + "/statuses/01FE5Y30E3W4P7TRE0R98KAYQV"
+	outboxForeign   = "http://fossbros-anonymous.io/users/foss_satan/statuses/106221634728637552"
+)
+
+// newOutboxDereferencer returns a dereferencer whose http client answers
+// requests for the outbox of brand_new_person with the given status code
+// and body, and counts them. Any other request goes to the usual mock client.
+func (suite *OutboxTestSuite) newOutboxDereferencer(outboxCode int, outbox string, hits *int32) dereferencing.Dereferencer {
+	mockClient := testrig.NewMockHTTPClient(nil, "../../../testrig/media")
+
+	httpClient := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.String() != outboxURI {
+			return mockClient.Do(req)
+		}
+
+		atomic.AddInt32(hits, 1)
+		body := []byte(outbox)
+		return &http.Response{
+			Request:       req,
+			StatusCode:    outboxCode,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Header: http.Header{
+				"content-type": {"application/activity+json"},
+			},
+		}, nil
+	}, "../../../testrig/media")
+
+	return dereferencing.NewDereferencer(
+		&suite.state,
+		testrig.NewTestTypeConverter(suite.db),
+		testrig.NewTestTransportController(&suite.state, httpClient),
+		testrig.NewTestMediaManager(&suite.state),
+	)
+}
+
+// captureFederator replaces the federator enqueue function of
+// the suite state with one that collects messages, returned by
+// the returned func, instead of processing them.
+func (suite *OutboxTestSuite) captureFederator() func() []messages.FromFederator {
+	var (
+		mu   sync.Mutex
+		msgs []messages.FromFederator
+	)
+
+	suite.state.Workers.EnqueueFederator = func(_ context.Context, m ...messages.FromFederator) {
+		mu.Lock()
+		msgs = append(msgs, m...)
+		mu.Unlock()
+	}
+
+	return func() []messages.FromFederator {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]messages.FromFederator(nil), msgs...)
+	}
+}
+
+func (suite *OutboxTestSuite) TestBackfillNewAccount() {
+	var (
+		ctx             = context.Background()
+		fetchingAccount = suite.testAccounts["local_account_1"]
+		hits            int32
+		captured        = suite.captureFederator()
+	)
+
+	// First page is embedded, with an Announce and
+	// a Create of a status on another host, which
+	// should both be skipped.
+	dereferencer := suite.newOutboxDereferencer(http.StatusOK, `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "`+outboxURI+`",
+  "type": "OrderedCollection",
+  "totalItems": 4,
+  "first": {
+    "id": "`+outboxURI+`?page=true",
+    "type": "OrderedCollectionPage",
+    "partOf": "`+outboxURI+`",
+    "orderedItems": [
+      {
+        "id": "`+outboxStatus2+`/activity",
+        "type": "Create",
+        "actor": "`+outboxPersonURI+`",
+        "object": "`+outboxStatus2+`"
+      },
+      {
+        "id": "`+outboxPersonURI+`/statuses/01FE5Y30E3W4P7TRE0R98KAYQX/activity",
+        "type": "Announce",
+        "actor": "`+outboxPersonURI+`",
+        "object": "`+outboxForeign+`"
+      },
+      {
+        "id": "`+outboxForeign+`/activity",
+        "type": "Create",
+        "actor": "`+outboxPersonURI+`",
+        "object": "`+outboxForeign+`"
+      },
+      {
+        "id": "`+outboxStatus1+`/activity",
+        "type": "Create",
+        "actor": "`+outboxPersonURI+`",
+        "object": "`+outboxStatus1+`"
+      }
+    ]
+  }
+}`, &hits)
+
+	account, _, err := dereferencer.GetAccountByURI(ctx, fetchingAccount.Username, testrig.URLMustParse(outboxPersonURI))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Backfilling the newly discovered account is done
+	// asynchronously: wait for both statuses to be
+	// enqueued for federated create processing.
+	if !testrig.WaitFor(func() bool { return len(captured()) == 2 }) {
+		suite.FailNow("timed out waiting for backfilled statuses")
+	}
+
+	msgs := captured()
+	for i, uri := range []string{outboxStatus2, outboxStatus1} {
+		msg := msgs[i]
+		suite.Equal(ap.ObjectNote, msg.APObjectType)
+		suite.Equal(ap.ActivityCreate, msg.APActivityType)
+		suite.Equal(fetchingAccount.ID, msg.ReceivingAccount.ID)
+
+		status, ok := msg.GTSModel.(*gtsmodel.Status)
+		if !ok {
+			suite.FailNow("", "expected *gtsmodel.Status, got %T", msg.GTSModel)
+		}
+		suite.Equal(uri, status.URI)
+		suite.Equal(account.ID, status.AccountID)
+
+		// The stored status is marked as backfilled.
+		dbStatus, err := suite.db.GetStatusByURI(ctx, uri)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.True(dbStatus.IsBackfilled())
+	}
+
+	// Neither the boosted status, nor the
+	// status on another host, were fetched.
+	_, err = suite.db.GetStatusByURI(ctx, outboxForeign)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.EqualValues(1, atomic.LoadInt32(&hits))
+
+	// Backfilling again fetches the outbox, but doesn't
+	// enqueue the statuses which we already have again.
+	dereferencer.BackfillAccountAsync(ctx, fetchingAccount.Username, account)
+	if !testrig.WaitFor(func() bool { return atomic.LoadInt32(&hits) == 2 }) {
+		suite.FailNow("timed out waiting for outbox to be fetched again")
+	}
+	suite.Len(captured(), 2)
+}
+
+func (suite *OutboxTestSuite) TestBackfillLookedUpStatusNotMarked() {
+	var (
+		ctx             = context.Background()
+		fetchingAccount = suite.testAccounts["local_account_1"]
+		hits            int32
+		_               = suite.captureFederator()
+	)
+
+	dereferencer := suite.newOutboxDereferencer(http.StatusNotFound, "", &hits)
+
+	// A status that's looked up rather
+	// than backfilled shouldn't be marked.
+	status, _, err := dereferencer.GetStatusByURI(ctx, fetchingAccount.Username, testrig.URLMustParse(outboxStatus1))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(status.IsBackfilled())
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(dbStatus.IsBackfilled())
+}
+
+func (suite *OutboxTestSuite) TestBackfillHiddenOutbox() {
+	var (
+		ctx             = context.Background()
+		fetchingAccount = suite.testAccounts["local_account_1"]
+		hits            int32
+		captured        = suite.captureFederator()
+	)
+
+	for _, code := range []int{
+		http.StatusUnauthorized,
+		http.StatusForbidden,
+		http.StatusNotFound,
+		http.StatusGone,
+	} {
+		atomic.StoreInt32(&hits, 0)
+		dereferencer := suite.newOutboxDereferencer(code, `{"error":"nope"}`, &hits)
+
+		account, err := suite.db.GetAccountByURI(ctx, outboxPersonURI)
+		if err != nil {
+			// Discover the account the first time
+			// round, which triggers the backfill.
+			account, _, err = dereferencer.GetAccountByURI(ctx, fetchingAccount.Username, testrig.URLMustParse(outboxPersonURI))
+			if err != nil {
+				suite.FailNow(err.Error())
+			}
+		} else {
+			dereferencer.BackfillAccountAsync(ctx, fetchingAccount.Username, account)
+		}
+
+		if !testrig.WaitFor(func() bool { return atomic.LoadInt32(&hits) == 1 }) {
+			suite.FailNow("", "timed out waiting for outbox to be fetched (%d)", code)
+		}
+
+		// Nothing was backfilled.
+		suite.Empty(captured())
+		count, err := suite.db.CountAccountStatuses(ctx, account.ID)
+		suite.NoError(err)
+		suite.Zero(count)
+	}
+}
+
+func TestOutboxTestSuite(t *testing.T) {
+	suite.Run(t, new(OutboxTestSuite))
+}
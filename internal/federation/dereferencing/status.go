@@ -34,6 +34,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"golang.org/x/exp/slices"
 )
 
 // statusUpToDate returns whether the given status model is both updateable
@@ -264,6 +266,11 @@ func (d *deref) enrichStatus(
 	// ActivityPub model was recently dereferenced, so assume that passed status
 	// may contain out-of-date information, convert AP model to our GTS model.
 	latestStatus, err := d.typeConverter.ASStatusToStatus(ctx, apubStatus)
+	if errors.Is(err, typeutils.ErrThreadTooDeep) {
+		// We're configured to drop replies this deep,
+		// so treat the status as if it can't be fetched.
+		return nil, nil, gtserror.SetUnretrievable(err)
+	}
 	if err != nil {
 		return nil, nil, gtserror.Newf("error converting statusable to gts model for status %s: %w", uri, err)
 	}
@@ -282,6 +289,27 @@ func (d *deref) enrichStatus(
 	// Carry-over values and set fetch time.
 	latestStatus.FetchedAt = time.Now()
 	latestStatus.Local = status.Local
+	latestStatus.PendingApproval = status.PendingApproval
+	latestStatus.ApprovedByURI = status.ApprovedByURI
+	latestStatus.Backfilled = status.Backfilled
+
+	if status.CreatedAt.IsZero() && gtscontext.Backfill(ctx) {
+		// This is a new status, fetched to backfill
+		// the recent statuses of its author: mark it
+		// so its creation isn't treated as new.
+		backfilled := true
+		latestStatus.Backfilled = &backfilled
+	}
+
+	if status.CreatedAt.IsZero() && latestStatus.InReplyTo != nil &&
+		latestStatus.InReplyTo.RequiresApproval(gtsmodel.InteractionReply, latestStatus.AccountID) {
+		// This is a new reply to a local status whose author
+		// needs to approve replies; keep it hidden from others
+		// until they do. The interaction request itself gets
+		// created when side effects of the reply are processed.
+		pending := true
+		latestStatus.PendingApproval = &pending
+	}
 
 	// Ensure the status' mentions are populated, and pass in existing to check for changes.
 	if err := d.fetchStatusMentions(ctx, requestUser, status, latestStatus); err != nil {
@@ -300,6 +328,11 @@ func (d *deref) enrichStatus(
 		return nil, nil, gtserror.Newf("error populating emojis for status %s: %w", uri, err)
 	}
 
+	// Ensure the status' poll is stored, passing in existing to update vote counts.
+	if err := d.handleStatusPoll(ctx, status, latestStatus); err != nil {
+		return nil, nil, gtserror.Newf("error handling poll for status %s: %w", uri, err)
+	}
+
 	if status.CreatedAt.IsZero() {
 		// CreatedAt will be zero if no local copy was
 		// found in one of the GetStatusBy___() functions.
@@ -316,7 +349,32 @@ func (d *deref) enrichStatus(
 		if err != nil {
 			return nil, nil, gtserror.Newf("error putting in database: %w", err)
 		}
+
+		if latestStatus.IsPendingApproval() {
+			// Store a request for the reply's approval.
+			if err := d.state.DB.PutInteractionRequest(ctx, &gtsmodel.InteractionRequest{
+				ID:                   id.NewULID(),
+				StatusID:             latestStatus.InReplyToID,
+				TargetAccountID:      latestStatus.InReplyToAccountID,
+				InteractingAccountID: latestStatus.AccountID,
+				InteractionType:      gtsmodel.InteractionReply,
+				InteractionID:        latestStatus.ID,
+				InteractionURI:       latestStatus.URI,
+			}); err != nil {
+				return nil, nil, gtserror.Newf("error putting interaction request in database: %w", err)
+			}
+		}
 	} else {
+		// Counts not included in the latest model (e.g. only
+		// an IRI was given for the collection) keep the last
+		// known values, so that counts never drop back to 0.
+		if latestStatus.FaveCountRemote == 0 {
+			latestStatus.FaveCountRemote = status.FaveCountRemote
+		}
+		if latestStatus.BoostCountRemote == 0 {
+			latestStatus.BoostCountRemote = status.BoostCountRemote
+		}
+
 		// This is an existing status, update the model in the database.
 		if err := d.state.DB.UpdateStatus(ctx, latestStatus); err != nil {
 			return nil, nil, gtserror.Newf("error updating database: %w", err)
@@ -401,6 +459,19 @@ func (d *deref) fetchStatusMentions(ctx context.Context, requestUser string, exi
 }
 
 func (d *deref) fetchStatusAttachments(ctx context.Context, tsport transport.Transport, existing, status *gtsmodel.Status) error {
+	if len(status.Attachments) > 0 {
+		// Media from domains blocked with reject_media
+		// isn't fetched, so drop the attachments entirely.
+		domainBlock, err := d.state.DB.GetDomainBlock(ctx, status.Account.Domain)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting domain block for %s: %w", status.Account.Domain, err)
+		}
+
+		if domainBlock != nil && *domainBlock.RejectMedia {
+			status.Attachments = nil
+		}
+	}
+
 	// Allocate new slice to take the yet-to-be fetched attachment IDs.
 	status.AttachmentIDs = make([]string, len(status.Attachments))
 
@@ -464,6 +535,55 @@ func (d *deref) fetchStatusAttachments(ctx context.Context, tsport transport.Tra
 	return nil
 }
 
+// handleStatusPoll stores the poll (if any) of the given status, or,
+// if the existing status already has a poll with the same options,
+// updates that with the latest vote counts and expiry instead.
+func (d *deref) handleStatusPoll(ctx context.Context, existing, status *gtsmodel.Status) error {
+	if status.Poll == nil {
+		// No poll (anymore).
+		return nil
+	}
+
+	if existing.PollID != "" {
+		poll, err := d.state.DB.GetPollByID(ctx, existing.PollID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("error getting existing poll %s: %w", existing.PollID, err)
+		}
+
+		if poll != nil && slices.Equal(poll.Options, status.Poll.Options) {
+			// Same poll, just update the counts.
+			poll.Votes = status.Poll.Votes
+			poll.Voters = status.Poll.Voters
+			poll.Multiple = status.Poll.Multiple
+			poll.ExpiresAt = status.Poll.ExpiresAt
+
+			if err := d.state.DB.UpdatePoll(ctx, poll,
+				"votes",
+				"voters",
+				"multiple",
+				"expires_at",
+			); err != nil {
+				return gtserror.Newf("error updating poll %s: %w", poll.ID, err)
+			}
+
+			status.Poll = poll
+			status.PollID = poll.ID
+			return nil
+		}
+	}
+
+	// This is a new poll (or its
+	// options changed), store it.
+	status.Poll.ID = id.NewULID()
+	status.Poll.StatusID = status.ID
+	if err := d.state.DB.PutPoll(ctx, status.Poll); err != nil {
+		return gtserror.Newf("error putting poll: %w", err)
+	}
+	status.PollID = status.Poll.ID
+
+	return nil
+}
+
 func (d *deref) fetchStatusEmojis(ctx context.Context, requestUser string, existing, status *gtsmodel.Status) error {
 	// Fetch the full-fleshed-out emoji objects for our status.
 	emojis, err := d.populateEmojis(ctx, status.Emojis, requestUser)
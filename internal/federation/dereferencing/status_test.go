@@ -19,12 +19,14 @@ package dereferencing_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -168,6 +170,45 @@ func (suite *StatusTestSuite) TestDereferenceStatusWithImageAndNoContent() {
 	suite.NoError(err)
 }
 
+func (suite *StatusTestSuite) TestDereferenceStatusWithEmojis() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	statusURL := testrig.URLMustParse("http://fossbros-anonymous.io/users/foss_satan/statuses/01HE7XJ1CG84TBKH5V9XKBVGF5")
+	status, _, err := suite.dereferencer.GetStatusByURI(context.Background(), fetchingAccount.Username, statusURL)
+	suite.NoError(err)
+	suite.NotNil(status)
+	suite.Equal("<p>look at these emojis :kip_van_den_bos: :yell:</p>", status.Content)
+
+	// both emojis should be attached to the status: kip
+	// is new so should have been downloaded, yell should
+	// have been taken from the database as we know it already
+	suite.Len(status.EmojiIDs, 2)
+	suite.Len(status.Emojis, 2)
+
+	kip, err := suite.db.GetEmojiByShortcodeDomain(context.Background(), "kip_van_den_bos", "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.Equal("http://fossbros-anonymous.io/emoji/kip.gif", kip.ImageRemoteURL)
+	suite.Contains(status.EmojiIDs, kip.ID)
+	suite.Contains(status.EmojiIDs, suite.testEmojis["yell"].ID)
+
+	// the frontend model should contain both emojis,
+	// pointing to our local copies of the images
+	apiStatus, err := testrig.NewTestTypeConverter(suite.db).StatusToAPIStatus(context.Background(), status, fetchingAccount)
+	suite.NoError(err)
+	suite.Len(apiStatus.Emojis, 2)
+	for _, emoji := range apiStatus.Emojis {
+		suite.True(strings.HasPrefix(emoji.URL, "http://localhost:8080/fileserver/"))
+		suite.True(strings.HasPrefix(emoji.StaticURL, "http://localhost:8080/fileserver/"))
+	}
+
+	// shortcodes in the content should be replaced
+	// with images when rendered with the emojis
+	emojified := text.Emojify(apiStatus.Emojis, apiStatus.Content)
+	suite.Equal(2, strings.Count(emojified, "<img "))
+	suite.Contains(emojified, `title=":yell:"`)
+	suite.Contains(emojified, `title=":kip_van_den_bos:"`)
+}
+
 func TestStatusTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusTestSuite))
 }
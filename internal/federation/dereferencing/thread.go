@@ -150,10 +150,37 @@ func (d *deref) DereferenceStatusAncestors(
 			// Update current status with new info.
 			current.InReplyToID = parent.ID
 			current.InReplyToAccountID = parent.AccountID
+			current.ThreadDepth = parent.ThreadDepth + 1
+
+			if threadTooDeep(current) {
+				// The current status is already stored, so it's too late
+				// to reject it; detach it from the thread instead, and
+				// stop here, as its ancestors are beyond our max depth.
+				l.Debugf("detaching status at thread depth %d", current.ThreadDepth)
+
+				threadTruncated := true
+				current.InReplyToURI = ""
+				current.InReplyToID = ""
+				current.InReplyToAccountID = ""
+				current.ThreadTruncated = &threadTruncated
+				if err := d.state.DB.UpdateStatus(
+					ctx, current,
+					"in_reply_to_uri",
+					"in_reply_to_id",
+					"in_reply_to_account_id",
+					"thread_depth",
+					"thread_truncated",
+				); err != nil {
+					return gtserror.Newf("db error updating status %s: %w", current.ID, err)
+				}
+				return nil
+			}
+
 			if err := d.state.DB.UpdateStatus(
 				ctx, current,
 				"in_reply_to_id",
 				"in_reply_to_account_id",
+				"thread_depth",
 			); err != nil {
 				return gtserror.Newf("db error updating status %s: %w", current.ID, err)
 			}
@@ -204,6 +231,18 @@ func (d *deref) DereferenceStatusAncestors(
 	return gtserror.Newf("reached %d ancestor iterations for %q", maxIter, status.URI)
 }
 
+// threadTooDeep returns whether the given status is a
+// remote reply deeper than the configured max thread depth.
+func threadTooDeep(status *gtsmodel.Status) bool {
+	maxDepth := config.GetStatusesMaxThreadDepth()
+	if maxDepth <= 0 || status.ThreadDepth <= maxDepth {
+		return false
+	}
+
+	// Replies by local accounts are always allowed.
+	return status.Local == nil || !*status.Local
+}
+
 func (d *deref) DereferenceStatusDescendants(ctx context.Context, username string, statusIRI *url.URL, parent ap.Statusable) error {
 	// Take ref to original
 	ogIRI := statusIRI
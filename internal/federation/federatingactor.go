@@ -18,7 +18,10 @@
 package federation
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -32,6 +35,7 @@ import (
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -128,6 +132,14 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 		return false, gtserror.NewErrorNotAcceptable(err)
 	}
 
+	// Reject bodies we already know are too large before
+	// going to the trouble of checking the http signature.
+	maxBodySize := int64(config.GetAdvancedInboxMaxBodySize())
+	if r.ContentLength > maxBodySize {
+		err := fmt.Errorf("Content-Length %d exceeds max inbox body size", r.ContentLength)
+		return false, gtserror.NewErrorRequestEntityTooLarge(err)
+	}
+
 	// Authenticate request by checking http signature.
 	ctx, authenticated, err := f.sideEffectActor.AuthenticatePostInbox(ctx, w, r)
 	if err != nil {
@@ -145,7 +157,7 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 	*/
 
 	// Obtain the activity; reject unknown activities.
-	activity, errWithCode := resolveActivity(ctx, r)
+	activity, errWithCode := resolveActivity(ctx, w, r, maxBodySize)
 	if errWithCode != nil {
 		return false, errWithCode
 	}
@@ -244,20 +256,54 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 
 // resolveActivity is a util function for pulling a
 // pub.Activity type out of an incoming POST request.
-func resolveActivity(ctx context.Context, r *http.Request) (pub.Activity, gtserror.WithCode) {
+//
+// The request body is decoded as it's read, and reading
+// stops with 413 once more than maxBodySize bytes have
+// been read, as Content-Length can't be relied upon.
+func resolveActivity(ctx context.Context, w http.ResponseWriter, r *http.Request, maxBodySize int64) (pub.Activity, gtserror.WithCode) {
+	body := http.MaxBytesReader(w, r.Body, maxBodySize)
+
 	// Tidy up when done.
-	defer r.Body.Close()
+	defer body.Close()
+
+	// Keep a copy of the body as it's read; we may
+	// need it to derive an ID for the activity below.
+	var buf bytes.Buffer
+	tee := io.TeeReader(body, &buf)
+
+	var rawActivity map[string]interface{}
+	err := json.NewDecoder(tee).Decode(&rawActivity)
+	if err == nil {
+		// Read whatever trails the decoded
+		// value, so the copy is the full body.
+		_, err = io.Copy(io.Discard, tee)
+	}
 
-	b, err := io.ReadAll(r.Body)
 	if err != nil {
-		err = fmt.Errorf("error reading request body: %w", err)
-		return nil, gtserror.NewErrorInternalError(err)
+		if errors.As(err, new(*http.MaxBytesError)) {
+			err = fmt.Errorf("request body exceeds max inbox body size: %w", err)
+			return nil, gtserror.NewErrorRequestEntityTooLarge(err)
+		}
+
+		err = fmt.Errorf("error decoding request body: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err)
 	}
 
-	var rawActivity map[string]interface{}
-	if err := json.Unmarshal(b, &rawActivity); err != nil {
-		err = fmt.Errorf("error unmarshalling request body: %w", err)
-		return nil, gtserror.NewErrorInternalError(err)
+	b := buf.Bytes()
+
+	if rawActivity["type"] == ap.ActivityPlay {
+		// Play isn't part of the ActivityStreams
+		// vocabulary, but it's used by some music
+		// scrobblers to mean the same as Listen.
+		rawActivity["type"] = ap.ActivityListen
+	}
+
+	if rawActivity["type"] == ap.ActivityBite {
+		// Bite isn't part of the ActivityStreams vocabulary
+		// either, so go-fed can't resolve it. Resolve it as
+		// a plain Activity instead, but keep 'Bite' as a
+		// second type so it can be picked out again later.
+		rawActivity["type"] = []interface{}{ap.ActivityActivity, ap.ActivityBite}
 	}
 
 	t, err := streams.ToType(ctx, rawActivity)
@@ -281,8 +327,20 @@ func resolveActivity(ctx context.Context, r *http.Request) (pub.Activity, gtserr
 	}
 
 	if activity.GetJSONLDId() == nil {
-		err = fmt.Errorf("incoming Activity %s did not have required id property set", activity.GetTypeName())
-		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		switch activity.GetTypeName() {
+		case ap.ActivityAdd, ap.ActivityRemove:
+			// Mastodon doesn't set an id on the Add / Remove
+			// activities it sends for featured collection
+			// updates, so derive a stable one from the body,
+			// as the activity library needs an id to lock on
+			// while handling the activity.
+			if err := setDerivedID(activity, b); err != nil {
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+		default:
+			err = fmt.Errorf("incoming Activity %s did not have required id property set", activity.GetTypeName())
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
 	}
 
 	// If activity Object is a Statusable, we'll want to replace the
@@ -294,6 +352,28 @@ func resolveActivity(ctx context.Context, r *http.Request) (pub.Activity, gtserr
 	return activity, nil
 }
 
+// setDerivedID sets an id on the given activity, in the form
+// `{actorURI}#{type}/{hash of body}`, for activities that were
+// received without an id of their own.
+func setDerivedID(activity pub.Activity, body []byte) error {
+	actorURI, err := ap.ExtractActorURI(activity)
+	if err != nil {
+		return fmt.Errorf("incoming Activity %s had neither id nor actor set", activity.GetTypeName())
+	}
+
+	sum := sha256.Sum256(body)
+
+	id := new(url.URL)
+	*id = *actorURI
+	id.Fragment = strings.ToLower(activity.GetTypeName()) + "/" + hex.EncodeToString(sum[:16])
+
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(id)
+	activity.SetJSONLDId(idProp)
+
+	return nil
+}
+
 /*
 	Functions below are just lightly wrapped versions
 	of the original go-fed federatingActor functions.
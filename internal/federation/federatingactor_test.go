@@ -21,12 +21,18 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -148,6 +154,26 @@ func (suite *FederatingActorTestSuite) TestSendRemoteFollower() {
 }`, dst.String())
 }
 
+func (suite *FederatingActorTestSuite) TestPostInboxBodyTooLarge() {
+	config.SetAdvancedInboxMaxBodySize(1024)
+	receivingAccount := suite.testAccounts["local_account_1"]
+
+	// Body is too large, but otherwise fine: it
+	// should be rejected before even authenticating.
+	body := `{"type":"Create","padding":"` + strings.Repeat("a", 1024) + `"}`
+	request := httptest.NewRequest(http.MethodPost, receivingAccount.InboxURI, strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/activity+json")
+
+	recorder := httptest.NewRecorder()
+	_, err := suite.federator.FederatingActor().PostInbox(context.Background(), recorder, request)
+
+	var errWithCode gtserror.WithCode
+	if !errors.As(err, &errWithCode) {
+		suite.FailNow("", "expected gtserror.WithCode, got %v", err)
+	}
+	suite.Equal(http.StatusRequestEntityTooLarge, errWithCode.Code())
+}
+
 func TestFederatingActorTestSuite(t *testing.T) {
 	suite.Run(t, new(FederatingActorTestSuite))
 }
@@ -0,0 +1,143 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"codeberg.org/gruf/go-logger/v2/level"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Add handles an incoming Add activity. The only Adds we currently care
+// about are a remote account adding one of its statuses to its featured
+// collection (ie., pinning it), in which case the status is marked as pinned.
+func (f *federatingDB) Add(ctx context.Context, add vocab.ActivityStreamsAdd) error {
+	if log.Level() >= level.DEBUG {
+		i, err := marshalItem(add)
+		if err != nil {
+			return err
+		}
+		l := log.WithContext(ctx).
+			WithField("add", i)
+		l.Debug("entering Add")
+	}
+
+	_, requestingAccount, internal := extractFromCtx(ctx)
+	if internal {
+		return nil // Already processed.
+	}
+
+	statuses, err := f.featuredStatuses(ctx, requestingAccount, add)
+	if err != nil {
+		return gtserror.Newf("error getting featured statuses: %w", err)
+	}
+
+	for _, status := range statuses {
+		if !status.PinnedAt.IsZero() {
+			// Already pinned.
+			continue
+		}
+
+		status.PinnedAt = time.Now()
+		if err := f.state.DB.UpdateStatus(ctx, status, "pinned_at"); err != nil {
+			return gtserror.Newf("error pinning status %s: %w", status.URI, err)
+		}
+	}
+
+	return nil
+}
+
+// featuredStatuses returns the statuses that are the object of the given
+// Add / Remove activity, if the activity targets the featured collection
+// of the requesting account. Only statuses that we already have stored,
+// and that were authored by (and are not boosts by) the requesting account
+// are returned. Statuses we don't have yet are skipped; they'll be picked
+// up the next time the account's featured collection is dereferenced.
+func (f *federatingDB) featuredStatuses(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	activity interface {
+		ap.WithObject
+		ap.WithTarget
+	},
+) ([]*gtsmodel.Status, error) {
+	targetURIs, err := ap.ExtractTargetURIs(activity)
+	if err != nil {
+		return nil, err
+	}
+
+	var targetsFeatured bool
+	for _, targetURI := range targetURIs {
+		if requestingAccount.FeaturedCollectionURI != "" &&
+			targetURI.String() == requestingAccount.FeaturedCollectionURI {
+			targetsFeatured = true
+			break
+		}
+	}
+
+	if !targetsFeatured {
+		// Not something we handle (yet).
+		log.Debugf(ctx, "target(s) %v not featured collection of %s, ignoring", targetURIs, requestingAccount.URI)
+		return nil, nil
+	}
+
+	objectURIs, err := ap.ExtractObjectURIs(activity)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*gtsmodel.Status, 0, len(objectURIs))
+	for _, objectURI := range objectURIs {
+		status, err := f.state.DB.GetStatusByURI(ctx, objectURI.String())
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				return nil, gtserror.Newf("db error getting status %s: %w", objectURI, err)
+			}
+
+			// Either this is a featured hashtag
+			// or similar, or a status we don't
+			// have yet; either way, skip it.
+			log.Debugf(ctx, "no status found with uri %s, skipping", objectURI)
+			continue
+		}
+
+		if status.AccountID != requestingAccount.ID {
+			// Someone's trying to feature a status
+			// that doesn't belong to them; not ok.
+			log.Debugf(ctx, "status %s not owned by %s, skipping", status.URI, requestingAccount.URI)
+			continue
+		}
+
+		if status.BoostOfID != "" {
+			// Boosts can't be pinned.
+			continue
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
@@ -0,0 +1,147 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+)
+
+type AddTestSuite struct {
+	FederatingDBTestSuite
+}
+
+// addJSON is an Add for a featured collection as generated by Mastodon;
+// note that Mastodon doesn't set an id on these activities.
+const addJSON = `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "Add",
+  "actor": "http://fossbros-anonymous.io/users/foss_satan",
+  "object": "http://fossbros-anonymous.io/users/foss_satan/statuses/01FVW7JHQFSFK166WWKR8CBA6M",
+  "target": "http://fossbros-anonymous.io/users/foss_satan/collections/featured"
+}`
+
+func (suite *AddTestSuite) addFromJSON(raw string) vocab.ActivityStreamsAdd {
+	m := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t, err := streams.ToType(context.Background(), m)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	add, ok := t.(vocab.ActivityStreamsAdd)
+	if !ok {
+		suite.FailNow("type was not ActivityStreamsAdd")
+	}
+
+	return add
+}
+
+func (suite *AddTestSuite) TestAddFeatured() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	testStatus := suite.testStatuses["remote_account_1_status_1"]
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	err := suite.federatingDB.Add(ctx, suite.addFromJSON(addJSON))
+	suite.NoError(err)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, testStatus.ID)
+	suite.NoError(err)
+	suite.False(dbStatus.PinnedAt.IsZero())
+
+	// Adding again should be a no-op.
+	pinnedAt := dbStatus.PinnedAt
+	err = suite.federatingDB.Add(ctx, suite.addFromJSON(addJSON))
+	suite.NoError(err)
+
+	dbStatus, err = suite.db.GetStatusByID(ctx, testStatus.ID)
+	suite.NoError(err)
+	suite.True(pinnedAt.Equal(dbStatus.PinnedAt))
+}
+
+func (suite *AddTestSuite) TestAddNotFeaturedCollection() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	testStatus := suite.testStatuses["remote_account_1_status_1"]
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	err := suite.federatingDB.Add(ctx, suite.addFromJSON(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "Add",
+  "actor": "http://fossbros-anonymous.io/users/foss_satan",
+  "object": "http://fossbros-anonymous.io/users/foss_satan/statuses/01FVW7JHQFSFK166WWKR8CBA6M",
+  "target": "http://fossbros-anonymous.io/users/foss_satan/collections/some_other_collection"
+}`))
+	suite.NoError(err)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, testStatus.ID)
+	suite.NoError(err)
+	suite.True(dbStatus.PinnedAt.IsZero())
+}
+
+func (suite *AddTestSuite) TestAddFeaturedNotOwnStatus() {
+	// Status belongs to local_account_1, but
+	// another account is trying to feature it.
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	testStatus := suite.testStatuses["local_account_1_status_1"]
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	err := suite.federatingDB.Add(ctx, suite.addFromJSON(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "Add",
+  "actor": "http://fossbros-anonymous.io/users/foss_satan",
+  "object": "`+testStatus.URI+`",
+  "target": "http://fossbros-anonymous.io/users/foss_satan/collections/featured"
+}`))
+	suite.NoError(err)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, testStatus.ID)
+	suite.NoError(err)
+	suite.True(dbStatus.PinnedAt.IsZero())
+}
+
+func (suite *AddTestSuite) TestAddFeaturedUnknownStatus() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	// We don't have this status yet, so
+	// it should just be skipped for now.
+	err := suite.federatingDB.Add(ctx, suite.addFromJSON(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "Add",
+  "actor": "http://fossbros-anonymous.io/users/foss_satan",
+  "object": "http://fossbros-anonymous.io/users/foss_satan/statuses/01H2Z9XBFFB3G8PTDPPHBN2ETY",
+  "target": "http://fossbros-anonymous.io/users/foss_satan/collections/featured"
+}`))
+	suite.NoError(err)
+}
+
+func TestAddTestSuite(t *testing.T) {
+	suite.Run(t, &AddTestSuite{})
+}
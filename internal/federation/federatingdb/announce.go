@@ -44,6 +44,21 @@ func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStre
 		return nil // Already processed.
 	}
 
+	// Check whether this boost was undone before we
+	// got it (activities can arrive out of order).
+	if idProp := announce.GetJSONLDId(); idProp != nil && idProp.IsIRI() {
+		gone, err := f.state.DB.TombstoneExistsWithURI(ctx, idProp.GetIRI().String())
+		if err != nil {
+			return gtserror.Newf("db error checking tombstone: %w", err)
+		}
+
+		if gone {
+			// Boost was already
+			// undone, ignore it.
+			return nil
+		}
+	}
+
 	boost, isNew, err := f.typeConverter.ASAnnounceToStatus(ctx, announce)
 	if err != nil {
 		return gtserror.Newf("error converting announce to boost: %w", err)
@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb
+
+import (
+	"context"
+	"errors"
+
+	"codeberg.org/gruf/go-logger/v2/level"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// Bite handles an incoming Bite activity, in which the requesting
+// account bites the receiving account, or one of its statuses.
+// Bites are resolved as plain Activities (see ap.ActivityBite),
+// so any plain Activity which isn't a Bite is ignored here.
+func (f *federatingDB) Bite(ctx context.Context, activity vocab.ActivityStreamsActivity) error {
+	if log.Level() >= level.DEBUG {
+		i, err := marshalItem(activity)
+		if err != nil {
+			return err
+		}
+		l := log.WithContext(ctx).
+			WithField("bite", i)
+		l.Debug("entering Bite")
+	}
+
+	receivingAccount, requestingAccount, internal := extractFromCtx(ctx)
+	if internal {
+		return nil // Already processed.
+	}
+
+	if !ap.IsBite(activity) {
+		log.Debug(ctx, "received plain Activity which was not a Bite, so ignoring it")
+		return nil
+	}
+
+	actorURI, err := ap.ExtractActorURI(activity)
+	if err != nil {
+		return gtserror.Newf("error extracting bite actor: %w", err)
+	}
+
+	if actorURI.String() != requestingAccount.URI {
+		return gtserror.Newf(
+			"bite actor %s was not the same as inbox requesting account %s",
+			actorURI, requestingAccount.URI,
+		)
+	}
+
+	targetURIs, err := ap.ExtractTargetURIs(activity)
+	if err != nil || len(targetURIs) == 0 {
+		return gtserror.Newf("bite %s had no target", activity.GetJSONLDId().Get())
+	}
+
+	bite := &gtsmodel.Bite{
+		URI:             activity.GetJSONLDId().Get().String(),
+		AccountID:       requestingAccount.ID,
+		Account:         requestingAccount,
+		TargetAccountID: receivingAccount.ID,
+		TargetAccount:   receivingAccount,
+	}
+
+	if targetURI := targetURIs[0].String(); targetURI != receivingAccount.URI &&
+		targetURI != receivingAccount.URL {
+		// Not a bite of the receiving account itself,
+		// so it should be a bite of one of its statuses.
+		status, err := f.state.DB.GetStatusByURI(ctx, targetURI)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting bitten status %s: %w", targetURI, err)
+		}
+
+		if status == nil || status.AccountID != receivingAccount.ID {
+			// Bite wasn't aimed at the receiving
+			// account, so there's nothing to do.
+			log.Debugf(ctx, "bite target %s is not owned by receiving account %s", targetURI, receivingAccount.URI)
+			return nil
+		}
+
+		bite.StatusID = status.ID
+		bite.Status = status
+	}
+
+	// Notify the bitten account asynchronously.
+	f.state.Workers.EnqueueFederator(ctx, messages.FromFederator{
+		APObjectType:     ap.ActivityBite,
+		APActivityType:   ap.ActivityCreate,
+		GTSModel:         bite,
+		ReceivingAccount: receivingAccount,
+	})
+
+	return nil
+}
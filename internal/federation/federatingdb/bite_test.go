@@ -0,0 +1,165 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type BiteTestSuite struct {
+	FederatingDBTestSuite
+}
+
+func (suite *BiteTestSuite) activityFromJSON(raw string) vocab.ActivityStreamsActivity {
+	m := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t, err := streams.ToType(context.Background(), m)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	activity, ok := t.(vocab.ActivityStreamsActivity)
+	if !ok {
+		suite.FailNow("type was not ActivityStreamsActivity")
+	}
+
+	return activity
+}
+
+func (suite *BiteTestSuite) TestBiteAccount() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	// Bites are resolved with both an
+	// 'Activity' and a 'Bite' type.
+	err := suite.federatingDB.Bite(ctx, suite.activityFromJSON(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": ["Activity", "Bite"],
+  "id": "http://fossbros-anonymous.io/users/foss_satan/bites/1",
+  "actor": "http://fossbros-anonymous.io/users/foss_satan",
+  "target": "http://localhost:8080/users/the_mighty_zork",
+  "to": "http://localhost:8080/users/the_mighty_zork"
+}`))
+	suite.NoError(err)
+
+	msg := <-suite.fromFederator
+	suite.Equal(ap.ActivityBite, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+
+	bite, ok := msg.GTSModel.(*gtsmodel.Bite)
+	if !ok {
+		suite.FailNow("model was not *gtsmodel.Bite")
+	}
+	suite.Equal("http://fossbros-anonymous.io/users/foss_satan/bites/1", bite.URI)
+	suite.Equal(requestingAccount.ID, bite.AccountID)
+	suite.Equal(receivingAccount.ID, bite.TargetAccountID)
+	suite.Empty(bite.StatusID)
+}
+
+func (suite *BiteTestSuite) TestBiteStatus() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	status := suite.testStatuses["local_account_1_status_1"]
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	err := suite.federatingDB.Bite(ctx, suite.activityFromJSON(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": ["Activity", "Bite"],
+  "id": "http://fossbros-anonymous.io/users/foss_satan/bites/2",
+  "actor": "http://fossbros-anonymous.io/users/foss_satan",
+  "target": "`+status.URI+`"
+}`))
+	suite.NoError(err)
+
+	msg := <-suite.fromFederator
+	bite, ok := msg.GTSModel.(*gtsmodel.Bite)
+	if !ok {
+		suite.FailNow("model was not *gtsmodel.Bite")
+	}
+	suite.Equal(receivingAccount.ID, bite.TargetAccountID)
+	suite.Equal(status.ID, bite.StatusID)
+}
+
+func (suite *BiteTestSuite) TestBiteOtherAccountsStatus() {
+	// Status belongs to local_account_2, so
+	// local_account_1 wasn't bitten at all.
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	status := suite.testStatuses["local_account_2_status_1"]
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	err := suite.federatingDB.Bite(ctx, suite.activityFromJSON(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": ["Activity", "Bite"],
+  "id": "http://fossbros-anonymous.io/users/foss_satan/bites/3",
+  "actor": "http://fossbros-anonymous.io/users/foss_satan",
+  "target": "`+status.URI+`"
+}`))
+	suite.NoError(err)
+	suite.Empty(suite.fromFederator)
+}
+
+func (suite *BiteTestSuite) TestBiteWrongActor() {
+	// Bite claims to be by local_account_2,
+	// but it's delivered by remote_account_1.
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	err := suite.federatingDB.Bite(ctx, suite.activityFromJSON(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": ["Activity", "Bite"],
+  "id": "http://fossbros-anonymous.io/users/foss_satan/bites/4",
+  "actor": "http://localhost:8080/users/1happyturtle",
+  "target": "http://localhost:8080/users/the_mighty_zork"
+}`))
+	suite.Error(err)
+	suite.Empty(suite.fromFederator)
+}
+
+func (suite *BiteTestSuite) TestPlainActivityIgnored() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	err := suite.federatingDB.Bite(ctx, suite.activityFromJSON(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "Activity",
+  "id": "http://fossbros-anonymous.io/users/foss_satan/activities/1",
+  "actor": "http://fossbros-anonymous.io/users/foss_satan",
+  "target": "http://localhost:8080/users/the_mighty_zork"
+}`))
+	suite.NoError(err)
+	suite.Empty(suite.fromFederator)
+}
+
+func TestBiteTestSuite(t *testing.T) {
+	suite.Run(t, &BiteTestSuite{})
+}
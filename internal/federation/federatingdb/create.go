@@ -32,6 +32,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 )
 
 // Create adds a new entry to the database which must be able to be
@@ -97,9 +98,21 @@ func (f *federatingDB) activityBlock(ctx context.Context, asType vocab.Type, rec
 		return fmt.Errorf("activityBlock: could not convert Block to gts model block")
 	}
 
+	if block.AccountID != requestingAccount.ID {
+		return fmt.Errorf("activityBlock: block account %s was not the same as inbox requesting account %s", block.AccountID, requestingAccount.ID)
+	}
+
+	if block.TargetAccountID != receiving.ID {
+		return fmt.Errorf("activityBlock: block target account %s was not the same as inbox receiving account %s", block.TargetAccountID, receiving.ID)
+	}
+
 	block.ID = id.NewULID()
 
 	if err := f.state.DB.PutBlock(ctx, block); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// We already know about this block, nothing to do.
+			return nil
+		}
 		return fmt.Errorf("activityBlock: database error inserting block: %s", err)
 	}
 
@@ -147,6 +160,26 @@ func (f *federatingDB) activityCreate(ctx context.Context, asType vocab.Type, re
 			if err := f.createNote(ctx, objectIter.GetActivityStreamsNote(), receivingAccount, requestingAccount); err != nil {
 				errs = append(errs, err.Error())
 			}
+		case ap.ObjectEvent:
+			// CREATE AN EVENT
+			if err := f.createNote(ctx, objectIter.GetActivityStreamsEvent(), receivingAccount, requestingAccount); err != nil {
+				errs = append(errs, err.Error())
+			}
+		case ap.ObjectArticle:
+			// CREATE AN ARTICLE
+			if err := f.createNote(ctx, objectIter.GetActivityStreamsArticle(), receivingAccount, requestingAccount); err != nil {
+				errs = append(errs, err.Error())
+			}
+		case ap.ObjectPage:
+			// CREATE A PAGE
+			if err := f.createNote(ctx, objectIter.GetActivityStreamsPage(), receivingAccount, requestingAccount); err != nil {
+				errs = append(errs, err.Error())
+			}
+		case ap.ActivityQuestion:
+			// CREATE A QUESTION (POLL)
+			if err := f.createNote(ctx, objectIter.GetActivityStreamsQuestion(), receivingAccount, requestingAccount); err != nil {
+				errs = append(errs, err.Error())
+			}
 		default:
 			errs = append(errs, fmt.Sprintf("received an object on a Create that we couldn't handle: %s", asObjectType.GetTypeName()))
 		}
@@ -159,8 +192,10 @@ func (f *federatingDB) activityCreate(ctx context.Context, asType vocab.Type, re
 	return nil
 }
 
-// createNote handles a Create activity with a Note type.
-func (f *federatingDB) createNote(ctx context.Context, note vocab.ActivityStreamsNote, receivingAccount *gtsmodel.Account, requestingAccount *gtsmodel.Account) error {
+// createNote handles a Create activity with a Note type, or another
+// statusable type which is handled in the same way, such as Event,
+// Article, Page or Question.
+func (f *federatingDB) createNote(ctx context.Context, note ap.Statusable, receivingAccount *gtsmodel.Account, requestingAccount *gtsmodel.Account) error {
 	l := log.WithContext(ctx).
 		WithFields(kv.Fields{
 			{"receivingAccount", receivingAccount.URI},
@@ -199,7 +234,7 @@ func (f *federatingDB) createNote(ctx context.Context, note vocab.ActivityStream
 		}
 		// pass the note iri into the processor and have it do the dereferencing instead of doing it here
 		f.state.Workers.EnqueueFederator(ctx, messages.FromFederator{
-			APObjectType:     ap.ObjectNote,
+			APObjectType:     note.GetTypeName(),
 			APActivityType:   ap.ActivityCreate,
 			APIri:            id.GetIRI(),
 			APObjectModel:    nil,
@@ -212,6 +247,12 @@ func (f *federatingDB) createNote(ctx context.Context, note vocab.ActivityStream
 	// if we reach this point, we know it's not a forwarded status, so proceed with processing it as normal
 
 	status, err := f.typeConverter.ASStatusToStatus(ctx, note)
+	if errors.Is(err, typeutils.ErrThreadTooDeep) {
+		// Reply is too deep in its thread and
+		// we're configured to drop such replies.
+		l.Debugf("dropping status: %v", err)
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("createNote: error converting note to status: %s", err)
 	}
@@ -223,6 +264,13 @@ func (f *federatingDB) createNote(ctx context.Context, note vocab.ActivityStream
 	}
 	status.ID = statusID
 
+	if status.Poll != nil {
+		// Set up the poll to be stored with the status.
+		status.Poll.ID = id.NewULID()
+		status.Poll.StatusID = status.ID
+		status.PollID = status.Poll.ID
+	}
+
 	if err := f.state.DB.PutStatus(ctx, status); err != nil {
 		if errors.Is(err, db.ErrAlreadyExists) {
 			// the status already exists in the database, which means we've already handled everything else,
@@ -233,14 +281,24 @@ func (f *federatingDB) createNote(ctx context.Context, note vocab.ActivityStream
 		return fmt.Errorf("createNote: database error inserting status: %s", err)
 	}
 
+	if status.Poll != nil {
+		if err := f.state.DB.PutPoll(ctx, status.Poll); err != nil {
+			return fmt.Errorf("createNote: database error inserting poll: %w", err)
+		}
+	}
+
 	f.state.Workers.EnqueueFederator(ctx, messages.FromFederator{
-		APObjectType:     ap.ObjectNote,
+		APObjectType:     note.GetTypeName(),
 		APActivityType:   ap.ActivityCreate,
 		APObjectModel:    note,
 		GTSModel:         status,
 		ReceivingAccount: receivingAccount,
 	})
 
+	// Replay anything that overtook this
+	// Create, such as an Update of the status.
+	f.replayPending(ctx, status.URI)
+
 	return nil
 }
 
@@ -292,6 +350,13 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 
 	fave.ID = id.NewULID()
 
+	if fave.Status.RequiresApproval(gtsmodel.InteractionLike, fave.AccountID) {
+		// The author of the faved status needs to
+		// approve likes; keep it hidden until they do.
+		pending := true
+		fave.PendingApproval = &pending
+	}
+
 	if err := f.state.DB.PutStatusFave(ctx, fave); err != nil {
 		if errors.Is(err, db.ErrAlreadyExists) {
 			// The Like already exists in the database, which
@@ -302,6 +367,21 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 		return fmt.Errorf("activityLike: database error inserting fave: %w", err)
 	}
 
+	if fave.IsPendingApproval() {
+		// Store a request for the fave's approval.
+		if err := f.state.DB.PutInteractionRequest(ctx, &gtsmodel.InteractionRequest{
+			ID:                   id.NewULID(),
+			StatusID:             fave.StatusID,
+			TargetAccountID:      fave.TargetAccountID,
+			InteractingAccountID: fave.AccountID,
+			InteractionType:      gtsmodel.InteractionLike,
+			InteractionID:        fave.ID,
+			InteractionURI:       fave.URI,
+		}); err != nil {
+			return fmt.Errorf("activityLike: database error inserting interaction request: %w", err)
+		}
+	}
+
 	f.state.Workers.EnqueueFederator(ctx, messages.FromFederator{
 		APObjectType:     ap.ActivityLike,
 		APActivityType:   ap.ActivityCreate,
@@ -309,6 +389,10 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 		ReceivingAccount: receivingAccount,
 	})
 
+	// Replay anything that overtook this
+	// Like, such as an Undo of it.
+	f.replayPending(ctx, fave.URI)
+
 	return nil
 }
 
@@ -322,6 +406,18 @@ func (f *federatingDB) activityFlag(ctx context.Context, asType vocab.Type, rece
 		return errors.New("activityFlag: could not convert type to flag")
 	}
 
+	// Check whether reports from the requester's
+	// domain have been set to be ignored by a block.
+	domainBlock, err := f.state.DB.GetDomainBlock(ctx, requestingAccount.Domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return fmt.Errorf("activityFlag: db error getting domain block: %w", err)
+	}
+
+	if domainBlock != nil && *domainBlock.RejectReports {
+		log.Debugf(ctx, "ignoring Flag from %s: domain block rejects reports", requestingAccount.Domain)
+		return nil
+	}
+
 	report, err := f.typeConverter.ASFlagToReport(ctx, flag)
 	if err != nil {
 		return fmt.Errorf("activityFlag: could not convert Flag to report: %w", err)
@@ -128,6 +128,82 @@ func (suite *CreateTestSuite) TestCreateFlag1() {
 	}
 }
 
+func (suite *CreateTestSuite) TestCreateBlock() {
+	blockedAccount := suite.testAccounts["local_account_1"]
+	blockingAccount := suite.testAccounts["remote_account_1"]
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "` + blockingAccount.URI + `",
+  "id": "http://fossbros-anonymous.io/users/foss_satan/blocks/01H5QSHRCGQ2VBB1EQ4QXMJCAB",
+  "object": "` + blockedAccount.URI + `",
+  "type": "Block"
+}`
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t, err := streams.ToType(context.Background(), m)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx := createTestContext(blockedAccount, blockingAccount)
+	if err := suite.federatingDB.Create(ctx, t); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// should be a message heading to the processor now, which we can intercept here
+	msg := <-suite.fromFederator
+	suite.Equal(ap.ActivityBlock, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+
+	block := msg.GTSModel.(*gtsmodel.Block)
+	suite.Equal(blockingAccount.ID, block.AccountID)
+	suite.Equal(blockedAccount.ID, block.TargetAccountID)
+
+	// block should be in the database
+	blocked, err := suite.db.IsBlocked(context.Background(), blockingAccount.ID, blockedAccount.ID)
+	suite.NoError(err)
+	suite.True(blocked)
+}
+
+func (suite *CreateTestSuite) TestCreateBlockWrongActor() {
+	blockedAccount := suite.testAccounts["local_account_1"]
+	blockingAccount := suite.testAccounts["remote_account_1"]
+	otherAccount := suite.testAccounts["remote_account_2"]
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "` + otherAccount.URI + `",
+  "id": "http://fossbros-anonymous.io/users/foss_satan/blocks/01H5QSHRCGQ2VBB1EQ4QXMJCAB",
+  "object": "` + blockedAccount.URI + `",
+  "type": "Block"
+}`
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t, err := streams.ToType(context.Background(), m)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// block is delivered by a different account than its actor
+	ctx := createTestContext(blockedAccount, blockingAccount)
+	err = suite.federatingDB.Create(ctx, t)
+	suite.Error(err)
+
+	// no block should be in the database
+	blocked, err := suite.db.IsBlocked(context.Background(), otherAccount.ID, blockedAccount.ID)
+	suite.NoError(err)
+	suite.False(blocked)
+}
+
 func TestCreateTestSuite(t *testing.T) {
 	suite.Run(t, &CreateTestSuite{})
 }
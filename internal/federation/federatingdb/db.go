@@ -34,14 +34,21 @@ type DB interface {
 	Accept(ctx context.Context, accept vocab.ActivityStreamsAccept) error
 	Reject(ctx context.Context, reject vocab.ActivityStreamsReject) error
 	Announce(ctx context.Context, announce vocab.ActivityStreamsAnnounce) error
+	Add(ctx context.Context, add vocab.ActivityStreamsAdd) error
+	Remove(ctx context.Context, remove vocab.ActivityStreamsRemove) error
+	Listen(ctx context.Context, listen vocab.ActivityStreamsListen) error
+	Bite(ctx context.Context, activity vocab.ActivityStreamsActivity) error
+	Dislike(ctx context.Context, dislike vocab.ActivityStreamsDislike) error
 }
 
 // FederatingDB uses the underlying DB interface to implement the go-fed pub.Database interface.
 // It doesn't care what the underlying implementation of the DB interface is, as long as it works.
 type federatingDB struct {
-	locks         mutexes.MutexMap
-	state         *state.State
-	typeConverter typeutils.TypeConverter
+	locks             mutexes.MutexMap
+	state             *state.State
+	typeConverter     typeutils.TypeConverter
+	accountUpdates    accountUpdates
+	pendingActivities pendingActivities
 }
 
 // New returns a DB interface using the given database and config
@@ -50,6 +57,12 @@ func New(state *state.State, tc typeutils.TypeConverter) DB {
 		locks:         mutexes.NewMap(-1, -1), // use defaults
 		state:         state,
 		typeConverter: tc,
+		accountUpdates: accountUpdates{
+			actors: make(map[string]*actorUpdates),
+		},
+		pendingActivities: pendingActivities{
+			objects: make(map[string][]*pendingActivity),
+		},
 	}
 	return &fdb
 }
@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb
+
+import (
+	"context"
+	"errors"
+
+	"codeberg.org/gruf/go-logger/v2/level"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Dislike handles an incoming Dislike activity, in which the
+// requesting account dislikes one of the receiving account's
+// statuses. Dislikes are only stored, to be counted; they
+// don't generate notifications or any other side effects.
+func (f *federatingDB) Dislike(ctx context.Context, dislike vocab.ActivityStreamsDislike) error {
+	if log.Level() >= level.DEBUG {
+		i, err := marshalItem(dislike)
+		if err != nil {
+			return err
+		}
+		l := log.WithContext(ctx).
+			WithField("dislike", i)
+		l.Debug("entering Dislike")
+	}
+
+	receivingAccount, requestingAccount, internal := extractFromCtx(ctx)
+	if internal {
+		return nil // Already processed.
+	}
+
+	statusDislike, err := f.typeConverter.ASDislikeToStatusDislike(ctx, dislike)
+	if err != nil {
+		return gtserror.Newf("could not convert Dislike to status dislike: %w", err)
+	}
+
+	if statusDislike.AccountID != requestingAccount.ID {
+		return gtserror.Newf(
+			"dislike actor %s was not the same as inbox requesting account %s",
+			statusDislike.Account.URI, requestingAccount.URI,
+		)
+	}
+
+	if statusDislike.TargetAccountID != receivingAccount.ID {
+		// Dislike wasn't aimed at the receiving
+		// account, so there's nothing to do.
+		log.Debugf(ctx, "dislike target %s is not owned by receiving account %s", statusDislike.Status.URI, receivingAccount.URI)
+		return nil
+	}
+
+	statusDislike.ID = id.NewULID()
+
+	if err := f.state.DB.PutStatusDislike(ctx, statusDislike); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// We already have a dislike of
+			// this status from this account.
+			return nil
+		}
+		return gtserror.Newf("database error inserting dislike: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+)
+
+type DislikeTestSuite struct {
+	FederatingDBTestSuite
+}
+
+func (suite *DislikeTestSuite) typeFromJSON(raw string) vocab.Type {
+	m := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t, err := streams.ToType(context.Background(), m)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return t
+}
+
+func (suite *DislikeTestSuite) TestDislikeThenUndo() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	status := suite.testStatuses["local_account_1_status_1"]
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	dislikeJSON := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "Dislike",
+  "id": "http://fossbros-anonymous.io/users/foss_satan/dislikes/1",
+  "actor": "http://fossbros-anonymous.io/users/foss_satan",
+  "object": "` + status.URI + `"
+}`
+
+	dislike, ok := suite.typeFromJSON(dislikeJSON).(vocab.ActivityStreamsDislike)
+	if !ok {
+		suite.FailNow("type was not ActivityStreamsDislike")
+	}
+
+	err := suite.federatingDB.Dislike(ctx, dislike)
+	suite.NoError(err)
+
+	statusDislike, err := suite.db.GetStatusDislike(ctx, requestingAccount.ID, status.ID)
+	suite.NoError(err)
+	suite.Equal("http://fossbros-anonymous.io/users/foss_satan/dislikes/1", statusDislike.URI)
+	suite.Equal(receivingAccount.ID, statusDislike.TargetAccountID)
+
+	count, err := suite.db.CountStatusDislikes(ctx, status.ID)
+	suite.NoError(err)
+	suite.Equal(1, count)
+
+	// Receiving the same Dislike
+	// again should be a no-op.
+	err = suite.federatingDB.Dislike(ctx, dislike)
+	suite.NoError(err)
+
+	undo, ok := suite.typeFromJSON(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "Undo",
+  "id": "http://fossbros-anonymous.io/users/foss_satan/dislikes/1/undo",
+  "actor": "http://fossbros-anonymous.io/users/foss_satan",
+  "object": ` + dislikeJSON + `
+}`).(vocab.ActivityStreamsUndo)
+	if !ok {
+		suite.FailNow("type was not ActivityStreamsUndo")
+	}
+
+	err = suite.federatingDB.Undo(ctx, undo)
+	suite.NoError(err)
+
+	count, err = suite.db.CountStatusDislikes(ctx, status.ID)
+	suite.NoError(err)
+	suite.Zero(count)
+}
+
+func (suite *DislikeTestSuite) TestDislikeOtherAccountsStatus() {
+	// Status belongs to local_account_2, so
+	// the Dislike shouldn't be stored.
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	status := suite.testStatuses["local_account_2_status_1"]
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	dislike, ok := suite.typeFromJSON(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "Dislike",
+  "id": "http://fossbros-anonymous.io/users/foss_satan/dislikes/2",
+  "actor": "http://fossbros-anonymous.io/users/foss_satan",
+  "object": "` + status.URI + `"
+}`).(vocab.ActivityStreamsDislike)
+	if !ok {
+		suite.FailNow("type was not ActivityStreamsDislike")
+	}
+
+	err := suite.federatingDB.Dislike(ctx, dislike)
+	suite.NoError(err)
+
+	count, err := suite.db.CountStatusDislikes(ctx, status.ID)
+	suite.NoError(err)
+	suite.Zero(count)
+}
+
+func TestDislikeTestSuite(t *testing.T) {
+	suite.Run(t, &DislikeTestSuite{})
+}
@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb
+
+import (
+	"context"
+	"errors"
+
+	"codeberg.org/gruf/go-logger/v2/level"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Listen handles an incoming Listen activity, as sent by music
+// scrobbling software to share the track that an account is
+// listening to. Incoming Play activities are treated as Listens.
+// The listen replaces any previous listen of the requesting account.
+func (f *federatingDB) Listen(ctx context.Context, listen vocab.ActivityStreamsListen) error {
+	if log.Level() >= level.DEBUG {
+		i, err := marshalItem(listen)
+		if err != nil {
+			return err
+		}
+		l := log.WithContext(ctx).
+			WithField("listen", i)
+		l.Debug("entering Listen")
+	}
+
+	receivingAccount, requestingAccount, internal := extractFromCtx(ctx)
+	if internal {
+		return nil // Already processed.
+	}
+
+	gtsListen, err := f.typeConverter.ASListenToListen(ctx, listen)
+	if err != nil {
+		return gtserror.Newf("error converting listen to gts model: %w", err)
+	}
+
+	if gtsListen.AccountID != requestingAccount.ID {
+		return gtserror.Newf(
+			"listen actor %s was not the same as inbox requesting account %s",
+			gtsListen.Account.URI, requestingAccount.URI,
+		)
+	}
+
+	gtsListen.ID = id.NewULID()
+	if err := f.state.DB.PutListen(ctx, gtsListen); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// A listen is delivered to the inbox of each
+			// follower, so we may have stored it already.
+			return nil
+		}
+		return gtserror.Newf("db error storing listen %s: %w", gtsListen.URI, err)
+	}
+
+	log.Debugf(ctx, "stored listen %s for receiving account %s", gtsListen.URI, receivingAccount.URI)
+	return nil
+}
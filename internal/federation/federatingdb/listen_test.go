@@ -0,0 +1,125 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+)
+
+type ListenTestSuite struct {
+	FederatingDBTestSuite
+}
+
+func (suite *ListenTestSuite) listenFromJSON(raw string) vocab.ActivityStreamsListen {
+	m := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t, err := streams.ToType(context.Background(), m)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	listen, ok := t.(vocab.ActivityStreamsListen)
+	if !ok {
+		suite.FailNow("type was not ActivityStreamsListen")
+	}
+
+	return listen
+}
+
+func (suite *ListenTestSuite) TestListen() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	err := suite.federatingDB.Listen(ctx, suite.listenFromJSON(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "Listen",
+  "id": "http://fossbros-anonymous.io/users/foss_satan/listens/1",
+  "actor": "http://fossbros-anonymous.io/users/foss_satan",
+  "published": "2023-07-06T12:00:00Z",
+  "object": {
+    "type": "Audio",
+    "name": "Pyramid Song",
+    "artist": "Radiohead",
+    "album": {
+      "name": "Amnesiac"
+    }
+  }
+}`))
+	suite.NoError(err)
+
+	listen, err := suite.db.GetAccountListen(ctx, requestingAccount.ID)
+	suite.NoError(err)
+	suite.Equal("http://fossbros-anonymous.io/users/foss_satan/listens/1", listen.URI)
+	suite.Equal("Pyramid Song", listen.TrackName)
+	suite.Equal("Radiohead", listen.ArtistName)
+	suite.Equal("Amnesiac", listen.AlbumName)
+	suite.False(listen.Active())
+
+	// A newer listen should replace the old one.
+	err = suite.federatingDB.Listen(ctx, suite.listenFromJSON(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "Listen",
+  "id": "http://fossbros-anonymous.io/users/foss_satan/listens/2",
+  "actor": "http://fossbros-anonymous.io/users/foss_satan",
+  "object": {
+    "type": "Audio",
+    "name": "Knives Out"
+  }
+}`))
+	suite.NoError(err)
+
+	listen, err = suite.db.GetAccountListen(ctx, requestingAccount.ID)
+	suite.NoError(err)
+	suite.Equal("Knives Out", listen.TrackName)
+	suite.Empty(listen.ArtistName)
+	suite.True(listen.Active())
+}
+
+func (suite *ListenTestSuite) TestListenWrongActor() {
+	// Listen claims to be by local_account_1,
+	// but it's delivered by remote_account_1.
+	receivingAccount := suite.testAccounts["local_account_2"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	err := suite.federatingDB.Listen(ctx, suite.listenFromJSON(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "Listen",
+  "id": "http://fossbros-anonymous.io/users/foss_satan/listens/1",
+  "actor": "http://localhost:8080/users/the_mighty_zork",
+  "object": {
+    "type": "Audio",
+    "name": "Pyramid Song"
+  }
+}`))
+	suite.Error(err)
+}
+
+func TestListenTestSuite(t *testing.T) {
+	suite.Run(t, &ListenTestSuite{})
+}
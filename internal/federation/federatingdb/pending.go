@@ -0,0 +1,159 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"codeberg.org/gruf/go-runners"
+	"codeberg.org/gruf/go-sched"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	pendingActivityLimit    = 1000             // max activities buffered at once, across all objects
+	pendingActivityAttempts = 6                // max times a buffered activity is retried before being dropped
+	pendingActivityInterval = 10 * time.Second // time between retries of buffered activities
+)
+
+// pendingActivities buffers incoming activities which depend on
+// an object we don't have yet, because activities often arrive out
+// of order: eg., an Update of a status whose Create we haven't
+// processed, or an Undo of a Like we haven't seen. Activities are
+// keyed by the URI of the object they depend on, and replayed once
+// it lands, or retried a limited number of times before being dropped.
+type pendingActivities struct {
+	objects map[string][]*pendingActivity
+	count   int
+	mutex   sync.Mutex
+}
+
+// pendingActivity is a single buffered activity.
+type pendingActivity struct {
+	// name of the activity, for logging.
+	name string
+
+	// replay processes the activity again, returning
+	// false if the object it depends on is still missing.
+	replay func(ctx context.Context) (bool, error)
+
+	// attempts is the number of
+	// times replay has been called.
+	attempts int
+}
+
+// put buffers the given activity on the object with given URI. It
+// returns false if the buffer is full, in which case the activity
+// should be dropped. Otherwise, first indicates whether this is the
+// only activity now buffered on the object.
+func (p *pendingActivities) put(uri string, activity *pendingActivity) (ok bool, first bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.count >= pendingActivityLimit {
+		return false, false
+	}
+
+	first = len(p.objects[uri]) == 0
+	p.objects[uri] = append(p.objects[uri], activity)
+	p.count++
+
+	return true, first
+}
+
+// take removes and returns all activities
+// buffered on the object with given URI.
+func (p *pendingActivities) take(uri string) []*pendingActivity {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	activities := p.objects[uri]
+	delete(p.objects, uri)
+	p.count -= len(activities)
+
+	return activities
+}
+
+// bufferActivity buffers the given activity until the object with given
+// URI lands, scheduling retries in case we don't otherwise learn of it.
+func (f *federatingDB) bufferActivity(ctx context.Context, uri string, activity *pendingActivity) {
+	ok, first := f.pendingActivities.put(uri, activity)
+	if !ok {
+		log.Warnf(ctx, "too many pending activities, dropping %s of %s", activity.name, uri)
+		return
+	}
+
+	log.Debugf(ctx, "buffering %s of %s until it lands", activity.name, uri)
+
+	if first {
+		f.schedulePendingRetry(uri)
+	}
+}
+
+// replayPending replays activities buffered on the object with given
+// URI, which may have just landed. Those still missing their object are
+// buffered again, unless they've run out of attempts. It returns whether
+// any activities remain buffered on the object.
+func (f *federatingDB) replayPending(ctx context.Context, uri string) bool {
+	var remaining bool
+
+	for _, activity := range f.pendingActivities.take(uri) {
+		activity.attempts++
+
+		done, err := activity.replay(ctx)
+		if err != nil {
+			log.Errorf(ctx, "error replaying %s of %s: %v", activity.name, uri, err)
+			continue
+		}
+
+		if done {
+			continue
+		}
+
+		if activity.attempts >= pendingActivityAttempts {
+			log.Infof(ctx, "dropping %s of %s after %d attempts", activity.name, uri, activity.attempts)
+			continue
+		}
+
+		if ok, _ := f.pendingActivities.put(uri, activity); !ok {
+			log.Warnf(ctx, "too many pending activities, dropping %s of %s", activity.name, uri)
+			continue
+		}
+
+		remaining = true
+	}
+
+	return remaining
+}
+
+// schedulePendingRetry schedules activities buffered on
+// the object with given URI to be replayed after an interval,
+// and again after that, for as long as any remain.
+func (f *federatingDB) schedulePendingRetry(uri string) {
+	// Get ctx associated with scheduler run state.
+	done := f.state.Workers.Scheduler.Done()
+	doneCtx := runners.CancelCtx(done)
+
+	f.state.Workers.Scheduler.Schedule(sched.NewJob(func(time.Time) {
+		if f.replayPending(doneCtx, uri) {
+			f.schedulePendingRetry(uri)
+		}
+	}).At(time.Now().Add(pendingActivityInterval)))
+}
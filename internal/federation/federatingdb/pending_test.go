@@ -0,0 +1,116 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestPendingActivitiesBounded(t *testing.T) {
+	p := pendingActivities{objects: make(map[string][]*pendingActivity)}
+
+	for i := 0; i < pendingActivityLimit; i++ {
+		ok, first := p.put(fmt.Sprintf("http://example.org/statuses/%d", i%10), &pendingActivity{})
+		if !ok {
+			t.Fatalf("put %d: expected ok", i)
+		}
+		if first != (i < 10) {
+			t.Fatalf("put %d: unexpected first %t", i, first)
+		}
+	}
+
+	// Buffer is full.
+	if ok, _ := p.put("http://example.org/statuses/new", &pendingActivity{}); ok {
+		t.Fatal("expected put on full buffer to fail")
+	}
+
+	// Taking frees up space.
+	if n := len(p.take("http://example.org/statuses/0")); n != pendingActivityLimit/10 {
+		t.Fatalf("expected %d activities, got %d", pendingActivityLimit/10, n)
+	}
+	if ok, _ := p.put("http://example.org/statuses/new", &pendingActivity{}); !ok {
+		t.Fatal("expected put to succeed after take")
+	}
+}
+
+func TestReplayPending(t *testing.T) {
+	f := &federatingDB{
+		pendingActivities: pendingActivities{objects: make(map[string][]*pendingActivity)},
+	}
+
+	const uri = "http://example.org/statuses/1"
+
+	var (
+		landed  bool
+		replays int
+	)
+
+	f.pendingActivities.put(uri, &pendingActivity{
+		name: "Update",
+		replay: func(context.Context) (bool, error) {
+			replays++
+			return landed, nil
+		},
+	})
+
+	// Object still missing, so
+	// the activity stays buffered.
+	if !f.replayPending(context.Background(), uri) {
+		t.Fatal("expected activity to remain buffered")
+	}
+
+	// Object lands, so the activity is done.
+	landed = true
+	if f.replayPending(context.Background(), uri) {
+		t.Fatal("expected no activity to remain buffered")
+	}
+
+	if replays != 2 {
+		t.Fatalf("expected 2 replays, got %d", replays)
+	}
+}
+
+func TestReplayPendingDropsAfterAttempts(t *testing.T) {
+	f := &federatingDB{
+		pendingActivities: pendingActivities{objects: make(map[string][]*pendingActivity)},
+	}
+
+	const uri = "http://example.org/statuses/1"
+
+	var replays int
+	f.pendingActivities.put(uri, &pendingActivity{
+		name: "Undo Like",
+		replay: func(context.Context) (bool, error) {
+			replays++
+			return false, nil
+		},
+	})
+
+	for f.replayPending(context.Background(), uri) {
+	}
+
+	if replays != pendingActivityAttempts {
+		t.Fatalf("expected %d replays, got %d", pendingActivityAttempts, replays)
+	}
+
+	if f.pendingActivities.count != 0 {
+		t.Fatalf("expected empty buffer, got %d", f.pendingActivities.count)
+	}
+}
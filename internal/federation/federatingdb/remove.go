@@ -0,0 +1,67 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb
+
+import (
+	"context"
+	"time"
+
+	"codeberg.org/gruf/go-logger/v2/level"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Remove handles an incoming Remove activity. The only Removes we currently
+// care about are a remote account removing one of its statuses from its
+// featured collection (ie., unpinning it), in which case the pin is removed.
+func (f *federatingDB) Remove(ctx context.Context, remove vocab.ActivityStreamsRemove) error {
+	if log.Level() >= level.DEBUG {
+		i, err := marshalItem(remove)
+		if err != nil {
+			return err
+		}
+		l := log.WithContext(ctx).
+			WithField("remove", i)
+		l.Debug("entering Remove")
+	}
+
+	_, requestingAccount, internal := extractFromCtx(ctx)
+	if internal {
+		return nil // Already processed.
+	}
+
+	statuses, err := f.featuredStatuses(ctx, requestingAccount, remove)
+	if err != nil {
+		return gtserror.Newf("error getting featured statuses: %w", err)
+	}
+
+	for _, status := range statuses {
+		if status.PinnedAt.IsZero() {
+			// Not pinned.
+			continue
+		}
+
+		status.PinnedAt = time.Time{}
+		if err := f.state.DB.UpdateStatus(ctx, status, "pinned_at"); err != nil {
+			return gtserror.Newf("error unpinning status %s: %w", status.URI, err)
+		}
+	}
+
+	return nil
+}
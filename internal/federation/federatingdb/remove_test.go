@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+)
+
+type RemoveTestSuite struct {
+	FederatingDBTestSuite
+}
+
+// removeJSON is a Remove for a featured collection as generated
+// by Mastodon; note that Mastodon doesn't set an id on these.
+const removeJSON = `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "Remove",
+  "actor": "http://fossbros-anonymous.io/users/foss_satan",
+  "object": "http://fossbros-anonymous.io/users/foss_satan/statuses/01FVW7JHQFSFK166WWKR8CBA6M",
+  "target": "http://fossbros-anonymous.io/users/foss_satan/collections/featured"
+}`
+
+func (suite *RemoveTestSuite) removeFromJSON(raw string) vocab.ActivityStreamsRemove {
+	m := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t, err := streams.ToType(context.Background(), m)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	remove, ok := t.(vocab.ActivityStreamsRemove)
+	if !ok {
+		suite.FailNow("type was not ActivityStreamsRemove")
+	}
+
+	return remove
+}
+
+func (suite *RemoveTestSuite) TestRemoveFeatured() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	testStatus := suite.testStatuses["remote_account_1_status_1"]
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	// Pin the status first.
+	dbStatus, err := suite.db.GetStatusByID(ctx, testStatus.ID)
+	suite.NoError(err)
+	dbStatus.PinnedAt = time.Now()
+	if err := suite.db.UpdateStatus(ctx, dbStatus, "pinned_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	err = suite.federatingDB.Remove(ctx, suite.removeFromJSON(removeJSON))
+	suite.NoError(err)
+
+	dbStatus, err = suite.db.GetStatusByID(ctx, testStatus.ID)
+	suite.NoError(err)
+	suite.True(dbStatus.PinnedAt.IsZero())
+}
+
+func (suite *RemoveTestSuite) TestRemoveFeaturedNotPinned() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	testStatus := suite.testStatuses["remote_account_1_status_1"]
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	err := suite.federatingDB.Remove(ctx, suite.removeFromJSON(removeJSON))
+	suite.NoError(err)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, testStatus.ID)
+	suite.NoError(err)
+	suite.True(dbStatus.PinnedAt.IsZero())
+}
+
+func TestRemoveTestSuite(t *testing.T) {
+	suite.Run(t, &RemoveTestSuite{})
+}
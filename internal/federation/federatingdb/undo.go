@@ -28,7 +28,9 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
 
 func (f *federatingDB) Undo(ctx context.Context, undo vocab.ActivityStreamsUndo) error {
@@ -68,8 +70,14 @@ func (f *federatingDB) Undo(ctx context.Context, undo vocab.ActivityStreamsUndo)
 			if err := f.undoLike(ctx, receivingAccount, undo, t); err != nil {
 				return err
 			}
+		case ap.ActivityDislike:
+			if err := f.undoDislike(ctx, receivingAccount, undo, t); err != nil {
+				return err
+			}
 		case ap.ActivityAnnounce:
-			// todo: undo boost / reblog / announce
+			if err := f.undoAnnounce(ctx, receivingAccount, undo, t); err != nil {
+				return err
+			}
 		case ap.ActivityBlock:
 			if err := f.undoBlock(ctx, receivingAccount, undo, t); err != nil {
 				return err
@@ -150,28 +158,188 @@ func (f *federatingDB) undoLike(
 		return nil
 	}
 
+	undone, err := f.undoFave(ctx, receivingAccount, fave.AccountID, fave.StatusID)
+	if err != nil || undone {
+		return err
+	}
+
+	// We don't have this fave (yet). The Undo may have
+	// overtaken the Like, so buffer it until the Like lands.
+	f.bufferActivity(ctx, fave.URI, &pendingActivity{
+		name: "Undo Like",
+		replay: func(ctx context.Context) (bool, error) {
+			return f.undoFave(ctx, receivingAccount, fave.AccountID, fave.StatusID)
+		},
+	})
+
+	return nil
+}
+
+// undoFave deletes the fave of the given status by the given account,
+// and enqueues processing of side effects. It returns false if there
+// was no such fave.
+func (f *federatingDB) undoFave(
+	ctx context.Context,
+	receivingAccount *gtsmodel.Account,
+	accountID string,
+	statusID string,
+) (bool, error) {
 	// Ignore URI on Likes, since we often get multiple Likes
 	// with the same target and account ID, but differing URIs.
 	// Instead, we'll select using account and target status.
 	// Regardless of the URI, we can read an Undo Like to mean
 	// "I don't want to fave this post anymore".
-	fave, err = f.state.DB.GetStatusFave(gtscontext.SetBarebones(ctx), fave.AccountID, fave.StatusID)
+	fave, err := f.state.DB.GetStatusFave(gtscontext.SetBarebones(ctx), accountID, statusID)
 	if err != nil {
 		if errors.Is(err, db.ErrNoEntries) {
-			// We didn't have a like/fave
-			// for this combo anyway, ignore.
-			return nil
+			// We don't have a like/fave
+			// for this combo (yet).
+			return false, nil
 		}
 		// Real error.
-		return fmt.Errorf("undoLike: db error getting fave from %s targeting %s: %w", fave.AccountID, fave.StatusID, err)
+		return false, fmt.Errorf("undoLike: db error getting fave from %s targeting %s: %w", accountID, statusID, err)
 	}
 
 	// Delete the status fave.
 	if err := f.state.DB.DeleteStatusFaveByID(ctx, fave.ID); err != nil {
-		return fmt.Errorf("undoLike: db error deleting fave %s: %w", fave.ID, err)
+		return false, fmt.Errorf("undoLike: db error deleting fave %s: %w", fave.ID, err)
 	}
 
+	// Process side effects asynchronously.
+	f.state.Workers.EnqueueFederator(ctx, messages.FromFederator{
+		APObjectType:     ap.ActivityLike,
+		APActivityType:   ap.ActivityUndo,
+		GTSModel:         fave,
+		ReceivingAccount: receivingAccount,
+	})
+
 	log.Debug(ctx, "Like undone")
+	return true, nil
+}
+
+func (f *federatingDB) undoDislike(
+	ctx context.Context,
+	receivingAccount *gtsmodel.Account,
+	undo vocab.ActivityStreamsUndo,
+	t vocab.Type,
+) error {
+	asDislike, ok := t.(vocab.ActivityStreamsDislike)
+	if !ok {
+		return errors.New("undoDislike: couldn't parse vocab.Type into vocab.ActivityStreamsDislike")
+	}
+
+	// Make sure the undo actor owns the target.
+	if !sameActor(undo.GetActivityStreamsActor(), asDislike.GetActivityStreamsActor()) {
+		// Ignore this Activity.
+		return nil
+	}
+
+	dislike, err := f.typeConverter.ASDislikeToStatusDislike(ctx, asDislike)
+	if err != nil {
+		return fmt.Errorf("undoDislike: error converting ActivityStreams Dislike to status dislike: %w", err)
+	}
+
+	// Ensure addressee is dislike target.
+	if dislike.TargetAccountID != receivingAccount.ID {
+		// Ignore this Activity.
+		return nil
+	}
+
+	// As with Likes, select using account and
+	// target status rather than the Dislike URI.
+	dislike, err = f.state.DB.GetStatusDislike(gtscontext.SetBarebones(ctx), dislike.AccountID, dislike.StatusID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// We didn't have a dislike
+			// for this combo anyway, ignore.
+			return nil
+		}
+		// Real error.
+		return fmt.Errorf("undoDislike: db error getting dislike: %w", err)
+	}
+
+	if err := f.state.DB.DeleteStatusDislikeByID(ctx, dislike.ID); err != nil {
+		return fmt.Errorf("undoDislike: db error deleting dislike %s: %w", dislike.ID, err)
+	}
+
+	log.Debug(ctx, "Dislike undone")
+	return nil
+}
+
+func (f *federatingDB) undoAnnounce(
+	ctx context.Context,
+	receivingAccount *gtsmodel.Account,
+	undo vocab.ActivityStreamsUndo,
+	t vocab.Type,
+) error {
+	Announce, ok := t.(vocab.ActivityStreamsAnnounce)
+	if !ok {
+		return errors.New("undoAnnounce: couldn't parse vocab.Type into vocab.ActivityStreamsAnnounce")
+	}
+
+	// Make sure the undo actor owns the target.
+	if !sameActor(undo.GetActivityStreamsActor(), Announce.GetActivityStreamsActor()) {
+		// Ignore this Activity.
+		return nil
+	}
+
+	actorURI, err := ap.ExtractActorURI(Announce)
+	if err != nil {
+		return fmt.Errorf("undoAnnounce: error extracting actor: %w", err)
+	}
+
+	idProp := Announce.GetJSONLDId()
+	if idProp == nil || !idProp.IsIRI() {
+		// Can't identify the boost, ignore.
+		return nil
+	}
+	announceID := idProp.GetIRI()
+
+	boost, err := f.state.DB.GetStatusByURI(gtscontext.SetBarebones(ctx), announceID.String())
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			// Real error.
+			return fmt.Errorf("undoAnnounce: db error getting boost %s: %w", announceID, err)
+		}
+
+		// We haven't seen this boost (yet). The Undo may have
+		// overtaken the Announce, so leave a tombstone behind
+		// to make sure we ignore the Announce if it turns up.
+		//
+		// Only do this for Announces on the actor's own domain
+		// though, or anyone could tombstone someone else's boost.
+		if announceID.Host != actorURI.Host {
+			// Ignore this Activity.
+			return nil
+		}
+
+		if err := f.state.DB.PutTombstone(ctx, &gtsmodel.Tombstone{
+			ID:     id.NewULID(),
+			Domain: announceID.Host,
+			URI:    announceID.String(),
+		}); err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+			return fmt.Errorf("undoAnnounce: db error putting tombstone for %s: %w", announceID, err)
+		}
+
+		return nil
+	}
+
+	// Ensure the boost is actually
+	// a boost by the undo actor.
+	if boost.BoostOfID == "" || boost.AccountURI != actorURI.String() {
+		// Ignore this Activity.
+		return nil
+	}
+
+	// Process side effects asynchronously.
+	f.state.Workers.EnqueueFederator(ctx, messages.FromFederator{
+		APObjectType:     ap.ActivityAnnounce,
+		APActivityType:   ap.ActivityUndo,
+		GTSModel:         boost,
+		ReceivingAccount: receivingAccount,
+	})
+
+	log.Debug(ctx, "Announce undone")
 	return nil
 }
 
@@ -0,0 +1,268 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type UndoTestSuite struct {
+	FederatingDBTestSuite
+}
+
+func (suite *UndoTestSuite) newUndoBlock(block *gtsmodel.Block, actorURI string) vocab.ActivityStreamsUndo {
+	asBlock, err := suite.tc.BlockToAS(createTestContext(block.TargetAccount, block.Account), block)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	undo := streams.NewActivityStreamsUndo()
+
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(testrig.URLMustParse(actorURI))
+	undo.SetActivityStreamsActor(actorProp)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendActivityStreamsBlock(asBlock)
+	undo.SetActivityStreamsObject(objectProp)
+
+	toProp := streams.NewActivityStreamsToProperty()
+	toProp.AppendIRI(testrig.URLMustParse(block.TargetAccount.URI))
+	undo.SetActivityStreamsTo(toProp)
+
+	return undo
+}
+
+func (suite *UndoTestSuite) TestUndoBlock() {
+	// remote_account_1 blocked local_account_1;
+	// remote_account_1 now unblocks local_account_1
+	blockingAccount := suite.testAccounts["remote_account_1"]
+	blockedAccount := suite.testAccounts["local_account_1"]
+	ctx := createTestContext(blockedAccount, blockingAccount)
+
+	block := &gtsmodel.Block{
+		ID:              "01H3T2ZVN4XTJX5S8N7SQ6Q62Y",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		URI:             blockingAccount.URI + "/blocks/01H3T2ZVN4XTJX5S8N7SQ6Q62Y",
+		AccountID:       blockingAccount.ID,
+		Account:         blockingAccount,
+		TargetAccountID: blockedAccount.ID,
+		TargetAccount:   blockedAccount,
+	}
+	if err := suite.db.PutBlock(ctx, block); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	err := suite.federatingDB.Undo(ctx, suite.newUndoBlock(block, blockingAccount.URI))
+	suite.NoError(err)
+
+	// The block should be gone,
+	// so local_account_1 is no
+	// longer blocked by remote.
+	_, err = suite.db.GetBlockByID(ctx, block.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	blocked, err := suite.db.IsBlocked(ctx, blockingAccount.ID, blockedAccount.ID)
+	suite.NoError(err)
+	suite.False(blocked)
+}
+
+func (suite *UndoTestSuite) TestUndoBlockWrongActor() {
+	// remote_account_1 blocked local_account_1;
+	// remote_account_2 tries to undo the block
+	blockingAccount := suite.testAccounts["remote_account_1"]
+	blockedAccount := suite.testAccounts["local_account_1"]
+	otherAccount := suite.testAccounts["remote_account_2"]
+	ctx := createTestContext(blockedAccount, otherAccount)
+
+	block := &gtsmodel.Block{
+		ID:              "01H3T2ZVN4XTJX5S8N7SQ6Q62Y",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		URI:             blockingAccount.URI + "/blocks/01H3T2ZVN4XTJX5S8N7SQ6Q62Y",
+		AccountID:       blockingAccount.ID,
+		Account:         blockingAccount,
+		TargetAccountID: blockedAccount.ID,
+		TargetAccount:   blockedAccount,
+	}
+	if err := suite.db.PutBlock(ctx, block); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	err := suite.federatingDB.Undo(ctx, suite.newUndoBlock(block, otherAccount.URI))
+	suite.NoError(err)
+
+	// The block should still be there.
+	_, err = suite.db.GetBlockByID(ctx, block.ID)
+	suite.NoError(err)
+}
+
+func (suite *UndoTestSuite) newUndoAnnounce(announce vocab.ActivityStreamsAnnounce, actorURI string) vocab.ActivityStreamsUndo {
+	undo := streams.NewActivityStreamsUndo()
+
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(testrig.URLMustParse(actorURI))
+	undo.SetActivityStreamsActor(actorProp)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendActivityStreamsAnnounce(announce)
+	undo.SetActivityStreamsObject(objectProp)
+
+	return undo
+}
+
+func (suite *UndoTestSuite) TestUndoAnnounce() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	announcingAccount := suite.testAccounts["remote_account_1"]
+	ctx := createTestContext(receivingAccount, announcingAccount)
+
+	announce := suite.testActivities["announce_forwarded_1_zork"].Activity.(vocab.ActivityStreamsAnnounce)
+	err := suite.federatingDB.Announce(ctx, announce)
+	suite.NoError(err)
+
+	msg := <-suite.fromFederator
+	boost, ok := msg.GTSModel.(*gtsmodel.Status)
+	suite.True(ok)
+
+	// Insert the boost into the DB
+	// cache to emulate processor handling.
+	boost.ID, _ = id.NewULIDFromTime(boost.CreatedAt)
+	suite.state.Caches.GTS.Status().Store(boost, func() error {
+		return nil
+	})
+
+	err = suite.federatingDB.Undo(ctx, suite.newUndoAnnounce(announce, announcingAccount.URI))
+	suite.NoError(err)
+
+	// Removing the boost is left to the processor.
+	msg = <-suite.fromFederator
+	suite.Equal(ap.ActivityAnnounce, msg.APObjectType)
+	suite.Equal(ap.ActivityUndo, msg.APActivityType)
+	suite.Equal(boost.ID, msg.GTSModel.(*gtsmodel.Status).ID)
+}
+
+func (suite *UndoTestSuite) TestUndoAnnounceBeforeAnnounce() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	announcingAccount := suite.testAccounts["remote_account_1"]
+	ctx := createTestContext(receivingAccount, announcingAccount)
+
+	// The Undo overtakes the Announce it undoes.
+	announce := suite.testActivities["announce_forwarded_1_zork"].Activity.(vocab.ActivityStreamsAnnounce)
+	err := suite.federatingDB.Undo(ctx, suite.newUndoAnnounce(announce, announcingAccount.URI))
+	suite.NoError(err)
+	suite.Empty(suite.fromFederator)
+
+	gone, err := suite.db.TombstoneExistsWithURI(ctx, announce.GetJSONLDId().GetIRI().String())
+	suite.NoError(err)
+	suite.True(gone)
+
+	// So when the Announce turns
+	// up, it should be ignored.
+	err = suite.federatingDB.Announce(ctx, announce)
+	suite.NoError(err)
+	suite.Empty(suite.fromFederator)
+}
+
+func (suite *UndoTestSuite) TestUndoAnnounceOtherDomain() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	announcingAccount := suite.testAccounts["remote_account_1"]
+	ctx := createTestContext(receivingAccount, announcingAccount)
+
+	// Announce by our actor, but with
+	// an ID on someone else's domain.
+	announce := streams.NewActivityStreamsAnnounce()
+	announce.SetJSONLDId(streams.NewJSONLDIdProperty())
+	announce.GetJSONLDId().SetIRI(testrig.URLMustParse("http://example.org/users/someone/activity/announce/01H8GWE8TZ0N2EBM6TWX3SXFEY"))
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(testrig.URLMustParse(announcingAccount.URI))
+	announce.SetActivityStreamsActor(actorProp)
+
+	err := suite.federatingDB.Undo(ctx, suite.newUndoAnnounce(announce, announcingAccount.URI))
+	suite.NoError(err)
+	suite.Empty(suite.fromFederator)
+
+	// No tombstone should have been left
+	// behind for the other domain's Announce.
+	gone, err := suite.db.TombstoneExistsWithURI(ctx, announce.GetJSONLDId().GetIRI().String())
+	suite.NoError(err)
+	suite.False(gone)
+}
+
+func (suite *UndoTestSuite) TestUndoLikeBeforeLike() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	likingAccount := suite.testAccounts["remote_account_1"]
+	ctx := createTestContext(receivingAccount, likingAccount)
+
+	like, err := suite.tc.FaveToAS(ctx, &gtsmodel.StatusFave{
+		ID:              id.NewULID(),
+		URI:             likingAccount.URI + "/likes/" + id.NewULID(),
+		AccountID:       likingAccount.ID,
+		TargetAccountID: receivingAccount.ID,
+		StatusID:        suite.testStatuses["local_account_1_status_1"].ID,
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	undo := streams.NewActivityStreamsUndo()
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(testrig.URLMustParse(likingAccount.URI))
+	undo.SetActivityStreamsActor(actorProp)
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendActivityStreamsLike(like)
+	undo.SetActivityStreamsObject(objectProp)
+
+	// The Undo overtakes the Like it undoes,
+	// so there's nothing to process yet.
+	err = suite.federatingDB.Undo(ctx, undo)
+	suite.NoError(err)
+	suite.Empty(suite.fromFederator)
+
+	// Now the Like turns up.
+	err = suite.federatingDB.Create(ctx, like)
+	suite.NoError(err)
+
+	msg := <-suite.fromFederator
+	suite.Equal(ap.ActivityLike, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+
+	// And the buffered Undo should follow.
+	msg = <-suite.fromFederator
+	suite.Equal(ap.ActivityLike, msg.APObjectType)
+	suite.Equal(ap.ActivityUndo, msg.APActivityType)
+	suite.Empty(suite.fromFederator)
+
+	// Leaving no fave behind.
+	_, err = suite.db.GetStatusFave(ctx, likingAccount.ID, suite.testStatuses["local_account_1_status_1"].ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestUndoTestSuite(t *testing.T) {
+	suite.Run(t, &UndoTestSuite{})
+}
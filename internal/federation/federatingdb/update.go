@@ -21,11 +21,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"codeberg.org/gruf/go-logger/v2/level"
+	"codeberg.org/gruf/go-runners"
+	"codeberg.org/gruf/go-sched"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
@@ -60,6 +66,8 @@ func (f *federatingDB) Update(ctx context.Context, asType vocab.Type) error {
 	switch asType.GetTypeName() {
 	case ap.ActorApplication, ap.ActorGroup, ap.ActorOrganization, ap.ActorPerson, ap.ActorService:
 		return f.updateAccountable(ctx, receivingAccount, requestingAccount, asType)
+	case ap.ObjectNote, ap.ObjectArticle, ap.ObjectPage, ap.ObjectEvent, ap.ActivityQuestion:
+		return f.updateStatusable(ctx, receivingAccount, requestingAccount, asType)
 	}
 
 	return nil
@@ -94,15 +102,275 @@ func (f *federatingDB) updateAccountable(ctx context.Context, receivingAcct *gts
 	updatedAcct.AvatarMediaAttachmentID = requestingAcct.AvatarMediaAttachmentID
 	updatedAcct.HeaderMediaAttachmentID = requestingAcct.HeaderMediaAttachmentID
 
-	// Pass to the processor for further updating of eg., avatar/header,
-	// emojis, etc. The actual db insert/update will take place there.
-	f.state.Workers.EnqueueFederator(ctx, messages.FromFederator{
+	msg := messages.FromFederator{
 		APObjectType:     ap.ObjectProfile,
 		APActivityType:   ap.ActivityUpdate,
 		GTSModel:         updatedAcct,
 		APObjectModel:    accountable,
 		ReceivingAccount: receivingAcct,
+	}
+
+	// Check whether this account has been sending too many
+	// updates; if so, only the latest will be processed later.
+	// Redeliveries of an update we've seen are dropped here.
+	key := accountUpdateKey(ctx, accountable)
+	ok, at := f.accountUpdates.throttle(updatedAcct.URI, key, msg, time.Now())
+	if !ok {
+		if !at.IsZero() {
+			log.Debugf(ctx, "throttling updates for account %s until %s", updatedAcct.URI, at)
+			f.scheduleAccountUpdate(updatedAcct.URI, at)
+		}
+		return nil
+	}
+
+	// Pass to the processor for further updating of eg., avatar/header,
+	// emojis, etc. The actual db insert/update will take place there.
+	f.state.Workers.EnqueueFederator(ctx, msg)
+
+	return nil
+}
+
+func (f *federatingDB) updateStatusable(ctx context.Context, receivingAcct *gtsmodel.Account, requestingAcct *gtsmodel.Account, asType vocab.Type) error {
+	statusable, ok := asType.(ap.Statusable)
+	if !ok {
+		return errors.New("updateStatusable: could not convert vocab.Type to Statusable")
+	}
+
+	idProp := statusable.GetJSONLDId()
+	if idProp == nil || !idProp.IsIRI() {
+		return errors.New("updateStatusable: statusable had no id")
+	}
+	statusURI := idProp.GetIRI()
+
+	attributedTo, err := ap.ExtractAttributedToURI(statusable)
+	if err != nil {
+		return fmt.Errorf("updateStatusable: error extracting attributedTo: %w", err)
+	}
+
+	if requestingAcct.URI != attributedTo.String() {
+		return fmt.Errorf("updateStatusable: update for status %s was requested by account %s, this is not valid", statusURI, requestingAcct.URI)
+	}
+
+	update := func(ctx context.Context) (bool, error) {
+		status, err := f.state.DB.GetStatusByURI(gtscontext.SetBarebones(ctx), statusURI.String())
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// We don't have
+				// this status (yet).
+				return false, nil
+			}
+			return false, fmt.Errorf("updateStatusable: db error getting status %s: %w", statusURI, err)
+		}
+
+		if status.AccountID != requestingAcct.ID {
+			// Status belongs to someone else, ignore.
+			return true, nil
+		}
+
+		// Pass to the processor to refresh the status
+		// from the updated statusable, and uncache it.
+		f.state.Workers.EnqueueFederator(ctx, messages.FromFederator{
+			APObjectType:     statusable.GetTypeName(),
+			APActivityType:   ap.ActivityUpdate,
+			GTSModel:         status,
+			APObjectModel:    statusable,
+			ReceivingAccount: receivingAcct,
+		})
+
+		return true, nil
+	}
+
+	updated, err := update(ctx)
+	if err != nil || updated {
+		return err
+	}
+
+	// We don't have this status (yet). The Update may have
+	// overtaken the Create, so buffer it until the status lands.
+	f.bufferActivity(ctx, statusURI.String(), &pendingActivity{
+		name:   "Update",
+		replay: update,
+	})
+
+	// In the meantime, have the processor
+	// dereference the status from its origin.
+	f.state.Workers.EnqueueFederator(ctx, messages.FromFederator{
+		APObjectType:     statusable.GetTypeName(),
+		APActivityType:   ap.ActivityCreate,
+		APIri:            statusURI,
+		ReceivingAccount: receivingAcct,
 	})
 
 	return nil
 }
+
+// scheduleAccountUpdate schedules the pending throttled
+// update for the account with given URI to be processed
+// at the given time.
+func (f *federatingDB) scheduleAccountUpdate(uri string, at time.Time) {
+	// Get ctx associated with scheduler run state.
+	done := f.state.Workers.Scheduler.Done()
+	doneCtx := runners.CancelCtx(done)
+
+	f.state.Workers.Scheduler.Schedule(sched.NewJob(func(now time.Time) {
+		msg := f.accountUpdates.take(uri, now)
+		if msg == nil {
+			// Superseded by an update
+			// that was processed already.
+			return
+		}
+
+		f.state.Workers.EnqueueFederator(doneCtx, *msg)
+	}).At(at))
+}
+
+const (
+	accountUpdateLimit  = 5         // max account updates processed per actor per window
+	accountUpdateWindow = time.Hour // window over which account updates are limited
+)
+
+// accountUpdateKey returns the key by which redeliveries of an update
+// of the given accountable are recognized: the ID of the incoming Update
+// activity, or else the updated time of the accountable, if either is set.
+func accountUpdateKey(ctx context.Context, accountable ap.Accountable) string {
+	if activityID := gtscontext.ActivityID(ctx); activityID != nil {
+		return activityID.String()
+	}
+
+	if withUpdated, ok := accountable.(ap.WithUpdated); ok {
+		updatedProp := withUpdated.GetActivityStreamsUpdated()
+		if updatedProp != nil && updatedProp.IsXMLSchemaDateTime() {
+			return "updated:" + updatedProp.Get().UTC().Format(time.RFC3339Nano)
+		}
+	}
+
+	return ""
+}
+
+// accountUpdates throttles processing of incoming account updates per
+// actor: once an actor has sent accountUpdateLimit updates within the
+// accountUpdateWindow, further updates are not processed immediately.
+// Instead, only the latest is kept, and processed lazily once the
+// oldest update drops out of the window. Updates with a key that was
+// already seen within the window are redeliveries, and are dropped
+// without counting towards the limit.
+type accountUpdates struct {
+	actors    map[string]*actorUpdates
+	lastSweep time.Time
+	mutex     sync.Mutex
+}
+
+// actorUpdates holds the update state for a single actor.
+type actorUpdates struct {
+	times   []time.Time             // times of processed updates, oldest first
+	pending *messages.FromFederator // latest throttled update, if any
+	seen    map[string]time.Time    // keys of updates received, by time received
+}
+
+// throttle records an update with given key and msg from the actor with given
+// URI at the given time. It returns true if the update should be processed now.
+// If the key is not empty and was seen already, the update is dropped. Otherwise,
+// the msg is kept as pending, and if the returned time is not zero the caller
+// should schedule it to be taken + processed at that time.
+func (a *accountUpdates) throttle(uri string, key string, msg messages.FromFederator, now time.Time) (bool, time.Time) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.sweep(now)
+
+	actor, ok := a.actors[uri]
+	if !ok {
+		actor = new(actorUpdates)
+		a.actors[uri] = actor
+	}
+	actor.prune(now)
+
+	if key != "" {
+		if _, ok := actor.seen[key]; ok {
+			// Redelivery of an update
+			// that we've already got.
+			return false, time.Time{}
+		}
+
+		if actor.seen == nil {
+			actor.seen = make(map[string]time.Time)
+		}
+		actor.seen[key] = now
+	}
+
+	if len(actor.times) < accountUpdateLimit {
+		// Under the limit, process now. Any
+		// pending update is superseded by this.
+		actor.times = append(actor.times, now)
+		actor.pending = nil
+		return true, time.Time{}
+	}
+
+	scheduled := actor.pending != nil
+	actor.pending = &msg
+
+	if scheduled {
+		// Already scheduled to
+		// process pending update.
+		return false, time.Time{}
+	}
+
+	// Pending update can be processed
+	// once oldest falls out of window.
+	return false, actor.times[0].Add(accountUpdateWindow)
+}
+
+// take returns the pending update for the actor
+// with given URI, if any, recording it as processed
+// at the given time.
+func (a *accountUpdates) take(uri string, now time.Time) *messages.FromFederator {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	actor, ok := a.actors[uri]
+	if !ok || actor.pending == nil {
+		return nil
+	}
+
+	actor.prune(now)
+	actor.times = append(actor.times, now)
+
+	msg := actor.pending
+	actor.pending = nil
+	return msg
+}
+
+// sweep removes state for actors with no recent or pending
+// updates, at most once per window. Mutex must be held.
+func (a *accountUpdates) sweep(now time.Time) {
+	if now.Sub(a.lastSweep) < accountUpdateWindow {
+		return
+	}
+
+	for uri, actor := range a.actors {
+		actor.prune(now)
+		if len(actor.times) == 0 && len(actor.seen) == 0 && actor.pending == nil {
+			delete(a.actors, uri)
+		}
+	}
+
+	a.lastSweep = now
+}
+
+// prune drops update times and keys which have fallen out of the window.
+func (u *actorUpdates) prune(now time.Time) {
+	cutoff := now.Add(-accountUpdateWindow)
+
+	var i int
+	for i < len(u.times) && !u.times[i].After(cutoff) {
+		i++
+	}
+
+	u.times = u.times[i:]
+
+	for key, t := range u.seen {
+		if !t.After(cutoff) {
+			delete(u.seen, key)
+		}
+	}
+}
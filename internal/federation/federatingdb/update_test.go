@@ -0,0 +1,228 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type UpdateTestSuite struct {
+	FederatingDBTestSuite
+}
+
+func (suite *UpdateTestSuite) updateAccount(receivingAccount *gtsmodel.Account, requestingAccount *gtsmodel.Account) {
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	asAccount, err := suite.tc.AccountToAS(context.Background(), requestingAccount)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	err = suite.federatingDB.Update(ctx, asAccount)
+	suite.NoError(err)
+}
+
+func (suite *UpdateTestSuite) TestUpdateAccount() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+
+	// Copy the requesting account, since we'll be changing it.
+	requestingAccount := new(gtsmodel.Account)
+	*requestingAccount = *suite.testAccounts["remote_account_1"]
+	requestingAccount.DisplayName = "updated display name!"
+
+	suite.updateAccount(receivingAccount, requestingAccount)
+
+	msg := <-suite.fromFederator
+	updatedAccount, ok := msg.GTSModel.(*gtsmodel.Account)
+	if !ok {
+		suite.FailNow("GTSModel was not *gtsmodel.Account")
+	}
+	suite.Equal(requestingAccount.ID, updatedAccount.ID)
+	suite.Equal("updated display name!", updatedAccount.DisplayName)
+}
+
+func (suite *UpdateTestSuite) TestUpdateAccountThrottled() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+
+	// Copy the requesting account, since we'll be changing it.
+	requestingAccount := new(gtsmodel.Account)
+	*requestingAccount = *suite.testAccounts["remote_account_1"]
+
+	for i := 0; i < 7; i++ {
+		requestingAccount.DisplayName = fmt.Sprintf("display name %d", i)
+		suite.updateAccount(receivingAccount, requestingAccount)
+	}
+
+	// Only the first 5 updates should
+	// have been passed on for processing.
+	for i := 0; i < 5; i++ {
+		msg := <-suite.fromFederator
+		updatedAccount, ok := msg.GTSModel.(*gtsmodel.Account)
+		if !ok {
+			suite.FailNow("GTSModel was not *gtsmodel.Account")
+		}
+		suite.Equal(fmt.Sprintf("display name %d", i), updatedAccount.DisplayName)
+	}
+	suite.Empty(suite.fromFederator)
+
+	// Updates from another account
+	// should not be throttled.
+	otherAccount := new(gtsmodel.Account)
+	*otherAccount = *suite.testAccounts["remote_account_2"]
+	otherAccount.DisplayName = "some other display name"
+	suite.updateAccount(receivingAccount, otherAccount)
+
+	msg := <-suite.fromFederator
+	updatedAccount, ok := msg.GTSModel.(*gtsmodel.Account)
+	if !ok {
+		suite.FailNow("GTSModel was not *gtsmodel.Account")
+	}
+	suite.Equal("some other display name", updatedAccount.DisplayName)
+}
+
+func (suite *UpdateTestSuite) TestUpdateAccountRedelivered() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+
+	// Copy the requesting account, since we'll be changing it.
+	requestingAccount := new(gtsmodel.Account)
+	*requestingAccount = *suite.testAccounts["remote_account_1"]
+
+	update := func(activityID string, displayName string) {
+		ctx := createTestContext(receivingAccount, requestingAccount)
+		ctx = gtscontext.SetActivityID(ctx, testrig.URLMustParse(activityID))
+
+		requestingAccount.DisplayName = displayName
+		asAccount, err := suite.tc.AccountToAS(context.Background(), requestingAccount)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		err = suite.federatingDB.Update(ctx, asAccount)
+		suite.NoError(err)
+	}
+
+	// The same update delivered 7 times should
+	// only be passed on for processing once.
+	for i := 0; i < 7; i++ {
+		update("http://fossbros-anonymous.io/updates/0", "display name 0")
+	}
+
+	msg := <-suite.fromFederator
+	suite.Equal("display name 0", msg.GTSModel.(*gtsmodel.Account).DisplayName)
+	suite.Empty(suite.fromFederator)
+
+	// Redeliveries shouldn't count towards the limit,
+	// so 4 other updates should be passed on, before
+	// throttling kicks in again.
+	for i := 1; i <= 5; i++ {
+		update(fmt.Sprintf("http://fossbros-anonymous.io/updates/%d", i), fmt.Sprintf("display name %d", i))
+	}
+
+	for i := 1; i <= 4; i++ {
+		msg := <-suite.fromFederator
+		suite.Equal(fmt.Sprintf("display name %d", i), msg.GTSModel.(*gtsmodel.Account).DisplayName)
+	}
+	suite.Empty(suite.fromFederator)
+}
+
+func (suite *UpdateTestSuite) TestUpdateStatus() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	status := suite.testStatuses["remote_account_1_status_1"]
+	statusable, err := suite.tc.StatusToAS(context.Background(), status)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	err = suite.federatingDB.Update(ctx, statusable)
+	suite.NoError(err)
+
+	// Status is known, so the update
+	// should be passed on right away.
+	msg := <-suite.fromFederator
+	suite.Equal(ap.ObjectNote, msg.APObjectType)
+	suite.Equal(ap.ActivityUpdate, msg.APActivityType)
+	suite.Equal(status.ID, msg.GTSModel.(*gtsmodel.Status).ID)
+	suite.Equal(statusable, msg.APObjectModel)
+}
+
+func (suite *UpdateTestSuite) TestUpdateStatusWrongActor() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_2"]
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	statusable, err := suite.tc.StatusToAS(context.Background(), suite.testStatuses["remote_account_1_status_1"])
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	err = suite.federatingDB.Update(ctx, statusable)
+	suite.Error(err)
+	suite.Empty(suite.fromFederator)
+}
+
+func (suite *UpdateTestSuite) TestUpdateStatusBeforeCreate() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	create := suite.testActivities["dm_for_zork"].Activity.(vocab.ActivityStreamsCreate)
+	note := create.GetActivityStreamsObject().At(0).GetActivityStreamsNote()
+
+	// The Update overtakes the Create of the status.
+	err := suite.federatingDB.Update(ctx, note)
+	suite.NoError(err)
+
+	// The update can't be processed yet, but
+	// the status should be dereferenced meanwhile.
+	msg := <-suite.fromFederator
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+	suite.Equal(note.GetJSONLDId().GetIRI(), msg.APIri)
+	suite.Nil(msg.GTSModel)
+	suite.Empty(suite.fromFederator)
+
+	// Now the Create turns up.
+	err = suite.federatingDB.Create(ctx, create)
+	suite.NoError(err)
+
+	msg = <-suite.fromFederator
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+	status := msg.GTSModel.(*gtsmodel.Status)
+
+	// And the buffered update should follow.
+	msg = <-suite.fromFederator
+	suite.Equal(ap.ActivityUpdate, msg.APActivityType)
+	suite.Equal(status.ID, msg.GTSModel.(*gtsmodel.Status).ID)
+	suite.Equal(note, msg.APObjectModel)
+	suite.Empty(suite.fromFederator)
+}
+
+func TestUpdateTestSuite(t *testing.T) {
+	suite.Run(t, &UpdateTestSuite{})
+}
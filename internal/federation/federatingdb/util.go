@@ -187,6 +187,19 @@ func (f *federatingDB) NewID(ctx context.Context, t vocab.Type) (idURL *url.URL,
 				return idProp.GetIRI(), nil
 			}
 		}
+	case ap.ActivityActivity:
+		// BITE
+		// ID might already be set on a bite we've created, so check it here and return it if it is
+		bite, ok := t.(vocab.ActivityStreamsActivity)
+		if !ok {
+			return nil, errors.New("newid: bite couldn't be parsed into vocab.ActivityStreamsActivity")
+		}
+		idProp := bite.GetJSONLDId()
+		if idProp != nil {
+			if idProp.IsIRI() {
+				return idProp.GetIRI(), nil
+			}
+		}
 	case ap.ActivityUndo:
 		// UNDO
 		// ID might already be set on an undo we've created, so check it here and return it if it is
@@ -22,7 +22,9 @@ import (
 	"errors"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"codeberg.org/gruf/go-kv"
 	"github.com/superseriousbusiness/activity/pub"
@@ -38,6 +40,10 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
+// backlogRetryAfter is the Retry-After value, in seconds, sent
+// for deliveries refused while the maintenance backlog is full.
+const backlogRetryAfter = "300"
+
 type errOtherIRIBlocked struct {
 	account     string
 	domainBlock bool
@@ -101,6 +107,11 @@ func (f *federator) PostInboxRequestBodyHook(ctx context.Context, r *http.Reques
 	activityID, err := pub.GetId(activity)
 	if err == nil {
 		otherIRIs = append(otherIRIs, activityID)
+
+		// Set the ID on the context too, so that
+		// redeliveries of this activity can be
+		// recognized when handling it later.
+		ctx = gtscontext.SetActivityID(ctx, activityID)
 	}
 
 	// Check if the Activity has an 'inReplyTo'.
@@ -118,6 +129,12 @@ func (f *federator) PostInboxRequestBodyHook(ctx context.Context, r *http.Reques
 
 	// Now perform the same checks, but for the Object(s) of the Activity.
 	objectProp := activity.GetActivityStreamsObject()
+	if objectProp == nil {
+		// Some activities (eg., Bite)
+		// have no object, only a target.
+		objectProp = streams.NewActivityStreamsObjectProperty()
+	}
+
 	for iter := objectProp.Begin(); iter != objectProp.End(); iter = iter.Next() {
 		if iter.IsIRI() {
 			otherIRIs = append(otherIRIs, iter.GetIRI())
@@ -208,6 +225,25 @@ func (f *federator) AuthenticatePostInbox(ctx context.Context, w http.ResponseWr
 		return nil, false, err
 	}
 
+	if !receivingAccount.SuspendedAt.IsZero() {
+		// The inbox owner has been deleted or
+		// suspended, so there's nobody to deliver
+		// to. Write 202 and leave without further
+		// processing: responding with an error
+		// would just make the sender retry.
+		w.WriteHeader(http.StatusAccepted)
+		return ctx, false, nil
+	}
+
+	if config.GetMaintenanceMode() && f.backlogFull(ctx) {
+		// Deliveries are deferred while in maintenance
+		// mode, but there's no room left to defer more.
+		// Write 503 so the sender retries them later.
+		w.Header().Set("Retry-After", backlogRetryAfter)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return ctx, false, nil
+	}
+
 	// Check who's trying to deliver to us by inspecting the http signature.
 	pubKeyOwner, errWithCode := f.AuthenticateFederatedRequest(ctx, receivingAccount.Username)
 	if errWithCode != nil {
@@ -232,7 +268,24 @@ func (f *federator) AuthenticatePostInbox(ctx context.Context, w http.ResponseWr
 		}
 	}
 
-	// Authentication has passed, check if we need to create a
+	// Authentication has passed, so we know which domain this
+	// delivery comes from: make sure it's not sending too many.
+	limited, retryAfter, err := f.inboxLimited(ctx, pubKeyOwner.Host)
+	if err != nil {
+		err = gtserror.Newf("error checking inbox rate limit for %s: %w", pubKeyOwner.Host, err)
+		return ctx, false, err
+	}
+
+	if limited {
+		// Write 429 and bail, setting Retry-After so that
+		// well-behaved senders back off until the reset.
+		secs := int(retryAfter.Round(time.Second) / time.Second)
+		w.Header().Set("Retry-After", strconv.Itoa(secs))
+		w.WriteHeader(http.StatusTooManyRequests)
+		return ctx, false, nil
+	}
+
+	// Check if we need to create a
 	// new instance entry for the Host of the requesting account.
 	if _, err := f.db.GetInstance(ctx, pubKeyOwner.Host); err != nil {
 		if !errors.Is(err, db.ErrNoEntries) {
@@ -520,6 +573,21 @@ func (f *federator) FederatingCallbacks(ctx context.Context) (wrapped pub.Federa
 		func(ctx context.Context, announce vocab.ActivityStreamsAnnounce) error {
 			return f.FederatingDB().Announce(ctx, announce)
 		},
+		func(ctx context.Context, add vocab.ActivityStreamsAdd) error {
+			return f.FederatingDB().Add(ctx, add)
+		},
+		func(ctx context.Context, remove vocab.ActivityStreamsRemove) error {
+			return f.FederatingDB().Remove(ctx, remove)
+		},
+		func(ctx context.Context, listen vocab.ActivityStreamsListen) error {
+			return f.FederatingDB().Listen(ctx, listen)
+		},
+		func(ctx context.Context, activity vocab.ActivityStreamsActivity) error {
+			return f.FederatingDB().Bite(ctx, activity)
+		},
+		func(ctx context.Context, dislike vocab.ActivityStreamsDislike) error {
+			return f.FederatingDB().Dislike(ctx, dislike)
+		},
 	}
 
 	return
@@ -581,3 +649,15 @@ func (f *federator) GetInbox(ctx context.Context, r *http.Request) (vocab.Activi
 	// the CLIENT API, not through the federation API, so we just do nothing here.
 	return streams.NewActivityStreamsOrderedCollectionPage(), nil
 }
+
+// backlogFull returns whether the backlog of deliveries
+// deferred by maintenance mode has no room left. Errors
+// are logged, and treated as the backlog not being full.
+func (f *federator) backlogFull(ctx context.Context) bool {
+	full, err := f.backlog.Full(ctx)
+	if err != nil {
+		log.Errorf(ctx, "error checking backlog: %v", err)
+		return false
+	}
+	return full
+}
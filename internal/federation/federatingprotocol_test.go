@@ -30,8 +30,11 @@ import (
 	"github.com/go-fed/httpsig"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/workers"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -257,6 +260,70 @@ func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInbox() {
 	suite.Equal(http.StatusOK, code)
 }
 
+func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInboxRateLimited() {
+	var (
+		activity         = suite.testActivities["dm_for_zork"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+	)
+
+	// Permit only one delivery per domain,
+	// and rebuild federator to pick this up.
+	config.SetAdvancedInboxRateLimitRequests(1)
+	suite.federator = testrig.NewTestFederator(&suite.state, suite.transportController, testrig.NewTestMediaManager(&suite.state))
+
+	_, authed, _, code := suite.authenticatePostInbox(
+		context.Background(),
+		receivingAccount,
+		activity,
+	)
+	suite.True(authed)
+	suite.Equal(http.StatusOK, code)
+
+	// Second delivery from the same domain should be limited.
+	ctx, authed, resp, code := suite.authenticatePostInbox(
+		context.Background(),
+		receivingAccount,
+		activity,
+	)
+	suite.Nil(gtscontext.RequestingAccount(ctx))
+	suite.False(authed)
+	suite.Equal([]byte{}, resp)
+	suite.Equal(http.StatusTooManyRequests, code)
+}
+
+func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInboxBacklogFull() {
+	var (
+		activity         = suite.testActivities["dm_for_zork"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+	)
+
+	// Fill up the backlog of
+	// deliveries in maintenance.
+	config.SetMaintenanceMode(true)
+	if err := suite.state.Workers.FederatorBacklog.Append(
+		context.Background(),
+		make([]messages.FromFederator, workers.BacklogMaxLen)...,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer func() {
+		config.SetMaintenanceMode(false)
+		if _, err := suite.state.Workers.FederatorBacklog.Take(context.Background()); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}()
+
+	ctx, authed, resp, code := suite.authenticatePostInbox(
+		context.Background(),
+		receivingAccount,
+		activity,
+	)
+	suite.Nil(gtscontext.RequestingAccount(ctx))
+	suite.False(authed)
+	suite.Equal([]byte{}, resp)
+	suite.Equal(http.StatusServiceUnavailable, code)
+}
+
 func (suite *FederatingProtocolTestSuite) TestAuthenticatePostGoneWithTombstone() {
 	var (
 		activity         = suite.testActivities["delete_https://somewhere.mysterious/users/rest_in_piss#main-key"]
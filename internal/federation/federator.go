@@ -22,6 +22,7 @@ import (
 	"net/url"
 
 	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/federatingdb"
@@ -30,6 +31,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/workers"
+	"github.com/ulule/limiter/v3"
 )
 
 // Federator wraps various interfaces and functions to manage activitypub federation from gotosocial
@@ -64,6 +67,8 @@ type federator struct {
 	transportController transport.Controller
 	mediaManager        *media.Manager
 	actor               pub.FederatingActor
+	inboxLimiter        *limiter.Limiter
+	backlog             *workers.Backlog
 	dereferencing.Dereferencer
 }
 
@@ -79,6 +84,8 @@ func NewFederator(state *state.State, federatingDB federatingdb.DB, transportCon
 		typeConverter:       typeConverter,
 		transportController: transportController,
 		mediaManager:        mediaManager,
+		inboxLimiter:        newInboxLimiter(config.GetAdvancedInboxRateLimitRequests()),
+		backlog:             &state.Workers.FederatorBacklog,
 		Dereferencer:        dereferencer,
 	}
 	actor := newFederatingActor(f, f, federatingDB, clock)
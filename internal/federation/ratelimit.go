@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"context"
+	"time"
+
+	"github.com/ulule/limiter/v3"
+	"github.com/ulule/limiter/v3/drivers/store/memory"
+)
+
+const inboxRateLimitPeriod = 5 * time.Minute
+
+// newInboxLimiter returns a limiter for inbox deliveries, permitting
+// the given limit of deliveries per inboxRateLimitPeriod to be made
+// from each remote domain. If limit <= 0, nil is returned.
+func newInboxLimiter(limit int) *limiter.Limiter {
+	if limit <= 0 {
+		return nil
+	}
+
+	return limiter.New(
+		memory.NewStore(),
+		limiter.Rate{Period: inboxRateLimitPeriod, Limit: int64(limit)},
+	)
+}
+
+// inboxLimited counts an inbox delivery from the given domain
+// against the inbox rate limit, and returns whether the limit
+// has now been exceeded, along with how long until it resets.
+func (f *federator) inboxLimited(ctx context.Context, domain string) (bool, time.Duration, error) {
+	if f.inboxLimiter == nil {
+		// Rate limiting disabled.
+		return false, 0, nil
+	}
+
+	lctx, err := f.inboxLimiter.Get(ctx, domain)
+	if err != nil {
+		return false, 0, err
+	}
+
+	if !lctx.Reached {
+		return false, 0, nil
+	}
+
+	return true, time.Until(time.Unix(lctx.Reset, 0)), nil
+}
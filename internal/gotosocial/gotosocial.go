@@ -19,11 +19,14 @@ package gotosocial
 
 import (
 	"context"
+	"time"
 
-	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
 )
 
 // Server is the 'main' function of the gotosocial server, and the place where everything hangs together.
@@ -32,18 +35,18 @@ type Server interface {
 	// Start starts up the gotosocial server. If something goes wrong
 	// while starting the server, then an error will be returned.
 	Start(context.Context) error
-	// Stop closes down the gotosocial server, first closing the router
-	// then the database. If something goes wrong while stopping, an
-	// error will be returned.
+	// Stop closes down the gotosocial server, first closing the router,
+	// then draining the worker queues, then closing the database. If
+	// something goes wrong while stopping, an error will be returned.
 	Stop(context.Context) error
 }
 
 // NewServer returns a new gotosocial server, initialized with the given configuration.
 // An error will be returned the caller if something goes wrong during initialization
 // eg., no db or storage connection, port for router already in use, etc.
-func NewServer(db db.DB, apiRouter router.Router, federator federation.Federator, mediaManager *media.Manager) (Server, error) {
+func NewServer(state *state.State, apiRouter router.Router, federator federation.Federator, mediaManager *media.Manager) (Server, error) {
 	return &gotosocial{
-		db:           db,
+		state:        state,
 		apiRouter:    apiRouter,
 		federator:    federator,
 		mediaManager: mediaManager,
@@ -52,7 +55,7 @@ func NewServer(db db.DB, apiRouter router.Router, federator federation.Federator
 
 // gotosocial fulfils the gotosocial interface.
 type gotosocial struct {
-	db           db.DB
+	state        *state.State
 	apiRouter    router.Router
 	federator    federation.Federator
 	mediaManager *media.Manager
@@ -66,12 +69,60 @@ func (gts *gotosocial) Start(ctx context.Context) error {
 }
 
 // Stop closes down the gotosocial server, first closing the router,
-// then the media manager, then the database.
+// then draining the worker queues, then the database.
 // If something goes wrong while stopping, an error will be returned.
 func (gts *gotosocial) Stop(ctx context.Context) error {
+	// Stop accepting new requests, and
+	// wait on any in-flight handlers.
 	if err := gts.apiRouter.Stop(ctx); err != nil {
 		return err
 	}
 
-	return gts.db.Stop(ctx)
+	// Finish up any queued side effects of
+	// the requests handled so far, while we
+	// still have a database to write them to.
+	gts.drainWorkers(ctx)
+
+	return gts.state.DB.Stop(ctx)
+}
+
+// drainWorkers waits for the worker queues to empty,
+// up to the configured shutdown grace period.
+func (gts *gotosocial) drainWorkers(ctx context.Context) {
+	grace := config.GetShutdownGracePeriod()
+	start := time.Now()
+
+	drainCtx, cancel := context.WithTimeout(ctx, grace)
+	defer cancel()
+
+	queued := gts.state.Workers.Queued()
+	log.Infof(ctx, "draining worker queues (%d queued) with %s grace period", queued, grace)
+
+	// Log progress while we wait, so that it's
+	// clear to admins why shutdown is taking a
+	// while when there's a lot left to process.
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(5 * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				log.Infof(ctx, "still draining worker queues (%d queued)", gts.state.Workers.Queued())
+			}
+		}
+	}()
+
+	remaining := gts.state.Workers.Drain(drainCtx)
+	close(done)
+
+	if remaining > 0 {
+		log.Warnf(ctx, "shutdown grace period expired, dropping %d queued tasks", remaining)
+		return
+	}
+
+	log.Infof(ctx, "drained worker queues in %s", time.Since(start))
 }
@@ -42,6 +42,9 @@ const (
 	httpSigKey
 	httpSigPubKeyIDKey
 	dryRunKey
+	webSessionIDKey
+	backfillKey
+	activityIDKey
 )
 
 // DryRun returns whether the "dryrun" context key has been set. This can be
@@ -58,6 +61,20 @@ func SetDryRun(ctx context.Context) context.Context {
 	return context.WithValue(ctx, dryRunKey, struct{}{})
 }
 
+// Backfill returns whether the "backfill" context key has been set. This can be
+// used to indicate to dereferencing functions that statuses are being fetched to
+// backfill the recent statuses of an account, so new ones are marked as backfilled.
+func Backfill(ctx context.Context) bool {
+	_, ok := ctx.Value(backfillKey).(struct{})
+	return ok
+}
+
+// SetBackfill sets the "backfill" context flag and returns this wrapped context.
+// See Backfill() for further information on the "backfill" context flag.
+func SetBackfill(ctx context.Context) context.Context {
+	return context.WithValue(ctx, backfillKey, struct{}{})
+}
+
 // RequestID returns the request ID associated with context. This value will usually
 // be set by the request ID middleware handler, either pulling an existing supplied
 // value from request headers, or generating a unique new entry. This is useful for
@@ -113,6 +130,20 @@ func SetRequestingAccount(ctx context.Context, acct *gtsmodel.Account) context.C
 	return context.WithValue(ctx, requestingAccountKey, acct)
 }
 
+// ActivityID returns the ID of the incoming activity being processed,
+// set on the context when the activity is posted to an inbox. This may
+// be nil, eg., if the activity was dereferenced rather than delivered.
+func ActivityID(ctx context.Context) *url.URL {
+	iri, _ := ctx.Value(activityIDKey).(*url.URL)
+	return iri
+}
+
+// SetActivityID stores the given incoming activity ID value and returns
+// the wrapped context. See ActivityID() for further information.
+func SetActivityID(ctx context.Context, iri *url.URL) context.Context {
+	return context.WithValue(ctx, activityIDKey, iri)
+}
+
 // OtherIRIs returns other IRIs which are involved in the current ActivityPub request
 // chain. This usually means: other accounts who are mentioned, CC'd, TO'd, or boosted
 // by the current inbox POST request.
@@ -166,6 +197,19 @@ func SetHTTPSignaturePubKeyID(ctx context.Context, pubKeyID *url.URL) context.Co
 	return context.WithValue(ctx, httpSigPubKeyIDKey, pubKeyID)
 }
 
+// WebSessionID returns the ID of the web session associated with context.
+// This is used to link oauth tokens to the web session they're created through.
+func WebSessionID(ctx context.Context) string {
+	id, _ := ctx.Value(webSessionIDKey).(string)
+	return id
+}
+
+// SetWebSessionID stores the given web session ID value and returns the wrapped
+// context. See WebSessionID() for further information on the web session ID value.
+func SetWebSessionID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, webSessionIDKey, id)
+}
+
 // IsFastFail returns whether the "fastfail" context key has been set. This
 // can be used to indicate to an http client, for example, that the result
 // of an outgoing request is time sensitive and so not to bother with retries.
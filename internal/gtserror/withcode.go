@@ -188,6 +188,45 @@ func NewErrorGone(original error, helpText ...string) WithCode {
 	}
 }
 
+// NewErrorRequestEntityTooLarge returns an ErrorWithCode 413 with the given original error and optional help text.
+func NewErrorRequestEntityTooLarge(original error, helpText ...string) WithCode {
+	safe := http.StatusText(http.StatusRequestEntityTooLarge)
+	if helpText != nil {
+		safe = safe + ": " + strings.Join(helpText, ": ")
+	}
+	return withCode{
+		original: original,
+		safe:     errors.New(safe),
+		code:     http.StatusRequestEntityTooLarge,
+	}
+}
+
+// NewErrorServiceUnavailable returns an ErrorWithCode 503 with the given original error and optional help text.
+func NewErrorServiceUnavailable(original error, helpText ...string) WithCode {
+	safe := http.StatusText(http.StatusServiceUnavailable)
+	if helpText != nil {
+		safe = safe + ": " + strings.Join(helpText, ": ")
+	}
+	return withCode{
+		original: original,
+		safe:     errors.New(safe),
+		code:     http.StatusServiceUnavailable,
+	}
+}
+
+// NewErrorInsufficientStorage returns an ErrorWithCode 507 with the given original error and optional help text.
+func NewErrorInsufficientStorage(original error, helpText ...string) WithCode {
+	safe := http.StatusText(http.StatusInsufficientStorage)
+	if helpText != nil {
+		safe = safe + ": " + strings.Join(helpText, ": ")
+	}
+	return withCode{
+		original: original,
+		safe:     errors.New(safe),
+		code:     http.StatusInsufficientStorage,
+	}
+}
+
 // NewErrorClientClosedRequest returns an ErrorWithCode 499 with the given original error.
 // This error type should only be used when an http caller has already hung up their request.
 // See: https://en.wikipedia.org/wiki/List_of_HTTP_status_codes#nginx
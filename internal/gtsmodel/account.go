@@ -71,6 +71,7 @@ type Account struct {
 	FollowingURI            string           `validate:"required_without=Domain,omitempty,url" bun:",nullzero,unique"`                                               // URI for getting the following list of this account
 	FollowersURI            string           `validate:"required_without=Domain,omitempty,url" bun:",nullzero,unique"`                                               // URI for getting the followers list of this account
 	FeaturedCollectionURI   string           `validate:"required_without=Domain,omitempty,url" bun:",nullzero,unique"`                                               // URL for getting the featured collection list of this account
+	FeaturedTagsURI         string           `validate:"omitempty,url" bun:",nullzero"`                                                                              // URL for getting the featured hashtags collection of this account (remote accounts only)
 	ActorType               string           `validate:"oneof=Application Group Organization Person Service" bun:",nullzero,notnull"`                                // What type of activitypub actor is this account?
 	PrivateKey              *rsa.PrivateKey  `validate:"required_without=Domain" bun:""`                                                                             // Privatekey for validating activitypub requests, will only be defined for local accounts
 	PublicKey               *rsa.PublicKey   `validate:"required" bun:",notnull"`                                                                                    // Publickey for encoding activitypub requests, will be defined for both local and remote accounts
@@ -81,6 +82,9 @@ type Account struct {
 	HideCollections         *bool            `validate:"-" bun:",default:false"`                                                                                     // Hide this account's collections
 	SuspensionOrigin        string           `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                                                // id of the database entry that caused this account to become suspended -- can be an account ID or a domain block ID
 	EnableRSS               *bool            `validate:"-" bun:",default:false"`                                                                                     // enable RSS feed subscription for this account's public posts at [URL]/feed
+	HideLinkPreviews        *bool            `validate:"-" bun:",default:false"`                                                                                     // don't offer OpenGraph link previews of this account's profile and statuses, and ask search engines not to index them
+	AttributionDomains      []string         `validate:"-" bun:"attribution_domains,array"`                                                                          // domains of websites allowed to credit this account as author of their pages, in addition to the account's own domain
+	MentionPolicy           MentionPolicy    `validate:"omitempty,oneof=anyone followed followers nobody" bun:",nullzero"`                                           // which accounts may mention this (local) account; empty means anyone
 }
 
 // IsLocal returns whether account is a local user account.
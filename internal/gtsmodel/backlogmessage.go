@@ -0,0 +1,35 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// BacklogMessage models a message from the federator whose processing
+// has been deferred, eg., while the instance is in maintenance mode. It
+// is stored so that deferred messages aren't lost on restart.
+type BacklogMessage struct {
+	ID                 string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt          time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	APObjectType       string    `validate:"-" bun:",nullzero"`                                                   // ActivityStreams type of the object of the activity
+	APActivityType     string    `validate:"-" bun:",nullzero"`                                                   // ActivityStreams type of the activity
+	APIri              string    `validate:"-" bun:",nullzero"`                                                   // IRI of the object of the activity, if set
+	APObject           []byte    `validate:"-" bun:",nullzero"`                                                   // serialized ActivityStreams JSON of the object of the activity, if set
+	GTSModelType       string    `validate:"-" bun:",nullzero"`                                                   // name of the type of the GTS model of the activity or object, if set
+	GTSModel           []byte    `validate:"-" bun:",nullzero"`                                                   // JSON encoded columns of the GTS model of the activity or object, if set
+	ReceivingAccountID string    `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                         // id of the local account which owns the inbox that the activity was posted to
+}
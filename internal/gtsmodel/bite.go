@@ -0,0 +1,33 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+// Bite represents one account playfully biting another
+// account, or one of their statuses, as done with the
+// ActivityPub Bite extension. Bites aren't stored in the
+// database; they only result in a notification for the
+// account that was bitten.
+type Bite struct {
+	URI             string   // ActivityPub URI of the Bite activity
+	AccountID       string   // which account did the biting?
+	Account         *Account // account corresponding to accountID
+	TargetAccountID string   // which account was bitten?
+	TargetAccount   *Account // account corresponding to targetAccountID
+	StatusID        string   // id of the status that was bitten, if a status rather than an account was bitten
+	Status          *Status  // status corresponding to statusID
+}
@@ -21,14 +21,33 @@ import "time"
 
 // DomainBlock represents a federation block against a particular domain
 type DomainBlock struct {
-	ID                 string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
-	CreatedAt          time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
-	UpdatedAt          time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
-	Domain             string    `validate:"required,fqdn" bun:",nullzero,notnull"`                               // domain to block. Eg. 'whatever.com'
-	CreatedByAccountID string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // Account ID of the creator of this block
-	CreatedByAccount   *Account  `validate:"-" bun:"rel:belongs-to"`                                              // Account corresponding to createdByAccountID
-	PrivateComment     string    `validate:"-" bun:""`                                                            // Private comment on this block, viewable to admins
-	PublicComment      string    `validate:"-" bun:""`                                                            // Public comment on this block, viewable (optionally) by everyone
-	Obfuscate          *bool     `validate:"-" bun:",nullzero,notnull,default:false"`                             // whether the domain name should appear obfuscated when displaying it publicly
-	SubscriptionID     string    `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                         // if this block was created through a subscription, what's the subscription ID?
+	ID                 string              `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`              // id of this item in the database
+	CreatedAt          time.Time           `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`       // when was item created
+	UpdatedAt          time.Time           `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`       // when was item last updated
+	Domain             string              `validate:"required,fqdn" bun:",nullzero,notnull"`                                     // domain to block. Eg. 'whatever.com'
+	CreatedByAccountID string              `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                        // Account ID of the creator of this block
+	CreatedByAccount   *Account            `validate:"-" bun:"rel:belongs-to"`                                                    // Account corresponding to createdByAccountID
+	PrivateComment     string              `validate:"-" bun:""`                                                                  // Private comment on this block, viewable to admins
+	PublicComment      string              `validate:"-" bun:""`                                                                  // Public comment on this block, viewable (optionally) by everyone
+	Obfuscate          *bool               `validate:"-" bun:",nullzero,notnull,default:false"`                                   // whether the domain name should appear obfuscated when displaying it publicly
+	SubscriptionID     string              `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                               // if this block was created through a subscription, what's the subscription ID?
+	Severity           DomainBlockSeverity `validate:"omitempty,oneof=silence suspend" bun:",nullzero,notnull,default:'suspend'"` // severity of this block; suspend if not set
+	RejectMedia        *bool               `validate:"-" bun:",nullzero,notnull,default:false"`                                   // don't fetch media from this domain
+	RejectReports      *bool               `validate:"-" bun:",nullzero,notnull,default:false"`                                   // ignore reports federated from this domain
 }
+
+// IsSilence returns whether this block only silences
+// accounts on the domain, rather than suspending them
+// and blocking federation with the domain altogether.
+func (b *DomainBlock) IsSilence() bool {
+	return b.Severity == DomainBlockSeveritySilence
+}
+
+// DomainBlockSeverity is the severity of a domain block.
+type DomainBlockSeverity string
+
+// DomainBlockSeverity values.
+const (
+	DomainBlockSeveritySilence DomainBlockSeverity = "silence" // accounts on the domain are silenced
+	DomainBlockSeveritySuspend DomainBlockSeverity = "suspend" // accounts on the domain are suspended, and federation with it is blocked
+)
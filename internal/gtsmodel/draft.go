@@ -0,0 +1,45 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Draft models an unpublished status being composed by a local account.
+//
+// Drafts store the status form as submitted by the client, so that
+// composition can be continued later (or on another device) before
+// being published as a normal status. Drafts are never federated.
+type Draft struct {
+	ID             string     `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt      time.Time  `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt      time.Time  `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID      string     `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // which account owns this draft?
+	Account        *Account   `validate:"-" bun:"-"`                                                           // account corresponding to accountID
+	Text           string     `validate:"-" bun:""`                                                            // text of the status, as submitted
+	ContentWarning string     `validate:"-" bun:",nullzero"`                                                   // cw string for the status
+	Sensitive      *bool      `validate:"-" bun:",nullzero,notnull,default:false"`                             // mark the status as sensitive?
+	Visibility     Visibility `validate:"-" bun:",nullzero"`                                                   // visibility of the status; if empty, the account default is used
+	InReplyToID    string     `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                         // id of the status this status will reply to
+	AttachmentIDs  []string   `validate:"dive,ulid" bun:"attachments,array"`                                   // Database IDs of any media attachments to be attached to the status
+	Language       string     `validate:"-" bun:",nullzero"`                                                   // what language is the status written in?
+	ContentType    string     `validate:"-" bun:",nullzero"`                                                   // content type with which to parse the text
+	PollOptions    []string   `validate:"-" bun:",array"`                                                      // options of a poll to attach to the status
+	PollExpiresIn  int        `validate:"-" bun:",nullzero"`                                                   // duration in seconds that the poll should be open
+	PollMultiple   *bool      `validate:"-" bun:",nullzero,notnull,default:false"`                             // allow multiple choices on the poll?
+	PollHideTotals *bool      `validate:"-" bun:",nullzero,notnull,default:false"`                             // hide vote counts until the poll ends?
+}
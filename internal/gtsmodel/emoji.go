@@ -42,4 +42,6 @@ type Emoji struct {
 	VisibleInPicker        *bool          `validate:"-" bun:",nullzero,notnull,default:true"`                                                      // Is this emoji visible in the admin emoji picker?
 	Category               *EmojiCategory `validate:"-" bun:"rel:belongs-to"`                                                                      // In which emoji category is this emoji visible?
 	CategoryID             string         `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                                 // ID of the category this emoji belongs to.
+	AccountID              string         `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                                 // ID of the local account that owns this emoji, if it's a personal emoji. Empty for instance and remote emojis.
+	Cached                 *bool          `validate:"-" bun:",nullzero,notnull,default:true"`                                                      // Are the image files of this emoji currently held in storage?
 }
@@ -25,4 +25,5 @@ type EmojiCategory struct {
 	CreatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
 	UpdatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
 	Name      string    `validate:"required" bun:",nullzero,notnull,unique"`                             // name of this category
+	Position  int       `validate:"-" bun:",notnull,default:0"`                                          // admin-defined position of this category in the emoji picker; lower comes first
 }
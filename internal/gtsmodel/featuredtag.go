@@ -0,0 +1,34 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// FeaturedTag represents a hashtag featured on the profile of an account.
+// For remote accounts, featured tags are dereferenced from the featured
+// tags collection advertised by the account, eg., by Mastodon.
+type FeaturedTag struct {
+	ID          string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                     // id of this item in the database
+	CreatedAt   time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`              // when was item created
+	UpdatedAt   time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`              // when was item last updated
+	AccountID   string    `validate:"required,ulid" bun:"type:CHAR(26),unique:featuredtagaccountname,nullzero,notnull"` // id of the account featuring the tag
+	Account     *Account  `validate:"-" bun:"-"`                                                                        // account featuring the tag
+	Name        string    `validate:"required" bun:",unique:featuredtagaccountname,nullzero,notnull"`                   // normalized (lowercase) name of the tag, without the hash
+	DisplayName string    `validate:"-" bun:",nullzero"`                                                                // name of the tag as given by the account, preserving case
+	URL         string    `validate:"omitempty,url" bun:",nullzero"`                                                    // href of the tag as given by the account, if any
+}
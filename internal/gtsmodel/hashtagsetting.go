@@ -0,0 +1,34 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// HashtagSetting models admin settings for one hashtag,
+// which apply to statuses created by accounts on this instance.
+//
+// Settings are keyed by the lowercase name of the hashtag rather
+// than by tag ID, so that they can be set before anyone has used
+// the hashtag, and so that they match however the tag is cased.
+type HashtagSetting struct {
+	ID                     string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt              time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt              time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Name                   string    `validate:"required" bun:",unique,nullzero,notnull"`                             // lowercase name of the hashtag, without the hash part
+	ContentWarningRequired *bool     `validate:"-" bun:",nullzero,notnull,default:false"`                             // must statuses using this hashtag have a content warning?
+}
@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// InteractionType describes the type of an interaction
+// with a status that may require the author's approval.
+type InteractionType string
+
+// Interaction types
+const (
+	InteractionLike     InteractionType = "favourite" // InteractionLike -- someone faved/liked a status
+	InteractionReply    InteractionType = "reply"     // InteractionReply -- someone replied to a status
+	InteractionAnnounce InteractionType = "reblog"    // InteractionAnnounce -- someone boosted/reblogged a status
+)
+
+// InteractionRequest models a remote interaction with a local status
+// which needs to be approved by the status author before it's shown
+// to anyone else, and which may subsequently be accepted or rejected.
+type InteractionRequest struct {
+	ID                   string          `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt            time.Time       `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt            time.Time       `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	StatusID             string          `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the status being interacted with
+	Status               *Status         `validate:"-" bun:"-"`                                                           // status corresponding to statusID
+	TargetAccountID      string          `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the account that owns the status, and whose approval is required
+	TargetAccount        *Account        `validate:"-" bun:"-"`                                                           // account corresponding to targetAccountID
+	InteractingAccountID string          `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the account that performed the interaction
+	InteractingAccount   *Account        `validate:"-" bun:"-"`                                                           // account corresponding to interactingAccountID
+	InteractionType      InteractionType `validate:"oneof=favourite reply reblog" bun:",nullzero,notnull"`                // type of the interaction
+	InteractionID        string          `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // database id of the fave, reply or boost
+	InteractionURI       string          `validate:"required,url" bun:",nullzero,notnull,unique"`                         // activitypub uri of the fave, reply or boost
+	Like                 *StatusFave     `validate:"-" bun:"-"`                                                           // fave corresponding to interactionID, if type is favourite
+	Reply                *Status         `validate:"-" bun:"-"`                                                           // status corresponding to interactionID, if type is reply
+	Announce             *Status         `validate:"-" bun:"-"`                                                           // status corresponding to interactionID, if type is reblog
+	URI                  string          `validate:"omitempty,url" bun:",nullzero,unique"`                                // activitypub uri of the Accept or Reject of this interaction, once handled
+	AcceptedAt           time.Time       `validate:"-" bun:"type:timestamptz,nullzero"`                                   // when was the interaction accepted, if at all
+	RejectedAt           time.Time       `validate:"-" bun:"type:timestamptz,nullzero"`                                   // when was the interaction rejected, if at all
+}
+
+// IsPending returns whether the interaction
+// has been neither accepted nor rejected yet.
+func (r *InteractionRequest) IsPending() bool {
+	return r.AcceptedAt.IsZero() && r.RejectedAt.IsZero()
+}
+
+// IsAccepted returns whether the interaction was accepted.
+func (r *InteractionRequest) IsAccepted() bool {
+	return !r.AcceptedAt.IsZero()
+}
+
+// IsRejected returns whether the interaction was rejected.
+func (r *InteractionRequest) IsRejected() bool {
+	return !r.RejectedAt.IsZero()
+}
@@ -0,0 +1,45 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// ListenActiveFor is how long after a listen was
+// created that it's considered to be 'now playing'.
+const ListenActiveFor = 10 * time.Minute
+
+// Listen represents an account listening to a music track, as
+// shared by music scrobbling via ActivityPub Listen (or Play)
+// activities. Only the most recent listen of each account is kept.
+type Listen struct {
+	ID         string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt  time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt  time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	URI        string    `validate:"required,url" bun:",nullzero,notnull,unique"`                         // ActivityPub URI of the Listen activity
+	AccountID  string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // which account listened to the track?
+	Account    *Account  `validate:"-" bun:"-"`                                                           // account corresponding to accountID
+	TrackName  string    `validate:"required" bun:",nullzero,notnull"`                                    // name of the track listened to
+	ArtistName string    `validate:"-" bun:",nullzero"`                                                   // name of the artist of the track, if known
+	AlbumName  string    `validate:"-" bun:",nullzero"`                                                   // name of the album of the track, if known
+}
+
+// Active returns whether this listen is recent
+// enough to be shown as currently playing.
+func (l *Listen) Active() bool {
+	return time.Since(l.CreatedAt) < ListenActiveFor
+}
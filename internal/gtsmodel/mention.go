@@ -60,6 +60,33 @@ type Mention struct {
 	// A pointer to the gtsmodel account of the mentioned account.
 }
 
+// MentionPolicy describes which accounts
+// are allowed to mention a local account.
+type MentionPolicy string
+
+const (
+	// MentionPolicyAnyone allows mentions from anyone.
+	MentionPolicyAnyone MentionPolicy = "anyone"
+	// MentionPolicyFollowed only allows mentions from accounts followed by the mentioned account.
+	MentionPolicyFollowed MentionPolicy = "followed"
+	// MentionPolicyFollowers only allows mentions from followers of the mentioned account.
+	MentionPolicyFollowers MentionPolicy = "followers"
+	// MentionPolicyNobody doesn't allow mentions from anyone.
+	MentionPolicyNobody MentionPolicy = "nobody"
+	// MentionPolicyDefault is the policy of accounts which haven't set one.
+	MentionPolicyDefault MentionPolicy = MentionPolicyAnyone
+)
+
+// HidesStatuses returns whether statuses by accounts not allowed to
+// mention under this policy are kept off the mentioned account's
+// timelines and thread views, rather than only not notifying them.
+//
+// Anyone can follow an unlocked account, so only the stricter
+// policies which don't depend on being followed do this.
+func (p MentionPolicy) HidesStatuses() bool {
+	return p == MentionPolicyFollowed || p == MentionPolicyNobody
+}
+
 // ParseMentionFunc describes a function that takes a lowercase account name
 // in the form "@test@whatever.example.org" for a remote account, or "@test"
 // for a local account, and returns a fully populated mention for that account,
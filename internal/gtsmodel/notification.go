@@ -24,7 +24,7 @@ type Notification struct {
 	ID               string           `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                                                                                                    // id of this item in the database
 	CreatedAt        time.Time        `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                                                                                                             // when was item created
 	UpdatedAt        time.Time        `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                                                                                                             // when was item last updated
-	NotificationType NotificationType `validate:"oneof=follow follow_request mention reblog favourite poll status" bun:",nullzero,notnull"`                                                                                                        // Type of this notification
+	NotificationType NotificationType `validate:"oneof=follow follow_request mention reblog favourite poll status pending.favourite pending.reply pending.reblog bite" bun:",nullzero,notnull"`                                                    // Type of this notification
 	TargetAccountID  string           `validate:"ulid" bun:"type:CHAR(26),nullzero,notnull"`                                                                                                                                                       // ID of the account targeted by the notification (ie., who will receive the notification?)
 	TargetAccount    *Account         `validate:"-" bun:"-"`                                                                                                                                                                                       // Account corresponding to TargetAccountID. Can be nil, always check first + select using ID if necessary.
 	OriginAccountID  string           `validate:"ulid" bun:"type:CHAR(26),nullzero,notnull"`                                                                                                                                                       // ID of the account that performed the action that created the notification.
@@ -39,11 +39,15 @@ type NotificationType string
 
 // Notification Types
 const (
-	NotificationFollow        NotificationType = "follow"         // NotificationFollow -- someone followed you
-	NotificationFollowRequest NotificationType = "follow_request" // NotificationFollowRequest -- someone requested to follow you
-	NotificationMention       NotificationType = "mention"        // NotificationMention -- someone mentioned you in their status
-	NotificationReblog        NotificationType = "reblog"         // NotificationReblog -- someone boosted one of your statuses
-	NotificationFave          NotificationType = "favourite"      // NotificationFave -- someone faved/liked one of your statuses
-	NotificationPoll          NotificationType = "poll"           // NotificationPoll -- a poll you voted in or created has ended
-	NotificationStatus        NotificationType = "status"         // NotificationStatus -- someone you enabled notifications for has posted a status.
+	NotificationFollow        NotificationType = "follow"            // NotificationFollow -- someone followed you
+	NotificationFollowRequest NotificationType = "follow_request"    // NotificationFollowRequest -- someone requested to follow you
+	NotificationMention       NotificationType = "mention"           // NotificationMention -- someone mentioned you in their status
+	NotificationReblog        NotificationType = "reblog"            // NotificationReblog -- someone boosted one of your statuses
+	NotificationFave          NotificationType = "favourite"         // NotificationFave -- someone faved/liked one of your statuses
+	NotificationPoll          NotificationType = "poll"              // NotificationPoll -- a poll you voted in or created has ended
+	NotificationStatus        NotificationType = "status"            // NotificationStatus -- someone you enabled notifications for has posted a status.
+	NotificationPendingFave   NotificationType = "pending.favourite" // NotificationPendingFave -- someone faved one of your statuses, and it needs your approval
+	NotificationPendingReply  NotificationType = "pending.reply"     // NotificationPendingReply -- someone replied to one of your statuses, and it needs your approval
+	NotificationPendingReblog NotificationType = "pending.reblog"    // NotificationPendingReblog -- someone boosted one of your statuses, and it needs your approval
+	NotificationBite          NotificationType = "bite"              // NotificationBite -- someone bit you, or one of your statuses
 )
@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Poll models a poll attached to a status.
+//
+// Vote counts are stored on the poll itself rather than
+// counted from PollVotes, since for remote polls we only
+// know the totals reported by the poll's origin server.
+type Poll struct {
+	ID         string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt  time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt  time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	StatusID   string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull,unique"`           // id of the status this poll is attached to
+	Status     *Status   `validate:"-" bun:"-"`                                                           // status corresponding to statusID
+	Options    []string  `validate:"min=1" bun:",array"`                                                  // titles of the options that can be voted for
+	Votes      []int     `validate:"-" bun:",array"`                                                      // number of votes for each option, in the same order as options
+	Voters     int       `validate:"min=0" bun:",notnull,default:0"`                                      // number of unique accounts that have voted
+	Multiple   *bool     `validate:"-" bun:",nullzero,notnull,default:false"`                             // can more than one option be chosen per vote?
+	HideCounts *bool     `validate:"-" bun:",nullzero,notnull,default:false"`                             // hide vote counts until the poll has ended?
+	ExpiresAt  time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // when does the poll end? zero if it doesn't
+}
+
+// Expired returns whether the poll has ended.
+func (p *Poll) Expired() bool {
+	return !p.ExpiresAt.IsZero() && time.Now().After(p.ExpiresAt)
+}
+
+// PollVote models one account's vote in a poll.
+type PollVote struct {
+	ID        string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	PollID    string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the poll voted in
+	Poll      *Poll     `validate:"-" bun:"-"`                                                           // poll corresponding to pollID
+	AccountID string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the account that voted
+	Account   *Account  `validate:"-" bun:"-"`                                                           // account corresponding to accountID
+	Choices   []int     `validate:"min=1" bun:",array"`                                                  // indices of the chosen options
+}
@@ -0,0 +1,45 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// PreviewCard models the preview metadata of a web page linked to in
+// a status, as fetched from the page itself. Cards are stored once per
+// URL and shared between all statuses linking to it, and refreshed when
+// they're linked to again after going stale.
+type PreviewCard struct {
+	ID              string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt       time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt       time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	FetchedAt       time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was the page last fetched to build this card
+	URL             string    `validate:"required,url" bun:",nullzero,notnull,unique"`                         // URL of the linked page
+	Title           string    `validate:"-" bun:",nullzero"`                                                   // title of the linked page
+	Description     string    `validate:"-" bun:",nullzero"`                                                   // description of the linked page
+	AuthorName      string    `validate:"-" bun:",nullzero"`                                                   // name of the author of the linked page
+	AuthorURL       string    `validate:"-" bun:",nullzero"`                                                   // URL of the author of the linked page
+	ProviderName    string    `validate:"-" bun:",nullzero"`                                                   // name of the site hosting the linked page
+	ProviderURL     string    `validate:"-" bun:",nullzero"`                                                   // URL of the site hosting the linked page
+	Image           string    `validate:"-" bun:",nullzero"`                                                   // remote URL of the preview image for the linked page
+	Width           int       `validate:"-" bun:",nullzero"`                                                   // width of the preview image in pixels, if known
+	Height          int       `validate:"-" bun:",nullzero"`                                                   // height of the preview image in pixels, if known
+	AuthorAccountID string    `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                         // id of the fediverse account credited as author of the linked page, if verified
+	AuthorAccount   *Account  `validate:"-" bun:"-"`                                                           // fediverse account credited as author of the linked page, if verified
+	Type            string    `validate:"-" bun:",nullzero"`                                                   // type of the card: video or audio for embeds, empty for plain links
+	HTML            string    `validate:"-" bun:",nullzero"`                                                   // sanitized iframe html embedding the linked audio or video, if any
+}
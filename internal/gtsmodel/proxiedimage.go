@@ -0,0 +1,38 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// ProxiedImage models an external image, embedded in the content of
+// a remote status, which has been fetched and cached in storage so
+// that it can be served to viewers from this instance instead.
+type ProxiedImage struct {
+	ID          string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt   time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt   time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	URL         string    `validate:"required,url" bun:",nullzero,notnull,unique"`                         // remote URL the image was fetched from
+	ContentType string    `validate:"required" bun:",nullzero,notnull"`                                    // MIME content type of the image
+	FileSize    int       `validate:"required" bun:",notnull"`                                             // size of the image in bytes
+	AccessedAt  time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was the image last served to someone
+}
+
+// StoragePath returns the key of the image in storage.
+func (p *ProxiedImage) StoragePath() string {
+	return "proxy/" + p.ID
+}
@@ -0,0 +1,37 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// QueuedEmail represents an email waiting to be sent over SMTP, or one
+// which could not be sent after retrying, for admins to look into. The
+// message is stored fully rendered, so that retries send exactly the
+// same contents (including any single-use links) as the first attempt.
+type QueuedEmail struct {
+	ID            string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt     time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt     time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	ToAddresses   []string  `validate:"min=1,dive,email" bun:",array"`                                       // addresses to send the email to
+	Subject       string    `validate:"required" bun:",nullzero,notnull"`                                    // subject of the email, for display to admins
+	Message       string    `validate:"required" bun:",nullzero,notnull"`                                    // the full message, including headers, as it should be sent
+	Attempts      int       `validate:"-" bun:",notnull,default:0"`                                          // how many times was sending this email attempted?
+	NextAttemptAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when should sending this email next be attempted?
+	LastError     string    `validate:"-" bun:",nullzero"`                                                   // error of the last attempt at sending this email, if any
+	FailedAt      time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // when was sending this email given up on, if it was
+}
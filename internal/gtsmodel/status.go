@@ -32,7 +32,9 @@ type Status struct {
 	PinnedAt                 time.Time          `validate:"-" bun:"type:timestamptz,nullzero"`                                                         // Status was pinned by owning account at this time.
 	URI                      string             `validate:"required,url" bun:",unique,nullzero,notnull"`                                               // activitypub URI of this status
 	URL                      string             `validate:"url" bun:",nullzero"`                                                                       // web url for viewing this status
+	Alias                    string             `validate:"-" bun:",nullzero,unique"`                                                                  // short alias derived from the ID, which may be used in place of it in web and api urls (local statuses only)
 	Content                  string             `validate:"-" bun:""`                                                                                  // content of this status; likely html-formatted but not guaranteed
+	ContentMap               map[string]string  `validate:"-" bun:",nullzero,type:text"`                                                               // content of this status in each language it was written in, keyed by language tag (remote statuses with several languages only)
 	AttachmentIDs            []string           `validate:"dive,ulid" bun:"attachments,array"`                                                         // Database IDs of any media attachments associated with this status
 	Attachments              []*MediaAttachment `validate:"-" bun:"attached_media,rel:has-many"`                                                       // Attachments corresponding to attachmentIDs
 	TagIDs                   []string           `validate:"dive,ulid" bun:"tags,array"`                                                                // Database IDs of any tags used in this status
@@ -50,6 +52,9 @@ type Status struct {
 	InReplyToAccountID       string             `validate:"required_with=InReplyToID InReplyToURI,omitempty,ulid" bun:"type:CHAR(26),nullzero"`        // id of the account that this status replies to
 	InReplyTo                *Status            `validate:"-" bun:"-"`                                                                                 // status corresponding to inReplyToID
 	InReplyToAccount         *Account           `validate:"-" bun:"rel:belongs-to"`                                                                    // account corresponding to inReplyToAccountID
+	ThreadDepth              int                `validate:"min=0" bun:",notnull,default:0"`                                                            // number of replies between this status and the root of its thread
+	ThreadTruncated          *bool              `validate:"-" bun:",nullzero,notnull,default:false"`                                                   // was this status detached from its parent for being deeper than the max thread depth?
+	ThreadID                 string             `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                               // id of the thread this status is in, inherited from its parent (or boosted status) when stored
 	BoostOfID                string             `validate:"required_with=BoostOfAccountID,omitempty,ulid" bun:"type:CHAR(26),nullzero"`                // id of the status this status is a boost of
 	BoostOfAccountID         string             `validate:"required_with=BoostOfID,omitempty,ulid" bun:"type:CHAR(26),nullzero"`                       // id of the account that owns the boosted status
 	BoostOf                  *Status            `validate:"-" bun:"-"`                                                                                 // status that corresponds to boostOfID
@@ -62,10 +67,27 @@ type Status struct {
 	CreatedWithApplication   *Application       `validate:"-" bun:"rel:belongs-to"`                                                                    // application corresponding to createdWithApplicationID
 	ActivityStreamsType      string             `validate:"required" bun:",nullzero,notnull"`                                                          // What is the activitystreams type of this status? See: https://www.w3.org/TR/activitystreams-vocabulary/#object-types. Will probably almost always be Note but who knows!.
 	Text                     string             `validate:"-" bun:""`                                                                                  // Original text of the status without formatting
+	ContentType              string             `validate:"-" bun:",nullzero"`                                                                         // Content type with which the original text was parsed (only for local statuses)
 	Federated                *bool              `validate:"-" bun:",notnull"`                                                                          // This status will be federated beyond the local timeline(s)
 	Boostable                *bool              `validate:"-" bun:",notnull"`                                                                          // This status can be boosted/reblogged
 	Replyable                *bool              `validate:"-" bun:",notnull"`                                                                          // This status can be replied to
 	Likeable                 *bool              `validate:"-" bun:",notnull"`                                                                          // This status can be liked/faved
+	PendingApproval          *bool              `validate:"-" bun:",nullzero,notnull,default:false"`                                                   // This status is a reply or boost awaiting approval by the author of the status it interacts with
+	ApprovedByURI            string             `validate:"omitempty,url" bun:",nullzero"`                                                             // activitypub uri of the Accept that approved this reply or boost, if any
+	Backfilled               *bool              `validate:"-" bun:",nullzero,notnull,default:false"`                                                   // This status was fetched when backfilling the recent statuses of its author, rather than delivered or looked up
+	FaveCountRemote          int                `validate:"min=0" bun:",notnull,default:0"`                                                            // Number of faves of this (remote) status, as reported by its origin server
+	BoostCountRemote         int                `validate:"min=0" bun:",notnull,default:0"`                                                            // Number of boosts of this (remote) status, as reported by its origin server
+	PollID                   string             `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                               // id of the poll attached to this status, if any
+	Poll                     *Poll              `validate:"-" bun:"-"`                                                                                 // poll corresponding to pollID
+	PreviewCardID            string             `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                               // id of the preview card of the first link in this status, if any
+	PreviewCard              *PreviewCard       `validate:"-" bun:"-"`                                                                                 // preview card corresponding to previewCardID
+	EventName                string             `validate:"-" bun:",nullzero"`                                                                         // Name of the event, if this status is an event
+	EventStartAt             time.Time          `validate:"-" bun:"type:timestamptz,nullzero"`                                                         // Start time of the event, if this status is an event
+	EventEndAt               time.Time          `validate:"-" bun:"type:timestamptz,nullzero"`                                                         // End time of the event, if this status is an event
+	EventLocation            string             `validate:"-" bun:",nullzero"`                                                                         // Location of the event, if this status is an event
+	LocationName             string             `validate:"-" bun:",nullzero"`                                                                         // Name of the place this status is about, if any
+	LocationLatitude         *float64           `validate:"omitempty,latitude" bun:",nullzero"`                                                        // Latitude of the place this status is about, if known
+	LocationLongitude        *float64           `validate:"omitempty,longitude" bun:",nullzero"`                                                       // Longitude of the place this status is about, if known
 }
 
 // GetID implements timeline.Timelineable{}.
@@ -250,6 +272,42 @@ func (s *Status) MentionsAccount(id string) bool {
 	return false
 }
 
+// IsPendingApproval returns whether this status is a reply
+// or boost that's still awaiting approval by the author of
+// the status it interacts with.
+func (s *Status) IsPendingApproval() bool {
+	return s.PendingApproval != nil && *s.PendingApproval
+}
+
+// IsBackfilled returns whether this status was fetched
+// when backfilling the recent statuses of its author.
+func (s *Status) IsBackfilled() bool {
+	return s.Backfilled != nil && *s.Backfilled
+}
+
+// RequiresApproval returns whether an interaction of the given type
+// with this status, by the given account, must first be approved by
+// the author of this status. Only interactions with local statuses
+// which have that interaction type turned off need approval; the
+// author's own interactions never do.
+func (s *Status) RequiresApproval(interactionType InteractionType, accountID string) bool {
+	if s.Local == nil || !*s.Local || s.AccountID == accountID {
+		return false
+	}
+
+	var allowed *bool
+	switch interactionType {
+	case InteractionLike:
+		allowed = s.Likeable
+	case InteractionReply:
+		allowed = s.Replyable
+	case InteractionAnnounce:
+		allowed = s.Boostable
+	}
+
+	return allowed != nil && !*allowed
+}
+
 // StatusToTag is an intermediate struct to facilitate the many2many relationship between a status and one or more tags.
 type StatusToTag struct {
 	StatusID string  `validate:"ulid,required" bun:"type:CHAR(26),unique:statustag,nullzero,notnull"`
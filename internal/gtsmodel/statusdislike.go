@@ -0,0 +1,36 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// StatusDislike refers to a 'dislike' (or downvote) in the database, from one account,
+// targeting the status of another account. Dislikes are federated as ActivityPub
+// Dislike activities by some implementations, eg., forks of Pleroma.
+type StatusDislike struct {
+	ID              string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                         // id of this item in the database
+	CreatedAt       time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                  // when was item created
+	UpdatedAt       time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                  // when was item last updated
+	AccountID       string    `validate:"required,ulid" bun:"type:CHAR(26),unique:statusdislikeaccountstatus,nullzero,notnull"` // id of the account that created ('did') the dislike
+	Account         *Account  `validate:"-" bun:"-"`                                                                            // account that created the dislike
+	TargetAccountID string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                                   // id the account owning the disliked status
+	TargetAccount   *Account  `validate:"-" bun:"-"`                                                                            // account owning the disliked status
+	StatusID        string    `validate:"required,ulid" bun:"type:CHAR(26),unique:statusdislikeaccountstatus,nullzero,notnull"` // database id of the status that has been disliked
+	Status          *Status   `validate:"-" bun:"-"`                                                                            // the disliked status
+	URI             string    `validate:"required,url" bun:",nullzero,notnull,unique"`                                          // ActivityPub URI of this dislike
+}
@@ -31,4 +31,12 @@ type StatusFave struct {
 	StatusID        string    `validate:"required,ulid" bun:"type:CHAR(26),unique:statusfaveaccountstatus,nullzero,notnull"` // database id of the status that has been 'faved'
 	Status          *Status   `validate:"-" bun:"-"`                                                                         // the faved status
 	URI             string    `validate:"required,url" bun:",nullzero,notnull,unique"`                                       // ActivityPub URI of this fave
+	PendingApproval *bool     `validate:"-" bun:",nullzero,notnull,default:false"`                                           // This fave is awaiting approval by the author of the faved status
+	ApprovedByURI   string    `validate:"omitempty,url" bun:",nullzero"`                                                     // ActivityPub URI of the Accept that approved this fave, if any
+}
+
+// IsPendingApproval returns whether this fave is still awaiting
+// approval by the author of the faved status.
+func (f *StatusFave) IsPendingApproval() bool {
+	return f.PendingApproval != nil && *f.PendingApproval
 }
@@ -19,7 +19,9 @@ package gtsmodel
 
 import "time"
 
-// StatusMute refers to one account having muted the status of another account or its own.
+// StatusMute refers to one account having muted the thread containing
+// the status of another account or its own. ThreadID identifies the
+// muted thread: it's the ID of the highest known ancestor of the status.
 type StatusMute struct {
 	ID              string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
 	CreatedAt       time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
@@ -30,4 +32,5 @@ type StatusMute struct {
 	TargetAccount   *Account  `validate:"-" bun:"rel:belongs-to"`                                              // pointer to the account specified by targetAccountID
 	StatusID        string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // database id of the status that has been muted
 	Status          *Status   `validate:"-" bun:"rel:belongs-to"`                                              // pointer to the muted status specified by statusID
+	ThreadID        string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero"`                          // thread id of the muted status (see Status.ThreadID)
 }
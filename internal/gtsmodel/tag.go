@@ -25,7 +25,8 @@ type Tag struct {
 	CreatedAt              time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
 	UpdatedAt              time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
 	URL                    string    `validate:"required,url" bun:",nullzero,notnull"`                                // Href/web address of this tag, eg https://example.org/tags/somehashtag
-	Name                   string    `validate:"required" bun:",unique,nullzero,notnull"`                             // name of this tag -- the tag without the hash part
+	Name                   string    `validate:"required" bun:",unique,nullzero,notnull"`                             // normalized (lowercase) name of this tag -- the tag without the hash part; used for matching
+	DisplayName            string    `validate:"-" bun:",nullzero"`                                                   // name of this tag as it was first seen, preserving case, eg CamelCase -- used for rendering
 	FirstSeenFromAccountID string    `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                         // Which account ID is the first one we saw using this tag?
 	Useable                *bool     `validate:"-" bun:",nullzero,notnull,default:true"`                              // can our instance users use this tag?
 	Listable               *bool     `validate:"-" bun:",nullzero,notnull,default:true"`                              // can our instance users look up this tag?
@@ -53,7 +53,38 @@ type User struct {
 	Admin                  *bool        `validate:"-" bun:",nullzero,notnull,default:false"`                             // Is this user an admin?
 	Disabled               *bool        `validate:"-" bun:",nullzero,notnull,default:false"`                             // Is this user disabled from posting?
 	Approved               *bool        `validate:"-" bun:",nullzero,notnull,default:false"`                             // Has this user been approved by a moderator?
+	RejectedAt             time.Time    `validate:"-" bun:"type:timestamptz,nullzero"`                                   // When was this user's sign-up rejected by a moderator?
 	ResetPasswordToken     string       `validate:"required_with=ResetPasswordSentAt" bun:",nullzero"`                   // The generated token that the user can use to reset their password
 	ResetPasswordSentAt    time.Time    `validate:"required_with=ResetPasswordToken" bun:"type:timestamptz,nullzero"`    // When did we email the user their reset-password email?
 	ExternalID             string       `validate:"-" bun:",nullzero,unique"`                                            // If the login for the user is managed externally (e.g OIDC), we need to keep a stable reference to the external object (e.g OIDC sub claim)
+	PasswordLoginDisabled  *bool        `validate:"-" bun:",nullzero,notnull,default:false"`                             // Has this user disabled signing in with their password, in favour of their WebAuthn credentials?
+	StatusesMaxChars       int          `validate:"min=0" bun:",notnull,default:0"`                                      // Max permitted characters for statuses posted by this user, set by an admin. If 0, the instance limits are used.
+	DeleteAt               time.Time    `validate:"-" bun:"type:timestamptz,nullzero"`                                   // When will this user's account be deleted, following a self-delete request? Zero if no deletion is pending.
+	MediaRole              string       `validate:"-" bun:",nullzero"`                                                   // Role used to look up this user's media limits in the media-roles config, set by an admin. If empty, the user's admin, moderator, or user role is used.
 }
+
+// GetMediaRole returns the role used to look up the media
+// limits of this user, being either the media role set by
+// an admin, or else one of "admin", "moderator", or "user".
+func (u *User) GetMediaRole() string {
+	switch {
+	case u.MediaRole != "":
+		return u.MediaRole
+	case u.Admin != nil && *u.Admin:
+		return "admin"
+	case u.Moderator != nil && *u.Moderator:
+		return "moderator"
+	default:
+		return "user"
+	}
+}
+
+// RegistrationStatus describes where the sign-up
+// of a user is at in the review process.
+type RegistrationStatus string
+
+const (
+	RegistrationStatusPending  RegistrationStatus = "pending"  // sign-up is awaiting review
+	RegistrationStatusApproved RegistrationStatus = "approved" // sign-up has been approved
+	RegistrationStatusRejected RegistrationStatus = "rejected" // sign-up has been rejected
+)
@@ -0,0 +1,36 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// WebAuthnCredential represents a WebAuthn public key credential
+// (aka a passkey) which a local user has registered, and which
+// they can use to sign in instead of their password.
+type WebAuthnCredential struct {
+	ID           string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt    time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt    time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	UserID       string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // which user does this credential belong to?
+	Name         string    `validate:"required" bun:",nullzero,notnull"`                                    // user-chosen name for this credential, eg., 'phone'
+	CredentialID string    `validate:"required" bun:",nullzero,notnull,unique"`                             // base64url encoded raw credential ID, as chosen by the authenticator
+	PublicKey    []byte    `validate:"required" bun:",nullzero,notnull"`                                    // DER encoded SubjectPublicKeyInfo of the credential
+	Algorithm    int       `validate:"required" bun:",notnull"`                                             // COSE algorithm identifier of the public key, eg., -7 for ES256
+	SignCount    uint32    `validate:"-" bun:",notnull,default:0"`                                          // last signature counter value reported by the authenticator
+	LastUsedAt   time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // when was this credential last used to sign in?
+}
@@ -0,0 +1,39 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import (
+	"net"
+	"time"
+)
+
+// WebSession represents a sign in by a local user through the web sign
+// in flow (password, passkey or OIDC). Tokens created while authorizing
+// apps during that sign in are linked to the web session, so that they
+// can be revoked along with it, eg., if the device it's on goes missing.
+type WebSession struct {
+	ID         string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt  time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt  time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	UserID     string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // which user does this session belong to?
+	IP         net.IP    `validate:"-" bun:",nullzero"`                                                   // IP address from which the user signed in
+	UserAgent  string    `validate:"-" bun:",nullzero"`                                                   // user agent with which the user signed in
+	LastSeenAt time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // when was a token of this session last used? Only updated periodically.
+	LastSeenIP net.IP    `validate:"-" bun:",nullzero"`                                                   // from which IP address was a token of this session last used?
+	IPChanged  *bool     `validate:"-" bun:",nullzero,notnull,default:false"`                             // has this session been used from a different network than the one it was created on?
+}
@@ -48,8 +48,16 @@ var (
 	// ErrInvalidNetwork is returned if the request would not be performed over TCP
 	ErrInvalidNetwork = errors.New("invalid network type")
 
+	// ErrSSRFBlocked is wrapped by all errors returned when a request is refused
+	// to protect against server side request forgery (SSRF), so callers can check
+	// for any of them with a single errors.Is(err, ErrSSRFBlocked).
+	ErrSSRFBlocked = errors.New("blocked to protect against SSRF")
+
 	// ErrReservedAddr is returned if a dialed address resolves to an IP within a blocked or reserved net.
-	ErrReservedAddr = errors.New("dial within blocked / reserved IP range")
+	ErrReservedAddr = fmt.Errorf("%w: dial within blocked / reserved IP range", ErrSSRFBlocked)
+
+	// ErrMetadataAddr is returned if a dialed address is that of a cloud metadata service.
+	ErrMetadataAddr = fmt.Errorf("%w: dial to cloud metadata service", ErrSSRFBlocked)
 
 	// ErrBodyTooLarge is returned when a received response body is above predefined limit (default 40MB).
 	ErrBodyTooLarge = errors.New("body size too large")
@@ -86,6 +94,11 @@ type Config struct {
 
 	// BlockRanges blocks outgoing communiciations to given IP nets.
 	BlockRanges []netip.Prefix
+
+	// AllowPrivateIPs allows outgoing communications to reserved
+	// IP nets (private, loopback, etc), which are otherwise blocked.
+	// Cloud metadata services are still blocked. Only for development.
+	AllowPrivateIPs bool
 }
 
 // Client wraps an underlying http.Client{} to provide the following:
@@ -130,10 +143,12 @@ func New(cfg Config) *Client {
 	}
 
 	// Protect dialer with IP range sanitizer.
-	d.Control = (&sanitizer{
-		allow: cfg.AllowRanges,
-		block: cfg.BlockRanges,
-	}).Sanitize
+	s := &sanitizer{
+		allow:        cfg.AllowRanges,
+		block:        cfg.BlockRanges,
+		allowPrivate: cfg.AllowPrivateIPs,
+	}
+	d.Control = s.Sanitize
 
 	// Prepare client fields.
 	c.client.Timeout = cfg.Timeout
@@ -143,7 +158,7 @@ func New(cfg Config) *Client {
 	c.client.Transport = &http.Transport{
 		Proxy:                 http.ProxyFromEnvironment,
 		ForceAttemptHTTP2:     true,
-		DialContext:           d.DialContext,
+		DialContext:           s.DialContext(d),
 		MaxIdleConns:          cfg.MaxIdleConns,
 		IdleConnTimeout:       90 * time.Second,
 		TLSHandshakeTimeout:   10 * time.Second,
@@ -274,7 +289,7 @@ func (c *Client) DoSigned(r *http.Request, sign SignFunc) (rsp *http.Response, e
 			context.DeadlineExceeded,
 			context.Canceled,
 			ErrBodyTooLarge,
-			ErrReservedAddr,
+			ErrSSRFBlocked,
 		) {
 			// Non-retryable errors.
 			return nil, err
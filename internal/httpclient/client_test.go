@@ -41,6 +41,13 @@ var privateIPs = []string{
 	"http://255.255.255.255:80",
 }
 
+var metadataAddrs = []string{
+	"http://169.254.169.254/latest/meta-data/",
+	"http://[fd00:ec2::254]/latest/meta-data/",
+	"http://metadata.google.internal/computeMetadata/v1/",
+	"http://METADATA.google.internal./computeMetadata/v1/",
+}
+
 var bodies = []string{
 	"hello world!",
 	"{}",
@@ -143,3 +150,47 @@ func TestHTTPClientPrivateIP(t *testing.T) {
 		}
 	}
 }
+
+func TestHTTPClientMetadataAddr(t *testing.T) {
+	for _, allowPrivate := range []bool{false, true} {
+		client := httpclient.New(httpclient.Config{
+			AllowPrivateIPs: allowPrivate,
+		})
+
+		for _, addr := range metadataAddrs {
+			// Prepare request to metadata service
+			req, _ := http.NewRequest("GET", addr, nil)
+
+			// Perform the HTTP request
+			_, err := client.Do(req)
+			if !errors.Is(err, httpclient.ErrMetadataAddr) {
+				t.Errorf("dialing metadata address %s did not return expected error: %v", addr, err)
+			}
+			if !errors.Is(err, httpclient.ErrSSRFBlocked) {
+				t.Errorf("dialing metadata address %s did not return ssrf error: %v", addr, err)
+			}
+		}
+	}
+}
+
+func TestHTTPClientAllowPrivateIPs(t *testing.T) {
+	client := httpclient.New(httpclient.Config{
+		AllowPrivateIPs: true,
+	})
+
+	// Start a test server on loopback
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte("hello world!"))
+	}))
+	defer srv.Close()
+
+	// Prepare request to private IP
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+
+	// Perform the HTTP request
+	rsp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("dialing private address with private IPs allowed returned error: %v", err)
+	}
+	rsp.Body.Close()
+}
@@ -18,15 +18,61 @@
 package httpclient
 
 import (
+	"context"
+	"net"
 	"net/netip"
+	"strings"
 	"syscall"
 
 	"github.com/superseriousbusiness/gotosocial/internal/netutil"
 )
 
+var (
+	// metadataHosts contains hostnames of cloud metadata
+	// services, which are blocked before even resolving them.
+	metadataHosts = map[string]struct{}{
+		"metadata":                   {},
+		"metadata.google.internal":   {},
+		"metadata.goog":              {},
+		"instance-data":              {},
+		"instance-data.ec2.internal": {},
+	}
+
+	// metadataAddrs contains IPs of cloud metadata services. These
+	// all fall within reserved nets, but are listed separately so
+	// they're still blocked when private IPs are otherwise allowed.
+	metadataAddrs = []netip.Addr{
+		netip.MustParseAddr("169.254.169.254"), // AWS, GCP, Azure, DigitalOcean, etc
+		netip.MustParseAddr("169.254.170.2"),   // AWS ECS task metadata
+		netip.MustParseAddr("fd00:ec2::254"),   // AWS IPv6
+		netip.MustParseAddr("100.100.100.200"), // Alibaba Cloud
+		netip.MustParseAddr("192.0.0.192"),     // Oracle Cloud
+	}
+)
+
 type sanitizer struct {
-	allow []netip.Prefix
-	block []netip.Prefix
+	allow        []netip.Prefix
+	block        []netip.Prefix
+	allowPrivate bool
+}
+
+// DialContext wraps the given dialer's DialContext function, to
+// refuse dialing known cloud metadata hostnames before resolving.
+// Resolved IPs are checked afterwards by Sanitize, as dialer.Control.
+func (s *sanitizer) DialContext(d *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		host = strings.TrimSuffix(strings.ToLower(host), ".")
+		if _, ok := metadataHosts[host]; ok {
+			return nil, ErrMetadataAddr
+		}
+
+		return d.DialContext(ctx, network, addr)
+	}
 }
 
 // Sanitize implements the required net.Dialer.Control function signature.
@@ -44,6 +90,13 @@ func (s *sanitizer) Sanitize(ntwrk, addr string, _ syscall.RawConn) error {
 	// Seperate the IP
 	ip := ipport.Addr()
 
+	// Never allow cloud metadata services
+	for i := 0; i < len(metadataAddrs); i++ {
+		if metadataAddrs[i] == ip.Unmap() {
+			return ErrMetadataAddr
+		}
+	}
+
 	// Check if this is explicitly allowed
 	for i := 0; i < len(s.allow); i++ {
 		if s.allow[i].Contains(ip) {
@@ -59,7 +112,7 @@ func (s *sanitizer) Sanitize(ntwrk, addr string, _ syscall.RawConn) error {
 	}
 
 	// Validate this is a safe IP
-	if !netutil.ValidateIP(ip) {
+	if !s.allowPrivate && !netutil.ValidateIP(ip) {
 		return ErrReservedAddr
 	}
 
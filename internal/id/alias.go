@@ -0,0 +1,61 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package id
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/oklog/ulid"
+)
+
+const (
+	// AliasMinLength is the length of the shortest
+	// alias that will be derived from a ULID.
+	AliasMinLength = 11
+
+	// AliasMaxLength is the length of the longest alias
+	// that may be derived from a ULID, which encodes the
+	// whole ULID, and so is unique to it. This is always
+	// shorter than a ULID, so the two can't be confused.
+	AliasMaxLength = 25
+)
+
+// Alias returns a short, lowercase base36 alias for the given ULID,
+// of the given length. It is the first length characters of the
+// zero-padded base36 encoding of the whole ULID, so aliases of distinct
+// ULIDs can only be equal if both are shorter than AliasMaxLength, in
+// which case a longer alias can be taken for one of them instead.
+//
+// Length is clamped between AliasMinLength and AliasMaxLength.
+func Alias(id string, length int) (string, error) {
+	u, err := ulid.ParseStrict(id)
+	if err != nil {
+		return "", err
+	}
+
+	if length < AliasMinLength {
+		length = AliasMinLength
+	} else if length > AliasMaxLength {
+		length = AliasMaxLength
+	}
+
+	enc := new(big.Int).SetBytes(u[:]).Text(36)
+	enc = strings.Repeat("0", AliasMaxLength-len(enc)) + enc
+	return enc[:length], nil
+}
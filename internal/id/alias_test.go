@@ -0,0 +1,73 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package id_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type AliasTestSuite struct {
+	suite.Suite
+}
+
+func (suite *AliasTestSuite) TestAlias() {
+	alias, err := id.Alias("01F8MH75CBF9JFX4ZAD54N0W0R", 0)
+	suite.NoError(err)
+	suite.Len(alias, id.AliasMinLength)
+
+	full, err := id.Alias("01F8MH75CBF9JFX4ZAD54N0W0R", 100)
+	suite.NoError(err)
+	suite.Len(full, id.AliasMaxLength)
+	suite.True(len(full) < len("01F8MH75CBF9JFX4ZAD54N0W0R"))
+
+	// Shorter aliases are prefixes of longer ones.
+	suite.Equal(full[:id.AliasMinLength], alias)
+}
+
+func (suite *AliasTestSuite) TestAliasLowestHighest() {
+	lowest, err := id.Alias(id.Lowest, id.AliasMaxLength)
+	suite.NoError(err)
+	suite.Equal("0000000000000000000000000", lowest)
+
+	// The largest valid ULID still fits.
+	highest, err := id.Alias("7ZZZZZZZZZZZZZZZZZZZZZZZZZ", id.AliasMaxLength)
+	suite.NoError(err)
+	suite.Equal("f5lxx1zz5pnorynqglhzmsp33", highest)
+}
+
+func (suite *AliasTestSuite) TestAliasDistinct() {
+	// Statuses created in the same millisecond
+	// may share short aliases, but never full ones.
+	a, err := id.Alias("01H6WPXFHC0000000000000000", id.AliasMaxLength)
+	suite.NoError(err)
+	b, err := id.Alias("01H6WPXFHC0000000000000001", id.AliasMaxLength)
+	suite.NoError(err)
+	suite.NotEqual(a, b)
+}
+
+func (suite *AliasTestSuite) TestAliasInvalid() {
+	_, err := id.Alias("not a ulid", id.AliasMinLength)
+	suite.Error(err)
+}
+
+func TestAliasTestSuite(t *testing.T) {
+	suite.Run(t, &AliasTestSuite{})
+}
@@ -54,6 +54,23 @@ func NewULIDFromTime(t time.Time) (string, error) {
 	return newUlid.String(), nil
 }
 
+// NewMaxULIDFromTime returns the highest possible ULID string for the given
+// time, ie., one that sorts after every other ULID generated at that time.
+func NewMaxULIDFromTime(t time.Time) string {
+	var newUlid ulid.ULID
+	if err := newUlid.SetTime(ulid.Timestamp(t)); err != nil {
+		panic(err)
+	}
+
+	// Fill entropy (the 10 bytes
+	// after the timestamp) with all 1s.
+	for i := 6; i < len(newUlid); i++ {
+		newUlid[i] = 0xff
+	}
+
+	return newUlid.String()
+}
+
 // NewRandomULID returns a new ULID string using a random time in an ~80 year range around the current datetime, or an error if something goes wrong.
 func NewRandomULID() (string, error) {
 	b1, err := rand.Int(rand.Reader, big.NewInt(randomRange))
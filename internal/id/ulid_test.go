@@ -0,0 +1,51 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type ULIDTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ULIDTestSuite) TestNewMaxULIDFromTime() {
+	t := time.Date(2023, 8, 9, 12, 0, 0, 0, time.UTC)
+
+	maxULID := id.NewMaxULIDFromTime(t)
+	suite.Equal("01H7D2P1G0ZZZZZZZZZZZZZZZZ", maxULID)
+
+	// Sorts after any ULID from the same millisecond,
+	// but before any ULID from the next millisecond.
+	sameTime, err := id.NewULIDFromTime(t)
+	suite.NoError(err)
+	suite.Less(sameTime, maxULID)
+
+	nextTime, err := id.NewULIDFromTime(t.Add(time.Millisecond))
+	suite.NoError(err)
+	suite.Less(maxULID, nextTime)
+}
+
+func TestULIDTestSuite(t *testing.T) {
+	suite.Run(t, &ULIDTestSuite{})
+}
@@ -0,0 +1,234 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package linkpreview parses the metadata that web pages
+// provide for building link previews: OpenGraph tags, plain
+// html title + meta tags, and oEmbed.
+package linkpreview
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	maxTitleLen       = 200  // max runes in titles and names
+	maxDescriptionLen = 1000 // max runes in descriptions
+)
+
+// Page contains preview metadata parsed from the <head> of a web page.
+type Page struct {
+	Title        string // og:title, or else the <title> of the page
+	Description  string // og:description, or else the description meta tag
+	SiteName     string // og:site_name
+	AuthorName   string // author meta tag
+	Image        string // absolute URL of og:image
+	ImageWidth   int    // og:image:width
+	ImageHeight  int    // og:image:height
+	OEmbedURL    string // absolute URL of json oEmbed discovery link
+	HasOpenGraph bool   // page has at least og:title or og:description
+	NoIndex      bool   // page asks robots not to index it
+
+	// FediverseCreator is the fediverse:creator meta tag,
+	// naming the account that claims authorship of the page
+	// (eg., @someone@example.org). The claim is unverified.
+	FediverseCreator string
+}
+
+// ParseHTML parses preview metadata from the html page read from r,
+// which was fetched from pageURL. Parsing stops at the start of the
+// page body, so callers should limit r to a sensible size but need
+// not read the rest of the page.
+func ParseHTML(r io.Reader, pageURL *url.URL) (*Page, error) {
+	var (
+		page    = &Page{}
+		z       = html.NewTokenizer(r)
+		title   strings.Builder
+		inTitle bool
+		desc    string
+	)
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if err := z.Err(); !errors.Is(err, io.EOF) {
+				return nil, err
+			}
+			return page.finish(title.String(), desc), nil
+
+		case html.TextToken:
+			if inTitle {
+				title.Write(z.Text())
+			}
+
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.Title:
+				inTitle = false
+			case atom.Head:
+				return page.finish(title.String(), desc), nil
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.Body:
+				return page.finish(title.String(), desc), nil
+
+			case atom.Title:
+				inTitle = tt == html.StartTagToken
+
+			case atom.Meta:
+				attrs := readAttrs(z, hasAttr)
+				key := attrs["property"]
+				if key == "" {
+					key = attrs["name"]
+				}
+				page.setMeta(strings.ToLower(key), attrs["content"], pageURL, &desc)
+
+			case atom.Link:
+				attrs := readAttrs(z, hasAttr)
+				if strings.EqualFold(attrs["rel"], "alternate") &&
+					strings.EqualFold(attrs["type"], "application/json+oembed") &&
+					page.OEmbedURL == "" {
+					page.OEmbedURL = resolveURL(pageURL, attrs["href"])
+				}
+			}
+		}
+	}
+}
+
+// setMeta sets the field of page
+// corresponding to one meta tag.
+func (page *Page) setMeta(key string, content string, pageURL *url.URL, desc *string) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return
+	}
+
+	switch key {
+	case "og:title":
+		page.Title = content
+		page.HasOpenGraph = true
+	case "og:description":
+		page.Description = content
+		page.HasOpenGraph = true
+	case "og:site_name":
+		page.SiteName = content
+	case "og:image", "og:image:url":
+		// Only the first image is used.
+		if page.Image == "" {
+			page.Image = resolveURL(pageURL, content)
+		}
+	case "og:image:width":
+		page.ImageWidth, _ = strconv.Atoi(content)
+	case "og:image:height":
+		page.ImageHeight, _ = strconv.Atoi(content)
+	case "description":
+		*desc = content
+	case "author":
+		page.AuthorName = content
+	case "robots":
+		page.NoIndex = page.NoIndex || IsNoIndex(content)
+	case "fediverse:creator":
+		// Only the first creator is used.
+		if page.FediverseCreator == "" {
+			page.FediverseCreator = content
+		}
+	}
+}
+
+// finish fills in fallbacks for any
+// OpenGraph values the page didn't set.
+func (page *Page) finish(title string, desc string) *Page {
+	if page.Title == "" {
+		page.Title = strings.Join(strings.Fields(title), " ")
+	}
+
+	if page.Description == "" {
+		page.Description = desc
+	}
+
+	page.Title = truncate(page.Title, maxTitleLen)
+	page.Description = truncate(page.Description, maxDescriptionLen)
+	page.SiteName = truncate(page.SiteName, maxTitleLen)
+	page.AuthorName = truncate(page.AuthorName, maxTitleLen)
+
+	return page
+}
+
+// IsNoIndex returns whether the given robots meta
+// tag or X-Robots-Tag header value forbids indexing.
+func IsNoIndex(directives string) bool {
+	for _, d := range strings.Split(directives, ",") {
+		switch strings.ToLower(strings.TrimSpace(d)) {
+		case "noindex", "none":
+			return true
+		}
+	}
+	return false
+}
+
+// truncate shortens s to at most
+// n runes, adding an ellipsis if so.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// readAttrs returns the attributes of the
+// current tag, with lowercased keys.
+func readAttrs(z *html.Tokenizer, hasAttr bool) map[string]string {
+	attrs := make(map[string]string)
+	for hasAttr {
+		var k, v []byte
+		k, v, hasAttr = z.TagAttr()
+		attrs[strings.ToLower(string(k))] = string(v)
+	}
+	return attrs
+}
+
+// resolveURL resolves ref relative to base (if not nil),
+// returning the empty string if the result is not an
+// absolute http(s) URL.
+func resolveURL(base *url.URL, ref string) string {
+	u, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return ""
+	}
+
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return ""
+	}
+
+	return u.String()
+}
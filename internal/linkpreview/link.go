@@ -0,0 +1,68 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package linkpreview
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// FindLink returns the first link in the given (sanitized) status
+// html content that might be worth previewing, skipping mentions,
+// hashtags, and any link for which skip returns true. It returns
+// nil if no such link is found.
+func FindLink(content string, skip func(*url.URL) bool) *url.URL {
+	if !strings.Contains(content, "<a") {
+		// Nothing to do.
+		return nil
+	}
+
+	z := html.NewTokenizer(strings.NewReader(content))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return nil
+
+		case html.StartTagToken:
+			name, hasAttr := z.TagName()
+			if atom.Lookup(name) != atom.A {
+				continue
+			}
+
+			attrs := readAttrs(z, hasAttr)
+			if classes := strings.Fields(attrs["class"]); slices.Contains(classes, "mention") || slices.Contains(classes, "hashtag") {
+				continue
+			}
+
+			u, err := url.Parse(attrs["href"])
+			if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+				continue
+			}
+
+			if skip != nil && skip(u) {
+				continue
+			}
+
+			return u
+		}
+	}
+}
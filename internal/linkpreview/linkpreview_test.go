@@ -0,0 +1,162 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package linkpreview_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/linkpreview"
+)
+
+type LinkPreviewTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LinkPreviewTestSuite) parse(page string) *linkpreview.Page {
+	pageURL, _ := url.Parse("https://news.example.org/articles/1")
+	p, err := linkpreview.ParseHTML(strings.NewReader(page), pageURL)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return p
+}
+
+func (suite *LinkPreviewTestSuite) TestParseOpenGraph() {
+	p := suite.parse(`<!DOCTYPE html>
+<html>
+<head>
+	<title>Ignored &amp; Overridden</title>
+	<meta name="description" content="plain description">
+	<meta property="og:title" content="Cats &amp; Dogs">
+	<meta property="og:description" content="All about cats and dogs.">
+	<meta property="og:site_name" content="Example News">
+	<meta property="og:image" content="/img/cats.jpg">
+	<meta property="og:image:width" content="1200">
+	<meta property="og:image:height" content="630">
+	<meta property="og:image" content="/img/dogs.jpg">
+	<meta name="author" content="Some Writer">
+	<meta name="fediverse:creator" content="@writer@example.org">
+	<meta name="fediverse:creator" content="@someone_else@example.org">
+	<link rel="alternate" type="application/json+oembed" href="https://news.example.org/oembed?url=1">
+</head>
+<body>
+	<meta property="og:title" content="not in head">
+</body>
+</html>`)
+
+	suite.Equal(&linkpreview.Page{
+		Title:            "Cats & Dogs",
+		Description:      "All about cats and dogs.",
+		SiteName:         "Example News",
+		AuthorName:       "Some Writer",
+		Image:            "https://news.example.org/img/cats.jpg",
+		ImageWidth:       1200,
+		ImageHeight:      630,
+		OEmbedURL:        "https://news.example.org/oembed?url=1",
+		HasOpenGraph:     true,
+		FediverseCreator: "@writer@example.org",
+	}, p)
+}
+
+func (suite *LinkPreviewTestSuite) TestParseNoOpenGraph() {
+	p := suite.parse(`<html><head>
+	<title>
+		Just a
+		title
+	</title>
+	<meta name="description" content="plain description">
+	<meta name="robots" content="noarchive, NoIndex">
+	<meta property="og:image" content="javascript:alert(1)">
+</head><body>hello</body></html>`)
+
+	suite.Equal(&linkpreview.Page{
+		Title:       "Just a title",
+		Description: "plain description",
+		NoIndex:     true,
+	}, p)
+}
+
+func (suite *LinkPreviewTestSuite) TestParseOEmbed() {
+	o, err := linkpreview.ParseOEmbed(strings.NewReader(`{
+		"version": "1.0",
+		"type": "video",
+		"title": "A video",
+		"author_name": "Someone",
+		"author_url": "https://video.example.org/someone",
+		"provider_name": "Example Video",
+		"provider_url": "https://video.example.org/",
+		"thumbnail_url": "https://video.example.org/thumb.jpg",
+		"thumbnail_width": "480",
+		"thumbnail_height": 360
+	}`))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("A video", o.Title)
+	suite.Equal("Someone", o.AuthorName)
+	suite.Equal("https://video.example.org/thumb.jpg", o.ThumbnailURL)
+	suite.EqualValues(480, o.ThumbnailWidth)
+	suite.EqualValues(360, o.ThumbnailHeight)
+}
+
+func (suite *LinkPreviewTestSuite) TestEmbedIframe() {
+	allow := func(src *url.URL) bool { return src.Hostname() == "www.youtube.com" }
+
+	embed := func(html string) string {
+		o := &linkpreview.OEmbed{HTML: html, Width: 200, Height: 113}
+		return o.EmbedIframe(allow)
+	}
+
+	suite.Equal(
+		`<iframe src="https://www.youtube.com/embed/abc?feature=oembed&amp;x=1" width="200" height="113" sandbox="allow-scripts allow-same-origin" allowfullscreen="allowfullscreen" frameborder="0"></iframe>`,
+		embed(`<script>alert(1)</script><iframe width="200" height="113" src="https://www.youtube.com/embed/abc?feature=oembed&amp;x=1" onload="alert(1)" allow="autoplay"></iframe>`),
+	)
+
+	// Not from the provider.
+	suite.Empty(embed(`<iframe src="https://evil.example.org/embed/abc"></iframe>`))
+
+	// Not https.
+	suite.Empty(embed(`<iframe src="http://www.youtube.com/embed/abc"></iframe>`))
+
+	// No iframe.
+	suite.Empty(embed(`<blockquote><a href="https://www.youtube.com/watch?v=abc">hi</a></blockquote>`))
+}
+
+func (suite *LinkPreviewTestSuite) TestFindLink() {
+	content := `<p>hey <span class="h-card"><a href="https://example.org/@someone" class="u-url mention">@<span>someone</span></a></span> ` +
+		`<a href="https://example.org/tags/cats" class="mention hashtag" rel="tag">#<span>cats</span></a> ` +
+		`see <a href="http://localhost:8080/@zork/statuses/1">my post</a> and ` +
+		`<a href="https://news.example.org/articles/1" rel="nofollow noreferrer noopener">this article</a></p>`
+
+	local := func(u *url.URL) bool { return u.Host == "localhost:8080" }
+
+	link := linkpreview.FindLink(content, local)
+	if suite.NotNil(link) {
+		suite.Equal("https://news.example.org/articles/1", link.String())
+	}
+
+	suite.Nil(linkpreview.FindLink(`<p>no links here</p>`, local))
+}
+
+func TestLinkPreviewTestSuite(t *testing.T) {
+	suite.Run(t, new(LinkPreviewTestSuite))
+}
@@ -0,0 +1,142 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package linkpreview
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// OEmbed contains the parts of a json oEmbed
+// response that are useful for link previews.
+//
+// See: https://oembed.com/#section2.3
+type OEmbed struct {
+	Type            string  `json:"type"`
+	Title           string  `json:"title"`
+	AuthorName      string  `json:"author_name"`
+	AuthorURL       string  `json:"author_url"`
+	ProviderName    string  `json:"provider_name"`
+	ProviderURL     string  `json:"provider_url"`
+	ThumbnailURL    string  `json:"thumbnail_url"`
+	ThumbnailWidth  flexInt `json:"thumbnail_width"`
+	ThumbnailHeight flexInt `json:"thumbnail_height"`
+	HTML            string  `json:"html"`
+	Width           flexInt `json:"width"`
+	Height          flexInt `json:"height"`
+}
+
+// ParseOEmbed parses a json oEmbed response read from r.
+// Any URLs in the response which aren't absolute http(s)
+// URLs are dropped.
+func ParseOEmbed(r io.Reader) (*OEmbed, error) {
+	oembed := &OEmbed{}
+	if err := json.NewDecoder(r).Decode(oembed); err != nil {
+		return nil, err
+	}
+
+	oembed.Title = truncate(oembed.Title, maxTitleLen)
+	oembed.AuthorName = truncate(oembed.AuthorName, maxTitleLen)
+	oembed.ProviderName = truncate(oembed.ProviderName, maxTitleLen)
+	oembed.AuthorURL = resolveURL(nil, oembed.AuthorURL)
+	oembed.ProviderURL = resolveURL(nil, oembed.ProviderURL)
+	oembed.ThumbnailURL = resolveURL(nil, oembed.ThumbnailURL)
+
+	return oembed, nil
+}
+
+// EmbedIframe builds a sandboxed iframe from the first iframe in the
+// html of an oEmbed response, to embed its media in a preview card. Only
+// the iframe's src is kept, and only if it's an https URL which allowSrc
+// returns true for. Size is taken from the iframe, or else from the
+// oEmbed itself. An empty string is returned if there's nothing to embed.
+func (o *OEmbed) EmbedIframe(allowSrc func(*url.URL) bool) string {
+	var (
+		z      = html.NewTokenizer(strings.NewReader(o.HTML))
+		width  = int(o.Width)
+		height = int(o.Height)
+	)
+
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			// End of html,
+			// no iframe.
+			return ""
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			if atom.Lookup(name) != atom.Iframe {
+				continue
+			}
+
+			var src *url.URL
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = z.TagAttr()
+
+				switch string(key) {
+				case "src":
+					src, _ = url.Parse(strings.TrimSpace(string(val)))
+				case "width":
+					if n, err := strconv.Atoi(string(val)); err == nil {
+						width = n
+					}
+				case "height":
+					if n, err := strconv.Atoi(string(val)); err == nil {
+						height = n
+					}
+				}
+			}
+
+			if src == nil || src.Scheme != "https" || src.Host == "" || !allowSrc(src) {
+				return ""
+			}
+
+			if width < 0 || height < 0 {
+				width, height = 0, 0
+			}
+
+			return `<iframe src="` + html.EscapeString(src.String()) + `"` +
+				` width="` + strconv.Itoa(width) + `"` +
+				` height="` + strconv.Itoa(height) + `"` +
+				` sandbox="allow-scripts allow-same-origin"` +
+				` allowfullscreen="allowfullscreen" frameborder="0"></iframe>`
+		}
+	}
+}
+
+// flexInt is an int which may be encoded in json as
+// either a number or a string, since providers differ.
+type flexInt int
+
+func (i *flexInt) UnmarshalJSON(b []byte) error {
+	n, err := strconv.Atoi(strings.Trim(string(b), `"`))
+	if err != nil {
+		// Not a number, ignore it.
+		return nil
+	}
+	*i = flexInt(n)
+	return nil
+}
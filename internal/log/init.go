@@ -27,25 +27,31 @@ import (
 
 // ParseLevel will parse the log level from given string and set to appropriate level.
 func ParseLevel(str string) error {
-	switch strings.ToLower(str) {
-	case "trace":
-		SetLevel(level.TRACE)
-	case "debug":
-		SetLevel(level.DEBUG)
-	case "", "info":
-		SetLevel(level.INFO)
-	case "warn":
-		SetLevel(level.WARN)
-	case "error":
-		SetLevel(level.ERROR)
-	case "fatal":
-		SetLevel(level.FATAL)
-	default:
+	lvl, ok := levels[strings.ToLower(str)]
+	if !ok {
 		return fmt.Errorf("unknown log level: %q", str)
 	}
+	SetLevel(lvl)
 	return nil
 }
 
+// ValidLevel returns whether given string is a log level that can be parsed by ParseLevel().
+func ValidLevel(str string) bool {
+	_, ok := levels[strings.ToLower(str)]
+	return ok
+}
+
+// levels maps lower-case level strings to their log level.
+var levels = map[string]level.LEVEL{
+	"trace": level.TRACE,
+	"debug": level.DEBUG,
+	"":      level.INFO,
+	"info":  level.INFO,
+	"warn":  level.WARN,
+	"error": level.ERROR,
+	"fatal": level.FATAL,
+}
+
 // EnableSyslog will enabling logging to the syslog at given address.
 func EnableSyslog(proto, addr string) error {
 	// Dial a connection to the syslog daemon
@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// AllowedMIMETypes returns the subset of SupportedMIMETypes
+// which may currently be uploaded as media attachments, taking
+// account of the media-allowed-types and media-blocked-types
+// config settings.
+func AllowedMIMETypes() []string {
+	allowed := make([]string, 0, len(SupportedMIMETypes))
+	for _, mimeType := range SupportedMIMETypes {
+		if MIMETypeAllowed(mimeType) {
+			allowed = append(allowed, mimeType)
+		}
+	}
+	return allowed
+}
+
+// MIMETypeAllowed returns true if the given MIME type may be
+// uploaded as a media attachment according to the configured
+// allow and block lists. It does not check whether the type is
+// actually supported; use SupportedMIMETypes for that.
+func MIMETypeAllowed(mimeType string) bool {
+	if blocked := config.GetMediaBlockedTypes(); len(blocked) > 0 {
+		return !matchMIMEType(blocked, mimeType)
+	}
+
+	if allowed := config.GetMediaAllowedTypes(); len(allowed) > 0 {
+		return matchMIMEType(allowed, mimeType)
+	}
+
+	// Neither list set,
+	// allow everything.
+	return true
+}
+
+// matchMIMEType returns true if mimeType matches any of the given
+// patterns, which may be either full MIME types (eg., "image/png")
+// or wildcards covering a whole top-level type (eg., "image/*").
+func matchMIMEType(patterns []string, mimeType string) bool {
+	mimeType = strings.ToLower(mimeType)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == mimeType {
+			return true
+		}
+
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok &&
+			strings.HasPrefix(mimeType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
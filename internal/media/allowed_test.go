@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+)
+
+type AllowedTestSuite struct {
+	MediaStandardTestSuite
+}
+
+func (suite *AllowedTestSuite) TestAllowedMIMETypesDefault() {
+	suite.Equal(media.SupportedMIMETypes, media.AllowedMIMETypes())
+	suite.True(media.MIMETypeAllowed("video/mp4"))
+}
+
+func (suite *AllowedTestSuite) TestAllowedMIMETypesAllowList() {
+	config.SetMediaAllowedTypes([]string{"image/*"})
+
+	suite.Equal([]string{"image/jpeg", "image/gif", "image/png", "image/webp"}, media.AllowedMIMETypes())
+	suite.True(media.MIMETypeAllowed("image/png"))
+	suite.False(media.MIMETypeAllowed("video/mp4"))
+}
+
+func (suite *AllowedTestSuite) TestAllowedMIMETypesBlockList() {
+	config.SetMediaBlockedTypes([]string{"video/mp4", "image/GIF"})
+
+	suite.Equal([]string{"image/jpeg", "image/png", "image/webp"}, media.AllowedMIMETypes())
+	suite.False(media.MIMETypeAllowed("image/gif"))
+	suite.True(media.MIMETypeAllowed("image/jpeg"))
+}
+
+func TestAllowedTestSuite(t *testing.T) {
+	suite.Run(t, &AllowedTestSuite{})
+}
@@ -326,6 +326,10 @@ func (m *Manager) PreProcessEmoji(ctx context.Context, data DataFunc, shortcode
 		if ai.CategoryID != nil {
 			emoji.CategoryID = *ai.CategoryID
 		}
+
+		if ai.AccountID != nil {
+			emoji.AccountID = *ai.AccountID
+		}
 	}
 
 	processingEmoji := &ProcessingEmoji{
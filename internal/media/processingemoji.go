@@ -121,6 +121,12 @@ func (p *ProcessingEmoji) load(ctx context.Context) (*gtsmodel.Emoji, bool, erro
 			return err
 		}
 
+		// Files are now in storage.
+		p.emoji.Cached = func() *bool {
+			ok := true
+			return &ok
+		}()
+
 		if p.refresh {
 			columns := []string{
 				"image_remote_url",
@@ -135,6 +141,7 @@ func (p *ProcessingEmoji) load(ctx context.Context) (*gtsmodel.Emoji, bool, erro
 				"image_updated_at",
 				"shortcode",
 				"uri",
+				"cached",
 			}
 
 			// Existing emoji we're refreshing, so only need to update.
@@ -36,12 +36,38 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
+// processedColumns are the attachment columns
+// filled in by finish(), once the original
+// media has been stored and inserted.
+var processedColumns = []string{
+	"original_width",
+	"original_height",
+	"original_size",
+	"original_aspect",
+	"original_duration",
+	"original_framerate",
+	"original_bitrate",
+	"small_width",
+	"small_height",
+	"small_size",
+	"small_aspect",
+	"blurhash",
+	"processing",
+	"file_updated_at",
+	"thumbnail_path",
+	"thumbnail_content_type",
+	"thumbnail_file_size",
+	"thumbnail_url",
+}
+
 // ProcessingMedia represents a piece of media that is currently being processed. It exposes
 // various functions for retrieving data from the process.
 type ProcessingMedia struct {
 	media   *gtsmodel.MediaAttachment // processing media attachment details
 	dataFn  DataFunc                  // load-data function, returns media stream
 	recache bool                      // recaching existing (uncached) media
+	stored  bool                      // stored is set once the original media has been written to storage
+	put     bool                      // put is set once the attachment has been inserted into the database
 	done    bool                      // done is set when process finishes with non ctx canceled type error
 	proc    runners.Processor         // proc helps synchronize only a singular running processing instance
 	err     error                     // error stores permanent error value when done
@@ -73,6 +99,58 @@ func (p *ProcessingMedia) LoadAttachment(ctx context.Context) (*gtsmodel.MediaAt
 	return nil, err
 }
 
+// LoadOriginal blocks until only the original media has been stored, then inserts the attachment
+// into the database with processing status "processing", and returns it. Generating the thumbnail
+// and blurhash is queued in the thumbnail worker pool, which updates the attachment once finished.
+func (p *ProcessingMedia) LoadOriginal(ctx context.Context) (*gtsmodel.MediaAttachment, error) {
+	var media gtsmodel.MediaAttachment
+
+	err := p.proc.Process(func() error {
+		if p.done {
+			// Already proc'd.
+			if p.err == nil {
+				media = *p.media
+			}
+			return p.err
+		}
+
+		if !p.stored {
+			// Store the original media
+			// and calculate its details.
+			if err := p.store(ctx); err != nil {
+				return err
+			}
+			p.stored = true
+		}
+
+		if !p.put && !p.recache {
+			// Insert the attachment so it can be
+			// fetched while being processed.
+			p.media.Processing = gtsmodel.ProcessingStatusProcessing
+			if err := p.mgr.state.DB.PutAttachment(ctx, p.media); err != nil {
+				return err
+			}
+			p.put = true
+		}
+
+		// Take a copy, as the
+		// worker will modify p.media.
+		media = *p.media
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if media.Processing != gtsmodel.ProcessingStatusProcessed {
+		// Queue the remaining processing.
+		go p.mgr.state.Workers.Thumbnail.Enqueue(p.Process)
+	}
+
+	return &media, nil
+}
+
 // Process allows the receiving object to fit the runners.WorkerFunc signature. It performs a (blocking) load and logs on error.
 func (p *ProcessingMedia) Process(ctx context.Context) {
 	if _, _, err := p.load(ctx); err != nil {
@@ -107,12 +185,24 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 			// Store final values.
 			p.done = true
 			p.err = err
+
+			if err != nil && p.put {
+				// Attachment was already inserted by
+				// LoadOriginal, so mark it as failed.
+				p.media.Processing = gtsmodel.ProcessingStatusError
+				if err := p.mgr.state.DB.UpdateAttachment(ctx, p.media, "processing"); err != nil {
+					log.Errorf(ctx, "error updating attachment processing status: %v", err)
+				}
+			}
 		}()
 
-		// Attempt to store media and calculate
-		// full-size media attachment details.
-		if err = p.store(ctx); err != nil {
-			return err
+		if !p.stored {
+			// Attempt to store media and calculate
+			// full-size media attachment details.
+			if err = p.store(ctx); err != nil {
+				return err
+			}
+			p.stored = true
 		}
 
 		// Finish processing by reloading media into
@@ -121,6 +211,14 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 			return err
 		}
 
+		if p.put {
+			// Attachment inserted by LoadOriginal, only update the
+			// processed columns so as not to overwrite any changes
+			// made to it (e.g. its description) in the meantime.
+			err = p.mgr.state.DB.UpdateAttachment(ctx, p.media, processedColumns...)
+			return err
+		}
+
 		if p.recache {
 			// Existing attachment we're recaching, so only update.
 			err = p.mgr.state.DB.UpdateAttachment(ctx, p.media)
@@ -106,6 +106,8 @@ type AdditionalEmojiInfo struct {
 	VisibleInPicker *bool
 	// ID of the category this emoji should be placed in; defaults to "".
 	CategoryID *string
+	// ID of the local account that owns this emoji, if it's a personal emoji; defaults to "".
+	AccountID *string
 }
 
 // DataFunc represents a function used to retrieve the raw bytes of a piece of media.
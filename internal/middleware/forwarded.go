@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// Forwarded returns a gin middleware which, for requests coming
+// directly from one of the configured trusted proxies, rewrites the
+// request scheme and host to the values given in the X-Forwarded-Proto
+// and X-Forwarded-Host headers. This means anything derived from the
+// request URL matches what the client actually asked for, rather than
+// the plain http hop between proxy and GoToSocial.
+//
+// Headers sent by untrusted peers are ignored entirely.
+func Forwarded() gin.HandlerFunc {
+	trusted := parseTrustedProxies(config.GetTrustedProxies())
+
+	return func(c *gin.Context) {
+		remoteIP := net.ParseIP(c.RemoteIP())
+		if remoteIP == nil || !ipInNets(remoteIP, trusted) {
+			return
+		}
+
+		if proto := lastForwardedValue(c.Request.Header, "X-Forwarded-Proto"); proto == "http" || proto == "https" {
+			c.Request.URL.Scheme = proto
+		}
+
+		if host := lastForwardedValue(c.Request.Header, "X-Forwarded-Host"); host != "" {
+			c.Request.Host = host
+		}
+	}
+}
+
+// parseTrustedProxies parses the given CIDRs or
+// plain IP addresses, skipping any that are invalid
+// (these are already rejected at router startup).
+func parseTrustedProxies(proxies []string) []*net.IPNet {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				continue
+			}
+
+			bits := 128
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 32
+			}
+
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			continue
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets
+}
+
+// ipInNets returns whether ip is contained in any of nets.
+func ipInNets(ip net.IP, nets []*net.IPNet) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastForwardedValue returns the last, trimmed and lowercased value
+// of the given header. Proxies chained together each append to these
+// headers, either to a comma-separated list or as a new header line,
+// so only the rightmost value of the last line was added by the trusted
+// proxy we're talking to; anything before it may have been supplied by
+// the client.
+func lastForwardedValue(header http.Header, key string) string {
+	values := header.Values(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	value := values[len(values)-1]
+	if i := strings.LastIndexByte(value, ','); i >= 0 {
+		value = value[i+1:]
+	}
+	return strings.ToLower(strings.TrimSpace(value))
+}
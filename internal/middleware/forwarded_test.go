@@ -0,0 +1,144 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ForwardedTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ForwardedTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	config.SetTrustedProxies([]string{"127.0.0.1/32", "::1"})
+}
+
+// serve passes a request from remoteAddr with the given
+// headers through the Forwarded middleware, and returns
+// the scheme and host seen by the handler after it.
+func (suite *ForwardedTestSuite) serve(remoteAddr string, headers map[string]string) (string, string) {
+	h := make(http.Header, len(headers))
+	for k, v := range headers {
+		h.Set(k, v)
+	}
+	return suite.serveHeader(remoteAddr, h)
+}
+
+// serveHeader is like serve, but takes the full
+// header so that keys can be given multiple lines.
+func (suite *ForwardedTestSuite) serveHeader(remoteAddr string, header http.Header) (string, string) {
+	var scheme, host string
+
+	engine := gin.New()
+	engine.Use(middleware.Forwarded())
+	engine.GET("/", func(c *gin.Context) {
+		scheme = c.Request.URL.Scheme
+		host = c.Request.Host
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost:8080/", nil)
+	req.RemoteAddr = remoteAddr
+	req.URL.Scheme = ""
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	engine.ServeHTTP(httptest.NewRecorder(), req)
+	return scheme, host
+}
+
+func (suite *ForwardedTestSuite) TestForwardedTrustedProxy() {
+	scheme, host := suite.serve("127.0.0.1:54321", map[string]string{
+		"X-Forwarded-Proto": "https",
+		"X-Forwarded-Host":  "example.org",
+	})
+	suite.Equal("https", scheme)
+	suite.Equal("example.org", host)
+}
+
+func (suite *ForwardedTestSuite) TestForwardedTrustedProxyIPv6() {
+	scheme, host := suite.serve("[::1]:54321", map[string]string{
+		"X-Forwarded-Proto": "https",
+	})
+	suite.Equal("https", scheme)
+	suite.Equal("localhost:8080", host)
+}
+
+func (suite *ForwardedTestSuite) TestForwardedChainedProxies() {
+	scheme, host := suite.serve("127.0.0.1:54321", map[string]string{
+		"X-Forwarded-Proto": "http, HTTPS",
+		"X-Forwarded-Host":  "internal.example.org, example.org",
+	})
+	suite.Equal("https", scheme)
+	suite.Equal("example.org", host)
+}
+
+func (suite *ForwardedTestSuite) TestForwardedSpoofedValues() {
+	// only the rightmost values were added by the
+	// trusted proxy, the rest came from the client
+	scheme, host := suite.serve("127.0.0.1:54321", map[string]string{
+		"X-Forwarded-Proto": "gopher, http",
+		"X-Forwarded-Host":  "evil.example.org, example.org",
+	})
+	suite.Equal("http", scheme)
+	suite.Equal("example.org", host)
+}
+
+func (suite *ForwardedTestSuite) TestForwardedMultipleHeaderLines() {
+	// the trusted proxy added its own header lines
+	// rather than extending the client-supplied ones
+	header := make(http.Header)
+	header.Add("X-Forwarded-Proto", "https")
+	header.Add("X-Forwarded-Proto", "http")
+	header.Add("X-Forwarded-Host", "evil.example.org")
+	header.Add("X-Forwarded-Host", "gopher.example.org, example.org")
+
+	scheme, host := suite.serveHeader("127.0.0.1:54321", header)
+	suite.Equal("http", scheme)
+	suite.Equal("example.org", host)
+}
+
+func (suite *ForwardedTestSuite) TestForwardedBadProto() {
+	scheme, _ := suite.serve("127.0.0.1:54321", map[string]string{
+		"X-Forwarded-Proto": "gopher",
+	})
+	suite.Empty(scheme)
+}
+
+func (suite *ForwardedTestSuite) TestForwardedUntrustedPeer() {
+	scheme, host := suite.serve("192.0.2.1:54321", map[string]string{
+		"X-Forwarded-Proto": "https",
+		"X-Forwarded-Host":  "evil.example.org",
+	})
+	suite.Empty(scheme)
+	suite.Equal("localhost:8080", host)
+}
+
+func TestForwardedTestSuite(t *testing.T) {
+	suite.Run(t, new(ForwardedTestSuite))
+}
@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/errorcodes"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// maintenanceRetryAfter is the Retry-After value,
+// in seconds, sent while in maintenance mode.
+const maintenanceRetryAfter = "300"
+
+// Maintenance returns a gin middleware which, while maintenance mode is turned on,
+// aborts requests with a 503 Service Unavailable and a Retry-After header, rendered
+// as an error page or as JSON depending on what the caller accepts.
+//
+// Requests with a path starting with any of the given prefixes are let through as
+// normal, so that eg., federation and health checks keep working during maintenance.
+func Maintenance(
+	instanceGet func(context.Context) (*apimodel.InstanceV1, gtserror.WithCode),
+	allowPrefixes ...string,
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !config.GetMaintenanceMode() {
+			// Business as usual.
+			return
+		}
+
+		path := c.Request.URL.Path
+		for _, prefix := range allowPrefixes {
+			if strings.HasPrefix(path, prefix) {
+				// Allowed during maintenance.
+				return
+			}
+		}
+
+		helpText := []string{"this instance is undergoing maintenance"}
+		if msg := config.GetMaintenanceMessage(); msg != "" {
+			helpText = append(helpText, msg)
+		}
+
+		err := errorcodes.Set(errors.New("maintenance mode"), errorcodes.Maintenance)
+		c.Header("Retry-After", maintenanceRetryAfter)
+		apiutil.ErrorHandler(c, gtserror.NewErrorServiceUnavailable(err, helpText...), instanceGet)
+		c.Abort()
+	}
+}
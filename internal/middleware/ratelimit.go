@@ -20,9 +20,11 @@ package middleware
 import (
 	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/errorcodes"
 	"github.com/ulule/limiter/v3"
 	limitergin "github.com/ulule/limiter/v3/drivers/middleware/gin"
 	"github.com/ulule/limiter/v3/drivers/store/memory"
@@ -40,9 +42,35 @@ const rateLimitPeriod = 5 * time.Minute
 // If `x-ratelimit-limit` is exceeded, the request is aborted and an HTTP 429 TooManyRequests
 // status is returned.
 //
-// If the config AdvancedRateLimitRequests value is <= 0, then a noop handler will be returned,
-// which performs no rate limiting.
-func RateLimit(limit int) gin.HandlerFunc {
+// If the given limit is <= 0, then no rate limiting will be performed.
+//
+// The limit is fetched from the given function on each request, so that it can be changed
+// while running (eg., on config reload). When it changes, a new limiter is created, which
+// means that any requests counted so far in the current rate limit period are forgotten.
+func RateLimit(limit func() int) gin.HandlerFunc {
+	var (
+		mu      sync.Mutex
+		current int
+		handler gin.HandlerFunc
+	)
+
+	return func(c *gin.Context) {
+		l := limit()
+
+		mu.Lock()
+		if handler == nil || l != current {
+			current = l
+			handler = rateLimit(l)
+		}
+		h := handler
+		mu.Unlock()
+
+		h(c)
+	}
+}
+
+// rateLimit returns a rate limiting gin middleware for the given limit.
+func rateLimit(limit int) gin.HandlerFunc {
 	if limit <= 0 {
 		// use noop middleware if ratelimiting is disabled
 		return func(ctx *gin.Context) {}
@@ -56,7 +84,10 @@ func RateLimit(limit int) gin.HandlerFunc {
 
 	// use custom rate limit reached error
 	handler := func(c *gin.Context) {
-		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit reached"})
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+			"error":      "rate limit reached",
+			"error_code": errorcodes.RateLimited,
+		})
 	}
 
 	return limitergin.NewMiddleware(
@@ -0,0 +1,257 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	redigo "github.com/gomodule/redigo/redis"
+	"github.com/gorilla/securecookie"
+	gsessions "github.com/gorilla/sessions"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+const (
+	// redisSessionPrefix is prepended to
+	// session IDs to form their Redis key.
+	redisSessionPrefix = "gotosocial:session:"
+
+	// redisTimeout bounds connecting to Redis,
+	// and each read / write of a session.
+	redisTimeout = 5 * time.Second
+)
+
+// redisStore is a sessions.Store that keeps each session in a
+// Redis hash, one field per session value, expiring along with
+// the session. This lets sessions be shared between several
+// running GoToSocial processes. As with memstore, the cookie
+// itself only holds the session ID, signed + encrypted with
+// the given codecs.
+type redisStore struct {
+	pool    *redigo.Pool
+	codecs  []securecookie.Codec
+	options *gsessions.Options
+}
+
+// newRedisPool returns a new Redis connection pool
+// using the session-redis-* configuration values.
+func newRedisPool() *redigo.Pool {
+	return &redigo.Pool{
+		MaxIdle:     10,
+		IdleTimeout: 5 * time.Minute,
+		Dial: func() (redigo.Conn, error) {
+			return redigo.Dial("tcp", config.GetSessionRedisAddress(),
+				redigo.DialPassword(config.GetSessionRedisPassword()),
+				redigo.DialDatabase(config.GetSessionRedisDB()),
+				redigo.DialConnectTimeout(redisTimeout),
+				redigo.DialReadTimeout(redisTimeout),
+				redigo.DialWriteTimeout(redisTimeout),
+			)
+		},
+	}
+}
+
+// newRedisStore returns a new redisStore using the given Redis
+// connection pool and cookie authentication / encryption keys.
+// This fails if the Redis server can't be reached.
+func newRedisStore(pool *redigo.Pool, keyPairs ...[]byte) (*redisStore, error) {
+	conn := pool.Get()
+	defer conn.Close()
+
+	if _, err := conn.Do("PING"); err != nil {
+		return nil, fmt.Errorf("error pinging redis: %w", err)
+	}
+
+	return &redisStore{
+		pool:    pool,
+		codecs:  securecookie.CodecsFromPairs(keyPairs...),
+		options: &gsessions.Options{Path: "/"},
+	}, nil
+}
+
+func (s *redisStore) Options(options sessions.Options) {
+	s.options = options.ToGorillaOptions()
+
+	// Make cookie decoding reject IDs that
+	// are older than the session max age.
+	for _, codec := range s.codecs {
+		if sc, ok := codec.(*securecookie.SecureCookie); ok {
+			sc.MaxAge(s.options.MaxAge)
+		}
+	}
+}
+
+func (s *redisStore) Get(r *http.Request, name string) (*gsessions.Session, error) {
+	return gsessions.GetRegistry(r).Get(s, name)
+}
+
+func (s *redisStore) New(r *http.Request, name string) (*gsessions.Session, error) {
+	session := gsessions.NewSession(s, name)
+	options := *s.options
+	session.Options = &options
+	session.IsNew = true
+
+	c, err := r.Cookie(name)
+	if err != nil {
+		// No cookie yet.
+		return session, nil
+	}
+
+	if err := securecookie.DecodeMulti(name, c.Value, &session.ID, s.codecs...); err != nil {
+		return session, err
+	}
+
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	fields, err := redigo.StringMap(conn.Do("HGETALL", redisSessionPrefix+session.ID))
+	if err != nil {
+		return session, err
+	}
+
+	if len(fields) == 0 {
+		// Session expired, or was
+		// stored by another backend.
+		return session, nil
+	}
+
+	for key, field := range fields {
+		value, err := decodeSessionValue(field)
+		if err != nil {
+			return session, fmt.Errorf("error decoding session value %s: %w", key, err)
+		}
+		session.Values[key] = value
+	}
+
+	session.IsNew = false
+	return session, nil
+}
+
+func (s *redisStore) Save(r *http.Request, w http.ResponseWriter, session *gsessions.Session) error {
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	if session.Options.MaxAge <= 0 {
+		// Session is being deleted.
+		if session.ID != "" {
+			if _, err := conn.Do("DEL", redisSessionPrefix+session.ID); err != nil {
+				return err
+			}
+		}
+
+		for k := range session.Values {
+			delete(session.Values, k)
+		}
+
+		http.SetCookie(w, gsessions.NewCookie(session.Name(), "", session.Options))
+		return nil
+	}
+
+	if session.ID == "" {
+		session.ID = strings.TrimRight(base32.StdEncoding.EncodeToString(securecookie.GenerateRandomKey(32)), "=")
+	}
+
+	// Each session value goes
+	// in its own hash field.
+	key := redisSessionPrefix + session.ID
+	args := redigo.Args{key}
+	for k, v := range session.Values {
+		field, ok := k.(string)
+		if !ok {
+			return fmt.Errorf("session key %v is not a string", k)
+		}
+
+		value, err := encodeSessionValue(v)
+		if err != nil {
+			return fmt.Errorf("error encoding session value %s: %w", field, err)
+		}
+
+		args = args.Add(field, value)
+	}
+
+	// Replace the whole hash and set its expiry in one
+	// transaction, so that deleted values don't linger
+	// and there's never a session key left without TTL.
+	if err := conn.Send("MULTI"); err != nil {
+		return err
+	}
+	if err := conn.Send("DEL", key); err != nil {
+		return err
+	}
+	if len(args) > 1 {
+		if err := conn.Send("HSET", args...); err != nil {
+			return err
+		}
+		if err := conn.Send("EXPIRE", key, session.Options.MaxAge); err != nil {
+			return err
+		}
+	}
+	if _, err := conn.Do("EXEC"); err != nil {
+		return err
+	}
+
+	encoded, err := securecookie.EncodeMulti(session.Name(), session.ID, s.codecs...)
+	if err != nil {
+		return err
+	}
+
+	http.SetCookie(w, gsessions.NewCookie(session.Name(), encoded, session.Options))
+	return nil
+}
+
+// encodeSessionValue encodes the given session value as a hash
+// field, prefixed with a byte indicating its type so it can be
+// decoded again. Only strings, bools and int64s are supported.
+func encodeSessionValue(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return "s" + v, nil
+	case bool:
+		return "b" + strconv.FormatBool(v), nil
+	case int64:
+		return "i" + strconv.FormatInt(v, 10), nil
+	default:
+		return "", fmt.Errorf("unsupported type %T", v)
+	}
+}
+
+// decodeSessionValue decodes a session value
+// previously encoded with encodeSessionValue.
+func decodeSessionValue(field string) (any, error) {
+	if field == "" {
+		return nil, errors.New("empty field")
+	}
+
+	switch t, v := field[0], field[1:]; t {
+	case 's':
+		return v, nil
+	case 'b':
+		return strconv.ParseBool(v)
+	case 'i':
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return nil, fmt.Errorf("unknown type %q", t)
+	}
+}
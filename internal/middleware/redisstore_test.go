@@ -0,0 +1,374 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-contrib/sessions"
+	redigo "github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeRedis is an in-memory stand-in for a Redis
+// server, supporting just the commands redisStore uses.
+type fakeRedis struct {
+	mu     sync.Mutex
+	hashes map[string]map[string]string
+	ttls   map[string]int
+}
+
+func (f *fakeRedis) exec(cmd string, args []any) (any, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	strs := make([]string, len(args))
+	for i, arg := range args {
+		strs[i] = fmt.Sprint(arg)
+	}
+
+	switch cmd {
+	case "PING":
+		return "PONG", nil
+	case "HGETALL":
+		reply := []any{}
+		for field, value := range f.hashes[strs[0]] {
+			reply = append(reply, []byte(field), []byte(value))
+		}
+		return reply, nil
+	case "HSET":
+		if len(strs) < 3 || len(strs)%2 != 1 {
+			return nil, redigo.Error("ERR wrong number of arguments for 'hset' command")
+		}
+		hash, ok := f.hashes[strs[0]]
+		if !ok {
+			hash = make(map[string]string)
+			f.hashes[strs[0]] = hash
+		}
+		for i := 1; i < len(strs); i += 2 {
+			hash[strs[i]] = strs[i+1]
+		}
+		return int64(len(strs) / 2), nil
+	case "EXPIRE":
+		var ttl int
+		if _, err := fmt.Sscan(strs[1], &ttl); err != nil {
+			return nil, err
+		}
+		f.ttls[strs[0]] = ttl
+		return int64(1), nil
+	case "DEL":
+		delete(f.hashes, strs[0])
+		delete(f.ttls, strs[0])
+		return int64(1), nil
+	default:
+		return nil, redigo.Error("ERR unknown command '" + cmd + "'")
+	}
+}
+
+// fakeRedisConn is a redigo.Conn on a fakeRedis,
+// which queues commands between MULTI and EXEC.
+type fakeRedisConn struct {
+	redis  *fakeRedis
+	sent   [][]any
+	multi  bool
+	queued [][]any
+}
+
+func (c *fakeRedisConn) Close() error { return nil }
+
+func (c *fakeRedisConn) Err() error { return nil }
+
+func (c *fakeRedisConn) Flush() error { return nil }
+
+func (c *fakeRedisConn) Receive() (any, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeRedisConn) Send(cmd string, args ...any) error {
+	c.sent = append(c.sent, append([]any{cmd}, args...))
+	return nil
+}
+
+func (c *fakeRedisConn) Do(cmd string, args ...any) (any, error) {
+	sent := c.sent
+	c.sent = nil
+
+	for _, s := range sent {
+		if _, err := c.do(s[0].(string), s[1:]); err != nil {
+			return nil, err
+		}
+	}
+
+	if cmd == "" {
+		// Just a flush.
+		return nil, nil
+	}
+
+	return c.do(cmd, args)
+}
+
+func (c *fakeRedisConn) do(cmd string, args []any) (any, error) {
+	switch {
+	case cmd == "MULTI":
+		c.multi = true
+		return "OK", nil
+	case cmd == "DISCARD":
+		c.multi, c.queued = false, nil
+		return "OK", nil
+	case cmd == "EXEC":
+		queued := c.queued
+		c.multi, c.queued = false, nil
+		replies := []any{}
+		for _, q := range queued {
+			reply, err := c.redis.exec(q[0].(string), q[1:])
+			if err != nil {
+				return nil, err
+			}
+			replies = append(replies, reply)
+		}
+		return replies, nil
+	case c.multi:
+		c.queued = append(c.queued, append([]any{cmd}, args...))
+		return "QUEUED", nil
+	default:
+		return c.redis.exec(cmd, args)
+	}
+}
+
+type RedisStoreTestSuite struct {
+	suite.Suite
+	redis *fakeRedis
+	store *redisStore
+}
+
+func (suite *RedisStoreTestSuite) SetupTest() {
+	suite.redis = &fakeRedis{
+		hashes: make(map[string]map[string]string),
+		ttls:   make(map[string]int),
+	}
+
+	pool := &redigo.Pool{
+		Dial: func() (redigo.Conn, error) {
+			return &fakeRedisConn{redis: suite.redis}, nil
+		},
+	}
+
+	store, err := newRedisStore(pool, []byte("01234567890123456789012345678901"), []byte("98765432109876543210987654321098"))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	store.Options(sessions.Options{Path: "/", MaxAge: 120})
+	suite.store = store
+}
+
+// saveSession saves the given values in a new session,
+// returning its ID and the cookie sent to the client.
+func (suite *RedisStoreTestSuite) saveSession(values map[any]any) (string, *http.Cookie) {
+	session, err := suite.store.New(httptest.NewRequest(http.MethodGet, "/", nil), "gotosocial-localhost")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(session.IsNew)
+
+	for k, v := range values {
+		session.Values[k] = v
+	}
+
+	recorder := httptest.NewRecorder()
+	if err := session.Save(httptest.NewRequest(http.MethodGet, "/", nil), recorder); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	cookies := recorder.Result().Cookies()
+	if !suite.Len(cookies, 1) {
+		suite.FailNow("no session cookie")
+	}
+
+	return session.ID, cookies[0]
+}
+
+// loadSession loads the session for the given cookie.
+func (suite *RedisStoreTestSuite) loadSession(cookie *http.Cookie) (map[any]any, bool, error) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(cookie)
+
+	session, err := suite.store.New(r, "gotosocial-localhost")
+	if err != nil {
+		return nil, false, err
+	}
+
+	return session.Values, session.IsNew, nil
+}
+
+func (suite *RedisStoreTestSuite) TestSaveAndLoad() {
+	id, cookie := suite.saveSession(map[any]any{
+		"userid":       "01F8MH1H7YV1Z7D2C8K2730QBF",
+		"force_login":  "true",
+		"challenge_at": int64(1692614400),
+		"mfa_verified": true,
+	})
+
+	// Each value should be kept in its
+	// own field of the session's hash.
+	key := "gotosocial:session:" + id
+	suite.Equal(map[string]string{
+		"userid":       "s01F8MH1H7YV1Z7D2C8K2730QBF",
+		"force_login":  "strue",
+		"challenge_at": "i1692614400",
+		"mfa_verified": "btrue",
+	}, suite.redis.hashes[key])
+
+	// The hash should expire along with the session.
+	suite.Equal(120, suite.redis.ttls[key])
+
+	// The cookie only holds the ID, encrypted.
+	suite.NotContains(cookie.Value, id)
+	suite.Equal(120, cookie.MaxAge)
+
+	values, isNew, err := suite.loadSession(cookie)
+	suite.NoError(err)
+	suite.False(isNew)
+	suite.Equal(map[any]any{
+		"userid":       "01F8MH1H7YV1Z7D2C8K2730QBF",
+		"force_login":  "true",
+		"challenge_at": int64(1692614400),
+		"mfa_verified": true,
+	}, values)
+}
+
+func (suite *RedisStoreTestSuite) TestSaveRemovesDeletedValues() {
+	id, cookie := suite.saveSession(map[any]any{
+		"userid": "01F8MH1H7YV1Z7D2C8K2730QBF",
+		"claims": "{}",
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(cookie)
+	session, err := suite.store.New(r, "gotosocial-localhost")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	delete(session.Values, "claims")
+	if err := session.Save(r, httptest.NewRecorder()); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	key := "gotosocial:session:" + id
+	suite.Equal(map[string]string{
+		"userid": "s01F8MH1H7YV1Z7D2C8K2730QBF",
+	}, suite.redis.hashes[key])
+	suite.Equal(120, suite.redis.ttls[key])
+}
+
+func (suite *RedisStoreTestSuite) TestLoadExpired() {
+	id, cookie := suite.saveSession(map[any]any{
+		"userid": "01F8MH1H7YV1Z7D2C8K2730QBF",
+	})
+
+	// Redis expires the key.
+	delete(suite.redis.hashes, "gotosocial:session:"+id)
+
+	values, isNew, err := suite.loadSession(cookie)
+	suite.NoError(err)
+	suite.True(isNew)
+	suite.Empty(values)
+}
+
+func (suite *RedisStoreTestSuite) TestLoadTamperedCookie() {
+	_, cookie := suite.saveSession(map[any]any{
+		"userid": "01F8MH1H7YV1Z7D2C8K2730QBF",
+	})
+
+	cookie.Value = strings.ToUpper(cookie.Value)
+
+	_, _, err := suite.loadSession(cookie)
+	suite.ErrorContains(err, "securecookie")
+}
+
+func (suite *RedisStoreTestSuite) TestLoadBadField() {
+	id, cookie := suite.saveSession(map[any]any{
+		"userid": "01F8MH1H7YV1Z7D2C8K2730QBF",
+	})
+
+	suite.redis.hashes["gotosocial:session:"+id]["userid"] = "x01F8MH1H7YV1Z7D2C8K2730QBF"
+
+	_, _, err := suite.loadSession(cookie)
+	suite.EqualError(err, `error decoding session value userid: unknown type 'x'`)
+}
+
+func (suite *RedisStoreTestSuite) TestDelete() {
+	id, cookie := suite.saveSession(map[any]any{
+		"userid": "01F8MH1H7YV1Z7D2C8K2730QBF",
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(cookie)
+	session, err := suite.store.New(r, "gotosocial-localhost")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	session.Options.MaxAge = -1
+	recorder := httptest.NewRecorder()
+	if err := session.Save(r, recorder); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotContains(suite.redis.hashes, "gotosocial:session:"+id)
+	suite.Empty(session.Values)
+
+	cookies := recorder.Result().Cookies()
+	if suite.Len(cookies, 1) {
+		suite.Empty(cookies[0].Value)
+	}
+}
+
+func (suite *RedisStoreTestSuite) TestSaveUnsupportedValue() {
+	session, err := suite.store.New(httptest.NewRequest(http.MethodGet, "/", nil), "gotosocial-localhost")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	session.Values["claims"] = struct{ Sub string }{Sub: "foo"}
+
+	err = session.Save(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	suite.EqualError(err, "error encoding session value claims: unsupported type struct { Sub string }")
+	suite.Empty(suite.redis.hashes)
+}
+
+func (suite *RedisStoreTestSuite) TestNewRedisStoreUnreachable() {
+	pool := &redigo.Pool{
+		Dial: func() (redigo.Conn, error) {
+			return nil, errors.New("dial tcp 127.0.0.1:6379: connect: connection refused")
+		},
+	}
+
+	_, err := newRedisStore(pool, []byte("01234567890123456789012345678901"))
+	suite.EqualError(err, "error pinging redis: dial tcp 127.0.0.1:6379: connect: connection refused")
+}
+
+func TestRedisStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisStoreTestSuite))
+}
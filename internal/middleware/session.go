@@ -18,6 +18,7 @@
 package middleware
 
 import (
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -87,8 +88,35 @@ func SessionName() (string, error) {
 // Session returns a new gin middleware that implements session cookies using the given
 // sessionName, authentication key, and encryption key. Session name can be derived from the
 // SessionName utility function in this package.
-func Session(sessionName string, auth []byte, crypt []byte) gin.HandlerFunc {
-	store := memstore.NewStore(auth, crypt)
-	store.Options(SessionOptions())
-	return sessions.Sessions(sessionName, store)
+//
+// If session-encryption-key is set, it's used in place of the given encryption key.
+//
+// Session values are kept in memory, or in Redis if session-backend is set to 'redis'.
+func Session(sessionName string, auth []byte, crypt []byte) (gin.HandlerFunc, error) {
+	var (
+		store   sessions.Store
+		options = SessionOptions()
+	)
+
+	if key := config.GetSessionEncryptionKey(); key != "" {
+		var err error
+		crypt, err = hex.DecodeString(key)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding %s: %w", config.SessionEncryptionKeyFlag(), err)
+		}
+	}
+
+	switch config.GetSessionBackend() {
+	case "redis":
+		redisStore, err := newRedisStore(newRedisPool(), auth, crypt)
+		if err != nil {
+			return nil, fmt.Errorf("error creating redis session store: %w", err)
+		}
+		store = redisStore
+	default:
+		store = memstore.NewStore(auth, crypt)
+	}
+
+	store.Options(options)
+	return sessions.Sessions(sessionName, store), nil
 }
@@ -89,6 +89,19 @@ func (suite *SessionTestSuite) TestDeriveSessionIDNOK() {
 	suite.Equal("gotosocial-xn--fid-gna.org", sessionName)
 }
 
+func (suite *SessionTestSuite) TestSessionEncryptionKey() {
+	auth := []byte("01234567890123456789012345678901")
+	crypt := []byte("98765432109876543210987654321098")
+
+	config.SetSessionEncryptionKey("3f4d1c0b7a9e8d2c5b6a7f8e9d0c1b2a3f4d1c0b7a9e8d2c5b6a7f8e9d0c1b2a")
+	_, err := middleware.Session("gotosocial-localhost", auth, crypt)
+	suite.NoError(err)
+
+	config.SetSessionEncryptionKey("not hex")
+	_, err = middleware.Session("gotosocial-localhost", auth, crypt)
+	suite.EqualError(err, "error decoding session-encryption-key: encoding/hex: invalid byte: U+006E 'n'")
+}
+
 func TestSessionTestSuite(t *testing.T) {
 	suite.Run(t, &SessionTestSuite{})
 }
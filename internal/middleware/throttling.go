@@ -32,6 +32,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/errorcodes"
 )
 
 // token represents a request that is being processed.
@@ -127,7 +128,10 @@ func Throttle(cpuMultiplier int, retryAfter time.Duration) gin.HandlerFunc {
 		default:
 			// we don't have space in the backlog queue
 			c.Header("Retry-After", retryAfterStr)
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": "server capacity exceeded"})
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error":      "server capacity exceeded",
+				"error_code": errorcodes.ServerBusy,
+			})
 			c.Abort()
 		}
 	}
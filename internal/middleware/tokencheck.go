@@ -18,6 +18,7 @@
 package middleware
 
 import (
+	"net"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -43,6 +44,9 @@ import (
 // Next, it will look up the *gtsmodel.Account for the User. If the Account has been suspended, then the
 // middleware will return early. Otherwise, it will set the Account on the gin context too.
 //
+// It will also update the last seen time and IP of the web session through which
+// the token was created, if any, at most once every few minutes per token.
+//
 // Finally, it will check the client ID of the token to see if a *gtsmodel.Application can be retrieved
 // for that client ID. This will also be set on the gin context.
 //
@@ -50,6 +54,8 @@ import (
 // won't abort the request, since the server might want to still allow public requests that don't have a
 // Bearer token set (eg., for public instance information and so on).
 func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.TokenInfo, error)) func(*gin.Context) {
+	webSessions := newWebSessionSeen(dbConn)
+
 	return func(c *gin.Context) {
 		// Acquire context from gin request.
 		ctx := c.Request.Context()
@@ -118,6 +124,8 @@ func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.
 			}
 
 			c.Set(oauth.SessionAuthorizedAccount, user.Account)
+
+			webSessions.Seen(ctx, ti.GetAccess(), net.ParseIP(c.ClientIP()))
 		}
 
 		// check for application token
@@ -22,6 +22,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/errorcodes"
 )
 
 // UserAgent returns a gin middleware which aborts requests with
@@ -32,7 +33,10 @@ func UserAgent() gin.HandlerFunc {
 		if ua := c.Request.UserAgent(); ua == "" {
 			code := http.StatusTeapot
 			err := errors.New(http.StatusText(code) + ": no user-agent sent with request")
-			c.AbortWithStatusJSON(code, gin.H{"error": err.Error()})
+			c.AbortWithStatusJSON(code, gin.H{
+				"error":      err.Error(),
+				"error_code": errorcodes.UserAgentRequired,
+			})
 		}
 	}
 }
@@ -0,0 +1,121 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"codeberg.org/gruf/go-cache/v3/ttl"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// webSessionSeenInterval is the minimum interval between
+// updates of the last seen time and IP of a web session
+// when one of its tokens is used.
+const webSessionSeenInterval = 5 * time.Minute
+
+// webSessionSeen tracks when and from where the tokens created
+// through web sessions are used, throttled per token so that
+// this doesn't result in a database write for every request.
+type webSessionSeen struct {
+	dbConn db.DB
+
+	// Access tokens seen within the last webSessionSeenInterval.
+	recent *ttl.Cache[string, struct{}]
+}
+
+func newWebSessionSeen(dbConn db.DB) *webSessionSeen {
+	recent := ttl.New[string, struct{}](0, 10000, webSessionSeenInterval)
+	recent.Start(time.Minute)
+
+	return &webSessionSeen{
+		dbConn: dbConn,
+		recent: recent,
+	}
+}
+
+// Seen records that the given access token was used from the given IP,
+// updating the web session it was created through, if any.
+func (w *webSessionSeen) Seen(ctx context.Context, access string, ip net.IP) {
+	if !w.recent.Add(access, struct{}{}) {
+		// Seen recently enough.
+		return
+	}
+
+	sessionID, err := w.dbConn.GetWebSessionIDByAccessToken(ctx, access)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			log.Errorf(ctx, "database error looking for web session of token: %s", err)
+		}
+		return
+	}
+
+	if sessionID == "" {
+		// Token not created through a web session.
+		return
+	}
+
+	session, err := w.dbConn.GetWebSessionByID(ctx, sessionID)
+	if err != nil {
+		log.Errorf(ctx, "database error looking for web session %s: %s", sessionID, err)
+		return
+	}
+
+	session.LastSeenAt = time.Now()
+	session.LastSeenIP = ip
+	columns := []string{"last_seen_at", "last_seen_ip"}
+
+	// Once flagged, a session stays flagged.
+	if (session.IPChanged == nil || !*session.IPChanged) && ipNetworkChanged(session.IP, ip) {
+		changed := true
+		session.IPChanged = &changed
+		columns = append(columns, "ip_changed")
+	}
+
+	if err := w.dbConn.UpdateWebSession(ctx, session, columns...); err != nil {
+		log.Errorf(ctx, "database error updating web session %s: %s", sessionID, err)
+	}
+}
+
+// ipNetworkChanged returns true if the given IPs are in different networks,
+// taken to be /16 for IPv4 and /32 for IPv6. This is a rough approximation
+// of the addresses belonging to different providers or regions; it doesn't
+// involve any geolocation, so it can't tell how far apart the networks are.
+func ipNetworkChanged(before net.IP, after net.IP) bool {
+	if before == nil || after == nil {
+		return false
+	}
+
+	if before4, after4 := before.To4(), after.To4(); before4 != nil || after4 != nil {
+		if before4 == nil || after4 == nil {
+			// Switched between IPv4 and
+			// IPv6, eg., from wifi to
+			// mobile data; not unusual.
+			return false
+		}
+
+		mask := net.CIDRMask(16, 32)
+		return !before4.Mask(mask).Equal(after4.Mask(mask))
+	}
+
+	mask := net.CIDRMask(32, 128)
+	return !before.Mask(mask).Equal(after.Mask(mask))
+}
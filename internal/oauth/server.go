@@ -25,7 +25,9 @@ import (
 	"strings"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/oauth2/v4"
 	oautherr "github.com/superseriousbusiness/oauth2/v4/errors"
@@ -72,6 +74,7 @@ type Server interface {
 // s fulfils the Server interface using the underlying oauth2 server
 type s struct {
 	server *server.Server
+	db     db.Basic
 }
 
 // New returns a new oauth server that implements the Server interface
@@ -122,6 +125,7 @@ func New(ctx context.Context, database db.Basic) Server {
 	srv.SetClientInfoHandler(server.ClientFormHandler)
 	return &s{
 		server: srv,
+		db:     database,
 	}
 }
 
@@ -139,6 +143,15 @@ func (s *s) HandleTokenRequest(r *http.Request) (map[string]interface{}, gtserro
 		return nil, gtserror.NewErrorBadRequest(err, help, adv)
 	}
 
+	if gt == oauth2.AuthorizationCode && tgr.Code != "" {
+		// The access token should be linked to the same web
+		// session as the code it's being exchanged for, if any.
+		code := &gtsmodel.Token{}
+		if err := s.db.GetWhere(ctx, []db.Where{{Key: "code", Value: tgr.Code}}, code); err == nil && code.WebSessionID != "" {
+			ctx = gtscontext.SetWebSessionID(ctx, code.WebSessionID)
+		}
+	}
+
 	ti, err := s.server.GetAccessToken(ctx, gt, tgr)
 	if err != nil {
 		help := fmt.Sprintf("could not get access token: %s", err)
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -99,6 +100,11 @@ func (ts *tokenStore) Create(ctx context.Context, info oauth2.TokenInfo) error {
 	}
 
 	dbt := TokenToDBToken(t)
+
+	// Link the token to the web session it's
+	// being created through, if there is one.
+	dbt.WebSessionID = gtscontext.WebSessionID(ctx)
+
 	if dbt.ID == "" {
 		dbtID, err := id.NewRandomULID()
 		if err != nil {
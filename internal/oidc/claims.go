@@ -17,7 +17,10 @@
 
 package oidc
 
-import "encoding/gob"
+import (
+	"encoding/gob"
+	"strings"
+)
 
 // Claims represents claims as found in an id_token returned from an OIDC flow.
 type Claims struct {
@@ -32,3 +35,41 @@ type Claims struct {
 func init() {
 	gob.Register(&Claims{})
 }
+
+// InGroups returns true if the claimed groups contain
+// any of the given groups, compared case-insensitively.
+func (c *Claims) InGroups(groups []string) bool {
+	for _, g := range c.Groups {
+		for _, og := range groups {
+			if strings.EqualFold(g, og) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// setFromRaw sets Groups and PreferredUsername from the
+// given raw claims map, using the given claim names.
+func (c *Claims) setFromRaw(raw map[string]any, groupsClaim string, usernameClaim string) {
+	switch groups := raw[groupsClaim].(type) {
+	case []any:
+		// Array of groups, only
+		// keep the string values.
+		c.Groups = c.Groups[:0]
+		for _, g := range groups {
+			if g, ok := g.(string); ok {
+				c.Groups = append(c.Groups, g)
+			}
+		}
+	case string:
+		// Some providers send
+		// just one group as string.
+		c.Groups = []string{groups}
+	default:
+		c.Groups = nil
+	}
+
+	username, _ := raw[usernameClaim].(string)
+	c.PreferredUsername = username
+}
@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oidc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ClaimsTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ClaimsTestSuite) TestInGroups() {
+	claims := &Claims{Groups: []string{"Members", "mods"}}
+
+	suite.True(claims.InGroups([]string{"members"}))
+	suite.True(claims.InGroups([]string{"admins", "MODS"}))
+	suite.False(claims.InGroups([]string{"admins"}))
+	suite.False(claims.InGroups(nil))
+}
+
+func (suite *ClaimsTestSuite) TestSetFromRaw() {
+	for _, test := range []struct {
+		raw              string
+		expectedGroups   []string
+		expectedUsername string
+	}{
+		{
+			raw:              `{"roles":["admins","members",1],"nickname":"someone"}`,
+			expectedGroups:   []string{"admins", "members"},
+			expectedUsername: "someone",
+		},
+		{
+			raw:              `{"roles":"admins","nickname":"someone"}`,
+			expectedGroups:   []string{"admins"},
+			expectedUsername: "someone",
+		},
+		{
+			raw:              `{"groups":["admins"],"preferred_username":"someone"}`,
+			expectedGroups:   nil,
+			expectedUsername: "",
+		},
+	} {
+		raw := make(map[string]any)
+		if err := json.Unmarshal([]byte(test.raw), &raw); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		claims := &Claims{}
+		claims.setFromRaw(raw, "roles", "nickname")
+		suite.Equal(test.expectedGroups, claims.Groups)
+		suite.Equal(test.expectedUsername, claims.PreferredUsername)
+	}
+}
+
+func TestClaimsTestSuite(t *testing.T) {
+	suite.Run(t, new(ClaimsTestSuite))
+}
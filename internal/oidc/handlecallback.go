@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
@@ -63,6 +64,19 @@ func (i *idp) HandleCallback(ctx context.Context, code string) (*Claims, gtserro
 		return nil, gtserror.NewErrorInternalError(err, err.Error())
 	}
 
+	// If groups and/or username should come from
+	// nonstandard claims, parse these separately.
+	groupsClaim := config.GetOIDCGroupsClaim()
+	usernameClaim := config.GetOIDCUsernameClaim()
+	if groupsClaim != "groups" || usernameClaim != "preferred_username" {
+		raw := make(map[string]any)
+		if err := idToken.Claims(&raw); err != nil {
+			err := fmt.Errorf("could not parse raw claims from idToken: %s", err)
+			return nil, gtserror.NewErrorInternalError(err, err.Error())
+		}
+		claims.setFromRaw(raw, groupsClaim, usernameClaim)
+	}
+
 	return claims, nil
 }
 
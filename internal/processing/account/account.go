@@ -18,6 +18,7 @@
 package account
 
 import (
+	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
@@ -40,6 +41,7 @@ type Processor struct {
 	formatter    text.Formatter
 	federator    federation.Federator
 	parseMention gtsmodel.ParseMentionFunc
+	emailSender  email.Sender
 }
 
 // New returns a new account processor.
@@ -51,6 +53,7 @@ func New(
 	federator federation.Federator,
 	filter *visibility.Filter,
 	parseMention gtsmodel.ParseMentionFunc,
+	emailSender email.Sender,
 ) Processor {
 	return Processor{
 		state:        state,
@@ -61,5 +64,6 @@ func New(
 		formatter:    text.NewFormatter(state.DB),
 		federator:    federator,
 		parseMention: parseMention,
+		emailSender:  emailSender,
 	}
 }
@@ -0,0 +1,84 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// Bite handles requestingAccount biting targetAccountID, either remote
+// or local. Bites aren't stored; the bitten account just gets a
+// notification, either directly or once the bite federates to it.
+func (p *Processor) Bite(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
+	// Account should not bite itself.
+	if requestingAccount.ID == targetAccountID {
+		err := gtserror.Newf("account %s cannot bite itself", requestingAccount.ID)
+		return nil, gtserror.NewErrorNotAcceptable(err, "you cannot bite yourself")
+	}
+
+	targetAccount, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error looking for target account %s: %w", targetAccountID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		err = gtserror.Newf("target account %s not found in the db", targetAccountID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	blocked, err := p.state.DB.IsEitherBlocked(ctx, requestingAccount.ID, targetAccountID)
+	if err != nil {
+		err = gtserror.Newf("db error checking block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if blocked {
+		err = gtserror.Newf("block exists between accounts %s and %s", requestingAccount.ID, targetAccountID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	biteID := id.NewULID()
+	bite := &gtsmodel.Bite{
+		URI:             uris.GenerateURIForBite(requestingAccount.Username, biteID),
+		AccountID:       requestingAccount.ID,
+		Account:         requestingAccount,
+		TargetAccountID: targetAccountID,
+		TargetAccount:   targetAccount,
+	}
+
+	// Process side effects (notification, federation).
+	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ActivityBite,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       bite,
+		OriginAccount:  requestingAccount,
+		TargetAccount:  targetAccount,
+	})
+
+	return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
+}
@@ -25,17 +25,28 @@ import (
 	"time"
 
 	"codeberg.org/gruf/go-kv"
+	"codeberg.org/gruf/go-runners"
+	"codeberg.org/gruf/go-sched"
 	"github.com/google/uuid"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"golang.org/x/crypto/bcrypt"
 )
 
-const deleteSelectLimit = 50
+const (
+	deleteSelectLimit = 50
+
+	// deleteDuePollInterval is how often to check
+	// for scheduled self-deletions which are due.
+	deleteDuePollInterval = time.Hour
+)
 
 // Delete deletes an account, and all of that account's statuses, media, follows, notifications, etc etc etc.
 // The origin passed here should be either the ID of the account doing the delete (can be itself), or the ID of a domain block.
@@ -81,6 +92,19 @@ func (p *Processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 		return gtserror.NewErrorInternalError(err)
 	}
 
+	if account.IsLocal() {
+		// Leave a tombstone behind, so that remote
+		// instances are told the account is gone
+		// for good, and stop delivering to it.
+		if err := p.state.DB.PutTombstone(ctx, &gtsmodel.Tombstone{
+			ID:     id.NewULID(),
+			Domain: config.GetHost(),
+			URI:    account.URI,
+		}); err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
 	l.Info("account deleted")
 	return nil
 }
@@ -105,8 +129,130 @@ func (p *Processor) DeleteSelf(ctx context.Context, account *gtsmodel.Account) g
 	return nil
 }
 
-// deleteUserAndTokensForAccount deletes the gtsmodel.User and
-// any OAuth tokens and applications for the given account.
+// ScheduleDeleteSelf handles a request by a local user to delete their own account.
+//
+// The account is hidden straight away, and nothing new is federated out on its behalf,
+// but it is only deleted (via DeleteSelf) once accounts-self-delete-delay has passed.
+// Until then, the user can cancel the deletion with CancelDeleteSelf, for example if
+// someone else requested it using a compromised session. The user is emailed to let
+// them know when the account will be deleted, and how to cancel.
+func (p *Processor) ScheduleDeleteSelf(ctx context.Context, user *gtsmodel.User, account *gtsmodel.Account) gtserror.WithCode {
+	delay := config.GetAccountsSelfDeleteDelay()
+	if delay <= 0 {
+		// No grace period, delete right away.
+		return p.DeleteSelf(ctx, account)
+	}
+
+	if !user.DeleteAt.IsZero() {
+		// Already scheduled, keep
+		// the original deletion time.
+		return nil
+	}
+
+	user.DeleteAt = time.Now().Add(delay)
+	if err := p.state.DB.UpdateUser(ctx, user, "delete_at"); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("db error updating user %s: %w", user.ID, err))
+	}
+
+	// Cached status visibilities aren't keyed by
+	// the account, so the whole cache has to go.
+	p.state.Caches.Visibility.Clear()
+
+	if err := p.emailDeleteScheduled(ctx, user, account); err != nil {
+		log.Errorf(ctx, "error emailing scheduled deletion of %s: %v", account.Username, err)
+	}
+
+	return nil
+}
+
+// CancelDeleteSelf cancels the pending deletion of the given
+// user's account, if any, making the account visible again.
+func (p *Processor) CancelDeleteSelf(ctx context.Context, user *gtsmodel.User) gtserror.WithCode {
+	if user.DeleteAt.IsZero() {
+		err := errors.New("no account deletion is pending")
+		return gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	user.DeleteAt = time.Time{}
+	if err := p.state.DB.UpdateUser(ctx, user, "delete_at"); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("db error updating user %s: %w", user.ID, err))
+	}
+
+	// See ScheduleDeleteSelf.
+	p.state.Caches.Visibility.Clear()
+
+	return nil
+}
+
+// DeleteDue deletes the accounts of all local
+// users whose scheduled self-deletion is now due.
+func (p *Processor) DeleteDue(ctx context.Context) {
+	users, err := p.state.DB.GetUsersDeleteDue(ctx, time.Now())
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting users due deletion: %v", err)
+		}
+		return
+	}
+
+	for _, user := range users {
+		if ctx.Err() != nil {
+			// Shutting down.
+			return
+		}
+
+		if user.Account == nil {
+			log.Warnf(ctx, "user %s due deletion had no associated account", user.ID)
+			continue
+		}
+
+		if errWithCode := p.DeleteSelf(ctx, user.Account); errWithCode != nil {
+			log.Errorf(ctx, "error deleting account %s: %v", user.Account.Username, errWithCode)
+		}
+	}
+}
+
+// ScheduleDeleteDue schedules regular runs of DeleteDue, including
+// straight away, for any deletions that fell due during downtime.
+// It should be called once at startup, after workers are started.
+func (p *Processor) ScheduleDeleteDue() {
+	p.state.Workers.Scheduler.Schedule(sched.NewJob(func(time.Time) {
+		p.DeleteDue(runners.CancelCtx(p.state.Workers.Scheduler.Done()))
+	}).EveryAt(time.Now(), deleteDuePollInterval))
+}
+
+// emailDeleteScheduled lets the given user know that their account is going
+// to be deleted, and how to cancel, provided email sending is configured, and
+// the user has a confirmed email address for us to send it to.
+func (p *Processor) emailDeleteScheduled(ctx context.Context, user *gtsmodel.User, account *gtsmodel.Account) error {
+	if config.GetSMTPHost() == "" {
+		// Email sending not configured.
+		return nil
+	}
+
+	if user.ConfirmedAt.IsZero() || user.Email == "" {
+		// No verified email address.
+		return nil
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	accountDeleteScheduledData := email.AccountDeleteScheduledData{
+		Username:     account.Username,
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+		DeleteAt:     user.DeleteAt.UTC().Format(time.RFC1123),
+		CancelURL:    instance.URI + "/settings/user/settings",
+	}
+
+	return p.emailSender.SendAccountDeleteScheduledEmail(user.Email, accountDeleteScheduledData)
+}
+
+// deleteUserAndTokensForAccount deletes the gtsmodel.User, and any
+// OAuth tokens, applications, passkeys and web sessions for the given account.
 //
 // Callers to this function should already have checked that
 // this is a local account, or else it won't have a user associated
@@ -139,6 +285,17 @@ func (p *Processor) deleteUserAndTokensForAccount(ctx context.Context, account *
 		}
 	}
 
+	// Delete any passkeys of the user, so
+	// they can't be used to sign in anymore.
+	if err := p.state.DB.DeleteWebAuthnCredentialsByUserID(ctx, user.ID); err != nil {
+		return fmt.Errorf("deleteUserAndTokensForAccount: db error deleting webauthn credentials: %w", err)
+	}
+
+	// Delete any web sessions of the user.
+	if err := p.state.DB.DeleteWebSessionsByUserID(ctx, user.ID); err != nil {
+		return fmt.Errorf("deleteUserAndTokensForAccount: db error deleting web sessions: %w", err)
+	}
+
 	columns, err := stubbifyUser(user)
 	if err != nil {
 		return fmt.Errorf("deleteUserAndTokensForAccount: error stubbifying user: %w", err)
@@ -157,16 +314,34 @@ func (p *Processor) deleteUserAndTokensForAccount(ctx context.Context, account *
 //   - Follows created by account.
 //   - Follow requests created by account.
 func (p *Processor) deleteAccountFollows(ctx context.Context, account *gtsmodel.Account) error {
+	var (
+		// Use this slice to batch reject + unfollow messages.
+		msgs = []messages.FromClientAPI{}
+		// To avoid checking if account is local over + over
+		// inside the subsequent loops, just generate static
+		// side effects functions once now.
+		rejectSideEffects   = p.rejectSideEffectsFunc(account)
+		unfollowSideEffects = p.unfollowSideEffectsFunc(account)
+	)
+
 	// Delete follows targeting this account.
 	followedBy, err := p.state.DB.GetAccountFollowers(ctx, account.ID)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		return fmt.Errorf("deleteAccountFollows: db error getting follows targeting account %s: %w", account.ID, err)
 	}
 
+	// For each follow targeting this account, delete
+	// it and reject it retroactively, so that remote
+	// followers' instances don't have to wait for the
+	// account delete to update their relationship.
 	for _, follow := range followedBy {
 		if err := p.state.DB.DeleteFollowByID(ctx, follow.ID); err != nil {
 			return fmt.Errorf("deleteAccountFollows: db error unfollowing account followedBy: %w", err)
 		}
+		if msg := rejectSideEffects(ctx, account, follow); msg != nil {
+			// There was a side effect to process.
+			msgs = append(msgs, *msg)
+		}
 	}
 
 	// Delete follow requests targeting this account.
@@ -175,20 +350,29 @@ func (p *Processor) deleteAccountFollows(ctx context.Context, account *gtsmodel.
 		return fmt.Errorf("deleteAccountFollows: db error getting follow requests targeting account %s: %w", account.ID, err)
 	}
 
+	// For each follow request targeting this
+	// account, delete it and reject it.
 	for _, followRequest := range followRequestedBy {
 		if err := p.state.DB.DeleteFollowRequestByID(ctx, followRequest.ID); err != nil {
 			return fmt.Errorf("deleteAccountFollows: db error unfollowing account followRequestedBy: %w", err)
 		}
-	}
 
-	var (
-		// Use this slice to batch unfollow messages.
-		msgs = []messages.FromClientAPI{}
-		// To avoid checking if account is local over + over
-		// inside the subsequent loops, just generate static
-		// side effects function once now.
-		unfollowSideEffects = p.unfollowSideEffectsFunc(account)
-	)
+		// Dummy out a follow so our side effects func
+		// has something to work with. This follow will
+		// never enter the db, it's just for convenience.
+		follow := &gtsmodel.Follow{
+			URI:             followRequest.URI,
+			AccountID:       followRequest.AccountID,
+			Account:         followRequest.Account,
+			TargetAccountID: followRequest.TargetAccountID,
+			TargetAccount:   followRequest.TargetAccount,
+		}
+
+		if msg := rejectSideEffects(ctx, account, follow); msg != nil {
+			// There was a side effect to process.
+			msgs = append(msgs, *msg)
+		}
+	}
 
 	// Delete follows originating from this account.
 	following, err := p.state.DB.GetAccountFollows(ctx, account.ID)
@@ -277,6 +461,39 @@ func (p *Processor) unfollowSideEffectsFunc(deletedAccount *gtsmodel.Account) fu
 	}
 }
 
+func (p *Processor) rejectSideEffectsFunc(deletedAccount *gtsmodel.Account) func(ctx context.Context, account *gtsmodel.Account, follow *gtsmodel.Follow) *messages.FromClientAPI {
+	if !deletedAccount.IsLocal() {
+		// Don't try to process side effects
+		// for accounts that aren't local.
+		return func(ctx context.Context, account *gtsmodel.Account, follow *gtsmodel.Follow) *messages.FromClientAPI {
+			return nil // noop
+		}
+	}
+
+	return func(ctx context.Context, account *gtsmodel.Account, follow *gtsmodel.Follow) *messages.FromClientAPI {
+		if follow.Account == nil {
+			// Account seems to have gone;
+			// race condition? db corruption?
+			log.WithContext(ctx).WithField("follow", follow).Warn("follow had no Account, likely race condition")
+			return nil
+		}
+
+		if follow.Account.IsLocal() {
+			// No side effects for local follows.
+			return nil
+		}
+
+		// There was a follow, process side effects.
+		return &messages.FromClientAPI{
+			APObjectType:   ap.ActivityFollow,
+			APActivityType: ap.ActivityReject,
+			GTSModel:       follow,
+			OriginAccount:  follow.Account,
+			TargetAccount:  account,
+		}
+	}
+}
+
 func (p *Processor) deleteAccountBlocks(ctx context.Context, account *gtsmodel.Account) error {
 	if err := p.state.DB.DeleteAccountBlocks(ctx, account.ID); err != nil {
 		return fmt.Errorf("deleteAccountBlocks: db error deleting account blocks for %s: %w", account.ID, err)
@@ -305,7 +522,7 @@ func (p *Processor) deleteAccountStatuses(ctx context.Context, account *gtsmodel
 statusLoop:
 	for {
 		// Page through account's statuses.
-		statuses, err = p.state.DB.GetAccountStatuses(ctx, account.ID, deleteSelectLimit, false, false, maxID, "", false, false)
+		statuses, err = p.state.DB.GetAccountStatuses(ctx, account.ID, deleteSelectLimit, false, false, maxID, "", false, false, false, false)
 		if err != nil && !errors.Is(err, db.ErrNoEntries) {
 			// Make sure we don't have a real error.
 			return err
@@ -411,6 +628,35 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return err
 	}
 
+	// Delete all dislikes owned by or targeting given account.
+	if err := p.state.DB.DeleteStatusDislikes(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Delete all hashtags featured by given account.
+	if err := p.state.DB.DeleteAccountFeaturedTags(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Delete all drafts owned by given account.
+	if err := p.state.DB.DeleteAccountDrafts(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Delete all listens by given account.
+	if err := p.state.DB.DeleteAccountListens(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Delete all personal emojis owned by given account.
+	if err := p.deletePersonalEmojis(ctx, account); err != nil {
+		return err
+	}
+
 	// TODO: add status mutes here when they're implemented.
 
 	return nil
@@ -24,6 +24,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
@@ -97,6 +100,127 @@ func (suite *AccountDeleteTestSuite) TestAccountDeleteLocal() {
 	suite.Zero(updatedUser.ResetPasswordSentAt)
 }
 
+func (suite *AccountDeleteTestSuite) TestAccountDeleteLocalRejectsRemoteFollows() {
+	ctx := context.Background()
+
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+	remoteFollower := suite.testAccounts["remote_account_1"]
+	remoteRequester := suite.testAccounts["remote_account_2"]
+
+	// Remote account 1 follows local account 1,
+	// and remote account 2 has requested to.
+	follow := &gtsmodel.Follow{
+		ID:              "01H7E9H3D0F2GM8VSYYBVZ8W9P",
+		URI:             "http://fossbros-anonymous.io/users/foss_satan/follow/01H7E9H3D0F2GM8VSYYBVZ8W9P",
+		AccountID:       remoteFollower.ID,
+		TargetAccountID: testAccount.ID,
+	}
+	if err := suite.db.PutFollow(ctx, follow); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	followRequest := &gtsmodel.FollowRequest{
+		ID:              "01H7E9HB6CMSQ3T8C6PQQ4B1QJ",
+		URI:             "http://example.org/users/Some_User/follow/01H7E9HB6CMSQ3T8C6PQQ4B1QJ",
+		AccountID:       remoteRequester.ID,
+		TargetAccountID: testAccount.ID,
+	}
+	if err := suite.db.PutFollowRequest(ctx, followRequest); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.accountProcessor.Delete(ctx, testAccount, testAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Both should be gone from the db.
+	_, err := suite.db.GetFollowByID(ctx, follow.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	_, err = suite.db.GetFollowRequestByID(ctx, followRequest.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// And a reject should have been queued for each,
+	// from the deleted account to the remote account.
+	rejected := map[string]string{}
+	for len(suite.fromClientAPIChan) > 0 {
+		msg := <-suite.fromClientAPIChan
+		if msg.APActivityType != ap.ActivityReject {
+			continue
+		}
+
+		suite.Equal(ap.ActivityFollow, msg.APObjectType)
+		suite.Equal(testAccount.ID, msg.TargetAccount.ID)
+		rejected[msg.GTSModel.(*gtsmodel.Follow).URI] = msg.OriginAccount.ID
+	}
+
+	suite.Equal(map[string]string{
+		follow.URI:        remoteFollower.ID,
+		followRequest.URI: remoteRequester.ID,
+	}, rejected)
+}
+
+func (suite *AccountDeleteTestSuite) TestAccountScheduleDeleteSelf() {
+	ctx := context.Background()
+	config.SetSMTPHost("smtp.example.org")
+
+	testUser := &gtsmodel.User{}
+	*testUser = *suite.testUsers["local_account_1"]
+	testAccount := suite.testAccounts["local_account_1"]
+
+	if errWithCode := suite.accountProcessor.ScheduleDeleteSelf(ctx, testUser, testAccount); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Deletion should be scheduled, not started.
+	dbUser, err := suite.db.GetUserByID(ctx, testUser.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.WithinDuration(time.Now().Add(config.GetAccountsSelfDeleteDelay()), dbUser.DeleteAt, time.Minute)
+	suite.Empty(suite.fromClientAPIChan)
+
+	// The user should have been told how to cancel.
+	suite.Contains(suite.sentEmails[testUser.Email], "/settings")
+
+	// Not due yet, so nothing should happen.
+	suite.accountProcessor.DeleteDue(ctx)
+	suite.Empty(suite.fromClientAPIChan)
+
+	// Cancel and make sure it's gone.
+	if errWithCode := suite.accountProcessor.CancelDeleteSelf(ctx, testUser); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	dbUser, err = suite.db.GetUserByID(ctx, testUser.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(dbUser.DeleteAt)
+}
+
+func (suite *AccountDeleteTestSuite) TestAccountDeleteDue() {
+	ctx := context.Background()
+
+	testUser := &gtsmodel.User{}
+	*testUser = *suite.testUsers["local_account_1"]
+	testUser.DeleteAt = time.Now().Add(-time.Minute)
+
+	if err := suite.db.UpdateUser(ctx, testUser, "delete_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.accountProcessor.DeleteDue(ctx)
+
+	select {
+	case msg := <-suite.fromClientAPIChan:
+		suite.Equal(ap.ActivityDelete, msg.APActivityType)
+		suite.Equal(testUser.AccountID, msg.TargetAccount.ID)
+	case <-time.After(5 * time.Second):
+		suite.FailNow("timed out waiting for delete message")
+	}
+}
+
 func TestAccountDeleteTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountDeleteTestSuite))
 }
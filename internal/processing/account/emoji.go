@@ -0,0 +1,204 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// PersonalEmojisGet returns all personal custom emojis owned by the given account.
+func (p *Processor) PersonalEmojisGet(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.PersonalEmoji, gtserror.WithCode) {
+	emojis, err := p.state.DB.GetEmojisByAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := fmt.Errorf("PersonalEmojisGet: db error: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiEmojis := make([]*apimodel.PersonalEmoji, 0, len(emojis))
+	for _, emoji := range emojis {
+		apiEmoji, err := p.tc.EmojiToPersonalAPIEmoji(ctx, emoji)
+		if err != nil {
+			err := fmt.Errorf("PersonalEmojisGet: error converting emoji %s: %w", emoji.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiEmojis = append(apiEmojis, apiEmoji)
+	}
+
+	return apiEmojis, nil
+}
+
+// PersonalEmojiCreate creates a new personal custom emoji owned by the given
+// account. The shortcode given in the form is prefixed with the account's
+// username, so that personal emojis can't clash with instance emojis, or
+// with the personal emojis of other accounts.
+func (p *Processor) PersonalEmojiCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.PersonalEmojiCreateRequest) (*apimodel.PersonalEmoji, gtserror.WithCode) {
+	maxEmojis := config.GetAccountsMaxEmojis()
+	if maxEmojis <= 0 {
+		err := errors.New("personal emojis are not enabled on this instance")
+		return nil, gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	count, err := p.state.DB.CountEmojisByAccountID(ctx, account.ID)
+	if err != nil {
+		err := fmt.Errorf("PersonalEmojiCreate: db error counting emojis: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if count >= maxEmojis {
+		err := fmt.Errorf("you already have the maximum of %d personal emojis; delete one first", maxEmojis)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if errWithCode := p.state.Storage.CheckQuota(form.Image.Size); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	shortcode := account.Username + "_" + form.Shortcode
+	if err := validate.PersonalEmojiShortcode(shortcode); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	existing, err := p.state.DB.GetEmojiByShortcodeDomain(ctx, shortcode, "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := fmt.Errorf("PersonalEmojiCreate: db error checking existence of emoji with shortcode %s: %w", shortcode, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		err := fmt.Errorf("emoji with shortcode %s already exists", shortcode)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	emojiID, err := id.NewRandomULID()
+	if err != nil {
+		err := fmt.Errorf("PersonalEmojiCreate: error creating id for new emoji: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	data := func(innerCtx context.Context) (io.ReadCloser, int64, error) {
+		f, err := form.Image.Open()
+		return f, form.Image.Size, err
+	}
+
+	processingEmoji, err := p.mediaManager.PreProcessEmoji(
+		ctx,
+		data,
+		shortcode,
+		emojiID,
+		uris.GenerateURIForEmoji(emojiID),
+		&media.AdditionalEmojiInfo{AccountID: &account.ID},
+		false,
+	)
+	if err != nil {
+		err := fmt.Errorf("PersonalEmojiCreate: error processing emoji: %w", err)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, "error processing emoji")
+	}
+
+	emoji, err := processingEmoji.LoadEmoji(ctx)
+	if err != nil {
+		err := fmt.Errorf("PersonalEmojiCreate: error loading emoji: %w", err)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, "error processing emoji")
+	}
+
+	apiEmoji, err := p.tc.EmojiToPersonalAPIEmoji(ctx, emoji)
+	if err != nil {
+		err := fmt.Errorf("PersonalEmojiCreate: error converting emoji: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiEmoji, nil
+}
+
+// PersonalEmojiDelete deletes the personal custom emoji with the given
+// id, if it's owned by the given account, returning the deleted emoji.
+func (p *Processor) PersonalEmojiDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.PersonalEmoji, gtserror.WithCode) {
+	emoji, err := p.state.DB.GetEmojiByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := fmt.Errorf("PersonalEmojiDelete: db error: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if emoji == nil || emoji.AccountID != account.ID {
+		// Don't reveal whether the
+		// emoji exists at all if it's
+		// not owned by this account.
+		err := fmt.Errorf("PersonalEmojiDelete: personal emoji %s not found for account %s", id, account.ID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	apiEmoji, err := p.tc.EmojiToPersonalAPIEmoji(ctx, emoji)
+	if err != nil {
+		err := fmt.Errorf("PersonalEmojiDelete: error converting emoji: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.deletePersonalEmoji(ctx, emoji); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiEmoji, nil
+}
+
+// deletePersonalEmojis deletes all personal
+// custom emojis owned by the given account.
+func (p *Processor) deletePersonalEmojis(ctx context.Context, account *gtsmodel.Account) error {
+	emojis, err := p.state.DB.GetEmojisByAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	for _, emoji := range emojis {
+		if err := p.deletePersonalEmoji(ctx, emoji); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deletePersonalEmoji deletes the given emoji
+// from the database, and its images from storage.
+func (p *Processor) deletePersonalEmoji(ctx context.Context, emoji *gtsmodel.Emoji) error {
+	if err := p.state.DB.DeleteEmojiByID(ctx, emoji.ID); err != nil {
+		return fmt.Errorf("error deleting emoji %s: %w", emoji.ID, err)
+	}
+
+	for _, path := range []string{emoji.ImagePath, emoji.ImageStaticPath} {
+		if err := p.state.Storage.Delete(ctx, path); err != nil {
+			// Not fatal; the emoji is gone
+			// from the db so won't be served.
+			log.Warnf(ctx, "error deleting emoji image %s from storage: %v", path, err)
+		}
+	}
+
+	return nil
+}
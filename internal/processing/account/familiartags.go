@@ -0,0 +1,87 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// familiarTagsLimit is the max number
+	// of familiar tags returned for an account.
+	familiarTagsLimit = 10
+
+	// familiarTagsPeriod is how far back to look
+	// at statuses when finding familiar tags.
+	familiarTagsPeriod = 90 * 24 * time.Hour
+)
+
+// FamiliarTagsGet returns hashtags recently used by targetAccountID that requestingAccount
+// has also used, most used first. If requestingAccount is nil, hashtags are compared with
+// the public timeline instead.
+func (p *Processor) FamiliarTagsGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) ([]apimodel.Tag, gtserror.WithCode) {
+	targetAccount, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorNotFound(errors.New("account not found"))
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error: %w", err))
+	}
+
+	visible, err := p.filter.AccountVisible(ctx, requestingAccount, targetAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error: %w", err))
+	}
+
+	if !visible {
+		return nil, gtserror.NewErrorNotFound(errors.New("account not found"))
+	}
+
+	var requestingAccountID string
+	if requestingAccount != nil {
+		requestingAccountID = requestingAccount.ID
+	}
+
+	since := time.Now().Add(-familiarTagsPeriod)
+	tags, err := p.state.DB.GetAccountFamiliarTags(ctx, requestingAccountID, targetAccount.ID, since, familiarTagsLimit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error: %w", err))
+	}
+
+	apiTags := make([]apimodel.Tag, 0, len(tags))
+	for _, tag := range tags {
+		apiTag, err := p.tc.TagToAPITag(ctx, tag)
+		if err != nil {
+			log.Debugf(ctx, "skipping tag %s due to error %q", tag.ID, err)
+			continue
+		}
+
+		apiTags = append(apiTags, apiTag)
+	}
+
+	return apiTags, nil
+}
@@ -0,0 +1,84 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// FeaturedTagsGet returns the hashtags featured by targetAccountID, if visible to requestingAccount.
+func (p *Processor) FeaturedTagsGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) ([]*apimodel.FeaturedTag, gtserror.WithCode) {
+	targetAccount, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorNotFound(errors.New("account not found"))
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error: %w", err))
+	}
+
+	visible, err := p.filter.AccountVisible(ctx, requestingAccount, targetAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error: %w", err))
+	}
+
+	if !visible {
+		return nil, gtserror.NewErrorNotFound(errors.New("account not found"))
+	}
+
+	featuredTags, err := p.state.DB.GetAccountFeaturedTags(ctx, targetAccount.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error: %w", err))
+	}
+
+	apiFeaturedTags := make([]*apimodel.FeaturedTag, 0, len(featuredTags))
+	if len(featuredTags) == 0 {
+		return apiFeaturedTags, nil
+	}
+
+	// Count how often the account used each
+	// tag, from the statuses we know about.
+	usage, err := p.state.DB.GetAccountTopTags(ctx, targetAccount.ID, 0)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error: %w", err))
+	}
+
+	counts := make(map[string]int, len(usage))
+	for _, u := range usage {
+		counts[u.Key] = u.Count
+	}
+
+	for _, featuredTag := range featuredTags {
+		apiFeaturedTag, err := p.tc.FeaturedTagToAPIFeaturedTag(ctx, featuredTag, counts[featuredTag.Name])
+		if err != nil {
+			log.Debugf(ctx, "skipping featured tag %s due to error %q", featuredTag.ID, err)
+			continue
+		}
+
+		apiFeaturedTags = append(apiFeaturedTags, apiFeaturedTag)
+	}
+
+	return apiFeaturedTags, nil
+}
@@ -149,6 +149,56 @@ func (p *Processor) FollowRemove(ctx context.Context, requestingAccount *gtsmode
 	return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
 }
 
+// FollowerRemove handles the removal of the follow from target account to requesting account,
+// ie., it forces target account to stop following requesting account, without blocking them.
+// Target account isn't notified of this, and is free to follow requesting account again.
+func (p *Processor) FollowerRemove(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
+	targetAccount, errWithCode := p.getFollowTarget(ctx, requestingAccount.ID, targetAccountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Get follow from target account to requesting account.
+	follow, err := p.state.DB.GetFollow(ctx, targetAccount.ID, requestingAccount.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = fmt.Errorf("FollowerRemove: error getting follow from %s targeting %s: %w", targetAccount.ID, requestingAccount.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if follow == nil {
+		// Not followed, nothing to do.
+		return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
+	}
+
+	if err := p.state.DB.DeleteFollowByID(ctx, follow.ID); err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("FollowerRemove: error deleting follow from %s targeting %s: %w", targetAccount.ID, requestingAccount.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// If err == db.ErrNoEntries here then it
+		// indicates a race condition with an unfollow
+		// or another removal of the same follow.
+		return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
+	}
+
+	// Let target account's instance know that the
+	// follow is no more, by rejecting it after the fact.
+	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ActivityFollow,
+		APActivityType: ap.ActivityReject,
+		GTSModel: &gtsmodel.Follow{
+			AccountID:       targetAccount.ID,
+			TargetAccountID: requestingAccount.ID,
+			URI:             follow.URI,
+		},
+		OriginAccount: requestingAccount,
+		TargetAccount: targetAccount,
+	})
+
+	return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
+}
+
 /*
 	Utility functions.
 */
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -31,6 +32,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
+// accountStaleAfter is the time since last fetch
+// after which a remote account is considered stale,
+// and will be refreshed in the background on lookup.
+const accountStaleAfter = 24 * time.Hour
+
 // Get processes the given request for account information.
 func (p *Processor) Get(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Account, gtserror.WithCode) {
 	targetAccount, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
@@ -41,7 +47,48 @@ func (p *Processor) Get(ctx context.Context, requestingAccount *gtsmodel.Account
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error: %w", err))
 	}
 
-	return p.getFor(ctx, requestingAccount, targetAccount)
+	return p.getFor(ctx, requestingAccount, targetAccount, true)
+}
+
+// GetWithRefresh processes the given request for account information, refreshing stale remote accounts.
+//
+// If forceRefresh is true, a remote target account is re-dereferenced before returning. Otherwise,
+// the cached account is returned immediately, and if it was last fetched more than 24 hours ago,
+// a refresh is started in the background. The returned bool indicates whether that was the case.
+func (p *Processor) GetWithRefresh(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string, forceRefresh bool) (*apimodel.Account, bool, gtserror.WithCode) {
+	targetAccount, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, false, gtserror.NewErrorNotFound(errors.New("account not found"))
+		}
+		return nil, false, gtserror.NewErrorInternalError(fmt.Errorf("db error: %w", err))
+	}
+
+	var refreshing bool
+
+	if requestingAccount != nil && !targetAccount.IsLocal() {
+		switch {
+		case forceRefresh:
+			latest, _, err := p.federator.RefreshAccount(ctx, requestingAccount.Username, targetAccount, nil, true)
+			if err != nil {
+				log.Errorf(ctx, "error refreshing target account: %v", err)
+			} else {
+				// Use latest account model.
+				targetAccount = latest
+			}
+
+		case time.Since(targetAccount.FetchedAt) > accountStaleAfter:
+			p.federator.RefreshAccountAsync(ctx, requestingAccount.Username, targetAccount, nil, true)
+			refreshing = true
+		}
+	}
+
+	apiAccount, errWithCode := p.getFor(ctx, requestingAccount, targetAccount, false)
+	if errWithCode != nil {
+		return nil, false, errWithCode
+	}
+
+	return apiAccount, refreshing, nil
 }
 
 // GetLocalByUsername processes the given request for account information targeting a local account by username.
@@ -54,7 +101,7 @@ func (p *Processor) GetLocalByUsername(ctx context.Context, requestingAccount *g
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error: %w", err))
 	}
 
-	return p.getFor(ctx, requestingAccount, targetAccount)
+	return p.getFor(ctx, requestingAccount, targetAccount, true)
 }
 
 // GetCustomCSSForUsername returns custom css for the given local username.
@@ -70,7 +117,7 @@ func (p *Processor) GetCustomCSSForUsername(ctx context.Context, username string
 	return customCSS, nil
 }
 
-func (p *Processor) getFor(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) (*apimodel.Account, gtserror.WithCode) {
+func (p *Processor) getFor(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccount *gtsmodel.Account, fetch bool) (*apimodel.Account, gtserror.WithCode) {
 	var err error
 
 	if requestingAccount != nil {
@@ -88,7 +135,7 @@ func (p *Processor) getFor(ctx context.Context, requestingAccount *gtsmodel.Acco
 		}
 	}
 
-	if targetAccount.Domain != "" {
+	if fetch && targetAccount.Domain != "" {
 		targetAccountURI, err := url.Parse(targetAccount.URI)
 		if err != nil {
 			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error parsing url %s: %w", targetAccount.URI, err))
@@ -0,0 +1,77 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type GetTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *GetTestSuite) TestGetWithRefreshStale() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	// Remote account has never been fetched,
+	// so a background refresh should start.
+	apiAccount, refreshing, errWithCode := suite.accountProcessor.GetWithRefresh(ctx, requestingAccount, targetAccount.ID, false)
+	suite.NoError(errWithCode)
+	suite.Equal(targetAccount.ID, apiAccount.ID)
+	suite.True(refreshing)
+}
+
+func (suite *GetTestSuite) TestGetWithRefreshUpToDate() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetAccount := new(gtsmodel.Account)
+	*targetAccount = *suite.testAccounts["remote_account_1"]
+
+	// Mark the remote account as recently fetched.
+	targetAccount.FetchedAt = time.Now()
+	if err := suite.db.UpdateAccount(ctx, targetAccount, "fetched_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiAccount, refreshing, errWithCode := suite.accountProcessor.GetWithRefresh(ctx, requestingAccount, targetAccount.ID, false)
+	suite.NoError(errWithCode)
+	suite.Equal(targetAccount.ID, apiAccount.ID)
+	suite.False(refreshing)
+}
+
+func (suite *GetTestSuite) TestGetWithRefreshLocal() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["local_account_2"]
+
+	// Local accounts are never refreshed.
+	_, refreshing, errWithCode := suite.accountProcessor.GetWithRefresh(ctx, requestingAccount, targetAccount.ID, false)
+	suite.NoError(errWithCode)
+	suite.False(refreshing)
+}
+
+func TestGetTestSuite(t *testing.T) {
+	suite.Run(t, new(GetTestSuite))
+}
@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// NowPlayingGet returns the most recent listen of the target account,
+// or a not found error if the account hasn't shared any listens.
+func (p *Processor) NowPlayingGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.NowPlaying, gtserror.WithCode) {
+	if requestingAccount != nil {
+		blocked, err := p.state.DB.IsEitherBlocked(ctx, requestingAccount.ID, targetAccountID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if blocked {
+			err := errors.New("block exists between accounts")
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+	}
+
+	listen, err := p.state.DB.GetAccountListen(ctx, targetAccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := fmt.Errorf("no listen found for account %s", targetAccountID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err := fmt.Errorf("db error getting listen for account %s: %w", targetAccountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiNowPlaying, err := p.tc.ListenToAPINowPlaying(ctx, listen)
+	if err != nil {
+		err := fmt.Errorf("error converting listen to api now playing: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiNowPlaying, nil
+}
+
+// Scrobble stores a listen of the given track by the requesting
+// account, replacing any previous listen, and federates it out to
+// the account's followers as a Listen activity.
+func (p *Processor) Scrobble(ctx context.Context, requestingAccount *gtsmodel.Account, form *apimodel.ScrobbleRequest) (*apimodel.NowPlaying, gtserror.WithCode) {
+	if err := validate.ScrobbleRequest(form); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	listenID := id.NewULID()
+	now := time.Now()
+	listen := &gtsmodel.Listen{
+		ID:         listenID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		URI:        uris.GenerateURIForListen(requestingAccount.Username, listenID),
+		AccountID:  requestingAccount.ID,
+		Account:    requestingAccount,
+		TrackName:  form.TrackName,
+		ArtistName: form.ArtistName,
+		AlbumName:  form.AlbumName,
+	}
+
+	if err := p.state.DB.PutListen(ctx, listen); err != nil {
+		err = fmt.Errorf("Scrobble: error storing listen in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process listen side effects (federation).
+	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectAudio,
+		APActivityType: ap.ActivityListen,
+		GTSModel:       listen,
+		OriginAccount:  requestingAccount,
+	})
+
+	apiNowPlaying, err := p.tc.ListenToAPINowPlaying(ctx, listen)
+	if err != nil {
+		err := fmt.Errorf("error converting listen to api now playing: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiNowPlaying, nil
+}
@@ -39,7 +39,11 @@ func (p *Processor) FollowersGet(ctx context.Context, requestingAccount *gtsmode
 		return nil, gtserror.NewErrorNotFound(err)
 	}
 
-	follows, err := p.state.DB.GetAccountFollowers(ctx, targetAccountID)
+	if errWithCode := p.checkCollectionsHidden(ctx, requestingAccount, targetAccountID); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	follows, err := p.state.DB.GetAccountFollowersNotBlocked(ctx, targetAccountID, requestingAccount.ID)
 	if err != nil {
 		if !errors.Is(err, db.ErrNoEntries) {
 			err = fmt.Errorf("FollowersGet: db error getting followers: %w", err)
@@ -48,7 +52,7 @@ func (p *Processor) FollowersGet(ctx context.Context, requestingAccount *gtsmode
 		return []apimodel.Account{}, nil
 	}
 
-	return p.accountsFromFollows(ctx, follows, requestingAccount.ID)
+	return p.accountsFromFollows(ctx, follows)
 }
 
 // FollowingGet fetches a list of the accounts that target account is following.
@@ -61,16 +65,78 @@ func (p *Processor) FollowingGet(ctx context.Context, requestingAccount *gtsmode
 		return nil, gtserror.NewErrorNotFound(err)
 	}
 
-	follows, err := p.state.DB.GetAccountFollows(ctx, targetAccountID)
+	if errWithCode := p.checkCollectionsHidden(ctx, requestingAccount, targetAccountID); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	follows, err := p.state.DB.GetAccountFollowsNotBlocked(ctx, targetAccountID, requestingAccount.ID)
 	if err != nil {
 		if !errors.Is(err, db.ErrNoEntries) {
-			err = fmt.Errorf("FollowingGet: db error getting followers: %w", err)
+			err = fmt.Errorf("FollowingGet: db error getting following: %w", err)
 			return nil, gtserror.NewErrorInternalError(err)
 		}
 		return []apimodel.Account{}, nil
 	}
 
-	return p.targetAccountsFromFollows(ctx, follows, requestingAccount.ID)
+	return p.targetAccountsFromFollows(ctx, follows)
+}
+
+// FollowersCountGet returns the number of accounts following the target account.
+// The requesting account may be nil, if the request is unauthenticated.
+func (p *Processor) FollowersCountGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.AccountCount, gtserror.WithCode) {
+	return p.followCountGet(ctx, requestingAccount, targetAccountID, p.state.DB.CountAccountFollowers)
+}
+
+// FollowingCountGet returns the number of accounts that the target account is following.
+// The requesting account may be nil, if the request is unauthenticated.
+func (p *Processor) FollowingCountGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.AccountCount, gtserror.WithCode) {
+	return p.followCountGet(ctx, requestingAccount, targetAccountID, p.state.DB.CountAccountFollows)
+}
+
+func (p *Processor) followCountGet(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	targetAccountID string,
+	count func(ctx context.Context, accountID string) (int, error),
+) (*apimodel.AccountCount, gtserror.WithCode) {
+	targetAccount, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("followCountGet: account %s not found", targetAccountID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err = fmt.Errorf("followCountGet: db error getting account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if requestingAccount == nil {
+		if targetAccount.Locked != nil && *targetAccount.Locked {
+			err = fmt.Errorf("followCountGet: account %s is locked", targetAccountID)
+			return nil, gtserror.NewErrorUnauthorized(err)
+		}
+	} else {
+		if blocked, err := p.state.DB.IsEitherBlocked(ctx, requestingAccount.ID, targetAccountID); err != nil {
+			err = fmt.Errorf("followCountGet: db error checking block: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		} else if blocked {
+			err = errors.New("followCountGet: block exists between accounts")
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+	}
+
+	if (requestingAccount == nil || requestingAccount.ID != targetAccountID) &&
+		targetAccount.HideCollections != nil && *targetAccount.HideCollections {
+		err = fmt.Errorf("followCountGet: account %s hides its collections", targetAccountID)
+		return nil, gtserror.NewErrorForbidden(err)
+	}
+
+	n, err := count(ctx, targetAccountID)
+	if err != nil {
+		err = fmt.Errorf("followCountGet: db error counting follows: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.AccountCount{Count: n}, nil
 }
 
 // RelationshipGet returns a relationship model describing the relationship of the targetAccount to the Authed account.
@@ -92,7 +158,34 @@ func (p *Processor) RelationshipGet(ctx context.Context, requestingAccount *gtsm
 	return r, nil
 }
 
-func (p *Processor) accountsFromFollows(ctx context.Context, follows []*gtsmodel.Follow, requestingAccountID string) ([]apimodel.Account, gtserror.WithCode) {
+// checkCollectionsHidden returns a 403 if the target account has chosen
+// to hide its followers / following collections, unless the requesting
+// account is the target account.
+func (p *Processor) checkCollectionsHidden(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) gtserror.WithCode {
+	if requestingAccount.ID == targetAccountID {
+		// Always allowed to see your own.
+		return nil
+	}
+
+	targetAccount, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("checkCollectionsHidden: account %s not found", targetAccountID)
+			return gtserror.NewErrorNotFound(err)
+		}
+		err = fmt.Errorf("checkCollectionsHidden: db error getting account: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if targetAccount.HideCollections != nil && *targetAccount.HideCollections {
+		err = fmt.Errorf("checkCollectionsHidden: account %s hides its collections", targetAccountID)
+		return gtserror.NewErrorForbidden(err)
+	}
+
+	return nil
+}
+
+func (p *Processor) accountsFromFollows(ctx context.Context, follows []*gtsmodel.Follow) ([]apimodel.Account, gtserror.WithCode) {
 	accounts := make([]apimodel.Account, 0, len(follows))
 	for _, follow := range follows {
 		if follow.Account == nil {
@@ -101,13 +194,6 @@ func (p *Processor) accountsFromFollows(ctx context.Context, follows []*gtsmodel
 			continue
 		}
 
-		if blocked, err := p.state.DB.IsEitherBlocked(ctx, requestingAccountID, follow.AccountID); err != nil {
-			err = fmt.Errorf("accountsFromFollows: db error checking block: %w", err)
-			return nil, gtserror.NewErrorInternalError(err)
-		} else if blocked {
-			continue
-		}
-
 		account, err := p.tc.AccountToAPIAccountPublic(ctx, follow.Account)
 		if err != nil {
 			err = fmt.Errorf("accountsFromFollows: error converting account to api account: %w", err)
@@ -119,7 +205,7 @@ func (p *Processor) accountsFromFollows(ctx context.Context, follows []*gtsmodel
 	return accounts, nil
 }
 
-func (p *Processor) targetAccountsFromFollows(ctx context.Context, follows []*gtsmodel.Follow, requestingAccountID string) ([]apimodel.Account, gtserror.WithCode) {
+func (p *Processor) targetAccountsFromFollows(ctx context.Context, follows []*gtsmodel.Follow) ([]apimodel.Account, gtserror.WithCode) {
 	accounts := make([]apimodel.Account, 0, len(follows))
 	for _, follow := range follows {
 		if follow.TargetAccount == nil {
@@ -128,13 +214,6 @@ func (p *Processor) targetAccountsFromFollows(ctx context.Context, follows []*gt
 			continue
 		}
 
-		if blocked, err := p.state.DB.IsEitherBlocked(ctx, requestingAccountID, follow.TargetAccountID); err != nil {
-			err = fmt.Errorf("targetAccountsFromFollows: db error checking block: %w", err)
-			return nil, gtserror.NewErrorInternalError(err)
-		} else if blocked {
-			continue
-		}
-
 		account, err := p.tc.AccountToAPIAccountPublic(ctx, follow.TargetAccount)
 		if err != nil {
 			err = fmt.Errorf("targetAccountsFromFollows: error converting account to api account: %w", err)
@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RelationshipsTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *RelationshipsTestSuite) TestFollowersGetExcludesBlocked() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	targetAccount := suite.testAccounts["local_account_1"]
+
+	// local_account_2 follows local_account_1,
+	// but blocks remote_account_1 in the fixtures.
+	followers, errWithCode := suite.accountProcessor.FollowersGet(ctx, requestingAccount, targetAccount.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Len(followers, 1)
+	suite.Equal(suite.testAccounts["admin_account"].ID, followers[0].ID)
+}
+
+func (suite *RelationshipsTestSuite) TestFollowingGetHideCollections() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["admin_account"]
+	targetAccount := &gtsmodel.Account{}
+	*targetAccount = *suite.testAccounts["local_account_1"]
+
+	targetAccount.HideCollections = testrig.TrueBool()
+	if err := suite.db.UpdateAccount(ctx, targetAccount, "hide_collections"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Someone else can't see the collection.
+	_, errWithCode := suite.accountProcessor.FollowingGet(ctx, requestingAccount, targetAccount.ID)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// But the owner can.
+	following, errWithCode := suite.accountProcessor.FollowingGet(ctx, targetAccount, targetAccount.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(following, 2)
+}
+
+func TestRelationshipsTestSuite(t *testing.T) {
+	suite.Run(t, new(RelationshipsTestSuite))
+}
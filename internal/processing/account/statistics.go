@@ -0,0 +1,134 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+const (
+	// statisticsTopHashtags is the number of top
+	// hashtags to include in account statistics.
+	statisticsTopHashtags = 10
+
+	// statisticsMonths is the number of months,
+	// including the current one, to include in
+	// the monthly breakdown of account statistics.
+	statisticsMonths = 12
+)
+
+// Statistics returns statistics about the given account's own activity and reach.
+func (p *Processor) Statistics(ctx context.Context, account *gtsmodel.Account) (*apimodel.AccountStatistics, gtserror.WithCode) {
+	month, errWithCode := p.statisticsBetween(ctx, account, time.Time{}, time.Time{})
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	followersGainedWeek, err := p.state.DB.CountAccountFollowersBetween(ctx, account.ID, time.Now().Add(-7*24*time.Hour), time.Time{})
+	if err != nil {
+		err = gtserror.Newf("error counting followers gained: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	tags, err := p.state.DB.GetAccountTopTags(ctx, account.ID, statisticsTopHashtags)
+	if err != nil {
+		err = gtserror.Newf("error getting top tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	topHashtags := make([]apimodel.AccountStatisticsHashtag, 0, len(tags))
+	for _, tag := range tags {
+		topHashtags = append(topHashtags, apimodel.AccountStatisticsHashtag{
+			Name:  tag.Key,
+			Count: tag.Count,
+		})
+	}
+
+	return &apimodel.AccountStatistics{
+		StatusesCount:       month.StatusesCount,
+		FavouritesReceived:  month.FavouritesReceived,
+		ReblogsReceived:     month.ReblogsReceived,
+		FollowersGainedWeek: followersGainedWeek,
+		TopHashtags:         topHashtags,
+	}, nil
+}
+
+// StatisticsMonthly returns statistics about the given account's activity
+// in each month of the past year, including the current month, oldest first.
+func (p *Processor) StatisticsMonthly(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.AccountStatisticsMonth, gtserror.WithCode) {
+	var (
+		now     = time.Now().UTC()
+		current = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		months  = make([]*apimodel.AccountStatisticsMonth, 0, statisticsMonths)
+	)
+
+	for i := statisticsMonths - 1; i >= 0; i-- {
+		since := current.AddDate(0, -i, 0)
+		until := since.AddDate(0, 1, 0)
+
+		month, errWithCode := p.statisticsBetween(ctx, account, since, until)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		followersGained, err := p.state.DB.CountAccountFollowersBetween(ctx, account.ID, since, until)
+		if err != nil {
+			err = gtserror.Newf("error counting followers gained: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		month.Month = since.Format("2006-01")
+		month.FollowersGained = followersGained
+		months = append(months, month)
+	}
+
+	return months, nil
+}
+
+// statisticsBetween counts statuses, faves received and boosts
+// received of the given account within the given time range.
+func (p *Processor) statisticsBetween(ctx context.Context, account *gtsmodel.Account, since time.Time, until time.Time) (*apimodel.AccountStatisticsMonth, gtserror.WithCode) {
+	statuses, err := p.state.DB.CountAccountStatusesBetween(ctx, account.ID, since, until)
+	if err != nil {
+		err = gtserror.Newf("error counting statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	faves, err := p.state.DB.CountAccountFavesReceivedBetween(ctx, account.ID, since, until)
+	if err != nil {
+		err = gtserror.Newf("error counting faves received: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	boosts, err := p.state.DB.CountAccountBoostsReceivedBetween(ctx, account.ID, since, until)
+	if err != nil {
+		err = gtserror.Newf("error counting boosts received: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.AccountStatisticsMonth{
+		StatusesCount:      statuses,
+		FavouritesReceived: faves,
+		ReblogsReceived:    boosts,
+	}, nil
+}
@@ -43,7 +43,9 @@ func (p *Processor) StatusesGet(
 	minID string,
 	pinned bool,
 	mediaOnly bool,
+	pollsOnly bool,
 	publicOnly bool,
+	boostsOnly bool,
 ) (*apimodel.PageableResponse, gtserror.WithCode) {
 	if requestingAccount != nil {
 		blocked, err := p.state.DB.IsEitherBlocked(ctx, requestingAccount.ID, targetAccountID)
@@ -65,9 +67,10 @@ func (p *Processor) StatusesGet(
 	if pinned {
 		// Get *ONLY* pinned statuses.
 		statuses, err = p.state.DB.GetAccountPinnedStatuses(ctx, targetAccountID)
+		statuses = filterPinned(statuses, targetAccountID, excludeReplies, excludeReblogs, pollsOnly, boostsOnly)
 	} else {
 		// Get account statuses which *may* include pinned ones.
-		statuses, err = p.state.DB.GetAccountStatuses(ctx, targetAccountID, limit, excludeReplies, excludeReblogs, maxID, minID, mediaOnly, publicOnly)
+		statuses, err = p.state.DB.GetAccountStatuses(ctx, targetAccountID, limit, excludeReplies, excludeReblogs, maxID, minID, mediaOnly, pollsOnly, publicOnly, boostsOnly)
 	}
 
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
@@ -126,19 +129,25 @@ func (p *Processor) StatusesGet(
 		}, nil
 	}
 
+	extraQueryParams := []string{
+		fmt.Sprintf("exclude_replies=%t", excludeReplies),
+		fmt.Sprintf("exclude_reblogs=%t", excludeReblogs),
+		fmt.Sprintf("pinned=%t", pinned),
+		fmt.Sprintf("only_media=%t", mediaOnly),
+		fmt.Sprintf("only_public=%t", publicOnly),
+	}
+
+	if boostsOnly {
+		extraQueryParams = append(extraQueryParams, "only_boosts=true")
+	}
+
 	return util.PackagePageableResponse(util.PageableResponseParams{
-		Items:          items,
-		Path:           "/api/v1/accounts/" + targetAccountID + "/statuses",
-		NextMaxIDValue: nextMaxIDValue,
-		PrevMinIDValue: prevMinIDValue,
-		Limit:          limit,
-		ExtraQueryParams: []string{
-			fmt.Sprintf("exclude_replies=%t", excludeReplies),
-			fmt.Sprintf("exclude_reblogs=%t", excludeReblogs),
-			fmt.Sprintf("pinned=%t", pinned),
-			fmt.Sprintf("only_media=%t", mediaOnly),
-			fmt.Sprintf("only_public=%t", publicOnly),
-		},
+		Items:            items,
+		Path:             "/api/v1/accounts/" + targetAccountID + "/statuses",
+		NextMaxIDValue:   nextMaxIDValue,
+		PrevMinIDValue:   prevMinIDValue,
+		Limit:            limit,
+		ExtraQueryParams: extraQueryParams,
 	})
 }
 
@@ -197,3 +206,38 @@ func (p *Processor) WebStatusesGet(ctx context.Context, targetAccountID string,
 		NextMaxIDValue: nextMaxIDValue,
 	})
 }
+
+// filterPinned applies the exclude replies, exclude reblogs, polls
+// only and boosts only filters to the given pinned statuses of the
+// target account. Unlike with other account statuses, this is done here
+// rather than in the database, as an account only has a few pins.
+func filterPinned(statuses []*gtsmodel.Status, targetAccountID string, excludeReplies bool, excludeReblogs bool, pollsOnly bool, boostsOnly bool) []*gtsmodel.Status {
+	if !excludeReplies && !excludeReblogs && !pollsOnly && !boostsOnly {
+		return statuses
+	}
+
+	filtered := make([]*gtsmodel.Status, 0, len(statuses))
+	for _, s := range statuses {
+		// Do include self replies (threads), but
+		// don't include replies to other people.
+		if excludeReplies && s.InReplyToURI != "" && s.InReplyToAccountID != targetAccountID {
+			continue
+		}
+
+		if excludeReblogs && s.BoostOfID != "" {
+			continue
+		}
+
+		if pollsOnly && s.PollID == "" {
+			continue
+		}
+
+		if boostsOnly && s.BoostOfID == "" {
+			continue
+		}
+
+		filtered = append(filtered, s)
+	}
+
+	return filtered
+}
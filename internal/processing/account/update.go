@@ -22,8 +22,11 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"slices"
+	"strings"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/api/errorcodes"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -33,6 +36,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
@@ -196,6 +200,10 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		}
 	}
 
+	if errWithCode := p.CheckStorageQuota(form.Avatar, form.Header); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	if form.Avatar != nil && form.Avatar.Size != 0 {
 		avatarInfo, err := p.UpdateAvatar(ctx, form.Avatar, nil, account.ID)
 		if err != nil {
@@ -247,6 +255,21 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 
 			account.StatusContentType = *form.Source.StatusContentType
 		}
+
+		if form.Source.HideLinkPreviews != nil {
+			account.HideLinkPreviews = form.Source.HideLinkPreviews
+		}
+
+		if form.Source.MentionPolicy != nil {
+			if err := validate.MentionPolicy(*form.Source.MentionPolicy); err != nil {
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+
+			// Only applies to mentions from
+			// now on, existing notifications
+			// of mentions are left as they are.
+			account.MentionPolicy = gtsmodel.MentionPolicy(*form.Source.MentionPolicy)
+		}
 	}
 
 	if form.CustomCSS != nil {
@@ -261,6 +284,26 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		account.EnableRSS = form.EnableRSS
 	}
 
+	if form.AttributionDomains != nil {
+		domains := make([]string, 0, len(*form.AttributionDomains))
+		for _, domain := range *form.AttributionDomains {
+			domain, err := util.Punify(strings.TrimSpace(domain))
+			if err != nil {
+				err := fmt.Errorf("error punifying attribution domain: %w", err)
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+
+			if domain != "" && !slices.Contains(domains, domain) {
+				domains = append(domains, domain)
+			}
+		}
+
+		if err := validate.AttributionDomains(domains); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		account.AttributionDomains = domains
+	}
+
 	err := p.state.DB.UpdateAccount(ctx, account)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("could not update account %s: %s", account.ID, err))
@@ -286,7 +329,8 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 func (p *Processor) UpdateAvatar(ctx context.Context, avatar *multipart.FileHeader, description *string, accountID string) (*gtsmodel.MediaAttachment, error) {
 	maxImageSize := config.GetMediaImageMaxSize()
 	if avatar.Size > int64(maxImageSize) {
-		return nil, fmt.Errorf("UpdateAvatar: avatar with size %d exceeded max image size of %d bytes", avatar.Size, maxImageSize)
+		err := fmt.Errorf("UpdateAvatar: avatar with size %d exceeded max image size of %d bytes", avatar.Size, maxImageSize)
+		return nil, errorcodes.Set(err, errorcodes.MediaTooLarge)
 	}
 
 	dataFunc := func(innerCtx context.Context) (io.ReadCloser, int64, error) {
@@ -314,7 +358,8 @@ func (p *Processor) UpdateAvatar(ctx context.Context, avatar *multipart.FileHead
 func (p *Processor) UpdateHeader(ctx context.Context, header *multipart.FileHeader, description *string, accountID string) (*gtsmodel.MediaAttachment, error) {
 	maxImageSize := config.GetMediaImageMaxSize()
 	if header.Size > int64(maxImageSize) {
-		return nil, fmt.Errorf("UpdateHeader: header with size %d exceeded max image size of %d bytes", header.Size, maxImageSize)
+		err := fmt.Errorf("UpdateHeader: header with size %d exceeded max image size of %d bytes", header.Size, maxImageSize)
+		return nil, errorcodes.Set(err, errorcodes.MediaTooLarge)
 	}
 
 	dataFunc := func(innerCtx context.Context) (io.ReadCloser, int64, error) {
@@ -334,3 +379,20 @@ func (p *Processor) UpdateHeader(ctx context.Context, header *multipart.FileHead
 
 	return processingMedia.LoadAttachment(ctx)
 }
+
+// CheckStorageQuota checks that the given avatar and / or header
+// uploads (either of which may be nil) fit within the storage quota.
+func (p *Processor) CheckStorageQuota(files ...*multipart.FileHeader) gtserror.WithCode {
+	var size int64
+	for _, file := range files {
+		if file != nil {
+			size += file.Size
+		}
+	}
+
+	if size == 0 {
+		return nil
+	}
+
+	return p.state.Storage.CheckQuota(size)
+}
@@ -85,6 +85,137 @@ func (suite *AccountUpdateTestSuite) TestAccountUpdateSimple() {
 	suite.Equal(noteExpected, dbAccount.Note)
 }
 
+func (suite *AccountUpdateTestSuite) TestAccountUpdateHideLinkPreviews() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	var (
+		ctx              = context.Background()
+		hideLinkPreviews = true
+	)
+
+	// Call update function.
+	apiAccount, errWithCode := suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		Source: &apimodel.UpdateSource{
+			HideLinkPreviews: &hideLinkPreviews,
+		},
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Returned profile should be updated.
+	suite.True(apiAccount.HideLinkPreviews)
+	suite.True(apiAccount.Source.HideLinkPreviews)
+
+	// We should have an update in the client api channel.
+	suite.checkClientAPIChan(testAccount.ID)
+
+	// Check database model of account as well.
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*dbAccount.HideLinkPreviews)
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateMentionPolicy() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	var (
+		ctx           = context.Background()
+		mentionPolicy = "followed"
+	)
+
+	notifsBefore, err := suite.db.GetAccountNotifications(ctx, testAccount.ID, "", "", "", 0, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Call update function.
+	apiAccount, errWithCode := suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		Source: &apimodel.UpdateSource{
+			MentionPolicy: &mentionPolicy,
+		},
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Returned profile should be updated.
+	suite.Equal(mentionPolicy, apiAccount.Source.MentionPolicy)
+
+	// We should have an update in the client api channel.
+	suite.checkClientAPIChan(testAccount.ID)
+
+	// Check database model of account as well.
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.MentionPolicyFollowed, dbAccount.MentionPolicy)
+
+	// Existing notifications should be left alone.
+	notifsAfter, err := suite.db.GetAccountNotifications(ctx, testAccount.ID, "", "", "", 0, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(notifsAfter, len(notifsBefore))
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateMentionPolicyInvalid() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	mentionPolicy := "strangers"
+
+	// Call update function.
+	apiAccount, errWithCode := suite.accountProcessor.Update(context.Background(), testAccount, &apimodel.UpdateCredentialsRequest{
+		Source: &apimodel.UpdateSource{
+			MentionPolicy: &mentionPolicy,
+		},
+	})
+	suite.Nil(apiAccount)
+	suite.EqualError(errWithCode, "mention policy 'strangers' was not recognized, valid options are 'anyone', 'followed', 'followers', 'nobody'")
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateAttributionDomains() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	var (
+		ctx     = context.Background()
+		domains = []string{" Blog.Example.org", "", "blog.example.org", "bücher.example"}
+	)
+
+	// Call update function.
+	apiAccount, errWithCode := suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		AttributionDomains: &domains,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Domains should be normalized and deduplicated.
+	expected := []string{"blog.example.org", "xn--bcher-kva.example"}
+	suite.Equal(expected, apiAccount.Source.AttributionDomains)
+
+	// Check database model of account as well.
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(expected, dbAccount.AttributionDomains)
+
+	// Bad domains should be rejected.
+	domains = []string{"https://example.org/blog"}
+	_, errWithCode = suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		AttributionDomains: &domains,
+	})
+	suite.EqualError(errWithCode, "attribution domain https://example.org/blog is not a valid domain")
+}
+
 func (suite *AccountUpdateTestSuite) TestAccountUpdateWithMention() {
 	testAccount := &gtsmodel.Account{}
 	*testAccount = *suite.testAccounts["local_account_1"]
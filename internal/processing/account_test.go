@@ -26,6 +26,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -94,6 +95,113 @@ func (suite *AccountTestSuite) TestAccountDeleteLocal() {
 	}
 }
 
+func (suite *AccountTestSuite) TestAccountRemoveFollower() {
+	ctx := context.Background()
+	removingAccount := suite.testAccounts["local_account_1"]
+	followingAccount := suite.testAccounts["remote_account_2"]
+
+	// make the following account follow the removing account
+	follow := &gtsmodel.Follow{
+		ID:              "01FJ1S8DX3STJJ6CEYPMZ1M0R3",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		URI:             fmt.Sprintf("%s/follow/01FJ1S8DX3STJJ6CEYPMZ1M0R3", followingAccount.URI),
+		AccountID:       followingAccount.ID,
+		TargetAccountID: removingAccount.ID,
+	}
+	err := suite.db.Put(ctx, follow)
+	suite.NoError(err)
+
+	relationship, errWithCode := suite.processor.Account().FollowerRemove(ctx, removingAccount, followingAccount.ID)
+	suite.NoError(errWithCode)
+	suite.False(relationship.FollowedBy)
+
+	// the follow should be gone from the db
+	_, err = suite.db.GetFollowByID(ctx, follow.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// a reject of the follow should be sent to the following account's inbox
+	var sent [][]byte
+	if !testrig.WaitFor(func() bool {
+		sentI, ok := suite.httpClient.SentMessages.Load(followingAccount.InboxURI)
+		if ok {
+			sent, ok = sentI.([][]byte)
+			if !ok {
+				panic("SentMessages entry was not [][]byte")
+			}
+			return true
+		}
+		return false
+	}) {
+		suite.FailNow("timed out waiting for message")
+	}
+
+	reject := &struct {
+		Actor  string `json:"actor"`
+		Object struct {
+			Actor  string `json:"actor"`
+			ID     string `json:"id"`
+			Object string `json:"object"`
+			Type   string `json:"type"`
+		}
+		To   string `json:"to"`
+		Type string `json:"type"`
+	}{}
+	err = json.Unmarshal(sent[0], reject)
+	suite.NoError(err)
+
+	suite.Equal("Reject", reject.Type)
+	suite.Equal(removingAccount.URI, reject.Actor)
+	suite.Equal(followingAccount.URI, reject.To)
+	suite.Equal("Follow", reject.Object.Type)
+	suite.Equal(follow.URI, reject.Object.ID)
+	suite.Equal(followingAccount.URI, reject.Object.Actor)
+	suite.Equal(removingAccount.URI, reject.Object.Object)
+
+}
+
+func (suite *AccountTestSuite) TestAccountBlockRemote() {
+	ctx := context.Background()
+	blockingAccount := suite.testAccounts["local_account_1"]
+	blockedAccount := suite.testAccounts["remote_account_2"]
+
+	relationship, errWithCode := suite.processor.Account().BlockCreate(ctx, blockingAccount, blockedAccount.ID)
+	suite.NoError(errWithCode)
+	suite.True(relationship.Blocking)
+
+	// a block should be sent to the blocked account's inbox
+	var sent [][]byte
+	if !testrig.WaitFor(func() bool {
+		sentI, ok := suite.httpClient.SentMessages.Load(blockedAccount.InboxURI)
+		if ok {
+			sent, ok = sentI.([][]byte)
+			if !ok {
+				panic("SentMessages entry was not [][]byte")
+			}
+			return true
+		}
+		return false
+	}) {
+		suite.FailNow("timed out waiting for message")
+	}
+
+	block := &struct {
+		Actor  string `json:"actor"`
+		ID     string `json:"id"`
+		Object string `json:"object"`
+		To     string `json:"to"`
+		Type   string `json:"type"`
+	}{}
+	err := json.Unmarshal(sent[0], block)
+	suite.NoError(err)
+
+	suite.Equal("Block", block.Type)
+	suite.Equal(blockingAccount.URI, block.Actor)
+	suite.Equal(blockedAccount.URI, block.Object)
+	suite.Equal(blockedAccount.URI, block.To)
+	suite.Contains(block.ID, blockingAccount.URI+"/blocks/")
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, &AccountTestSuite{})
 }
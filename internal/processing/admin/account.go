@@ -19,22 +19,121 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
+// AccountsGet returns accounts known to this instance, paged by account ID.
+//
+// If local is set, only local (true) or remote (false) accounts will be returned. If domain
+// is set, only accounts from that domain will be returned. If suspended is set, only accounts
+// that are (true) or aren't (false) suspended will be returned.
+func (p *Processor) AccountsGet(
+	ctx context.Context,
+	local *bool,
+	domain string,
+	suspended *bool,
+	maxID string,
+	sinceID string,
+	minID string,
+	limit int,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	accounts, err := p.state.DB.GetAccounts(ctx, local, domain, suspended, maxID, sinceID, minID, limit)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return util.EmptyPageableResponse(), nil
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(accounts)
+	items := make([]interface{}, 0, count)
+	nextMaxIDValue := ""
+	prevMinIDValue := ""
+	for i, a := range accounts {
+		item, err := p.tc.AccountToAdminAPIAccount(ctx, a)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting account to api: %w", err))
+		}
+
+		if i == count-1 {
+			nextMaxIDValue = item.ID
+		}
+
+		if i == 0 {
+			prevMinIDValue = item.ID
+		}
+
+		items = append(items, item)
+	}
+
+	extraQueryParams := []string{}
+	if local != nil {
+		if *local {
+			extraQueryParams = append(extraQueryParams, "local=true")
+		} else {
+			extraQueryParams = append(extraQueryParams, "remote=true")
+		}
+	}
+	if domain != "" {
+		extraQueryParams = append(extraQueryParams, "by_domain="+domain)
+	}
+	if suspended != nil {
+		extraQueryParams = append(extraQueryParams, "suspended="+strconv.FormatBool(*suspended))
+	}
+
+	return util.PackagePageableResponse(util.PageableResponseParams{
+		Items:            items,
+		Path:             "/api/v1/admin/accounts",
+		NextMaxIDValue:   nextMaxIDValue,
+		PrevMinIDValue:   prevMinIDValue,
+		Limit:            limit,
+		ExtraQueryParams: extraQueryParams,
+	})
+}
+
+// AccountGet returns the admin view of the account with the given ID.
+func (p *Processor) AccountGet(ctx context.Context, accountID string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("account %s not found", accountID)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAccount, err := p.tc.AccountToAdminAPIAccount(ctx, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting account to api: %w", err))
+	}
+
+	return apiAccount, nil
+}
+
 func (p *Processor) AccountAction(ctx context.Context, account *gtsmodel.Account, form *apimodel.AdminAccountActionRequest) gtserror.WithCode {
 	targetAccount, err := p.state.DB.GetAccountByID(ctx, form.TargetAccountID)
 	if err != nil {
 		return gtserror.NewErrorInternalError(err)
 	}
 
+	var targetUser *gtsmodel.User
+
 	adminAction := &gtsmodel.AdminAccountAction{
 		ID:              id.NewULID(),
 		AccountID:       account.ID,
@@ -45,6 +144,16 @@ func (p *Processor) AccountAction(ctx context.Context, account *gtsmodel.Account
 	switch form.Type {
 	case string(gtsmodel.AdminActionSuspend):
 		adminAction.Type = gtsmodel.AdminActionSuspend
+
+		if targetAccount.IsLocal() && (form.SendEmail == nil || *form.SendEmail) {
+			// Fetch the user now, as it will
+			// be stubbified by the account delete.
+			targetUser, err = p.state.DB.GetUserByAccountID(ctx, targetAccount.ID)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return gtserror.NewErrorInternalError(err)
+			}
+		}
+
 		// pass the account delete through the client api channel for processing
 		p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
 			APObjectType:   ap.ActorPerson,
@@ -52,6 +161,18 @@ func (p *Processor) AccountAction(ctx context.Context, account *gtsmodel.Account
 			OriginAccount:  account,
 			TargetAccount:  targetAccount,
 		})
+	case string(gtsmodel.AdminActionUnsuspend):
+		adminAction.Type = gtsmodel.AdminActionUnsuspend
+
+		if errWithCode := p.accountUnsuspend(ctx, targetAccount); errWithCode != nil {
+			return errWithCode
+		}
+	case string(gtsmodel.AdminActionUnsilence):
+		adminAction.Type = gtsmodel.AdminActionUnsilence
+
+		if errWithCode := p.accountUnsilence(ctx, targetAccount); errWithCode != nil {
+			return errWithCode
+		}
 	default:
 		return gtserror.NewErrorBadRequest(fmt.Errorf("admin action type %s is not supported for this endpoint", form.Type))
 	}
@@ -60,5 +181,197 @@ func (p *Processor) AccountAction(ctx context.Context, account *gtsmodel.Account
 		return gtserror.NewErrorInternalError(err)
 	}
 
+	if targetUser != nil {
+		// The suspension itself has gone through
+		// fine, so don't fail it if the email doesn't.
+		if err := p.emailAccountSuspended(ctx, targetAccount, targetUser, adminAction); err != nil {
+			log.Warnf(ctx, "error emailing suspended user %s: %v", targetUser.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// accountUnsuspend reverses the suspension of the given account.
+//
+// Content removed by the suspension (statuses, media, follows, etc)
+// is gone for good, but the account can be used and federated with
+// again. Local accounts are federated out again in an Update, so that
+// remote instances which still know the account see it's back. Users
+// of local accounts will need to reset their password to log in again.
+func (p *Processor) accountUnsuspend(ctx context.Context, targetAccount *gtsmodel.Account) gtserror.WithCode {
+	if targetAccount.SuspendedAt.IsZero() {
+		err := fmt.Errorf("account %s is not suspended", targetAccount.ID)
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if targetAccount.IsRemote() {
+		// Accounts on a blocked domain are suspended
+		// by the domain block, which would need to be
+		// lifted instead of suspending single accounts.
+		blocked, err := p.state.DB.IsDomainBlocked(ctx, targetAccount.Domain)
+		if err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if blocked {
+			err := fmt.Errorf("account %s is on blocked domain %s; remove the domain block instead", targetAccount.ID, targetAccount.Domain)
+			return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+	}
+
+	targetAccount.SuspendedAt = time.Time{}
+	targetAccount.SuspensionOrigin = ""
+	if err := p.state.DB.UpdateAccount(ctx, targetAccount, "suspended_at", "suspension_origin"); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Cached visibility of the account's statuses is keyed
+	// by status rather than account, so clear all of it, for
+	// the statuses to be shown (and streamed) again.
+	p.state.Caches.Visibility.Clear()
+
+	if targetAccount.IsLocal() {
+		// The account isn't gone anymore,
+		// so remove the tombstone it left.
+		tombstone, err := p.state.DB.GetTombstoneByURI(ctx, targetAccount.URI)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if tombstone != nil {
+			if err := p.state.DB.DeleteTombstone(ctx, tombstone.ID); err != nil {
+				return gtserror.NewErrorInternalError(err)
+			}
+		}
+
+		p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+			APObjectType:   ap.ActorPerson,
+			APActivityType: ap.ActivityUpdate,
+			GTSModel:       targetAccount,
+			OriginAccount:  targetAccount,
+		})
+	}
+
+	return nil
+}
+
+// accountUnsilence reverses the silencing of the given account.
+func (p *Processor) accountUnsilence(ctx context.Context, targetAccount *gtsmodel.Account) gtserror.WithCode {
+	if targetAccount.SilencedAt.IsZero() {
+		err := fmt.Errorf("account %s is not silenced", targetAccount.ID)
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	targetAccount.SilencedAt = time.Time{}
+	if err := p.state.DB.UpdateAccount(ctx, targetAccount, "silenced_at"); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// See accountUnsuspend.
+	p.state.Caches.Visibility.Clear()
+
+	return nil
+}
+
+// emailAccountSuspended lets the given user know that their account has
+// been suspended by the given admin action, provided email sending is configured,
+// and the user has a confirmed email address for us to send it to.
+func (p *Processor) emailAccountSuspended(ctx context.Context, account *gtsmodel.Account, user *gtsmodel.User, adminAction *gtsmodel.AdminAccountAction) error {
+	if config.GetSMTPHost() == "" {
+		// Email sending not configured.
+		return nil
+	}
+
+	if user.ConfirmedAt.IsZero() || user.Email == "" {
+		// No verified email address.
+		return nil
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	accountSuspendedData := email.AccountSuspendedData{
+		Username:     account.Username,
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+		Reason:       adminAction.Text,
+		ContactEmail: instance.ContactEmail,
+	}
+
+	return p.emailSender.SendAccountSuspendedEmail(user.Email, accountSuspendedData)
+}
+
+// AccountMaxChars sets the maximum permitted characters for statuses
+// posted by the local account with the given id, overriding the instance
+// limits for statuses of every visibility. If maxChars is 0, the override
+// is removed, and the instance limits apply to the account again.
+func (p *Processor) AccountMaxChars(ctx context.Context, targetAccountID string, maxChars int) gtserror.WithCode {
+	if maxChars < 0 {
+		err := fmt.Errorf("max_chars must be 0 or greater, provided value was %d", maxChars)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	targetAccount, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return gtserror.NewErrorNotFound(err)
+		}
+		return gtserror.NewErrorInternalError(fmt.Errorf("db error getting account %s: %w", targetAccountID, err))
+	}
+
+	if !targetAccount.IsLocal() {
+		err := fmt.Errorf("account %s is not a local account", targetAccountID)
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, targetAccount.ID)
+	if err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("db error getting user for account %s: %w", targetAccountID, err))
+	}
+
+	user.StatusesMaxChars = maxChars
+	if err := p.state.DB.UpdateUser(ctx, user, "statuses_max_chars"); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("db error updating user %s: %w", user.ID, err))
+	}
+
+	return nil
+}
+
+// AccountMediaRole sets the role used to look up media limits for the local
+// account with the given id, in the media-roles config. If role is empty,
+// the account's admin, moderator, or user role is used again instead.
+func (p *Processor) AccountMediaRole(ctx context.Context, targetAccountID string, role string) gtserror.WithCode {
+	role = strings.ToLower(strings.TrimSpace(role))
+	if _, ok := config.GetMediaRoles()[role]; role != "" && !ok {
+		err := fmt.Errorf("media_role %s is not configured in %s", role, config.MediaRolesFlag())
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	targetAccount, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return gtserror.NewErrorNotFound(err)
+		}
+		return gtserror.NewErrorInternalError(fmt.Errorf("db error getting account %s: %w", targetAccountID, err))
+	}
+
+	if !targetAccount.IsLocal() {
+		err := fmt.Errorf("account %s is not a local account", targetAccountID)
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, targetAccount.ID)
+	if err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("db error getting user for account %s: %w", targetAccountID, err))
+	}
+
+	user.MediaRole = role
+	if err := p.state.DB.UpdateUser(ctx, user, "media_role"); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("db error updating user %s: %w", user.ID, err))
+	}
+
 	return nil
 }
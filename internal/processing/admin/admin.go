@@ -33,6 +33,10 @@ type Processor struct {
 	mediaManager        *media.Manager
 	transportController transport.Controller
 	emailSender         email.Sender
+
+	// cohortRetention caches the
+	// results of CohortRetention.
+	cohortRetention *cohortRetentionCache
 }
 
 // New returns a new admin processor.
@@ -44,5 +48,6 @@ func New(state *state.State, tc typeutils.TypeConverter, mediaManager *media.Man
 		mediaManager:        mediaManager,
 		transportController: transportController,
 		emailSender:         emailSender,
+		cohortRetention:     &cohortRetentionCache{},
 	}
 }
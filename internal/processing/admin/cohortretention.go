@@ -0,0 +1,169 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strconv"
+	"sync"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+const (
+	// cohortRetentionMonths is the number of months,
+	// including the current month, which are covered
+	// by the report returned by CohortRetention.
+	cohortRetentionMonths = 12
+
+	// cohortRetentionTTL is how long the results of
+	// CohortRetention are cached before being recounted.
+	cohortRetentionTTL = 24 * time.Hour
+)
+
+// cohortRetentionCache wraps the
+// cohort retention report with an expiry.
+type cohortRetentionCache struct {
+	mu        sync.Mutex
+	retention *apimodel.AdminCohortRetention
+	expires   time.Time
+}
+
+// CohortRetention returns a report of how many users who signed up in each of
+// the last 12 months (including the current month) were active in each month
+// since they signed up. Months are calendar months in UTC. The report is cached
+// for 24 hours, as counting activity may be slow on instances with many users.
+func (p *Processor) CohortRetention(ctx context.Context) (*apimodel.AdminCohortRetention, gtserror.WithCode) {
+	p.cohortRetention.mu.Lock()
+	defer p.cohortRetention.mu.Unlock()
+
+	now := time.Now()
+	if p.cohortRetention.retention != nil && now.Before(p.cohortRetention.expires) {
+		return p.cohortRetention.retention, nil
+	}
+
+	// Find the start of the oldest month in the report.
+	now = now.UTC()
+	since := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).
+		AddDate(0, -(cohortRetentionMonths - 1), 0)
+
+	cohorts, err := p.state.DB.CountUserCohorts(ctx, since)
+	if err != nil {
+		err := gtserror.Newf("db error counting user cohorts: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	activity, err := p.state.DB.CountUserCohortActivity(ctx, since)
+	if err != nil {
+		err := gtserror.Newf("db error counting user cohort activity: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	retention := &apimodel.AdminCohortRetention{
+		Months:  make([]string, 0, cohortRetentionMonths),
+		Cohorts: make([]apimodel.AdminRetentionCohort, 0, cohortRetentionMonths),
+	}
+
+	// Index of each month in the report.
+	months := make(map[string]int, cohortRetentionMonths)
+	for i := 0; i < cohortRetentionMonths; i++ {
+		month := since.AddDate(0, i, 0).Format("2006-01")
+		months[month] = i
+
+		retention.Months = append(retention.Months, month)
+		retention.Cohorts = append(retention.Cohorts, apimodel.AdminRetentionCohort{
+			Period: month,
+			Active: make([]int, cohortRetentionMonths-i),
+		})
+	}
+
+	for _, c := range cohorts {
+		i, ok := months[c.Cohort]
+		if !ok {
+			continue
+		}
+
+		retention.Cohorts[i].Users = c.Users
+	}
+
+	for _, a := range activity {
+		i, ok := months[a.Cohort]
+		if !ok {
+			continue
+		}
+
+		j, ok := months[a.Month]
+		if !ok || j < i {
+			continue
+		}
+
+		// Activity is counted from
+		// the cohort's own month.
+		retention.Cohorts[i].Active[j-i] = a.Users
+	}
+
+	p.cohortRetention.retention = retention
+	p.cohortRetention.expires = now.Add(cohortRetentionTTL)
+	return retention, nil
+}
+
+// CohortRetentionCSV returns the report from CohortRetention
+// as CSV, with one row per cohort, and one column per month
+// of activity. Columns for months before a cohort signed up
+// are left empty.
+func (p *Processor) CohortRetentionCSV(ctx context.Context) ([]byte, gtserror.WithCode) {
+	retention, errWithCode := p.CohortRetention(ctx)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+
+	header := append([]string{"period", "users"}, retention.Months...)
+	if err := w.Write(header); err != nil {
+		err := gtserror.Newf("error writing csv: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for i, c := range retention.Cohorts {
+		record := make([]string, len(header))
+		record[0] = c.Period
+		record[1] = strconv.Itoa(c.Users)
+		for j, active := range c.Active {
+			record[2+i+j] = strconv.Itoa(active)
+		}
+
+		if err := w.Write(record); err != nil {
+			err := gtserror.Newf("error writing csv: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		err := gtserror.Newf("error writing csv: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// ConfigReload re-reads the configuration file (and env), applying any changed
+// values that are safe to change while running, and reporting any other changed
+// values as rejected. Config that cannot be parsed, or contains invalid values,
+// results in a 422 with help text, with none of the changes applied.
+//
+// Turning maintenance mode off this way enqueues any federated
+// activities received in the meantime, as with MaintenanceSet.
+func (p *Processor) ConfigReload(ctx context.Context) (*apimodel.AdminConfigReload, gtserror.WithCode) {
+	changed, rejected, err := config.HotReload()
+	if err != nil {
+		err = fmt.Errorf("error reloading config: %w", err)
+		log.Error(ctx, err)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if len(rejected) > 0 {
+		log.Warnf(ctx, "config reload: changes to %v require a restart, ignoring", rejected)
+	}
+
+	log.Infof(ctx, "config reload: changed %v", changed)
+
+	// Maintenance mode may have been
+	// turned off by the reload.
+	p.DrainBacklog(ctx)
+
+	// Ensure keys are serialized
+	// as empty arrays, not null.
+	if changed == nil {
+		changed = []string{}
+	}
+
+	if rejected == nil {
+		rejected = []string{}
+	}
+
+	return &apimodel.AdminConfigReload{
+		Changed:  changed,
+		Rejected: rejected,
+	}, nil
+}
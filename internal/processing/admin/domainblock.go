@@ -61,6 +61,9 @@ func (p *Processor) DomainBlockCreate(ctx context.Context, account *gtsmodel.Acc
 			PublicComment:      text.SanitizePlaintext(publicComment),
 			Obfuscate:          &obfuscate,
 			SubscriptionID:     subscriptionID,
+			Severity:           gtsmodel.DomainBlockSeveritySuspend,
+			RejectMedia:        func() *bool { v := false; return &v }(),
+			RejectReports:      func() *bool { v := false; return &v }(),
 		}
 
 		// Insert the new block into the database
@@ -174,6 +177,127 @@ selectAccountsLoop:
 	}
 }
 
+// DomainBlockUpdate updates the domain block with the given ID from the given form.
+//
+// If the severity of the block changes, the side effects of the change are processed
+// asynchronously, and the updated block is returned straight away: when going from
+// silence to suspend, accounts on the domain are suspended just as for a new block,
+// and when going from suspend to silence, they're unsuspended and silenced instead.
+func (p *Processor) DomainBlockUpdate(ctx context.Context, account *gtsmodel.Account, id string, form *apimodel.DomainBlockUpdateRequest) (*apimodel.DomainBlock, gtserror.WithCode) {
+	domainBlock := &gtsmodel.DomainBlock{}
+
+	if err := p.state.DB.GetByID(ctx, id, domainBlock); err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			// something has gone really wrong
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		// there are no entries for this ID
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no entry for ID %s", id))
+	}
+
+	var (
+		wasSilence = domainBlock.IsSilence()
+		columns    []string
+	)
+
+	if form.Severity != nil {
+		domainBlock.Severity = gtsmodel.DomainBlockSeverity(*form.Severity)
+		columns = append(columns, "severity")
+	}
+
+	if form.RejectMedia != nil {
+		domainBlock.RejectMedia = form.RejectMedia
+		columns = append(columns, "reject_media")
+	}
+
+	if form.RejectReports != nil {
+		domainBlock.RejectReports = form.RejectReports
+		columns = append(columns, "reject_reports")
+	}
+
+	if form.Obfuscate != nil {
+		domainBlock.Obfuscate = form.Obfuscate
+		columns = append(columns, "obfuscate")
+	}
+
+	if form.PrivateComment != nil {
+		domainBlock.PrivateComment = text.SanitizePlaintext(*form.PrivateComment)
+		columns = append(columns, "private_comment")
+	}
+
+	if form.PublicComment != nil {
+		domainBlock.PublicComment = text.SanitizePlaintext(*form.PublicComment)
+		columns = append(columns, "public_comment")
+	}
+
+	if len(columns) != 0 {
+		if err := p.state.DB.UpdateDomainBlock(ctx, domainBlock, columns...); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error updating domain block %s: %w", domainBlock.Domain, err))
+		}
+	}
+
+	// Process the side effects of any change in severity
+	// asynchronously, since they might take a while.
+	switch isSilence := domainBlock.IsSilence(); {
+	case wasSilence && !isSilence:
+		go func() {
+			p.initiateDomainBlockSideEffects(context.Background(), account, domainBlock)
+		}()
+	case !wasSilence && isSilence:
+		go func() {
+			p.initiateDomainSilenceSideEffects(context.Background(), domainBlock)
+		}()
+	}
+
+	apiDomainBlock, err := p.tc.DomainBlockToAPIDomainBlock(ctx, domainBlock, false)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiDomainBlock, nil
+}
+
+// initiateDomainSilenceSideEffects should be called asynchronously, to process
+// the side effects of a domain block being downgraded from suspend to silence:
+//
+// 1. Mark the instance entry for the domain as no longer suspended.
+// 2. Unsuspend all accounts whose suspension origin was this domain block.
+// 3. Silence all accounts from this instance.
+//
+// Content removed while the domain was suspended is gone for good.
+func (p *Processor) initiateDomainSilenceSideEffects(ctx context.Context, block *gtsmodel.DomainBlock) {
+	l := log.WithContext(ctx).WithFields(kv.Fields{{"domain", block.Domain}}...)
+	l.Debug("processing domain silence side effects")
+
+	instance := &gtsmodel.Instance{}
+	if err := p.state.DB.GetWhere(ctx, []db.Where{{Key: "domain", Value: block.Domain}}, instance); err == nil {
+		instance.SuspendedAt = time.Time{}
+		instance.UpdatedAt = time.Now()
+		if err := p.state.DB.UpdateByID(ctx, instance, instance.ID, "suspended_at", "updated_at"); err != nil {
+			l.Errorf("db error updating instance: %s", err)
+		}
+	}
+
+	for _, column := range []string{"suspended_at", "suspension_origin"} {
+		if err := p.state.DB.UpdateWhere(ctx, []db.Where{
+			{Key: "suspension_origin", Value: block.ID},
+		}, column, nil, &[]*gtsmodel.Account{}); err != nil {
+			l.Errorf("db error removing %s from accounts: %s", column, err)
+		}
+	}
+
+	if err := p.state.DB.UpdateWhere(ctx, []db.Where{
+		{Key: "domain", Value: block.Domain},
+	}, "silenced_at", time.Now(), &[]*gtsmodel.Account{}); err != nil {
+		l.Errorf("db error silencing accounts: %s", err)
+	}
+
+	// Accounts were updated underneath
+	// the caches, so clear them out.
+	p.state.Caches.GTS.Account().Clear()
+	p.state.Caches.Visibility.Clear()
+}
+
 // DomainBlocksImport handles the import of a bunch of domain blocks at once, by calling the DomainBlockCreate function for each domain in the provided file.
 func (p *Processor) DomainBlocksImport(ctx context.Context, account *gtsmodel.Account, domains *multipart.FileHeader) ([]*apimodel.DomainBlock, gtserror.WithCode) {
 	f, err := domains.Open()
@@ -307,5 +431,17 @@ func (p *Processor) DomainBlockDelete(ctx context.Context, account *gtsmodel.Acc
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("database error removing suspension_origin from accounts: %s", err))
 	}
 
+	if domainBlock.IsSilence() {
+		// unsilence all accounts from the silenced domain
+		if err := p.state.DB.UpdateWhere(ctx, []db.Where{
+			{Key: "domain", Value: domainBlock.Domain},
+		}, "silenced_at", nil, &[]*gtsmodel.Account{}); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("database error removing silenced_at from accounts: %s", err))
+		}
+
+		p.state.Caches.GTS.Account().Clear()
+		p.state.Caches.Visibility.Clear()
+	}
+
 	return apiDomainBlock, nil
 }
@@ -0,0 +1,171 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// domainBlockCSVColumns are the columns of domain block CSVs, in the
+// same order as Mastodon's domain block exports. When importing, any
+// columns apart from 'domain' may be missing, and they may be in any order.
+var domainBlockCSVColumns = []string{
+	"domain",
+	"severity",
+	"reject_media",
+	"reject_reports",
+	"public_comment",
+	"obfuscate",
+}
+
+// domainBlockCSVPrivateCommentColumn is appended to
+// domainBlockCSVColumns when exporting private comments.
+const domainBlockCSVPrivateCommentColumn = "private_comment"
+
+// DomainBlocksExport returns all existing domain blocks, sorted by domain, and
+// serialized in the given format (one of apimodel.DomainBlocksFormat*), so that
+// they can be shared with other instances. Private comments on the blocks are
+// left out unless privateComments is true.
+func (p *Processor) DomainBlocksExport(ctx context.Context, account *gtsmodel.Account, format string, privateComments bool) ([]byte, gtserror.WithCode) {
+	domainBlocks := []*gtsmodel.DomainBlock{}
+
+	if err := p.state.DB.GetAll(ctx, &domainBlocks); err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			// something has gone really wrong
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	exportBlocks := make([]*apimodel.DomainBlock, 0, len(domainBlocks))
+	for _, b := range domainBlocks {
+		// Domain may be in Punycode,
+		// de-punify it just in case.
+		d, err := util.DePunify(b.Domain)
+		if err != nil {
+			err := gtserror.Newf("error de-punifying domain %s: %w", b.Domain, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		exportBlock := &apimodel.DomainBlock{
+			Domain: apimodel.Domain{
+				Domain:        d,
+				PublicComment: b.PublicComment,
+			},
+			Obfuscate:     b.Obfuscate != nil && *b.Obfuscate,
+			Severity:      string(gtsmodel.DomainBlockSeveritySuspend),
+			RejectMedia:   b.RejectMedia != nil && *b.RejectMedia,
+			RejectReports: b.RejectReports != nil && *b.RejectReports,
+		}
+
+		if b.IsSilence() {
+			exportBlock.Severity = string(gtsmodel.DomainBlockSeveritySilence)
+		}
+
+		if privateComments {
+			exportBlock.PrivateComment = b.PrivateComment
+		}
+
+		exportBlocks = append(exportBlocks, exportBlock)
+	}
+
+	// Sort by domain, so that exports
+	// are stable and easy to compare.
+	sort.Slice(exportBlocks, func(i, j int) bool {
+		return exportBlocks[i].Domain.Domain < exportBlocks[j].Domain.Domain
+	})
+
+	var (
+		b   []byte
+		err error
+	)
+
+	switch format {
+	case apimodel.DomainBlocksFormatJSON:
+		b, err = json.Marshal(exportBlocks)
+	case apimodel.DomainBlocksFormatCSV:
+		b, err = exportDomainBlocksCSV(exportBlocks, "", privateComments)
+	case apimodel.DomainBlocksFormatMastodon:
+		b, err = exportDomainBlocksCSV(exportBlocks, "#", privateComments)
+	default:
+		err := fmt.Errorf("format %q not recognized, must be one of csv, json, mastodon", format)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err != nil {
+		err := gtserror.Newf("error serializing domain blocks as %s: %w", format, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return b, nil
+}
+
+// exportDomainBlocksCSV writes the given domain blocks as CSV,
+// with a header row of column names prefixed by headerPrefix.
+func exportDomainBlocksCSV(blocks []*apimodel.DomainBlock, headerPrefix string, privateComments bool) ([]byte, error) {
+	columns := domainBlockCSVColumns
+	if privateComments {
+		columns = append(columns[:len(columns):len(columns)], domainBlockCSVPrivateCommentColumn)
+	}
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = headerPrefix + column
+	}
+
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, b := range blocks {
+		record := []string{
+			b.Domain.Domain,
+			b.Severity,
+			strconv.FormatBool(b.RejectMedia),
+			strconv.FormatBool(b.RejectReports),
+			b.PublicComment,
+			strconv.FormatBool(b.Obfuscate),
+		}
+
+		if privateComments {
+			record = append(record, b.PrivateComment)
+		}
+
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,484 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// importedDomainBlock is one entry of an imported list of domain blocks.
+type importedDomainBlock struct {
+	domain string                // domain as given in the list
+	block  *gtsmodel.DomainBlock // parsed block, nil if err is set
+	err    error                 // error parsing the entry
+}
+
+// DomainBlocksImportFile imports the domain blocks listed in the given file, which
+// should be in the given format (one of apimodel.DomainBlocksFormat*).
+//
+// Each entry is checked against existing domain blocks (including blocks of parent
+// domains), and against the other entries, and reported as either new, existing,
+// conflicting, or erroring. Only new entries are created, each one separately, so
+// that one bad entry doesn't prevent the rest from being imported. If dryRun is
+// true then nothing is created, and the result is a preview of the import.
+func (p *Processor) DomainBlocksImportFile(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	file *multipart.FileHeader,
+	format string,
+	dryRun bool,
+) (*apimodel.DomainBlocksImportResult, gtserror.WithCode) {
+	f, err := file.Open()
+	if err != nil {
+		err := fmt.Errorf("error opening domain blocks file: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer f.Close()
+
+	var entries []*importedDomainBlock
+	switch format {
+	case apimodel.DomainBlocksFormatJSON:
+		entries, err = parseDomainBlocksJSON(f)
+	case apimodel.DomainBlocksFormatCSV, apimodel.DomainBlocksFormatMastodon:
+		entries, err = parseDomainBlocksCSV(f)
+	default:
+		err := fmt.Errorf("format %q not recognized, must be one of csv, json, mastodon", format)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err != nil {
+		err := fmt.Errorf("error parsing domain blocks file as %s: %w", format, err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if len(entries) == 0 {
+		err := errors.New("domain blocks file contained no entries")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	existing := []*gtsmodel.DomainBlock{}
+	if err := p.state.DB.GetAll(ctx, &existing); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Index blocks by punycode domain; this holds existing
+	// blocks, plus imported blocks as they're accepted.
+	blocks := make(map[string]*gtsmodel.DomainBlock, len(existing)+len(entries))
+	for _, b := range existing {
+		if domain, err := util.Punify(b.Domain); err == nil {
+			blocks[domain] = b
+		}
+	}
+
+	// Check entries for parent domains before entries for their subdomains,
+	// so that subdomains covered by other entries can be detected. The
+	// results are put back in their original order afterwards.
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return domainDepth(entries[order[i]]) < domainDepth(entries[order[j]])
+	})
+
+	type outcome struct {
+		isNew       bool
+		existing    string // reason, if existing
+		conflicting string // reason, if conflicting
+	}
+
+	var (
+		outcomes = make([]outcome, len(entries))
+		imported = make(map[string]bool, len(entries))
+	)
+
+	for _, i := range order {
+		entry := entries[i]
+		if entry.err != nil {
+			continue
+		}
+
+		block := entry.block
+		switch reason, conflict := checkImportedDomainBlock(block, blocks, imported); {
+		case conflict:
+			outcomes[i].conflicting = reason
+		case reason != "":
+			outcomes[i].existing = reason
+		default:
+			outcomes[i].isNew = true
+			blocks[block.Domain] = block
+			imported[block.Domain] = true
+		}
+	}
+
+	result := &apimodel.DomainBlocksImportResult{
+		DryRun:      dryRun,
+		New:         []*apimodel.DomainBlock{},
+		Existing:    []*apimodel.DomainBlocksImportEntry{},
+		Conflicting: []*apimodel.DomainBlocksImportEntry{},
+		Errors:      []*apimodel.DomainBlocksImportEntry{},
+	}
+
+	for i, entry := range entries {
+		switch o := outcomes[i]; {
+		case entry.err != nil:
+			result.Errors = append(result.Errors, &apimodel.DomainBlocksImportEntry{
+				Domain: entry.domain,
+				Reason: entry.err.Error(),
+			})
+		case o.conflicting != "":
+			result.Conflicting = append(result.Conflicting, &apimodel.DomainBlocksImportEntry{
+				Domain: entry.domain,
+				Reason: o.conflicting,
+			})
+		case o.existing != "":
+			result.Existing = append(result.Existing, &apimodel.DomainBlocksImportEntry{
+				Domain: entry.domain,
+				Reason: o.existing,
+			})
+		case o.isNew:
+			block := entry.block
+			block.CreatedByAccountID = account.ID
+			block.CreatedAt = time.Now()
+			block.UpdatedAt = block.CreatedAt
+
+			if !dryRun {
+				// Create each block on its own, so that
+				// a failure doesn't affect the others.
+				block.ID = id.NewULID()
+				if err := p.state.DB.CreateDomainBlock(ctx, block); err != nil {
+					log.Errorf(ctx, "db error creating imported domain block %s: %v", block.Domain, err)
+					result.Errors = append(result.Errors, &apimodel.DomainBlocksImportEntry{
+						Domain: entry.domain,
+						Reason: "error creating domain block",
+					})
+					continue
+				}
+
+				// Process the side effects of the domain
+				// block asynchronously since it might take a while.
+				go func() {
+					if block.IsSilence() {
+						p.initiateDomainSilenceSideEffects(context.Background(), block)
+					} else {
+						p.initiateDomainBlockSideEffects(context.Background(), account, block)
+					}
+				}()
+			}
+
+			apiBlock, err := p.tc.DomainBlockToAPIDomainBlock(ctx, block, false)
+			if err != nil {
+				err := gtserror.Newf("error converting domain block %s: %w", block.Domain, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+			result.New = append(result.New, apiBlock)
+		}
+	}
+
+	return result, nil
+}
+
+// checkImportedDomainBlock checks the given imported block against the given
+// blocks, which are indexed by domain, returning a reason for skipping it if it
+// shouldn't be created. If conflict is true, the block conflicts with the state
+// of this instance; otherwise, it's already in place. An empty reason means the
+// block can be created.
+func checkImportedDomainBlock(
+	block *gtsmodel.DomainBlock,
+	blocks map[string]*gtsmodel.DomainBlock,
+	imported map[string]bool,
+) (reason string, conflict bool) {
+	for _, own := range []string{config.GetHost(), config.GetAccountDomain()} {
+		if host, _, err := net.SplitHostPort(own); err == nil {
+			// Strip the port, eg. from "localhost:8080".
+			own = host
+		}
+
+		if own == "" {
+			continue
+		}
+
+		if own == block.Domain || strings.HasSuffix(own, "."+block.Domain) {
+			return "domain block would cover this instance's own domain " + own, true
+		}
+	}
+
+	if b, ok := blocks[block.Domain]; ok {
+		if imported[block.Domain] {
+			return "duplicate of an earlier entry", false
+		}
+
+		if coversDomainBlock(b, block) && coversDomainBlock(block, b) {
+			return "already blocked", false
+		}
+
+		return fmt.Sprintf(
+			"already blocked with different settings: severity %s, reject_media %t, reject_reports %t",
+			severityOf(b), b.RejectMedia != nil && *b.RejectMedia, b.RejectReports != nil && *b.RejectReports,
+		), true
+	}
+
+	for _, parent := range parentDomains(block.Domain) {
+		b, ok := blocks[parent]
+		if !ok || !coversDomainBlock(b, block) {
+			continue
+		}
+
+		if imported[parent] {
+			return "covered by imported block of " + parent, false
+		}
+
+		return "covered by existing block of " + parent, false
+	}
+
+	return "", false
+}
+
+// coversDomainBlock returns true if block a is at least as
+// strict as block b, ignoring the domains of the blocks.
+func coversDomainBlock(a *gtsmodel.DomainBlock, b *gtsmodel.DomainBlock) bool {
+	if !a.IsSilence() {
+		// Suspension covers everything,
+		// since nothing gets through.
+		return true
+	}
+
+	if !b.IsSilence() {
+		return false
+	}
+
+	return (!isTrue(b.RejectMedia) || isTrue(a.RejectMedia)) &&
+		(!isTrue(b.RejectReports) || isTrue(a.RejectReports))
+}
+
+// severityOf returns the severity of the given
+// block, taking an unset severity as suspend.
+func severityOf(b *gtsmodel.DomainBlock) gtsmodel.DomainBlockSeverity {
+	if b.IsSilence() {
+		return gtsmodel.DomainBlockSeveritySilence
+	}
+	return gtsmodel.DomainBlockSeveritySuspend
+}
+
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
+
+// parentDomains returns the parent domains of the
+// given domain, nearest first; eg., for "a.b.example.org",
+// it returns "b.example.org", "example.org", "org".
+func parentDomains(domain string) []string {
+	var parents []string
+	for {
+		i := strings.IndexByte(domain, '.')
+		if i < 0 {
+			return parents
+		}
+		domain = domain[i+1:]
+		parents = append(parents, domain)
+	}
+}
+
+// domainDepth returns the number of labels in
+// the domain of the given entry, for sorting.
+func domainDepth(entry *importedDomainBlock) int {
+	if entry.block == nil {
+		return 0
+	}
+	return strings.Count(entry.block.Domain, ".") + 1
+}
+
+// newImportedDomainBlock returns an imported domain block entry for the given
+// fields, normalizing and validating them, and setting err if they're invalid.
+func newImportedDomainBlock(
+	domain string,
+	severity string,
+	rejectMedia bool,
+	rejectReports bool,
+	obfuscate bool,
+	publicComment string,
+	privateComment string,
+) *importedDomainBlock {
+	entry := &importedDomainBlock{domain: domain}
+
+	punified, err := util.Punify(strings.TrimSpace(domain))
+	if err != nil || punified == "" {
+		entry.err = fmt.Errorf("domain %q is not a valid domain", domain)
+		return entry
+	}
+
+	if _, ok := dns.IsDomainName(punified); !ok || strings.ContainsAny(punified, "/:@?#* ") {
+		entry.err = fmt.Errorf("domain %q is not a valid domain", domain)
+		return entry
+	}
+
+	sev := gtsmodel.DomainBlockSeverity(strings.ToLower(strings.TrimSpace(severity)))
+	switch sev {
+	case "":
+		sev = gtsmodel.DomainBlockSeveritySuspend
+	case gtsmodel.DomainBlockSeveritySilence, gtsmodel.DomainBlockSeveritySuspend:
+		// No problem.
+	default:
+		entry.err = fmt.Errorf("severity %q not supported, must be one of silence, suspend", severity)
+		return entry
+	}
+
+	entry.block = &gtsmodel.DomainBlock{
+		Domain:         punified,
+		PrivateComment: text.SanitizePlaintext(privateComment),
+		PublicComment:  text.SanitizePlaintext(publicComment),
+		Obfuscate:      &obfuscate,
+		Severity:       sev,
+		RejectMedia:    &rejectMedia,
+		RejectReports:  &rejectReports,
+	}
+
+	return entry
+}
+
+// parseDomainBlocksJSON parses a JSON array of domain blocks,
+// as exported with apimodel.DomainBlocksFormatJSON.
+func parseDomainBlocksJSON(r io.Reader) ([]*importedDomainBlock, error) {
+	var list []apimodel.DomainBlock
+	if err := json.NewDecoder(r).Decode(&list); err != nil {
+		return nil, err
+	}
+
+	entries := make([]*importedDomainBlock, 0, len(list))
+	for _, d := range list {
+		entries = append(entries, newImportedDomainBlock(
+			d.Domain.Domain,
+			d.Severity,
+			d.RejectMedia,
+			d.RejectReports,
+			d.Obfuscate,
+			d.PublicComment,
+			d.PrivateComment,
+		))
+	}
+
+	return entries, nil
+}
+
+// parseDomainBlocksCSV parses a CSV list of domain blocks, as exported
+// with apimodel.DomainBlocksFormatCSV or apimodel.DomainBlocksFormatMastodon.
+// The first row must be a header row naming the columns, optionally
+// prefixed with '#'; of these, only 'domain' is required.
+func parseDomainBlocksCSV(r io.Reader) ([]*importedDomainBlock, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading header row: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimPrefix(strings.TrimSpace(name), "#")
+		columns[name] = i
+	}
+
+	if _, ok := columns["domain"]; !ok {
+		return nil, errors.New("header row has no domain column")
+	}
+
+	var entries []*importedDomainBlock
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return entries, nil
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+
+			// Malformed row; record it and carry on.
+			entries = append(entries, &importedDomainBlock{
+				domain: "line " + strconv.Itoa(parseErr.StartLine),
+				err:    err,
+			})
+			continue
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return record[i]
+		}
+
+		var boolErr error
+		boolField := func(name string) bool {
+			v := strings.TrimSpace(field(name))
+			if v == "" {
+				return false
+			}
+
+			b, err := strconv.ParseBool(v)
+			if err != nil && boolErr == nil {
+				boolErr = fmt.Errorf("%s %q is not a boolean", name, v)
+			}
+			return b
+		}
+
+		entry := newImportedDomainBlock(
+			field("domain"),
+			field("severity"),
+			boolField("reject_media"),
+			boolField("reject_reports"),
+			boolField("obfuscate"),
+			field("public_comment"),
+			field(domainBlockCSVPrivateCommentColumn),
+		)
+
+		if entry.err == nil && boolErr != nil {
+			entry.block = nil
+			entry.err = boolErr
+		}
+
+		entries = append(entries, entry)
+	}
+}
@@ -19,25 +19,30 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 // EmailTest sends a generic test email to the given toAddress (which
-// should be a valid email address). To help callers differentiate between
-// proper errors and the smtp errors they're likely fishing for, will return
-// 422 + help text on an SMTP error, or error 500 otherwise.
-func (p *Processor) EmailTest(ctx context.Context, account *gtsmodel.Account, toAddress string) gtserror.WithCode {
+// should be a valid email address), returning a transcript of the SMTP
+// conversation. To help callers differentiate between proper errors and
+// the smtp errors they're likely fishing for, will return 422 + help text
+// (including the transcript) on an SMTP error, or error 500 otherwise.
+func (p *Processor) EmailTest(ctx context.Context, account *gtsmodel.Account, toAddress string) (*apimodel.AdminEmailTestResult, gtserror.WithCode) {
 	// Pull our instance entry from the database,
 	// so we can greet the email recipient nicely.
 	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
 	if err != nil {
 		err = fmt.Errorf("SendConfirmEmail: error getting instance: %s", err)
-		return gtserror.NewErrorInternalError(err)
+		return nil, gtserror.NewErrorInternalError(err)
 	}
 
 	testData := email.TestData{
@@ -46,14 +51,71 @@ func (p *Processor) EmailTest(ctx context.Context, account *gtsmodel.Account, to
 		InstanceName:    instance.Title,
 	}
 
-	if err := p.emailSender.SendTestEmail(toAddress, testData); err != nil {
+	transcript, err := p.emailSender.SendTestEmail(toAddress, testData)
+	if err != nil {
 		if errorType := gtserror.Type(err); errorType == gtserror.TypeSMTP {
 			// An error occurred during the SMTP part.
 			// We should indicate this to the caller, as
 			// it will likely help them debug the issue.
-			return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error(), transcript)
 		}
 		// An actual error has occurred.
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.AdminEmailTestResult{
+		Status:     "test email sent",
+		Transcript: transcript,
+	}, nil
+}
+
+// EmailFailedGet returns up to limit emails which
+// could not be sent, most recently failed first.
+func (p *Processor) EmailFailedGet(ctx context.Context, limit int) ([]*apimodel.AdminFailedEmail, gtserror.WithCode) {
+	emails, err := p.state.DB.GetFailedQueuedEmails(ctx, limit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting failed emails: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiEmails := make([]*apimodel.AdminFailedEmail, 0, len(emails))
+	for _, e := range emails {
+		apiEmail, err := p.tc.QueuedEmailToAdminAPIFailedEmail(ctx, e)
+		if err != nil {
+			err := gtserror.Newf("error converting email %s: %w", e.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiEmails = append(apiEmails, apiEmail)
+	}
+
+	return apiEmails, nil
+}
+
+// EmailFailedRetry puts the failed email with the given ID back
+// in the queue, to be sent again at the next check of the queue.
+func (p *Processor) EmailFailedRetry(ctx context.Context, id string) gtserror.WithCode {
+	email, err := p.state.DB.GetQueuedEmailByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting email %s: %w", id, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if email == nil || email.FailedAt.IsZero() {
+		err := fmt.Errorf("failed email %s not found", id)
+		return gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	// Give the email a fresh set of attempts.
+	email.Attempts = 0
+	email.FailedAt = time.Time{}
+	email.NextAttemptAt = time.Now()
+
+	if err := p.state.DB.UpdateQueuedEmail(ctx, email,
+		"attempts",
+		"failed_at",
+		"next_attempt_at",
+	); err != nil {
+		err := gtserror.Newf("db error updating email %s: %w", id, err)
 		return gtserror.NewErrorInternalError(err)
 	}
 
@@ -41,6 +41,10 @@ func (p *Processor) EmojiCreate(ctx context.Context, account *gtsmodel.Account,
 		return nil, gtserror.NewErrorUnauthorized(fmt.Errorf("user %s not an admin", user.ID), "user is not an admin")
 	}
 
+	if errWithCode := p.state.Storage.CheckQuota(form.Image.Size); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	maybeExisting, err := p.state.DB.GetEmojiByShortcodeDomain(ctx, form.Shortcode, "")
 	if maybeExisting != nil {
 		return nil, gtserror.NewErrorConflict(fmt.Errorf("emoji with shortcode %s already exists", form.Shortcode), fmt.Sprintf("emoji with shortcode %s already exists", form.Shortcode))
@@ -271,6 +275,35 @@ func (p *Processor) EmojiCategoriesGet(ctx context.Context) ([]*apimodel.EmojiCa
 	return apiCategories, nil
 }
 
+// EmojiCategoryUpdate updates one emoji category with the given id, using the provided form parameters.
+func (p *Processor) EmojiCategoryUpdate(ctx context.Context, id string, form *apimodel.EmojiCategoryUpdateRequest) (*apimodel.EmojiCategory, gtserror.WithCode) {
+	category, err := p.state.DB.GetEmojiCategory(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("EmojiCategoryUpdate: no emoji category with id %s found in the db", id)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err := fmt.Errorf("EmojiCategoryUpdate: db error: %s", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if form.Position != nil {
+		category.Position = *form.Position
+		if err := p.state.DB.UpdateEmojiCategory(ctx, category, "position"); err != nil {
+			err = fmt.Errorf("EmojiCategoryUpdate: error updating emoji category %s: %s", id, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	apiCategory, err := p.tc.EmojiCategoryToAPIEmojiCategory(ctx, category)
+	if err != nil {
+		err = fmt.Errorf("EmojiCategoryUpdate: error converting emoji category to api emoji category: %s", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiCategory, nil
+}
+
 /*
 	UTIL FUNCTIONS
 */
@@ -426,6 +459,9 @@ func (p *Processor) emojiUpdateModify(ctx context.Context, emoji *gtsmodel.Emoji
 	// only update image if provided with one
 	var updateImage bool
 	if image != nil && image.Size != 0 {
+		if errWithCode := p.state.Storage.CheckQuota(image.Size); errWithCode != nil {
+			return nil, errWithCode
+		}
 		updateImage = true
 	}
 
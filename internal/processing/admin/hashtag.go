@@ -0,0 +1,93 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+	"golang.org/x/text/unicode/norm"
+)
+
+// HashtagsGet returns the admin settings of all hashtags which have any.
+func (p *Processor) HashtagsGet(ctx context.Context) ([]*apimodel.AdminHashtag, gtserror.WithCode) {
+	settings, err := p.state.DB.GetHashtagSettings(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error getting hashtag settings: %w", err))
+	}
+
+	apiHashtags := make([]*apimodel.AdminHashtag, 0, len(settings))
+	for _, setting := range settings {
+		apiHashtag, err := p.tc.HashtagSettingToAdminAPIHashtag(ctx, setting)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiHashtags = append(apiHashtags, apiHashtag)
+	}
+
+	return apiHashtags, nil
+}
+
+// HashtagRequireCW sets whether statuses created by local
+// accounts using the given hashtag must have a content warning.
+func (p *Processor) HashtagRequireCW(ctx context.Context, name string, required bool) (*apimodel.AdminHashtag, gtserror.WithCode) {
+	// Normalize the same way as hashtags
+	// parsed out of status text are.
+	name = strings.ToLower(norm.NFC.String(strings.TrimPrefix(name, "#")))
+	if err := validate.Hashtag(name); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	setting, err := p.state.DB.GetHashtagSettingByName(ctx, name)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error getting hashtag setting %s: %w", name, err))
+	}
+
+	if setting == nil {
+		setting = &gtsmodel.HashtagSetting{
+			ID:                     id.NewULID(),
+			Name:                   name,
+			ContentWarningRequired: &required,
+		}
+
+		if err := p.state.DB.PutHashtagSetting(ctx, setting); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error putting hashtag setting %s: %w", name, err))
+		}
+	} else {
+		setting.ContentWarningRequired = &required
+
+		if err := p.state.DB.UpdateHashtagSetting(ctx, setting, "content_warning_required"); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error updating hashtag setting %s: %w", name, err))
+		}
+	}
+
+	apiHashtag, err := p.tc.HashtagSettingToAdminAPIHashtag(ctx, setting)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiHashtag, nil
+}
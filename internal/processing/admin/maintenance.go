@@ -0,0 +1,98 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// MaintenanceGet returns the current maintenance mode state of the instance.
+func (p *Processor) MaintenanceGet(ctx context.Context) (*apimodel.AdminMaintenance, gtserror.WithCode) {
+	return p.maintenance(ctx)
+}
+
+// MaintenanceSet turns maintenance mode on or off, optionally updating the maintenance message.
+// Turning maintenance mode off enqueues any federated activities received in the meantime.
+//
+// This only changes the running config: the value from file / env is
+// restored by a config reload, or on restart of the instance.
+func (p *Processor) MaintenanceSet(ctx context.Context, form *apimodel.AdminMaintenanceRequest) (*apimodel.AdminMaintenance, gtserror.WithCode) {
+	if form.Message != nil {
+		config.SetMaintenanceMessage(*form.Message)
+	}
+
+	if form.Enabled != nil && *form.Enabled != config.GetMaintenanceMode() {
+		config.SetMaintenanceMode(*form.Enabled)
+		log.Infof(ctx, "maintenance mode turned on: %t", *form.Enabled)
+		p.DrainBacklog(ctx)
+	}
+
+	return p.maintenance(ctx)
+}
+
+func (p *Processor) maintenance(ctx context.Context) (*apimodel.AdminMaintenance, gtserror.WithCode) {
+	backlog, err := p.state.Workers.FederatorBacklog.Len(ctx)
+	if err != nil {
+		err := gtserror.Newf("error counting backlog: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.AdminMaintenance{
+		Enabled: config.GetMaintenanceMode(),
+		Message: config.GetMaintenanceMessage(),
+		Backlog: backlog,
+	}, nil
+}
+
+// DrainBacklog enqueues federator messages deferred during
+// maintenance mode, if maintenance mode is no longer on.
+//
+// The backlog is kept in the database, so this is also
+// called on startup to process messages deferred before
+// the last shutdown.
+func (p *Processor) DrainBacklog(ctx context.Context) {
+	if config.GetMaintenanceMode() {
+		return
+	}
+
+	msgs, err := p.state.Workers.FederatorBacklog.Take(ctx)
+	if err != nil {
+		log.Errorf(ctx, "error taking backlog: %v", err)
+		return
+	}
+
+	if len(msgs) == 0 {
+		return
+	}
+
+	// Enqueue messages one by one so they're spread
+	// over the workers; do this in the background as
+	// enqueuing blocks while the queue is full.
+	go func() {
+		ctx := context.Background()
+		log.Infof(ctx, "enqueuing %d deferred federator messages", len(msgs))
+		for _, msg := range msgs {
+			p.state.Workers.EnqueueFederator(ctx, msg)
+		}
+	}()
+}
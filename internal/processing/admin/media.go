@@ -19,8 +19,12 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -63,3 +67,74 @@ func (p *Processor) MediaPrune(ctx context.Context, mediaRemoteCacheDays int) gt
 
 	return nil
 }
+
+// MediaUsage returns a report of how much storage is used by cached media,
+// grouped by "domain" or "account", for the limit n largest groups. If orphaned
+// is true, storage is also walked to count files missing a database entry.
+func (p *Processor) MediaUsage(ctx context.Context, groupBy string, limit int, orphaned bool) (*apimodel.AdminMediaUsage, gtserror.WithCode) {
+	var (
+		usage []*db.MediaUsage
+		err   error
+	)
+
+	switch groupBy {
+	case "", "domain":
+		groupBy = "domain"
+		usage, err = p.state.DB.GetMediaUsageByDomain(ctx, limit)
+	case "account":
+		usage, err = p.state.DB.GetMediaUsageByAccount(ctx, limit)
+	default:
+		err := fmt.Errorf("group_by %s not recognized; valid options are domain, account", groupBy)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err != nil {
+		err := gtserror.Newf("db error getting media usage: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	resp := &apimodel.AdminMediaUsage{
+		GroupBy: groupBy,
+		Usage:   make([]apimodel.AdminMediaUsageEntry, 0, len(usage)),
+	}
+
+	for _, u := range usage {
+		entry := apimodel.AdminMediaUsageEntry{
+			Key:   u.Key,
+			Count: u.Count,
+			Size:  u.Size,
+		}
+
+		if groupBy == "account" {
+			account, err := p.state.DB.GetAccountByID(gtscontext.SetBarebones(ctx), u.Key)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				err := gtserror.Newf("db error getting account %s: %w", u.Key, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+			if account != nil {
+				entry.Account = account.Username
+				if account.Domain != "" {
+					entry.Account += "@" + account.Domain
+				}
+			}
+		}
+
+		resp.Usage = append(resp.Usage, entry)
+	}
+
+	if orphaned {
+		count, size, err := p.cleaner.Media().CountOrphaned(ctx)
+		if err != nil {
+			err := gtserror.Newf("error counting orphaned media: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		resp.Orphaned = &apimodel.AdminMediaUsageEntry{
+			Count: count,
+			Size:  size,
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,246 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// RegistrationsGet returns the sign-ups of local users with the given
+// registration status (or all of them if status is empty), paged by account ID.
+func (p *Processor) RegistrationsGet(
+	ctx context.Context,
+	status string,
+	maxID string,
+	sinceID string,
+	minID string,
+	limit int,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	switch gtsmodel.RegistrationStatus(status) {
+	case "",
+		gtsmodel.RegistrationStatusPending,
+		gtsmodel.RegistrationStatusApproved,
+		gtsmodel.RegistrationStatusRejected:
+		// No problem.
+	default:
+		err := fmt.Errorf("status must be one of pending, approved or rejected, provided value was %s", status)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	users, err := p.state.DB.GetRegistrations(ctx, gtsmodel.RegistrationStatus(status), maxID, sinceID, minID, limit)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return util.EmptyPageableResponse(), nil
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(users)
+	items := make([]interface{}, 0, count)
+	nextMaxIDValue := ""
+	prevMinIDValue := ""
+	for i, u := range users {
+		item, err := p.tc.AccountToAdminAPIAccount(ctx, u.Account)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting account to api: %w", err))
+		}
+
+		if i == count-1 {
+			nextMaxIDValue = item.ID
+		}
+
+		if i == 0 {
+			prevMinIDValue = item.ID
+		}
+
+		items = append(items, item)
+	}
+
+	extraQueryParams := []string{}
+	if status != "" {
+		extraQueryParams = append(extraQueryParams, "status="+status)
+	}
+
+	return util.PackagePageableResponse(util.PageableResponseParams{
+		Items:            items,
+		Path:             "/api/v1/admin/registrations",
+		NextMaxIDValue:   nextMaxIDValue,
+		PrevMinIDValue:   prevMinIDValue,
+		Limit:            limit,
+		ExtraQueryParams: extraQueryParams,
+	})
+}
+
+// RegistrationApprove approves the pending or rejected sign-up of the
+// local account with the given id, so that it can be used to log in,
+// and sends the new user a welcome email.
+func (p *Processor) RegistrationApprove(ctx context.Context, targetAccountID string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	user, errWithCode := p.registrationUser(ctx, targetAccountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if *user.Approved {
+		err := fmt.Errorf("sign-up of account %s has already been approved", targetAccountID)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	// A previously rejected sign-up
+	// may still be approved after all.
+	approved := true
+	disabled := false
+	user.Approved = &approved
+	user.Disabled = &disabled
+	user.RejectedAt = time.Time{}
+	if err := p.state.DB.UpdateUser(ctx, user, "approved", "disabled", "rejected_at"); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error updating user %s: %w", user.ID, err))
+	}
+
+	if err := p.emailSignupApproved(ctx, user); err != nil {
+		log.Errorf(ctx, "error emailing approved sign-up %s: %v", user.Account.Username, err)
+	}
+
+	return p.registrationAccount(ctx, user)
+}
+
+// RegistrationReject rejects the pending sign-up of the local account
+// with the given id, and lets the applicant know by email, including
+// the reason given for the rejection, if any.
+func (p *Processor) RegistrationReject(ctx context.Context, targetAccountID string, reason string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	user, errWithCode := p.registrationUser(ctx, targetAccountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if *user.Approved {
+		err := fmt.Errorf("sign-up of account %s has already been approved", targetAccountID)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	if !user.RejectedAt.IsZero() {
+		err := fmt.Errorf("sign-up of account %s has already been rejected", targetAccountID)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	disabled := true
+	user.Disabled = &disabled
+	user.RejectedAt = time.Now()
+	if err := p.state.DB.UpdateUser(ctx, user, "disabled", "rejected_at"); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error updating user %s: %w", user.ID, err))
+	}
+
+	if err := p.emailSignupRejected(ctx, user, reason); err != nil {
+		log.Errorf(ctx, "error emailing rejected sign-up %s: %v", user.Account.Username, err)
+	}
+
+	return p.registrationAccount(ctx, user)
+}
+
+// registrationUser fetches the user belonging
+// to the local account with the given id.
+func (p *Processor) registrationUser(ctx context.Context, targetAccountID string) (*gtsmodel.User, gtserror.WithCode) {
+	user, err := p.state.DB.GetUserByAccountID(ctx, targetAccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := fmt.Errorf("no local user found for account %s", targetAccountID)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error getting user for account %s: %w", targetAccountID, err))
+	}
+
+	return user, nil
+}
+
+func (p *Processor) registrationAccount(ctx context.Context, user *gtsmodel.User) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	apiAccount, err := p.tc.AccountToAdminAPIAccount(ctx, user.Account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting account to api: %w", err))
+	}
+
+	return apiAccount, nil
+}
+
+// emailSignupApproved lets the given user know that their sign-up has been
+// approved, provided email sending is configured, and the user gave us an
+// email address to send it to.
+func (p *Processor) emailSignupApproved(ctx context.Context, user *gtsmodel.User) error {
+	if config.GetSMTPHost() == "" {
+		// Email sending not configured.
+		return nil
+	}
+
+	toAddress := user.Email
+	if toAddress == "" {
+		toAddress = user.UnconfirmedEmail
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	signupApprovedData := email.SignupApprovedData{
+		Username:     user.Account.Username,
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+		Confirmed:    !user.ConfirmedAt.IsZero(),
+	}
+
+	return p.emailSender.SendSignupApprovedEmail(toAddress, signupApprovedData)
+}
+
+// emailSignupRejected lets the given user know that their sign-up has been
+// rejected, provided email sending is configured, and the user gave us an
+// email address to send it to.
+func (p *Processor) emailSignupRejected(ctx context.Context, user *gtsmodel.User, reason string) error {
+	if config.GetSMTPHost() == "" {
+		// Email sending not configured.
+		return nil
+	}
+
+	toAddress := user.Email
+	if toAddress == "" {
+		toAddress = user.UnconfirmedEmail
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	signupRejectedData := email.SignupRejectedData{
+		Username:     user.Account.Username,
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+		Reason:       reason,
+	}
+
+	return p.emailSender.SendSignupRejectedEmail(toAddress, signupRejectedData)
+}
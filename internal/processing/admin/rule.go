@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// RuleCreate creates a new instance rule with the given text.
+func (p *Processor) RuleCreate(ctx context.Context, form *apimodel.InstanceRuleCreateRequest) (*apimodel.InstanceRule, gtserror.WithCode) {
+	if err := validate.InstanceRule(form.Text); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	rule := &gtsmodel.Rule{
+		ID:   id.NewULID(),
+		Text: text.SanitizePlaintext(form.Text),
+	}
+
+	if err := p.state.DB.PutRule(ctx, rule); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error putting new rule: %w", err))
+	}
+
+	apiRule, err := p.tc.RuleToAPIRule(ctx, rule)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiRule, nil
+}
+
+// RuleUpdate updates the text of the instance rule with the given id.
+func (p *Processor) RuleUpdate(ctx context.Context, id string, form *apimodel.InstanceRuleUpdateRequest) (*apimodel.InstanceRule, gtserror.WithCode) {
+	if err := validate.InstanceRule(form.Text); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	rule, err := p.state.DB.GetRuleByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	rule.Text = text.SanitizePlaintext(form.Text)
+
+	rule, err = p.state.DB.UpdateRule(ctx, rule, "text")
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error updating rule %s: %w", id, err))
+	}
+
+	apiRule, err := p.tc.RuleToAPIRule(ctx, rule)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiRule, nil
+}
+
+// RuleDelete deletes the instance rule with the given id,
+// returning the rule as it was before it was deleted.
+func (p *Processor) RuleDelete(ctx context.Context, id string) (*apimodel.InstanceRule, gtserror.WithCode) {
+	rule, err := p.state.DB.GetRuleByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteRuleByID(ctx, id); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error deleting rule %s: %w", id, err))
+	}
+
+	apiRule, err := p.tc.RuleToAPIRule(ctx, rule)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiRule, nil
+}
@@ -0,0 +1,93 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type BlockTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *BlockTestSuite) TestBlockRemoveRemote() {
+	ctx := context.Background()
+	block := suite.testBlocks["local_account_2_block_remote_account_1"]
+	blockingAccount := suite.testAccounts["local_account_2"]
+	blockedAccount := suite.testAccounts["remote_account_1"]
+
+	relationship, errWithCode := suite.processor.Account().BlockRemove(ctx, blockingAccount, blockedAccount.ID)
+	suite.NoError(errWithCode)
+	suite.False(relationship.Blocking)
+
+	// The block should be gone from the db.
+	_, err := suite.db.GetBlockByID(ctx, block.ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+
+	// An Undo{Block} should be federated
+	// to the blocked account's inbox.
+	var sent [][]byte
+	undo := new(struct {
+		Actor  string `json:"actor"`
+		ID     string `json:"id"`
+		Object struct {
+			Actor  string `json:"actor"`
+			ID     string `json:"id"`
+			Object string `json:"object"`
+			To     string `json:"to"`
+			Type   string `json:"type"`
+		}
+		To   string `json:"to"`
+		Type string `json:"type"`
+	})
+
+	if !testrig.WaitFor(func() bool {
+		sentI, ok := suite.httpClient.SentMessages.Load(*blockedAccount.SharedInboxURI)
+		if ok {
+			sent, ok = sentI.([][]byte)
+			if !ok {
+				panic("SentMessages entry was not [][]byte")
+			}
+			err = json.Unmarshal(sent[0], undo)
+			return err == nil
+		}
+		return false
+	}) {
+		suite.FailNow("timed out waiting for message")
+	}
+
+	suite.Equal(blockingAccount.URI, undo.Actor)
+	suite.Equal(blockedAccount.URI, undo.To)
+	suite.Equal("Undo", undo.Type)
+	suite.Equal(blockingAccount.URI, undo.Object.Actor)
+	suite.Equal(block.URI, undo.Object.ID)
+	suite.Equal(blockedAccount.URI, undo.Object.Object)
+	suite.Equal(blockedAccount.URI, undo.Object.To)
+	suite.Equal("Block", undo.Object.Type)
+}
+
+func TestBlockTestSuite(t *testing.T) {
+	suite.Run(t, &BlockTestSuite{})
+}
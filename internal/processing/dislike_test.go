@@ -0,0 +1,166 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DislikeTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *DislikeTestSuite) TestDislikeRemote() {
+	ctx := context.Background()
+	dislikingAccount := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["remote_account_1"]
+	targetStatus := suite.testStatuses["remote_account_1_status_1"]
+
+	// Fave the status first, so we can
+	// check the Dislike replaces the fave.
+	if _, errWithCode := suite.processor.Status().FaveCreate(ctx, dislikingAccount, targetStatus.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	fave, err := suite.db.GetStatusFave(ctx, dislikingAccount.ID, targetStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiStatus, errWithCode := suite.processor.Status().Dislike(ctx, dislikingAccount, targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.False(apiStatus.Favourited)
+
+	// The fave should be gone from the db,
+	// replaced by a dislike of the status.
+	_, err = suite.db.GetStatusFave(ctx, dislikingAccount.ID, targetStatus.ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+
+	dislike, err := suite.db.GetStatusDislike(ctx, dislikingAccount.ID, targetStatus.ID)
+	suite.NoError(err)
+	suite.Equal(targetAccount.ID, dislike.TargetAccountID)
+
+	// As well as the Like from the fave, an
+	// Undo of that Like and then a Dislike
+	// should be federated to the target
+	// account's inbox.
+	type activity struct {
+		Actor  string          `json:"actor"`
+		ID     string          `json:"id"`
+		Object json.RawMessage `json:"object"`
+		To     string          `json:"to"`
+		Type   string          `json:"type"`
+	}
+
+	var sent []activity
+	if !testrig.WaitFor(func() bool {
+		sentI, ok := suite.httpClient.SentMessages.Load(*targetAccount.SharedInboxURI)
+		if !ok {
+			return false
+		}
+
+		sent = sent[:0]
+		for _, s := range sentI.([][]byte) {
+			var a activity
+			if err := json.Unmarshal(s, &a); err != nil {
+				suite.FailNow(err.Error())
+			}
+			sent = append(sent, a)
+		}
+		return len(sent) == 3
+	}) {
+		suite.FailNow("timed out waiting for messages")
+	}
+
+	byType := make(map[string]activity, len(sent))
+	for _, a := range sent {
+		byType[a.Type] = a
+	}
+
+	suite.Equal(fave.URI, byType["Like"].ID)
+
+	undo := byType["Undo"]
+	suite.Equal(dislikingAccount.URI, undo.Actor)
+	undone := new(struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	})
+	if err := json.Unmarshal(undo.Object, undone); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("Like", undone.Type)
+	suite.Equal(fave.URI, undone.ID)
+
+	disliked := byType["Dislike"]
+	suite.Equal(dislikingAccount.URI, disliked.Actor)
+	suite.Equal(dislike.URI, disliked.ID)
+	suite.Equal(`"`+targetStatus.URI+`"`, string(disliked.Object))
+	suite.Equal(targetAccount.URI, disliked.To)
+}
+
+func (suite *DislikeTestSuite) TestDislikeRemovesFaveNotification() {
+	ctx := context.Background()
+	dislikingAccount := suite.testAccounts["local_account_2"]
+	targetAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	if _, errWithCode := suite.processor.Status().FaveCreate(ctx, dislikingAccount, targetStatus.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	notified := func() bool {
+		_, err := suite.db.GetNotification(
+			ctx,
+			gtsmodel.NotificationFave,
+			targetAccount.ID,
+			dislikingAccount.ID,
+			targetStatus.ID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			suite.FailNow(err.Error())
+		}
+		return err == nil
+	}
+
+	// The fave should notify the status author.
+	if !testrig.WaitFor(notified) {
+		suite.FailNow("timed out waiting for fave notification")
+	}
+
+	if _, errWithCode := suite.processor.Status().Dislike(ctx, dislikingAccount, targetStatus.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Replacing the fave with a dislike
+	// should remove the notification.
+	if !testrig.WaitFor(func() bool { return !notified() }) {
+		suite.FailNow("timed out waiting for fave notification to be removed")
+	}
+}
+
+func TestDislikeTestSuite(t *testing.T) {
+	suite.Run(t, &DislikeTestSuite{})
+}
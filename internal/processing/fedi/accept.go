@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package fedi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// AcceptGet handles the getting of a fedi/activitypub representation of an Accept of an interaction
+// with one of the requested account's statuses, performing appropriate authentication before returning
+// a JSON serializable interface to the caller. Remote instances can use this to verify the approval
+// URI that an approved reply, boost, or like was stamped with.
+func (p *Processor) AcceptGet(ctx context.Context, requestedUsername string, requestedAcceptID string) (interface{}, gtserror.WithCode) {
+	requestedAccount, requestingAccount, errWithCode := p.authenticate(ctx, requestedUsername)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	req, err := p.state.DB.GetInteractionRequestByID(ctx, requestedAcceptID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if req.TargetAccountID != requestedAccount.ID || !req.IsAccepted() {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("accept with id %s does not belong to account with id %s", req.ID, requestedAccount.ID))
+	}
+
+	visible, err := p.filter.StatusVisible(ctx, requestingAccount, req.Status)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	if !visible {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("status with id %s not visible to user with id %s", req.StatusID, requestingAccount.ID))
+	}
+
+	asAccept, err := p.tc.InteractionRequestToASAccept(ctx, req)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	data, err := ap.Serialize(asAccept)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return data, nil
+}
@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"net/url"
 
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -57,3 +59,30 @@ func (p *Processor) authenticate(ctx context.Context, requestedUsername string)
 
 	return
 }
+
+// gone returns the given error as 410 Gone, along with a serialized
+// ActivityStreams Tombstone for the given uri, which callers should
+// write as the response body, to tell remote instances that the
+// Actor or Object of the given former type is gone for good.
+func gone(uri string, formerType string, err error) (interface{}, gtserror.WithCode) {
+	tombstone := streams.NewActivityStreamsTombstone()
+
+	idIRI, e := url.Parse(uri)
+	if e != nil {
+		return nil, gtserror.NewErrorInternalError(e)
+	}
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.Set(idIRI)
+	tombstone.SetJSONLDId(idProp)
+
+	formerTypeProp := streams.NewActivityStreamsFormerTypeProperty()
+	formerTypeProp.AppendXMLSchemaString(formerType)
+	tombstone.SetActivityStreamsFormerType(formerTypeProp)
+
+	data, e := ap.Serialize(tombstone)
+	if e != nil {
+		return nil, gtserror.NewErrorInternalError(e)
+	}
+
+	return data, gtserror.NewErrorGone(err)
+}
@@ -19,12 +19,15 @@ package fedi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
 // StatusGet handles the getting of a fedi/activitypub representation of a particular status, performing appropriate
@@ -37,6 +40,23 @@ func (p *Processor) StatusGet(ctx context.Context, requestedUsername string, req
 
 	status, err := p.state.DB.GetStatusByID(ctx, requestedStatusID)
 	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// The status may have been deleted,
+		// in which case it left a tombstone.
+		statusURI := uris.GenerateURIsForAccount(requestedAccount.Username).StatusesURI + "/" + requestedStatusID
+		deleted, dbErr := p.state.DB.TombstoneExistsWithURI(ctx, statusURI)
+		if dbErr != nil {
+			return nil, gtserror.NewErrorInternalError(dbErr)
+		}
+
+		if deleted {
+			err := fmt.Errorf("status with id %s was deleted", requestedStatusID)
+			return gone(statusURI, ap.ObjectNote, err)
+		}
+
 		return nil, gtserror.NewErrorNotFound(err)
 	}
 
@@ -132,6 +152,11 @@ func (p *Processor) StatusRepliesGet(ctx context.Context, requestedUsername stri
 		// filter children and extract URIs
 		replyURIs := map[string]*url.URL{}
 		for _, r := range replies {
+			// don't show replies awaiting approval
+			if r.IsPendingApproval() {
+				continue
+			}
+
 			// only show public or unlocked statuses as replies
 			if r.Visibility != gtsmodel.VisibilityPublic && r.Visibility != gtsmodel.VisibilityUnlocked {
 				continue
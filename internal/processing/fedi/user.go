@@ -47,6 +47,12 @@ func (p *Processor) UserGet(ctx context.Context, requestedUsername string, reque
 			return nil, gtserror.NewErrorInternalError(err)
 		}
 	} else {
+		// a suspended account is gone until it's unsuspended; only its public key is still served, see above
+		if !requestedAccount.SuspendedAt.IsZero() {
+			err := fmt.Errorf("account %s is suspended", requestedAccount.ID)
+			return gone(requestedAccount.URI, ap.ActorPerson, err)
+		}
+
 		// if it's any other path, we want to fully authenticate the request before we serve any data, and then we can serve a more complete profile
 		requestingAccountURI, errWithCode := p.federator.AuthenticateFederatedRequest(ctx, requestedUsername)
 		if errWithCode != nil {
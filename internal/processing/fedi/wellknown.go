@@ -123,6 +123,10 @@ func (p *Processor) WebfingerGet(ctx context.Context, requestedUsername string)
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("database error getting account with username %s: %s", requestedUsername, err))
 	}
 
+	if !requestedAccount.SuspendedAt.IsZero() {
+		return nil, gtserror.NewErrorGone(fmt.Errorf("account with username %s is suspended", requestedUsername))
+	}
+
 	return &apimodel.WellKnownResponse{
 		Subject: webfingerAccount + ":" + requestedAccount.Username + "@" + config.GetAccountDomain(),
 		Aliases: []string{
@@ -19,6 +19,7 @@ package processing
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -27,7 +28,10 @@ import (
 	"codeberg.org/gruf/go-logger/v2/level"
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -75,6 +79,15 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages
 		case ap.ActivityBlock:
 			// CREATE BLOCK
 			return p.processCreateBlockFromClientAPI(ctx, clientMsg)
+		case ap.ActivityQuestion:
+			// CREATE POLL VOTE
+			return p.processCreatePollVoteFromClientAPI(ctx, clientMsg)
+		case ap.ActivityBite:
+			// CREATE BITE
+			return p.processCreateBiteFromClientAPI(ctx, clientMsg)
+		case ap.ActivityDislike:
+			// CREATE DISLIKE
+			return p.processCreateDislikeFromClientAPI(ctx, clientMsg)
 		}
 	case ap.ActivityUpdate:
 		// UPDATE
@@ -88,15 +101,23 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages
 		}
 	case ap.ActivityAccept:
 		// ACCEPT
-		if clientMsg.APObjectType == ap.ActivityFollow {
+		switch clientMsg.APObjectType {
+		case ap.ActivityFollow:
 			// ACCEPT FOLLOW
 			return p.processAcceptFollowFromClientAPI(ctx, clientMsg)
+		case ap.ActivityLike, ap.ObjectNote, ap.ActivityAnnounce:
+			// ACCEPT PENDING LIKE/REPLY/ANNOUNCE
+			return p.processAcceptInteractionFromClientAPI(ctx, clientMsg)
 		}
 	case ap.ActivityReject:
 		// REJECT
-		if clientMsg.APObjectType == ap.ActivityFollow {
-			// REJECT FOLLOW (request)
+		switch clientMsg.APObjectType {
+		case ap.ActivityFollow:
+			// REJECT FOLLOW (request, or removed follower)
 			return p.processRejectFollowFromClientAPI(ctx, clientMsg)
+		case ap.ActivityLike, ap.ObjectNote, ap.ActivityAnnounce:
+			// REJECT PENDING LIKE/REPLY/ANNOUNCE
+			return p.processRejectInteractionFromClientAPI(ctx, clientMsg)
 		}
 	case ap.ActivityUndo:
 		// UNDO
@@ -130,6 +151,12 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages
 			// FLAG/REPORT A PROFILE
 			return p.processReportAccountFromClientAPI(ctx, clientMsg)
 		}
+	case ap.ActivityListen:
+		// LISTEN
+		if clientMsg.APObjectType == ap.ObjectAudio {
+			// LISTEN TO A TRACK
+			return p.processListenFromClientAPI(ctx, clientMsg)
+		}
 	}
 	return nil
 }
@@ -152,7 +179,22 @@ func (p *Processor) processCreateAccountFromClientAPI(ctx context.Context, clien
 	}
 
 	// email a confirmation to this user
-	return p.User().EmailSendConfirmation(ctx, user, account.Username)
+	if err := p.User().EmailSendConfirmation(ctx, user, account.Username); err != nil {
+		return err
+	}
+
+	if *user.Approved {
+		// Nothing to review.
+		return nil
+	}
+
+	// let the user know their signup is
+	// awaiting review, and tell the mods
+	if err := p.User().EmailSignupReceived(ctx, user, account.Username); err != nil {
+		log.Errorf(ctx, "error emailing new signup %s: %v", account.Username, err)
+	}
+
+	return p.User().EmailNewSignup(ctx, user, account)
 }
 
 func (p *Processor) processCreateStatusFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
@@ -175,6 +217,8 @@ func (p *Processor) processCreateStatusFromClientAPI(ctx context.Context, client
 		return gtserror.Newf("error federating status: %w", err)
 	}
 
+	p.enqueuePreviewCard(status)
+
 	return nil
 }
 
@@ -188,9 +232,34 @@ func (p *Processor) processCreateFollowRequestFromClientAPI(ctx context.Context,
 		return err
 	}
 
+	if err := p.backfillFollowed(ctx, clientMsg.OriginAccount, clientMsg.TargetAccount); err != nil {
+		log.Errorf(ctx, "error backfilling followed account: %v", err)
+	}
+
 	return p.federateFollow(ctx, followRequest, clientMsg.OriginAccount, clientMsg.TargetAccount)
 }
 
+// backfillFollowed enqueues backfilling the recent statuses
+// of the followed account, if it's a remote account that we
+// have no statuses of yet: eg., it was discovered through a
+// search and nobody here has followed it before.
+func (p *Processor) backfillFollowed(ctx context.Context, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) error {
+	if targetAccount.IsLocal() {
+		return nil
+	}
+
+	count, err := p.state.DB.CountAccountStatuses(ctx, targetAccount.ID)
+	if err != nil {
+		return gtserror.Newf("error counting statuses of account %s: %w", targetAccount.ID, err)
+	}
+
+	if count == 0 {
+		p.federator.BackfillAccountAsync(ctx, originAccount.Username, targetAccount)
+	}
+
+	return nil
+}
+
 func (p *Processor) processCreateFaveFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
 	statusFave, ok := clientMsg.GTSModel.(*gtsmodel.StatusFave)
 	if !ok {
@@ -258,6 +327,50 @@ func (p *Processor) processCreateBlockFromClientAPI(ctx context.Context, clientM
 	return p.federateBlock(ctx, block)
 }
 
+func (p *Processor) processCreateBiteFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	bite, ok := clientMsg.GTSModel.(*gtsmodel.Bite)
+	if !ok {
+		return gtserror.New("bite was not parseable as *gtsmodel.Bite")
+	}
+
+	if err := p.notifyBite(ctx, bite); err != nil {
+		return gtserror.Newf("error notifying bite: %w", err)
+	}
+
+	if err := p.federateBite(ctx, bite); err != nil {
+		return gtserror.Newf("error federating bite: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Processor) processCreateDislikeFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	dislike, ok := clientMsg.GTSModel.(*gtsmodel.StatusDislike)
+	if !ok {
+		return gtserror.New("dislike was not parseable as *gtsmodel.StatusDislike")
+	}
+
+	// Interaction counts changed on the disliked
+	// (and possibly previously faved) status;
+	// uncache the prepared version from all timelines.
+	p.invalidateStatusFromTimelines(ctx, dislike.StatusID)
+
+	if err := p.federateDislike(ctx, dislike); err != nil {
+		return gtserror.Newf("error federating dislike: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Processor) processCreatePollVoteFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	vote, ok := clientMsg.GTSModel.(*gtsmodel.PollVote)
+	if !ok {
+		return gtserror.New("vote was not parseable as *gtsmodel.PollVote")
+	}
+
+	return p.federatePollVote(ctx, vote, clientMsg.OriginAccount)
+}
+
 func (p *Processor) processUpdateAccountFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
 	account, ok := clientMsg.GTSModel.(*gtsmodel.Account)
 	if !ok {
@@ -296,12 +409,92 @@ func (p *Processor) processAcceptFollowFromClientAPI(ctx context.Context, client
 }
 
 func (p *Processor) processRejectFollowFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
-	followRequest, ok := clientMsg.GTSModel.(*gtsmodel.FollowRequest)
+	switch model := clientMsg.GTSModel.(type) {
+	case *gtsmodel.FollowRequest:
+		return p.federateRejectFollowRequest(ctx, model)
+	case *gtsmodel.Follow:
+		// Follower was removed after the follow was
+		// already accepted, so reject it retroactively.
+		return p.federateRejectFollow(ctx, model)
+	default:
+		return errors.New("reject was not parseable as *gtsmodel.FollowRequest or *gtsmodel.Follow")
+	}
+}
+
+func (p *Processor) processAcceptInteractionFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	req, ok := clientMsg.GTSModel.(*gtsmodel.InteractionRequest)
 	if !ok {
-		return errors.New("reject was not parseable as *gtsmodel.FollowRequest")
+		return gtserror.New("accept was not parseable as *gtsmodel.InteractionRequest")
+	}
+
+	// The pending notification has served
+	// its purpose; replace it with a normal one.
+	p.deletePendingNotification(ctx, req)
+
+	switch req.InteractionType {
+	case gtsmodel.InteractionLike:
+		if err := p.notifyFave(ctx, req.Like); err != nil {
+			return gtserror.Newf("error notifying status fave: %w", err)
+		}
+	case gtsmodel.InteractionReply:
+		if err := p.timelineAndNotifyStatus(ctx, req.Reply); err != nil {
+			return gtserror.Newf("error timelining reply: %w", err)
+		}
+	case gtsmodel.InteractionAnnounce:
+		if err := p.timelineAndNotifyStatus(ctx, req.Announce); err != nil {
+			return gtserror.Newf("error timelining boost: %w", err)
+		}
+
+		if err := p.notifyAnnounce(ctx, req.Announce); err != nil {
+			return gtserror.Newf("error notifying boost: %w", err)
+		}
 	}
 
-	return p.federateRejectFollowRequest(ctx, followRequest)
+	// Interaction counts changed on the target status;
+	// uncache the prepared version from all timelines.
+	p.invalidateStatusFromTimelines(ctx, req.StatusID)
+
+	if err := p.federateAcceptInteraction(ctx, req); err != nil {
+		return gtserror.Newf("error federating accept: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Processor) processRejectInteractionFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	req, ok := clientMsg.GTSModel.(*gtsmodel.InteractionRequest)
+	if !ok {
+		return gtserror.New("reject was not parseable as *gtsmodel.InteractionRequest")
+	}
+
+	p.deletePendingNotification(ctx, req)
+
+	switch req.InteractionType {
+	case gtsmodel.InteractionLike:
+		if err := p.state.DB.DeleteStatusFaveByID(ctx, req.InteractionID); err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error deleting fave: %w", err)
+		}
+	case gtsmodel.InteractionReply, gtsmodel.InteractionAnnounce:
+		status, err := p.state.DB.GetStatusByID(ctx, req.InteractionID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting status: %w", err)
+		}
+
+		if status != nil {
+			// Rejected interactions were never
+			// timelined, but may have media attached.
+			deleteAttachments := true
+			if err := p.wipeStatus(ctx, status, deleteAttachments); err != nil {
+				return gtserror.Newf("error wiping status: %w", err)
+			}
+		}
+	}
+
+	if err := p.federateRejectInteraction(ctx, req); err != nil {
+		return gtserror.Newf("error federating reject: %w", err)
+	}
+
+	return nil
 }
 
 func (p *Processor) processUndoFollowFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
@@ -326,6 +519,10 @@ func (p *Processor) processUndoFaveFromClientAPI(ctx context.Context, clientMsg
 		return gtserror.New("statusFave was not parseable as *gtsmodel.StatusFave")
 	}
 
+	if err := p.unnotifyFave(ctx, statusFave); err != nil {
+		return gtserror.Newf("error removing status fave notification: %w", err)
+	}
+
 	// Interaction counts changed on the faved status;
 	// uncache the prepared version from all timelines.
 	p.invalidateStatusFromTimelines(ctx, statusFave.StatusID)
@@ -347,6 +544,10 @@ func (p *Processor) processUndoAnnounceFromClientAPI(ctx context.Context, client
 		return gtserror.Newf("db error deleting boost: %w", err)
 	}
 
+	if err := p.unnotifyAnnounce(ctx, status); err != nil {
+		return gtserror.Newf("error removing boost notification: %w", err)
+	}
+
 	if err := p.deleteStatusFromTimelines(ctx, status.ID); err != nil {
 		return gtserror.Newf("error removing boost from timelines: %w", err)
 	}
@@ -372,10 +573,10 @@ func (p *Processor) processDeleteStatusFromClientAPI(ctx context.Context, client
 		return gtserror.Newf("db error populating status: %w", err)
 	}
 
-	// Don't delete attachments, just unattach them: this
-	// request comes from the client API and the poster
-	// may want to use attachments again in a new post.
-	deleteAttachments := false
+	// Any attachments the poster wanted to keep for
+	// a redraft have already been unattached by the
+	// status processor, so delete whatever remains.
+	deleteAttachments := true
 	if err := p.wipeStatus(ctx, status, deleteAttachments); err != nil {
 		return gtserror.Newf("error wiping status: %w", err)
 	}
@@ -430,8 +631,60 @@ func (p *Processor) processReportAccountFromClientAPI(ctx context.Context, clien
 	return nil
 }
 
+func (p *Processor) processListenFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	listen, ok := clientMsg.GTSModel.(*gtsmodel.Listen)
+	if !ok {
+		return errors.New("listen was not parseable as *gtsmodel.Listen")
+	}
+
+	return p.federateListen(ctx, listen)
+}
+
 // TODO: move all the below functions into federation.Federator
 
+// send sends the given activity out via the given outbox, unless the outbox belongs
+// to a local account pending self-deletion: while that deletion may still be cancelled,
+// nothing new is federated out on the account's behalf. Deletes are always sent, as
+// they only remove content, and are needed to federate the account deletion itself.
+func (p *Processor) send(ctx context.Context, outboxIRI *url.URL, t vocab.Type) error {
+	if t.GetTypeName() != ap.ActivityDelete {
+		account, err := p.state.DB.GetAccountByOutboxURI(ctx, outboxIRI.String())
+		if err != nil {
+			return fmt.Errorf("send: db error getting account for outbox %s: %w", outboxIRI, err)
+		}
+
+		pending, err := p.pendingDeletion(ctx, account)
+		if err != nil {
+			return fmt.Errorf("send: %w", err)
+		}
+
+		if pending {
+			log.Debugf(ctx, "not sending %s for account %s pending deletion", t.GetTypeName(), account.Username)
+			return nil
+		}
+	}
+
+	_, err := p.federator.FederatingActor().Send(ctx, outboxIRI, t)
+	return err
+}
+
+// pendingDeletion returns whether the given account is a
+// local account which has scheduled its own deletion.
+func (p *Processor) pendingDeletion(ctx context.Context, account *gtsmodel.Account) (bool, error) {
+	if !account.IsLocal() || account.Username == config.GetHost() {
+		// Remote accounts and the instance
+		// account are never pending deletion.
+		return false, nil
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, account.ID)
+	if err != nil {
+		return false, fmt.Errorf("db error getting user for account %s: %w", account.ID, err)
+	}
+
+	return !user.DeleteAt.IsZero(), nil
+}
+
 func (p *Processor) federateAccountDelete(ctx context.Context, account *gtsmodel.Account) error {
 	// Do nothing if this isn't our activity.
 	if !account.IsLocal() {
@@ -481,7 +734,7 @@ func (p *Processor) federateAccountDelete(ctx context.Context, account *gtsmodel
 	deleteCC.AppendIRI(publicIRI)
 	delete.SetActivityStreamsCc(deleteCC)
 
-	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, delete)
+	err = p.send(ctx, outboxIRI, delete)
 	return err
 }
 
@@ -509,7 +762,7 @@ func (p *Processor) federateStatus(ctx context.Context, status *gtsmodel.Status)
 		return fmt.Errorf("federateStatus: error converting status to as format: %s", err)
 	}
 
-	create, err := p.tc.WrapNoteInCreate(asStatus, false)
+	create, err := p.tc.WrapStatusableInCreate(asStatus, false)
 	if err != nil {
 		return fmt.Errorf("federateStatus: error wrapping status in create: %s", err)
 	}
@@ -519,8 +772,111 @@ func (p *Processor) federateStatus(ctx context.Context, status *gtsmodel.Status)
 		return fmt.Errorf("federateStatus: error parsing outboxURI %s: %s", status.Account.OutboxURI, err)
 	}
 
-	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, create)
-	return err
+	if err := p.send(ctx, outboxIRI, create); err != nil {
+		return err
+	}
+
+	if status.Visibility == gtsmodel.VisibilityMutualsOnly {
+		return p.federateStatusToMutuals(ctx, status, asStatus, create)
+	}
+
+	return nil
+}
+
+// federateStatusToMutuals delivers the given Create of a mutuals-only status to the
+// personal inbox of each remote mutual of the status author, addressed to only that
+// mutual, so that no recipient can see who else the status was sent to. Mutuals who
+// are mentioned are skipped, since they're cc'd on the Create already.
+func (p *Processor) federateStatusToMutuals(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	asStatus ap.Statusable,
+	create vocab.ActivityStreamsCreate,
+) error {
+	pending, err := p.pendingDeletion(ctx, status.Account)
+	if err != nil {
+		return fmt.Errorf("federateStatusToMutuals: %w", err)
+	}
+
+	if pending {
+		// Nothing is sent for accounts pending deletion.
+		return nil
+	}
+
+	follows, err := p.state.DB.GetAccountMutualFollowers(ctx, status.AccountID)
+	if err != nil {
+		return fmt.Errorf("federateStatusToMutuals: db error getting mutuals: %w", err)
+	}
+
+	mentioned := make(map[string]struct{})
+	for _, iri := range ap.ExtractCcURIs(asStatus) {
+		mentioned[iri.String()] = struct{}{}
+	}
+
+	tsport, err := p.federator.TransportController().NewTransportForUsername(ctx, status.Account.Username)
+	if err != nil {
+		return fmt.Errorf("federateStatusToMutuals: error creating transport: %w", err)
+	}
+
+	errs := make(gtserror.MultiError, 0, len(follows))
+	for _, follow := range follows {
+		mutual := follow.Account
+		if mutual == nil || mutual.IsLocal() {
+			// Local mutuals get the
+			// status through timelining.
+			continue
+		}
+
+		if _, ok := mentioned[mutual.URI]; ok {
+			continue
+		}
+
+		mutualIRI, err := url.Parse(mutual.URI)
+		if err != nil {
+			errs.Appendf("error parsing uri %s: %v", mutual.URI, err)
+			continue
+		}
+
+		inboxIRI, err := url.Parse(mutual.InboxURI)
+		if err != nil {
+			errs.Appendf("error parsing inbox uri %s: %v", mutual.InboxURI, err)
+			continue
+		}
+
+		// Address both the status and
+		// the Create to only this mutual.
+		statusTo := asStatus.GetActivityStreamsTo()
+		for statusTo.Len() != 0 {
+			statusTo.Remove(0)
+		}
+		statusTo.AppendIRI(mutualIRI)
+
+		createTo := streams.NewActivityStreamsToProperty()
+		createTo.AppendIRI(mutualIRI)
+		create.SetActivityStreamsTo(createTo)
+
+		data, err := ap.Serialize(create)
+		if err != nil {
+			errs.Appendf("error serializing create for %s: %v", mutual.URI, err)
+			continue
+		}
+
+		b, err := json.Marshal(data)
+		if err != nil {
+			errs.Appendf("error marshaling create for %s: %v", mutual.URI, err)
+			continue
+		}
+
+		if err := tsport.Deliver(ctx, b, inboxIRI); err != nil {
+			errs.Appendf("error delivering to %s: %v", inboxIRI, err)
+		}
+	}
+
+	if err := errs.Combine(); err != nil {
+		return fmt.Errorf("federateStatusToMutuals: %w", err)
+	}
+
+	return nil
 }
 
 func (p *Processor) federateStatusDelete(ctx context.Context, status *gtsmodel.Status) error {
@@ -547,7 +903,7 @@ func (p *Processor) federateStatusDelete(ctx context.Context, status *gtsmodel.S
 		return fmt.Errorf("federateStatusDelete: error parsing outboxURI %s: %w", status.Account.OutboxURI, err)
 	}
 
-	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, delete)
+	err = p.send(ctx, outboxIRI, delete)
 	return err
 }
 
@@ -569,7 +925,7 @@ func (p *Processor) federateFollow(ctx context.Context, followRequest *gtsmodel.
 		return fmt.Errorf("federateFollow: error parsing outboxURI %s: %s", originAccount.OutboxURI, err)
 	}
 
-	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, asFollow)
+	err = p.send(ctx, outboxIRI, asFollow)
 	return err
 }
 
@@ -610,7 +966,7 @@ func (p *Processor) federateUnfollow(ctx context.Context, follow *gtsmodel.Follo
 	}
 
 	// send off the Undo
-	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, undo)
+	err = p.send(ctx, outboxIRI, undo)
 	return err
 }
 
@@ -649,7 +1005,7 @@ func (p *Processor) federateUnfave(ctx context.Context, fave *gtsmodel.StatusFav
 	if err != nil {
 		return fmt.Errorf("federateFave: error parsing outboxURI %s: %s", originAccount.OutboxURI, err)
 	}
-	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, undo)
+	err = p.send(ctx, outboxIRI, undo)
 	return err
 }
 
@@ -684,7 +1040,7 @@ func (p *Processor) federateUnannounce(ctx context.Context, boost *gtsmodel.Stat
 		return fmt.Errorf("federateUnannounce: error parsing outboxURI %s: %s", originAccount.OutboxURI, err)
 	}
 
-	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, undo)
+	err = p.send(ctx, outboxIRI, undo)
 	return err
 }
 
@@ -753,28 +1109,75 @@ func (p *Processor) federateAcceptFollowRequest(ctx context.Context, follow *gts
 	}
 
 	// send off the accept using the accepter's outbox
-	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, accept)
+	err = p.send(ctx, outboxIRI, accept)
+	return err
+}
+
+func (p *Processor) federateAcceptInteraction(ctx context.Context, req *gtsmodel.InteractionRequest) error {
+	// Do nothing if the interacting account is local.
+	if req.InteractingAccount.IsLocal() {
+		return nil
+	}
+
+	accept, err := p.tc.InteractionRequestToASAccept(ctx, req)
+	if err != nil {
+		return gtserror.Newf("error converting interaction request to accept: %w", err)
+	}
+
+	outboxIRI, err := url.Parse(req.TargetAccount.OutboxURI)
+	if err != nil {
+		return gtserror.Newf("error parsing outboxURI %s: %w", req.TargetAccount.OutboxURI, err)
+	}
+
+	// send off the accept using the accepter's outbox
+	err = p.send(ctx, outboxIRI, accept)
+	return err
+}
+
+func (p *Processor) federateRejectInteraction(ctx context.Context, req *gtsmodel.InteractionRequest) error {
+	// Do nothing if the interacting account is local.
+	if req.InteractingAccount.IsLocal() {
+		return nil
+	}
+
+	reject, err := p.tc.InteractionRequestToASReject(ctx, req)
+	if err != nil {
+		return gtserror.Newf("error converting interaction request to reject: %w", err)
+	}
+
+	outboxIRI, err := url.Parse(req.TargetAccount.OutboxURI)
+	if err != nil {
+		return gtserror.Newf("error parsing outboxURI %s: %w", req.TargetAccount.OutboxURI, err)
+	}
+
+	// send off the reject using the rejecter's outbox
+	err = p.send(ctx, outboxIRI, reject)
 	return err
 }
 
 func (p *Processor) federateRejectFollowRequest(ctx context.Context, followRequest *gtsmodel.FollowRequest) error {
-	if followRequest.Account == nil {
-		a, err := p.state.DB.GetAccountByID(ctx, followRequest.AccountID)
+	// recreate the follow and reject that
+	return p.federateRejectFollow(ctx, p.tc.FollowRequestToFollow(ctx, followRequest))
+}
+
+func (p *Processor) federateRejectFollow(ctx context.Context, follow *gtsmodel.Follow) error {
+	if follow.Account == nil {
+		a, err := p.state.DB.GetAccountByID(ctx, follow.AccountID)
 		if err != nil {
 			return err
 		}
-		followRequest.Account = a
+		follow.Account = a
 	}
-	originAccount := followRequest.Account
+	originAccount := follow.Account
 
-	if followRequest.TargetAccount == nil {
-		a, err := p.state.DB.GetAccountByID(ctx, followRequest.TargetAccountID)
+	if follow.TargetAccount == nil {
+		a, err := p.state.DB.GetAccountByID(ctx, follow.TargetAccountID)
 		if err != nil {
 			return err
 		}
-		followRequest.TargetAccount = a
+		follow.TargetAccount = a
 	}
-	targetAccount := followRequest.TargetAccount
+	targetAccount := follow.TargetAccount
 
 	// Do nothing if target account *isn't* local,
 	// or both origin + target *are* local.
@@ -783,10 +1186,9 @@ func (p *Processor) federateRejectFollowRequest(ctx context.Context, followReque
 	}
 
 	// recreate the AS follow
-	follow := p.tc.FollowRequestToFollow(ctx, followRequest)
 	asFollow, err := p.tc.FollowToAS(ctx, follow, originAccount, targetAccount)
 	if err != nil {
-		return fmt.Errorf("federateUnfollow: error converting follow to as format: %s", err)
+		return fmt.Errorf("federateRejectFollow: error converting follow to as format: %s", err)
 	}
 
 	rejectingAccountURI, err := url.Parse(targetAccount.URI)
@@ -819,14 +1221,39 @@ func (p *Processor) federateRejectFollowRequest(ctx context.Context, followReque
 
 	outboxIRI, err := url.Parse(targetAccount.OutboxURI)
 	if err != nil {
-		return fmt.Errorf("federateRejectFollowRequest: error parsing outboxURI %s: %s", originAccount.OutboxURI, err)
+		return fmt.Errorf("federateRejectFollow: error parsing outboxURI %s: %s", originAccount.OutboxURI, err)
 	}
 
 	// send off the reject using the rejecting account's outbox
-	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, reject)
+	err = p.send(ctx, outboxIRI, reject)
 	return err
 }
 
+func (p *Processor) federatePollVote(ctx context.Context, vote *gtsmodel.PollVote, originAccount *gtsmodel.Account) error {
+	notes, err := p.tc.PollVoteToASNotes(ctx, vote)
+	if err != nil {
+		return gtserror.Newf("error converting vote to as format: %w", err)
+	}
+
+	outboxIRI, err := url.Parse(originAccount.OutboxURI)
+	if err != nil {
+		return gtserror.Newf("error parsing outboxURI %s: %w", originAccount.OutboxURI, err)
+	}
+
+	for _, note := range notes {
+		create, err := p.tc.WrapStatusableInCreate(note, false)
+		if err != nil {
+			return gtserror.Newf("error wrapping vote in create: %w", err)
+		}
+
+		if err := p.send(ctx, outboxIRI, create); err != nil {
+			return gtserror.Newf("error sending vote: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func (p *Processor) federateFave(ctx context.Context, fave *gtsmodel.StatusFave, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) error {
 	// Do nothing if both accounts are local.
 	if originAccount.IsLocal() && targetAccount.IsLocal() {
@@ -843,7 +1270,7 @@ func (p *Processor) federateFave(ctx context.Context, fave *gtsmodel.StatusFave,
 	if err != nil {
 		return fmt.Errorf("federateFave: error parsing outboxURI %s: %s", originAccount.OutboxURI, err)
 	}
-	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, asFave)
+	err = p.send(ctx, outboxIRI, asFave)
 	return err
 }
 
@@ -858,7 +1285,47 @@ func (p *Processor) federateAnnounce(ctx context.Context, boostWrapperStatus *gt
 		return fmt.Errorf("federateAnnounce: error parsing outboxURI %s: %s", boostingAccount.OutboxURI, err)
 	}
 
-	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, announce)
+	err = p.send(ctx, outboxIRI, announce)
+	return err
+}
+
+func (p *Processor) federateBite(ctx context.Context, bite *gtsmodel.Bite) error {
+	// Do nothing if both accounts are local.
+	if bite.Account.IsLocal() && bite.TargetAccount.IsLocal() {
+		return nil
+	}
+
+	asBite, err := p.tc.BiteToAS(ctx, bite)
+	if err != nil {
+		return gtserror.Newf("error converting bite to as format: %w", err)
+	}
+
+	outboxIRI, err := url.Parse(bite.Account.OutboxURI)
+	if err != nil {
+		return gtserror.Newf("error parsing outboxURI %s: %w", bite.Account.OutboxURI, err)
+	}
+
+	err = p.send(ctx, outboxIRI, asBite)
+	return err
+}
+
+func (p *Processor) federateDislike(ctx context.Context, dislike *gtsmodel.StatusDislike) error {
+	// Do nothing if both accounts are local.
+	if dislike.Account.IsLocal() && dislike.TargetAccount.IsLocal() {
+		return nil
+	}
+
+	asDislike, err := p.tc.StatusDislikeToAS(ctx, dislike)
+	if err != nil {
+		return gtserror.Newf("error converting dislike to as format: %w", err)
+	}
+
+	outboxIRI, err := url.Parse(dislike.Account.OutboxURI)
+	if err != nil {
+		return gtserror.Newf("error parsing outboxURI %s: %w", dislike.Account.OutboxURI, err)
+	}
+
+	err = p.send(ctx, outboxIRI, asDislike)
 	return err
 }
 
@@ -878,7 +1345,7 @@ func (p *Processor) federateAccountUpdate(ctx context.Context, updatedAccount *g
 		return fmt.Errorf("federateAnnounce: error parsing outboxURI %s: %s", originAccount.OutboxURI, err)
 	}
 
-	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, update)
+	err = p.send(ctx, outboxIRI, update)
 	return err
 }
 
@@ -914,7 +1381,7 @@ func (p *Processor) federateBlock(ctx context.Context, block *gtsmodel.Block) er
 		return fmt.Errorf("federateBlock: error parsing outboxURI %s: %s", block.Account.OutboxURI, err)
 	}
 
-	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, asBlock)
+	err = p.send(ctx, outboxIRI, asBlock)
 	return err
 }
 
@@ -968,7 +1435,7 @@ func (p *Processor) federateUnblock(ctx context.Context, block *gtsmodel.Block)
 	if err != nil {
 		return fmt.Errorf("federateUnblock: error parsing outboxURI %s: %s", block.Account.OutboxURI, err)
 	}
-	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, undo)
+	err = p.send(ctx, outboxIRI, undo)
 	return err
 }
 
@@ -1016,6 +1483,29 @@ func (p *Processor) federateReport(ctx context.Context, report *gtsmodel.Report)
 		return fmt.Errorf("federateReport: error parsing outboxURI %s: %w", instanceAccount.OutboxURI, err)
 	}
 
-	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, flag)
+	err = p.send(ctx, outboxIRI, flag)
+	return err
+}
+
+func (p *Processor) federateListen(ctx context.Context, listen *gtsmodel.Listen) error {
+	if listen.Account == nil {
+		listenAccount, err := p.state.DB.GetAccountByID(ctx, listen.AccountID)
+		if err != nil {
+			return fmt.Errorf("federateListen: error getting listen account from database: %w", err)
+		}
+		listen.Account = listenAccount
+	}
+
+	asListen, err := p.tc.ListenToAS(ctx, listen)
+	if err != nil {
+		return fmt.Errorf("federateListen: error converting listen to AS format: %w", err)
+	}
+
+	outboxIRI, err := url.Parse(listen.Account.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federateListen: error parsing outboxURI %s: %w", listen.Account.OutboxURI, err)
+	}
+
+	err = p.send(ctx, outboxIRI, asListen)
 	return err
 }
@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -176,6 +177,109 @@ func (suite *FromClientAPITestSuite) TestProcessStatusDelete() {
 	}
 }
 
+// This test ensures that a mutuals-only status is delivered to the
+// personal inbox of each remote mutual, addressed to that mutual only.
+func (suite *FromClientAPITestSuite) TestProcessNewMutualsOnlyStatus() {
+	var (
+		ctx            = context.Background()
+		postingAccount = suite.testAccounts["local_account_1"]
+		mutuals        = []*gtsmodel.Account{
+			suite.testAccounts["remote_account_1"],
+			suite.testAccounts["remote_account_2"],
+		}
+	)
+
+	// Make both remote accounts mutuals of the posting account.
+	for i, mutual := range mutuals {
+		for _, follow := range []*gtsmodel.Follow{
+			{
+				ID:              fmt.Sprintf("01H7Y3ZQ0B8G6H2Y1N4RWMV9K%d", 2*i),
+				URI:             fmt.Sprintf("%s/follow/%d", mutual.URI, i),
+				AccountID:       mutual.ID,
+				TargetAccountID: postingAccount.ID,
+			},
+			{
+				ID:              fmt.Sprintf("01H7Y3ZQ0B8G6H2Y1N4RWMV9K%d", 2*i+1),
+				URI:             fmt.Sprintf("%s/follow/%d", postingAccount.URI, i),
+				AccountID:       postingAccount.ID,
+				TargetAccountID: mutual.ID,
+			},
+		} {
+			if err := suite.db.PutFollow(ctx, follow); err != nil {
+				suite.FailNow(err.Error())
+			}
+		}
+	}
+
+	newStatus := &gtsmodel.Status{
+		ID:                       "01H7Y40M2S5QW8Y3X2C9ZKB6TD",
+		URI:                      "http://localhost:8080/users/the_mighty_zork/statuses/01H7Y40M2S5QW8Y3X2C9ZKB6TD",
+		URL:                      "http://localhost:8080/@the_mighty_zork/statuses/01H7Y40M2S5QW8Y3X2C9ZKB6TD",
+		Content:                  "this status is for mutuals only",
+		AttachmentIDs:            []string{},
+		TagIDs:                   []string{},
+		MentionIDs:               []string{},
+		EmojiIDs:                 []string{},
+		CreatedAt:                testrig.TimeMustParse("2021-10-20T11:36:45Z"),
+		UpdatedAt:                testrig.TimeMustParse("2021-10-20T11:36:45Z"),
+		Local:                    testrig.TrueBool(),
+		AccountURI:               postingAccount.URI,
+		AccountID:                postingAccount.ID,
+		Visibility:               gtsmodel.VisibilityMutualsOnly,
+		Sensitive:                testrig.FalseBool(),
+		Language:                 "en",
+		CreatedWithApplicationID: "01F8MGXQRHYF5QPMTMXP78QC2F",
+		Federated:                testrig.TrueBool(),
+		Boostable:                testrig.FalseBool(),
+		Replyable:                testrig.TrueBool(),
+		Likeable:                 testrig.TrueBool(),
+		ActivityStreamsType:      ap.ObjectNote,
+	}
+
+	if err := suite.db.PutStatus(ctx, newStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.processor.ProcessFromClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       newStatus,
+		OriginAccount:  postingAccount,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Each mutual should get its own copy, addressed
+	// only to itself, in its personal inbox.
+	for _, mutual := range mutuals {
+		var create struct {
+			To     string `json:"to"`
+			Object struct {
+				ID string `json:"id"`
+				To string `json:"to"`
+			} `json:"object"`
+		}
+
+		if !testrig.WaitFor(func() bool {
+			sentI, ok := suite.httpClient.SentMessages.Load(mutual.InboxURI)
+			if !ok {
+				return false
+			}
+			sent, ok := sentI.([][]byte)
+			if !ok {
+				panic("SentMessages entry was not [][]byte")
+			}
+			return json.Unmarshal(sent[0], &create) == nil
+		}) {
+			suite.FailNow("timed out waiting for message to " + mutual.InboxURI)
+		}
+
+		suite.Equal(newStatus.URI, create.Object.ID)
+		suite.Equal(mutual.URI, create.To)
+		suite.Equal(mutual.URI, create.Object.To)
+	}
+}
+
 func (suite *FromClientAPITestSuite) TestProcessNewStatusWithNotification() {
 	var (
 		ctx              = context.Background()
@@ -220,6 +220,27 @@ func (p *Processor) notifyStatusMentions(ctx context.Context, status *gtsmodel.S
 	errs := make(gtserror.MultiError, 0, len(status.Mentions))
 
 	for _, m := range status.Mentions {
+		if m.TargetAccount == nil {
+			target, err := p.state.DB.GetAccountByID(ctx, m.TargetAccountID)
+			if err != nil {
+				errs.Appendf("error getting mention target account %s: %v", m.TargetAccountID, err)
+				continue
+			}
+			m.TargetAccount = target
+		}
+
+		// Don't notify about mentions from accounts
+		// the target doesn't accept mentions from.
+		mentionable, err := p.filter.AccountMentionable(ctx, m.TargetAccount, m.OriginAccountID)
+		if err != nil {
+			errs.Append(err)
+			continue
+		}
+
+		if !mentionable {
+			continue
+		}
+
 		if err := p.notify(
 			ctx,
 			gtsmodel.NotificationMention,
@@ -301,6 +322,36 @@ func (p *Processor) notifyAnnounce(ctx context.Context, status *gtsmodel.Status)
 		return nil
 	}
 
+	// Keep only one boost notification per booster and
+	// boosted status. If one exists for an earlier boost
+	// wrapper (eg., the Undo of a previous boost hasn't
+	// arrived yet), remove it in favour of this one.
+	boostOf, err := p.state.DB.GetStatusByID(ctx, status.BoostOfID)
+	if err != nil {
+		return fmt.Errorf("notifyAnnounce: error getting boosted status %s: %w", status.BoostOfID, err)
+	}
+
+	boosts, err := p.state.DB.GetStatusReblogs(ctx, boostOf)
+	if err != nil {
+		return fmt.Errorf("notifyAnnounce: error getting boosts of status %s: %w", boostOf.ID, err)
+	}
+
+	for _, boost := range boosts {
+		if boost.ID == status.ID || boost.AccountID != status.AccountID {
+			continue
+		}
+
+		if err := p.deleteNotification(
+			ctx,
+			gtsmodel.NotificationReblog,
+			status.BoostOfAccountID,
+			status.AccountID,
+			boost.ID,
+		); err != nil {
+			return fmt.Errorf("notifyAnnounce: %w", err)
+		}
+	}
+
 	return p.notify(
 		ctx,
 		gtsmodel.NotificationReblog,
@@ -310,6 +361,113 @@ func (p *Processor) notifyAnnounce(ctx context.Context, status *gtsmodel.Status)
 	)
 }
 
+// unnotifyFave removes the notifications (if any) created
+// for the given fave, for when the fave is undone.
+func (p *Processor) unnotifyFave(ctx context.Context, fave *gtsmodel.StatusFave) error {
+	for _, notificationType := range []gtsmodel.NotificationType{
+		gtsmodel.NotificationFave,
+		gtsmodel.NotificationPendingFave,
+	} {
+		if err := p.deleteNotification(
+			ctx,
+			notificationType,
+			fave.TargetAccountID,
+			fave.AccountID,
+			fave.StatusID,
+		); err != nil {
+			return fmt.Errorf("unnotifyFave: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// unnotifyAnnounce removes the notifications (if any) created
+// for the given boost wrapper status, for when the boost is undone,
+// so that no notifications are left pointing at a deleted status.
+func (p *Processor) unnotifyAnnounce(ctx context.Context, status *gtsmodel.Status) error {
+	if err := p.state.DB.DeleteNotificationsForStatus(ctx, status.ID); err != nil {
+		return fmt.Errorf("unnotifyAnnounce: db error deleting notifications for boost %s: %w", status.ID, err)
+	}
+	return nil
+}
+
+// deleteNotification deletes the notification
+// with the given params, if it exists.
+func (p *Processor) deleteNotification(
+	ctx context.Context,
+	notificationType gtsmodel.NotificationType,
+	targetAccountID string,
+	originAccountID string,
+	statusID string,
+) error {
+	notif, err := p.state.DB.GetNotification(
+		gtscontext.SetBarebones(ctx),
+		notificationType,
+		targetAccountID,
+		originAccountID,
+		statusID,
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Nothing to do.
+			return nil
+		}
+		return fmt.Errorf("db error getting %s notification: %w", notificationType, err)
+	}
+
+	if err := p.state.DB.DeleteNotificationByID(ctx, notif.ID); err != nil {
+		return fmt.Errorf("db error deleting notification %s: %w", notif.ID, err)
+	}
+
+	return nil
+}
+
+// notifyBite notifies the bitten account of a bite. As
+// with other notifications, an account is only notified
+// once per account biting it (or per status bitten).
+func (p *Processor) notifyBite(ctx context.Context, bite *gtsmodel.Bite) error {
+	return p.notify(
+		ctx,
+		gtsmodel.NotificationBite,
+		bite.TargetAccountID,
+		bite.AccountID,
+		bite.StatusID,
+	)
+}
+
+// deletePendingNotification removes the notification
+// that was sent to the target account of the given
+// interaction request when it was first received, if
+// it still exists. Errors are logged but not returned,
+// since a lingering notification is harmless.
+func (p *Processor) deletePendingNotification(ctx context.Context, req *gtsmodel.InteractionRequest) {
+	var (
+		notificationType gtsmodel.NotificationType
+		statusID         = req.InteractionID
+	)
+
+	switch req.InteractionType {
+	case gtsmodel.InteractionLike:
+		notificationType = gtsmodel.NotificationPendingFave
+		statusID = req.StatusID
+	case gtsmodel.InteractionReply:
+		notificationType = gtsmodel.NotificationPendingReply
+	case gtsmodel.InteractionAnnounce:
+		notificationType = gtsmodel.NotificationPendingReblog
+	}
+
+	if err := p.deleteNotification(
+		ctx,
+		notificationType,
+		req.TargetAccountID,
+		req.InteractingAccountID,
+		statusID,
+	); err != nil {
+		log.Errorf(ctx, "error deleting pending notification: %v", err)
+	}
+}
+
 func (p *Processor) notify(
 	ctx context.Context,
 	notificationType gtsmodel.NotificationType,
@@ -327,6 +485,26 @@ func (p *Processor) notify(
 		return nil
 	}
 
+	if statusID != "" {
+		// Don't notify about statuses in threads
+		// that the target account has muted. This
+		// includes replies arriving after the mute.
+		status, err := p.state.DB.GetStatusByID(ctx, statusID)
+		if err != nil {
+			return fmt.Errorf("notify: error getting status %s: %w", statusID, err)
+		}
+
+		muted, err := p.state.DB.IsStatusMutedBy(ctx, status, targetAccountID)
+		if err != nil {
+			return fmt.Errorf("notify: error checking thread mute: %w", err)
+		}
+
+		if muted {
+			// Nothing to do.
+			return nil
+		}
+	}
+
 	// Make sure a notification doesn't
 	// already exist with these params.
 	if _, err := p.state.DB.GetNotification(
@@ -417,6 +595,11 @@ func (p *Processor) wipeStatus(ctx context.Context, statusToDelete *gtsmodel.Sta
 		return err
 	}
 
+	// delete all dislikes of this status
+	if err := p.state.DB.DeleteStatusDislikesForStatus(ctx, statusToDelete.ID); err != nil {
+		return err
+	}
+
 	// delete all boosts for this status + remove them from timelines
 	if boosts, err := p.state.DB.GetStatusReblogs(ctx, statusToDelete); err == nil {
 		for _, b := range boosts {
@@ -435,7 +618,23 @@ func (p *Processor) wipeStatus(ctx context.Context, statusToDelete *gtsmodel.Sta
 	}
 
 	// delete the status itself
-	return p.state.DB.DeleteStatusByID(ctx, statusToDelete.ID)
+	if err := p.state.DB.DeleteStatusByID(ctx, statusToDelete.ID); err != nil {
+		return err
+	}
+
+	// leave a tombstone behind for our own statuses, so
+	// remote instances are told they're gone for good
+	if statusToDelete.Local != nil && *statusToDelete.Local {
+		if err := p.state.DB.PutTombstone(ctx, &gtsmodel.Tombstone{
+			ID:     id.NewULID(),
+			Domain: config.GetHost(),
+			URI:    statusToDelete.URI,
+		}); err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // deleteStatusFromTimelines completely removes the given status from all timelines.
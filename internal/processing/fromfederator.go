@@ -26,6 +26,7 @@ import (
 	"codeberg.org/gruf/go-kv"
 	"codeberg.org/gruf/go-logger/v2/level"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -66,7 +67,7 @@ func (p *Processor) ProcessFromFederator(ctx context.Context, federatorMsg messa
 	case ap.ActivityCreate:
 		// CREATE SOMETHING
 		switch federatorMsg.APObjectType {
-		case ap.ObjectNote:
+		case ap.ObjectNote, ap.ObjectEvent, ap.ObjectArticle, ap.ObjectPage, ap.ActivityQuestion:
 			// CREATE A STATUS
 			return p.processCreateStatusFromFederator(ctx, federatorMsg)
 		case ap.ActivityLike:
@@ -84,12 +85,29 @@ func (p *Processor) ProcessFromFederator(ctx context.Context, federatorMsg messa
 		case ap.ActivityFlag:
 			// CREATE A FLAG / REPORT
 			return p.processCreateFlagFromFederator(ctx, federatorMsg)
+		case ap.ActivityBite:
+			// CREATE A BITE
+			return p.processCreateBiteFromFederator(ctx, federatorMsg)
 		}
 	case ap.ActivityUpdate:
 		// UPDATE SOMETHING
-		if federatorMsg.APObjectType == ap.ObjectProfile {
+		switch federatorMsg.APObjectType {
+		case ap.ObjectProfile:
 			// UPDATE AN ACCOUNT
 			return p.processUpdateAccountFromFederator(ctx, federatorMsg)
+		case ap.ObjectNote, ap.ObjectEvent, ap.ObjectArticle, ap.ObjectPage, ap.ActivityQuestion:
+			// UPDATE A STATUS
+			return p.processUpdateStatusFromFederator(ctx, federatorMsg)
+		}
+	case ap.ActivityUndo:
+		// UNDO SOMETHING
+		switch federatorMsg.APObjectType {
+		case ap.ActivityLike:
+			// UNDO A FAVE
+			return p.processUndoFaveFromFederator(ctx, federatorMsg)
+		case ap.ActivityAnnounce:
+			// UNDO AN ANNOUNCE
+			return p.processUndoAnnounceFromFederator(ctx, federatorMsg)
 		}
 	case ap.ActivityDelete:
 		// DELETE SOMETHING
@@ -156,16 +174,43 @@ func (p *Processor) processCreateStatusFromFederator(ctx context.Context, federa
 		return err
 	}
 
+	if status.IsPendingApproval() {
+		// This reply needs approval before it's timelined
+		// or counted, so just let the replied-to account
+		// know that there's a reply waiting for them.
+		if err := p.notify(
+			ctx,
+			gtsmodel.NotificationPendingReply,
+			status.InReplyToAccountID,
+			status.AccountID,
+			status.ID,
+		); err != nil {
+			return gtserror.Newf("error notifying pending reply: %w", err)
+		}
+
+		return nil
+	}
+
 	if status.InReplyToID != "" {
 		// Interaction counts changed on the replied status;
 		// uncache the prepared version from all timelines.
 		p.invalidateStatusFromTimelines(ctx, status.InReplyToID)
 	}
 
+	if status.IsBackfilled() {
+		// This status was fetched from the outbox of its
+		// author, it's not new: don't notify about it, nor
+		// put it at the top of timelines out of order.
+		p.enqueuePreviewCard(status)
+		return nil
+	}
+
 	if err := p.timelineAndNotifyStatus(ctx, status); err != nil {
 		return gtserror.Newf("error timelining status: %w", err)
 	}
 
+	p.enqueuePreviewCard(status)
+
 	return nil
 }
 
@@ -228,6 +273,22 @@ func (p *Processor) processCreateFaveFromFederator(ctx context.Context, federato
 		return gtserror.New("Like was not parseable as *gtsmodel.StatusFave")
 	}
 
+	if statusFave.IsPendingApproval() {
+		// This fave needs approval before it's counted, so
+		// just let the faved account know it's waiting.
+		if err := p.notify(
+			ctx,
+			gtsmodel.NotificationPendingFave,
+			statusFave.TargetAccountID,
+			statusFave.AccountID,
+			statusFave.StatusID,
+		); err != nil {
+			return gtserror.Newf("error notifying pending status fave: %w", err)
+		}
+
+		return nil
+	}
+
 	if err := p.notifyFave(ctx, statusFave); err != nil {
 		return gtserror.Newf("error notifying status fave: %w", err)
 	}
@@ -311,6 +372,19 @@ func (p *Processor) processCreateAnnounceFromFederator(ctx context.Context, fede
 		return gtserror.Newf("error dereferencing announce: %w", err)
 	}
 
+	// Some statuses can't be boosted by the booster whatever
+	// the status author's policy on boosts is, so there's
+	// nothing to approve; drop the boost without storing it.
+	boostable, err := p.boostableByVisibility(ctx, status)
+	if err != nil {
+		return gtserror.Newf("error checking boostability: %w", err)
+	}
+
+	if !boostable {
+		log.Debugf(ctx, "dropping boost %s of status %s, which %s can't boost", status.URI, status.BoostOf.URI, status.AccountURI)
+		return nil
+	}
+
 	// Generate an ID for the boost wrapper status.
 	statusID, err := id.NewULIDFromTime(status.CreatedAt)
 	if err != nil {
@@ -318,11 +392,46 @@ func (p *Processor) processCreateAnnounceFromFederator(ctx context.Context, fede
 	}
 	status.ID = statusID
 
+	if status.BoostOf.RequiresApproval(gtsmodel.InteractionAnnounce, status.AccountID) {
+		// The author of the boosted status needs to
+		// approve boosts; keep it hidden until they do.
+		pending := true
+		status.PendingApproval = &pending
+	}
+
 	// Store the boost wrapper status.
 	if err := p.state.DB.PutStatus(ctx, status); err != nil {
 		return gtserror.Newf("db error inserting status: %w", err)
 	}
 
+	if status.IsPendingApproval() {
+		// Store a request for the boost's approval,
+		// and let the boosted account know about it.
+		if err := p.state.DB.PutInteractionRequest(ctx, &gtsmodel.InteractionRequest{
+			ID:                   id.NewULID(),
+			StatusID:             status.BoostOfID,
+			TargetAccountID:      status.BoostOfAccountID,
+			InteractingAccountID: status.AccountID,
+			InteractionType:      gtsmodel.InteractionAnnounce,
+			InteractionID:        status.ID,
+			InteractionURI:       status.URI,
+		}); err != nil {
+			return gtserror.Newf("db error inserting interaction request: %w", err)
+		}
+
+		if err := p.notify(
+			ctx,
+			gtsmodel.NotificationPendingReblog,
+			status.BoostOfAccountID,
+			status.AccountID,
+			status.ID,
+		); err != nil {
+			return gtserror.Newf("error notifying pending boost: %w", err)
+		}
+
+		return nil
+	}
+
 	// Ensure boosted status ancestors dereferenced. We need at least
 	// the immediate parent (if present) to ascertain timelineability.
 	if err := p.federator.DereferenceStatusAncestors(ctx,
@@ -348,7 +457,82 @@ func (p *Processor) processCreateAnnounceFromFederator(ctx context.Context, fede
 	return nil
 }
 
+// boostableByVisibility returns whether the booster of the given
+// boost could boost the boosted status at all, leaving aside any
+// approval of boosts by the boosted status' author: direct statuses
+// can't be boosted, private statuses can only be boosted by their
+// author, and the booster must be able to see the boosted status.
+func (p *Processor) boostableByVisibility(ctx context.Context, boost *gtsmodel.Status) (bool, error) {
+	switch boost.BoostOf.Visibility {
+	case gtsmodel.VisibilityDirect:
+		return false, nil
+	case gtsmodel.VisibilityFollowersOnly, gtsmodel.VisibilityMutualsOnly:
+		if boost.AccountID != boost.BoostOf.AccountID {
+			return false, nil
+		}
+	}
+
+	return p.filter.StatusVisible(ctx, boost.Account, boost.BoostOf)
+}
+
 // processCreateBlockFromFederator handles Activity Create and Object Block
+func (p *Processor) processCreateBiteFromFederator(ctx context.Context, federatorMsg messages.FromFederator) error {
+	bite, ok := federatorMsg.GTSModel.(*gtsmodel.Bite)
+	if !ok {
+		return gtserror.New("bite was not parseable as *gtsmodel.Bite")
+	}
+
+	if err := p.notifyBite(ctx, bite); err != nil {
+		return gtserror.Newf("error notifying bite: %w", err)
+	}
+
+	return nil
+}
+
+// processUndoFaveFromFederator handles Activity Undo with Object Like.
+func (p *Processor) processUndoFaveFromFederator(ctx context.Context, federatorMsg messages.FromFederator) error {
+	statusFave, ok := federatorMsg.GTSModel.(*gtsmodel.StatusFave)
+	if !ok {
+		return gtserror.New("Like was not parseable as *gtsmodel.StatusFave")
+	}
+
+	if err := p.unnotifyFave(ctx, statusFave); err != nil {
+		return gtserror.Newf("error removing status fave notification: %w", err)
+	}
+
+	// Interaction counts changed on the faved status;
+	// uncache the prepared version from all timelines.
+	p.invalidateStatusFromTimelines(ctx, statusFave.StatusID)
+
+	return nil
+}
+
+// processUndoAnnounceFromFederator handles Activity Undo with Object Announce.
+func (p *Processor) processUndoAnnounceFromFederator(ctx context.Context, federatorMsg messages.FromFederator) error {
+	status, ok := federatorMsg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		return gtserror.New("Announce was not parseable as *gtsmodel.Status")
+	}
+
+	if err := p.state.DB.DeleteStatusByID(ctx, status.ID); err != nil {
+		return gtserror.Newf("db error deleting boost: %w", err)
+	}
+
+	if err := p.unnotifyAnnounce(ctx, status); err != nil {
+		return gtserror.Newf("error removing boost notification: %w", err)
+	}
+
+	if err := p.deleteStatusFromTimelines(ctx, status.ID); err != nil {
+		return gtserror.Newf("error removing boost from timelines: %w", err)
+	}
+
+	// Interaction counts changed on the boosted status;
+	// uncache the prepared version from all timelines.
+	p.invalidateStatusFromTimelines(ctx, status.BoostOfID)
+
+	return nil
+}
+
 func (p *Processor) processCreateBlockFromFederator(ctx context.Context, federatorMsg messages.FromFederator) error {
 	block, ok := federatorMsg.GTSModel.(*gtsmodel.Block)
 	if !ok {
@@ -362,12 +546,51 @@ func (p *Processor) processCreateBlockFromFederator(ctx context.Context, federat
 	if err := p.state.Timelines.Home.WipeItemsFromAccountID(ctx, block.TargetAccountID, block.AccountID); err != nil {
 		return err
 	}
+
+	// remove any follows or follow requests between the two accounts, in either direction;
+	// the blocking account is remote and will have done the same on its side, so there's
+	// no need to federate these removals
+	if err := p.deleteFollowsBetween(ctx, block.AccountID, block.TargetAccountID); err != nil {
+		return err
+	}
+	if err := p.deleteFollowsBetween(ctx, block.TargetAccountID, block.AccountID); err != nil {
+		return err
+	}
+
 	// TODO: same with notifications
 	// TODO: same with bookmarks
 
 	return nil
 }
 
+// deleteFollowsBetween deletes any follow or follow request from
+// the source account to the target account, if either exists.
+func (p *Processor) deleteFollowsBetween(ctx context.Context, sourceAccountID string, targetAccountID string) error {
+	follow, err := p.state.DB.GetFollow(ctx, sourceAccountID, targetAccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return fmt.Errorf("deleteFollowsBetween: error getting follow from %s targeting %s: %w", sourceAccountID, targetAccountID, err)
+	}
+
+	if follow != nil {
+		if err := p.state.DB.DeleteFollowByID(ctx, follow.ID); err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return fmt.Errorf("deleteFollowsBetween: error deleting follow from %s targeting %s: %w", sourceAccountID, targetAccountID, err)
+		}
+	}
+
+	followReq, err := p.state.DB.GetFollowRequest(ctx, sourceAccountID, targetAccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return fmt.Errorf("deleteFollowsBetween: error getting follow request from %s targeting %s: %w", sourceAccountID, targetAccountID, err)
+	}
+
+	if followReq != nil {
+		if err := p.state.DB.DeleteFollowRequestByID(ctx, followReq.ID); err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return fmt.Errorf("deleteFollowsBetween: error deleting follow request from %s targeting %s: %w", sourceAccountID, targetAccountID, err)
+		}
+	}
+
+	return nil
+}
+
 func (p *Processor) processCreateFlagFromFederator(ctx context.Context, federatorMsg messages.FromFederator) error {
 	incomingReport, ok := federatorMsg.GTSModel.(*gtsmodel.Report)
 	if !ok {
@@ -380,6 +603,39 @@ func (p *Processor) processCreateFlagFromFederator(ctx context.Context, federato
 	return p.emailReport(ctx, incomingReport)
 }
 
+// processUpdateStatusFromFederator handles Activity Update and Object Note.
+func (p *Processor) processUpdateStatusFromFederator(ctx context.Context, federatorMsg messages.FromFederator) error {
+	status, ok := federatorMsg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		return gtserror.New("Note was not parseable as *gtsmodel.Status")
+	}
+
+	// Because this was an Update, the new AP Object should be set on the message.
+	statusable, ok := federatorMsg.APObjectModel.(ap.Statusable)
+	if !ok {
+		return gtserror.New("Statusable was not parseable on update status message")
+	}
+
+	// Force refresh of the status
+	// from the updated statusable.
+	status, _, err := p.federator.RefreshStatus(
+		ctx,
+		federatorMsg.ReceivingAccount.Username,
+		status,
+		statusable,
+		true,
+	)
+	if err != nil {
+		return gtserror.Newf("error refreshing updated status: %w", err)
+	}
+
+	// Uncache the prepared
+	// version from all timelines.
+	p.invalidateStatusFromTimelines(ctx, status.ID)
+
+	return nil
+}
+
 // processUpdateAccountFromFederator handles Activity Update and Object Profile
 func (p *Processor) processUpdateAccountFromFederator(ctx context.Context, federatorMsg messages.FromFederator) error {
 	incomingAccount, ok := federatorMsg.GTSModel.(*gtsmodel.Account)
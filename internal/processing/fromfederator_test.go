@@ -86,6 +86,253 @@ func (suite *FromFederatorTestSuite) TestProcessFederationAnnounce() {
 	suite.False(*notif.Read)
 }
 
+// newAnnounce returns a boost of the given status by the
+// given account, as it would come in from federatingdb.
+func (suite *FromFederatorTestSuite) newAnnounce(uri string, boostingAccount *gtsmodel.Account, boostedStatus *gtsmodel.Status) *gtsmodel.Status {
+	return &gtsmodel.Status{
+		URI:        uri,
+		BoostOf:    &gtsmodel.Status{URI: boostedStatus.URI},
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+		AccountID:  boostingAccount.ID,
+		AccountURI: boostingAccount.URI,
+		Account:    boostingAccount,
+		Visibility: boostedStatus.Visibility,
+	}
+}
+
+func (suite *FromFederatorTestSuite) TestProcessFederationAnnounceRequiresApproval() {
+	ctx := context.Background()
+
+	// Public status, but its
+	// author doesn't allow boosts.
+	boostedStatus := new(gtsmodel.Status)
+	*boostedStatus = *suite.testStatuses["local_account_1_status_1"]
+	boostedStatus.Boostable = testrig.FalseBool()
+	if err := suite.db.UpdateStatus(ctx, boostedStatus, "boostable"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	boostingAccount := suite.testAccounts["remote_account_1"]
+	announceStatus := suite.newAnnounce("http://fossbros-anonymous.io/users/foss_satan/statuses/01H82V3ZRC1Z0K6XQ1W8D5T7NB/activity", boostingAccount, boostedStatus)
+
+	err := suite.processor.ProcessFromFederator(ctx, messages.FromFederator{
+		APObjectType:     ap.ActivityAnnounce,
+		APActivityType:   ap.ActivityCreate,
+		GTSModel:         announceStatus,
+		ReceivingAccount: suite.testAccounts["local_account_1"],
+	})
+	suite.NoError(err)
+
+	// The boost should be stored, but pending.
+	dbAnnounce, err := suite.db.GetStatusByID(ctx, announceStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbAnnounce.IsPendingApproval())
+	suite.Equal(boostedStatus.ID, dbAnnounce.BoostOfID)
+
+	// There should be a request for approval of it.
+	req, err := suite.db.GetInteractionRequestByInteractionURI(ctx, announceStatus.URI)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(boostedStatus.ID, req.StatusID)
+	suite.Equal(boostedStatus.AccountID, req.TargetAccountID)
+	suite.Equal(boostingAccount.ID, req.InteractingAccountID)
+	suite.Equal(gtsmodel.InteractionAnnounce, req.InteractionType)
+	suite.Equal(announceStatus.ID, req.InteractionID)
+	suite.True(req.AcceptedAt.IsZero())
+	suite.True(req.RejectedAt.IsZero())
+
+	// And the boosted account should be told
+	// about the pending boost, not the boost.
+	notif := &gtsmodel.Notification{}
+	err = suite.db.GetWhere(ctx, []db.Where{
+		{Key: "status_id", Value: announceStatus.ID},
+	}, notif)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.NotificationPendingReblog, notif.NotificationType)
+	suite.Equal(boostedStatus.AccountID, notif.TargetAccountID)
+	suite.Equal(boostingAccount.ID, notif.OriginAccountID)
+}
+
+func (suite *FromFederatorTestSuite) TestProcessFederationAnnounceNotBoostable() {
+	ctx := context.Background()
+	boostingAccount := suite.testAccounts["remote_account_1"]
+
+	for i, test := range []struct {
+		boostedStatus    *gtsmodel.Status
+		receivingAccount *gtsmodel.Account
+	}{
+		{
+			// Mutuals only, and not boostable.
+			boostedStatus:    suite.testStatuses["local_account_1_status_3"],
+			receivingAccount: suite.testAccounts["local_account_1"],
+		},
+		{
+			// Followers only.
+			boostedStatus:    suite.testStatuses["local_account_1_status_5"],
+			receivingAccount: suite.testAccounts["local_account_1"],
+		},
+		{
+			// Direct.
+			boostedStatus:    suite.testStatuses["local_account_2_status_6"],
+			receivingAccount: suite.testAccounts["local_account_2"],
+		},
+		{
+			// Public and not boostable, but the
+			// author blocks the boosting account.
+			boostedStatus:    suite.testStatuses["local_account_2_status_4"],
+			receivingAccount: suite.testAccounts["local_account_2"],
+		},
+	} {
+		boostedStatus := test.boostedStatus
+		announceURI := fmt.Sprintf("http://fossbros-anonymous.io/users/foss_satan/statuses/01H82V3ZRC1Z0K6XQ1W8D5T7N%d/activity", i)
+		announceStatus := suite.newAnnounce(announceURI, boostingAccount, boostedStatus)
+
+		err := suite.processor.ProcessFromFederator(ctx, messages.FromFederator{
+			APObjectType:     ap.ActivityAnnounce,
+			APActivityType:   ap.ActivityCreate,
+			GTSModel:         announceStatus,
+			ReceivingAccount: test.receivingAccount,
+		})
+		suite.NoError(err)
+
+		// There's nothing to approve, so the boost
+		// should be dropped, with no request for
+		// approval of it and no notification.
+		suite.Empty(announceStatus.ID, boostedStatus.ID)
+
+		_, err = suite.db.GetStatusByURI(ctx, announceURI)
+		suite.ErrorIs(err, db.ErrNoEntries, boostedStatus.ID)
+
+		_, err = suite.db.GetInteractionRequestByInteractionURI(ctx, announceURI)
+		suite.ErrorIs(err, db.ErrNoEntries, boostedStatus.ID)
+	}
+}
+
+func (suite *FromFederatorTestSuite) TestProcessFederationAnnounceUndoOutOfOrder() {
+	ctx := context.Background()
+	boostedStatus := suite.testStatuses["local_account_1_status_1"]
+	boostingAccount := suite.testAccounts["remote_account_1"]
+	receivingAccount := suite.testAccounts["local_account_1"]
+
+	process := func(activityType string, boost *gtsmodel.Status) {
+		err := suite.processor.ProcessFromFederator(ctx, messages.FromFederator{
+			APObjectType:     ap.ActivityAnnounce,
+			APActivityType:   activityType,
+			GTSModel:         boost,
+			ReceivingAccount: receivingAccount,
+		})
+		suite.NoError(err)
+	}
+
+	newBoost := func(uri string) *gtsmodel.Status {
+		return &gtsmodel.Status{
+			URI:        uri,
+			BoostOf:    &gtsmodel.Status{URI: boostedStatus.URI},
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+			AccountID:  boostingAccount.ID,
+			AccountURI: boostingAccount.URI,
+			Account:    boostingAccount,
+			Visibility: boostedStatus.Visibility,
+		}
+	}
+
+	reblogNotif := func(boost *gtsmodel.Status) error {
+		_, err := suite.db.GetNotification(
+			ctx,
+			gtsmodel.NotificationReblog,
+			boostedStatus.AccountID,
+			boostingAccount.ID,
+			boost.ID,
+		)
+		return err
+	}
+
+	// Boost, then boost again before
+	// the Undo of the first boost arrives.
+	boost1 := newBoost("https://example.org/some-announce-uri/1")
+	process(ap.ActivityCreate, boost1)
+	suite.NoError(reblogNotif(boost1))
+
+	boost2 := newBoost("https://example.org/some-announce-uri/2")
+	process(ap.ActivityCreate, boost2)
+
+	// Only one notification should exist
+	// for the booster + boosted status.
+	suite.ErrorIs(reblogNotif(boost1), db.ErrNoEntries)
+	suite.NoError(reblogNotif(boost2))
+
+	// The late Undo for the first boost
+	// leaves the second boost's alone.
+	process(ap.ActivityUndo, boost1)
+	_, err := suite.db.GetStatusByID(ctx, boost1.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.NoError(reblogNotif(boost2))
+
+	// Undoing the second boost leaves no
+	// notification pointing at the deleted
+	// boost wrapper status.
+	process(ap.ActivityUndo, boost2)
+	_, err = suite.db.GetStatusByID(ctx, boost2.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.ErrorIs(reblogNotif(boost2), db.ErrNoEntries)
+
+	// And boosting yet again notifies again.
+	boost3 := newBoost("https://example.org/some-announce-uri/3")
+	process(ap.ActivityCreate, boost3)
+	suite.NoError(reblogNotif(boost3))
+}
+
+func (suite *FromFederatorTestSuite) TestProcessUndoFave() {
+	ctx := context.Background()
+	favingAccount := suite.testAccounts["remote_account_1"]
+	favedStatus := suite.testStatuses["local_account_1_status_1"]
+
+	fave := &gtsmodel.StatusFave{
+		ID:              "01H7S2W3KX2CCAVVX2X8B5YA3N",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       favingAccount.ID,
+		Account:         favingAccount,
+		TargetAccountID: favedStatus.AccountID,
+		StatusID:        favedStatus.ID,
+		Status:          favedStatus,
+		URI:             favingAccount.URI + "/faves/aaaaaaaaaaaa",
+	}
+
+	for _, activityType := range []string{
+		ap.ActivityCreate,
+		ap.ActivityUndo,
+		ap.ActivityCreate,
+		ap.ActivityUndo,
+	} {
+		err := suite.processor.ProcessFromFederator(ctx, messages.FromFederator{
+			APObjectType:     ap.ActivityLike,
+			APActivityType:   activityType,
+			GTSModel:         fave,
+			ReceivingAccount: suite.testAccounts["local_account_1"],
+		})
+		suite.NoError(err)
+
+		_, err = suite.db.GetNotification(
+			ctx,
+			gtsmodel.NotificationFave,
+			favedStatus.AccountID,
+			favingAccount.ID,
+			favedStatus.ID,
+		)
+		if activityType == ap.ActivityCreate {
+			suite.NoError(err)
+		} else {
+			suite.ErrorIs(err, db.ErrNoEntries)
+		}
+	}
+}
+
 func (suite *FromFederatorTestSuite) TestProcessReplyMention() {
 	repliedAccount := suite.testAccounts["local_account_1"]
 	repliedStatus := suite.testStatuses["local_account_1_status_1"]
@@ -172,6 +419,214 @@ func (suite *FromFederatorTestSuite) TestProcessReplyMention() {
 	suite.Equal(replyingAccount.ID, notifStreamed.Account.ID)
 }
 
+func (suite *FromFederatorTestSuite) TestProcessReplyMentionBackfilled() {
+	repliedAccount := suite.testAccounts["local_account_1"]
+	repliedStatus := suite.testStatuses["local_account_1_status_1"]
+	replyingAccount := suite.testAccounts["remote_account_1"]
+
+	// The same reply as above, but fetched
+	// when backfilling the replying account.
+	replyingStatus := &gtsmodel.Status{
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		URI:       "http://fossbros-anonymous.io/users/foss_satan/statuses/106221634728637552",
+		URL:       "http://fossbros-anonymous.io/@foss_satan/106221634728637552",
+		Content:   `<p><span class="h-card"><a href="http://localhost:8080/@the_mighty_zork" class="u-url mention">@<span>the_mighty_zork</span></a></span> nice there it is</p>`,
+		Mentions: []*gtsmodel.Mention{
+			{
+				TargetAccountURI: repliedAccount.URI,
+				NameString:       "@the_mighty_zork@localhost:8080",
+			},
+		},
+		AccountID:           replyingAccount.ID,
+		AccountURI:          replyingAccount.URI,
+		InReplyToID:         repliedStatus.ID,
+		InReplyToURI:        repliedStatus.URI,
+		InReplyToAccountID:  repliedAccount.ID,
+		Visibility:          gtsmodel.VisibilityUnlocked,
+		ActivityStreamsType: ap.ObjectNote,
+		Federated:           testrig.TrueBool(),
+		Boostable:           testrig.TrueBool(),
+		Replyable:           testrig.TrueBool(),
+		Likeable:            testrig.FalseBool(),
+		Backfilled:          testrig.TrueBool(),
+	}
+
+	wssStream, errWithCode := suite.processor.Stream().Open(context.Background(), repliedAccount, stream.TimelineHome)
+	suite.NoError(errWithCode)
+
+	statusID, err := id.NewULIDFromTime(replyingStatus.CreatedAt)
+	suite.NoError(err)
+	replyingStatus.ID = statusID
+
+	err = suite.db.PutStatus(context.Background(), replyingStatus)
+	suite.NoError(err)
+
+	err = suite.processor.ProcessFromFederator(context.Background(), messages.FromFederator{
+		APObjectType:     ap.ObjectNote,
+		APActivityType:   ap.ActivityCreate,
+		GTSModel:         replyingStatus,
+		ReceivingAccount: repliedAccount,
+	})
+	suite.NoError(err)
+
+	// The status is stored, and still marked as backfilled.
+	dbStatus, err := suite.db.GetStatusByID(context.Background(), replyingStatus.ID)
+	suite.NoError(err)
+	suite.True(dbStatus.IsBackfilled())
+
+	// No notification should exist for the mention.
+	var notif gtsmodel.Notification
+	err = suite.db.GetWhere(context.Background(), []db.Where{
+		{Key: "status_id", Value: replyingStatus.ID},
+	}, &notif)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Nothing should be streamed: neither
+	// a notification, nor the status itself.
+	select {
+	case msg := <-wssStream.Messages:
+		suite.FailNow("", "unexpected message from wssStream: %+v", msg)
+	case <-time.After(2 * time.Second):
+		// fine
+	}
+
+	// And the status isn't in the home timeline.
+	statuses, err := suite.state.Timelines.Home.GetTimeline(context.Background(), repliedAccount.ID, "", "", "", 20, false)
+	suite.NoError(err)
+	for _, s := range statuses {
+		suite.NotEqual(replyingStatus.ID, s.GetID())
+	}
+}
+
+func (suite *FromFederatorTestSuite) TestProcessReplyMentionMutedThread() {
+	ctx := context.Background()
+
+	repliedAccount := suite.testAccounts["local_account_1"]
+	rootStatus := suite.testStatuses["local_account_1_status_1"]
+	repliedStatus := suite.testStatuses["admin_account_status_3"] // reply to rootStatus
+	replyingAccount := suite.testAccounts["remote_account_1"]
+
+	// Mute the thread from its root.
+	_, errWithCode := suite.processor.Status().MuteCreate(ctx, repliedAccount, rootStatus.ID)
+	suite.NoError(errWithCode)
+
+	// A reply deeper in the thread arrives later.
+	replyingStatus := &gtsmodel.Status{
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		URI:       "http://fossbros-anonymous.io/users/foss_satan/statuses/01H7Q3F6Z3TQ8W2C3T5M9CX0YB",
+		URL:       "http://fossbros-anonymous.io/@foss_satan/01H7Q3F6Z3TQ8W2C3T5M9CX0YB",
+		Content:   `<p><span class="h-card"><a href="http://localhost:8080/@the_mighty_zork" class="u-url mention">@<span>the_mighty_zork</span></a></span> still going on about this?</p>`,
+		Mentions: []*gtsmodel.Mention{
+			{
+				TargetAccountURI: repliedAccount.URI,
+				NameString:       "@the_mighty_zork@localhost:8080",
+			},
+		},
+		AccountID:           replyingAccount.ID,
+		AccountURI:          replyingAccount.URI,
+		InReplyToID:         repliedStatus.ID,
+		InReplyToURI:        repliedStatus.URI,
+		InReplyToAccountID:  repliedStatus.AccountID,
+		Visibility:          gtsmodel.VisibilityUnlocked,
+		ActivityStreamsType: ap.ObjectNote,
+		Federated:           testrig.TrueBool(),
+		Boostable:           testrig.TrueBool(),
+		Replyable:           testrig.TrueBool(),
+		Likeable:            testrig.FalseBool(),
+	}
+
+	statusID, err := id.NewULIDFromTime(replyingStatus.CreatedAt)
+	suite.NoError(err)
+	replyingStatus.ID = statusID
+
+	err = suite.db.PutStatus(ctx, replyingStatus)
+	suite.NoError(err)
+
+	err = suite.processor.ProcessFromFederator(ctx, messages.FromFederator{
+		APObjectType:     ap.ObjectNote,
+		APActivityType:   ap.ActivityCreate,
+		GTSModel:         replyingStatus,
+		ReceivingAccount: repliedAccount,
+	})
+	suite.NoError(err)
+
+	// No notification should exist for the mention.
+	var notif gtsmodel.Notification
+	err = suite.db.GetWhere(ctx, []db.Where{
+		{Key: "status_id", Value: replyingStatus.ID},
+	}, &notif)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *FromFederatorTestSuite) TestProcessReplyMentionNotAllowed() {
+	ctx := context.Background()
+
+	repliedAccount := new(gtsmodel.Account)
+	*repliedAccount = *suite.testAccounts["local_account_1"]
+	repliedStatus := suite.testStatuses["local_account_1_status_1"]
+	replyingAccount := suite.testAccounts["remote_account_1"]
+
+	// Only accept mentions from accounts
+	// followed, which doesn't include
+	// the replying account.
+	repliedAccount.MentionPolicy = gtsmodel.MentionPolicyFollowed
+	err := suite.db.UpdateAccount(ctx, repliedAccount, "mention_policy")
+	suite.NoError(err)
+
+	replyingStatus := &gtsmodel.Status{
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		URI:       "http://fossbros-anonymous.io/users/foss_satan/statuses/01H7VB7SQ4B1DTTVBRBDMZ7ZQ6",
+		URL:       "http://fossbros-anonymous.io/@foss_satan/01H7VB7SQ4B1DTTVBRBDMZ7ZQ6",
+		Content:   `<p><span class="h-card"><a href="http://localhost:8080/@the_mighty_zork" class="u-url mention">@<span>the_mighty_zork</span></a></span> hey, listen to me!</p>`,
+		Mentions: []*gtsmodel.Mention{
+			{
+				TargetAccountURI: repliedAccount.URI,
+				NameString:       "@the_mighty_zork@localhost:8080",
+			},
+		},
+		AccountID:           replyingAccount.ID,
+		AccountURI:          replyingAccount.URI,
+		InReplyToID:         repliedStatus.ID,
+		InReplyToURI:        repliedStatus.URI,
+		InReplyToAccountID:  repliedAccount.ID,
+		Visibility:          gtsmodel.VisibilityUnlocked,
+		ActivityStreamsType: ap.ObjectNote,
+		Federated:           testrig.TrueBool(),
+		Boostable:           testrig.TrueBool(),
+		Replyable:           testrig.TrueBool(),
+		Likeable:            testrig.FalseBool(),
+	}
+
+	statusID, err := id.NewULIDFromTime(replyingStatus.CreatedAt)
+	suite.NoError(err)
+	replyingStatus.ID = statusID
+
+	err = suite.db.PutStatus(ctx, replyingStatus)
+	suite.NoError(err)
+
+	err = suite.processor.ProcessFromFederator(ctx, messages.FromFederator{
+		APObjectType:     ap.ObjectNote,
+		APActivityType:   ap.ActivityCreate,
+		GTSModel:         replyingStatus,
+		ReceivingAccount: repliedAccount,
+	})
+	suite.NoError(err)
+
+	// Status should still be stored for the thread.
+	_, err = suite.db.GetStatusByID(ctx, replyingStatus.ID)
+	suite.NoError(err)
+
+	// But no notification should exist for the mention.
+	var notif gtsmodel.Notification
+	err = suite.db.GetWhere(ctx, []db.Where{
+		{Key: "status_id", Value: replyingStatus.ID},
+	}, &notif)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func (suite *FromFederatorTestSuite) TestProcessFave() {
 	favedAccount := suite.testAccounts["local_account_1"]
 	favedStatus := suite.testStatuses["local_account_1_status_1"]
@@ -239,6 +694,106 @@ func (suite *FromFederatorTestSuite) TestProcessFave() {
 	suite.EqualValues([]string{stream.TimelineNotifications}, msg.Stream)
 }
 
+func (suite *FromFederatorTestSuite) TestProcessBite() {
+	bittenAccount := suite.testAccounts["local_account_1"]
+	bitingAccount := suite.testAccounts["remote_account_1"]
+
+	wssStream, errWithCode := suite.processor.Stream().Open(context.Background(), bittenAccount, stream.TimelineNotifications)
+	suite.NoError(errWithCode)
+
+	err := suite.processor.ProcessFromFederator(context.Background(), messages.FromFederator{
+		APObjectType:   ap.ActivityBite,
+		APActivityType: ap.ActivityCreate,
+		GTSModel: &gtsmodel.Bite{
+			URI:             bitingAccount.URI + "/bites/aaaaaaaaaaaa",
+			AccountID:       bitingAccount.ID,
+			Account:         bitingAccount,
+			TargetAccountID: bittenAccount.ID,
+			TargetAccount:   bittenAccount,
+		},
+		ReceivingAccount: bittenAccount,
+	})
+	suite.NoError(err)
+
+	// 1. a bite notification should exist, without a status
+	notif, err := suite.db.GetNotification(context.Background(), gtsmodel.NotificationBite, bittenAccount.ID, bitingAccount.ID, "")
+	suite.NoError(err)
+	suite.Empty(notif.StatusID)
+	suite.False(*notif.Read)
+
+	// 2. the notification should be streamed, as type bite
+	var msg *stream.Message
+	select {
+	case msg = <-wssStream.Messages:
+		// fine
+	case <-time.After(5 * time.Second):
+		suite.FailNow("no message from wssStream")
+	}
+	suite.Equal(stream.EventTypeNotification, msg.Event)
+	suite.Contains(msg.Payload, `"type":"bite"`)
+	suite.EqualValues([]string{stream.TimelineNotifications}, msg.Stream)
+}
+
+func (suite *FromFederatorTestSuite) TestProcessBlock() {
+	ctx := context.Background()
+
+	blockingAccount := suite.testAccounts["remote_account_1"]
+	blockedAccount := suite.testAccounts["local_account_1"]
+
+	// before doing the block....
+	// make local_account_1 follow remote_account_1,
+	// and remote_account_1 request to follow local_account_1
+	zorkFollowSatan := &gtsmodel.Follow{
+		ID:              "01H5QSKZQ3MRHN2V8F5AB0SVTT",
+		CreatedAt:       time.Now().Add(-1 * time.Hour),
+		UpdatedAt:       time.Now().Add(-1 * time.Hour),
+		AccountID:       blockedAccount.ID,
+		TargetAccountID: blockingAccount.ID,
+		ShowReblogs:     testrig.TrueBool(),
+		URI:             fmt.Sprintf("%s/follow/01H5QSKZQ3MRHN2V8F5AB0SVTT", blockedAccount.URI),
+		Notify:          testrig.FalseBool(),
+	}
+	err := suite.db.Put(ctx, zorkFollowSatan)
+	suite.NoError(err)
+
+	satanFollowRequestZork := &gtsmodel.FollowRequest{
+		ID:              "01H5QSM6W3C5AY6N5WPTQ0YDKD",
+		CreatedAt:       time.Now().Add(-1 * time.Hour),
+		UpdatedAt:       time.Now().Add(-1 * time.Hour),
+		AccountID:       blockingAccount.ID,
+		TargetAccountID: blockedAccount.ID,
+		ShowReblogs:     testrig.TrueBool(),
+		URI:             fmt.Sprintf("%s/follows/01H5QSM6W3C5AY6N5WPTQ0YDKD", blockingAccount.URI),
+		Notify:          testrig.FalseBool(),
+	}
+	err = suite.db.Put(ctx, satanFollowRequestZork)
+	suite.NoError(err)
+
+	err = suite.processor.ProcessFromFederator(ctx, messages.FromFederator{
+		APObjectType:   ap.ActivityBlock,
+		APActivityType: ap.ActivityCreate,
+		GTSModel: &gtsmodel.Block{
+			ID:              "01H5QSMDKP4Y3X9V4SD5B3SNBY",
+			URI:             blockingAccount.URI + "/blocks/01H5QSMDKP4Y3X9V4SD5B3SNBY",
+			AccountID:       blockingAccount.ID,
+			Account:         blockingAccount,
+			TargetAccountID: blockedAccount.ID,
+			TargetAccount:   blockedAccount,
+		},
+		ReceivingAccount: blockedAccount,
+	})
+	suite.NoError(err)
+
+	// the follow and the follow request should be gone now
+	zorkFollowsSatan, err := suite.db.IsFollowing(ctx, blockedAccount.ID, blockingAccount.ID)
+	suite.NoError(err)
+	suite.False(zorkFollowsSatan)
+
+	satanRequestedZork, err := suite.db.IsFollowRequested(ctx, blockingAccount.ID, blockedAccount.ID)
+	suite.NoError(err)
+	suite.False(satanRequestedZork)
+}
+
 // TestProcessFaveWithDifferentReceivingAccount ensures that when an account receives a fave that's for
 // another account in their AP inbox, a notification isn't streamed to the receiving account.
 //
@@ -362,7 +917,7 @@ func (suite *FromFederatorTestSuite) TestProcessAccountDelete() {
 
 	// no statuses from foss satan should be left in the database
 	if !testrig.WaitFor(func() bool {
-		s, err := suite.db.GetAccountStatuses(ctx, deletedAccount.ID, 0, false, false, "", "", false, false)
+		s, err := suite.db.GetAccountStatuses(ctx, deletedAccount.ID, 0, false, false, "", "", false, false, false, false)
 		return s == nil && err == db.ErrNoEntries
 	}) {
 		suite.FailNow("timeout waiting for statuses to be deleted")
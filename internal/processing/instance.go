@@ -21,6 +21,9 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
+	"sync"
+	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -52,10 +55,20 @@ func (p *Processor) InstanceGetV1(ctx context.Context) (*apimodel.InstanceV1, gt
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting instance to api representation: %s", err))
 	}
 
+	if quota := p.state.Storage.MaxSize; quota > 0 {
+		if ai.Stats == nil {
+			ai.Stats = make(map[string]int, 2)
+		}
+		ai.Stats["storage_quota_bytes"] = int(quota)
+		ai.Stats["storage_used_bytes"] = int(p.state.Storage.Used())
+	}
+
 	return ai, nil
 }
 
-func (p *Processor) InstanceGetV2(ctx context.Context) (*apimodel.InstanceV2, gtserror.WithCode) {
+// InstanceGetV2 returns the v2 api model of this instance. If user is
+// not nil, advertised status length and media limits are those of that user.
+func (p *Processor) InstanceGetV2(ctx context.Context, user *gtsmodel.User) (*apimodel.InstanceV2, gtserror.WithCode) {
 	i, err := p.getThisInstance(ctx)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error fetching instance: %s", err))
@@ -66,6 +79,29 @@ func (p *Processor) InstanceGetV2(ctx context.Context) (*apimodel.InstanceV2, gt
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting instance to api representation: %s", err))
 	}
 
+	if user != nil && user.StatusesMaxChars > 0 {
+		// An admin has set a limit for this user,
+		// which applies regardless of visibility.
+		ai.Configuration.Statuses.MaxCharacters = user.StatusesMaxChars
+		for visibility := range ai.Configuration.Statuses.MaxCharactersByVisibility {
+			ai.Configuration.Statuses.MaxCharactersByVisibility[visibility] = user.StatusesMaxChars
+		}
+	}
+
+	if user != nil {
+		// Media limits may be overridden for this user's role.
+		ai.Configuration.Statuses.MaxMediaAttachments = validate.UserStatusMediaMaxFiles(user)
+		ai.Configuration.MediaAttachments.ImageSizeLimit = int(validate.UserMediaImageMaxSize(user))
+		ai.Configuration.MediaAttachments.VideoSizeLimit = int(validate.UserMediaVideoMaxSize(user))
+	}
+
+	if quota := p.state.Storage.MaxSize; quota > 0 {
+		ai.Configuration.Storage = &apimodel.InstanceConfigurationStorage{
+			QuotaBytes: quota,
+			UsedBytes:  p.state.Storage.Used(),
+		}
+	}
+
 	return ai, nil
 }
 
@@ -135,6 +171,83 @@ func (p *Processor) InstancePeersGet(ctx context.Context, includeSuspended bool,
 	return domains, nil
 }
 
+const (
+	// instanceActivityWeeks is the number of
+	// weeks returned by InstanceActivityGet.
+	instanceActivityWeeks = 12
+
+	// instanceActivityTTL is how long the results of
+	// InstanceActivityGet are cached before being recounted.
+	instanceActivityTTL = time.Hour
+)
+
+// instanceActivityCache wraps the weekly
+// activity of this instance with an expiry.
+type instanceActivityCache struct {
+	mu      sync.Mutex
+	weeks   []*apimodel.InstanceActivity
+	expires time.Time
+}
+
+// InstanceActivityGet returns counts of local statuses, logins and
+// registrations for each of the last 12 weeks, most recent week first.
+// Weeks start at midnight UTC on Monday, and the current week is included.
+func (p *Processor) InstanceActivityGet(ctx context.Context) ([]*apimodel.InstanceActivity, gtserror.WithCode) {
+	p.instanceActivity.mu.Lock()
+	defer p.instanceActivity.mu.Unlock()
+
+	now := time.Now()
+	if p.instanceActivity.weeks != nil && now.Before(p.instanceActivity.expires) {
+		return p.instanceActivity.weeks, nil
+	}
+
+	// Find midnight at the start of this week.
+	today := now.UTC().Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+
+	weeks := make([]*apimodel.InstanceActivity, 0, instanceActivityWeeks)
+	for i := 0; i < instanceActivityWeeks; i++ {
+		until := since.AddDate(0, 0, 7)
+
+		statuses, logins, registrations, err := p.state.DB.CountInstanceActivity(ctx, since, until)
+		if err != nil {
+			err = gtserror.Newf("db error counting activity for week %s: %w", since, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		weeks = append(weeks, &apimodel.InstanceActivity{
+			Week:          strconv.FormatInt(since.Unix(), 10),
+			Statuses:      strconv.Itoa(statuses),
+			Logins:        strconv.Itoa(logins),
+			Registrations: strconv.Itoa(registrations),
+		})
+
+		since = since.AddDate(0, 0, -7)
+	}
+
+	p.instanceActivity.weeks = weeks
+	p.instanceActivity.expires = now.Add(instanceActivityTTL)
+	return weeks, nil
+}
+
+func (p *Processor) InstanceRulesGet(ctx context.Context) ([]*apimodel.InstanceRule, gtserror.WithCode) {
+	rules, err := p.state.DB.GetRules(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error fetching instance rules: %s", err))
+	}
+
+	apiRules := make([]*apimodel.InstanceRule, 0, len(rules))
+	for _, r := range rules {
+		apiRule, err := p.tc.RuleToAPIRule(ctx, r)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting instance rule to api representation: %s", err))
+		}
+		apiRules = append(apiRules, apiRule)
+	}
+
+	return apiRules, nil
+}
+
 func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSettingsUpdateRequest) (*apimodel.InstanceV1, gtserror.WithCode) {
 	// fetch the instance entry from the db for processing
 	i := &gtsmodel.Instance{}
@@ -236,6 +349,10 @@ func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSe
 
 	var updateInstanceAccount bool
 
+	if errWithCode := p.account.CheckStorageQuota(form.Avatar, form.Header); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	if form.Avatar != nil && form.Avatar.Size != 0 {
 		// process instance avatar image + description
 		avatarInfo, err := p.account.UpdateAvatar(ctx, form.Avatar, form.AvatarDescription, ia.ID)
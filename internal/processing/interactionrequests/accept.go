@@ -0,0 +1,86 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interactionrequests
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// Accept approves the pending interaction request with the given id,
+// stamping the interaction with the URI of the Accept, so that it's
+// shown and counted like any other, and federating the Accept to the
+// interacting account.
+func (p *Processor) Accept(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.InteractionRequest, gtserror.WithCode) {
+	req, errWithCode := p.getPendingInteractionRequest(ctx, account, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	req.AcceptedAt = time.Now()
+	req.URI = uris.GenerateURIForAccept(account.Username, req.ID)
+	if err := p.state.DB.UpdateInteractionRequest(ctx, req, "accepted_at", "uri"); err != nil {
+		err = gtserror.Newf("db error updating interaction request %s: %w", req.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Stamp the interaction as approved.
+	pending := false
+	switch req.InteractionType {
+	case gtsmodel.InteractionLike:
+		req.Like.PendingApproval = &pending
+		req.Like.ApprovedByURI = req.URI
+		if err := p.state.DB.UpdateStatusFave(ctx, req.Like, "pending_approval", "approved_by_uri"); err != nil {
+			err = gtserror.Newf("db error updating fave %s: %w", req.Like.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	case gtsmodel.InteractionReply:
+		req.Reply.PendingApproval = &pending
+		req.Reply.ApprovedByURI = req.URI
+		if err := p.state.DB.UpdateStatus(ctx, req.Reply, "pending_approval", "approved_by_uri"); err != nil {
+			err = gtserror.Newf("db error updating reply %s: %w", req.Reply.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	case gtsmodel.InteractionAnnounce:
+		req.Announce.PendingApproval = &pending
+		req.Announce.ApprovedByURI = req.URI
+		if err := p.state.DB.UpdateStatus(ctx, req.Announce, "pending_approval", "approved_by_uri"); err != nil {
+			err = gtserror.Newf("db error updating boost %s: %w", req.Announce.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// Timeline, notify and federate
+	// the now-approved interaction.
+	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   apObjectType(req.InteractionType),
+		APActivityType: ap.ActivityAccept,
+		GTSModel:       req,
+		OriginAccount:  account,
+		TargetAccount:  req.InteractingAccount,
+	})
+
+	return p.apiInteractionRequest(ctx, account, req)
+}
@@ -0,0 +1,120 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interactionrequests
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Get returns the interaction request with the given id,
+// provided it targets the given account and is still pending.
+func (p *Processor) Get(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.InteractionRequest, gtserror.WithCode) {
+	req, errWithCode := p.getPendingInteractionRequest(ctx, account, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiInteractionRequest(ctx, account, req)
+}
+
+// GetMultiple returns pending interaction requests targeting the
+// given account, optionally only those for the given status, and
+// only of the interaction types which have been asked for.
+func (p *Processor) GetMultiple(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	statusID string,
+	favourites bool,
+	replies bool,
+	reblogs bool,
+	maxID string,
+	sinceID string,
+	minID string,
+	limit int,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	types := make([]gtsmodel.InteractionType, 0, 3)
+	if favourites {
+		types = append(types, gtsmodel.InteractionLike)
+	}
+	if replies {
+		types = append(types, gtsmodel.InteractionReply)
+	}
+	if reblogs {
+		types = append(types, gtsmodel.InteractionAnnounce)
+	}
+
+	reqs, err := p.state.DB.GetPendingInteractionRequests(ctx, account.ID, statusID, types, maxID, sinceID, minID, limit)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return util.EmptyPageableResponse(), nil
+		}
+		err = gtserror.Newf("db error getting interaction requests: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(reqs)
+	items := make([]interface{}, 0, count)
+	nextMaxIDValue := ""
+	prevMinIDValue := ""
+	for i, req := range reqs {
+		item, errWithCode := p.apiInteractionRequest(ctx, account, req)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		if i == count-1 {
+			nextMaxIDValue = item.ID
+		}
+
+		if i == 0 {
+			prevMinIDValue = item.ID
+		}
+
+		items = append(items, item)
+	}
+
+	extraQueryParams := []string{}
+	if statusID != "" {
+		extraQueryParams = append(extraQueryParams, "status_id="+statusID)
+	}
+	if !favourites {
+		extraQueryParams = append(extraQueryParams, "favourites=false")
+	}
+	if !replies {
+		extraQueryParams = append(extraQueryParams, "replies=false")
+	}
+	if !reblogs {
+		extraQueryParams = append(extraQueryParams, "reblogs=false")
+	}
+
+	return util.PackagePageableResponse(util.PageableResponseParams{
+		Items:            items,
+		Path:             "/api/v1/interaction_requests",
+		NextMaxIDValue:   nextMaxIDValue,
+		PrevMinIDValue:   prevMinIDValue,
+		Limit:            limit,
+		ExtraQueryParams: extraQueryParams,
+	})
+}
@@ -0,0 +1,89 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interactionrequests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+type Processor struct {
+	state *state.State
+	tc    typeutils.TypeConverter
+}
+
+func New(state *state.State, tc typeutils.TypeConverter) Processor {
+	return Processor{
+		state: state,
+		tc:    tc,
+	}
+}
+
+// getPendingInteractionRequest gets the interaction request with the given
+// ID, provided it targets the given account and hasn't been handled yet.
+func (p *Processor) getPendingInteractionRequest(ctx context.Context, account *gtsmodel.Account, id string) (*gtsmodel.InteractionRequest, gtserror.WithCode) {
+	req, err := p.state.DB.GetInteractionRequestByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting interaction request %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if req == nil || req.TargetAccountID != account.ID {
+		err := fmt.Errorf("interaction request %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if !req.IsPending() {
+		err := fmt.Errorf("interaction request %s has already been accepted or rejected", id)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return req, nil
+}
+
+func (p *Processor) apiInteractionRequest(ctx context.Context, account *gtsmodel.Account, req *gtsmodel.InteractionRequest) (*apimodel.InteractionRequest, gtserror.WithCode) {
+	apiReq, err := p.tc.InteractionRequestToAPIInteractionRequest(ctx, req, account)
+	if err != nil {
+		err = gtserror.Newf("error converting interaction request %s to api: %w", req.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiReq, nil
+}
+
+// apObjectType returns the activitypub object
+// type corresponding to the given interaction.
+func apObjectType(interactionType gtsmodel.InteractionType) string {
+	switch interactionType {
+	case gtsmodel.InteractionLike:
+		return ap.ActivityLike
+	case gtsmodel.InteractionAnnounce:
+		return ap.ActivityAnnounce
+	default:
+		return ap.ObjectNote
+	}
+}
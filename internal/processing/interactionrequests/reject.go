@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interactionrequests
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// Reject rejects the pending interaction request with the given id.
+// The rejected interaction will be deleted, and the Reject federated
+// to the interacting account.
+func (p *Processor) Reject(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.InteractionRequest, gtserror.WithCode) {
+	req, errWithCode := p.getPendingInteractionRequest(ctx, account, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	req.RejectedAt = time.Now()
+	req.URI = uris.GenerateURIForReject(account.Username, req.ID)
+	if err := p.state.DB.UpdateInteractionRequest(ctx, req, "rejected_at", "uri"); err != nil {
+		err = gtserror.Newf("db error updating interaction request %s: %w", req.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Convert to api model now, since the
+	// interaction will be gone after this.
+	apiReq, errWithCode := p.apiInteractionRequest(ctx, account, req)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Delete and federate
+	// the rejected interaction.
+	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   apObjectType(req.InteractionType),
+		APActivityType: ap.ActivityReject,
+		GTSModel:       req,
+		OriginAccount:  account,
+		TargetAccount:  req.InteractingAccount,
+	})
+
+	return apiReq, nil
+}
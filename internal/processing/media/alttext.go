@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// altTextClient is used for requests to the image captioning service.
+// This is a locally-hosted service, so we deliberately don't use the
+// federation httpclient, which refuses connections to local addresses.
+var altTextClient = &http.Client{Timeout: 30 * time.Second}
+
+// altTextMaxResponse is the max number of bytes
+// read from the image captioning service response.
+const altTextMaxResponse = 64 * 1024
+
+// altTextResponse models the response body
+// expected from the image captioning service.
+type altTextResponse struct {
+	Description string `json:"description"`
+}
+
+// suggestAltText requests an alt text suggestion for the given attachment from the configured
+// image captioning service. An empty string is returned if no suggestion could be obtained.
+func (p *Processor) suggestAltText(ctx context.Context, attachment *gtsmodel.MediaAttachment) string {
+	if attachment.Type != gtsmodel.FileTypeImage {
+		// Only images are supported.
+		return ""
+	}
+
+	rc, err := p.state.Storage.GetStream(ctx, attachment.File.Path)
+	if err != nil {
+		log.Errorf(ctx, "error getting attachment %s from storage: %v", attachment.ID, err)
+		return ""
+	}
+	defer rc.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.GetMediaAutoAltTextURL(), rc)
+	if err != nil {
+		log.Warnf(ctx, "error creating alt text suggestion request: %v", err)
+		return ""
+	}
+	req.Header.Set("Content-Type", attachment.File.ContentType)
+	req.Header.Set("Accept", "application/json")
+
+	rsp, err := altTextClient.Do(req)
+	if err != nil {
+		log.Warnf(ctx, "error requesting alt text suggestion: %v", err)
+		return ""
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		log.Warnf(ctx, "error requesting alt text suggestion: %v", gtserror.NewFromResponse(rsp))
+		return ""
+	}
+
+	var suggestion altTextResponse
+	if err := json.NewDecoder(io.LimitReader(rsp.Body, altTextMaxResponse)).Decode(&suggestion); err != nil {
+		log.Warnf(ctx, "error decoding alt text suggestion: %v", err)
+		return ""
+	}
+
+	// Ensure suggestion fits within the allowed description length.
+	description := []rune(strings.TrimSpace(suggestion.Description))
+	if max := config.GetMediaDescriptionMaxChars(); len(description) > max {
+		description = description[:max]
+	}
+
+	return string(description)
+}
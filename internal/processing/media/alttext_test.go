@@ -0,0 +1,133 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type AltTextTestSuite struct {
+	MediaStandardTestSuite
+}
+
+// attachmentRequest returns an attachment
+// request form for the given test file.
+func (suite *AltTextTestSuite) attachmentRequest(filePath string, description string) *apimodel.AttachmentRequest {
+	b, err := os.ReadFile(filePath)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	fw, err := w.CreateFormFile("file", "image.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := fw.Write(b); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if err := w.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	form, err := multipart.NewReader(buf, w.Boundary()).ReadForm(int64(len(b)) * 2)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return &apimodel.AttachmentRequest{
+		File:        form.File["file"][0],
+		Description: description,
+		Focus:       "0,0",
+	}
+}
+
+func (suite *AltTextTestSuite) TestSuggestAltText() {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"description":"  a pixelated test image  "}`))
+	}))
+	defer server.Close()
+
+	config.SetMediaAutoAltTextEnabled(true)
+	config.SetMediaAutoAltTextURL(server.URL)
+
+	attachment, errWithCode := suite.mediaProcessor.Create(context.Background(),
+		suite.testAccounts["local_account_1"],
+		suite.attachmentRequest("../../../testrig/media/ohyou-original.jpg", ""),
+	)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(received)
+	suite.NotNil(attachment.DescriptionSuggestion)
+	suite.Equal("a pixelated test image", *attachment.DescriptionSuggestion)
+
+	// Suggestion must not be used as description.
+	suite.Nil(attachment.Description)
+}
+
+func (suite *AltTextTestSuite) TestSuggestAltTextWithDescription() {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	config.SetMediaAutoAltTextEnabled(true)
+	config.SetMediaAutoAltTextURL(server.URL)
+
+	attachment, errWithCode := suite.mediaProcessor.Create(context.Background(),
+		suite.testAccounts["local_account_1"],
+		suite.attachmentRequest("../../../testrig/media/ohyou-original.jpg", "a picture"),
+	)
+	suite.NoError(errWithCode)
+	suite.False(called)
+	suite.Nil(attachment.DescriptionSuggestion)
+}
+
+func (suite *AltTextTestSuite) TestSuggestAltTextUnreachable() {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	config.SetMediaAutoAltTextEnabled(true)
+	config.SetMediaAutoAltTextURL(server.URL)
+
+	attachment, errWithCode := suite.mediaProcessor.Create(context.Background(),
+		suite.testAccounts["local_account_1"],
+		suite.attachmentRequest("../../../testrig/media/ohyou-original.jpg", ""),
+	)
+	suite.NoError(errWithCode)
+	suite.Nil(attachment.DescriptionSuggestion)
+}
+
+func TestAltTextTestSuite(t *testing.T) {
+	suite.Run(t, &AltTextTestSuite{})
+}
@@ -19,17 +19,51 @@ package media
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
+	"strings"
 
+	"codeberg.org/gruf/go-bytesize"
+	"github.com/h2non/filetype"
+	"github.com/superseriousbusiness/gotosocial/internal/api/errorcodes"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
 // Create creates a new media attachment belonging to the given account, using the request form.
+// It blocks until the attachment has been fully processed, including its thumbnail and blurhash.
 func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form *apimodel.AttachmentRequest) (*apimodel.Attachment, gtserror.WithCode) {
+	return p.create(ctx, account, form, true)
+}
+
+// CreateAsync is like Create, but returns as soon as the original media has been
+// stored. Its thumbnail and blurhash are generated by the thumbnail worker pool, and
+// until then the returned attachment has a processing status of "processing".
+func (p *Processor) CreateAsync(ctx context.Context, account *gtsmodel.Account, form *apimodel.AttachmentRequest) (*apimodel.Attachment, gtserror.WithCode) {
+	return p.create(ctx, account, form, false)
+}
+
+func (p *Processor) create(ctx context.Context, account *gtsmodel.Account, form *apimodel.AttachmentRequest, wait bool) (*apimodel.Attachment, gtserror.WithCode) {
+	if errWithCode := p.state.Storage.CheckQuota(form.File.Size); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, account.ID)
+	if err != nil {
+		err := fmt.Errorf("error getting user for account %s: %w", account.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if errWithCode := checkAllowedType(form.File, user); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	data := func(innerCtx context.Context) (io.ReadCloser, int64, error) {
 		f, err := form.File.Open()
 		return f, form.File.Size, err
@@ -51,7 +85,12 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 		return nil, gtserror.NewErrorUnprocessableEntity(err)
 	}
 
-	attachment, err := media.LoadAttachment(ctx)
+	var attachment *gtsmodel.MediaAttachment
+	if wait {
+		attachment, err = media.LoadAttachment(ctx)
+	} else {
+		attachment, err = media.LoadOriginal(ctx)
+	}
 	if err != nil {
 		return nil, gtserror.NewErrorUnprocessableEntity(err)
 	}
@@ -62,5 +101,75 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if form.Description == "" && config.GetMediaAutoAltTextEnabled() {
+		// No description given, offer a suggestion to the user.
+		if suggestion := p.suggestAltText(ctx, attachment); suggestion != "" {
+			apiAttachment.DescriptionSuggestion = &suggestion
+		}
+	}
+
 	return &apiAttachment, nil
 }
+
+// checkAllowedType sniffs the MIME type of the given
+// file from its header bytes, and returns an error if
+// media of that type may not be uploaded to this instance,
+// or if the file exceeds the user's size limit for its type.
+func checkAllowedType(fh *multipart.FileHeader, user *gtsmodel.User) gtserror.WithCode {
+	f, err := fh.Open()
+	if err != nil {
+		err := fmt.Errorf("error opening uploaded file: %w", err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer f.Close()
+
+	// See: https://github.com/h2non/filetype
+	hdrBuf := make([]byte, 261)
+	n, err := io.ReadFull(f, hdrBuf)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		err := fmt.Errorf("error reading uploaded file: %w", err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	info, err := filetype.Match(hdrBuf[:n])
+	if err != nil {
+		err := fmt.Errorf("error parsing file type: %w", err)
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	// Unsupported types will be rejected later
+	// on by the media manager, so only deal with
+	// those which are explicitly disallowed here.
+	if info.MIME.Value == "" {
+		return nil
+	}
+
+	if !media.MIMETypeAllowed(info.MIME.Value) {
+		err := fmt.Errorf("media type %s is not allowed on this instance", info.MIME.Value)
+		return gtserror.NewErrorUnprocessableEntity(
+			err,
+			err.Error(),
+			"allowed types are "+strings.Join(media.AllowedMIMETypes(), ", "),
+		)
+	}
+
+	// The handler only checked the upload against
+	// the larger of the two size limits, so now that
+	// we know the type, check against the right one.
+	var maxSize bytesize.Size
+	switch info.MIME.Type {
+	case "image":
+		maxSize = validate.UserMediaImageMaxSize(user)
+	case "video":
+		maxSize = validate.UserMediaVideoMaxSize(user)
+	default:
+		return nil
+	}
+
+	if fh.Size > int64(maxSize) {
+		err := fmt.Errorf("%s file size limit exceeded: limit is %d bytes but attachment was %d bytes", info.MIME.Type, maxSize, fh.Size)
+		return gtserror.NewErrorBadRequest(errorcodes.Set(err, errorcodes.MediaTooLarge), err.Error())
+	}
+
+	return nil
+}
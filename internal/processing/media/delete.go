@@ -26,6 +26,7 @@ import (
 	"codeberg.org/gruf/go-store/v2/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 // Delete deletes the media attachment with the given ID, including all files pertaining to that attachment.
@@ -67,3 +68,43 @@ func (p *Processor) Delete(ctx context.Context, mediaAttachmentID string) gtserr
 
 	return nil
 }
+
+// DeleteUnattached deletes the media attachment with the given ID on behalf of the
+// given account, which must own it. Only media which is not yet attached to a status,
+// a draft, or the account's profile may be deleted; attached media is deleted along
+// with its status instead.
+func (p *Processor) DeleteUnattached(ctx context.Context, account *gtsmodel.Account, mediaAttachmentID string) gtserror.WithCode {
+	attachment, err := p.state.DB.GetAttachmentByID(ctx, mediaAttachmentID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return gtserror.NewErrorNotFound(errors.New("attachment doesn't exist in the db"))
+		}
+		return gtserror.NewErrorInternalError(fmt.Errorf("db error getting attachment: %w", err))
+	}
+
+	if attachment.AccountID != account.ID {
+		return gtserror.NewErrorNotFound(errors.New("attachment not owned by requesting account"))
+	}
+
+	if attachment.StatusID != "" || attachment.ScheduledStatusID != "" {
+		err := fmt.Errorf("attachment %s is attached to a status", mediaAttachmentID)
+		return gtserror.NewErrorUnprocessableEntity(err, "media attachment is attached to a status; delete the status instead")
+	}
+
+	if *attachment.Avatar || *attachment.Header {
+		err := fmt.Errorf("attachment %s is used in the account's profile", mediaAttachmentID)
+		return gtserror.NewErrorUnprocessableEntity(err, "media attachment is used as an avatar or header")
+	}
+
+	inDraft, err := p.state.DB.IsAttachmentInDraft(ctx, account.ID, mediaAttachmentID)
+	if err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("db error checking drafts: %w", err))
+	}
+
+	if inDraft {
+		err := fmt.Errorf("attachment %s is attached to a draft", mediaAttachmentID)
+		return gtserror.NewErrorUnprocessableEntity(err, "media attachment is attached to a draft; delete the draft instead")
+	}
+
+	return p.Delete(ctx, mediaAttachmentID)
+}
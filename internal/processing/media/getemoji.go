@@ -19,11 +19,14 @@ package media
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
@@ -49,3 +52,100 @@ func (p *Processor) GetCustomEmojis(ctx context.Context) ([]*apimodel.Emoji, gts
 
 	return apiEmojis, nil
 }
+
+// GetCustomEmojisSince returns the changes to the useable local custom emojis of this
+// instance since the given time, so that clients can delta-sync their emoji list.
+//
+// Emojis that were deleted, or that have become unuseable, since the given time are
+// returned as deleted. If the given time is further in the past than tombstones of
+// deleted emojis are kept for, all useable emojis are returned instead, and the
+// returned delta is marked as full.
+func (p *Processor) GetCustomEmojisSince(ctx context.Context, since time.Time) (*apimodel.EmojisDelta, gtserror.WithCode) {
+	delta := &apimodel.EmojisDelta{
+		Emojis:     []*apimodel.Emoji{},
+		Deleted:    []string{},
+		Categories: []string{},
+	}
+
+	categories, err := p.state.DB.GetEmojiCategories(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := fmt.Errorf("db error retrieving emoji categories: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, category := range categories {
+		delta.Categories = append(delta.Categories, category.Name)
+	}
+
+	if since.Before(time.Now().Add(-gtsmodel.EmojiTombstoneRetention)) {
+		// Tombstones may have been pruned
+		// already, so we can't give a delta.
+		emojis, errWithCode := p.GetCustomEmojis(ctx)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		delta.Emojis = emojis
+		delta.Full = true
+		return delta, nil
+	}
+
+	emojis, err := p.state.DB.GetLocalEmojisUpdatedSince(ctx, since)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := fmt.Errorf("db error retrieving updated custom emojis: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Track shortcodes we've seen already, in case
+	// an emoji was deleted and then recreated with
+	// the same shortcode; the emoji takes precedence.
+	seen := make(map[string]struct{}, len(emojis))
+
+	for _, gtsEmoji := range emojis {
+		seen[gtsEmoji.Shortcode] = struct{}{}
+
+		if *gtsEmoji.Disabled || !*gtsEmoji.VisibleInPicker {
+			// No longer useable,
+			// so treat as deleted.
+			delta.Deleted = append(delta.Deleted, gtsEmoji.Shortcode)
+			continue
+		}
+
+		apiEmoji, err := p.tc.EmojiToAPIEmoji(ctx, gtsEmoji)
+		if err != nil {
+			log.Errorf(ctx, "error converting emoji with id %s: %s", gtsEmoji.ID, err)
+			continue
+		}
+
+		delta.Emojis = append(delta.Emojis, &apiEmoji)
+	}
+
+	tombstones, err := p.state.DB.GetEmojiTombstonesSince(ctx, since)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := fmt.Errorf("db error retrieving custom emoji tombstones: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, tombstone := range tombstones {
+		if _, ok := seen[tombstone.Shortcode]; ok {
+			continue
+		}
+
+		seen[tombstone.Shortcode] = struct{}{}
+		delta.Deleted = append(delta.Deleted, tombstone.Shortcode)
+	}
+
+	return delta, nil
+}
+
+// GetCustomEmojisLastModified returns the last time that the
+// custom emojis of this instance, or their categories, changed.
+func (p *Processor) GetCustomEmojisLastModified(ctx context.Context) (time.Time, gtserror.WithCode) {
+	lastModified, err := p.state.DB.GetLocalEmojisLastModified(ctx)
+	if err != nil {
+		err := fmt.Errorf("db error retrieving custom emojis last modified time: %w", err)
+		return time.Time{}, gtserror.NewErrorInternalError(err)
+	}
+
+	return lastModified, nil
+}
@@ -20,8 +20,10 @@ package media_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type GetEmojiTestSuite struct {
@@ -36,6 +38,43 @@ func (suite *GetEmojiTestSuite) TestGetCustomEmojis() {
 	suite.Equal("rainbow", emojis[0].Shortcode)
 }
 
+func (suite *GetEmojiTestSuite) TestGetCustomEmojisSince() {
+	ctx := context.Background()
+	since := time.Now().Add(-time.Hour)
+
+	// Nothing has changed recently.
+	delta, errWithCode := suite.mediaProcessor.GetCustomEmojisSince(ctx, since)
+	suite.NoError(errWithCode)
+	suite.False(delta.Full)
+	suite.Empty(delta.Emojis)
+	suite.Empty(delta.Deleted)
+	suite.Equal([]string{"cute stuff", "reactions"}, delta.Categories)
+
+	// Delete an emoji, it should now show as deleted.
+	if err := suite.db.DeleteEmojiByID(ctx, testrig.NewTestEmojis()["rainbow"].ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	delta, errWithCode = suite.mediaProcessor.GetCustomEmojisSince(ctx, since)
+	suite.NoError(errWithCode)
+	suite.False(delta.Full)
+	suite.Empty(delta.Emojis)
+	suite.Equal([]string{"rainbow"}, delta.Deleted)
+
+	lastModified, errWithCode := suite.mediaProcessor.GetCustomEmojisLastModified(ctx)
+	suite.NoError(errWithCode)
+	suite.True(lastModified.After(since))
+}
+
+func (suite *GetEmojiTestSuite) TestGetCustomEmojisSinceTooLongAgo() {
+	delta, errWithCode := suite.mediaProcessor.GetCustomEmojisSince(context.Background(), time.Now().Add(-365*24*time.Hour))
+	suite.NoError(errWithCode)
+	suite.True(delta.Full)
+	suite.Len(delta.Emojis, 1)
+	suite.Equal("rainbow", delta.Emojis[0].Shortcode)
+	suite.Empty(delta.Deleted)
+}
+
 func TestGetEmojiTestSuite(t *testing.T) {
 	suite.Run(t, &GetEmojiTestSuite{})
 }
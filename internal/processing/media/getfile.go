@@ -194,7 +194,7 @@ func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount
 	}
 
 	// ... so now we can safely return it
-	return p.retrieveFromStorage(ctx, storagePath, attachmentContent)
+	return p.retrieveFromStorage(ctx, storagePath, attachmentContent, true)
 }
 
 func (p *Processor) getEmojiContent(ctx context.Context, fileName string, owningAccountID string, emojiSize media.Size) (*apimodel.Content, gtserror.WithCode) {
@@ -229,15 +229,20 @@ func (p *Processor) getEmojiContent(ctx context.Context, fileName string, owning
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("media size %s not recognized for emoji", emojiSize))
 	}
 
-	return p.retrieveFromStorage(ctx, storagePath, emojiContent)
+	// Emojis are fetched by remote instances as well as clients, which
+	// may not follow a redirect to another host (especially for signed
+	// requests), so always serve them ourselves rather than redirecting.
+	return p.retrieveFromStorage(ctx, storagePath, emojiContent, false)
 }
 
-func (p *Processor) retrieveFromStorage(ctx context.Context, storagePath string, content *apimodel.Content) (*apimodel.Content, gtserror.WithCode) {
+func (p *Processor) retrieveFromStorage(ctx context.Context, storagePath string, content *apimodel.Content, allowRedirect bool) (*apimodel.Content, gtserror.WithCode) {
 	// If running on S3 storage with proxying disabled then
 	// just fetch a pre-signed URL instead of serving the content.
-	if url := p.state.Storage.URL(ctx, storagePath); url != nil {
-		content.URL = url
-		return content, nil
+	if allowRedirect {
+		if url := p.state.Storage.URL(ctx, storagePath); url != nil {
+			content.URL = url
+			return content, nil
+		}
 	}
 
 	reader, err := p.state.Storage.GetStream(ctx, storagePath)
@@ -0,0 +1,253 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/h2non/filetype"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// proxiedImageAccessFreq is how often the last-accessed
+// time of a proxied image is updated when it's served,
+// to avoid a database write on every single request.
+const proxiedImageAccessFreq = time.Hour
+
+// GetProxiedImage retrieves the external image encoded in the given
+// image proxy path, fetching and caching it in storage on first access,
+// and streams it back to the caller via an io.reader embedded in *apimodel.Content.
+//
+// Only images with a valid signature, found in a known remote status or account
+// (or the preview card of a known status) with the given owner ID, are served.
+func (p *Processor) GetProxiedImage(ctx context.Context, ownerID string, encoded string, signature string) (*apimodel.Content, gtserror.WithCode) {
+	if !config.GetMediaProxyEnabled() {
+		err := errors.New("image proxy not enabled")
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	remoteURL, err := uris.ParseProxiedImagePath(ownerID, encoded, signature)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if remoteURL.Scheme != "https" && remoteURL.Scheme != "http" {
+		err := fmt.Errorf("url %s is not http(s)", remoteURL)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if host := remoteURL.Hostname(); host == config.GetHost() || host == config.GetAccountDomain() {
+		err := fmt.Errorf("url %s is local", remoteURL)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	blocked, err := p.state.DB.IsDomainBlocked(ctx, remoteURL.Hostname())
+	if err != nil {
+		err := gtserror.Newf("db error checking domain block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if blocked {
+		err := fmt.Errorf("domain of url %s is blocked", remoteURL)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	owned, err := p.proxiedImageOwned(ctx, ownerID, remoteURL.String())
+	if err != nil {
+		err := gtserror.Newf("db error checking proxied image owner: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !owned {
+		err := fmt.Errorf("url %s not found in status or account %s", remoteURL, ownerID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	image, err := p.state.DB.GetProxiedImageByURL(ctx, remoteURL.String())
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting proxied image: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if image == nil {
+		// Not fetched yet (or evicted), get it now.
+		var errWithCode gtserror.WithCode
+		image, errWithCode = p.fetchProxiedImage(ctx, remoteURL)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+	} else if time.Since(image.AccessedAt) > proxiedImageAccessFreq {
+		// Mark as recently accessed, so it won't be evicted.
+		image.AccessedAt = time.Now()
+		if err := p.state.DB.UpdateProxiedImage(ctx, image, "accessed_at"); err != nil {
+			log.Errorf(ctx, "db error updating proxied image: %v", err)
+		}
+	}
+
+	rc, err := p.state.Storage.GetStream(ctx, image.StoragePath())
+	if err != nil {
+		err := gtserror.Newf("error getting proxied image %s from storage: %w", image.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.Content{
+		ContentType:   image.ContentType,
+		ContentLength: int64(image.FileSize),
+		Content:       rc,
+	}, nil
+}
+
+// proxiedImageOwned returns whether the image at remoteURL is embedded in
+// the content of the remote status or note of the remote account with the
+// given owner ID, or is the preview card image of the status with that ID.
+func (p *Processor) proxiedImageOwned(ctx context.Context, ownerID string, remoteURL string) (bool, error) {
+	contains := func(content string) bool {
+		for _, src := range text.ImageSources(content) {
+			if src == remoteURL {
+				return true
+			}
+		}
+		return false
+	}
+
+	status, err := p.state.DB.GetStatusByID(gtscontext.SetBarebones(ctx), ownerID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, err
+	}
+
+	if status != nil {
+		if status.PreviewCardID != "" {
+			card, err := p.state.DB.GetPreviewCardByID(ctx, status.PreviewCardID)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return false, err
+			}
+
+			if card != nil && card.Image == remoteURL {
+				return true, nil
+			}
+		}
+
+		return !*status.Local && contains(status.Content), nil
+	}
+
+	account, err := p.state.DB.GetAccountByID(gtscontext.SetBarebones(ctx), ownerID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, err
+	}
+
+	if account != nil {
+		return !account.IsLocal() && contains(account.Note), nil
+	}
+
+	return false, nil
+}
+
+// fetchProxiedImage fetches the image at remoteURL, checks that it's an
+// image of an acceptable type and size, and puts it in storage + the db.
+func (p *Processor) fetchProxiedImage(ctx context.Context, remoteURL *url.URL) (*gtsmodel.ProxiedImage, gtserror.WithCode) {
+	tsport, err := p.transportController.NewTransportForUsername(ctx, "")
+	if err != nil {
+		err := gtserror.Newf("error getting instance transport: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	rc, _, err := tsport.DereferenceMedia(ctx, remoteURL)
+	if err != nil {
+		err := gtserror.Newf("error fetching image %s: %w", remoteURL, err)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+	defer rc.Close()
+
+	// Read up to one byte more than the max size,
+	// so we can tell if the image is too large.
+	maxSize := int64(config.GetMediaImageMaxSize())
+	b, err := io.ReadAll(io.LimitReader(rc, maxSize+1))
+	if err != nil {
+		err := gtserror.Newf("error reading image %s: %w", remoteURL, err)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if int64(len(b)) > maxSize {
+		err := fmt.Errorf("image %s is larger than %d bytes", remoteURL, maxSize)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	info, err := filetype.Match(b)
+	if err != nil {
+		err := gtserror.Newf("error parsing file type of %s: %w", remoteURL, err)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	switch info.Extension {
+	case "gif", "jpg", "jpeg", "png", "webp":
+		// Image types we also accept as media.
+	default:
+		err := fmt.Errorf("image %s has unsupported file type: %s", remoteURL, info.Extension)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	image := &gtsmodel.ProxiedImage{
+		ID:          id.NewULID(),
+		URL:         remoteURL.String(),
+		ContentType: info.MIME.Value,
+		FileSize:    len(b),
+		AccessedAt:  time.Now(),
+	}
+
+	if _, err := p.state.Storage.PutStream(ctx, image.StoragePath(), bytes.NewReader(b)); err != nil {
+		err := gtserror.Newf("error storing image %s: %w", remoteURL, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.PutProxiedImage(ctx, image); err != nil {
+		// Clean up the now unused stored image.
+		if err := p.state.Storage.Delete(ctx, image.StoragePath()); err != nil {
+			log.Errorf(ctx, "error removing image %s from storage: %v", image.ID, err)
+		}
+
+		if !errors.Is(err, db.ErrAlreadyExists) {
+			err := gtserror.Newf("db error putting proxied image: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// Image was fetched concurrently
+		// by another request, use that.
+		image, err = p.state.DB.GetProxiedImageByURL(ctx, remoteURL.String())
+		if err != nil {
+			err := gtserror.Newf("db error getting proxied image: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return image, nil
+}
@@ -0,0 +1,215 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+type GetProxiedTestSuite struct {
+	MediaStandardTestSuite
+}
+
+// proxyPath returns the owner ID, encoded url and signature
+// parts of an image proxy uri for remoteURL in ownerID.
+func (suite *GetProxiedTestSuite) proxyPath(ownerID string, remoteURL string) (string, string, string) {
+	u, err := url.Parse(uris.GenerateURIForProxiedImage(ownerID, remoteURL))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	parts := strings.Split(strings.TrimPrefix(u.Path, "/"+uris.ProxyPath+"/"), "/")
+	if len(parts) != 3 {
+		suite.FailNow("unexpected proxy uri path: " + u.Path)
+	}
+
+	return parts[0], parts[1], parts[2]
+}
+
+// get gets remoteURL from the image proxy,
+// as found in the status or account with ownerID.
+func (suite *GetProxiedTestSuite) get(ownerID string, remoteURL string) (*apimodel.Content, gtserror.WithCode) {
+	ownerID, encoded, signature := suite.proxyPath(ownerID, remoteURL)
+	return suite.mediaProcessor.GetProxiedImage(context.Background(), ownerID, encoded, signature)
+}
+
+// embed updates the content of the given
+// status to include an image at remoteURL.
+func (suite *GetProxiedTestSuite) embed(status *gtsmodel.Status, remoteURL string) {
+	status.Content += `<p><img src="` + remoteURL + `" alt="an image"></p>`
+	if err := suite.db.UpdateStatus(context.Background(), status, "content"); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *GetProxiedTestSuite) TestGetProxiedImage() {
+	ctx := context.Background()
+	config.SetMediaProxyEnabled(true)
+
+	remoteURL := "http://fossbros-anonymous.io/attachments/original/13bbc3f8-2b5e-46ea-9531-40b4974d9912.jpg"
+	remoteFile := suite.testRemoteAttachments[remoteURL]
+
+	status := suite.testStatuses["remote_account_1_status_1"]
+	suite.embed(status, remoteURL)
+
+	content, errWithCode := suite.get(status.ID, remoteURL)
+	suite.NoError(errWithCode)
+	suite.Equal("image/jpeg", content.ContentType)
+	suite.EqualValues(len(remoteFile.Data), content.ContentLength)
+
+	b, err := io.ReadAll(content.Content)
+	suite.NoError(err)
+	suite.NoError(content.Content.Close())
+	suite.Equal(remoteFile.Data, b)
+
+	// The image should now be cached.
+	image, err := suite.db.GetProxiedImageByURL(ctx, remoteURL)
+	suite.NoError(err)
+	suite.Equal(len(remoteFile.Data), image.FileSize)
+
+	stored, err := suite.storage.Get(ctx, image.StoragePath())
+	suite.NoError(err)
+	suite.Equal(remoteFile.Data, stored)
+
+	// Getting it again should serve the cached copy.
+	content, errWithCode = suite.get(status.ID, remoteURL)
+	suite.NoError(errWithCode)
+	suite.NoError(content.Content.Close())
+
+	again, err := suite.db.GetProxiedImageByURL(ctx, remoteURL)
+	suite.NoError(err)
+	suite.Equal(image.ID, again.ID)
+}
+
+func (suite *GetProxiedTestSuite) TestGetProxiedImageDisabled() {
+	config.SetMediaProxyEnabled(false)
+
+	remoteURL := "http://fossbros-anonymous.io/attachments/original/13bbc3f8-2b5e-46ea-9531-40b4974d9912.jpg"
+
+	status := suite.testStatuses["remote_account_1_status_1"]
+	suite.embed(status, remoteURL)
+
+	_, errWithCode := suite.get(status.ID, remoteURL)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *GetProxiedTestSuite) TestGetProxiedImageLocal() {
+	config.SetMediaProxyEnabled(true)
+
+	localURL := suite.testAttachments["admin_account_status_1_attachment_1"].URL
+
+	status := suite.testStatuses["remote_account_1_status_1"]
+	suite.embed(status, localURL)
+
+	_, errWithCode := suite.get(status.ID, localURL)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *GetProxiedTestSuite) TestGetProxiedImageNotFound() {
+	config.SetMediaProxyEnabled(true)
+
+	remoteURL := "https://fossbros-anonymous.io/not/an/image.png"
+
+	status := suite.testStatuses["remote_account_1_status_1"]
+	suite.embed(status, remoteURL)
+
+	_, errWithCode := suite.get(status.ID, remoteURL)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *GetProxiedTestSuite) TestGetProxiedImageForged() {
+	ctx := context.Background()
+	config.SetMediaProxyEnabled(true)
+
+	remoteURL := "http://fossbros-anonymous.io/attachments/original/13bbc3f8-2b5e-46ea-9531-40b4974d9912.jpg"
+	otherURL := "http://fossbros-anonymous.io/attachments/original/some-other-image.jpg"
+
+	status := suite.testStatuses["remote_account_1_status_1"]
+	suite.embed(status, remoteURL)
+	suite.embed(status, otherURL)
+
+	ownerID, encoded, signature := suite.proxyPath(status.ID, remoteURL)
+	_, otherEncoded, otherSignature := suite.proxyPath(status.ID, otherURL)
+	otherOwnerID, _, _ := suite.proxyPath(suite.testAccounts["remote_account_1"].ID, remoteURL)
+
+	for _, forged := range []struct {
+		name      string
+		ownerID   string
+		encoded   string
+		signature string
+	}{
+		{"unsigned", ownerID, encoded, ""},
+		{"garbage signature", ownerID, encoded, "not-a-signature"},
+		{"signature for another url", ownerID, encoded, otherSignature},
+		{"url swapped under signature", ownerID, otherEncoded, signature},
+		{"owner swapped under signature", otherOwnerID, encoded, signature},
+	} {
+		_, errWithCode := suite.mediaProcessor.GetProxiedImage(ctx, forged.ownerID, forged.encoded, forged.signature)
+		if suite.NotNil(errWithCode, forged.name) {
+			suite.Equal(http.StatusNotFound, errWithCode.Code(), forged.name)
+		}
+	}
+
+	// None of these should have fetched anything.
+	_, err := suite.db.GetProxiedImageByURL(ctx, remoteURL)
+	suite.Error(err)
+	_, err = suite.db.GetProxiedImageByURL(ctx, otherURL)
+	suite.Error(err)
+}
+
+func (suite *GetProxiedTestSuite) TestGetProxiedImageNotInOwner() {
+	config.SetMediaProxyEnabled(true)
+
+	remoteURL := "http://fossbros-anonymous.io/attachments/original/13bbc3f8-2b5e-46ea-9531-40b4974d9912.jpg"
+
+	// Validly signed, but the status doesn't contain the image.
+	_, errWithCode := suite.get(suite.testStatuses["remote_account_1_status_1"].ID, remoteURL)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Validly signed, but the owner doesn't exist.
+	_, errWithCode = suite.get("01H7TKAJ8EPWXHMC8CC4CDA3NR", remoteURL)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *GetProxiedTestSuite) TestGetProxiedImageLocalStatus() {
+	config.SetMediaProxyEnabled(true)
+
+	remoteURL := "http://fossbros-anonymous.io/attachments/original/13bbc3f8-2b5e-46ea-9531-40b4974d9912.jpg"
+
+	status := suite.testStatuses["local_account_1_status_1"]
+	suite.embed(status, remoteURL)
+
+	_, errWithCode := suite.get(status.ID, remoteURL)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestGetProxiedTestSuite(t *testing.T) {
+	suite.Run(t, &GetProxiedTestSuite{})
+}
@@ -0,0 +1,37 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package polls
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// PollGet returns the poll with the given ID, as seen by the
+// requesting account, provided its status is visible to them.
+func (p *Processor) PollGet(ctx context.Context, requestingAccount *gtsmodel.Account, pollID string) (*apimodel.Poll, gtserror.WithCode) {
+	poll, errWithCode := p.getVisiblePoll(ctx, requestingAccount, pollID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiPoll(ctx, requestingAccount, poll)
+}
@@ -0,0 +1,90 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package polls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+)
+
+type Processor struct {
+	state  *state.State
+	tc     typeutils.TypeConverter
+	filter *visibility.Filter
+}
+
+func New(state *state.State, tc typeutils.TypeConverter, filter *visibility.Filter) Processor {
+	return Processor{
+		state:  state,
+		tc:     tc,
+		filter: filter,
+	}
+}
+
+// getVisiblePoll gets the poll with the given ID, with its
+// status set, provided the status is visible to the requester.
+func (p *Processor) getVisiblePoll(ctx context.Context, requestingAccount *gtsmodel.Account, pollID string) (*gtsmodel.Poll, gtserror.WithCode) {
+	poll, err := p.state.DB.GetPollByID(ctx, pollID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := fmt.Errorf("poll %s not found", pollID)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+		err = gtserror.Newf("db error getting poll %s: %w", pollID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	status, err := p.state.DB.GetStatusByID(ctx, poll.StatusID)
+	if err != nil {
+		err = gtserror.Newf("db error getting status %s of poll %s: %w", poll.StatusID, pollID, err)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	visible, err := p.filter.StatusVisible(ctx, requestingAccount, status)
+	if err != nil {
+		err = gtserror.Newf("error seeing if status %s is visible: %w", status.ID, err)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if !visible {
+		err := fmt.Errorf("poll %s not found", pollID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	poll.Status = status
+	return poll, nil
+}
+
+func (p *Processor) apiPoll(ctx context.Context, requestingAccount *gtsmodel.Account, poll *gtsmodel.Poll) (*apimodel.Poll, gtserror.WithCode) {
+	apiPoll, err := p.tc.PollToAPIPoll(ctx, requestingAccount, poll)
+	if err != nil {
+		err = gtserror.Newf("error converting poll %s to frontend representation: %w", poll.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPoll, nil
+}
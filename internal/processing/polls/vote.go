@@ -0,0 +1,136 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package polls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// PollVote casts a vote by the requesting account for the
+// given choices (option indices) in the poll with the given ID,
+// returning the updated poll.
+//
+// Votes in remote polls are federated to the poll's author.
+func (p *Processor) PollVote(ctx context.Context, requestingAccount *gtsmodel.Account, pollID string, choices []int) (*apimodel.Poll, gtserror.WithCode) {
+	poll, errWithCode := p.getVisiblePoll(ctx, requestingAccount, pollID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if poll.Status.AccountID == requestingAccount.ID {
+		err := errors.New("you can't vote in your own poll")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if poll.Expired() {
+		err := fmt.Errorf("poll %s has ended", pollID)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if errWithCode := validateChoices(poll, choices); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if _, err := p.state.DB.GetPollVoteBy(ctx, pollID, requestingAccount.ID); err == nil {
+		err := fmt.Errorf("you have already voted in poll %s", pollID)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	} else if !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error checking for existing vote: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	vote := &gtsmodel.PollVote{
+		ID:        id.NewULID(),
+		CreatedAt: time.Now(),
+		PollID:    pollID,
+		AccountID: requestingAccount.ID,
+		Account:   requestingAccount,
+		Choices:   choices,
+	}
+
+	if err := p.state.DB.PutPollVote(ctx, vote); err != nil {
+		err = gtserror.Newf("db error putting vote: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Use the updated vote counts
+	// from the poll set on the vote.
+	vote.Poll.Status = poll.Status
+	poll = vote.Poll
+
+	// The voter's prepared copy of the status
+	// no longer reflects that they've voted.
+	if err := p.state.Timelines.Home.UnprepareItem(ctx, requestingAccount.ID, poll.StatusID); err != nil {
+		log.Errorf(ctx, "error unpreparing status %s from home timeline: %v", poll.StatusID, err)
+	}
+
+	if !*poll.Status.Local {
+		// Let the poll's author
+		// know about the vote.
+		p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+			APObjectType:   ap.ActivityQuestion,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       vote,
+			OriginAccount:  requestingAccount,
+		})
+	}
+
+	return p.apiPoll(ctx, requestingAccount, poll)
+}
+
+// validateChoices checks that the given choices
+// make up a valid vote in the given poll.
+func validateChoices(poll *gtsmodel.Poll, choices []int) gtserror.WithCode {
+	if len(choices) == 0 {
+		err := errors.New("no choices provided")
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if len(choices) > 1 && (poll.Multiple == nil || !*poll.Multiple) {
+		err := fmt.Errorf("poll %s only allows one choice, %d provided", poll.ID, len(choices))
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	seen := make(map[int]bool, len(choices))
+	for _, choice := range choices {
+		if choice < 0 || choice >= len(poll.Options) {
+			err := fmt.Errorf("choice %d is not an option in poll %s", choice, poll.ID)
+			return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+
+		if seen[choice] {
+			err := fmt.Errorf("choice %d provided more than once", choice)
+			return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+		seen[choice] = true
+	}
+
+	return nil
+}
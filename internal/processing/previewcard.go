@@ -0,0 +1,453 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/url"
+	"time"
+
+	"codeberg.org/gruf/go-bytesize"
+	"github.com/miekg/dns"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/linkpreview"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/ulule/limiter/v3"
+	"github.com/ulule/limiter/v3/drivers/store/memory"
+)
+
+const (
+	// previewCardTTL is how long a fetched preview
+	// card is reused for, before the page is fetched
+	// again the next time a status links to it.
+	previewCardTTL = 24 * time.Hour
+
+	// previewCardTimeout limits the time spent
+	// fetching a page (and its oEmbed) for a card.
+	previewCardTimeout = 10 * time.Second
+
+	// previewCardMaxPageSize and previewCardMaxOEmbedSize
+	// limit how much is read of fetched pages and oEmbeds;
+	// card metadata should be near the top of the page.
+	previewCardMaxPageSize   = int64(bytesize.MiB)
+	previewCardMaxOEmbedSize = int64(64 * bytesize.KiB)
+
+	// oembedRateLimit is the max number of oEmbeds
+	// fetched from each domain per oembedRatePeriod.
+	oembedRateLimit  = 30
+	oembedRatePeriod = time.Minute
+)
+
+// newOEmbedLimiter returns a limiter for oEmbed fetches, permitting
+// oembedRateLimit fetches per oembedRatePeriod from each domain.
+func newOEmbedLimiter() *limiter.Limiter {
+	return limiter.New(
+		memory.NewStore(),
+		limiter.Rate{Period: oembedRatePeriod, Limit: oembedRateLimit},
+	)
+}
+
+// enqueuePreviewCard queues fetching a preview card for the first
+// external link in the given status, if it has one. Direct statuses
+// never get cards, since fetching the link could leak that it was
+// shared privately.
+func (p *Processor) enqueuePreviewCard(status *gtsmodel.Status) {
+	if status.Visibility == gtsmodel.VisibilityDirect || status.PreviewCardID != "" {
+		return
+	}
+
+	link := linkpreview.FindLink(status.Content, func(u *url.URL) bool {
+		// Host rather than hostname,
+		// since config host may have a port.
+		return u.Host == config.GetHost() || u.Host == config.GetAccountDomain()
+	})
+	if link == nil {
+		return
+	}
+
+	statusID := status.ID
+	p.state.Workers.Media.Enqueue(func(ctx context.Context) {
+		if err := p.attachPreviewCard(ctx, statusID, link); err != nil {
+			log.Warnf(ctx, "error attaching preview card for %s to status %s: %v", link, statusID, err)
+		}
+	})
+}
+
+// attachPreviewCard gets a preview card for link, and
+// stores it as the preview card of the given status.
+func (p *Processor) attachPreviewCard(ctx context.Context, statusID string, link *url.URL) error {
+	card, err := p.getPreviewCard(ctx, link)
+	if err != nil {
+		return err
+	}
+
+	if card == nil {
+		// Nothing to show.
+		return nil
+	}
+
+	status, err := p.state.DB.GetStatusByID(ctx, statusID)
+	if err != nil {
+		return gtserror.Newf("db error getting status: %w", err)
+	}
+
+	status.PreviewCardID = card.ID
+	status.PreviewCard = card
+	if err := p.state.DB.UpdateStatus(ctx, status, "preview_card_id"); err != nil {
+		return gtserror.Newf("db error updating status: %w", err)
+	}
+
+	// Make sure timelines pick up the card.
+	p.invalidateStatusFromTimelines(ctx, statusID)
+
+	return nil
+}
+
+// getPreviewCard returns the stored preview card for link if
+// it's still fresh, or else fetches the page at link to build
+// (or rebuild) the card. A stale card is returned as-is if the
+// page can't be fetched. A nil card with no error means the page
+// has no preview worth showing, or its domain is blocked.
+func (p *Processor) getPreviewCard(ctx context.Context, link *url.URL) (*gtsmodel.PreviewCard, error) {
+	blocked, err := p.state.DB.IsDomainBlocked(ctx, link.Hostname())
+	if err != nil {
+		return nil, gtserror.Newf("db error checking domain block: %w", err)
+	}
+
+	if blocked {
+		return nil, nil
+	}
+
+	card, err := p.state.DB.GetPreviewCardByURL(ctx, link.String())
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting preview card: %w", err)
+	}
+
+	if card != nil && time.Since(card.FetchedAt) < previewCardTTL {
+		// Fresh enough.
+		return card, nil
+	}
+
+	fetched, err := p.fetchPreviewCard(ctx, link)
+	if err != nil {
+		if card != nil {
+			// Better stale than nothing.
+			log.Debugf(ctx, "error refreshing preview card for %s: %v", link, err)
+			return card, nil
+		}
+		return nil, err
+	}
+
+	if fetched == nil {
+		return card, nil
+	}
+
+	if card != nil {
+		// Refresh the existing card.
+		fetched.ID = card.ID
+		fetched.CreatedAt = card.CreatedAt
+		if err := p.state.DB.UpdatePreviewCard(ctx, fetched); err != nil {
+			return nil, gtserror.Newf("db error updating preview card: %w", err)
+		}
+		return fetched, nil
+	}
+
+	fetched.ID = id.NewULID()
+	if err := p.state.DB.PutPreviewCard(ctx, fetched); err != nil {
+		if !errors.Is(err, db.ErrAlreadyExists) {
+			return nil, gtserror.Newf("db error putting preview card: %w", err)
+		}
+
+		// Card was fetched concurrently
+		// for another status, use that.
+		return p.state.DB.GetPreviewCardByURL(ctx, link.String())
+	}
+
+	return fetched, nil
+}
+
+// fetchPreviewCard fetches the page at link, and builds a preview
+// card from it. If the page asks not to be indexed, or provides no
+// OpenGraph or oEmbed metadata, the card only includes its title.
+// Links to trusted oEmbed providers get a card embedding their media.
+func (p *Processor) fetchPreviewCard(ctx context.Context, link *url.URL) (*gtsmodel.PreviewCard, error) {
+	ctx, cancel := context.WithTimeout(ctx, previewCardTimeout)
+	defer cancel()
+
+	tsport, err := p.federator.TransportController().NewTransportForUsername(ctx, "")
+	if err != nil {
+		return nil, gtserror.Newf("error getting instance transport: %w", err)
+	}
+
+	if provider := oembedProviderFor(link); provider != nil {
+		// Link to a trusted audio / video provider,
+		// ask it directly for media to embed.
+		card, err := p.fetchEmbedCard(ctx, tsport, link, provider)
+		if err != nil {
+			log.Debugf(ctx, "error fetching oEmbed for %s from provider: %v", link, err)
+		} else if card != nil {
+			return card, nil
+		}
+
+		// Nothing to embed,
+		// fall back to the page.
+	}
+
+	rc, header, err := tsport.DereferencePage(ctx, link, "text/html,application/xhtml+xml")
+	if err != nil {
+		return nil, gtserror.Newf("error fetching page: %w", err)
+	}
+	defer rc.Close()
+
+	if ct, _, _ := mime.ParseMediaType(header.Get("Content-Type")); ct != "text/html" && ct != "application/xhtml+xml" {
+		// Not a web page, no preview.
+		return nil, nil
+	}
+
+	page, err := linkpreview.ParseHTML(io.LimitReader(rc, previewCardMaxPageSize), link)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing page: %w", err)
+	}
+
+	if page.Title == "" {
+		// Nothing to show.
+		return nil, nil
+	}
+
+	card := &gtsmodel.PreviewCard{
+		URL:       link.String(),
+		Title:     page.Title,
+		FetchedAt: time.Now(),
+	}
+
+	if author := p.previewCardAuthor(ctx, link, page.FediverseCreator); author != nil {
+		card.AuthorAccountID = author.ID
+		card.AuthorAccount = author
+	}
+
+	if page.NoIndex || linkpreview.IsNoIndex(header.Get("X-Robots-Tag")) {
+		// Publisher opted out of
+		// previews, title only.
+		return card, nil
+	}
+
+	var oembed *linkpreview.OEmbed
+	if page.OEmbedURL != "" {
+		oembed, err = p.fetchOEmbed(ctx, tsport, page.OEmbedURL)
+		if err != nil {
+			log.Debugf(ctx, "error fetching oEmbed for %s: %v", link, err)
+		}
+	}
+
+	if !page.HasOpenGraph && oembed == nil {
+		// No preview metadata, title only.
+		return card, nil
+	}
+
+	card.Description = page.Description
+	card.AuthorName = page.AuthorName
+	card.ProviderName = page.SiteName
+	card.ProviderURL = link.Scheme + "://" + link.Host
+	card.Image = page.Image
+	card.Width = page.ImageWidth
+	card.Height = page.ImageHeight
+
+	if oembed != nil {
+		if !page.HasOpenGraph && oembed.Title != "" {
+			card.Title = oembed.Title
+		}
+		if oembed.AuthorName != "" {
+			card.AuthorName = oembed.AuthorName
+			card.AuthorURL = oembed.AuthorURL
+		}
+		if oembed.ProviderName != "" {
+			card.ProviderName = oembed.ProviderName
+			card.ProviderURL = oembed.ProviderURL
+		}
+		if card.Image == "" && oembed.ThumbnailURL != "" {
+			card.Image = oembed.ThumbnailURL
+			card.Width = int(oembed.ThumbnailWidth)
+			card.Height = int(oembed.ThumbnailHeight)
+		}
+	}
+
+	return card, nil
+}
+
+// previewCardAuthor resolves the account named in the fediverse:creator
+// meta tag of the page at link. To stop pages crediting any account they
+// like, the account is only returned if link is on the account's own
+// domain, or on one of the domains it has allowed to credit it.
+func (p *Processor) previewCardAuthor(ctx context.Context, link *url.URL, creator string) *gtsmodel.Account {
+	if creator == "" {
+		return nil
+	}
+
+	username, domain, err := util.ExtractWebfingerParts(creator)
+	if err != nil || domain == "" {
+		log.Debugf(ctx, "invalid fediverse:creator %q on %s", creator, link)
+		return nil
+	}
+
+	account, _, err := p.federator.GetAccountByUsernameDomain(
+		gtscontext.SetFastFail(ctx), "", username, domain,
+	)
+	if err != nil {
+		log.Debugf(ctx, "error resolving fediverse:creator %q on %s: %v", creator, link, err)
+		return nil
+	}
+
+	if !account.SuspendedAt.IsZero() {
+		return nil
+	}
+
+	domains := []string{account.Domain}
+	if account.IsLocal() {
+		domains = []string{config.GetHost(), config.GetAccountDomain()}
+	}
+	domains = append(domains, account.AttributionDomains...)
+
+	host := link.Hostname()
+	for _, d := range domains {
+		if d != "" && dns.IsSubDomain(d, host) {
+			return account
+		}
+	}
+
+	log.Debugf(ctx, "%s may not credit %s as its author", link, creator)
+	return nil
+}
+
+// fetchEmbedCard fetches the oEmbed for link from the endpoint of the
+// given trusted provider, and builds a video or audio card embedding
+// its media. A nil card with no error means there's nothing to embed.
+func (p *Processor) fetchEmbedCard(ctx context.Context, tsport transport.Transport, link *url.URL, provider *config.OEmbedProviderConfiguration) (*gtsmodel.PreviewCard, error) {
+	endpoint, err := url.Parse(provider.Endpoint)
+	if err != nil {
+		return nil, gtserror.Newf("invalid oEmbed endpoint: %w", err)
+	}
+
+	query := endpoint.Query()
+	query.Set("url", link.String())
+	query.Set("format", "json")
+	endpoint.RawQuery = query.Encode()
+
+	oembed, err := p.fetchOEmbed(ctx, tsport, endpoint.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var cardType string
+	switch oembed.Type {
+	case "video":
+		cardType = "video"
+	case "rich":
+		// Trusted providers only give rich
+		// embeds for players, eg., of music.
+		cardType = "audio"
+	default:
+		return nil, nil
+	}
+
+	// Only embed media from the provider itself.
+	embed := oembed.EmbedIframe(func(src *url.URL) bool {
+		return oembedProviderHasDomain(provider, src.Hostname())
+	})
+	if embed == "" {
+		return nil, nil
+	}
+
+	title := oembed.Title
+	if title == "" {
+		title = oembed.ProviderName
+	}
+
+	return &gtsmodel.PreviewCard{
+		URL:          link.String(),
+		Title:        title,
+		AuthorName:   oembed.AuthorName,
+		AuthorURL:    oembed.AuthorURL,
+		ProviderName: oembed.ProviderName,
+		ProviderURL:  oembed.ProviderURL,
+		Image:        oembed.ThumbnailURL,
+		Width:        int(oembed.Width),
+		Height:       int(oembed.Height),
+		Type:         cardType,
+		HTML:         embed,
+		FetchedAt:    time.Now(),
+	}, nil
+}
+
+// oembedProviderFor returns the configured oEmbed
+// provider of audio / video at link, if there is one.
+func oembedProviderFor(link *url.URL) *config.OEmbedProviderConfiguration {
+	host := link.Hostname()
+	for _, provider := range config.GetMediaOEmbedProviders() {
+		if provider.Endpoint != "" && oembedProviderHasDomain(&provider, host) {
+			return &provider
+		}
+	}
+	return nil
+}
+
+// oembedProviderHasDomain returns whether host is one of
+// the domains of the given provider, or a subdomain of one.
+func oembedProviderHasDomain(provider *config.OEmbedProviderConfiguration, host string) bool {
+	for _, domain := range provider.Domains {
+		if dns.IsSubDomain(domain, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchOEmbed fetches and parses the json oEmbed at oembedURL,
+// unless too many oEmbeds were fetched from its domain recently.
+func (p *Processor) fetchOEmbed(ctx context.Context, tsport transport.Transport, oembedURL string) (*linkpreview.OEmbed, error) {
+	u, err := url.Parse(oembedURL)
+	if err != nil {
+		return nil, err
+	}
+
+	lctx, err := p.oembedLimiter.Get(ctx, u.Hostname())
+	if err != nil {
+		return nil, gtserror.Newf("error checking oEmbed rate limit: %w", err)
+	}
+
+	if lctx.Reached {
+		return nil, gtserror.Newf("too many oEmbeds fetched from %s, try again later", u.Hostname())
+	}
+
+	rc, _, err := tsport.DereferencePage(ctx, u, "application/json")
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return linkpreview.ParseOEmbed(io.LimitReader(rc, previewCardMaxOEmbedSize))
+}
@@ -0,0 +1,435 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processing_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+// mockPage is a response served
+// by the mock http client.
+type mockPage struct {
+	code        int
+	contentType string
+	header      http.Header
+	body        string
+}
+
+type PreviewCardTestSuite struct {
+	ProcessingStandardTestSuite
+
+	pagesMu   sync.Mutex
+	pages     map[string]mockPage
+	requested []string
+}
+
+func (suite *PreviewCardTestSuite) SetupTest() {
+	suite.ProcessingStandardTestSuite.SetupTest()
+
+	suite.pages = make(map[string]mockPage)
+	suite.requested = nil
+
+	// Serve pages from suite.pages instead of
+	// the usual mock remote accounts + statuses.
+	suite.httpClient = testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		suite.pagesMu.Lock()
+		defer suite.pagesMu.Unlock()
+
+		suite.requested = append(suite.requested, req.URL.String())
+
+		page, ok := suite.pages[req.URL.String()]
+		if !ok {
+			page = mockPage{code: http.StatusNotFound, contentType: "text/plain", body: "not found"}
+		}
+
+		header := http.Header{}
+		for k, v := range page.header {
+			header[k] = v
+		}
+		header.Set("Content-Type", page.contentType)
+
+		return &http.Response{
+			StatusCode:    page.code,
+			Status:        http.StatusText(page.code),
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader([]byte(page.body))),
+			ContentLength: int64(len(page.body)),
+			Request:       req,
+		}, nil
+	}, "")
+
+	suite.transportController = testrig.NewTestTransportController(&suite.state, suite.httpClient)
+	suite.federator = testrig.NewTestFederator(&suite.state, suite.transportController, suite.mediaManager)
+	suite.processor = processing.NewProcessor(suite.typeconverter, suite.federator, suite.oauthServer, suite.mediaManager, &suite.state, suite.emailSender)
+	suite.state.Workers.EnqueueClientAPI = suite.processor.EnqueueClientAPI
+	suite.state.Workers.EnqueueFederator = suite.processor.EnqueueFederator
+}
+
+func (suite *PreviewCardTestSuite) servePage(url string, page mockPage) {
+	suite.pagesMu.Lock()
+	defer suite.pagesMu.Unlock()
+
+	if page.code == 0 {
+		page.code = http.StatusOK
+	}
+	suite.pages[url] = page
+}
+
+func (suite *PreviewCardTestSuite) requestedURLs() []string {
+	suite.pagesMu.Lock()
+	defer suite.pagesMu.Unlock()
+
+	return append([]string{}, suite.requested...)
+}
+
+// postLink creates a status by local_account_1 linking to the given
+// url, and returns it once any preview card has been fetched for it.
+func (suite *PreviewCardTestSuite) postLink(url string, visibility gtsmodel.Visibility) *gtsmodel.Status {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	statusID := id.NewULID()
+
+	status := &gtsmodel.Status{
+		ID:                       statusID,
+		URI:                      account.URI + "/statuses/" + statusID,
+		URL:                      account.URL + "/statuses/" + statusID,
+		Content:                  `<p>look at this: <a href="` + url + `" rel="nofollow noreferrer noopener" target="_blank">` + url + `</a></p>`,
+		AttachmentIDs:            []string{},
+		TagIDs:                   []string{},
+		MentionIDs:               []string{},
+		EmojiIDs:                 []string{},
+		CreatedAt:                time.Now(),
+		UpdatedAt:                time.Now(),
+		Local:                    testrig.TrueBool(),
+		AccountURI:               account.URI,
+		AccountID:                account.ID,
+		Visibility:               visibility,
+		Sensitive:                testrig.FalseBool(),
+		Language:                 "en",
+		CreatedWithApplicationID: suite.testApplications["local_account_1"].ID,
+		Federated:                testrig.FalseBool(),
+		Boostable:                testrig.TrueBool(),
+		Replyable:                testrig.TrueBool(),
+		Likeable:                 testrig.TrueBool(),
+		ActivityStreamsType:      ap.ObjectNote,
+	}
+
+	if err := suite.db.PutStatus(ctx, status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.processor.ProcessFromClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       status,
+		OriginAccount:  account,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// There's only one media worker, so once this
+	// has run, fetching the card has finished too.
+	done := make(chan struct{})
+	suite.state.Workers.Media.Enqueue(func(context.Context) { close(done) })
+	select {
+	case <-done:
+	case <-time.After(time.Minute):
+		suite.FailNow("timed out waiting for media worker")
+	}
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, statusID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return dbStatus
+}
+
+func (suite *PreviewCardTestSuite) TestOpenGraph() {
+	suite.servePage("https://example.org/article", mockPage{
+		contentType: "text/html; charset=utf-8",
+		body: `<!DOCTYPE html>
+<html>
+<head>
+<title>Page title</title>
+<meta property="og:title" content="Article title">
+<meta property="og:description" content="An article about things.">
+<meta property="og:site_name" content="Example News">
+<meta property="og:image" content="/images/article.jpg">
+<meta property="og:image:width" content="1200">
+<meta property="og:image:height" content="630">
+<meta name="author" content="Some Writer">
+</head>
+<body><p>The article.</p></body>
+</html>`,
+	})
+
+	status := suite.postLink("https://example.org/article", gtsmodel.VisibilityPublic)
+	if !suite.NotEmpty(status.PreviewCardID) {
+		suite.FailNow("")
+	}
+
+	card, err := suite.db.GetPreviewCardByID(context.Background(), status.PreviewCardID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("https://example.org/article", card.URL)
+	suite.Equal("Article title", card.Title)
+	suite.Equal("An article about things.", card.Description)
+	suite.Equal("Example News", card.ProviderName)
+	suite.Equal("https://example.org", card.ProviderURL)
+	suite.Equal("Some Writer", card.AuthorName)
+	suite.Equal("https://example.org/images/article.jpg", card.Image)
+	suite.Equal(1200, card.Width)
+	suite.Equal(630, card.Height)
+	suite.WithinDuration(time.Now(), card.FetchedAt, time.Minute)
+
+	// Another status linking to the same page
+	// reuses the card without fetching it again.
+	status = suite.postLink("https://example.org/article", gtsmodel.VisibilityPublic)
+	suite.Equal(card.ID, status.PreviewCardID)
+	suite.Equal([]string{"https://example.org/article"}, suite.requestedURLs())
+}
+
+func (suite *PreviewCardTestSuite) TestOEmbedDiscovery() {
+	suite.servePage("https://video.example.org/watch/1", mockPage{
+		contentType: "text/html",
+		body: `<html><head>
+<title>Some video</title>
+<link rel="alternate" type="application/json+oembed" href="https://video.example.org/oembed?url=1">
+</head><body></body></html>`,
+	})
+	suite.servePage("https://video.example.org/oembed?url=1", mockPage{
+		contentType: "application/json",
+		body: `{
+  "type": "video",
+  "title": "Video title",
+  "author_name": "Video Maker",
+  "author_url": "https://video.example.org/maker",
+  "provider_name": "Example Video",
+  "provider_url": "https://video.example.org",
+  "thumbnail_url": "https://video.example.org/thumb.jpg",
+  "thumbnail_width": 640,
+  "thumbnail_height": "360"
+}`,
+	})
+
+	status := suite.postLink("https://video.example.org/watch/1", gtsmodel.VisibilityPublic)
+	if !suite.NotEmpty(status.PreviewCardID) {
+		suite.FailNow("")
+	}
+
+	// No OpenGraph, so details come from the oEmbed.
+	card, err := suite.db.GetPreviewCardByID(context.Background(), status.PreviewCardID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("Video title", card.Title)
+	suite.Equal("Video Maker", card.AuthorName)
+	suite.Equal("https://video.example.org/maker", card.AuthorURL)
+	suite.Equal("Example Video", card.ProviderName)
+	suite.Equal("https://video.example.org", card.ProviderURL)
+	suite.Equal("https://video.example.org/thumb.jpg", card.Image)
+	suite.Equal(640, card.Width)
+	suite.Equal(360, card.Height)
+
+	// Untrusted providers never get embedded.
+	suite.Empty(card.HTML)
+}
+
+// assertTitleOnly checks that linking to a page
+// served as the given page gives a title-only card.
+func (suite *PreviewCardTestSuite) assertTitleOnly(page mockPage) {
+	suite.servePage("https://example.org/private", page)
+
+	status := suite.postLink("https://example.org/private", gtsmodel.VisibilityPublic)
+	if !suite.NotEmpty(status.PreviewCardID) {
+		suite.FailNow("")
+	}
+
+	card, err := suite.db.GetPreviewCardByID(context.Background(), status.PreviewCardID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("Private page", card.Title)
+	suite.Empty(card.Description)
+	suite.Empty(card.Image)
+}
+
+func (suite *PreviewCardTestSuite) TestNoIndexMeta() {
+	suite.assertTitleOnly(mockPage{
+		contentType: "text/html",
+		body: `<html><head>
+<title>Private page</title>
+<meta name="robots" content="noindex, nofollow">
+<meta property="og:description" content="Shouldn't be shown.">
+<meta property="og:image" content="https://example.org/image.jpg">
+</head></html>`,
+	})
+}
+
+func (suite *PreviewCardTestSuite) TestNoIndexHeader() {
+	suite.assertTitleOnly(mockPage{
+		contentType: "text/html",
+		header:      http.Header{"X-Robots-Tag": {"noindex"}},
+		body: `<html><head>
+<title>Private page</title>
+<meta property="og:description" content="Shouldn't be shown.">
+<meta property="og:image" content="https://example.org/image.jpg">
+</head></html>`,
+	})
+}
+
+func (suite *PreviewCardTestSuite) TestNoOpenGraph() {
+	suite.assertTitleOnly(mockPage{
+		contentType: "text/html",
+		body: `<html><head>
+<title>Private page</title>
+<meta name="description" content="Shouldn't be shown.">
+</head></html>`,
+	})
+}
+
+func (suite *PreviewCardTestSuite) TestNoCard() {
+	for url, page := range map[string]*mockPage{
+		// Not a web page.
+		"https://example.org/file.pdf": {
+			contentType: "application/pdf",
+			body:        "%PDF-1.4",
+		},
+		// Nothing to title the card with.
+		"https://example.org/untitled": {
+			contentType: "text/html",
+			body:        `<html><head></head><body>hello</body></html>`,
+		},
+		// Page can't be fetched.
+		"https://example.org/gone": {
+			code:        http.StatusGone,
+			contentType: "text/plain",
+			body:        "gone",
+		},
+		// Not served at all, so 404.
+		"https://example.org/missing": nil,
+	} {
+		if page != nil {
+			suite.servePage(url, *page)
+		}
+
+		status := suite.postLink(url, gtsmodel.VisibilityPublic)
+		suite.Empty(status.PreviewCardID, url)
+		suite.Contains(suite.requestedURLs(), url)
+
+		_, err := suite.db.GetPreviewCardByURL(context.Background(), url)
+		suite.ErrorIs(err, db.ErrNoEntries, url)
+	}
+}
+
+func (suite *PreviewCardTestSuite) TestNotFetched() {
+	suite.servePage("https://replyguys.com/article", mockPage{
+		contentType: "text/html",
+		body:        `<html><head><title>Blocked</title></head></html>`,
+	})
+	suite.servePage("https://example.org/secret", mockPage{
+		contentType: "text/html",
+		body:        `<html><head><title>Secret</title></head></html>`,
+	})
+
+	// Domain of the link is blocked.
+	status := suite.postLink("https://replyguys.com/article", gtsmodel.VisibilityPublic)
+	suite.Empty(status.PreviewCardID)
+
+	// Link is to this instance.
+	status = suite.postLink("http://localhost:8080/@the_mighty_zork", gtsmodel.VisibilityPublic)
+	suite.Empty(status.PreviewCardID)
+
+	// Links in direct statuses are
+	// never fetched, to avoid leaking
+	// that they were shared privately.
+	status = suite.postLink("https://example.org/secret", gtsmodel.VisibilityDirect)
+	suite.Empty(status.PreviewCardID)
+
+	suite.Empty(suite.requestedURLs())
+}
+
+func (suite *PreviewCardTestSuite) TestStaleCard() {
+	ctx := context.Background()
+
+	// Put a card in the db which is due a refresh.
+	stale := &gtsmodel.PreviewCard{
+		ID:        id.NewULID(),
+		URL:       "https://example.org/article",
+		Title:     "Old title",
+		FetchedAt: time.Now().Add(-48 * time.Hour),
+	}
+	if err := suite.db.PutPreviewCard(ctx, stale); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The page can't be fetched right now,
+	// so the stale card is better than nothing.
+	suite.servePage("https://example.org/article", mockPage{
+		code:        http.StatusServiceUnavailable,
+		contentType: "text/plain",
+		body:        "try again later",
+	})
+
+	status := suite.postLink("https://example.org/article", gtsmodel.VisibilityPublic)
+	suite.Equal(stale.ID, status.PreviewCardID)
+
+	card, err := suite.db.GetPreviewCardByID(ctx, stale.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("Old title", card.Title)
+
+	// Once the page is back, the same
+	// card is refreshed in place.
+	suite.servePage("https://example.org/article", mockPage{
+		contentType: "text/html",
+		body:        `<html><head><meta property="og:title" content="New title"></head></html>`,
+	})
+
+	status = suite.postLink("https://example.org/article", gtsmodel.VisibilityPublic)
+	suite.Equal(stale.ID, status.PreviewCardID)
+
+	card, err = suite.db.GetPreviewCardByID(ctx, stale.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("New title", card.Title)
+	suite.WithinDuration(time.Now(), card.FetchedAt, time.Minute)
+	suite.Len(suite.requestedURLs(), 2)
+}
+
+func TestPreviewCardTestSuite(t *testing.T) {
+	suite.Run(t, &PreviewCardTestSuite{})
+}
@@ -20,6 +20,7 @@ package processing
 import (
 	"context"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -29,8 +30,10 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/admin"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/fedi"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/interactionrequests"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/list"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/media"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/polls"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/report"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/search"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
@@ -40,6 +43,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+	"github.com/ulule/limiter/v3"
 )
 
 type Processor struct {
@@ -51,21 +55,31 @@ type Processor struct {
 	emailSender  email.Sender
 	filter       *visibility.Filter
 
+	// instanceActivity caches the
+	// results of InstanceActivityGet.
+	instanceActivity instanceActivityCache
+
+	// oembedLimiter limits oEmbed
+	// fetches for preview cards.
+	oembedLimiter *limiter.Limiter
+
 	/*
 		SUB-PROCESSORS
 	*/
 
-	account  account.Processor
-	admin    admin.Processor
-	fedi     fedi.Processor
-	list     list.Processor
-	media    media.Processor
-	report   report.Processor
-	search   search.Processor
-	status   status.Processor
-	stream   stream.Processor
-	timeline timeline.Processor
-	user     user.Processor
+	account             account.Processor
+	admin               admin.Processor
+	fedi                fedi.Processor
+	interactionRequests interactionrequests.Processor
+	list                list.Processor
+	media               media.Processor
+	polls               polls.Processor
+	report              report.Processor
+	search              search.Processor
+	status              status.Processor
+	stream              stream.Processor
+	timeline            timeline.Processor
+	user                user.Processor
 }
 
 func (p *Processor) Account() *account.Processor {
@@ -80,6 +94,10 @@ func (p *Processor) Fedi() *fedi.Processor {
 	return &p.fedi
 }
 
+func (p *Processor) InteractionRequests() *interactionrequests.Processor {
+	return &p.interactionRequests
+}
+
 func (p *Processor) List() *list.Processor {
 	return &p.list
 }
@@ -88,6 +106,10 @@ func (p *Processor) Media() *media.Processor {
 	return &p.media
 }
 
+func (p *Processor) Polls() *polls.Processor {
+	return &p.polls
+}
+
 func (p *Processor) Report() *report.Processor {
 	return &p.report
 }
@@ -126,27 +148,30 @@ func NewProcessor(
 	filter := visibility.NewFilter(state)
 
 	processor := &Processor{
-		federator:    federator,
-		tc:           tc,
-		oauthServer:  oauthServer,
-		mediaManager: mediaManager,
-		state:        state,
-		filter:       filter,
-		emailSender:  emailSender,
+		federator:     federator,
+		tc:            tc,
+		oauthServer:   oauthServer,
+		mediaManager:  mediaManager,
+		state:         state,
+		filter:        filter,
+		emailSender:   emailSender,
+		oembedLimiter: newOEmbedLimiter(),
 	}
 
 	// Instantiate sub processors.
-	processor.account = account.New(state, tc, mediaManager, oauthServer, federator, filter, parseMentionFunc)
+	processor.account = account.New(state, tc, mediaManager, oauthServer, federator, filter, parseMentionFunc, emailSender)
 	processor.admin = admin.New(state, tc, mediaManager, federator.TransportController(), emailSender)
 	processor.fedi = fedi.New(state, tc, federator, filter)
+	processor.interactionRequests = interactionrequests.New(state, tc)
 	processor.list = list.New(state, tc)
 	processor.media = media.New(state, tc, mediaManager, federator.TransportController())
+	processor.polls = polls.New(state, tc, filter)
 	processor.report = report.New(state, tc)
 	processor.timeline = timeline.New(state, tc, filter)
 	processor.search = search.New(state, federator, tc, filter)
 	processor.status = status.New(state, federator, tc, filter, parseMentionFunc)
 	processor.stream = stream.New(state, oauthServer)
-	processor.user = user.New(state, emailSender)
+	processor.user = user.New(state, tc, emailSender)
 
 	return processor
 }
@@ -164,6 +189,33 @@ func (p *Processor) EnqueueClientAPI(ctx context.Context, msgs ...messages.FromC
 }
 
 func (p *Processor) EnqueueFederator(ctx context.Context, msgs ...messages.FromFederator) {
+	if config.GetMaintenanceMode() {
+		// Defer processing until
+		// maintenance mode is off.
+		log.Trace(ctx, "deferring to backlog")
+		if err := p.state.Workers.FederatorBacklog.Append(ctx, msgs...); err != nil {
+			// Don't lose the messages: process them now instead.
+			log.Errorf(ctx, "error deferring to backlog, enqueuing: %v", err)
+		} else {
+			if config.GetMaintenanceMode() {
+				return
+			}
+
+			// Maintenance mode was turned off in the meantime, and
+			// the backlog may have already been taken to be enqueued;
+			// make sure that these messages aren't left behind in it.
+			msgs, err = p.state.Workers.FederatorBacklog.Take(ctx)
+			if err != nil {
+				log.Errorf(ctx, "error taking backlog: %v", err)
+				return
+			}
+
+			if len(msgs) == 0 {
+				return
+			}
+		}
+	}
+
 	log.Trace(ctx, "enqueuing")
 	_ = p.state.Workers.Federator.MustEnqueueCtx(ctx, func(ctx context.Context) {
 		for _, msg := range msgs {
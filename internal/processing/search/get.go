@@ -271,12 +271,15 @@ func (p *Processor) accountsByNamestring(
 	// No error, and domain and username were both set.
 	// Caller is likely trying to search for an exact
 	// match, from either a remote instance or local.
+	//
+	// If caller only wants accounts they follow, they
+	// already know about the account, so never resolve.
 	foundAccount, err := p.accountByUsernameDomain(
 		ctx,
 		requestingAccount,
 		username,
 		domain,
-		resolve,
+		resolve && !following,
 	)
 	if err != nil {
 		// Check for semi-expected error types.
@@ -285,6 +288,18 @@ func (p *Processor) accountsByNamestring(
 			err = gtserror.Newf("error looking up %s as account: %w", query, err)
 			return false, gtserror.NewErrorInternalError(err)
 		}
+	} else if following {
+		// Only include the account
+		// if it's followed by caller.
+		isFollowing, err := p.state.DB.IsFollowing(ctx, requestingAccount.ID, foundAccount.ID)
+		if err != nil {
+			err = gtserror.Newf("error checking follow of %s: %w", query, err)
+			return false, gtserror.NewErrorInternalError(err)
+		}
+
+		if isFollowing {
+			appendAccount(foundAccount)
+		}
 	} else {
 		appendAccount(foundAccount)
 	}
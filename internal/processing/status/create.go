@@ -21,19 +21,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/api/errorcodes"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
 // Create processes the given form to create a new status, returning the api model representation of that status if it's OK.
@@ -42,6 +47,7 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, appli
 	thisStatusID := id.NewULID()
 	local := true
 	sensitive := form.Sensitive
+	threadTruncated := false
 
 	newStatus := &gtsmodel.Status{
 		ID:                       thisStatusID,
@@ -55,6 +61,7 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, appli
 		ContentWarning:           text.SanitizePlaintext(form.SpoilerText),
 		ActivityStreamsType:      ap.ObjectNote,
 		Sensitive:                &sensitive,
+		ThreadTruncated:          &threadTruncated,
 		Language:                 form.Language,
 		CreatedWithApplicationID: application.ID,
 		Text:                     form.Status,
@@ -72,16 +79,51 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, appli
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Status length limits depend on visibility,
+	// so this can only be checked once it's set.
+	user, err := p.state.DB.GetUserByAccountID(ctx, account.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting user for account %s: %w", account.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := validate.StatusText(form.Status, newStatus.Visibility, user); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := validate.StatusMediaFiles(len(form.MediaIDs), user); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
 	if err := processLanguage(ctx, form, account.Language, newStatus); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	processPlace(form, newStatus)
+
 	if err := processContent(ctx, p.state.DB, p.formatter, p.parseMention, form, account.ID, newStatus); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Hashtags are only known once content
+	// is parsed, so this can only be checked now.
+	if errWithCode := processRequiredContentWarning(ctx, p.state.DB, newStatus); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.Poll != nil {
+		// Put the poll first, so the
+		// status never refers to a poll
+		// that doesn't exist.
+		newStatus.Poll = newPoll(form.Poll, thisStatusID)
+		newStatus.PollID = newStatus.Poll.ID
+		if err := p.state.DB.PutPoll(ctx, newStatus.Poll); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
 	// put the new status in the database
-	if err := p.state.DB.PutStatus(ctx, newStatus); err != nil {
+	if err := putStatusWithAlias(ctx, p.state.DB, newStatus); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
@@ -96,6 +138,67 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, appli
 	return p.apiStatus(ctx, newStatus, account)
 }
 
+// CreateIdempotent is like Create, but given a non-empty Idempotency-Key
+// from the client, it makes sure only one status is created for the key.
+// A repeat of a request that already succeeded returns the status that was
+// created then, while a repeat of one still in progress gets a conflict error.
+func (p *Processor) CreateIdempotent(ctx context.Context, account *gtsmodel.Account, application *gtsmodel.Application, idempotencyKey string, form *apimodel.AdvancedStatusCreateForm) (*apimodel.Status, gtserror.WithCode) {
+	if idempotencyKey == "" {
+		return p.Create(ctx, account, application, form)
+	}
+
+	key := account.ID + ":" + idempotencyKey
+	if !p.idempotency.Add(key, "") {
+		statusID, _ := p.idempotency.Get(key)
+		if statusID == "" {
+			err := fmt.Errorf("a status with Idempotency-Key %s is already being created", idempotencyKey)
+			return nil, gtserror.NewErrorConflict(errorcodes.Set(err, errorcodes.DuplicateStatus), err.Error())
+		}
+
+		return p.Get(ctx, account, statusID)
+	}
+
+	apiStatus, errWithCode := p.Create(ctx, account, application, form)
+	if errWithCode != nil {
+		// Let the client retry.
+		p.idempotency.Invalidate(key)
+		return nil, errWithCode
+	}
+
+	p.idempotency.Set(key, apiStatus.ID)
+	return apiStatus, nil
+}
+
+// newPoll returns a new poll for the status with the given
+// ID, with the options and settings from the given request.
+func newPoll(form *apimodel.PollRequest, statusID string) *gtsmodel.Poll {
+	now := time.Now()
+	multiple := form.Multiple
+	hideCounts := form.HideTotals
+
+	options := make([]string, len(form.Options))
+	for i, option := range form.Options {
+		options[i] = text.SanitizePlaintext(option)
+	}
+
+	poll := &gtsmodel.Poll{
+		ID:         id.NewULID(),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		StatusID:   statusID,
+		Options:    options,
+		Votes:      make([]int, len(options)),
+		Multiple:   &multiple,
+		HideCounts: &hideCounts,
+	}
+
+	if form.ExpiresIn > 0 {
+		poll.ExpiresAt = now.Add(time.Duration(form.ExpiresIn) * time.Second)
+	}
+
+	return poll
+}
+
 func processReplyToID(ctx context.Context, dbService db.DB, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) gtserror.WithCode {
 	if form.InReplyToID == "" {
 		return nil
@@ -145,6 +248,10 @@ func processReplyToID(ctx context.Context, dbService db.DB, form *apimodel.Advan
 	status.InReplyToURI = repliedStatus.URI
 	status.InReplyToAccountID = repliedAccount.ID
 
+	// Replies by local accounts are always
+	// allowed, regardless of max thread depth.
+	status.ThreadDepth = repliedStatus.ThreadDepth + 1
+
 	return nil
 }
 
@@ -176,6 +283,11 @@ func processMediaIDs(ctx context.Context, dbService db.DB, form *apimodel.Advanc
 			return gtserror.NewErrorBadRequest(err, err.Error())
 		}
 
+		if attachment.Processing != gtsmodel.ProcessingStatusProcessed {
+			err = fmt.Errorf("ProcessMediaIDs: media with id %s has not finished processing, try again in a moment", mediaID)
+			return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+
 		minDescriptionChars := config.GetMediaDescriptionMinChars()
 		if descriptionLength := len([]rune(attachment.Description)); descriptionLength < minDescriptionChars {
 			err = fmt.Errorf("ProcessMediaIDs: description too short! media description of at least %d chararacters is required but %d was provided for media with id %s", minDescriptionChars, descriptionLength, mediaID)
@@ -276,6 +388,96 @@ func processLanguage(ctx context.Context, form *apimodel.AdvancedStatusCreateFor
 	return nil
 }
 
+// processPlace sets the place given in the form (if any) on the status.
+// Coordinates are only set if explicitly given, never guessed from eg.,
+// attached media, and are kept with limited precision.
+func processPlace(form *apimodel.AdvancedStatusCreateForm, status *gtsmodel.Status) {
+	status.LocationName = text.SanitizePlaintext(form.PlaceName)
+	if status.LocationName == "" {
+		return
+	}
+
+	if form.PlaceLatitude != nil && form.PlaceLongitude != nil {
+		status.LocationLatitude = util.RoundCoordinate(form.PlaceLatitude)
+		status.LocationLongitude = util.RoundCoordinate(form.PlaceLongitude)
+	}
+}
+
+// processRequiredContentWarning returns 422 Unprocessable Entity if the
+// status uses any hashtags which admins have marked as requiring a content
+// warning, but doesn't have one.
+func processRequiredContentWarning(ctx context.Context, dbService db.DB, status *gtsmodel.Status) gtserror.WithCode {
+	if status.ContentWarning != "" || len(status.Tags) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(status.Tags))
+	for _, tag := range status.Tags {
+		names = append(names, tag.Name)
+	}
+
+	required, err := dbService.GetContentWarningRequiredHashtags(ctx, names)
+	if err != nil {
+		err := fmt.Errorf("db error checking hashtag settings: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if len(required) == 0 {
+		return nil
+	}
+
+	err = fmt.Errorf("status uses hashtag(s) #%s, which require a content warning; set spoiler_text to post it", strings.Join(required, ", #"))
+	return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+}
+
+// processAlias sets the shortest alias derived from the status
+// ID that isn't already taken by another status. Such an alias
+// always exists, as the longest alias encodes the whole ID.
+func processAlias(ctx context.Context, dbService db.DB, status *gtsmodel.Status) error {
+	for length := id.AliasMinLength; length <= id.AliasMaxLength; length++ {
+		alias, err := id.Alias(status.ID, length)
+		if err != nil {
+			return fmt.Errorf("error deriving alias: %w", err)
+		}
+
+		_, err = dbService.GetStatusByAlias(ctx, alias)
+		if errors.Is(err, db.ErrNoEntries) {
+			status.Alias = alias
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("db error checking alias %s: %w", alias, err)
+		}
+	}
+
+	return fmt.Errorf("no free alias for status %s", status.ID)
+}
+
+// putStatusWithAlias gives the given new status an alias, and puts
+// it in the database. Another status may take the alias in between
+// checking that it's free and putting this status, in which case
+// the next longer alias is tried, until the longest, which is unique
+// to the status ID.
+func putStatusWithAlias(ctx context.Context, dbService db.DB, status *gtsmodel.Status) error {
+	if err := processAlias(ctx, dbService, status); err != nil {
+		return err
+	}
+
+	for {
+		err := dbService.PutStatus(ctx, status)
+		if !errors.Is(err, db.ErrAlreadyExists) || len(status.Alias) >= id.AliasMaxLength {
+			return err
+		}
+
+		log.Debugf(ctx, "alias %s of status %s was taken, trying a longer one", status.Alias, status.ID)
+		status.Alias, err = id.Alias(status.ID, len(status.Alias)+1)
+		if err != nil {
+			return fmt.Errorf("error deriving alias: %w", err)
+		}
+	}
+}
+
 func processContent(ctx context.Context, dbService db.DB, formatter text.Formatter, parseMention gtsmodel.ParseMentionFunc, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) error {
 	// if there's nothing in the status at all we can just return early
 	if form.Status == "" {
@@ -310,6 +512,7 @@ func processContent(ctx context.Context, dbService db.DB, formatter text.Formatt
 	default:
 		return fmt.Errorf("format %s not recognised as a valid status format", form.ContentType)
 	}
+	status.ContentType = string(form.ContentType)
 	formatted := f(ctx, parseMention, accountID, status.ID, form.Status)
 
 	// add full populated gts {mentions, tags, emojis} to the status for passing them around conveniently
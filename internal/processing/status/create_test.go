@@ -26,6 +26,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 )
 
 type StatusCreateTestSuite struct {
@@ -208,6 +209,219 @@ func (suite *StatusCreateTestSuite) TestProcessMediaDescriptionTooShort() {
 	suite.Nil(apiStatus)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessStatusMaxCharsPerVisibility() {
+	ctx := context.Background()
+
+	config.SetStatusesMaxCharsPublic(10)
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "this status is longer than ten characters",
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	// Too long for a public status.
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.EqualError(err, "status too long, 41 characters provided but limit for public statuses is 10")
+	suite.Nil(apiStatus)
+
+	// No direct limit set, so this should
+	// fall back to statuses-max-chars.
+	statusCreateForm.Visibility = apimodel.VisibilityDirect
+	apiStatus, err = suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessStatusMaxCharsUserOverride() {
+	ctx := context.Background()
+
+	config.SetStatusesMaxCharsPublic(10)
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	user, err := suite.db.GetUserByAccountID(ctx, creatingAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "this status is longer than ten characters",
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	// The user's own limit takes
+	// precedence over the instance limit.
+	user.StatusesMaxChars = 50
+	if err := suite.db.UpdateUser(ctx, user, "statuses_max_chars"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	// And applies to every visibility.
+	user.StatusesMaxChars = 20
+	if err := suite.db.UpdateUser(ctx, user, "statuses_max_chars"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statusCreateForm.Visibility = apimodel.VisibilityDirect
+	apiStatus, err = suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.EqualError(err, "status too long, 41 characters provided but limit for direct statuses is 20")
+	suite.Nil(apiStatus)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessHashtagContentWarningRequired() {
+	ctx := context.Background()
+
+	required := true
+	if err := suite.db.PutHashtagSetting(ctx, &gtsmodel.HashtagSetting{
+		ID:                     "01H5E6KQ8ZJ4Y3T8Q0V4M2N7XW",
+		Name:                   "spiders",
+		ContentWarningRequired: &required,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "look at this big one #Spiders",
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	// No content warning set.
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.EqualError(err, "status uses hashtag(s) #spiders, which require a content warning; set spoiler_text to post it")
+	suite.Nil(apiStatus)
+
+	// With a content warning it's fine.
+	statusCreateForm.SpoilerText = "spider pic"
+	apiStatus, err = suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessPlace() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	latitude, longitude := 51.50135, -0.14189
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:         "look at this tree",
+			Visibility:     apimodel.VisibilityPublic,
+			Language:       "en",
+			ContentType:    apimodel.StatusContentTypePlain,
+			PlaceName:      "The Old Tree, Slothville",
+			PlaceLatitude:  &latitude,
+			PlaceLongitude: &longitude,
+		},
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(errWithCode)
+	suite.NotNil(apiStatus)
+
+	// Coordinates should be rounded before storing.
+	suite.Equal("The Old Tree, Slothville", apiStatus.Location.Name)
+	suite.Equal(51.501, *apiStatus.Location.Latitude)
+	suite.Equal(-0.142, *apiStatus.Location.Longitude)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, apiStatus.ID)
+	suite.NoError(err)
+	suite.Equal("The Old Tree, Slothville", dbStatus.LocationName)
+	suite.Equal(51.501, *dbStatus.LocationLatitude)
+	suite.Equal(-0.142, *dbStatus.LocationLongitude)
+}
+
+// aliasRaceDB simulates another status taking the
+// alias of the next status put, after that alias was
+// checked to be free, but before the status is put.
+type aliasRaceDB struct {
+	db.DB
+	other *gtsmodel.Status
+}
+
+func (r *aliasRaceDB) PutStatus(ctx context.Context, status *gtsmodel.Status) db.Error {
+	if r.other.Alias == "" {
+		r.other.Alias = status.Alias
+		if err := r.DB.PutStatus(ctx, r.other); err != nil {
+			return err
+		}
+	}
+
+	return r.DB.PutStatus(ctx, status)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessAliasTakenConcurrently() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// Another status, which takes the alias in between.
+	other := new(gtsmodel.Status)
+	*other = *suite.testStatuses["local_account_1_status_1"]
+	other.ID = id.NewULID()
+	other.URI = creatingAccount.URI + "/statuses/" + other.ID
+	other.URL = creatingAccount.URL + "/statuses/" + other.ID
+
+	suite.state.DB = &aliasRaceDB{DB: suite.db, other: other}
+	defer func() { suite.state.DB = suite.db }()
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "racing to the alias",
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	})
+	suite.NoError(errWithCode)
+	suite.NotNil(apiStatus)
+
+	// The other status got the shortest alias, so
+	// this one should have the next longer alias.
+	shortest, err := id.Alias(apiStatus.ID, id.AliasMinLength)
+	suite.NoError(err)
+	longer, err := id.Alias(apiStatus.ID, id.AliasMinLength+1)
+	suite.NoError(err)
+	suite.Equal(shortest, other.Alias)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, apiStatus.ID)
+	suite.NoError(err)
+	suite.Equal(longer, dbStatus.Alias)
+
+	dbStatus, err = suite.db.GetStatusByAlias(ctx, shortest)
+	suite.NoError(err)
+	suite.Equal(other.ID, dbStatus.ID)
+
+	dbStatus, err = suite.db.GetStatusByAlias(ctx, longer)
+	suite.NoError(err)
+	suite.Equal(apiStatus.ID, dbStatus.ID)
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
@@ -24,13 +24,18 @@ import (
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
 
 // Delete processes the delete of a given status, returning the deleted status if the delete goes through.
-func (p *Processor) Delete(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
+//
+// Unless deleteMedia is true, attachments of the status are unattached rather than deleted,
+// so that they can be reused in a new status (ie., delete and redraft). Unused attachments
+// are cleaned up by the media cleaner once media-unused-grace-period has passed.
+func (p *Processor) Delete(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string, deleteMedia bool) (*apimodel.Status, gtserror.WithCode) {
 	targetStatus, err := p.state.DB.GetStatusByID(ctx, targetStatusID)
 	if err != nil {
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error fetching status %s: %s", targetStatusID, err))
@@ -50,6 +55,21 @@ func (p *Processor) Delete(ctx context.Context, requestingAccount *gtsmodel.Acco
 		return nil, errWithCode
 	}
 
+	if !deleteMedia {
+		// Unattach media now rather than in the worker, so
+		// that a redraft can immediately reuse attachment IDs.
+		for _, attachmentID := range targetStatus.AttachmentIDs {
+			if err := p.unattachMedia(ctx, attachmentID); err != nil {
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+		}
+
+		// The worker will delete any attachments still
+		// on the status, so make sure there are none.
+		targetStatus.AttachmentIDs = nil
+		targetStatus.Attachments = nil
+	}
+
 	// Process delete side effects.
 	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
 		APObjectType:   ap.ObjectNote,
@@ -61,3 +81,23 @@ func (p *Processor) Delete(ctx context.Context, requestingAccount *gtsmodel.Acco
 
 	return apiStatus, nil
 }
+
+// unattachMedia clears the status ID of the given attachment,
+// bumping its updated_at time to restart the cleanup grace period.
+func (p *Processor) unattachMedia(ctx context.Context, attachmentID string) error {
+	attachment, err := p.state.DB.GetAttachmentByID(ctx, attachmentID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Already gone.
+			return nil
+		}
+		return gtserror.Newf("db error getting attachment %s: %w", attachmentID, err)
+	}
+
+	attachment.StatusID = ""
+	if err := p.state.DB.UpdateAttachment(ctx, attachment, "status_id"); err != nil {
+		return gtserror.Newf("db error unattaching attachment %s: %w", attachmentID, err)
+	}
+
+	return nil
+}
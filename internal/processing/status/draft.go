@@ -0,0 +1,326 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// DraftCreate processes the given form to create a new draft belonging to the given account.
+func (p *Processor) DraftCreate(ctx context.Context, requestingAccount *gtsmodel.Account, form *apimodel.DraftRequest) (*apimodel.Draft, gtserror.WithCode) {
+	maxDrafts := config.GetStatusesMaxDrafts()
+	if maxDrafts <= 0 {
+		err := errors.New("drafts are not enabled on this instance")
+		return nil, gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	count, err := p.state.DB.CountAccountDrafts(ctx, requestingAccount.ID)
+	if err != nil {
+		err = gtserror.Newf("db error counting drafts: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if count >= maxDrafts {
+		err := fmt.Errorf("draft limit of %d reached; publish or delete existing drafts first", maxDrafts)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	draft := &gtsmodel.Draft{
+		ID:        id.NewULID(),
+		AccountID: requestingAccount.ID,
+	}
+
+	if errWithCode := p.processDraftForm(ctx, requestingAccount, form, draft); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.PutDraft(ctx, draft); err != nil {
+		err = gtserror.Newf("db error putting draft: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDraft(ctx, draft)
+}
+
+// DraftsGet returns all drafts belonging to the given account, newest first.
+func (p *Processor) DraftsGet(ctx context.Context, requestingAccount *gtsmodel.Account) ([]*apimodel.Draft, gtserror.WithCode) {
+	drafts, err := p.state.DB.GetAccountDrafts(ctx, requestingAccount.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting drafts: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiDrafts := make([]*apimodel.Draft, 0, len(drafts))
+	for _, draft := range drafts {
+		apiDraft, errWithCode := p.apiDraft(ctx, draft)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiDrafts = append(apiDrafts, apiDraft)
+	}
+
+	return apiDrafts, nil
+}
+
+// DraftGet returns the draft with the given ID, if it belongs to the given account.
+func (p *Processor) DraftGet(ctx context.Context, requestingAccount *gtsmodel.Account, draftID string) (*apimodel.Draft, gtserror.WithCode) {
+	draft, errWithCode := p.getOwnDraft(ctx, requestingAccount, draftID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiDraft(ctx, draft)
+}
+
+// DraftUpdate replaces the content of the draft with the given ID with the given form.
+func (p *Processor) DraftUpdate(ctx context.Context, requestingAccount *gtsmodel.Account, draftID string, form *apimodel.DraftRequest) (*apimodel.Draft, gtserror.WithCode) {
+	unlock := p.drafts.Lock(draftID)
+	defer unlock()
+
+	draft, errWithCode := p.getOwnDraft(ctx, requestingAccount, draftID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := p.processDraftForm(ctx, requestingAccount, form, draft); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.UpdateDraft(ctx, draft); err != nil {
+		err = gtserror.Newf("db error updating draft: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDraft(ctx, draft)
+}
+
+// DraftDelete deletes the draft with the given ID, returning the deleted draft.
+// Media attachments referenced by the draft are left alone; they will be
+// cleaned up as unused media if they're not attached to anything else.
+func (p *Processor) DraftDelete(ctx context.Context, requestingAccount *gtsmodel.Account, draftID string) (*apimodel.Draft, gtserror.WithCode) {
+	unlock := p.drafts.Lock(draftID)
+	defer unlock()
+
+	draft, errWithCode := p.getOwnDraft(ctx, requestingAccount, draftID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiDraft, errWithCode := p.apiDraft(ctx, draft)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteDraftByID(ctx, draft.ID); err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("draft %s not found", draft.ID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err = gtserror.Newf("db error deleting draft: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiDraft, nil
+}
+
+// DraftPublish publishes the draft with the given ID as a new status, using the normal
+// status creation path, and deletes the draft. If status creation fails, the draft is
+// left in place so that it can be fixed up and published again.
+func (p *Processor) DraftPublish(ctx context.Context, requestingAccount *gtsmodel.Account, application *gtsmodel.Application, draftID string) (*apimodel.Status, gtserror.WithCode) {
+	// Lock the draft for the whole publish, so that concurrent
+	// publish requests can't create the status twice: the others
+	// wait for this one, then find the draft gone and get a 404.
+	unlock := p.drafts.Lock(draftID)
+	defer unlock()
+
+	draft, errWithCode := p.getOwnDraft(ctx, requestingAccount, draftID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	form := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      draft.Text,
+			MediaIDs:    draft.AttachmentIDs,
+			InReplyToID: draft.InReplyToID,
+			Sensitive:   draft.Sensitive != nil && *draft.Sensitive,
+			SpoilerText: draft.ContentWarning,
+			Language:    draft.Language,
+			ContentType: apimodel.StatusContentType(draft.ContentType),
+		},
+	}
+
+	if draft.Visibility != "" {
+		form.Visibility = p.tc.VisToAPIVis(ctx, draft.Visibility)
+	}
+
+	if len(draft.PollOptions) != 0 {
+		form.Poll = &apimodel.PollRequest{
+			Options:    draft.PollOptions,
+			ExpiresIn:  draft.PollExpiresIn,
+			Multiple:   draft.PollMultiple != nil && *draft.PollMultiple,
+			HideTotals: draft.PollHideTotals != nil && *draft.PollHideTotals,
+		}
+	}
+
+	// Drafts are permitted to be incomplete,
+	// so do the full validation only now.
+	if err := validate.StatusCreateRequest(&form.StatusCreateRequest); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Only delete the draft once the status has been created,
+	// so if that fails, the draft is left as it was.
+	apiStatus, errWithCode := p.Create(ctx, requestingAccount, application, form)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteDraftByID(ctx, draft.ID); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		// The status is out, so don't fail the request;
+		// worst case the draft lingers until deleted.
+		log.Errorf(ctx, "error deleting published draft %s: %v", draft.ID, err)
+	}
+
+	return apiStatus, nil
+}
+
+// getOwnDraft gets the draft with the given ID, returning
+// a 404 if it doesn't exist or isn't owned by the account.
+func (p *Processor) getOwnDraft(ctx context.Context, requestingAccount *gtsmodel.Account, draftID string) (*gtsmodel.Draft, gtserror.WithCode) {
+	draft, err := p.state.DB.GetDraftByID(ctx, draftID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("draft %s not found", draftID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err = gtserror.Newf("db error getting draft: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if draft.AccountID != requestingAccount.ID {
+		err = fmt.Errorf("draft %s does not belong to account %s", draftID, requestingAccount.ID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return draft, nil
+}
+
+// processDraftForm checks the given form and sets its values on the given draft.
+// Unlike status creation, empty drafts are allowed, but text length and media
+// ownership are checked up front so that surprises aren't left for publishing.
+func (p *Processor) processDraftForm(ctx context.Context, requestingAccount *gtsmodel.Account, form *apimodel.DraftRequest, draft *gtsmodel.Draft) gtserror.WithCode {
+	var visibility gtsmodel.Visibility
+	if form.Visibility != "" {
+		if err := validate.Privacy(string(form.Visibility)); err != nil {
+			return gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		visibility = typeutils.APIVisToVis(form.Visibility)
+	}
+
+	textVisibility := visibility
+	if textVisibility == "" {
+		textVisibility = requestingAccount.Privacy
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, requestingAccount.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting user for account %s: %w", requestingAccount.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := validate.StatusText(form.Status, textVisibility, user); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if maxFiles := validate.UserStatusMediaMaxFiles(user); len(form.MediaIDs) > maxFiles {
+		err := fmt.Errorf("too many media files attached to draft, %d attached but limit is %d", len(form.MediaIDs), maxFiles)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	for _, mediaID := range form.MediaIDs {
+		attachment, err := p.state.DB.GetAttachmentByID(ctx, mediaID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				err = fmt.Errorf("media not found for media id %s", mediaID)
+				return gtserror.NewErrorBadRequest(err, err.Error())
+			}
+			err = gtserror.Newf("db error getting media %s: %w", mediaID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if attachment.AccountID != requestingAccount.ID {
+			err = fmt.Errorf("media with id %s does not belong to account %s", mediaID, requestingAccount.ID)
+			return gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		if attachment.StatusID != "" {
+			err = fmt.Errorf("media with id %s is already attached to a status", mediaID)
+			return gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	sensitive := form.Sensitive
+	pollMultiple := false
+	pollHideTotals := false
+
+	draft.Text = form.Status
+	draft.ContentWarning = form.SpoilerText
+	draft.Sensitive = &sensitive
+	draft.Visibility = visibility
+	draft.InReplyToID = form.InReplyToID
+	draft.AttachmentIDs = form.MediaIDs
+	draft.Language = form.Language
+	draft.ContentType = string(form.ContentType)
+	draft.PollOptions = nil
+	draft.PollExpiresIn = 0
+
+	if form.Poll != nil {
+		draft.PollOptions = form.Poll.Options
+		draft.PollExpiresIn = form.Poll.ExpiresIn
+		pollMultiple = form.Poll.Multiple
+		pollHideTotals = form.Poll.HideTotals
+	}
+
+	draft.PollMultiple = &pollMultiple
+	draft.PollHideTotals = &pollHideTotals
+
+	return nil
+}
+
+func (p *Processor) apiDraft(ctx context.Context, draft *gtsmodel.Draft) (*apimodel.Draft, gtserror.WithCode) {
+	apiDraft, err := p.tc.DraftToAPIDraft(ctx, draft)
+	if err != nil {
+		err = gtserror.Newf("error converting draft %s to api representation: %w", draft.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiDraft, nil
+}
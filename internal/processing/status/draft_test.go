@@ -0,0 +1,221 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+type StatusDraftTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusDraftTestSuite) TestDraftCreatePublish() {
+	ctx := context.Background()
+
+	account := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+	attachment := suite.testAttachments["local_account_1_unattached_1"]
+
+	apiDraft, errWithCode := suite.status.DraftCreate(ctx, account, &apimodel.DraftRequest{
+		Status:      "this is a draft, it's not finished yet",
+		MediaIDs:    []string{attachment.ID},
+		SpoilerText: "draft",
+		Visibility:  apimodel.VisibilityPrivate,
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("this is a draft, it's not finished yet", apiDraft.Status)
+	suite.Equal(apimodel.VisibilityPrivate, apiDraft.Visibility)
+	suite.Equal([]string{attachment.ID}, apiDraft.MediaIDs)
+	suite.Len(apiDraft.MediaAttachments, 1)
+
+	// Media should now be protected by the draft.
+	inDraft, err := suite.db.IsAttachmentInDraft(ctx, account.ID, attachment.ID)
+	suite.NoError(err)
+	suite.True(inDraft)
+
+	apiStatus, errWithCode := suite.status.DraftPublish(ctx, account, application, apiDraft.ID)
+	suite.NoError(errWithCode)
+	suite.Equal("draft", apiStatus.SpoilerText)
+	suite.Equal(apimodel.VisibilityPrivate, apiStatus.Visibility)
+	suite.Len(apiStatus.MediaAttachments, 1)
+
+	// Draft should be gone now it's been published.
+	_, err = suite.db.GetDraftByID(ctx, apiDraft.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *StatusDraftTestSuite) TestDraftPublishTwice() {
+	ctx := context.Background()
+
+	account := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+
+	apiDraft, errWithCode := suite.status.DraftCreate(ctx, account, &apimodel.DraftRequest{
+		Status: "don't post this twice",
+	})
+	suite.NoError(errWithCode)
+
+	statusesBefore, err := suite.db.CountAccountStatuses(ctx, account.ID)
+	suite.NoError(err)
+
+	// Publish the same draft twice at once,
+	// only one of the requests should win.
+	var (
+		wg    sync.WaitGroup
+		codes = make([]int, 2)
+	)
+	for i := range codes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errWithCode := suite.status.DraftPublish(ctx, account, application, apiDraft.ID)
+			if errWithCode != nil {
+				codes[i] = errWithCode.Code()
+			} else {
+				codes[i] = http.StatusOK
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	suite.ElementsMatch([]int{http.StatusOK, http.StatusNotFound}, codes)
+
+	statusesAfter, err := suite.db.CountAccountStatuses(ctx, account.ID)
+	suite.NoError(err)
+	suite.Equal(statusesBefore+1, statusesAfter)
+
+	// Publishing again afterwards should 404 too.
+	_, errWithCode = suite.status.DraftPublish(ctx, account, application, apiDraft.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *StatusDraftTestSuite) TestDraftPublishInvalid() {
+	ctx := context.Background()
+
+	account := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+
+	// Empty drafts are fine, but can't be published.
+	apiDraft, errWithCode := suite.status.DraftCreate(ctx, account, &apimodel.DraftRequest{})
+	suite.NoError(errWithCode)
+
+	_, errWithCode = suite.status.DraftPublish(ctx, account, application, apiDraft.ID)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// Draft should still be there.
+	_, err := suite.db.GetDraftByID(ctx, apiDraft.ID)
+	suite.NoError(err)
+}
+
+func (suite *StatusDraftTestSuite) TestDraftPublishCreateFails() {
+	ctx := context.Background()
+
+	account := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+	attachment := suite.testAttachments["local_account_1_unattached_1"]
+
+	apiDraft, errWithCode := suite.status.DraftCreate(ctx, account, &apimodel.DraftRequest{
+		Status:      "replying to a status that will be gone by the time this is published",
+		MediaIDs:    []string{attachment.ID},
+		InReplyToID: "01HAZNE9WRDJ6S36N5J2Q2FZQX",
+	})
+	suite.NoError(errWithCode)
+
+	statusesBefore, err := suite.db.CountAccountStatuses(ctx, account.ID)
+	suite.NoError(err)
+
+	// The draft passes validation, but
+	// status creation fails on the reply.
+	_, errWithCode = suite.status.DraftPublish(ctx, account, application, apiDraft.ID)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	statusesAfter, err := suite.db.CountAccountStatuses(ctx, account.ID)
+	suite.NoError(err)
+	suite.Equal(statusesBefore, statusesAfter)
+
+	// Draft should be left as it was,
+	// media still protected by it.
+	draft, err := suite.db.GetDraftByID(ctx, apiDraft.ID)
+	suite.NoError(err)
+	suite.Equal("replying to a status that will be gone by the time this is published", draft.Text)
+	suite.Equal([]string{attachment.ID}, draft.AttachmentIDs)
+
+	inDraft, err := suite.db.IsAttachmentInDraft(ctx, account.ID, attachment.ID)
+	suite.NoError(err)
+	suite.True(inDraft)
+
+	// Once fixed up, it can be published.
+	_, errWithCode = suite.status.DraftUpdate(ctx, account, apiDraft.ID, &apimodel.DraftRequest{
+		Status:   "not replying to anything after all",
+		MediaIDs: []string{attachment.ID},
+	})
+	suite.NoError(errWithCode)
+
+	apiStatus, errWithCode := suite.status.DraftPublish(ctx, account, application, apiDraft.ID)
+	suite.NoError(errWithCode)
+	suite.Contains(apiStatus.Content, "not replying to anything after all")
+	suite.Empty(apiStatus.InReplyToID)
+	suite.Len(apiStatus.MediaAttachments, 1)
+
+	_, err = suite.db.GetDraftByID(ctx, apiDraft.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *StatusDraftTestSuite) TestDraftNotOwned() {
+	ctx := context.Background()
+
+	apiDraft, errWithCode := suite.status.DraftCreate(ctx, suite.testAccounts["local_account_1"], &apimodel.DraftRequest{
+		Status: "secret draft",
+	})
+	suite.NoError(errWithCode)
+
+	_, errWithCode = suite.status.DraftGet(ctx, suite.testAccounts["local_account_2"], apiDraft.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *StatusDraftTestSuite) TestDraftLimit() {
+	ctx := context.Background()
+
+	config.SetStatusesMaxDrafts(2)
+	account := suite.testAccounts["local_account_1"]
+
+	for i := 0; i < 2; i++ {
+		_, errWithCode := suite.status.DraftCreate(ctx, account, &apimodel.DraftRequest{Status: "draft"})
+		suite.NoError(errWithCode)
+	}
+
+	_, errWithCode := suite.status.DraftCreate(ctx, account, &apimodel.DraftRequest{Status: "one too many"})
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	drafts, errWithCode := suite.status.DraftsGet(ctx, account)
+	suite.NoError(errWithCode)
+	suite.Len(drafts, 2)
+}
+
+func TestStatusDraftTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusDraftTestSuite))
+}
@@ -105,6 +105,73 @@ func (p *Processor) FaveRemove(ctx context.Context, requestingAccount *gtsmodel.
 	return p.apiStatus(ctx, targetStatus, requestingAccount)
 }
 
+// Dislike removes any fave for the requesting account targeting the given
+// status, and replaces it with a dislike (no-op if dislike already exists).
+// A removed fave is undone just as with FaveRemove, before the dislike is
+// created, so its notifications are removed and an Undo Like is federated.
+func (p *Processor) Dislike(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
+	targetStatus, existingFave, errWithCode := p.getFaveTarget(ctx, requestingAccount, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if existingFave != nil {
+		if err := p.state.DB.DeleteStatusFaveByID(ctx, existingFave.ID); err != nil {
+			err = fmt.Errorf("Dislike: error removing status fave: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// Process remove status fave side effects.
+		p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+			APObjectType:   ap.ActivityLike,
+			APActivityType: ap.ActivityUndo,
+			GTSModel:       existingFave,
+			OriginAccount:  requestingAccount,
+			TargetAccount:  targetStatus.Account,
+		})
+	}
+
+	existingDislike, err := p.state.DB.GetStatusDislike(ctx, requestingAccount.ID, targetStatus.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = fmt.Errorf("Dislike: error checking existing dislike: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existingDislike != nil {
+		// Status is already disliked.
+		return p.apiStatus(ctx, targetStatus, requestingAccount)
+	}
+
+	// Create and store a new dislike
+	dislikeID := id.NewULID()
+	gtsDislike := &gtsmodel.StatusDislike{
+		ID:              dislikeID,
+		AccountID:       requestingAccount.ID,
+		Account:         requestingAccount,
+		TargetAccountID: targetStatus.AccountID,
+		TargetAccount:   targetStatus.Account,
+		StatusID:        targetStatus.ID,
+		Status:          targetStatus,
+		URI:             uris.GenerateURIForDislike(requestingAccount.Username, dislikeID),
+	}
+
+	if err := p.state.DB.PutStatusDislike(ctx, gtsDislike); err != nil {
+		err = fmt.Errorf("Dislike: error putting dislike in database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process new status dislike side effects.
+	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ActivityDislike,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       gtsDislike,
+		OriginAccount:  requestingAccount,
+		TargetAccount:  targetStatus.Account,
+	})
+
+	return p.apiStatus(ctx, targetStatus, requestingAccount)
+}
+
 // FavedBy returns a slice of accounts that have liked the given status, filtered according to privacy settings.
 func (p *Processor) FavedBy(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) ([]*apimodel.Account, gtserror.WithCode) {
 	targetStatus, errWithCode := p.getVisibleStatus(ctx, requestingAccount, targetStatusID)
@@ -122,6 +189,11 @@ func (p *Processor) FavedBy(ctx context.Context, requestingAccount *gtsmodel.Acc
 	// and which don't block them.
 	apiAccounts := make([]*apimodel.Account, 0, len(statusFaves))
 	for _, fave := range statusFaves {
+		if fave.IsPendingApproval() {
+			// Fave hasn't been approved yet.
+			continue
+		}
+
 		if blocked, err := p.state.DB.IsEitherBlocked(ctx, requestingAccount.ID, fave.AccountID); err != nil {
 			err = fmt.Errorf("FavedBy: error checking blocks: %w", err)
 			return nil, gtserror.NewErrorInternalError(err)
@@ -19,15 +19,27 @@ package status
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sort"
+	"strings"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 )
 
 // Get gets the given status, taking account of privacy settings and blocks etc.
+// The status may be given by its ID, or by the short alias of a local status.
 func (p *Processor) Get(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
+	targetStatusID, errWithCode := p.resolveStatusID(ctx, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	targetStatus, errWithCode := p.getVisibleStatus(ctx, requestingAccount, targetStatusID)
 	if errWithCode != nil {
 		return nil, errWithCode
@@ -36,6 +48,96 @@ func (p *Processor) Get(ctx context.Context, requestingAccount *gtsmodel.Account
 	return p.apiStatus(ctx, targetStatus, requestingAccount)
 }
 
+// WebGet is like Get, but for showing the status on the web, where a
+// status is shown as pinned whenever its author has pinned it, rather
+// than only to an author looking at their own status.
+func (p *Processor) WebGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
+	apiStatus, errWithCode := p.Get(ctx, requestingAccount, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.setAuthorPinned(ctx, apiStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiStatus, nil
+}
+
+// setAuthorPinned sets pinned on each of the given API
+// statuses according to whether their author pinned them.
+// Remote statuses are pinned by incoming featured collection
+// Adds, so this covers remote authors in a thread too.
+func (p *Processor) setAuthorPinned(ctx context.Context, apiStatuses ...*apimodel.Status) error {
+	for _, apiStatus := range apiStatuses {
+		status, err := p.state.DB.GetStatusByID(gtscontext.SetBarebones(ctx), apiStatus.ID)
+		if err != nil {
+			return gtserror.Newf("db error getting status %s: %w", apiStatus.ID, err)
+		}
+		apiStatus.Pinned = !status.PinnedAt.IsZero()
+	}
+	return nil
+}
+
+// resolveStatusID returns the ID of the status with the given ID or alias.
+// Anything not alias-length is taken to be an ID, so existing urls keep working.
+func (p *Processor) resolveStatusID(ctx context.Context, idOrAlias string) (string, gtserror.WithCode) {
+	if l := len(idOrAlias); l < id.AliasMinLength || l > id.AliasMaxLength {
+		return strings.ToUpper(idOrAlias), nil
+	}
+
+	status, err := p.state.DB.GetStatusByAlias(ctx, strings.ToLower(idOrAlias))
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("resolveStatusID: no status with alias %s", idOrAlias)
+			return "", gtserror.NewErrorNotFound(err)
+		}
+		err = fmt.Errorf("resolveStatusID: db error fetching status with alias %s: %w", idOrAlias, err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	return status.ID, nil
+}
+
+// GetThreadRoot returns the root of the thread that the given status is in,
+// by walking up its chain of replies. If some ancestor is not visible to the
+// requesting account (or isn't known to us), then the walk stops there, and
+// the highest visible status before it is returned instead. The returned
+// status may be the given status itself, if it isn't a reply.
+func (p *Processor) GetThreadRoot(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
+	root, errWithCode := p.getVisibleStatus(ctx, requestingAccount, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	for root.InReplyToID != "" {
+		parent, err := p.state.DB.GetStatusByID(ctx, root.InReplyToID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				err = gtserror.Newf("db error getting parent %s of status %s: %w", root.InReplyToID, root.ID, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+			// Parent not dereferenced.
+			break
+		}
+
+		visible, err := p.filter.StatusVisible(ctx, requestingAccount, parent)
+		if err != nil {
+			err = gtserror.Newf("error checking visibility of status %s: %w", parent.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if !visible {
+			break
+		}
+
+		root = parent
+	}
+
+	return p.apiStatus(ctx, root, requestingAccount)
+}
+
 // ContextGet returns the context (previous and following posts) from the given status ID.
 func (p *Processor) ContextGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Context, gtserror.WithCode) {
 	targetStatus, errWithCode := p.getVisibleStatus(ctx, requestingAccount, targetStatusID)
@@ -62,6 +164,14 @@ func (p *Processor) ContextGet(ctx context.Context, requestingAccount *gtsmodel.
 		}
 	}
 
+	// Check whether the top of the thread was detached
+	// from its own parent for exceeding max thread depth.
+	root := targetStatus
+	if len(parents) != 0 {
+		root = parents[len(parents)-1]
+	}
+	context.Truncated = root.ThreadTruncated != nil && *root.ThreadTruncated
+
 	sort.Slice(context.Ancestors, func(i int, j int) bool {
 		return context.Ancestors[i].ID < context.Ancestors[j].ID
 	})
@@ -82,3 +192,63 @@ func (p *Processor) ContextGet(ctx context.Context, requestingAccount *gtsmodel.
 
 	return context, nil
 }
+
+// WebContextGet is like ContextGet, but for showing the thread on the web. As
+// with WebGet, statuses are shown as pinned whenever their author pinned them.
+// If the thread owner (author of its root) keeps statuses from accounts they
+// don't accept mentions from off their surfaces, then those are left out.
+func (p *Processor) WebContextGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Context, gtserror.WithCode) {
+	context, errWithCode := p.ContextGet(ctx, requestingAccount, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	for _, statuses := range [][]apimodel.Status{context.Ancestors, context.Descendants} {
+		for i := range statuses {
+			if err := p.setAuthorPinned(ctx, &statuses[i]); err != nil {
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+		}
+	}
+
+	root, errWithCode := p.GetThreadRoot(ctx, requestingAccount, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	owner, err := p.state.DB.GetAccountByID(ctx, root.Account.ID)
+	if err != nil {
+		err = gtserror.Newf("db error getting thread owner %s: %w", root.Account.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !owner.IsLocal() || !owner.MentionPolicy.HidesStatuses() {
+		// Show the whole thread.
+		return context, nil
+	}
+
+	mentionable := func(statuses []apimodel.Status) ([]apimodel.Status, error) {
+		kept := statuses[:0]
+		for _, status := range statuses {
+			ok, err := p.filter.AccountMentionable(ctx, owner, status.Account.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				kept = append(kept, status)
+			}
+		}
+		return kept, nil
+	}
+
+	if context.Ancestors, err = mentionable(context.Ancestors); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if context.Descendants, err = mentionable(context.Descendants); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return context, nil
+}
@@ -0,0 +1,150 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type StatusGetTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusGetTestSuite) TestGetByAlias() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	created, errWithCode := suite.status.Create(ctx, account, suite.testApplications["application_1"], &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:     "short links please",
+			Visibility: apimodel.VisibilityPublic,
+			Language:   "en",
+		},
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, created.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	expectedAlias, err := id.Alias(created.ID, id.AliasMinLength)
+	suite.NoError(err)
+	suite.Equal(expectedAlias, dbStatus.Alias)
+
+	// The status can be got by its alias, in any case...
+	for _, alias := range []string{dbStatus.Alias, strings.ToUpper(dbStatus.Alias)} {
+		apiStatus, errWithCode := suite.status.Get(ctx, account, alias)
+		suite.NoError(errWithCode)
+		suite.Equal(created.ID, apiStatus.ID)
+	}
+
+	// ...and still by its ID.
+	apiStatus, errWithCode := suite.status.Get(ctx, account, strings.ToLower(created.ID))
+	suite.NoError(errWithCode)
+	suite.Equal(created.ID, apiStatus.ID)
+}
+
+func (suite *StatusGetTestSuite) TestGetByAliasUnauthed() {
+	ctx := context.Background()
+
+	existing := suite.testStatuses["local_account_1_status_1"]
+	existing.Alias = "0000000000a"
+	if err := suite.db.UpdateStatus(ctx, existing, "alias"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Unknown aliases aren't found.
+	_, errWithCode := suite.status.Get(ctx, nil, "0000000000b")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	apiStatus, errWithCode := suite.status.Get(ctx, nil, "0000000000A")
+	suite.NoError(errWithCode)
+	suite.Equal(existing.ID, apiStatus.ID)
+}
+
+func (suite *StatusGetTestSuite) TestWebGetPinned() {
+	ctx := context.Background()
+
+	pinned := suite.testStatuses["local_account_1_status_1"]
+	pinned.PinnedAt = time.Now()
+	if err := suite.db.UpdateStatus(ctx, pinned, "pinned_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Through the API, only the author sees their status as pinned.
+	apiStatus, errWithCode := suite.status.Get(ctx, nil, pinned.ID)
+	suite.NoError(errWithCode)
+	suite.False(apiStatus.Pinned)
+
+	// But on the web, everyone does.
+	apiStatus, errWithCode = suite.status.WebGet(ctx, nil, pinned.ID)
+	suite.NoError(errWithCode)
+	suite.True(apiStatus.Pinned)
+}
+
+func (suite *StatusGetTestSuite) TestWebContextGetRemotePinned() {
+	ctx := context.Background()
+	parent := suite.testStatuses["local_account_1_status_1"]
+
+	// A remote reply, pinned by its
+	// author through a featured Add.
+	reply := suite.testStatuses["remote_account_1_status_1"]
+	reply.InReplyToID = parent.ID
+	reply.InReplyToAccountID = parent.AccountID
+	reply.InReplyToURI = parent.URI
+	reply.PinnedAt = time.Now()
+	if err := suite.db.UpdateStatus(ctx, reply, "in_reply_to_id", "in_reply_to_account_id", "in_reply_to_uri", "pinned_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	pinnedIn := func(statuses []apimodel.Status) (bool, bool) {
+		for _, status := range statuses {
+			if status.ID == reply.ID {
+				return true, status.Pinned
+			}
+		}
+		return false, false
+	}
+
+	apiContext, errWithCode := suite.status.ContextGet(ctx, nil, parent.ID)
+	suite.NoError(errWithCode)
+	found, pinnedBadge := pinnedIn(apiContext.Descendants)
+	suite.True(found)
+	suite.False(pinnedBadge)
+
+	apiContext, errWithCode = suite.status.WebContextGet(ctx, nil, parent.ID)
+	suite.NoError(errWithCode)
+	found, pinnedBadge = pinnedIn(apiContext.Descendants)
+	suite.True(found)
+	suite.True(pinnedBadge)
+}
+
+func TestStatusGetTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusGetTestSuite))
+}
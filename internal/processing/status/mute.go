@@ -0,0 +1,126 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// MuteCreate mutes the thread containing the given status for the requestingAccount,
+// so that no more notifications are created for it, including for replies that arrive
+// later (no-op if the thread is already muted).
+func (p *Processor) MuteCreate(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
+	targetStatus, threadID, muted, errWithCode := p.getMuteTarget(ctx, requestingAccount, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if muted {
+		// Thread is already muted.
+		return p.apiStatus(ctx, targetStatus, requestingAccount)
+	}
+
+	// Create and store a new mute.
+	gtsMute := &gtsmodel.StatusMute{
+		ID:              id.NewULID(),
+		AccountID:       requestingAccount.ID,
+		Account:         requestingAccount,
+		TargetAccountID: targetStatus.AccountID,
+		TargetAccount:   targetStatus.Account,
+		StatusID:        targetStatus.ID,
+		Status:          targetStatus,
+		ThreadID:        threadID,
+	}
+
+	if err := p.state.DB.PutStatusMute(ctx, gtsMute); err != nil {
+		err = gtserror.Newf("error putting status mute in database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.invalidateThread(ctx, requestingAccount.ID, threadID); err != nil {
+		err = gtserror.Newf("error invalidating thread from timelines: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiStatus(ctx, targetStatus, requestingAccount)
+}
+
+// MuteRemove unmutes the thread containing the given status for the requestingAccount,
+// regardless of which status of the thread was muted (no-op if the thread isn't muted).
+func (p *Processor) MuteRemove(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
+	targetStatus, threadID, muted, errWithCode := p.getMuteTarget(ctx, requestingAccount, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if !muted {
+		// Thread isn't muted.
+		return p.apiStatus(ctx, targetStatus, requestingAccount)
+	}
+
+	// We have a thread mute to remove.
+	if err := p.state.DB.DeleteThreadMutes(ctx, threadID, requestingAccount.ID); err != nil {
+		err = gtserror.Newf("error removing status mutes: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.invalidateThread(ctx, requestingAccount.ID, threadID); err != nil {
+		err = gtserror.Newf("error invalidating thread from timelines: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiStatus(ctx, targetStatus, requestingAccount)
+}
+
+func (p *Processor) getMuteTarget(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*gtsmodel.Status, string, bool, gtserror.WithCode) {
+	targetStatus, errWithCode := p.getVisibleStatus(ctx, requestingAccount, targetStatusID)
+	if errWithCode != nil {
+		return nil, "", false, errWithCode
+	}
+
+	muted, err := p.state.DB.IsStatusMutedBy(ctx, targetStatus, requestingAccount.ID)
+	if err != nil {
+		err = gtserror.Newf("error checking existing status mute: %w", err)
+		return nil, "", false, gtserror.NewErrorInternalError(err)
+	}
+
+	return targetStatus, targetStatus.ThreadID, muted, nil
+}
+
+// invalidateThread invalidates every known status of the given
+// thread from the timelines of the given account, since the
+// muted state of all of them changes together.
+func (p *Processor) invalidateThread(ctx context.Context, accountID string, threadID string) error {
+	statusIDs, err := p.state.DB.GetStatusIDsInThread(ctx, threadID)
+	if err != nil {
+		return gtserror.Newf("db error getting statuses in thread %s: %w", threadID, err)
+	}
+
+	for _, statusID := range statusIDs {
+		if err := p.invalidateStatus(ctx, accountID, statusID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusMuteTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusMuteTestSuite) TestMuteThread() {
+	ctx := context.Background()
+
+	mutingAccount := suite.testAccounts["local_account_1"]
+	rootStatus := suite.testStatuses["local_account_1_status_1"]
+	replyStatus := suite.testStatuses["admin_account_status_3"]
+
+	// Mute the thread via the reply.
+	apiStatus, errWithCode := suite.status.MuteCreate(ctx, mutingAccount, replyStatus.ID)
+	suite.NoError(errWithCode)
+	suite.True(apiStatus.Muted)
+	suite.Equal(replyStatus.ID, apiStatus.ID)
+
+	// The mute is keyed on the thread, so the root is muted too.
+	apiStatus, errWithCode = suite.status.Get(ctx, mutingAccount, rootStatus.ID)
+	suite.NoError(errWithCode)
+	suite.True(apiStatus.Muted)
+
+	// Muting again is a no-op.
+	apiStatus, errWithCode = suite.status.MuteCreate(ctx, mutingAccount, rootStatus.ID)
+	suite.NoError(errWithCode)
+	suite.True(apiStatus.Muted)
+
+	// Other accounts are unaffected.
+	apiStatus, errWithCode = suite.status.Get(ctx, suite.testAccounts["local_account_2"], replyStatus.ID)
+	suite.NoError(errWithCode)
+	suite.False(apiStatus.Muted)
+}
+
+func (suite *StatusMuteTestSuite) TestUnmuteThreadViaOtherStatus() {
+	ctx := context.Background()
+
+	mutingAccount := suite.testAccounts["local_account_1"]
+	rootStatus := suite.testStatuses["local_account_1_status_1"]
+	replyStatus := suite.testStatuses["admin_account_status_3"]
+
+	apiStatus, errWithCode := suite.status.MuteCreate(ctx, mutingAccount, replyStatus.ID)
+	suite.NoError(errWithCode)
+	suite.True(apiStatus.Muted)
+
+	// Unmuting any status of the thread
+	// clears the thread-level mute.
+	apiStatus, errWithCode = suite.status.MuteRemove(ctx, mutingAccount, rootStatus.ID)
+	suite.NoError(errWithCode)
+	suite.False(apiStatus.Muted)
+
+	apiStatus, errWithCode = suite.status.Get(ctx, mutingAccount, replyStatus.ID)
+	suite.NoError(errWithCode)
+	suite.False(apiStatus.Muted)
+}
+
+func TestStatusMuteTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusMuteTestSuite))
+}
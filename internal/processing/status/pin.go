@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/api/errorcodes"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -80,7 +81,7 @@ func (p *Processor) PinCreate(ctx context.Context, requestingAccount *gtsmodel.A
 
 	if !targetStatus.PinnedAt.IsZero() {
 		err := errors.New("status already pinned")
-		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		return nil, gtserror.NewErrorUnprocessableEntity(errorcodes.Set(err, errorcodes.StatusAlreadyPinned), err.Error())
 	}
 
 	pinnedCount, err := p.state.DB.CountAccountPinned(ctx, requestingAccount.ID)
@@ -90,7 +91,7 @@ func (p *Processor) PinCreate(ctx context.Context, requestingAccount *gtsmodel.A
 
 	if pinnedCount >= allowedPinnedCount {
 		err = fmt.Errorf("status pin limit exceeded, you've already pinned %d status(es) out of %d", pinnedCount, allowedPinnedCount)
-		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		return nil, gtserror.NewErrorUnprocessableEntity(errorcodes.Set(err, errorcodes.PinLimitReached), err.Error())
 	}
 
 	targetStatus.PinnedAt = time.Now()
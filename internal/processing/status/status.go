@@ -18,6 +18,10 @@
 package status
 
 import (
+	"time"
+
+	"codeberg.org/gruf/go-cache/v3/ttl"
+	"codeberg.org/gruf/go-mutexes"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
@@ -33,10 +37,24 @@ type Processor struct {
 	filter       *visibility.Filter
 	formatter    text.Formatter
 	parseMention gtsmodel.ParseMentionFunc
+
+	// idempotency maps account ID + Idempotency-Key
+	// of a status create request to the ID of the
+	// status created, or "" while still in progress.
+	idempotency *ttl.Cache[string, string]
+
+	// drafts locks drafts by ID while
+	// they're updated or published.
+	drafts *mutexes.MutexMap
 }
 
 // New returns a new status processor.
 func New(state *state.State, federator federation.Federator, tc typeutils.TypeConverter, filter *visibility.Filter, parseMention gtsmodel.ParseMentionFunc) Processor {
+	idempotency := ttl.New[string, string](0, 10000, time.Hour)
+	idempotency.Start(time.Minute)
+
+	drafts := mutexes.NewMap(-1, -1) // use defaults
+
 	return Processor{
 		state:        state,
 		federator:    federator,
@@ -44,5 +62,7 @@ func New(state *state.State, federator federation.Federator, tc typeutils.TypeCo
 		filter:       filter,
 		formatter:    text.NewFormatter(state.DB),
 		parseMention: parseMention,
+		idempotency:  idempotency,
+		drafts:       &drafts,
 	}
 }
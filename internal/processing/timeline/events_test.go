@@ -0,0 +1,207 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type EventsTestSuite struct {
+	TimelineStandardTestSuite
+
+	// events put in the db for each test
+	testEvents map[string]*gtsmodel.Status
+}
+
+func (suite *EventsTestSuite) SetupTest() {
+	suite.TimelineStandardTestSuite.SetupTest()
+
+	suite.testEvents = map[string]*gtsmodel.Status{
+		"local_account_1_event":  suite.newEvent(suite.testAccounts["local_account_1"], "Sloth appreciation meetup", "2023-07-01T10:00:00Z", gtsmodel.VisibilityPublic),
+		"remote_account_1_event": suite.newEvent(suite.testAccounts["remote_account_1"], "Foss bros anonymous", "2023-07-02T10:00:00Z", gtsmodel.VisibilityPublic),
+		"admin_account_event":    suite.newEvent(suite.testAccounts["admin_account"], "Instance birthday", "2023-07-03T10:00:00Z", gtsmodel.VisibilityPublic),
+		"local_account_2_event":  suite.newEvent(suite.testAccounts["local_account_2"], "Turtle race", "2023-07-04T10:00:00Z", gtsmodel.VisibilityUnlocked),
+	}
+
+	for _, event := range suite.testEvents {
+		if err := suite.db.PutStatus(context.Background(), event); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+}
+
+// newEvent returns a new event status by the
+// given account, created at the given time.
+func (suite *EventsTestSuite) newEvent(account *gtsmodel.Account, name string, created string, visibility gtsmodel.Visibility) *gtsmodel.Status {
+	createdAt := testrig.TimeMustParse(created)
+
+	statusID, err := id.NewULIDFromTime(createdAt)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	local := account.IsLocal()
+
+	return &gtsmodel.Status{
+		ID:                  statusID,
+		CreatedAt:           createdAt,
+		UpdatedAt:           createdAt,
+		URI:                 account.URI + "/events/" + statusID,
+		URL:                 account.URL + "/events/" + statusID,
+		Content:             "<p>See you there!</p>",
+		Local:               &local,
+		AccountID:           account.ID,
+		AccountURI:          account.URI,
+		Visibility:          visibility,
+		ActivityStreamsType: ap.ObjectEvent,
+		EventName:           name,
+		EventStartAt:        createdAt.Add(7 * 24 * time.Hour),
+		EventLocation:       "The Old Tree, Slothville",
+		Federated:           testrig.TrueBool(),
+		Boostable:           testrig.TrueBool(),
+		Replyable:           testrig.TrueBool(),
+		Likeable:            testrig.TrueBool(),
+	}
+}
+
+func (suite *EventsTestSuite) getEvents(requester string, maxID string, minID string, limit int, local bool) *apimodel.PageableResponse {
+	authed := &oauth.Auth{
+		Account: suite.testAccounts[requester],
+	}
+
+	resp, errWithCode := suite.timeline.EventsTimelineGet(context.Background(), authed, maxID, "", minID, limit, local)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	return resp
+}
+
+// eventIDs returns the ids of the events keyed by the given keys.
+func (suite *EventsTestSuite) eventIDs(keys ...string) []string {
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, suite.testEvents[key].ID)
+	}
+	return ids
+}
+
+func itemIDs(items []interface{}) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, statusID(item))
+	}
+	return ids
+}
+
+func (suite *EventsTestSuite) TestEventsTimelineGet() {
+	resp := suite.getEvents("local_account_1", "", "", 20, false)
+
+	// Public events only, newest first; no
+	// statuses that aren't events, and not
+	// the unlisted event of local_account_2.
+	suite.Equal(suite.eventIDs(
+		"admin_account_event",
+		"remote_account_1_event",
+		"local_account_1_event",
+	), itemIDs(resp.Items))
+
+	// Event details are set, and the name is
+	// included in content for clients that
+	// don't know about events.
+	event := resp.Items[0].(*apimodel.Status)
+	if !suite.NotNil(event.Event) {
+		suite.FailNow("")
+	}
+	suite.Equal("Instance birthday", event.Event.Name)
+	suite.Equal("2023-07-10T10:00:00.000Z", event.Event.StartTime)
+	suite.Empty(event.Event.EndTime)
+	suite.Equal("The Old Tree, Slothville", event.Event.Location)
+	suite.Equal("<p><strong>Instance birthday</strong></p><p>See you there!</p>", event.Content)
+}
+
+func (suite *EventsTestSuite) TestEventsTimelineGetBlocked() {
+	// local_account_2 blocks remote_account_1,
+	// so shouldn't see their event.
+	resp := suite.getEvents("local_account_2", "", "", 20, false)
+	suite.Equal(suite.eventIDs(
+		"admin_account_event",
+		"local_account_1_event",
+	), itemIDs(resp.Items))
+}
+
+func (suite *EventsTestSuite) TestEventsTimelineGetLocal() {
+	resp := suite.getEvents("local_account_1", "", "", 20, true)
+	suite.Equal(suite.eventIDs(
+		"admin_account_event",
+		"local_account_1_event",
+	), itemIDs(resp.Items))
+
+	newestID := suite.testEvents["admin_account_event"].ID
+	oldestID := suite.testEvents["local_account_1_event"].ID
+	suite.Equal("http://localhost:8080/api/v1/timelines/events?limit=20&max_id="+oldestID+"&local=true", resp.NextLink)
+	suite.Equal("http://localhost:8080/api/v1/timelines/events?limit=20&min_id="+newestID+"&local=true", resp.PrevLink)
+}
+
+func (suite *EventsTestSuite) TestEventsTimelineGetPaging() {
+	// First page.
+	resp := suite.getEvents("local_account_1", "", "", 2, false)
+	suite.Equal(suite.eventIDs(
+		"admin_account_event",
+		"remote_account_1_event",
+	), itemIDs(resp.Items))
+
+	var (
+		newestID = suite.testEvents["admin_account_event"].ID
+		oldestID = suite.testEvents["remote_account_1_event"].ID
+	)
+	suite.Equal("http://localhost:8080/api/v1/timelines/events?limit=2&max_id="+oldestID, resp.NextLink)
+	suite.Equal("http://localhost:8080/api/v1/timelines/events?limit=2&min_id="+newestID, resp.PrevLink)
+	suite.Equal(`<`+resp.NextLink+`>; rel="next", <`+resp.PrevLink+`>; rel="prev"`, resp.LinkHeader)
+
+	// Next page picks up where the first ended.
+	resp = suite.getEvents("local_account_1", oldestID, "", 2, false)
+	suite.Equal(suite.eventIDs("local_account_1_event"), itemIDs(resp.Items))
+
+	// Going back from the last page
+	// returns events newer than it.
+	lastID := suite.testEvents["local_account_1_event"].ID
+	resp = suite.getEvents("local_account_1", "", lastID, 2, false)
+	for _, id := range itemIDs(resp.Items) {
+		suite.Greater(id, lastID)
+	}
+
+	// Nothing's older than the last event.
+	resp = suite.getEvents("local_account_1", lastID, "", 2, false)
+	suite.Empty(resp.Items)
+	suite.Empty(resp.LinkHeader)
+}
+
+func TestEventsTestSuite(t *testing.T) {
+	suite.Run(t, new(EventsTestSuite))
+}
@@ -121,6 +121,11 @@ func (p *Processor) HomeTimelineGet(ctx context.Context, authed *oauth.Auth, max
 	)
 
 	for i, item := range statuses {
+		// Statuses are sorted newest to oldest, so
+		// "next" (older) pages go down from the last
+		// item, and "prev" (newer) pages go up from
+		// the first, using min_id so that they start
+		// right after it, rather than at the very top.
 		if i == count-1 {
 			nextMaxIDValue = item.GetID()
 		}
@@ -132,11 +137,19 @@ func (p *Processor) HomeTimelineGet(ctx context.Context, authed *oauth.Auth, max
 		items[i] = item
 	}
 
+	var extraQueryParams []string
+	if local {
+		extraQueryParams = append(extraQueryParams, "local=true")
+	}
+
 	return util.PackagePageableResponse(util.PageableResponseParams{
-		Items:          items,
-		Path:           "api/v1/timelines/home",
-		NextMaxIDValue: nextMaxIDValue,
-		PrevMinIDValue: prevMinIDValue,
-		Limit:          limit,
+		Items:            items,
+		Path:             "api/v1/timelines/home",
+		NextMaxIDKey:     "max_id",
+		NextMaxIDValue:   nextMaxIDValue,
+		PrevMinIDKey:     "min_id",
+		PrevMinIDValue:   prevMinIDValue,
+		Limit:            limit,
+		ExtraQueryParams: extraQueryParams,
 	})
 }
@@ -0,0 +1,106 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type HomeTestSuite struct {
+	TimelineStandardTestSuite
+}
+
+func (suite *HomeTestSuite) getHome(maxID string, minID string, limit int, local bool) *apimodel.PageableResponse {
+	authed := &oauth.Auth{
+		Account: suite.testAccounts["local_account_1"],
+	}
+
+	resp, errWithCode := suite.timeline.HomeTimelineGet(context.Background(), authed, maxID, "", minID, limit, local)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if len(resp.Items) == 0 {
+		suite.FailNow("no statuses returned")
+	}
+
+	return resp
+}
+
+func statusID(item interface{}) string {
+	return item.(*apimodel.Status).ID
+}
+
+func (suite *HomeTestSuite) TestHomeTimelineGetLinkHeader() {
+	resp := suite.getHome("", "", 2, false)
+	suite.Len(resp.Items, 2)
+
+	var (
+		newestID = statusID(resp.Items[0])
+		oldestID = statusID(resp.Items[1])
+	)
+	suite.Greater(newestID, oldestID)
+
+	// Next page goes back from the oldest
+	// status, prev page forward from the newest.
+	suite.Equal("http://localhost:8080/api/v1/timelines/home?limit=2&max_id="+oldestID, resp.NextLink)
+	suite.Equal("http://localhost:8080/api/v1/timelines/home?limit=2&min_id="+newestID, resp.PrevLink)
+	suite.Equal(`<`+resp.NextLink+`>; rel="next", <`+resp.PrevLink+`>; rel="prev"`, resp.LinkHeader)
+}
+
+func (suite *HomeTestSuite) TestHomeTimelineGetPrevPage() {
+	firstPage := suite.getHome("", "", 2, false)
+	firstPageOldestID := statusID(firstPage.Items[len(firstPage.Items)-1])
+
+	// Page back, then forward again
+	// using the prev link's min_id.
+	secondPage := suite.getHome(firstPageOldestID, "", 2, false)
+	secondPageNewestID := statusID(secondPage.Items[0])
+	suite.Less(secondPageNewestID, firstPageOldestID)
+	suite.Equal("http://localhost:8080/api/v1/timelines/home?limit=2&min_id="+secondPageNewestID, secondPage.PrevLink)
+
+	prevPage := suite.getHome("", secondPageNewestID, 2, false)
+	for _, item := range prevPage.Items {
+		suite.Greater(statusID(item), secondPageNewestID)
+	}
+
+	// Paging forward should pick up
+	// right where the first page ended.
+	suite.Equal(firstPageOldestID, statusID(prevPage.Items[len(prevPage.Items)-1]))
+}
+
+func (suite *HomeTestSuite) TestHomeTimelineGetLinkHeaderLocal() {
+	resp := suite.getHome("", "", 2, true)
+
+	var (
+		newestID = statusID(resp.Items[0])
+		oldestID = statusID(resp.Items[len(resp.Items)-1])
+	)
+
+	suite.Equal("http://localhost:8080/api/v1/timelines/home?limit=2&max_id="+oldestID+"&local=true", resp.NextLink)
+	suite.Equal("http://localhost:8080/api/v1/timelines/home?limit=2&min_id="+newestID+"&local=true", resp.PrevLink)
+}
+
+func TestHomeTestSuite(t *testing.T) {
+	suite.Run(t, new(HomeTestSuite))
+}
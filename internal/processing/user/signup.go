@@ -0,0 +1,183 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"codeberg.org/gruf/go-runners"
+	"codeberg.org/gruf/go-sched"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// maxBatchedSignups is the maximum number of new
+// signups listed individually in one email to admins.
+const maxBatchedSignups = 50
+
+// signupBatch collects new signups awaiting approval, so that
+// admins can be emailed about several of them at once.
+type signupBatch struct {
+	mu        sync.Mutex
+	signups   []email.NewSignupEntry
+	more      int       // signups not listed, over maxBatchedSignups
+	lastSent  time.Time // last time an email was sent
+	scheduled bool      // whether a send is already scheduled
+}
+
+// EmailSignupReceived lets the given new user know that their
+// signup has been received, and is awaiting review by a moderator.
+func (p *Processor) EmailSignupReceived(ctx context.Context, user *gtsmodel.User, username string) error {
+	if config.GetSMTPHost() == "" {
+		// Email sending not configured.
+		return nil
+	}
+
+	toAddress := user.UnconfirmedEmail
+	if toAddress == "" {
+		toAddress = user.Email
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	signupReceivedData := email.SignupReceivedData{
+		Username:     username,
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+	}
+
+	return p.emailSender.SendSignupReceivedEmail(toAddress, signupReceivedData)
+}
+
+// EmailNewSignup lets the admins and moderators of this instance know
+// that the given new user is awaiting approval.
+//
+// At most one email is sent per accounts-notify-new-signups-interval;
+// signups arriving in the meantime are batched, and sent together once
+// the interval has passed.
+func (p *Processor) EmailNewSignup(ctx context.Context, user *gtsmodel.User, account *gtsmodel.Account) error {
+	if config.GetSMTPHost() == "" || !config.GetAccountsNotifyNewSignups() {
+		// Email sending not
+		// configured or wanted.
+		return nil
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	signupEmail := user.UnconfirmedEmail
+	if signupEmail == "" {
+		signupEmail = user.Email
+	}
+
+	b := p.signups
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.signups) < maxBatchedSignups {
+		b.signups = append(b.signups, email.NewSignupEntry{
+			Username:   account.Username,
+			Email:      signupEmail,
+			Reason:     account.Reason,
+			AccountURL: instance.URI + "/settings/admin/accounts/" + account.ID,
+		})
+	} else {
+		b.more++
+	}
+
+	if b.scheduled {
+		// Will be sent along
+		// with the next batch.
+		return nil
+	}
+
+	next := b.lastSent.Add(config.GetAccountsNotifyNewSignupsInterval())
+	if !next.After(time.Now()) {
+		// Not emailed recently,
+		// send this one right away.
+		return p.sendSignupBatch(ctx, instance)
+	}
+
+	b.scheduled = true
+	p.state.Workers.Scheduler.Schedule(sched.NewJob(func(time.Time) {
+		ctx := runners.CancelCtx(p.state.Workers.Scheduler.Done())
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.scheduled = false
+
+		instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+		if err != nil {
+			log.Errorf(ctx, "db error getting instance: %v", err)
+			return
+		}
+
+		if err := p.sendSignupBatch(ctx, instance); err != nil {
+			log.Errorf(ctx, "error emailing new signups: %v", err)
+		}
+	}).At(next))
+
+	return nil
+}
+
+// sendSignupBatch emails all currently batched signups to the
+// admins and moderators of this instance, and clears the batch.
+// The signup batch lock must be held when calling this function.
+func (p *Processor) sendSignupBatch(ctx context.Context, instance *gtsmodel.Instance) error {
+	b := p.signups
+	if len(b.signups) == 0 {
+		return nil
+	}
+
+	newSignupData := email.NewSignupData{
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+		AccountsURL:  instance.URI + "/settings/admin/accounts",
+		Signups:      b.signups,
+		MoreSignups:  b.more,
+	}
+
+	// Clear the batch whether or not sending
+	// works, so it doesn't grow indefinitely.
+	b.signups = nil
+	b.more = 0
+	b.lastSent = time.Now()
+
+	toAddresses, err := p.state.DB.GetInstanceModeratorAddresses(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// No registered moderator addresses.
+			return nil
+		}
+		return gtserror.Newf("db error getting instance moderator addresses: %w", err)
+	}
+
+	return p.emailSender.SendNewSignupEmail(toAddresses, newSignupData)
+}
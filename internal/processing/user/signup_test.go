@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SignupTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *SignupTestSuite) TestEmailNewSignupNoSMTP() {
+	user := suite.testUsers["unconfirmed_account"]
+	account := testrig.NewTestAccounts()["unconfirmed_account"]
+
+	// SMTP isn't configured in
+	// the test config, so nothing
+	// should be sent to anyone.
+	suite.NoError(suite.user.EmailSignupReceived(context.Background(), user, account.Username))
+	suite.NoError(suite.user.EmailNewSignup(context.Background(), user, account))
+	suite.Empty(suite.sentEmails)
+}
+
+func (suite *SignupTestSuite) TestEmailNewSignup() {
+	config.SetSMTPHost("smtp.example.org")
+	config.SetAccountsNotifyNewSignupsInterval(0)
+
+	user := suite.testUsers["unconfirmed_account"]
+	account := testrig.NewTestAccounts()["unconfirmed_account"]
+
+	suite.NoError(suite.user.EmailSignupReceived(context.Background(), user, account.Username))
+	suite.NoError(suite.user.EmailNewSignup(context.Background(), user, account))
+	suite.Len(suite.sentEmails, 2)
+
+	// The applicant should be told their signup is pending.
+	suite.Contains(suite.sentEmails["weed_lord420@example.org"], "Subject: GoToSocial Sign-Up Received\r\n")
+
+	// The admin should get the reason and a link to the account.
+	suite.Equal("To: admin@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial New Sign-Up\r\n\r\nHello moderator of GoToSocial Testrig Instance (http://localhost:8080)!\r\n\r\nSomeone has signed up to your instance, and their account is awaiting approval.\r\n\r\nUsername: weed_lord420\r\nEmail: weed_lord420@example.org\r\nReason: hi, please let me in! I'm looking for somewhere neato bombeato to hang out.\r\nTo review this account, paste the following link into your browser: http://localhost:8080/settings/admin/accounts/01F8MH0BBE4FHXPH513MBVFHB0\r\n\r\nTo view accounts on your instance, paste the following link into your browser: http://localhost:8080/settings/admin/accounts\r\n\r\n", suite.sentEmails["admin@example.org"])
+}
+
+func (suite *SignupTestSuite) TestEmailNewSignupDisabled() {
+	config.SetSMTPHost("smtp.example.org")
+	config.SetAccountsNotifyNewSignups(false)
+
+	user := suite.testUsers["unconfirmed_account"]
+	account := testrig.NewTestAccounts()["unconfirmed_account"]
+
+	suite.NoError(suite.user.EmailNewSignup(context.Background(), user, account))
+	suite.Empty(suite.sentEmails)
+}
+
+func TestSignupTestSuite(t *testing.T) {
+	suite.Run(t, &SignupTestSuite{})
+}
@@ -18,19 +18,39 @@
 package user
 
 import (
+	"time"
+
+	"codeberg.org/gruf/go-cache/v3/ttl"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
 )
 
 type Processor struct {
 	state       *state.State
+	tc          typeutils.TypeConverter
 	emailSender email.Sender
+
+	// webAuthnChallenges holds the challenges of
+	// WebAuthn registrations in progress, by user ID.
+	webAuthnChallenges *ttl.Cache[string, string]
+
+	// signups batches new signups
+	// for emailing to admins.
+	signups *signupBatch
 }
 
 // New returns a new user processor
-func New(state *state.State, emailSender email.Sender) Processor {
+func New(state *state.State, tc typeutils.TypeConverter, emailSender email.Sender) Processor {
+	webAuthnChallenges := ttl.New[string, string](0, 1000, webauthn.ChallengeTimeout)
+	webAuthnChallenges.Start(time.Minute)
+
 	return Processor{
-		state:       state,
-		emailSender: emailSender,
+		state:              state,
+		tc:                 tc,
+		emailSender:        emailSender,
+		webAuthnChallenges: webAuthnChallenges,
+		signups:            &signupBatch{},
 	}
 }
@@ -53,7 +53,7 @@ func (suite *UserStandardTestSuite) SetupTest() {
 	suite.emailSender = testrig.NewEmailSender("../../../web/template/", suite.sentEmails)
 	suite.testUsers = testrig.NewTestUsers()
 
-	suite.user = user.New(&suite.state, suite.emailSender)
+	suite.user = user.New(&suite.state, testrig.NewTestTypeConverter(suite.db), suite.emailSender)
 
 	testrig.StandardDBSetup(suite.db, nil)
 }
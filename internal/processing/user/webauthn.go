@@ -0,0 +1,296 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
+)
+
+const maximumWebAuthnCredentialNameLength = 64
+
+// WebAuthnRegisterBegin starts the registration of a new WebAuthn credential
+// for the given user, returning the options that the browser should use to
+// create it. The registration should be finished within webauthn.ChallengeTimeout.
+func (p *Processor) WebAuthnRegisterBegin(ctx context.Context, user *gtsmodel.User, account *gtsmodel.Account) (*apimodel.WebAuthnCreationOptions, gtserror.WithCode) {
+	if errWithCode := checkWebAuthnAvailable(); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	credentials, err := p.state.DB.GetWebAuthnCredentialsByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("error getting webauthn credentials for user %s: %w", user.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	p.webAuthnChallenges.Set(user.ID, challenge)
+
+	displayName := account.DisplayName
+	if displayName == "" {
+		displayName = account.Username
+	}
+
+	params := make([]apimodel.WebAuthnCredentialParameters, 0, len(webauthn.Algorithms))
+	for _, alg := range webauthn.Algorithms {
+		params = append(params, apimodel.WebAuthnCredentialParameters{Type: "public-key", Alg: alg})
+	}
+
+	// Prevent registering the same
+	// authenticator more than once.
+	exclude := make([]apimodel.WebAuthnCredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		exclude = append(exclude, apimodel.WebAuthnCredentialDescriptor{Type: "public-key", ID: credential.CredentialID})
+	}
+
+	return &apimodel.WebAuthnCreationOptions{
+		Challenge: challenge,
+		RP: apimodel.WebAuthnRelyingParty{
+			ID:   webauthn.RPID(),
+			Name: config.GetHost(),
+		},
+		User: apimodel.WebAuthnUser{
+			ID:          base64.RawURLEncoding.EncodeToString([]byte(user.ID)),
+			Name:        account.Username,
+			DisplayName: displayName,
+		},
+		PubKeyCredParams:   params,
+		Timeout:            webauthn.ChallengeTimeout.Milliseconds(),
+		ExcludeCredentials: exclude,
+		AuthenticatorSelection: apimodel.WebAuthnAuthenticatorSelection{
+			// Discoverable credentials let users sign
+			// in without entering their email first.
+			ResidentKey:      "required",
+			UserVerification: "preferred",
+		},
+		Attestation: "none",
+	}, nil
+}
+
+// WebAuthnRegisterFinish finishes the registration of a new WebAuthn
+// credential for the given user, started by WebAuthnRegisterBegin.
+func (p *Processor) WebAuthnRegisterFinish(ctx context.Context, user *gtsmodel.User, form *apimodel.WebAuthnRegisterRequest) (*apimodel.WebAuthnCredential, gtserror.WithCode) {
+	if errWithCode := checkWebAuthnAvailable(); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	name := strings.TrimSpace(form.Name)
+	if name == "" {
+		err := errors.New("name must be set")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if length := len([]rune(name)); length > maximumWebAuthnCredentialNameLength {
+		err := fmt.Errorf("name must be %d characters or less, provided name was %d characters", maximumWebAuthnCredentialNameLength, length)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Challenges may only be used once.
+	challenge, ok := p.webAuthnChallenges.Get(user.ID)
+	if !ok {
+		err := errors.New("no passkey registration in progress, or it took too long; please try again")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	p.webAuthnChallenges.Invalidate(user.ID)
+
+	registration := &webauthn.Registration{Algorithm: form.PublicKeyAlgorithm}
+	for _, field := range []struct {
+		name  string
+		value string
+		dst   *[]byte
+	}{
+		{"id", form.ID, &registration.CredentialID},
+		{"client_data_json", form.ClientDataJSON, &registration.ClientDataJSON},
+		{"authenticator_data", form.AuthenticatorData, &registration.AuthenticatorData},
+		{"public_key", form.PublicKey, &registration.PublicKey},
+	} {
+		b, err := webauthn.DecodeField(field.name, field.value)
+		if err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		*field.dst = b
+	}
+
+	authData, err := webauthn.VerifyRegistration(registration, challenge)
+	if err != nil {
+		err = fmt.Errorf("passkey registration could not be verified: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	credentialID := base64.RawURLEncoding.EncodeToString(registration.CredentialID)
+
+	_, err = p.state.DB.GetWebAuthnCredentialByCredentialID(ctx, credentialID)
+	if err == nil {
+		err := errors.New("this passkey is already registered")
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	} else if !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("error checking for existing webauthn credential: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	credential := &gtsmodel.WebAuthnCredential{
+		ID:           id.NewULID(),
+		UserID:       user.ID,
+		Name:         name,
+		CredentialID: credentialID,
+		PublicKey:    registration.PublicKey,
+		Algorithm:    registration.Algorithm,
+		SignCount:    authData.SignCount,
+	}
+
+	if err := p.state.DB.PutWebAuthnCredential(ctx, credential); err != nil {
+		err = gtserror.Newf("error putting webauthn credential: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiCredential, err := p.tc.WebAuthnCredentialToAPIWebAuthnCredential(ctx, credential)
+	if err != nil {
+		err = gtserror.Newf("error converting webauthn credential: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiCredential, nil
+}
+
+// WebAuthnCredentialsGet returns the WebAuthn credentials registered by the given user.
+func (p *Processor) WebAuthnCredentialsGet(ctx context.Context, user *gtsmodel.User) ([]*apimodel.WebAuthnCredential, gtserror.WithCode) {
+	credentials, err := p.state.DB.GetWebAuthnCredentialsByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("error getting webauthn credentials for user %s: %w", user.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiCredentials := make([]*apimodel.WebAuthnCredential, 0, len(credentials))
+	for _, credential := range credentials {
+		apiCredential, err := p.tc.WebAuthnCredentialToAPIWebAuthnCredential(ctx, credential)
+		if err != nil {
+			err = gtserror.Newf("error converting webauthn credential %s: %w", credential.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiCredentials = append(apiCredentials, apiCredential)
+	}
+
+	return apiCredentials, nil
+}
+
+// WebAuthnCredentialDelete deletes one WebAuthn credential of the given user.
+// The last credential of a user cannot be deleted while they have password
+// login disabled, since they would then have no way left to sign in.
+func (p *Processor) WebAuthnCredentialDelete(ctx context.Context, user *gtsmodel.User, credentialID string) (*apimodel.WebAuthnCredential, gtserror.WithCode) {
+	credential, err := p.state.DB.GetWebAuthnCredentialByID(ctx, credentialID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("error getting webauthn credential %s: %w", credentialID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if credential == nil || credential.UserID != user.ID {
+		err := fmt.Errorf("passkey %s not found", credentialID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if passwordLoginDisabled(user) {
+		credentials, err := p.state.DB.GetWebAuthnCredentialsByUserID(ctx, user.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("error getting webauthn credentials for user %s: %w", user.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if len(credentials) <= 1 {
+			err := errors.New("this is your last passkey and password login is disabled; enable password login before removing it")
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+	}
+
+	apiCredential, err := p.tc.WebAuthnCredentialToAPIWebAuthnCredential(ctx, credential)
+	if err != nil {
+		err = gtserror.Newf("error converting webauthn credential: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteWebAuthnCredentialByID(ctx, credential.ID); err != nil {
+		err = gtserror.Newf("error deleting webauthn credential %s: %w", credential.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiCredential, nil
+}
+
+// WebAuthnPasswordLoginGet returns whether the given user may sign in with their password.
+func (p *Processor) WebAuthnPasswordLoginGet(ctx context.Context, user *gtsmodel.User) *apimodel.WebAuthnPasswordLogin {
+	return &apimodel.WebAuthnPasswordLogin{Enabled: !passwordLoginDisabled(user)}
+}
+
+// WebAuthnPasswordLoginSet enables or disables signing in with a password for the
+// given user. Password login can only be disabled once the user has registered at
+// least one WebAuthn credential, so that they don't lock themselves out.
+func (p *Processor) WebAuthnPasswordLoginSet(ctx context.Context, user *gtsmodel.User, enabled bool) (*apimodel.WebAuthnPasswordLogin, gtserror.WithCode) {
+	if !enabled {
+		if errWithCode := checkWebAuthnAvailable(); errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		credentials, err := p.state.DB.GetWebAuthnCredentialsByUserID(ctx, user.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("error getting webauthn credentials for user %s: %w", user.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if len(credentials) == 0 {
+			err := errors.New("register a passkey before disabling password login")
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+	}
+
+	disabled := !enabled
+	user.PasswordLoginDisabled = &disabled
+	if err := p.state.DB.UpdateUser(ctx, user, "password_login_disabled"); err != nil {
+		err = gtserror.Newf("error updating user %s: %w", user.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.WebAuthnPasswordLogin{Enabled: enabled}, nil
+}
+
+// checkWebAuthnAvailable returns an error if passkeys cannot
+// be used on this instance, ie., when sign in is handled by
+// an external OIDC provider instead of by us.
+func checkWebAuthnAvailable() gtserror.WithCode {
+	if config.GetOIDCEnabled() {
+		err := errors.New("sign in is handled by an external OIDC provider on this instance, so passkeys cannot be used")
+		return gtserror.NewErrorForbidden(err, err.Error())
+	}
+	return nil
+}
+
+func passwordLoginDisabled(user *gtsmodel.User) bool {
+	return user.PasswordLoginDisabled != nil && *user.PasswordLoginDisabled
+}
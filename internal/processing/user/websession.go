@@ -0,0 +1,99 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// WebSessionsGet returns the web sessions of the given user, newest first.
+// The session through which the given access token was created, if any,
+// is marked as the current session.
+func (p *Processor) WebSessionsGet(ctx context.Context, user *gtsmodel.User, access string) ([]*apimodel.WebSession, gtserror.WithCode) {
+	sessions, err := p.state.DB.GetWebSessionsByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("error getting web sessions for user %s: %w", user.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	currentID, err := p.state.DB.GetWebSessionIDByAccessToken(ctx, access)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("error getting web session id of token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiSessions := make([]*apimodel.WebSession, 0, len(sessions))
+	for _, session := range sessions {
+		apiSession, err := p.tc.WebSessionToAPIWebSession(ctx, session)
+		if err != nil {
+			err = gtserror.Newf("error converting web session %s: %w", session.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiSession.Current = currentID != "" && session.ID == currentID
+		apiSessions = append(apiSessions, apiSession)
+	}
+
+	return apiSessions, nil
+}
+
+// WebSessionDelete revokes one web session of the given
+// user, along with any tokens created through it.
+func (p *Processor) WebSessionDelete(ctx context.Context, user *gtsmodel.User, sessionID string) (*apimodel.WebSession, gtserror.WithCode) {
+	session, err := p.state.DB.GetWebSessionByID(ctx, sessionID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("error getting web session %s: %w", sessionID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if session == nil || session.UserID != user.ID {
+		err := fmt.Errorf("session %s not found", sessionID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	apiSession, err := p.tc.WebSessionToAPIWebSession(ctx, session)
+	if err != nil {
+		err = gtserror.Newf("error converting web session: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteWebSessionByID(ctx, session.ID); err != nil {
+		err = gtserror.Newf("error deleting web session %s: %w", session.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiSession, nil
+}
+
+// WebSessionsDelete revokes all web sessions of the given user,
+// along with any tokens created through them, logging the user
+// out everywhere they signed in through the web.
+func (p *Processor) WebSessionsDelete(ctx context.Context, user *gtsmodel.User) gtserror.WithCode {
+	if err := p.state.DB.DeleteWebSessionsByUserID(ctx, user.ID); err != nil {
+		err = gtserror.Newf("error deleting web sessions for user %s: %w", user.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
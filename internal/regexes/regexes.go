@@ -42,9 +42,14 @@ const (
 	schemes                  = `(http|https)://`                                         // Allowed URI protocols for parsing links in text.
 	alphaNumeric             = `\p{L}\p{M}*|\p{N}`                                       // A single number or script character in any language, including chars with accents.
 	usernameGrp              = `(?:` + alphaNumeric + `|\.|\-|\_)`                       // Non-capturing group that matches against a single valid username character.
+	usernameEnd              = `(?:` + alphaNumeric + `|\_)`                             // Non-capturing group that matches against the last character of a username, which can't be punctuation.
+	username                 = usernameGrp + `*` + usernameEnd                           // A whole username, eg some.one in @some.one@example.org.
 	domainGrp                = `(?:` + alphaNumeric + `|\.|\-|\:)`                       // Non-capturing group that matches against a single valid domain character.
-	mentionName              = `^@(` + usernameGrp + `+)(?:@(` + domainGrp + `+))?$`     // Extract parts of one mention, maybe including domain.
-	mentionFinder            = `(?:^|\s)(@` + usernameGrp + `+(?:@` + domainGrp + `+)?)` // Extract all mentions from a text, each mention may include domain.
+	domainEnd                = `(?:` + alphaNumeric + `)`                                // Non-capturing group that matches against the last character of a domain, which can't be punctuation.
+	domain                   = domainGrp + `*` + domainEnd                               // A whole domain, maybe including port, eg example.org in @some.one@example.org.
+	mentionName              = `^@(` + username + `)(?:@(` + domain + `))?$`             // Extract parts of one mention, maybe including domain.
+	mentionFinder            = `(?:^|\s)(@` + username + `(?:@` + domain + `)?)`         // Extract all mentions from a text, each mention may include domain.
+	profileURL               = `^` + schemes + `(` + domain + `)/@(` + username + `)/?$` // Extract parts of a profile URL such as https://example.org/@some.one.
 	emojiShortcode           = `\w{2,30}`                                                // Pattern for emoji shortcodes. maximumEmojiShortcodeLength = 30
 	emojiFinder              = `(?:\b)?:(` + emojiShortcode + `):(?:\b)?`                // Extract all emoji shortcodes from a text.
 	usernameStrict           = `^[a-z0-9_]{1,64}$`                                       // Pattern for usernames on THIS instance. maximumUsernameLength = 64
@@ -93,6 +98,11 @@ var (
 	// MentionFinder extracts whole mentions from a piece of text.
 	MentionFinder = regexp.MustCompile(mentionFinder)
 
+	// ProfileURL captures the scheme, domain and username parts
+	// from the URL of a profile such as https://example.org/@whatever_user,
+	// returning https, example.org and whatever_user (without the @ symbol).
+	ProfileURL = regexp.MustCompile(profileURL)
+
 	// EmojiShortcode validates an emoji name.
 	EmojiShortcode = regexp.MustCompile(emojiShortcode)
 
@@ -20,6 +20,7 @@ package router
 import (
 	"fmt"
 	"html/template"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -30,6 +31,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -159,6 +161,40 @@ func emojify(emojis []apimodel.Emoji, inputText template.HTML) template.HTML {
 	return template.HTML(out)
 }
 
+// proxyImages rewrites external images in inputText (which should already
+// be escaped), the content of the status with statusID by author, to be
+// served via the image proxy, if enabled. Only images in remote statuses
+// are proxied, since the proxy won't serve those found in local ones.
+func proxyImages(statusID string, author *apimodel.Account, inputText template.HTML) template.HTML {
+	if !config.GetMediaProxyEnabled() {
+		return inputText
+	}
+
+	if author == nil || !strings.Contains(author.Acct, "@") {
+		// Local status.
+		return inputText
+	}
+
+	out := text.ProxyImages(string(inputText), func(src string) string {
+		u, err := url.Parse(src)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+			// Not something we can proxy.
+			return src
+		}
+
+		if u.Host == config.GetHost() || u.Host == config.GetAccountDomain() {
+			// Already served by us.
+			return src
+		}
+
+		return uris.GenerateURIForProxiedImage(statusID, src)
+	})
+
+	/* #nosec G203 */
+	// (this is escaped above)
+	return template.HTML(out)
+}
+
 func acctInstance(acct string) string {
 	parts := strings.Split(acct, "@")
 	if len(parts) > 1 {
@@ -179,6 +215,7 @@ func LoadTemplateFunctions(engine *gin.Engine) {
 		"timestampVague":   timestampVague,
 		"timestampPrecise": timestampPrecise,
 		"emojify":          emojify,
+		"proxyImages":      proxyImages,
 		"acctInstance":     acctInstance,
 	})
 }
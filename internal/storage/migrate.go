@@ -0,0 +1,219 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"codeberg.org/gruf/go-store/v2/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// MigrateProgress describes the progress of a storage migration.
+type MigrateProgress struct {
+	// Copied is the number of keys copied.
+	Copied int
+	// Skipped is the number of keys skipped, as they
+	// had already been copied (ie., by an earlier run).
+	Skipped int
+	// Failed is the number of keys that couldn't be copied.
+	Failed int
+}
+
+// Total returns the total number of keys processed so far.
+func (p MigrateProgress) Total() int {
+	return p.Copied + p.Skipped + p.Failed
+}
+
+// OpenMigration opens the storage backends to migrate from and to, as set
+// by the storage-migrate-from and storage-backend config respectively. Local
+// storage is opened with a separate lockfile, so that a migration can be run
+// while GoToSocial itself is also running with these storage backends open.
+func OpenMigration() (from *Driver, to *Driver, err error) {
+	backend := config.GetStorageBackend()
+	fromBackend := config.GetStorageMigrateFrom()
+
+	switch fromBackend {
+	case "":
+		return nil, nil, fmt.Errorf("%s must be set", config.StorageMigrateFromFlag())
+	case backend:
+		return nil, nil, fmt.Errorf("%s must differ from %s", config.StorageMigrateFromFlag(), config.StorageBackendFlag())
+	}
+
+	from, err = openBackend(fromBackend, migrateLockFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening storage backend to migrate from: %w", err)
+	}
+
+	to, err = openBackend(backend, migrateLockFile)
+	if err != nil {
+		_ = from.Close()
+		return nil, nil, fmt.Errorf("error opening storage backend to migrate to: %w", err)
+	}
+
+	return from, to, nil
+}
+
+// Migrate copies every key from src storage to dst storage, verifying each copy
+// by comparing checksums of the source and copied values. Keys that are already
+// in dst with a matching checksum are skipped, so an interrupted migration can
+// simply be run again to resume it. Keys are processed in batches of batchSize,
+// calling progress after each batch. Keys that fail to copy are passed to onError
+// and counted as failed, rather than aborting the migration.
+func Migrate(
+	ctx context.Context,
+	dst storage.Storage,
+	src storage.Storage,
+	batchSize int,
+	progress func(MigrateProgress),
+	onError func(key string, err error),
+) (MigrateProgress, error) {
+	var (
+		p     MigrateProgress
+		batch = make([]string, 0, batchSize)
+	)
+
+	flush := func() error {
+		for _, key := range batch {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			copied, err := migrateKey(ctx, dst, src, key)
+			switch {
+			case err != nil:
+				p.Failed++
+				onError(key, err)
+			case copied:
+				p.Copied++
+			default:
+				p.Skipped++
+			}
+		}
+
+		batch = batch[:0]
+		progress(p)
+		return nil
+	}
+
+	if err := src.WalkKeys(ctx, storage.WalkKeysOptions{
+		WalkFn: func(ctx context.Context, entry storage.Entry) error {
+			switch strings.TrimPrefix(entry.Key, "/") {
+			case storeLockFile, migrateLockFile:
+				// Not media.
+				return nil
+			}
+
+			batch = append(batch, entry.Key)
+			if len(batch) < batchSize {
+				return nil
+			}
+
+			return flush()
+		},
+	}); err != nil {
+		return p, err
+	}
+
+	if len(batch) > 0 {
+		if err := flush(); err != nil {
+			return p, err
+		}
+	}
+
+	return p, nil
+}
+
+// migrateKey copies value at key from src to dst, verifying the copy by
+// checksum, returning false if it was already present in dst (ie. skipped).
+func migrateKey(ctx context.Context, dst storage.Storage, src storage.Storage, key string) (bool, error) {
+	ok, err := dst.Stat(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("error checking destination: %w", err)
+	}
+
+	if ok {
+		srcSum, err := checksum(ctx, src, key)
+		if err != nil {
+			return false, fmt.Errorf("error reading source: %w", err)
+		}
+
+		dstSum, err := checksum(ctx, dst, key)
+		if err != nil {
+			return false, fmt.Errorf("error reading destination: %w", err)
+		}
+
+		if bytes.Equal(srcSum, dstSum) {
+			// Already migrated.
+			return false, nil
+		}
+
+		// Partial or otherwise bad copy,
+		// remove so we can write it again.
+		if err := dst.Remove(ctx, key); err != nil && !errors.Is(err, ErrNotFound) {
+			return false, fmt.Errorf("error removing bad copy from destination: %w", err)
+		}
+	}
+
+	rc, err := src.ReadStream(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("error reading source: %w", err)
+	}
+	defer rc.Close()
+
+	// Checksum the source value as we copy it.
+	hash := sha256.New()
+	if _, err := dst.WriteStream(ctx, key, io.TeeReader(rc, hash)); err != nil {
+		return false, fmt.Errorf("error writing destination: %w", err)
+	}
+	srcSum := hash.Sum(nil)
+
+	dstSum, err := checksum(ctx, dst, key)
+	if err != nil {
+		return false, fmt.Errorf("error verifying destination: %w", err)
+	}
+
+	if !bytes.Equal(srcSum, dstSum) {
+		_ = dst.Remove(ctx, key)
+		return false, errors.New("checksum mismatch after copy")
+	}
+
+	return true, nil
+}
+
+// checksum returns the sha256 checksum of the value at key in st.
+func checksum(ctx context.Context, st storage.Storage, key string) ([]byte, error) {
+	rc, err := st.ReadStream(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, rc); err != nil {
+		return nil, err
+	}
+
+	return hash.Sum(nil), nil
+}
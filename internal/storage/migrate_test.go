@@ -0,0 +1,140 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"codeberg.org/gruf/go-store/v2/storage"
+	"github.com/stretchr/testify/suite"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+type MigrateTestSuite struct {
+	suite.Suite
+	src *storage.MemoryStorage
+	dst *storage.MemoryStorage
+}
+
+func (suite *MigrateTestSuite) SetupTest() {
+	suite.src = storage.OpenMemory(16, false)
+	suite.dst = storage.OpenMemory(16, false)
+}
+
+func (suite *MigrateTestSuite) put(st storage.Storage, key string, value string) {
+	if _, err := st.WriteBytes(context.Background(), key, []byte(value)); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *MigrateTestSuite) get(st storage.Storage, key string) string {
+	b, err := st.ReadBytes(context.Background(), key)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return string(b)
+}
+
+func (suite *MigrateTestSuite) migrate() gtsstorage.MigrateProgress {
+	var batches int
+	res, err := gtsstorage.Migrate(context.Background(), suite.dst, suite.src, 2,
+		func(gtsstorage.MigrateProgress) { batches++ },
+		func(key string, err error) { suite.FailNow("unexpected error migrating " + key + ": " + err.Error()) },
+	)
+	suite.NoError(err)
+	suite.Equal((res.Total()+1)/2, batches)
+	return res
+}
+
+func (suite *MigrateTestSuite) TestMigrate() {
+	suite.put(suite.src, "a/original/1.jpg", "one")
+	suite.put(suite.src, "a/small/1.jpg", "two")
+	suite.put(suite.src, "b/original/2.png", "three")
+
+	res := suite.migrate()
+	suite.Equal(gtsstorage.MigrateProgress{Copied: 3}, res)
+
+	suite.Equal("one", suite.get(suite.dst, "a/original/1.jpg"))
+	suite.Equal("two", suite.get(suite.dst, "a/small/1.jpg"))
+	suite.Equal("three", suite.get(suite.dst, "b/original/2.png"))
+
+	// Running again should be a no-op.
+	res = suite.migrate()
+	suite.Equal(gtsstorage.MigrateProgress{Skipped: 3}, res)
+}
+
+func (suite *MigrateTestSuite) TestMigrateResume() {
+	suite.put(suite.src, "a/original/1.jpg", "one")
+	suite.put(suite.src, "a/small/1.jpg", "two")
+	suite.put(suite.src, "b/original/2.png", "three")
+
+	// One already copied, one only partially copied.
+	suite.put(suite.dst, "a/original/1.jpg", "one")
+	suite.put(suite.dst, "a/small/1.jpg", "tw")
+
+	res := suite.migrate()
+	suite.Equal(gtsstorage.MigrateProgress{Copied: 2, Skipped: 1}, res)
+	suite.Equal("two", suite.get(suite.dst, "a/small/1.jpg"))
+}
+
+func (suite *MigrateTestSuite) TestMigrateSkipsLockFiles() {
+	suite.put(suite.src, "store.lock", "")
+	suite.put(suite.src, "a/original/1.jpg", "one")
+
+	res := suite.migrate()
+	suite.Equal(gtsstorage.MigrateProgress{Copied: 1}, res)
+
+	ok, err := suite.dst.Stat(context.Background(), "store.lock")
+	suite.NoError(err)
+	suite.False(ok)
+}
+
+func (suite *MigrateTestSuite) TestDriverFallback() {
+	ctx := context.Background()
+	driver := &gtsstorage.Driver{
+		Storage:  suite.dst,
+		Fallback: suite.src,
+	}
+
+	suite.put(suite.src, "old", "old value")
+
+	// Reads fall back to the old storage.
+	b, err := driver.Get(ctx, "old")
+	suite.NoError(err)
+	suite.Equal("old value", string(b))
+
+	// Writes only go to the new storage.
+	_, err = driver.Put(ctx, "new", []byte("new value"))
+	suite.NoError(err)
+	suite.Equal("new value", suite.get(suite.dst, "new"))
+	ok, err := suite.src.Stat(ctx, "new")
+	suite.NoError(err)
+	suite.False(ok)
+
+	// Deletes apply to both.
+	suite.put(suite.dst, "old", "old value")
+	suite.NoError(driver.Delete(ctx, "old"))
+	_, err = driver.Get(ctx, "old")
+	suite.True(errors.Is(err, gtsstorage.ErrNotFound))
+}
+
+func TestMigrateTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrateTestSuite))
+}
@@ -0,0 +1,139 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"codeberg.org/gruf/go-store/v2/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+const (
+	// Fractions of MaxSize at (or above) which usage is
+	// considered to be at the high- and low-water marks.
+	highWaterMark = 0.9
+	lowWaterMark  = 0.8
+)
+
+// Used returns the total size in bytes of all values in local storage.
+//
+// This is tracked by a counter that is updated on each Put / Delete, so it may
+// drift from the true size (eg., if files are changed outside of GoToSocial),
+// until the next call to Reconcile. If not on local storage, this will be 0.
+func (d *Driver) Used() int64 {
+	return d.used.Load()
+}
+
+// CheckQuota returns an error if storing a further value of size bytes would
+// take local storage usage above MaxSize: 413 if the value could never fit,
+// or 507 otherwise. This should be checked before accepting new uploads from
+// local accounts, and the error returned to the uploader.
+func (d *Driver) CheckQuota(size int64) gtserror.WithCode {
+	switch {
+	case d.MaxSize <= 0:
+		return nil
+
+	case size > d.MaxSize:
+		err := fmt.Errorf("upload of %d bytes is larger than storage quota of %d bytes", size, d.MaxSize)
+		return gtserror.NewErrorRequestEntityTooLarge(err, "upload is larger than this instance's storage quota")
+
+	case d.Used()+size > d.MaxSize:
+		err := fmt.Errorf("upload of %d bytes would exceed storage quota of %d bytes (%d used)", size, d.MaxSize, d.Used())
+		return gtserror.NewErrorInsufficientStorage(err, "this instance has run out of storage space, please contact your admin")
+	}
+
+	return nil
+}
+
+// AboveHighWater returns whether local storage usage
+// is at or above the high-water mark of MaxSize.
+func (d *Driver) AboveHighWater() bool {
+	return d.MaxSize > 0 && float64(d.Used()) >= float64(d.MaxSize)*highWaterMark
+}
+
+// AboveLowWater returns whether local storage usage
+// is at or above the low-water mark of MaxSize.
+func (d *Driver) AboveLowWater() bool {
+	return d.MaxSize > 0 && float64(d.Used()) >= float64(d.MaxSize)*lowWaterMark
+}
+
+// Reconcile walks local storage to total up the size of all stored
+// values, resetting the tracked usage counter to this and returning it.
+// This corrects any drift of the counter from the true usage.
+func (d *Driver) Reconcile(ctx context.Context) (int64, error) {
+	if _, ok := d.Storage.(*storage.DiskStorage); !ok {
+		// Only tracked on disk.
+		return 0, nil
+	}
+
+	var total int64
+
+	if err := d.Storage.WalkKeys(ctx, storage.WalkKeysOptions{
+		WalkFn: func(ctx context.Context, entry storage.Entry) error {
+			if entry.Size > 0 {
+				total += entry.Size
+			}
+			return nil
+		},
+	}); err != nil {
+		return 0, err
+	}
+
+	d.used.Store(total)
+	return total, nil
+}
+
+// track adds n (which may be negative) to the tracked
+// usage of local storage, calling OnHighWater if usage
+// is left at or above the high-water mark after adding.
+func (d *Driver) track(n int64) {
+	if _, ok := d.Storage.(*storage.DiskStorage); !ok || n == 0 {
+		// Only tracked on disk.
+		return
+	}
+
+	d.used.Add(n)
+
+	if n > 0 && d.OnHighWater != nil && d.AboveHighWater() {
+		d.OnHighWater()
+	}
+}
+
+// sizeOf returns the size of value at key in local
+// storage, or 0 if not on local storage or not found.
+func (d *Driver) sizeOf(key string) int64 {
+	disk, ok := d.Storage.(*storage.DiskStorage)
+	if !ok {
+		return 0
+	}
+
+	fpath, err := disk.Filepath(key)
+	if err != nil {
+		return 0
+	}
+
+	info, err := os.Stat(fpath)
+	if err != nil {
+		return 0
+	}
+
+	return info.Size()
+}
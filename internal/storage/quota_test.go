@@ -0,0 +1,134 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+
+	"codeberg.org/gruf/go-store/v2/storage"
+	"github.com/stretchr/testify/suite"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+type QuotaTestSuite struct {
+	suite.Suite
+	dir    string
+	driver *gtsstorage.Driver
+}
+
+func (suite *QuotaTestSuite) SetupTest() {
+	suite.dir = suite.T().TempDir()
+
+	disk, err := storage.OpenDisk(suite.dir, &storage.DiskConfig{
+		LockFile: path.Join(suite.dir, "store.lock"),
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.driver = &gtsstorage.Driver{
+		Storage: disk,
+		MaxSize: 100,
+	}
+}
+
+func (suite *QuotaTestSuite) TearDownTest() {
+	_ = suite.driver.Close()
+}
+
+func (suite *QuotaTestSuite) TestTrackUsage() {
+	ctx := context.Background()
+
+	_, err := suite.driver.Put(ctx, "a", make([]byte, 30))
+	suite.NoError(err)
+	suite.EqualValues(30, suite.driver.Used())
+
+	_, err = suite.driver.Put(ctx, "b", make([]byte, 20))
+	suite.NoError(err)
+	suite.EqualValues(50, suite.driver.Used())
+
+	suite.NoError(suite.driver.Delete(ctx, "a"))
+	suite.EqualValues(20, suite.driver.Used())
+}
+
+func (suite *QuotaTestSuite) TestCheckQuota() {
+	ctx := context.Background()
+
+	_, err := suite.driver.Put(ctx, "a", make([]byte, 60))
+	suite.NoError(err)
+
+	suite.Nil(suite.driver.CheckQuota(40))
+
+	errWithCode := suite.driver.CheckQuota(41)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusInsufficientStorage, errWithCode.Code())
+
+	errWithCode = suite.driver.CheckQuota(101)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusRequestEntityTooLarge, errWithCode.Code())
+
+	// No quota means no limit.
+	suite.driver.MaxSize = 0
+	suite.Nil(suite.driver.CheckQuota(1000))
+}
+
+func (suite *QuotaTestSuite) TestHighWater() {
+	ctx := context.Background()
+
+	var calls int
+	suite.driver.OnHighWater = func() { calls++ }
+
+	_, err := suite.driver.Put(ctx, "a", make([]byte, 85))
+	suite.NoError(err)
+	suite.Equal(0, calls)
+	suite.True(suite.driver.AboveLowWater())
+	suite.False(suite.driver.AboveHighWater())
+
+	_, err = suite.driver.Put(ctx, "b", make([]byte, 5))
+	suite.NoError(err)
+	suite.Equal(1, calls)
+	suite.True(suite.driver.AboveHighWater())
+
+	// Removing shouldn't call it again.
+	suite.NoError(suite.driver.Delete(ctx, "b"))
+	suite.Equal(1, calls)
+}
+
+func (suite *QuotaTestSuite) TestReconcile() {
+	ctx := context.Background()
+
+	_, err := suite.driver.Put(ctx, "a", make([]byte, 30))
+	suite.NoError(err)
+
+	// Change file behind the driver's back.
+	suite.NoError(os.WriteFile(path.Join(suite.dir, "a"), make([]byte, 10), 0o600))
+	suite.EqualValues(30, suite.driver.Used())
+
+	used, err := suite.driver.Reconcile(ctx)
+	suite.NoError(err)
+	suite.EqualValues(10, used)
+	suite.EqualValues(10, suite.driver.Used())
+}
+
+func TestQuotaTestSuite(t *testing.T) {
+	suite.Run(t, new(QuotaTestSuite))
+}
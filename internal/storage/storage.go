@@ -18,12 +18,16 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"mime"
 	"net/url"
 	"path"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"codeberg.org/gruf/go-bytesize"
@@ -35,8 +39,20 @@ import (
 )
 
 const (
-	urlCacheTTL             = time.Hour * 24
 	urlCacheExpiryFrequency = time.Minute * 5
+
+	// Bounds for configured presigned URL expiry,
+	// the upper bound being the max allowed by S3.
+	minURLExpiry = time.Minute
+	maxURLExpiry = 7 * 24 * time.Hour
+
+	// Minimum size of all but the last
+	// part of an S3 multipart upload.
+	minS3PartSize = 5 * bytesize.MiB
+
+	// Lockfile names for local storage, see newFileStorage().
+	storeLockFile   = "store.lock"
+	migrateLockFile = "migrate.lock"
 )
 
 // PresignedURL represents a pre signed S3 URL with
@@ -57,57 +73,174 @@ type Driver struct {
 	// Underlying storage
 	Storage storage.Storage
 
+	// Fallback storage, set when migrating from one
+	// storage backend to another. Reads of keys not
+	// in Storage are attempted from Fallback instead,
+	// and removals are applied to both. Writes only
+	// ever go to Storage.
+	Fallback storage.Storage
+
+	// Local-only parameters, see quota.go.
+	MaxSize     int64
+	OnHighWater func()
+	used        atomic.Int64
+
 	// S3-only parameters
-	Proxy          bool
-	Bucket         string
-	PresignedCache *ttl.Cache[string, PresignedURL]
+	Proxy              bool
+	Bucket             string
+	URLExpiry          time.Duration
+	MultipartThreshold int
+	PresignedCache     *ttl.Cache[string, PresignedURL]
 }
 
 // Get returns the byte value for key in storage.
 func (d *Driver) Get(ctx context.Context, key string) ([]byte, error) {
-	return d.Storage.ReadBytes(ctx, key)
+	b, err := d.Storage.ReadBytes(ctx, key)
+	if d.Fallback != nil && errors.Is(err, ErrNotFound) {
+		return d.Fallback.ReadBytes(ctx, key)
+	}
+	return b, err
 }
 
 // GetStream returns an io.ReadCloser for the value bytes at key in the storage.
 func (d *Driver) GetStream(ctx context.Context, key string) (io.ReadCloser, error) {
-	return d.Storage.ReadStream(ctx, key)
+	rc, err := d.Storage.ReadStream(ctx, key)
+	if d.Fallback != nil && errors.Is(err, ErrNotFound) {
+		return d.Fallback.ReadStream(ctx, key)
+	}
+	return rc, err
 }
 
 // Put writes the supplied value bytes at key in the storage
 func (d *Driver) Put(ctx context.Context, key string, value []byte) (int, error) {
-	return d.Storage.WriteBytes(ctx, key, value)
+	n, err := d.Storage.WriteBytes(ctx, key, value)
+	if err == nil {
+		d.track(int64(n))
+	}
+	return n, err
 }
 
-// PutStream writes the bytes from supplied reader at key in the storage
+// PutStream writes the bytes from supplied reader at key in the storage.
+//
+// On S3 storage, values up to MultipartThreshold in size are uploaded in a single
+// request, and larger values are uploaded in parts of MultipartThreshold size, so
+// that only one part at a time has to be held in memory.
 func (d *Driver) PutStream(ctx context.Context, key string, r io.Reader) (int64, error) {
-	return d.Storage.WriteStream(ctx, key, r)
+	n, err := d.putStream(ctx, key, r)
+	if err == nil {
+		d.track(n)
+	}
+	return n, err
+}
+
+func (d *Driver) putStream(ctx context.Context, key string, r io.Reader) (int64, error) {
+	if _, ok := d.Storage.(*storage.S3Storage); !ok || d.MultipartThreshold <= 0 {
+		return d.Storage.WriteStream(ctx, key, r)
+	}
+
+	// Read up to the threshold (+1 byte, to
+	// tell if there's more) into memory.
+	buf := make([]byte, d.MultipartThreshold+1)
+	n, err := io.ReadFull(r, buf)
+
+	switch {
+	case err == io.EOF || err == io.ErrUnexpectedEOF:
+		// Whole value fits under the threshold,
+		// so upload it in a single request.
+		sz, err := d.Storage.WriteBytes(ctx, key, buf[:n])
+		return int64(sz), err
+
+	case err != nil:
+		return 0, err
+	}
+
+	// Value is larger than the threshold, stream it as a
+	// multipart upload, starting with what's already read.
+	return d.Storage.WriteStream(ctx, key, io.MultiReader(bytes.NewReader(buf), r))
 }
 
 // Remove attempts to remove the supplied key (and corresponding value) from storage.
 func (d *Driver) Delete(ctx context.Context, key string) error {
-	return d.Storage.Remove(ctx, key)
+	size := d.sizeOf(key)
+	err := d.Storage.Remove(ctx, key)
+	if err == nil {
+		d.track(-size)
+	}
+
+	if d.Fallback == nil {
+		return err
+	}
+
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	// Also remove from fallback, only
+	// returning not found if the key
+	// was in neither of the storages.
+	fallbackErr := d.Fallback.Remove(ctx, key)
+	if errors.Is(fallbackErr, ErrNotFound) {
+		return err
+	}
+
+	return fallbackErr
 }
 
 // Has checks if the supplied key is in the storage.
 func (d *Driver) Has(ctx context.Context, key string) (bool, error) {
-	return d.Storage.Stat(ctx, key)
+	ok, err := d.Storage.Stat(ctx, key)
+	if d.Fallback != nil && err == nil && !ok {
+		return d.Fallback.Stat(ctx, key)
+	}
+	return ok, err
 }
 
 // WalkKeys walks the keys in the storage.
 func (d *Driver) WalkKeys(ctx context.Context, walk func(context.Context, string) error) error {
-	return d.Storage.WalkKeys(ctx, storage.WalkKeysOptions{
+	return d.WalkEntries(ctx, func(ctx context.Context, key string, _ int64) error {
+		return walk(ctx, key)
+	})
+}
+
+// WalkEntries walks the keys in the storage, along with
+// the size in bytes of each value (or -1 if not known).
+func (d *Driver) WalkEntries(ctx context.Context, walk func(context.Context, string, int64) error) error {
+	if err := d.Storage.WalkKeys(ctx, storage.WalkKeysOptions{
 		WalkFn: func(ctx context.Context, entry storage.Entry) error {
-			return walk(ctx, entry.Key)
+			return walk(ctx, entry.Key, entry.Size)
+		},
+	}); err != nil || d.Fallback == nil {
+		return err
+	}
+
+	return d.Fallback.WalkKeys(ctx, storage.WalkKeysOptions{
+		WalkFn: func(ctx context.Context, entry storage.Entry) error {
+			// Skip keys already walked above.
+			ok, err := d.Storage.Stat(ctx, entry.Key)
+			if err != nil || ok {
+				return err
+			}
+			return walk(ctx, entry.Key, entry.Size)
 		},
 	})
 }
 
 // Close will close the storage, releasing any file locks.
 func (d *Driver) Close() error {
-	return d.Storage.Close()
+	err := d.Storage.Close()
+	if d.Fallback != nil {
+		if fallbackErr := d.Fallback.Close(); err == nil {
+			err = fallbackErr
+		}
+	}
+	return err
 }
 
 // URL will return a presigned GET object URL, but only if running on S3 storage with proxying disabled.
+//
+// Presigned URLs are cached until close to their expiry, so that repeated requests for the same file are
+// redirected to the same URL (which clients can in turn cache), and so that we don't redirect clients to
+// URLs which will have expired before they get around to using them.
 func (d *Driver) URL(ctx context.Context, key string) *PresignedURL {
 	// Check whether S3 *without* proxying is enabled
 	s3, ok := d.Storage.(*storage.S3Storage)
@@ -121,12 +254,34 @@ func (d *Driver) URL(ctx context.Context, key string) *PresignedURL {
 	e, ok := d.PresignedCache.Cache.Get(key)
 	d.PresignedCache.Unlock()
 
-	if ok {
+	if ok && time.Until(e.Value.Expiry) > d.URLExpiry/10 {
 		return &e.Value
 	}
 
-	u, err := s3.Client().PresignedGetObject(ctx, d.Bucket, key, urlCacheTTL, url.Values{
+	if d.Fallback != nil {
+		// Key may still only be in fallback storage,
+		// in which case we have to serve it ourselves.
+		if ok, err := s3.Stat(ctx, key); err != nil || !ok {
+			return nil
+		}
+	}
+
+	// Take expiry time before presigning, so that
+	// it's always slightly earlier than the real one.
+	expiry := time.Now().Add(d.URLExpiry)
+
+	u, err := s3.Client().PresignedGetObject(ctx, d.Bucket, key, d.URLExpiry, url.Values{
 		"response-content-type": []string{mime.TypeByExtension(path.Ext(key))},
+
+		// Serve inline (ie., display in browser) but with the proper
+		// file name, rather than the whole key, for when downloading.
+		"response-content-disposition": []string{mime.FormatMediaType("inline", map[string]string{
+			"filename": path.Base(key),
+		})},
+
+		// Media at a given key never changes, so let
+		// clients cache it for as long as the URL is valid.
+		"response-cache-control": []string{"private, max-age=" + strconv.Itoa(int(d.URLExpiry.Seconds())) + ", immutable"},
 	})
 	if err != nil {
 		// If URL request fails, fallback is to fetch the file. So ignore the error here
@@ -135,25 +290,58 @@ func (d *Driver) URL(ctx context.Context, key string) *PresignedURL {
 
 	psu := PresignedURL{
 		URL:    u,
-		Expiry: time.Now().Add(urlCacheTTL), // link expires in 24h time
+		Expiry: expiry,
 	}
 
 	d.PresignedCache.Set(key, psu)
 	return &psu
 }
 
+// AutoConfig returns a new storage Driver for the configured storage backend. If
+// a storage backend to migrate from is also configured, this is set as fallback.
 func AutoConfig() (*Driver, error) {
-	switch backend := config.GetStorageBackend(); backend {
+	backend := config.GetStorageBackend()
+	driver, err := openBackend(backend, storeLockFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if from := config.GetStorageMigrateFrom(); from != "" {
+		if from == backend {
+			_ = driver.Close()
+			return nil, fmt.Errorf("storage backend to migrate from must differ from storage backend: %s", from)
+		}
+
+		fallback, err := openBackend(from, storeLockFile)
+		if err != nil {
+			_ = driver.Close()
+			return nil, fmt.Errorf("error opening storage backend to migrate from: %w", err)
+		}
+
+		driver.Fallback = fallback.Storage
+	}
+
+	return driver, nil
+}
+
+// openBackend returns a new storage Driver for the given storage backend
+// name, using the given lockfile name in the case of local storage.
+func openBackend(backend string, lockFile string) (*Driver, error) {
+	switch backend {
 	case "s3":
 		return NewS3Storage()
 	case "local":
-		return NewFileStorage()
+		return newFileStorage(lockFile)
 	default:
 		return nil, fmt.Errorf("invalid storage backend: %s", backend)
 	}
 }
 
 func NewFileStorage() (*Driver, error) {
+	return newFileStorage(storeLockFile)
+}
+
+func newFileStorage(lockFile string) (*Driver, error) {
 	// Load runtime configuration
 	basePath := config.GetStorageLocalBasePath()
 
@@ -161,10 +349,11 @@ func NewFileStorage() (*Driver, error) {
 	disk, err := storage.OpenDisk(basePath, &storage.DiskConfig{
 		// Put the store lockfile in the storage dir itself.
 		// Normally this would not be safe, since we could end up
-		// overwriting the lockfile if we store a file called 'store.lock'.
+		// overwriting the lockfile if we store a file called 'store.lock'
+		// (or 'migrate.lock', used when running a storage migration).
 		// However, in this case it's OK because the keys are set by
 		// GtS and not the user, so we know we're never going to overwrite it.
-		LockFile:     path.Join(basePath, "store.lock"),
+		LockFile:     path.Join(basePath, lockFile),
 		WriteBufSize: int(16 * bytesize.KiB),
 	})
 	if err != nil {
@@ -173,6 +362,7 @@ func NewFileStorage() (*Driver, error) {
 
 	return &Driver{
 		Storage: disk,
+		MaxSize: int64(config.GetStorageLocalMaxSize()),
 	}, nil
 }
 
@@ -183,6 +373,16 @@ func NewS3Storage() (*Driver, error) {
 	secret := config.GetStorageS3SecretKey()
 	secure := config.GetStorageS3UseSSL()
 	bucket := config.GetStorageS3BucketName()
+	expiry := config.GetStorageS3RedirectURLExpiry()
+	threshold := config.GetStorageS3MultipartThreshold()
+
+	if expiry < minURLExpiry || expiry > maxURLExpiry {
+		return nil, fmt.Errorf("%s must be between %s and %s, was %s", config.StorageS3RedirectURLExpiryFlag(), minURLExpiry, maxURLExpiry, expiry)
+	}
+
+	if threshold < minS3PartSize {
+		return nil, fmt.Errorf("%s must be at least %s, was %s", config.StorageS3MultipartThresholdFlag(), minS3PartSize, threshold)
+	}
 
 	// Open the s3 storage implementation
 	s3, err := storage.OpenS3(endpoint, bucket, &storage.S3Config{
@@ -192,7 +392,7 @@ func NewS3Storage() (*Driver, error) {
 		},
 		GetOpts:      minio.GetObjectOptions{},
 		PutOpts:      minio.PutObjectOptions{},
-		PutChunkSize: 5 * 1024 * 1024, // 5MiB
+		PutChunkSize: int64(threshold),
 		StatOpts:     minio.StatObjectOptions{},
 		RemoveOpts:   minio.RemoveObjectOptions{},
 		ListSize:     200,
@@ -201,14 +401,17 @@ func NewS3Storage() (*Driver, error) {
 		return nil, fmt.Errorf("error opening s3 storage: %w", err)
 	}
 
-	// ttl should be lower than the expiry used by S3 to avoid serving invalid URLs
-	presignedCache := ttl.New[string, PresignedURL](0, 1000, urlCacheTTL-urlCacheExpiryFrequency)
+	// entries are only served until close to expiry (see Driver.URL()),
+	// so the ttl here is just to make sure they're eventually swept
+	presignedCache := ttl.New[string, PresignedURL](0, 1000, expiry)
 	presignedCache.Start(urlCacheExpiryFrequency)
 
 	return &Driver{
-		Proxy:          config.GetStorageS3Proxy(),
-		Bucket:         config.GetStorageS3BucketName(),
-		Storage:        s3,
-		PresignedCache: presignedCache,
+		Proxy:              config.GetStorageS3Proxy(),
+		Bucket:             config.GetStorageS3BucketName(),
+		URLExpiry:          expiry,
+		MultipartThreshold: int(threshold),
+		Storage:            s3,
+		PresignedCache:     presignedCache,
 	}, nil
 }
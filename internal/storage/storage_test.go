@@ -0,0 +1,247 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"codeberg.org/gruf/go-bytesize"
+	"codeberg.org/gruf/go-cache/v3/ttl"
+	"codeberg.org/gruf/go-store/v2/storage"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+// fakeS3 is a minimal S3 server, storing objects in
+// memory, and recording the requests made to it.
+type fakeS3 struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	parts    map[string][][]byte
+	requests []string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	query := r.URL.Query()
+	key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+
+	switch {
+	case r.URL.Path == "/bucket" || r.URL.Path == "/bucket/":
+		// BucketExists.
+		f.requests = append(f.requests, "bucket")
+
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		f.requests = append(f.requests, "start multipart "+key)
+		_ = xml.NewEncoder(w).Encode(struct {
+			XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+			Bucket   string
+			Key      string
+			UploadID string `xml:"UploadId"`
+		}{Bucket: "bucket", Key: key, UploadID: key})
+
+	case r.Method == http.MethodPut && query.Has("partNumber"):
+		b, _ := io.ReadAll(r.Body)
+		f.requests = append(f.requests, "put part "+key)
+		f.parts[key] = append(f.parts[key], b)
+		w.Header().Set("ETag", `"part"`)
+
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		f.requests = append(f.requests, "complete multipart "+key)
+		f.objects[key] = bytes.Join(f.parts[key], nil)
+		delete(f.parts, key)
+		_ = xml.NewEncoder(w).Encode(struct {
+			XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+			Bucket  string
+			Key     string
+			ETag    string
+		}{Bucket: "bucket", Key: key, ETag: `"object"`})
+
+	case r.Method == http.MethodPut:
+		b, _ := io.ReadAll(r.Body)
+		f.requests = append(f.requests, "put "+key)
+		f.objects[key] = b
+		w.Header().Set("ETag", `"object"`)
+
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+type S3TestSuite struct {
+	suite.Suite
+	fake   *fakeS3
+	server *httptest.Server
+	s3     *storage.S3Storage
+}
+
+func (suite *S3TestSuite) SetupTest() {
+	testrig.InitTestConfig()
+
+	suite.fake = &fakeS3{
+		objects: make(map[string][]byte),
+		parts:   make(map[string][][]byte),
+	}
+	suite.server = httptest.NewServer(suite.fake)
+
+	s3, err := storage.OpenS3(strings.TrimPrefix(suite.server.URL, "http://"), "bucket", &storage.S3Config{
+		CoreOpts: minio.Options{
+			Creds:  credentials.NewStaticV4("access", "secret", ""),
+			Region: "us-east-1",
+		},
+		PutChunkSize: 16,
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.s3 = s3
+}
+
+func (suite *S3TestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+// driver returns a driver for the fake S3 storage, with a multipart
+// threshold of 16 bytes and presigned URLs valid for an hour.
+func (suite *S3TestSuite) driver(proxy bool) *gtsstorage.Driver {
+	return &gtsstorage.Driver{
+		Storage:            suite.s3,
+		Proxy:              proxy,
+		Bucket:             "bucket",
+		URLExpiry:          time.Hour,
+		MultipartThreshold: 16,
+		PresignedCache:     ttl.New[string, gtsstorage.PresignedURL](0, 100, time.Hour),
+	}
+}
+
+func (suite *S3TestSuite) TestPutStreamUnderThreshold() {
+	value := []byte("small value")
+
+	n, err := suite.driver(false).PutStream(context.Background(), "small", bytes.NewReader(value))
+	suite.NoError(err)
+	suite.EqualValues(len(value), n)
+
+	// Uploaded in a single request.
+	suite.Equal([]string{"bucket", "put small"}, suite.fake.requests)
+	suite.Equal(value, suite.fake.objects["small"])
+}
+
+func (suite *S3TestSuite) TestPutStreamOverThreshold() {
+	value := []byte("this value is too large to upload all at once")
+
+	// Don't pass a bytes.Reader, as its size is known.
+	n, err := suite.driver(false).PutStream(context.Background(), "large", io.MultiReader(bytes.NewReader(value)))
+	suite.NoError(err)
+	suite.EqualValues(len(value), n)
+
+	// Uploaded in 16 byte parts.
+	suite.Equal([]string{
+		"bucket",
+		"start multipart large",
+		"put part large",
+		"put part large",
+		"put part large",
+		"complete multipart large",
+	}, suite.fake.requests)
+	suite.Equal(value, suite.fake.objects["large"])
+}
+
+func (suite *S3TestSuite) TestURL() {
+	driver := suite.driver(false)
+
+	presigned := driver.URL(context.Background(), "01GVKXG0D34XGS2RPN8YXSA6B4/attachment/original/01GVKXG0P0B9FWAVN8Q29CCVW2.jpg")
+	if !suite.NotNil(presigned) {
+		suite.FailNow("no presigned url")
+	}
+	suite.WithinDuration(time.Now().Add(time.Hour), presigned.Expiry, time.Minute)
+
+	query := presigned.URL.Query()
+	suite.Equal("3600", query.Get("X-Amz-Expires"))
+	suite.Equal("image/jpeg", query.Get("response-content-type"))
+	suite.Equal("inline; filename=01GVKXG0P0B9FWAVN8Q29CCVW2.jpg", query.Get("response-content-disposition"))
+	suite.Equal("private, max-age=3600, immutable", query.Get("response-cache-control"))
+
+	// Presigning happens locally, no requests needed.
+	suite.Equal([]string{"bucket"}, suite.fake.requests)
+
+	// The same URL should be served again from cache.
+	again := driver.URL(context.Background(), "01GVKXG0D34XGS2RPN8YXSA6B4/attachment/original/01GVKXG0P0B9FWAVN8Q29CCVW2.jpg")
+	suite.Equal(presigned.URL.String(), again.URL.String())
+}
+
+func (suite *S3TestSuite) TestURLNearExpiry() {
+	driver := suite.driver(false)
+	key := "01GVKXG0D34XGS2RPN8YXSA6B4/attachment/original/01GVKXG0P0B9FWAVN8Q29CCVW2.jpg"
+
+	// Cache a URL that's just about to expire.
+	stale := gtsstorage.PresignedURL{
+		URL:    &url.URL{Scheme: "http", Host: "example.org", Path: "/stale"},
+		Expiry: time.Now().Add(time.Minute),
+	}
+	driver.PresignedCache.Set(key, stale)
+
+	// A fresh one should be presigned instead.
+	presigned := driver.URL(context.Background(), key)
+	if !suite.NotNil(presigned) {
+		suite.FailNow("no presigned url")
+	}
+	suite.NotEqual(stale.URL.String(), presigned.URL.String())
+	suite.WithinDuration(time.Now().Add(time.Hour), presigned.Expiry, time.Minute)
+}
+
+func (suite *S3TestSuite) TestURLProxied() {
+	suite.Nil(suite.driver(true).URL(context.Background(), "some/key.jpg"))
+}
+
+func (suite *S3TestSuite) TestNewS3StorageBadConfig() {
+	config.SetStorageBackend("s3")
+	config.SetStorageS3Endpoint(strings.TrimPrefix(suite.server.URL, "http://"))
+	config.SetStorageS3BucketName("bucket")
+
+	config.SetStorageS3RedirectURLExpiry(8 * 24 * time.Hour)
+	_, err := gtsstorage.NewS3Storage()
+	suite.EqualError(err, "storage-s3-redirect-url-expiry must be between 1m0s and 168h0m0s, was 192h0m0s")
+
+	config.SetStorageS3RedirectURLExpiry(time.Hour)
+	config.SetStorageS3MultipartThreshold(bytesize.MiB)
+	_, err = gtsstorage.NewS3Storage()
+	suite.ErrorContains(err, "storage-s3-multipart-threshold must be at least")
+
+	// Nothing should have been asked of S3.
+	suite.Empty(suite.fake.requests[1:])
+}
+
+func TestS3TestSuite(t *testing.T) {
+	suite.Run(t, new(S3TestSuite))
+}
@@ -18,9 +18,11 @@
 package text
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -158,27 +160,36 @@ func (p *hashtagParser) Parse(parent ast.Node, block text.Reader, pc parser.Cont
 		return nil
 	}
 
+	// Underscores are allowed within a hashtag, as in #COVID_19,
+	// but trailing underscores are left out of it, so that they
+	// can still close emphasis, as in _#hashtag_.
+	stop := 0
+loop:
 	for i, r := range s {
 		switch {
 		case r == '#' && i == 0:
 			// ignore initial #
 			continue
-		case !util.IsPlausiblyInHashtag(r) && !util.IsMentionOrHashtagBoundary(r):
-			// Fake hashtag, don't trust it
-			return nil
+		case r == '_':
+			continue
+		case util.IsPlausiblyInHashtag(r):
+			stop = i + utf8.RuneLen(r)
 		case util.IsMentionOrHashtagBoundary(r):
-			if i <= 1 {
-				// empty
-				return nil
-			}
 			// End of hashtag
-			block.Advance(i)
-			return newHashtag(segment.WithStop(segment.Start + i))
+			break loop
+		default:
+			// Fake hashtag, don't trust it
+			return nil
 		}
 	}
-	// If we don't find invalid characters before the end of the line then it's all hashtag, babey
-	block.Advance(segment.Len())
-	return newHashtag(segment)
+
+	if stop <= 1 {
+		// empty
+		return nil
+	}
+
+	block.Advance(stop)
+	return newHashtag(segment.WithStop(segment.Start + stop))
 }
 
 func (p *emojiParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
@@ -214,6 +225,9 @@ func (r *customRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer)
 	reg.Register(kindMention, r.renderMention)
 	reg.Register(kindHashtag, r.renderHashtag)
 	reg.Register(kindEmoji, r.renderEmoji)
+	if !r.emojiOnly {
+		reg.Register(ast.KindAutoLink, r.renderAutoLink)
+	}
 }
 
 func (r *customRenderer) Extend(m goldmark.Markdown) {
@@ -227,8 +241,10 @@ func (r *customRenderer) Extend(m goldmark.Markdown) {
 			mdutil.Prioritized(&hashtagParser{}, 1000),
 		))
 	}
+	// 999 so that our autolink renderer takes
+	// precedence over the default html renderer
 	m.Renderer().AddOptions(renderer.WithNodeRenderers(
-		mdutil.Prioritized(r, 1000),
+		mdutil.Prioritized(r, 999),
 	))
 }
 
@@ -244,7 +260,10 @@ func (r *customRenderer) renderMention(w mdutil.BufWriter, source []byte, node a
 	}
 	text := string(n.Segment.Value(source))
 
-	html := r.replaceMention(text)
+	html, ok := r.replaceMention(text)
+	if !ok {
+		html = text
+	}
 
 	// we don't have much recourse if this fails
 	if _, err := w.WriteString(html); err != nil {
@@ -274,6 +293,52 @@ func (r *customRenderer) renderHashtag(w mdutil.BufWriter, source []byte, node a
 	return ast.WalkSkipChildren, nil
 }
 
+// renderAutoLink renders a bare URL as a mention if it's the URL of a profile,
+// such as https://example.org/@someone, and the account can be resolved.
+// Otherwise it's rendered as a regular link.
+func (r *customRenderer) renderAutoLink(w mdutil.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n, ok := node.(*ast.AutoLink) // this function is only registered for ast.KindAutoLink
+	if !ok {
+		log.Panic(r.ctx, "type assertion failed")
+	}
+	url := n.URL(source)
+
+	if n.AutoLinkType == ast.AutoLinkURL {
+		if matches := regexes.ProfileURL.FindSubmatch(url); matches != nil {
+			// Try the profile as mention @username@domain.
+			namestring := "@" + string(matches[3]) + "@" + string(matches[2])
+			if html, ok := r.replaceMention(namestring); ok {
+				if _, err := w.WriteString(html); err != nil {
+					log.Errorf(r.ctx, "error writing HTML: %s", err)
+				}
+				return ast.WalkSkipChildren, nil
+			}
+		}
+	}
+
+	// Not a mention, render
+	// it as a regular link.
+	var b strings.Builder
+	b.WriteString(`<a href="`)
+	if n.AutoLinkType == ast.AutoLinkEmail && !bytes.HasPrefix(bytes.ToLower(url), []byte("mailto:")) {
+		b.WriteString("mailto:")
+	}
+	b.Write(mdutil.EscapeHTML(mdutil.URLEscape(url, false)))
+	b.WriteString(`">`)
+	b.Write(mdutil.EscapeHTML(n.Label(source)))
+	b.WriteString(`</a>`)
+
+	// we don't have much recourse if this fails
+	if _, err := w.WriteString(b.String()); err != nil {
+		log.Errorf(r.ctx, "error writing HTML: %s", err)
+	}
+	return ast.WalkSkipChildren, nil
+}
+
 // renderEmoji doesn't turn an emoji into HTML, but adds it to the metadata.
 func (r *customRenderer) renderEmoji(w mdutil.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
 	if !entering {
@@ -292,7 +357,9 @@ func (r *customRenderer) renderEmoji(w mdutil.BufWriter, source []byte, node ast
 		if err != db.ErrNoEntries {
 			log.Errorf(nil, "error getting local emoji with shortcode %s: %s", shortcode, err)
 		}
-	} else if *emoji.VisibleInPicker && !*emoji.Disabled {
+	} else if *emoji.VisibleInPicker && !*emoji.Disabled &&
+		(emoji.AccountID == "" || emoji.AccountID == r.accountID) {
+		// Personal emojis can only be used by their owner.
 		listed := false
 		for _, e := range r.result.Emojis {
 			if e.Shortcode == emoji.Shortcode {
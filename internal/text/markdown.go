@@ -46,6 +46,11 @@ func (f *formatter) FromMarkdown(ctx context.Context, pmf gtsmodel.ParseMentionF
 			&customRenderer{f, ctx, pmf, authorID, statusID, false, result},
 			extension.Linkify, // turns URLs into links
 			extension.Strikethrough,
+			extension.Table,
+			// prefix footnote IDs with the status ID, so that
+			// footnotes from different statuses rendered on
+			// the same page don't link to one another
+			extension.NewFootnote(extension.WithFootnoteIDPrefix(footnoteIDPrefix(statusID))),
 		),
 	)
 
@@ -67,3 +72,12 @@ func (f *formatter) FromMarkdown(ctx context.Context, pmf gtsmodel.ParseMentionF
 
 	return result
 }
+
+// footnoteIDPrefix returns the prefix to use for
+// the IDs of footnotes rendered in the given status.
+func footnoteIDPrefix(statusID string) []byte {
+	if statusID == "" {
+		return nil
+	}
+	return []byte(statusID + "-")
+}
@@ -59,7 +59,11 @@ const (
 	mdCodeBlockWithNewlines         = "some code coming up\n\n```\n\n\n\n```\nthat was some code"
 	mdCodeBlockWithNewlinesExpected = "<p>some code coming up</p><pre><code>\n\n\n</code></pre><p>that was some code</p>"
 	mdWithFootnote                  = "fox mulder,fbi.[^1]\n\n[^1]: federated bureau of investigation"
-	mdWithFootnoteExpected          = "<p>fox mulder,fbi.[^1]</p><p>[^1]: federated bureau of investigation</p>"
+	mdWithFootnoteExpected          = "<p>fox mulder,fbi.<sup id=\"status_ID-fnref:1\"><a href=\"#status_ID-fn:1\" class=\"footnote-ref\" rel=\"noreferrer\">1</a></sup></p><div><hr><ol><li id=\"status_ID-fn:1\"><p>federated bureau of investigation\u00a0<a href=\"#status_ID-fnref:1\" class=\"footnote-backref\" rel=\"noreferrer\">↩︎</a></p></li></ol></div>"
+	mdWithTable                     = "| left | right |\n|:--|--:|\n| 1 | 2 |"
+	mdWithTableExpected             = "<table><thead><tr><th align=\"left\">left</th><th align=\"right\">right</th></tr></thead><tbody><tr><td align=\"left\">1</td><td align=\"right\">2</td></tr></tbody></table>"
+	mdWithCodeBlockLanguage         = "```c++\nstd::cout << \"hi\";\n```"
+	mdWithCodeBlockLanguageExpected = "<pre><code class=\"language-c++\">std::cout &lt;&lt; &#34;hi&#34;;\n</code></pre>"
 	mdWithBlockQuote                = "get ready, there's a block quote coming:\n\n>line1\n>line2\n>\n>line3\n\n"
 	mdWithBlockQuoteExpected        = "<p>get ready, there's a block quote coming:</p><blockquote><p>line1<br>line2</p><p>line3</p></blockquote>"
 	mdHashtagAndCodeBlock           = "#Hashtag\n\n```\n#Hashtag\n```"
@@ -140,6 +144,16 @@ func (suite *MarkdownTestSuite) TestParseWithFootnote() {
 	suite.Equal(mdWithFootnoteExpected, formatted.HTML)
 }
 
+func (suite *MarkdownTestSuite) TestParseWithTable() {
+	formatted := suite.FromMarkdown(mdWithTable)
+	suite.Equal(mdWithTableExpected, formatted.HTML)
+}
+
+func (suite *MarkdownTestSuite) TestParseWithCodeBlockLanguage() {
+	formatted := suite.FromMarkdown(mdWithCodeBlockLanguage)
+	suite.Equal(mdWithCodeBlockLanguageExpected, formatted.HTML)
+}
+
 func (suite *MarkdownTestSuite) TestParseWithBlockquote() {
 	formatted := suite.FromMarkdown(mdWithBlockQuote)
 	suite.Equal(mdWithBlockQuoteExpected, formatted.HTML)
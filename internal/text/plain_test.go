@@ -18,10 +18,14 @@
 package text_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 const (
@@ -82,6 +86,59 @@ that link shouldn't come out formatted as a mention!`
 	suite.Empty(menchies)
 }
 
+func (suite *PlainTestSuite) TestDeriveMentionsPunctuation() {
+	for _, test := range []struct {
+		statusText string
+		namestring string
+	}{
+		{"(@foss_satan@fossbros-anonymous.io)", "@foss_satan@fossbros-anonymous.io"},
+		{"hey @foss_satan@fossbros-anonymous.io.", "@foss_satan@fossbros-anonymous.io"},
+		{"hey @foss_satan@fossbros-anonymous.io, what's up?", "@foss_satan@fossbros-anonymous.io"},
+		{"hey @foss_satan@fossbros-anonymous.io: what's up?", "@foss_satan@fossbros-anonymous.io"},
+		{"hey @foss_satan@fossbros-anonymous.io!", "@foss_satan@fossbros-anonymous.io"},
+		{"[@the_mighty_zork]", "@the_mighty_zork"},
+		{"hey @the_mighty_zork.", "@the_mighty_zork"},
+		{"hey @the_mighty_zork...", "@the_mighty_zork"},
+		{"hey @the_mighty_zork?!", "@the_mighty_zork"},
+		{"\"@the_mighty_zork\"", "@the_mighty_zork"},
+	} {
+		menchies := suite.FromPlain(test.statusText).Mentions
+		if suite.Len(menchies, 1, test.statusText) {
+			suite.Equal(test.namestring, menchies[0].NameString, test.statusText)
+		}
+	}
+}
+
+func (suite *PlainTestSuite) TestDeriveMentionsProfileURL() {
+	for _, test := range []struct {
+		statusText string
+		namestring string
+	}{
+		{"http://fossbros-anonymous.io/@foss_satan", "@foss_satan@fossbros-anonymous.io"},
+		{"(http://fossbros-anonymous.io/@foss_satan)", "@foss_satan@fossbros-anonymous.io"},
+		{"hey http://localhost:8080/@the_mighty_zork.", "@the_mighty_zork@localhost:8080"},
+		{"hey http://localhost:8080/@the_mighty_zork/", "@the_mighty_zork@localhost:8080"},
+	} {
+		menchies := suite.FromPlain(test.statusText).Mentions
+		if suite.Len(menchies, 1, test.statusText) {
+			suite.Equal(test.namestring, menchies[0].NameString, test.statusText)
+		}
+	}
+}
+
+func (suite *PlainTestSuite) TestParseProfileURLMention() {
+	formatted := suite.FromPlain("hey http://fossbros-anonymous.io/@foss_satan")
+	suite.Equal("<p>hey <span class=\"h-card\"><a href=\"http://fossbros-anonymous.io/@foss_satan\" class=\"u-url mention\" rel=\"nofollow noreferrer noopener\" target=\"_blank\">@<span>foss_satan</span></a></span></p>", formatted.HTML)
+}
+
+func (suite *PlainTestSuite) TestParseProfileURLNoAccount() {
+	// No such account, so the
+	// URL stays a regular link.
+	formatted := suite.FromPlain("hey http://localhost:8080/@nobody")
+	suite.Equal("<p>hey <a href=\"http://localhost:8080/@nobody\" rel=\"nofollow noreferrer noopener\" target=\"_blank\">http://localhost:8080/@nobody</a></p>", formatted.HTML)
+	suite.Empty(formatted.Mentions)
+}
+
 func (suite *PlainTestSuite) TestDeriveMentionsEmpty() {
 	statusText := ``
 	menchies := suite.FromPlain(statusText).Mentions
@@ -98,7 +155,7 @@ func (suite *PlainTestSuite) TestDeriveHashtagsOK() {
 	here's a link with a fragment: https://example.org/whatever#ahhh
 	here's another link with a fragment: https://example.org/whatever/#ahhh
 
-(#ThisShouldAlsoWork) #this_should_be_split
+(#ThisShouldAlsoWork) #this_should_not_be_split
 
 #111111 thisalsoshouldn'twork#### ##
 
@@ -108,26 +165,51 @@ func (suite *PlainTestSuite) TestDeriveHashtagsOK() {
 `
 
 	tags := suite.FromPlain(statusText).Tags
-	assert.Len(suite.T(), tags, 13)
+	assert.Len(suite.T(), tags, 12)
 	assert.Equal(suite.T(), "testing123", tags[0].Name)
 	assert.Equal(suite.T(), "also", tags[1].Name)
 	assert.Equal(suite.T(), "thisshouldwork", tags[2].Name)
 	assert.Equal(suite.T(), "dupe", tags[3].Name)
-	assert.Equal(suite.T(), "ThisShouldAlsoWork", tags[4].Name)
-	assert.Equal(suite.T(), "this", tags[5].Name)
-	assert.Equal(suite.T(), "111111", tags[6].Name)
-	assert.Equal(suite.T(), "alimentación", tags[7].Name)
-	assert.Equal(suite.T(), "saúde", tags[8].Name)
-	assert.Equal(suite.T(), "lävistää", tags[9].Name)
-	assert.Equal(suite.T(), "ö", tags[10].Name)
-	assert.Equal(suite.T(), "네", tags[11].Name)
-	assert.Equal(suite.T(), "ThisOneIsThirteyCharactersLong", tags[12].Name)
+	assert.Equal(suite.T(), "thisshouldalsowork", tags[4].Name)
+	assert.Equal(suite.T(), "this_should_not_be_split", tags[5].Name)
+	assert.Equal(suite.T(), "alimentación", tags[6].Name)
+	assert.Equal(suite.T(), "saúde", tags[7].Name)
+	assert.Equal(suite.T(), "lävistää", tags[8].Name)
+	assert.Equal(suite.T(), "ö", tags[9].Name)
+	assert.Equal(suite.T(), "네", tags[10].Name)
+	assert.Equal(suite.T(), "thisoneisthirteycharacterslong", tags[11].Name)
 
 	statusText = `#올빼미 hej`
 	tags = suite.FromPlain(statusText).Tags
 	assert.Equal(suite.T(), "올빼미", tags[0].Name)
 }
 
+func (suite *PlainTestSuite) TestDeriveHashtagsDisplayName() {
+	statusText := `#COVID_19 #2024Election #trailing__ #_leading #1234 #__`
+
+	f := suite.FromPlain(statusText)
+	suite.Equal(`<p><a href="http://localhost:8080/tags/covid_19" class="mention hashtag" rel="tag nofollow noreferrer noopener" target="_blank">#<span>COVID_19</span></a> <a href="http://localhost:8080/tags/2024election" class="mention hashtag" rel="tag nofollow noreferrer noopener" target="_blank">#<span>2024Election</span></a> <a href="http://localhost:8080/tags/trailing" class="mention hashtag" rel="tag nofollow noreferrer noopener" target="_blank">#<span>trailing</span></a>__ <a href="http://localhost:8080/tags/_leading" class="mention hashtag" rel="tag nofollow noreferrer noopener" target="_blank">#<span>_leading</span></a> #1234 #__</p>`, f.HTML)
+
+	tags := f.Tags
+	if suite.Len(tags, 4) {
+		suite.Equal("covid_19", tags[0].Name)
+		suite.Equal("COVID_19", tags[0].DisplayName)
+		suite.Equal("2024election", tags[1].Name)
+		suite.Equal("2024Election", tags[1].DisplayName)
+		suite.Equal("trailing", tags[2].Name)
+		suite.Equal("_leading", tags[3].Name)
+	}
+
+	// Existing tags are matched case-insensitively,
+	// but rendered as entered in this status.
+	f = suite.FromPlain(`#covid_19`)
+	suite.Equal(`<p><a href="http://localhost:8080/tags/covid_19" class="mention hashtag" rel="tag nofollow noreferrer noopener" target="_blank">#<span>covid_19</span></a></p>`, f.HTML)
+	if suite.Len(f.Tags, 1) {
+		suite.Equal(tags[0].ID, f.Tags[0].ID)
+		suite.Equal("COVID_19", f.Tags[0].DisplayName)
+	}
+}
+
 func (suite *PlainTestSuite) TestDeriveMultiple() {
 	statusText := `Another test @foss_satan@fossbros-anonymous.io
 
@@ -146,6 +228,33 @@ func (suite *PlainTestSuite) TestDeriveMultiple() {
 	assert.Len(suite.T(), f.Emojis, 0)
 }
 
+func (suite *PlainTestSuite) TestDerivePersonalEmojis() {
+	// Put a personal emoji for both the
+	// formatting account and another account.
+	for shortcode, account := range map[string]*gtsmodel.Account{
+		"the_mighty_zork_cat": suite.testAccounts["local_account_1"],
+		"1happyturtle_cat":    suite.testAccounts["local_account_2"],
+	} {
+		emoji := new(gtsmodel.Emoji)
+		*emoji = *testrig.NewTestEmojis()["rainbow"]
+		emoji.ID = id.NewULID()
+		emoji.Shortcode = shortcode
+		emoji.URI = "http://localhost:8080/emoji/" + emoji.ID
+		emoji.AccountID = account.ID
+		if err := suite.db.PutEmoji(context.Background(), emoji); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	f := suite.FromPlain("my cat :the_mighty_zork_cat: and someone else's :1happyturtle_cat:")
+
+	// Only the formatting account's
+	// own personal emoji can be used.
+	if assert.Len(suite.T(), f.Emojis, 1) {
+		assert.Equal(suite.T(), "the_mighty_zork_cat", f.Emojis[0].Shortcode)
+	}
+}
+
 func (suite *PlainTestSuite) TestZalgoHashtag() {
 	statusText := `yo who else loves #praying to #z̸͉̅a̸͚͋l̵͈̊g̸̫͌ỏ̷̪?`
 	f := suite.FromPlain(statusText)
@@ -0,0 +1,95 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package text
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ImageSources returns the src of each <img> tag in `inputText`.
+func ImageSources(inputText string) []string {
+	var srcs []string
+	ProxyImages(inputText, func(src string) string {
+		srcs = append(srcs, src)
+		return src
+	})
+	return srcs
+}
+
+// ProxyImages replaces the src of each <img> tag in `inputText`
+// with the result of calling proxy on it. Other html is unchanged.
+//
+// Callers should ensure that inputText has already been sanitized,
+// as it is assumed that any <img> tags are safe to include as-is.
+func ProxyImages(inputText string, proxy func(src string) string) string {
+	if !strings.Contains(inputText, "<img") {
+		// Nothing to do.
+		return inputText
+	}
+
+	var (
+		buf bytes.Buffer
+		z   = html.NewTokenizer(strings.NewReader(inputText))
+	)
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if z.Err() != io.EOF {
+				// Not valid html, leave as-is.
+				return inputText
+			}
+			return buf.String()
+		}
+
+		// Copy raw bytes before reading
+		// the tag, which may change them.
+		raw := append([]byte(nil), z.Raw()...)
+
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			buf.Write(raw)
+			continue
+		}
+
+		name, hasAttr := z.TagName()
+		if string(name) != "img" {
+			buf.Write(raw)
+			continue
+		}
+
+		// Rebuild the img tag with proxied src.
+		token := html.Token{Type: tt, Data: "img"}
+		for hasAttr {
+			var key, val []byte
+			key, val, hasAttr = z.TagAttr()
+
+			attr := html.Attribute{Key: string(key), Val: string(val)}
+			if attr.Key == "src" {
+				attr.Val = proxy(attr.Val)
+			}
+
+			token.Attr = append(token.Attr, attr)
+		}
+
+		buf.WriteString(token.String())
+	}
+}
@@ -0,0 +1,49 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package text_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+type ProxyImagesTestSuite struct {
+	suite.Suite
+}
+
+func proxy(src string) string {
+	return "https://example.org/proxy/" + src
+}
+
+func (suite *ProxyImagesTestSuite) TestProxyImages() {
+	in := `<p>look at this <a href="https://cats.example.com/" rel="nofollow">cat</a>: <img src="cat.png" alt="a &#34;cat&#34;"/><br><img alt="another" src="cat2.png"></p>`
+	out := text.ProxyImages(in, proxy)
+	suite.Equal(`<p>look at this <a href="https://cats.example.com/" rel="nofollow">cat</a>: <img src="https://example.org/proxy/cat.png" alt="a &#34;cat&#34;"/><br><img alt="another" src="https://example.org/proxy/cat2.png"></p>`, out)
+}
+
+func (suite *ProxyImagesTestSuite) TestProxyImagesNoImages() {
+	in := `<p>no images <em>here</em> &amp; that's fine</p>`
+	out := text.ProxyImages(in, proxy)
+	suite.Equal(in, out)
+}
+
+func TestProxyImagesTestSuite(t *testing.T) {
+	suite.Run(t, new(ProxyImagesTestSuite))
+}
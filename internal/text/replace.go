@@ -36,18 +36,19 @@ const (
 // add it to the database, and render it as HTML. If any of these steps fails, the method
 // will just return the original string and log an error.
 
-// replaceMention takes a string in the form @username@domain.com or @localusername
-func (r *customRenderer) replaceMention(text string) string {
+// replaceMention takes a string in the form @username@domain.com or @localusername.
+// Unlike replaceHashtag, it also reports whether the mention could be rendered.
+func (r *customRenderer) replaceMention(text string) (string, bool) {
 	mention, err := r.parseMention(r.ctx, text, r.accountID, r.statusID)
 	if err != nil {
 		log.Errorf(r.ctx, "error parsing mention %s from status: %s", text, err)
-		return text
+		return text, false
 	}
 
 	if r.statusID != "" {
 		if err := r.f.db.PutMention(r.ctx, mention); err != nil {
 			log.Errorf(r.ctx, "error putting mention in db: %s", err)
-			return text
+			return text, false
 		}
 	}
 
@@ -71,7 +72,7 @@ func (r *customRenderer) replaceMention(text string) string {
 		)
 		if err != nil {
 			log.Errorf(r.ctx, "error populating mention target account: %v", err)
-			return text
+			return text, false
 		}
 	}
 
@@ -87,7 +88,7 @@ func (r *customRenderer) replaceMention(text string) string {
 	b.WriteString(`" class="u-url mention">@<span>`)
 	b.WriteString(targetAccount.Username)
 	b.WriteString(`</span></a></span>`)
-	return b.String()
+	return b.String(), true
 }
 
 // replaceMention takes a string in the form #HashedTag, and will normalize it before
@@ -100,10 +101,8 @@ func (r *customRenderer) replaceHashtag(text string) string {
 	// symbols.
 	normalized := norm.NFC.String(text[1:])
 
-	for i, r := range normalized {
-		if i >= maximumHashtagLength || !util.IsPermittedInHashtag(r) {
-			return text
-		}
+	if len([]rune(normalized)) > maximumHashtagLength || !util.IsValidHashtag(normalized) {
+		return text
 	}
 
 	tag, err := r.f.db.TagStringToTag(r.ctx, normalized, r.accountID)
@@ -103,6 +103,8 @@ func (suite *GetTestSuite) emptyAccountStatuses(ctx context.Context, accountID s
 		id.Lowest,
 		false,
 		false,
+		false,
+		false,
 	)
 	if err != nil {
 		suite.FailNow(err.Error())
@@ -58,13 +58,19 @@ type controller struct {
 // NewController returns an implementation of the Controller interface for creating new transports
 func NewController(state *state.State, federatingDB federatingdb.DB, clock pub.Clock, client httpclient.SigningClient) Controller {
 	var (
-		applicationName  = config.GetApplicationName()
 		host             = config.GetHost()
 		proto            = config.GetProtocol()
 		version          = config.GetSoftwareVersion()
+		userAgent        = config.GetFederationUserAgent()
 		senderMultiplier = config.GetAdvancedSenderMultiplier()
 	)
 
+	if userAgent == "" {
+		// Identify the software and the instance, but never
+		// the account on whose behalf a request is made.
+		userAgent = fmt.Sprintf("gotosocial/%s (+%s://%s)", version, proto, host)
+	}
+
 	senders := senderMultiplier * runtime.GOMAXPROCS(0)
 	if senders < 1 {
 		// Clamp senders to 1.
@@ -77,7 +83,7 @@ func NewController(state *state.State, federatingDB federatingdb.DB, clock pub.C
 		clock:     clock,
 		client:    client,
 		trspCache: cache.New[string, *transport](0, 100, 0),
-		userAgent: fmt.Sprintf("%s (+%s://%s) gotosocial/%s", applicationName, proto, host, version),
+		userAgent: userAgent,
 		senders:   senders,
 	}
 
@@ -0,0 +1,53 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+func (t *transport) DereferencePage(ctx context.Context, iri *url.URL, accept string) (io.ReadCloser, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, iri.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req.Header.Add("Accept", accept)
+	req.Header.Set("Host", iri.Host)
+	req.Header.Set("User-Agent", t.controller.userAgent)
+
+	// Don't sign: this is an ordinary web page,
+	// not something that knows about signatures.
+	rsp, err := t.controller.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if rsp.StatusCode != http.StatusOK {
+		err := gtserror.NewFromResponse(rsp)
+		_ = rsp.Body.Close()
+		return nil, nil, err
+	}
+
+	return rsp.Body, rsp.Header, nil
+}
@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+)
+
+func (t *transport) Probe(ctx context.Context, method string, iri *url.URL, signed bool) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, iri.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Add("Accept", string(apiutil.AppActivityLDJSON)+","+string(apiutil.AppActivityJSON))
+	req.Header.Add("Accept-Charset", "utf-8")
+	req.Header.Set("Host", iri.Host)
+	req.Header.Set("User-Agent", t.controller.userAgent)
+
+	var sign httpclient.SignFunc
+	if signed {
+		// Sign as for a GET, which covers only
+		// the request target, host and date.
+		req = req.WithContext(gtscontext.SetOutgoingPublicKeyID(ctx, t.pubKeyID))
+		sign = t.signGET()
+	} else {
+		sign = func(*http.Request) error { return nil }
+	}
+
+	rsp, err := t.controller.client.DoSigned(req, sign)
+	if err != nil {
+		return 0, err
+	}
+	defer rsp.Body.Close()
+
+	// Drain body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, rsp.Body)
+
+	return rsp.StatusCode, nil
+}
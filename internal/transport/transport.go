@@ -62,11 +62,23 @@ type Transport interface {
 	// DereferenceMedia fetches the given media attachment IRI, returning the reader and filesize.
 	DereferenceMedia(ctx context.Context, iri *url.URL) (io.ReadCloser, int64, error)
 
+	// DereferencePage fetches the given web page (or other non-ActivityStreams document) IRI
+	// with an unsigned GET request, returning the body reader and response headers.
+	DereferencePage(ctx context.Context, iri *url.URL, accept string) (io.ReadCloser, http.Header, error)
+
 	// DereferenceInstance dereferences remote instance information, first by checking /api/v1/instance, and then by checking /.well-known/nodeinfo.
 	DereferenceInstance(ctx context.Context, iri *url.URL) (*gtsmodel.Instance, error)
 
 	// Finger performs a webfinger request with the given username and domain, and returns the bytes from the response body.
 	Finger(ctx context.Context, targetUsername string, targetDomain string) ([]byte, error)
+
+	/*
+		Diagnostic functions
+	*/
+
+	// Probe performs a request with the given method (eg., HEAD) against the given IRI, and returns the
+	// response status code, discarding the body. If signed is false, the request will not be signed.
+	Probe(ctx context.Context, method string, iri *url.URL, signed bool) (int, error)
 }
 
 // transport implements the Transport interface.
@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type UserAgentTestSuite struct {
+	TransportTestSuite
+}
+
+// dereferenceUserAgent dereferences a page using a
+// fresh transport controller, returning the User-Agent
+// header sent with the request.
+func (suite *UserAgentTestSuite) dereferenceUserAgent() string {
+	var userAgent string
+	client := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		userAgent = req.Header.Get("User-Agent")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("<html></html>")),
+		}, nil
+	}, "")
+
+	tc := testrig.NewTestTransportController(&suite.state, client)
+	ts, err := tc.NewTransportForUsername(context.Background(), "the_mighty_zork")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	iri, _ := url.Parse("https://example.org/some/page")
+	rc, _, err := ts.DereferencePage(context.Background(), iri, "text/html")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	rc.Close()
+
+	return userAgent
+}
+
+func (suite *UserAgentTestSuite) TestDefaultUserAgent() {
+	userAgent := suite.dereferenceUserAgent()
+	suite.Equal("gotosocial/"+config.GetSoftwareVersion()+" (+http://localhost:8080)", userAgent)
+
+	// Never reveal the account making the request.
+	suite.NotContains(userAgent, "the_mighty_zork")
+}
+
+func (suite *UserAgentTestSuite) TestConfiguredUserAgent() {
+	config.SetFederationUserAgent("my instance's crawler")
+	defer config.SetFederationUserAgent("")
+
+	suite.Equal("my instance's crawler", suite.dereferenceUserAgent())
+}
+
+func TestUserAgentTestSuite(t *testing.T) {
+	suite.Run(t, &UserAgentTestSuite{})
+}
@@ -22,8 +22,10 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/miekg/dns"
+	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -31,6 +33,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 func (c *converter) ASRepresentationToAccount(ctx context.Context, accountable ap.Accountable, accountDomain string) (*gtsmodel.Account, error) {
@@ -143,6 +146,10 @@ func (c *converter) ASRepresentationToAccount(ctx context.Context, accountable a
 	enableRSS := false
 	acct.EnableRSS = &enableRSS
 
+	// link previews are up to the remote instance
+	hideLinkPreviews := false
+	acct.HideLinkPreviews = &hideLinkPreviews
+
 	// url property
 	url, err := ap.ExtractURL(accountable)
 	if err == nil {
@@ -192,7 +199,14 @@ func (c *converter) ASRepresentationToAccount(ctx context.Context, accountable a
 		}
 	}
 
-	// TODO: FeaturedTagsURI
+	// FeaturedTagsURI:
+	// Only trust featured tags URI if it has at least two
+	// domains, from the right, in common with the domain of
+	// the account, as with the featured URI above.
+	if featuredTagsURI := ap.ExtractFeaturedTagsURI(accountable); // nocollapse
+	featuredTagsURI != nil && dns.CompareDomainName(acct.Domain, featuredTagsURI.Host) >= 2 {
+		acct.FeaturedTagsURI = featuredTagsURI.String()
+	}
 
 	// TODO: alsoKnownAs
 
@@ -273,6 +287,39 @@ func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 	// The (html-formatted) content of this status.
 	status.Content = ap.ExtractContent(statusable)
 
+	// status.Language, status.ContentMap
+	//
+	// Content may also be given per language in a
+	// contentMap, possibly without plain content.
+	if contentMap := ap.ExtractContentMap(statusable); len(contentMap) != 0 {
+		status.Language = contentLanguage(contentMap, status.Content)
+		if status.Language == "" {
+			// No plain content (or it's not one of
+			// the variants), so use one from the map.
+			status.Language = util.PickLanguage(contentMap)
+			status.Content = contentMap[status.Language]
+		}
+
+		if len(contentMap) > 1 {
+			// Only worth keeping
+			// if there's a choice.
+			status.ContentMap = contentMap
+		}
+	}
+
+	// Articles (eg., blog posts) and Pages (eg., link
+	// aggregator posts) have a title in their name, and
+	// potentially very long content; tidy both up.
+	typeName := statusable.GetTypeName()
+	isArticle := typeName == ap.ObjectArticle || typeName == ap.ObjectPage
+	if isArticle {
+		name := ap.ExtractName(statusable)
+		status.Content = articleContent(name, status.Content, status.URL)
+		for lang, content := range status.ContentMap {
+			status.ContentMap[lang] = articleContent(name, content, status.URL)
+		}
+	}
+
 	// status.Attachments
 	//
 	// Media attachments for later dereferencing.
@@ -305,13 +352,51 @@ func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		status.Mentions = mentions
 	}
 
+	// status.Event___
+	//
+	// Details of the event described by this
+	// status, if the status is an event.
+	isEvent := typeName == ap.ObjectEvent
+	if eventable, ok := statusable.(ap.Eventable); ok && isEvent {
+		status.EventName = ap.ExtractName(eventable)
+		status.EventStartAt = ap.ExtractStartTime(eventable)
+		status.EventEndAt = ap.ExtractEndTime(eventable)
+		status.EventLocation = ap.ExtractLocation(eventable)
+	}
+
+	// status.Poll
+	//
+	// Poll attached to this status, if it's a Question;
+	// it's given an ID and stored along with the status.
+	if pollable, ok := statusable.(ap.Pollable); ok {
+		poll, err := ap.ExtractPoll(pollable)
+		if err != nil {
+			l.Warnf("rejecting status with invalid poll: %v", err)
+			return nil, gtserror.Newf("invalid poll on status %s: %w", status.URI, err)
+		}
+		status.Poll = poll
+	}
+
+	// status.Location___
+	//
+	// Place this status is about, if any. Coordinates
+	// are only kept with limited precision.
+	if withLocation, ok := statusable.(ap.WithLocation); ok {
+		name, latitude, longitude := ap.ExtractPlace(withLocation)
+		status.LocationName = name
+		status.LocationLatitude = util.RoundCoordinate(latitude)
+		status.LocationLongitude = util.RoundCoordinate(longitude)
+	}
+
 	// status.ContentWarning
 	//
 	// Topic or content warning for this status;
-	// prefer Summary, fall back to Name.
+	// prefer Summary, fall back to Name. For events
+	// and articles, Name is the event name or article
+	// title, not a content warning.
 	if summary := ap.ExtractSummary(statusable); summary != "" {
 		status.ContentWarning = summary
-	} else {
+	} else if !isEvent && !isArticle {
 		status.ContentWarning = ap.ExtractName(statusable)
 	}
 
@@ -377,9 +462,31 @@ func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 			status.InReplyTo = inReplyTo
 			status.InReplyToAccountID = inReplyTo.AccountID
 			status.InReplyToAccount = inReplyTo.Account
+			status.ThreadDepth = inReplyTo.ThreadDepth + 1
 		}
 	}
 
+	// status.ThreadTruncated
+	//
+	// Replies from remote accounts deeper than the
+	// configured max thread depth are either rejected
+	// outright, or detached from the thread they're in.
+	threadTruncated := false
+	if maxDepth := config.GetStatusesMaxThreadDepth(); maxDepth > 0 &&
+		status.ThreadDepth > maxDepth && account.IsRemote() {
+		if config.GetStatusesThreadDepthPolicy() == "reject" {
+			return nil, gtserror.Newf("%w: depth %d of status %s", ErrThreadTooDeep, status.ThreadDepth, status.URI)
+		}
+
+		status.InReplyToURI = ""
+		status.InReplyToID = ""
+		status.InReplyTo = nil
+		status.InReplyToAccountID = ""
+		status.InReplyToAccount = nil
+		threadTruncated = true
+	}
+	status.ThreadTruncated = &threadTruncated
+
 	// status.Visibility
 	visibility, err := ap.ExtractVisibility(
 		statusable,
@@ -408,8 +515,9 @@ func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		return &s
 	}()
 
-	// language
-	// TODO: we might be able to extract this from the contentMap field
+	// Interaction counts as reported by the origin server.
+	status.FaveCountRemote = ap.ExtractLikesCount(statusable)
+	status.BoostCountRemote = ap.ExtractSharesCount(statusable)
 
 	// ActivityStreamsType
 	status.ActivityStreamsType = statusable.GetTypeName()
@@ -533,6 +641,51 @@ func (c *converter) ASLikeToFave(ctx context.Context, likeable ap.Likeable) (*gt
 	}, nil
 }
 
+func (c *converter) ASDislikeToStatusDislike(ctx context.Context, dislikeable ap.Dislikeable) (*gtsmodel.StatusDislike, error) {
+	idProp := dislikeable.GetJSONLDId()
+	if idProp == nil || !idProp.IsIRI() {
+		return nil, errors.New("no id property set on dislike, or was not an iri")
+	}
+	uri := idProp.GetIRI().String()
+
+	origin, err := ap.ExtractActorURI(dislikeable)
+	if err != nil {
+		return nil, errors.New("error extracting actor property from dislike")
+	}
+	originAccount, err := c.db.GetAccountByURI(ctx, origin.String())
+	if err != nil {
+		return nil, fmt.Errorf("error extracting account with uri %s from the database: %w", origin.String(), err)
+	}
+
+	target, err := ap.ExtractObjectURI(dislikeable)
+	if err != nil {
+		return nil, errors.New("error extracting object property from dislike")
+	}
+
+	targetStatus, err := c.db.GetStatusByURI(ctx, target.String())
+	if err != nil {
+		return nil, fmt.Errorf("error extracting status with uri %s from the database: %w", target.String(), err)
+	}
+
+	targetAccount := targetStatus.Account
+	if targetAccount == nil {
+		targetAccount, err = c.db.GetAccountByID(ctx, targetStatus.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("error extracting account with id %s from the database: %w", targetStatus.AccountID, err)
+		}
+	}
+
+	return &gtsmodel.StatusDislike{
+		AccountID:       originAccount.ID,
+		Account:         originAccount,
+		TargetAccountID: targetAccount.ID,
+		TargetAccount:   targetAccount,
+		StatusID:        targetStatus.ID,
+		Status:          targetStatus,
+		URI:             uri,
+	}, nil
+}
+
 func (c *converter) ASBlockToBlock(ctx context.Context, blockable ap.Blockable) (*gtsmodel.Block, error) {
 	idProp := blockable.GetJSONLDId()
 	if idProp == nil || !idProp.IsIRI() {
@@ -568,6 +721,79 @@ func (c *converter) ASBlockToBlock(ctx context.Context, blockable ap.Blockable)
 	}, nil
 }
 
+func (c *converter) ASListenToListen(ctx context.Context, listen vocab.ActivityStreamsListen) (*gtsmodel.Listen, error) {
+	idProp := listen.GetJSONLDId()
+	if idProp == nil || !idProp.IsIRI() {
+		return nil, errors.New("ASListenToListen: no id property set on listen, or was not an iri")
+	}
+	uri := idProp.GetIRI().String()
+
+	origin, err := ap.ExtractActorURI(listen)
+	if err != nil {
+		return nil, errors.New("ASListenToListen: error extracting actor property from listen")
+	}
+	originAccount, err := c.db.GetAccountByURI(ctx, origin.String())
+	if err != nil {
+		return nil, fmt.Errorf("error extracting account with uri %s from the database: %s", origin.String(), err)
+	}
+
+	// Find the Audio object being listened to; other
+	// types of object don't make sense for scrobbling.
+	var audio vocab.ActivityStreamsAudio
+	if objectProp := listen.GetActivityStreamsObject(); objectProp != nil {
+		for iter := objectProp.Begin(); iter != objectProp.End(); iter = iter.Next() {
+			if iter.IsActivityStreamsAudio() {
+				audio = iter.GetActivityStreamsAudio()
+				break
+			}
+		}
+	}
+	if audio == nil {
+		return nil, errors.New("ASListenToListen: no Audio object set on listen")
+	}
+
+	trackName := ap.ExtractName(audio)
+	if trackName == "" {
+		return nil, errors.New("ASListenToListen: no name set on Audio object")
+	}
+
+	// Listened-to time is optional, fall back to now.
+	createdAt, err := ap.ExtractPublished(listen)
+	if err != nil {
+		createdAt = time.Now()
+	}
+
+	// Artist and album aren't part of the AS vocabulary,
+	// so have a look for them in the unknown properties.
+	unknown := audio.GetUnknownProperties()
+
+	return &gtsmodel.Listen{
+		CreatedAt:  createdAt,
+		UpdatedAt:  createdAt,
+		URI:        uri,
+		AccountID:  originAccount.ID,
+		Account:    originAccount,
+		TrackName:  trackName,
+		ArtistName: extractAudioMeta(unknown, "artist"),
+		AlbumName:  extractAudioMeta(unknown, "album"),
+	}, nil
+}
+
+// extractAudioMeta extracts the given key from the unknown properties
+// of an Audio object. Implementations either give the value as a plain
+// string, or as an object with a name, so account for both.
+func extractAudioMeta(unknown map[string]interface{}, key string) string {
+	switch v := unknown[key].(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		name, _ := v["name"].(string)
+		return name
+	default:
+		return ""
+	}
+}
+
 // Implementation note: this function creates and returns a boost WRAPPER
 // status which references the boosted status in its BoostOf field. No
 // dereferencing is done on the boosted status by this function. Callers
@@ -702,25 +928,21 @@ func (c *converter) ASFlagToReport(ctx context.Context, flaggable ap.Flaggable)
 	// In Misskey's case, it may also contain the URLs of
 	// one or more reported statuses, so extract these too.
 	content := ap.ExtractContent(flaggable)
-	statusURIs := []*url.URL{}
-	inlineURLs := misskeyReportInlineURLs(content)
-	statusURIs = append(statusURIs, inlineURLs...)
+	statusURIs := misskeyReportInlineURLs(content)
 
 	// Extract account and statuses targeted by the flag / report.
 	//
 	// Incoming flags from mastodon usually have a target account uri as
 	// first entry in objects, followed by URIs of one or more statuses.
-	// Misskey on the other hand will just contain the target account uri.
+	// Misskey on the other hand will just contain the target account uri,
+	// or sometimes only statuses. Akkoma may embed the statuses as objects.
 	// We shouldn't assume the order of the objects will correspond to this,
-	// but we can check that he objects slice contains just one account, and
-	// maybe some statuses.
+	// but we can check that he objects slice contains at most one account,
+	// and maybe some statuses.
 	//
 	// Throw away anything that's not relevant to us.
-	objects, err := ap.ExtractObjectURIs(flaggable)
-	if err != nil {
-		return nil, fmt.Errorf("ASFlagToReport: error extracting objects: %w", err)
-	}
-	if len(objects) == 0 {
+	objects, _ := ap.ExtractObjectURIs(flaggable)
+	if len(objects) == 0 && len(statusURIs) == 0 {
 		return nil, errors.New("ASFlagToReport: flaggable objects empty, can't create report")
 	}
 
@@ -731,7 +953,7 @@ func (c *converter) ASFlagToReport(ctx context.Context, flaggable ap.Flaggable)
 			// object doesn't belong to us, just ignore it
 			continue
 		case uris.IsUserPath(object):
-			if targetAccountURI != nil {
+			if targetAccountURI != nil && targetAccountURI.String() != object.String() {
 				return nil, errors.New("ASFlagToReport: flaggable objects contained more than one target account uri")
 			}
 			targetAccountURI = object
@@ -740,23 +962,10 @@ func (c *converter) ASFlagToReport(ctx context.Context, flaggable ap.Flaggable)
 		}
 	}
 
-	// Make sure we actually have a target account now.
-	if targetAccountURI == nil {
-		return nil, errors.New("ASFlagToReport: flaggable objects contained no recognizable target account uri")
-	}
-	targetAccount, err := c.db.GetAccountByURI(ctx, targetAccountURI.String())
-	if err != nil {
-		if errors.Is(err, db.ErrNoEntries) {
-			return nil, fmt.Errorf("ASFlagToReport: account with uri %s could not be found in the db", targetAccountURI.String())
-		}
-		return nil, fmt.Errorf("ASFlagToReport: db error getting account with uri %s: %w", targetAccountURI.String(), err)
-	}
-
-	// If we got some status URIs, try to get them from the db now
-	var (
-		statusIDs = make([]string, 0, len(statusURIs))
-		statuses  = make([]*gtsmodel.Status, 0, len(statusURIs))
-	)
+	// If we got some status URIs, try to get them from the db now,
+	// skipping any that can't be found and any we've seen already.
+	statuses := make([]*gtsmodel.Status, 0, len(statusURIs))
+	seen := make(map[string]struct{}, len(statusURIs))
 	for _, statusURI := range statusURIs {
 		statusURIString := statusURI.String()
 
@@ -778,13 +987,51 @@ func (c *converter) ASFlagToReport(ctx context.Context, flaggable ap.Flaggable)
 			}
 		}
 
+		if _, ok := seen[status.ID]; ok {
+			// already got this one
+			continue
+		}
+		seen[status.ID] = struct{}{}
+
+		statuses = append(statuses, status)
+	}
+
+	// Make sure we actually have a target account now. If the
+	// flag didn't include one, assume it's the author of the
+	// first reported status that belongs to one of our accounts.
+	if targetAccountURI == nil {
+		for _, status := range statuses {
+			if *status.Local {
+				targetAccountURI, err = url.Parse(status.AccountURI)
+				if err != nil {
+					return nil, fmt.Errorf("ASFlagToReport: error parsing account uri of status %s: %w", status.URI, err)
+				}
+				break
+			}
+		}
+	}
+	if targetAccountURI == nil {
+		return nil, errors.New("ASFlagToReport: flaggable objects contained no recognizable target account uri")
+	}
+	targetAccount, err := c.db.GetAccountByURI(ctx, targetAccountURI.String())
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, fmt.Errorf("ASFlagToReport: account with uri %s could not be found in the db", targetAccountURI.String())
+		}
+		return nil, fmt.Errorf("ASFlagToReport: db error getting account with uri %s: %w", targetAccountURI.String(), err)
+	}
+
+	// Only keep statuses that actually belong to the target account.
+	statusIDs := make([]string, 0, len(statuses))
+	targetStatuses := make([]*gtsmodel.Status, 0, len(statuses))
+	for _, status := range statuses {
 		if status.AccountID != targetAccount.ID {
 			// status doesn't belong to this account, ignore it
 			continue
 		}
 
 		statusIDs = append(statusIDs, status.ID)
-		statuses = append(statuses, status)
+		targetStatuses = append(targetStatuses, status)
 	}
 
 	// id etc should be handled the caller, so just return what we got
@@ -796,6 +1043,6 @@ func (c *converter) ASFlagToReport(ctx context.Context, flaggable ap.Flaggable)
 		TargetAccount:   targetAccount,
 		Comment:         content,
 		StatusIDs:       statusIDs,
-		Statuses:        statuses,
+		Statuses:        targetStatuses,
 	}, nil
 }
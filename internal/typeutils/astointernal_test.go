@@ -21,13 +21,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 )
 
 type ASToInternalTestSuite struct {
@@ -175,6 +179,60 @@ func (suite *ASToInternalTestSuite) TestParseReplyWithMention() {
 	suite.Equal(gtsmodel.VisibilityUnlocked, status.Visibility)
 }
 
+func (suite *ASToInternalTestSuite) parseReplyWithMention() (*gtsmodel.Status, error) {
+	t := suite.jsonToType(statusWithMentionsActivityJson)
+	create, ok := t.(vocab.ActivityStreamsCreate)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	statusable := create.GetActivityStreamsObject().Begin().GetActivityStreamsNote()
+	return suite.typeconverter.ASStatusToStatus(context.Background(), statusable)
+}
+
+func (suite *ASToInternalTestSuite) TestParseReplyThreadDepth() {
+	status, err := suite.parseReplyWithMention()
+	suite.NoError(err)
+
+	suite.Equal(suite.testStatuses["local_account_1_status_1"].ID, status.InReplyToID)
+	suite.Equal(1, status.ThreadDepth)
+	suite.False(*status.ThreadTruncated)
+}
+
+func (suite *ASToInternalTestSuite) setReplyParentDepth(depth int) {
+	parent := new(gtsmodel.Status)
+	*parent = *suite.testStatuses["local_account_1_status_1"]
+	parent.ThreadDepth = depth
+	if err := suite.db.UpdateStatus(context.Background(), parent, "thread_depth"); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *ASToInternalTestSuite) TestParseReplyTooDeepDetach() {
+	config.SetStatusesMaxThreadDepth(5)
+	config.SetStatusesThreadDepthPolicy("detach")
+	suite.setReplyParentDepth(5)
+
+	status, err := suite.parseReplyWithMention()
+	suite.NoError(err)
+
+	suite.Empty(status.InReplyToID)
+	suite.Empty(status.InReplyToURI)
+	suite.Empty(status.InReplyToAccountID)
+	suite.Equal(6, status.ThreadDepth)
+	suite.True(*status.ThreadTruncated)
+}
+
+func (suite *ASToInternalTestSuite) TestParseReplyTooDeepReject() {
+	config.SetStatusesMaxThreadDepth(5)
+	config.SetStatusesThreadDepthPolicy("reject")
+	suite.setReplyParentDepth(5)
+
+	status, err := suite.parseReplyWithMention()
+	suite.ErrorIs(err, typeutils.ErrThreadTooDeep)
+	suite.Nil(status)
+}
+
 func (suite *ASToInternalTestSuite) TestParseOwncastService() {
 	t := suite.jsonToType(owncastService)
 	rep, ok := t.(ap.Accountable)
@@ -458,6 +516,403 @@ func (suite *ASToInternalTestSuite) TestParseFlag6() {
 	suite.Equal(report.Comment, "misinformation")
 }
 
+func (suite *ASToInternalTestSuite) TestParseFlagStatusesOnly() {
+	reportedAccount := suite.testAccounts["local_account_1"]
+	reportingAccount := suite.testAccounts["remote_account_1"]
+	reportedStatus1 := suite.testStatuses["local_account_1_status_1"]
+	reportedStatus2 := suite.testStatuses["local_account_1_status_2"]
+
+	// Misskey-style flag with no account object,
+	// and one status both inline and as an object.
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "` + reportingAccount.URI + `",
+  "content": "Note: ` + reportedStatus1.URL + `\n-----\nspam",
+  "id": "http://fossbros-anonymous.io/db22128d-884e-4358-9935-6a7c3940535d",
+  "object": [
+    "` + reportedStatus1.URI + `",
+    "` + reportedStatus2.URI + `"
+  ],
+  "type": "Flag"
+}`
+
+	t := suite.jsonToType(raw)
+	asFlag, ok := t.(ap.Flaggable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	report, err := suite.typeconverter.ASFlagToReport(context.Background(), asFlag)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Target account should be taken from the
+	// statuses, and statuses should be deduplicated.
+	suite.Equal(reportingAccount.ID, report.AccountID)
+	suite.Equal(reportedAccount.ID, report.TargetAccountID)
+	suite.Equal([]string{reportedStatus1.ID, reportedStatus2.ID}, report.StatusIDs)
+	suite.Len(report.Statuses, 2)
+}
+
+func (suite *ASToInternalTestSuite) TestParseFlagMultipleObjects() {
+	reportedAccount := suite.testAccounts["local_account_1"]
+	reportingAccount := suite.testAccounts["remote_account_1"]
+	reportedStatus1 := suite.testStatuses["local_account_1_status_1"]
+	reportedStatus2 := suite.testStatuses["local_account_1_status_2"]
+
+	// Mastodon-style flag with the account repeated,
+	// plus a status that doesn't exist, and a remote
+	// object that doesn't belong to us.
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "` + reportingAccount.URI + `",
+  "content": "misinformation",
+  "id": "http://fossbros-anonymous.io/db22128d-884e-4358-9935-6a7c3940535d",
+  "object": [
+    "` + reportedAccount.URI + `",
+    "` + reportedStatus1.URI + `",
+    "http://localhost:8080/users/the_mighty_zork/statuses/01GQHR6MCQSTCP85ZG4A0VR316",
+    "` + reportedAccount.URI + `",
+    "http://fossbros-anonymous.io/users/foss_satan/statuses/01FVW7JHQFSFK166WWKR8CBA6M",
+    "` + reportedStatus2.URI + `"
+  ],
+  "type": "Flag"
+}`
+
+	t := suite.jsonToType(raw)
+	asFlag, ok := t.(ap.Flaggable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	report, err := suite.typeconverter.ASFlagToReport(context.Background(), asFlag)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(reportingAccount.ID, report.AccountID)
+	suite.Equal(reportedAccount.ID, report.TargetAccountID)
+	suite.Equal([]string{reportedStatus1.ID, reportedStatus2.ID}, report.StatusIDs)
+	suite.Len(report.Statuses, 2)
+}
+
+func (suite *ASToInternalTestSuite) TestParseFlagEmbeddedStatuses() {
+	reportedAccount := suite.testAccounts["local_account_1"]
+	reportingAccount := suite.testAccounts["remote_account_1"]
+	reportedStatus := suite.testStatuses["local_account_1_status_1"]
+
+	// Akkoma-style flag with the reported
+	// status embedded in objects.
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "` + reportingAccount.URI + `",
+  "content": "rude",
+  "id": "http://fossbros-anonymous.io/activities/af2c8d41-8e4a-4bd6-9a5b-0d4f0a3b2e3c",
+  "object": [
+    "` + reportedAccount.URI + `",
+    {
+      "actor": "` + reportedAccount.URI + `",
+      "content": "` + reportedStatus.Content + `",
+      "id": "` + reportedStatus.URI + `",
+      "published": "2021-10-20T12:40:37+02:00",
+      "type": "Note"
+    }
+  ],
+  "type": "Flag"
+}`
+
+	t := suite.jsonToType(raw)
+	asFlag, ok := t.(ap.Flaggable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	report, err := suite.typeconverter.ASFlagToReport(context.Background(), asFlag)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(reportingAccount.ID, report.AccountID)
+	suite.Equal(reportedAccount.ID, report.TargetAccountID)
+	suite.Equal([]string{reportedStatus.ID}, report.StatusIDs)
+	suite.Equal("rude", report.Comment)
+}
+
+func (suite *ASToInternalTestSuite) TestParseFlagTwoAccounts() {
+	reportingAccount := suite.testAccounts["remote_account_1"]
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "` + reportingAccount.URI + `",
+  "content": "both of them",
+  "id": "http://fossbros-anonymous.io/db22128d-884e-4358-9935-6a7c3940535d",
+  "object": [
+    "` + suite.testAccounts["local_account_1"].URI + `",
+    "` + suite.testAccounts["local_account_2"].URI + `"
+  ],
+  "type": "Flag"
+}`
+
+	t := suite.jsonToType(raw)
+	asFlag, ok := t.(ap.Flaggable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	report, err := suite.typeconverter.ASFlagToReport(context.Background(), asFlag)
+	suite.Nil(report)
+	suite.EqualError(err, "ASFlagToReport: flaggable objects contained more than one target account uri")
+}
+
+func (suite *ASToInternalTestSuite) TestParseEvent() {
+	t := suite.jsonToType(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/events/01H4C9Z1PSWTT0SJ6JE6AP6FV7",
+  "type": "Event",
+  "attributedTo": "http://fossbros-anonymous.io/users/foss_satan",
+  "name": "Sloth appreciation meetup",
+  "content": "<p>Come and appreciate sloths with us!</p>",
+  "published": "2023-07-01T10:00:00Z",
+  "startTime": "2023-07-15T18:00:00Z",
+  "endTime": "2023-07-15T20:00:00Z",
+  "location": {
+    "type": "Place",
+    "name": "The Old Tree, Slothville"
+  },
+  "to": "https://www.w3.org/ns/activitystreams#Public"
+}`)
+	rep, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), rep)
+	suite.NoError(err)
+
+	suite.Equal(ap.ObjectEvent, status.ActivityStreamsType)
+	suite.Equal("Sloth appreciation meetup", status.EventName)
+	suite.Equal("2023-07-15T18:00:00Z", status.EventStartAt.UTC().Format(time.RFC3339))
+	suite.Equal("2023-07-15T20:00:00Z", status.EventEndAt.UTC().Format(time.RFC3339))
+	suite.Equal("The Old Tree, Slothville", status.EventLocation)
+
+	// Event name should not be used as content warning.
+	suite.Empty(status.ContentWarning)
+	suite.Equal("<p>Come and appreciate sloths with us!</p>", status.Content)
+}
+
+func (suite *ASToInternalTestSuite) TestParseArticle() {
+	t := suite.jsonToType(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/articles/on-sloths",
+  "type": "Article",
+  "attributedTo": "http://fossbros-anonymous.io/users/foss_satan",
+  "name": "On Sloths & Other Slow Things",
+  "content": "<p>Sloths are <em>great</em>.</p><script>alert('hi')</script>",
+  "url": "http://fossbros-anonymous.io/blog/on-sloths",
+  "published": "2023-07-01T10:00:00Z",
+  "to": "https://www.w3.org/ns/activitystreams#Public"
+}`)
+	rep, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), rep)
+	suite.NoError(err)
+
+	suite.Equal(ap.ObjectArticle, status.ActivityStreamsType)
+	suite.Equal("http://fossbros-anonymous.io/blog/on-sloths", status.URL)
+
+	// Article title should be prepended to content,
+	// not used as content warning, and content
+	// should be sanitized.
+	suite.Empty(status.ContentWarning)
+	suite.Equal("<p><strong>On Sloths &amp; Other Slow Things</strong></p><p>Sloths are <em>great</em>.</p>", status.Content)
+}
+
+func (suite *ASToInternalTestSuite) TestParseLongArticle() {
+	content := "<p>" + strings.Repeat("sloths are slow ", 1000) + "</p>"
+	t := suite.jsonToType(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/articles/on-sloths-at-length",
+  "type": "Page",
+  "attributedTo": "http://fossbros-anonymous.io/users/foss_satan",
+  "name": "On Sloths, At Length",
+  "content": "` + content + `",
+  "url": "http://fossbros-anonymous.io/blog/on-sloths-at-length",
+  "published": "2023-07-01T10:00:00Z",
+  "to": "https://www.w3.org/ns/activitystreams#Public"
+}`)
+	rep, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), rep)
+	suite.NoError(err)
+
+	// Only an excerpt should be kept, with a link to the rest.
+	suite.Equal(ap.ObjectPage, status.ActivityStreamsType)
+	suite.Less(len(status.Content), 1000)
+	suite.True(strings.HasPrefix(status.Content, "<p><strong>On Sloths, At Length</strong></p><p>sloths are slow sloths are slow "))
+	suite.True(strings.HasSuffix(status.Content, `…</p><p><a href="http://fossbros-anonymous.io/blog/on-sloths-at-length" rel="nofollow noreferrer noopener" target="_blank">Read more</a></p>`))
+}
+
+func (suite *ASToInternalTestSuite) TestParseContentMap() {
+	t := suite.jsonToType(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/users/foss_satan/statuses/01H6Z2Q3NNJ5Y3XW0T8B1Y9M4K",
+  "type": "Note",
+  "attributedTo": "http://fossbros-anonymous.io/users/foss_satan",
+  "contentMap": {
+    "en": "<p>hello world</p>",
+    "de": "<p>hallo welt</p>"
+  },
+  "published": "2023-08-04T12:00:00Z",
+  "to": "https://www.w3.org/ns/activitystreams#Public"
+}`)
+	rep, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), rep)
+	suite.NoError(err)
+
+	// With no plain content to go on, the first
+	// variant in sorted order should be picked,
+	// and all variants should be kept.
+	suite.Equal("de", status.Language)
+	suite.Equal("<p>hallo welt</p>", status.Content)
+	suite.Equal(map[string]string{
+		"en": "<p>hello world</p>",
+		"de": "<p>hallo welt</p>",
+	}, status.ContentMap)
+}
+
+func (suite *ASToInternalTestSuite) TestParseLocation() {
+	t := suite.jsonToType(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/users/foss_satan/statuses/01H7EC1Z8RBTN6K5W8D3GFX6QY",
+  "type": "Note",
+  "attributedTo": "http://fossbros-anonymous.io/users/foss_satan",
+  "content": "<p>look at this tree</p>",
+  "location": {
+    "type": "Place",
+    "name": "The Old Tree, Slothville",
+    "latitude": 51.50135,
+    "longitude": -0.14189
+  },
+  "published": "2023-08-10T12:00:00Z",
+  "to": "https://www.w3.org/ns/activitystreams#Public"
+}`)
+	rep, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), rep)
+	suite.NoError(err)
+
+	// Coordinates should be rounded.
+	suite.Equal("The Old Tree, Slothville", status.LocationName)
+	suite.Equal(51.501, *status.LocationLatitude)
+	suite.Equal(-0.142, *status.LocationLongitude)
+
+	// Not an event, so no event location.
+	suite.Empty(status.EventLocation)
+}
+
+func (suite *ASToInternalTestSuite) TestParseLocationBadCoordinates() {
+	t := suite.jsonToType(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/users/foss_satan/statuses/01H7EC1Z8RBTN6K5W8D3GFX6QY",
+  "type": "Note",
+  "attributedTo": "http://fossbros-anonymous.io/users/foss_satan",
+  "content": "<p>look at this tree</p>",
+  "location": {
+    "type": "Place",
+    "name": "The Old Tree, Slothville",
+    "latitude": 151.50135,
+    "longitude": -0.14189
+  },
+  "published": "2023-08-10T12:00:00Z",
+  "to": "https://www.w3.org/ns/activitystreams#Public"
+}`)
+	rep, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), rep)
+	suite.NoError(err)
+
+	// Name should be kept, nonsense coordinates dropped.
+	suite.Equal("The Old Tree, Slothville", status.LocationName)
+	suite.Nil(status.LocationLatitude)
+	suite.Nil(status.LocationLongitude)
+}
+
+func (suite *ASToInternalTestSuite) parsePollJSON(options string) (*gtsmodel.Status, error) {
+	t := suite.jsonToType(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/users/foss_satan/statuses/01H7JX2V3C0X3KQ8Y6S3BDFV7N",
+  "type": "Question",
+  "attributedTo": "http://fossbros-anonymous.io/users/foss_satan",
+  "content": "<p>which is best?</p>",
+  ` + options + `,
+  "endTime": "2023-08-13T12:00:00Z",
+  "votersCount": 3,
+  "published": "2023-08-12T12:00:00Z",
+  "to": "https://www.w3.org/ns/activitystreams#Public"
+}`)
+	rep, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	return suite.typeconverter.ASStatusToStatus(context.Background(), rep)
+}
+
+func (suite *ASToInternalTestSuite) TestParsePollAnyOf() {
+	status, err := suite.parsePollJSON(`"anyOf": [
+    {"type": "Note", "name": "tabs", "replies": {"type": "Collection", "totalItems": 2}},
+    {"type": "Note", "name": "spaces", "replies": {"type": "Collection", "totalItems": 3}}
+  ]`)
+	suite.NoError(err)
+	suite.NotNil(status.Poll)
+
+	suite.True(*status.Poll.Multiple)
+	suite.Equal([]string{"tabs", "spaces"}, status.Poll.Options)
+	suite.Equal([]int{2, 3}, status.Poll.Votes)
+	suite.Equal(3, status.Poll.Voters)
+	suite.Equal("2023-08-13T12:00:00Z", status.Poll.ExpiresAt.UTC().Format(time.RFC3339))
+}
+
+func (suite *ASToInternalTestSuite) TestParsePollOneOf() {
+	status, err := suite.parsePollJSON(`"oneOf": [
+    {"type": "Note", "name": "tabs", "replies": {"type": "Collection", "totalItems": 1}},
+    {"type": "Note", "name": "spaces", "replies": {"type": "Collection", "totalItems": 2}}
+  ]`)
+	suite.NoError(err)
+	suite.NotNil(status.Poll)
+
+	suite.False(*status.Poll.Multiple)
+	suite.Equal([]string{"tabs", "spaces"}, status.Poll.Options)
+	suite.Equal([]int{1, 2}, status.Poll.Votes)
+}
+
+func (suite *ASToInternalTestSuite) TestParsePollAnyOfAndOneOf() {
+	_, err := suite.parsePollJSON(`"anyOf": [
+    {"type": "Note", "name": "tabs"}
+  ],
+  "oneOf": [
+    {"type": "Note", "name": "spaces"}
+  ]`)
+	suite.ErrorContains(err, "invalid poll")
+}
+
 func TestASToInternalTestSuite(t *testing.T) {
 	suite.Run(t, new(ASToInternalTestSuite))
 }
@@ -19,6 +19,7 @@ package typeutils
 
 import (
 	"context"
+	"errors"
 	"net/url"
 	"sync"
 
@@ -30,6 +31,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
+// ErrThreadTooDeep is returned by ASStatusToStatus when the
+// status is a reply deeper than the configured max thread
+// depth, and the configured policy is to reject such replies.
+var ErrThreadTooDeep = errors.New("status is deeper than max thread depth")
+
 // TypeConverter is an interface for the common action of converting between apimodule (frontend, serializable) models,
 // internal gts models used in the database, and activitypub models used in federation.
 //
@@ -52,6 +58,8 @@ type TypeConverter interface {
 	// something goes wrong. The returned account will be a bare minimum representation of the account. This function should be used
 	// when someone wants to view an account they've blocked.
 	AccountToAPIAccountBlocked(ctx context.Context, account *gtsmodel.Account) (*apimodel.Account, error)
+	// AccountToAdminAPIAccount converts a gts model account into an admin view of the account, for serving at /api/v1/admin endpoints.
+	AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Account) (*apimodel.AdminAccountInfo, error)
 	// AppToAPIAppSensitive takes a db model application as a param, and returns a populated apitype application, or an error
 	// if something goes wrong. The returned application should be ready to serialize on an API level, and may have sensitive fields
 	// (such as client id and client secret), so serve it only to an authorized user who should have permission to see it.
@@ -68,10 +76,19 @@ type TypeConverter interface {
 	EmojiToAPIEmoji(ctx context.Context, e *gtsmodel.Emoji) (apimodel.Emoji, error)
 	// EmojiToAdminAPIEmoji converts a gts model emoji into an API representation with extra admin information.
 	EmojiToAdminAPIEmoji(ctx context.Context, e *gtsmodel.Emoji) (*apimodel.AdminEmoji, error)
+	// EmojiToPersonalAPIEmoji converts a gts model personal emoji into an API representation for its owner.
+	EmojiToPersonalAPIEmoji(ctx context.Context, e *gtsmodel.Emoji) (*apimodel.PersonalEmoji, error)
 	// EmojiCategoryToAPIEmojiCategory converts a gts model emoji category into its api (frontend) representation.
 	EmojiCategoryToAPIEmojiCategory(ctx context.Context, category *gtsmodel.EmojiCategory) (*apimodel.EmojiCategory, error)
+	// WebAuthnCredentialToAPIWebAuthnCredential converts a gts model WebAuthn credential into an API representation for its owner.
+	WebAuthnCredentialToAPIWebAuthnCredential(ctx context.Context, c *gtsmodel.WebAuthnCredential) (*apimodel.WebAuthnCredential, error)
+	// WebSessionToAPIWebSession converts a gts model web session into an API representation for its owner.
+	WebSessionToAPIWebSession(ctx context.Context, s *gtsmodel.WebSession) (*apimodel.WebSession, error)
 	// TagToAPITag converts a gts model tag into its api (frontend) representation for serialization on the API.
 	TagToAPITag(ctx context.Context, t *gtsmodel.Tag) (apimodel.Tag, error)
+	// FeaturedTagToAPIFeaturedTag converts a gts model featured tag into its api (frontend) representation,
+	// using the given count of statuses by the featuring account that contain the tag.
+	FeaturedTagToAPIFeaturedTag(ctx context.Context, f *gtsmodel.FeaturedTag, statusesCount int) (*apimodel.FeaturedTag, error)
 	// StatusToAPIStatus converts a gts model status into its api (frontend) representation for serialization on the API.
 	//
 	// Requesting account can be nil.
@@ -90,10 +107,26 @@ type TypeConverter interface {
 	DomainBlockToAPIDomainBlock(ctx context.Context, b *gtsmodel.DomainBlock, export bool) (*apimodel.DomainBlock, error)
 	// ReportToAPIReport converts a gts model report into an api model report, for serving at /api/v1/reports
 	ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (*apimodel.Report, error)
+	// QueuedEmailToAdminAPIFailedEmail converts a gts model queued email into an admin view of an email that could not be sent.
+	QueuedEmailToAdminAPIFailedEmail(ctx context.Context, e *gtsmodel.QueuedEmail) (*apimodel.AdminFailedEmail, error)
+	// HashtagSettingToAdminAPIHashtag converts a gts model hashtag setting into an admin view of a hashtag, for serving at /api/v1/admin/hashtags
+	HashtagSettingToAdminAPIHashtag(ctx context.Context, h *gtsmodel.HashtagSetting) (*apimodel.AdminHashtag, error)
 	// ReportToAdminAPIReport converts a gts model report into an admin view report, for serving at /api/v1/admin/reports
 	ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Report, requestingAccount *gtsmodel.Account) (*apimodel.AdminReport, error)
 	// ListToAPIList converts one gts model list into an api model list, for serving at /api/v1/lists/{id}
 	ListToAPIList(ctx context.Context, l *gtsmodel.List) (*apimodel.List, error)
+	// RuleToAPIRule converts one gts model rule into an api model instance rule, for serving at /api/v1/instance/rules
+	RuleToAPIRule(ctx context.Context, r *gtsmodel.Rule) (*apimodel.InstanceRule, error)
+	// InteractionRequestToAPIInteractionRequest converts a gts model interaction request into an api model interaction request, as seen by the given requesting account, for serving at /api/v1/interaction_requests
+	InteractionRequestToAPIInteractionRequest(ctx context.Context, req *gtsmodel.InteractionRequest, requestingAccount *gtsmodel.Account) (*apimodel.InteractionRequest, error)
+	// PollToAPIPoll converts one gts model poll into an api model poll, as seen by the given requesting account (which may be nil), for serving at /api/v1/polls/{id}
+	PollToAPIPoll(ctx context.Context, requestingAccount *gtsmodel.Account, p *gtsmodel.Poll) (*apimodel.Poll, error)
+	// PreviewCardToAPICard converts one gts model preview card into an api model card, for serving as the card of a status
+	PreviewCardToAPICard(ctx context.Context, card *gtsmodel.PreviewCard) (*apimodel.Card, error)
+	// DraftToAPIDraft converts one gts model draft into an api model draft, for serving at /api/v1/drafts/{id}
+	DraftToAPIDraft(ctx context.Context, d *gtsmodel.Draft) (*apimodel.Draft, error)
+	// ListenToAPINowPlaying converts a gts model listen into an api model now playing, for serving at /api/v1/accounts/{id}/now_playing
+	ListenToAPINowPlaying(ctx context.Context, l *gtsmodel.Listen) (*apimodel.NowPlaying, error)
 
 	/*
 		INTERNAL (gts) MODEL TO FRONTEND (rss) MODEL
@@ -117,8 +150,12 @@ type TypeConverter interface {
 	ASFollowToFollow(ctx context.Context, followable ap.Followable) (*gtsmodel.Follow, error)
 	// ASLikeToFave converts a remote activitystreams 'like' representation into a gts model status fave.
 	ASLikeToFave(ctx context.Context, likeable ap.Likeable) (*gtsmodel.StatusFave, error)
+	// ASDislikeToStatusDislike converts a remote activitystreams 'dislike' representation into a gts model status dislike.
+	ASDislikeToStatusDislike(ctx context.Context, dislikeable ap.Dislikeable) (*gtsmodel.StatusDislike, error)
 	// ASBlockToBlock converts a remote activity streams 'block' representation into a gts model block.
 	ASBlockToBlock(ctx context.Context, blockable ap.Blockable) (*gtsmodel.Block, error)
+	// ASListenToListen converts a remote activitystreams 'listen' representation into a gts model listen.
+	ASListenToListen(ctx context.Context, listen vocab.ActivityStreamsListen) (*gtsmodel.Listen, error)
 	// ASAnnounceToStatus converts an activitystreams 'announce' into a status.
 	//
 	// The returned bool indicates whether this status is new (true) or not new (false).
@@ -148,7 +185,7 @@ type TypeConverter interface {
 	// we don't trust them (yet).
 	AccountToASMinimal(ctx context.Context, a *gtsmodel.Account) (vocab.ActivityStreamsPerson, error)
 	// StatusToAS converts a gts model status into an activity streams note, suitable for federation
-	StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.ActivityStreamsNote, error)
+	StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Statusable, error)
 	// StatusToASDelete converts a gts model status into a Delete of that status, using just the
 	// URI of the status as object, and addressing the Delete appropriately.
 	StatusToASDelete(ctx context.Context, status *gtsmodel.Status) (vocab.ActivityStreamsDelete, error)
@@ -156,16 +193,31 @@ type TypeConverter interface {
 	FollowToAS(ctx context.Context, f *gtsmodel.Follow, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) (vocab.ActivityStreamsFollow, error)
 	// MentionToAS converts a gts model mention into an activity streams Mention, suitable for federation
 	MentionToAS(ctx context.Context, m *gtsmodel.Mention) (vocab.ActivityStreamsMention, error)
+	// TagToAS converts a gts model tag into a Hashtag, suitable for federation
+	TagToAS(ctx context.Context, t *gtsmodel.Tag) (vocab.ActivityStreamsLink, error)
 	// EmojiToAS converts a gts emoji into a mastodon ns Emoji, suitable for federation
 	EmojiToAS(ctx context.Context, e *gtsmodel.Emoji) (vocab.TootEmoji, error)
 	// AttachmentToAS converts a gts model media attachment into an activity streams Attachment, suitable for federation
 	AttachmentToAS(ctx context.Context, a *gtsmodel.MediaAttachment) (vocab.ActivityStreamsDocument, error)
 	// FaveToAS converts a gts model status fave into an activityStreams LIKE, suitable for federation.
 	FaveToAS(ctx context.Context, f *gtsmodel.StatusFave) (vocab.ActivityStreamsLike, error)
+	// StatusDislikeToAS converts a gts model status dislike into an activityStreams DISLIKE, suitable for federation.
+	StatusDislikeToAS(ctx context.Context, d *gtsmodel.StatusDislike) (vocab.ActivityStreamsDislike, error)
+	// PollVoteToASNotes converts a gts model poll vote into activityStreams NOTEs, one per chosen option, suitable for
+	// wrapping in a Create and federating to the poll's author. This is how Mastodon and others federate poll votes.
+	PollVoteToASNotes(ctx context.Context, v *gtsmodel.PollVote) ([]vocab.ActivityStreamsNote, error)
+	// InteractionRequestToASAccept converts an accepted gts model interaction request into an activityStreams ACCEPT of the interaction, suitable for federation.
+	InteractionRequestToASAccept(ctx context.Context, req *gtsmodel.InteractionRequest) (vocab.ActivityStreamsAccept, error)
+	// InteractionRequestToASReject converts a rejected gts model interaction request into an activityStreams REJECT of the interaction, suitable for federation.
+	InteractionRequestToASReject(ctx context.Context, req *gtsmodel.InteractionRequest) (vocab.ActivityStreamsReject, error)
 	// BoostToAS converts a gts model boost into an activityStreams ANNOUNCE, suitable for federation
 	BoostToAS(ctx context.Context, boostWrapperStatus *gtsmodel.Status, boostingAccount *gtsmodel.Account, boostedAccount *gtsmodel.Account) (vocab.ActivityStreamsAnnounce, error)
 	// BlockToAS converts a gts model block into an activityStreams BLOCK, suitable for federation.
 	BlockToAS(ctx context.Context, block *gtsmodel.Block) (vocab.ActivityStreamsBlock, error)
+	// ListenToAS converts a gts model listen into an activityStreams LISTEN of an Audio object, suitable for federation.
+	ListenToAS(ctx context.Context, l *gtsmodel.Listen) (vocab.ActivityStreamsListen, error)
+	// BiteToAS converts a gts model bite into an activityStreams BITE, suitable for federation.
+	BiteToAS(ctx context.Context, b *gtsmodel.Bite) (vocab.ActivityStreamsActivity, error)
 	// StatusToASRepliesCollection converts a gts model status into an activityStreams REPLIES collection.
 	StatusToASRepliesCollection(ctx context.Context, status *gtsmodel.Status, onlyOtherAccounts bool) (vocab.ActivityStreamsCollection, error)
 	// StatusURIsToASRepliesPage returns a collection page with appropriate next/part of pagination.
@@ -203,12 +255,12 @@ type TypeConverter interface {
 
 	// WrapPersonInUpdate
 	WrapPersonInUpdate(person vocab.ActivityStreamsPerson, originAccount *gtsmodel.Account) (vocab.ActivityStreamsUpdate, error)
-	// WrapNoteInCreate wraps a Note with a Create activity.
+	// WrapStatusableInCreate wraps a Statusable (eg., a Note, or a Question for a status with a poll) with a Create activity.
 	//
 	// If objectIRIOnly is set to true, then the function won't put the *entire* note in the Object field of the Create,
 	// but just the AP URI of the note. This is useful in cases where you want to give a remote server something to dereference,
 	// and still have control over whether or not they're allowed to actually see the contents.
-	WrapNoteInCreate(note vocab.ActivityStreamsNote, objectIRIOnly bool) (vocab.ActivityStreamsCreate, error)
+	WrapStatusableInCreate(note ap.Statusable, objectIRIOnly bool) (vocab.ActivityStreamsCreate, error)
 }
 
 type converter struct {
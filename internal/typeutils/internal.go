@@ -58,6 +58,7 @@ func (c *converter) StatusToBoost(ctx context.Context, s *gtsmodel.Status, boost
 	boostable := *s.Boostable
 	replyable := *s.Replyable
 	likeable := *s.Likeable
+	threadTruncated := false
 
 	boostWrapperStatus := &gtsmodel.Status{
 		ID:  boostWrapperStatusID,
@@ -74,6 +75,7 @@ func (c *converter) StatusToBoost(ctx context.Context, s *gtsmodel.Status, boost
 		// replies can be boosted, but boosts are never replies
 		InReplyToID:        "",
 		InReplyToAccountID: "",
+		ThreadTruncated:    &threadTruncated,
 
 		// these will all be wrapped in the boosted status so set them empty here
 		AttachmentIDs: []string{},
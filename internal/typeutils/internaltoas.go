@@ -24,15 +24,18 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strconv"
 
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // Converts a gts model account into an Activity Streams person type.
@@ -399,7 +402,28 @@ func (c *converter) AccountToASMinimal(ctx context.Context, a *gtsmodel.Account)
 	return person, nil
 }
 
-func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.ActivityStreamsNote, error) {
+// asStatusable is the set of functions needed to build an
+// AS representation of a status. It's fulfilled by Note, and
+// by Question, which is used for statuses with a poll.
+type asStatusable interface {
+	ap.Statusable
+
+	SetJSONLDId(vocab.JSONLDIdProperty)
+	SetActivityStreamsInReplyTo(vocab.ActivityStreamsInReplyToProperty)
+	SetActivityStreamsPublished(vocab.ActivityStreamsPublishedProperty)
+	SetActivityStreamsUrl(vocab.ActivityStreamsUrlProperty)
+	SetActivityStreamsAttributedTo(vocab.ActivityStreamsAttributedToProperty)
+	SetActivityStreamsTag(vocab.ActivityStreamsTagProperty)
+	SetActivityStreamsTo(vocab.ActivityStreamsToProperty)
+	SetActivityStreamsCc(vocab.ActivityStreamsCcProperty)
+	SetActivityStreamsAttachment(vocab.ActivityStreamsAttachmentProperty)
+	SetActivityStreamsReplies(vocab.ActivityStreamsRepliesProperty)
+	SetActivityStreamsSensitive(vocab.ActivityStreamsSensitiveProperty)
+	SetActivityStreamsLocation(vocab.ActivityStreamsLocationProperty)
+	GetUnknownProperties() map[string]interface{}
+}
+
+func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Statusable, error) {
 	// ensure prerequisites here before we get stuck in
 
 	// check if author account is already attached to status and attach it if not
@@ -412,8 +436,17 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 		s.Account = a
 	}
 
-	// create the Note!
-	status := streams.NewActivityStreamsNote()
+	// create the Note! Or a Question, if there's a poll.
+	var status asStatusable
+	if s.PollID != "" {
+		question, err := c.pollToASQuestion(ctx, s)
+		if err != nil {
+			return nil, fmt.Errorf("StatusToAS: error converting poll: %w", err)
+		}
+		status = question
+	} else {
+		status = streams.NewActivityStreamsNote()
+	}
 
 	// id
 	statusURI, err := url.Parse(s.URI)
@@ -510,7 +543,24 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	}
 
 	// tag -- hashtags
-	// TODO
+	tags := s.Tags
+	if len(s.TagIDs) > len(tags) {
+		tags = []*gtsmodel.Tag{}
+		for _, tagID := range s.TagIDs {
+			tag := &gtsmodel.Tag{}
+			if err := c.db.GetByID(ctx, tagID, tag); err != nil {
+				return nil, fmt.Errorf("StatusToAS: error getting tag %s from database: %s", tagID, err)
+			}
+			tags = append(tags, tag)
+		}
+	}
+	for _, tag := range tags {
+		asHashtag, err := c.TagToAS(ctx, tag)
+		if err != nil {
+			return nil, fmt.Errorf("StatusToAS: error converting tag to AS hashtag: %s", err)
+		}
+		tagProp.AppendActivityStreamsLink(asHashtag)
+	}
 
 	status.SetActivityStreamsTag(tagProp)
 
@@ -539,7 +589,17 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 			toProp.AppendIRI(iri)
 		}
 	case gtsmodel.VisibilityMutualsOnly:
-		// TODO
+		// if MUTUALS ONLY then we only want to add mentions to CC. Mutuals are not
+		// addressed here, since that would reveal the author's mutuals to anyone who
+		// sees the status; instead, a copy addressed to just that mutual is delivered
+		// to each one of them individually when the status is federated.
+		for _, m := range mentions {
+			iri, err := url.Parse(m.TargetAccount.URI)
+			if err != nil {
+				return nil, fmt.Errorf("StatusToAS: error parsing uri %s: %s", m.TargetAccount.URI, err)
+			}
+			ccProp.AppendIRI(iri)
+		}
 	case gtsmodel.VisibilityFollowersOnly:
 		// if FOLLOWERS ONLY then we want to add followers to TO, and mentions to CC
 		toProp.AppendIRI(authorFollowersURI)
@@ -579,9 +639,13 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	// conversation
 	// TODO
 
-	// content -- the actual post itself
+	// content -- the actual post itself, also
+	// as contentMap, keyed by the post language
 	contentProp := streams.NewActivityStreamsContentProperty()
 	contentProp.AppendXMLSchemaString(s.Content)
+	if contentMap := statusContentMap(s); len(contentMap) != 0 {
+		contentProp.AppendRDFLangString(contentMap)
+	}
 	status.SetActivityStreamsContent(contentProp)
 
 	// attachments
@@ -621,9 +685,128 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	sensitiveProp.AppendXMLSchemaBoolean(*s.Sensitive)
 	status.SetActivityStreamsSensitive(sensitiveProp)
 
+	// location
+	if s.LocationName != "" {
+		place := streams.NewActivityStreamsPlace()
+
+		nameProp := streams.NewActivityStreamsNameProperty()
+		nameProp.AppendXMLSchemaString(s.LocationName)
+		place.SetActivityStreamsName(nameProp)
+
+		// Only federate coordinates if both are set,
+		// and never with more precision than we keep.
+		lat := util.RoundCoordinate(s.LocationLatitude)
+		lon := util.RoundCoordinate(s.LocationLongitude)
+		if lat != nil && lon != nil {
+			latProp := streams.NewActivityStreamsLatitudeProperty()
+			latProp.Set(*lat)
+			place.SetActivityStreamsLatitude(latProp)
+
+			lonProp := streams.NewActivityStreamsLongitudeProperty()
+			lonProp.Set(*lon)
+			place.SetActivityStreamsLongitude(lonProp)
+		}
+
+		locationProp := streams.NewActivityStreamsLocationProperty()
+		locationProp.AppendActivityStreamsPlace(place)
+		status.SetActivityStreamsLocation(locationProp)
+	}
+
+	// dislikes
+	if config.GetStatusShowDislikes() {
+		dislikesCount, err := c.db.CountStatusDislikes(ctx, s.ID)
+		if err != nil {
+			return nil, fmt.Errorf("StatusToAS: error counting dislikes: %w", err)
+		}
+
+		// There's no dislikes property in ActivityStreams,
+		// so set a minimal collection as an extension property.
+		status.GetUnknownProperties()["dislikes"] = map[string]interface{}{
+			"type":       ap.ObjectCollection,
+			"totalItems": dislikesCount,
+		}
+	}
+
 	return status, nil
 }
 
+// pollToASQuestion returns a Question with the poll of the given
+// status set on it, for StatusToAS to fill in the rest. Options are
+// given as anyOf for multiple-choice polls, oneOf otherwise, each
+// with its vote count as the totalItems of its replies collection.
+func (c *converter) pollToASQuestion(ctx context.Context, s *gtsmodel.Status) (vocab.ActivityStreamsQuestion, error) {
+	if s.Poll == nil {
+		poll, err := c.db.GetPollByID(ctx, s.PollID)
+		if err != nil {
+			return nil, gtserror.Newf("error getting poll %s: %w", s.PollID, err)
+		}
+		s.Poll = poll
+	}
+	poll := s.Poll
+
+	// Don't give counts away
+	// early if they're hidden.
+	showCounts := poll.HideCounts == nil || !*poll.HideCounts || poll.Expired()
+
+	question := streams.NewActivityStreamsQuestion()
+
+	options := make([]vocab.ActivityStreamsNote, len(poll.Options))
+	for i, option := range poll.Options {
+		note := streams.NewActivityStreamsNote()
+
+		nameProp := streams.NewActivityStreamsNameProperty()
+		nameProp.AppendXMLSchemaString(option)
+		note.SetActivityStreamsName(nameProp)
+
+		var votes int
+		if showCounts && i < len(poll.Votes) {
+			votes = poll.Votes[i]
+		}
+
+		totalItemsProp := streams.NewActivityStreamsTotalItemsProperty()
+		totalItemsProp.Set(votes)
+		replies := streams.NewActivityStreamsCollection()
+		replies.SetActivityStreamsTotalItems(totalItemsProp)
+		repliesProp := streams.NewActivityStreamsRepliesProperty()
+		repliesProp.SetActivityStreamsCollection(replies)
+		note.SetActivityStreamsReplies(repliesProp)
+
+		options[i] = note
+	}
+
+	if poll.Multiple != nil && *poll.Multiple {
+		anyOfProp := streams.NewActivityStreamsAnyOfProperty()
+		for _, note := range options {
+			anyOfProp.AppendActivityStreamsNote(note)
+		}
+		question.SetActivityStreamsAnyOf(anyOfProp)
+	} else {
+		oneOfProp := streams.NewActivityStreamsOneOfProperty()
+		for _, note := range options {
+			oneOfProp.AppendActivityStreamsNote(note)
+		}
+		question.SetActivityStreamsOneOf(oneOfProp)
+	}
+
+	if !poll.ExpiresAt.IsZero() {
+		endTimeProp := streams.NewActivityStreamsEndTimeProperty()
+		endTimeProp.Set(poll.ExpiresAt)
+		question.SetActivityStreamsEndTime(endTimeProp)
+
+		if poll.Expired() {
+			closedProp := streams.NewActivityStreamsClosedProperty()
+			closedProp.AppendXMLSchemaDateTime(poll.ExpiresAt)
+			question.SetActivityStreamsClosed(closedProp)
+		}
+	}
+
+	votersCountProp := streams.NewTootVotersCountProperty()
+	votersCountProp.Set(poll.Voters)
+	question.SetTootVotersCount(votersCountProp)
+
+	return question, nil
+}
+
 func (c *converter) StatusToASDelete(ctx context.Context, s *gtsmodel.Status) (vocab.ActivityStreamsDelete, error) {
 	// Parse / fetch some information
 	// we need to create the Delete.
@@ -846,6 +1029,36 @@ func (c *converter) MentionToAS(ctx context.Context, m *gtsmodel.Mention) (vocab
 	return mention, nil
 }
 
+// TagToAS converts a gts model tag into a Hashtag. Since go-fed has
+// no Hashtag type, the hashtag is a plain Link with its type overridden,
+// so it serializes with type 'Hashtag'.
+func (c *converter) TagToAS(ctx context.Context, t *gtsmodel.Tag) (vocab.ActivityStreamsLink, error) {
+	hashtag := streams.NewActivityStreamsLink()
+	typeProp := streams.NewJSONLDTypeProperty()
+	typeProp.AppendXMLSchemaString(ap.TagHashtag)
+	hashtag.SetJSONLDType(typeProp)
+
+	// href -- this should be the web address of the tag
+	hrefProp := streams.NewActivityStreamsHrefProperty()
+	hrefURI, err := url.Parse(t.URL)
+	if err != nil {
+		return nil, fmt.Errorf("TagToAS: error parsing url %s: %s", t.URL, err)
+	}
+	hrefProp.SetIRI(hrefURI)
+	hashtag.SetActivityStreamsHref(hrefProp)
+
+	// name -- this should be the display name of the tag, something like #SomeHashtag
+	displayName := t.DisplayName
+	if displayName == "" {
+		displayName = t.Name
+	}
+	nameProp := streams.NewActivityStreamsNameProperty()
+	nameProp.AppendXMLSchemaString("#" + displayName)
+	hashtag.SetActivityStreamsName(nameProp)
+
+	return hashtag, nil
+}
+
 /*
 	 we're making something like this:
 		{
@@ -1017,6 +1230,72 @@ func (c *converter) FaveToAS(ctx context.Context, f *gtsmodel.StatusFave) (vocab
 	return like, nil
 }
 
+func (c *converter) StatusDislikeToAS(ctx context.Context, d *gtsmodel.StatusDislike) (vocab.ActivityStreamsDislike, error) {
+	if d.Status == nil {
+		s, err := c.db.GetStatusByID(ctx, d.StatusID)
+		if err != nil {
+			return nil, gtserror.Newf("error fetching target status from database: %w", err)
+		}
+		d.Status = s
+	}
+
+	if d.TargetAccount == nil {
+		a, err := c.db.GetAccountByID(ctx, d.TargetAccountID)
+		if err != nil {
+			return nil, gtserror.Newf("error fetching target account from database: %w", err)
+		}
+		d.TargetAccount = a
+	}
+
+	if d.Account == nil {
+		a, err := c.db.GetAccountByID(ctx, d.AccountID)
+		if err != nil {
+			return nil, gtserror.Newf("error fetching disliking account from database: %w", err)
+		}
+		d.Account = a
+	}
+
+	dislike := streams.NewActivityStreamsDislike()
+
+	// Set the actor property to the disliking account's URI.
+	actorIRI, err := url.Parse(d.Account.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", d.Account.URI, err)
+	}
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(actorIRI)
+	dislike.SetActivityStreamsActor(actorProp)
+
+	// Set the ID property to the dislike's URI.
+	idIRI, err := url.Parse(d.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", d.URI, err)
+	}
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.Set(idIRI)
+	dislike.SetJSONLDId(idProp)
+
+	// Set the object property to the target status's URI.
+	statusIRI, err := url.Parse(d.Status.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", d.Status.URI, err)
+	}
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(statusIRI)
+	dislike.SetActivityStreamsObject(objectProp)
+
+	// Set the to property to the target account's URI.
+	toIRI, err := url.Parse(d.TargetAccount.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", d.TargetAccount.URI, err)
+	}
+	toProp := streams.NewActivityStreamsToProperty()
+	toProp.AppendIRI(toIRI)
+	dislike.SetActivityStreamsTo(toProp)
+
+	return dislike, nil
+}
+
 func (c *converter) BoostToAS(ctx context.Context, boostWrapperStatus *gtsmodel.Status, boostingAccount *gtsmodel.Account, boostedAccount *gtsmodel.Account) (vocab.ActivityStreamsAnnounce, error) {
 	// the boosted status is probably pinned to the boostWrapperStatus but double check to make sure
 	if boostWrapperStatus.BoostOf == nil {
@@ -1164,6 +1443,176 @@ func (c *converter) BlockToAS(ctx context.Context, b *gtsmodel.Block) (vocab.Act
 	return block, nil
 }
 
+// ListenToAS converts a gts model listen into an activityStreams
+// LISTEN, with the listened-to track as an Audio object. Artist and
+// album names are set as extra properties on the Audio object, since
+// the AS vocabulary has no equivalent. For example:
+//
+//	{
+//	  "@context": "https://www.w3.org/ns/activitystreams",
+//	  "actor": "https://example.org/users/someone",
+//	  "cc": "https://www.w3.org/ns/activitystreams#Public",
+//	  "id": "https://example.org/users/someone#listens/01H4M3FRT4P2XAYV7J5XXE8KDF",
+//	  "object": {
+//	    "album": "Amnesiac",
+//	    "artist": "Radiohead",
+//	    "name": "Pyramid Song",
+//	    "type": "Audio"
+//	  },
+//	  "published": "2023-07-06T12:00:00Z",
+//	  "to": "https://example.org/users/someone/followers",
+//	  "type": "Listen"
+//	}
+func (c *converter) ListenToAS(ctx context.Context, l *gtsmodel.Listen) (vocab.ActivityStreamsListen, error) {
+	if l.Account == nil {
+		a, err := c.db.GetAccountByID(ctx, l.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("ListenToAS: error getting listen owner account from database: %s", err)
+		}
+		l.Account = a
+	}
+
+	// create the listen
+	listen := streams.NewActivityStreamsListen()
+
+	// set the actor property to the listening account's URI
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorIRI, err := url.Parse(l.Account.URI)
+	if err != nil {
+		return nil, fmt.Errorf("ListenToAS: error parsing uri %s: %s", l.Account.URI, err)
+	}
+	actorProp.AppendIRI(actorIRI)
+	listen.SetActivityStreamsActor(actorProp)
+
+	// set the ID property to the listen's URI
+	idProp := streams.NewJSONLDIdProperty()
+	idIRI, err := url.Parse(l.URI)
+	if err != nil {
+		return nil, fmt.Errorf("ListenToAS: error parsing uri %s: %s", l.URI, err)
+	}
+	idProp.Set(idIRI)
+	listen.SetJSONLDId(idProp)
+
+	// set the published property to when the track was listened to
+	publishedProp := streams.NewActivityStreamsPublishedProperty()
+	publishedProp.Set(l.CreatedAt)
+	listen.SetActivityStreamsPublished(publishedProp)
+
+	// set the object property to the listened-to track
+	audio := streams.NewActivityStreamsAudio()
+	nameProp := streams.NewActivityStreamsNameProperty()
+	nameProp.AppendXMLSchemaString(l.TrackName)
+	audio.SetActivityStreamsName(nameProp)
+	unknown := audio.GetUnknownProperties()
+	if l.ArtistName != "" {
+		unknown["artist"] = l.ArtistName
+	}
+	if l.AlbumName != "" {
+		unknown["album"] = l.AlbumName
+	}
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendActivityStreamsAudio(audio)
+	listen.SetActivityStreamsObject(objectProp)
+
+	// set the TO property to the listening account's followers
+	toProp := streams.NewActivityStreamsToProperty()
+	followersIRI, err := url.Parse(l.Account.FollowersURI)
+	if err != nil {
+		return nil, fmt.Errorf("ListenToAS: error parsing uri %s: %s", l.Account.FollowersURI, err)
+	}
+	toProp.AppendIRI(followersIRI)
+	listen.SetActivityStreamsTo(toProp)
+
+	// set the CC property to public
+	ccProp := streams.NewActivityStreamsCcProperty()
+	publicIRI, err := url.Parse(pub.PublicActivityPubIRI)
+	if err != nil {
+		return nil, fmt.Errorf("ListenToAS: error parsing uri %s: %s", pub.PublicActivityPubIRI, err)
+	}
+	ccProp.AppendIRI(publicIRI)
+	listen.SetActivityStreamsCc(ccProp)
+
+	return listen, nil
+}
+
+// BiteToAS converts a gts model bite into an activityStreams BITE
+// of the target account, or of the target status if one is set.
+// Since go-fed has no Bite type, the bite is a plain Activity
+// with its type overridden, so it serializes with type 'Bite'.
+func (c *converter) BiteToAS(ctx context.Context, b *gtsmodel.Bite) (vocab.ActivityStreamsActivity, error) {
+	if b.Account == nil {
+		a, err := c.db.GetAccountByID(ctx, b.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("BiteToAS: error getting bite owner account from database: %s", err)
+		}
+		b.Account = a
+	}
+
+	if b.TargetAccount == nil {
+		a, err := c.db.GetAccountByID(ctx, b.TargetAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("BiteToAS: error getting bite target account from database: %s", err)
+		}
+		b.TargetAccount = a
+	}
+
+	if b.StatusID != "" && b.Status == nil {
+		s, err := c.db.GetStatusByID(ctx, b.StatusID)
+		if err != nil {
+			return nil, fmt.Errorf("BiteToAS: error getting bite target status from database: %s", err)
+		}
+		b.Status = s
+	}
+
+	// create the bite
+	bite := streams.NewActivityStreamsActivity()
+	typeProp := streams.NewJSONLDTypeProperty()
+	typeProp.AppendXMLSchemaString(ap.ActivityBite)
+	bite.SetJSONLDType(typeProp)
+
+	// set the actor property to the bite-ing account's URI
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorIRI, err := url.Parse(b.Account.URI)
+	if err != nil {
+		return nil, fmt.Errorf("BiteToAS: error parsing uri %s: %s", b.Account.URI, err)
+	}
+	actorProp.AppendIRI(actorIRI)
+	bite.SetActivityStreamsActor(actorProp)
+
+	// set the ID property to the bite's URI
+	idProp := streams.NewJSONLDIdProperty()
+	idIRI, err := url.Parse(b.URI)
+	if err != nil {
+		return nil, fmt.Errorf("BiteToAS: error parsing uri %s: %s", b.URI, err)
+	}
+	idProp.Set(idIRI)
+	bite.SetJSONLDId(idProp)
+
+	// set the target property to the bitten status or account's URI
+	targetURI := b.TargetAccount.URI
+	if b.Status != nil {
+		targetURI = b.Status.URI
+	}
+	targetProp := streams.NewActivityStreamsTargetProperty()
+	targetIRI, err := url.Parse(targetURI)
+	if err != nil {
+		return nil, fmt.Errorf("BiteToAS: error parsing uri %s: %s", targetURI, err)
+	}
+	targetProp.AppendIRI(targetIRI)
+	bite.SetActivityStreamsTarget(targetProp)
+
+	// set the TO property to the target account's IRI
+	toProp := streams.NewActivityStreamsToProperty()
+	toIRI, err := url.Parse(b.TargetAccount.URI)
+	if err != nil {
+		return nil, fmt.Errorf("BiteToAS: error parsing uri %s: %s", b.TargetAccount.URI, err)
+	}
+	toProp.AppendIRI(toIRI)
+	bite.SetActivityStreamsTo(toProp)
+
+	return bite, nil
+}
+
 /*
 the goal is to end up with something like this:
 
@@ -1362,7 +1811,7 @@ func (c *converter) StatusesToASOutboxPage(ctx context.Context, outboxID string,
 			return nil, err
 		}
 
-		create, err := c.WrapNoteInCreate(note, true)
+		create, err := c.WrapStatusableInCreate(note, true)
 		if err != nil {
 			return nil, err
 		}
@@ -1515,3 +1964,203 @@ func (c *converter) ReportToASFlag(ctx context.Context, r *gtsmodel.Report) (voc
 
 	return flag, nil
 }
+
+func (c *converter) PollVoteToASNotes(ctx context.Context, v *gtsmodel.PollVote) ([]vocab.ActivityStreamsNote, error) {
+	if v.Poll == nil {
+		p, err := c.db.GetPollByID(ctx, v.PollID)
+		if err != nil {
+			return nil, gtserror.Newf("error fetching poll from database: %w", err)
+		}
+		v.Poll = p
+	}
+
+	if v.Poll.Status == nil {
+		s, err := c.db.GetStatusByID(ctx, v.Poll.StatusID)
+		if err != nil {
+			return nil, gtserror.Newf("error fetching poll status from database: %w", err)
+		}
+		v.Poll.Status = s
+	}
+
+	if v.Account == nil {
+		a, err := c.db.GetAccountByID(ctx, v.AccountID)
+		if err != nil {
+			return nil, gtserror.Newf("error fetching voting account from database: %w", err)
+		}
+		v.Account = a
+	}
+
+	actorIRI, err := url.Parse(v.Account.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", v.Account.URI, err)
+	}
+
+	statusIRI, err := url.Parse(v.Poll.Status.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", v.Poll.Status.URI, err)
+	}
+
+	authorIRI, err := url.Parse(v.Poll.Status.AccountURI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", v.Poll.Status.AccountURI, err)
+	}
+
+	notes := make([]vocab.ActivityStreamsNote, 0, len(v.Choices))
+	for i, choice := range v.Choices {
+		if choice < 0 || choice >= len(v.Poll.Options) {
+			return nil, gtserror.Newf("choice %d out of range for poll %s", choice, v.Poll.ID)
+		}
+
+		note := streams.NewActivityStreamsNote()
+
+		// Votes aren't dereferenceable, so
+		// just give each a unique fragment ID
+		// on the voter's URI, like Mastodon.
+		idIRI, err := url.Parse(v.Account.URI + "#votes/" + v.ID + "/" + strconv.Itoa(i))
+		if err != nil {
+			return nil, gtserror.Newf("error parsing vote uri: %w", err)
+		}
+		idProp := streams.NewJSONLDIdProperty()
+		idProp.SetIRI(idIRI)
+		note.SetJSONLDId(idProp)
+
+		attributedToProp := streams.NewActivityStreamsAttributedToProperty()
+		attributedToProp.AppendIRI(actorIRI)
+		note.SetActivityStreamsAttributedTo(attributedToProp)
+
+		// The name of the note is
+		// the title of the chosen option.
+		nameProp := streams.NewActivityStreamsNameProperty()
+		nameProp.AppendXMLSchemaString(v.Poll.Options[choice])
+		note.SetActivityStreamsName(nameProp)
+
+		inReplyToProp := streams.NewActivityStreamsInReplyToProperty()
+		inReplyToProp.AppendIRI(statusIRI)
+		note.SetActivityStreamsInReplyTo(inReplyToProp)
+
+		// Votes are addressed only to the poll's author.
+		toProp := streams.NewActivityStreamsToProperty()
+		toProp.AppendIRI(authorIRI)
+		note.SetActivityStreamsTo(toProp)
+
+		publishedProp := streams.NewActivityStreamsPublishedProperty()
+		publishedProp.Set(v.CreatedAt)
+		note.SetActivityStreamsPublished(publishedProp)
+
+		notes = append(notes, note)
+	}
+
+	return notes, nil
+}
+
+// InteractionRequestToASAccept converts an accepted gts model interaction
+// request into an activityStreams ACCEPT of the interaction, addressed to
+// the interacting account. For example:
+//
+//	{
+//	  "@context": "https://www.w3.org/ns/activitystreams",
+//	  "actor": "https://example.org/users/the_mighty_zork",
+//	  "id": "https://example.org/users/the_mighty_zork/accepts/01H57ZJ8E1V9G3E7ZSXVH2ZMGR",
+//	  "object": "https://fossbros-anonymous.io/users/foss_satan/statuses/01H57ZE3KDSZ1WJ2H5V6T0RXWJ",
+//	  "to": "https://fossbros-anonymous.io/users/foss_satan",
+//	  "type": "Accept"
+//	}
+//
+// The id of the Accept is the URI that approved interactions are
+// stamped with, so it can be dereferenced to verify the approval.
+func (c *converter) InteractionRequestToASAccept(ctx context.Context, req *gtsmodel.InteractionRequest) (vocab.ActivityStreamsAccept, error) {
+	accept := streams.NewActivityStreamsAccept()
+
+	id, actor, object, to, err := c.interactionRequestIRIs(ctx, req)
+	if err != nil {
+		return nil, gtserror.Newf("%w", err)
+	}
+
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.Set(id)
+	accept.SetJSONLDId(idProp)
+
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(actor)
+	accept.SetActivityStreamsActor(actorProp)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(object)
+	accept.SetActivityStreamsObject(objectProp)
+
+	toProp := streams.NewActivityStreamsToProperty()
+	toProp.AppendIRI(to)
+	accept.SetActivityStreamsTo(toProp)
+
+	return accept, nil
+}
+
+// InteractionRequestToASReject converts a rejected gts model interaction
+// request into an activityStreams REJECT of the interaction, addressed
+// to the interacting account.
+func (c *converter) InteractionRequestToASReject(ctx context.Context, req *gtsmodel.InteractionRequest) (vocab.ActivityStreamsReject, error) {
+	reject := streams.NewActivityStreamsReject()
+
+	id, actor, object, to, err := c.interactionRequestIRIs(ctx, req)
+	if err != nil {
+		return nil, gtserror.Newf("%w", err)
+	}
+
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.Set(id)
+	reject.SetJSONLDId(idProp)
+
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(actor)
+	reject.SetActivityStreamsActor(actorProp)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(object)
+	reject.SetActivityStreamsObject(objectProp)
+
+	toProp := streams.NewActivityStreamsToProperty()
+	toProp.AppendIRI(to)
+	reject.SetActivityStreamsTo(toProp)
+
+	return reject, nil
+}
+
+// interactionRequestIRIs returns the id, actor, object and to
+// IRIs of an Accept or Reject of the given interaction request.
+func (c *converter) interactionRequestIRIs(ctx context.Context, req *gtsmodel.InteractionRequest) (id, actor, object, to *url.URL, err error) {
+	if req.URI == "" {
+		err = fmt.Errorf("interaction request %s has not been accepted or rejected", req.ID)
+		return
+	}
+
+	if req.TargetAccount == nil {
+		req.TargetAccount, err = c.db.GetAccountByID(ctx, req.TargetAccountID)
+		if err != nil {
+			err = fmt.Errorf("error getting target account %s: %w", req.TargetAccountID, err)
+			return
+		}
+	}
+
+	if req.InteractingAccount == nil {
+		req.InteractingAccount, err = c.db.GetAccountByID(ctx, req.InteractingAccountID)
+		if err != nil {
+			err = fmt.Errorf("error getting interacting account %s: %w", req.InteractingAccountID, err)
+			return
+		}
+	}
+
+	if id, err = url.Parse(req.URI); err != nil {
+		return
+	}
+
+	if actor, err = url.Parse(req.TargetAccount.URI); err != nil {
+		return
+	}
+
+	if object, err = url.Parse(req.InteractionURI); err != nil {
+		return
+	}
+
+	to, err = url.Parse(req.InteractingAccount.URI)
+	return
+}
@@ -340,6 +340,9 @@ func (suite *InternalToASTestSuite) TestStatusToAS() {
   "attributedTo": "http://localhost:8080/users/the_mighty_zork",
   "cc": "http://localhost:8080/users/the_mighty_zork/followers",
   "content": "hello everyone!",
+  "contentMap": {
+    "en": "hello everyone!"
+  },
   "id": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
   "published": "2021-10-20T12:40:37+02:00",
   "replies": {
@@ -361,6 +364,68 @@ func (suite *InternalToASTestSuite) TestStatusToAS() {
 }`, string(bytes))
 }
 
+func (suite *InternalToASTestSuite) TestStatusToASMutualsOnly() {
+	testStatus := &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["local_account_1_status_1"]
+	testStatus.Visibility = gtsmodel.VisibilityMutualsOnly
+	ctx := context.Background()
+
+	asStatus, err := suite.typeconverter.StatusToAS(ctx, testStatus)
+	suite.NoError(err)
+
+	// Mutuals should not be revealed in the
+	// serialized status, and neither the public
+	// nor followers collections should be addressed.
+	suite.Empty(ap.ExtractToURIs(asStatus))
+	suite.Empty(ap.ExtractCcURIs(asStatus))
+}
+
+func (suite *InternalToASTestSuite) TestStatusToASWithLocation() {
+	testStatus := &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["local_account_1_status_1"]
+	testStatus.LocationName = "The Old Tree, Slothville"
+	latitude, longitude := 51.50135, -0.14189
+	testStatus.LocationLatitude = &latitude
+	testStatus.LocationLongitude = &longitude
+	ctx := context.Background()
+
+	asStatus, err := suite.typeconverter.StatusToAS(ctx, testStatus)
+	suite.NoError(err)
+
+	ser, err := ap.Serialize(asStatus)
+	suite.NoError(err)
+
+	bytes, err := json.MarshalIndent(ser["location"], "", "  ")
+	suite.NoError(err)
+
+	// Coordinates should never be federated
+	// with more precision than we keep.
+	suite.Equal(`{
+  "latitude": 51.501,
+  "longitude": -0.142,
+  "name": "The Old Tree, Slothville",
+  "type": "Place"
+}`, string(bytes))
+
+	// Name only should federate without coordinates.
+	testStatus.LocationLatitude = nil
+	testStatus.LocationLongitude = nil
+
+	asStatus, err = suite.typeconverter.StatusToAS(ctx, testStatus)
+	suite.NoError(err)
+
+	ser, err = ap.Serialize(asStatus)
+	suite.NoError(err)
+
+	bytes, err = json.MarshalIndent(ser["location"], "", "  ")
+	suite.NoError(err)
+
+	suite.Equal(`{
+  "name": "The Old Tree, Slothville",
+  "type": "Place"
+}`, string(bytes))
+}
+
 func (suite *InternalToASTestSuite) TestStatusWithTagsToASWithIDs() {
 	// use the status with just IDs of attachments and emojis pinned on it
 	testStatus := suite.testStatuses["admin_account_status_1"]
@@ -389,6 +454,9 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASWithIDs() {
   "attributedTo": "http://localhost:8080/users/admin",
   "cc": "http://localhost:8080/users/admin/followers",
   "content": "hello world! #welcome ! first post on the instance :rainbow: !",
+  "contentMap": {
+    "en": "hello world! #welcome ! first post on the instance :rainbow: !"
+  },
   "id": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R",
   "published": "2021-10-20T11:36:45Z",
   "replies": {
@@ -403,17 +471,24 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASWithIDs() {
   },
   "sensitive": false,
   "summary": "",
-  "tag": {
-    "icon": {
-      "mediaType": "image/png",
-      "type": "Image",
-      "url": "http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png"
+  "tag": [
+    {
+      "icon": {
+        "mediaType": "image/png",
+        "type": "Image",
+        "url": "http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png"
+      },
+      "id": "http://localhost:8080/emoji/01F8MH9H8E4VG3KDYJR9EGPXCQ",
+      "name": ":rainbow:",
+      "type": "Emoji",
+      "updated": "2021-09-20T10:40:37Z"
     },
-    "id": "http://localhost:8080/emoji/01F8MH9H8E4VG3KDYJR9EGPXCQ",
-    "name": ":rainbow:",
-    "type": "Emoji",
-    "updated": "2021-09-20T10:40:37Z"
-  },
+    {
+      "href": "http://localhost:8080/tags/welcome",
+      "name": "#welcome",
+      "type": "Hashtag"
+    }
+  ],
   "to": "https://www.w3.org/ns/activitystreams#Public",
   "type": "Note",
   "url": "http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R"
@@ -449,6 +524,9 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASFromDB() {
   "attributedTo": "http://localhost:8080/users/admin",
   "cc": "http://localhost:8080/users/admin/followers",
   "content": "hello world! #welcome ! first post on the instance :rainbow: !",
+  "contentMap": {
+    "en": "hello world! #welcome ! first post on the instance :rainbow: !"
+  },
   "id": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R",
   "published": "2021-10-20T11:36:45Z",
   "replies": {
@@ -463,17 +541,24 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASFromDB() {
   },
   "sensitive": false,
   "summary": "",
-  "tag": {
-    "icon": {
-      "mediaType": "image/png",
-      "type": "Image",
-      "url": "http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png"
+  "tag": [
+    {
+      "icon": {
+        "mediaType": "image/png",
+        "type": "Image",
+        "url": "http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png"
+      },
+      "id": "http://localhost:8080/emoji/01F8MH9H8E4VG3KDYJR9EGPXCQ",
+      "name": ":rainbow:",
+      "type": "Emoji",
+      "updated": "2021-09-20T10:40:37Z"
     },
-    "id": "http://localhost:8080/emoji/01F8MH9H8E4VG3KDYJR9EGPXCQ",
-    "name": ":rainbow:",
-    "type": "Emoji",
-    "updated": "2021-09-20T10:40:37Z"
-  },
+    {
+      "href": "http://localhost:8080/tags/welcome",
+      "name": "#welcome",
+      "type": "Hashtag"
+    }
+  ],
   "to": "https://www.w3.org/ns/activitystreams#Public",
   "type": "Note",
   "url": "http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R"
@@ -505,6 +590,9 @@ func (suite *InternalToASTestSuite) TestStatusToASWithMentions() {
     "http://localhost:8080/users/the_mighty_zork"
   ],
   "content": "hi @the_mighty_zork welcome to the instance!",
+  "contentMap": {
+    "en": "hi @the_mighty_zork welcome to the instance!"
+  },
   "id": "http://localhost:8080/users/admin/statuses/01FF25D5Q0DH7CHD57CTRS6WK0",
   "inReplyTo": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
   "published": "2021-11-20T13:32:16Z",
@@ -646,7 +734,7 @@ func (suite *InternalToASTestSuite) TestStatusesToASOutboxPage() {
 	ctx := context.Background()
 
 	// get public statuses from testaccount
-	statuses, err := suite.db.GetAccountStatuses(ctx, testAccount.ID, 30, true, true, "", "", false, true)
+	statuses, err := suite.db.GetAccountStatuses(ctx, testAccount.ID, 30, true, true, "", "", false, false, true, false)
 	suite.NoError(err)
 
 	page, err := suite.typeconverter.StatusesToASOutboxPage(ctx, testAccount.OutboxURI, "", "", statuses)
@@ -849,6 +937,69 @@ func (suite *InternalToASTestSuite) TestPinnedStatusesToASOneItem() {
 }`, string(bytes))
 }
 
+func (suite *InternalToASTestSuite) statusWithPollToAS(multiple bool) map[string]interface{} {
+	testStatus := new(gtsmodel.Status)
+	*testStatus = *suite.testStatuses["local_account_1_status_1"]
+	testStatus.PollID = "01H7JXBQ4M8V5ZC0WGR6E3V1YS"
+	testStatus.Poll = &gtsmodel.Poll{
+		ID:         testStatus.PollID,
+		StatusID:   testStatus.ID,
+		Options:    []string{"tabs", "spaces"},
+		Votes:      []int{1, 2},
+		Voters:     3,
+		Multiple:   testrig.FalseBool(),
+		HideCounts: testrig.FalseBool(),
+	}
+	if multiple {
+		testStatus.Poll.Multiple = testrig.TrueBool()
+	}
+
+	asStatus, err := suite.typeconverter.StatusToAS(context.Background(), testStatus)
+	suite.NoError(err)
+
+	ser, err := ap.Serialize(asStatus)
+	suite.NoError(err)
+
+	return ser
+}
+
+func (suite *InternalToASTestSuite) TestStatusWithPollToASOneOf() {
+	ser := suite.statusWithPollToAS(false)
+
+	suite.Equal("Question", ser["type"])
+	suite.Nil(ser["anyOf"])
+	suite.EqualValues(3, ser["votersCount"])
+
+	bytes, err := json.MarshalIndent(ser["oneOf"], "", "  ")
+	suite.NoError(err)
+	suite.Equal(`[
+  {
+    "name": "tabs",
+    "replies": {
+      "totalItems": 1,
+      "type": "Collection"
+    },
+    "type": "Note"
+  },
+  {
+    "name": "spaces",
+    "replies": {
+      "totalItems": 2,
+      "type": "Collection"
+    },
+    "type": "Note"
+  }
+]`, string(bytes))
+}
+
+func (suite *InternalToASTestSuite) TestStatusWithPollToASAnyOf() {
+	ser := suite.statusWithPollToAS(true)
+
+	suite.Equal("Question", ser["type"])
+	suite.Nil(ser["oneOf"])
+	suite.NotNil(ser["anyOf"])
+}
+
 func TestInternalToASTestSuite(t *testing.T) {
 	suite.Run(t, new(InternalToASTestSuite))
 }
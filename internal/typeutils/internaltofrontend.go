@@ -21,18 +21,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"html"
 	"math"
 	"strconv"
 	"strings"
 
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
 const (
@@ -52,6 +57,14 @@ var instanceStatusesSupportedMimeTypes = []string{
 	string(apimodel.StatusContentTypeMarkdown),
 }
 
+var instanceStatusesSupportedVisibilities = []string{
+	string(apimodel.VisibilityPublic),
+	string(apimodel.VisibilityUnlisted),
+	string(apimodel.VisibilityPrivate),
+	string(apimodel.VisibilityMutualsOnly),
+	string(apimodel.VisibilityDirect),
+}
+
 func (c *converter) AccountToAPIAccountSensitive(ctx context.Context, a *gtsmodel.Account) (*apimodel.Account, error) {
 	// we can build this sensitive account easily by first getting the public account....
 	apiAccount, err := c.AccountToAPIAccountPublic(ctx, a)
@@ -72,6 +85,11 @@ func (c *converter) AccountToAPIAccountSensitive(ctx context.Context, a *gtsmode
 		statusContentType = a.StatusContentType
 	}
 
+	mentionPolicy := gtsmodel.MentionPolicyDefault
+	if a.MentionPolicy != "" {
+		mentionPolicy = a.MentionPolicy
+	}
+
 	apiAccount.Source = &apimodel.Source{
 		Privacy:             c.VisToAPIVis(ctx, a.Privacy),
 		Sensitive:           *a.Sensitive,
@@ -80,6 +98,24 @@ func (c *converter) AccountToAPIAccountSensitive(ctx context.Context, a *gtsmode
 		Note:                a.NoteRaw,
 		Fields:              c.fieldsToAPIFields(a.FieldsRaw),
 		FollowRequestsCount: frc,
+		HideLinkPreviews:    apiAccount.HideLinkPreviews,
+		AttributionDomains:  a.AttributionDomains,
+		MentionPolicy:       string(mentionPolicy),
+	}
+
+	if apiAccount.Source.AttributionDomains == nil {
+		apiAccount.Source.AttributionDomains = []string{}
+	}
+
+	// Let the user know if their account is
+	// pending deletion, so they can cancel it.
+	user, err := c.db.GetUserByAccountID(ctx, a.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+
+	if user != nil && !user.DeleteAt.IsZero() {
+		apiAccount.Source.DeleteAt = util.FormatISO8601(user.DeleteAt)
 	}
 
 	return apiAccount, nil
@@ -224,6 +260,10 @@ func (c *converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		Role:           role,
 	}
 
+	if a.HideLinkPreviews != nil {
+		accountFrontend.HideLinkPreviews = *a.HideLinkPreviews
+	}
+
 	// Bodge default avatar + header in,
 	// if we didn't have one already.
 	c.ensureAvatar(accountFrontend)
@@ -443,6 +483,15 @@ func (c *converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.M
 		apiAttachment.Description = &i
 	}
 
+	switch a.Processing {
+	case gtsmodel.ProcessingStatusProcessed:
+		apiAttachment.ProcessingStatus = "succeeded"
+	case gtsmodel.ProcessingStatusError:
+		apiAttachment.ProcessingStatus = "failed"
+	default:
+		apiAttachment.ProcessingStatus = "processing"
+	}
+
 	// type specific fields
 	switch a.Type {
 	case gtsmodel.FileTypeImage:
@@ -544,7 +593,7 @@ func (c *converter) EmojiToAdminAPIEmoji(ctx context.Context, e *gtsmodel.Emoji)
 		}
 	}
 
-	return &apimodel.AdminEmoji{
+	adminEmoji := &apimodel.AdminEmoji{
 		Emoji:         emoji,
 		ID:            e.ID,
 		Disabled:      *e.Disabled,
@@ -553,23 +602,117 @@ func (c *converter) EmojiToAdminAPIEmoji(ctx context.Context, e *gtsmodel.Emoji)
 		TotalFileSize: e.ImageFileSize + e.ImageStaticFileSize,
 		ContentType:   e.ImageContentType,
 		URI:           e.URI,
+	}
+
+	if e.AccountID != "" {
+		// Personal emoji, show
+		// admins who owns it.
+		owner, err := c.db.GetAccountByID(gtscontext.SetBarebones(ctx), e.AccountID)
+		if err != nil {
+			err = fmt.Errorf("EmojiToAdminAPIEmoji: error getting owner account %s for emoji id %s: %w", e.AccountID, e.ID, err)
+			return nil, err
+		}
+
+		adminEmoji.OwnerAccountID = owner.ID
+		adminEmoji.OwnerUsername = owner.Username
+	}
+
+	return adminEmoji, nil
+}
+
+func (c *converter) EmojiToPersonalAPIEmoji(ctx context.Context, e *gtsmodel.Emoji) (*apimodel.PersonalEmoji, error) {
+	emoji, err := c.EmojiToAPIEmoji(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apimodel.PersonalEmoji{
+		Emoji: emoji,
+		ID:    e.ID,
 	}, nil
 }
 
 func (c *converter) EmojiCategoryToAPIEmojiCategory(ctx context.Context, category *gtsmodel.EmojiCategory) (*apimodel.EmojiCategory, error) {
 	return &apimodel.EmojiCategory{
-		ID:   category.ID,
-		Name: category.Name,
+		ID:       category.ID,
+		Name:     category.Name,
+		Position: category.Position,
+	}, nil
+}
+
+func (c *converter) WebAuthnCredentialToAPIWebAuthnCredential(ctx context.Context, wc *gtsmodel.WebAuthnCredential) (*apimodel.WebAuthnCredential, error) {
+	var lastUsedAt *string
+	if !wc.LastUsedAt.IsZero() {
+		t := util.FormatISO8601(wc.LastUsedAt)
+		lastUsedAt = &t
+	}
+
+	return &apimodel.WebAuthnCredential{
+		ID:         wc.ID,
+		Name:       wc.Name,
+		CreatedAt:  util.FormatISO8601(wc.CreatedAt),
+		LastUsedAt: lastUsedAt,
+	}, nil
+}
+
+func (c *converter) WebSessionToAPIWebSession(ctx context.Context, s *gtsmodel.WebSession) (*apimodel.WebSession, error) {
+	var (
+		lastSeenAt *string
+		lastSeenIP *string
+	)
+
+	if !s.LastSeenAt.IsZero() {
+		t := util.FormatISO8601(s.LastSeenAt)
+		lastSeenAt = &t
+	}
+
+	if s.LastSeenIP != nil {
+		ip := s.LastSeenIP.String()
+		lastSeenIP = &ip
+	}
+
+	var ip string
+	if s.IP != nil {
+		ip = s.IP.String()
+	}
+
+	return &apimodel.WebSession{
+		ID:         s.ID,
+		CreatedAt:  util.FormatISO8601(s.CreatedAt),
+		IP:         ip,
+		UserAgent:  s.UserAgent,
+		LastSeenAt: lastSeenAt,
+		LastSeenIP: lastSeenIP,
+		IPChanged:  s.IPChanged != nil && *s.IPChanged,
 	}, nil
 }
 
 func (c *converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag) (apimodel.Tag, error) {
+	name := t.DisplayName
+	if name == "" {
+		name = t.Name
+	}
+
 	return apimodel.Tag{
-		Name: t.Name,
+		Name: name,
 		URL:  t.URL,
 	}, nil
 }
 
+func (c *converter) FeaturedTagToAPIFeaturedTag(ctx context.Context, f *gtsmodel.FeaturedTag, statusesCount int) (*apimodel.FeaturedTag, error) {
+	name := f.DisplayName
+	if name == "" {
+		name = f.Name
+	}
+
+	return &apimodel.FeaturedTag{
+		ID:            f.ID,
+		Name:          name,
+		URL:           f.URL,
+		StatusesCount: statusesCount,
+	}, nil
+}
+
 func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, requestingAccount *gtsmodel.Account) (*apimodel.Status, error) {
 	if err := c.db.PopulateStatus(ctx, s); err != nil {
 		// Ensure author account present + correct;
@@ -601,6 +744,16 @@ func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, r
 		return nil, fmt.Errorf("error counting faves: %w", err)
 	}
 
+	// Remote statuses may have been interacted with far more
+	// than we know about locally, so use the counts reported
+	// by the origin server where these are larger.
+	if s.BoostCountRemote > reblogsCount {
+		reblogsCount = s.BoostCountRemote
+	}
+	if s.FaveCountRemote > favesCount {
+		favesCount = s.FaveCountRemote
+	}
+
 	interacts, err := c.interactionsWithStatusForAccount(ctx, s, requestingAccount)
 	if err != nil {
 		log.Errorf(ctx, "error getting interactions for status %s for account %s: %v", s.ID, requestingAccount.ID, err)
@@ -649,6 +802,7 @@ func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, r
 		Reblogged:          interacts.Reblogged,
 		Pinned:             interacts.Pinned,
 		Content:            s.Content,
+		ContentMap:         s.ContentMap,
 		Reblog:             nil,
 		Application:        nil,
 		Account:            apiAuthorAccount,
@@ -656,9 +810,10 @@ func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, r
 		Mentions:           apiMentions,
 		Tags:               apiTags,
 		Emojis:             apiEmojis,
-		Card:               nil, // TODO: implement cards
-		Poll:               nil, // TODO: implement polls
+		Card:               nil,
+		Poll:               nil,
 		Text:               s.Text,
+		ContentType:        apimodel.StatusContentType(s.ContentType),
 	}
 
 	// Nullable fields.
@@ -675,6 +830,78 @@ func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, r
 		apiStatus.Language = func() *string { i := s.Language; return &i }()
 	}
 
+	if config.GetStatusShowDislikes() {
+		dislikesCount, err := c.db.CountStatusDislikes(ctx, s.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error counting dislikes: %w", err)
+		}
+		apiStatus.DislikesCount = &dislikesCount
+	}
+
+	if s.PollID != "" {
+		if s.Poll == nil {
+			s.Poll, err = c.db.GetPollByID(ctx, s.PollID)
+		}
+
+		if s.Poll != nil {
+			s.Poll.Status = s
+			apiStatus.Poll, err = c.PollToAPIPoll(ctx, requestingAccount, s.Poll)
+		}
+
+		if err != nil {
+			log.Errorf(ctx, "error converting status poll: %v", err)
+		}
+	}
+
+	if s.PreviewCardID != "" {
+		if s.PreviewCard == nil {
+			s.PreviewCard, err = c.db.GetPreviewCardByID(ctx, s.PreviewCardID)
+		}
+
+		if s.PreviewCard != nil {
+			apiStatus.Card, err = c.PreviewCardToAPICard(ctx, s.PreviewCard)
+		}
+
+		if apiStatus.Card != nil && apiStatus.Card.Image != "" && config.GetMediaProxyEnabled() {
+			// Don't make clients fetch the
+			// image from the remote site.
+			apiStatus.Card.Image = uris.GenerateURIForProxiedImage(s.ID, s.PreviewCard.Image)
+		}
+
+		if err != nil {
+			log.Errorf(ctx, "error converting status preview card: %v", err)
+		}
+	}
+
+	if s.ActivityStreamsType == ap.ObjectEvent {
+		apiStatus.Event = &apimodel.StatusEvent{
+			Name:     s.EventName,
+			Location: s.EventLocation,
+		}
+
+		if !s.EventStartAt.IsZero() {
+			apiStatus.Event.StartTime = util.FormatISO8601(s.EventStartAt)
+		}
+
+		if !s.EventEndAt.IsZero() {
+			apiStatus.Event.EndTime = util.FormatISO8601(s.EventEndAt)
+		}
+
+		if s.EventName != "" {
+			// Most clients don't know about events, so
+			// include the event name in content as well.
+			apiStatus.Content = "<p><strong>" + html.EscapeString(s.EventName) + "</strong></p>" + s.Content
+		}
+	}
+
+	if s.LocationName != "" {
+		apiStatus.Location = &apimodel.StatusLocation{
+			Name:      s.LocationName,
+			Latitude:  s.LocationLatitude,
+			Longitude: s.LocationLongitude,
+		}
+	}
+
 	if s.BoostOf != nil {
 		apiBoostOf, err := c.StatusToAPIStatus(ctx, s.BoostOf, requestingAccount)
 		if err != nil {
@@ -745,7 +972,8 @@ func (c *converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 	instance.Configuration.Statuses.MaxMediaAttachments = config.GetStatusesMediaMaxFiles()
 	instance.Configuration.Statuses.CharactersReservedPerURL = instanceStatusesCharactersReservedPerURL
 	instance.Configuration.Statuses.SupportedMimeTypes = instanceStatusesSupportedMimeTypes
-	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.SupportedMIMETypes
+	instance.Configuration.Statuses.SupportedVisibilities = instanceStatusesSupportedVisibilities
+	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.AllowedMIMETypes()
 	instance.Configuration.MediaAttachments.ImageSizeLimit = int(config.GetMediaImageMaxSize())
 	instance.Configuration.MediaAttachments.ImageMatrixLimit = instanceMediaAttachmentsImageMatrixLimit
 	instance.Configuration.MediaAttachments.VideoSizeLimit = int(config.GetMediaVideoMaxSize())
@@ -836,7 +1064,21 @@ func (c *converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 		Description:   i.Description,
 		Usage:         apimodel.InstanceV2Usage{}, // todo: not implemented
 		Languages:     []string{},                 // todo: not implemented
-		Rules:         []interface{}{},            // todo: not implemented
+	}
+
+	// rules
+	rules, err := c.db.GetRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("InstanceToAPIV2Instance: db error getting instance rules: %w", err)
+	}
+
+	instance.Rules = make([]apimodel.InstanceRule, 0, len(rules))
+	for _, r := range rules {
+		rule, err := c.RuleToAPIRule(ctx, r)
+		if err != nil {
+			return nil, fmt.Errorf("InstanceToAPIV2Instance: error converting instance rule %s: %w", r.ID, err)
+		}
+		instance.Rules = append(instance.Rules, *rule)
 	}
 
 	// thumbnail
@@ -872,7 +1114,15 @@ func (c *converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 	instance.Configuration.Statuses.MaxMediaAttachments = config.GetStatusesMediaMaxFiles()
 	instance.Configuration.Statuses.CharactersReservedPerURL = instanceStatusesCharactersReservedPerURL
 	instance.Configuration.Statuses.SupportedMimeTypes = instanceStatusesSupportedMimeTypes
-	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.SupportedMIMETypes
+	instance.Configuration.Statuses.SupportedVisibilities = instanceStatusesSupportedVisibilities
+	instance.Configuration.Statuses.MaxCharactersByVisibility = map[string]int{
+		string(apimodel.VisibilityPublic):      validate.StatusMaxChars(gtsmodel.VisibilityPublic),
+		string(apimodel.VisibilityUnlisted):    validate.StatusMaxChars(gtsmodel.VisibilityUnlocked),
+		string(apimodel.VisibilityPrivate):     validate.StatusMaxChars(gtsmodel.VisibilityFollowersOnly),
+		string(apimodel.VisibilityMutualsOnly): validate.StatusMaxChars(gtsmodel.VisibilityMutualsOnly),
+		string(apimodel.VisibilityDirect):      validate.StatusMaxChars(gtsmodel.VisibilityDirect),
+	}
+	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.AllowedMIMETypes()
 	instance.Configuration.MediaAttachments.ImageSizeLimit = int(config.GetMediaImageMaxSize())
 	instance.Configuration.MediaAttachments.ImageMatrixLimit = instanceMediaAttachmentsImageMatrixLimit
 	instance.Configuration.MediaAttachments.VideoSizeLimit = int(config.GetMediaVideoMaxSize())
@@ -1015,6 +1265,12 @@ func (c *converter) DomainBlockToAPIDomainBlock(ctx context.Context, b *gtsmodel
 		domainBlock.SubscriptionID = b.SubscriptionID
 		domainBlock.CreatedBy = b.CreatedByAccountID
 		domainBlock.CreatedAt = util.FormatISO8601(b.CreatedAt)
+		domainBlock.Severity = string(gtsmodel.DomainBlockSeveritySuspend)
+		if b.IsSilence() {
+			domainBlock.Severity = string(gtsmodel.DomainBlockSeveritySilence)
+		}
+		domainBlock.RejectMedia = b.RejectMedia != nil && *b.RejectMedia
+		domainBlock.RejectReports = b.RejectReports != nil && *b.RejectReports
 	}
 
 	return domainBlock, nil
@@ -1058,6 +1314,26 @@ func (c *converter) ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (
 	return report, nil
 }
 
+func (c *converter) QueuedEmailToAdminAPIFailedEmail(ctx context.Context, e *gtsmodel.QueuedEmail) (*apimodel.AdminFailedEmail, error) {
+	return &apimodel.AdminFailedEmail{
+		ID:        e.ID,
+		CreatedAt: util.FormatISO8601(e.CreatedAt),
+		To:        e.ToAddresses,
+		Subject:   e.Subject,
+		Attempts:  e.Attempts,
+		LastError: e.LastError,
+		FailedAt:  util.FormatISO8601(e.FailedAt),
+	}, nil
+}
+
+func (c *converter) HashtagSettingToAdminAPIHashtag(ctx context.Context, h *gtsmodel.HashtagSetting) (*apimodel.AdminHashtag, error) {
+	return &apimodel.AdminHashtag{
+		Name:                   h.Name,
+		ContentWarningRequired: *h.ContentWarningRequired,
+		UpdatedAt:              util.FormatISO8601(h.UpdatedAt),
+	}, nil
+}
+
 func (c *converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Report, requestingAccount *gtsmodel.Account) (*apimodel.AdminReport, error) {
 	var (
 		err                  error
@@ -1153,6 +1429,252 @@ func (c *converter) ListToAPIList(ctx context.Context, l *gtsmodel.List) (*apimo
 	}, nil
 }
 
+func (c *converter) RuleToAPIRule(ctx context.Context, r *gtsmodel.Rule) (*apimodel.InstanceRule, error) {
+	return &apimodel.InstanceRule{
+		ID:   r.ID,
+		Text: r.Text,
+	}, nil
+}
+
+func (c *converter) InteractionRequestToAPIInteractionRequest(ctx context.Context, req *gtsmodel.InteractionRequest, requestingAccount *gtsmodel.Account) (*apimodel.InteractionRequest, error) {
+	apiReq := &apimodel.InteractionRequest{
+		ID:        req.ID,
+		Type:      string(req.InteractionType),
+		CreatedAt: util.FormatISO8601(req.CreatedAt),
+		URI:       req.URI,
+	}
+
+	if req.IsAccepted() {
+		apiReq.AcceptedAt = util.FormatISO8601(req.AcceptedAt)
+	}
+
+	if req.IsRejected() {
+		apiReq.RejectedAt = util.FormatISO8601(req.RejectedAt)
+	}
+
+	if req.InteractingAccount == nil {
+		a, err := c.db.GetAccountByID(ctx, req.InteractingAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("InteractionRequestToAPIInteractionRequest: error getting interacting account %s: %w", req.InteractingAccountID, err)
+		}
+		req.InteractingAccount = a
+	}
+
+	apiAccount, err := c.AccountToAPIAccountPublic(ctx, req.InteractingAccount)
+	if err != nil {
+		return nil, fmt.Errorf("InteractionRequestToAPIInteractionRequest: error converting interacting account to api: %w", err)
+	}
+	apiReq.Account = apiAccount
+
+	if req.Status == nil {
+		s, err := c.db.GetStatusByID(ctx, req.StatusID)
+		if err != nil {
+			return nil, fmt.Errorf("InteractionRequestToAPIInteractionRequest: error getting status %s: %w", req.StatusID, err)
+		}
+		req.Status = s
+	}
+
+	apiStatus, err := c.StatusToAPIStatus(ctx, req.Status, requestingAccount)
+	if err != nil {
+		return nil, fmt.Errorf("InteractionRequestToAPIInteractionRequest: error converting status to api: %w", err)
+	}
+	apiReq.Status = apiStatus
+
+	if req.Reply != nil {
+		apiReply, err := c.StatusToAPIStatus(ctx, req.Reply, requestingAccount)
+		if err != nil {
+			return nil, fmt.Errorf("InteractionRequestToAPIInteractionRequest: error converting reply to api: %w", err)
+		}
+		apiReq.Reply = apiReply
+	}
+
+	return apiReq, nil
+}
+
+func (c *converter) PreviewCardToAPICard(ctx context.Context, card *gtsmodel.PreviewCard) (*apimodel.Card, error) {
+	apiCard := &apimodel.Card{
+		URL:          card.URL,
+		Title:        card.Title,
+		Description:  card.Description,
+		Type:         "link",
+		HTML:         card.HTML,
+		AuthorName:   card.AuthorName,
+		AuthorURL:    card.AuthorURL,
+		ProviderName: card.ProviderName,
+		ProviderURL:  card.ProviderURL,
+		Width:        card.Width,
+		Height:       card.Height,
+		Image:        card.Image,
+		Authors:      []apimodel.CardAuthor{},
+	}
+
+	if card.Type != "" {
+		apiCard.Type = card.Type
+	}
+
+	if card.AuthorAccountID != "" && card.AuthorAccount == nil {
+		var err error
+		card.AuthorAccount, err = c.db.GetAccountByID(ctx, card.AuthorAccountID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("error getting card author account: %w", err)
+		}
+	}
+
+	switch {
+	case card.AuthorAccount != nil && card.AuthorAccount.SuspendedAt.IsZero():
+		// Page credits a fediverse account,
+		// and was verified as allowed to.
+		apiAccount, err := c.AccountToAPIAccountPublic(ctx, card.AuthorAccount)
+		if err != nil {
+			return nil, gtserror.Newf("error converting card author account: %w", err)
+		}
+
+		name := card.AuthorName
+		if name == "" {
+			name = apiAccount.DisplayName
+		}
+		if name == "" {
+			name = apiAccount.Username
+		}
+
+		apiCard.Authors = append(apiCard.Authors, apimodel.CardAuthor{
+			Name:    name,
+			URL:     apiAccount.URL,
+			Account: apiAccount,
+		})
+
+	case card.AuthorName != "":
+		apiCard.Authors = append(apiCard.Authors, apimodel.CardAuthor{
+			Name: card.AuthorName,
+			URL:  card.AuthorURL,
+		})
+	}
+
+	return apiCard, nil
+}
+
+func (c *converter) PollToAPIPoll(ctx context.Context, requestingAccount *gtsmodel.Account, p *gtsmodel.Poll) (*apimodel.Poll, error) {
+	if p.Status == nil {
+		status, err := c.db.GetStatusByID(ctx, p.StatusID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting poll status: %w", err)
+		}
+		p.Status = status
+	}
+
+	var (
+		voted    bool
+		ownVotes []int
+		isAuthor = requestingAccount != nil && requestingAccount.ID == p.Status.AccountID
+	)
+
+	switch {
+	case isAuthor:
+		// Authors count as having
+		// voted in their own poll.
+		voted = true
+	case requestingAccount != nil:
+		vote, err := c.db.GetPollVoteBy(ctx, p.ID, requestingAccount.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, fmt.Errorf("error getting poll vote: %w", err)
+		}
+		if vote != nil {
+			voted = true
+			ownVotes = vote.Choices
+		}
+	}
+
+	// Counts per option are hidden from everyone
+	// but the author until the poll has ended, if
+	// the author chose to hide them.
+	hideCounts := p.HideCounts != nil && *p.HideCounts && !p.Expired() && !isAuthor
+
+	votesCount := 0
+	options := make([]apimodel.PollOptions, len(p.Options))
+	for i, title := range p.Options {
+		options[i].Title = title
+		if i < len(p.Votes) {
+			votesCount += p.Votes[i]
+			if !hideCounts {
+				options[i].VotesCount = p.Votes[i]
+			}
+		}
+	}
+
+	apiPoll := &apimodel.Poll{
+		ID:         p.ID,
+		Expired:    p.Expired(),
+		Multiple:   p.Multiple != nil && *p.Multiple,
+		VotesCount: votesCount,
+		Voted:      voted,
+		OwnVotes:   ownVotes,
+		Options:    options,
+		Emojis:     []apimodel.Emoji{},
+	}
+
+	if apiPoll.Multiple {
+		apiPoll.VotersCount = p.Voters
+	}
+
+	if !p.ExpiresAt.IsZero() {
+		apiPoll.ExpiresAt = util.FormatISO8601(p.ExpiresAt)
+	}
+
+	return apiPoll, nil
+}
+
+func (c *converter) DraftToAPIDraft(ctx context.Context, d *gtsmodel.Draft) (*apimodel.Draft, error) {
+	apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx, nil, d.AttachmentIDs)
+	if err != nil {
+		// Attachments may have been deleted
+		// since the draft was last updated.
+		log.Errorf(ctx, "error converting draft attachments: %v", err)
+	}
+
+	apiDraft := &apimodel.Draft{
+		ID:               d.ID,
+		CreatedAt:        util.FormatISO8601(d.CreatedAt),
+		UpdatedAt:        util.FormatISO8601(d.UpdatedAt),
+		Status:           d.Text,
+		SpoilerText:      d.ContentWarning,
+		Sensitive:        d.Sensitive != nil && *d.Sensitive,
+		InReplyToID:      d.InReplyToID,
+		MediaIDs:         d.AttachmentIDs,
+		MediaAttachments: apiAttachments,
+		Language:         d.Language,
+		ContentType:      apimodel.StatusContentType(d.ContentType),
+	}
+
+	if apiDraft.MediaIDs == nil {
+		apiDraft.MediaIDs = []string{}
+	}
+
+	if d.Visibility != "" {
+		apiDraft.Visibility = c.VisToAPIVis(ctx, d.Visibility)
+	}
+
+	if len(d.PollOptions) != 0 {
+		apiDraft.Poll = &apimodel.PollRequest{
+			Options:    d.PollOptions,
+			ExpiresIn:  d.PollExpiresIn,
+			Multiple:   d.PollMultiple != nil && *d.PollMultiple,
+			HideTotals: d.PollHideTotals != nil && *d.PollHideTotals,
+		}
+	}
+
+	return apiDraft, nil
+}
+
+func (c *converter) ListenToAPINowPlaying(ctx context.Context, l *gtsmodel.Listen) (*apimodel.NowPlaying, error) {
+	return &apimodel.NowPlaying{
+		TrackName:  l.TrackName,
+		ArtistName: l.ArtistName,
+		AlbumName:  l.AlbumName,
+		ListenedAt: util.FormatISO8601(l.CreatedAt),
+		Active:     l.Active(),
+	}, nil
+}
+
 // convertAttachmentsToAPIAttachments will convert a slice of GTS model attachments to frontend API model attachments, falling back to IDs if no GTS models supplied.
 func (c *converter) convertAttachmentsToAPIAttachments(ctx context.Context, attachments []*gtsmodel.MediaAttachment, attachmentIDs []string) ([]apimodel.Attachment, error) {
 	var errs gtserror.MultiError
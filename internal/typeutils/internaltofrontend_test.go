@@ -203,7 +203,10 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendSensitive() {
     "status_content_type": "text/plain",
     "note": "hey yo this is my profile!",
     "fields": [],
-    "follow_requests_count": 0
+    "follow_requests_count": 0,
+    "hide_link_previews": false,
+    "attribution_domains": [],
+    "mention_policy": "anyone"
   },
   "enable_rss": true,
   "role": {
@@ -212,6 +215,25 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendSensitive() {
 }`, string(b))
 }
 
+func (suite *InternalToFrontendTestSuite) TestAccountToFrontendSensitivePendingDelete() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	user, err := suite.db.GetUserByAccountID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user.DeleteAt = testrig.TimeMustParse("2023-07-24T12:00:00Z")
+	if err := suite.db.UpdateUser(ctx, user, "delete_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiAccount, err := suite.typeconverter.AccountToAPIAccountSensitive(ctx, testAccount)
+	suite.NoError(err)
+	suite.Equal("2023-07-24T12:00:00.000Z", apiAccount.Source.DeleteAt)
+}
+
 func (suite *InternalToFrontendTestSuite) TestAccountToFrontendPublicPunycode() {
 	testAccount := suite.testAccounts["remote_account_4"]
 	apiAccount, err := suite.typeconverter.AccountToAPIAccountPublic(context.Background(), testAccount)
@@ -412,7 +434,8 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
         }
       },
       "description": "Black and white image of some 50's style text saying: Welcome On Board",
-      "blurhash": "LNJRdVM{00Rj%Mayt7j[4nWBofRj"
+      "blurhash": "LNJRdVM{00Rj%Mayt7j[4nWBofRj",
+      "processing_status": "succeeded"
     }
   ],
   "mentions": [],
@@ -527,7 +550,8 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage()
         }
       },
       "description": "Black and white image of some 50's style text saying: Welcome On Board",
-      "blurhash": "LNJRdVM{00Rj%Mayt7j[4nWBofRj"
+      "blurhash": "LNJRdVM{00Rj%Mayt7j[4nWBofRj",
+      "processing_status": "succeeded"
     }
   ],
   "mentions": [],
@@ -583,7 +607,8 @@ func (suite *InternalToFrontendTestSuite) TestVideoAttachmentToFrontend() {
       "aspect": 1.7821782
     }
   },
-  "description": "A cow adorably licking another cow!"
+  "description": "A cow adorably licking another cow!",
+  "processing_status": "succeeded"
 }`, string(b))
 }
 
@@ -623,6 +648,13 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV1ToFrontend() {
       "supported_mime_types": [
         "text/plain",
         "text/markdown"
+      ],
+      "supported_visibilities": [
+        "public",
+        "unlisted",
+        "private",
+        "mutuals_only",
+        "direct"
       ]
     },
     "media_attachments": {
@@ -736,11 +768,25 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV2ToFrontend() {
     },
     "statuses": {
       "max_characters": 5000,
+      "max_characters_by_visibility": {
+        "direct": 5000,
+        "mutuals_only": 5000,
+        "private": 5000,
+        "public": 5000,
+        "unlisted": 5000
+      },
       "max_media_attachments": 6,
       "characters_reserved_per_url": 25,
       "supported_mime_types": [
         "text/plain",
         "text/markdown"
+      ],
+      "supported_visibilities": [
+        "public",
+        "unlisted",
+        "private",
+        "mutuals_only",
+        "direct"
       ]
     },
     "media_attachments": {
@@ -1370,7 +1416,8 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
             }
           },
           "description": "tweet from thoughts of dog: i drank. all the water. in my bowl. earlier. but just now. i returned. to the same bowl. and it was. full again.. the bowl. is haunted",
-          "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6"
+          "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6",
+          "processing_status": "succeeded"
         }
       ],
       "mentions": [],
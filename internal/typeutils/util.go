@@ -21,11 +21,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"html"
 	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+const (
+	// articleMaxContentLength is the length in characters
+	// of Article / Page html content beyond which only an
+	// excerpt of the content is kept, with a link to the
+	// full content at the original url.
+	articleMaxContentLength = 5000
+
+	// articleExcerptLength is the length in
+	// characters of the plaintext excerpt.
+	articleExcerptLength = 500
 )
 
 type statusInteractions struct {
@@ -100,3 +117,94 @@ func getURI(withID ap.WithJSONLDId) (*url.URL, string, error) {
 	id := idProp.Get()
 	return id, id.String(), nil
 }
+
+// articleContent returns html content for an Article or Page
+// (eg., a blog post), which, unlike a Note, has a title in its
+// name, and content that may be as long as a whole web page.
+//
+// The title is prepended to the content in bold. Content longer
+// than articleMaxContentLength is replaced by a plaintext excerpt
+// followed by a link to read the rest at the given url; otherwise
+// it's sanitized, as articles tend to use more varied html.
+func articleContent(title string, content string, url string) string {
+	var b strings.Builder
+
+	if title != "" {
+		b.WriteString("<p><strong>")
+		b.WriteString(html.EscapeString(title))
+		b.WriteString("</strong></p>")
+	}
+
+	if utf8.RuneCountInString(content) <= articleMaxContentLength {
+		b.WriteString(text.SanitizeHTML(content))
+		return b.String()
+	}
+
+	excerpt := text.SanitizePlaintext(content)
+	if runes := []rune(excerpt); len(runes) > articleExcerptLength {
+		// Cut at the last space before the limit,
+		// if there is one, to avoid splitting words.
+		excerpt = string(runes[:articleExcerptLength])
+		if i := strings.LastIndexByte(excerpt, ' '); i > 0 {
+			excerpt = excerpt[:i]
+		}
+		excerpt += "…"
+	}
+
+	b.WriteString("<p>")
+	b.WriteString(html.EscapeString(excerpt))
+	b.WriteString("</p>")
+
+	if url != "" {
+		b.WriteString(`<p><a href="`)
+		b.WriteString(html.EscapeString(url))
+		b.WriteString(`" rel="nofollow noreferrer noopener" target="_blank">Read more</a></p>`)
+	}
+
+	return b.String()
+}
+
+// contentLanguage returns the language of the given plain content
+// according to the given contentMap, ie., the key of the variant
+// equal to it, or else the only key if there's just one variant.
+// If there's no plain content, or no match, "" is returned.
+func contentLanguage(contentMap map[string]string, content string) string {
+	if content == "" {
+		return ""
+	}
+
+	langs := make([]string, 0, len(contentMap))
+	for lang := range contentMap {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	for _, lang := range langs {
+		if contentMap[lang] == content {
+			return lang
+		}
+	}
+
+	if len(langs) == 1 {
+		return langs[0]
+	}
+
+	return ""
+}
+
+// statusContentMap returns the contentMap to serialize for the given
+// status: any content variants it has, along with its content keyed
+// by its language. Nil is returned if the status has no language.
+func statusContentMap(s *gtsmodel.Status) map[string]string {
+	if s.Language == "" {
+		return nil
+	}
+
+	contentMap := make(map[string]string, len(s.ContentMap)+1)
+	for lang, content := range s.ContentMap {
+		contentMap[lang] = content
+	}
+	contentMap[s.Language] = s.Content
+
+	return contentMap
+}
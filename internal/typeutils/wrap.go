@@ -84,15 +84,15 @@ func (c *converter) WrapPersonInUpdate(person vocab.ActivityStreamsPerson, origi
 	return update, nil
 }
 
-func (c *converter) WrapNoteInCreate(note vocab.ActivityStreamsNote, objectIRIOnly bool) (vocab.ActivityStreamsCreate, error) {
+func (c *converter) WrapStatusableInCreate(note ap.Statusable, objectIRIOnly bool) (vocab.ActivityStreamsCreate, error) {
 	create := streams.NewActivityStreamsCreate()
 
 	// Object property
 	objectProp := streams.NewActivityStreamsObjectProperty()
 	if objectIRIOnly {
 		objectProp.AppendIRI(note.GetJSONLDId().GetIRI())
-	} else {
-		objectProp.AppendActivityStreamsNote(note)
+	} else if err := objectProp.AppendType(note); err != nil {
+		return nil, gtserror.Newf("couldn't append %T to object: %w", note, err)
 	}
 	create.SetActivityStreamsObject(objectProp)
 
@@ -18,8 +18,13 @@
 package uris
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/url"
+	"sync/atomic"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
@@ -40,9 +45,15 @@ const (
 	UpdatePath       = "updates"       // UpdatePath is used to generate the URI for an account update
 	BlocksPath       = "blocks"        // BlocksPath is used to generate the URI for a block
 	ReportsPath      = "reports"       // ReportsPath is used to generate the URI for a report/flag
+	AcceptsPath      = "accepts"       // AcceptsPath is used to generate the URI for an accepted interaction
+	RejectsPath      = "rejects"       // RejectsPath is used to generate the URI for a rejected interaction
+	ListensPath      = "listens"       // ListensPath is used to generate the URI for a listen activity
+	BitesPath        = "bites"         // BitesPath is used to generate the URI for a bite activity
+	DislikesPath     = "dislikes"      // DislikesPath is used to generate the URI for a dislike activity
 	ConfirmEmailPath = "confirm_email" // ConfirmEmailPath is used to generate the URI for an email confirmation link
 	FileserverPath   = "fileserver"    // FileserverPath is a path component for serving attachments + media
 	EmojiPath        = "emoji"         // EmojiPath represents the activitypub emoji location
+	ProxyPath        = "proxy"         // ProxyPath is a path component for serving proxied external images
 )
 
 // UserURIs contains a bunch of UserURIs and URLs for a user, host, account, etc.
@@ -98,6 +109,30 @@ func GenerateURIForUpdate(username string, thisUpdateID string) string {
 	return fmt.Sprintf("%s://%s/%s/%s#%s/%s", protocol, host, UsersPath, username, UpdatePath, thisUpdateID)
 }
 
+// GenerateURIForListen returns the AP URI for a new listen activity -- something like:
+// https://example.org/users/whatever_user#listens/01F7XTH1QGBAPMGF49WJZ91XGC
+func GenerateURIForListen(username string, thisListenID string) string {
+	protocol := config.GetProtocol()
+	host := config.GetHost()
+	return fmt.Sprintf("%s://%s/%s/%s#%s/%s", protocol, host, UsersPath, username, ListensPath, thisListenID)
+}
+
+// GenerateURIForBite returns the AP URI for a new bite activity -- something like:
+// https://example.org/users/whatever_user#bites/01F7XTH1QGBAPMGF49WJZ91XGC
+func GenerateURIForBite(username string, thisBiteID string) string {
+	protocol := config.GetProtocol()
+	host := config.GetHost()
+	return fmt.Sprintf("%s://%s/%s/%s#%s/%s", protocol, host, UsersPath, username, BitesPath, thisBiteID)
+}
+
+// GenerateURIForDislike returns the AP URI for a new dislike activity -- something like:
+// https://example.org/users/whatever_user#dislikes/01F7XTH1QGBAPMGF49WJZ91XGC
+func GenerateURIForDislike(username string, thisDislikeID string) string {
+	protocol := config.GetProtocol()
+	host := config.GetHost()
+	return fmt.Sprintf("%s://%s/%s/%s#%s/%s", protocol, host, UsersPath, username, DislikesPath, thisDislikeID)
+}
+
 // GenerateURIForBlock returns the AP URI for a new block activity -- something like:
 // https://example.org/users/whatever_user/blocks/01F7XTH1QGBAPMGF49WJZ91XGC
 func GenerateURIForBlock(username string, thisBlockID string) string {
@@ -106,6 +141,22 @@ func GenerateURIForBlock(username string, thisBlockID string) string {
 	return fmt.Sprintf("%s://%s/%s/%s/%s/%s", protocol, host, UsersPath, username, BlocksPath, thisBlockID)
 }
 
+// GenerateURIForAccept returns the AP URI for a new Accept of an interaction -- something like:
+// https://example.org/users/whatever_user/accepts/01F7XTH1QGBAPMGF49WJZ91XGC
+func GenerateURIForAccept(username string, thisAcceptID string) string {
+	protocol := config.GetProtocol()
+	host := config.GetHost()
+	return fmt.Sprintf("%s://%s/%s/%s/%s/%s", protocol, host, UsersPath, username, AcceptsPath, thisAcceptID)
+}
+
+// GenerateURIForReject returns the AP URI for a new Reject of an interaction -- something like:
+// https://example.org/users/whatever_user/rejects/01F7XTH1QGBAPMGF49WJZ91XGC
+func GenerateURIForReject(username string, thisRejectID string) string {
+	protocol := config.GetProtocol()
+	host := config.GetHost()
+	return fmt.Sprintf("%s://%s/%s/%s/%s/%s", protocol, host, UsersPath, username, RejectsPath, thisRejectID)
+}
+
 // GenerateURIForReport returns the API URI for a new Flag activity -- something like:
 // https://example.org/reports/01GP3AWY4CRDVRNZKW0TEAMB5R
 //
@@ -178,6 +229,78 @@ func GenerateURIForEmoji(emojiID string) string {
 	return fmt.Sprintf("%s://%s/%s/%s", protocol, host, EmojiPath, emojiID)
 }
 
+// proxiedImageKey is the HMAC key used to sign
+// image proxy URIs, see SetProxiedImageSecret.
+var proxiedImageKey atomic.Pointer[[]byte]
+
+// SetProxiedImageSecret sets the instance secret from which the key used to
+// sign image proxy URIs is derived. Until this is called, no image proxy URIs
+// will be generated, and any given to ParseProxiedImagePath will be rejected.
+func SetProxiedImageSecret(secret []byte) {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(ProxyPath))
+	key := mac.Sum(nil)
+	proxiedImageKey.Store(&key)
+}
+
+// signProxiedImage returns the signature of the given owner ID
+// and encoded remote URL, or false if no key has been set.
+func signProxiedImage(ownerID string, encoded string) ([]byte, bool) {
+	key := proxiedImageKey.Load()
+	if key == nil {
+		return nil, false
+	}
+
+	mac := hmac.New(sha256.New, *key)
+	mac.Write([]byte(ownerID + "/" + encoded))
+	return mac.Sum(nil), true
+}
+
+// GenerateURIForProxiedImage generates a URI for serving the external image at remoteURL,
+// found in the status or account with ownerID, via this instance's image proxy. The remote
+// URL is encoded as unpadded, URL-safe base64, followed by a signature of owner + URL, eg.,
+// https://example.org/proxy/01F8MH75CBF9JFX4ZAD54N0W0R/aHR0cHM6Ly9pbWFnZXMuZXhhbXBsZS5jb20vY2F0LnBuZw/<signature>
+//
+// If no secret has been set with SetProxiedImageSecret, remoteURL is returned as-is.
+func GenerateURIForProxiedImage(ownerID string, remoteURL string) string {
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(remoteURL))
+
+	sig, ok := signProxiedImage(ownerID, encoded)
+	if !ok {
+		return remoteURL
+	}
+
+	protocol := config.GetProtocol()
+	host := config.GetHost()
+	return fmt.Sprintf("%s://%s/%s/%s/%s/%s", protocol, host, ProxyPath, ownerID, encoded, base64.RawURLEncoding.EncodeToString(sig))
+}
+
+// ParseProxiedImagePath checks the signature of, and parses the remote image URL from,
+// the owner ID, encoded URL and signature path components of a URI generated by
+// GenerateURIForProxiedImage. An error is returned if the signature doesn't match.
+func ParseProxiedImagePath(ownerID string, encoded string, signature string) (*url.URL, error) {
+	expect, ok := signProxiedImage(ownerID, encoded)
+	if !ok {
+		return nil, errors.New("image proxy secret not set")
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding signature %s: %w", signature, err)
+	}
+
+	if !hmac.Equal(sig, expect) {
+		return nil, fmt.Errorf("invalid signature for %s/%s", ownerID, encoded)
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s: %w", encoded, err)
+	}
+
+	return url.Parse(string(b))
+}
+
 // IsUserPath returns true if the given URL path corresponds to eg /users/example_username
 func IsUserPath(id *url.URL) bool {
 	return regexes.UserPath.MatchString(id.Path)
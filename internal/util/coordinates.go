@@ -0,0 +1,39 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import "math"
+
+// CoordinateDecimals is the number of decimal places to
+// which latitude and longitude are stored and federated.
+// 3 decimal places is a precision of roughly 100 metres,
+// which is enough to say which place a status is about,
+// without pinpointing where exactly its author stands.
+const CoordinateDecimals = 3
+
+// RoundCoordinate returns the given latitude or longitude
+// rounded to CoordinateDecimals places, or nil if nil.
+func RoundCoordinate(f *float64) *float64 {
+	if f == nil {
+		return nil
+	}
+
+	scale := math.Pow(10, CoordinateDecimals)
+	rounded := math.Round(*f*scale) / scale
+	return &rounded
+}
@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	"sort"
+
+	"golang.org/x/text/language"
+)
+
+// PickLanguage returns the key of the given language map, eg., a status
+// contentMap, which best matches the given language preferences, in order
+// of preference. If none of them match, or there are no preferences, the
+// first key in sorted order is returned. An empty map returns "".
+func PickLanguage(langMap map[string]string, prefs ...language.Tag) string {
+	if len(langMap) == 0 {
+		return ""
+	}
+
+	// Sort keys so the
+	// result is stable.
+	keys := make([]string, 0, len(langMap))
+	for key := range langMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Only keys which are valid tags can
+	// be matched; others are never picked
+	// unless there's nothing else.
+	tags := make([]language.Tag, 0, len(keys))
+	tagKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		tag, err := language.Parse(key)
+		if err != nil {
+			continue
+		}
+		tags = append(tags, tag)
+		tagKeys = append(tagKeys, key)
+	}
+
+	if len(tags) == 0 {
+		return keys[0]
+	}
+
+	if len(prefs) == 0 {
+		return tagKeys[0]
+	}
+
+	_, i, confidence := language.NewMatcher(tags).Match(prefs...)
+	if confidence == language.No {
+		return tagKeys[0]
+	}
+
+	return tagKeys[i]
+}
@@ -0,0 +1,64 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"golang.org/x/text/language"
+)
+
+type LanguageTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LanguageTestSuite) TestPickLanguage() {
+	contentMap := map[string]string{
+		"en":    "hello",
+		"de":    "hallo",
+		"pt-BR": "olá",
+	}
+
+	for _, test := range []struct {
+		prefs  string
+		expect string
+	}{
+		{"", "de"},
+		{"en-GB,en;q=0.9", "en"},
+		{"de-AT", "de"},
+		{"pt", "pt-BR"},
+		{"fr,de;q=0.5", "de"},
+		{"ja", "de"},
+	} {
+		prefs, _, err := language.ParseAcceptLanguage(test.prefs)
+		suite.NoError(err)
+		suite.Equal(test.expect, util.PickLanguage(contentMap, prefs...), test.prefs)
+	}
+}
+
+func (suite *LanguageTestSuite) TestPickLanguageInvalidKeys() {
+	suite.Equal("", util.PickLanguage(nil))
+	suite.Equal("en", util.PickLanguage(map[string]string{"und!": "?", "en": "hello"}, language.German))
+	suite.Equal("no good", util.PickLanguage(map[string]string{"no good": "?"}, language.German))
+}
+
+func TestLanguageTestSuite(t *testing.T) {
+	suite.Run(t, new(LanguageTestSuite))
+}
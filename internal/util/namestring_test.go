@@ -112,6 +112,40 @@ func (suite *NamestringSuite) TestExtractNamestringParts4() {
 	suite.EqualError(err, "couldn't match mention ")
 }
 
+func (suite *NamestringSuite) TestExtractNamestringPartsTable() {
+	for _, test := range []struct {
+		namestring string
+		username   string
+		host       string
+		err        bool
+	}{
+		{namestring: "@someone", username: "someone"},
+		{namestring: "@some.one", username: "some.one"},
+		{namestring: "@some_one_", username: "some_one_"},
+		{namestring: "@someone@example.org", username: "someone", host: "example.org"},
+		{namestring: "@some.one@example.org", username: "some.one", host: "example.org"},
+		{namestring: "@someone@localhost:8080", username: "someone", host: "localhost:8080"},
+		{namestring: "@someone@例え.テスト", username: "someone", host: "例え.テスト"},
+		{namestring: "@lävistää@xn--lvist-gra.example", username: "lävistää", host: "xn--lvist-gra.example"},
+		{namestring: "@someone.", err: true},
+		{namestring: "@someone@example.org.", err: true},
+		{namestring: "@someone@example.org:", err: true},
+		{namestring: "@someone@", err: true},
+		{namestring: "@.", err: true},
+		{namestring: "someone@example.org", err: true},
+	} {
+		username, host, err := util.ExtractNamestringParts(test.namestring)
+		if test.err {
+			suite.Error(err, test.namestring)
+			continue
+		}
+
+		suite.NoError(err, test.namestring)
+		suite.Equal(test.username, username, test.namestring)
+		suite.Equal(test.host, host, test.namestring)
+	}
+}
+
 func TestNamestringSuite(t *testing.T) {
 	suite.Run(t, &NamestringSuite{})
 }
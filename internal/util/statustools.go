@@ -24,11 +24,28 @@ import (
 func IsPlausiblyInHashtag(r rune) bool {
 	// Marks are allowed during parsing, prior to normalization, but not after,
 	// since they may be combined into letters during normalization.
-	return unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r)
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r) || r == '_'
 }
 
 func IsPermittedInHashtag(r rune) bool {
-	return unicode.IsLetter(r) || unicode.IsNumber(r)
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || r == '_'
+}
+
+// IsValidHashtag reports whether the given normalized hashtag
+// (without the hash part) contains only permitted characters,
+// and at least one letter, so that eg #2024Election and
+// #COVID_19 are hashtags but #111111 and #__ are not.
+func IsValidHashtag(tag string) bool {
+	var letter bool
+	for _, r := range tag {
+		if !IsPermittedInHashtag(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			letter = true
+		}
+	}
+	return letter
 }
 
 // Decides where to break before or after a #hashtag or @mention
@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package validate
+
+import (
+	"crypto/sha1" //nolint:gosec
+	"fmt"
+	"sync"
+
+	"github.com/superseriousbusiness/gotosocial/internal/bloom"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// breachedPasswords is the filter of breached
+// passwords loaded from the configured path.
+var breachedPasswords struct {
+	path   string
+	filter *bloom.Filter
+	mu     sync.Mutex
+}
+
+// LoadBreachedPasswords loads the breached passwords filter
+// from the configured path, if it isn't loaded already, so
+// that any problem with it can be reported early on.
+func LoadBreachedPasswords() error {
+	_, err := breachedPasswordsFilter()
+	return err
+}
+
+// passwordBreached returns whether the given password
+// is in the configured filter of breached passwords.
+//
+// If the filter can't be loaded, the error is logged
+// and the password is assumed not to be breached, so
+// that nobody gets locked out of changing passwords.
+func passwordBreached(password string) bool {
+	filter, err := breachedPasswordsFilter()
+	if err != nil {
+		log.Error(nil, err)
+		return false
+	}
+
+	if filter == nil {
+		// Not configured.
+		return false
+	}
+
+	// Breached password lists are
+	// keyed by SHA-1 hash; not used
+	// for anything security sensitive.
+	sum := sha1.Sum([]byte(password)) //nolint:gosec
+	return filter.Has(sum[:])
+}
+
+// breachedPasswordsFilter returns the filter of breached passwords
+// at the configured path, loading it on first use. It returns nil
+// if no path is configured.
+func breachedPasswordsFilter() (*bloom.Filter, error) {
+	path := config.GetAccountsPasswordBreachedFilter()
+
+	breachedPasswords.mu.Lock()
+	defer breachedPasswords.mu.Unlock()
+
+	if path == "" {
+		breachedPasswords.path = ""
+		breachedPasswords.filter = nil
+		return nil, nil
+	}
+
+	if path != breachedPasswords.path {
+		filter, err := bloom.Load(path)
+		if err != nil {
+			return nil, fmt.Errorf("error loading breached passwords filter from %s: %w", path, err)
+		}
+
+		breachedPasswords.path = path
+		breachedPasswords.filter = filter
+	}
+
+	return breachedPasswords.filter, nil
+}
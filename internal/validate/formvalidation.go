@@ -23,17 +23,19 @@ import (
 	"net/mail"
 	"strings"
 
+	"codeberg.org/gruf/go-bytesize"
+	"github.com/miekg/dns"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	pwv "github.com/wagslane/go-password-validator"
 	"golang.org/x/text/language"
 )
 
 const (
 	maximumPasswordLength         = 256
-	minimumPasswordEntropy        = 60 // dictates password strength. See https://github.com/wagslane/go-password-validator
 	minimumReasonLength           = 40
 	maximumReasonLength           = 500
 	maximumSiteTitleLength        = 40
@@ -42,12 +44,20 @@ const (
 	maximumSiteTermsLength        = 5000
 	maximumUsernameLength         = 64
 	maximumEmojiCategoryLength    = 64
+	maximumEmojiShortcodeLength   = 30
 	maximumProfileFieldLength     = 255
 	maximumProfileFields          = 6
 	maximumListTitleLength        = 200
+	maximumInstanceRuleLength     = 1000
+	maximumScrobbleFieldLength    = 255
+	maximumHashtagLength          = 30
+	maximumAttributionDomains     = 10
+	maximumPlaceNameLength        = 255
 )
 
-// NewPassword returns an error if the given password is not sufficiently strong, or nil if it's ok.
+// NewPassword returns an error if the given password doesn't meet the password
+// policy of this instance, or nil if it's ok. If it breaks more than one rule of
+// the policy, the error lists all of them.
 func NewPassword(password string) error {
 	if password == "" {
 		return errors.New("no password provided")
@@ -57,13 +67,29 @@ func NewPassword(password string) error {
 		return fmt.Errorf("password should be no more than %d chars", maximumPasswordLength)
 	}
 
-	if err := pwv.Validate(password, minimumPasswordEntropy); err != nil {
-		// Modify error message to include percentage requred entropy the password has
-		percent := int(100 * pwv.GetEntropy(password) / minimumPasswordEntropy)
-		return errors.New(strings.ReplaceAll(
-			err.Error(),
-			"insecure password",
-			fmt.Sprintf("password is only %d%% strength", percent)))
+	var errs []string
+
+	if minLength := config.GetAccountsPasswordMinLength(); len([]rune(password)) < minLength {
+		errs = append(errs, fmt.Sprintf("password should be at least %d chars", minLength))
+	}
+
+	if minEntropy := config.GetAccountsPasswordMinEntropy(); minEntropy > 0 {
+		if err := pwv.Validate(password, minEntropy); err != nil {
+			// Modify error message to include percentage requred entropy the password has
+			percent := int(100 * pwv.GetEntropy(password) / minEntropy)
+			errs = append(errs, strings.ReplaceAll(
+				err.Error(),
+				"insecure password",
+				fmt.Sprintf("password is only %d%% strength", percent)))
+		}
+	}
+
+	if passwordBreached(password) {
+		errs = append(errs, "password appears in a list of breached passwords, so attackers may already know it")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
 	}
 
 	return nil // pasword OK
@@ -164,6 +190,194 @@ func StatusContentType(statusContentType string) error {
 	return fmt.Errorf("status content type '%s' was not recognized, valid options are 'text/plain', 'text/markdown'", statusContentType)
 }
 
+// MentionPolicy checks that the desired mention policy setting is valid.
+func MentionPolicy(policy string) error {
+	switch gtsmodel.MentionPolicy(policy) {
+	case gtsmodel.MentionPolicyAnyone, gtsmodel.MentionPolicyFollowed, gtsmodel.MentionPolicyFollowers, gtsmodel.MentionPolicyNobody:
+		return nil
+	}
+	return fmt.Errorf("mention policy '%s' was not recognized, valid options are 'anyone', 'followed', 'followers', 'nobody'", policy)
+}
+
+// StatusCreateRequest checks that the given status create request contains content,
+// and doesn't exceed the configured limits for media, polls, and content warnings.
+// Status text length depends on visibility, so it's checked separately with StatusText.
+func StatusCreateRequest(form *apimodel.StatusCreateRequest) error {
+	hasStatus := form.Status != ""
+	hasMedia := len(form.MediaIDs) != 0
+	hasPoll := form.Poll != nil
+
+	if !hasStatus && !hasMedia && !hasPoll {
+		return errors.New("no status, media, or poll provided")
+	}
+
+	if hasMedia && hasPoll {
+		return errors.New("can't post media + poll in same status")
+	}
+
+	maxPollOptions := config.GetStatusesPollMaxOptions()
+	maxPollChars := config.GetStatusesPollOptionMaxChars()
+	maxCwChars := config.GetStatusesCWMaxChars()
+
+	if form.Poll != nil {
+		if form.Poll.Options == nil {
+			return errors.New("poll with no options")
+		}
+		if len(form.Poll.Options) > maxPollOptions {
+			return fmt.Errorf("too many poll options provided, %d provided but limit is %d", len(form.Poll.Options), maxPollOptions)
+		}
+		for _, p := range form.Poll.Options {
+			if length := len([]rune(p)); length > maxPollChars {
+				return fmt.Errorf("poll option too long, %d characters provided but limit is %d", length, maxPollChars)
+			}
+		}
+	}
+
+	if form.SpoilerText != "" {
+		if length := len([]rune(form.SpoilerText)); length > maxCwChars {
+			return fmt.Errorf("content-warning/spoilertext too long, %d characters provided but limit is %d", length, maxCwChars)
+		}
+	}
+
+	if form.Language != "" {
+		if err := Language(form.Language); err != nil {
+			return err
+		}
+	}
+
+	if err := StatusPlace(form.PlaceName, form.PlaceLatitude, form.PlaceLongitude); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// StatusPlace validates the name and optional coordinates of a place
+// attached to a new status. Coordinates must be given together, and
+// only alongside a name, so that a place is never just a pin on a map.
+func StatusPlace(name string, latitude *float64, longitude *float64) error {
+	if length := len([]rune(name)); length > maximumPlaceNameLength {
+		return fmt.Errorf("place_name must be no more than %d chars, provided place_name was %d chars", maximumPlaceNameLength, length)
+	}
+
+	if latitude == nil && longitude == nil {
+		return nil
+	}
+
+	if name == "" {
+		return errors.New("place_name must be provided along with place_latitude and place_longitude")
+	}
+
+	if latitude == nil || longitude == nil {
+		return errors.New("place_latitude and place_longitude must be provided together")
+	}
+
+	if !(*latitude >= -90 && *latitude <= 90) {
+		return fmt.Errorf("place_latitude must be between -90 and 90, provided place_latitude was %v", *latitude)
+	}
+
+	if !(*longitude >= -180 && *longitude <= 180) {
+		return fmt.Errorf("place_longitude must be between -180 and 180, provided place_longitude was %v", *longitude)
+	}
+
+	return nil
+}
+
+// StatusMaxChars returns the maximum permitted length, in characters,
+// of a status with the given visibility. If no limit is configured for
+// the visibility level, the instance-wide statuses-max-chars is returned.
+func StatusMaxChars(visibility gtsmodel.Visibility) int {
+	var maxChars int
+
+	switch visibility {
+	case gtsmodel.VisibilityPublic:
+		maxChars = config.GetStatusesMaxCharsPublic()
+	case gtsmodel.VisibilityUnlocked:
+		maxChars = config.GetStatusesMaxCharsUnlisted()
+	case gtsmodel.VisibilityFollowersOnly, gtsmodel.VisibilityMutualsOnly:
+		maxChars = config.GetStatusesMaxCharsPrivate()
+	case gtsmodel.VisibilityDirect:
+		maxChars = config.GetStatusesMaxCharsDirect()
+	}
+
+	if maxChars <= 0 {
+		maxChars = config.GetStatusesMaxChars()
+	}
+
+	return maxChars
+}
+
+// UserStatusMaxChars returns the maximum permitted length, in characters,
+// of a status with the given visibility posted by the given user. If an admin
+// has set a limit for the user, it applies to statuses of every visibility;
+// otherwise, this is the same as StatusMaxChars. User may be nil.
+func UserStatusMaxChars(user *gtsmodel.User, visibility gtsmodel.Visibility) int {
+	if user != nil && user.StatusesMaxChars > 0 {
+		return user.StatusesMaxChars
+	}
+
+	return StatusMaxChars(visibility)
+}
+
+// userMediaLimits returns the media limits configured
+// for the media role of the given user, if any.
+func userMediaLimits(user *gtsmodel.User) config.MediaRoleConfiguration {
+	if user == nil {
+		return config.MediaRoleConfiguration{}
+	}
+	return config.GetMediaRoles()[user.GetMediaRole()]
+}
+
+// UserMediaImageMaxSize returns the maximum permitted size of images
+// uploaded by the given user, taking their media role into account.
+// User may be nil, in which case the instance limit is returned.
+func UserMediaImageMaxSize(user *gtsmodel.User) bytesize.Size {
+	if maxSize := userMediaLimits(user).ImageMaxSize; maxSize > 0 {
+		return maxSize
+	}
+	return config.GetMediaImageMaxSize()
+}
+
+// UserMediaVideoMaxSize returns the maximum permitted size of videos
+// uploaded by the given user, taking their media role into account.
+// User may be nil, in which case the instance limit is returned.
+func UserMediaVideoMaxSize(user *gtsmodel.User) bytesize.Size {
+	if maxSize := userMediaLimits(user).VideoMaxSize; maxSize > 0 {
+		return maxSize
+	}
+	return config.GetMediaVideoMaxSize()
+}
+
+// UserStatusMediaMaxFiles returns the maximum permitted number of media
+// attachments on statuses posted by the given user, taking their media
+// role into account. User may be nil, in which case the instance limit
+// is returned.
+func UserStatusMediaMaxFiles(user *gtsmodel.User) int {
+	if maxFiles := userMediaLimits(user).MaxFiles; maxFiles > 0 {
+		return maxFiles
+	}
+	return config.GetStatusesMediaMaxFiles()
+}
+
+// StatusMediaFiles checks that the given number of media attachments
+// is within the permitted number for a status posted by the given user.
+func StatusMediaFiles(count int, user *gtsmodel.User) error {
+	if maxFiles := UserStatusMediaMaxFiles(user); count > maxFiles {
+		return fmt.Errorf("too many media files attached to status, %d attached but limit is %d", count, maxFiles)
+	}
+	return nil
+}
+
+// StatusText checks that the given status text is within the permitted
+// length for a status with the given visibility, posted by the given user.
+func StatusText(text string, visibility gtsmodel.Visibility, user *gtsmodel.User) error {
+	maxChars := UserStatusMaxChars(user, visibility)
+	if length := len([]rune(text)); length > maxChars {
+		return fmt.Errorf("status too long, %d characters provided but limit for %s statuses is %d", length, visibility, maxChars)
+	}
+	return nil
+}
+
 func CustomCSS(customCSS string) error {
 	if !config.GetAccountsAllowCustomCSS() {
 		return errors.New("accounts-allow-custom-css is not enabled for this instance")
@@ -187,6 +401,15 @@ func EmojiShortcode(shortcode string) error {
 	return nil
 }
 
+// PersonalEmojiShortcode validates the shortcode of a personal emoji,
+// once it's been prefixed with the username of the owning account.
+func PersonalEmojiShortcode(shortcode string) error {
+	if length := len(shortcode); length > maximumEmojiShortcodeLength {
+		return fmt.Errorf("shortcode %s did not pass validation, must be no more than %d characters including the username prefix, but provided value was %d characters", shortcode, maximumEmojiShortcodeLength, length)
+	}
+	return EmojiShortcode(shortcode)
+}
+
 // EmojiCategory validates the length of the given category string.
 func EmojiCategory(category string) error {
 	if length := len(category); length > maximumEmojiCategoryLength {
@@ -273,6 +496,55 @@ func ListTitle(title string) error {
 	return nil
 }
 
+// ScrobbleRequest validates the track, artist, and album names of a new scrobble.
+func ScrobbleRequest(form *apimodel.ScrobbleRequest) error {
+	if form.TrackName == "" {
+		return fmt.Errorf("track_name must be provided, and must be no more than %d chars", maximumScrobbleFieldLength)
+	}
+
+	for _, field := range []struct{ name, value string }{
+		{"track_name", form.TrackName},
+		{"artist_name", form.ArtistName},
+		{"album_name", form.AlbumName},
+	} {
+		if length := len([]rune(field.value)); length > maximumScrobbleFieldLength {
+			return fmt.Errorf("%s must be no more than %d chars, provided %s was %d chars", field.name, maximumScrobbleFieldLength, field.name, length)
+		}
+	}
+
+	return nil
+}
+
+// InstanceRule validates the text of a new or updated instance rule.
+func InstanceRule(text string) error {
+	if text == "" {
+		return fmt.Errorf("instance rule text must be provided, and must be no more than %d chars", maximumInstanceRuleLength)
+	}
+
+	if length := len([]rune(text)); length > maximumInstanceRuleLength {
+		return fmt.Errorf("instance rule text must be no more than %d chars, provided text was %d chars", maximumInstanceRuleLength, length)
+	}
+
+	return nil
+}
+
+// Hashtag validates the name of a hashtag, given without the hash part.
+func Hashtag(name string) error {
+	if name == "" {
+		return errors.New("hashtag must be provided")
+	}
+
+	if length := len([]rune(name)); length > maximumHashtagLength {
+		return fmt.Errorf("hashtag must be no more than %d chars, provided hashtag was %d chars", maximumHashtagLength, length)
+	}
+
+	if !util.IsValidHashtag(name) {
+		return fmt.Errorf("hashtag %s must contain at least one letter, and only letters, numbers and underscores", name)
+	}
+
+	return nil
+}
+
 // ListRepliesPolicy validates the replies_policy of a new or updated list.
 func ListRepliesPolicy(repliesPolicy gtsmodel.RepliesPolicy) error {
 	switch repliesPolicy {
@@ -284,3 +556,20 @@ func ListRepliesPolicy(repliesPolicy gtsmodel.RepliesPolicy) error {
 		return fmt.Errorf("list replies_policy must be either empty or one of 'followed', 'list', 'none'")
 	}
 }
+
+// AttributionDomains validates the domains an account allows to credit
+// it as the author of their pages. Domains should already be lowercased
+// and converted to punycode.
+func AttributionDomains(domains []string) error {
+	if len(domains) > maximumAttributionDomains {
+		return fmt.Errorf("cannot have more than %d attribution domains", maximumAttributionDomains)
+	}
+
+	for _, domain := range domains {
+		if _, ok := dns.IsDomainName(domain); !ok || strings.ContainsAny(domain, "/:@?# ") {
+			return fmt.Errorf("attribution domain %s is not a valid domain", domain)
+		}
+	}
+
+	return nil
+}
@@ -18,14 +18,21 @@
 package validate_test
 
 import (
+	"crypto/sha1" //nolint:gosec
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"codeberg.org/gruf/go-bytesize"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/bloom"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type ValidationTestSuite struct {
@@ -60,12 +67,12 @@ func (suite *ValidationTestSuite) TestCheckPasswordStrength() {
 
 	err = validate.NewPassword(shortPassword)
 	if suite.Error(err) {
-		suite.Equal(errors.New("password is only 39% strength, try including more special characters or using a longer password"), err)
+		suite.Equal(errors.New("password should be at least 8 chars; password is only 39% strength, try including more special characters or using a longer password"), err)
 	}
 
 	err = validate.NewPassword(specialPassword)
 	if suite.Error(err) {
-		suite.Equal(errors.New("password is only 53% strength, try including more special characters or using a longer password"), err)
+		suite.Equal(errors.New("password should be at least 8 chars; password is only 53% strength, try including more special characters or using a longer password"), err)
 	}
 
 	err = validate.NewPassword(longPassword)
@@ -84,6 +91,66 @@ func (suite *ValidationTestSuite) TestCheckPasswordStrength() {
 	}
 }
 
+func (suite *ValidationTestSuite) TestCheckPasswordPolicy() {
+	defer func() {
+		config.SetAccountsPasswordMinLength(config.Defaults.AccountsPasswordMinLength)
+		config.SetAccountsPasswordMinEntropy(config.Defaults.AccountsPasswordMinEntropy)
+	}()
+
+	config.SetAccountsPasswordMinLength(20)
+	config.SetAccountsPasswordMinEntropy(0)
+
+	err := validate.NewPassword("password")
+	suite.EqualError(err, "password should be at least 20 chars")
+
+	err = validate.NewPassword("passwordpasswordpassword")
+	suite.NoError(err)
+
+	config.SetAccountsPasswordMinLength(0)
+	config.SetAccountsPasswordMinEntropy(80)
+
+	err = validate.NewPassword("3dX5@Zc%mV*W2MBNEy$@")
+	suite.NoError(err)
+
+	err = validate.NewPassword("passwordpass")
+	suite.EqualError(err, "password is only 70% strength, try including more special characters, using uppercase letters, using numbers or using a longer password")
+}
+
+func (suite *ValidationTestSuite) TestCheckPasswordBreached() {
+	defer config.SetAccountsPasswordBreachedFilter("")
+
+	breached := "3dX5@Zc%mV*W2MBNEy$@"
+	sum := sha1.Sum([]byte(breached)) //nolint:gosec
+
+	filter := bloom.New(10, 0.01)
+	filter.Add(sum[:])
+
+	path := filepath.Join(suite.T().TempDir(), "breached.bloom")
+	file, err := os.Create(path)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := filter.WriteTo(file); err != nil {
+		suite.FailNow(err.Error())
+	}
+	file.Close()
+
+	config.SetAccountsPasswordBreachedFilter(path)
+	suite.NoError(validate.LoadBreachedPasswords())
+
+	err = validate.NewPassword(breached)
+	suite.EqualError(err, "password appears in a list of breached passwords, so attackers may already know it")
+
+	err = validate.NewPassword("H7p#2kLq!9vZx@Wm4$Rt")
+	suite.NoError(err)
+
+	err = validate.NewPassword("Ok12")
+	suite.EqualError(err, "password should be at least 8 chars; password is only 39% strength, try including more special characters or using a longer password")
+
+	config.SetAccountsPasswordBreachedFilter(filepath.Join(suite.T().TempDir(), "missing.bloom"))
+	suite.ErrorContains(validate.LoadBreachedPasswords(), "error loading breached passwords filter")
+}
+
 func (suite *ValidationTestSuite) TestValidateUsername() {
 	empty := ""
 	tooLong := "holycrapthisisthelongestusernameiveeverseeninmylifethatstoomuchman"
@@ -342,6 +409,70 @@ func (suite *ValidationTestSuite) TestValidateCustomCSSTooLongUnicode() {
 	suite.EqualError(err, "custom_css must be less than 5 characters, but submitted custom_css was 10 characters")
 }
 
+func (suite *ValidationTestSuite) TestValidateAttributionDomains() {
+	for _, domains := range [][]string{
+		{},
+		{"example.org"},
+		{"blog.example.org", "xn--bcher-kva.example"},
+	} {
+		suite.NoError(validate.AttributionDomains(domains))
+	}
+
+	for _, domains := range [][]string{
+		{"https://example.org"},
+		{"example.org/blog"},
+		{"someone@example.org"},
+		{"example.org:8080"},
+		{"1.example", "2.example", "3.example", "4.example", "5.example", "6.example", "7.example", "8.example", "9.example", "10.example", "11.example"},
+	} {
+		suite.Error(validate.AttributionDomains(domains))
+	}
+}
+
+func (suite *ValidationTestSuite) TestValidateStatusPlace() {
+	lat, lon, far := 51.501, -0.142, 200.0
+
+	suite.NoError(validate.StatusPlace("", nil, nil))
+	suite.NoError(validate.StatusPlace("The Old Tree, Slothville", nil, nil))
+	suite.NoError(validate.StatusPlace("The Old Tree, Slothville", &lat, &lon))
+
+	suite.EqualError(validate.StatusPlace("", &lat, &lon), "place_name must be provided along with place_latitude and place_longitude")
+	suite.EqualError(validate.StatusPlace("The Old Tree, Slothville", &lat, nil), "place_latitude and place_longitude must be provided together")
+	suite.EqualError(validate.StatusPlace("The Old Tree, Slothville", &far, &lon), "place_latitude must be between -90 and 90, provided place_latitude was 200")
+	suite.EqualError(validate.StatusPlace("The Old Tree, Slothville", &lat, &far), "place_longitude must be between -180 and 180, provided place_longitude was 200")
+	suite.Error(validate.StatusPlace(strings.Repeat("a", 256), nil, nil))
+}
+
+func (suite *ValidationTestSuite) TestValidateUserMediaLimits() {
+	config.SetMediaImageMaxSize(10 * bytesize.MiB)
+	config.SetMediaVideoMaxSize(40 * bytesize.MiB)
+	config.SetStatusesMediaMaxFiles(6)
+	config.SetMediaRoles(map[string]config.MediaRoleConfiguration{
+		"admin":   {ImageMaxSize: 20 * bytesize.MiB},
+		"trusted": {VideoMaxSize: 100 * bytesize.MiB, MaxFiles: 8},
+	})
+	defer config.SetMediaRoles(nil)
+
+	admin := &gtsmodel.User{Admin: testrig.TrueBool()}
+	suite.Equal(20*bytesize.MiB, validate.UserMediaImageMaxSize(admin))
+	suite.Equal(40*bytesize.MiB, validate.UserMediaVideoMaxSize(admin))
+	suite.Equal(6, validate.UserStatusMediaMaxFiles(admin))
+
+	// Role set by an admin takes precedence.
+	trusted := &gtsmodel.User{Admin: testrig.TrueBool(), MediaRole: "trusted"}
+	suite.Equal(10*bytesize.MiB, validate.UserMediaImageMaxSize(trusted))
+	suite.Equal(100*bytesize.MiB, validate.UserMediaVideoMaxSize(trusted))
+	suite.Equal(8, validate.UserStatusMediaMaxFiles(trusted))
+	suite.NoError(validate.StatusMediaFiles(8, trusted))
+	suite.EqualError(validate.StatusMediaFiles(9, trusted), "too many media files attached to status, 9 attached but limit is 8")
+
+	// No role configured, so instance limits apply.
+	user := &gtsmodel.User{}
+	suite.Equal(10*bytesize.MiB, validate.UserMediaImageMaxSize(user))
+	suite.Equal(6, validate.UserStatusMediaMaxFiles(user))
+	suite.Equal(6, validate.UserStatusMediaMaxFiles(nil))
+}
+
 func TestValidationTestSuite(t *testing.T) {
 	suite.Run(t, new(ValidationTestSuite))
 }
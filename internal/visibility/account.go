@@ -129,6 +129,13 @@ func (f *Filter) isAccountVisible(ctx context.Context, account *gtsmodel.Account
 			log.Trace(ctx, "local account not active")
 			return false, nil
 		}
+
+		// Accounts pending self-deletion are hidden
+		// until either deleted, or the deletion is cancelled.
+		if !user.DeleteAt.IsZero() {
+			log.Trace(ctx, "local account pending deletion")
+			return false, nil
+		}
 	} else {
 		// This is a remote account.
 
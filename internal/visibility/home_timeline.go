@@ -92,8 +92,23 @@ func (f *Filter) isStatusHomeTimelineable(ctx context.Context, owner *gtsmodel.A
 	}
 
 	if status.MentionsAccount(owner.ID) {
-		// Can always see when you are mentioned.
-		return true, nil
+		if !owner.MentionPolicy.HidesStatuses() {
+			// Can always see when you are mentioned.
+			return true, nil
+		}
+
+		// Unless the owner keeps out mentions
+		// from accounts they don't accept them from.
+		mentionable, err := f.AccountMentionable(ctx, owner, status.AccountID)
+		if err != nil {
+			return false, err
+		}
+
+		if !mentionable {
+			log.Trace(ctx, "status author not allowed to mention timeline owner")
+		}
+
+		return mentionable, nil
 	}
 
 	var (
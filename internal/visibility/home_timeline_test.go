@@ -65,6 +65,31 @@ func (suite *StatusStatusHomeTimelineableTestSuite) TestNotFollowingStatusHomeTi
 	suite.False(timelineable)
 }
 
+func (suite *StatusStatusHomeTimelineableTestSuite) mentionStatusHomeTimelineable(policy gtsmodel.MentionPolicy) bool {
+	// Reply to local_account_1, mentioning them,
+	// by local_account_2 who they follow.
+	testStatus := &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["local_account_2_status_5"]
+	testStatus.Mentions = []*gtsmodel.Mention{suite.testMentions["local_user_2_mention_zork"]}
+
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+	testAccount.MentionPolicy = policy
+
+	timelineable, err := suite.filter.StatusHomeTimelineable(context.Background(), testAccount, testStatus)
+	suite.NoError(err)
+
+	return timelineable
+}
+
+func (suite *StatusStatusHomeTimelineableTestSuite) TestMentionPolicyFollowedHomeTimelineable() {
+	suite.True(suite.mentionStatusHomeTimelineable(gtsmodel.MentionPolicyFollowed))
+}
+
+func (suite *StatusStatusHomeTimelineableTestSuite) TestMentionPolicyNobodyNotHomeTimelineable() {
+	suite.False(suite.mentionStatusHomeTimelineable(gtsmodel.MentionPolicyNobody))
+}
+
 func (suite *StatusStatusHomeTimelineableTestSuite) TestStatusTooNewNotTimelineable() {
 	testStatus := &gtsmodel.Status{}
 	*testStatus = *suite.testStatuses["local_account_1_status_1"]
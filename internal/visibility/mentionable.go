@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// AccountMentionable checks if the account with the given ID is allowed to mention target, according to the mention policy of target.
+func (f *Filter) AccountMentionable(ctx context.Context, target *gtsmodel.Account, originAccountID string) (bool, error) {
+	if originAccountID == target.ID {
+		// Can always mention yourself.
+		return true, nil
+	}
+
+	switch target.MentionPolicy {
+	case gtsmodel.MentionPolicyFollowed:
+		followed, err := f.state.DB.IsFollowing(ctx, target.ID, originAccountID)
+		if err != nil {
+			return false, fmt.Errorf("AccountMentionable: error checking follow: %w", err)
+		}
+		return followed, nil
+
+	case gtsmodel.MentionPolicyFollowers:
+		follower, err := f.state.DB.IsFollowing(ctx, originAccountID, target.ID)
+		if err != nil {
+			return false, fmt.Errorf("AccountMentionable: error checking follow: %w", err)
+		}
+		return follower, nil
+
+	case gtsmodel.MentionPolicyNobody:
+		return false, nil
+
+	default:
+		return true, nil
+	}
+}
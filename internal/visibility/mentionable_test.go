@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type AccountMentionableTestSuite struct {
+	FilterStandardTestSuite
+}
+
+func (suite *AccountMentionableTestSuite) mentionable(policy gtsmodel.MentionPolicy, origin string) bool {
+	// local_account_2 follows
+	// and is followed by local_account_1,
+	// but has nothing to do with admin_account.
+	target := new(gtsmodel.Account)
+	*target = *suite.testAccounts["local_account_2"]
+	target.MentionPolicy = policy
+
+	mentionable, err := suite.filter.AccountMentionable(context.Background(), target, suite.testAccounts[origin].ID)
+	suite.NoError(err)
+
+	return mentionable
+}
+
+func (suite *AccountMentionableTestSuite) TestMentionPolicyAnyone() {
+	suite.True(suite.mentionable("", "local_account_1"))
+	suite.True(suite.mentionable("", "admin_account"))
+	suite.True(suite.mentionable(gtsmodel.MentionPolicyAnyone, "remote_account_1"))
+}
+
+func (suite *AccountMentionableTestSuite) TestMentionPolicyFollowed() {
+	suite.True(suite.mentionable(gtsmodel.MentionPolicyFollowed, "local_account_1"))
+	suite.False(suite.mentionable(gtsmodel.MentionPolicyFollowed, "admin_account"))
+	suite.False(suite.mentionable(gtsmodel.MentionPolicyFollowed, "remote_account_1"))
+}
+
+func (suite *AccountMentionableTestSuite) TestMentionPolicyFollowers() {
+	suite.True(suite.mentionable(gtsmodel.MentionPolicyFollowers, "local_account_1"))
+	suite.False(suite.mentionable(gtsmodel.MentionPolicyFollowers, "admin_account"))
+}
+
+func (suite *AccountMentionableTestSuite) TestMentionPolicyNobody() {
+	suite.False(suite.mentionable(gtsmodel.MentionPolicyNobody, "local_account_1"))
+	suite.True(suite.mentionable(gtsmodel.MentionPolicyNobody, "local_account_2"))
+}
+
+func TestAccountMentionableTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountMentionableTestSuite))
+}
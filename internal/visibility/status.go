@@ -88,6 +88,21 @@ func (f *Filter) isStatusVisible(ctx context.Context, requester *gtsmodel.Accoun
 		return false, fmt.Errorf("isStatusVisible: error populating status %s: %w", status.ID, err)
 	}
 
+	if status.IsPendingApproval() {
+		// Replies and boosts awaiting approval are only visible to
+		// their author, and to the author whose approval is needed.
+		if requester == nil {
+			return false, nil
+		}
+
+		switch requester.ID {
+		case status.AccountID, status.InReplyToAccountID, status.BoostOfAccountID:
+		default:
+			log.Trace(ctx, "status pending approval not visible to requester")
+			return false, nil
+		}
+	}
+
 	// Check whether status accounts are visible to the requester.
 	visible, err := f.areStatusAccountsVisible(ctx, requester, status)
 	if err != nil {
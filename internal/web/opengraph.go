@@ -54,6 +54,9 @@ type ogMeta struct {
 
 	// profile tags
 	ProfileUsername string // profile:username
+
+	// fediverse tags
+	FediverseCreator string // fediverse:creator
 }
 
 // ogBase returns an *ogMeta suitable for serving at
@@ -99,6 +102,10 @@ func (og *ogMeta) withAccount(account *apimodel.Account) *ogMeta {
 
 	og.ProfileUsername = account.Username
 
+	// Lets sites that link to this profile
+	// credit the account as their author.
+	og.FediverseCreator = "@" + account.Username + "@" + og.SiteName
+
 	return og
 }
 
@@ -75,6 +75,7 @@ func (suite *OpenGraphTestSuite) TestWithAccountWithNote() {
 		ArticleModifiedTime:  "",
 		ArticlePublishedTime: "",
 		ProfileUsername:      "example_account",
+		FediverseCreator:     "@example_account@example.org",
 	}, *accountMeta)
 }
 
@@ -108,6 +109,7 @@ func (suite *OpenGraphTestSuite) TestWithAccountNoNote() {
 		ArticleModifiedTime:  "",
 		ArticlePublishedTime: "",
 		ProfileUsername:      "example_account",
+		FediverseCreator:     "@example_account@example.org",
 	}, *accountMeta)
 }
 
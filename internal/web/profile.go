@@ -83,10 +83,19 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 		rssFeed = "/@" + account.Username + "/feed.rss"
 	}
 
-	// only allow search engines / robots to view this page if account is discoverable
-	var robotsMeta string
-	if account.Discoverable {
-		robotsMeta = robotsMetaAllowSome
+	// only allow search engines / robots to view this page if account is discoverable,
+	// and only generate OpenGraph meta if the account hasn't opted out of link previews
+	var (
+		robotsMeta string
+		og         *ogMeta
+	)
+	if account.HideLinkPreviews {
+		c.Header(robotsTagHeader, "noindex")
+	} else {
+		og = ogBase(instance).withAccount(account)
+		if account.Discoverable {
+			robotsMeta = robotsMetaAllowSome
+		}
 	}
 
 	// We need to change our response slightly if the
@@ -113,13 +122,20 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 	// load pinned statuses so we can show them at the
 	// top of the profile.
 	if !paging {
-		pinnedResp, errWithCode = m.processor.Account().StatusesGet(ctx, authed.Account, account.ID, 0, false, false, "", "", true, false, false)
+		pinnedResp, errWithCode = m.processor.Account().StatusesGet(ctx, authed.Account, account.ID, 0, false, false, "", "", true, false, false, false, false)
 		if errWithCode != nil {
 			apiutil.WebErrorHandler(c, errWithCode, instanceGet)
 			return
 		}
 	}
 
+	// Show a "Now Playing" badge if the account
+	// has shared a listen that's still active.
+	var nowPlaying *apimodel.NowPlaying
+	if np, errWithCode := m.processor.Account().NowPlayingGet(ctx, authed.Account, account.ID); errWithCode == nil && np.Active {
+		nowPlaying = np
+	}
+
 	stylesheets := []string{
 		assetsPathPrefix + "/Fork-Awesome/css/fork-awesome.min.css",
 		distPathPrefix + "/status.css",
@@ -132,12 +148,13 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 	c.HTML(http.StatusOK, "profile.tmpl", gin.H{
 		"instance":         instance,
 		"account":          account,
-		"ogMeta":           ogBase(instance).withAccount(account),
+		"ogMeta":           og,
 		"rssFeed":          rssFeed,
 		"robotsMeta":       robotsMeta,
 		"statuses":         statusResp.Items,
 		"statuses_next":    statusResp.NextLink,
 		"pinned_statuses":  pinnedResp.Items,
+		"now_playing":      nowPlaying,
 		"show_back_to_top": paging,
 		"stylesheets":      stylesheets,
 		"javascript":       []string{distPathPrefix + "/frontend.js"},
@@ -26,6 +26,7 @@ import (
 const (
 	robotsPath          = "/robots.txt"
 	robotsMetaAllowSome = "nofollow, noarchive, nositelinkssearchbox, max-image-preview:standard" // https://developers.google.com/search/docs/crawling-indexing/robots-meta-tag#robotsmeta
+	robotsTagHeader     = "X-Robots-Tag"                                                          // https://developers.google.com/search/docs/crawling-indexing/robots-meta-tag#xrobotstag
 	robotsTxt           = `# GoToSocial robots.txt -- to edit, see internal/web/robots.go
 # more info @ https://developers.google.com/search/docs/crawling-indexing/robots/intro
 User-agent: *
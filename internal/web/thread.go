@@ -31,6 +31,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"golang.org/x/text/language"
 )
 
 func (m *Module) threadGETHandler(c *gin.Context) {
@@ -50,8 +52,8 @@ func (m *Module) threadGETHandler(c *gin.Context) {
 		return
 	}
 
-	// status ids will always be uppercase
-	statusID := strings.ToUpper(c.Param(statusIDKey))
+	// may be either a status id or its short alias
+	statusID := c.Param(statusIDKey)
 	if statusID == "" {
 		err := errors.New("no status id specified")
 		apiutil.WebErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
@@ -75,7 +77,7 @@ func (m *Module) threadGETHandler(c *gin.Context) {
 		return
 	}
 
-	status, errWithCode := m.processor.Status().Get(ctx, authed.Account, statusID)
+	status, errWithCode := m.processor.Status().WebGet(ctx, authed.Account, statusID)
 	if errWithCode != nil {
 		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
 		return
@@ -87,6 +89,10 @@ func (m *Module) threadGETHandler(c *gin.Context) {
 		return
 	}
 
+	// Use the status id from here
+	// on, in case we got an alias.
+	statusID = status.ID
+
 	// if we're getting an AP request on this endpoint we
 	// should render the status's AP representation instead
 	accept := apiutil.NegotiateFormat(c, string(apiutil.TextHTML), string(apiutil.AppActivityJSON), string(apiutil.AppActivityLDJSON))
@@ -95,12 +101,39 @@ func (m *Module) threadGETHandler(c *gin.Context) {
 		return
 	}
 
-	context, errWithCode := m.processor.Status().ContextGet(ctx, authed.Account, statusID)
+	context, errWithCode := m.processor.Status().WebContextGet(ctx, authed.Account, statusID)
 	if errWithCode != nil {
 		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
 		return
 	}
 
+	// Link to the start of the thread if
+	// this status isn't the start already.
+	var threadRoot *apimodel.Status
+	if status.InReplyToID != nil {
+		root, errWithCode := m.processor.Status().GetThreadRoot(ctx, authed.Account, statusID)
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+			return
+		}
+
+		if root.ID != status.ID {
+			threadRoot = root
+		}
+	}
+
+	// Render the language variant of each
+	// status that best suits the viewer.
+	prefs, _, _ := language.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+	pickContent(status, prefs)
+	pickContent(threadRoot, prefs)
+	for i := range context.Ancestors {
+		pickContent(&context.Ancestors[i], prefs)
+	}
+	for i := range context.Descendants {
+		pickContent(&context.Descendants[i], prefs)
+	}
+
 	stylesheets := []string{
 		assetsPathPrefix + "/Fork-Awesome/css/fork-awesome.min.css",
 		distPathPrefix + "/status.css",
@@ -109,16 +142,49 @@ func (m *Module) threadGETHandler(c *gin.Context) {
 		stylesheets = append(stylesheets, "/@"+username+"/custom.css")
 	}
 
+	// A status in the thread may be highlighted, eg., when
+	// linking to a reply, for browsers where fragments and
+	// the :target selector aren't available to do this.
+	highlight := strings.ToUpper(c.Query(highlightParam))
+
+	// OpenGraph meta is always for the requested status,
+	// even if it's a reply, rather than for the thread.
+	//
+	// Skip OpenGraph meta entirely if the author
+	// doesn't want link previews of their statuses.
+	var og *ogMeta
+	if status.Account.HideLinkPreviews {
+		c.Header(robotsTagHeader, "noindex")
+	} else {
+		og = ogBase(instance).withStatus(status)
+	}
+
 	c.HTML(http.StatusOK, "thread.tmpl", gin.H{
 		"instance":    instance,
 		"status":      status,
 		"context":     context,
-		"ogMeta":      ogBase(instance).withStatus(status),
+		"threadRoot":  threadRoot,
+		"highlight":   highlight,
+		"ogMeta":      og,
+		"canonical":   status.URL,
 		"stylesheets": stylesheets,
 		"javascript":  []string{distPathPrefix + "/frontend.js"},
 	})
 }
 
+// pickContent replaces the content of the given status with
+// the language variant from its content map that best matches
+// the given preferences, if it has more than one variant.
+func pickContent(status *apimodel.Status, prefs []language.Tag) {
+	if status == nil || len(status.ContentMap) == 0 {
+		return
+	}
+
+	lang := util.PickLanguage(status.ContentMap, prefs...)
+	status.Content = status.ContentMap[lang]
+	status.Language = &lang
+}
+
 func (m *Module) returnAPStatus(c *gin.Context, username string, statusID string, accept string) {
 	status, errWithCode := m.processor.Fedi().StatusGet(c.Request.Context(), username, statusID)
 	if errWithCode != nil {
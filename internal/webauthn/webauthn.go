@@ -0,0 +1,343 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package webauthn implements the server side verification of
+// WebAuthn registrations and assertions, as needed to let users
+// sign in with passkeys.
+//
+// To avoid having to decode CBOR, browsers are expected to send
+// along the DER encoded public key and raw authenticator data of
+// new credentials, as exposed by getPublicKey() and
+// getAuthenticatorData() on AuthenticatorAttestationResponse.
+// Since only "none" attestation is requested, nothing else of
+// the attestation object is needed.
+//
+// See: https://www.w3.org/TR/webauthn-2/
+package webauthn
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// COSE algorithm identifiers of the supported public key types.
+//
+// See: https://www.iana.org/assignments/cose/cose.xhtml#algorithms
+const (
+	AlgES256 = -7
+	AlgEdDSA = -8
+	AlgRS256 = -257
+)
+
+// ChallengeTimeout is how long clients have to respond
+// to a registration or sign in challenge once issued.
+const ChallengeTimeout = 5 * time.Minute
+
+const (
+	typeCreate = "webauthn.create"
+	typeGet    = "webauthn.get"
+
+	flagUserPresent       = 0x01
+	flagAttestedCredsData = 0x40
+)
+
+// Algorithms contains the COSE algorithm identifiers
+// of the supported public key types, most preferred first.
+var Algorithms = []int{AlgES256, AlgEdDSA, AlgRS256}
+
+// RPID returns the relying party ID of this
+// instance, which is its host minus any port.
+func RPID() string {
+	host := config.GetHost()
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return host
+}
+
+// Origin returns the origin which browsers
+// will report when talking to this instance.
+func Origin() string {
+	return config.GetProtocol() + "://" + config.GetHost()
+}
+
+// NewChallenge returns a new random base64url encoded challenge.
+func NewChallenge() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating challenge: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeField decodes the base64url encoded value of the
+// named field of a registration or assertion request.
+func DecodeField(name string, value string) ([]byte, error) {
+	if value == "" {
+		return nil, fmt.Errorf("%s must be set", name)
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, fmt.Errorf("%s was not valid base64url: %w", name, err)
+	}
+
+	return b, nil
+}
+
+// AuthenticatorData contains the parts of
+// the raw authenticator data that we use.
+type AuthenticatorData struct {
+	RPIDHash  []byte
+	Flags     byte
+	SignCount uint32
+
+	// CredentialID is only set if the authenticator
+	// data contains attested credential data, ie.,
+	// when a new credential is being registered.
+	CredentialID []byte
+}
+
+// ParseAuthenticatorData parses the given raw authenticator data.
+//
+// See: https://www.w3.org/TR/webauthn-2/#sctn-authenticator-data
+func ParseAuthenticatorData(raw []byte) (*AuthenticatorData, error) {
+	if len(raw) < 37 {
+		return nil, fmt.Errorf("authenticator data was %d bytes, expected at least 37", len(raw))
+	}
+
+	data := &AuthenticatorData{
+		RPIDHash:  raw[:32],
+		Flags:     raw[32],
+		SignCount: binary.BigEndian.Uint32(raw[33:37]),
+	}
+
+	if data.Flags&flagAttestedCredsData != 0 {
+		// Attested credential data starts with a 16 byte
+		// AAGUID, then the credential ID preceded by its
+		// length as a 2 byte big endian integer.
+		creds := raw[37:]
+		if len(creds) < 18 {
+			return nil, errors.New("attested credential data was too short")
+		}
+
+		idLen := int(binary.BigEndian.Uint16(creds[16:18]))
+		if len(creds) < 18+idLen {
+			return nil, errors.New("attested credential data was too short for credential ID")
+		}
+
+		data.CredentialID = creds[18 : 18+idLen]
+	}
+
+	return data, nil
+}
+
+func (d *AuthenticatorData) verify() error {
+	rpIDHash := sha256.Sum256([]byte(RPID()))
+	if subtle.ConstantTimeCompare(d.RPIDHash, rpIDHash[:]) != 1 {
+		return errors.New("authenticator data was not for this relying party")
+	}
+
+	if d.Flags&flagUserPresent == 0 {
+		return errors.New("authenticator did not report user presence")
+	}
+
+	return nil
+}
+
+// SignCountValid returns whether a signature counter reported by an
+// authenticator is plausible given the last one we stored. A counter
+// which does not increase may point to a cloned authenticator, but
+// authenticators without a counter always report zero.
+func SignCountValid(stored uint32, reported uint32) bool {
+	if stored == 0 && reported == 0 {
+		return true
+	}
+	return reported > stored
+}
+
+// Registration contains the parts of a
+// new credential as sent by the browser.
+type Registration struct {
+	CredentialID      []byte
+	ClientDataJSON    []byte
+	AuthenticatorData []byte
+	PublicKey         []byte // DER encoded SubjectPublicKeyInfo
+	Algorithm         int
+}
+
+// VerifyRegistration verifies that the given registration is
+// a response to the given challenge, made for this instance.
+//
+// See: https://www.w3.org/TR/webauthn-2/#sctn-registering-a-new-credential
+func VerifyRegistration(r *Registration, challenge string) (*AuthenticatorData, error) {
+	if err := verifyClientData(r.ClientDataJSON, typeCreate, challenge); err != nil {
+		return nil, err
+	}
+
+	authData, err := ParseAuthenticatorData(r.AuthenticatorData)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authData.verify(); err != nil {
+		return nil, err
+	}
+
+	if authData.CredentialID == nil {
+		return nil, errors.New("authenticator data contained no attested credential data")
+	}
+
+	if !bytes.Equal(authData.CredentialID, r.CredentialID) {
+		return nil, errors.New("credential ID did not match attested credential data")
+	}
+
+	if _, err := parsePublicKey(r.PublicKey, r.Algorithm); err != nil {
+		return nil, err
+	}
+
+	return authData, nil
+}
+
+// Assertion contains the parts of a signed
+// sign in challenge as sent by the browser.
+type Assertion struct {
+	ClientDataJSON    []byte
+	AuthenticatorData []byte
+	Signature         []byte
+}
+
+// VerifyAssertion verifies that the given assertion is a response to
+// the given challenge made for this instance, signed by the private
+// key belonging to the given DER encoded public key.
+//
+// See: https://www.w3.org/TR/webauthn-2/#sctn-verifying-assertion
+func VerifyAssertion(a *Assertion, challenge string, publicKey []byte, alg int) (*AuthenticatorData, error) {
+	if err := verifyClientData(a.ClientDataJSON, typeGet, challenge); err != nil {
+		return nil, err
+	}
+
+	authData, err := ParseAuthenticatorData(a.AuthenticatorData)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authData.verify(); err != nil {
+		return nil, err
+	}
+
+	key, err := parsePublicKey(publicKey, alg)
+	if err != nil {
+		return nil, err
+	}
+
+	// The signature is over the authenticator
+	// data followed by the hash of the client data.
+	clientDataHash := sha256.Sum256(a.ClientDataJSON)
+	signed := make([]byte, 0, len(a.AuthenticatorData)+len(clientDataHash))
+	signed = append(signed, a.AuthenticatorData...)
+	signed = append(signed, clientDataHash[:]...)
+
+	if !verifySignature(key, signed, a.Signature) {
+		return nil, errors.New("signature did not verify")
+	}
+
+	return authData, nil
+}
+
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+func verifyClientData(raw []byte, typ string, challenge string) error {
+	var data clientData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return fmt.Errorf("error parsing client data: %w", err)
+	}
+
+	if data.Type != typ {
+		return fmt.Errorf("client data type was %q, expected %q", data.Type, typ)
+	}
+
+	if challenge == "" || subtle.ConstantTimeCompare([]byte(data.Challenge), []byte(challenge)) != 1 {
+		return errors.New("client data challenge did not match")
+	}
+
+	if origin := Origin(); data.Origin != origin {
+		return fmt.Errorf("client data origin was %q, expected %q", data.Origin, origin)
+	}
+
+	return nil
+}
+
+func parsePublicKey(der []byte, alg int) (crypto.PublicKey, error) {
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %w", err)
+	}
+
+	var ok bool
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		ok = alg == AlgES256 && k.Curve == elliptic.P256()
+	case ed25519.PublicKey:
+		ok = alg == AlgEdDSA
+	case *rsa.PublicKey:
+		ok = alg == AlgRS256
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("public key of type %T is not supported with algorithm %d", key, alg)
+	}
+
+	return key, nil
+}
+
+func verifySignature(key crypto.PublicKey, signed []byte, sig []byte) bool {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		hash := sha256.Sum256(signed)
+		return ecdsa.VerifyASN1(k, hash[:], sig)
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, signed, sig)
+	case *rsa.PublicKey:
+		hash := sha256.Sum256(signed)
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], sig) == nil
+	default:
+		return false
+	}
+}
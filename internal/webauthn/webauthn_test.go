@@ -0,0 +1,201 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webauthn_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type WebAuthnTestSuite struct {
+	suite.Suite
+	key          *ecdsa.PrivateKey
+	publicKey    []byte
+	credentialID []byte
+}
+
+func (suite *WebAuthnTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.key = key
+	suite.publicKey = publicKey
+	suite.credentialID = []byte("some-credential-id")
+}
+
+func (suite *WebAuthnTestSuite) clientData(typ string, challenge string, origin string) []byte {
+	b, err := json.Marshal(map[string]string{
+		"type":      typ,
+		"challenge": challenge,
+		"origin":    origin,
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return b
+}
+
+func (suite *WebAuthnTestSuite) authData(rpID string, flags byte, signCount uint32, credentialID []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+
+	b := append([]byte{}, rpIDHash[:]...)
+	b = append(b, flags)
+	b = binary.BigEndian.AppendUint32(b, signCount)
+
+	if credentialID != nil {
+		b = append(b, make([]byte, 16)...) // AAGUID
+		b = binary.BigEndian.AppendUint16(b, uint16(len(credentialID)))
+		b = append(b, credentialID...)
+	}
+
+	return b
+}
+
+func (suite *WebAuthnTestSuite) sign(authData []byte, clientDataJSON []byte) []byte {
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	hash := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+
+	sig, err := ecdsa.SignASN1(rand.Reader, suite.key, hash[:])
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return sig
+}
+
+func (suite *WebAuthnTestSuite) TestRPIDAndOrigin() {
+	suite.Equal("localhost", webauthn.RPID())
+	suite.Equal("http://localhost:8080", webauthn.Origin())
+}
+
+func (suite *WebAuthnTestSuite) TestVerifyRegistration() {
+	authData, err := webauthn.VerifyRegistration(&webauthn.Registration{
+		CredentialID:      suite.credentialID,
+		ClientDataJSON:    suite.clientData("webauthn.create", "challenge", webauthn.Origin()),
+		AuthenticatorData: suite.authData(webauthn.RPID(), 0x41, 0, suite.credentialID),
+		PublicKey:         suite.publicKey,
+		Algorithm:         webauthn.AlgES256,
+	}, "challenge")
+	suite.NoError(err)
+	suite.Equal(suite.credentialID, authData.CredentialID)
+}
+
+func (suite *WebAuthnTestSuite) TestVerifyRegistrationWrongChallenge() {
+	_, err := webauthn.VerifyRegistration(&webauthn.Registration{
+		CredentialID:      suite.credentialID,
+		ClientDataJSON:    suite.clientData("webauthn.create", "challenge", webauthn.Origin()),
+		AuthenticatorData: suite.authData(webauthn.RPID(), 0x41, 0, suite.credentialID),
+		PublicKey:         suite.publicKey,
+		Algorithm:         webauthn.AlgES256,
+	}, "some other challenge")
+	suite.EqualError(err, "client data challenge did not match")
+}
+
+func (suite *WebAuthnTestSuite) TestVerifyRegistrationWrongAlgorithm() {
+	_, err := webauthn.VerifyRegistration(&webauthn.Registration{
+		CredentialID:      suite.credentialID,
+		ClientDataJSON:    suite.clientData("webauthn.create", "challenge", webauthn.Origin()),
+		AuthenticatorData: suite.authData(webauthn.RPID(), 0x41, 0, suite.credentialID),
+		PublicKey:         suite.publicKey,
+		Algorithm:         webauthn.AlgRS256,
+	}, "challenge")
+	suite.EqualError(err, "public key of type *ecdsa.PublicKey is not supported with algorithm -257")
+}
+
+func (suite *WebAuthnTestSuite) TestVerifyAssertion() {
+	clientDataJSON := suite.clientData("webauthn.get", "challenge", webauthn.Origin())
+	authData := suite.authData(webauthn.RPID(), 0x01, 5, nil)
+
+	data, err := webauthn.VerifyAssertion(&webauthn.Assertion{
+		ClientDataJSON:    clientDataJSON,
+		AuthenticatorData: authData,
+		Signature:         suite.sign(authData, clientDataJSON),
+	}, "challenge", suite.publicKey, webauthn.AlgES256)
+	suite.NoError(err)
+	suite.EqualValues(5, data.SignCount)
+}
+
+func (suite *WebAuthnTestSuite) TestVerifyAssertionWrongOrigin() {
+	clientDataJSON := suite.clientData("webauthn.get", "challenge", "https://evil.example.org")
+	authData := suite.authData(webauthn.RPID(), 0x01, 5, nil)
+
+	_, err := webauthn.VerifyAssertion(&webauthn.Assertion{
+		ClientDataJSON:    clientDataJSON,
+		AuthenticatorData: authData,
+		Signature:         suite.sign(authData, clientDataJSON),
+	}, "challenge", suite.publicKey, webauthn.AlgES256)
+	suite.EqualError(err, `client data origin was "https://evil.example.org", expected "http://localhost:8080"`)
+}
+
+func (suite *WebAuthnTestSuite) TestVerifyAssertionWrongRPID() {
+	clientDataJSON := suite.clientData("webauthn.get", "challenge", webauthn.Origin())
+	authData := suite.authData("evil.example.org", 0x01, 5, nil)
+
+	_, err := webauthn.VerifyAssertion(&webauthn.Assertion{
+		ClientDataJSON:    clientDataJSON,
+		AuthenticatorData: authData,
+		Signature:         suite.sign(authData, clientDataJSON),
+	}, "challenge", suite.publicKey, webauthn.AlgES256)
+	suite.EqualError(err, "authenticator data was not for this relying party")
+}
+
+func (suite *WebAuthnTestSuite) TestVerifyAssertionBadSignature() {
+	clientDataJSON := suite.clientData("webauthn.get", "challenge", webauthn.Origin())
+	authData := suite.authData(webauthn.RPID(), 0x01, 5, nil)
+
+	// Sign different authenticator data
+	// from the data that's sent along.
+	sig := suite.sign(suite.authData(webauthn.RPID(), 0x01, 6, nil), clientDataJSON)
+
+	_, err := webauthn.VerifyAssertion(&webauthn.Assertion{
+		ClientDataJSON:    clientDataJSON,
+		AuthenticatorData: authData,
+		Signature:         sig,
+	}, "challenge", suite.publicKey, webauthn.AlgES256)
+	suite.EqualError(err, "signature did not verify")
+}
+
+func (suite *WebAuthnTestSuite) TestSignCountValid() {
+	suite.True(webauthn.SignCountValid(0, 0))
+	suite.True(webauthn.SignCountValid(0, 1))
+	suite.True(webauthn.SignCountValid(4, 5))
+	suite.False(webauthn.SignCountValid(5, 5))
+	suite.False(webauthn.SignCountValid(5, 0))
+}
+
+func TestWebAuthnTestSuite(t *testing.T) {
+	suite.Run(t, &WebAuthnTestSuite{})
+}
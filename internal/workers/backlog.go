@@ -0,0 +1,371 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// BacklogMaxLen is the number of messages at which a Backlog is full.
+// Inbox deliveries are refused while the federator backlog is full,
+// so it only goes beyond this by messages of deliveries in progress.
+const BacklogMaxLen = 10000
+
+// Backlog holds federator messages whose processing has
+// been deferred, eg., while the instance is in maintenance
+// mode, until they are taken to be enqueued as normal.
+//
+// Messages are stored in the database, so that they survive
+// a restart of the instance. The GTS model of each message is
+// stored without its relations, which are loaded again when
+// the message is taken; models which were already stored are
+// reloaded as-is, and messages whose model was deleted in the
+// meantime are dropped.
+type Backlog struct {
+	db    db.DB
+	mutex sync.Mutex
+}
+
+// Init sets the database that the backlog is stored in.
+// This must be called before using the backlog.
+func (b *Backlog) Init(db db.DB) {
+	b.db = db
+}
+
+// Append adds the given messages to the end of the backlog.
+func (b *Backlog) Append(ctx context.Context, msgs ...messages.FromFederator) error {
+	if b.db == nil {
+		return errors.New("backlog not initialized")
+	}
+
+	stored := make([]*gtsmodel.BacklogMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		bmsg, err := encodeBacklogMessage(msg)
+		if err != nil {
+			return err
+		}
+		stored = append(stored, bmsg)
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if err := b.db.PutBacklogMessages(ctx, stored); err != nil {
+		return gtserror.Newf("db error putting backlog messages: %w", err)
+	}
+
+	return nil
+}
+
+// Take empties the backlog, returning all messages in it, in the order they were added.
+func (b *Backlog) Take(ctx context.Context) ([]messages.FromFederator, error) {
+	if b.db == nil {
+		return nil, errors.New("backlog not initialized")
+	}
+
+	b.mutex.Lock()
+	stored, err := b.db.GetBacklogMessages(ctx)
+	if err == nil && len(stored) > 0 {
+		err = b.db.DeleteBacklogMessagesUpTo(ctx, stored[len(stored)-1].ID)
+	}
+	b.mutex.Unlock()
+
+	if err != nil {
+		return nil, gtserror.Newf("db error taking backlog messages: %w", err)
+	}
+
+	msgs := make([]messages.FromFederator, 0, len(stored))
+	for _, bmsg := range stored {
+		msg, err := b.decode(ctx, bmsg)
+		if err != nil {
+			log.Errorf(ctx, "dropping backlog message %s: %v", bmsg.ID, err)
+			continue
+		}
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
+
+// Len returns the number of messages currently in the backlog.
+func (b *Backlog) Len(ctx context.Context) (int, error) {
+	if b.db == nil {
+		return 0, errors.New("backlog not initialized")
+	}
+
+	n, err := b.db.CountBacklogMessages(ctx)
+	if err != nil {
+		return 0, gtserror.Newf("db error counting backlog messages: %w", err)
+	}
+
+	return n, nil
+}
+
+// Full returns whether the backlog holds BacklogMaxLen messages or more.
+func (b *Backlog) Full(ctx context.Context) (bool, error) {
+	n, err := b.Len(ctx)
+	return n >= BacklogMaxLen, err
+}
+
+// backlogModel describes how one type of GTS
+// model carried by federator messages is restored.
+type backlogModel struct {
+	// new allocates a model of this type.
+	new func() interface{}
+
+	// load loads the relations of the given decoded model from the
+	// database, or reloads the model itself if it's already stored.
+	load func(ctx context.Context, state db.DB, model interface{}) (interface{}, error)
+}
+
+// backlogModels contains the GTS models that federator
+// messages may carry, keyed by the name of their type.
+var backlogModels = map[string]backlogModel{
+	"Account": {
+		new: func() interface{} { return new(gtsmodel.Account) },
+		load: func(ctx context.Context, db db.DB, model interface{}) (interface{}, error) {
+			return model, db.PopulateAccount(ctx, model.(*gtsmodel.Account))
+		},
+	},
+	"Status": {
+		new: func() interface{} { return new(gtsmodel.Status) },
+		load: func(ctx context.Context, db db.DB, model interface{}) (interface{}, error) {
+			return model, db.PopulateStatus(ctx, model.(*gtsmodel.Status))
+		},
+	},
+	"StatusFave": {
+		new: func() interface{} { return new(gtsmodel.StatusFave) },
+		load: func(ctx context.Context, db db.DB, model interface{}) (interface{}, error) {
+			return model, db.PopulateStatusFave(ctx, model.(*gtsmodel.StatusFave))
+		},
+	},
+	"Follow": {
+		new: func() interface{} { return new(gtsmodel.Follow) },
+		load: func(ctx context.Context, db db.DB, model interface{}) (interface{}, error) {
+			return model, db.PopulateFollow(ctx, model.(*gtsmodel.Follow))
+		},
+	},
+	"FollowRequest": {
+		new: func() interface{} { return new(gtsmodel.FollowRequest) },
+		load: func(ctx context.Context, db db.DB, model interface{}) (interface{}, error) {
+			return db.GetFollowRequestByID(ctx, model.(*gtsmodel.FollowRequest).ID)
+		},
+	},
+	"Block": {
+		new: func() interface{} { return new(gtsmodel.Block) },
+		load: func(ctx context.Context, db db.DB, model interface{}) (interface{}, error) {
+			return db.GetBlockByID(ctx, model.(*gtsmodel.Block).ID)
+		},
+	},
+	"Report": {
+		new: func() interface{} { return new(gtsmodel.Report) },
+		load: func(ctx context.Context, db db.DB, model interface{}) (interface{}, error) {
+			return db.GetReportByID(ctx, model.(*gtsmodel.Report).ID)
+		},
+	},
+	"Bite": {
+		new: func() interface{} { return new(gtsmodel.Bite) },
+		load: func(ctx context.Context, db db.DB, model interface{}) (interface{}, error) {
+			var (
+				bite = model.(*gtsmodel.Bite)
+				err  error
+			)
+
+			bite.Account, err = db.GetAccountByID(ctx, bite.AccountID)
+			if err != nil {
+				return nil, err
+			}
+
+			bite.TargetAccount, err = db.GetAccountByID(ctx, bite.TargetAccountID)
+			if err != nil {
+				return nil, err
+			}
+
+			if bite.StatusID != "" {
+				bite.Status, err = db.GetStatusByID(ctx, bite.StatusID)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			return bite, nil
+		},
+	},
+}
+
+// encodeBacklogMessage encodes the given
+// federator message to be stored in the db.
+func encodeBacklogMessage(msg messages.FromFederator) (*gtsmodel.BacklogMessage, error) {
+	bmsg := &gtsmodel.BacklogMessage{
+		ID:             id.NewULID(),
+		APObjectType:   msg.APObjectType,
+		APActivityType: msg.APActivityType,
+	}
+
+	if msg.APIri != nil {
+		bmsg.APIri = msg.APIri.String()
+	}
+
+	if msg.APObjectModel != nil {
+		t, ok := msg.APObjectModel.(vocab.Type)
+		if !ok {
+			return nil, gtserror.Newf("unsupported ap model type %T", msg.APObjectModel)
+		}
+
+		m, err := ap.Serialize(t)
+		if err != nil {
+			return nil, gtserror.Newf("error serializing ap model: %w", err)
+		}
+
+		bmsg.APObject, err = json.Marshal(m)
+		if err != nil {
+			return nil, gtserror.Newf("error marshaling ap model: %w", err)
+		}
+	}
+
+	if msg.GTSModel != nil {
+		v := reflect.ValueOf(msg.GTSModel)
+		if v.Kind() != reflect.Pointer || v.IsNil() {
+			return nil, gtserror.Newf("unsupported gts model type %T", msg.GTSModel)
+		}
+
+		name := v.Elem().Type().Name()
+		if _, ok := backlogModels[name]; !ok {
+			return nil, gtserror.Newf("unsupported gts model type %T", msg.GTSModel)
+		}
+
+		b, err := json.Marshal(columns(v))
+		if err != nil {
+			return nil, gtserror.Newf("error marshaling gts model: %w", err)
+		}
+
+		bmsg.GTSModelType = name
+		bmsg.GTSModel = b
+	}
+
+	if msg.ReceivingAccount != nil {
+		bmsg.ReceivingAccountID = msg.ReceivingAccount.ID
+	}
+
+	return bmsg, nil
+}
+
+// decode decodes the given stored message, loading the
+// relations of its models from the db as necessary.
+func (b *Backlog) decode(ctx context.Context, bmsg *gtsmodel.BacklogMessage) (messages.FromFederator, error) {
+	msg := messages.FromFederator{
+		APObjectType:   bmsg.APObjectType,
+		APActivityType: bmsg.APActivityType,
+	}
+
+	var err error
+
+	if bmsg.APIri != "" {
+		msg.APIri, err = url.Parse(bmsg.APIri)
+		if err != nil {
+			return msg, gtserror.Newf("error parsing ap iri: %w", err)
+		}
+	}
+
+	if len(bmsg.APObject) != 0 {
+		if bmsg.APObjectType == ap.ObjectProfile {
+			msg.APObjectModel, err = ap.ResolveAccountable(ctx, bmsg.APObject)
+		} else {
+			msg.APObjectModel, err = ap.ResolveStatusable(ctx, bmsg.APObject)
+		}
+
+		if err != nil {
+			return msg, gtserror.Newf("error resolving ap model: %w", err)
+		}
+	}
+
+	if bmsg.GTSModelType != "" {
+		m, ok := backlogModels[bmsg.GTSModelType]
+		if !ok {
+			return msg, gtserror.Newf("unsupported gts model type %s", bmsg.GTSModelType)
+		}
+
+		model := m.new()
+		if err := json.Unmarshal(bmsg.GTSModel, model); err != nil {
+			return msg, gtserror.Newf("error unmarshaling gts model: %w", err)
+		}
+
+		msg.GTSModel, err = m.load(ctx, b.db, model)
+		if err != nil {
+			return msg, gtserror.Newf("error loading gts model: %w", err)
+		}
+	}
+
+	if bmsg.ReceivingAccountID != "" {
+		msg.ReceivingAccount, err = b.db.GetAccountByID(ctx, bmsg.ReceivingAccountID)
+		if err != nil {
+			return msg, gtserror.Newf("error getting receiving account: %w", err)
+		}
+	}
+
+	return msg, nil
+}
+
+// columns returns a shallow copy of the GTS model pointed to by v, with
+// all of its relations unset, so that only its own columns are encoded.
+// Relations are fields tagged with `bun:"-"`, a bun relation, or untagged
+// pointers to other GTS models.
+func columns(v reflect.Value) interface{} {
+	cp := reflect.New(v.Elem().Type())
+	cp.Elem().Set(v.Elem())
+
+	var (
+		s   = cp.Elem()
+		typ = s.Type()
+	)
+
+	for i := 0; i < s.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, tagged := field.Tag.Lookup("bun")
+		relation := tag == "-" ||
+			strings.Contains(tag, "rel:") ||
+			strings.Contains(tag, "m2m:") ||
+			(!tagged && field.Type.Kind() == reflect.Pointer &&
+				field.Type.Elem().PkgPath() == typ.PkgPath())
+
+		if relation {
+			s.Field(i).Set(reflect.Zero(field.Type))
+		}
+	}
+
+	return cp.Interface()
+}
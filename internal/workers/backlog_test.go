@@ -0,0 +1,184 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/workers"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type BacklogTestSuite struct {
+	suite.Suite
+	db    db.DB
+	state state.State
+
+	testAccounts map[string]*gtsmodel.Account
+	testFaves    map[string]*gtsmodel.StatusFave
+}
+
+func (suite *BacklogTestSuite) SetupSuite() {
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testFaves = testrig.NewTestFaves()
+}
+
+func (suite *BacklogTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+	suite.state.Caches.Init()
+	suite.db = testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.db, suite.testAccounts)
+}
+
+func (suite *BacklogTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *BacklogTestSuite) TestBacklogSurvivesRestart() {
+	var (
+		ctx              = context.Background()
+		receivingAccount = suite.testAccounts["local_account_1"]
+		remoteAccount    = suite.testAccounts["remote_account_1"]
+		note             = testrig.NewTestFediStatuses()["http://example.org/users/Some_User/statuses/afaba698-5740-4e32-a702-af61aa543bc1"]
+		person           = testrig.NewTestFediPeople()["https://unknown-instance.com/users/brand_new_person"]
+	)
+
+	fave, err := suite.db.GetStatusFaveByID(ctx, suite.testFaves["local_account_1_admin_account_status_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Messages as they're deferred
+	// while in maintenance mode.
+	msgs := []messages.FromFederator{
+		{
+			APObjectType:     ap.ObjectNote,
+			APActivityType:   ap.ActivityCreate,
+			APObjectModel:    note,
+			ReceivingAccount: receivingAccount,
+		},
+		{
+			APObjectType:     ap.ObjectProfile,
+			APActivityType:   ap.ActivityUpdate,
+			APObjectModel:    person,
+			GTSModel:         remoteAccount,
+			ReceivingAccount: receivingAccount,
+		},
+		{
+			APObjectType:     ap.ActivityLike,
+			APActivityType:   ap.ActivityCreate,
+			GTSModel:         fave,
+			ReceivingAccount: receivingAccount,
+		},
+		{
+			// Block that's gone by the time
+			// the backlog is taken: dropped.
+			APObjectType:   ap.ActivityBlock,
+			APActivityType: ap.ActivityCreate,
+			GTSModel: &gtsmodel.Block{
+				ID:              "01H7ZKR4M8T4J5Y0VQ2A1S5D3B",
+				AccountID:       remoteAccount.ID,
+				TargetAccountID: receivingAccount.ID,
+			},
+			ReceivingAccount: receivingAccount,
+		},
+	}
+
+	if err := suite.state.Workers.FederatorBacklog.Append(ctx, msgs...); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Start over with a new backlog
+	// on the same db, as on restart.
+	var backlog workers.Backlog
+	backlog.Init(suite.db)
+
+	n, err := backlog.Len(ctx)
+	suite.NoError(err)
+	suite.Equal(len(msgs), n)
+
+	taken, err := backlog.Take(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The block should have been dropped,
+	// the rest is in the order it was added.
+	if !suite.Len(taken, 3) {
+		suite.FailNow("")
+	}
+
+	suite.Equal(ap.ObjectNote, taken[0].APObjectType)
+	suite.Equal(ap.ActivityCreate, taken[0].APActivityType)
+	suite.Nil(taken[0].GTSModel)
+	suite.Equal(receivingAccount.ID, taken[0].ReceivingAccount.ID)
+	statusable, ok := taken[0].APObjectModel.(ap.Statusable)
+	if !ok {
+		suite.FailNow("", "expected Statusable, got %T", taken[0].APObjectModel)
+	}
+	suite.Equal(note.GetJSONLDId().Get().String(), statusable.GetJSONLDId().Get().String())
+	suite.Equal("this is a public status, please forward it!", ap.ExtractContent(statusable))
+
+	suite.Equal(ap.ObjectProfile, taken[1].APObjectType)
+	suite.Equal(ap.ActivityUpdate, taken[1].APActivityType)
+	accountable, ok := taken[1].APObjectModel.(ap.Accountable)
+	if !ok {
+		suite.FailNow("", "expected Accountable, got %T", taken[1].APObjectModel)
+	}
+	suite.Equal(person.GetJSONLDId().Get().String(), accountable.GetJSONLDId().Get().String())
+	account, ok := taken[1].GTSModel.(*gtsmodel.Account)
+	if !ok {
+		suite.FailNow("", "expected *gtsmodel.Account, got %T", taken[1].GTSModel)
+	}
+	suite.Equal(remoteAccount.ID, account.ID)
+	suite.Equal(remoteAccount.URI, account.URI)
+	suite.Equal(remoteAccount.Username, account.Username)
+
+	suite.Equal(ap.ActivityLike, taken[2].APObjectType)
+	gotFave, ok := taken[2].GTSModel.(*gtsmodel.StatusFave)
+	if !ok {
+		suite.FailNow("", "expected *gtsmodel.StatusFave, got %T", taken[2].GTSModel)
+	}
+	suite.Equal(fave.ID, gotFave.ID)
+	suite.Equal(fave.URI, gotFave.URI)
+
+	// Relations are loaded again from the db.
+	suite.NotNil(gotFave.Account)
+	suite.NotNil(gotFave.TargetAccount)
+	suite.NotNil(gotFave.Status)
+	suite.Equal(fave.StatusID, gotFave.Status.ID)
+
+	// The backlog was emptied for both.
+	for _, b := range []*workers.Backlog{&backlog, &suite.state.Workers.FederatorBacklog} {
+		n, err := b.Len(ctx)
+		suite.NoError(err)
+		suite.Zero(n)
+	}
+}
+
+func TestBacklogTestSuite(t *testing.T) {
+	suite.Run(t, new(BacklogTestSuite))
+}
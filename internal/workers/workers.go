@@ -21,9 +21,11 @@ import (
 	"context"
 	"log"
 	"runtime"
+	"sync"
 
 	"codeberg.org/gruf/go-runners"
 	"codeberg.org/gruf/go-sched"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
 
@@ -45,9 +47,27 @@ type Workers struct {
 	EnqueueClientAPI func(context.Context, ...messages.FromClientAPI)
 	EnqueueFederator func(context.Context, ...messages.FromFederator)
 
+	// FederatorBacklog holds federator messages
+	// received while in maintenance mode, to be
+	// enqueued once maintenance mode is turned off.
+	FederatorBacklog Backlog
+
 	// Media manager worker pools.
 	Media runners.WorkerPool
 
+	// Thumbnail provides a worker pool that generates
+	// thumbnails and blurhashes for uploaded media. This
+	// is CPU bound, so it's kept apart from the Media pool,
+	// which is mostly busy fetching remote media.
+	Thumbnail runners.WorkerPool
+
+	// Worker counts of the above pools,
+	// set on Start() and used by Drain().
+	clientAPIWorkers int
+	federatorWorkers int
+	mediaWorkers     int
+	thumbnailWorkers int
+
 	// prevent pass-by-value.
 	_ nocopy
 }
@@ -61,25 +81,70 @@ func (w *Workers) Start() {
 		return w.Scheduler.Start(nil)
 	})
 
+	w.clientAPIWorkers = 4 * maxprocs
 	tryUntil("starting client API workerpool", 5, func() bool {
-		return w.ClientAPI.Start(4*maxprocs, 400*maxprocs)
+		return w.ClientAPI.Start(w.clientAPIWorkers, 400*maxprocs)
 	})
 
+	w.federatorWorkers = 4 * maxprocs
 	tryUntil("starting federator workerpool", 5, func() bool {
-		return w.Federator.Start(4*maxprocs, 400*maxprocs)
+		return w.Federator.Start(w.federatorWorkers, 400*maxprocs)
 	})
 
+	w.mediaWorkers = 8 * maxprocs
 	tryUntil("starting media workerpool", 5, func() bool {
-		return w.Media.Start(8*maxprocs, 80*maxprocs)
+		return w.Media.Start(w.mediaWorkers, 80*maxprocs)
+	})
+
+	// Thumbnailing is CPU bound,
+	// so default to one worker per CPU.
+	w.thumbnailWorkers = config.GetMediaThumbnailWorkers()
+	if w.thumbnailWorkers <= 0 {
+		w.thumbnailWorkers = runtime.NumCPU()
+	}
+	tryUntil("starting thumbnail workerpool", 5, func() bool {
+		return w.Thumbnail.Start(w.thumbnailWorkers, 10*w.thumbnailWorkers)
 	})
 }
 
+// Drain will wait for all queued and in-progress work in the client API,
+// federator, media and thumbnail worker pools to be finished, while the pools are still
+// running. This should be called before Stop(), as Stop() runs any remaining
+// queued work with an already-cancelled context, which will almost always fail.
+//
+// Drain returns early once ctx is cancelled, returning the number of functions
+// that were still queued at that point; these will be dropped on Stop().
+func (w *Workers) Drain(ctx context.Context) int {
+	// Work in one pool may enqueue further
+	// work in another (e.g. client API side
+	// effects queueing federator work), so
+	// keep going until all pools are empty.
+	for {
+		drain(ctx, &w.ClientAPI, w.clientAPIWorkers)
+		drain(ctx, &w.Federator, w.federatorWorkers)
+		drain(ctx, &w.Media, w.mediaWorkers)
+		drain(ctx, &w.Thumbnail, w.thumbnailWorkers)
+
+		queued := w.Queued()
+		if queued == 0 || ctx.Err() != nil {
+			return queued
+		}
+	}
+}
+
+// Queued returns the total number of functions currently
+// queued in the client API, federator, media and thumbnail worker pools.
+func (w *Workers) Queued() int {
+	return w.ClientAPI.Queue() + w.Federator.Queue() + w.Media.Queue() + w.Thumbnail.Queue()
+}
+
 // Stop will stop all of the contained worker pools (and global scheduler).
 func (w *Workers) Stop() {
 	tryUntil("stopping scheduler", 5, w.Scheduler.Stop)
 	tryUntil("stopping client API workerpool", 5, w.ClientAPI.Stop)
 	tryUntil("stopping federator workerpool", 5, w.Federator.Stop)
 	tryUntil("stopping media workerpool", 5, w.Media.Stop)
+	tryUntil("stopping thumbnail workerpool", 5, w.Thumbnail.Stop)
 }
 
 // nocopy when embedded will signal linter to
@@ -90,6 +155,43 @@ func (*nocopy) Lock() {}
 
 func (*nocopy) Unlock() {}
 
+// drain blocks until everything queued on pool before calling drain has been
+// run to completion, or ctx is cancelled. It does this by queueing a blocking
+// barrier function for each of the pool's workers: once every worker is held
+// at a barrier, nothing queued ahead of them can still be queued or in progress.
+func drain(ctx context.Context, pool *runners.WorkerPool, workers int) {
+	if workers <= 0 {
+		// Never started.
+		return
+	}
+
+	var wg sync.WaitGroup
+	release := make(chan struct{})
+	defer close(release)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		if !pool.EnqueueCtx(ctx, func(context.Context) {
+			wg.Done()
+			<-release
+		}) {
+			// ctx cancelled or pool stopped.
+			return
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
 // tryUntil will attempt to call 'do' for 'count' attempts, before panicking with 'msg'.
 func tryUntil(msg string, count int, do func() bool) {
 	for i := 0; i < count; i++ {
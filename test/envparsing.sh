@@ -8,9 +8,20 @@ EXPECT=$(cat <<"EOF"
     "accounts-allow-custom-css": true,
     "accounts-approval-required": false,
     "accounts-custom-css-length": 5000,
+    "accounts-emoji-max-size": 102400,
+    "accounts-max-emojis": 10,
+    "accounts-notify-new-signups": false,
+    "accounts-notify-new-signups-interval": 300000000000,
+    "accounts-password-breached-filter": "/gotosocial/breached-passwords.bloom",
+    "accounts-password-min-entropy": 50,
+    "accounts-password-min-length": 10,
     "accounts-reason-required": false,
     "accounts-registration-open": true,
+    "accounts-self-delete-delay": 604800000000000,
+    "admin": false,
     "advanced-cookies-samesite": "strict",
+    "advanced-inbox-max-body-size": 2097152,
+    "advanced-inbox-rate-limit-requests": 420,
     "advanced-rate-limit-requests": 6969,
     "advanced-sender-multiplier": -1,
     "advanced-throttling-multiplier": -1,
@@ -92,14 +103,22 @@ EXPECT=$(cat <<"EOF"
     "db-tls-mode": "disable",
     "db-type": "sqlite",
     "db-user": "sex-haver",
+    "domain": "",
     "dry-run": true,
     "email": "",
+    "federation-allow-private-ips": true,
+    "federation-user-agent": "example.com federation bot",
+    "format": "table",
+    "group-by": "domain",
     "host": "example.com",
+    "input": "",
     "instance-deliver-to-shared-inboxes": false,
+    "instance-expose-activity": true,
     "instance-expose-peers": true,
     "instance-expose-public-timeline": true,
     "instance-expose-suspended": true,
     "instance-expose-suspended-web": true,
+    "instance-stats-api": false,
     "landing-page-user": "admin",
     "letsencrypt-cert-dir": "/gotosocial/storage/certs",
     "letsencrypt-email-address": "",
@@ -108,12 +127,54 @@ EXPECT=$(cat <<"EOF"
     "log-client-ip": false,
     "log-db-queries": true,
     "log-level": "info",
+    "maintenance-message": "back soon",
+    "maintenance-mode": true,
+    "media-allowed-types": [
+        "image/*",
+        "video/mp4"
+    ],
+    "media-auto-alt-text-enabled": true,
+    "media-auto-alt-text-url": "http://localhost:9000/caption",
+    "media-blocked-types": [
+        "image/webp"
+    ],
     "media-description-max-chars": 5000,
     "media-description-min-chars": 69,
     "media-emoji-local-max-size": 420,
     "media-emoji-remote-max-size": 420,
     "media-image-max-size": 420,
+    "media-oembed-providers": {
+        "soundcloud": {
+            "Domains": [
+                "soundcloud.com"
+            ],
+            "Endpoint": "https://soundcloud.com/oembed"
+        },
+        "spotify": {
+            "Domains": [
+                "open.spotify.com"
+            ],
+            "Endpoint": "https://open.spotify.com/oembed"
+        },
+        "vimeo": {
+            "Domains": [
+                "vimeo.com"
+            ],
+            "Endpoint": "https://vimeo.com/api/oembed.json"
+        },
+        "youtube": {
+            "Domains": [
+                "youtube.com",
+                "youtu.be"
+            ],
+            "Endpoint": "https://www.youtube.com/oembed"
+        }
+    },
+    "media-proxy-enabled": true,
     "media-remote-cache-days": 30,
+    "media-roles": null,
+    "media-thumbnail-workers": 8,
+    "media-unused-grace-period": 1800000000000,
     "media-video-max-size": 420,
     "oidc-admin-groups": [
         "steamy"
@@ -121,44 +182,80 @@ EXPECT=$(cat <<"EOF"
     "oidc-client-id": "1234",
     "oidc-client-secret": "shhhh its a secret",
     "oidc-enabled": true,
+    "oidc-groups-claim": "roles",
     "oidc-idp-name": "sex-haver",
     "oidc-issuer": "whoknows",
     "oidc-link-existing": true,
+    "oidc-moderator-groups": [
+        "mods"
+    ],
     "oidc-scopes": [
         "read",
         "write"
     ],
     "oidc-skip-verification": true,
+    "oidc-signup-groups": [
+        "members"
+    ],
+    "oidc-username-claim": "nickname",
+    "oidc-username-mode": "suffix",
+    "older-than": 86400000000000,
+    "origin": "local",
     "password": "",
     "path": "",
     "port": 6969,
     "protocol": "http",
     "request-id-header": "X-Trace-Id",
+    "session-backend": "redis",
+    "session-encryption-key": "3f4d1c0b7a9e8d2c5b6a7f8e9d0c1b2a3f4d1c0b7a9e8d2c5b6a7f8e9d0c1b2a",
+    "session-redis-address": "127.0.0.1:6380",
+    "session-redis-db": 2,
+    "session-redis-password": "hunter2",
+    "shutdown-grace-period": 20000000000,
     "smtp-disclose-recipients": true,
     "smtp-from": "queen.rip.in.piss@terfisland.org",
     "smtp-host": "example.com",
     "smtp-password": "hunter2",
     "smtp-port": 4269,
+    "smtp-template-override-dir": "/opt/gts/email",
     "smtp-username": "sex-haver",
     "software-version": "",
+    "status-show-dislikes": true,
     "statuses-cw-max-chars": 420,
     "statuses-max-chars": 69,
+    "statuses-max-chars-direct": 420,
+    "statuses-max-chars-private": 100,
+    "statuses-max-chars-public": 50,
+    "statuses-max-chars-unlisted": 60,
+    "statuses-max-drafts": 5,
+    "statuses-max-thread-depth": 100,
     "statuses-media-max-files": 1,
     "statuses-poll-max-options": 1,
     "statuses-poll-option-max-chars": 50,
+    "statuses-thread-depth-policy": "reject",
     "storage-backend": "local",
     "storage-local-base-path": "/root/store",
+    "storage-local-max-size": 10737418240,
+    "storage-migrate-from": "s3",
     "storage-s3-access-key": "minio",
     "storage-s3-bucket": "gts",
     "storage-s3-endpoint": "localhost:9000",
+    "storage-s3-multipart-threshold": 10485760,
     "storage-s3-proxy": true,
+    "storage-s3-redirect-url-expiry": 3600000000000,
     "storage-s3-secret-key": "miniostorage",
     "storage-s3-use-ssl": false,
+    "streaming-ping-interval": 15000000000,
+    "streaming-ping-timeout": 5000000000,
+    "suspended": false,
     "syslog-address": "127.0.0.1:6969",
     "syslog-enabled": true,
     "syslog-protocol": "udp",
+    "target": "",
     "tls-certificate-chain": "",
     "tls-certificate-key": "",
+    "token": "",
+    "top": 50,
     "tracing-enabled": false,
     "tracing-endpoint": "localhost:4317",
     "tracing-insecure": false,
@@ -167,6 +264,7 @@ EXPECT=$(cat <<"EOF"
         "127.0.0.1/32",
         "docker.host.local"
     ],
+    "use-api": false,
     "username": "",
     "web-asset-base-dir": "/root",
     "web-template-base-dir": "/root"
@@ -205,33 +303,65 @@ GTS_WEB_ASSET_BASE_DIR='/root' \
 GTS_INSTANCE_EXPOSE_PEERS=true \
 GTS_INSTANCE_EXPOSE_SUSPENDED=true \
 GTS_INSTANCE_EXPOSE_SUSPENDED_WEB=true \
+GTS_INSTANCE_EXPOSE_ACTIVITY=true \
+GTS_INSTANCE_STATS_API=false \
 GTS_INSTANCE_EXPOSE_PUBLIC_TIMELINE=true \
 GTS_INSTANCE_DELIVER_TO_SHARED_INBOXES=false \
+GTS_FEDERATION_ALLOW_PRIVATE_IPS=true \
+GTS_FEDERATION_USER_AGENT='example.com federation bot' \
 GTS_ACCOUNTS_ALLOW_CUSTOM_CSS=true \
 GTS_ACCOUNTS_CUSTOM_CSS_LENGTH=5000 \
+GTS_ACCOUNTS_MAX_EMOJIS=10 \
+GTS_ACCOUNTS_EMOJI_MAX_SIZE=102400 \
+GTS_ACCOUNTS_PASSWORD_MIN_LENGTH=10 \
+GTS_ACCOUNTS_PASSWORD_MIN_ENTROPY=50 \
+GTS_ACCOUNTS_PASSWORD_BREACHED_FILTER='/gotosocial/breached-passwords.bloom' \
 GTS_ACCOUNTS_REGISTRATION_OPEN=true \
 GTS_ACCOUNTS_APPROVAL_REQUIRED=false \
+GTS_ACCOUNTS_NOTIFY_NEW_SIGNUPS=false \
+GTS_ACCOUNTS_NOTIFY_NEW_SIGNUPS_INTERVAL=5m \
 GTS_ACCOUNTS_REASON_REQUIRED=false \
 GTS_MEDIA_IMAGE_MAX_SIZE=420 \
 GTS_MEDIA_VIDEO_MAX_SIZE=420 \
 GTS_MEDIA_DESCRIPTION_MIN_CHARS=69 \
 GTS_MEDIA_DESCRIPTION_MAX_CHARS=5000 \
 GTS_MEDIA_REMOTE_CACHE_DAYS=30 \
+GTS_MEDIA_UNUSED_GRACE_PERIOD='30m' \
 GTS_MEDIA_EMOJI_LOCAL_MAX_SIZE=420 \
 GTS_MEDIA_EMOJI_REMOTE_MAX_SIZE=420 \
+GTS_MEDIA_PROXY_ENABLED=true \
+GTS_MEDIA_AUTO_ALT_TEXT_ENABLED=true \
+GTS_MEDIA_AUTO_ALT_TEXT_URL='http://localhost:9000/caption' \
+GTS_MEDIA_ALLOWED_TYPES='image/*,video/mp4' \
+GTS_MEDIA_BLOCKED_TYPES='image/webp' \
+GTS_MEDIA_THUMBNAIL_WORKERS=8 \
 GTS_STORAGE_BACKEND='local' \
 GTS_STORAGE_LOCAL_BASE_PATH='/root/store' \
+GTS_STORAGE_LOCAL_MAX_SIZE='10GiB' \
+GTS_STORAGE_MIGRATE_FROM='s3' \
 GTS_STORAGE_S3_ACCESS_KEY='minio' \
 GTS_STORAGE_S3_SECRET_KEY='miniostorage' \
 GTS_STORAGE_S3_ENDPOINT='localhost:9000' \
 GTS_STORAGE_S3_USE_SSL='false' \
 GTS_STORAGE_S3_PROXY='true' \
+GTS_STORAGE_S3_REDIRECT_URL_EXPIRY='1h' \
+GTS_STORAGE_S3_MULTIPART_THRESHOLD='10MiB' \
 GTS_STORAGE_S3_BUCKET='gts' \
 GTS_STATUSES_MAX_CHARS=69 \
+GTS_STATUSES_MAX_CHARS_PUBLIC=50 \
+GTS_STATUSES_MAX_CHARS_UNLISTED=60 \
+GTS_STATUSES_MAX_CHARS_PRIVATE=100 \
+GTS_STATUSES_MAX_CHARS_DIRECT=420 \
 GTS_STATUSES_CW_MAX_CHARS=420 \
 GTS_STATUSES_POLL_MAX_OPTIONS=1 \
 GTS_STATUSES_POLL_OPTIONS_MAX_CHARS=69 \
 GTS_STATUSES_MEDIA_MAX_FILES=1 \
+GTS_STATUSES_MAX_DRAFTS=5 \
+GTS_STATUSES_MAX_THREAD_DEPTH=100 \
+GTS_STATUSES_THREAD_DEPTH_POLICY='reject' \
+GTS_STATUS_SHOW_DISLIKES=true \
+GTS_STREAMING_PING_INTERVAL='15s' \
+GTS_STREAMING_PING_TIMEOUT='5s' \
 GTS_LETS_ENCRYPT_ENABLED=false \
 GTS_LETS_ENCRYPT_PORT=8080 \
 GTS_LETS_ENCRYPT_CERT_DIR='/root/certs' \
@@ -245,21 +375,37 @@ GTS_OIDC_CLIENT_SECRET='shhhh its a secret' \
 GTS_OIDC_SCOPES='read,write' \
 GTS_OIDC_LINK_EXISTING=true \
 GTS_OIDC_ADMIN_GROUPS='steamy' \
+GTS_OIDC_MODERATOR_GROUPS='mods' \
+GTS_OIDC_SIGNUP_GROUPS='members' \
+GTS_OIDC_GROUPS_CLAIM='roles' \
+GTS_OIDC_USERNAME_CLAIM='nickname' \
+GTS_OIDC_USERNAME_MODE='suffix' \
 GTS_SMTP_HOST='example.com' \
 GTS_SMTP_PORT=4269 \
 GTS_SMTP_USERNAME='sex-haver' \
 GTS_SMTP_PASSWORD='hunter2' \
 GTS_SMTP_FROM='queen.rip.in.piss@terfisland.org' \
 GTS_SMTP_DISCLOSE_RECIPIENTS=true \
+GTS_SMTP_TEMPLATE_OVERRIDE_DIR='/opt/gts/email' \
 GTS_SYSLOG_ENABLED=true \
 GTS_SYSLOG_PROTOCOL='udp' \
 GTS_SYSLOG_ADDRESS='127.0.0.1:6969' \
+GTS_SESSION_BACKEND='redis' \
+GTS_SESSION_REDIS_ADDRESS='127.0.0.1:6380' \
+GTS_SESSION_REDIS_PASSWORD='hunter2' \
+GTS_SESSION_REDIS_DB=2 \
+GTS_SESSION_ENCRYPTION_KEY='3f4d1c0b7a9e8d2c5b6a7f8e9d0c1b2a3f4d1c0b7a9e8d2c5b6a7f8e9d0c1b2a' \
 GTS_TRACING_ENDPOINT='localhost:4317' \
 GTS_ADVANCED_COOKIES_SAMESITE='strict' \
 GTS_ADVANCED_RATE_LIMIT_REQUESTS=6969 \
+GTS_ADVANCED_INBOX_RATE_LIMIT_REQUESTS=420 \
+GTS_ADVANCED_INBOX_MAX_BODY_SIZE=2097152 \
 GTS_ADVANCED_SENDER_MULTIPLIER=-1 \
 GTS_ADVANCED_THROTTLING_MULTIPLIER=-1 \
 GTS_ADVANCED_THROTTLING_RETRY_AFTER='10s' \
+GTS_MAINTENANCE_MODE=true \
+GTS_MAINTENANCE_MESSAGE='back soon' \
+GTS_SHUTDOWN_GRACE_PERIOD='20s' \
 GTS_REQUEST_ID_HEADER='X-Trace-Id' \
 go run ./cmd/gotosocial/... --config-path internal/config/testdata/test.yaml debug config)
 
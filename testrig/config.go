@@ -23,6 +23,7 @@ import (
 	"codeberg.org/gruf/go-bytesize"
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
 // InitTestConfig initializes viper configuration with test defaults.
@@ -30,6 +31,10 @@ func InitTestConfig() {
 	config.Config(func(cfg *config.Configuration) {
 		*cfg = testDefaults
 	})
+
+	// Sign image proxy URIs
+	// with a fixed test secret.
+	uris.SetProxiedImageSecret([]byte("gotosocial-test-secret"))
 }
 
 var testDefaults = config.Configuration{
@@ -65,13 +70,26 @@ var testDefaults = config.Configuration{
 	InstanceExposePeers:            true,
 	InstanceExposeSuspended:        true,
 	InstanceExposeSuspendedWeb:     true,
+	InstanceExposeActivity:         true,
+	InstanceStatsAPI:               true,
 	InstanceDeliverToSharedInboxes: true,
 
-	AccountsRegistrationOpen: true,
-	AccountsApprovalRequired: true,
-	AccountsReasonRequired:   true,
-	AccountsAllowCustomCSS:   true,
-	AccountsCustomCSSLength:  10000,
+	FederationAllowPrivateIPs: false,
+	FederationUserAgent:       "",
+
+	AccountsRegistrationOpen:         true,
+	AccountsApprovalRequired:         true,
+	AccountsNotifyNewSignups:         true,
+	AccountsNotifyNewSignupsInterval: 10 * time.Minute,
+	AccountsReasonRequired:           true,
+	AccountsAllowCustomCSS:           true,
+	AccountsCustomCSSLength:          10000,
+	AccountsMaxEmojis:                5,
+	AccountsEmojiMaxSize:             51200, // 50kb
+	AccountsPasswordMinLength:        8,
+	AccountsPasswordMinEntropy:       60,
+	AccountsPasswordBreachedFilter:   "",
+	AccountsSelfDeleteDelay:          7 * 24 * time.Hour,
 
 	MediaImageMaxSize:        10485760, // 10mb
 	MediaVideoMaxSize:        41943040, // 40mb
@@ -80,6 +98,7 @@ var testDefaults = config.Configuration{
 	MediaRemoteCacheDays:     30,
 	MediaEmojiLocalMaxSize:   51200,  // 50kb
 	MediaEmojiRemoteMaxSize:  102400, // 100kb
+	MediaThumbnailWorkers:    0,
 
 	// the testrig only uses in-memory storage, so we can
 	// safely set this value to 'test' to avoid running storage
@@ -92,6 +111,13 @@ var testDefaults = config.Configuration{
 	StatusesPollMaxOptions:     6,
 	StatusesPollOptionMaxChars: 50,
 	StatusesMediaMaxFiles:      6,
+	StatusesMaxDrafts:          20,
+	StatusesMaxThreadDepth:     0,
+	StatusesThreadDepthPolicy:  "detach",
+	StatusShowDislikes:         false,
+
+	StreamingPingInterval: 30 * time.Second,
+	StreamingPingTimeout:  10 * time.Second,
 
 	LetsEncryptEnabled:      false,
 	LetsEncryptPort:         0,
@@ -106,13 +132,17 @@ var testDefaults = config.Configuration{
 	OIDCClientSecret:     "",
 	OIDCScopes:           []string{oidc.ScopeOpenID, "profile", "email", "groups"},
 	OIDCLinkExisting:     false,
-
-	SMTPHost:               "",
-	SMTPPort:               0,
-	SMTPUsername:           "",
-	SMTPPassword:           "",
-	SMTPFrom:               "GoToSocial",
-	SMTPDiscloseRecipients: false,
+	OIDCGroupsClaim:      "groups",
+	OIDCUsernameClaim:    "preferred_username",
+	OIDCUsernameMode:     "ask",
+
+	SMTPHost:                "",
+	SMTPPort:                0,
+	SMTPUsername:            "",
+	SMTPPassword:            "",
+	SMTPFrom:                "GoToSocial",
+	SMTPDiscloseRecipients:  false,
+	SMTPTemplateOverrideDir: "",
 
 	TracingEnabled:           false,
 	TracingEndpoint:          "localhost:4317",
@@ -123,10 +153,16 @@ var testDefaults = config.Configuration{
 	SyslogProtocol: "udp",
 	SyslogAddress:  "localhost:514",
 
-	AdvancedCookiesSamesite:      "lax",
-	AdvancedRateLimitRequests:    0, // disabled
-	AdvancedThrottlingMultiplier: 0, // disabled
-	AdvancedSenderMultiplier:     0, // 1 sender only, regardless of CPU
+	SessionBackend: "memory",
+
+	AdvancedCookiesSamesite:        "lax",
+	AdvancedRateLimitRequests:      0,       // disabled
+	AdvancedInboxRateLimitRequests: 0,       // disabled
+	AdvancedInboxMaxBodySize:       1048576, // 1mb
+	AdvancedThrottlingMultiplier:   0,       // disabled
+	AdvancedSenderMultiplier:       0,       // 1 sender only, regardless of CPU
+
+	ShutdownGracePeriod: 5 * time.Second,
 
 	SoftwareVersion: "0.0.0-testrig",
 
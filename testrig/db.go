@@ -33,12 +33,14 @@ import (
 var testModels = []interface{}{
 	&gtsmodel.Account{},
 	&gtsmodel.AccountToEmoji{},
+	&gtsmodel.AdminAccountAction{},
 	&gtsmodel.Application{},
 	&gtsmodel.Block{},
 	&gtsmodel.DomainBlock{},
 	&gtsmodel.EmailDomainBlock{},
 	&gtsmodel.Follow{},
 	&gtsmodel.FollowRequest{},
+	&gtsmodel.FeaturedTag{},
 	&gtsmodel.List{},
 	&gtsmodel.ListEntry{},
 	&gtsmodel.MediaAttachment{},
@@ -47,6 +49,7 @@ var testModels = []interface{}{
 	&gtsmodel.StatusToEmoji{},
 	&gtsmodel.StatusToTag{},
 	&gtsmodel.StatusFave{},
+	&gtsmodel.StatusDislike{},
 	&gtsmodel.StatusBookmark{},
 	&gtsmodel.StatusMute{},
 	&gtsmodel.Tag{},
@@ -58,8 +61,22 @@ var testModels = []interface{}{
 	&gtsmodel.Token{},
 	&gtsmodel.Client{},
 	&gtsmodel.EmojiCategory{},
+	&gtsmodel.EmojiTombstone{},
 	&gtsmodel.Tombstone{},
 	&gtsmodel.Report{},
+	&gtsmodel.Rule{},
+	&gtsmodel.PreviewCard{},
+	&gtsmodel.ProxiedImage{},
+	&gtsmodel.Draft{},
+	&gtsmodel.Listen{},
+	&gtsmodel.WebAuthnCredential{},
+	&gtsmodel.WebSession{},
+	&gtsmodel.QueuedEmail{},
+	&gtsmodel.Poll{},
+	&gtsmodel.PollVote{},
+	&gtsmodel.InteractionRequest{},
+	&gtsmodel.HashtagSetting{},
+	&gtsmodel.BacklogMessage{},
 }
 
 // NewTestDB returns a new initialized, empty database for testing.
@@ -97,6 +114,7 @@ func NewTestDB(state *state.State) db.DB {
 	}
 
 	state.DB = testDB
+	state.Workers.FederatorBacklog.Init(testDB)
 
 	return testDB
 }
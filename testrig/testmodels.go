@@ -350,6 +350,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			HideCollections:         FalseBool(),
 			SuspensionOrigin:        "",
 			EnableRSS:               FalseBool(),
+			HideLinkPreviews:        FalseBool(),
 		},
 		"unconfirmed_account": {
 			ID:                      "01F8MH0BBE4FHXPH513MBVFHB0",
@@ -428,6 +429,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			HideCollections:         FalseBool(),
 			SuspensionOrigin:        "",
 			EnableRSS:               TrueBool(),
+			HideLinkPreviews:        FalseBool(),
 		},
 		"local_account_1": {
 			ID:                      "01F8MH1H7YV1Z7D2C8K2730QBF",
@@ -468,6 +470,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			HideCollections:         FalseBool(),
 			SuspensionOrigin:        "",
 			EnableRSS:               TrueBool(),
+			HideLinkPreviews:        FalseBool(),
 		},
 		"local_account_2": {
 			ID:                      "01F8MH5NBDF2MV7CTC4Q5128HF",
@@ -527,6 +530,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			HideCollections:       FalseBool(),
 			SuspensionOrigin:      "",
 			EnableRSS:             FalseBool(),
+			HideLinkPreviews:      FalseBool(),
 		},
 		"remote_account_1": {
 			ID:                    "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -673,6 +677,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			SuspensionOrigin:        "",
 			HeaderMediaAttachmentID: "",
 			EnableRSS:               FalseBool(),
+			HideLinkPreviews:        FalseBool(),
 		},
 	}
 
@@ -1161,6 +1166,7 @@ func NewTestEmojis() map[string]*gtsmodel.Emoji {
 			URI:                    "http://localhost:8080/emoji/01F8MH9H8E4VG3KDYJR9EGPXCQ",
 			VisibleInPicker:        TrueBool(),
 			CategoryID:             "01GGQ8V4993XK67B2JB396YFB7",
+			Cached:                 TrueBool(),
 		},
 		"yell": {
 			ID:                     "01GD5KP5CQEE1R3X43Y1EHS2CW",
@@ -1183,6 +1189,7 @@ func NewTestEmojis() map[string]*gtsmodel.Emoji {
 			URI:                    "http://fossbros-anonymous.io/emoji/01GD5KP5CQEE1R3X43Y1EHS2CW",
 			VisibleInPicker:        FalseBool(),
 			CategoryID:             "",
+			Cached:                 TrueBool(),
 		},
 	}
 }
@@ -1256,6 +1263,9 @@ func NewTestDomainBlocks() map[string]*gtsmodel.DomainBlock {
 			PrivateComment:     "i blocked this domain because they keep replying with pushy + unwarranted linux advice",
 			PublicComment:      "reply-guying to tech posts",
 			Obfuscate:          FalseBool(),
+			Severity:           gtsmodel.DomainBlockSeveritySuspend,
+			RejectMedia:        FalseBool(),
+			RejectReports:      FalseBool(),
 		},
 	}
 }
@@ -1345,6 +1355,8 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			Boostable:                TrueBool(),
 			Replyable:                TrueBool(),
 			Likeable:                 TrueBool(),
+			ThreadTruncated:          FalseBool(),
+			ThreadID:                 "01F8MH75CBF9JFX4ZAD54N0W0R",
 			ActivityStreamsType:      ap.ObjectNote,
 		},
 		"admin_account_status_2": {
@@ -1370,6 +1382,8 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			Boostable:                TrueBool(),
 			Replyable:                TrueBool(),
 			Likeable:                 TrueBool(),
+			ThreadTruncated:          FalseBool(),
+			ThreadID:                 "01F8MHAAY43M6RJ473VQFCVH37",
 			ActivityStreamsType:      ap.ObjectNote,
 		},
 		"admin_account_status_3": {
@@ -1385,6 +1399,7 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			MentionIDs:               []string{"01FF26A6BGEKCZFWNEHXB2ZZ6M"},
 			AccountID:                "01F8MH17FWEB39HZJ76B6VXSKF",
 			InReplyToID:              "01F8MHAMCHF6Y650WCRSCP4WMY",
+			ThreadDepth:              1,
 			InReplyToAccountID:       "01F8MH1H7YV1Z7D2C8K2730QBF",
 			InReplyToURI:             "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
 			BoostOfID:                "",
@@ -1396,6 +1411,8 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			Boostable:                TrueBool(),
 			Replyable:                TrueBool(),
 			Likeable:                 TrueBool(),
+			ThreadTruncated:          FalseBool(),
+			ThreadID:                 "01F8MHAMCHF6Y650WCRSCP4WMY",
 			ActivityStreamsType:      ap.ObjectNote,
 		},
 		"admin_account_status_4": {
@@ -1423,6 +1440,8 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			Boostable:                TrueBool(),
 			Replyable:                TrueBool(),
 			Likeable:                 TrueBool(),
+			ThreadTruncated:          FalseBool(),
+			ThreadID:                 "01F8MHAMCHF6Y650WCRSCP4WMY",
 			ActivityStreamsType:      ap.ObjectNote,
 		},
 		"local_account_1_status_1": {
@@ -1447,6 +1466,8 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			Boostable:                TrueBool(),
 			Replyable:                TrueBool(),
 			Likeable:                 TrueBool(),
+			ThreadTruncated:          FalseBool(),
+			ThreadID:                 "01F8MHAMCHF6Y650WCRSCP4WMY",
 			ActivityStreamsType:      ap.ObjectNote,
 		},
 		"local_account_1_status_2": {
@@ -1471,6 +1492,8 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			Boostable:                TrueBool(),
 			Replyable:                TrueBool(),
 			Likeable:                 TrueBool(),
+			ThreadTruncated:          FalseBool(),
+			ThreadID:                 "01F8MHAYFKS4KMXF8K5Y1C0KRN",
 			ActivityStreamsType:      ap.ObjectNote,
 		},
 		"local_account_1_status_3": {
@@ -1495,6 +1518,8 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			Boostable:                FalseBool(),
 			Replyable:                FalseBool(),
 			Likeable:                 FalseBool(),
+			ThreadTruncated:          FalseBool(),
+			ThreadID:                 "01F8MHBBN8120SYH7D5S050MGK",
 			ActivityStreamsType:      ap.ObjectNote,
 		},
 		"local_account_1_status_4": {
@@ -1520,6 +1545,8 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			Boostable:                TrueBool(),
 			Replyable:                TrueBool(),
 			Likeable:                 TrueBool(),
+			ThreadTruncated:          FalseBool(),
+			ThreadID:                 "01F8MH82FYRXD2RC6108DAJ5HB",
 			ActivityStreamsType:      ap.ObjectNote,
 		},
 		"local_account_1_status_5": {
@@ -1545,6 +1572,8 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			Boostable:                TrueBool(),
 			Replyable:                TrueBool(),
 			Likeable:                 TrueBool(),
+			ThreadTruncated:          FalseBool(),
+			ThreadID:                 "01FCTA44PW9H1TB328S9AQXKDS",
 			ActivityStreamsType:      ap.ObjectNote,
 		},
 		"local_account_2_status_1": {
@@ -1569,6 +1598,8 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			Boostable:                TrueBool(),
 			Replyable:                TrueBool(),
 			Likeable:                 TrueBool(),
+			ThreadTruncated:          FalseBool(),
+			ThreadID:                 "01F8MHBQCBTDKN6X5VHGMMN4MA",
 			ActivityStreamsType:      ap.ObjectNote,
 		},
 		"local_account_2_status_2": {
@@ -1593,6 +1624,8 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			Boostable:                TrueBool(),
 			Replyable:                FalseBool(),
 			Likeable:                 TrueBool(),
+			ThreadTruncated:          FalseBool(),
+			ThreadID:                 "01F8MHC0H0A7XHTVH5F596ZKBM",
 			ActivityStreamsType:      ap.ObjectNote,
 		},
 		"local_account_2_status_3": {
@@ -1617,6 +1650,8 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			Boostable:                TrueBool(),
 			Replyable:                FalseBool(),
 			Likeable:                 FalseBool(),
+			ThreadTruncated:          FalseBool(),
+			ThreadID:                 "01F8MHC8VWDRBQR0N1BATDDEM5",
 			ActivityStreamsType:      ap.ObjectNote,
 		},
 		"local_account_2_status_4": {
@@ -1641,6 +1676,8 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			Boostable:                FalseBool(),
 			Replyable:                TrueBool(),
 			Likeable:                 TrueBool(),
+			ThreadTruncated:          FalseBool(),
+			ThreadID:                 "01F8MHCP5P2NWYQ416SBA0XSEV",
 
 			ActivityStreamsType: ap.ObjectNote,
 		},
@@ -1657,6 +1694,7 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			MentionIDs:               []string{"01FDF2HM2NF6FSRZCDEDV451CN"},
 			AccountID:                "01F8MH5NBDF2MV7CTC4Q5128HF",
 			InReplyToID:              "01F8MHAMCHF6Y650WCRSCP4WMY",
+			ThreadDepth:              1,
 			InReplyToAccountID:       "01F8MH1H7YV1Z7D2C8K2730QBF",
 			InReplyToURI:             "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
 			BoostOfID:                "",
@@ -1669,6 +1707,8 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			Boostable:                TrueBool(),
 			Replyable:                TrueBool(),
 			Likeable:                 TrueBool(),
+			ThreadTruncated:          FalseBool(),
+			ThreadID:                 "01F8MHAMCHF6Y650WCRSCP4WMY",
 			ActivityStreamsType:      ap.ObjectNote,
 		},
 		"local_account_2_status_6": {
@@ -1696,6 +1736,8 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			Boostable:                TrueBool(),
 			Replyable:                TrueBool(),
 			Likeable:                 TrueBool(),
+			ThreadTruncated:          FalseBool(),
+			ThreadID:                 "01FN3VJGFH10KR7S2PB0GFJZYG",
 			ActivityStreamsType:      ap.ObjectNote,
 		},
 		"local_account_2_status_7": {
@@ -1722,6 +1764,8 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			Boostable:                TrueBool(),
 			Replyable:                TrueBool(),
 			Likeable:                 TrueBool(),
+			ThreadTruncated:          FalseBool(),
+			ThreadID:                 "01G20ZM733MGN8J344T4ZDDFY1",
 			ActivityStreamsType:      ap.ObjectNote,
 		},
 		"remote_account_1_status_1": {
@@ -1749,6 +1793,8 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			Boostable:                TrueBool(),
 			Replyable:                TrueBool(),
 			Likeable:                 TrueBool(),
+			ThreadTruncated:          FalseBool(),
+			ThreadID:                 "01FVW7JHQFSFK166WWKR8CBA6M",
 			ActivityStreamsType:      ap.ObjectNote,
 		},
 	}
@@ -1761,6 +1807,7 @@ func NewTestTags() map[string]*gtsmodel.Tag {
 			ID:                     "01F8MHA1A2NF9MJ3WCCQ3K8BSZ",
 			URL:                    "http://localhost:8080/tags/welcome",
 			Name:                   "welcome",
+			DisplayName:            "welcome",
 			FirstSeenFromAccountID: "",
 			CreatedAt:              TimeMustParse("2022-05-14T13:21:09+02:00"),
 			UpdatedAt:              TimeMustParse("2022-05-14T13:21:09+02:00"),
@@ -1771,7 +1818,8 @@ func NewTestTags() map[string]*gtsmodel.Tag {
 		"Hashtag": {
 			ID:                     "01FCT9SGYA71487N8D0S1M638G",
 			URL:                    "http://localhost:8080/tags/Hashtag",
-			Name:                   "Hashtag",
+			Name:                   "hashtag",
+			DisplayName:            "Hashtag",
 			FirstSeenFromAccountID: "",
 			CreatedAt:              TimeMustParse("2022-05-14T13:21:09+02:00"),
 			UpdatedAt:              TimeMustParse("2022-05-14T13:21:09+02:00"),
@@ -2493,6 +2541,45 @@ func NewTestFediStatuses() map[string]vocab.ActivityStreamsNote {
 			},
 			nil,
 		),
+		"http://fossbros-anonymous.io/users/foss_satan/statuses/01HE7XJ1CG84TBKH5V9XKBVGF5": withAPNoteEmojis(
+			NewAPNote(
+				URLMustParse("http://fossbros-anonymous.io/users/foss_satan/statuses/01HE7XJ1CG84TBKH5V9XKBVGF5"),
+				URLMustParse("http://fossbros-anonymous.io/@foss_satan/01HE7XJ1CG84TBKH5V9XKBVGF5"),
+				TimeMustParse("2022-07-13T12:13:12+02:00"),
+				"<p>look at these emojis :kip_van_den_bos: :yell:</p>",
+				"",
+				URLMustParse("http://fossbros-anonymous.io/users/foss_satan"),
+				[]*url.URL{
+					URLMustParse(pub.PublicActivityPubIRI),
+				},
+				[]*url.URL{},
+				false,
+				nil,
+				nil,
+			),
+			newAPEmoji(
+				URLMustParse("http://fossbros-anonymous.io/emoji/01GD5HCC2YECT012TK8PAGX4D1"),
+				"kip_van_den_bos",
+				TimeMustParse("2022-09-13T12:13:12+02:00"),
+				newAPImage(
+					URLMustParse("http://fossbros-anonymous.io/emoji/kip.gif"),
+					"image/gif",
+					"",
+					"",
+				),
+			),
+			newAPEmoji(
+				URLMustParse("http://fossbros-anonymous.io/emoji/01GD5KP5CQEE1R3X43Y1EHS2CW"),
+				"yell",
+				TimeMustParse("2020-03-18T13:12:00+01:00"),
+				newAPImage(
+					URLMustParse("http://fossbros-anonymous.io/emoji/yell.gif"),
+					"image/png",
+					"",
+					"",
+				),
+			),
+		),
 		"https://turnip.farm/users/turniplover6969/statuses/70c53e54-3146-42d5-a630-83c8b6c7c042": NewAPNote(
 			URLMustParse("https://turnip.farm/users/turniplover6969/statuses/70c53e54-3146-42d5-a630-83c8b6c7c042"),
 			URLMustParse("https://turnip.farm/@turniplover6969/70c53e54-3146-42d5-a630-83c8b6c7c042"),
@@ -3368,6 +3455,21 @@ func newAPEmoji(id *url.URL, name string, updated time.Time, image vocab.Activit
 	return emoji
 }
 
+// withAPNoteEmojis appends the given emojis to the tags of the given note, and returns the note
+func withAPNoteEmojis(note vocab.ActivityStreamsNote, emojis ...vocab.TootEmoji) vocab.ActivityStreamsNote {
+	tag := note.GetActivityStreamsTag()
+	if tag == nil {
+		tag = streams.NewActivityStreamsTagProperty()
+		note.SetActivityStreamsTag(tag)
+	}
+
+	for _, e := range emojis {
+		tag.AppendTootEmoji(e)
+	}
+
+	return note
+}
+
 // NewAPNote returns a new activity streams note for the given parameters
 func NewAPNote(
 	noteID *url.URL,
@@ -24,11 +24,15 @@ const PhotoswipeLightbox = require("photoswipe/dist/umd/photoswipe-lightbox.umd.
 const PhotoswipeCaptionPlugin = require("photoswipe-dynamic-caption-plugin").default;
 const Plyr = require("plyr");
 
-let [_, _user, type, id] = window.location.pathname.split("/");
-if (type == "statuses") {
-	let firstStatus = document.getElementsByClassName("thread")[0].children[0];
-	if (firstStatus.id != id) {
-		document.getElementById(id).scrollIntoView();
+let [_, _user, type] = window.location.pathname.split("/");
+if (type == "statuses" && window.location.hash == "") {
+	// Scroll to the requested status, which may be a reply
+	// further down the thread. Links with a #status-ID
+	// fragment are already scrolled to by the browser.
+	let expanded = document.querySelector(".thread .toot.expanded");
+	let firstStatus = document.querySelector(".thread .toot");
+	if (expanded && expanded != firstStatus) {
+		expanded.scrollIntoView();
 	}
 }
 
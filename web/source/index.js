@@ -66,6 +66,15 @@ skulk({
 				}]
 			],
 		},
+		passkey: {
+			entryFile: "passkey",
+			outputFile: "passkey.js",
+			preset: ["js"],
+			prodCfg: prodCfg,
+			transform: [
+				["babelify", { global: true }]
+			],
+		},
 		settings: {
 			entryFile: "settings",
 			outputFile: "settings.js",
@@ -0,0 +1,62 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+"use strict";
+
+const webauthn = require("../settings/lib/webauthn");
+
+const button = document.getElementById("passkey-signin");
+const error = document.getElementById("passkey-error");
+
+async function post(url, body) {
+	const res = await fetch(url, {
+		method: "POST",
+		credentials: "same-origin",
+		headers: {
+			"Accept": "application/json",
+			"Content-Type": "application/json"
+		},
+		body: JSON.stringify(body ?? {})
+	});
+
+	const json = await res.json();
+	if (!res.ok) {
+		throw new Error(json.error ?? res.statusText);
+	}
+	return json;
+}
+
+if (button != undefined && webauthn.supported()) {
+	button.hidden = false;
+	button.addEventListener("click", async () => {
+		error.hidden = true;
+		button.disabled = true;
+
+		try {
+			const options = await post("/auth/passkey/begin");
+			const assertion = await webauthn.signIn(options);
+			const { redirect_uri } = await post("/auth/passkey/finish", assertion);
+			window.location.assign(redirect_uri);
+		} catch (e) {
+			error.textContent = e.message;
+			error.hidden = false;
+			button.disabled = false;
+		}
+	});
+}
@@ -60,9 +60,7 @@ module.exports = function AccountDetail({ }) {
 function AccountDetailForm({ data: account }) {
 	let content;
 	if (account.suspended) {
-		content = (
-			<h2 className="error">Account is suspended.</h2>
-		);
+		content = <UnsuspendAccount account={account} />;
 	} else {
 		content = <ModifyAccount account={account} />;
 	}
@@ -111,4 +109,36 @@ function ModifyAccount({ account }) {
 			</div>
 		</form>
 	);
-}
\ No newline at end of file
+}
+
+function UnsuspendAccount({ account }) {
+	const form = {
+		id: useValue("id", account.id),
+		reason: useTextInput("text", {})
+	};
+
+	const [modifyAccount, result] = useFormSubmit(form, query.useActionAccountMutation());
+
+	return (
+		<form onSubmit={modifyAccount}>
+			<h2 className="error">Account is suspended.</h2>
+			<p>
+				Unsuspending the account allows it to be used, and federated with, again.
+				Content removed by the suspension will not be restored, and the user of a
+				local account will need to reset their password before they can log in.
+			</p>
+			<TextInput
+				field={form.reason}
+				placeholder="Reason for this action"
+			/>
+
+			<div className="action-buttons">
+				<MutationButton
+					label="Unsuspend"
+					name="unsuspend"
+					result={result}
+				/>
+			</div>
+		</form>
+	);
+}
@@ -19,8 +19,10 @@
 
 "use strict";
 
-const { replaceCacheOnMutation } = require("./lib");
+const { replaceCacheOnMutation, unwrapRes } = require("./lib");
 const base = require("./base");
+const webauthn = require("../webauthn");
+const oauth = require("../../redux/oauth").actions;
 
 const endpoints = (build) => ({
 	updateCredentials: build.mutation({
@@ -39,6 +41,91 @@ const endpoints = (build) => ({
 			url: `/api/v1/user/password_change`,
 			body: data
 		})
+	}),
+	cancelAccountDelete: build.mutation({
+		query: (data) => ({
+			method: "POST",
+			url: `/api/v1/accounts/delete/cancel`,
+			body: data
+		}),
+		// Refetch the account, so that
+		// source.delete_at is cleared.
+		invalidatesTags: ["Auth"]
+	}),
+	listPasskeys: build.query({
+		query: () => ({
+			url: `/api/v1/user/webauthn/credentials`
+		}),
+		providesTags: ["Passkey"]
+	}),
+	registerPasskey: build.mutation({
+		queryFn: ({ name }, _api, _extraOpts, baseQuery) => {
+			return baseQuery({
+				method: "POST",
+				url: `/api/v1/user/webauthn/register/begin`
+			}).then(unwrapRes).then((options) => {
+				return webauthn.register(options, name);
+			}).then((body) => {
+				return baseQuery({
+					method: "POST",
+					url: `/api/v1/user/webauthn/register/finish`,
+					body
+				});
+			}).catch((e) => {
+				return { error: e };
+			});
+		},
+		invalidatesTags: ["Passkey"]
+	}),
+	deletePasskey: build.mutation({
+		query: (id) => ({
+			method: "DELETE",
+			url: `/api/v1/user/webauthn/credentials/${id}`
+		}),
+		invalidatesTags: ["Passkey"]
+	}),
+	passwordLogin: build.query({
+		query: () => ({
+			url: `/api/v1/user/webauthn/password_login`
+		}),
+		providesTags: ["Passkey"]
+	}),
+	setPasswordLogin: build.mutation({
+		query: (data) => ({
+			method: "POST",
+			url: `/api/v1/user/webauthn/password_login`,
+			body: data
+		}),
+		invalidatesTags: ["Passkey"]
+	}),
+	listSessions: build.query({
+		query: () => ({
+			url: `/api/v1/user/sessions`
+		}),
+		providesTags: ["Session"]
+	}),
+	deleteSession: build.mutation({
+		query: (id) => ({
+			method: "DELETE",
+			url: `/api/v1/user/sessions/${id}`
+		}),
+		invalidatesTags: ["Session"]
+	}),
+	logoutEverywhere: build.mutation({
+		queryFn: (_arg, api, _extraOpts, baseQuery) => {
+			return baseQuery({
+				method: "DELETE",
+				url: `/api/v1/user/sessions`
+			}).then(unwrapRes).then(() => {
+				// The token of the settings panel is most
+				// likely revoked along with its session.
+				api.dispatch(oauth.remove());
+				return { data: null };
+			}).catch((e) => {
+				return { error: e };
+			});
+		},
+		invalidatesTags: ["Session", "Auth"]
 	})
 });
 
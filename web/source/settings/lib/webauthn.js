@@ -0,0 +1,96 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+"use strict";
+
+// Binary WebAuthn values are exchanged
+// with the server as base64url strings.
+
+function decode(str) {
+	const base64 = str.replace(/-/g, "+").replace(/_/g, "/");
+	return Uint8Array.from(atob(base64), (c) => c.charCodeAt(0));
+}
+
+function encode(buf) {
+	const bytes = new Uint8Array(buf);
+	let str = "";
+	bytes.forEach((b) => {
+		str += String.fromCharCode(b);
+	});
+	return btoa(str).replace(/\+/g, "-").replace(/\//g, "_").replace(/=+$/, "");
+}
+
+function supported() {
+	return window.PublicKeyCredential != undefined;
+}
+
+// Create a new credential using the creation options
+// returned by /api/v1/user/webauthn/register/begin,
+// and return the body to send to .../register/finish.
+async function register(options, name) {
+	const credential = await navigator.credentials.create({
+		publicKey: {
+			...options,
+			challenge: decode(options.challenge),
+			user: {
+				...options.user,
+				id: decode(options.user.id)
+			},
+			excludeCredentials: options.excludeCredentials.map((c) => ({
+				...c,
+				id: decode(c.id)
+			}))
+		}
+	});
+
+	return {
+		name,
+		id: encode(credential.rawId),
+		client_data_json: encode(credential.response.clientDataJSON),
+		authenticator_data: encode(credential.response.getAuthenticatorData()),
+		public_key: encode(credential.response.getPublicKey()),
+		public_key_algorithm: credential.response.getPublicKeyAlgorithm()
+	};
+}
+
+// Sign the challenge in the request options returned
+// by /auth/passkey/begin with a credential, and return
+// the body to send to /auth/passkey/finish.
+async function signIn(options) {
+	const credential = await navigator.credentials.get({
+		publicKey: {
+			...options,
+			challenge: decode(options.challenge),
+			allowCredentials: options.allowCredentials.map((c) => ({
+				...c,
+				id: decode(c.id)
+			}))
+		}
+	});
+
+	return {
+		id: encode(credential.rawId),
+		client_data_json: encode(credential.response.clientDataJSON),
+		authenticator_data: encode(credential.response.authenticatorData),
+		signature: encode(credential.response.signature),
+		user_handle: credential.response.userHandle ? encode(credential.response.userHandle) : ""
+	};
+}
+
+module.exports = { supported, register, signIn };
@@ -39,6 +39,9 @@ const {
 const FormWithData = require("../lib/form/form-with-data");
 const Languages = require("../components/languages");
 const MutationButton = require("../components/form/mutation-button");
+const Loading = require("../components/loading");
+const { Error } = require("../components/error");
+const webauthn = require("../lib/webauthn");
 
 module.exports = function UserSettings() {
 	return (
@@ -55,6 +58,8 @@ function UserSettingsForm({ data }) {
 		- bool source[sensitive]
 		- string source[language]
 		- string source[status_content_type]
+		- bool source[hide_link_previews]
+		- string source[mention_policy]
 	 */
 
 	const form = {
@@ -62,12 +67,19 @@ function UserSettingsForm({ data }) {
 		isSensitive: useBoolInput("source[sensitive]", { source: data }),
 		language: useTextInput("source[language]", { source: data, valueSelector: (s) => s.source.language?.toUpperCase() ?? "EN" }),
 		statusContentType: useTextInput("source[status_content_type]", { source: data, defaultValue: "text/plain" }),
+		hideLinkPreviews: useBoolInput("source[hide_link_previews]", { source: data }),
+		mentionPolicy: useTextInput("source[mention_policy]", { source: data, defaultValue: "anyone" }),
 	};
 
 	const [submitForm, result] = useFormSubmit(form, query.useUpdateCredentialsMutation());
 
 	return (
 		<>
+			{data.source.delete_at &&
+				<div>
+					<AccountDeleteCancel deleteAt={data.source.delete_at} />
+				</div>
+			}
 			<form className="user-settings" onSubmit={submitForm}>
 				<h1>Post settings</h1>
 				<Select field={form.language} label="Default post language" options={
@@ -95,16 +107,62 @@ function UserSettingsForm({ data }) {
 					field={form.isSensitive}
 					label="Mark my posts as sensitive by default"
 				/>
+				<Checkbox
+					field={form.hideLinkPreviews}
+					label="Hide link previews of my profile and posts, and ask search engines not to index them"
+				/>
+				<Select field={form.mentionPolicy} label="Who can mention me" options={
+					<>
+						<option value="anyone">Anyone (default)</option>
+						<option value="followers">Only my followers</option>
+						<option value="followed">Only accounts I follow</option>
+						<option value="nobody">Nobody</option>
+					</>
+				}>
+					<span className="moreinfolink">Mentions from anyone else won't notify you. With "only accounts I follow" or "nobody", their posts are also kept off your timelines, and their replies out of your threads on the web. Existing notifications are kept.</span>
+				</Select>
 
 				<MutationButton label="Save settings" result={result} />
 			</form>
 			<div>
 				<PasswordChange />
 			</div>
+			<div>
+				<Passkeys />
+			</div>
+			<div>
+				<Sessions />
+			</div>
 		</>
 	);
 }
 
+function AccountDeleteCancel({ deleteAt }) {
+	const form = {
+		password: useTextInput("password")
+	};
+
+	const [submitForm, result] = useFormSubmit(form, query.useCancelAccountDeleteMutation());
+
+	return (
+		<form className="account-delete-cancel" onSubmit={submitForm}>
+			<h1>Pending account deletion</h1>
+			<p>
+				Your account is hidden, and will be deleted on {new Date(deleteAt).toLocaleString()}.
+				To keep your account, enter your password to cancel the deletion.
+			</p>
+			<TextInput
+				type="password"
+				name="password"
+				field={form.password}
+				label="Current password"
+				required
+			/>
+			<MutationButton label="Cancel account deletion" result={result} />
+		</form>
+	);
+}
+
 function PasswordChange() {
 	const form = {
 		oldPassword: useTextInput("old_password"),
@@ -153,4 +211,142 @@ function PasswordChange() {
 			<MutationButton label="Change password" result={result} />
 		</form>
 	);
-}
\ No newline at end of file
+}
+
+function Passkeys() {
+	const passkeysQuery = query.useListPasskeysQuery();
+	const passwordLoginQuery = query.usePasswordLoginQuery();
+
+	const form = {
+		name: useTextInput("name")
+	};
+
+	const [submitForm, result] = useFormSubmit(form, query.useRegisterPasskeyMutation());
+	const [deletePasskey, deleteResult] = query.useDeletePasskeyMutation();
+	const [setPasswordLogin, passwordLoginResult] = query.useSetPasswordLoginMutation();
+
+	if (passkeysQuery.isLoading || passwordLoginQuery.isLoading) {
+		return <Loading />;
+	} else if (passkeysQuery.error || passwordLoginQuery.error) {
+		return <Error error={passkeysQuery.error ?? passwordLoginQuery.error} />;
+	}
+
+	const passkeys = passkeysQuery.data;
+	const passwordLoginEnabled = passwordLoginQuery.data.enabled;
+
+	return (
+		<div className="passkeys">
+			<h1>Passkeys</h1>
+			<p>
+				Passkeys let you sign in with your device&apos;s fingerprint reader, face recognition,
+				screen lock, or a hardware security key, instead of your password.
+			</p>
+			{passkeys.length > 0 &&
+				<ul className="passkey-list">
+					{passkeys.map((passkey) => (
+						<li key={passkey.id}>
+							<span>
+								{passkey.name}
+								{passkey.last_used_at && <> (last used {new Date(passkey.last_used_at).toLocaleString()})</>}
+							</span>
+							<MutationButton
+								label="Remove"
+								type="button"
+								className="danger"
+								onClick={() => deletePasskey(passkey.id)}
+								result={deleteResult}
+							/>
+						</li>
+					))}
+				</ul>
+			}
+			{webauthn.supported()
+				? <form onSubmit={submitForm}>
+					<TextInput
+						field={form.name}
+						label="Name for new passkey, eg. 'phone'"
+						required
+						maxLength={64}
+					/>
+					<MutationButton label="Add passkey" result={result} />
+				</form>
+				: <p>Your browser does not support passkeys.</p>
+			}
+			{(passkeys.length > 0 || !passwordLoginEnabled) &&
+				<MutationButton
+					label={passwordLoginEnabled ? "Disable password login" : "Enable password login"}
+					type="button"
+					onClick={() => setPasswordLogin({ enabled: !passwordLoginEnabled })}
+					result={passwordLoginResult}
+				/>
+			}
+		</div>
+	);
+}
+
+function Sessions() {
+	const sessionsQuery = query.useListSessionsQuery();
+	const [deleteSession, deleteResult] = query.useDeleteSessionMutation();
+	const [logoutEverywhere, logoutResult] = query.useLogoutEverywhereMutation();
+
+	if (sessionsQuery.isLoading) {
+		return <Loading />;
+	} else if (sessionsQuery.error) {
+		return <Error error={sessionsQuery.error} />;
+	}
+
+	const sessions = sessionsQuery.data;
+
+	return (
+		<div className="sessions">
+			<h1>Sessions</h1>
+			<p>
+				These are the places where you signed in on the web, eg. to use this settings panel or
+				to authorize an app. Revoking a session also revokes the access of any app authorized through it.
+			</p>
+			{sessions.length > 0 &&
+				<ul className="session-list">
+					{sessions.map((session) => (
+						<li key={session.id}>
+							<div>
+								<span className="user-agent">{session.user_agent || "Unknown browser"}</span>
+								{session.current && <b> (this session)</b>}
+								<br />
+								Signed in {new Date(session.created_at).toLocaleString()} from {session.ip || "unknown IP"}
+								{session.last_seen_at &&
+									<>
+										<br />
+										Last active {new Date(session.last_seen_at).toLocaleString()} from {session.last_seen_ip}
+									</>
+								}
+								{session.ip_changed &&
+									<>
+										<br />
+										<span className="ip-changed">
+											Used from a different network than the one it was started on.
+											If you don&apos;t recognize this, revoke the session and change your password.
+										</span>
+									</>
+								}
+							</div>
+							<MutationButton
+								label="Revoke"
+								type="button"
+								className="danger"
+								onClick={() => deleteSession(session.id)}
+								result={deleteResult}
+							/>
+						</li>
+					))}
+				</ul>
+			}
+			<MutationButton
+				label="Log out everywhere"
+				type="button"
+				className="danger"
+				onClick={() => logoutEverywhere()}
+				result={logoutResult}
+			/>
+		</div>
+	);
+}